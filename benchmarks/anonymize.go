@@ -0,0 +1,391 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package benchmarks
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Codebase is a synthetic codebase with the structure of an analyzed one, for `tukey
+// anonymize`: the same directories and files, the same declarations in them, and the same
+// references between them, under generated names and with bodies holding nothing but
+// those references. Performance problems that depend on a tree's shape can be reproduced
+// on it without sharing the source.
+//
+// It's PHP whatever the analyzed language, since PHP's syntax can express every kind of
+// element and reference a graph holds. Every name is unique, so each reference resolves
+// to the element it stands for.
+type Codebase struct {
+	Files      []*File
+	Elements   int // Declarations, one per node, plus classes holding members whose class is elsewhere
+	References int // Edges between the declarations
+	Skipped    int // Nodes without a declaration: outside the scanned files, or of no element type
+}
+
+// File is a synthetic source file
+type File struct {
+	Path    string // Relative to the output directory, with forward slashes
+	Content string
+}
+
+// prefixes name the declarations of each element type, followed by a number
+var prefixes = map[string]string{
+	"class": "C", "module": "C", "interface": "I", "trait": "T", "enum": "E",
+	"function": "f", "method": "m", "property": "p", "constant": "K",
+}
+
+// decl is a declaration in a synthetic file
+type decl struct {
+	node    *models.DependencyNode // nil for a class holding members whose class is elsewhere
+	kind    string                 // "class", "interface", "trait", "enum", "function", "method", "property", or "constant"
+	name    string
+	space   string  // Synthetic namespace
+	owner   *decl   // The class of a member
+	members []*decl // Of a class
+}
+
+// generator assigns names and writes declarations
+type generator struct {
+	decls   map[string]*decl // By node ID
+	spaces  map[string]string
+	dirs    map[string]string
+	counter int
+	files   int
+}
+
+// Anonymize builds the synthetic codebase for graph, whose nodes are in files. The same
+// graph always gives the same codebase: names are numbered in the order of the files'
+// relative paths and of the nodes' lines in them.
+func Anonymize(graph *models.DependencyGraph, files []models.FileInfo) *Codebase {
+	g := &generator{decls: make(map[string]*decl), spaces: make(map[string]string), dirs: make(map[string]string)}
+	codebase := &Codebase{}
+
+	relative := make(map[string]string)
+	for _, file := range files {
+		relative[file.Path] = filepath.ToSlash(filepath.Clean(file.RelativePath))
+	}
+	byFile := make(map[string][]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		if _, ok := relative[node.File]; !ok || prefixes[node.Type] == "" {
+			codebase.Skipped++
+			continue
+		}
+		byFile[node.File] = append(byFile[node.File], node)
+	}
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool { return relative[paths[i]] < relative[paths[j]] })
+
+	// Names first, so references can be written to declarations in any file
+	units := make([][]*decl, len(paths))
+	for i, path := range paths {
+		units[i] = g.declare(byFile[path])
+	}
+	for i, path := range paths {
+		content, references := g.write(units[i])
+		codebase.Files = append(codebase.Files, &File{Path: g.path(relative[path]), Content: content})
+		codebase.References += references
+		for _, d := range units[i] {
+			codebase.Elements += 1 + len(d.members)
+		}
+	}
+	return codebase
+}
+
+// declare names a file's nodes, returning its top-level declarations with members in
+// their classes
+func (g *generator) declare(nodes []*models.DependencyNode) []*decl {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Line != nodes[j].Line {
+			return nodes[i].Line < nodes[j].Line
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	var top []*decl
+	classes := make(map[string]*decl) // By namespace and class name, as members name them
+	for _, node := range nodes {
+		if kind := elementKind(node.Type); isType(kind) {
+			d := g.add(node, kind)
+			classes[node.Namespace+`\`+node.Name] = d
+			top = append(top, d)
+		}
+	}
+	for _, node := range nodes {
+		kind := elementKind(node.Type)
+		switch {
+		case kind == "method" || ((kind == "property" || kind == "constant") && node.ClassName != ""):
+			key := node.Namespace + `\` + node.ClassName
+			owner := classes[key]
+			if owner == nil {
+				// A member of a class declared in another file, such as a Go method or a Swift
+				// extension, goes in a class of its own
+				owner = &decl{kind: "class", name: g.next("class"), space: g.namespace(node.Namespace)}
+				classes[key] = owner
+				top = append(top, owner)
+			}
+			d := g.add(node, kind)
+			d.owner = owner
+			owner.members = append(owner.members, d)
+		case kind == "function" || kind == "property" || kind == "constant":
+			if kind == "property" {
+				kind = "constant" // A module-level variable
+			}
+			top = append(top, g.add(node, kind))
+		}
+	}
+
+	// Declarations outside a namespace come first, since a namespace lasts to the end of the file
+	sort.SliceStable(top, func(i, j int) bool { return top[i].space == "" && top[j].space != "" })
+	return top
+}
+
+// add names a node's declaration
+func (g *generator) add(node *models.DependencyNode, kind string) *decl {
+	d := &decl{node: node, kind: kind, name: g.next(kind), space: g.namespace(node.Namespace)}
+	g.decls[node.ID] = d
+	return d
+}
+
+// next returns a new name for a declaration kind
+func (g *generator) next(kind string) string {
+	g.counter++
+	return prefixes[kind] + strconv.Itoa(g.counter)
+}
+
+// namespace returns the synthetic namespace for a namespace, naming each segment by the
+// namespace up to it so the hierarchy is kept
+func (g *generator) namespace(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	if synthetic, ok := g.spaces[namespace]; ok {
+		return synthetic
+	}
+	parent := ""
+	if idx := strings.LastIndex(namespace, `\`); idx != -1 {
+		parent = g.namespace(namespace[:idx])
+	}
+	synthetic := "N" + strconv.Itoa(len(g.spaces)+1)
+	if parent != "" {
+		synthetic = parent + `\` + synthetic
+	}
+	g.spaces[namespace] = synthetic
+	return synthetic
+}
+
+// path returns the synthetic path for a relative path, naming each directory by the path
+// up to it so the tree is kept
+func (g *generator) path(relative string) string {
+	g.files++
+	name := "f" + strconv.Itoa(g.files) + ".php"
+	if dir := path.Dir(relative); dir != "." {
+		return g.dir(dir) + "/" + name
+	}
+	return name
+}
+
+// dir returns the synthetic path for a relative directory
+func (g *generator) dir(dir string) string {
+	if synthetic, ok := g.dirs[dir]; ok {
+		return synthetic
+	}
+	parent := ""
+	if dir := path.Dir(dir); dir != "." {
+		parent = g.dir(dir) + "/"
+	}
+	synthetic := parent + "d" + strconv.Itoa(len(g.dirs)+1)
+	g.dirs[dir] = synthetic
+	return synthetic
+}
+
+// write returns a file's content and the number of references in it
+func (g *generator) write(top []*decl) (string, int) {
+	var b strings.Builder
+	b.WriteString("<?php\n")
+	references := 0
+	space := ""
+	for _, d := range top {
+		if d.space != space {
+			space = d.space
+			fmt.Fprintf(&b, "\nnamespace %s;\n", space)
+		}
+		b.WriteString("\n")
+		switch d.kind {
+		case "function":
+			fmt.Fprintf(&b, "function %s() {\n", d.name)
+			references += g.body(&b, d, "    ")
+			b.WriteString("}\n")
+		case "constant":
+			fmt.Fprintf(&b, "const %s = 0;\n", d.name)
+		default:
+			references += g.writeType(&b, d)
+		}
+	}
+	return b.String(), references
+}
+
+// writeType writes a class, interface, trait, or enum with its members. Inheritance goes
+// in its header and trait uses in its body; its other references are attributes, which
+// are the references a body can hold outside its methods.
+func (g *generator) writeType(b *strings.Builder, d *decl) int {
+	references := 0
+	var extends, implements, traits, attributes []string
+	for _, ref := range g.refs(d) {
+		target := g.decls[ref.TargetID]
+		classLike := isType(target.kind)
+		switch {
+		case ref.Type == "extends" && classLike && d.kind == "interface":
+			extends = append(extends, target.name) // Interface lists take plain names
+		case ref.Type == "extends" && classLike && d.kind == "class" && len(extends) == 0:
+			extends = append(extends, qualified(target))
+		case ref.Type == "implements" && classLike && (d.kind == "class" || d.kind == "enum"):
+			implements = append(implements, qualified(target))
+		case ref.Type == "uses_trait" && classLike && d.kind != "interface":
+			traits = append(traits, qualified(target))
+		case classLike:
+			attributes = append(attributes, qualified(target))
+		default:
+			attributes = append(attributes, target.name)
+		}
+		references++
+	}
+
+	b.WriteString(d.kind + " " + d.name)
+	if len(extends) > 0 {
+		b.WriteString(" extends " + strings.Join(extends, ", "))
+	}
+	if len(implements) > 0 {
+		b.WriteString(" implements " + strings.Join(implements, ", "))
+	}
+	b.WriteString(" {\n")
+	for _, trait := range traits {
+		fmt.Fprintf(b, "    use %s;\n", trait)
+	}
+	for _, attribute := range attributes {
+		fmt.Fprintf(b, "    #[%s]\n", attribute)
+	}
+	for _, member := range d.members {
+		switch member.kind {
+		case "property":
+			fmt.Fprintf(b, "    public $%s;\n", member.name)
+		case "constant":
+			fmt.Fprintf(b, "    const %s = 0;\n", member.name)
+		}
+	}
+	for _, member := range d.members {
+		if member.kind != "method" {
+			continue
+		}
+		if d.kind == "interface" && len(g.refs(member)) == 0 {
+			fmt.Fprintf(b, "    public function %s();\n", member.name)
+			continue
+		}
+		fmt.Fprintf(b, "    public function %s() {\n", member.name)
+		references += g.body(b, member, "        ")
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	return references
+}
+
+// body writes a function's or method's references, one statement per reference counted,
+// returning the number of edges
+func (g *generator) body(b *strings.Builder, d *decl, indent string) int {
+	refs := g.refs(d)
+	for _, ref := range refs {
+		target := g.decls[ref.TargetID]
+		var stmt string
+		switch {
+		case target.kind == "function":
+			stmt = qualified(target) + "();"
+		case target.owner != nil && target.owner == d.owner:
+			stmt = "$this->" + target.name + "();"
+		case target.owner != nil || target.kind == "constant":
+			stmt = "$x->" + target.name + "();"
+		case ref.Type == "instantiation":
+			stmt = "new " + qualified(target) + "();"
+		case ref.Type == "static_call":
+			stmt = qualified(target) + "::call();"
+		default:
+			stmt = "$x instanceof " + qualified(target) + ";"
+		}
+		for i := 0; i < max(ref.Count, 1); i++ {
+			b.WriteString(indent + stmt + "\n")
+		}
+	}
+	return len(refs)
+}
+
+// refs returns the references from a declaration to other declarations, by target name
+func (g *generator) refs(d *decl) []*models.DependencyRef {
+	if d.node == nil {
+		return nil
+	}
+	var refs []*models.DependencyRef
+	for targetID, ref := range d.node.Dependencies {
+		if target := g.decls[targetID]; target != nil && target != d {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := g.decls[refs[i].TargetID].name, g.decls[refs[j].TargetID].name
+		if a != b {
+			return a < b
+		}
+		return refs[i].Type < refs[j].Type
+	})
+	return refs
+}
+
+// Write writes the codebase under dir, which must not exist or be empty so nothing is
+// overwritten, and returns the bytes written
+func (c *Codebase) Write(dir string) (int64, error) {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return 0, fmt.Errorf("%s is not empty", dir)
+	}
+	var total int64
+	for _, file := range c.Files {
+		target := filepath.Join(dir, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return total, err
+		}
+		if err := os.WriteFile(target, []byte(file.Content), 0644); err != nil {
+			return total, err
+		}
+		total += int64(len(file.Content))
+	}
+	return total, nil
+}
+
+// elementKind returns the declaration kind of an element type: modules are classes
+func elementKind(elementType string) string {
+	if elementType == "module" {
+		return "class"
+	}
+	return elementType
+}
+
+// isType reports whether a declaration kind is a class, interface, trait, or enum
+func isType(kind string) bool {
+	return kind == "class" || kind == "interface" || kind == "trait" || kind == "enum"
+}
+
+// qualified returns a declaration's fully qualified name
+func qualified(d *decl) string {
+	if d.space == "" {
+		return `\` + d.name
+	}
+	return `\` + d.space + `\` + d.name
+}
@@ -0,0 +1,160 @@
+package benchmarks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/lang"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// analyze parses the PHP files under root and builds their graph
+func analyze(t *testing.T, root string, names ...string) (*models.DependencyGraph, []models.FileInfo) {
+	t.Helper()
+	var files []models.FileInfo
+	var parsed []*models.ParsedFile
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		file, err := lang.NewPHPParser().ParseFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, models.FileInfo{Path: path, RelativePath: name})
+		parsed = append(parsed, file)
+	}
+	return analyzer.NewDependencyTracker().BuildDependencyGraph(parsed), files
+}
+
+// shape counts a graph's nodes by type and its edges
+func shape(graph *models.DependencyGraph) map[string]int {
+	counts := make(map[string]int)
+	for _, node := range graph.Nodes {
+		counts[node.Type]++
+		counts["edges"] += len(node.Dependencies)
+	}
+	return counts
+}
+
+func TestAnonymize(t *testing.T) {
+	tmp := t.TempDir()
+	fixtures := map[string]string{
+		"src/Billing/Invoice.php": `<?php
+namespace App\Billing;
+
+interface Payable {
+    public function charge();
+}
+
+class Invoice extends Document implements Payable {
+    use \App\Support\Auditable;
+
+    const STATUS_PAID = 'paid';
+    private $total;
+
+    public function pay() {
+        $this->audit();
+        $line = new Line();
+        helper();
+    }
+}
+`,
+		"src/Billing/Document.php": `<?php
+namespace App\Billing;
+
+abstract class Document {
+}
+
+class Line {
+}
+`,
+		"src/Support/Auditable.php": `<?php
+namespace App\Support;
+
+trait Auditable {
+    public function audit() {
+    }
+}
+`,
+		"src/helpers.php": `<?php
+
+function helper() {
+    $invoice = new \App\Billing\Invoice();
+    $invoice->pay();
+    $invoice->pay();
+}
+`,
+	}
+	var names []string
+	for name, code := range fixtures {
+		path := filepath.Join(tmp, "app", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	graph, files := analyze(t, filepath.Join(tmp, "app"), names...)
+
+	codebase := Anonymize(graph, files)
+	if len(codebase.Files) != 4 || codebase.Skipped != 0 || codebase.Elements != len(graph.Nodes) {
+		t.Fatalf("expected a file per file and a declaration per node, got %d files, %d elements, %d skipped",
+			len(codebase.Files), codebase.Elements, codebase.Skipped)
+	}
+	var generated []string
+	for _, file := range codebase.Files {
+		for _, name := range []string{"Billing", "Invoice", "Payable", "helper", "audit", "charge", "pay", "total", "STATUS"} {
+			if strings.Contains(file.Path+file.Content, name) {
+				t.Errorf("expected %s to be renamed in %s:\n%s", name, file.Path, file.Content)
+			}
+		}
+		generated = append(generated, file.Path)
+	}
+	if generated[0] != "d1/d2/f1.php" || generated[3] != "d1/f4.php" {
+		t.Errorf("expected the tree's directories to be kept, got %v", generated)
+	}
+	if again := Anonymize(graph, files); again.Files[1].Content != codebase.Files[1].Content {
+		t.Errorf("expected the same codebase from the same graph, got:\n%s\n%s", codebase.Files[1].Content, again.Files[1].Content)
+	}
+
+	out := filepath.Join(tmp, "synthetic")
+	if size, err := codebase.Write(out); err != nil || size == 0 {
+		t.Fatalf("expected the files written, got %d bytes (%v)", size, err)
+	}
+	synthetic, _ := analyze(t, out, generated...)
+	want, got := shape(graph), shape(synthetic)
+	if len(want) != len(got) {
+		t.Errorf("expected the shape %v, got %v", want, got)
+	}
+	for kind, count := range want {
+		if got[kind] != count {
+			t.Errorf("expected %d %s, got %d", count, kind, got[kind])
+		}
+	}
+	if codebase.References != want["edges"] {
+		t.Errorf("expected %d references, got %d", want["edges"], codebase.References)
+	}
+
+	if _, err := codebase.Write(out); err == nil {
+		t.Error("expected an error writing over a directory that isn't empty")
+	}
+}
+
+func TestAnonymize_Members(t *testing.T) {
+	method := &models.DependencyNode{ID: "method:shop\\Total:3", Name: "Total", Type: "method", File: "/src/order_total.go", Namespace: "shop", ClassName: "Order", Line: 3}
+	graph := &models.DependencyGraph{Nodes: map[string]*models.DependencyNode{
+		method.ID:   method,
+		"hook:save": {ID: "hook:save", Name: "save", Type: "hook"},
+	}}
+	codebase := Anonymize(graph, []models.FileInfo{{Path: "/src/order_total.go", RelativePath: "order_total.go"}})
+	if codebase.Skipped != 1 || codebase.Elements != 2 || len(codebase.Files) != 1 {
+		t.Fatalf("expected the method in a class of its own and the hook skipped, got %+v", codebase)
+	}
+	if content := codebase.Files[0].Content; !strings.Contains(content, "namespace N1;\n\nclass C1 {\n    public function m2() {") {
+		t.Errorf("unexpected content:\n%s", content)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package benchmarks generates synthetic codebases of a chosen size for `tukey bench`,
+// so pipeline throughput can be measured on the same input from run to run
+package benchmarks
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// filesPerModule is how many files share a namespace (and directory)
+const filesPerModule = 50
+
+// Spec describes a corpus
+type Spec struct {
+	Language  string // "php" or "javascript"
+	Files     int
+	Functions int   // Methods per class
+	Seed      int64 // Same seed, same corpus
+}
+
+// DefaultSpec is the corpus `tukey bench` generates without options
+var DefaultSpec = Spec{Language: "php", Files: 1000, Functions: 10, Seed: 1}
+
+// Languages are the languages a corpus can be generated in
+var Languages = []string{"javascript", "php"}
+
+// Generate writes the corpus described by spec under dir: one class per file, in
+// modules of 50 files, whose methods instantiate and call classes in other modules so
+// the dependency graph has cross-file edges to resolve. It returns the bytes written.
+func Generate(dir string, spec Spec) (int64, error) {
+	if spec.Files < 1 || spec.Functions < 1 {
+		return 0, fmt.Errorf("a corpus needs at least one file and one function per file")
+	}
+	var render func(spec Spec, rng *rand.Rand, i int) string
+	ext := ""
+	switch spec.Language {
+	case "php":
+		render, ext = renderPHP, ".php"
+	case "javascript":
+		render, ext = renderJS, ".js"
+	default:
+		return 0, fmt.Errorf("unsupported corpus language %q (supported: %v)", spec.Language, Languages)
+	}
+
+	rng := rand.New(rand.NewSource(spec.Seed))
+	var total int64
+	for i := 0; i < spec.Files; i++ {
+		path := filepath.Join(dir, "src", moduleName(i), className(i)+ext)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return total, err
+		}
+		src := render(spec, rng, i)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			return total, err
+		}
+		total += int64(len(src))
+	}
+	return total, nil
+}
+
+func moduleName(i int) string { return fmt.Sprintf("Module%d", i/filesPerModule) }
+func className(i int) string  { return fmt.Sprintf("Class%d", i) }
+
+// methodName names method m of class i after the class: the graph tells PHP methods
+// apart by namespace, name, and line, which classes in one module would otherwise share
+func methodName(i, m int) string { return fmt.Sprintf("class%dMethod%d", i, m) }
+
+// call is a method of another generated class that a method calls
+type call struct {
+	file   int
+	method int
+}
+
+// calls picks the classes a method depends on
+func calls(spec Spec, rng *rand.Rand) []call {
+	picked := make([]call, 1+rng.Intn(3))
+	for i := range picked {
+		picked[i] = call{file: rng.Intn(spec.Files), method: rng.Intn(spec.Functions)}
+	}
+	return picked
+}
+
+func renderPHP(spec Spec, rng *rand.Rand, i int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<?php\n\nnamespace Bench\\%s;\n\n", moduleName(i))
+	fmt.Fprintf(&b, "/**\n * %s is generated benchmark code\n */\nclass %s\n{\n    private $value%d = %d;\n", className(i), className(i), i, i)
+	for m := 0; m < spec.Functions; m++ {
+		fmt.Fprintf(&b, "\n    public function %s($input, array $options = [])\n    {\n        $result = $this->value%d + %d;\n", methodName(i, m), i, m)
+		for _, c := range calls(spec, rng) {
+			fmt.Fprintf(&b, "        $dep = new \\Bench\\%s\\%s();\n", moduleName(c.file), className(c.file))
+			fmt.Fprintf(&b, "        $result += $dep->%s($input);\n", methodName(c.file, c.method))
+		}
+		if m > 0 {
+			fmt.Fprintf(&b, "        if ($result > %d) {\n            return $this->%s($result);\n        }\n", rng.Intn(1000), methodName(i, m-1))
+		}
+		b.WriteString("        return $result;\n    }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func renderJS(spec Spec, rng *rand.Rand, i int) string {
+	var b strings.Builder
+	var body strings.Builder
+	imports := make(map[int]bool)
+	for m := 0; m < spec.Functions; m++ {
+		fmt.Fprintf(&body, "\n  %s(input, options = {}) {\n    let result = this.value + %d\n", methodName(i, m), m)
+		for _, c := range calls(spec, rng) {
+			if c.file != i {
+				imports[c.file] = true
+			}
+			fmt.Fprintf(&body, "    result += new %s().%s(input)\n", className(c.file), methodName(c.file, c.method))
+		}
+		if m > 0 {
+			fmt.Fprintf(&body, "    if (result > %d) {\n      return this.%s(result)\n    }\n", rng.Intn(1000), methodName(i, m-1))
+		}
+		body.WriteString("    return result\n  }\n")
+	}
+
+	files := make([]int, 0, len(imports))
+	for file := range imports {
+		files = append(files, file)
+	}
+	sort.Ints(files)
+	for _, file := range files {
+		path := "./" + className(file) + ".js"
+		if moduleName(file) != moduleName(i) {
+			path = "../" + moduleName(file) + "/" + className(file) + ".js"
+		}
+		fmt.Fprintf(&b, "import { %s } from '%s'\n", className(file), path)
+	}
+	fmt.Fprintf(&b, "\n/**\n * %s is generated benchmark code\n */\nexport class %s {\n  value = %d\n", className(i), className(i), i)
+	b.WriteString(body.String())
+	b.WriteString("}\n")
+	return b.String()
+}
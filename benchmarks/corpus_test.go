@@ -0,0 +1,74 @@
+package benchmarks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// dependencyPattern finds what a generated PHP method depends on: the classes it
+// instantiates, the methods it calls, and its class's property
+var dependencyPattern = regexp.MustCompile(`new \\[\w\\]*\\(Class\d+)\(|->(class\d+Method\d+)\(|\$this->(value)\d+`)
+
+func TestGenerate(t *testing.T) {
+	spec := Spec{Language: "php", Files: 60, Functions: 3, Seed: 7}
+	first, second := t.TempDir(), t.TempDir()
+
+	size, err := Generate(first, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again, _ := Generate(second, spec); again != size {
+		t.Errorf("expected the same seed to give the same corpus, got %d and %d bytes", size, again)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(first, "src", "*", "*.php"))
+	if len(files) != 60 {
+		t.Fatalf("expected 60 files, got %d", len(files))
+	}
+	src, _ := os.ReadFile(filepath.Join(first, "src", "Module1", "Class50.php"))
+	if !strings.Contains(string(src), `namespace Bench\Module1;`) || strings.Count(string(src), "public function") != 3 {
+		t.Errorf("unexpected class:\n%s", src)
+	}
+
+	// Parsed, each class is a node with its property and methods, and each method
+	// depends on its property and on every class it instantiates and other method it calls
+	names := make([]string, len(files))
+	edges := 0
+	for i, file := range files {
+		rel, _ := filepath.Rel(first, file)
+		names[i] = filepath.ToSlash(rel)
+		src, _ := os.ReadFile(file)
+		for _, body := range strings.Split(string(src), "public function")[1:] {
+			targets := make(map[string]bool)
+			for _, match := range dependencyPattern.FindAllStringSubmatch(body, -1) {
+				targets[strings.Join(match[1:], "")] = true
+			}
+			delete(targets, strings.TrimSpace(body[:strings.Index(body, "(")])) // Recursion isn't an edge
+			edges += len(targets)
+		}
+	}
+	graph, _ := analyze(t, first, names...)
+	want := map[string]int{"class": 60, "method": 180, "property": 60, "edges": edges}
+	if got := shape(graph); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected graph %v, got %v", want, got)
+	}
+
+	if _, err := Generate(t.TempDir(), Spec{Language: "cobol", Files: 1, Functions: 1}); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
+
+func TestGenerate_JavaScriptImports(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(dir, Spec{Language: "javascript", Files: 100, Functions: 2, Seed: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src, _ := os.ReadFile(filepath.Join(dir, "src", "Module0", "Class0.js"))
+	if !strings.Contains(string(src), "export class Class0 {") || !strings.Contains(string(src), "import { Class") {
+		t.Errorf("unexpected module:\n%s", src)
+	}
+}
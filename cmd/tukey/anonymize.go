@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boone-studios/tukey/benchmarks"
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/scanner"
+)
+
+const anonymizeUsage = "Usage: tukey anonymize -o <dir> [--language <lang>] [<directory>]"
+
+// anonymizeOptions are the parsed arguments of `tukey anonymize`
+type anonymizeOptions struct {
+	Output   string
+	Language string
+	Dir      string
+}
+
+// parseAnonymizeArgs parses the arguments following `tukey anonymize`
+func parseAnonymizeArgs(args []string) (*anonymizeOptions, error) {
+	opts := &anonymizeOptions{Language: "php", Dir: "."}
+	dirSet := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-o" || arg == "--output" || arg == "-l" || arg == "--language":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			if arg == "-o" || arg == "--output" {
+				opts.Output = args[i+1]
+			} else {
+				opts.Language = args[i+1]
+			}
+			i++
+		case strings.HasPrefix(arg, "-"):
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		case dirSet:
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		default:
+			opts.Dir = arg
+			dirSet = true
+		}
+	}
+	if opts.Output == "" {
+		return nil, fmt.Errorf("an output directory is required (-o <dir>)")
+	}
+	if _, ok := parser.Get(opts.Language); !ok {
+		return nil, fmt.Errorf("unsupported language %q (supported: %v)", opts.Language, parser.SupportedLanguages())
+	}
+	return opts, nil
+}
+
+// runAnonymize implements `tukey anonymize`: it analyzes the directory and writes a PHP
+// codebase with the same shape (files, declarations, and references between them) under
+// generated names, so performance problems can be reproduced without sharing the source
+func runAnonymize(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(anonymizeUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseAnonymizeArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, anonymizeUsage)
+		return runstatus.ExitUsage
+	}
+
+	// The codebase is built from the graph and the scanned files' relative paths, so this
+	// analyzes in-process like `tukey similar`
+	p, _ := parser.Get(opts.Language)
+	fileScanner := scanner.NewScanner(opts.Dir)
+	fileScanner.SetExtensions(p.FileExtensions())
+	fileScanner.AddDefaultExcludes(p.DefaultExcludes())
+	files, err := fileScanner.ScanFiles()
+	if err != nil {
+		sayErr("❌ Error scanning files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	parsed, err := p.ProcessFiles(files, progress.NewProgressBar(len(files), "Parsing"))
+	printParseErrors()
+	if err != nil {
+		sayErr("❌ Error parsing files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(parsed)
+
+	codebase := benchmarks.Anonymize(graph, files)
+	size, err := codebase.Write(opts.Output)
+	if err != nil {
+		sayErr("❌ Failed to write the synthetic codebase: %v\n", err)
+		return runstatus.ExitUsage
+	}
+	say("\n🧪 Wrote %d files (%d bytes) to %s: %d elements and %d references\n",
+		len(codebase.Files), size, opts.Output, codebase.Elements, codebase.References)
+	if codebase.Skipped > 0 {
+		say("   %d nodes outside the scanned files or of no element type were left out\n", codebase.Skipped)
+	}
+	say("   Analyze it with: tukey --language php %s\n", opts.Output)
+	return runstatus.ExitOK
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestParseAnonymizeArgs(t *testing.T) {
+	opts, err := parseAnonymizeArgs([]string{"src", "-o", "synthetic", "--language", "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Output != "synthetic" || opts.Language != "go" || opts.Dir != "src" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts, err := parseAnonymizeArgs([]string{"--output", "out"}); err != nil || opts.Dir != "." || opts.Language != "php" {
+		t.Errorf("expected PHP in the current directory by default, got %+v (%v)", opts, err)
+	}
+
+	for _, bad := range [][]string{{}, {"src"}, {"-o"}, {"-o", "out", "-l", "cobol"}, {"-o", "out", "a", "b"}, {"-o", "out", "--verbose"}} {
+		if _, err := parseAnonymizeArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
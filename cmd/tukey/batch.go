@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/portfolio"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+const batchUsage = "Usage: tukey batch [--output <dir>] <projects.yml> [-- <analysis flags>]"
+
+// batchOptions are the parsed arguments of `tukey batch`
+type batchOptions struct {
+	File     string
+	Output   string   // Overrides the project list's output directory
+	Analysis []string // Extra flags for every project, after "--"
+}
+
+// parseBatchArgs parses the arguments following `tukey batch`
+func parseBatchArgs(args []string) (*batchOptions, error) {
+	opts := &batchOptions{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--":
+			opts.Analysis = append([]string(nil), args[i+1:]...)
+			i = len(args)
+		case "-o", "--output":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a directory", arg)
+			}
+			opts.Output = args[i+1]
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") || opts.File != "" {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.File = arg
+		}
+	}
+
+	if opts.File == "" {
+		return nil, fmt.Errorf("a project list is required")
+	}
+	return opts, nil
+}
+
+// runBatch implements `tukey batch`: it analyzes each project in a YAML or JSON list with
+// its own config, saving each report and run status under the output directory, and
+// summarizes them all in portfolio.json
+func runBatch(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(batchUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseBatchArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, batchUsage)
+		return runstatus.ExitUsage
+	}
+	cfg, err := config.LoadBatchConfig(opts.File)
+	if err != nil {
+		sayErr("❌ Failed to load %s: %v\n", opts.File, err)
+		return runstatus.ExitUsage
+	}
+	if opts.Output != "" {
+		cfg.Output = opts.Output
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		sayErr("❌ Can't locate the tukey binary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	summary := &portfolio.Summary{Version: displayVersion(), Projects: []portfolio.Project{}}
+	for i, project := range cfg.Projects {
+		say("🔍 [%d/%d] Analyzing %s (%s)\n", i+1, len(cfg.Projects), project.Name, project.Root)
+		dir := filepath.Join(cfg.Output, project.Name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			sayErr("❌ Can't create %s: %v\n", dir, err)
+			return runstatus.ExitInternal
+		}
+
+		flags := append(append([]string{}, project.Flags...), opts.Analysis...)
+		result := analyzeProject(exe, project.Root, dir, flags)
+		result.Name = project.Name
+		summary.Add(result)
+
+		if result.Error != "" {
+			sayErr("   ⚠️ %s: %s\n", result.Status, result.Error)
+		} else {
+			say("   %s: %d files, %d nodes, %d orphans, %d findings\n",
+				result.Status, result.Counts.Files, result.Counts.Nodes, result.Counts.Orphans, len(result.Findings))
+		}
+	}
+	summary.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+
+	printPortfolio(summary)
+	if err := summary.Write(filepath.Join(cfg.Output, portfolio.FileName)); err != nil {
+		sayErr("❌ Failed to write the portfolio summary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	say("\n💾 Reports saved to %s (summary in portfolio.json)\n", cfg.Output)
+	return summary.ExitCode
+}
+
+// analyzeProject analyzes root with a child tukey process, writing report.json and
+// run-status.json to dir, and reads back the project's outcome
+func analyzeProject(exe, root, dir string, flags []string) portfolio.Project {
+	reportPath := filepath.Join(dir, "report.json")
+	statusPath := filepath.Join(dir, "run-status.json")
+	os.Remove(statusPath) // Don't read a previous run's status if this one fails early
+
+	args := append(flags, "--output", reportPath, "--status-file", statusPath, root)
+	cmd := exec.Command(exe, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Run() // The exit code is also in the status file
+
+	result := portfolio.Project{Root: root, Findings: []runstatus.Finding{}}
+	var status runstatus.Status
+	data, err := os.ReadFile(statusPath)
+	if err == nil {
+		err = json.Unmarshal(data, &status)
+	}
+	if err != nil {
+		result.Status, result.ExitCode = "internal_error", runstatus.ExitInternal
+		result.Error = strings.TrimSpace(stderr.String())
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("no run status: %v", err)
+		}
+		return result
+	}
+
+	result.Status, result.ExitCode, result.Error = status.Status, status.ExitCode, status.Error
+	result.DurationMs, result.Counts, result.Metrics = status.DurationMs, status.Counts, status.Metrics
+	if status.Findings != nil {
+		result.Findings = status.Findings
+	}
+	if _, err := os.Stat(reportPath); err == nil {
+		result.Report = filepath.Join(filepath.Base(dir), "report.json")
+	}
+	return result
+}
+
+// printPortfolio prints a row per project, those with the most findings first, and the
+// totals across them
+func printPortfolio(summary *portfolio.Summary) {
+	projects := append([]portfolio.Project(nil), summary.Projects...)
+	sort.SliceStable(projects, func(i, j int) bool {
+		if projects[i].ExitCode != projects[j].ExitCode {
+			return projects[i].ExitCode > projects[j].ExitCode
+		}
+		return len(projects[i].Findings) > len(projects[j].Findings)
+	})
+
+	width := len("Project")
+	for _, project := range projects {
+		width = max(width, len(project.Name))
+	}
+	say("\n📊 Portfolio: %d projects\n", len(projects))
+	say("   %-*s  %-14s %8s %8s %8s %8s %9s\n", width, "Project", "Status", "Files", "Nodes", "Orphans", "Cycles", "Findings")
+	for _, project := range projects {
+		say("   %-*s  %-14s %8d %8d %8d %8d %9d\n", width, project.Name, project.Status, project.Counts.Files,
+			project.Counts.Nodes, project.Counts.Orphans, project.Metrics["cycles"], len(project.Findings))
+	}
+	totals := summary.Totals
+	say("   Total: %d files, %d nodes, %d edges, %d orphans, %d parse errors\n",
+		totals.Files, totals.Nodes, totals.Edges, totals.Orphans, totals.ParseErrors)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBatchArgs(t *testing.T) {
+	opts, err := parseBatchArgs([]string{"--output", "reports", "projects.yml", "--", "--exclude", "tests"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.File != "projects.yml" || opts.Output != "reports" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Analysis, []string{"--exclude", "tests"}) {
+		t.Errorf("expected analysis flags after --, got %v", opts.Analysis)
+	}
+
+	for _, bad := range [][]string{
+		{},                        // no project list
+		{"a.yml", "b.yml"},        // two project lists
+		{"projects.yml", "--out"}, // unknown flag
+		{"projects.yml", "--output"},
+	} {
+		if _, err := parseBatchArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/benchmarks"
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/scanner"
+)
+
+const benchUsage = "Usage: tukey bench [--files <n>] [--functions <n>] [--language <lang>] [--runs <n>] [--baseline <file>] [--save] [--tolerance <percent>] [<directory>]"
+
+// benchPhases are the pipeline phases `tukey bench` times, in order
+var benchPhases = []string{"scan", "parse", "graph"}
+
+// benchOptions are the parsed arguments of `tukey bench`
+type benchOptions struct {
+	Spec      benchmarks.Spec
+	Runs      int
+	Baseline  string
+	Save      bool
+	Tolerance float64 // Percent of throughput a phase may lose before failing
+	Dir       string  // Benchmark this directory instead of a generated corpus
+}
+
+// benchPhase is one phase's median timing
+type benchPhase struct {
+	Seconds        float64 `json:"seconds"`
+	FilesPerSecond float64 `json:"filesPerSecond"`
+	MBPerSecond    float64 `json:"mbPerSecond"`
+}
+
+// benchResult is what `tukey bench` measures, and what a baseline stores
+type benchResult struct {
+	Version string                 `json:"version"`
+	Corpus  string                 `json:"corpus"` // The spec, or the directory, measured
+	Files   int                    `json:"files"`
+	Bytes   int64                  `json:"bytes"`
+	Nodes   int                    `json:"nodes"`
+	Edges   int                    `json:"edges"`
+	Runs    int                    `json:"runs"`
+	Phases  map[string]*benchPhase `json:"phases"`
+}
+
+// parseBenchArgs parses the arguments following `tukey bench`
+func parseBenchArgs(args []string) (*benchOptions, error) {
+	opts := &benchOptions{
+		Spec:      benchmarks.DefaultSpec,
+		Runs:      3,
+		Baseline:  filepath.Join("benchmarks", "baseline.json"),
+		Tolerance: 20,
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--save":
+			opts.Save = true
+		case "--files", "--functions", "--language", "--runs", "--baseline", "--tolerance":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			value := args[i+1]
+			i++
+			switch arg {
+			case "--language":
+				opts.Spec.Language = value
+			case "--baseline":
+				opts.Baseline = value
+			case "--tolerance":
+				tolerance, err := strconv.ParseFloat(value, 64)
+				if err != nil || tolerance < 0 || tolerance >= 100 {
+					return nil, fmt.Errorf("--tolerance needs a percentage from 0 to 100, got %q", value)
+				}
+				opts.Tolerance = tolerance
+			default:
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("%s needs a positive integer, got %q", arg, value)
+				}
+				switch arg {
+				case "--files":
+					opts.Spec.Files = n
+				case "--functions":
+					opts.Spec.Functions = n
+				case "--runs":
+					opts.Runs = n
+				}
+			}
+		default:
+			if strings.HasPrefix(arg, "-") || opts.Dir != "" {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Dir = arg
+		}
+	}
+
+	if _, ok := parser.Get(opts.Spec.Language); !ok {
+		return nil, fmt.Errorf("unsupported language %q (supported: %v)", opts.Spec.Language, parser.SupportedLanguages())
+	}
+	if opts.Dir == "" && !slices.Contains(benchmarks.Languages, opts.Spec.Language) {
+		return nil, fmt.Errorf("can't generate a %s corpus (supported: %v); benchmark a directory instead", opts.Spec.Language, benchmarks.Languages)
+	}
+	return opts, nil
+}
+
+// runBench implements `tukey bench`: it times the scan, parse, and graph phases over a
+// generated corpus (or a directory), reports each phase's median throughput, and fails
+// when a phase has slowed by more than --tolerance percent against the stored baseline
+func runBench(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(benchUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseBenchArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, benchUsage)
+		return runstatus.ExitUsage
+	}
+
+	dir, corpus := opts.Dir, opts.Dir
+	if dir == "" {
+		spec := opts.Spec
+		corpus = fmt.Sprintf("%s, %d files, %d functions each, seed %d", spec.Language, spec.Files, spec.Functions, spec.Seed)
+		if dir, err = os.MkdirTemp("", "tukey-bench-"); err != nil {
+			sayErr("❌ Can't create the corpus directory: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		defer os.RemoveAll(dir)
+		say("🏗️  Generating corpus: %s\n", corpus)
+		if _, err := benchmarks.Generate(dir, spec); err != nil {
+			sayErr("❌ Failed to generate the corpus: %v\n", err)
+			return runstatus.ExitInternal
+		}
+	}
+
+	result, err := measurePipeline(dir, opts.Spec.Language, opts.Runs)
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitInternal
+	}
+	result.Corpus = corpus
+	printBench(result)
+
+	if opts.Save {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		if err := os.WriteFile(opts.Baseline, append(data, '\n'), 0644); err != nil {
+			sayErr("❌ Failed to save the baseline: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		say("💾 Saved baseline to %s\n", opts.Baseline)
+		return runstatus.ExitOK
+	}
+
+	data, err := os.ReadFile(opts.Baseline)
+	if errors.Is(err, os.ErrNotExist) {
+		say("ℹ️  No baseline at %s; run with --save to record one\n", opts.Baseline)
+		return runstatus.ExitOK
+	}
+	var baseline benchResult
+	if err == nil {
+		err = json.Unmarshal(data, &baseline)
+	}
+	if err != nil {
+		sayErr("❌ Failed to read baseline %s: %v\n", opts.Baseline, err)
+		return runstatus.ExitUsage
+	}
+	if baseline.Corpus != result.Corpus {
+		sayErr("❌ Baseline %s measured a different corpus (%s); rerun with --save to replace it\n", opts.Baseline, baseline.Corpus)
+		return runstatus.ExitUsage
+	}
+
+	regressions := compareBench(&baseline, result, opts.Tolerance)
+	if len(regressions) == 0 {
+		say("✅ No phase is more than %.0f%% slower than the baseline (%s)\n", opts.Tolerance, baseline.Version)
+		return runstatus.ExitOK
+	}
+	for _, regression := range regressions {
+		sayErr("❌ %s\n", regression)
+	}
+	return runstatus.ExitFindings
+}
+
+// measurePipeline runs the scan, parse, and graph phases over dir runs times, keeping
+// each phase's median duration
+func measurePipeline(dir, language string, runs int) (*benchResult, error) {
+	p, _ := parser.Get(language)
+	result := &benchResult{Version: displayVersion(), Runs: runs, Phases: make(map[string]*benchPhase)}
+	timings := make(map[string][]time.Duration)
+
+	for run := 1; run <= runs; run++ {
+		start := time.Now()
+		fileScanner := scanner.NewScanner(dir)
+		fileScanner.SetExtensions(p.FileExtensions())
+		fileScanner.AddDefaultExcludes(p.DefaultExcludes())
+		files, err := fileScanner.ScanFiles()
+		if err != nil {
+			return nil, fmt.Errorf("error scanning files: %v", err)
+		}
+		timings["scan"] = append(timings["scan"], time.Since(start))
+
+		start = time.Now()
+		parsed, err := p.ProcessFiles(files, progress.NewProgressBar(len(files), fmt.Sprintf("Run %d of %d", run, runs)))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing files: %v", err)
+		}
+		timings["parse"] = append(timings["parse"], time.Since(start))
+		printParseErrors()
+
+		start = time.Now()
+		graph := analyzer.NewDependencyTracker().BuildDependencyGraph(parsed)
+		timings["graph"] = append(timings["graph"], time.Since(start))
+
+		result.Files, result.Bytes = len(files), getTotalSize(files)
+		result.Nodes, result.Edges = len(graph.Nodes), countEdges(graph)
+	}
+
+	mb := float64(result.Bytes) / (1024 * 1024)
+	for _, phase := range benchPhases {
+		seconds := median(timings[phase]).Seconds()
+		if seconds <= 0 {
+			seconds = time.Microsecond.Seconds()
+		}
+		result.Phases[phase] = &benchPhase{
+			Seconds:        seconds,
+			FilesPerSecond: float64(result.Files) / seconds,
+			MBPerSecond:    mb / seconds,
+		}
+	}
+	return result, nil
+}
+
+// median returns the middle of the given durations
+func median(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+// countEdges counts the graph's dependency edges
+func countEdges(graph *models.DependencyGraph) int {
+	edges := 0
+	for _, node := range graph.Nodes {
+		edges += len(node.Dependencies)
+	}
+	return edges
+}
+
+// compareBench describes each phase whose throughput fell more than tolerance percent
+// below the baseline's
+func compareBench(baseline, current *benchResult, tolerance float64) []string {
+	var regressions []string
+	for _, phase := range benchPhases {
+		before, after := baseline.Phases[phase], current.Phases[phase]
+		if before == nil || after == nil || before.FilesPerSecond <= 0 {
+			continue
+		}
+		change := (after.FilesPerSecond - before.FilesPerSecond) / before.FilesPerSecond * 100
+		if change < -tolerance {
+			regressions = append(regressions, fmt.Sprintf("%s is %.0f%% slower than the baseline: %.0f files/s, was %.0f files/s",
+				phase, -change, after.FilesPerSecond, before.FilesPerSecond))
+		}
+	}
+	return regressions
+}
+
+// printBench prints each phase's median time and throughput
+func printBench(result *benchResult) {
+	say("\n⏱️  %d files (%.2f MB), %d nodes, %d edges; median of %d runs\n",
+		result.Files, float64(result.Bytes)/(1024*1024), result.Nodes, result.Edges, result.Runs)
+	for _, name := range benchPhases {
+		phase := result.Phases[name]
+		say("   %-6s %8.3fs  %10.0f files/s  %8.2f MB/s\n", name, phase.Seconds, phase.FilesPerSecond, phase.MBPerSecond)
+	}
+}
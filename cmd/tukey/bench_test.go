@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBenchArgs(t *testing.T) {
+	opts, err := parseBenchArgs(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Spec.Files != 1000 || opts.Spec.Language != "php" || opts.Runs != 3 || opts.Tolerance != 20 || opts.Save {
+		t.Errorf("unexpected defaults: %+v", opts)
+	}
+
+	opts, err = parseBenchArgs([]string{"--files", "50", "--language", "javascript", "--runs", "5", "--tolerance", "7.5", "--save"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Spec.Files != 50 || opts.Spec.Language != "javascript" || opts.Runs != 5 || opts.Tolerance != 7.5 || !opts.Save {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	for _, bad := range [][]string{
+		{"--files", "0"},
+		{"--runs"},
+		{"--tolerance", "100"},
+		{"--language", "cobol"},
+		{"a", "b"}, // two directories
+	} {
+		if _, err := parseBenchArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestCompareBench(t *testing.T) {
+	baseline := &benchResult{Phases: map[string]*benchPhase{
+		"scan":  {FilesPerSecond: 1000},
+		"parse": {FilesPerSecond: 100},
+		"graph": {FilesPerSecond: 500},
+	}}
+	current := &benchResult{Phases: map[string]*benchPhase{
+		"scan":  {FilesPerSecond: 2000},
+		"parse": {FilesPerSecond: 70},
+		"graph": {FilesPerSecond: 450},
+	}}
+
+	regressions := compareBench(baseline, current, 20)
+	if len(regressions) != 1 || !strings.HasPrefix(regressions[0], "parse is 30% slower") {
+		t.Errorf("expected only parse to regress, got %v", regressions)
+	}
+	if regressions := compareBench(baseline, current, 35); len(regressions) != 0 {
+		t.Errorf("expected no regressions within tolerance, got %v", regressions)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+const bisectUsage = "Usage: tukey bisect --metric <name> --good <rev> [--bad <rev>] [--max <n>] [<directory>] [-- <analysis flags>]"
+
+// bisectOptions are the parsed arguments of `tukey bisect`
+type bisectOptions struct {
+	Metric   string
+	Good     string
+	Bad      string
+	Max      int
+	HasMax   bool
+	Dir      string
+	Analysis []string // Extra flags for each analysis run, after "--"
+}
+
+// parseBisectArgs parses the arguments following `tukey bisect`
+func parseBisectArgs(args []string) (*bisectOptions, error) {
+	opts := &bisectOptions{Bad: "HEAD", Dir: "."}
+	dirSet := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--":
+			opts.Analysis = append([]string(nil), args[i+1:]...)
+			i = len(args)
+		case "--metric", "--good", "--bad", "--max":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			value := args[i+1]
+			i++
+			switch arg {
+			case "--metric":
+				opts.Metric = value
+			case "--good":
+				opts.Good = value
+			case "--bad":
+				opts.Bad = value
+			case "--max":
+				max, err := strconv.Atoi(value)
+				if err != nil || max < 0 {
+					return nil, fmt.Errorf("--max needs a non-negative integer, got %q", value)
+				}
+				opts.Max, opts.HasMax = max, true
+			}
+		default:
+			if strings.HasPrefix(arg, "-") || dirSet {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Dir, dirSet = arg, true
+		}
+	}
+
+	if opts.Metric == "" || opts.Good == "" {
+		return nil, errors.New("--metric and --good are required")
+	}
+	if err := runstatus.ValidateThresholds(map[string]int{opts.Metric: 0}); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// runBisect implements `tukey bisect`: it drives `git bisect` between a good and a bad
+// revision, analyzing each candidate commit, to find the first commit whose metric
+// exceeds --max (by default, the metric's value at the good revision)
+func runBisect(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(bisectUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseBisectArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, bisectUsage)
+		return runstatus.ExitUsage
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		sayErr("❌ Can't locate the tukey binary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	dir := opts.Dir
+	if out, err := git(dir, "status", "--porcelain", "--untracked-files=no"); err != nil {
+		sayErr("❌ %s isn't in a git repository: %v\n", dir, err)
+		return runstatus.ExitUsage
+	} else if out != "" {
+		sayErr("❌ The working tree has uncommitted changes; commit or stash them before bisecting\n")
+		return runstatus.ExitUsage
+	}
+
+	good, err := git(dir, "rev-parse", "--verify", opts.Good+"^{commit}")
+	if err != nil {
+		sayErr("❌ Unknown good revision %q\n", opts.Good)
+		return runstatus.ExitUsage
+	}
+	bad, err := git(dir, "rev-parse", "--verify", opts.Bad+"^{commit}")
+	if err != nil {
+		sayErr("❌ Unknown bad revision %q\n", opts.Bad)
+		return runstatus.ExitUsage
+	}
+
+	// Return to the starting branch (or commit) however the bisect ends
+	original, err := git(dir, "symbolic-ref", "-q", "--short", "HEAD")
+	if err != nil {
+		original, _ = git(dir, "rev-parse", "HEAD")
+	}
+	bisecting := false
+	defer func() {
+		if bisecting {
+			git(dir, "bisect", "reset", original)
+		} else {
+			git(dir, "checkout", "-q", original)
+		}
+	}()
+
+	measure := func(rev string) (int, error) {
+		if _, err := git(dir, "checkout", "-q", "--detach", rev); err != nil {
+			return 0, err
+		}
+		return measureMetric(exe, opts)
+	}
+
+	say("🔎 Bisecting %s between %s (good) and %s (bad)\n", opts.Metric, opts.Good, opts.Bad)
+
+	goodValue, err := measure(good)
+	if err != nil {
+		sayErr("❌ Failed to analyze good revision %s: %v\n", opts.Good, err)
+		return runstatus.ExitInternal
+	}
+	max := goodValue
+	if opts.HasMax {
+		max = opts.Max
+		if goodValue > max {
+			sayErr("❌ %s is already %d at %s, above --max %d; pick an older good revision\n", opts.Metric, goodValue, opts.Good, max)
+			return runstatus.ExitUsage
+		}
+	}
+	say("   %s: %s=%d (good)\n", shortRev(good), opts.Metric, goodValue)
+
+	badValue, err := measure(bad)
+	if err != nil {
+		sayErr("❌ Failed to analyze bad revision %s: %v\n", opts.Bad, err)
+		return runstatus.ExitInternal
+	}
+	if badValue <= max {
+		say("   %s: %s=%d\n", shortRev(bad), opts.Metric, badValue)
+		say("✅ %s doesn't exceed %d at %s; nothing to bisect\n", opts.Metric, max, opts.Bad)
+		return runstatus.ExitOK
+	}
+	say("   %s: %s=%d (bad)\n", shortRev(bad), opts.Metric, badValue)
+
+	if _, err := git(dir, "bisect", "start", bad, good); err != nil {
+		sayErr("❌ git bisect start failed: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	bisecting = true
+
+	for {
+		head, err := git(dir, "rev-parse", "HEAD")
+		if err != nil {
+			sayErr("❌ %v\n", err)
+			return runstatus.ExitInternal
+		}
+
+		verdict := "good"
+		value, err := measureMetric(exe, opts)
+		if err != nil {
+			verdict = "skip"
+			say("   %s: skipped (%v)\n", shortRev(head), err)
+		} else {
+			if value > max {
+				verdict = "bad"
+			}
+			say("   %s: %s=%d (%s)\n", shortRev(head), opts.Metric, value, verdict)
+		}
+
+		out, err := git(dir, "bisect", verdict)
+		if err != nil && !strings.Contains(out, "first bad commit") {
+			sayErr("❌ git bisect %s failed: %v\n", verdict, err)
+			return runstatus.ExitInternal
+		}
+
+		if culprit := firstBadCommit(out); culprit != "" {
+			summary, _ := git(dir, "show", "-s", "--format=%h %s (%an, %ad)", "--date=short", culprit)
+			say("\n🎯 First bad commit: %s\n", summary)
+			say("   %s went above %d here\n", opts.Metric, max)
+			return runstatus.ExitOK
+		}
+		if strings.Contains(out, "only 'skip'ped commits left") {
+			say("\n⚠️ Couldn't narrow it down past commits that failed to analyze:\n%s\n", out)
+			return runstatus.ExitOK
+		}
+	}
+}
+
+// measureMetric analyzes the checked-out tree with a child tukey process and returns the
+// value of the bisected metric. Runs that fail outright (usage or internal errors, e.g. a
+// broken config at that commit) are errors, so the commit is skipped.
+func measureMetric(exe string, opts *bisectOptions) (int, error) {
+	statusFile, err := os.CreateTemp("", "tukey-bisect-*.json")
+	if err != nil {
+		return 0, err
+	}
+	statusFile.Close()
+	defer os.Remove(statusFile.Name())
+
+	args := append([]string{"--summary-only", "--status-file", statusFile.Name()}, opts.Analysis...)
+	exec.Command(exe, append(args, opts.Dir)...).Run() // The exit code is also in the status file
+
+	data, err := os.ReadFile(statusFile.Name())
+	if err != nil {
+		return 0, err
+	}
+	var status runstatus.Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return 0, fmt.Errorf("unreadable run status: %w", err)
+	}
+	if status.ExitCode >= runstatus.ExitUsage {
+		return 0, fmt.Errorf("analysis failed: %s", status.Error)
+	}
+	value, ok := status.Metrics[opts.Metric]
+	if !ok {
+		return 0, fmt.Errorf("analysis didn't report %s", opts.Metric)
+	}
+	return value, nil
+}
+
+// firstBadCommit extracts the commit from git bisect's "<sha> is the first bad commit"
+func firstBadCommit(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if sha, ok := strings.CutSuffix(strings.TrimSpace(line), " is the first bad commit"); ok {
+			return sha
+		}
+	}
+	return ""
+}
+
+// git runs a git command in dir and returns its trimmed combined output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", filepath.Clean(dir)}, args...)...)
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// shortRev abbreviates a commit hash for progress output
+func shortRev(sha string) string {
+	if len(sha) > 10 {
+		return sha[:10]
+	}
+	return sha
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBisectArgs(t *testing.T) {
+	opts, err := parseBisectArgs([]string{"--metric", "cycles", "--good", "v1.4.0", "src", "--", "--language", "javascript"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Metric != "cycles" || opts.Good != "v1.4.0" || opts.Bad != "HEAD" || opts.Dir != "src" || opts.HasMax {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Analysis, []string{"--language", "javascript"}) {
+		t.Errorf("expected analysis flags after --, got %v", opts.Analysis)
+	}
+
+	opts, err = parseBisectArgs([]string{"--metric", "orphans", "--good", "abc123", "--bad", "main", "--max", "20"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Bad != "main" || !opts.HasMax || opts.Max != 20 || opts.Dir != "." {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	for _, bad := range [][]string{
+		{"--good", "v1"},                                // missing metric
+		{"--metric", "cycles"},                          // missing good
+		{"--metric", "loops", "--good", "v1"},           // unknown metric
+		{"--metric", "cycles", "--good", "v1", "--max"}, // missing value
+		{"--metric", "cycles", "--good", "v1", "--max", "-2"},
+		{"--metric", "cycles", "--good", "v1", "a", "b"}, // two directories
+	} {
+		if _, err := parseBisectArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestFirstBadCommit(t *testing.T) {
+	output := "9bd63a52b4c1e0f0e6f1c2d3a4b5c6d7e8f90a1b is the first bad commit\ncommit 9bd63a52b4c1e0f0e6f1c2d3a4b5c6d7e8f90a1b\nAuthor: Dev <dev@example.com>\n"
+	if got := firstBadCommit(output); got != "9bd63a52b4c1e0f0e6f1c2d3a4b5c6d7e8f90a1b" {
+		t.Errorf("unexpected commit %q", got)
+	}
+	if got := firstBadCommit("Bisecting: 2 revisions left to test after this (roughly 1 step)"); got != "" {
+		t.Errorf("expected no commit mid-bisect, got %q", got)
+	}
+}
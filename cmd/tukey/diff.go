@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/boone-studios/tukey/internal/diff"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// diffListLimit caps each section of the console diff; --json has the full lists
+const diffListLimit = 20
+
+// runDiff implements `tukey diff [--json <file>] [--changelog <file>] [--rename-threshold <0-1>]
+// <old.json> <new.json>` and returns the exit code
+func runDiff(args []string) int {
+	usage := "Usage: tukey diff [--json <file>] [--changelog <file>] [--rename-threshold <0-1>] <old.json> <new.json>"
+	jsonFile := ""
+	changelogFile := ""
+	threshold := diff.DefaultRenameThreshold
+	var reports []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-h", "--help":
+			fmt.Println(usage)
+			return runstatus.ExitOK
+		case "--json":
+			if i+1 >= len(args) {
+				sayErr("❌ --json requires a filename\n")
+				return runstatus.ExitUsage
+			}
+			jsonFile = args[i+1]
+			i++
+		case "--changelog":
+			if i+1 >= len(args) {
+				sayErr("❌ --changelog requires a filename\n")
+				return runstatus.ExitUsage
+			}
+			changelogFile = args[i+1]
+			i++
+		case "--rename-threshold":
+			if i+1 >= len(args) {
+				sayErr("❌ --rename-threshold requires a value\n")
+				return runstatus.ExitUsage
+			}
+			value, err := strconv.ParseFloat(args[i+1], 64)
+			if err != nil || value < 0 || value > 1 {
+				sayErr("❌ --rename-threshold needs a number between 0 and 1, got %q\n", args[i+1])
+				return runstatus.ExitUsage
+			}
+			threshold = value
+			i++
+		default:
+			reports = append(reports, args[i])
+		}
+	}
+	if len(reports) != 2 {
+		fmt.Println(usage)
+		return runstatus.ExitUsage
+	}
+
+	oldGraph, err := diff.Load(reports[0])
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+	newGraph, err := diff.Load(reports[1])
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+
+	report := diff.Compare(oldGraph, newGraph, threshold)
+	oldAPI, err := diff.LoadAPISurface(reports[0])
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+	newAPI, err := diff.LoadAPISurface(reports[1])
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+	if oldAPI != nil && newAPI != nil {
+		report.API = diff.CompareAPI(oldAPI, newAPI)
+	}
+	printDiff(reports[0], reports[1], report)
+
+	if changelogFile != "" {
+		if report.API == nil {
+			sayErr("❌ --changelog needs both reports to record a public API (set apiNamespaces)\n")
+			return runstatus.ExitUsage
+		}
+		title := fmt.Sprintf("Public API changes: %s → %s", reports[0], reports[1])
+		if err := os.WriteFile(changelogFile, []byte(report.API.Changelog(title)), 0644); err != nil {
+			sayErr("❌ Failed to write changelog: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		say("\n📝 API changelog saved to %s\n", changelogFile)
+	}
+
+	if jsonFile != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(jsonFile, data, 0644)
+		}
+		if err != nil {
+			sayErr("❌ Failed to write diff: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		say("\n💾 Diff saved to %s\n", jsonFile)
+	}
+	return runstatus.ExitOK
+}
+
+// printDiff summarizes a graph diff on the console
+func printDiff(oldName, newName string, report *diff.Report) {
+	say("📊 Graph diff: %s → %s\n", oldName, newName)
+	say("   %d added, %d removed, %d renamed or moved, %d nodes with changed dependencies (+%d/-%d edges)\n",
+		len(report.Added), len(report.Removed), len(report.Renamed), len(report.Changed),
+		report.EdgesAdded, report.EdgesGone)
+
+	if len(report.Renamed) > 0 {
+		say("\n🔀 Renamed or moved:\n")
+		for i, rename := range report.Renamed {
+			if i == diffListLimit {
+				say("   ... and %d more\n", len(report.Renamed)-i)
+				break
+			}
+			say("   • %s %s (%s) → %s (%s), %.0f%% similar\n", rename.Kind,
+				rename.From.Name, rename.From.File, rename.To.Name, rename.To.File, rename.Similarity*100)
+		}
+	}
+
+	printNodeRefs("\n➕ Added:\n", report.Added)
+	printNodeRefs("\n➖ Removed:\n", report.Removed)
+
+	if len(report.Changed) > 0 {
+		say("\n🔗 Changed dependencies:\n")
+		for i, change := range report.Changed {
+			if i == diffListLimit {
+				say("   ... and %d more\n", len(report.Changed)-i)
+				break
+			}
+			say("   • %s (%s): +%d/-%d\n", change.Node.Name, change.Node.File, len(change.Added), len(change.Removed))
+		}
+	}
+
+	if report.API != nil {
+		printAPIChanges(report.API)
+	}
+}
+
+// printAPIChanges summarizes public API changes, breaking ones first
+func printAPIChanges(changes *diff.APIChanges) {
+	breaking := ""
+	if changes.Breaking() {
+		breaking = " (breaking)"
+	}
+	say("\n📚 Public API: %d added, %d removed, %d changed signatures%s\n",
+		len(changes.Added), len(changes.Removed), len(changes.Changed), breaking)
+
+	for i, symbol := range changes.Removed {
+		if i == diffListLimit {
+			say("   ... and %d more removed\n", len(changes.Removed)-i)
+			break
+		}
+		say("   - %s %s\n", symbol.Kind, symbol.Name)
+	}
+	for i, change := range changes.Changed {
+		if i == diffListLimit {
+			say("   ... and %d more changed\n", len(changes.Changed)-i)
+			break
+		}
+		say("   ~ %s: %s → %s\n", change.Name, change.Old, change.New)
+	}
+	for i, symbol := range changes.Added {
+		if i == diffListLimit {
+			say("   ... and %d more added\n", len(changes.Added)-i)
+			break
+		}
+		say("   + %s %s\n", symbol.Kind, symbol.Name)
+	}
+}
+
+// printNodeRefs prints a titled, capped list of nodes, or nothing when it's empty
+func printNodeRefs(title string, refs []*diff.NodeRef) {
+	if len(refs) == 0 {
+		return
+	}
+	say(title)
+	for i, ref := range refs {
+		if i == diffListLimit {
+			say("   ... and %d more\n", len(refs)-i)
+			break
+		}
+		say("   • %s %s (%s:%d)\n", ref.Type, ref.Name, ref.File, ref.Line)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+func TestRunDiff_Changelog(t *testing.T) {
+	dir := t.TempDir()
+	oldReport := filepath.Join(dir, "old.json")
+	newReport := filepath.Join(dir, "new.json")
+	changelog := filepath.Join(dir, "API.md")
+	os.WriteFile(oldReport, []byte(`{"graph":{"nodes":{}},"apiSurface":{"namespaces":["Acme"],"symbols":[
+		{"name":"Acme\\Invoice::total","kind":"method","signature":"public function total(): int"},
+		{"name":"Acme\\Invoice::legacy","kind":"method","signature":"public function legacy()"}]}}`), 0644)
+	os.WriteFile(newReport, []byte(`{"graph":{"nodes":{}},"apiSurface":{"namespaces":["Acme"],"symbols":[
+		{"name":"Acme\\Invoice::total","kind":"method","signature":"public function total(float $tax): int"}]}}`), 0644)
+
+	var code int
+	out := captureOutput(func() { code = runDiff([]string{"--changelog", changelog, oldReport, newReport}) })
+	if code != runstatus.ExitOK {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(out, "Public API: 0 added, 1 removed, 1 changed signatures (breaking)") {
+		t.Errorf("expected an API summary, got:\n%s", out)
+	}
+	data, err := os.ReadFile(changelog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "### Removed\n\n- `Acme\\Invoice::legacy` (method)") {
+		t.Errorf("unexpected changelog:\n%s", data)
+	}
+
+	bare := filepath.Join(dir, "bare.json")
+	os.WriteFile(bare, []byte(`{"graph":{"nodes":{}}}`), 0644)
+	if code := runDiff([]string{"--changelog", changelog, bare, newReport}); code != runstatus.ExitUsage {
+		t.Errorf("expected a usage error without API surfaces, got %d", code)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/layout"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/scanner"
+)
+
+const layoutUsage = "Usage: tukey layout [--json <file>] [<directory>]"
+
+// layoutOptions are the parsed arguments of `tukey layout`
+type layoutOptions struct {
+	JSONFile string
+	Dir      string
+}
+
+// parseLayoutArgs parses the arguments following `tukey layout`
+func parseLayoutArgs(args []string) (*layoutOptions, error) {
+	opts := &layoutOptions{Dir: "."}
+	dirSet := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--json":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--json requires a file")
+			}
+			opts.JSONFile = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "-"):
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		case dirSet:
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		default:
+			opts.Dir = arg
+			dirSet = true
+		}
+	}
+	return opts, nil
+}
+
+// runLayout implements `tukey layout`: it checks that PHP namespaces match their
+// directories under the project's PSR-4 mappings, and lists a fix for each mismatch. With
+// --json, the fixes are saved as a plan for codemod tools to apply.
+func runLayout(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(layoutUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseLayoutArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, layoutUsage)
+		return runstatus.ExitUsage
+	}
+
+	mappings, err := layout.LoadComposer(opts.Dir)
+	if err != nil {
+		sayErr("❌ Error reading composer.json: %v\n", err)
+		return runstatus.ExitUsage
+	}
+
+	// The use changes need the graph and the parsed use statements, so this analyzes
+	// in-process like `tukey similar`
+	p, _ := parser.Get("php")
+	fileScanner := scanner.NewScanner(opts.Dir)
+	fileScanner.SetExtensions(p.FileExtensions())
+	fileScanner.AddDefaultExcludes(p.DefaultExcludes())
+	files, err := fileScanner.ScanFiles()
+	if err != nil {
+		sayErr("❌ Error scanning files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	parsed, err := p.ProcessFiles(files, progress.NewProgressBar(len(files), "Parsing"))
+	printParseErrors()
+	if err != nil {
+		sayErr("❌ Error parsing files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(parsed)
+
+	if len(mappings) == 0 {
+		mappings = layout.Infer(opts.Dir, parsed)
+	}
+	plan := layout.Check(opts.Dir, mappings, parsed, graph)
+	printLayout(plan)
+
+	if opts.JSONFile != "" {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err == nil {
+			err = os.WriteFile(opts.JSONFile, data, 0644)
+		}
+		if err != nil {
+			sayErr("❌ Failed to write fix plan: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		say("\n💾 Fix plan saved to %s\n", opts.JSONFile)
+	}
+	return runstatus.ExitOK
+}
+
+// printLayout lists the mappings checked against and the fixes planned
+func printLayout(plan *layout.Plan) {
+	if len(plan.Mappings) == 0 {
+		say("\n📐 No PSR-4 mappings in composer.json, and none could be inferred\n")
+		return
+	}
+	mappings := make([]string, 0, len(plan.Mappings))
+	for _, mapping := range plan.Mappings {
+		mappings = append(mappings, fmt.Sprintf(`%s\ → %s/`, mapping.Prefix, mapping.Dir))
+	}
+	say("\n📐 Namespace layout (%s): %s\n", plan.Mappings[0].Source, strings.Join(mappings, ", "))
+
+	if len(plan.Fixes) == 0 {
+		say("\n✅ Every namespace matches its directory\n")
+		return
+	}
+	for _, fix := range plan.Fixes {
+		say("\n   %s: %s\\%s\n", fix.File, fix.Namespace, fix.Class)
+		switch fix.Action {
+		case layout.ActionMove:
+			say("      → move to %s\n", fix.MoveTo)
+		case layout.ActionNamespace:
+			say("      → change the namespace to %s", fix.NewNamespace)
+			if fix.MoveTo != "" {
+				say(" and rename the file to %s", fix.MoveTo)
+			}
+			say("\n")
+			for _, change := range fix.Uses {
+				if change.Action == "add" {
+					say("        + use %s; in %s\n", change.Name, change.File)
+				} else {
+					say("        - use %s; in %s:%d\n", change.Name, change.File, change.Line)
+				}
+			}
+		}
+	}
+	say("\n📝 %d files don't match their namespace\n", len(plan.Fixes))
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestParseLayoutArgs(t *testing.T) {
+	opts, err := parseLayoutArgs([]string{"--json", "plan.json", "src"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.JSONFile != "plan.json" || opts.Dir != "src" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts, err := parseLayoutArgs(nil); err != nil || opts.Dir != "." {
+		t.Errorf("expected the current directory by default, got %+v (%v)", opts, err)
+	}
+
+	for _, bad := range [][]string{{"--json"}, {"a", "b"}, {"--verbose"}} {
+		if _, err := parseLayoutArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
@@ -4,108 +4,566 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/checkpoint"
+	"github.com/boone-studios/tukey/internal/churn"
+	"github.com/boone-studios/tukey/internal/clones"
+	"github.com/boone-studios/tukey/internal/codeowners"
 	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/coverage"
+	"github.com/boone-studios/tukey/internal/distribute"
+	"github.com/boone-studios/tukey/internal/literals"
 	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/openapi"
 	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/pathstyle"
+	"github.com/boone-studios/tukey/internal/plugin"
 	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/provenance"
+	"github.com/boone-studios/tukey/internal/redact"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/sample"
 	"github.com/boone-studios/tukey/internal/scanner"
+	"github.com/boone-studios/tukey/internal/trace"
+	"github.com/boone-studios/tukey/internal/wasmrule"
+	"github.com/boone-studios/tukey/internal/workspace"
 	"github.com/boone-studios/tukey/pkg/output"
 
 	_ "github.com/boone-studios/tukey/internal/lang"
 )
 
-const version = "0.3.0"
+// Build metadata, set with -ldflags "-X main.version=... -X main.commit=... -X main.date=..."
+var (
+	version = "0.3.0"
+	commit  = "none"
+	date    = "unknown"
+)
+
+// accessible switches CLI output to plain text for screen readers. It is set by
+// --accessible, `accessible: true` in config, or the TUKEY_ACCESSIBLE environment variable.
+var accessible = os.Getenv("TUKEY_ACCESSIBLE") != ""
 
 func main() {
-	argv, err := parseArgs()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	os.Exit(run())
+}
+
+// run executes the CLI and returns its exit code (see internal/runstatus)
+func run() (code int) {
+	progress.SetAccessible(accessible)
+	parser.SetErrorReporter(func(err *parser.FileError) {
+		parseFailures.Lock()
+		defer parseFailures.Unlock()
+		parseFailures.errs = append(parseFailures.errs, err)
+	})
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "self-update":
+			return runSelfUpdate(os.Args[2:])
+		case "verify":
+			return runVerify(os.Args[2:])
+		case "diff":
+			return runDiff(os.Args[2:])
+		case "bisect":
+			return runBisect(os.Args[2:])
+		case "bench":
+			return runBench(os.Args[2:])
+		case "work":
+			return runWork(os.Args[2:])
+		case "semver":
+			return runSemver(os.Args[2:])
+		case "serve":
+			return runServe(os.Args[2:])
+		case "orphans":
+			return runOrphans(os.Args[2:])
+		case "similar":
+			return runSimilar(os.Args[2:])
+		case "mv-preview":
+			return runMvPreview(os.Args[2:])
+		case "layout":
+			return runLayout(os.Args[2:])
+		case "batch":
+			return runBatch(os.Args[2:])
+		case "portfolio":
+			return runPortfolio(os.Args[2:])
+		case "anonymize":
+			return runAnonymize(os.Args[2:])
+		}
 	}
 
-	fileCfg, err := config.LoadConfig(argv.RootPath)
+	status := runstatus.New(displayVersion())
+	statusFile := ""
+	defer func() {
+		if r := recover(); r != nil {
+			sayErr("❌ Internal error: %v\n", r)
+			status.Error = fmt.Sprint(r)
+			code = runstatus.ExitInternal
+		}
+		status.Finish(code)
+		if statusFile != "" {
+			if err := status.Write(statusFile); err != nil {
+				sayErr("⚠️ Failed to write run status: %v\n", err)
+			}
+		}
+	}()
+
+	// fail reports an error that ends the run and records it in the run status
+	fail := func(code int, format string, args ...interface{}) int {
+		status.Error = fmt.Sprintf(format, args...)
+		sayErr("❌ %s\n", status.Error)
+		return code
+	}
+
+	argv, err := parseArgs()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "⚠️ Failed to load config file: %v\n", err)
+		sayErr("Error: %v\n", err)
+		return runstatus.ExitUsage
 	}
 
+	fileCfg, configErr := config.LoadConfig(argv.RootPath)
+
 	// Merge CLI args with file config
 	argv = mergeConfigs(argv, fileCfg)
+	targetSource := "configured"
+	if argv.TargetVersion == "" && argv.Distribute == "" {
+		// Audit against the minimum version the project declares; workers can't
+		argv.TargetVersion, targetSource = declaredVersion(argv.RootPath, argv.Language)
+	}
+	statusFile = argv.StatusFile
+	status.Root = argv.RootPath
+	if argv.Accessible {
+		accessible = true
+		progress.SetAccessible(true)
+	}
+	if argv.Debug {
+		parser.SetDebug(true)
+	}
+
+	// Config problems fall back to the defaults with a warning, or end the run with
+	// --strict-config
+	for _, problem := range configProblems(fileCfg, configErr) {
+		if argv.StrictConfig {
+			return fail(runstatus.ExitUsage, "Invalid config: %v", problem)
+		}
+		sayErr("⚠️ Config ignored: %v\n", problem)
+	}
+	if argv.Sign && argv.OutputFile == "" {
+		sayErr("⚠️ --sign only applies to exported reports; add --output <file>\n")
+	}
+	if argv.Redact && argv.OutputFile == "" {
+		sayErr("⚠️ --redact only applies to exported reports; add --output <file>\n")
+	}
+	if len(argv.Prune) > 0 && argv.OutputFile == "" {
+		sayErr("⚠️ --prune only applies to exported reports; add --output <file>\n")
+	}
 
 	if argv.ShowVersion {
-		fmt.Printf("Tukey v%s\n", version)
-		os.Exit(0)
+		fmt.Println(versionString())
+		return runstatus.ExitOK
 	}
 
 	if argv.ShowHelp {
 		showHelp()
-		os.Exit(0)
+		return runstatus.ExitOK
+	}
+
+	// Plugins register their passes and exporters, so their metrics and formats validate
+	plugins, err := plugin.Start(argv.RootPath, displayVersion(), argv.Plugins)
+	if err != nil {
+		return fail(runstatus.ExitUsage, "Error starting plugin %v", err)
+	}
+	defer plugin.Close(plugins)
+	rules, err := wasmrule.Load(argv.RootPath, argv.WasmRules)
+	if err != nil {
+		return fail(runstatus.ExitUsage, "Error loading WASM rule %v", err)
 	}
+	defer wasmrule.Close(rules)
 
-	fmt.Printf("🔍 Tukey Code Analyzer v%s\n", version)
-	fmt.Printf("🎯 Analyzing codebase in: %s\n", argv.RootPath)
-	fmt.Println(strings.Repeat("-", 50))
+	if err := runstatus.ValidateThresholds(argv.Thresholds); err != nil {
+		return fail(runstatus.ExitUsage, "%v", err)
+	}
+	if err := runstatus.ValidateSeverities(argv.Severities); err != nil {
+		return fail(runstatus.ExitUsage, "%v", err)
+	}
+	if err := pathstyle.Validate(argv.PathStyle); err != nil {
+		return fail(runstatus.ExitUsage, "%v", err)
+	}
+	if len(argv.Prune) > 0 {
+		if argv.Prune, err = analyzer.ParsePrune(strings.Join(argv.Prune, ",")); err != nil {
+			return fail(runstatus.ExitUsage, "%v", err)
+		}
+	}
+	suppressions, err := loadSuppressions(argv)
+	if err != nil {
+		return fail(runstatus.ExitUsage, "Error reading suppressions: %v", err)
+	}
+	notes, err := loadNotes(argv)
+	if err != nil {
+		return fail(runstatus.ExitUsage, "Error reading notes: %v", err)
+	}
+
+	say("🔍 Tukey Code Analyzer v%s\n", displayVersion())
+	say("🎯 Analyzing codebase in: %s\n", argv.RootPath)
+	say("%s\n", strings.Repeat("-", 50))
 
 	// Initialize components
 	fileScanner := scanner.NewScanner(argv.RootPath)
 
 	p, ok := parser.Get(argv.Language)
 	if !ok {
-		fmt.Fprintf(os.Stderr, "❌ Unsupported language: %s\n", argv.Language)
-		fmt.Fprintf(os.Stderr, "Supported: %v\n", parser.SupportedLanguages())
-		os.Exit(1)
+		code := fail(runstatus.ExitUsage, "Unsupported language: %s", argv.Language)
+		sayErr("Supported: %v\n", parser.SupportedLanguages())
+		return code
+	}
+
+	if argv.TargetVersion != "" {
+		versioned, ok := p.(parser.Versioned)
+		if !ok {
+			return fail(runstatus.ExitUsage, "The %s parser can't target a language version", argv.Language)
+		}
+		if err := versioned.SetVersion(argv.TargetVersion); err != nil {
+			return fail(runstatus.ExitUsage, "Invalid target version: %v", err)
+		}
+	}
+
+	exporter, ok := output.Get(argv.Format)
+	if !ok {
+		code := fail(runstatus.ExitUsage, "Unsupported output format: %s", argv.Format)
+		sayErr("Supported: %v\n", output.SupportedFormats())
+		return code
+	}
+	if te, ok := exporter.(*output.TemplateExporter); ok {
+		if argv.Template == "" {
+			return fail(runstatus.ExitUsage, "The template format requires --template <file>")
+		}
+		te.SetTemplate(argv.Template)
+	}
+	if reader, ok := exporter.(output.SourceReader); ok && argv.Redact && reader.ReadsSource() {
+		return fail(runstatus.ExitUsage, "The %s format reads the analyzed files and can't be redacted", argv.Format)
+	}
+
+	var preset *config.Preset
+	if argv.Framework != "" {
+		preset, ok = config.GetPreset(argv.Framework)
+		if !ok {
+			code := fail(runstatus.ExitUsage, "Unsupported framework: %s", argv.Framework)
+			sayErr("Supported: %v\n", config.SupportedFrameworks())
+			return code
+		}
+		argv = applyPreset(argv, preset)
+	}
+
+	var spec *openapi.Spec
+	if argv.OpenAPI != "" {
+		if spec, err = openapi.Load(argv.OpenAPI); err != nil {
+			return fail(runstatus.ExitUsage, "Error reading OpenAPI spec: %v", err)
+		}
+	}
+	var profile *coverage.Profile
+	if len(argv.Coverage) > 0 {
+		if profile, err = coverage.Load(argv.Coverage...); err != nil {
+			return fail(runstatus.ExitUsage, "Error reading coverage: %v", err)
+		}
+	}
+	var runtimeTrace *trace.Trace
+	if len(argv.Traces) > 0 {
+		if runtimeTrace, err = trace.Load(argv.Traces...); err != nil {
+			return fail(runstatus.ExitUsage, "Error reading trace: %v", err)
+		}
 	}
 
-	fileScanner.SetExtensions(p.FileExtensions())
+	extensions := p.FileExtensions()
+	if preset != nil {
+		extensions = append(extensions, preset.Extensions...)
+	}
+	parsers := []parser.LanguageParser{p}
+	if argv.Bridges {
+		parsers = append(parsers, companionParsers(argv.Language)...)
+	}
+	scanned := extensions
+	for _, companion := range parsers[1:] {
+		scanned = append(scanned, companion.FileExtensions()...)
+	}
+	fileScanner.SetExtensions(scanned)
 
-	// Configure scanner exclusions
+	// Configure scanner exclusions: each parser's defaults, then the configured ones
+	for _, lp := range parsers {
+		fileScanner.AddDefaultExcludes(lp.DefaultExcludes())
+	}
 	for _, dir := range argv.ExcludeDirs {
 		fileScanner.AddExcludeDir(dir)
 	}
+	for _, dir := range argv.IncludeDirs {
+		fileScanner.IncludeDir(dir)
+	}
+	if argv.Gitignore {
+		fileScanner.UseGitignore()
+	}
+	if argv.Extensionless {
+		for _, lp := range parsers {
+			if sniffer, ok := lp.(parser.Sniffer); ok {
+				fileScanner.AddSniffer(lp.Language(), sniffer.Sniff)
+			}
+		}
+	}
+	fileScanner.SetMaxFileSize(argv.MaxFileSize)
 
 	// Step 1: Scan for files
 	spinner := progress.NewSpinner("Scanning for code files...")
 	spinner.Start()
 
+	phaseStart := time.Now()
 	files, err := fileScanner.ScanFiles()
+	spinner.Stop()
+	status.Phase("scan", phaseStart)
 	if err != nil {
-		spinner.Stop()
-		fmt.Printf("❌ Error scanning files: %v\n", err)
-		os.Exit(1)
+		return fail(runstatus.ExitInternal, "Error scanning files: %v", err)
 	}
 
-	spinner.Stop()
-	fmt.Printf("✅ Found %d files (%.2f MB total)\n",
+	say("✅ Found %d files (%.2f MB total)\n",
 		len(files), float64(getTotalSize(files))/(1024*1024))
+	scanReport := fileScanner.Report()
+	if argv.ScanReport {
+		formatter := output.NewConsoleFormatter()
+		formatter.SetAccessible(accessible)
+		formatter.PrintScanReport(scanReport, argv.Verbose)
+	} else if n := len(scanReport.Oversized); n > 0 {
+		sayErr("⚠️ Skipped %d files over the size limit (see --scan-report)\n", n)
+	}
+	population := len(files)
+	if argv.Sample > 0 {
+		files = sample.Select(files, argv.Sample)
+		say("🎲 Sampling %d of %d files (%g%%); counts are estimated for the whole tree\n", len(files), population, argv.Sample)
+	}
+	var changed map[string]bool // Scanned paths changed since --changed-only's ref
+	if argv.ChangedOnly != "" {
+		changedFiles, err := churn.Changed(argv.RootPath, argv.ChangedOnly)
+		if err != nil {
+			return fail(runstatus.ExitUsage, "Error listing files changed since %s: %v", argv.ChangedOnly, err)
+		}
+		changed = changedPaths(files, changedFiles)
+		say("🎯 %d of %d files changed since %s; findings are scoped to them\n", len(changed), len(files), argv.ChangedOnly)
+	}
+	if argv.DryRun {
+		_, excludes := fileScanner.GetStats()
+		printPlan(argv, parsers, filesByParser(files, parsers, extensions), excludes)
+		return runstatus.ExitOK
+	}
 
 	// Step 2: Parse files
-	fmt.Printf("🔧 Parsing project files and extracting elements...\n")
-	parseProgress := progress.NewProgressBar(len(files), "Parsing files")
-
+	say("🔧 Parsing project files and extracting elements...\n")
 	startTime := time.Now()
-	parsedFiles, err := p.ProcessFiles(files, parseProgress)
-	if err != nil {
-		fmt.Printf("❌ Error parsing files: %v\n", err)
-		os.Exit(1)
+	var saved *checkpoint.Checkpoint
+	var cache *gitCache
+	if argv.TargetVersion != "" && argv.Distribute != "" {
+		sayErr("⚠️ --target-version doesn't apply to --distribute runs; workers parse for the latest version\n")
+	}
+	if argv.Checkpoint != "" && argv.Distribute != "" {
+		sayErr("⚠️ --checkpoint doesn't apply to --distribute runs; workers parse every file\n")
+	} else if argv.Checkpoint != "" {
+		root, _ := filepath.Abs(argv.RootPath)
+		header := checkpoint.Header{Version: displayVersion(), Root: root, Languages: []string{}, TargetVersion: argv.TargetVersion}
+		for _, lp := range parsers {
+			header.Languages = append(header.Languages, lp.Language())
+		}
+		if changed != nil {
+			if header.Commit, err = churn.Head(argv.RootPath); err != nil {
+				return fail(runstatus.ExitUsage, "Error reading the checked out commit: %v", err)
+			}
+		}
+		if saved, err = checkpoint.Open(argv.Checkpoint, header); err != nil {
+			return fail(runstatus.ExitInternal, "Error opening checkpoint: %v", err)
+		}
+		defer saved.Close()
+		if changed != nil {
+			if cache, err = openGitCache(argv.RootPath, files, saved, header); err != nil {
+				return fail(runstatus.ExitInternal, "Error opening checkpoint: %v", err)
+			}
+		}
+		if n := saved.Restored(); n > 0 && changed != nil {
+			say("♻️ Loading unchanged files from %s: %d files cached\n", argv.Checkpoint, n)
+		} else if n > 0 {
+			say("♻️ Resuming from %s: %d files already parsed\n", argv.Checkpoint, n)
+		}
+	}
+	var parsedFiles []*models.ParsedFile
+	batches := filesByParser(files, parsers, extensions)
+	if argv.Distribute != "" {
+		if parsedFiles, err = parseDistributed(argv, batches, parsers); err != nil {
+			return fail(runstatus.ExitUsage, "Error starting the coordinator: %v", err)
+		}
+		batches = nil // Parsed by the workers
+	} else if saved == nil && len(parsers) > 1 {
+		if parsedFiles, err = parseLanguages(batches, parsers); err != nil {
+			return fail(runstatus.ExitInternal, "Error parsing files: %v", err)
+		}
+		batches = nil // Parsed side by side
+	}
+	for i, batch := range batches {
+		label := "Parsing files"
+		if len(parsers) > 1 {
+			label = fmt.Sprintf("Parsing %s files", parsers[i].Language())
+		}
+		var parsed []*models.ParsedFile
+		if saved != nil {
+			parsed, err = parseWithCheckpoint(parsers[i], batch, label, saved, cache)
+		} else {
+			parsed, err = parsers[i].ProcessFiles(batch, progress.NewProgressBar(len(batch), label))
+		}
+		if err != nil {
+			return fail(runstatus.ExitInternal, "Error parsing files: %v", err)
+		}
+		parsedFiles = append(parsedFiles, parsed...)
+	}
+	printParseErrors()
+	status.Phase("parse", startTime)
+
+	var estimates *models.SampleReport
+	if argv.Sample > 0 {
+		// Before --summary-only drops the usage counted as references
+		estimates = sample.Estimate(parsedFiles, population, argv.Sample)
 	}
 
 	totalElements := getTotalElements(parsedFiles)
-	fmt.Printf("✅ Parsing complete! Found %d code elements in %d files\n",
+	say("✅ Parsing complete! Found %d code elements in %d files\n",
 		totalElements, len(parsedFiles))
 
 	// Step 3: Build dependency graph
 	dependencySpinner := progress.NewSpinner("Building dependency relationships...")
 	dependencySpinner.Start()
 
+	phaseStart = time.Now()
 	tracker := analyzer.NewDependencyTracker()
+	if argv.WordPress {
+		tracker.EnableHooks()
+	}
+	if argv.CollapseBarrels {
+		tracker.CollapseBarrels()
+	}
+	if argv.Bridges {
+		tracker.EnableBridges(argv.RootPath)
+	}
+	if spec != nil {
+		tracker.EnableRoutes()
+	}
+	if argv.SummaryOnly {
+		tracker.SummaryOnly()
+	}
+	if argv.MaxLinesPerEdge > 0 {
+		tracker.SetMaxLinesPerEdge(argv.MaxLinesPerEdge)
+	}
+	if argv.MaxParameters > 0 {
+		tracker.SetMaxParameters(argv.MaxParameters)
+	}
+	if ws, err := workspace.Detect(argv.RootPath); err != nil {
+		sayErr("⚠️ Failed to read workspace manifests: %v\n", err)
+	} else if ws != nil {
+		tracker.SetWorkspace(ws)
+	}
+	if owners, err := loadCodeowners(argv); err != nil {
+		sayErr("⚠️ Failed to read CODEOWNERS: %v\n", err)
+	} else if owners != nil {
+		tracker.SetCodeowners(argv.RootPath, owners)
+	}
+	if profile != nil {
+		tracker.SetCoverage(argv.RootPath, profile)
+	}
+	if runtimeTrace != nil {
+		tracker.SetTrace(runtimeTrace)
+	}
+	if len(argv.Groups) > 0 {
+		if err := tracker.SetGroups(argv.RootPath, argv.Groups); err != nil {
+			dependencySpinner.Stop()
+			return fail(runstatus.ExitUsage, "Error in config groups: %v", err)
+		}
+	}
+	if preset != nil {
+		if err := configurePreset(tracker, preset); err != nil {
+			dependencySpinner.Stop()
+			return fail(runstatus.ExitUsage, "Error applying %s preset: %v", preset.Name, err)
+		}
+	}
+	for _, lp := range parsers {
+		if entrypointer, ok := lp.(parser.Entrypointer); ok {
+			for _, pattern := range entrypointer.Entrypoints() {
+				if err := tracker.AddEntrypoint(pattern); err != nil {
+					dependencySpinner.Stop()
+					return fail(runstatus.ExitInternal, "Error in %s entrypoints: %v", lp.Language(), err)
+				}
+			}
+		}
+	}
 	graph := tracker.BuildDependencyGraph(parsedFiles)
+	suppressionReport := analyzer.Suppress(argv.RootPath, graph, parsedFiles, suppressions)
+	staleNotes := analyzer.AttachNotes(argv.RootPath, graph, notes)
+	if changed != nil {
+		scope := make([]string, 0, len(changed))
+		for path := range changed {
+			scope = append(scope, path)
+		}
+		analyzer.ScopeToChanges(graph, argv.ChangedOnly, scope)
+	}
+	if argv.SummaryOnly {
+		// Usage has been folded into edge counts; don't keep it around for reporting
+		for _, file := range parsedFiles {
+			file.Usage = nil
+		}
+	}
 
 	dependencySpinner.Stop()
+	status.Phase("analyze", phaseStart)
+	for _, note := range staleNotes {
+		sayErr("⚠️ Note on %s matches nothing: %s\n", note.Pattern, note.Text)
+	}
+
+	// Clone detection and the literal inventory lex the parsed files of the analyzed
+	// language again. A threshold on their metric needs them, even without the flag.
+	var paths []string
+	for _, file := range parsedFiles {
+		if file.Language == p.Language() {
+			paths = append(paths, file.Path)
+		}
+	}
+	var cloneReport *models.CloneReport
+	if _, gated := argv.Thresholds["clones"]; argv.Clones || gated {
+		cloneSpinner := progress.NewSpinner("Detecting duplicated code...")
+		cloneSpinner.Start()
+		phaseStart = time.Now()
+		cloneReport = clones.Detect(argv.Language, paths, argv.MinCloneTokens)
+		cloneSpinner.Stop()
+		status.Phase("clones", phaseStart)
+	}
+	var literalReport *models.LiteralReport
+	if _, gated := argv.Thresholds["repeatedLiterals"]; argv.Literals || gated {
+		literalSpinner := progress.NewSpinner("Collecting repeated literals...")
+		literalSpinner.Start()
+		phaseStart = time.Now()
+		literalReport = literals.Detect(argv.Language, paths, argv.MinLiteralCount)
+		literalSpinner.Stop()
+		status.Phase("literals", phaseStart)
+	}
+	debt, err := analyzer.TechnicalDebt(argv.RootPath, parsedFiles, argv.TicketPattern)
+	if err != nil {
+		return fail(runstatus.ExitUsage, "Error in ticketPattern: %v", err)
+	}
+	if debt != nil && argv.DebtAge {
+		phaseStart = time.Now()
+		if err := analyzer.DateDebt(debt, argv.RootPath, churn.Blame, time.Now()); err != nil {
+			sayErr("⚠️ No git history, technical debt isn't dated: %v\n", err)
+		}
+		status.Phase("blame", phaseStart)
+	}
 
 	processingTime := time.Since(startTime)
 
@@ -116,41 +574,216 @@ func main() {
 		TotalFiles:     len(files),
 		TotalElements:  getTotalElements(parsedFiles),
 		ProcessingTime: processingTime.String(),
+		Root:           argv.RootPath,
+		APISurface:     analyzer.PublicAPI(argv.RootPath, parsedFiles, argv.APINamespaces),
+		Clones:         cloneReport,
+		Literals:       literalReport,
+		Debt:           debt,
+		Docs:           analyzer.Documentation(argv.RootPath, parsedFiles),
+		Tables:         analyzer.DatabaseTables(argv.RootPath, parsedFiles),
+	}
+	result.Sample = estimates
+	result.Scan = scanReport
+	result.Suppressions = suppressionReport
+	result.Flags = analyzer.FeatureFlags(argv.RootPath, graph, parsedFiles, argv.FeatureFlags)
+	result.Syntax = analyzer.NewerSyntax(argv.RootPath, parsedFiles, argv.Language, argv.TargetVersion)
+	if result.Syntax != nil {
+		result.Syntax.Source = targetSource
+	}
+	result.Platform = analyzer.PlatformExtensions(argv.RootPath, parsedFiles)
+	if spec != nil {
+		result.OpenAPI = analyzer.CorrelateOpenAPI(argv.RootPath, graph, spec)
+	}
+
+	status.Counts = runstatus.Counts{
+		Files:       len(files),
+		ParsedFiles: len(parsedFiles),
+		ParseErrors: len(files) - len(parsedFiles),
+		Elements:    totalElements,
+		Nodes:       graph.TotalNodes,
+		Edges:       graph.TotalEdges,
+		Orphans:     len(graph.Orphans),
+	}
+	status.Metrics = runstatus.Metrics(result)
+	for _, loaded := range plugins {
+		if err := loaded.Err(); err != nil {
+			return fail(runstatus.ExitInternal, "Error running plugin: %v", err)
+		}
+	}
+	for _, rule := range rules {
+		if err := rule.Err(); err != nil {
+			return fail(runstatus.ExitInternal, "Error running %v", err)
+		}
+	}
+	status.Findings = runstatus.Check(status.Metrics, argv.Thresholds)
+	if argv.Sample > 0 && (len(argv.Thresholds) > 0 || argv.MinDocCoverage > 0) {
+		// Metrics of a sample aren't the tree's; don't gate on them
+		sayErr("⚠️ Thresholds aren't checked on a sample; run without --sample to enforce them\n")
+		status.Findings = nil
+	} else if argv.MinDocCoverage > 0 {
+		minimums := map[string]int{"docCoverage": argv.MinDocCoverage}
+		status.Findings = append(status.Findings, runstatus.CheckMinimums(status.Metrics, minimums)...)
 	}
+	runstatus.ApplySeverities(status.Findings, argv.Severities)
 
 	// Step 4: Display results
 	formatter := output.NewConsoleFormatter()
-	formatter.PrintSummary(result, argv.Verbose)
+	formatter.SetAccessible(accessible)
+	if argv.SummaryOnly {
+		formatter.PrintMetrics(result, status.Metrics)
+	} else {
+		formatter.PrintSummary(result, argv.Verbose)
+	}
 
 	// Step 5: Export if requested
 	if argv.OutputFile != "" {
+		// Pruning only shapes the export; metrics and thresholds saw the whole graph
+		if len(argv.Prune) > 0 {
+			graph.Lock()
+			pruned := analyzer.Prune(graph, argv.Prune)
+			graph.Unlock()
+			say("✂️ Pruned %d of %d nodes (%s)\n", len(pruned.Removed), pruned.NodesBefore, strings.Join(pruned.Heuristics, ", "))
+		}
+
 		exportSpinner := progress.NewSpinner(fmt.Sprintf("Exporting to %s...", argv.OutputFile))
 		exportSpinner.Start()
 
-		exporter := output.NewJSONExporter()
-		if err := exporter.Export(result, argv.OutputFile); err != nil {
-			exportSpinner.Stop()
-			fmt.Printf("❌ Error exporting: %v\n", err)
-			os.Exit(1)
-		}
+		result.Metadata = provenance.Metadata(argv.RootPath, displayVersion(), commit)
+		result.Metadata.Args = os.Args[1:]
+		result.Metadata.Config = effectiveConfig(argv)
 
+		if argv.Sign {
+			if signer, ok := exporter.(output.SigningExporter); ok {
+				result.Provenance = provenance.New(argv.RootPath, displayVersion(), commit)
+				signer.SetSigningKey([]byte(os.Getenv(provenance.KeyEnv)))
+			} else {
+				sayErr("⚠️ The %s format doesn't support --sign; exporting unsigned\n", argv.Format)
+			}
+		}
+		if flows, ok := exporter.(*output.FlowsExporter); ok && argv.FlowDepth > 0 {
+			flows.SetDepth(argv.FlowDepth)
+		}
+		if churner, ok := exporter.(output.ChurnExporter); ok && churner.UsesChurn() {
+			lines, err := churn.Collect(argv.RootPath, argv.ChurnSince)
+			if err != nil {
+				sayErr("⚠️ No churn data, risk uses complexity only: %v\n", err)
+			}
+			result.Churn = lines
+		}
+		pathstyle.Apply(result, files, argv.PathStyle)
+		if argv.Redact {
+			redact.Apply(result, files, os.Getenv(redact.SaltEnv))
+		}
+		phaseStart = time.Now()
+		err := exporter.Export(result, argv.OutputFile)
 		exportSpinner.Stop()
-		fmt.Printf("✅ Analysis exported to %s\n", argv.OutputFile)
+		status.Phase("export", phaseStart)
+		if err != nil {
+			return fail(runstatus.ExitInternal, "Error exporting: %v", err)
+		}
+
+		say("✅ Analysis exported to %s\n", argv.OutputFile)
+	}
+	if saved != nil && changed == nil {
+		if err := saved.Remove(); err != nil {
+			sayErr("⚠️ Failed to remove checkpoint %s: %v\n", argv.Checkpoint, err)
+		}
+	}
+
+	say("\n🎉 Analysis complete! Processed %d files with %d dependencies\n",
+		len(files), status.Counts.Edges)
+
+	parseSeverity := runstatus.SeverityOf(argv.Severities, runstatus.ParseErrors)
+	if status.Counts.ParseErrors > 0 {
+		sayErr("%s %d files couldn't be parsed; results are incomplete\n", severityMarker(parseSeverity), status.Counts.ParseErrors)
+	}
+	for _, finding := range status.Findings {
+		if finding.Minimum {
+			sayErr("%s Threshold not met: %s is %d (min %d)\n", severityMarker(finding.Severity), finding.Metric, finding.Value, finding.Threshold)
+			continue
+		}
+		sayErr("%s Threshold exceeded: %s is %d (max %d)\n", severityMarker(finding.Severity), finding.Metric, finding.Value, finding.Threshold)
 	}
 
-	fmt.Printf("\n🎉 Analysis complete! Processed %d files with %d dependencies\n",
-		len(files), graph.TotalEdges)
+	switch {
+	case status.Counts.ParseErrors > 0 && parseSeverity == runstatus.SeverityError:
+		return runstatus.ExitParseErrors
+	case runstatus.HasErrors(status.Findings):
+		return runstatus.ExitFindings
+	}
+	return runstatus.ExitOK
+}
+
+// severityMarker is the symbol findings of a severity are printed with
+func severityMarker(severity string) string {
+	switch severity {
+	case runstatus.SeverityInfo:
+		return "ℹ️"
+	case runstatus.SeverityWarning:
+		return "⚠️"
+	}
+	return "❌"
 }
 
 // Config holds application configuration
 type Config struct {
-	RootPath    string
-	OutputFile  string
-	Verbose     bool
-	ShowHelp    bool
-	ShowVersion bool
-	ExcludeDirs []string
-	Language    string
+	RootPath        string
+	OutputFile      string
+	Format          string
+	Template        string
+	PathStyle       string // Separators in exported paths: "native" or "posix"
+	Accessible      bool
+	StrictConfig    bool // Fail on config files that don't load or have unknown settings
+	Debug           bool // Attach stack traces to parser panics
+	DryRun          bool // Scan and resolve configuration, then print the plan
+	ScanReport      bool // Print what the scanner matched and skipped
+	Gitignore       bool
+	Extensionless   bool  // Include extensionless scripts the parsers recognize by content
+	MaxFileSize     int64 // Bytes; larger files are skipped. 0 for no limit
+	Verbose         bool
+	ShowHelp        bool
+	ShowVersion     bool
+	ExcludeDirs     []string
+	IncludeDirs     []string // Directories to scan even though a parser excludes them by default
+	Language        string
+	TargetVersion   string // Language version the code targets, e.g. "7.4"; "" for the latest
+	WordPress       bool
+	Framework       string
+	CollapseBarrels bool
+	Bridges         bool // Parse every supported language and link references across them
+	Sign            bool
+	Redact          bool // Hash names and strip source text in the export
+	StatusFile      string
+	Checkpoint      string  // Saves parsed files as the run goes, to resume from
+	ChangedOnly     string  // Git ref: parse only files changed since it and scope findings to them
+	Distribute      string  // Address to hand parsing out to `tukey work` workers on
+	Sample          float64 // Percent of files to analyze, extrapolating counts; 0 for all
+	SummaryOnly     bool
+	MaxLinesPerEdge int
+	MaxParameters   int
+	Clones          bool
+	MinCloneTokens  int
+	Literals        bool
+	MinLiteralCount int
+	ChurnSince      string
+	TicketPattern   string // Regular expression for ticket references in TODO comments
+	DebtAge         bool
+	MinDocCoverage  int // Percent of the public API that must be documented; 0 for no minimum
+	FlowDepth       int
+	Prune           []string              // Heuristics applied to the graph before export
+	Groups          map[string][]string   // Virtual groups, from config only
+	Codeowners      string                // CODEOWNERS file; found in the root when empty
+	Coverage        []string              // Clover, LCOV, or Go coverage files to overlay on the graph
+	Traces          []string              // Runtime traces to reconcile with the graph
+	Suppressions    string                // Suppression file; .tukey-suppressions in the root when empty
+	Notes           string                // Notes file; .tukey/notes.yml in the root when empty
+	OpenAPI         string                // OpenAPI specification to correlate with the routes
+	FeatureFlags    []string              // Feature flag accessors, e.g. "Feature::active"
+	APINamespaces   []string              // Namespaces whose public API is recorded in reports
+	Thresholds      map[string]int        // Maximum allowed value per runstatus metric
+	Severities      map[string]string     // Severity per finding: a metric or runstatus.ParseErrors
+	Plugins         []config.PluginConfig // External passes and exporters, from config only
+	WasmRules       []string              // Sandboxed rules compiled to WebAssembly, from config only
 }
 
 // parseArgs parses command line arguments
@@ -184,24 +817,302 @@ func parseArgs() (*Config, error) {
 			}
 			argv.OutputFile = args[i+1]
 			i++
+		case "-f", "--format":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--format requires a format name")
+			}
+			argv.Format = strings.ToLower(args[i+1])
+			i++
+		case "--template":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--template requires a template file")
+			}
+			argv.Template = args[i+1]
+			i++
 		case "--exclude":
 			if i+1 >= len(args) {
 				return nil, fmt.Errorf("--exclude requires a directory name")
 			}
 			argv.ExcludeDirs = append(argv.ExcludeDirs, args[i+1])
 			i++
+		case "--path-style":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--path-style requires a style (%s)", strings.Join(pathstyle.Styles, ", "))
+			}
+			argv.PathStyle = strings.ToLower(args[i+1])
+			i++
+		case "--include-dir":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--include-dir requires a directory name")
+			}
+			argv.IncludeDirs = append(argv.IncludeDirs, args[i+1])
+			i++
+		case "--wordpress":
+			argv.WordPress = true
+		case "--collapse-barrels":
+			argv.CollapseBarrels = true
+		case "--bridges":
+			argv.Bridges = true
+		case "--sign":
+			argv.Sign = true
+		case "--redact":
+			argv.Redact = true
+		case "--clones":
+			argv.Clones = true
+		case "--min-clone-tokens":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--min-clone-tokens requires a number")
+			}
+			tokens, err := strconv.Atoi(args[i+1])
+			if err != nil || tokens < 1 {
+				return nil, fmt.Errorf("--min-clone-tokens needs a positive integer, got %q", args[i+1])
+			}
+			argv.MinCloneTokens = tokens
+			i++
+		case "--literals":
+			argv.Literals = true
+		case "--min-literal-count":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--min-literal-count requires a number")
+			}
+			count, err := strconv.Atoi(args[i+1])
+			if err != nil || count < 2 {
+				return nil, fmt.Errorf("--min-literal-count needs an integer of at least 2, got %q", args[i+1])
+			}
+			argv.MinLiteralCount = count
+			i++
+		case "--accessible":
+			argv.Accessible = true
+		case "--debug":
+			argv.Debug = true
+		case "--strict-config":
+			argv.StrictConfig = true
+		case "--dry-run":
+			argv.DryRun = true
+		case "--scan-report":
+			argv.ScanReport = true
+		case "--gitignore":
+			argv.Gitignore = true
+		case "--extensionless":
+			argv.Extensionless = true
+		case "--max-file-size":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--max-file-size requires a size")
+			}
+			size, err := parseSize(args[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("--max-file-size: %v", err)
+			}
+			argv.MaxFileSize = size
+			i++
+		case "--summary-only":
+			argv.SummaryOnly = true
+		case "--max-lines-per-edge":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--max-lines-per-edge requires a number")
+			}
+			max, err := strconv.Atoi(args[i+1])
+			if err != nil || max < 0 {
+				return nil, fmt.Errorf("--max-lines-per-edge needs a non-negative integer, got %q", args[i+1])
+			}
+			argv.MaxLinesPerEdge = max
+			i++
+		case "--max-parameters":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--max-parameters requires a number")
+			}
+			max, err := strconv.Atoi(args[i+1])
+			if err != nil || max < 1 {
+				return nil, fmt.Errorf("--max-parameters needs a positive integer, got %q", args[i+1])
+			}
+			argv.MaxParameters = max
+			i++
+		case "--churn-since":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--churn-since requires a date")
+			}
+			argv.ChurnSince = args[i+1]
+			i++
+		case "--ticket-pattern":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--ticket-pattern requires a regular expression")
+			}
+			if _, err := regexp.Compile(args[i+1]); err != nil {
+				return nil, fmt.Errorf("--ticket-pattern: %v", err)
+			}
+			argv.TicketPattern = args[i+1]
+			i++
+		case "--debt-age":
+			argv.DebtAge = true
+		case "--min-doc-coverage":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--min-doc-coverage requires a percentage")
+			}
+			coverage, err := strconv.Atoi(strings.TrimSuffix(args[i+1], "%"))
+			if err != nil || coverage < 1 || coverage > 100 {
+				return nil, fmt.Errorf("--min-doc-coverage needs a percentage from 1 to 100, got %q", args[i+1])
+			}
+			argv.MinDocCoverage = coverage
+			i++
+		case "--flow-depth":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--flow-depth requires a number")
+			}
+			depth, err := strconv.Atoi(args[i+1])
+			if err != nil || depth < 1 {
+				return nil, fmt.Errorf("--flow-depth needs a positive integer, got %q", args[i+1])
+			}
+			argv.FlowDepth = depth
+			i++
+		case "--prune":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--prune requires a heuristic list")
+			}
+			heuristics, err := analyzer.ParsePrune(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			argv.Prune = append(argv.Prune, heuristics...)
+			i++
+		case "--api-namespace":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--api-namespace requires a namespace")
+			}
+			argv.APINamespaces = append(argv.APINamespaces, args[i+1])
+			i++
+		case "--codeowners":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--codeowners requires a filename")
+			}
+			argv.Codeowners = args[i+1]
+			i++
+		case "--coverage":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--coverage requires a filename")
+			}
+			argv.Coverage = append(argv.Coverage, args[i+1])
+			i++
+		case "--trace":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--trace requires a filename")
+			}
+			argv.Traces = append(argv.Traces, args[i+1])
+			i++
+		case "--suppressions":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--suppressions requires a filename")
+			}
+			argv.Suppressions = args[i+1]
+			i++
+		case "--notes":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--notes requires a filename")
+			}
+			argv.Notes = args[i+1]
+			i++
+		case "--openapi":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--openapi requires a specification file")
+			}
+			argv.OpenAPI = args[i+1]
+			i++
+		case "--feature-flag":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--feature-flag requires an accessor")
+			}
+			argv.FeatureFlags = append(argv.FeatureFlags, args[i+1])
+			i++
+		case "--status-file":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--status-file requires a filename")
+			}
+			argv.StatusFile = args[i+1]
+			i++
+		case "--checkpoint":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--checkpoint requires a filename")
+			}
+			argv.Checkpoint = args[i+1]
+			i++
+		case "--changed-only":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--changed-only requires a git ref")
+			}
+			argv.ChangedOnly = args[i+1]
+			i++
+		case "--distribute":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--distribute requires an address to listen on")
+			}
+			argv.Distribute = args[i+1]
+			i++
+		case "--sample":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--sample requires a percentage")
+			}
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(args[i+1], "%"), 64)
+			if err != nil || percent <= 0 || percent > 100 {
+				return nil, fmt.Errorf("--sample needs a percentage above 0 and up to 100, got %q", args[i+1])
+			}
+			argv.Sample = percent
+			i++
+		case "--threshold":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--threshold requires metric=value")
+			}
+			name, value, err := parseThreshold(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			if argv.Thresholds == nil {
+				argv.Thresholds = make(map[string]int)
+			}
+			argv.Thresholds[name] = value
+			i++
+		case "--severity":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--severity requires finding=level")
+			}
+			name, level, ok := strings.Cut(args[i+1], "=")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("--severity expects finding=level, got %q", args[i+1])
+			}
+			if argv.Severities == nil {
+				argv.Severities = make(map[string]string)
+			}
+			argv.Severities[name] = strings.ToLower(level)
+			i++
+		case "--framework":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--framework requires a framework name")
+			}
+			argv.Framework = strings.ToLower(args[i+1])
+			i++
 		case "-l", "--language":
 			if i+1 >= len(args) {
 				return nil, fmt.Errorf("--language requires a language name")
 			}
 			argv.Language = strings.ToLower(args[i+1])
 			i++
+		case "--target-version":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--target-version requires a version")
+			}
+			argv.TargetVersion = args[i+1]
+			i++
 		default:
 			if strings.HasPrefix(arg, "-") {
 				return nil, fmt.Errorf("unknown flag: %s", arg)
 			}
-			// Assume it's the root path
+			// Assume it's the root path. Accept Go's "./..." pattern for the tree under a
+			// directory, which is what the root path means already.
 			argv.RootPath = arg
+			if arg == "..." || strings.HasSuffix(arg, "/...") {
+				argv.RootPath = strings.TrimSuffix(strings.TrimSuffix(arg, "..."), "/")
+				if argv.RootPath == "" {
+					argv.RootPath = "."
+				}
+			}
 		}
 		i++
 	}
@@ -228,14 +1139,216 @@ func showHelp() {
 
 USAGE:
     Tukey [FLAGS] <directory>
+    Tukey self-update [--check]
+    Tukey verify <report.json>
+    Tukey diff [--json <file>] <old.json> <new.json>
+    Tukey bisect --metric <name> --good <rev> [--bad <rev>] [--max <n>] [<directory>]
+    Tukey bench [--files <n>] [--language <lang>] [--runs <n>] [--save] [<directory>]
+    Tukey work --connect <host:port> [--wait <duration>] [<directory>]
+    Tukey semver --against <rev> [--json <file>] [<directory>]
+    Tukey serve [--addr <host:port>] [--schedule <cron>] [--history <dir>] [<directory>]
+    Tukey serve --projects <file> [--addr <host:port>] [--schedule <cron>]
+    Tukey orphans [--interactive] [--notes <file>] [<directory>]
+    Tukey similar <symbol> [--language <lang>] [--limit <n>] [--min <score>] [<directory>]
+    Tukey mv-preview <old/path> <new/path> [--language <lang>] [--json <file>] [<directory>]
+    Tukey layout [--json <file>] [<directory>]
+    Tukey batch [--output <dir>] <projects.yml>
+    Tukey portfolio [--previous <file|dir>] [--format markdown|html] [-o <file>] <file|dir>...
+    Tukey anonymize -o <dir> [--language <lang>] [<directory>]
 
 FLAGS:
     -v, --verbose           Show detailed output including function usage report
-    -o, --output <file>     Export results to JSON file
+    -o, --output <file>     Export results to a file (a directory for --format source)
+    -f, --format <name>     Export format: json, template, symbols (an editor symbol map),
+                            heatmap (directory size, complexity, and churn as JSON), flows
+                            (namespace-to-namespace chord and sankey data), or source
+                            (an HTML source browser) (default: json)
+    --template <file>       Render the export through a Go text/template (implies --format template)
+    --path-style <style>    Separators in exported file paths: native (default) or posix,
+                            so reports from Windows match those from Linux and macOS
     --exclude <dir>         Exclude directory from analysis (can be used multiple times)
+    --include-dir <dir>     Scan a directory the language excludes by default, such as
+                            vendor or dist (can be used multiple times)
     -h, --help              Show this help message
-    -l, --language    	    Specify the programming language to use
-    --version               Show version information
+    -l, --language    	    Specify the programming language to use (php, javascript,
+                            typescript, python, go, java, ruby, csharp,
+                            kotlin, rust, swift, cpp, scala, dart,
+                            elixir, lua, perl, sql)
+    --target-version <v>    The language version the code targets (PHP, e.g. 7.4; JavaScript,
+                            an ecmaVersion such as 2020 or 11): report the constructs newer
+                            than it and read the syntax as that version does (also php.version
+                            or ecmaVersion in config)
+    --wordpress             Link WordPress hooks (add_action/add_filter/do_action) to their callbacks
+    --framework <name>      Apply a framework preset (drupal, wordpress, codeigniter)
+    --collapse-barrels      Leave JS barrel (re-export only) files out of the graph
+    --bridges               Also parse the other supported languages and link references
+                            across them: scripts loaded with mix()/@vite, and fetch/axios
+                            requests to the PHP routes serving them
+    --sign                  Embed provenance and a checksum in the exported report
+                            (HMAC-signed when TUKEY_SIGNING_KEY is set)
+    --redact                Hash names and drop paths, comments, and literals in the
+                            exported report, keeping its graph and metrics, to share it
+                            outside the team (set TUKEY_REDACT_SALT to compare reports)
+    --accessible            Plain screen-reader friendly output: no emoji, separators,
+                            or animated progress (also TUKEY_ACCESSIBLE=1)
+    --dry-run               Scan and resolve configuration only: print which files each
+                            parser would analyze (all of them with -v), the effective
+                            excludes, the analyses and export that would run, and the work
+                            ahead in files and MB, without parsing anything
+    --scan-report           Print the files matched per extension, the files no parser reads,
+                            and the directories and files skipped, with the reason (default
+                            or configured exclude, .gitignore, size limit)
+    --gitignore             Skip what the .gitignore files in the tree ignore
+    --extensionless         Also analyze extensionless scripts recognized by their content,
+                            such as bin/console starting with #!/usr/bin/env php
+    --max-file-size <size>  Skip files larger than size (e.g. 512KB, 2MB; bytes without a unit)
+    --debug                 Include the stack trace when a parser panics on a file (also
+                            TUKEY_DEBUG=1); the file is reported as a parse error either way
+    --strict-config         Fail when a config file doesn't load, has a setting Tukey doesn't
+                            know, or names an unsupported language, instead of warning and
+                            using the defaults (also strictConfig: true in config)
+    --threshold <m>=<max>   Exit with code 1 when a metric exceeds max (can be used multiple
+                            times; metrics: orphans, maxComplexity, ambiguousNames, cycles,
+                            edges, nodes, moduleBoundaries, includeCycles, packageViolations,
+                            longParameterLists, clones, repeatedLiterals, debtMarkers,
+                            undocumentedAPI, unimplementedEndpoints, undocumentedEndpoints,
+                            featureFlags, newerSyntax, undeclaredExtensions)
+    --severity <f>=<level>  Set a finding's severity: error (the default) fails the run,
+                            warning and info are only reported (can be used multiple times;
+                            findings: the threshold metrics, and parseErrors)
+    --summary-only          Only compute aggregate metrics: skips edge line numbers, usage
+                            retention, and detailed reports (fast CI smoke checks)
+    --max-lines-per-edge <n>
+                            Keep at most n sampled line numbers per edge (counts stay exact)
+    --max-parameters <n>    Report functions and methods with more than n parameters, with
+                            their call sites (default: 5)
+    --clones                Detect duplicated blocks of code across and within files
+    --min-clone-tokens <n>  Smallest duplicated block to report, in tokens (default: 50)
+    --literals              Inventory string literals and numbers repeated across the code
+    --min-literal-count <n> Fewest occurrences of a value to report (default: 3)
+    --ticket-pattern <re>   Regular expression for the ticket a TODO/FIXME/HACK comment
+                            references (e.g. '[A-Z]+-[0-9]+'), counted in the debt report
+    --debt-age              Date TODO/FIXME/HACK comments with git blame and group the
+                            technical debt report by age
+    --min-doc-coverage <n>  Exit with code 1 when less than n percent of the public classes,
+                            functions, and methods have a docblock
+    --churn-since <date>    How far back git churn goes for the heatmap and source formats
+                            (default: "90 days ago"; any date git log --since accepts)
+    --flow-depth <n>        Group the flows format by the first n namespace segments or
+                            directories (default: 2)
+    --prune <list>          Shrink the exported graph: leaves (drop nodes with one dependent
+                            and no dependencies), accessors (fold getters and setters into
+                            their class), overloads (merge same-named definitions), or all;
+                            comma-separated, recorded under "pruned" in JSON reports
+    --codeowners <file>     Attribute nodes to owners from this CODEOWNERS file (default:
+                            .github/CODEOWNERS, CODEOWNERS, docs/ or .gitlab/CODEOWNERS)
+    --coverage <file>       Overlay test coverage (PHPUnit Clover XML, LCOV, or a Go cover
+                            profile) on the graph: each node's coverage, the heavily
+                            depended-on code no test runs, and code only used by other
+                            code that never ran (can be used multiple times)
+    --trace <file>          Reconcile a runtime trace (Xdebug function trace, Tideways or
+                            XHProf profile, or OpenTelemetry OTLP JSON) with the graph:
+                            edges confirmed at runtime, and dynamic calls the static
+                            analysis missed (can be used multiple times)
+    --suppressions <file>   Suppress findings on the nodes matching this file's patterns,
+                            one "<findings> <pattern> [-- reason]" per line (default:
+                            .tukey-suppressions in the root)
+    --notes <file>          Show this YAML file's notes, such as why a cycle is intentional,
+                            with the nodes and findings they match (default:
+                            .tukey/notes.yml in the root)
+    --openapi <file>        Match the operations of an OpenAPI (or Swagger) YAML/JSON spec to
+                            the PHP routes implementing them, reporting each endpoint's
+                            dependency footprint, unimplemented operations, and
+                            undocumented routes
+    --feature-flag <call>   Report the flags checked through a feature flag accessor (e.g.
+                            Feature::active or flags.isEnabled), where they're checked, and
+                            the code gated behind them (can be used multiple times)
+    --api-namespace <ns>    Record the public API of a namespace (and those beneath it) in
+                            JSON reports, for tukey diff (can be used multiple times)
+    --status-file <file>    Write counts, timings, findings, and the exit code as JSON
+    --checkpoint <file>     Save parsed files to this file every 500 files; a run interrupted
+                            or crashed resumes from it, parsing only new or changed files.
+                            Removed once the run finishes
+    --changed-only <ref>    Report findings only for files changed since a git ref (e.g.
+                            origin/main). With --checkpoint, unchanged files are loaded
+                            from it instead of parsed, and it is kept as the cache
+    --sample <percent>      Analyze a deterministic sample of the files (e.g. 10%%) and
+                            estimate lines, elements, references, and debt markers for the
+                            whole tree with 95%% confidence intervals; thresholds aren't
+                            checked (a quick preview before a full run)
+    --distribute <addr>     Parse on workers instead of locally: listen on addr (e.g. :7879)
+                            for tukey work --connect <host:port>, shard the files across
+                            them, and merge what they parse into one graph
+    --version               Show version information (including commit and build date)
+
+COMMANDS:
+    self-update             Replace this binary with the latest GitHub release
+    self-update --check     Only report whether a newer release is available
+    verify <report.json>    Check a signed report's checksum and signature
+    diff <old> <new>        Compare two JSON reports, reporting renamed and moved nodes as
+                            such (--rename-threshold <0-1> sets the edge similarity needed),
+                            and public API changes (--changelog <file> writes them as Markdown)
+    bisect                  Find the first commit between --good and --bad (default HEAD)
+                            where a metric exceeds --max (default: its value at --good);
+                            analysis flags go after --
+    bench                   Time the scan, parse, and graph phases over a generated corpus
+                            (--files, default 1000; --functions per file, default 10;
+                            --language php or javascript) or a directory, median of --runs
+                            (default 3); --save records benchmarks/baseline.json (or
+                            --baseline <file>), later runs fail when a phase is more than
+                            --tolerance percent (default 20) slower
+    work                    Parse files for a coordinator started with --distribute, from
+                            this machine's checkout of the tree (default: the current
+                            directory); retries --connect for --wait (default 30s).
+                            Set TUKEY_WORK_TOKEN on both ends to require a shared secret
+                            (required unless --distribute listens on loopback)
+    semver                  Classify public API changes since --against <rev> (e.g. v2.3.0)
+                            as major, minor, or patch and suggest the next version; needs
+                            apiNamespaces in config or --api-namespace after --
+    serve                   Analyze on a cron-like --schedule (or @every 1h, @daily, ...),
+                            keep snapshots in --history (default <directory>/.tukey/history,
+                            --keep n to prune), and serve them and metric trends over HTTP
+                            on --addr (default localhost:7878); --projects <file> hosts every
+                            project in a YAML/JSON list under /api/projects/{project};
+                            --auth <file> (or TUKEY_SERVE_TOKEN) requires tokens or basic
+                            auth, --tls-cert/--tls-key serve HTTPS, --client-ca requires
+                            client certificates
+    orphans                 List the orphans; --interactive asks about each one and marks
+                            those you say are expected (entrypoint, reflection, or keep) in
+                            the notes file (--notes, default .tukey/notes.yml), which later
+                            runs leave out of the orphans; analysis flags go after --
+    similar                 List the elements most like <symbol> (a qualified or short
+                            name): same kind, shared dependencies, parameters, and naming,
+                            scored 0-100%%; --limit (default 10), --min (default 0.5)
+    mv-preview              List the imports, use statements, and references to update if
+                            <old/path> moved to <new/path>, following the namespace when it
+                            matches the directories; nothing is changed
+    layout                  Check PHP namespaces against composer.json's PSR-4 mappings (or
+                            those the tree follows) and plan a fix for each mismatch: a
+                            move, or a namespace change with its use statements; --json
+                            saves the plan for codemod tools
+    batch                   Analyze every project in a YAML/JSON list (name, root, flags),
+                            each with its own config, saving report.json and
+                            run-status.json per project under --output (default
+                            tukey-batch next to the list) with a combined portfolio.json;
+                            analysis flags for every project go after --
+    portfolio               Compare the projects of batch runs (portfolio.json or its
+                            directory) and single runs (run-status.json) side by side:
+                            size, coupling, cycles, complexity, and a 0-100
+                            maintainability score, with trend arrows against --previous;
+                            Markdown to stdout, or --format html (or -o <file>.html)
+    anonymize               Write a PHP codebase shaped like the analyzed one (the same
+                            tree, declarations, and references, under generated names, with
+                            empty bodies) to -o <dir>, to reproduce performance problems
+                            without sharing the source; the same tree gives the same output
+
+EXIT CODES:
+    0    Analysis completed and no threshold was exceeded
+    1    A --threshold with error severity was exceeded
+    2    Some files couldn't be parsed (results are incomplete), unless
+         parseErrors has a lower --severity
+    3    Usage error: invalid flags, config, language, or format
+    4    Internal error while scanning, analyzing, or exporting
 
 CONFIGURATION:
     Tukey will automatically load settings from a config file in the project root
@@ -245,15 +1358,72 @@ CONFIGURATION:
         .tukey.yaml
         .tukey.json
 
-    These files let you define defaults such as language, excludeDirs, verbose,
-    and outputFile so you don’t need to pass flags every run.
+    These files let you define defaults such as language, excludeDirs,
+    includeDirs, verbose, outputFile, format, template, pathStyle, wordpress,
+    framework, collapseBarrels, bridges, sign, redact, accessible, summaryOnly,
+    maxLinesPerEdge, maxParameters, clones, minCloneTokens, literals,
+    minLiteralCount, churnSince, ticketPattern, debtAge, minDocCoverage,
+    flowDepth, prune, groups, codeowners, coverage, traces, suppressions, notes,
+    openapi, featureFlags, apiNamespaces, statusFile, checkpoint, changedOnly, gitignore,
+    extensionless, maxFileSize, php.version, ecmaVersion, thresholds, severities,
+    plugins, and wasmRules
+    so you don’t need to pass flags every run. List shared configs, as files or
+    URLs, under extends to inherit their settings; pin a URL with a sha256.
 
 EXAMPLES:
     tukey ./my-project
     tukey -v ./my-project -o analysis.json
     tukey --exclude vendor --exclude tests ./my-project
+    tukey --threshold orphans=20 --status-file run-status.json ./my-project
+
+`, displayVersion())
+}
+
+// say prints a status message, rewritten without emoji in accessible mode
+func say(format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	if accessible {
+		text = output.Plain(text)
+	}
+	fmt.Print(text)
+}
+
+// sayErr is say for stderr
+func sayErr(format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	if accessible {
+		text = output.Plain(text)
+	}
+	fmt.Fprint(os.Stderr, text)
+}
+
+// parseFailures are the files parsers reported they couldn't parse, held until the
+// progress bars are done so printing them doesn't break the redraw
+var parseFailures struct {
+	sync.Mutex
+	errs []*parser.FileError
+}
+
+// printParseErrors prints the files that couldn't be parsed since it was last called
+func printParseErrors() {
+	parseFailures.Lock()
+	defer parseFailures.Unlock()
+	errs := parseFailures.errs
+	sort.Slice(errs, func(i, j int) bool { return errs[i].File.RelativePath < errs[j].File.RelativePath })
+	for _, failure := range errs {
+		sayErr("⚠️  Error parsing %s: %v\n", failure.File.RelativePath, failure.Err)
+	}
+	parseFailures.errs = nil
+}
+
+// displayVersion returns the version without the "v" that release tags carry
+func displayVersion() string {
+	return strings.TrimPrefix(version, "v")
+}
 
-`, version)
+// versionString describes this build, e.g. "Tukey v1.2.0 (commit abc1234, built 2025-01-02T03:04:05Z)"
+func versionString() string {
+	return fmt.Sprintf("Tukey v%s (commit %s, built %s)", displayVersion(), commit, date)
 }
 
 // getTotalSize calculates total size of files
@@ -274,19 +1444,630 @@ func getTotalElements(parsedFiles []*models.ParsedFile) int {
 	return total
 }
 
+// configProblems lists what's wrong with the loaded config files: a file that failed to
+// load, keys that aren't settings, and an unsupported language, which --language would
+// otherwise hide. Each names the file and key it's in.
+func configProblems(fileCfg *config.FileConfig, err error) []error {
+	var problems []error
+	if err != nil {
+		problems = append(problems, err)
+	}
+	problems = append(problems, fileCfg.Warnings...)
+	if fileCfg.Language != "" {
+		if _, ok := parser.Get(fileCfg.Language); !ok {
+			problems = append(problems, &config.Error{
+				File: fileCfg.Origins["language"],
+				Key:  "language",
+				Err:  fmt.Errorf("unsupported language %q (supported: %v)", fileCfg.Language, parser.SupportedLanguages()),
+			})
+		}
+	}
+	return problems
+}
+
 // mergeConfigs merges CLI args with file config, giving CLI priority.
 func mergeConfigs(argv *Config, fileCfg *config.FileConfig) *Config {
 	if argv.Language == "" && fileCfg.Language != "" {
 		argv.Language = fileCfg.Language
 	}
+	if argv.TargetVersion == "" {
+		argv.TargetVersion = fileCfg.TargetVersion(argv.Language)
+	}
 	if len(fileCfg.ExcludeDirs) > 0 {
 		argv.ExcludeDirs = append(argv.ExcludeDirs, fileCfg.ExcludeDirs...)
 	}
+	if len(fileCfg.IncludeDirs) > 0 {
+		argv.IncludeDirs = append(argv.IncludeDirs, fileCfg.IncludeDirs...)
+	}
 	if argv.OutputFile == "" && fileCfg.OutputFile != "" {
 		argv.OutputFile = fileCfg.OutputFile
 	}
+	if argv.Format == "" && fileCfg.Format != "" {
+		argv.Format = strings.ToLower(fileCfg.Format)
+	}
+	if argv.PathStyle == "" && fileCfg.PathStyle != "" {
+		argv.PathStyle = strings.ToLower(fileCfg.PathStyle)
+	}
+	if argv.Template == "" && fileCfg.Template != "" {
+		argv.Template = fileCfg.Template
+	}
+	if argv.Format == "" && argv.Template != "" {
+		argv.Format = "template"
+	}
+	if argv.Format == "" {
+		argv.Format = "json"
+	}
 	if !argv.Verbose && fileCfg.Verbose {
 		argv.Verbose = true
 	}
+	if !argv.WordPress && fileCfg.WordPress {
+		argv.WordPress = true
+	}
+	if !argv.CollapseBarrels && fileCfg.CollapseBarrels {
+		argv.CollapseBarrels = true
+	}
+	if !argv.Bridges && fileCfg.Bridges {
+		argv.Bridges = true
+	}
+	if !argv.Sign && fileCfg.Sign {
+		argv.Sign = true
+	}
+	if !argv.Redact && fileCfg.Redact {
+		argv.Redact = true
+	}
+	if !argv.Accessible && fileCfg.Accessible {
+		argv.Accessible = true
+	}
+	if !argv.StrictConfig && fileCfg.StrictConfig {
+		argv.StrictConfig = true
+	}
+	if !argv.SummaryOnly && fileCfg.SummaryOnly {
+		argv.SummaryOnly = true
+	}
+	if !argv.Clones && fileCfg.Clones {
+		argv.Clones = true
+	}
+	if argv.MinCloneTokens == 0 && fileCfg.MinCloneTokens > 0 {
+		argv.MinCloneTokens = fileCfg.MinCloneTokens
+	}
+	if !argv.Literals && fileCfg.Literals {
+		argv.Literals = true
+	}
+	if argv.MinLiteralCount == 0 && fileCfg.MinLiteralCount > 0 {
+		argv.MinLiteralCount = fileCfg.MinLiteralCount
+	}
+	if argv.MaxLinesPerEdge == 0 && fileCfg.MaxLinesPerEdge > 0 {
+		argv.MaxLinesPerEdge = fileCfg.MaxLinesPerEdge
+	}
+	if argv.MaxParameters == 0 && fileCfg.MaxParameters > 0 {
+		argv.MaxParameters = fileCfg.MaxParameters
+	}
+	if argv.ChurnSince == "" && fileCfg.ChurnSince != "" {
+		argv.ChurnSince = fileCfg.ChurnSince
+	}
+	if argv.TicketPattern == "" && fileCfg.TicketPattern != "" {
+		argv.TicketPattern = fileCfg.TicketPattern
+	}
+	if !argv.DebtAge && fileCfg.DebtAge {
+		argv.DebtAge = true
+	}
+	if argv.MinDocCoverage == 0 && fileCfg.MinDocCoverage > 0 {
+		argv.MinDocCoverage = fileCfg.MinDocCoverage
+	}
+	if argv.FlowDepth == 0 && fileCfg.FlowDepth > 0 {
+		argv.FlowDepth = fileCfg.FlowDepth
+	}
+	if len(argv.APINamespaces) == 0 && len(fileCfg.APINamespaces) > 0 {
+		argv.APINamespaces = fileCfg.APINamespaces
+	}
+	if argv.Codeowners == "" && fileCfg.Codeowners != "" {
+		argv.Codeowners = fileCfg.Codeowners
+	}
+	if len(argv.Coverage) == 0 && len(fileCfg.Coverage) > 0 {
+		argv.Coverage = fileCfg.Coverage
+	}
+	if len(argv.Traces) == 0 && len(fileCfg.Traces) > 0 {
+		argv.Traces = fileCfg.Traces
+	}
+	if argv.Suppressions == "" && fileCfg.Suppressions != "" {
+		argv.Suppressions = fileCfg.Suppressions
+	}
+	if argv.Notes == "" && fileCfg.Notes != "" {
+		argv.Notes = fileCfg.Notes
+	}
+	if len(fileCfg.Groups) > 0 {
+		argv.Groups = fileCfg.Groups
+	}
+	if len(fileCfg.Plugins) > 0 {
+		argv.Plugins = fileCfg.Plugins
+	}
+	if len(fileCfg.WasmRules) > 0 {
+		argv.WasmRules = fileCfg.WasmRules
+	}
+	if len(argv.Prune) == 0 && len(fileCfg.Prune) > 0 {
+		argv.Prune = fileCfg.Prune
+	}
+	if argv.OpenAPI == "" && fileCfg.OpenAPI != "" {
+		argv.OpenAPI = fileCfg.OpenAPI
+	}
+	if len(argv.FeatureFlags) == 0 && len(fileCfg.FeatureFlags) > 0 {
+		argv.FeatureFlags = fileCfg.FeatureFlags
+	}
+	if argv.StatusFile == "" && fileCfg.StatusFile != "" {
+		argv.StatusFile = fileCfg.StatusFile
+	}
+	if !argv.Gitignore && fileCfg.Gitignore {
+		argv.Gitignore = true
+	}
+	if !argv.Extensionless && fileCfg.Extensionless {
+		argv.Extensionless = true
+	}
+	if argv.MaxFileSize == 0 && fileCfg.MaxFileSize != "" {
+		if size, err := parseSize(fileCfg.MaxFileSize); err == nil {
+			argv.MaxFileSize = size
+		} else {
+			sayErr("⚠️ Ignoring maxFileSize in config: %v\n", err)
+		}
+	}
+	if argv.Checkpoint == "" && fileCfg.Checkpoint != "" {
+		argv.Checkpoint = fileCfg.Checkpoint
+	}
+	if argv.ChangedOnly == "" && fileCfg.ChangedOnly != "" {
+		argv.ChangedOnly = fileCfg.ChangedOnly
+	}
+	for name, value := range fileCfg.Thresholds {
+		if _, set := argv.Thresholds[name]; !set {
+			if argv.Thresholds == nil {
+				argv.Thresholds = make(map[string]int)
+			}
+			argv.Thresholds[name] = value
+		}
+	}
+	for name, level := range fileCfg.Severities {
+		if _, set := argv.Severities[name]; !set {
+			if argv.Severities == nil {
+				argv.Severities = make(map[string]string)
+			}
+			argv.Severities[name] = strings.ToLower(level)
+		}
+	}
+	if argv.Framework == "" && fileCfg.Framework != "" {
+		argv.Framework = strings.ToLower(fileCfg.Framework)
+	}
+	return argv
+}
+
+// loadCodeowners reads --codeowners, or the CODEOWNERS file in the project root. It
+// returns nil when there is none.
+func loadCodeowners(argv *Config) (*codeowners.File, error) {
+	if argv.Codeowners != "" {
+		return codeowners.Load(argv.Codeowners)
+	}
+	return codeowners.Detect(argv.RootPath)
+}
+
+// loadSuppressions reads --suppressions, or the .tukey-suppressions file in the project
+// root. It returns nil when there is none.
+func loadSuppressions(argv *Config) ([]*models.Suppression, error) {
+	if argv.Suppressions != "" {
+		return analyzer.LoadSuppressions(argv.Suppressions)
+	}
+	path := filepath.Join(argv.RootPath, ".tukey-suppressions")
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	return analyzer.LoadSuppressions(path)
+}
+
+// loadNotes reads --notes, or the .tukey/notes.yml file in the project root. It returns
+// nil when there is none.
+func loadNotes(argv *Config) ([]*models.NoteEntry, error) {
+	if argv.Notes != "" {
+		return analyzer.LoadNotes(argv.Notes)
+	}
+	path := filepath.Join(argv.RootPath, ".tukey", "notes.yml")
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	return analyzer.LoadNotes(path)
+}
+
+// companionParsers returns the parsers of every supported language but the analyzed one,
+// by name
+func companionParsers(language string) []parser.LanguageParser {
+	languages := parser.SupportedLanguages()
+	sort.Strings(languages)
+	var parsers []parser.LanguageParser
+	for _, name := range languages {
+		if name != language {
+			companion, _ := parser.Get(name)
+			parsers = append(parsers, companion)
+		}
+	}
+	return parsers
+}
+
+// effectiveConfig lists the settings a run used, after the config file, preset, and
+// flags were merged, keyed like the config file and leaving out those left unset
+func effectiveConfig(argv *Config) map[string]interface{} {
+	data, _ := json.Marshal(argv)
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+
+	settings := make(map[string]interface{})
+	for name, value := range fields {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case bool:
+			if !v {
+				continue
+			}
+		case string:
+			if v == "" {
+				continue
+			}
+		case float64:
+			if v == 0 {
+				continue
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				continue
+			}
+		case map[string]interface{}:
+			if len(v) == 0 {
+				continue
+			}
+		}
+		settings[strings.ToLower(name[:1])+name[1:]] = value
+	}
+	return settings
+}
+
+// filesByParser splits files between parsers: the first parser takes its extensions (the
+// analyzed language's, including a framework preset's), the others take the rest of theirs
+func filesByParser(files []models.FileInfo, parsers []parser.LanguageParser, extensions []string) [][]models.FileInfo {
+	owner := make(map[string]int)
+	for _, ext := range extensions {
+		owner[strings.ToLower(ext)] = 0
+	}
+	for i, lp := range parsers[1:] {
+		for _, ext := range lp.FileExtensions() {
+			if _, claimed := owner[strings.ToLower(ext)]; !claimed {
+				owner[strings.ToLower(ext)] = i + 1
+			}
+		}
+	}
+
+	batches := make([][]models.FileInfo, len(parsers))
+	for _, file := range files {
+		if file.Language != "" {
+			// Recognized by its content; it goes to the parser that sniffed it
+			for i, lp := range parsers {
+				if lp.Language() == file.Language {
+					batches[i] = append(batches[i], file)
+					break
+				}
+			}
+			continue
+		}
+		if i, ok := owner[strings.ToLower(filepath.Ext(file.Path))]; ok {
+			batches[i] = append(batches[i], file)
+		}
+	}
+	return batches
+}
+
+// printPlan describes what a run with argv would do, for --dry-run
+func printPlan(argv *Config, parsers []parser.LanguageParser, batches [][]models.FileInfo, excludes map[string]bool) {
+	say("\n📋 Dry run: nothing is parsed or exported\n")
+	say("   Root: %s\n", argv.RootPath)
+	if argv.Framework != "" {
+		say("   Framework preset: %s\n", argv.Framework)
+	}
+
+	var dirs []string
+	for dir, excluded := range excludes {
+		if excluded {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	say("   Excluded directories: %s\n", strings.Join(dirs, ", "))
+
+	total, size := 0, int64(0)
+	for i, batch := range batches {
+		batchSize := getTotalSize(batch)
+		total += len(batch)
+		size += batchSize
+		say("   • %s: %d files (%.2f MB), extensions %s\n",
+			parsers[i].Language(), len(batch), float64(batchSize)/(1024*1024), strings.Join(parsers[i].FileExtensions(), " "))
+		if argv.Verbose {
+			for _, file := range batch {
+				say("      %s\n", file.RelativePath)
+			}
+		}
+	}
+
+	analyses := []string{"dependency graph", "technical debt", "documentation coverage", "database tables"}
+	if argv.WordPress {
+		analyses = append(analyses, "WordPress hooks")
+	}
+	if argv.Bridges {
+		analyses = append(analyses, "cross-language bridges")
+	}
+	if _, gated := argv.Thresholds["clones"]; argv.Clones || gated {
+		minTokens := argv.MinCloneTokens
+		if minTokens <= 0 {
+			minTokens = clones.DefaultMinTokens
+		}
+		analyses = append(analyses, fmt.Sprintf("clones (min %d tokens)", minTokens))
+	}
+	if _, gated := argv.Thresholds["repeatedLiterals"]; argv.Literals || gated {
+		minCount := argv.MinLiteralCount
+		if minCount <= 0 {
+			minCount = literals.DefaultMinCount
+		}
+		analyses = append(analyses, fmt.Sprintf("repeated literals (min %d)", minCount))
+	}
+	if argv.DebtAge {
+		analyses = append(analyses, "debt age (git blame)")
+	}
+	if argv.OpenAPI != "" {
+		analyses = append(analyses, "OpenAPI correlation with "+argv.OpenAPI)
+	}
+	if len(argv.Coverage) > 0 {
+		analyses = append(analyses, "coverage from "+strings.Join(argv.Coverage, ", "))
+	}
+	if len(argv.Traces) > 0 {
+		analyses = append(analyses, "runtime traces from "+strings.Join(argv.Traces, ", "))
+	}
+	if len(argv.FeatureFlags) > 0 {
+		analyses = append(analyses, "feature flags via "+strings.Join(argv.FeatureFlags, ", "))
+	}
+	if argv.TargetVersion != "" {
+		analyses = append(analyses, fmt.Sprintf("syntax newer than %s %s", argv.Language, argv.TargetVersion))
+	}
+	if len(argv.APINamespaces) > 0 {
+		analyses = append(analyses, "public API of "+strings.Join(argv.APINamespaces, ", "))
+	}
+	say("   Analyses: %s\n", strings.Join(analyses, ", "))
+
+	if argv.OutputFile != "" {
+		export := argv.Format
+		if argv.Redact {
+			export += ", redacted,"
+		}
+		say("   Export: %s to %s\n", export, argv.OutputFile)
+	}
+	if len(argv.Thresholds) > 0 {
+		names := make([]string, 0, len(argv.Thresholds))
+		for name, max := range argv.Thresholds {
+			names = append(names, fmt.Sprintf("%s<=%d", name, max))
+		}
+		sort.Strings(names)
+		say("   Thresholds: %s\n", strings.Join(names, ", "))
+	}
+	if len(argv.Severities) > 0 {
+		names := make([]string, 0, len(argv.Severities))
+		for name, level := range argv.Severities {
+			names = append(names, name+"="+level)
+		}
+		sort.Strings(names)
+		say("   Severities: %s\n", strings.Join(names, ", "))
+	}
+	say("   Work: %d files, %.2f MB to parse\n", total, float64(size)/(1024*1024))
+}
+
+// parseDistributed shards the files across `tukey work` workers connecting to
+// argv.Distribute and returns what they parsed
+func parseDistributed(argv *Config, batches [][]models.FileInfo, parsers []parser.LanguageParser) ([]*models.ParsedFile, error) {
+	coordinator, err := distribute.Listen(argv.Distribute, argv.RootPath, displayVersion())
+	if err != nil {
+		return nil, err
+	}
+	defer coordinator.Close()
+
+	var shards []distribute.Shard
+	total := 0
+	for i, batch := range batches {
+		shards = append(shards, distribute.Shards(argv.RootPath, parsers[i].Language(), batch, len(shards))...)
+		total += len(batch)
+	}
+	say("🌐 Waiting for workers on %s (tukey work --connect <host:port>)\n", coordinator.Addr())
+	return coordinator.Run(shards, progress.NewProgressBar(total, fmt.Sprintf("Parsing %d shards on workers", len(shards)))), nil
+}
+
+// parseLanguages parses each language's files at the same time, each language with its
+// own bar of a multi-bar, and returns the files in the order of the parsers
+func parseLanguages(batches [][]models.FileInfo, parsers []parser.LanguageParser) ([]*models.ParsedFile, error) {
+	bars := progress.NewMultiBar()
+	results := make([][]*models.ParsedFile, len(batches))
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		if len(batch) == 0 {
+			continue
+		}
+		bar := bars.Add(len(batch), fmt.Sprintf("Parsing %s files", parsers[i].Language()))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = parsers[i].ProcessFiles(batch, bar)
+		}()
+	}
+	wg.Wait()
+
+	var parsed []*models.ParsedFile
+	for i, batch := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		parsed = append(parsed, batch...)
+	}
+	return parsed, nil
+}
+
+// gitCache is what git says about a --changed-only checkpoint: which files it can
+// still serve, and which it can keep for the next run
+type gitCache struct {
+	stale   map[string]bool // Changed since the commit the checkpoint was saved at; nil when git can't tell
+	dirty   map[string]bool // Changed since HEAD, so not saved for the next run
+	resaved bool            // HEAD moved on, so the checkpoint restarted at it and its files are saved again
+}
+
+// openGitCache compares the commit saved was written at with the one checked out in
+// root, restarting saved at the latter (header.Commit) when they differ. When the saved
+// commit is unknown to git, as after a shallow clone, modification times decide instead.
+func openGitCache(root string, files []models.FileInfo, saved *checkpoint.Checkpoint, header checkpoint.Header) (*gitCache, error) {
+	dirty, err := churn.Changed(root, header.Commit)
+	if err != nil {
+		return nil, err
+	}
+	cache := &gitCache{dirty: changedPaths(files, dirty)}
+	if savedAt := saved.Commit(); savedAt == header.Commit {
+		cache.stale = cache.dirty
+	} else if savedAt != "" {
+		if stale, err := churn.Changed(root, savedAt); err == nil {
+			cache.stale = changedPaths(files, stale)
+		}
+	}
+	if saved.Commit() != header.Commit {
+		if err := saved.Restart(header); err != nil {
+			return nil, err
+		}
+		cache.resaved = true
+	}
+	return cache, nil
+}
+
+// parseWithCheckpoint parses files BatchSize at a time, saving each batch to the
+// checkpoint, and takes the files the checkpoint already holds unchanged from it. With
+// --changed-only, git decides what is unchanged instead of the modification time: files
+// changed since the checkpoint's commit are parsed again and saved for the commit
+// checked out now, and files with uncommitted changes are parsed without being saved.
+func parseWithCheckpoint(lp parser.LanguageParser, files []models.FileInfo, label string, saved *checkpoint.Checkpoint, cache *gitCache) ([]*models.ParsedFile, error) {
+	var parsed []*models.ParsedFile
+	var pending, fresh []models.FileInfo
+	for _, file := range files {
+		var restored *models.ParsedFile
+		ok := false
+		switch {
+		case cache != nil && cache.dirty[file.Path]:
+			fresh = append(fresh, file)
+			continue
+		case cache != nil && cache.stale != nil:
+			if !cache.stale[file.Path] {
+				restored, ok = saved.Cached(file.Path)
+			}
+		default:
+			restored, ok = saved.Lookup(file)
+		}
+		if ok {
+			parsed = append(parsed, restored)
+		} else {
+			pending = append(pending, file)
+		}
+	}
+	if cache != nil && cache.resaved && len(parsed) > 0 {
+		if err := saved.Append(parsed); err != nil {
+			return nil, err
+		}
+	}
+	if len(fresh) > 0 {
+		batch, err := lp.ProcessFiles(fresh, progress.NewProgressBar(len(fresh), label+" (changed)"))
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, batch...)
+	}
+
+	for start := 0; start < len(pending); start += checkpoint.BatchSize {
+		end := min(start+checkpoint.BatchSize, len(pending))
+		description := fmt.Sprintf("%s (%d-%d of %d)", label, start+1, end, len(pending))
+		batch, err := lp.ProcessFiles(pending[start:end], progress.NewProgressBar(end-start, description))
+		if err != nil {
+			return nil, err
+		}
+		if err := saved.Append(batch); err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, batch...)
+	}
+	return parsed, nil
+}
+
+// changedPaths returns the scanned files among changedFiles, which are relative to the
+// root with forward slashes, by path
+func changedPaths(files []models.FileInfo, changedFiles []string) map[string]bool {
+	relative := make(map[string]bool, len(changedFiles))
+	for _, file := range changedFiles {
+		relative[file] = true
+	}
+	changed := make(map[string]bool)
+	for _, file := range files {
+		if relative[filepath.ToSlash(file.RelativePath)] {
+			changed[file.Path] = true
+		}
+	}
+	return changed
+}
+
+// parseSize reads a file size such as "2MB", "512KB", or "1048576" (bytes)
+func parseSize(value string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		bytes  int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(text, unit.suffix) {
+			text, multiplier = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix)), unit.bytes
+			break
+		}
+	}
+	size, err := strconv.ParseFloat(text, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("expected a size such as 512KB or 2MB, got %q", value)
+	}
+	return int64(size * float64(multiplier)), nil
+}
+
+// parseThreshold splits a --threshold value such as "orphans=20"
+func parseThreshold(arg string) (string, int, error) {
+	name, value, ok := strings.Cut(arg, "=")
+	if !ok || name == "" {
+		return "", 0, fmt.Errorf("--threshold expects metric=value, got %q", arg)
+	}
+	max, err := strconv.Atoi(value)
+	if err != nil || max < 0 {
+		return "", 0, fmt.Errorf("--threshold %s needs a non-negative integer, got %q", name, value)
+	}
+	return name, max, nil
+}
+
+// applyPreset folds a framework preset's CLI-level defaults into the config
+func applyPreset(argv *Config, preset *config.Preset) *Config {
+	argv.ExcludeDirs = append(argv.ExcludeDirs, preset.ExcludeDirs...)
+	if preset.HasResolver("hooks") {
+		argv.WordPress = true
+	}
 	return argv
 }
+
+// configurePreset registers a preset's builtins and entrypoints with the tracker
+func configurePreset(tracker *analyzer.DependencyTracker, preset *config.Preset) error {
+	tracker.AddBuiltins(preset.Builtins...)
+	for _, pattern := range preset.Entrypoints {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range preset.EntrypointFiles {
+		if err := tracker.AddEntrypointFile(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
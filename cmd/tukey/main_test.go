@@ -3,11 +3,17 @@ package main
 import (
 	"bytes"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/boone-studios/tukey/internal/checkpoint"
+	"github.com/boone-studios/tukey/internal/churn"
 	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
 )
 
 func captureOutput(f func()) string {
@@ -103,14 +109,27 @@ func TestMergeConfigs_FileProvidesDefaults(t *testing.T) {
 		// nothing else set
 	}
 	fileCfg := &config.FileConfig{
-		Language:    "php",
-		ExcludeDirs: []string{"vendor", "tests"},
-		OutputFile:  "report.json",
-		Verbose:     true,
+		Language:        "php",
+		ExcludeDirs:     []string{"vendor", "tests"},
+		OutputFile:      "report.json",
+		Verbose:         true,
+		WordPress:       true,
+		CollapseBarrels: true,
+		Sign:            true,
 	}
 
 	merged := mergeConfigs(argv, fileCfg)
 
+	if !merged.WordPress {
+		t.Errorf("expected wordpress = true")
+	}
+	if !merged.CollapseBarrels {
+		t.Errorf("expected collapseBarrels = true")
+	}
+	if !merged.Sign {
+		t.Errorf("expected sign = true")
+	}
+
 	if merged.Language != "php" {
 		t.Errorf("expected language php, got %s", merged.Language)
 	}
@@ -125,6 +144,26 @@ func TestMergeConfigs_FileProvidesDefaults(t *testing.T) {
 	}
 }
 
+func TestConfigProblems(t *testing.T) {
+	loadErr := &config.Error{File: ".tukey.yml", Key: "maxParameters", Err: os.ErrInvalid}
+	fileCfg := &config.FileConfig{
+		Language: "cobol",
+		Origins:  map[string]string{"language": "policy/base.yml"},
+		Warnings: []error{&config.Error{File: ".tukey.yml", Key: "verbos", Err: os.ErrNotExist}},
+	}
+	problems := configProblems(fileCfg, loadErr)
+	if len(problems) != 3 || problems[0] != error(loadErr) ||
+		!strings.HasPrefix(problems[2].Error(), `policy/base.yml: language: unsupported language "cobol"`) {
+		t.Errorf("expected the load error, the warning, and the language, got %v", problems)
+	}
+	if problems := configProblems(&config.FileConfig{Language: "php"}, nil); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+	if merged := mergeConfigs(&Config{}, &config.FileConfig{StrictConfig: true}); !merged.StrictConfig {
+		t.Error("expected strictConfig from the file")
+	}
+}
+
 func TestMergeConfigs_CLIOverridesFile(t *testing.T) {
 	argv := &Config{
 		RootPath:    "myproj",
@@ -132,12 +171,14 @@ func TestMergeConfigs_CLIOverridesFile(t *testing.T) {
 		OutputFile:  "cli.json",
 		Verbose:     true,
 		ExcludeDirs: []string{"cli-only"},
+		Framework:   "drupal",
 	}
 	fileCfg := &config.FileConfig{
 		Language:    "php",
 		ExcludeDirs: []string{"vendor"},
 		OutputFile:  "file.json",
 		Verbose:     false,
+		Framework:   "wordpress",
 	}
 
 	merged := mergeConfigs(argv, fileCfg)
@@ -154,4 +195,843 @@ func TestMergeConfigs_CLIOverridesFile(t *testing.T) {
 	if len(merged.ExcludeDirs) != 2 {
 		t.Errorf("expected merged excludeDirs length 2, got %d", len(merged.ExcludeDirs))
 	}
+	if merged.Framework != "drupal" {
+		t.Errorf("expected drupal from CLI, got %s", merged.Framework)
+	}
+}
+
+func TestApplyPreset(t *testing.T) {
+	preset, _ := config.GetPreset("wordpress")
+	argv := applyPreset(&Config{ExcludeDirs: []string{"vendor"}}, preset)
+
+	if !argv.WordPress {
+		t.Errorf("expected wordpress preset to enable hook resolution")
+	}
+	if len(argv.ExcludeDirs) != 1+len(preset.ExcludeDirs) {
+		t.Errorf("expected preset excludeDirs appended, got %v", argv.ExcludeDirs)
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	oldVersion, oldCommit, oldDate := version, commit, date
+	defer func() { version, commit, date = oldVersion, oldCommit, oldDate }()
+
+	version, commit, date = "v1.2.3", "abc1234", "2025-01-02T03:04:05Z"
+	if got, want := versionString(), "Tukey v1.2.3 (commit abc1234, built 2025-01-02T03:04:05Z)"; got != want {
+		t.Errorf("versionString() = %q, want %q", got, want)
+	}
+}
+
+func TestParseArgs_Format(t *testing.T) {
+	os.Args = []string{"tukey", "--format", "JSON", "-o", "out.json", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Format != "json" {
+		t.Errorf("expected format json, got %s", cfg.Format)
+	}
+
+	merged := mergeConfigs(&Config{RootPath: "myproj"}, &config.FileConfig{})
+	if merged.Format != "json" {
+		t.Errorf("expected format to default to json, got %q", merged.Format)
+	}
+}
+
+func TestParseArgs_Thresholds(t *testing.T) {
+	os.Args = []string{"tukey", "--threshold", "orphans=10", "--threshold", "maxComplexity=30", "--status-file", "run-status.json", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]int{"orphans": 10, "maxComplexity": 30}
+	if !reflect.DeepEqual(cfg.Thresholds, want) {
+		t.Errorf("expected %v, got %v", want, cfg.Thresholds)
+	}
+	if cfg.StatusFile != "run-status.json" {
+		t.Errorf("expected status file, got %q", cfg.StatusFile)
+	}
+
+	// CLI thresholds win over config; config fills in the rest
+	merged := mergeConfigs(cfg, &config.FileConfig{Thresholds: map[string]int{"orphans": 99, "edges": 500}})
+	want = map[string]int{"orphans": 10, "maxComplexity": 30, "edges": 500}
+	if !reflect.DeepEqual(merged.Thresholds, want) {
+		t.Errorf("expected %v, got %v", want, merged.Thresholds)
+	}
+
+	for _, bad := range []string{"orphans", "orphans=-1", "=3", "orphans=many"} {
+		os.Args = []string{"tukey", "--threshold", bad, "myproj"}
+		if _, err := parseArgs(); err == nil {
+			t.Errorf("expected error for --threshold %q", bad)
+		}
+	}
+}
+
+func TestParseArgs_ChurnSince(t *testing.T) {
+	os.Args = []string{"tukey", "--churn-since", "6 months ago", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{ChurnSince: "2025-01-01"}); merged.ChurnSince != "6 months ago" {
+		t.Errorf("expected CLI value to win, got %q", merged.ChurnSince)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{ChurnSince: "2025-01-01"}); merged.ChurnSince != "2025-01-01" {
+		t.Errorf("expected config value, got %q", merged.ChurnSince)
+	}
+
+	os.Args = []string{"tukey", "myproj", "--churn-since"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a missing date")
+	}
+}
+
+func TestParseArgs_FlowDepth(t *testing.T) {
+	os.Args = []string{"tukey", "--flow-depth", "3", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{FlowDepth: 1}); merged.FlowDepth != 3 {
+		t.Errorf("expected CLI value to win, got %d", merged.FlowDepth)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{FlowDepth: 1}); merged.FlowDepth != 1 {
+		t.Errorf("expected config value, got %d", merged.FlowDepth)
+	}
+
+	for _, bad := range []string{"0", "deep"} {
+		os.Args = []string{"tukey", "--flow-depth", bad, "myproj"}
+		if _, err := parseArgs(); err == nil {
+			t.Errorf("expected an error for --flow-depth %s", bad)
+		}
+	}
+}
+
+func TestMergeConfigs_Groups(t *testing.T) {
+	groups := map[string][]string{"Billing": {`App\\Billing\\.*`}}
+	merged := mergeConfigs(&Config{RootPath: "myproj"}, &config.FileConfig{Groups: groups})
+	if !reflect.DeepEqual(merged.Groups, groups) {
+		t.Errorf("expected groups from config, got %v", merged.Groups)
+	}
+}
+
+func TestMergeConfigs_Plugins(t *testing.T) {
+	plugins := []config.PluginConfig{{Command: "./bin/tukey-acme", Args: []string{"--strict"}}}
+	merged := mergeConfigs(&Config{RootPath: "myproj"}, &config.FileConfig{Plugins: plugins})
+	if !reflect.DeepEqual(merged.Plugins, plugins) {
+		t.Errorf("expected plugins from config, got %v", merged.Plugins)
+	}
+}
+
+func TestParseArgs_APINamespaces(t *testing.T) {
+	os.Args = []string{"tukey", "--api-namespace", `Acme\Billing`, "--api-namespace", `Acme\Http`, "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{`Acme\Billing`, `Acme\Http`}; !reflect.DeepEqual(cfg.APINamespaces, want) {
+		t.Errorf("expected %v, got %v", want, cfg.APINamespaces)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{APINamespaces: []string{"Acme"}}); len(merged.APINamespaces) != 2 {
+		t.Errorf("expected CLI value to win, got %v", merged.APINamespaces)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{APINamespaces: []string{"Acme"}}); !reflect.DeepEqual(merged.APINamespaces, []string{"Acme"}) {
+		t.Errorf("expected config value, got %v", merged.APINamespaces)
+	}
+
+	os.Args = []string{"tukey", "myproj", "--api-namespace"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a missing namespace")
+	}
+}
+
+func TestParseArgs_Codeowners(t *testing.T) {
+	os.Args = []string{"tukey", "--codeowners", "OWNERS", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Codeowners: "docs/CODEOWNERS"}); merged.Codeowners != "OWNERS" {
+		t.Errorf("expected CLI value to win, got %q", merged.Codeowners)
+	}
+
+	root := t.TempDir()
+	if owners, err := loadCodeowners(&Config{RootPath: root}); owners != nil || err != nil {
+		t.Errorf("expected no CODEOWNERS, got %v, %v", owners, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @acme/core\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if owners, err := loadCodeowners(&Config{RootPath: root}); err != nil || owners == nil || len(owners.Rules) != 1 {
+		t.Errorf("expected the root CODEOWNERS to be detected, got %v, %v", owners, err)
+	}
+	if _, err := loadCodeowners(&Config{RootPath: root, Codeowners: filepath.Join(root, "missing")}); err == nil {
+		t.Error("expected an error for a missing --codeowners file")
+	}
+}
+
+func TestParseArgs_Coverage(t *testing.T) {
+	os.Args = []string{"tukey", "--coverage", "clover.xml", "--coverage", "lcov.info", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Coverage: []string{"cover.out"}}); !reflect.DeepEqual(merged.Coverage, []string{"clover.xml", "lcov.info"}) {
+		t.Errorf("expected the CLI files to win, got %v", merged.Coverage)
+	}
+
+	os.Args = []string{"tukey", "--coverage"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a missing coverage file")
+	}
+}
+
+func TestParseArgs_Trace(t *testing.T) {
+	os.Args = []string{"tukey", "--trace", "trace.xt", "--trace", "spans.json", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Traces: []string{"profile.json"}}); !reflect.DeepEqual(merged.Traces, []string{"trace.xt", "spans.json"}) {
+		t.Errorf("expected the CLI files to win, got %v", merged.Traces)
+	}
+
+	os.Args = []string{"tukey", "--trace"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a missing trace file")
+	}
+}
+
+func TestParseArgs_Suppressions(t *testing.T) {
+	os.Args = []string{"tukey", "--suppressions", "reviewed.txt", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Suppressions: "other.txt"}); merged.Suppressions != "reviewed.txt" {
+		t.Errorf("expected CLI value to win, got %q", merged.Suppressions)
+	}
+
+	root := t.TempDir()
+	if entries, err := loadSuppressions(&Config{RootPath: root}); entries != nil || err != nil {
+		t.Errorf("expected no suppressions, got %v, %v", entries, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".tukey-suppressions"), []byte("orphans App\\.*\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := loadSuppressions(&Config{RootPath: root}); err != nil || len(entries) != 1 {
+		t.Errorf("expected the root .tukey-suppressions to be detected, got %v, %v", entries, err)
+	}
+	if _, err := loadSuppressions(&Config{RootPath: root, Suppressions: filepath.Join(root, "missing")}); err == nil {
+		t.Error("expected an error for a missing --suppressions file")
+	}
+}
+
+func TestParseArgs_Notes(t *testing.T) {
+	os.Args = []string{"tukey", "--notes", "notes.yml", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Notes: "other.yml"}); merged.Notes != "notes.yml" {
+		t.Errorf("expected CLI value to win, got %q", merged.Notes)
+	}
+
+	root := t.TempDir()
+	if entries, err := loadNotes(&Config{RootPath: root}); entries != nil || err != nil {
+		t.Errorf("expected no notes, got %v, %v", entries, err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".tukey"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".tukey", "notes.yml"), []byte("notes:\n  - match: App\\\\.*\n    note: Legacy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := loadNotes(&Config{RootPath: root}); err != nil || len(entries) != 1 {
+		t.Errorf("expected the root .tukey/notes.yml to be detected, got %v, %v", entries, err)
+	}
+}
+
+func TestParseArgs_GoPackagePattern(t *testing.T) {
+	for arg, want := range map[string]string{"./...": ".", "...": ".", "cmd/...": "cmd", "/src/app": "/src/app"} {
+		os.Args = []string{"tukey", "-l", "go", arg}
+		cfg, err := parseArgs()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.RootPath != want {
+			t.Errorf("expected %q to mean root %q, got %q", arg, want, cfg.RootPath)
+		}
+	}
+}
+
+func TestParseArgs_ChangedOnly(t *testing.T) {
+	os.Args = []string{"tukey", "--changed-only", "origin/main", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{ChangedOnly: "HEAD"}); merged.ChangedOnly != "origin/main" {
+		t.Errorf("expected CLI value to win, got %q", merged.ChangedOnly)
+	}
+
+	files := []models.FileInfo{
+		{Path: filepath.Join("myproj", "app", "a.php"), RelativePath: filepath.Join("app", "a.php")},
+		{Path: filepath.Join("myproj", "b.php"), RelativePath: "b.php"},
+	}
+	changed := changedPaths(files, []string{"app/a.php", "README.md"})
+	if len(changed) != 1 || !changed[filepath.Join("myproj", "app", "a.php")] {
+		t.Errorf("expected only the scanned changed file, got %v", changed)
+	}
+}
+
+func TestParseArgs_Prune(t *testing.T) {
+	os.Args = []string{"tukey", "--prune", "leaves,accessors", "--prune", "overloads", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"leaves", "accessors", "overloads"}; !reflect.DeepEqual(cfg.Prune, want) {
+		t.Errorf("expected %v, got %v", want, cfg.Prune)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Prune: []string{"all"}}); len(merged.Prune) != 3 {
+		t.Errorf("expected CLI value to win, got %v", merged.Prune)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{Prune: []string{"all"}}); !reflect.DeepEqual(merged.Prune, []string{"all"}) {
+		t.Errorf("expected config value, got %v", merged.Prune)
+	}
+
+	os.Args = []string{"tukey", "--prune", "everything", "myproj"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for an unknown heuristic")
+	}
+}
+
+func TestParseArgs_Clones(t *testing.T) {
+	os.Args = []string{"tukey", "--clones", "--min-clone-tokens", "80", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Clones || cfg.MinCloneTokens != 80 {
+		t.Errorf("unexpected clone settings: %v, %d", cfg.Clones, cfg.MinCloneTokens)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{MinCloneTokens: 30}); merged.MinCloneTokens != 80 {
+		t.Errorf("expected CLI value to win, got %d", merged.MinCloneTokens)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{Clones: true, MinCloneTokens: 30}); !merged.Clones || merged.MinCloneTokens != 30 {
+		t.Errorf("expected config values, got %v, %d", merged.Clones, merged.MinCloneTokens)
+	}
+
+	os.Args = []string{"tukey", "--min-clone-tokens", "none", "myproj"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a non-numeric block size")
+	}
+}
+
+func TestParseArgs_Literals(t *testing.T) {
+	os.Args = []string{"tukey", "--literals", "--min-literal-count", "5", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Literals || cfg.MinLiteralCount != 5 {
+		t.Errorf("unexpected literal settings: %v, %d", cfg.Literals, cfg.MinLiteralCount)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{MinLiteralCount: 4}); merged.MinLiteralCount != 5 {
+		t.Errorf("expected CLI value to win, got %d", merged.MinLiteralCount)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{Literals: true, MinLiteralCount: 4}); !merged.Literals || merged.MinLiteralCount != 4 {
+		t.Errorf("expected config values, got %v, %d", merged.Literals, merged.MinLiteralCount)
+	}
+
+	os.Args = []string{"tukey", "--min-literal-count", "1", "myproj"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a count that every literal meets")
+	}
+}
+
+func TestParseArgs_Debt(t *testing.T) {
+	os.Args = []string{"tukey", "--ticket-pattern", `[A-Z]+-\d+`, "--debt-age", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TicketPattern != `[A-Z]+-\d+` || !cfg.DebtAge {
+		t.Errorf("unexpected debt settings: %q, %v", cfg.TicketPattern, cfg.DebtAge)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{TicketPattern: `#\d+`}); merged.TicketPattern != `[A-Z]+-\d+` {
+		t.Errorf("expected CLI value to win, got %q", merged.TicketPattern)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{TicketPattern: `#\d+`, DebtAge: true}); merged.TicketPattern != `#\d+` || !merged.DebtAge {
+		t.Errorf("expected config values, got %q, %v", merged.TicketPattern, merged.DebtAge)
+	}
+
+	os.Args = []string{"tukey", "--ticket-pattern", "(", "myproj"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestParseArgs_MinDocCoverage(t *testing.T) {
+	os.Args = []string{"tukey", "--min-doc-coverage", "80%", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{MinDocCoverage: 50}); merged.MinDocCoverage != 80 {
+		t.Errorf("expected CLI value to win, got %d", merged.MinDocCoverage)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{MinDocCoverage: 50}); merged.MinDocCoverage != 50 {
+		t.Errorf("expected config value, got %d", merged.MinDocCoverage)
+	}
+
+	os.Args = []string{"tukey", "--min-doc-coverage", "120", "myproj"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a coverage over 100")
+	}
+}
+
+func TestParseArgs_MaxParameters(t *testing.T) {
+	os.Args = []string{"tukey", "--max-parameters", "4", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{MaxParameters: 7}); merged.MaxParameters != 4 {
+		t.Errorf("expected CLI value to win, got %d", merged.MaxParameters)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{MaxParameters: 7}); merged.MaxParameters != 7 {
+		t.Errorf("expected config value, got %d", merged.MaxParameters)
+	}
+
+	os.Args = []string{"tukey", "--max-parameters", "0", "myproj"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for a non-positive limit")
+	}
+}
+
+func TestParseArgs_MaxLinesPerEdge(t *testing.T) {
+	os.Args = []string{"tukey", "--max-lines-per-edge", "25", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxLinesPerEdge != 25 {
+		t.Errorf("expected 25, got %d", cfg.MaxLinesPerEdge)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{MaxLinesPerEdge: 100}); merged.MaxLinesPerEdge != 25 {
+		t.Errorf("expected CLI value to win, got %d", merged.MaxLinesPerEdge)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{MaxLinesPerEdge: 100}); merged.MaxLinesPerEdge != 100 {
+		t.Errorf("expected config value, got %d", merged.MaxLinesPerEdge)
+	}
+
+	for _, bad := range []string{"-1", "lots"} {
+		os.Args = []string{"tukey", "--max-lines-per-edge", bad, "myproj"}
+		if _, err := parseArgs(); err == nil {
+			t.Errorf("expected error for --max-lines-per-edge %q", bad)
+		}
+	}
+}
+
+func TestParseArgs_Bridges(t *testing.T) {
+	os.Args = []string{"tukey", "--bridges", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Bridges {
+		t.Error("expected --bridges to be set")
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{Bridges: true}); !merged.Bridges {
+		t.Error("expected bridges from config")
+	}
+}
+
+func TestParseArgs_OpenAPI(t *testing.T) {
+	os.Args = []string{"tukey", "--openapi", "openapi.yaml", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{OpenAPI: "docs/api.json"}); merged.OpenAPI != "openapi.yaml" {
+		t.Errorf("expected CLI value to win, got %q", merged.OpenAPI)
+	}
+
+	os.Args = []string{"tukey", "myproj", "--openapi"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error for --openapi without a file")
+	}
+}
+
+func TestParseArgs_FeatureFlags(t *testing.T) {
+	os.Args = []string{"tukey", "--feature-flag", "Feature::active", "--feature-flag", "flags.isEnabled", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged := mergeConfigs(cfg, &config.FileConfig{FeatureFlags: []string{"Feature::enabled"}})
+	if !reflect.DeepEqual(merged.FeatureFlags, []string{"Feature::active", "flags.isEnabled"}) {
+		t.Errorf("expected CLI accessors to win, got %v", merged.FeatureFlags)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{FeatureFlags: []string{"Feature::enabled"}}); len(merged.FeatureFlags) != 1 {
+		t.Errorf("expected accessors from config, got %v", merged.FeatureFlags)
+	}
+}
+
+func TestParseArgs_TargetVersion(t *testing.T) {
+	os.Args = []string{"tukey", "--target-version", "7.4", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{PHP: config.PHPConfig{Version: "8.1"}}); merged.TargetVersion != "7.4" {
+		t.Errorf("expected the CLI version to win, got %q", merged.TargetVersion)
+	}
+
+	os.Args = []string{"tukey", "--language", "javascript", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{PHP: config.PHPConfig{Version: "8.1"}, EcmaVersion: 2019}); merged.TargetVersion != "2019" {
+		t.Errorf("expected the language's version from config, got %q", merged.TargetVersion)
+	}
+
+	os.Args = []string{"tukey", "--target-version"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected error for --target-version without a version")
+	}
+}
+
+func TestParseArgs_Checkpoint(t *testing.T) {
+	os.Args = []string{"tukey", "--checkpoint", "run.checkpoint", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged := mergeConfigs(cfg, &config.FileConfig{Checkpoint: "other.checkpoint"}); merged.Checkpoint != "run.checkpoint" {
+		t.Errorf("expected the CLI checkpoint to win, got %q", merged.Checkpoint)
+	}
+
+	os.Args = []string{"tukey", "--checkpoint"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected error for --checkpoint without a filename")
+	}
+}
+
+func TestParseArgs_Sample(t *testing.T) {
+	for arg, want := range map[string]float64{"10%": 10, "2.5": 2.5, "100%": 100} {
+		os.Args = []string{"tukey", "--sample", arg, "myproj"}
+		cfg, err := parseArgs()
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", arg, err)
+		}
+		if cfg.Sample != want {
+			t.Errorf("expected %v for %s, got %v", want, arg, cfg.Sample)
+		}
+	}
+	for _, arg := range []string{"0%", "150%", "some"} {
+		os.Args = []string{"tukey", "--sample", arg, "myproj"}
+		if _, err := parseArgs(); err == nil {
+			t.Errorf("expected error for --sample %s", arg)
+		}
+	}
+}
+
+func TestPrintPlan(t *testing.T) {
+	os.Args = []string{"tukey", "--dry-run", "--clones", "-o", "report.json", "--threshold", "cycles=0", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.DryRun {
+		t.Fatal("expected --dry-run to be set")
+	}
+	cfg = mergeConfigs(cfg, &config.FileConfig{})
+	php, _ := parser.Get("php")
+	batches := [][]models.FileInfo{{{RelativePath: "src/A.php", Size: 1024 * 1024}, {RelativePath: "src/B.php", Size: 1024 * 1024}}}
+
+	out := captureOutput(func() {
+		printPlan(cfg, []parser.LanguageParser{php}, batches, map[string]bool{"vendor": true, "build": true})
+	})
+	for _, want := range []string{
+		"Excluded directories: build, vendor",
+		"php: 2 files (2.00 MB)",
+		"clones (min 50 tokens)",
+		"Export: json to report.json",
+		"Thresholds: cycles<=0",
+		"Work: 2 files, 2.00 MB to parse",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in plan:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "src/A.php") {
+		t.Errorf("expected files listed only with -v:\n%s", out)
+	}
+}
+
+func TestParseArgs_ScanOptions(t *testing.T) {
+	os.Args = []string{"tukey", "--scan-report", "--gitignore", "--extensionless", "--max-file-size", "2MB", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.ScanReport || !cfg.Gitignore || !cfg.Extensionless || cfg.MaxFileSize != 2<<20 {
+		t.Errorf("unexpected options: %+v", cfg)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	merged := mergeConfigs(cfg, &config.FileConfig{Gitignore: true, Extensionless: true, MaxFileSize: "512KB"})
+	if !merged.Gitignore || !merged.Extensionless || merged.MaxFileSize != 512<<10 {
+		t.Errorf("expected scan options from config, got %+v", merged)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for value, want := range map[string]int64{"1048576": 1 << 20, "512kb": 512 << 10, "1.5MB": 3 << 19, "1 GB": 1 << 30, "100B": 100} {
+		if got, err := parseSize(value); err != nil || got != want {
+			t.Errorf("parseSize(%q) = %d, %v; want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "MB", "-1KB", "big"} {
+		if _, err := parseSize(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestFilesByParser(t *testing.T) {
+	php, _ := parser.Get("php")
+	var js parser.LanguageParser
+	for _, companion := range companionParsers("php") {
+		if companion.Language() == "javascript" {
+			js = companion
+		}
+	}
+	if js == nil {
+		t.Fatalf("expected javascript among the companions, got %v", companionParsers("php"))
+	}
+	parsers := []parser.LanguageParser{php, js}
+
+	files := []models.FileInfo{{Path: "app/User.php"}, {Path: "web/app.js"}, {Path: "core/node.module"}, {Path: "README.md"}}
+	batches := filesByParser(files, parsers, append(php.FileExtensions(), ".module"))
+	if len(batches[0]) != 2 || batches[0][1].Path != "core/node.module" {
+		t.Errorf("expected PHP and preset files for the analyzed language, got %v", batches[0])
+	}
+	if len(batches[1]) != 1 || batches[1][0].Path != "web/app.js" {
+		t.Errorf("expected the script for javascript, got %v", batches[1])
+	}
+
+	sniffed := []models.FileInfo{{Path: "bin/console", Language: "php"}, {Path: "bin/serve", Language: "javascript"}}
+	batches = filesByParser(sniffed, parsers, php.FileExtensions())
+	if len(batches[0]) != 1 || len(batches[1]) != 1 || batches[1][0].Path != "bin/serve" {
+		t.Errorf("expected sniffed scripts to go to the parser that recognized them, got %v", batches)
+	}
+}
+
+func TestParseArgs_IncludeDir(t *testing.T) {
+	os.Args = []string{"tukey", "--include-dir", "vendor", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged := mergeConfigs(cfg, &config.FileConfig{IncludeDirs: []string{"dist"}})
+	if strings.Join(merged.IncludeDirs, ",") != "vendor,dist" {
+		t.Errorf("expected vendor and dist to be included, got %v", merged.IncludeDirs)
+	}
+
+	os.Args = []string{"tukey", "--include-dir"}
+	if _, err := parseArgs(); err == nil {
+		t.Error("expected an error without a directory")
+	}
+}
+
+func TestParseArgs_PathStyle(t *testing.T) {
+	os.Args = []string{"tukey", "--path-style", "POSIX", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PathStyle != "posix" {
+		t.Errorf("expected posix, got %q", cfg.PathStyle)
+	}
+
+	os.Args = []string{"tukey", "myproj"}
+	cfg, _ = parseArgs()
+	if merged := mergeConfigs(cfg, &config.FileConfig{PathStyle: "posix"}); merged.PathStyle != "posix" {
+		t.Errorf("expected the path style from config, got %q", merged.PathStyle)
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	os.Args = []string{"tukey", "--exclude", "tests", "--clones", "myproj"}
+	cfg, _ := parseArgs()
+	merged := mergeConfigs(cfg, &config.FileConfig{MinCloneTokens: 80, Thresholds: map[string]int{"orphans": 5}})
+
+	settings := effectiveConfig(merged)
+	if settings["rootPath"] != "myproj" || settings["clones"] != true || settings["minCloneTokens"] != float64(80) {
+		t.Errorf("unexpected settings %v", settings)
+	}
+	if excludes, ok := settings["excludeDirs"].([]interface{}); !ok || len(excludes) != 1 {
+		t.Errorf("expected the excluded directory, got %v", settings["excludeDirs"])
+	}
+	if _, ok := settings["thresholds"]; !ok {
+		t.Errorf("expected thresholds from config, got %v", settings)
+	}
+	if _, ok := settings["verbose"]; ok {
+		t.Error("expected unset settings to be left out")
+	}
+}
+
+func TestParseArgs_Severity(t *testing.T) {
+	os.Args = []string{"tukey", "--severity", "orphans=Warning", "myproj"}
+	cfg, err := parseArgs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged := mergeConfigs(cfg, &config.FileConfig{Severities: map[string]string{"orphans": "error", "parseErrors": "info"}})
+	want := map[string]string{"orphans": "warning", "parseErrors": "info"}
+	if !reflect.DeepEqual(merged.Severities, want) {
+		t.Errorf("expected %v, got %v", want, merged.Severities)
+	}
+
+	for _, bad := range []string{"orphans", "=warning"} {
+		os.Args = []string{"tukey", "--severity", bad, "myproj"}
+		if _, err := parseArgs(); err == nil {
+			t.Errorf("expected error for --severity %q", bad)
+		}
+	}
+}
+
+func TestParseWithCheckpoint_ChangedOnly(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(rel, content string) {
+		if err := os.WriteFile(filepath.Join(repo, rel), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := []models.FileInfo{
+		{Path: filepath.Join(repo, "Foo.php"), RelativePath: "Foo.php"},
+		{Path: filepath.Join(repo, "Bar.php"), RelativePath: "Bar.php"},
+	}
+	php, _ := parser.Get("php")
+	path := filepath.Join(t.TempDir(), "cache")
+	// parse runs tukey --changed-only HEAD --checkpoint, returning the names in Foo.php
+	// and how many files the checkpoint held when opened
+	parse := func() (map[string]bool, int) {
+		header := checkpoint.Header{Version: "1.0.0", Root: repo, Languages: []string{"php"}}
+		var err error
+		if header.Commit, err = churn.Head(repo); err != nil {
+			t.Fatal(err)
+		}
+		saved, err := checkpoint.Open(path, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer saved.Close()
+		cache, err := openGitCache(repo, files, saved, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parsed []*models.ParsedFile
+		captureOutput(func() {
+			parsed, err = parseWithCheckpoint(php, files, "Parsing files", saved, cache)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make(map[string]bool)
+		for _, file := range parsed {
+			if file.Path == files[0].Path {
+				for _, element := range file.Elements {
+					names[element.Name] = true
+				}
+			}
+		}
+		return names, saved.Restored()
+	}
+
+	git("init", "-q")
+	write("Foo.php", "<?php\nclass Foo {\n    public function bar() {}\n}\n")
+	write("Bar.php", "<?php\nclass Bar {}\n")
+	git("add", "-A")
+	git("commit", "-qm", "first")
+	if names, restored := parse(); !names["bar"] || restored != 0 {
+		t.Fatalf("expected the first run to parse bar, got %v (%d restored)", names, restored)
+	}
+
+	write("Foo.php", "<?php\nclass Foo {\n    public function bar() {}\n    public function baz() {}\n}\n")
+	git("commit", "-qam", "second")
+	if names, restored := parse(); !names["baz"] || restored != 2 {
+		t.Fatalf("expected a file committed since the cache was built to be parsed again, got %v (%d restored)", names, restored)
+	}
+
+	// Uncommitted changes are parsed but not saved
+	write("Foo.php", "<?php\nclass Foo {\n    public function qux() {}\n}\n")
+	if names, _ := parse(); !names["qux"] || names["baz"] {
+		t.Fatalf("expected an uncommitted change to be parsed, got %v", names)
+	}
+	git("checkout", "-q", "--", "Foo.php")
+	saved, err := checkpoint.Open(path, checkpoint.Header{Version: "1.0.0", Root: repo, Languages: []string{"php"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer saved.Close()
+	restored, ok := saved.Cached(files[0].Path)
+	if !ok || len(restored.Elements) == 0 {
+		t.Fatal("expected the re-parsed file to be saved back to the checkpoint")
+	}
+	names := make(map[string]bool)
+	for _, element := range restored.Elements {
+		names[element.Name] = true
+	}
+	if !names["baz"] || names["qux"] {
+		t.Errorf("expected the checkpoint to hold the committed Foo.php, got %v", names)
+	}
 }
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/move"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/scanner"
+)
+
+const mvPreviewUsage = "Usage: tukey mv-preview <old/path> <new/path> [--language <lang>] [--json <file>] [<directory>]"
+
+// mvPreviewOptions are the parsed arguments of `tukey mv-preview`
+type mvPreviewOptions struct {
+	From     string
+	To       string
+	Language string // Inferred from From's extension when not given
+	JSONFile string
+	Dir      string
+}
+
+// parseMvPreviewArgs parses the arguments following `tukey mv-preview`
+func parseMvPreviewArgs(args []string) (*mvPreviewOptions, error) {
+	opts := &mvPreviewOptions{Dir: "."}
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "-l", "--language", "--json":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			if arg == "--json" {
+				opts.JSONFile = args[i+1]
+			} else {
+				opts.Language = args[i+1]
+			}
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			positional = append(positional, arg)
+		}
+	}
+
+	switch len(positional) {
+	case 0, 1:
+		return nil, fmt.Errorf("the old and new paths are required")
+	case 2, 3:
+		opts.From, opts.To = positional[0], positional[1]
+		if len(positional) == 3 {
+			opts.Dir = positional[2]
+		}
+	default:
+		return nil, fmt.Errorf("unexpected argument %q", positional[3])
+	}
+
+	if opts.Language == "" {
+		opts.Language = languageOf(opts.From)
+		if opts.Language == "" {
+			return nil, fmt.Errorf("no parser handles %s; pass --language", opts.From)
+		}
+	}
+	if _, ok := parser.Get(opts.Language); !ok {
+		return nil, fmt.Errorf("unsupported language %q (supported: %v)", opts.Language, parser.SupportedLanguages())
+	}
+	return opts, nil
+}
+
+// languageOf returns the language whose parser handles path's extension, or ""
+func languageOf(path string) string {
+	ext := filepath.Ext(path)
+	for _, language := range parser.SupportedLanguages() {
+		p, _ := parser.Get(language)
+		for _, handled := range p.FileExtensions() {
+			if handled == ext {
+				return language
+			}
+		}
+	}
+	return ""
+}
+
+// runMvPreview implements `tukey mv-preview`: it analyzes the directory and lists every
+// import, use statement, and reference that would need updating if the file moved,
+// without touching anything
+func runMvPreview(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(mvPreviewUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseMvPreviewArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, mvPreviewUsage)
+		return runstatus.ExitUsage
+	}
+	if info, err := os.Stat(opts.From); err != nil || info.IsDir() {
+		sayErr("❌ %s is not a file\n", opts.From)
+		return runstatus.ExitUsage
+	}
+
+	// Import bindings aren't in the report, so this analyzes in-process like `tukey similar`
+	p, _ := parser.Get(opts.Language)
+	fileScanner := scanner.NewScanner(opts.Dir)
+	fileScanner.SetExtensions(p.FileExtensions())
+	fileScanner.AddDefaultExcludes(p.DefaultExcludes())
+	files, err := fileScanner.ScanFiles()
+	if err != nil {
+		sayErr("❌ Error scanning files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	parsed, err := p.ProcessFiles(files, progress.NewProgressBar(len(files), "Parsing"))
+	printParseErrors()
+	if err != nil {
+		sayErr("❌ Error parsing files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(parsed)
+
+	preview := move.Plan(parsed, graph, opts.From, opts.To)
+	root, _ := filepath.Abs(opts.Dir)
+	printPreview(root, preview)
+
+	if opts.JSONFile != "" {
+		data, err := json.MarshalIndent(preview, "", "  ")
+		if err == nil {
+			err = os.WriteFile(opts.JSONFile, data, 0644)
+		}
+		if err != nil {
+			sayErr("❌ Failed to write preview: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		say("\n💾 Preview saved to %s\n", opts.JSONFile)
+	}
+	return runstatus.ExitOK
+}
+
+// printPreview lists a move preview's edits, grouped by file
+func printPreview(root string, preview *move.Preview) {
+	say("\n🚚 Moving %s → %s\n", relativePath(root, preview.From), relativePath(root, preview.To))
+	switch {
+	case preview.Namespace == "":
+	case preview.NewNamespace == "":
+		say("   Namespace %s doesn't follow the directories; it stays as is\n", preview.Namespace)
+	case preview.NewNamespace != preview.Namespace:
+		say("   Namespace %s → %s\n", preview.Namespace, preview.NewNamespace)
+	}
+	for _, name := range preview.Renames {
+		say("   %s → %s\n", name.Old, name.New)
+	}
+
+	if len(preview.Edits) == 0 {
+		say("\n✅ Nothing else refers to it by path or name\n")
+		return
+	}
+	files := make(map[string]bool)
+	file := ""
+	for _, edit := range preview.Edits {
+		files[edit.File] = true
+		if edit.File != file {
+			file = edit.File
+			say("\n   %s\n", relativePath(root, file))
+		}
+		updated := edit.New
+		if updated == "" {
+			updated = "?"
+		}
+		say("      :%-4d %-11s %s → %s\n", edit.Line, edit.Kind, edit.Old, updated)
+	}
+	say("\n📝 %d edits in %d files\n", len(preview.Edits), len(files))
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestParseMvPreviewArgs(t *testing.T) {
+	opts, err := parseMvPreviewArgs([]string{"src/Billing/Invoice.php", "src/Payments/Bill.php", "--json", "move.json", "src"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.From != "src/Billing/Invoice.php" || opts.To != "src/Payments/Bill.php" || opts.Dir != "src" || opts.JSONFile != "move.json" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts.Language != "php" {
+		t.Errorf("expected the language from the extension, got %q", opts.Language)
+	}
+	if opts, err := parseMvPreviewArgs([]string{"lib/a.rb", "lib/b.rb", "-l", "ruby"}); err != nil || opts.Language != "ruby" {
+		t.Errorf("expected --language to apply, got %+v (%v)", opts, err)
+	}
+
+	for _, bad := range [][]string{{}, {"a.php"}, {"a.php", "b.php", "c", "d"}, {"a.txt", "b.txt"}, {"a.php", "b.php", "-l", "cobol"}, {"a.php", "b.php", "--verbose"}} {
+		if _, err := parseMvPreviewArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/diff"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+const orphansUsage = "Usage: tukey orphans [--interactive] [--notes <file>] [<directory>] [-- <analysis flags>]"
+
+// orphansOptions are the parsed arguments of `tukey orphans`
+type orphansOptions struct {
+	Interactive bool
+	Notes       string // Notes file to update; default .tukey/notes.yml in the directory
+	Dir         string
+	Analysis    []string // Extra flags for the analysis run, after "--"
+}
+
+// orphanChoices maps the answers to the triage prompt to the reason an orphan is expected,
+// with the note recorded when none is typed
+var orphanChoices = map[string][2]string{
+	"e": {"entrypoint", "Called by a framework or runtime"},
+	"r": {"reflection", "Invoked through reflection"},
+	"k": {"keep", "Kept on purpose"},
+}
+
+// parseOrphansArgs parses the arguments following `tukey orphans`
+func parseOrphansArgs(args []string) (*orphansOptions, error) {
+	opts := &orphansOptions{Dir: "."}
+	dirSet := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--":
+			opts.Analysis = append([]string(nil), args[i+1:]...)
+			i = len(args)
+		case "-i", "--interactive":
+			opts.Interactive = true
+		case "--notes":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--notes requires a filename")
+			}
+			opts.Notes = args[i+1]
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") || dirSet {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Dir, dirSet = arg, true
+		}
+	}
+	return opts, nil
+}
+
+// runOrphans implements `tukey orphans`: it analyzes the directory and lists its orphans.
+// With --interactive, it asks about each one and records those marked expected in the
+// notes file, so later runs leave them out and the list converges on the orphans that
+// need work.
+func runOrphans(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(orphansUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseOrphansArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, orphansUsage)
+		return runstatus.ExitUsage
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		sayErr("❌ Can't locate the tukey binary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	say("🔎 Analyzing %s for orphans...\n", opts.Dir)
+	orphans, err := findOrphans(exe, opts)
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitInternal
+	}
+	if len(orphans) == 0 {
+		say("✅ No orphans left to triage\n")
+		return runstatus.ExitOK
+	}
+
+	root, _ := filepath.Abs(opts.Dir)
+	if !opts.Interactive {
+		say("🏝️  %d orphans:\n", len(orphans))
+		for _, node := range orphans {
+			say("   • %s (%s:%d)\n", node.Name, relativePath(root, node.File), node.Line)
+		}
+		say("\n💡 Run tukey orphans --interactive to mark the expected ones\n")
+		return runstatus.ExitOK
+	}
+
+	author, _ := git(opts.Dir, "config", "user.name")
+	entries := triageOrphans(os.Stdin, root, orphans, author)
+	if len(entries) == 0 {
+		say("ℹ️  No orphans marked\n")
+		return runstatus.ExitOK
+	}
+	notesPath := opts.Notes
+	if notesPath == "" {
+		notesPath = filepath.Join(opts.Dir, ".tukey", "notes.yml")
+	}
+	if err := analyzer.AppendNotes(notesPath, entries); err != nil {
+		sayErr("❌ Failed to update %s: %v\n", notesPath, err)
+		return runstatus.ExitInternal
+	}
+	say("💾 Marked %d of %d orphans as expected in %s\n", len(entries), len(orphans), notesPath)
+	return runstatus.ExitOK
+}
+
+// findOrphans analyzes the directory with a child tukey process and returns its orphans,
+// ordered by file and line. The child reads the same notes file, so orphans already
+// marked expected are left out.
+func findOrphans(exe string, opts *orphansOptions) ([]*models.DependencyNode, error) {
+	report, err := os.CreateTemp("", "tukey-orphans-*.json")
+	if err != nil {
+		return nil, err
+	}
+	report.Close()
+	defer os.Remove(report.Name())
+
+	args := []string{"--summary-only", "--format", "json", "--output", report.Name()}
+	if opts.Notes != "" {
+		args = append(args, "--notes", opts.Notes)
+	}
+	args = append(append(args, opts.Analysis...), opts.Dir)
+	// Exceeded thresholds and unparseable files still leave a report
+	out, err := exec.Command(exe, args...).CombinedOutput()
+	if exit, ok := err.(*exec.ExitError); err != nil && (!ok || exit.ExitCode() >= runstatus.ExitUsage) {
+		return nil, fmt.Errorf("analysis failed: %v\n%s", err, strings.TrimSpace(string(out)))
+	}
+
+	graph, err := diff.Load(report.Name())
+	if err != nil {
+		return nil, err
+	}
+	orphans := graph.Orphans
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].File != orphans[j].File {
+			return orphans[i].File < orphans[j].File
+		}
+		return orphans[i].Line < orphans[j].Line
+	})
+	return orphans, nil
+}
+
+// triageOrphans asks about each orphan on in, and returns notes marking the ones the user
+// says are expected. Answering q stops early, keeping the answers so far.
+func triageOrphans(in io.Reader, root string, orphans []*models.DependencyNode, author string) []*models.NoteEntry {
+	reader := bufio.NewReader(in)
+	ask := func(prompt string) (string, bool) {
+		say("%s", prompt)
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return "", false
+		}
+		return strings.TrimSpace(line), true
+	}
+
+	var entries []*models.NoteEntry
+	for i, node := range orphans {
+		say("\n[%d/%d] %s %s (%s:%d)\n", i+1, len(orphans), node.Type, analyzer.QualifiedName(node), relativePath(root, node.File), node.Line)
+		var choice [2]string
+		for {
+			answer, ok := ask("   [e]ntrypoint, [r]eflection, [k]eep, [s]kip, [q]uit? ")
+			if !ok || answer == "q" {
+				return entries
+			}
+			if answer == "s" || answer == "" {
+				break
+			}
+			if c, known := orphanChoices[answer]; known {
+				choice = c
+				break
+			}
+		}
+		if choice[0] == "" {
+			continue
+		}
+
+		text := choice[1]
+		if answer, ok := ask(fmt.Sprintf("   Note [%s]: ", text)); ok && answer != "" {
+			text = answer
+		}
+		entries = append(entries, &models.NoteEntry{
+			Note:    models.Note{Text: text, Finding: "orphans", Author: author, Expected: choice[0]},
+			Pattern: regexp.QuoteMeta(analyzer.QualifiedName(node)),
+		})
+	}
+	return entries
+}
+
+// relativePath shortens path to be relative to root when it's inside it
+func relativePath(root, path string) string {
+	abs, _ := filepath.Abs(path)
+	if rel, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestParseOrphansArgs(t *testing.T) {
+	opts, err := parseOrphansArgs([]string{"-i", "--notes", "docs/notes.yml", "src", "--", "--language", "python"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Interactive || opts.Notes != "docs/notes.yml" || opts.Dir != "src" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Analysis, []string{"--language", "python"}) {
+		t.Errorf("expected analysis flags after --, got %v", opts.Analysis)
+	}
+
+	for _, bad := range [][]string{{"--notes"}, {"--verbose"}, {"a", "b"}} {
+		if _, err := parseOrphansArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestTriageOrphans(t *testing.T) {
+	root := t.TempDir()
+	var orphans []*models.DependencyNode
+	for i, name := range []string{"nightly", "helper", "byName", "legacy", "later"} {
+		orphans = append(orphans, &models.DependencyNode{Type: "function", Name: name, Namespace: `App\Cron`, File: filepath.Join(root, "cron.php"), Line: i + 1})
+	}
+
+	// Unknown answers are asked again; an empty note keeps the default
+	in := strings.NewReader("x\ne\nRun by the scheduler\ns\nr\n\nk\n\nq\n")
+	entries := triageOrphans(in, root, orphans, "dana")
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 marked orphans, got %+v", entries)
+	}
+	if entries[0].Pattern != `App\\Cron\\nightly` || entries[0].Expected != "entrypoint" || entries[0].Text != "Run by the scheduler" {
+		t.Errorf("expected the entrypoint with its typed note, got %+v", entries[0])
+	}
+	if entries[1].Expected != "reflection" || entries[1].Text != "Invoked through reflection" || entries[1].Author != "dana" {
+		t.Errorf("expected the default note and the author, got %+v", entries[1])
+	}
+	if entries[2].Pattern != `App\\Cron\\legacy` || entries[2].Finding != "orphans" {
+		t.Errorf("expected legacy kept, got %+v", entries[2])
+	}
+
+	// Running out of input ends the triage like q does
+	if entries := triageOrphans(strings.NewReader("k\n"), root, orphans, ""); len(entries) != 1 {
+		t.Errorf("expected the answers before the end of input, got %+v", entries)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/portfolio"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+const portfolioUsage = "Usage: tukey portfolio [--previous <portfolio.json|dir>] [--format markdown|html] [-o <file>] <portfolio.json|dir|run-status.json>..."
+
+// portfolioOptions are the parsed arguments of `tukey portfolio`
+type portfolioOptions struct {
+	Inputs   []string // Batch summaries, batch output directories, or run statuses, merged
+	Previous string   // An earlier summary to show trends against
+	Format   string   // "markdown" or "html"
+	Output   string   // Empty for stdout
+}
+
+// parsePortfolioArgs parses the arguments following `tukey portfolio`
+func parsePortfolioArgs(args []string) (*portfolioOptions, error) {
+	opts := &portfolioOptions{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--previous", "--format", "-o", "--output":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			switch arg {
+			case "--previous":
+				opts.Previous = args[i+1]
+			case "--format":
+				opts.Format = args[i+1]
+			default:
+				opts.Output = args[i+1]
+			}
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Inputs = append(opts.Inputs, arg)
+		}
+	}
+
+	if len(opts.Inputs) == 0 {
+		return nil, fmt.Errorf("a portfolio summary or run status is required")
+	}
+	if opts.Format == "" {
+		opts.Format = "markdown"
+		if ext := strings.ToLower(filepath.Ext(opts.Output)); ext == ".html" || ext == ".htm" {
+			opts.Format = "html"
+		}
+	}
+	if opts.Format != "markdown" && opts.Format != "html" {
+		return nil, fmt.Errorf("--format must be markdown or html, got %q", opts.Format)
+	}
+	return opts, nil
+}
+
+// runPortfolio implements `tukey portfolio`: it lines up the projects of one or more
+// batch runs or single runs in a comparison table, with trends against an earlier batch
+// run, as Markdown or HTML
+func runPortfolio(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(portfolioUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parsePortfolioArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, portfolioUsage)
+		return runstatus.ExitUsage
+	}
+
+	var summaries []*portfolio.Summary
+	for _, input := range opts.Inputs {
+		summary, err := portfolio.Load(input)
+		if err != nil {
+			sayErr("❌ Failed to load %s: %v\n", input, err)
+			return runstatus.ExitUsage
+		}
+		summaries = append(summaries, summary)
+	}
+	current, err := portfolio.Merge(summaries...)
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+	var previous *portfolio.Summary
+	if opts.Previous != "" {
+		if previous, err = portfolio.Load(opts.Previous); err != nil {
+			sayErr("❌ Failed to load %s: %v\n", opts.Previous, err)
+			return runstatus.ExitUsage
+		}
+	}
+
+	comparison := portfolio.Compare(current, previous)
+	var out bytes.Buffer
+	if opts.Format == "html" {
+		err = comparison.HTML(&out)
+	} else {
+		err = comparison.Markdown(&out)
+	}
+	if err != nil {
+		sayErr("❌ Failed to render the comparison: %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	if opts.Output == "" {
+		os.Stdout.Write(out.Bytes())
+		return runstatus.ExitOK
+	}
+	if err := os.WriteFile(opts.Output, out.Bytes(), 0644); err != nil {
+		sayErr("❌ Failed to write %s: %v\n", opts.Output, err)
+		return runstatus.ExitInternal
+	}
+	say("💾 Portfolio comparison of %d projects saved to %s\n", len(comparison.Rows), opts.Output)
+	return runstatus.ExitOK
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePortfolioArgs(t *testing.T) {
+	opts, err := parsePortfolioArgs([]string{"--previous", "last-quarter", "-o", "review.html", "reports", "extra/run-status.json"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Previous != "last-quarter" || opts.Output != "review.html" || opts.Format != "html" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Inputs, []string{"reports", "extra/run-status.json"}) {
+		t.Errorf("unexpected inputs: %v", opts.Inputs)
+	}
+
+	opts, err = parsePortfolioArgs([]string{"reports"})
+	if err != nil || opts.Format != "markdown" || opts.Output != "" {
+		t.Errorf("expected Markdown to stdout by default, got %+v (%v)", opts, err)
+	}
+
+	for _, bad := range [][]string{
+		{},                             // no input
+		{"reports", "--format", "pdf"}, // unknown format
+		{"reports", "--since", "old"},  // unknown flag
+		{"reports", "--previous"},
+	} {
+		if _, err := parsePortfolioArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/update"
+)
+
+// runSelfUpdate implements `tukey self-update [--check]` and returns the exit code
+func runSelfUpdate(args []string) int {
+	checkOnly := false
+	for _, arg := range args {
+		switch arg {
+		case "--check":
+			checkOnly = true
+		case "-h", "--help":
+			fmt.Println("Usage: tukey self-update [--check]")
+			return runstatus.ExitOK
+		default:
+			sayErr("Error: unknown flag: %s\n", arg)
+			return runstatus.ExitUsage
+		}
+	}
+
+	updater := update.NewUpdater(update.DefaultRepo)
+	release, err := updater.Latest()
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	if !update.IsNewer(release.TagName, version) {
+		say("✅ Tukey v%s is up to date\n", displayVersion())
+		return runstatus.ExitOK
+	}
+
+	say("⬆️  Tukey %s is available (you have v%s)\n", release.TagName, displayVersion())
+	if checkOnly {
+		say("   Run `tukey self-update` to install it, or download it from %s\n", release.HTMLURL)
+		return runstatus.ExitOK
+	}
+
+	exePath, err := os.Executable()
+	if err == nil {
+		// Replace the real file when tukey is run through a symlink
+		exePath, err = filepath.EvalSymlinks(exePath)
+	}
+	if err != nil {
+		sayErr("❌ Failed to locate the tukey binary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	spinner := progress.NewSpinner(fmt.Sprintf("Installing %s...", release.TagName))
+	spinner.Start()
+	err = updater.Apply(release, exePath)
+	spinner.Stop()
+	if err != nil {
+		sayErr("❌ Update failed: %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	say("✅ Updated to Tukey %s\n", release.TagName)
+	return runstatus.ExitOK
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/diff"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/semver"
+)
+
+const semverUsage = "Usage: tukey semver --against <rev> [--json <file>] [<directory>] [-- <analysis flags>]"
+
+// semverOptions are the parsed arguments of `tukey semver`
+type semverOptions struct {
+	Against  string
+	JSONFile string
+	Dir      string
+	Analysis []string // Extra flags for both analysis runs, after "--"
+}
+
+// parseSemverArgs parses the arguments following `tukey semver`
+func parseSemverArgs(args []string) (*semverOptions, error) {
+	opts := &semverOptions{Dir: "."}
+	dirSet := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--":
+			opts.Analysis = append([]string(nil), args[i+1:]...)
+			i = len(args)
+		case "--against", "--json":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			if arg == "--against" {
+				opts.Against = args[i+1]
+			} else {
+				opts.JSONFile = args[i+1]
+			}
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") || dirSet {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Dir, dirSet = arg, true
+		}
+	}
+
+	if opts.Against == "" {
+		return nil, errors.New("--against is required")
+	}
+	return opts, nil
+}
+
+// runSemver implements `tukey semver`: it compares the public API of the working tree with
+// its API at a released revision, classifies each change per semver, and suggests the next
+// version. The release is analyzed in a temporary git worktree, so the working tree (and
+// any uncommitted changes in it) is left alone.
+func runSemver(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(semverUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseSemverArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, semverUsage)
+		return runstatus.ExitUsage
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		sayErr("❌ Can't locate the tukey binary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+
+	dir := opts.Dir
+	prefix, err := git(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		sayErr("❌ %s isn't in a git repository: %v\n", dir, err)
+		return runstatus.ExitUsage
+	}
+	release, err := git(dir, "rev-parse", "--verify", opts.Against+"^{commit}")
+	if err != nil {
+		sayErr("❌ Unknown revision %q\n", opts.Against)
+		return runstatus.ExitUsage
+	}
+
+	tmp, err := os.MkdirTemp("", "tukey-semver-")
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitInternal
+	}
+	defer os.RemoveAll(tmp)
+
+	worktree := filepath.Join(tmp, "tree")
+	if out, err := git(dir, "worktree", "add", "-q", "--detach", worktree, release); err != nil {
+		sayErr("❌ Failed to check out %s: %v\n%s\n", opts.Against, err, out)
+		return runstatus.ExitInternal
+	}
+	defer git(dir, "worktree", "remove", "--force", worktree)
+
+	say("📦 Comparing the public API of %s with %s\n", dir, opts.Against)
+
+	before, err := analyzeAPI(exe, filepath.Join(worktree, prefix), filepath.Join(tmp, "before.json"), opts.Analysis)
+	if err != nil {
+		sayErr("❌ Failed to analyze %s: %v\n", opts.Against, err)
+		return runstatus.ExitInternal
+	}
+	after, err := analyzeAPI(exe, dir, filepath.Join(tmp, "after.json"), opts.Analysis)
+	if err != nil {
+		sayErr("❌ Failed to analyze the working tree: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	if before == nil || after == nil {
+		sayErr("❌ No public API recorded; set apiNamespaces in config or pass --api-namespace after --\n")
+		return runstatus.ExitUsage
+	}
+
+	advice := semver.Classify(diff.CompareAPI(before, after), releaseVersion(dir, opts.Against, release))
+	printAdvice(opts.Against, advice)
+
+	if opts.JSONFile != "" {
+		data, err := json.MarshalIndent(advice, "", "  ")
+		if err == nil {
+			err = os.WriteFile(opts.JSONFile, data, 0644)
+		}
+		if err != nil {
+			sayErr("❌ Failed to write advice: %v\n", err)
+			return runstatus.ExitInternal
+		}
+		say("\n💾 Advice saved to %s\n", opts.JSONFile)
+	}
+	return runstatus.ExitOK
+}
+
+// analyzeAPI exports a report of dir with a child tukey process and returns its public API
+// surface, or nil when no API namespaces were configured
+func analyzeAPI(exe, dir, reportPath string, analysis []string) (*models.APISurface, error) {
+	statusPath := reportPath + ".status"
+	args := append([]string{"--output", reportPath, "--status-file", statusPath}, analysis...)
+	exec.Command(exe, append(args, dir)...).Run() // The exit code is also in the status file
+
+	data, err := os.ReadFile(statusPath)
+	if err != nil {
+		return nil, err
+	}
+	var status runstatus.Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("unreadable run status: %w", err)
+	}
+	if status.ExitCode >= runstatus.ExitUsage {
+		return nil, fmt.Errorf("analysis failed: %s", status.Error)
+	}
+	return diff.LoadAPISurface(reportPath)
+}
+
+// releaseVersion reads the version being compared against from the revision name, or else
+// from the nearest tag reachable from it. It returns nil when neither is a version.
+func releaseVersion(dir, rev, sha string) *semver.Version {
+	if v, err := semver.Parse(rev); err == nil {
+		return &v
+	}
+	if tag, err := git(dir, "describe", "--tags", "--abbrev=0", sha); err == nil {
+		if v, err := semver.Parse(tag); err == nil {
+			return &v
+		}
+	}
+	return nil
+}
+
+// printAdvice shows the suggested bump and the changes behind it
+func printAdvice(against string, advice *semver.Advice) {
+	if advice.Next != "" {
+		say("\n🏷️ Suggested bump: %s (%s → %s)\n", advice.Level, advice.Current, advice.Next)
+	} else {
+		say("\n🏷️ Suggested bump: %s (%s isn't a version tag)\n", advice.Level, against)
+	}
+	if len(advice.Changes) == 0 {
+		say("   No public API changes\n")
+		return
+	}
+
+	for i, change := range advice.Changes {
+		if i == diffListLimit {
+			say("   ... and %d more (use --json for the full list)\n", len(advice.Changes)-i)
+			break
+		}
+		say("   • [%s] %s %s %s: %s\n", change.Level, change.Change, change.Kind, change.Name, change.Reason)
+	}
+}
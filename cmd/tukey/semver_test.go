@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSemverArgs(t *testing.T) {
+	opts, err := parseSemverArgs([]string{"--against", "v2.3.0", "--json", "bump.json", "lib", "--", "--api-namespace", "Acme"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Against != "v2.3.0" || opts.JSONFile != "bump.json" || opts.Dir != "lib" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Analysis, []string{"--api-namespace", "Acme"}) {
+		t.Errorf("expected analysis flags after --, got %v", opts.Analysis)
+	}
+
+	for _, bad := range [][]string{
+		{},                              // missing --against
+		{"--against"},                   // missing value
+		{"--against", "v1", "a", "b"},   // two directories
+		{"--against", "v1", "--strict"}, // unknown flag
+	} {
+		if _, err := parseSemverArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/history"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/schedule"
+	"github.com/boone-studios/tukey/internal/server"
+)
+
+const serveUsage = `Usage: tukey serve [--addr <host:port>] [--schedule <cron>] [--history <dir>] [--keep <n>] [<directory>] [-- <analysis flags>]
+       tukey serve --projects <file> [--addr <host:port>] [--schedule <cron>] [--keep <n>]
+Access: [--auth <file>] [--tls-cert <file> --tls-key <file> [--client-ca <file>]]`
+
+// serveTokenEnv holds a write token for the API, for deployments that don't need a
+// credentials file
+const serveTokenEnv = "TUKEY_SERVE_TOKEN"
+
+// serveOptions are the parsed arguments of `tukey serve`
+type serveOptions struct {
+	Addr     string
+	AddrSet  bool
+	Schedule string
+	History  string
+	Keep     int
+	Dir      string
+	DirSet   bool
+	Projects string // Project list file; --schedule and --keep become defaults
+	Auth     string // Credentials file
+	TLSCert  string
+	TLSKey   string
+	ClientCA string   // Requires client certificates signed by this CA
+	Analysis []string // Extra flags for each analysis run, after "--"
+}
+
+// serveListener is where and how `tukey serve` accepts connections
+type serveListener struct {
+	Addr string
+	TLS  *tls.Config // nil serves plain HTTP
+	Auth bool        // Whether requests must authenticate
+}
+
+// parseServeArgs parses the arguments following `tukey serve`
+func parseServeArgs(args []string) (*serveOptions, error) {
+	opts := &serveOptions{Addr: "localhost:7878", Dir: "."}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--":
+			opts.Analysis = append([]string(nil), args[i+1:]...)
+			i = len(args)
+		case "--addr", "--schedule", "--history", "--keep", "--projects", "--auth", "--tls-cert", "--tls-key", "--client-ca":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			value := args[i+1]
+			i++
+			switch arg {
+			case "--addr":
+				opts.Addr, opts.AddrSet = value, true
+			case "--schedule":
+				if _, err := schedule.Parse(value); err != nil {
+					return nil, err
+				}
+				opts.Schedule = value
+			case "--history":
+				opts.History = value
+			case "--projects":
+				opts.Projects = value
+			case "--auth":
+				opts.Auth = value
+			case "--tls-cert":
+				opts.TLSCert = value
+			case "--tls-key":
+				opts.TLSKey = value
+			case "--client-ca":
+				opts.ClientCA = value
+			case "--keep":
+				keep, err := strconv.Atoi(value)
+				if err != nil || keep < 0 {
+					return nil, fmt.Errorf("--keep needs a non-negative integer, got %q", value)
+				}
+				opts.Keep = keep
+			}
+		default:
+			if strings.HasPrefix(arg, "-") || opts.DirSet {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Dir, opts.DirSet = arg, true
+		}
+	}
+
+	if opts.Projects != "" && (opts.DirSet || opts.History != "" || len(opts.Analysis) > 0) {
+		return nil, errors.New("with --projects, set each project's root, history, and flags in the project list")
+	}
+	if (opts.TLSCert == "") != (opts.TLSKey == "") {
+		return nil, errors.New("--tls-cert and --tls-key must be set together")
+	}
+	if opts.ClientCA != "" && opts.TLSCert == "" {
+		return nil, errors.New("--client-ca needs --tls-cert and --tls-key")
+	}
+	if opts.History == "" {
+		opts.History = filepath.Join(opts.Dir, ".tukey", "history")
+	}
+	return opts, nil
+}
+
+// runServe implements `tukey serve`: it analyzes each project at startup and on its
+// schedule, storing each snapshot, and serves snapshots and trends over HTTP
+func runServe(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(serveUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseServeArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, serveUsage)
+		return runstatus.ExitUsage
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		sayErr("❌ Can't locate the tukey binary: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	registry, listener, err := buildRegistry(opts, exe)
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Timeouts keep slow or idle clients from holding connections open indefinitely
+	httpServer := &http.Server{
+		Addr:              listener.Addr,
+		Handler:           registry.Handler(),
+		TLSConfig:         listener.TLS,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+	errs := make(chan error, 1)
+	scheme := "http"
+	if listener.TLS != nil {
+		scheme = "https"
+		go func() { errs <- httpServer.ListenAndServeTLS("", "") }()
+	} else {
+		go func() { errs <- httpServer.ListenAndServe() }()
+	}
+
+	say("🌐 Serving on %s://%s\n", scheme, listener.Addr)
+	if !listener.Auth && !loopback(listener.Addr) {
+		sayErr("⚠️ The API is open to anyone who can reach %s; set --auth or %s to require credentials\n", listener.Addr, serveTokenEnv)
+	}
+	for _, srv := range registry.Projects() {
+		say("   • %s: %s (history in %s", srv.Name, srv.Root, srv.Store.Dir)
+		if srv.Spec != "" {
+			say(", schedule %q", srv.Spec)
+		}
+		say(")\n")
+	}
+	go registry.Run(ctx)
+
+	select {
+	case err := <-errs:
+		sayErr("❌ Server failed: %v\n", err)
+		return runstatus.ExitInternal
+	case <-ctx.Done():
+	}
+
+	say("\n👋 Shutting down\n")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		sayErr("⚠️ Shutdown: %v\n", err)
+	}
+	return runstatus.ExitOK
+}
+
+// buildRegistry creates a server for the directory being served, or for each project in
+// the --projects list, and returns the registry and how to listen
+func buildRegistry(opts *serveOptions, exe string) (*server.Registry, *serveListener, error) {
+	projects := []config.ServeProject{{
+		Root:     opts.Dir,
+		Schedule: opts.Schedule,
+		History:  opts.History,
+		Keep:     opts.Keep,
+		Flags:    opts.Analysis,
+	}}
+	listener := &serveListener{Addr: opts.Addr}
+	tlsFiles := config.TLSConfig{Cert: opts.TLSCert, Key: opts.TLSKey, ClientCA: opts.ClientCA}
+	var authConfigs []*config.AuthConfig
+	if opts.Projects != "" {
+		cfg, err := config.LoadServeConfig(opts.Projects)
+		if err != nil {
+			return nil, nil, err
+		}
+		projects = cfg.Projects
+		if cfg.Addr != "" && !opts.AddrSet {
+			listener.Addr = cfg.Addr
+		}
+		if cfg.TLS != nil && tlsFiles.Cert == "" {
+			tlsFiles = *cfg.TLS
+		}
+		if cfg.Auth != nil {
+			authConfigs = append(authConfigs, cfg.Auth)
+		}
+	}
+	if opts.Auth != "" {
+		cfg, err := config.LoadAuthConfig(opts.Auth)
+		if err != nil {
+			return nil, nil, err
+		}
+		authConfigs = append(authConfigs, cfg)
+	}
+
+	registry := server.NewRegistry()
+	for _, project := range projects {
+		if project.Name == "" {
+			abs, _ := filepath.Abs(project.Root)
+			project.Name = filepath.Base(abs)
+		}
+		if project.Schedule == "" {
+			project.Schedule = opts.Schedule
+		}
+		if project.Keep == 0 {
+			project.Keep = opts.Keep
+		}
+
+		store, err := history.Open(project.History)
+		if err != nil {
+			return nil, nil, err
+		}
+		srv := &server.Server{
+			Name:    project.Name,
+			Root:    project.Root,
+			Store:   store,
+			Spec:    project.Schedule,
+			Keep:    project.Keep,
+			Analyze: childAnalyzer(exe, project.Root, project.Flags),
+			Logf:    func(format string, args ...interface{}) { say(format, args...) },
+		}
+		if project.Schedule != "" {
+			if srv.Schedule, err = schedule.Parse(project.Schedule); err != nil {
+				return nil, nil, fmt.Errorf("project %q: %w", project.Name, err)
+			}
+		}
+		if err := registry.Add(srv); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	credentials, err := serveCredentials(authConfigs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(credentials) > 0 {
+		auth, err := server.NewAuth(credentials)
+		if err != nil {
+			return nil, nil, err
+		}
+		registry.SetAuth(auth)
+		listener.Auth = true
+	}
+	if tlsFiles.Cert != "" || tlsFiles.Key != "" {
+		if listener.TLS, err = serveTLS(tlsFiles); err != nil {
+			return nil, nil, err
+		}
+	}
+	return registry, listener, nil
+}
+
+// serveCredentials collects the API credentials from the auth configs and
+// TUKEY_SERVE_TOKEN, reading secrets kept in the environment
+func serveCredentials(configs []*config.AuthConfig) ([]server.Credential, error) {
+	var credentials []server.Credential
+	if token := os.Getenv(serveTokenEnv); token != "" {
+		credentials = append(credentials, server.Credential{Name: serveTokenEnv, Secret: token, Role: server.RoleWrite})
+	}
+	for _, cfg := range configs {
+		for _, token := range cfg.Tokens {
+			secret, err := secretFrom(token.Token, token.TokenEnv, token.Name)
+			if err != nil {
+				return nil, err
+			}
+			credentials = append(credentials, server.Credential{
+				Name:     token.Name,
+				Secret:   secret,
+				Role:     server.Role(token.Role),
+				Projects: token.Projects,
+			})
+		}
+		for _, user := range cfg.Users {
+			secret, err := secretFrom(user.Password, user.PasswordEnv, user.Name)
+			if err != nil {
+				return nil, err
+			}
+			credentials = append(credentials, server.Credential{
+				Name:     user.Name,
+				Secret:   secret,
+				Basic:    true,
+				Role:     server.Role(user.Role),
+				Projects: user.Projects,
+			})
+		}
+	}
+	return credentials, nil
+}
+
+// secretFrom returns value, or the environment variable env when it's named
+func secretFrom(value, env, name string) (string, error) {
+	if env == "" {
+		return value, nil
+	}
+	secret := os.Getenv(env)
+	if secret == "" {
+		return "", fmt.Errorf("credential %q: $%s is not set", name, env)
+	}
+	return secret, nil
+}
+
+// serveTLS loads the server certificate and, for mutual TLS, the CA that client
+// certificates must be signed by
+func serveTLS(files config.TLSConfig) (*tls.Config, error) {
+	if files.Cert == "" || files.Key == "" {
+		return nil, errors.New("TLS needs both a certificate and a key")
+	}
+	cert, err := tls.LoadX509KeyPair(files.Cert, files.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if files.ClientCA != "" {
+		pem, err := os.ReadFile(files.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", files.ClientCA)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// loopback reports whether addr only accepts connections from this machine
+func loopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// childAnalyzer analyzes dir by running the tukey binary itself, so scheduled snapshots
+// are exactly what `tukey -o <report> <dir>` would produce
+func childAnalyzer(exe, dir string, flags []string) server.AnalyzeFunc {
+	return func(reportPath, statusPath string) error {
+		args := append(append([]string{}, flags...), "--output", reportPath, "--status-file", statusPath, dir)
+		cmd := exec.Command(exe, args...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		cmd.Run() // Failures are recorded in the status file
+
+		if _, err := os.Stat(statusPath); err != nil {
+			return fmt.Errorf("analysis failed: %s", strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/server"
+)
+
+func TestParseServeArgs(t *testing.T) {
+	opts, err := parseServeArgs([]string{"--schedule", "0 */6 * * *", "--keep", "48", "app", "--", "--framework", "drupal"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Addr != "localhost:7878" || opts.Schedule != "0 */6 * * *" || opts.Keep != 48 || opts.Dir != "app" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts.History != filepath.Join("app", ".tukey", "history") {
+		t.Errorf("expected history to default under the project, got %q", opts.History)
+	}
+	if !reflect.DeepEqual(opts.Analysis, []string{"--framework", "drupal"}) {
+		t.Errorf("expected analysis flags after --, got %v", opts.Analysis)
+	}
+
+	for _, bad := range [][]string{
+		{"--schedule", "every hour"},
+		{"--keep", "-1"},
+		{"--addr"},
+		{"a", "b"},
+		{"--verbose"},
+		{"--tls-cert", "server.pem"},
+		{"--client-ca", "ca.pem"},
+	} {
+		if _, err := parseServeArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestBuildRegistry_Projects(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "projects.yml")
+	content := `
+addr: ":9000"
+projects:
+  - root: api
+    schedule: "@daily"
+  - name: web
+    root: frontend
+`
+	if err := os.WriteFile(list, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := parseServeArgs([]string{"--projects", list, "--schedule", "@hourly", "--keep", "10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	registry, listener, err := buildRegistry(opts, "tukey")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listener.Addr != ":9000" || listener.Auth || listener.TLS != nil {
+		t.Errorf("expected the project list's address without auth or TLS, got %+v", listener)
+	}
+
+	projects := registry.Projects()
+	if len(projects) != 2 || projects[0].Name != "api" || projects[1].Name != "web" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+	if projects[0].Spec != "@daily" || projects[1].Spec != "@hourly" || projects[1].Keep != 10 {
+		t.Errorf("expected --schedule and --keep to fill in defaults, got %q/%q keep %d", projects[0].Spec, projects[1].Spec, projects[1].Keep)
+	}
+
+	if _, err := parseServeArgs([]string{"--projects", list, "app"}); err == nil {
+		t.Errorf("expected an error combining --projects with a directory")
+	}
+}
+
+func TestServeCredentials(t *testing.T) {
+	t.Setenv(serveTokenEnv, "ci-secret")
+	t.Setenv("TUKEY_DASHBOARD_TOKEN", "dash-secret")
+	cfg := &config.AuthConfig{
+		Tokens: []config.AuthToken{{Name: "dashboard", TokenEnv: "TUKEY_DASHBOARD_TOKEN", Projects: []string{"api"}}},
+		Users:  []config.AuthUser{{Name: "alice", Password: "hunter2", Role: "write"}},
+	}
+
+	credentials, err := serveCredentials([]*config.AuthConfig{cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []server.Credential{
+		{Name: serveTokenEnv, Secret: "ci-secret", Role: server.RoleWrite},
+		{Name: "dashboard", Secret: "dash-secret", Projects: []string{"api"}},
+		{Name: "alice", Secret: "hunter2", Basic: true, Role: server.RoleWrite},
+	}
+	if !reflect.DeepEqual(credentials, want) {
+		t.Errorf("expected %+v, got %+v", want, credentials)
+	}
+
+	cfg.Tokens[0].TokenEnv = "TUKEY_UNSET_TOKEN"
+	if _, err := serveCredentials([]*config.AuthConfig{cfg}); err == nil {
+		t.Errorf("expected an error for a token whose variable is unset")
+	}
+}
+
+func TestBuildRegistry_AuthFile(t *testing.T) {
+	t.Setenv(serveTokenEnv, "")
+	authFile := filepath.Join(t.TempDir(), "auth.yml")
+	if err := os.WriteFile(authFile, []byte("tokens:\n  - name: ci\n    token: s3cret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := parseServeArgs([]string{"--auth", authFile, "--history", t.TempDir(), t.TempDir()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, listener, err := buildRegistry(opts, "tukey")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !listener.Auth {
+		t.Errorf("expected --auth to require credentials")
+	}
+}
+
+func TestLoopback(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"localhost:7878": true,
+		"127.0.0.1:80":   true,
+		"[::1]:7878":     true,
+		":7878":          false,
+		"0.0.0.0:7878":   false,
+		"ci.example:80":  false,
+	} {
+		if got := loopback(addr); got != want {
+			t.Errorf("loopback(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
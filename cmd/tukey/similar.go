@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+	"github.com/boone-studios/tukey/internal/scanner"
+	"github.com/boone-studios/tukey/internal/similar"
+)
+
+const similarUsage = "Usage: tukey similar <symbol> [--language <lang>] [--limit <n>] [--min <score>] [<directory>]"
+
+// similarOptions are the parsed arguments of `tukey similar`
+type similarOptions struct {
+	Symbol   string
+	Language string
+	Limit    int
+	Min      float64 // Lowest score reported, from 0 to 1
+	Dir      string
+}
+
+// parseSimilarArgs parses the arguments following `tukey similar`
+func parseSimilarArgs(args []string) (*similarOptions, error) {
+	opts := &similarOptions{Language: "php", Limit: similar.DefaultLimit, Min: similar.DefaultMinScore, Dir: "."}
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "-l", "--language", "--limit", "--min":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			value := args[i+1]
+			i++
+			switch arg {
+			case "--limit":
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("--limit needs a positive integer, got %q", value)
+				}
+				opts.Limit = n
+			case "--min":
+				min, err := strconv.ParseFloat(value, 64)
+				if err != nil || min < 0 || min > 1 {
+					return nil, fmt.Errorf("--min needs a score from 0 to 1, got %q", value)
+				}
+				opts.Min = min
+			default:
+				opts.Language = value
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			positional = append(positional, arg)
+		}
+	}
+
+	switch len(positional) {
+	case 0:
+		return nil, fmt.Errorf("a symbol is required")
+	case 1, 2:
+		opts.Symbol = positional[0]
+		if len(positional) == 2 {
+			opts.Dir = positional[1]
+		}
+	default:
+		return nil, fmt.Errorf("unexpected argument %q", positional[2])
+	}
+	if _, ok := parser.Get(opts.Language); !ok {
+		return nil, fmt.Errorf("unsupported language %q (supported: %v)", opts.Language, parser.SupportedLanguages())
+	}
+	return opts, nil
+}
+
+// runSimilar implements `tukey similar`: it analyzes the directory and lists the elements
+// most like the symbol, by shared dependencies, parameters, and naming, to find
+// copy-pasted implementations and candidates for consolidation
+func runSimilar(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(similarUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseSimilarArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, similarUsage)
+		return runstatus.ExitUsage
+	}
+
+	// The report doesn't keep parameters, so this analyzes in-process rather than through
+	// a child run
+	p, _ := parser.Get(opts.Language)
+	fileScanner := scanner.NewScanner(opts.Dir)
+	fileScanner.SetExtensions(p.FileExtensions())
+	fileScanner.AddDefaultExcludes(p.DefaultExcludes())
+	files, err := fileScanner.ScanFiles()
+	if err != nil {
+		sayErr("❌ Error scanning files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	parsed, err := p.ProcessFiles(files, progress.NewProgressBar(len(files), "Parsing"))
+	printParseErrors()
+	if err != nil {
+		sayErr("❌ Error parsing files: %v\n", err)
+		return runstatus.ExitInternal
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(parsed)
+
+	candidates := findSymbol(graph, opts.Symbol)
+	switch len(candidates) {
+	case 0:
+		sayErr("❌ No element named %s\n", opts.Symbol)
+		return runstatus.ExitUsage
+	case 1:
+	default:
+		sayErr("❌ %s is ambiguous; qualify it as one of:\n", opts.Symbol)
+		for _, node := range candidates {
+			sayErr("   • %s\n", analyzer.QualifiedName(node))
+		}
+		return runstatus.ExitUsage
+	}
+
+	root, _ := filepath.Abs(opts.Dir)
+	target := candidates[0]
+	matches := similar.Find(graph, parsed, target, opts.Min, opts.Limit)
+	say("\n🔎 Elements similar to %s %s (%s:%d):\n", target.Type, analyzer.QualifiedName(target), relativePath(root, target.File), target.Line)
+	if len(matches) == 0 {
+		say("   None scored %.0f%% or more\n", opts.Min*100)
+		return runstatus.ExitOK
+	}
+	for _, match := range matches {
+		say("   %3.0f%%  %s (%s:%d)\n", match.Score*100, analyzer.QualifiedName(match.Node), relativePath(root, match.Node.File), match.Node.Line)
+		if len(match.Reasons) > 0 {
+			say("         %s\n", strings.Join(match.Reasons, ", "))
+		}
+	}
+	return runstatus.ExitOK
+}
+
+// findSymbol returns the nodes symbol names: the one with that qualified name if there is
+// one, else those whose qualified name ends with it
+func findSymbol(graph *models.DependencyGraph, symbol string) []*models.DependencyNode {
+	var found []*models.DependencyNode
+	for _, node := range graph.Nodes {
+		name := analyzer.QualifiedName(node)
+		if name == symbol {
+			return []*models.DependencyNode{node}
+		}
+		if strings.HasSuffix(name, `\`+symbol) || strings.HasSuffix(name, "::"+symbol) || node.Name == symbol {
+			found = append(found, node)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return analyzer.QualifiedName(found[i]) < analyzer.QualifiedName(found[j])
+	})
+	return found
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestParseSimilarArgs(t *testing.T) {
+	opts, err := parseSimilarArgs([]string{`Invoice::total`, "--limit", "3", "src", "--min", "0.8", "-l", "python"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Symbol != "Invoice::total" || opts.Dir != "src" || opts.Limit != 3 || opts.Min != 0.8 || opts.Language != "python" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	for _, bad := range [][]string{{}, {"x", "--min", "2"}, {"x", "--limit", "0"}, {"x", "-l", "cobol"}, {"x", "a", "b"}, {"x", "--verbose"}} {
+		if _, err := parseSimilarArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestFindSymbol(t *testing.T) {
+	graph := &models.DependencyGraph{Nodes: map[string]*models.DependencyNode{
+		"a": {ID: "a", Name: "total", Namespace: `App\Billing`, ClassName: "Invoice"},
+		"b": {ID: "b", Name: "total", Namespace: `App\Billing`, ClassName: "Quote"},
+		"c": {ID: "c", Name: "Invoice", Namespace: `App\Billing`},
+	}}
+	if found := findSymbol(graph, "Invoice::total"); len(found) != 1 || found[0].ID != "a" {
+		t.Errorf("expected the method, got %+v", found)
+	}
+	if found := findSymbol(graph, `App\Billing\Invoice`); len(found) != 1 || found[0].ID != "c" {
+		t.Errorf("expected the exact qualified name, got %+v", found)
+	}
+	if found := findSymbol(graph, "total"); len(found) != 2 {
+		t.Errorf("expected both methods named total, got %+v", found)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// constraintVersionPattern finds the versions in a Composer or npm version constraint
+var constraintVersionPattern = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// nodeEditions maps Node.js major versions to the newest ECMAScript edition they fully
+// support, by year
+var nodeEditions = []struct {
+	node    int
+	edition string
+}{
+	{22, "2024"}, {20, "2023"}, {18, "2022"}, {16, "2021"}, {14, "2020"},
+	{12, "2019"}, {10, "2018"}, {8, "2017"}, {6, "2015"},
+}
+
+// declaredVersion is the oldest language version the project says it supports: the PHP
+// version composer.json requires, or the ECMAScript edition of the oldest Node.js in
+// package.json's engines. It returns the version and the file it's from, or "" for both.
+func declaredVersion(root, language string) (string, string) {
+	switch language {
+	case "php":
+		var manifest struct {
+			Require map[string]string `json:"require"`
+		}
+		if readManifest(filepath.Join(root, "composer.json"), &manifest) {
+			if version := constraintFloor(manifest.Require["php"]); version != "" {
+				return version, "composer.json"
+			}
+		}
+	case "javascript":
+		var manifest struct {
+			Engines map[string]string `json:"engines"`
+		}
+		if readManifest(filepath.Join(root, "package.json"), &manifest) {
+			major, err := strconv.Atoi(strings.Split(constraintFloor(manifest.Engines["node"]), ".")[0])
+			if err != nil {
+				return "", ""
+			}
+			for _, known := range nodeEditions {
+				if major >= known.node {
+					return known.edition, "package.json"
+				}
+			}
+			return "2009", "package.json"
+		}
+	}
+	return "", ""
+}
+
+// readManifest decodes a JSON manifest into v, reporting whether it could
+func readManifest(path string, v interface{}) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && json.Unmarshal(data, v) == nil
+}
+
+// constraintFloor is the oldest major.minor version a constraint such as "^7.4 || ^8.0"
+// or ">=14.17" allows, or "" when it names none
+func constraintFloor(constraint string) string {
+	floor := ""
+	for _, version := range constraintVersionPattern.FindAllString(constraint, -1) {
+		if parts := strings.SplitN(version, ".", 3); len(parts) == 3 {
+			version = parts[0] + "." + parts[1]
+		}
+		if floor == "" || versionBefore(version, floor) {
+			floor = version
+		}
+	}
+	return floor
+}
+
+// versionBefore reports whether major.minor version a is older than b
+func versionBefore(a, b string) bool {
+	as, bs := strings.Split(a+".0", "."), strings.Split(b+".0", ".")
+	for i := 0; i < 2; i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
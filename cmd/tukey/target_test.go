@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConstraintFloor(t *testing.T) {
+	tests := map[string]string{
+		"^7.4 || ^8.0":  "7.4",
+		">=8.1 <8.4":    "8.1",
+		"~7.2.5":        "7.2",
+		">=14.17.0":     "14.17",
+		"8.*":           "8",
+		"*":             "",
+		"":              "",
+		"^8.0|^7.3.10 ": "7.3",
+	}
+	for constraint, want := range tests {
+		if got := constraintFloor(constraint); got != want {
+			t.Errorf("constraintFloor(%q) = %q, want %q", constraint, got, want)
+		}
+	}
+}
+
+func TestDeclaredVersion(t *testing.T) {
+	dir := t.TempDir()
+	if version, source := declaredVersion(dir, "php"); version != "" || source != "" {
+		t.Errorf("expected no version without a manifest, got %q from %q", version, source)
+	}
+
+	os.WriteFile(filepath.Join(dir, "composer.json"), []byte(`{"require": {"php": "^7.4 || ^8.0", "ext-json": "*"}}`), 0644)
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"engines": {"node": ">=15"}}`), 0644)
+	if version, source := declaredVersion(dir, "php"); version != "7.4" || source != "composer.json" {
+		t.Errorf("expected PHP 7.4 from composer.json, got %q from %q", version, source)
+	}
+	if version, source := declaredVersion(dir, "javascript"); version != "2020" || source != "package.json" {
+		t.Errorf("expected Node 15's edition, ES2020, from package.json, got %q from %q", version, source)
+	}
+	if version, _ := declaredVersion(dir, "go"); version != "" {
+		t.Errorf("expected no declared version for Go, got %q", version)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/boone-studios/tukey/internal/provenance"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// runVerify implements `tukey verify <report.json>` and returns the exit code
+func runVerify(args []string) int {
+	if len(args) != 1 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Println("Usage: tukey verify <report.json>")
+		if len(args) == 1 {
+			return runstatus.ExitOK
+		}
+		return runstatus.ExitUsage
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitUsage
+	}
+
+	prov, err := provenance.Verify(data, []byte(os.Getenv(provenance.KeyEnv)))
+	if err != nil {
+		sayErr("❌ %s: %v\n", args[0], err)
+		return runstatus.ExitVerifyFailed
+	}
+
+	say("✅ %s is intact (%s)\n", args[0], prov.Algorithm)
+	say("   Generated: %s by %s v%s\n", prov.Timestamp, prov.Tool, prov.ToolVersion)
+	say("   Analyzed:  %s", prov.Root)
+	if prov.GitCommit != "" {
+		say(" @ %s", prov.GitCommit)
+		if prov.GitDirty {
+			say(" (uncommitted changes)")
+		}
+	}
+	say("\n")
+	return runstatus.ExitOK
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/provenance"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+func TestRunVerify_ExitCodes(t *testing.T) {
+	t.Setenv(provenance.KeyEnv, "")
+	report := struct {
+		TotalFiles int                `json:"totalFiles"`
+		Provenance *models.Provenance `json:"provenance"`
+	}{
+		TotalFiles: 3,
+		Provenance: &models.Provenance{Tool: "tukey", ToolVersion: "1.0.0", Root: "/src", Algorithm: "sha256"},
+	}
+	data, _ := json.Marshal(report)
+	digest, err := provenance.Digest(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.Provenance.Checksum = digest
+	data, _ = json.Marshal(report)
+
+	dir := t.TempDir()
+	intact, tampered := filepath.Join(dir, "intact.json"), filepath.Join(dir, "tampered.json")
+	os.WriteFile(intact, data, 0644)
+	os.WriteFile(tampered, bytes.Replace(data, []byte(`"totalFiles":3`), []byte(`"totalFiles":4`), 1), 0644)
+
+	for _, tc := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{intact}, runstatus.ExitOK},
+		{[]string{"--help"}, runstatus.ExitOK},
+		{nil, runstatus.ExitUsage},
+		{[]string{filepath.Join(dir, "missing.json")}, runstatus.ExitUsage},
+		{[]string{tampered}, runstatus.ExitVerifyFailed},
+	} {
+		var code int
+		captureOutput(func() { code = runVerify(tc.args) })
+		if code != tc.want {
+			t.Errorf("runVerify(%v): expected exit code %d, got %d", tc.args, tc.want, code)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/distribute"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+const workUsage = "Usage: tukey work --connect <host:port> [--wait <duration>] [<directory>]"
+
+// workOptions are the parsed arguments of `tukey work`
+type workOptions struct {
+	Connect string
+	Wait    time.Duration // How long to keep retrying the coordinator
+	Dir     string        // This worker's checkout of the analyzed tree
+}
+
+// parseWorkArgs parses the arguments following `tukey work`
+func parseWorkArgs(args []string) (*workOptions, error) {
+	opts := &workOptions{Wait: 30 * time.Second, Dir: "."}
+	dirSet := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--connect", "--wait":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", arg)
+			}
+			value := args[i+1]
+			i++
+			if arg == "--connect" {
+				opts.Connect = value
+				continue
+			}
+			wait, err := time.ParseDuration(value)
+			if err != nil || wait < 0 {
+				return nil, fmt.Errorf("--wait needs a duration such as 30s or 5m, got %q", value)
+			}
+			opts.Wait = wait
+		default:
+			if strings.HasPrefix(arg, "-") || dirSet {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			opts.Dir, dirSet = arg, true
+		}
+	}
+
+	if opts.Connect == "" {
+		return nil, errors.New("--connect is required")
+	}
+	return opts, nil
+}
+
+// runWork implements `tukey work`: it parses shards of files for a coordinator started
+// with --distribute, reading them from its own checkout of the tree
+func runWork(args []string) int {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		fmt.Println(workUsage)
+		return runstatus.ExitOK
+	}
+	opts, err := parseWorkArgs(args)
+	if err != nil {
+		sayErr("❌ %v\n%s\n", err, workUsage)
+		return runstatus.ExitUsage
+	}
+
+	say("👷 Working for %s on %s\n", opts.Connect, opts.Dir)
+	parse := func(language string, files []models.FileInfo) ([]*models.ParsedFile, error) {
+		lp, ok := parser.Get(language)
+		if !ok {
+			return nil, fmt.Errorf("unsupported language: %s", language)
+		}
+		parsed, err := lp.ProcessFiles(files, progress.NewProgressBar(len(files), fmt.Sprintf("Parsing %s files", language)))
+		printParseErrors()
+		return parsed, err
+	}
+	shards, err := distribute.Work(opts.Connect, opts.Dir, displayVersion(), opts.Wait, parse)
+	if err != nil {
+		sayErr("❌ %v\n", err)
+		return runstatus.ExitInternal
+	}
+	say("✅ Done: parsed %d shards\n", shards)
+	return runstatus.ExitOK
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWorkArgs(t *testing.T) {
+	opts, err := parseWorkArgs([]string{"--connect", "coordinator:7879", "/src/monorepo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Connect != "coordinator:7879" || opts.Dir != "/src/monorepo" || opts.Wait != 30*time.Second {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	opts, err = parseWorkArgs([]string{"--wait", "5m", "--connect", "10.0.0.2:7879"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Wait != 5*time.Minute || opts.Dir != "." {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	for _, bad := range [][]string{
+		{},            // missing --connect
+		{"--connect"}, // missing value
+		{"--connect", "a:1", "--wait", "soon"},
+		{"--connect", "a:1", "x", "y"}, // two directories
+	} {
+		if _, err := parseWorkArgs(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
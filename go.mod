@@ -1,5 +1,8 @@
 module github.com/boone-studios/tukey
 
-go 1.22
+go 1.22.0
 
-require gopkg.in/yaml.v3 v3.0.1
+require (
+	github.com/tetratelabs/wazero v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// apiKinds are the element types that can be part of a public API
+var apiKinds = map[string]bool{
+	"class": true, "interface": true, "trait": true, "enum": true, "module": true,
+	"function": true, "method": true, "property": true, "constant": true,
+}
+
+// PublicAPI derives the public API surface of the given namespaces (and the namespaces
+// beneath them): their classes, interfaces, traits, enums, and functions, and the public
+// members of their classes. Files are recorded relative to root. It returns nil when no
+// namespaces are given.
+func PublicAPI(root string, files []*models.ParsedFile, namespaces []string) *models.APISurface {
+	var prefixes []string
+	for _, namespace := range namespaces {
+		if namespace = strings.Trim(namespace, `\`); namespace != "" {
+			prefixes = append(prefixes, namespace)
+		}
+	}
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	surface := &models.APISurface{Namespaces: prefixes, Symbols: []*models.APISymbol{}}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		for _, element := range file.Elements {
+			if !apiKinds[element.Type] || !inNamespaces(element.Namespace, prefixes) {
+				continue
+			}
+			if element.Visibility != "" && element.Visibility != "public" {
+				continue
+			}
+
+			name := apiName(element)
+			if seen[name] {
+				continue // Conditional declarations of the same name; the first one stands
+			}
+			seen[name] = true
+			surface.Symbols = append(surface.Symbols, &models.APISymbol{
+				Name:      name,
+				Kind:      element.Type,
+				Signature: apiSignature(element),
+				File:      relativeTo(root, file.Path),
+				Line:      element.Line,
+			})
+		}
+	}
+
+	sort.Slice(surface.Symbols, func(i, j int) bool { return surface.Symbols[i].Name < surface.Symbols[j].Name })
+	return surface
+}
+
+// inNamespaces reports whether namespace is one of prefixes or nested in one
+func inNamespaces(namespace string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if namespace == prefix || strings.HasPrefix(namespace, prefix+`\`) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiName qualifies an element the way PHP refers to it: App\Invoice, App\Invoice::total,
+// App\Invoice::$amount, App\Invoice::CURRENCY
+func apiName(element models.CodeElement) string {
+	qualify := func(name string) string {
+		if element.Namespace == "" {
+			return name
+		}
+		return element.Namespace + `\` + name
+	}
+	if element.ClassName == "" {
+		return qualify(element.Name)
+	}
+	member := element.Name
+	if element.Type == "property" {
+		member = "$" + member
+	}
+	return qualify(element.ClassName) + "::" + member
+}
+
+// apiSignature renders the parts of a declaration callers depend on: its kind and
+// modifiers, and for functions the typed parameter list and return type
+func apiSignature(element models.CodeElement) string {
+	var parts []string
+	if element.ClassName != "" {
+		parts = append(parts, "public")
+	}
+	if element.IsAbstract {
+		parts = append(parts, "abstract")
+	}
+	if element.IsStatic {
+		parts = append(parts, "static")
+	}
+	if element.IsReadonly {
+		parts = append(parts, "readonly")
+	}
+
+	switch element.Type {
+	case "function", "method":
+		params := make([]string, len(element.Parameters))
+		for i, param := range element.Parameters {
+			params[i] = "$" + param
+			if i < len(element.ParamTypes) && element.ParamTypes[i] != "" {
+				params[i] = element.ParamTypes[i] + " " + params[i]
+			}
+		}
+		signature := "function " + element.Name + "(" + strings.Join(params, ", ") + ")"
+		if element.ReturnType != "" {
+			signature += ": " + element.ReturnType
+		}
+		parts = append(parts, signature)
+	case "property":
+		parts = append(parts, "$"+element.Name)
+	case "constant":
+		parts = append(parts, "const "+element.Name)
+	default:
+		parts = append(parts, element.Type+" "+element.Name)
+	}
+	return strings.Join(parts, " ")
+}
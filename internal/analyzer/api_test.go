@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestPublicAPI(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:      "proj/src/Billing/Invoice.php",
+			Namespace: `Acme\Billing`,
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Invoice", Namespace: `Acme\Billing`, IsAbstract: true, Line: 3},
+				{Type: "method", Name: "total", Namespace: `Acme\Billing`, ClassName: "Invoice", Visibility: "public",
+					Parameters: []string{"tax", "currency"}, ParamTypes: []string{"float", ""}, ReturnType: "int", Line: 5},
+				{Type: "method", Name: "round", Namespace: `Acme\Billing`, ClassName: "Invoice", Visibility: "private", Line: 9},
+				{Type: "method", Name: "make", Namespace: `Acme\Billing`, ClassName: "Invoice", Visibility: "public", IsStatic: true, Line: 12},
+				{Type: "property", Name: "amount", Namespace: `Acme\Billing`, ClassName: "Invoice", Visibility: "public", IsReadonly: true, Line: 4},
+				{Type: "constant", Name: "CURRENCY", Namespace: `Acme\Billing`, ClassName: "Invoice", Visibility: "public", Line: 2},
+			},
+		},
+		{
+			Path:      "proj/src/helpers.php",
+			Namespace: `Acme`,
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "format_money", Namespace: `Acme`, Parameters: []string{"cents"}, Line: 1},
+			},
+		},
+		{
+			Path:      "proj/tests/InvoiceTest.php",
+			Namespace: `Tests`,
+			Elements:  []models.CodeElement{{Type: "class", Name: "InvoiceTest", Namespace: `Tests`, Line: 1}},
+		},
+	}
+
+	surface := PublicAPI("proj", files, []string{`\Acme\`})
+	if surface == nil || len(surface.Namespaces) != 1 || surface.Namespaces[0] != "Acme" {
+		t.Fatalf("unexpected surface: %+v", surface)
+	}
+
+	want := []struct{ name, signature string }{
+		{`Acme\Billing\Invoice`, "abstract class Invoice"},
+		{`Acme\Billing\Invoice::$amount`, "public readonly $amount"},
+		{`Acme\Billing\Invoice::CURRENCY`, "public const CURRENCY"},
+		{`Acme\Billing\Invoice::make`, "public static function make()"},
+		{`Acme\Billing\Invoice::total`, "public function total(float $tax, $currency): int"},
+		{`Acme\format_money`, "function format_money($cents)"},
+	}
+	if len(surface.Symbols) != len(want) {
+		t.Fatalf("expected %d symbols, got %d: %+v", len(want), len(surface.Symbols), surface.Symbols)
+	}
+	for i, symbol := range surface.Symbols {
+		if symbol.Name != want[i].name || symbol.Signature != want[i].signature {
+			t.Errorf("symbol %d: got %s %q, want %s %q", i, symbol.Name, symbol.Signature, want[i].name, want[i].signature)
+		}
+	}
+	if surface.Symbols[0].File != "src/Billing/Invoice.php" {
+		t.Errorf("expected files relative to the root, got %s", surface.Symbols[0].File)
+	}
+
+	if PublicAPI("proj", files, nil) != nil {
+		t.Error("expected no surface without namespaces")
+	}
+}
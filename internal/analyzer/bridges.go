@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// scriptExtensions are the assets that load code, as opposed to styles and images
+var scriptExtensions = map[string]bool{
+	".js": true, ".jsx": true, ".mjs": true, ".cjs": true, ".ts": true, ".tsx": true, ".vue": true,
+}
+
+// mixStepPattern matches a Laravel Mix build step: mix.js('resources/js/app.js', 'public/js')
+var mixStepPattern = regexp.MustCompile(`\.(?:js|ts|react|vue)\(\s*['"]([^'"]+)['"]\s*,\s*['"]([^'"]+)['"]`)
+
+// routeEntry is a route node with the method and path segments requests are matched on
+type routeEntry struct {
+	node     *models.DependencyNode
+	method   string
+	segments []string
+	handler  string // Node ID of the first action that resolved
+}
+
+// EnableBridges links references that cross languages. Server-side code that loads a
+// script (Laravel Mix's mix(), Vite's @vite) depends on an "asset" node for it, and
+// scripts that make HTTP requests (fetch, axios) depend on the "route" node serving them,
+// which depends on its controller action; both are "cross_language" edges, listed in the
+// graph's bridge report. Asset paths and webpack.mix.js are looked up under root.
+func (dt *DependencyTracker) EnableBridges(root string) {
+	dt.routing = true
+	dt.bridgeRoot = root
+	dt.bridges = &models.BridgeReport{Bridges: []*models.Bridge{}, Unmatched: []*models.Bridge{}}
+}
+
+// EnableRoutes adds a "route" node for each HTTP route the code defines, depending on
+// its action, without linking requests from other languages (see EnableBridges)
+func (dt *DependencyTracker) EnableRoutes() {
+	dt.routing = true
+}
+
+// indexScripts records a script by its root-relative path, for the assets that load it
+func (dt *DependencyTracker) indexScripts(file *models.ParsedFile) {
+	if scriptExtensions[strings.ToLower(filepath.Ext(file.Path))] {
+		dt.scripts[relativeTo(dt.bridgeRoot, file.Path)] = file
+	}
+}
+
+// indexRoutes creates nodes for the routes a file defines, linked to their actions
+func (dt *DependencyTracker) indexRoutes(file *models.ParsedFile) {
+	for _, usage := range file.Usage {
+		if usage.Type != "route" {
+			continue
+		}
+		method, routePath, _ := strings.Cut(usage.Name, " ")
+		entry := dt.routes[usage.Name]
+		if entry == nil {
+			entry = &routeEntry{
+				node:     dt.bridgeNode("route:"+usage.Name, usage.Name, "route", file.Path, usage.Line, file.Language),
+				method:   method,
+				segments: urlSegments(routePath),
+			}
+			dt.routes[usage.Name] = entry
+		}
+		if handlerID := dt.findCallbackNode(usage, dt.findSourceNode(usage, file), file); handlerID != "" {
+			dt.addDependencyRef(entry.node, dt.graph.Nodes[handlerID], "routes", usage.Line)
+			if entry.handler == "" {
+				entry.handler = handlerID
+			}
+		}
+	}
+}
+
+// processBridges links a file's script assets and HTTP requests to their asset and
+// route nodes
+func (dt *DependencyTracker) processBridges(file *models.ParsedFile) {
+	for _, usage := range file.Usage {
+		if usage.Type != "asset" && usage.Type != "http_request" {
+			continue
+		}
+		bridge := &models.Bridge{
+			Kind:     "request",
+			Target:   usage.Name,
+			File:     relativeTo(dt.bridgeRoot, file.Path),
+			Line:     usage.Line,
+			Language: file.Language,
+		}
+		var target *models.DependencyNode
+		if usage.Type == "asset" {
+			bridge.Kind = "asset"
+			rel := dt.assetSource(usage.Name)
+			if !scriptExtensions[strings.ToLower(path.Ext(rel))] {
+				continue // Styles and images don't cross into code
+			}
+			if script := dt.scripts[rel]; script != nil {
+				target = dt.bridgeNode("asset:"+rel, rel, "asset", script.Path, 0, script.Language)
+			} else if _, err := os.Stat(filepath.Join(dt.bridgeRoot, filepath.FromSlash(rel))); err == nil {
+				target = dt.bridgeNode("asset:"+rel, rel, "asset", filepath.Join(dt.bridgeRoot, filepath.FromSlash(rel)), 0, "")
+			}
+		} else if entry := dt.matchRoute(usage.Name); entry != nil {
+			target = entry.node
+			bridge.Handler = entry.handler
+		}
+
+		if target == nil {
+			dt.bridges.Unmatched = append(dt.bridges.Unmatched, bridge)
+			continue
+		}
+		bridge.To, bridge.ToLanguage = target.ID, target.Language
+		if source := dt.findSourceNode(usage, file); source != nil {
+			bridge.From = source.ID
+			dt.addDependencyRef(source, target, "cross_language", usage.Line)
+		}
+		dt.bridges.Bridges = append(dt.bridges.Bridges, bridge)
+	}
+}
+
+// bridgeNode returns the route or asset node with the given ID, creating it on first
+// reference. Both are reached from outside the code (an HTTP request, a browser), so
+// they're entrypoints rather than orphans.
+func (dt *DependencyTracker) bridgeNode(nodeID, name, nodeType, file string, line int, language string) *models.DependencyNode {
+	if node, exists := dt.graph.Nodes[nodeID]; exists {
+		return node
+	}
+
+	dt.graph.Lock()
+	defer dt.graph.Unlock()
+
+	node := &models.DependencyNode{
+		ID:           nodeID,
+		Name:         name,
+		Type:         nodeType,
+		File:         file,
+		Line:         line,
+		Dependencies: make(map[string]*models.DependencyRef),
+		Dependents:   make(map[string]*models.DependencyRef),
+		Score:        1,
+		IsEntrypoint: true,
+		Package:      dt.packageOf(file),
+		Language:     language,
+	}
+	dt.graph.Nodes[nodeID] = node
+	dt.graph.TotalNodes = len(dt.graph.Nodes)
+	return node
+}
+
+// assetSource returns the root-relative source of a script asset: the entry Laravel Mix
+// builds it from, or the path itself (Vite references sources directly)
+func (dt *DependencyTracker) assetSource(asset string) string {
+	rel := strings.TrimPrefix(path.Clean("/"+asset), "/")
+	if dt.mixSources == nil {
+		dt.mixSources = readMixSources(dt.bridgeRoot)
+	}
+	if source, ok := dt.mixSources[rel]; ok {
+		return source
+	}
+	return rel
+}
+
+// readMixSources maps the scripts webpack.mix.js builds, relative to public/, to their
+// entries. A missing or unreadable file maps nothing.
+func readMixSources(root string) map[string]string {
+	sources := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(root, "webpack.mix.js"))
+	if err != nil {
+		return sources
+	}
+	for _, match := range mixStepPattern.FindAllStringSubmatch(string(data), -1) {
+		source := strings.TrimPrefix(path.Clean("/"+match[1]), "/")
+		output := strings.TrimPrefix(path.Clean("/"+match[2]), "/")
+		output = strings.TrimPrefix(output, "public/")
+		if path.Ext(output) == "" {
+			output = path.Join(output, strings.TrimSuffix(path.Base(source), path.Ext(source))+".js")
+		}
+		sources[output] = source
+	}
+	return sources
+}
+
+// matchRoute finds the route serving a request ("METHOD url"). Route parameters ({id})
+// and interpolated segments (${id}) match any segment; among several matches, the one
+// whose segments agree most (literal with the same literal, parameter with
+// interpolation) wins.
+func (dt *DependencyTracker) matchRoute(request string) *routeEntry {
+	if dt.routeOrder == nil {
+		for _, entry := range dt.routes {
+			dt.routeOrder = append(dt.routeOrder, entry)
+		}
+		sort.Slice(dt.routeOrder, func(i, j int) bool { return dt.routeOrder[i].node.ID < dt.routeOrder[j].node.ID })
+	}
+
+	method, url, _ := strings.Cut(request, " ")
+	segments := urlSegments(url)
+	var best *routeEntry
+	bestScore := -1
+	for _, entry := range dt.routeOrder {
+		if (entry.method != "ANY" && entry.method != method) || len(entry.segments) != len(segments) {
+			continue
+		}
+		score := 0
+		for i, segment := range entry.segments {
+			param, interpolated := strings.HasPrefix(segment, "{"), strings.Contains(segments[i], "${")
+			switch {
+			case param == interpolated && (param || segment == segments[i]):
+				score++
+			case param || interpolated:
+			default:
+				score = -1
+			}
+			if score < 0 {
+				break
+			}
+		}
+		if score > bestScore {
+			best, bestScore = entry, score
+		}
+	}
+	return best
+}
+
+// urlSegments splits a URL's path into segments, dropping the scheme, host, query, and
+// fragment
+func urlSegments(url string) []string {
+	if i := strings.Index(url, "://"); i != -1 {
+		url = url[i+3:]
+		if j := strings.Index(url, "/"); j != -1 {
+			url = url[j:]
+		} else {
+			url = ""
+		}
+	}
+	if i := strings.IndexAny(url, "?#"); i != -1 {
+		url = url[:i]
+	}
+	if url = strings.Trim(url, "/"); url == "" {
+		return []string{}
+	}
+	return strings.Split(url, "/")
+}
+
+// analyzeBridges sorts the bridge report. It returns nil when bridges aren't enabled.
+func (dt *DependencyTracker) analyzeBridges() *models.BridgeReport {
+	if dt.bridges == nil {
+		return nil
+	}
+	dt.bridges.Routes = len(dt.routes)
+	for _, bridges := range [][]*models.Bridge{dt.bridges.Bridges, dt.bridges.Unmatched} {
+		sort.SliceStable(bridges, func(i, j int) bool {
+			a, b := bridges[i], bridges[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	return dt.bridges
+}
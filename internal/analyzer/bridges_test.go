@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func bridgeFiles(root string) []*models.ParsedFile {
+	return []*models.ParsedFile{
+		{
+			Path:     filepath.Join(root, "routes", "api.php"),
+			Language: "php",
+			Usage: []models.UsageElement{
+				{Type: "route", Name: "GET /api/users/{id}", Callback: "UserController::show", Line: 3},
+				{Type: "route", Name: "GET /api/users/me", Callback: "UserController::me", Line: 4},
+				{Type: "route", Name: "POST /api/users", Callback: "UserController::store", Line: 5},
+			},
+		},
+		{
+			Path:     filepath.Join(root, "app", "UserController.php"),
+			Language: "php",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "UserController", Line: 3},
+				{Type: "method", Name: "show", ClassName: "UserController", Line: 5},
+				{Type: "method", Name: "me", ClassName: "UserController", Line: 7},
+				{Type: "method", Name: "store", ClassName: "UserController", Line: 9},
+				{Type: "method", Name: "page", ClassName: "UserController", Line: 11},
+			},
+			Usage: []models.UsageElement{
+				{Type: "asset", Name: "/js/app.js", Context: "page", Line: 12},
+				{Type: "asset", Name: "css/app.css", Context: "page", Line: 13},
+				{Type: "asset", Name: "js/missing.js", Context: "page", Line: 14},
+			},
+		},
+		{
+			Path:     filepath.Join(root, "resources", "js", "app.js"),
+			Language: "javascript",
+			Elements: []models.CodeElement{{Type: "function", Name: "loadUser", Line: 1}},
+			Usage: []models.UsageElement{
+				{Type: "http_request", Name: "GET /api/users/${id}", Context: "loadUser", Line: 2},
+				{Type: "http_request", Name: "POST https://example.com/api/users?x=1", Context: "loadUser", Line: 3},
+				{Type: "http_request", Name: "DELETE /api/users/1", Context: "loadUser", Line: 4},
+			},
+		},
+	}
+}
+
+func TestBridges(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "webpack.mix.js"), []byte("mix.js('resources/js/app.js', 'public/js');\n"), 0644)
+
+	dt := NewDependencyTracker()
+	dt.EnableBridges(root)
+	graph := dt.BuildDependencyGraph(bridgeFiles(root))
+
+	route := graph.Nodes["route:GET /api/users/{id}"]
+	if route == nil || !route.IsEntrypoint || route.Language != "php" {
+		t.Fatalf("expected an entrypoint route node, got %+v", route)
+	}
+	if dep := route.Dependencies["method:show:5"]; dep == nil || dep.Type != "routes" {
+		t.Errorf("expected the route to depend on its action, got %v", route.Dependencies)
+	}
+
+	loadUser := graph.Nodes["function:loadUser:1"]
+	if dep := loadUser.Dependencies["route:GET /api/users/{id}"]; dep == nil || dep.Type != "cross_language" {
+		t.Errorf("expected loadUser to request the show route, got %v", loadUser.Dependencies)
+	}
+	if loadUser.Dependencies["route:GET /api/users/me"] != nil {
+		t.Errorf("an interpolated segment matched the literal route")
+	}
+	if loadUser.Dependencies["route:POST /api/users"] == nil {
+		t.Errorf("expected an absolute URL to match by path, got %v", loadUser.Dependencies)
+	}
+
+	asset := graph.Nodes["asset:resources/js/app.js"]
+	if asset == nil || asset.Language != "javascript" {
+		t.Fatalf("expected mix() to resolve to its entry, got %+v", asset)
+	}
+	if dep := asset.Dependents["method:page:11"]; dep == nil || dep.Type != "cross_language" {
+		t.Errorf("expected page to load the asset, got %v", asset.Dependents)
+	}
+
+	report := graph.Bridges
+	if report == nil || report.Routes != 3 || len(report.Bridges) != 3 || len(report.Unmatched) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	first := report.Bridges[0]
+	if first.Kind != "asset" || first.File != "app/UserController.php" || first.From != "method:page:11" || first.To != "asset:resources/js/app.js" {
+		t.Errorf("unexpected first bridge %+v", first)
+	}
+	request := report.Bridges[1]
+	if request.Handler != "method:show:5" || request.ToLanguage != "php" || request.Language != "javascript" {
+		t.Errorf("unexpected request bridge %+v", request)
+	}
+	if report.Unmatched[0].Target != "js/missing.js" || report.Unmatched[1].Target != "DELETE /api/users/1" {
+		t.Errorf("unexpected unmatched %+v and %+v", report.Unmatched[0], report.Unmatched[1])
+	}
+}
+
+func TestBridgesOff(t *testing.T) {
+	graph := NewDependencyTracker().BuildDependencyGraph(bridgeFiles(t.TempDir()))
+	if graph.Bridges != nil {
+		t.Errorf("expected no bridge report, got %+v", graph.Bridges)
+	}
+	for id := range graph.Nodes {
+		if id[:6] == "route:" || id[:6] == "asset:" {
+			t.Errorf("unexpected bridge node %s", id)
+		}
+	}
+}
+
+func TestURLSegments(t *testing.T) {
+	tests := map[string]int{"/": 0, "": 0, "/api/users/": 2, "https://example.com": 0, "users?page=2#top": 1}
+	for url, want := range tests {
+		if got := urlSegments(url); len(got) != want {
+			t.Errorf("%q: expected %d segments, got %v", url, want, got)
+		}
+	}
+}
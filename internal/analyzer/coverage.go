@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/coverage"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// uncoveredMinDependents is how many dependents a never-run node needs to be reported as
+// uncovered: below that, missing tests put little else at risk
+const uncoveredMinDependents = 2
+
+// SetCoverage overlays a coverage profile on the graph and enables the coverage report.
+// Files are matched relative to root.
+func (dt *DependencyTracker) SetCoverage(root string, profile *coverage.Profile) {
+	dt.coverage = profile
+	dt.coverageRoot = root
+}
+
+// analyzeCoverage sets each node's coverage from the lines between it and the next
+// declaration in its file (a class's span includes its members), then reports the
+// never-run nodes that much depends on, and those that are only used by other never-run
+// code. It returns nil without a coverage profile.
+func (dt *DependencyTracker) analyzeCoverage() *models.CoverageReport {
+	if dt.coverage == nil {
+		return nil
+	}
+
+	dt.graph.Lock()
+	defer dt.graph.Unlock()
+
+	report := &models.CoverageReport{
+		Sources:        dt.coverage.Sources,
+		Uncovered:      []*models.CoverageNode{},
+		DeadInPractice: []*models.CoverageNode{},
+	}
+
+	byFile := make(map[string][]*models.DependencyNode)
+	for _, node := range dt.graph.Nodes {
+		if node.File != "" && node.Line > 0 {
+			byFile[node.File] = append(byFile[node.File], node)
+		}
+	}
+	ran := make(map[string]bool) // Node IDs with coverage, to whether any of their lines ran
+	for file, nodes := range byFile {
+		lines := dt.coverage.Lines(relativeTo(dt.coverageRoot, file))
+		if lines == nil {
+			continue
+		}
+		report.Files++
+		for _, count := range lines {
+			report.Lines++
+			if count > 0 {
+				report.CoveredLines++
+			}
+		}
+
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Line < nodes[j].Line })
+		for i, node := range nodes {
+			end := math.MaxInt
+			for _, next := range nodes[i+1:] {
+				if next.Line > node.Line && next.ClassName != node.Name {
+					end = next.Line
+					break
+				}
+			}
+			executable, covered := 0, 0
+			for line, count := range lines {
+				if line >= node.Line && line < end {
+					executable++
+					if count > 0 {
+						covered++
+					}
+				}
+			}
+			if executable == 0 {
+				continue // Abstract methods, interfaces, and the like
+			}
+			percent := math.Round(float64(covered)/float64(executable)*1000) / 10
+			node.Coverage = &percent
+			ran[node.ID] = covered > 0
+			report.Nodes++
+			if covered > 0 {
+				report.CoveredNodes++
+			}
+		}
+	}
+	report.Unmatched = len(dt.coverage.Unmatched())
+	if report.Lines > 0 {
+		report.Percent = math.Round(float64(report.CoveredLines)/float64(report.Lines)*1000) / 10
+	}
+
+	for id, covered := range ran {
+		node := dt.graph.Nodes[id]
+		if covered || len(node.Dependents) == 0 {
+			continue // Nodes nothing depends on are already orphans
+		}
+		entry := &models.CoverageNode{
+			ID:         node.ID,
+			Name:       node.Name,
+			Type:       node.Type,
+			File:       node.File,
+			Line:       node.Line,
+			Dependents: len(node.Dependents),
+		}
+		if len(node.Dependents) >= uncoveredMinDependents {
+			report.Uncovered = append(report.Uncovered, entry)
+		}
+		// Used statically, but every user is known not to have run either
+		dead := !node.IsEntrypoint
+		for dependentID := range node.Dependents {
+			if covered, known := ran[dependentID]; !known || covered {
+				dead = false
+				break
+			}
+		}
+		if dead {
+			report.DeadInPractice = append(report.DeadInPractice, entry)
+		}
+	}
+
+	for _, list := range [][]*models.CoverageNode{report.Uncovered, report.DeadInPractice} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Dependents != list[j].Dependents {
+				return list[i].Dependents > list[j].Dependents
+			}
+			return list[i].ID < list[j].ID
+		})
+	}
+	return report
+}
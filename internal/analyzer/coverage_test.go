@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/coverage"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestCoverageReport(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path: "proj/app/Invoice.php",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Invoice", Line: 1},
+				{Type: "method", Name: "total", ClassName: "Invoice", Line: 3},
+			},
+			Usage: []models.UsageElement{{Type: "function_call", Name: "format_money", Context: "total", Line: 4}},
+		},
+		{
+			Path:     "proj/app/charge.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "charge", Line: 1}},
+			Usage:    []models.UsageElement{{Type: "function_call", Name: "format_money", Context: "charge", Line: 2}},
+		},
+		{
+			Path:     "proj/lib/money.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "format_money", Line: 1}},
+		},
+		{
+			Path: "proj/legacy/report.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "old_report", Line: 1},
+				{Type: "function", Name: "old_format", Line: 5},
+			},
+			Usage: []models.UsageElement{{Type: "function_call", Name: "old_format", Context: "old_report", Line: 2}},
+		},
+	}
+	profile := coverage.NewProfile()
+	if err := profile.Parse([]byte(`SF:/ci/build/app/Invoice.php
+DA:4,3
+DA:5,0
+end_of_record
+SF:/ci/build/app/charge.php
+DA:2,1
+end_of_record
+SF:/ci/build/lib/money.php
+DA:2,0
+DA:3,0
+end_of_record
+SF:/ci/build/legacy/report.php
+DA:2,0
+DA:6,0
+end_of_record
+SF:/ci/build/vendor/autoload.php
+DA:1,1
+end_of_record
+`)); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SetCoverage("proj", profile)
+	graph := tracker.BuildDependencyGraph(files)
+
+	percents := make(map[string]float64)
+	for _, node := range graph.Nodes {
+		if node.Coverage != nil {
+			percents[node.Name] = *node.Coverage
+		}
+	}
+	if percents["Invoice"] != 50 || percents["total"] != 50 || percents["charge"] != 100 || percents["format_money"] != 0 {
+		t.Errorf("expected the class's span to include its method, got %v", percents)
+	}
+
+	report := graph.Coverage
+	if report == nil || report.Files != 4 || report.Unmatched != 1 || report.Lines != 7 || report.CoveredLines != 2 || report.Percent != 28.6 {
+		t.Fatalf("unexpected coverage report: %+v", report)
+	}
+	if report.Nodes != 6 || report.CoveredNodes != 3 {
+		t.Errorf("expected 3 of 6 nodes to have run, got %d of %d", report.CoveredNodes, report.Nodes)
+	}
+	if len(report.Uncovered) != 1 || report.Uncovered[0].Name != "format_money" || report.Uncovered[0].Dependents != 2 {
+		t.Errorf("expected format_money to be depended on but uncovered, got %+v", report.Uncovered)
+	}
+	if len(report.DeadInPractice) != 1 || report.DeadInPractice[0].Name != "old_format" {
+		t.Errorf("expected only old_format to be dead in practice, got %+v", report.DeadInPractice)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// FindCycles returns the dependency cycles in graph: each strongly connected group of two
+// or more nodes, as sorted node IDs, ordered by first ID. Self-references (e.g. recursion)
+// aren't cycles, and neither are groups with a node whose cycles are suppressed or, in a
+// graph scoped to changed files, groups without a changed node. Callers must hold the
+// graph's read lock if it may still change.
+func FindCycles(graph *models.DependencyGraph) [][]string {
+	ids := make([]string, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Tarjan's algorithm
+	index := make(map[string]int, len(ids))
+	lowlink := make(map[string]int, len(ids))
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = len(index)
+		lowlink[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+
+		targets := make([]string, 0, len(graph.Nodes[id].Dependencies))
+		for target := range graph.Nodes[id].Dependencies {
+			if _, ok := graph.Nodes[target]; ok && target != id {
+				targets = append(targets, target)
+			}
+		}
+		sort.Strings(targets)
+
+		for _, target := range targets {
+			if _, seen := index[target]; !seen {
+				visit(target)
+				lowlink[id] = min(lowlink[id], lowlink[target])
+			} else if onStack[target] {
+				lowlink[id] = min(lowlink[id], index[target])
+			}
+		}
+
+		if lowlink[id] != index[id] {
+			return
+		}
+		var group []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			group = append(group, top)
+			if top == id {
+				break
+			}
+		}
+		if len(group) > 1 && !cycleSuppressed(graph, group) && cycleInScope(graph, group) {
+			sort.Strings(group)
+			cycles = append(cycles, group)
+		}
+	}
+
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// cycleSuppressed reports whether any node in group has its cycles suppressed
+func cycleSuppressed(graph *models.DependencyGraph, group []string) bool {
+	for _, id := range group {
+		if IsSuppressed(graph.Nodes[id], "cycles") {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleInScope reports whether any node in group is in the graph's scope
+func cycleInScope(graph *models.DependencyGraph, group []string) bool {
+	for _, id := range group {
+		if InScope(graph, graph.Nodes[id]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestFindCycles(t *testing.T) {
+	file := &models.ParsedFile{
+		Path: "app/functions.php",
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "a", Line: 1},
+			{Type: "function", Name: "b", Line: 5},
+			{Type: "function", Name: "c", Line: 9},
+			{Type: "function", Name: "walk", Line: 13},
+			{Type: "function", Name: "main", Line: 17},
+		},
+		Usage: []models.UsageElement{
+			// a -> b -> c -> a is a cycle
+			{Type: "function_call", Name: "b", Context: "a", Line: 2},
+			{Type: "function_call", Name: "c", Context: "b", Line: 6},
+			{Type: "function_call", Name: "a", Context: "c", Line: 10},
+			// Recursion is not
+			{Type: "function_call", Name: "walk", Context: "walk", Line: 14},
+			// Entering the cycle from outside doesn't join it
+			{Type: "function_call", Name: "a", Context: "main", Line: 18},
+		},
+	}
+
+	graph := NewDependencyTracker().BuildDependencyGraph([]*models.ParsedFile{file})
+	cycles := FindCycles(graph)
+
+	want := [][]string{{"function:a:1", "function:b:5", "function:c:9"}}
+	if !reflect.DeepEqual(cycles, want) {
+		t.Errorf("expected %v, got %v", want, cycles)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// debtAges are the age ranges debt markers are counted in, youngest first
+var debtAges = []struct {
+	label   string
+	maxDays int // Exclusive; 0 for no limit
+}{
+	{"under 30 days", 30},
+	{"30-90 days", 90},
+	{"90-365 days", 365},
+	{"over a year", 0},
+}
+
+// TechnicalDebt gathers the TODO, FIXME, and HACK comments found while parsing into a
+// report grouped by directory. ticketPattern, a regular expression, picks out the ticket
+// each marker references (e.g. `[A-Z]+-\d+`); empty skips it. The report is nil when
+// there are no markers.
+func TechnicalDebt(root string, files []*models.ParsedFile, ticketPattern string) (*models.DebtReport, error) {
+	var ticket *regexp.Regexp
+	if ticketPattern != "" {
+		var err error
+		if ticket, err = regexp.Compile(ticketPattern); err != nil {
+			return nil, fmt.Errorf("invalid ticket pattern %q: %w", ticketPattern, err)
+		}
+	}
+
+	report := &models.DebtReport{Tags: make(map[string]int), Directories: []*models.DebtDirectory{}}
+	dirs := make(map[string]*models.DebtDirectory)
+	for _, file := range files {
+		rel := relativeTo(root, file.Path)
+		for _, marker := range file.Debt {
+			item := &models.DebtItem{Tag: marker.Tag, Text: marker.Text, Author: marker.Author, File: rel, Line: marker.Line}
+			if ticket != nil {
+				if item.Ticket = ticket.FindString(marker.Text); item.Ticket != "" {
+					report.Tickets++
+				}
+			}
+
+			dir := dirs[path.Dir(rel)]
+			if dir == nil {
+				dir = &models.DebtDirectory{Path: path.Dir(rel)}
+				dirs[dir.Path] = dir
+				report.Directories = append(report.Directories, dir)
+			}
+			dir.Count++
+			dir.Items = append(dir.Items, item)
+			report.Tags[marker.Tag]++
+			report.Total++
+		}
+	}
+	if report.Total == 0 {
+		return nil, nil
+	}
+
+	for _, dir := range report.Directories {
+		sort.SliceStable(dir.Items, func(i, j int) bool {
+			a, b := dir.Items[i], dir.Items[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	sort.Slice(report.Directories, func(i, j int) bool {
+		a, b := report.Directories[i], report.Directories[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Path < b.Path
+	})
+	return report, nil
+}
+
+// DateDebt dates every marker with blame, which returns when the given lines of a file
+// last changed (see churn.Blame), and counts the markers by age as of now. Files blame
+// fails for stay undated; the error is returned only when no file could be dated.
+func DateDebt(report *models.DebtReport, root string, blame func(file string, lines []int) (map[int]time.Time, error), now time.Time) error {
+	byFile := make(map[string][]*models.DebtItem)
+	var order []string
+	for _, dir := range report.Directories {
+		for _, item := range dir.Items {
+			if byFile[item.File] == nil {
+				order = append(order, item.File)
+			}
+			byFile[item.File] = append(byFile[item.File], item)
+		}
+	}
+
+	var firstErr error
+	dated := 0
+	for _, file := range order {
+		items := byFile[file]
+		lines := make([]int, len(items))
+		for i, item := range items {
+			lines[i] = item.Line
+		}
+		abs := filepath.FromSlash(file)
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(root, abs)
+		}
+		dates, err := blame(abs, lines)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		dated++
+		for _, item := range items {
+			if date, ok := dates[item.Line]; ok {
+				item.Date = date.Format("2006-01-02")
+				item.AgeDays = max(int(now.Sub(date).Hours()/24), 0)
+			}
+		}
+	}
+	if dated == 0 {
+		return firstErr
+	}
+
+	report.Ages = make([]*models.DebtAge, len(debtAges))
+	for i, age := range debtAges {
+		report.Ages[i] = &models.DebtAge{Label: age.label}
+	}
+	for _, dir := range report.Directories {
+		for _, item := range dir.Items {
+			if item.Date == "" {
+				report.Undated++
+				continue
+			}
+			dir.OldestDays = max(dir.OldestDays, item.AgeDays)
+			for i, age := range debtAges {
+				if age.maxDays == 0 || item.AgeDays < age.maxDays {
+					report.Ages[i].Count++
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
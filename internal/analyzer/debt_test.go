@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func makeDebtFiles() []*models.ParsedFile {
+	return []*models.ParsedFile{
+		{Path: "proj/app/Billing.php", Debt: []models.DebtMarker{
+			{Tag: "TODO", Text: "split per currency, see PAY-12", Line: 9},
+			{Tag: "HACK", Text: "rounds twice", Line: 3},
+		}},
+		{Path: "proj/app/Invoice.php", Debt: []models.DebtMarker{{Tag: "FIXME", Text: "off by one", Author: "ana", Line: 4}}},
+		{Path: "proj/index.php", Debt: []models.DebtMarker{{Tag: "TODO", Text: "remove", Line: 1}}},
+		{Path: "proj/lib/clean.php"},
+	}
+}
+
+func TestTechnicalDebt(t *testing.T) {
+	report, err := TechnicalDebt("proj", makeDebtFiles(), `[A-Z]+-\d+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 4 || report.Tags["TODO"] != 2 || report.Tags["FIXME"] != 1 || report.Tickets != 1 {
+		t.Errorf("unexpected totals: %+v", report)
+	}
+	if len(report.Directories) != 2 || report.Directories[0].Path != "app" || report.Directories[1].Path != "." {
+		t.Fatalf("expected app, then the root, got %+v", report.Directories)
+	}
+	app := report.Directories[0]
+	if app.Count != 3 || app.Items[0].Line != 3 || app.Items[1].Ticket != "PAY-12" || app.Items[2].File != "app/Invoice.php" {
+		t.Errorf("expected app's items by file and line, got %+v %+v %+v", app.Items[0], app.Items[1], app.Items[2])
+	}
+
+	if report, _ := TechnicalDebt("proj", makeDebtFiles()[3:], ""); report != nil {
+		t.Errorf("expected no report without markers, got %+v", report)
+	}
+	if _, err := TechnicalDebt("proj", makeDebtFiles(), "("); err == nil {
+		t.Error("expected an error for an invalid ticket pattern")
+	}
+}
+
+func TestDateDebt(t *testing.T) {
+	report, _ := TechnicalDebt("proj", makeDebtFiles(), "")
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	blame := func(file string, lines []int) (map[int]time.Time, error) {
+		switch file {
+		case filepath.Join("proj", "app", "Billing.php"):
+			return map[int]time.Time{3: now.AddDate(-2, 0, 0), 9: now.AddDate(0, 0, -10)}, nil
+		case filepath.Join("proj", "app", "Invoice.php"):
+			return map[int]time.Time{4: now.AddDate(0, 0, -45)}, nil
+		}
+		return nil, errors.New("not tracked")
+	}
+	if err := DateDebt(report, "proj", blame, now); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[string]int{}
+	for _, age := range report.Ages {
+		counts[age.Label] = age.Count
+	}
+	if counts["under 30 days"] != 1 || counts["30-90 days"] != 1 || counts["over a year"] != 1 || report.Undated != 1 {
+		t.Errorf("unexpected ages %v, %d undated", counts, report.Undated)
+	}
+	app := report.Directories[0]
+	if app.OldestDays != 731 || app.Items[0].Date != "2023-06-01" {
+		t.Errorf("expected the oldest marker to be two years old, got %d days, %s", app.OldestDays, app.Items[0].Date)
+	}
+
+	report, _ = TechnicalDebt("proj", makeDebtFiles(), "")
+	fail := func(string, []int) (map[int]time.Time, error) { return nil, errors.New("not a git repository") }
+	if err := DateDebt(report, "proj", fail, now); err == nil || report.Ages != nil {
+		t.Errorf("expected an error and no ages when nothing could be dated, got %v", err)
+	}
+}
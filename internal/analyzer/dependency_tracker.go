@@ -6,11 +6,18 @@ package analyzer
 import (
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/boone-studios/tukey/internal/codeowners"
+	"github.com/boone-studios/tukey/internal/coverage"
 	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/trace"
+	"github.com/boone-studios/tukey/internal/workspace"
 )
 
 // DependencyTracker builds dependency relationships
@@ -19,6 +26,48 @@ type DependencyTracker struct {
 	nodeIndex    map[string]string     // Maps element names to node IDs
 	namespaceMap map[string]string     // Maps class names to full-namespaced names
 	allUsage     []models.UsageElement // Store all usage for function reporting
+	conflicts    map[string]*models.AmbiguousName
+	classMethods map[string]map[string]string      // Maps class full names to their own methods' node IDs
+	composition  map[string]*traitComposition      // Maps class full names to the traits they use
+	methodTables map[string]map[string]string      // Memoized effective method tables (own + trait methods)
+	resolveHooks bool                              // Link hook registrations/dispatches through hook nodes
+	builtins     map[string]bool                   // Extra (framework) functions to drop from function calls
+	entrypoints  []*regexp.Regexp                  // Element names invoked by a framework
+	entryFiles   []*regexp.Regexp                  // File paths whose elements are invoked by a framework
+	fileSymbols  map[string]map[string]string      // Maps module file paths to their top-level elements' node IDs
+	fileExports  map[string][]models.ExportBinding // Maps module file paths to their exports
+	fileImports  map[string][]models.ImportBinding // Maps module file paths to their import bindings
+	exportTables map[string]*exportTable           // Memoized resolved exports per module file
+	barrels      bool                              // Add barrel nodes for files that only re-export
+	workspace    *workspace.Workspace              // Monorepo packages used to tag nodes
+	groups       []*virtualGroup                   // Config-defined virtual groups, by name
+	groupRoot    string                            // Root that group path patterns are relative to
+	owners       *codeowners.File                  // CODEOWNERS rules used to tag nodes
+	ownersRoot   string                            // Root that CODEOWNERS patterns are relative to
+	coverage     *coverage.Profile                 // Test coverage overlaid on nodes
+	coverageRoot string                            // Root that project files are matched to coverage from
+	trace        *trace.Trace                      // Runtime calls reconciled with the graph
+	maxParams    int                               // Parameter count above which a list is long (0 = off)
+	longParams   map[string][]string               // Parameters of functions over maxParams, by node ID
+	summaryOnly  bool                              // Count edges without keeping line numbers or usage
+	maxLines     int                               // Line numbers kept per edge (0 = all)
+	sampler      *rand.Rand                        // Reservoir sampling for capped edges
+	routing      bool                              // Add route nodes linked to their actions
+	bridges      *models.BridgeReport              // Cross-language references (nil = off)
+	bridgeRoot   string                            // Root that asset paths are relative to
+	scripts      map[string]*models.ParsedFile     // Parsed scripts by root-relative path
+	routes       map[string]*routeEntry            // Defined routes by "METHOD /path"
+	routeOrder   []*routeEntry                     // Routes sorted by node ID, for matching
+	mixSources   map[string]string                 // Laravel Mix outputs to their entries
+	views        map[string]string                 // Maps Blade view and Twig template names to their node IDs
+	twigCalls    map[string]string                 // Maps Twig filters, functions, and tests ("filter:money") to their callables' node IDs
+	sqlObjects   map[string]string                 // Maps lowercased SQL names, with and without their schema, to node IDs
+}
+
+// traitComposition describes how a class pulls in trait methods
+type traitComposition struct {
+	traits      []string // Fully-qualified trait names, in declaration order
+	adaptations []models.TraitAdaptation
 }
 
 // NewDependencyTracker creates a new dependency tracker
@@ -29,15 +78,92 @@ func NewDependencyTracker() *DependencyTracker {
 			Orphans:        []*models.DependencyNode{},
 			HighlyDepended: []*models.DependencyNode{},
 			ComplexNodes:   []*models.DependencyNode{},
+			AmbiguousNames: []*models.AmbiguousName{},
 		},
 		nodeIndex:    make(map[string]string),
 		namespaceMap: make(map[string]string),
 		allUsage:     []models.UsageElement{},
+		conflicts:    make(map[string]*models.AmbiguousName),
+		classMethods: make(map[string]map[string]string),
+		composition:  make(map[string]*traitComposition),
+		methodTables: make(map[string]map[string]string),
+		builtins:     make(map[string]bool),
+		fileSymbols:  make(map[string]map[string]string),
+		fileExports:  make(map[string][]models.ExportBinding),
+		fileImports:  make(map[string][]models.ImportBinding),
+		exportTables: make(map[string]*exportTable),
+		barrels:      true,
+		maxParams:    DefaultMaxParameters,
+		longParams:   make(map[string][]string),
+		scripts:      make(map[string]*models.ParsedFile),
+		routes:       make(map[string]*routeEntry),
+		views:        make(map[string]string),
+		twigCalls:    make(map[string]string),
+		sqlObjects:   make(map[string]string),
+	}
+}
+
+// AddBuiltins registers framework API functions whose calls are dropped from function usage
+func (dt *DependencyTracker) AddBuiltins(names ...string) {
+	for _, name := range names {
+		dt.builtins[strings.ToLower(name)] = true
+	}
+}
+
+// AddEntrypoint marks elements whose name matches pattern as framework-invoked
+func (dt *DependencyTracker) AddEntrypoint(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid entrypoint pattern %q: %w", pattern, err)
 	}
+	dt.entrypoints = append(dt.entrypoints, re)
+	return nil
+}
+
+// AddEntrypointFile marks every element in files whose path matches pattern as framework-invoked
+func (dt *DependencyTracker) AddEntrypointFile(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid entrypoint file pattern %q: %w", pattern, err)
+	}
+	dt.entryFiles = append(dt.entryFiles, re)
+	return nil
+}
+
+// EnableHooks turns on hook resolution, linking code that fires a hook and the callbacks
+// registered on it (e.g. WordPress actions and filters) through "hook" nodes
+func (dt *DependencyTracker) EnableHooks() {
+	dt.resolveHooks = true
+}
+
+// CollapseBarrels leaves JS barrel files (index files that only re-export) out of the graph
+func (dt *DependencyTracker) CollapseBarrels() {
+	dt.barrels = false
+}
+
+// SummaryOnly keeps edge counts but not their line numbers, and doesn't retain usage for
+// the function usage report, trading detail for memory and speed on very large projects
+func (dt *DependencyTracker) SummaryOnly() {
+	dt.summaryOnly = true
+}
+
+// SetMaxLinesPerEdge caps the line numbers stored per edge. Beyond the cap, lines are
+// reservoir-sampled so every call site is equally likely to be kept; Count stays exact.
+func (dt *DependencyTracker) SetMaxLinesPerEdge(max int) {
+	dt.maxLines = max
+}
+
+// SetWorkspace tags nodes with the monorepo package that contains them and enables the
+// cross-package dependency report
+func (dt *DependencyTracker) SetWorkspace(ws *workspace.Workspace) {
+	dt.workspace = ws
 }
 
 // BuildDependencyGraph creates the complete dependency graph from parsed files
 func (dt *DependencyTracker) BuildDependencyGraph(parsedFiles []*models.ParsedFile) *models.DependencyGraph {
+	// Phase 0: Drop calls to framework builtins
+	dt.filterBuiltins(parsedFiles)
+
 	// Phase 1: Create all nodes and build indexes
 	dt.createNodes(parsedFiles)
 
@@ -45,8 +171,18 @@ func (dt *DependencyTracker) BuildDependencyGraph(parsedFiles []*models.ParsedFi
 	dt.buildRelationships(parsedFiles)
 
 	// Phase 3: Calculate metrics and analyze patterns
+	dt.sortSampledLines()
 	dt.calculateMetrics()
 	dt.identifyPatterns()
+	dt.graph.ModuleInterop = analyzeModuleInterop(parsedFiles)
+	dt.graph.Includes = analyzeIncludes(parsedFiles)
+	dt.graph.Packages = dt.analyzePackages()
+	dt.graph.Groups = dt.analyzeGroups()
+	dt.graph.Ownership = dt.analyzeOwnership()
+	dt.graph.Coverage = dt.analyzeCoverage()
+	dt.graph.Runtime = dt.analyzeRuntime()
+	dt.graph.LongParameters = dt.analyzeParameters()
+	dt.graph.Bridges = dt.analyzeBridges()
 
 	return dt.graph
 }
@@ -62,7 +198,7 @@ func (dt *DependencyTracker) createNodes(parsedFiles []*models.ParsedFile) {
 			fullName := dt.getFullName(element.Namespace, element.Name)
 
 			// Create unique node ID
-			nodeID := fmt.Sprintf("%s:%s:%d", element.Type, fullName, element.Line)
+			nodeID := dt.nodeIDFor(element)
 
 			node := &models.DependencyNode{
 				ID:           nodeID,
@@ -75,9 +211,44 @@ func (dt *DependencyTracker) createNodes(parsedFiles []*models.ParsedFile) {
 				Dependencies: make(map[string]*models.DependencyRef),
 				Dependents:   make(map[string]*models.DependencyRef),
 				Score:        dt.calculateComplexityScore(&element),
+				IsEntrypoint: dt.isEntrypoint(element.Name, file.Path),
+				Package:      dt.packageOf(file.Path),
+				Language:     file.Language,
 			}
 
 			dt.graph.Nodes[nodeID] = node
+			dt.recordParameters(nodeID, &element)
+
+			// SQL names are case-insensitive and apart from the application's, so SQL
+			// objects are only found through SQL usage and the tables code names
+			if file.Language == "sql" {
+				dt.indexSQLObject(element, node)
+				continue
+			}
+
+			// Views are rendered by name, so they're only found through view usage
+			if element.Type == "view" {
+				dt.views[element.Name] = nodeID
+				continue
+			}
+
+			// Index module-level declarations for import resolution
+			if file.Imports != nil && element.ClassName == "" {
+				path := filepath.Clean(file.Path)
+				if dt.fileSymbols[path] == nil {
+					dt.fileSymbols[path] = make(map[string]string)
+				}
+				dt.fileSymbols[path][element.Name] = nodeID
+			}
+
+			// Index methods by their owning class for member resolution
+			if element.Type == "method" && element.ClassName != "" {
+				classKey := dt.getFullName(element.Namespace, element.ClassName)
+				if dt.classMethods[classKey] == nil {
+					dt.classMethods[classKey] = make(map[string]string)
+				}
+				dt.classMethods[classKey][element.Name] = nodeID
+			}
 
 			// Build search indexes - be more careful about conflicts
 			// Always index by full name (with namespace)
@@ -88,8 +259,9 @@ func (dt *DependencyTracker) createNodes(parsedFiles []*models.ParsedFile) {
 				// Global namespace - safe to index by short name
 				dt.nodeIndex[element.Name] = nodeID
 			} else {
-				// Check if this short name already exists
-				if _, exists := dt.nodeIndex[element.Name]; exists {
+				// Check if this short name already exists (or was already found ambiguous)
+				existingID, exists := dt.nodeIndex[element.Name]
+				if _, conflicted := dt.conflicts[element.Name]; exists || conflicted {
 					// There's a conflict - remove the short name index
 					// This forces resolution to use full namespaced names
 					delete(dt.nodeIndex, element.Name)
@@ -98,6 +270,13 @@ func (dt *DependencyTracker) createNodes(parsedFiles []*models.ParsedFile) {
 					if element.Type == "class" {
 						delete(dt.namespaceMap, element.Name)
 					}
+
+					if isClassLike(element.Type) {
+						if existing := dt.graph.Nodes[existingID]; existing != nil && isClassLike(existing.Type) {
+							dt.recordConflict(element.Name, dt.getFullName(existing.Namespace, existing.Name))
+						}
+						dt.recordConflict(element.Name, fullName)
+					}
 				} else {
 					// No conflict yet - add a short name index
 					dt.nodeIndex[element.Name] = nodeID
@@ -113,19 +292,317 @@ func (dt *DependencyTracker) createNodes(parsedFiles []*models.ParsedFile) {
 	dt.graph.TotalNodes = len(dt.graph.Nodes)
 }
 
+// filterBuiltins removes function calls to registered builtins from the parsed usage
+func (dt *DependencyTracker) filterBuiltins(parsedFiles []*models.ParsedFile) {
+	if len(dt.builtins) == 0 {
+		return
+	}
+
+	for _, file := range parsedFiles {
+		kept := file.Usage[:0]
+		for _, usage := range file.Usage {
+			if usage.Type == "function_call" && dt.builtins[strings.ToLower(usage.Name)] {
+				continue
+			}
+			kept = append(kept, usage)
+		}
+		file.Usage = kept
+	}
+}
+
+// isEntrypoint checks an element against the registered entrypoint patterns
+func (dt *DependencyTracker) isEntrypoint(name, path string) bool {
+	for _, re := range dt.entrypoints {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+
+	path = filepath.ToSlash(path)
+	for _, re := range dt.entryFiles {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordConflict notes that fullName shares its short name with another declaration
+func (dt *DependencyTracker) recordConflict(shortName, fullName string) {
+	conflict, exists := dt.conflicts[shortName]
+	if !exists {
+		conflict = &models.AmbiguousName{Name: shortName, Candidates: []string{}}
+		dt.conflicts[shortName] = conflict
+	}
+
+	for _, candidate := range conflict.Candidates {
+		if candidate == fullName {
+			return
+		}
+	}
+	conflict.Candidates = append(conflict.Candidates, fullName)
+}
+
+// isClassLike reports whether an element type declares a class-like symbol
+func isClassLike(elementType string) bool {
+	switch elementType {
+	case "class", "interface", "trait", "enum", "module":
+		return true
+	}
+	return false
+}
+
 // buildRelationships creates dependency links between nodes
 func (dt *DependencyTracker) buildRelationships(parsedFiles []*models.ParsedFile) {
+	for _, file := range parsedFiles {
+		dt.indexTraitComposition(file)
+		dt.indexModule(file)
+	}
+	for _, file := range parsedFiles {
+		dt.indexTwigExtensions(file) // After the trait index, which $this callbacks resolve through
+	}
+	if dt.routing {
+		for _, file := range parsedFiles {
+			dt.indexRoutes(file)
+			if dt.bridges != nil {
+				dt.indexScripts(file)
+			}
+		}
+	}
+
 	for _, file := range parsedFiles {
 		dt.processFileUsage(file)
 		dt.processImports(file)
+		dt.processSignatures(file)
+		if dt.resolveHooks {
+			dt.processHooks(file)
+		}
+		if dt.barrels {
+			dt.processBarrel(file)
+		}
+		if dt.bridges != nil {
+			dt.processBridges(file)
+		}
+		dt.processTables(file)
+	}
+}
+
+// processHooks links hook dispatchers to the hook, and the hook to its registered callbacks
+func (dt *DependencyTracker) processHooks(file *models.ParsedFile) {
+	for _, usage := range file.Usage {
+		if usage.Type != "hook_register" && usage.Type != "hook_fire" {
+			continue
+		}
+
+		hookNode := dt.hookNode(usage.Name, file.Language)
+		sourceNode := dt.findSourceNode(usage, file)
+
+		if usage.Type == "hook_fire" {
+			if sourceNode != nil {
+				dt.addDependencyRef(sourceNode, hookNode, "fires", usage.Line)
+			}
+			continue
+		}
+
+		if sourceNode != nil {
+			dt.addDependencyRef(sourceNode, hookNode, "registers", usage.Line)
+		}
+		if callbackID := dt.findCallbackNode(usage, sourceNode, file); callbackID != "" {
+			dt.addDependencyRef(hookNode, dt.graph.Nodes[callbackID], "hooks", usage.Line)
+		}
+	}
+}
+
+// hookNode returns the node for a named hook, creating it on first reference in a file
+// of the given language
+func (dt *DependencyTracker) hookNode(name, language string) *models.DependencyNode {
+	nodeID := "hook:" + name
+	if node, exists := dt.graph.Nodes[nodeID]; exists {
+		return node
+	}
+
+	dt.graph.Lock()
+	defer dt.graph.Unlock()
+
+	node := &models.DependencyNode{
+		ID:           nodeID,
+		Name:         name,
+		Type:         "hook",
+		Dependencies: make(map[string]*models.DependencyRef),
+		Dependents:   make(map[string]*models.DependencyRef),
+		Score:        1,
+		Language:     language,
+	}
+	dt.graph.Nodes[nodeID] = node
+	dt.graph.TotalNodes = len(dt.graph.Nodes)
+	return node
+}
+
+// findCallbackNode resolves a hook callback ("func", "$this->method", "Class::method") to a node ID
+func (dt *DependencyTracker) findCallbackNode(usage models.UsageElement, source *models.DependencyNode, file *models.ParsedFile) string {
+	callback := usage.Callback
+	switch {
+	case callback == "":
+		return ""
+	case strings.HasPrefix(callback, "$this->"):
+		if source == nil {
+			return ""
+		}
+		return dt.findClassMember(models.UsageElement{
+			Type:     "method_call",
+			Name:     strings.TrimPrefix(callback, "$this->"),
+			Receiver: "$this",
+		}, source)
+	case strings.Contains(callback, "::"):
+		parts := strings.SplitN(callback, "::", 2)
+		if (parts[0] == "self" || parts[0] == "static") && source != nil {
+			return dt.findClassMember(models.UsageElement{Type: "static_call", Name: callback, Receiver: parts[0]}, source)
+		}
+
+		classID := dt.findTargetNode(parts[0], file.Namespace)
+		classNode := dt.graph.Nodes[classID]
+		if classNode == nil {
+			return ""
+		}
+		classKey := dt.getFullName(classNode.Namespace, classNode.Name)
+		return dt.methodTable(classKey, make(map[string]bool))[parts[1]]
+	default:
+		return dt.findTargetNode(callback, file.Namespace)
+	}
+}
+
+// indexTraitComposition records which traits each class in a file uses, and how
+func (dt *DependencyTracker) indexTraitComposition(file *models.ParsedFile) {
+	compositionFor := func(className string) *traitComposition {
+		classKey := dt.getFullName(file.Namespace, className)
+		if dt.composition[classKey] == nil {
+			dt.composition[classKey] = &traitComposition{}
+		}
+		return dt.composition[classKey]
+	}
+
+	for _, usage := range file.Usage {
+		if usage.Type != "uses_trait" {
+			continue
+		}
+
+		traitName := strings.TrimPrefix(usage.Name, "\\")
+		if nodeID := dt.findTargetNode(usage.Name, file.Namespace); nodeID != "" {
+			if traitNode := dt.graph.Nodes[nodeID]; traitNode != nil {
+				traitName = dt.getFullName(traitNode.Namespace, traitNode.Name)
+			}
+		}
+
+		comp := compositionFor(usage.Context)
+		comp.traits = append(comp.traits, traitName)
+	}
+
+	for _, adaptation := range file.TraitAdaptations {
+		comp := compositionFor(adaptation.ClassName)
+		comp.adaptations = append(comp.adaptations, adaptation)
+	}
+}
+
+// methodTable returns the methods callable on a class: its own, plus those its traits
+// provide after applying insteadof exclusions and as aliases
+func (dt *DependencyTracker) methodTable(classKey string, visiting map[string]bool) map[string]string {
+	if table, exists := dt.methodTables[classKey]; exists {
+		return table
+	}
+	if visiting[classKey] {
+		return nil // Recursive trait composition
+	}
+	visiting[classKey] = true
+	defer delete(visiting, classKey)
+
+	table := make(map[string]string)
+	for name, nodeID := range dt.classMethods[classKey] {
+		table[name] = nodeID
+	}
+
+	if comp := dt.composition[classKey]; comp != nil {
+		for _, trait := range comp.traits {
+			traitShort := dt.extractClassNameFromImport(trait)
+			for name, nodeID := range dt.methodTable(trait, visiting) {
+				if _, exists := table[name]; exists || comp.excludes(traitShort, name) {
+					continue // Class methods override trait methods; insteadof drops the loser
+				}
+				table[name] = nodeID
+			}
+		}
+
+		for _, adaptation := range comp.adaptations {
+			if adaptation.Alias == "" {
+				continue // Visibility change only
+			}
+			if _, exists := table[adaptation.Alias]; exists {
+				continue
+			}
+			for _, trait := range comp.traits {
+				if adaptation.Trait != "" && dt.extractClassNameFromImport(trait) != dt.extractClassNameFromImport(adaptation.Trait) {
+					continue
+				}
+				if nodeID, exists := dt.methodTable(trait, visiting)[adaptation.Method]; exists {
+					table[adaptation.Alias] = nodeID
+					break
+				}
+			}
+		}
 	}
+
+	dt.methodTables[classKey] = table
+	return table
+}
+
+// excludes reports whether an insteadof rule removes method from the given trait
+func (tc *traitComposition) excludes(traitShort, method string) bool {
+	for _, adaptation := range tc.adaptations {
+		if adaptation.Method != method {
+			continue
+		}
+		for _, excluded := range adaptation.InsteadOf {
+			parts := strings.Split(excluded, "\\")
+			if parts[len(parts)-1] == traitShort {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findClassMember resolves $this->method() and self::/static::method() calls (and
+// Python's self.method()) against the calling class's method table, including methods
+// provided by traits
+func (dt *DependencyTracker) findClassMember(usage models.UsageElement, source *models.DependencyNode) string {
+	var method string
+	switch {
+	case usage.Type == "method_call" && (usage.Receiver == "$this" || usage.Receiver == "this" || usage.Receiver == "self" || usage.Receiver == "cls"):
+		method = usage.Name
+	case usage.Type == "static_call" && (usage.Receiver == "self" || usage.Receiver == "static"):
+		method = strings.TrimPrefix(usage.Name, usage.Receiver+"::")
+	default:
+		return ""
+	}
+
+	className := source.ClassName
+	if isClassLike(source.Type) {
+		className = source.Name
+	}
+	if className == "" || strings.HasPrefix(method, "$") {
+		return ""
+	}
+
+	classKey := dt.getFullName(source.Namespace, className)
+	return dt.methodTable(classKey, make(map[string]bool))[method]
 }
 
 // processFileUsage analyzes usage patterns in a file
 func (dt *DependencyTracker) processFileUsage(file *models.ParsedFile) {
 	for _, usage := range file.Usage {
 		// Store usage for function reporting
-		dt.allUsage = append(dt.allUsage, usage)
+		if !dt.summaryOnly {
+			dt.allUsage = append(dt.allUsage, usage)
+		}
 		dt.createDependency(usage, file)
 	}
 }
@@ -142,27 +619,93 @@ func (dt *DependencyTracker) processImports(file *models.ParsedFile) {
 	}
 }
 
-// createDependency establishes a dependency relationship
-func (dt *DependencyTracker) createDependency(usage models.UsageElement, file *models.ParsedFile) {
-	// Find the source node (where the usage occurs)
-	var sourceNode *models.DependencyNode
+// processSignatures links functions and methods to the types they accept and return
+func (dt *DependencyTracker) processSignatures(file *models.ParsedFile) {
+	for _, element := range file.Elements {
+		if element.Type != "method" && element.Type != "function" {
+			continue
+		}
+
+		sourceNode := dt.graph.Nodes[dt.nodeIDFor(element)]
+		if sourceNode == nil {
+			continue
+		}
+
+		for _, paramType := range element.ParamTypes {
+			dt.createTypeDependency(sourceNode, paramType, "accepts", element.Line, file)
+		}
+		dt.createTypeDependency(sourceNode, element.ReturnType, "returns", element.Line, file)
+	}
+}
+
+// createTypeDependency adds an edge to every project type named in a type declaration
+func (dt *DependencyTracker) createTypeDependency(source *models.DependencyNode, typeDecl, depType string, line int, file *models.ParsedFile) {
+	for _, typeName := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return r == '|' || r == '&' || r == '?' || r == '(' || r == ')'
+	}) {
+		targetNodeID := dt.findTargetNode(typeName, file.Namespace)
+		if targetNodeID == "" {
+			continue // Scalar, external, or ambiguous type
+		}
+
+		if targetNode := dt.graph.Nodes[targetNodeID]; targetNode != nil && isClassLike(targetNode.Type) {
+			dt.addDependencyRef(source, targetNode, depType, line)
+		}
+	}
+}
+
+// findSourceNode finds the node a usage occurs in
+func (dt *DependencyTracker) findSourceNode(usage models.UsageElement, file *models.ParsedFile) *models.DependencyNode {
 	for _, node := range dt.graph.Nodes {
 		if node.File == file.Path {
 			if usage.Context == node.Name ||
 				(usage.Context == node.ClassName && node.Type == "class") {
-				sourceNode = node
-				break
+				return node
 			}
 		}
 	}
+	return nil
+}
+
+// createDependency establishes a dependency relationship
+func (dt *DependencyTracker) createDependency(usage models.UsageElement, file *models.ParsedFile) {
+	switch usage.Type {
+	case "hook_register", "hook_fire":
+		return // Handled by processHooks when hook resolution is enabled
+	case "route", "asset", "http_request":
+		return // Handled by indexRoutes and processBridges when enabled
+	case "twig_register":
+		return // Handled by indexTwigExtensions
+	case "twig_call":
+		dt.createTwigDependency(usage, file)
+		return
+	case "table_reference", "foreign_key", "sql_call":
+		dt.createSQLDependency(usage, file)
+		return
+	}
 
+	// Find the source node (where the usage occurs)
+	sourceNode := dt.findSourceNode(usage, file)
 	if sourceNode == nil {
 		return // Can't find source context
 	}
+	if usage.Type == "view" {
+		if targetNode := dt.graph.Nodes[dt.views[usage.Name]]; targetNode != nil {
+			dt.addDependencyRef(sourceNode, targetNode, usage.Type, usage.Line)
+		}
+		return
+	}
 
-	// Find target node
-	targetNodeID := dt.findTargetNode(usage.Name, file.Namespace)
+	// Find target node, preferring members of the calling class (and its traits)
+	targetNodeID := dt.findClassMember(usage, sourceNode)
 	if targetNodeID == "" {
+		var bound bool
+		if targetNodeID, bound = dt.findModuleBinding(usage, file); !bound {
+			targetNodeID = dt.findTargetNode(usage.Name, file.Namespace)
+		}
+	}
+	if targetNodeID == "" {
+		dt.trackUnresolvedUsage(usage)
 		return // External dependency or not found
 	}
 
@@ -175,6 +718,23 @@ func (dt *DependencyTracker) createDependency(usage models.UsageElement, file *m
 	dt.addDependencyRef(sourceNode, targetNode, usage.Type, usage.Line)
 }
 
+// trackUnresolvedUsage counts usages left dangling because their class name is ambiguous
+func (dt *DependencyTracker) trackUnresolvedUsage(usage models.UsageElement) {
+	if usage.Type == "method_call" || usage.Type == "function_call" {
+		return // Names are members, not classes
+	}
+
+	className := usage.Name
+	if idx := strings.Index(className, "::"); idx != -1 {
+		className = className[:idx]
+	}
+	className = dt.extractClassNameFromImport(className)
+
+	if conflict, exists := dt.conflicts[className]; exists {
+		conflict.UnresolvedUsages++
+	}
+}
+
 // createImportDependency handles import-based dependencies
 func (dt *DependencyTracker) createImportDependency(element models.CodeElement, importPath string, file *models.ParsedFile) {
 	sourceNodeID := dt.nodeIndex[dt.getFullName(element.Namespace, element.Name)]
@@ -209,38 +769,75 @@ func (dt *DependencyTracker) addDependencyRef(source, target *models.DependencyN
 	defer dt.graph.Unlock()
 
 	// Add to source's dependencies
-	if dep, exists := source.Dependencies[target.ID]; exists {
-		dep.Count++
-		dep.Lines = append(dep.Lines, line)
-	} else {
-		source.Dependencies[target.ID] = &models.DependencyRef{
+	dep, exists := source.Dependencies[target.ID]
+	if !exists {
+		dep = &models.DependencyRef{
 			TargetID:   target.ID,
 			TargetName: target.Name,
 			Type:       depType,
-			Count:      1,
-			Lines:      []int{line},
 		}
+		source.Dependencies[target.ID] = dep
 	}
+	dt.recordLine(dep, line)
 
 	// Add to target's dependents
-	if dep, exists := target.Dependents[source.ID]; exists {
-		dep.Count++
-		dep.Lines = append(dep.Lines, line)
-	} else {
-		target.Dependents[source.ID] = &models.DependencyRef{
+	dependent, exists := target.Dependents[source.ID]
+	if !exists {
+		dependent = &models.DependencyRef{
 			TargetID:   source.ID,
 			TargetName: source.Name,
 			Type:       depType,
-			Count:      1,
-			Lines:      []int{line},
 		}
+		target.Dependents[source.ID] = dependent
 	}
+	dt.recordLine(dependent, line)
 
 	dt.graph.TotalEdges++
 }
 
+// recordLine counts one more reference on an edge and keeps its line number, sampling
+// once the edge holds maxLines of them
+func (dt *DependencyTracker) recordLine(dep *models.DependencyRef, line int) {
+	dep.Count++
+	if dt.summaryOnly {
+		return
+	}
+	if dt.maxLines <= 0 || len(dep.Lines) < dt.maxLines {
+		dep.Lines = append(dep.Lines, line)
+		return
+	}
+
+	// Algorithm R: the new line replaces a kept one with probability maxLines/Count
+	dep.Sampled = true
+	if dt.sampler == nil {
+		dt.sampler = rand.New(rand.NewSource(1)) // Fixed seed keeps reports reproducible
+	}
+	if j := dt.sampler.Intn(dep.Count); j < dt.maxLines {
+		dep.Lines[j] = line
+	}
+}
+
+// sortSampledLines restores line order on edges whose lines were sampled
+func (dt *DependencyTracker) sortSampledLines() {
+	if dt.maxLines <= 0 {
+		return
+	}
+	for _, node := range dt.graph.Nodes {
+		for _, refs := range []map[string]*models.DependencyRef{node.Dependencies, node.Dependents} {
+			for _, ref := range refs {
+				if ref.Sampled {
+					sort.Ints(ref.Lines)
+				}
+			}
+		}
+	}
+}
+
 // findTargetNode locates a target node by name and context
 func (dt *DependencyTracker) findTargetNode(name, namespace string) string {
+	// Fully-qualified references (\App\Models\User) are indexed without the leading backslash
+	name = strings.TrimPrefix(name, "\\")
+
 	// For static calls like "Response::create", extract just the class name
 	if strings.Contains(name, "::") {
 		parts := strings.Split(name, "::")
@@ -301,7 +898,7 @@ func (dt *DependencyTracker) calculateComplexityScore(element *models.CodeElemen
 	score := 1 // Base score
 
 	switch element.Type {
-	case "class", "interface", "trait", "enum":
+	case "class", "interface", "trait", "enum", "module":
 		score = 5
 		if element.IsAbstract {
 			score += 2
@@ -362,9 +959,9 @@ func (dt *DependencyTracker) identifyPatterns() {
 	}
 	dt.graph.HighlyDepended = allNodes[:maxHighlyDepended]
 
-	// Find orphans
+	// Find orphans (framework entrypoints are reachable even without dependents)
 	for _, node := range allNodes {
-		if len(node.Dependencies) == 0 && len(node.Dependents) == 0 {
+		if len(node.Dependencies) == 0 && len(node.Dependents) == 0 && !node.IsEntrypoint {
 			dt.graph.Orphans = append(dt.graph.Orphans, node)
 		}
 	}
@@ -379,9 +976,25 @@ func (dt *DependencyTracker) identifyPatterns() {
 		maxComplexNodes = len(allNodes)
 	}
 	dt.graph.ComplexNodes = allNodes[:maxComplexNodes]
+
+	// Report short names that resolved to more than one declaration
+	for _, conflict := range dt.conflicts {
+		if len(conflict.Candidates) < 2 {
+			continue
+		}
+		sort.Strings(conflict.Candidates)
+		dt.graph.AmbiguousNames = append(dt.graph.AmbiguousNames, conflict)
+	}
+	sort.Slice(dt.graph.AmbiguousNames, func(i, j int) bool {
+		return dt.graph.AmbiguousNames[i].Name < dt.graph.AmbiguousNames[j].Name
+	})
 }
 
 // Helper functions
+func (dt *DependencyTracker) nodeIDFor(element models.CodeElement) string {
+	return fmt.Sprintf("%s:%s:%d", element.Type, dt.getFullName(element.Namespace, element.Name), element.Line)
+}
+
 func (dt *DependencyTracker) getFullName(namespace, name string) string {
 	if namespace == "" {
 		return name
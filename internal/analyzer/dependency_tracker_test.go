@@ -3,6 +3,7 @@ package analyzer
 import (
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
 
 	"github.com/boone-studios/tukey/internal/models"
@@ -96,3 +97,389 @@ func TestCalculateComplexityScore(t *testing.T) {
 		t.Errorf("expected static property complexity 3, got %d", got)
 	}
 }
+
+func TestAmbiguousNamesReported(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:      "app/Models/User.php",
+			Namespace: "App\\Models",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "User", Namespace: "App\\Models", Line: 5},
+			},
+		},
+		{
+			Path:      "legacy/User.php",
+			Namespace: "Legacy",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "User", Namespace: "Legacy", Line: 3},
+			},
+		},
+		{
+			Path:      "app/Http/Controller.php",
+			Namespace: "App\\Http",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Controller", Namespace: "App\\Http", Line: 4},
+			},
+			Usage: []models.UsageElement{
+				{Type: "instantiation", Name: "User", Context: "Controller", Line: 10},
+				{Type: "static_call", Name: "User::find", Context: "Controller", Line: 11},
+				{Type: "method_call", Name: "User", Context: "Controller", Line: 12},
+			},
+		},
+	}
+
+	dt := NewDependencyTracker()
+	graph := dt.BuildDependencyGraph(files)
+
+	if len(graph.AmbiguousNames) != 1 {
+		t.Fatalf("expected 1 ambiguous name, got %d", len(graph.AmbiguousNames))
+	}
+	ambiguous := graph.AmbiguousNames[0]
+	if ambiguous.Name != "User" {
+		t.Errorf("expected ambiguous name User, got %q", ambiguous.Name)
+	}
+	if len(ambiguous.Candidates) != 2 {
+		t.Errorf("expected 2 candidates, got %v", ambiguous.Candidates)
+	}
+	if ambiguous.UnresolvedUsages != 2 {
+		t.Errorf("expected 2 unresolved usages, got %d", ambiguous.UnresolvedUsages)
+	}
+}
+
+func TestSignatureTypeEdges(t *testing.T) {
+	file := &models.ParsedFile{
+		Path:      "app/Services/OrderService.php",
+		Namespace: "App\\Services",
+		Elements: []models.CodeElement{
+			{Type: "class", Name: "Cart", Namespace: "App\\Services", Line: 3},
+			{Type: "class", Name: "Order", Namespace: "App\\Services", Line: 5},
+			{
+				Type:       "method",
+				Name:       "place",
+				Namespace:  "App\\Services",
+				ClassName:  "OrderService",
+				Line:       9,
+				Parameters: []string{"cart", "qty"},
+				ParamTypes: []string{"?Cart", "int"},
+				ReturnType: "Order",
+			},
+		},
+	}
+
+	dt := NewDependencyTracker()
+	graph := dt.BuildDependencyGraph([]*models.ParsedFile{file})
+
+	method := graph.Nodes["method:App\\Services\\place:9"]
+	if method == nil {
+		t.Fatalf("method node not found")
+	}
+
+	accepts := method.Dependencies["class:App\\Services\\Cart:3"]
+	if accepts == nil || accepts.Type != "accepts" {
+		t.Errorf("expected accepts edge to Cart, got %+v", accepts)
+	}
+	returns := method.Dependencies["class:App\\Services\\Order:5"]
+	if returns == nil || returns.Type != "returns" {
+		t.Errorf("expected returns edge to Order, got %+v", returns)
+	}
+	if len(method.Dependencies) != 2 {
+		t.Errorf("expected exactly 2 signature edges, got %d", len(method.Dependencies))
+	}
+}
+
+func TestTraitMethodResolution(t *testing.T) {
+	file := &models.ParsedFile{
+		Path:      "app/Talker.php",
+		Namespace: "App",
+		Elements: []models.CodeElement{
+			{Type: "trait", Name: "A", Namespace: "App", Line: 2},
+			{Type: "method", Name: "smallTalk", Namespace: "App", ClassName: "A", Line: 3},
+			{Type: "method", Name: "bigTalk", Namespace: "App", ClassName: "A", Line: 4},
+			{Type: "trait", Name: "B", Namespace: "App", Line: 6},
+			{Type: "method", Name: "smallTalk", Namespace: "App", ClassName: "B", Line: 7},
+			{Type: "method", Name: "bigTalk", Namespace: "App", ClassName: "B", Line: 8},
+			{Type: "class", Name: "Talker", Namespace: "App", Line: 10},
+			{Type: "method", Name: "speak", Namespace: "App", ClassName: "Talker", Line: 16},
+		},
+		Usage: []models.UsageElement{
+			{Type: "uses_trait", Name: "A", Context: "Talker", Line: 11},
+			{Type: "uses_trait", Name: "B", Context: "Talker", Line: 11},
+			{Type: "static_call", Name: "self::smallTalk", Receiver: "self", Context: "speak", Line: 17},
+			{Type: "method_call", Name: "bigTalk", Receiver: "$this", Context: "speak", Line: 18},
+			{Type: "method_call", Name: "talk", Receiver: "$this", Context: "speak", Line: 19},
+		},
+		TraitAdaptations: []models.TraitAdaptation{
+			{ClassName: "Talker", Trait: "B", Method: "smallTalk", InsteadOf: []string{"A"}, Line: 12},
+			{ClassName: "Talker", Trait: "A", Method: "bigTalk", InsteadOf: []string{"B"}, Line: 13},
+			{ClassName: "Talker", Trait: "B", Method: "bigTalk", Alias: "talk", Line: 14},
+		},
+	}
+
+	dt := NewDependencyTracker()
+	graph := dt.BuildDependencyGraph([]*models.ParsedFile{file})
+
+	speak := graph.Nodes["method:App\\speak:16"]
+	if speak == nil {
+		t.Fatalf("speak node not found")
+	}
+
+	want := map[string]int{
+		"method:App\\smallTalk:7": 17, // B::smallTalk insteadof A
+		"method:App\\bigTalk:4":   18, // A::bigTalk insteadof B
+		"method:App\\bigTalk:8":   19, // B::bigTalk as talk
+	}
+	for targetID, line := range want {
+		dep := speak.Dependencies[targetID]
+		if dep == nil {
+			t.Errorf("expected speak to depend on %s, got %v", targetID, speak.Dependencies)
+			continue
+		}
+		if dep.Lines[0] != line {
+			t.Errorf("expected %s edge on line %d, got %v", targetID, line, dep.Lines)
+		}
+	}
+}
+
+func TestHookResolution(t *testing.T) {
+	file := &models.ParsedFile{
+		Path:     "wp-content/plugins/demo/demo.php",
+		Language: "php",
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "demo_boot", Line: 3},
+			{Type: "function", Name: "demo_init", Line: 8},
+		},
+		Usage: []models.UsageElement{
+			{Type: "hook_register", Name: "init", Callback: "demo_init", Context: "demo_boot", Line: 4},
+			{Type: "hook_fire", Name: "init", Context: "demo_boot", Line: 5},
+		},
+	}
+
+	// Hooks are ignored unless enabled
+	graph := NewDependencyTracker().BuildDependencyGraph([]*models.ParsedFile{file})
+	if _, exists := graph.Nodes["hook:init"]; exists {
+		t.Fatalf("hook node created without EnableHooks")
+	}
+
+	dt := NewDependencyTracker()
+	dt.EnableHooks()
+	graph = dt.BuildDependencyGraph([]*models.ParsedFile{file})
+
+	hook := graph.Nodes["hook:init"]
+	if hook == nil {
+		t.Fatalf("expected hook node for init")
+	}
+	if hook.Language != "php" || graph.Nodes["function:demo_init:8"].Language != "php" {
+		t.Errorf("expected nodes tagged with the file's language, got %q and %q", hook.Language, graph.Nodes["function:demo_init:8"].Language)
+	}
+	if dep := hook.Dependencies["function:demo_init:8"]; dep == nil || dep.Type != "hooks" {
+		t.Errorf("expected hook to depend on its callback, got %v", hook.Dependencies)
+	}
+	if dep := hook.Dependents["function:demo_boot:3"]; dep == nil {
+		t.Errorf("expected demo_boot to depend on hook, got %v", hook.Dependents)
+	}
+	for _, orphan := range graph.Orphans {
+		if orphan.Name == "demo_init" {
+			t.Errorf("hook callback should not be orphaned")
+		}
+	}
+	if graph.TotalNodes != 3 {
+		t.Errorf("expected 3 nodes including the hook, got %d", graph.TotalNodes)
+	}
+}
+
+func TestFrameworkEntrypointsAndBuiltins(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path: "modules/demo/demo.module",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "demo_menu", Line: 3},
+				{Type: "function", Name: "demo_helper", Line: 10},
+				{Type: "function", Name: "t", Line: 20},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "t", Context: "demo_helper", Line: 11},
+			},
+		},
+		{
+			Path: "application/controllers/Welcome.php",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Welcome", Line: 2},
+			},
+		},
+	}
+
+	dt := NewDependencyTracker()
+	dt.AddBuiltins("T")
+	if err := dt.AddEntrypoint(`_menu$`); err != nil {
+		t.Fatalf("AddEntrypoint failed: %v", err)
+	}
+	if err := dt.AddEntrypointFile(`(^|/)controllers/`); err != nil {
+		t.Fatalf("AddEntrypointFile failed: %v", err)
+	}
+	if err := dt.AddEntrypoint(`(`); err == nil {
+		t.Errorf("expected invalid pattern to be rejected")
+	}
+
+	graph := dt.BuildDependencyGraph(files)
+
+	if !graph.Nodes["function:demo_menu:3"].IsEntrypoint {
+		t.Errorf("expected demo_menu to be an entrypoint")
+	}
+	if !graph.Nodes["class:Welcome:2"].IsEntrypoint {
+		t.Errorf("expected controller class to be an entrypoint")
+	}
+	if len(graph.Nodes["function:demo_helper:10"].Dependencies) != 0 {
+		t.Errorf("expected builtin call to be dropped")
+	}
+
+	orphans := make(map[string]bool)
+	for _, orphan := range graph.Orphans {
+		orphans[orphan.Name] = true
+	}
+	if orphans["demo_menu"] || orphans["Welcome"] {
+		t.Errorf("entrypoints should not be orphaned: %v", orphans)
+	}
+	if !orphans["demo_helper"] {
+		t.Errorf("expected demo_helper to remain an orphan")
+	}
+}
+
+func TestModuleImportResolution(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:      "src/app.js",
+			Namespace: "src/app",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "main", Namespace: "src/app", Line: 5},
+				{Type: "function", Name: "local", Namespace: "src/app", Line: 12},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "fmt", Context: "main", Line: 6},
+				{Type: "method_call", Name: "run", Receiver: "legacy", Context: "main", Line: 7},
+				{Type: "function_call", Name: "map", Context: "main", Line: 8},
+				{Type: "function_call", Name: "local", Context: "main", Line: 9},
+			},
+			Imports: []models.ImportBinding{
+				{Local: "fmt", Imported: "format", Source: "./utils", Resolved: "src/utils/index.js", Kind: "import"},
+				{Local: "legacy", Imported: "*", Source: "./legacy", Resolved: "src/legacy.js", Kind: "require"},
+				{Local: "map", Imported: "map", Source: "lodash", Kind: "import"},
+			},
+		},
+		{
+			Path:      "src/utils/index.js",
+			Namespace: "src/utils/index",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "formatValue", Namespace: "src/utils/index", Line: 1},
+			},
+			Imports: []models.ImportBinding{},
+			Exports: []models.ExportBinding{{Name: "format", Local: "formatValue"}},
+		},
+		{
+			Path:      "src/legacy.js",
+			Namespace: "src/legacy",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "run", Namespace: "src/legacy", Line: 1},
+			},
+			Imports: []models.ImportBinding{},
+		},
+		{
+			Path:      "src/other.js",
+			Namespace: "src/other",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "map", Namespace: "src/other", Line: 1},
+				{Type: "function", Name: "local", Namespace: "src/other", Line: 3},
+			},
+			Imports: []models.ImportBinding{},
+		},
+	}
+
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+	main := graph.Nodes["function:src/app\\main:5"]
+	if main == nil {
+		t.Fatalf("expected main node, got %v", graph.Nodes)
+	}
+
+	for _, id := range []string{"function:src/utils/index\\formatValue:1", "function:src/legacy\\run:1", "function:src/app\\local:12"} {
+		if main.Dependencies[id] == nil {
+			t.Errorf("expected main to depend on %s, got %v", id, main.Dependencies)
+		}
+	}
+	for _, id := range []string{"function:src/other\\map:1", "function:src/other\\local:3"} {
+		if main.Dependencies[id] != nil {
+			t.Errorf("expected %s not to be matched by name", id)
+		}
+	}
+}
+
+func TestSummaryOnlyDropsLines(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path: "app/helpers.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "format_phone", Line: 3},
+				{Type: "function", Name: "render", Line: 10},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "format_phone", Context: "render", Line: 11},
+				{Type: "function_call", Name: "format_phone", Context: "render", Line: 12},
+			},
+		},
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SummaryOnly()
+	graph := tracker.BuildDependencyGraph(files)
+
+	if graph.TotalEdges != 2 {
+		t.Fatalf("expected 2 edges counted, got %d", graph.TotalEdges)
+	}
+	for _, node := range graph.Nodes {
+		for _, dep := range node.Dependencies {
+			if dep.Count != 2 || len(dep.Lines) != 0 {
+				t.Errorf("expected count 2 without lines, got %+v", dep)
+			}
+		}
+	}
+	if len(tracker.allUsage) != 0 {
+		t.Errorf("expected usage not to be retained, got %d", len(tracker.allUsage))
+	}
+}
+
+func TestMaxLinesPerEdgeSamples(t *testing.T) {
+	file := &models.ParsedFile{
+		Path: "app/helpers.php",
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "log_event", Line: 1},
+			{Type: "function", Name: "handle", Line: 5},
+		},
+	}
+	for line := 10; line < 1010; line++ {
+		file.Usage = append(file.Usage, models.UsageElement{Type: "function_call", Name: "log_event", Context: "handle", Line: line})
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SetMaxLinesPerEdge(50)
+	graph := tracker.BuildDependencyGraph([]*models.ParsedFile{file})
+
+	var dep *models.DependencyRef
+	for _, node := range graph.Nodes {
+		if node.Name == "handle" {
+			for _, d := range node.Dependencies {
+				dep = d
+			}
+		}
+	}
+	if dep == nil {
+		t.Fatalf("expected handle -> log_event edge")
+	}
+	if dep.Count != 1000 || len(dep.Lines) != 50 || !dep.Sampled {
+		t.Fatalf("expected 50 sampled lines of 1000, got count=%d lines=%d sampled=%v", dep.Count, len(dep.Lines), dep.Sampled)
+	}
+	if !sort.IntsAreSorted(dep.Lines) {
+		t.Errorf("expected sampled lines to be sorted: %v", dep.Lines)
+	}
+	// A uniform sample shouldn't be stuck on the first 50 call sites
+	if dep.Lines[len(dep.Lines)-1] < 500 {
+		t.Errorf("expected sample to cover later call sites: %v", dep.Lines)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// docKinds are the declarations documentation coverage counts
+var docKinds = map[string]bool{
+	"class": true, "interface": true, "trait": true, "enum": true, "module": true,
+	"function": true, "method": true,
+}
+
+// globalNamespace names code outside any namespace in the documentation report
+const globalNamespace = "(global)"
+
+// Documentation measures docblock coverage of the public classes, functions, and
+// methods, and comment density, per namespace. In JS modules only exported
+// declarations (and their classes' members) count as public. Files are recorded
+// relative to root. It returns nil when there are no files.
+func Documentation(root string, files []*models.ParsedFile) *models.DocReport {
+	if len(files) == 0 {
+		return nil
+	}
+
+	report := &models.DocReport{Namespaces: []*models.DocNamespace{}, Undocumented: []*models.APISymbol{}}
+	namespaces := make(map[string]*models.DocNamespace)
+	stats := func(name string) *models.DocStats {
+		if name == "" {
+			name = globalNamespace
+		}
+		ns := namespaces[name]
+		if ns == nil {
+			ns = &models.DocNamespace{Name: name}
+			namespaces[name] = ns
+			report.Namespaces = append(report.Namespaces, ns)
+		}
+		return &ns.DocStats
+	}
+
+	for _, file := range files {
+		fileStats := stats(file.Namespace)
+		fileStats.Lines += file.Lines
+		fileStats.CommentLines += file.CommentLines
+		report.Lines += file.Lines
+		report.CommentLines += file.CommentLines
+
+		exported := exportedNames(file)
+		for _, element := range file.Elements {
+			if !docKinds[element.Type] || (element.Visibility != "" && element.Visibility != "public") {
+				continue
+			}
+			if exported != nil {
+				owner := element.Name
+				if element.ClassName != "" {
+					owner = element.ClassName
+				}
+				if !exported[owner] {
+					continue
+				}
+			}
+
+			ns := stats(element.Namespace)
+			ns.Public++
+			report.Public++
+			if element.Documented {
+				ns.Documented++
+				report.Documented++
+				continue
+			}
+			report.Undocumented = append(report.Undocumented, &models.APISymbol{
+				Name:      apiName(element),
+				Kind:      element.Type,
+				Signature: apiSignature(element),
+				File:      relativeTo(root, file.Path),
+				Line:      element.Line,
+			})
+		}
+	}
+
+	setPercentages(&report.DocStats)
+	for _, ns := range report.Namespaces {
+		setPercentages(&ns.DocStats)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		a, b := report.Namespaces[i], report.Namespaces[j]
+		if a.Coverage != b.Coverage {
+			return a.Coverage < b.Coverage
+		}
+		if a.Public != b.Public {
+			return a.Public > b.Public
+		}
+		return a.Name < b.Name
+	})
+	sort.SliceStable(report.Undocumented, func(i, j int) bool {
+		a, b := report.Undocumented[i], report.Undocumented[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return report
+}
+
+// exportedNames returns the local names a JS module exports, or nil for files that
+// aren't modules, where every declaration is public
+func exportedNames(file *models.ParsedFile) map[string]bool {
+	if file.ModuleSystem == "" {
+		return nil
+	}
+	names := make(map[string]bool)
+	for _, export := range file.Exports {
+		if export.Source == "" {
+			names[export.Local] = true
+		}
+	}
+	return names
+}
+
+// setPercentages derives coverage and comment density from the counts, to one decimal
+func setPercentages(stats *models.DocStats) {
+	percent := func(part, whole int) float64 {
+		return math.Round(float64(part)/float64(whole)*1000) / 10
+	}
+	stats.Coverage = 100
+	if stats.Public > 0 {
+		stats.Coverage = percent(stats.Documented, stats.Public)
+	}
+	stats.CommentDensity = 0
+	if stats.Lines > 0 {
+		stats.CommentDensity = percent(stats.CommentLines, stats.Lines)
+	}
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestDocumentation(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:      "proj/src/Billing/Invoice.php",
+			Namespace: `App\Billing`,
+			Lines:     40,
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Invoice", Namespace: `App\Billing`, Line: 5, Documented: true},
+				{Type: "method", Name: "total", Namespace: `App\Billing`, ClassName: "Invoice", Visibility: "public", Line: 9, Documented: true},
+				{Type: "method", Name: "send", Namespace: `App\Billing`, ClassName: "Invoice", Visibility: "public", Line: 20},
+				{Type: "method", Name: "round", Namespace: `App\Billing`, ClassName: "Invoice", Visibility: "private", Line: 30},
+				{Type: "property", Name: "amount", Namespace: `App\Billing`, ClassName: "Invoice", Visibility: "public", Line: 6},
+			},
+			CommentLines: 10,
+		},
+		{
+			Path:  "proj/helpers.php",
+			Lines: 10,
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "money", Line: 2},
+			},
+		},
+		{
+			Path:         "proj/web/util.js",
+			Namespace:    "web/util",
+			ModuleSystem: "esm",
+			Lines:        10,
+			CommentLines: 5,
+			Exports:      []models.ExportBinding{{Name: "format", Local: "format", Kind: "export"}},
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "format", Namespace: "web/util", Line: 3, Documented: true},
+				{Type: "function", Name: "helper", Namespace: "web/util", Line: 8},
+			},
+		},
+	}
+
+	report := Documentation("proj", files)
+	if report.Public != 5 || report.Documented != 3 || report.Coverage != 60 {
+		t.Errorf("expected 3 of 5 public declarations documented, got %+v", report.DocStats)
+	}
+	if report.Lines != 60 || report.CommentLines != 15 || report.CommentDensity != 25 {
+		t.Errorf("unexpected comment density %+v", report.DocStats)
+	}
+
+	if len(report.Namespaces) != 3 {
+		t.Fatalf("expected three namespaces, got %+v", report.Namespaces)
+	}
+	global, billing, web := report.Namespaces[0], report.Namespaces[1], report.Namespaces[2]
+	if global.Name != "(global)" || global.Coverage != 0 {
+		t.Errorf("expected the undocumented global namespace first, got %+v", global)
+	}
+	if billing.Name != `App\Billing` || billing.Public != 3 || billing.Coverage != 66.7 || billing.CommentDensity != 25 {
+		t.Errorf("unexpected billing namespace %+v", billing)
+	}
+	if web.Coverage != 100 {
+		t.Errorf("expected unexported JS functions not to count, got %+v", web)
+	}
+
+	if len(report.Undocumented) != 2 {
+		t.Fatalf("expected two undocumented declarations, got %+v", report.Undocumented)
+	}
+	if sym := report.Undocumented[0]; sym.Name != "money" || sym.File != "helpers.php" {
+		t.Errorf("expected helpers.php first, got %+v", sym)
+	}
+	if sym := report.Undocumented[1]; sym.Name != `App\Billing\Invoice::send` || sym.Line != 20 {
+		t.Errorf("unexpected undocumented method %+v", sym)
+	}
+
+	if Documentation("proj", nil) != nil {
+		t.Error("expected no report without files")
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// flagBodyLines is how far past a check FeatureFlags looks for the branch it guards
+const flagBodyLines = 3
+
+// FeatureFlags finds the calls to the given flag accessors (e.g. "Feature::active",
+// "flags.isEnabled") whose first argument is a literal flag name, and the nodes called
+// in the branch each check guards: a braced block opened after it, or the rest of the
+// statement (a ternary, a one-line if). The gated subgraph is those nodes and all they
+// reach. Files are read again and recorded relative to root; files that can't be read
+// are skipped. It returns nil when no accessors are given.
+func FeatureFlags(root string, graph *models.DependencyGraph, files []*models.ParsedFile, accessors []string) *models.FlagReport {
+	if len(accessors) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(accessors))
+	accessorNames := make(map[string]bool)
+	for i, accessor := range accessors {
+		quoted[i] = regexp.QuoteMeta(accessor)
+		accessorNames[memberName(accessor)] = true
+	}
+	pattern := regexp.MustCompile(`(?:^|[^A-Za-z0-9_$])(?:` + strings.Join(quoted, "|") + `)\s*\(\s*['"]([^'"]+)['"]`)
+
+	// Functions and methods by file, to find the node making each check
+	scopes := make(map[string][]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		if node.Type == "function" || node.Type == "method" {
+			scopes[node.File] = append(scopes[node.File], node)
+		}
+	}
+
+	report := &models.FlagReport{Accessors: accessors, Flags: []*models.FeatureFlag{}}
+	flags := make(map[string]*models.FeatureFlag)
+	for _, file := range files {
+		src, err := os.ReadFile(file.Path)
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(string(src), "\n")
+		for i, line := range lines {
+			if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "*") {
+				continue
+			}
+			for _, match := range pattern.FindAllStringSubmatchIndex(line, -1) {
+				name := line[match[2]:match[3]]
+				flag := flags[name]
+				if flag == nil {
+					flag = &models.FeatureFlag{Name: name}
+					flags[name] = flag
+					report.Flags = append(report.Flags, flag)
+				}
+				check := &models.FlagCheck{File: relativeTo(root, file.Path), Line: i + 1}
+				if scope := enclosingScope(scopes[file.Path], i+1); scope != nil {
+					check.From = scope.ID
+					first, last := guardedLines(lines, i, match[1])
+					check.Gated = gatedNodes(scope, first, last, accessorNames)
+				}
+				flag.Checks = append(flag.Checks, check)
+			}
+		}
+	}
+
+	for _, flag := range report.Flags {
+		gated := make(map[string]bool)
+		for _, check := range flag.Checks {
+			for _, id := range check.Gated {
+				gated[id] = true
+			}
+		}
+		flag.Gated = sortedKeys(gated)
+		flag.Footprint = footprint(graph, flag.Gated)
+		sort.SliceStable(flag.Checks, func(i, j int) bool {
+			a, b := flag.Checks[i], flag.Checks[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	sort.Slice(report.Flags, func(i, j int) bool { return report.Flags[i].Name < report.Flags[j].Name })
+	return report
+}
+
+// memberName is the function an accessor calls: "active" for "Feature::active"
+func memberName(accessor string) string {
+	if i := strings.LastIndexAny(accessor, ":>."); i != -1 {
+		return accessor[i+1:]
+	}
+	return accessor
+}
+
+// enclosingScope returns the function or method declared last before line
+func enclosingScope(nodes []*models.DependencyNode, line int) *models.DependencyNode {
+	var scope *models.DependencyNode
+	for _, node := range nodes {
+		if node.Line <= line && (scope == nil || node.Line > scope.Line) {
+			scope = node
+		}
+	}
+	return scope
+}
+
+// guardedLines returns the 1-based line range a check at lines[index], ending at
+// offset, guards: from the check to the brace closing the block opened after it, or
+// to the end of the statement when a semicolon comes first. The range is the check's
+// line alone when neither is found within flagBodyLines.
+func guardedLines(lines []string, index, offset int) (int, int) {
+	depth := 0
+	for i := index; i < len(lines) && (depth > 0 || i <= index+flagBodyLines); i++ {
+		text := lines[i]
+		if i == index {
+			text = text[offset:]
+		}
+		for _, r := range text {
+			switch {
+			case r == '{':
+				depth++
+			case r == '}':
+				if depth--; depth == 0 {
+					return min(index+2, i+1), i + 1 // The block's lines after the check
+				}
+			case r == ';' && depth == 0:
+				return index + 1, i + 1
+			}
+		}
+	}
+	return index + 1, index + 1
+}
+
+// gatedNodes returns the nodes scope calls between lines first and last, besides the
+// flag accessor itself
+func gatedNodes(scope *models.DependencyNode, first, last int, accessorNames map[string]bool) []string {
+	var gated []string
+	for id, dep := range scope.Dependencies {
+		if accessorNames[memberName(dep.TargetName)] {
+			continue
+		}
+		for _, line := range dep.Lines {
+			if line >= first && line <= last {
+				gated = append(gated, id)
+				break
+			}
+		}
+	}
+	sort.Strings(gated)
+	return gated
+}
+
+// footprint counts the given nodes and every node reachable from them
+func footprint(graph *models.DependencyGraph, ids []string) int {
+	visited := make(map[string]bool)
+	queue := append([]string(nil), ids...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		if node := graph.Nodes[id]; node != nil {
+			for dep := range node.Dependencies {
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return len(visited)
+}
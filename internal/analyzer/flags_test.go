@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "checkout.php")
+	os.WriteFile(path, []byte(`<?php
+function checkout() {
+    if (Feature::active('new-checkout')) {
+        newCheckout();
+    } else {
+        oldCheckout();
+    }
+    $label = Feature::active('beta-label') ? betaLabel() : '';
+}
+function newCheckout() { audit(); }
+function oldCheckout() {}
+function betaLabel() {}
+function audit() { return \Feature::active("new-checkout"); }
+// Feature::active('commented')
+`), 0644)
+
+	files := []*models.ParsedFile{{
+		Path: path,
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "checkout", Line: 2, File: path},
+			{Type: "function", Name: "newCheckout", Line: 10, File: path},
+			{Type: "function", Name: "oldCheckout", Line: 11, File: path},
+			{Type: "function", Name: "betaLabel", Line: 12, File: path},
+			{Type: "function", Name: "audit", Line: 13, File: path},
+		},
+		Usage: []models.UsageElement{
+			{Type: "function_call", Name: "newCheckout", Context: "checkout", Line: 4},
+			{Type: "function_call", Name: "oldCheckout", Context: "checkout", Line: 6},
+			{Type: "function_call", Name: "betaLabel", Context: "checkout", Line: 8},
+			{Type: "function_call", Name: "audit", Context: "newCheckout", Line: 10},
+		},
+	}}
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+
+	if FeatureFlags(root, graph, files, nil) != nil {
+		t.Error("expected no report without accessors")
+	}
+	report := FeatureFlags(root, graph, files, []string{"Feature::active"})
+	if len(report.Flags) != 2 {
+		t.Fatalf("unexpected flags %+v", report.Flags)
+	}
+
+	beta, checkout := report.Flags[0], report.Flags[1]
+	if beta.Name != "beta-label" || !reflect.DeepEqual(beta.Gated, []string{"function:betaLabel:12"}) || beta.Footprint != 1 {
+		t.Errorf("expected the ternary to gate betaLabel, got %+v", beta)
+	}
+	if checkout.Name != "new-checkout" || len(checkout.Checks) != 2 {
+		t.Fatalf("unexpected checks %+v", checkout)
+	}
+	first := checkout.Checks[0]
+	if first.File != "checkout.php" || first.Line != 3 || first.From != "function:checkout:2" {
+		t.Errorf("unexpected check %+v", first)
+	}
+	if !reflect.DeepEqual(checkout.Gated, []string{"function:newCheckout:10"}) {
+		t.Errorf("expected only the if block to be gated, got %v", checkout.Gated)
+	}
+	if checkout.Footprint != 2 {
+		t.Errorf("expected the footprint to reach audit, got %d", checkout.Footprint)
+	}
+}
+
+func TestGuardedLines(t *testing.T) {
+	lines := []string{"if (on('a'))", "{", "  run();", "}", "x = on('b') { y(); }"}
+	if first, last := guardedLines(lines, 0, 11); first != 2 || last != 4 {
+		t.Errorf("expected the block on the following lines, got %d-%d", first, last)
+	}
+	if first, last := guardedLines(lines, 4, 11); first != 5 || last != 5 {
+		t.Errorf("expected a one-line block, got %d-%d", first, last)
+	}
+}
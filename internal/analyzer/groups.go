@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// virtualGroup is a config-defined group and the patterns that select its nodes
+type virtualGroup struct {
+	name     string
+	patterns []*regexp.Regexp
+}
+
+// SetGroups defines virtual groups from config: each name maps to regular expressions
+// matched against a node's qualified name (e.g. App\Billing\Invoice::total) or its file
+// path relative to root (e.g. legacy/billing/invoice.php). Patterns must match the whole
+// name or path. A node joins the first group, by name, with a matching pattern.
+func (dt *DependencyTracker) SetGroups(root string, groups map[string][]string) error {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dt.groups = nil
+	for _, name := range names {
+		group := &virtualGroup{name: name}
+		for _, pattern := range groups[name] {
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err != nil {
+				return fmt.Errorf("group %s: invalid pattern %q: %w", name, pattern, err)
+			}
+			group.patterns = append(group.patterns, re)
+		}
+		dt.groups = append(dt.groups, group)
+	}
+	dt.groupRoot = root
+	return nil
+}
+
+// groupOf returns the name of the first virtual group matching node, or ""
+func (dt *DependencyTracker) groupOf(node *models.DependencyNode) string {
+	if len(dt.groups) == 0 {
+		return ""
+	}
+	qualified := dt.getFullName(node.Namespace, node.Name)
+	if node.ClassName != "" {
+		qualified = dt.getFullName(node.Namespace, node.ClassName) + "::" + node.Name
+	}
+	path := relativeTo(dt.groupRoot, node.File)
+
+	for _, group := range dt.groups {
+		for _, re := range group.patterns {
+			if re.MatchString(qualified) || re.MatchString(path) {
+				return group.name
+			}
+		}
+	}
+	return ""
+}
+
+// relativeTo returns file relative to root with forward slashes, or file itself when it
+// lies outside root
+func relativeTo(root, file string) string {
+	if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file)
+}
+
+// analyzeGroups tags every node with its virtual group, then builds the group metrics
+// and the cross-group dependency matrix. It returns nil when no groups are defined.
+func (dt *DependencyTracker) analyzeGroups() *models.GroupReport {
+	if len(dt.groups) == 0 {
+		return nil
+	}
+
+	dt.graph.Lock()
+	defer dt.graph.Unlock()
+
+	for _, node := range dt.graph.Nodes {
+		node.Group = dt.groupOf(node)
+	}
+
+	report := &models.GroupReport{
+		Groups:       []*models.GroupSummary{},
+		Dependencies: []*models.GroupDependency{},
+	}
+	summaries := make(map[string]*models.GroupSummary)
+	for _, group := range dt.groups {
+		summary := &models.GroupSummary{Name: group.name}
+		summaries[group.name] = summary
+		report.Groups = append(report.Groups, summary)
+	}
+
+	ids := make([]string, 0, len(dt.graph.Nodes))
+	for id := range dt.graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	files := make(map[string]map[string]bool)
+	matrix := make(map[[2]string]*models.GroupDependency)
+	for _, id := range ids {
+		node := dt.graph.Nodes[id]
+		summary := summaries[node.Group]
+		if summary == nil {
+			report.Ungrouped++
+			continue
+		}
+		summary.Nodes++
+		summary.Complexity += node.Score
+		if files[node.Group] == nil {
+			files[node.Group] = make(map[string]bool)
+		}
+		files[node.Group][node.File] = true
+
+		for targetID, ref := range node.Dependencies {
+			target := dt.graph.Nodes[targetID]
+			if target == nil || summaries[target.Group] == nil {
+				continue
+			}
+			if target.Group == node.Group {
+				summary.Internal += ref.Count
+				continue
+			}
+			summary.Outgoing += ref.Count
+			summaries[target.Group].Incoming += ref.Count
+
+			key := [2]string{node.Group, target.Group}
+			dep := matrix[key]
+			if dep == nil {
+				dep = &models.GroupDependency{From: node.Group, To: target.Group}
+				matrix[key] = dep
+				report.Dependencies = append(report.Dependencies, dep)
+			}
+			dep.Edges++
+			dep.Usages += ref.Count
+			if dep.Example == "" || node.Name+" -> "+target.Name < dep.Example {
+				dep.Example = node.Name + " -> " + target.Name
+			}
+		}
+	}
+
+	for _, summary := range report.Groups {
+		summary.Files = len(files[summary.Name])
+		if coupling := summary.Outgoing + summary.Incoming; coupling > 0 {
+			summary.Instability = math.Round(float64(summary.Outgoing)/float64(coupling)*100) / 100
+		}
+	}
+
+	sort.Slice(report.Dependencies, func(i, j int) bool {
+		a, b := report.Dependencies[i], report.Dependencies[j]
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	return report
+}
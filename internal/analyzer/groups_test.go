@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestGroupReport(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:      "proj/app/Billing/Invoice.php",
+			Namespace: `App\Billing`,
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Invoice", Namespace: `App\Billing`, Line: 3},
+				{Type: "method", Name: "total", Namespace: `App\Billing`, ClassName: "Invoice", Line: 5},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "legacy_charge", Context: "total", Line: 6},
+				{Type: "function_call", Name: "legacy_charge", Context: "total", Line: 7},
+			},
+		},
+		{
+			Path: "proj/legacy/billing/charge.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "legacy_charge", Line: 1},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "send_mail", Context: "legacy_charge", Line: 2},
+			},
+		},
+		{
+			Path:      "proj/app/Http/InvoiceController.php",
+			Namespace: `App\Http`,
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "show", Namespace: `App\Http`, Line: 4},
+			},
+			Usage: []models.UsageElement{
+				{Type: "instantiation", Name: "Invoice", Context: "show", Line: 5},
+				{Type: "function_call", Name: "send_mail", Context: "show", Line: 6},
+			},
+		},
+		{
+			Path:     "proj/lib/mail.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "send_mail", Line: 1}},
+		},
+	}
+
+	tracker := NewDependencyTracker()
+	err := tracker.SetGroups("proj", map[string][]string{
+		"Billing": {`App\\Billing\\.*`, `legacy/billing/.*`},
+		"Web":     {`App\\Http\\.*`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph := tracker.BuildDependencyGraph(files)
+
+	for _, node := range graph.Nodes {
+		want := map[string]string{"Invoice": "Billing", "total": "Billing", "legacy_charge": "Billing", "show": "Web", "send_mail": ""}[node.Name]
+		if node.Group != want {
+			t.Errorf("expected %s in group %q, got %q", node.Name, want, node.Group)
+		}
+	}
+
+	report := graph.Groups
+	if report == nil || len(report.Groups) != 2 || report.Ungrouped != 1 {
+		t.Fatalf("unexpected group report: %+v", report)
+	}
+	billing, web := report.Groups[0], report.Groups[1]
+	if billing.Name != "Billing" || billing.Nodes != 3 || billing.Files != 2 || billing.Internal != 2 || billing.Incoming != 1 || billing.Outgoing != 0 {
+		t.Errorf("unexpected Billing summary: %+v", billing)
+	}
+	if web.Outgoing != 1 || web.Instability != 1 || billing.Instability != 0 {
+		t.Errorf("unexpected instability: Web %+v, Billing %+v", web, billing)
+	}
+
+	if len(report.Dependencies) != 1 {
+		t.Fatalf("expected one cross-group dependency (ungrouped targets excluded), got %+v", report.Dependencies)
+	}
+	if dep := report.Dependencies[0]; dep.From != "Web" || dep.To != "Billing" || dep.Edges != 1 || dep.Usages != 1 || dep.Example != "show -> Invoice" {
+		t.Errorf("unexpected dependency: %+v", dep)
+	}
+
+	if NewDependencyTracker().BuildDependencyGraph(files).Groups != nil {
+		t.Errorf("expected no group report without groups")
+	}
+}
+
+func TestSetGroups_InvalidPattern(t *testing.T) {
+	err := NewDependencyTracker().SetGroups(".", map[string][]string{"Broken": {"App("}})
+	if err == nil || !strings.Contains(err.Error(), "Broken") {
+		t.Errorf("expected an error naming the group, got %v", err)
+	}
+}
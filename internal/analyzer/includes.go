@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// headerExtensions are the extensions of C/C++ files meant to be included rather than
+// compiled on their own
+var headerExtensions = map[string]bool{
+	".h": true, ".hh": true, ".hpp": true, ".hxx": true, ".h++": true, ".inl": true, ".ipp": true, ".tpp": true,
+}
+
+// analyzeIncludes builds the file-level graph of C/C++ #include directives (and Dart's
+// import, export, and part directives, which parsers record the same way): which files
+// include which, the headers that reach the most files (and so trigger the most
+// recompilation), headers nothing includes, and include cycles. It returns nil when no
+// file was parsed by a parser that records includes.
+func analyzeIncludes(parsedFiles []*models.ParsedFile) *models.IncludeGraph {
+	analyzed := make(map[string]string) // Cleaned path → path as parsed
+	for _, file := range parsedFiles {
+		if file.Includes != nil {
+			analyzed[filepath.Clean(file.Path)] = file.Path
+		}
+	}
+	if len(analyzed) == 0 {
+		return nil
+	}
+
+	graph := &models.IncludeGraph{
+		Edges:      []*models.IncludeEdge{},
+		Unresolved: []*models.IncludeEdge{},
+		Headers:    []*models.IncludedHeader{},
+		Unused:     []string{},
+		Cycles:     [][]string{},
+	}
+	includes := make(map[string][]string)         // File → files it includes
+	includers := make(map[string]map[string]bool) // File → files including it
+	involved := make(map[string]bool)
+
+	for _, file := range parsedFiles {
+		from := filepath.Clean(file.Path)
+		for _, include := range file.Includes {
+			if include.Resolved == "" {
+				if include.System {
+					graph.System++
+				} else {
+					graph.Unresolved = append(graph.Unresolved, &models.IncludeEdge{From: file.Path, Header: include.Path, Line: include.Line})
+				}
+				continue
+			}
+			to := filepath.Clean(include.Resolved)
+			target, ok := analyzed[to]
+			if !ok {
+				continue // Outside the analyzed files, such as an excluded third-party directory
+			}
+			graph.Edges = append(graph.Edges, &models.IncludeEdge{From: file.Path, To: target, Header: include.Path, Line: include.Line})
+			involved[from], involved[to] = true, true
+			if includers[to] == nil {
+				includers[to] = make(map[string]bool)
+			}
+			if !includers[to][from] {
+				includers[to][from] = true
+				includes[from] = append(includes[from], to)
+			}
+		}
+	}
+	graph.Files = len(involved)
+
+	for to, direct := range includers {
+		graph.Headers = append(graph.Headers, &models.IncludedHeader{
+			File:      analyzed[to],
+			Includers: len(direct),
+			Reach:     includeReach(to, includers),
+		})
+	}
+	sort.Slice(graph.Headers, func(i, j int) bool {
+		a, b := graph.Headers[i], graph.Headers[j]
+		if a.Reach != b.Reach {
+			return a.Reach > b.Reach
+		}
+		if a.Includers != b.Includers {
+			return a.Includers > b.Includers
+		}
+		return a.File < b.File
+	})
+
+	for path, file := range analyzed {
+		if headerExtensions[strings.ToLower(filepath.Ext(path))] && len(includers[path]) == 0 {
+			graph.Unused = append(graph.Unused, file)
+		}
+	}
+	sort.Strings(graph.Unused)
+
+	for _, cycle := range includeCycles(includes) {
+		files := make([]string, len(cycle))
+		for i, path := range cycle {
+			files[i] = analyzed[path]
+		}
+		graph.Cycles = append(graph.Cycles, files)
+	}
+	return graph
+}
+
+// includeReach counts the files that include a file, directly or through other files
+func includeReach(file string, includers map[string]map[string]bool) int {
+	seen := map[string]bool{file: true}
+	queue := []string{file}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for includer := range includers[next] {
+			if !seen[includer] {
+				seen[includer] = true
+				queue = append(queue, includer)
+			}
+		}
+	}
+	return len(seen) - 1
+}
+
+// includeCycles returns the groups of files that include each other, as sorted paths
+// ordered by first path, using Tarjan's algorithm like FindCycles
+func includeCycles(includes map[string][]string) [][]string {
+	files := make([]string, 0, len(includes))
+	for file := range includes {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(file string)
+	visit = func(file string) {
+		index[file] = len(index)
+		lowlink[file] = index[file]
+		stack = append(stack, file)
+		onStack[file] = true
+
+		targets := append([]string(nil), includes[file]...)
+		sort.Strings(targets)
+		for _, target := range targets {
+			if _, seen := index[target]; !seen {
+				visit(target)
+				lowlink[file] = min(lowlink[file], lowlink[target])
+			} else if onStack[target] {
+				lowlink[file] = min(lowlink[file], index[target])
+			}
+		}
+
+		if lowlink[file] != index[file] {
+			return
+		}
+		var group []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			group = append(group, top)
+			if top == file {
+				break
+			}
+		}
+		if len(group) > 1 {
+			sort.Strings(group)
+			cycles = append(cycles, group)
+		}
+	}
+
+	for _, file := range files {
+		if _, seen := index[file]; !seen {
+			visit(file)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
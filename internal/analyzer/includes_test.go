@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestAnalyzeIncludes(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path: "src/main.cpp",
+			Includes: []models.Include{
+				{Path: "vector", System: true, Line: 1},
+				{Path: "a.h", Resolved: "src/a.h", Line: 2},
+				{Path: "gone.h", Line: 3},
+			},
+		},
+		{Path: "src/a.h", Includes: []models.Include{{Path: "b.h", Resolved: "src/b.h", Line: 1}}},
+		{Path: "src/b.h", Includes: []models.Include{{Path: "a.h", Resolved: "src/a.h", Line: 1}}},
+		{Path: "src/c.h", Includes: []models.Include{}},
+		{Path: "src/other.cpp", Includes: []models.Include{{Path: "zlib.h", Resolved: "third_party/zlib.h", Line: 1}}},
+		{Path: "app/index.js"},
+	}
+
+	includes := analyzeIncludes(files)
+	if includes == nil {
+		t.Fatalf("expected an include graph")
+	}
+	if includes.Files != 3 || len(includes.Edges) != 3 || includes.System != 1 {
+		t.Errorf("expected edges only between analyzed files, got %+v", includes)
+	}
+	if len(includes.Unresolved) != 1 || includes.Unresolved[0].Header != "gone.h" || includes.Unresolved[0].From != "src/main.cpp" {
+		t.Errorf("expected gone.h to be unresolved, got %+v", includes.Unresolved)
+	}
+	if len(includes.Headers) != 2 || includes.Headers[0].File != "src/a.h" || includes.Headers[0].Includers != 2 || includes.Headers[0].Reach != 2 {
+		t.Errorf("expected a.h to be included by main.cpp and b.h, got %+v", includes.Headers)
+	}
+	if len(includes.Unused) != 1 || includes.Unused[0] != "src/c.h" {
+		t.Errorf("expected c.h to be unused, got %+v", includes.Unused)
+	}
+	if len(includes.Cycles) != 1 || len(includes.Cycles[0]) != 2 || includes.Cycles[0][0] != "src/a.h" || includes.Cycles[0][1] != "src/b.h" {
+		t.Errorf("expected the a.h/b.h cycle, got %+v", includes.Cycles)
+	}
+
+	if analyzeIncludes([]*models.ParsedFile{{Path: "app/index.js"}}) != nil {
+		t.Errorf("expected no include graph without C/C++ files")
+	}
+}
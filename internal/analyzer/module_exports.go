@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"path/filepath"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// exportTable is what a JS/TS module exports once re-exports are followed to their definitions
+type exportTable struct {
+	nodes      map[string]string // Exported name → node ID of the original definition
+	namespaces map[string]string // Exported name → file re-exported as a namespace ("export * as ns")
+	lines      map[string]int    // Exported name → line of the export statement
+}
+
+// indexModule records a module file's exports and import bindings for later resolution
+func (dt *DependencyTracker) indexModule(file *models.ParsedFile) {
+	if file.Imports == nil {
+		return
+	}
+
+	path := filepath.Clean(file.Path)
+	dt.fileExports[path] = file.Exports
+	dt.fileImports[path] = file.Imports
+}
+
+// moduleExports resolves a module's export table, following re-exports and re-exported
+// imports through barrel files to the files that declare each name
+func (dt *DependencyTracker) moduleExports(path string, visiting map[string]bool) *exportTable {
+	path = filepath.Clean(path)
+	if table, exists := dt.exportTables[path]; exists {
+		return table
+	}
+
+	table := &exportTable{
+		nodes:      make(map[string]string),
+		namespaces: make(map[string]string),
+		lines:      make(map[string]int),
+	}
+	if visiting[path] {
+		return table // Circular re-export
+	}
+	visiting[path] = true
+	defer delete(visiting, path)
+
+	// Explicit exports take precedence over names pulled in by "export *"
+	var stars []models.ExportBinding
+	for _, export := range dt.fileExports[path] {
+		switch {
+		case export.Name == "*":
+			stars = append(stars, export)
+		case export.Local == "*":
+			if export.Resolved != "" {
+				table.namespaces[export.Name] = filepath.Clean(export.Resolved)
+			}
+		case export.Resolved != "":
+			dt.copyExport(table, export.Name, dt.moduleExports(export.Resolved, visiting), export.Local)
+		case dt.fileSymbols[path][export.Local] != "":
+			table.nodes[export.Name] = dt.fileSymbols[path][export.Local]
+		default:
+			dt.exportImported(table, path, export, visiting)
+		}
+		table.lines[export.Name] = export.Line
+	}
+
+	for _, star := range stars {
+		if star.Resolved == "" {
+			continue
+		}
+		source := dt.moduleExports(star.Resolved, visiting)
+		for name := range source.nodes {
+			if _, exists := table.nodes[name]; !exists && name != "default" {
+				dt.copyExport(table, name, source, name)
+				table.lines[name] = star.Line
+			}
+		}
+		for name := range source.namespaces {
+			if _, exists := table.namespaces[name]; !exists {
+				dt.copyExport(table, name, source, name)
+				table.lines[name] = star.Line
+			}
+		}
+	}
+
+	if len(visiting) == 1 {
+		dt.exportTables[path] = table // Only memoize tables not cut short by a cycle
+	}
+	return table
+}
+
+// exportImported handles "import { a } from './x'; export { a }" by following the import
+func (dt *DependencyTracker) exportImported(table *exportTable, path string, export models.ExportBinding, visiting map[string]bool) {
+	for _, binding := range dt.fileImports[path] {
+		if binding.Local != export.Local || binding.Resolved == "" {
+			continue
+		}
+		if binding.Imported == "*" {
+			table.namespaces[export.Name] = filepath.Clean(binding.Resolved)
+		} else {
+			dt.copyExport(table, export.Name, dt.moduleExports(binding.Resolved, visiting), binding.Imported)
+		}
+		return
+	}
+}
+
+// copyExport exposes source's export `from` as `name` in table
+func (dt *DependencyTracker) copyExport(table *exportTable, name string, source *exportTable, from string) {
+	if nodeID, exists := source.nodes[from]; exists {
+		table.nodes[name] = nodeID
+	}
+	if namespace, exists := source.namespaces[from]; exists {
+		table.namespaces[name] = namespace
+	}
+}
+
+// findExport resolves a name exported by a module file to the node ID of its definition
+func (dt *DependencyTracker) findExport(path, name string) string {
+	if nodeID, exists := dt.moduleExports(path, make(map[string]bool)).nodes[name]; exists {
+		return nodeID
+	}
+	return dt.fileSymbols[filepath.Clean(path)][name]
+}
+
+// findModuleBinding resolves a usage through the importing file's bindings and its own
+// declarations. It reports whether the name is bound by the module at all, so names
+// imported from external packages aren't string-matched to unrelated project nodes.
+func (dt *DependencyTracker) findModuleBinding(usage models.UsageElement, file *models.ParsedFile) (string, bool) {
+	if file.Imports == nil {
+		return "", false // Not a module-based language
+	}
+
+	local, member := usage.Name, ""
+	switch usage.Type {
+	case "function_call":
+	case "instantiation", "extends", "implements", "type_reference", "decorator", "component":
+		if usage.Receiver != "" {
+			local, member = usage.Receiver, usage.Name
+		}
+	case "method_call":
+		local, member = usage.Receiver, usage.Name
+	default:
+		return "", false
+	}
+
+	for _, binding := range file.Imports {
+		if binding.Local != local || local == "" {
+			continue
+		}
+		if binding.Resolved == "" {
+			return "", true // External package or unresolvable specifier
+		}
+
+		switch {
+		case binding.Imported == "*" && member != "":
+			return dt.findExport(binding.Resolved, member), true
+		case binding.Imported == "*":
+			return dt.findExport(binding.Resolved, "default"), true // CommonJS module.exports
+		case member != "":
+			// A namespace re-exported by a barrel: import { utils } from './lib'; utils.format()
+			if namespace, exists := dt.moduleExports(binding.Resolved, make(map[string]bool)).namespaces[binding.Imported]; exists {
+				return dt.findExport(namespace, member), true
+			}
+			return "", true // Member of an imported value, e.g. a class's static method
+		default:
+			return dt.findExport(binding.Resolved, binding.Imported), true
+		}
+	}
+
+	if member != "" {
+		return "", false
+	}
+	nodeID, exists := dt.fileSymbols[filepath.Clean(file.Path)][local]
+	return nodeID, exists
+}
+
+// processBarrel adds a "barrel" node for a file that only re-exports other modules,
+// with "re_exports" edges to the definitions it exposes
+func (dt *DependencyTracker) processBarrel(file *models.ParsedFile) {
+	if len(file.Elements) > 0 || !reExports(file) {
+		return
+	}
+
+	table := dt.moduleExports(file.Path, make(map[string]bool))
+	if len(table.nodes) == 0 {
+		return
+	}
+
+	dt.graph.Lock()
+	barrel := &models.DependencyNode{
+		ID:           "barrel:" + file.Path,
+		Name:         file.Namespace,
+		Type:         "barrel",
+		File:         file.Path,
+		Namespace:    file.Namespace,
+		Dependencies: make(map[string]*models.DependencyRef),
+		Dependents:   make(map[string]*models.DependencyRef),
+		Score:        1,
+		Package:      dt.packageOf(file.Path),
+		Language:     file.Language,
+	}
+	dt.graph.Nodes[barrel.ID] = barrel
+	dt.graph.TotalNodes = len(dt.graph.Nodes)
+	dt.graph.Unlock()
+
+	for name, nodeID := range table.nodes {
+		if target := dt.graph.Nodes[nodeID]; target != nil {
+			dt.addDependencyRef(barrel, target, "re_exports", table.lines[name])
+		}
+	}
+}
+
+// reExports reports whether a file re-exports from another module
+func reExports(file *models.ParsedFile) bool {
+	for _, export := range file.Exports {
+		if export.Resolved != "" {
+			return true
+		}
+	}
+	for _, binding := range file.Imports {
+		for _, export := range file.Exports {
+			if export.Local == binding.Local && binding.Resolved != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
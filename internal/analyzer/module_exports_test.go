@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// barrelProject is a component library whose index barrel re-exports from nested barrels
+func barrelProject() []*models.ParsedFile {
+	return []*models.ParsedFile{
+		{
+			Path:      "src/app.js",
+			Namespace: "src/app",
+			Elements:  []models.CodeElement{{Type: "function", Name: "render", Namespace: "src/app", Line: 3}},
+			Usage: []models.UsageElement{
+				{Type: "instantiation", Name: "Button", Context: "render", Line: 4},
+				{Type: "function_call", Name: "format", Context: "render", Line: 5},
+				{Type: "method_call", Name: "pad", Receiver: "strings", Context: "render", Line: 6},
+			},
+			Imports: []models.ImportBinding{
+				{Local: "Button", Imported: "Button", Resolved: "src/components/index.js", Kind: "import"},
+				{Local: "format", Imported: "format", Resolved: "src/components/index.js", Kind: "import"},
+				{Local: "strings", Imported: "strings", Resolved: "src/components/index.js", Kind: "import"},
+			},
+		},
+		{
+			Path:      "src/components/index.js",
+			Namespace: "src/components/index",
+			Imports: []models.ImportBinding{
+				{Local: "format", Imported: "formatValue", Resolved: "src/utils/format.js", Kind: "import", Line: 1},
+			},
+			Exports: []models.ExportBinding{
+				{Name: "format", Local: "format", Kind: "export", Line: 2},
+				{Name: "*", Local: "*", Kind: "export", Resolved: "src/components/button/index.js", Line: 3},
+				{Name: "strings", Local: "*", Kind: "export", Resolved: "src/utils/strings.js", Line: 4},
+			},
+		},
+		{
+			Path:      "src/components/button/index.js",
+			Namespace: "src/components/button/index",
+			Imports:   []models.ImportBinding{},
+			Exports: []models.ExportBinding{
+				{Name: "Button", Local: "default", Kind: "export", Resolved: "src/components/button/Button.js", Line: 1},
+			},
+		},
+		{
+			Path:      "src/components/button/Button.js",
+			Namespace: "src/components/button/Button",
+			Elements:  []models.CodeElement{{Type: "class", Name: "Button", Namespace: "src/components/button/Button", Line: 1}},
+			Imports:   []models.ImportBinding{},
+			Exports:   []models.ExportBinding{{Name: "default", Local: "Button", Kind: "export", Line: 1}},
+		},
+		{
+			Path:      "src/utils/format.js",
+			Namespace: "src/utils/format",
+			Elements:  []models.CodeElement{{Type: "function", Name: "formatValue", Namespace: "src/utils/format", Line: 1}},
+			Imports:   []models.ImportBinding{},
+			Exports:   []models.ExportBinding{{Name: "formatValue", Local: "formatValue", Kind: "export", Line: 1}},
+		},
+		{
+			Path:      "src/utils/strings.js",
+			Namespace: "src/utils/strings",
+			Elements:  []models.CodeElement{{Type: "function", Name: "pad", Namespace: "src/utils/strings", Line: 1}},
+			Imports:   []models.ImportBinding{},
+			Exports:   []models.ExportBinding{{Name: "pad", Local: "pad", Kind: "export", Line: 1}},
+		},
+	}
+}
+
+func TestReExportFlattening(t *testing.T) {
+	graph := NewDependencyTracker().BuildDependencyGraph(barrelProject())
+
+	render := graph.Nodes["function:src/app\\render:3"]
+	for _, id := range []string{
+		"class:src/components/button/Button\\Button:1",
+		"function:src/utils/format\\formatValue:1",
+		"function:src/utils/strings\\pad:1",
+	} {
+		if render.Dependencies[id] == nil {
+			t.Errorf("expected render to depend on %s through the barrels, got %v", id, render.Dependencies)
+		}
+	}
+
+	barrel := graph.Nodes["barrel:src/components/index.js"]
+	if barrel == nil {
+		t.Fatalf("expected a barrel node for src/components/index.js")
+	}
+	if barrel.Dependencies["class:src/components/button/Button\\Button:1"] == nil {
+		t.Errorf("expected barrel to re-export Button, got %v", barrel.Dependencies)
+	}
+	if dep := barrel.Dependencies["function:src/utils/format\\formatValue:1"]; dep == nil || dep.Type != "re_exports" {
+		t.Errorf("expected re_exports edge to formatValue, got %v", barrel.Dependencies)
+	}
+	if graph.Nodes["barrel:src/components/button/index.js"] == nil {
+		t.Errorf("expected nested barrel node")
+	}
+}
+
+func TestCollapseBarrels(t *testing.T) {
+	dt := NewDependencyTracker()
+	dt.CollapseBarrels()
+	graph := dt.BuildDependencyGraph(barrelProject())
+
+	for id, node := range graph.Nodes {
+		if node.Type == "barrel" {
+			t.Errorf("expected barrels to be collapsed, found %s", id)
+		}
+	}
+	if button := graph.Nodes["class:src/components/button/Button\\Button:1"]; len(button.Dependents) != 1 {
+		t.Errorf("expected Button's only dependent to be render, got %v", button.Dependents)
+	}
+}
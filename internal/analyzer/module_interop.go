@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// analyzeModuleInterop reports imports that cross between ES modules and CommonJS, and the
+// files that still rely on CommonJS. It returns nil when no file uses either module system.
+func analyzeModuleInterop(parsedFiles []*models.ParsedFile) *models.ModuleInterop {
+	systems := make(map[string]string)
+	for _, file := range parsedFiles {
+		if file.ModuleSystem != "" {
+			systems[filepath.Clean(file.Path)] = file.ModuleSystem
+		}
+	}
+	if len(systems) == 0 {
+		return nil
+	}
+
+	interop := &models.ModuleInterop{
+		Boundaries:        []*models.ModuleBoundary{},
+		MigrationBlockers: []*models.MigrationBlocker{},
+	}
+	importers := make(map[string]map[string]bool)
+
+	for _, file := range parsedFiles {
+		switch file.ModuleSystem {
+		case "esm":
+			interop.ESMFiles++
+		case "commonjs":
+			interop.CommonJSFiles++
+		case "mixed":
+			interop.MixedFiles++
+		}
+
+		from := filepath.Clean(file.Path)
+		seen := make(map[string]bool)
+		for _, binding := range file.Imports {
+			if binding.Resolved == "" {
+				continue
+			}
+			to := filepath.Clean(binding.Resolved)
+			toSystem, parsed := systems[to]
+			if !parsed {
+				continue // External or outside the analyzed files
+			}
+
+			if importers[to] == nil {
+				importers[to] = make(map[string]bool)
+			}
+			importers[to][from] = true
+
+			// import of CommonJS, or require() of a file using ES module syntax, crosses systems
+			crosses := (binding.Kind == "import" && toSystem == "commonjs") ||
+				(binding.Kind == "require" && toSystem != "commonjs")
+			key := binding.Kind + "\x00" + to
+			if !crosses || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			interop.Boundaries = append(interop.Boundaries, &models.ModuleBoundary{
+				From:       file.Path,
+				To:         binding.Resolved,
+				FromSystem: file.ModuleSystem,
+				ToSystem:   toSystem,
+				Kind:       binding.Kind,
+				Line:       binding.Line,
+			})
+		}
+	}
+
+	for _, file := range parsedFiles {
+		if file.ModuleSystem != "commonjs" && file.ModuleSystem != "mixed" {
+			continue
+		}
+		interop.MigrationBlockers = append(interop.MigrationBlockers, &models.MigrationBlocker{
+			File:      file.Path,
+			System:    file.ModuleSystem,
+			Features:  file.CommonJSFeatures,
+			Importers: len(importers[filepath.Clean(file.Path)]),
+		})
+	}
+
+	// Most-imported blockers first: migrating them unblocks the most files
+	sort.Slice(interop.MigrationBlockers, func(i, j int) bool {
+		a, b := interop.MigrationBlockers[i], interop.MigrationBlockers[j]
+		if a.Importers != b.Importers {
+			return a.Importers > b.Importers
+		}
+		return a.File < b.File
+	})
+	sort.Slice(interop.Boundaries, func(i, j int) bool {
+		a, b := interop.Boundaries[i], interop.Boundaries[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.Line < b.Line
+	})
+
+	return interop
+}
@@ -0,0 +1,61 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestAnalyzeModuleInterop(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:         "src/app.mjs",
+			ModuleSystem: "esm",
+			Imports: []models.ImportBinding{
+				{Local: "legacy", Imported: "default", Resolved: "lib/legacy.js", Kind: "import", Line: 1},
+				{Local: "run", Imported: "run", Resolved: "lib/legacy.js", Kind: "import", Line: 2},
+				{Local: "format", Imported: "format", Resolved: "src/format.mjs", Kind: "import", Line: 3},
+			},
+		},
+		{
+			Path:             "lib/legacy.js",
+			ModuleSystem:     "commonjs",
+			CommonJSFeatures: []string{"module.exports", "require"},
+			Imports: []models.ImportBinding{
+				{Local: "format", Imported: "*", Resolved: "src/format.mjs", Kind: "require", Line: 4},
+				{Local: "fs", Imported: "*", Source: "fs", Kind: "require", Line: 5},
+			},
+		},
+		{Path: "src/format.mjs", ModuleSystem: "esm"},
+		{Path: "lib/unused.cjs", ModuleSystem: "commonjs", CommonJSFeatures: []string{"__dirname"}},
+	}
+
+	interop := analyzeModuleInterop(files)
+	if interop == nil {
+		t.Fatalf("expected module interop report")
+	}
+	if interop.ESMFiles != 2 || interop.CommonJSFiles != 2 || interop.MixedFiles != 0 {
+		t.Errorf("unexpected counts: %+v", interop)
+	}
+
+	if len(interop.Boundaries) != 2 {
+		t.Fatalf("expected 2 boundaries (deduplicated per file), got %+v", interop.Boundaries)
+	}
+	if b := interop.Boundaries[0]; b.From != "lib/legacy.js" || b.Kind != "require" || b.ToSystem != "esm" {
+		t.Errorf("expected require() of an ES module first, got %+v", b)
+	}
+	if b := interop.Boundaries[1]; b.From != "src/app.mjs" || b.Kind != "import" || b.Line != 1 {
+		t.Errorf("expected ESM import of CommonJS, got %+v", b)
+	}
+
+	if len(interop.MigrationBlockers) != 2 {
+		t.Fatalf("expected 2 migration blockers, got %+v", interop.MigrationBlockers)
+	}
+	if first := interop.MigrationBlockers[0]; first.File != "lib/legacy.js" || first.Importers != 1 {
+		t.Errorf("expected most-imported blocker first, got %+v", first)
+	}
+
+	if analyzeModuleInterop([]*models.ParsedFile{{Path: "app/User.php"}}) != nil {
+		t.Errorf("expected no report for files without a module system")
+	}
+}
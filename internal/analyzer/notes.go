@@ -0,0 +1,238 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// notesFile is the layout of a notes file:
+//
+//	notes:
+//	  - match: App\\Billing\\(Invoice|Payment)
+//	    finding: cycles
+//	    note: Intentional cycle, scheduled refactor Q3
+//	    author: billing-team
+//	  - match: App\\Cron\\nightly
+//	    finding: orphans
+//	    expected: entrypoint
+//	    note: Called by the scheduler
+type notesFile struct {
+	Notes []struct {
+		Match    string `yaml:"match"`
+		Finding  string `yaml:"finding"`
+		Note     string `yaml:"note"`
+		Author   string `yaml:"author"`
+		Expected string `yaml:"expected"`
+	} `yaml:"notes"`
+}
+
+// ExpectedOrphans are the reasons an orphan can be marked expected in the notes file:
+// called by a framework or runtime, invoked through reflection (e.g. by name from a
+// string), or kept on purpose
+var ExpectedOrphans = []string{"entrypoint", "reflection", "keep"}
+
+// LoadNotes reads a notes file. Each note has a pattern matched against a node's
+// qualified name or root-relative path, as in suppression files, and may name the
+// finding it explains; without one it's about the nodes themselves. A note that marks
+// orphans as expected is about the orphans finding.
+func LoadNotes(path string) ([]*models.NoteEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file notesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	entries := make([]*models.NoteEntry, 0, len(file.Notes))
+	for i, note := range file.Notes {
+		if note.Match == "" || note.Note == "" {
+			return nil, fmt.Errorf("%s: note %d: expected a match and a note", path, i+1)
+		}
+		if _, err := regexp.Compile(note.Match); err != nil {
+			return nil, fmt.Errorf("%s: note %d: invalid pattern %q: %w", path, i+1, note.Match, err)
+		}
+		finding := ""
+		if findings := parseFindings([]string{note.Finding}); len(findings) > 0 {
+			finding = findings[0]
+		}
+		if note.Expected != "" {
+			if !isExpectedOrphan(note.Expected) {
+				return nil, fmt.Errorf("%s: note %d: expected must be one of %s, got %q", path, i+1, strings.Join(ExpectedOrphans, ", "), note.Expected)
+			}
+			if finding != "" && finding != "orphans" {
+				return nil, fmt.Errorf("%s: note %d: only orphans can be expected, not %s", path, i+1, finding)
+			}
+			finding = "orphans"
+		}
+		entries = append(entries, &models.NoteEntry{
+			Note:    models.Note{Text: note.Note, Finding: finding, Author: note.Author, Expected: note.Expected},
+			Pattern: note.Match,
+		})
+	}
+	return entries, nil
+}
+
+// AttachNotes adds each note to the nodes its pattern matches, and returns the notes
+// that matched nothing, so stale ones can be cleaned up. Orphans with a note marking
+// them expected are dropped from the graph's orphans, and those expected as
+// entrypoints are marked as such.
+func AttachNotes(root string, graph *models.DependencyGraph, entries []*models.NoteEntry) []*models.NoteEntry {
+	ids := make([]string, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var unused []*models.NoteEntry
+	for _, entry := range entries {
+		entry.Nodes = []string{}
+		re := regexp.MustCompile("^(?:" + entry.Pattern + ")$")
+		for _, id := range ids {
+			node := graph.Nodes[id]
+			if re.MatchString(QualifiedName(node)) || re.MatchString(relativeTo(root, node.File)) {
+				node.Notes = append(node.Notes, entry.Note)
+				entry.Nodes = append(entry.Nodes, id)
+				if entry.Expected == "entrypoint" {
+					node.IsEntrypoint = true
+				}
+			}
+		}
+		if len(entry.Nodes) == 0 {
+			unused = append(unused, entry)
+		}
+	}
+
+	orphans := make([]*models.DependencyNode, 0, len(graph.Orphans))
+	for _, node := range graph.Orphans {
+		if !isExpected(node) {
+			orphans = append(orphans, node)
+		}
+	}
+	graph.Orphans = orphans
+	return unused
+}
+
+// AppendNotes adds entries to the notes file at path, creating it if needed. The file is
+// rewritten from its parsed form, which keeps its comments.
+func AppendNotes(path string, entries []*models.NoteEntry) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: expected a mapping with a notes list", path)
+	}
+
+	var notes *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "notes" {
+			notes = root.Content[i+1]
+		}
+	}
+	if notes == nil || notes.Tag == "!!null" {
+		if notes == nil {
+			notes = &yaml.Node{}
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "notes"}, notes)
+		}
+		*notes = yaml.Node{Kind: yaml.SequenceNode}
+	}
+	if notes.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%s: expected notes to be a list", path)
+	}
+
+	for _, entry := range entries {
+		note := &yaml.Node{Kind: yaml.MappingNode}
+		for _, field := range [][2]string{{"match", entry.Pattern}, {"finding", entry.Finding},
+			{"expected", entry.Expected}, {"note", entry.Text}, {"author", entry.Author}} {
+			if field[1] != "" {
+				note.Content = append(note.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Value: field[0]},
+					&yaml.Node{Kind: yaml.ScalarNode, Value: field[1]})
+			}
+		}
+		notes.Content = append(notes.Content, note)
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), 0644)
+}
+
+// isExpectedOrphan checks reason against ExpectedOrphans
+func isExpectedOrphan(reason string) bool {
+	for _, expected := range ExpectedOrphans {
+		if reason == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpected checks if a note marks node as an expected orphan
+func isExpected(node *models.DependencyNode) bool {
+	for _, note := range node.Notes {
+		if note.Expected != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// notesFor returns the notes on node that apply to finding: those about the finding
+// and those about the node itself
+func notesFor(node *models.DependencyNode, finding string) []models.Note {
+	var notes []models.Note
+	for _, note := range node.Notes {
+		if note.Finding == "" || note.Finding == finding {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// findingNotes collects the notes on a pass finding's nodes that apply to it, once each
+func findingNotes(graph *models.DependencyGraph, finding *models.PassFinding) []models.Note {
+	var notes []models.Note
+	seen := make(map[models.Note]bool)
+	for _, id := range finding.Nodes {
+		node := graph.Nodes[id]
+		if node == nil {
+			continue
+		}
+		for _, note := range notesFor(node, finding.Pass) {
+			if !seen[note] {
+				seen[note] = true
+				notes = append(notes, note)
+			}
+		}
+	}
+	return notes
+}
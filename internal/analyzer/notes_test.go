@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestAttachNotes(t *testing.T) {
+	root := t.TempDir()
+	files := []*models.ParsedFile{{
+		Path:      filepath.Join(root, "src", "billing.php"),
+		Namespace: "App",
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "invoice", Namespace: "App", Line: 3},
+			{Type: "function", Name: "payment", Namespace: "App", Line: 7},
+			{Type: "function", Name: "unused", Namespace: "App", Line: 11},
+		},
+		Usage: []models.UsageElement{
+			{Type: "function_call", Name: "payment", Context: "invoice", Line: 4},
+			{Type: "function_call", Name: "invoice", Context: "payment", Line: 8},
+		},
+	}}
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+
+	path := filepath.Join(root, "notes.yml")
+	notes := `notes:
+  - match: App\\(invoice|payment)
+    finding: cycle
+    note: Intentional cycle, scheduled refactor Q3
+    author: billing-team
+  - match: src/billing.php
+    note: Owned by the billing team
+  - match: App\\unused
+    finding: orphans
+    note: Called from templates
+  - match: App\\gone
+    note: Removed last quarter
+`
+	if err := os.WriteFile(path, []byte(notes), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadNotes(path)
+	if err != nil {
+		t.Fatalf("LoadNotes failed: %v", err)
+	}
+	if entries[0].Finding != "cycles" {
+		t.Errorf("expected the finding name canonicalized, got %q", entries[0].Finding)
+	}
+
+	unused := AttachNotes(root, graph, entries)
+	if len(unused) != 1 || unused[0].Pattern != `App\\gone` {
+		t.Errorf("expected the note matching nothing to be returned, got %+v", unused)
+	}
+	if len(entries[1].Nodes) != 3 {
+		t.Errorf("expected the path pattern to match every node in the file, got %v", entries[1].Nodes)
+	}
+
+	_, findings := RunPasses(&models.AnalysisResult{Graph: graph})
+	var cycle *models.PassFinding
+	for _, finding := range findings {
+		if finding.Pass == "cycles" {
+			cycle = finding
+		}
+	}
+	if cycle == nil {
+		t.Fatalf("expected a cycle finding, got %+v", findings)
+	}
+	if len(cycle.Notes) != 2 || cycle.Notes[0].Text != "Intentional cycle, scheduled refactor Q3" || cycle.Notes[0].Author != "billing-team" {
+		t.Errorf("expected the cycle and file notes once each, got %+v", cycle.Notes)
+	}
+}
+
+func TestLoadNotes_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for content, want := range map[string]string{
+		"notes:\n  - match: App\n":                   "expected a match and a note",
+		"notes:\n  - match: \"App(\"\n    note: x\n": "invalid pattern",
+		"notes: [": "notes.yml",
+		"notes:\n  - match: App\n    note: x\n    expected: maybe\n":                     "expected must be one of",
+		"notes:\n  - match: App\n    note: x\n    finding: cycles\n    expected: keep\n": "only orphans",
+	} {
+		path := filepath.Join(dir, "notes.yml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadNotes(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected an error containing %q for %q, got %v", want, content, err)
+		}
+	}
+}
+
+func TestAttachNotes_ExpectedOrphans(t *testing.T) {
+	root := t.TempDir()
+	files := []*models.ParsedFile{{
+		Path:      filepath.Join(root, "cron.php"),
+		Namespace: "App",
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "nightly", Namespace: "App", Line: 3},
+			{Type: "function", Name: "byName", Namespace: "App", Line: 7},
+			{Type: "function", Name: "unused", Namespace: "App", Line: 11},
+		},
+	}}
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+	if len(graph.Orphans) != 3 {
+		t.Fatalf("expected 3 orphans, got %d", len(graph.Orphans))
+	}
+
+	path := filepath.Join(root, ".tukey", "notes.yml")
+	if err := AppendNotes(path, []*models.NoteEntry{
+		{Note: models.Note{Text: "Run by the scheduler", Finding: "orphans", Expected: "entrypoint"}, Pattern: `App\\nightly`},
+	}); err != nil {
+		t.Fatalf("AppendNotes failed: %v", err)
+	}
+	if err := AppendNotes(path, []*models.NoteEntry{
+		{Note: models.Note{Text: "Called by name", Finding: "orphans", Expected: "reflection", Author: "ops"}, Pattern: `App\\byName`},
+	}); err != nil {
+		t.Fatalf("AppendNotes failed: %v", err)
+	}
+	entries, err := LoadNotes(path)
+	if err != nil {
+		t.Fatalf("LoadNotes failed: %v", err)
+	}
+	if len(entries) != 2 || entries[1].Expected != "reflection" || entries[1].Author != "ops" {
+		t.Fatalf("expected both appended notes to be read back, got %+v", entries)
+	}
+
+	AttachNotes(root, graph, entries)
+	if len(graph.Orphans) != 1 || graph.Orphans[0].Name != "unused" {
+		t.Errorf("expected only the unmarked orphan to remain, got %+v", graph.Orphans)
+	}
+	for _, node := range graph.Nodes {
+		if node.IsEntrypoint != (node.Name == "nightly") {
+			t.Errorf("expected only the node marked as an entrypoint to be one, got %s: %v", node.Name, node.IsEntrypoint)
+		}
+	}
+}
+
+func TestAppendNotes_KeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.yml")
+	original := "# Reviewed quarterly\nnotes:\n  - match: App\\\\Legacy\n    note: Frozen\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendNotes(path, []*models.NoteEntry{{Note: models.Note{Text: "Kept on purpose", Finding: "orphans", Expected: "keep"}, Pattern: "App"}}); err != nil {
+		t.Fatalf("AppendNotes failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# Reviewed quarterly\nnotes:\n  - match: App\\\\Legacy\n    note: Frozen\n  - match: App\n") {
+		t.Errorf("expected the comment and existing note kept and the new note appended, got:\n%s", data)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/openapi"
+)
+
+// CorrelateOpenAPI matches the operations of spec to the graph's route nodes (see
+// EnableRoutes) by method and path, with or without the prefix of one of the spec's
+// servers. Path parameters match each other whatever their names. Each implemented
+// operation is reported with the handlers of its route and the dependency footprint
+// behind it; operations no route serves and routes the spec doesn't describe are
+// listed apart. Files are recorded relative to root.
+func CorrelateOpenAPI(root string, graph *models.DependencyGraph, spec *openapi.Spec) *models.OpenAPIReport {
+	var routes []*models.DependencyNode
+	for _, node := range graph.Nodes {
+		if node.Type == "route" {
+			routes = append(routes, node)
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].ID < routes[j].ID })
+
+	report := &models.OpenAPIReport{
+		Spec:          spec.Path,
+		Title:         spec.Title,
+		Version:       spec.Version,
+		Operations:    len(spec.Operations),
+		Routes:        len(routes),
+		Endpoints:     []*models.Endpoint{},
+		Unimplemented: []*models.Endpoint{},
+		Undocumented:  []*models.Endpoint{},
+	}
+	documented := make(map[string]bool)
+	for _, op := range spec.Operations {
+		endpoint := &models.Endpoint{Method: op.Method, Path: op.Path, OperationID: op.OperationID}
+		route := implementingRoute(op, spec.Prefixes, routes)
+		if route == nil {
+			report.Unimplemented = append(report.Unimplemented, endpoint)
+			continue
+		}
+		documented[route.ID] = true
+		describeRoute(root, graph, endpoint, route)
+		report.Endpoints = append(report.Endpoints, endpoint)
+	}
+
+	for _, route := range routes {
+		if documented[route.ID] {
+			continue
+		}
+		method, path, _ := strings.Cut(route.Name, " ")
+		endpoint := &models.Endpoint{Method: method, Path: path}
+		describeRoute(root, graph, endpoint, route)
+		report.Undocumented = append(report.Undocumented, endpoint)
+	}
+	sort.SliceStable(report.Undocumented, func(i, j int) bool {
+		a, b := report.Undocumented[i], report.Undocumented[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return report
+}
+
+// implementingRoute returns the route serving op: one registered for its method over
+// one accepting any method, then the first by ID. It returns nil when none does.
+func implementingRoute(op openapi.Operation, prefixes []string, routes []*models.DependencyNode) *models.DependencyNode {
+	var candidates [][]string
+	for _, prefix := range append([]string{""}, prefixes...) {
+		candidates = append(candidates, urlSegments(prefix+op.Path))
+	}
+
+	var fallback *models.DependencyNode
+	for _, route := range routes {
+		method, path, _ := strings.Cut(route.Name, " ")
+		if method != op.Method && method != "ANY" {
+			continue
+		}
+		segments := urlSegments(path)
+		for _, candidate := range candidates {
+			if !samePath(segments, candidate) {
+				continue
+			}
+			if method == op.Method {
+				return route
+			}
+			if fallback == nil {
+				fallback = route
+			}
+		}
+	}
+	return fallback
+}
+
+// samePath reports whether two paths' segments agree, a parameter ({id}, {id?}) only
+// matching another parameter
+func samePath(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		param := strings.HasPrefix(a[i], "{")
+		if param != strings.HasPrefix(b[i], "{") || (!param && a[i] != b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// describeRoute fills in where route is defined, what it dispatches to, and how much
+// code it reaches
+func describeRoute(root string, graph *models.DependencyGraph, endpoint *models.Endpoint, route *models.DependencyNode) {
+	endpoint.Route = route.ID
+	endpoint.File = relativeTo(root, route.File)
+	endpoint.Line = route.Line
+	for id, dep := range route.Dependencies {
+		if dep.Type == "routes" {
+			endpoint.Handlers = append(endpoint.Handlers, id)
+		}
+	}
+	sort.Strings(endpoint.Handlers)
+
+	visited := map[string]bool{route.ID: true}
+	files := make(map[string]bool)
+	queue := []*models.DependencyNode{route}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for id := range node.Dependencies {
+			target := graph.Nodes[id]
+			if target == nil || visited[id] {
+				continue
+			}
+			visited[id] = true
+			files[target.File] = true
+			queue = append(queue, target)
+		}
+	}
+	endpoint.Footprint = len(visited) - 1
+	endpoint.Files = len(files)
+}
@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/openapi"
+)
+
+func TestCorrelateOpenAPI(t *testing.T) {
+	root := t.TempDir()
+	dt := NewDependencyTracker()
+	dt.EnableRoutes()
+	graph := dt.BuildDependencyGraph(bridgeFiles(root))
+	if graph.Bridges != nil {
+		t.Errorf("routes alone shouldn't report bridges, got %+v", graph.Bridges)
+	}
+
+	spec := &openapi.Spec{
+		Path:     "openapi.yaml",
+		Prefixes: []string{"/api"},
+		Operations: []openapi.Operation{
+			{Method: "GET", Path: "/users/me", OperationID: "me"},
+			{Method: "GET", Path: "/users/{userId}", OperationID: "getUser"},
+			{Method: "DELETE", Path: "/users/{userId}", OperationID: "deleteUser"},
+		},
+	}
+	report := CorrelateOpenAPI(root, graph, spec)
+	if report.Operations != 3 || report.Routes != 3 || len(report.Endpoints) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	me, show := report.Endpoints[0], report.Endpoints[1]
+	if me.Route != "route:GET /api/users/me" || !reflect.DeepEqual(me.Handlers, []string{"method:me:7"}) {
+		t.Errorf("expected the literal path to match its own route, got %+v", me)
+	}
+	if show.Route != "route:GET /api/users/{id}" || show.File != "routes/api.php" || show.Line != 3 {
+		t.Errorf("expected parameters to match whatever their names, got %+v", show)
+	}
+	if show.Footprint != 1 || show.Files != 1 {
+		t.Errorf("expected a footprint of the handler alone, got %d nodes in %d files", show.Footprint, show.Files)
+	}
+
+	if len(report.Unimplemented) != 1 || report.Unimplemented[0].OperationID != "deleteUser" {
+		t.Errorf("unexpected unimplemented %+v", report.Unimplemented)
+	}
+	if len(report.Undocumented) != 1 || report.Undocumented[0].Method != "POST" || report.Undocumented[0].Path != "/api/users" {
+		t.Errorf("unexpected undocumented %+v", report.Undocumented)
+	}
+}
+
+func TestSamePath(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/users/{id}", "/users/{userId}", true},
+		{"/users/{id?}", "/users/{id}", true},
+		{"/users/{id}", "/users/me", false},
+		{"/users", "/users/{id}", false},
+		{"/", "", true},
+	}
+	for _, test := range tests {
+		if got := samePath(urlSegments(test.a), urlSegments(test.b)); got != test.want {
+			t.Errorf("%s vs %s: expected %v", test.a, test.b, test.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/codeowners"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// SetCodeowners tags nodes with the owners a CODEOWNERS file gives their files and
+// enables the ownership report. Files are matched relative to root, which should be the
+// repository root the CODEOWNERS file describes.
+func (dt *DependencyTracker) SetCodeowners(root string, owners *codeowners.File) {
+	dt.owners = owners
+	dt.ownersRoot = root
+}
+
+// analyzeOwnership tags every node with its owners, then builds per-owner totals and the
+// cross-owner dependency matrix. It returns nil without a CODEOWNERS file.
+func (dt *DependencyTracker) analyzeOwnership() *models.OwnershipReport {
+	if dt.owners == nil {
+		return nil
+	}
+
+	dt.graph.Lock()
+	defer dt.graph.Unlock()
+
+	report := &models.OwnershipReport{
+		Source:       dt.owners.Path,
+		Owners:       []*models.OwnerSummary{},
+		Dependencies: []*models.OwnerDependency{},
+	}
+	summaries := make(map[string]*models.OwnerSummary)
+	files := make(map[string]map[string]bool)
+	for _, node := range dt.graph.Nodes {
+		node.Owners = dt.owners.OwnersOf(relativeTo(dt.ownersRoot, node.File))
+		if len(node.Owners) == 0 {
+			report.Unowned++
+		}
+		for _, owner := range node.Owners {
+			summary := summaries[owner]
+			if summary == nil {
+				summary = &models.OwnerSummary{Name: owner, DependsOn: []*models.OwnerUsage{}}
+				summaries[owner] = summary
+				files[owner] = make(map[string]bool)
+				report.Owners = append(report.Owners, summary)
+			}
+			summary.Nodes++
+			files[owner][node.File] = true
+		}
+	}
+
+	matrix := make(map[[2]string]*models.OwnerDependency)
+	for _, node := range dt.graph.Nodes {
+		for targetID, ref := range node.Dependencies {
+			target := dt.graph.Nodes[targetID]
+			if target == nil {
+				continue
+			}
+			for _, from := range node.Owners {
+				for _, to := range target.Owners {
+					if from == to {
+						summaries[from].Internal += ref.Count
+						continue
+					}
+					summaries[from].Outgoing += ref.Count
+					summaries[to].Incoming += ref.Count
+
+					key := [2]string{from, to}
+					dep := matrix[key]
+					if dep == nil {
+						dep = &models.OwnerDependency{From: from, To: to}
+						matrix[key] = dep
+						report.Dependencies = append(report.Dependencies, dep)
+					}
+					dep.Edges++
+					dep.Usages += ref.Count
+					if example := node.Name + " -> " + target.Name; dep.Example == "" || example < dep.Example {
+						dep.Example = example
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(report.Owners, func(i, j int) bool { return report.Owners[i].Name < report.Owners[j].Name })
+	sort.Slice(report.Dependencies, func(i, j int) bool {
+		a, b := report.Dependencies[i], report.Dependencies[j]
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	for _, dep := range report.Dependencies {
+		summary := summaries[dep.From]
+		summary.DependsOn = append(summary.DependsOn, &models.OwnerUsage{Owner: dep.To, Usages: dep.Usages, Edges: dep.Edges})
+	}
+	for _, summary := range report.Owners {
+		summary.Files = len(files[summary.Name])
+	}
+	return report
+}
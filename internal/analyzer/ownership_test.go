@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/codeowners"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestOwnershipReport(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:     "proj/app/Billing/invoice.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "invoice", Line: 1}},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "format_money", Context: "invoice", Line: 2},
+				{Type: "function_call", Name: "format_money", Context: "invoice", Line: 3},
+				{Type: "function_call", Name: "charge", Context: "invoice", Line: 4},
+			},
+		},
+		{
+			Path:     "proj/app/Billing/charge.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "charge", Line: 1}},
+			Usage:    []models.UsageElement{{Type: "function_call", Name: "log_event", Context: "charge", Line: 2}},
+		},
+		{
+			Path:     "proj/lib/money.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "format_money", Line: 1}},
+		},
+		{
+			Path:     "proj/lib/log.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "log_event", Line: 1}},
+		},
+		{
+			Path:     "proj/scripts/run.php",
+			Elements: []models.CodeElement{{Type: "function", Name: "run", Line: 1}},
+			Usage:    []models.UsageElement{{Type: "function_call", Name: "invoice", Context: "run", Line: 2}},
+		},
+	}
+	owners, err := codeowners.Parse(strings.NewReader("/app/Billing/ @acme/billing\n/lib/ @acme/platform\n/lib/log.php @acme/platform @acme/sre\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SetCodeowners("proj", owners)
+	graph := tracker.BuildDependencyGraph(files)
+
+	for _, node := range graph.Nodes {
+		if node.Name == "log_event" && !reflect.DeepEqual(node.Owners, []string{"@acme/platform", "@acme/sre"}) {
+			t.Errorf("expected log_event owned by platform and sre, got %v", node.Owners)
+		}
+	}
+
+	report := graph.Ownership
+	if report == nil || report.Unowned != 1 || len(report.Owners) != 3 {
+		t.Fatalf("unexpected ownership report: %+v", report)
+	}
+	billing := report.Owners[0]
+	if billing.Name != "@acme/billing" || billing.Nodes != 2 || billing.Files != 2 || billing.Internal != 1 || billing.Outgoing != 4 {
+		t.Errorf("unexpected billing summary: %+v", billing)
+	}
+	if len(billing.DependsOn) != 2 || billing.DependsOn[0].Owner != "@acme/platform" || billing.DependsOn[0].Usages != 3 || billing.DependsOn[1].Owner != "@acme/sre" {
+		t.Errorf("expected billing to depend on platform most, then sre: %+v %+v", billing.DependsOn[0], billing.DependsOn[1])
+	}
+	if sre := report.Owners[2]; sre.Name != "@acme/sre" || sre.Incoming != 1 || len(sre.DependsOn) != 0 {
+		t.Errorf("unexpected sre summary: %+v", sre)
+	}
+
+	if len(report.Dependencies) != 2 {
+		t.Fatalf("expected 2 cross-owner dependencies (unowned code excluded), got %+v", report.Dependencies)
+	}
+	if dep := report.Dependencies[0]; dep.From != "@acme/billing" || dep.To != "@acme/platform" || dep.Edges != 2 || dep.Example != "charge -> log_event" {
+		t.Errorf("unexpected top dependency: %+v", dep)
+	}
+
+	if NewDependencyTracker().BuildDependencyGraph(files).Ownership != nil {
+		t.Errorf("expected no ownership report without CODEOWNERS")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// packageOf returns the name of the workspace package containing path
+func (dt *DependencyTracker) packageOf(path string) string {
+	if dt.workspace == nil {
+		return ""
+	}
+	if pkg := dt.workspace.PackageFor(path); pkg != nil {
+		return pkg.Name
+	}
+	return ""
+}
+
+// analyzePackages builds the cross-package dependency matrix and flags dependencies the
+// depending package doesn't declare in its manifest. It returns nil without a workspace.
+func (dt *DependencyTracker) analyzePackages() *models.PackageReport {
+	if dt.workspace == nil {
+		return nil
+	}
+
+	dt.graph.RLock()
+	defer dt.graph.RUnlock()
+
+	report := &models.PackageReport{
+		Manager:      dt.workspace.Manager,
+		Packages:     []*models.PackageSummary{},
+		Dependencies: []*models.PackageDependency{},
+		Violations:   []*models.PackageDependency{},
+	}
+
+	counts := make(map[string]int)
+	for _, node := range dt.graph.Nodes {
+		if node.Package != "" {
+			counts[node.Package]++
+		}
+	}
+	for _, pkg := range dt.workspace.Packages {
+		report.Packages = append(report.Packages, &models.PackageSummary{
+			Name:  pkg.Name,
+			Dir:   pkg.Dir,
+			Kind:  pkg.Kind,
+			Nodes: counts[pkg.Name],
+		})
+	}
+
+	// Walk nodes in ID order so the example edge is stable between runs
+	ids := make([]string, 0, len(dt.graph.Nodes))
+	for id := range dt.graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	matrix := make(map[[2]string]*models.PackageDependency)
+	for _, id := range ids {
+		node := dt.graph.Nodes[id]
+		if node.Package == "" {
+			continue
+		}
+		for targetID := range node.Dependencies {
+			target := dt.graph.Nodes[targetID]
+			if target == nil || target.Package == "" || target.Package == node.Package {
+				continue
+			}
+
+			key := [2]string{node.Package, target.Package}
+			dep := matrix[key]
+			if dep == nil {
+				dep = &models.PackageDependency{
+					From:     node.Package,
+					To:       target.Package,
+					Declared: dt.declares(node.Package, target.Package),
+					Example:  node.Name + " -> " + target.Name,
+				}
+				matrix[key] = dep
+				report.Dependencies = append(report.Dependencies, dep)
+			}
+			dep.Edges++
+		}
+	}
+
+	sort.Slice(report.Dependencies, func(i, j int) bool {
+		a, b := report.Dependencies[i], report.Dependencies[j]
+		if a.Edges != b.Edges {
+			return a.Edges > b.Edges
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	for _, dep := range report.Dependencies {
+		if !dep.Declared && dt.sameKind(dep.From, dep.To) {
+			report.Violations = append(report.Violations, dep)
+		}
+	}
+
+	return report
+}
+
+// declares reports whether package from lists package to in its manifest
+func (dt *DependencyTracker) declares(from, to string) bool {
+	pkg := dt.workspace.Find(from)
+	return pkg != nil && pkg.Dependencies[to]
+}
+
+// sameKind reports whether two packages come from the same package manager. A JS package
+// can't declare a Composer package (or vice versa), so such edges aren't violations.
+func (dt *DependencyTracker) sameKind(a, b string) bool {
+	pa, pb := dt.workspace.Find(a), dt.workspace.Find(b)
+	return pa != nil && pb != nil && pa.Kind == pb.Kind
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/workspace"
+)
+
+func TestPackageReport(t *testing.T) {
+	ws := &workspace.Workspace{
+		Root:    ".",
+		Manager: "npm",
+		Packages: []*workspace.Package{
+			{Name: "@acme/db", Dir: "packages/db", Kind: "npm", Dependencies: map[string]bool{}},
+			{Name: "@acme/ui", Dir: "packages/ui", Kind: "npm", Dependencies: map[string]bool{}},
+			{Name: "@acme/web", Dir: "packages/web", Kind: "npm", Dependencies: map[string]bool{"@acme/ui": true}},
+		},
+	}
+
+	files := []*models.ParsedFile{
+		{
+			Path:      "packages/web/src/app.js",
+			Namespace: "packages/web/src/app",
+			Elements:  []models.CodeElement{{Type: "function", Name: "render", Namespace: "packages/web/src/app", Line: 3}},
+			Usage: []models.UsageElement{
+				{Type: "instantiation", Name: "Button", Context: "render", Line: 4},
+				{Type: "function_call", Name: "query", Context: "render", Line: 5},
+				{Type: "function_call", Name: "helper", Context: "render", Line: 6},
+			},
+			Imports: []models.ImportBinding{
+				{Local: "Button", Imported: "Button", Resolved: "packages/ui/src/Button.js", Kind: "import"},
+				{Local: "query", Imported: "query", Resolved: "packages/db/src/query.js", Kind: "import"},
+				{Local: "helper", Imported: "helper", Resolved: "packages/web/src/helper.js", Kind: "import"},
+			},
+		},
+		{
+			Path:      "packages/web/src/helper.js",
+			Namespace: "packages/web/src/helper",
+			Elements:  []models.CodeElement{{Type: "function", Name: "helper", Namespace: "packages/web/src/helper", Line: 1}},
+			Imports:   []models.ImportBinding{},
+			Exports:   []models.ExportBinding{{Name: "helper", Local: "helper", Kind: "export", Line: 1}},
+		},
+		{
+			Path:      "packages/ui/src/Button.js",
+			Namespace: "packages/ui/src/Button",
+			Elements:  []models.CodeElement{{Type: "class", Name: "Button", Namespace: "packages/ui/src/Button", Line: 1}},
+			Imports:   []models.ImportBinding{},
+			Exports:   []models.ExportBinding{{Name: "Button", Local: "Button", Kind: "export", Line: 1}},
+		},
+		{
+			Path:      "packages/db/src/query.js",
+			Namespace: "packages/db/src/query",
+			Elements:  []models.CodeElement{{Type: "function", Name: "query", Namespace: "packages/db/src/query", Line: 1}},
+			Imports:   []models.ImportBinding{},
+			Exports:   []models.ExportBinding{{Name: "query", Local: "query", Kind: "export", Line: 1}},
+		},
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SetWorkspace(ws)
+	graph := tracker.BuildDependencyGraph(files)
+
+	for _, node := range graph.Nodes {
+		if node.Name == "render" && node.Package != "@acme/web" {
+			t.Errorf("expected render to be tagged @acme/web, got %q", node.Package)
+		}
+	}
+
+	report := graph.Packages
+	if report == nil {
+		t.Fatalf("expected a package report")
+	}
+	if len(report.Packages) != 3 || report.Packages[2].Nodes != 2 {
+		t.Errorf("unexpected package summaries: %+v", report.Packages)
+	}
+	if len(report.Dependencies) != 2 {
+		t.Fatalf("expected 2 cross-package dependencies (same-package edges excluded), got %+v", report.Dependencies)
+	}
+	if len(report.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", report.Violations)
+	}
+	if v := report.Violations[0]; v.From != "@acme/web" || v.To != "@acme/db" || v.Edges != 1 || v.Example != "render -> query" {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+
+	if NewDependencyTracker().BuildDependencyGraph(files).Packages != nil {
+		t.Errorf("expected no package report without a workspace")
+	}
+}
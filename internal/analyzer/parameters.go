@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// DefaultMaxParameters is the parameter count above which a function's parameter list is
+// reported as long
+const DefaultMaxParameters = 5
+
+// minClump is the fewest shared parameters worth suggesting a parameter object for
+const minClump = 3
+
+// SetMaxParameters sets the parameter count above which functions and methods are
+// reported as having a long parameter list; 0 turns the report off
+func (dt *DependencyTracker) SetMaxParameters(max int) {
+	dt.maxParams = max
+}
+
+// recordParameters remembers the parameters of a function or method over the limit
+func (dt *DependencyTracker) recordParameters(nodeID string, element *models.CodeElement) {
+	if dt.maxParams <= 0 || (element.Type != "function" && element.Type != "method") {
+		return
+	}
+	if len(element.Parameters) > dt.maxParams {
+		dt.longParams[nodeID] = element.Parameters
+	}
+}
+
+// analyzeParameters reports the long parameter lists with their call sites, and the
+// groups of parameters they share. It returns nil when the report is turned off.
+func (dt *DependencyTracker) analyzeParameters() *models.ParameterReport {
+	if dt.maxParams <= 0 {
+		return nil
+	}
+
+	dt.graph.RLock()
+	defer dt.graph.RUnlock()
+
+	report := &models.ParameterReport{
+		Max:       dt.maxParams,
+		Functions: []*models.LongParameterList{},
+		Clumps:    []*models.ParameterClump{},
+	}
+	for id, params := range dt.longParams {
+		node := dt.graph.Nodes[id]
+		if node == nil {
+			continue
+		}
+		long := &models.LongParameterList{
+			ID:         id,
+			Name:       displayName(node),
+			File:       node.File,
+			Line:       node.Line,
+			Parameters: params,
+			CallSites:  []*models.CallSite{},
+		}
+		for callerID, ref := range node.Dependents {
+			site := &models.CallSite{Caller: callerID, Name: ref.TargetName, Count: ref.Count, Lines: ref.Lines}
+			if caller := dt.graph.Nodes[callerID]; caller != nil {
+				site.Name, site.File = displayName(caller), caller.File
+			}
+			long.Calls += ref.Count
+			long.CallSites = append(long.CallSites, site)
+		}
+		sort.Slice(long.CallSites, func(i, j int) bool {
+			a, b := long.CallSites[i], long.CallSites[j]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.Caller < b.Caller
+		})
+		report.Functions = append(report.Functions, long)
+	}
+
+	sort.Slice(report.Functions, func(i, j int) bool {
+		a, b := report.Functions[i], report.Functions[j]
+		if len(a.Parameters) != len(b.Parameters) {
+			return len(a.Parameters) > len(b.Parameters)
+		}
+		return a.ID < b.ID
+	})
+	report.Clumps = parameterClumps(report.Functions)
+	return report
+}
+
+// parameterClumps finds sets of at least minClump parameter names that two or more long
+// parameter lists share. Each pair of functions proposes its common parameters; a clump
+// then gathers every function having all of them. Clumps contained in a larger one with
+// the same functions are dropped.
+func parameterClumps(functions []*models.LongParameterList) []*models.ParameterClump {
+	sets := make([]map[string]bool, len(functions))
+	for i, fn := range functions {
+		sets[i] = make(map[string]bool, len(fn.Parameters))
+		for _, param := range fn.Parameters {
+			sets[i][param] = true
+		}
+	}
+
+	byKey := make(map[string]*models.ParameterClump)
+	for i := range functions {
+		for j := i + 1; j < len(functions); j++ {
+			var shared []string
+			for param := range sets[i] {
+				if sets[j][param] {
+					shared = append(shared, param)
+				}
+			}
+			if len(shared) < minClump {
+				continue
+			}
+			sort.Strings(shared)
+			key := strings.Join(shared, ",")
+			if byKey[key] != nil {
+				continue
+			}
+
+			clump := &models.ParameterClump{Parameters: shared}
+			for k, fn := range functions {
+				if containsAll(sets[k], shared) {
+					clump.Functions = append(clump.Functions, fn.Name)
+				}
+			}
+			byKey[key] = clump
+		}
+	}
+
+	clumps := []*models.ParameterClump{}
+	for _, clump := range byKey {
+		subsumed := false
+		for _, other := range byKey {
+			if len(other.Parameters) > len(clump.Parameters) && len(other.Functions) == len(clump.Functions) &&
+				containsAll(toSet(other.Parameters), clump.Parameters) {
+				subsumed = true
+				break
+			}
+		}
+		if !subsumed {
+			clumps = append(clumps, clump)
+		}
+	}
+	sort.Slice(clumps, func(i, j int) bool {
+		a, b := clumps[i], clumps[j]
+		if len(a.Functions) != len(b.Functions) {
+			return len(a.Functions) > len(b.Functions)
+		}
+		if len(a.Parameters) != len(b.Parameters) {
+			return len(a.Parameters) > len(b.Parameters)
+		}
+		return strings.Join(a.Parameters, ",") < strings.Join(b.Parameters, ",")
+	})
+	return clumps
+}
+
+// containsAll reports whether set has every name
+func containsAll(set map[string]bool, names []string) bool {
+	for _, name := range names {
+		if !set[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// toSet turns names into a set
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// displayName names a node the way reports show it: Class::method, or the bare name
+func displayName(node *models.DependencyNode) string {
+	if node.ClassName != "" {
+		return node.ClassName + "::" + node.Name
+	}
+	return node.Name
+}
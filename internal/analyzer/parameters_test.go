@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestParameterReport(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path: "proj/billing.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "create_invoice", Line: 1, Parameters: []string{"customer", "amount", "currency", "taxRate", "dueDate", "notes"}},
+				{Type: "function", Name: "quote", Line: 10, Parameters: []string{"customer", "amount", "currency", "taxRate", "validUntil", "notes", "discount"}},
+				{Type: "function", Name: "refund", Line: 20, Parameters: []string{"invoice", "amount", "reason"}},
+			},
+		},
+		{
+			Path: "proj/checkout.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "checkout", Line: 1},
+				{Type: "method", Name: "send", ClassName: "Mailer", Line: 5, Parameters: []string{"to", "cc", "bcc", "subject", "body", "attachments"}},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "create_invoice", Context: "checkout", Line: 2},
+				{Type: "function_call", Name: "create_invoice", Context: "checkout", Line: 3},
+				{Type: "function_call", Name: "quote", Context: "checkout", Line: 4},
+			},
+		},
+	}
+
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+	report := graph.LongParameters
+	if report == nil || report.Max != DefaultMaxParameters {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	var names []string
+	for _, fn := range report.Functions {
+		names = append(names, fn.Name)
+	}
+	if want := []string{"quote", "create_invoice", "Mailer::send"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected %v, most parameters first, got %v", want, names)
+	}
+
+	invoice := report.Functions[1]
+	if invoice.Calls != 2 || len(invoice.CallSites) != 1 {
+		t.Fatalf("expected two calls from one site, got %+v", invoice)
+	}
+	if site := invoice.CallSites[0]; site.Name != "checkout" || site.File != "proj/checkout.php" || !reflect.DeepEqual(site.Lines, []int{2, 3}) {
+		t.Errorf("unexpected call site: %+v", site)
+	}
+
+	if len(report.Clumps) != 1 {
+		t.Fatalf("expected one parameter clump, got %+v", report.Clumps)
+	}
+	clump := report.Clumps[0]
+	if want := []string{"amount", "currency", "customer", "notes", "taxRate"}; !reflect.DeepEqual(clump.Parameters, want) {
+		t.Errorf("expected clump %v, got %v", want, clump.Parameters)
+	}
+	if want := []string{"quote", "create_invoice"}; !reflect.DeepEqual(clump.Functions, want) {
+		t.Errorf("expected clump functions %v, got %v", want, clump.Functions)
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SetMaxParameters(0)
+	if tracker.BuildDependencyGraph(files).LongParameters != nil {
+		t.Error("expected no report when turned off")
+	}
+}
+
+func TestParameterClumps_DropsSubsumed(t *testing.T) {
+	functions := []*models.LongParameterList{
+		{Name: "a", Parameters: []string{"x", "y", "z", "w", "v", "u"}},
+		{Name: "b", Parameters: []string{"x", "y", "z", "w", "q", "r"}},
+		{Name: "c", Parameters: []string{"x", "y", "z", "s", "t", "p"}},
+	}
+	clumps := parameterClumps(functions)
+
+	// a and b share w, x, y, z; all three share x, y, z
+	if len(clumps) != 2 {
+		t.Fatalf("expected two clumps, got %+v", clumps)
+	}
+	if !reflect.DeepEqual(clumps[0].Parameters, []string{"x", "y", "z"}) || len(clumps[0].Functions) != 3 {
+		t.Errorf("expected the clump shared by all three first, got %+v", clumps[0])
+	}
+	if !reflect.DeepEqual(clumps[1].Parameters, []string{"w", "x", "y", "z"}) || len(clumps[1].Functions) != 2 {
+		t.Errorf("unexpected second clump %+v", clumps[1])
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Pass is an analysis run on the finished result: the built graph, the parsed files, and
+// the other reports. It contributes metrics, which thresholds and severities can be set
+// on like the built-in ones, and findings for the report. Passes must not modify the
+// result; they run under the graph's read lock.
+type Pass interface {
+	Name() string      // e.g. "cycles"
+	Metrics() []string // The metrics Run returns, e.g. "cycles"
+	Run(result *models.AnalysisResult) *PassResult
+}
+
+// PassResult is what a pass found
+type PassResult struct {
+	Metrics  map[string]int
+	Findings []*models.PassFinding // Pass is filled in by RunPasses
+}
+
+// registry of analyzer passes, and the pass declaring each metric
+var (
+	passMu    sync.RWMutex
+	passes    = map[string]Pass{}
+	passOwner = map[string]string{}
+)
+
+// RegisterPass adds a pass to the global registry.
+// Typically called from pass init() functions.
+func RegisterPass(p Pass) {
+	passMu.Lock()
+	defer passMu.Unlock()
+
+	name := p.Name()
+	if _, exists := passes[name]; exists {
+		panic(fmt.Sprintf("analyzer pass %q already registered", name))
+	}
+	for _, metric := range p.Metrics() {
+		if owner, exists := passOwner[metric]; exists {
+			panic(fmt.Sprintf("metric %q of analyzer pass %q is already declared by %q", metric, name, owner))
+		}
+	}
+	for _, metric := range p.Metrics() {
+		passOwner[metric] = name
+	}
+	passes[name] = p
+}
+
+// Passes returns the registered passes, sorted by name
+func Passes() []Pass {
+	passMu.RLock()
+	defer passMu.RUnlock()
+
+	list := make([]Pass, 0, len(passes))
+	for _, p := range passes {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// PassMetrics returns the sorted names of the metrics the registered passes declare
+func PassMetrics() []string {
+	passMu.RLock()
+	defer passMu.RUnlock()
+
+	names := make([]string, 0, len(passOwner))
+	for name := range passOwner {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RunPasses runs every registered pass on result, by name, and returns their metrics and
+// findings, with the notes on their nodes that explain them. Metrics a pass doesn't
+// return are 0. Callers must hold the graph's read lock
+// if it may still change.
+func RunPasses(result *models.AnalysisResult) (map[string]int, []*models.PassFinding) {
+	metrics := make(map[string]int)
+	findings := []*models.PassFinding{}
+	for _, p := range Passes() {
+		out := p.Run(result)
+		for _, metric := range p.Metrics() {
+			if out != nil {
+				metrics[metric] = out.Metrics[metric]
+			} else {
+				metrics[metric] = 0
+			}
+		}
+		if out == nil {
+			continue
+		}
+		for _, finding := range out.Findings {
+			finding.Pass = p.Name()
+			finding.Notes = findingNotes(result.Graph, finding)
+			findings = append(findings, finding)
+		}
+	}
+	return metrics, findings
+}
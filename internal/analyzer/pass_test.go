@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// godClassPass is a custom pass, registered the way a user's own pass would be
+type godClassPass struct{}
+
+func (godClassPass) Name() string      { return "testGodClasses" }
+func (godClassPass) Metrics() []string { return []string{"testGodClasses"} }
+
+func (godClassPass) Run(result *models.AnalysisResult) *PassResult {
+	out := &PassResult{Metrics: map[string]int{}}
+	for _, node := range result.Graph.Nodes {
+		if node.Type == "class" && len(node.Dependencies) >= 2 {
+			out.Metrics["testGodClasses"]++
+			out.Findings = append(out.Findings, &models.PassFinding{Message: node.Name + " depends on too much", Nodes: []string{node.ID}})
+		}
+	}
+	return out
+}
+
+func TestRunPasses(t *testing.T) {
+	RegisterPass(godClassPass{})
+
+	file := &models.ParsedFile{
+		Path: "app/functions.php",
+		Elements: []models.CodeElement{
+			{Type: "class", Name: "Kitchen", Line: 1},
+			{Type: "function", Name: "a", Line: 5},
+			{Type: "function", Name: "b", Line: 9},
+		},
+		Usage: []models.UsageElement{
+			{Type: "function_call", Name: "a", Context: "Kitchen", Line: 2},
+			{Type: "function_call", Name: "b", Context: "Kitchen", Line: 3},
+			{Type: "function_call", Name: "b", Context: "a", Line: 6},
+			{Type: "function_call", Name: "a", Context: "b", Line: 10},
+		},
+	}
+	graph := NewDependencyTracker().BuildDependencyGraph([]*models.ParsedFile{file})
+	metrics, findings := RunPasses(&models.AnalysisResult{Graph: graph})
+
+	if metrics["cycles"] != 1 || metrics["testGodClasses"] != 1 {
+		t.Errorf("expected the built-in and custom metrics, got %v", metrics)
+	}
+	if _, ok := metrics["orphans"]; !ok {
+		t.Errorf("expected every declared metric, got %v", metrics)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected a cycle and a custom finding, got %d", len(findings))
+	}
+	if findings[0].Pass != "cycles" || !strings.Contains(findings[0].Message, "a, b") || findings[0].File != "app/functions.php" {
+		t.Errorf("unexpected cycle finding %+v", findings[0])
+	}
+	if findings[1].Pass != "testGodClasses" {
+		t.Errorf("expected findings tagged with their pass, got %+v", findings[1])
+	}
+
+	found := false
+	for _, metric := range PassMetrics() {
+		found = found || metric == "testGodClasses"
+	}
+	if !found {
+		t.Errorf("expected the custom metric to be declared, got %v", PassMetrics())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a pass twice to panic")
+		}
+	}()
+	RegisterPass(godClassPass{})
+}
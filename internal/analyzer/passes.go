@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// The built-in passes: the cycle detector, and the smell detectors behind the orphan,
+// complexity, and long parameter list metrics. Findings are only reported for what the
+// graph doesn't already list, so orphans and long parameter lists are metrics only.
+func init() {
+	RegisterPass(cyclePass{})
+	RegisterPass(orphanPass{})
+	RegisterPass(complexityPass{})
+	RegisterPass(parameterPass{})
+}
+
+// cyclePass reports each dependency cycle FindCycles finds
+type cyclePass struct{}
+
+func (cyclePass) Name() string      { return "cycles" }
+func (cyclePass) Metrics() []string { return []string{"cycles"} }
+
+func (cyclePass) Run(result *models.AnalysisResult) *PassResult {
+	cycles := FindCycles(result.Graph)
+	out := &PassResult{Metrics: map[string]int{"cycles": len(cycles)}}
+	for _, cycle := range cycles {
+		names := make([]string, len(cycle))
+		for i, id := range cycle {
+			names[i] = result.Graph.Nodes[id].Name
+		}
+		first := result.Graph.Nodes[cycle[0]]
+		out.Findings = append(out.Findings, &models.PassFinding{
+			Message: fmt.Sprintf("Cycle through %d nodes: %s", len(cycle), strings.Join(names, ", ")),
+			Nodes:   cycle,
+			File:    first.File,
+			Line:    first.Line,
+		})
+	}
+	return out
+}
+
+// orphanPass counts the nodes nothing depends on
+type orphanPass struct{}
+
+func (orphanPass) Name() string      { return "orphans" }
+func (orphanPass) Metrics() []string { return []string{"orphans"} }
+
+func (orphanPass) Run(result *models.AnalysisResult) *PassResult {
+	return &PassResult{Metrics: map[string]int{"orphans": len(result.Graph.Orphans)}}
+}
+
+// complexityPass finds the highest complexity score in scope
+type complexityPass struct{}
+
+func (complexityPass) Name() string      { return "complexity" }
+func (complexityPass) Metrics() []string { return []string{"maxComplexity"} }
+
+func (complexityPass) Run(result *models.AnalysisResult) *PassResult {
+	max := 0
+	for _, node := range result.Graph.Nodes {
+		if node.Score > max && !IsSuppressed(node, "maxComplexity") && InScope(result.Graph, node) {
+			max = node.Score
+		}
+	}
+	return &PassResult{Metrics: map[string]int{"maxComplexity": max}}
+}
+
+// parameterPass counts the functions over the parameter limit
+type parameterPass struct{}
+
+func (parameterPass) Name() string      { return "longParameterLists" }
+func (parameterPass) Metrics() []string { return []string{"longParameterLists"} }
+
+func (parameterPass) Run(result *models.AnalysisResult) *PassResult {
+	count := 0
+	if result.Graph.LongParameters != nil {
+		count = len(result.Graph.LongParameters.Functions)
+	}
+	return &PassResult{Metrics: map[string]int{"longParameterLists": count}}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// extensionPrefixes map function name prefixes to the PHP extension defining the
+// functions; extensions PHP can't be built without, such as json and pcre, aren't listed
+var extensionPrefixes = []struct{ prefix, extension string }{
+	{"curl_", "curl"}, {"mb_", "mbstring"}, {"gmp_", "gmp"}, {"openssl_", "openssl"},
+	{"sodium_", "sodium"}, {"mysqli_", "mysqli"}, {"pg_", "pgsql"}, {"ldap_", "ldap"},
+	{"imap_", "imap"}, {"ftp_", "ftp"}, {"pcntl_", "pcntl"}, {"posix_", "posix"},
+	{"socket_", "sockets"}, {"exif_", "exif"}, {"finfo_", "fileinfo"}, {"iconv_", "iconv"},
+	{"ctype_", "ctype"}, {"grapheme_", "intl"}, {"idn_to_", "intl"}, {"intl_", "intl"},
+	{"numfmt_", "intl"}, {"collator_", "intl"}, {"msgfmt_", "intl"}, {"normalizer_", "intl"},
+	{"xml_", "xml"}, {"simplexml_", "simplexml"}, {"xmlwriter_", "xmlwriter"},
+	{"libxml_", "libxml"}, {"zip_", "zip"}, {"zlib_", "zlib"}, {"apcu_", "apcu"},
+	{"igbinary_", "igbinary"}, {"yaml_", "yaml"}, {"tidy_", "tidy"}, {"readline_", "readline"},
+	{"imagecreate", "gd"}, {"imagecolor", "gd"}, {"gd_", "gd"},
+}
+
+// extensionFunctions map functions without an extension's prefix to the extension
+var extensionFunctions = map[string]string{
+	"iconv": "iconv", "mime_content_type": "fileinfo", "readline": "readline",
+	"gettext": "gettext", "ngettext": "gettext", "dgettext": "gettext",
+	"textdomain": "gettext", "bindtextdomain": "gettext",
+	"bcadd": "bcmath", "bcsub": "bcmath", "bcmul": "bcmath", "bcdiv": "bcmath", "bcmod": "bcmath",
+	"bcpow": "bcmath", "bcsqrt": "bcmath", "bcscale": "bcmath", "bccomp": "bcmath", "bcpowmod": "bcmath",
+	"gzopen": "zlib", "gzcompress": "zlib", "gzuncompress": "zlib", "gzencode": "zlib",
+	"gzdecode": "zlib", "gzinflate": "zlib", "gzdeflate": "zlib",
+	"bzopen": "bz2", "bzcompress": "bz2", "bzdecompress": "bz2",
+	"imagepng": "gd", "imagejpeg": "gd", "imagegif": "gd", "imagewebp": "gd", "imagedestroy": "gd",
+	"imagecopyresampled": "gd", "imagecopyresized": "gd", "imagesx": "gd", "imagesy": "gd",
+	"imagettftext": "gd", "imagestring": "gd", "imagefill": "gd", "imagescale": "gd", "imagerotate": "gd",
+	"dom_import_simplexml": "dom", "token_get_all": "tokenizer",
+}
+
+// extensionClasses map classes to the extension defining them
+var extensionClasses = map[string]string{
+	"PDO": "pdo", "PDOStatement": "pdo", "PDOException": "pdo",
+	"ZipArchive": "zip", "DOMDocument": "dom", "DOMXPath": "dom", "DOMElement": "dom", "DOMNode": "dom",
+	"SimpleXMLElement": "simplexml", "XMLReader": "xmlreader", "XMLWriter": "xmlwriter",
+	"XSLTProcessor": "xsl", "SoapClient": "soap", "SoapServer": "soap", "SoapFault": "soap",
+	"Redis": "redis", "Memcached": "memcached", "Imagick": "imagick", "SQLite3": "sqlite3",
+	"mysqli": "mysqli", "finfo": "fileinfo", "AMQPConnection": "amqp",
+	"NumberFormatter": "intl", "IntlDateFormatter": "intl", "Collator": "intl", "Normalizer": "intl",
+	"Locale": "intl", "Transliterator": "intl", `MongoDB\Driver\Manager`: "mongodb",
+}
+
+// composerPlatform is the subset of composer.json naming platform requirements
+type composerPlatform struct {
+	Require map[string]string `json:"require"`
+}
+
+// PlatformExtensions finds the PHP extensions the code calls into, by the functions and
+// classes each defines, and reports those composer.json doesn't require as ext-*
+// packages or through a polyfill. Functions the code defines itself, such as its own
+// polyfills, don't count. It returns nil without a composer.json in root or when no
+// extension is used.
+func PlatformExtensions(root string, files []*models.ParsedFile) *models.PlatformReport {
+	data, err := os.ReadFile(filepath.Join(root, "composer.json"))
+	if err != nil {
+		return nil
+	}
+	var manifest composerPlatform
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil
+	}
+	declared := make(map[string]bool)
+	for pkg := range manifest.Require {
+		pkg = strings.ToLower(pkg)
+		switch {
+		case strings.HasPrefix(pkg, "ext-pdo_"):
+			declared["pdo"] = true // A driver requires PDO itself
+			declared[strings.TrimPrefix(pkg, "ext-")] = true
+		case strings.HasPrefix(pkg, "ext-"):
+			declared[strings.TrimPrefix(pkg, "ext-")] = true
+		case strings.HasPrefix(pkg, "symfony/polyfill-intl-"):
+			declared["intl"] = true
+		case strings.HasPrefix(pkg, "symfony/polyfill-") && !strings.HasPrefix(pkg, "symfony/polyfill-php"):
+			declared[strings.TrimPrefix(pkg, "symfony/polyfill-")] = true
+		case pkg == "paragonie/sodium_compat":
+			declared["sodium"] = true
+		case pkg == "phpseclib/bcmath_compat":
+			declared["bcmath"] = true
+		}
+	}
+
+	defined := make(map[string]bool) // Functions the code declares
+	for _, file := range files {
+		for _, element := range file.Elements {
+			if element.Type == "function" {
+				defined[strings.ToLower(element.Name)] = true
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	extensions := make(map[string]*models.PlatformExtension)
+	report := &models.PlatformReport{Declared: sortedKeys(declared), Undeclared: []*models.PlatformExtension{}}
+	for _, file := range files {
+		if file.Language != "php" {
+			continue
+		}
+		rel := relativeTo(root, file.Path)
+		for _, usage := range file.Usage {
+			symbol, extension := extensionOf(usage, file, defined)
+			if extension == "" {
+				continue
+			}
+			used[extension] = true
+			if declared[extension] {
+				continue
+			}
+			ext := extensions[extension]
+			if ext == nil {
+				ext = &models.PlatformExtension{Name: extension}
+				extensions[extension] = ext
+				report.Undeclared = append(report.Undeclared, ext)
+			}
+			ext.Symbols = appendUnique(ext.Symbols, symbol)
+			ext.Uses = append(ext.Uses, &models.PlatformUse{Symbol: symbol, File: rel, Line: usage.Line})
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	report.Used = sortedKeys(used)
+	for _, ext := range report.Undeclared {
+		sort.Strings(ext.Symbols)
+		sort.SliceStable(ext.Uses, func(i, j int) bool {
+			a, b := ext.Uses[i], ext.Uses[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	sort.Slice(report.Undeclared, func(i, j int) bool { return report.Undeclared[i].Name < report.Undeclared[j].Name })
+	return report
+}
+
+// extensionOf returns the extension function or class a usage names, and the extension
+// defining it, or "" when it names neither. Classes count when fully qualified, imported,
+// or used outside a namespace, where a bare name can't be the code's own class.
+func extensionOf(usage models.UsageElement, file *models.ParsedFile, defined map[string]bool) (string, string) {
+	switch usage.Type {
+	case "function_call":
+		name := strings.ToLower(strings.TrimPrefix(usage.Name, `\`))
+		if defined[name] {
+			return "", ""
+		}
+		if extension := extensionFunctions[name]; extension != "" {
+			return name, extension
+		}
+		for _, entry := range extensionPrefixes {
+			if strings.HasPrefix(name, entry.prefix) {
+				return name, entry.extension
+			}
+		}
+	case "instantiation", "static_call", "type_reference", "extends":
+		name := usage.Name
+		if usage.Type == "static_call" {
+			name = usage.Receiver
+		}
+		qualified := strings.HasPrefix(name, `\`)
+		name = strings.TrimPrefix(name, `\`)
+		extension := extensionClasses[name]
+		if extension == "" {
+			return "", ""
+		}
+		if qualified || file.Namespace == "" || strings.Contains(name, `\`) {
+			return name, extension
+		}
+		for _, use := range file.Uses {
+			if strings.TrimPrefix(use, `\`) == name {
+				return name, extension
+			}
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestPlatformExtensions(t *testing.T) {
+	root := t.TempDir()
+	files := []*models.ParsedFile{
+		{
+			Path:      filepath.Join(root, "src", "Client.php"),
+			Language:  "php",
+			Namespace: `App\Http`,
+			Uses:      []string{"ZipArchive"},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "curl_init", Line: 7},
+				{Type: "function_call", Name: "curl_exec", Line: 9},
+				{Type: "function_call", Name: "mb_strlen", Line: 12},
+				{Type: "instantiation", Name: "ZipArchive", Line: 15},
+				{Type: "instantiation", Name: `\PDO`, Line: 18},
+				{Type: "instantiation", Name: "Locale", Line: 20}, // App\Http\Locale
+				{Type: "function_call", Name: "mb_str_pad", Line: 22},
+			},
+		},
+		{
+			Path:     filepath.Join(root, "lib", "polyfill.php"),
+			Language: "php",
+			Elements: []models.CodeElement{{Type: "function", Name: "mb_str_pad"}},
+			Usage:    []models.UsageElement{{Type: "function_call", Name: "curl_close", Line: 3}},
+		},
+	}
+
+	if PlatformExtensions(root, files) != nil {
+		t.Error("expected no report without a composer.json")
+	}
+	composer := `{"require": {"php": "^8.1", "ext-pdo_mysql": "*", "symfony/polyfill-mbstring": "^1.0"}}`
+	if err := os.WriteFile(filepath.Join(root, "composer.json"), []byte(composer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := PlatformExtensions(root, files)
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+	if strings.Join(report.Declared, ",") != "mbstring,pdo,pdo_mysql" {
+		t.Errorf("expected the driver, PDO, and the polyfilled extension declared, got %v", report.Declared)
+	}
+	if strings.Join(report.Used, ",") != "curl,mbstring,pdo,zip" {
+		t.Errorf("expected the extensions called into, got %v", report.Used)
+	}
+	if len(report.Undeclared) != 2 || report.Undeclared[0].Name != "curl" || report.Undeclared[1].Name != "zip" {
+		t.Fatalf("expected curl and zip undeclared, got %+v", report.Undeclared)
+	}
+	curl := report.Undeclared[0]
+	if strings.Join(curl.Symbols, ",") != "curl_close,curl_exec,curl_init" {
+		t.Errorf("expected curl's functions, got %v", curl.Symbols)
+	}
+	if len(curl.Uses) != 3 || curl.Uses[0].File != "lib/polyfill.php" || curl.Uses[1].Line != 7 {
+		t.Errorf("expected uses by file and line, got %+v", curl.Uses)
+	}
+}
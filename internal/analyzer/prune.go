@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Pruning heuristics for --prune, in the order Prune applies them
+const (
+	PruneOverloads = "overloads" // Merge same-named functions or methods of one scope
+	PruneAccessors = "accessors" // Collapse getters and setters into their class
+	PruneLeaves    = "leaves"    // Drop nodes that depend on nothing and have one dependent
+)
+
+// PruneHeuristics lists every heuristic in application order
+var PruneHeuristics = []string{PruneOverloads, PruneAccessors, PruneLeaves}
+
+// accessorPattern matches getter and setter names such as getName, setName, isActive,
+// and has_items
+var accessorPattern = regexp.MustCompile(`^(get|set|is|has)([A-Z_]|$)`)
+
+// ParsePrune splits a comma-separated --prune value, accepting "all" for every heuristic
+func ParsePrune(spec string) ([]string, error) {
+	var heuristics []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "all":
+			heuristics = append(heuristics, PruneHeuristics...)
+		case name == PruneOverloads || name == PruneAccessors || name == PruneLeaves:
+			heuristics = append(heuristics, name)
+		default:
+			return nil, fmt.Errorf("unknown prune heuristic %q (use %s, or all)", name, strings.Join(PruneHeuristics, ", "))
+		}
+	}
+	return heuristics, nil
+}
+
+// Prune shrinks graph in place with the given heuristics and records what it removed in
+// graph.Pruned. Edges of merged nodes move to the node that absorbs them, so usage counts
+// are kept except for the edges into dropped leaves. The caller must hold the graph's
+// write lock if it may be shared.
+func Prune(graph *models.DependencyGraph, heuristics []string) *models.PruneReport {
+	enabled := make(map[string]bool)
+	for _, name := range heuristics {
+		enabled[name] = true
+	}
+	report := &models.PruneReport{NodesBefore: len(graph.Nodes), Removed: []*models.PrunedNode{}}
+	for _, name := range PruneHeuristics {
+		if !enabled[name] {
+			continue
+		}
+		report.Heuristics = append(report.Heuristics, name)
+		switch name {
+		case PruneOverloads:
+			pruneOverloads(graph, report)
+		case PruneAccessors:
+			pruneAccessors(graph, report)
+		case PruneLeaves:
+			pruneLeaves(graph, report)
+		}
+	}
+
+	removed := make(map[string]bool, len(report.Removed))
+	for _, node := range report.Removed {
+		removed[node.ID] = true
+	}
+	keep := func(nodes []*models.DependencyNode) []*models.DependencyNode {
+		kept := nodes[:0]
+		for _, node := range nodes {
+			if !removed[node.ID] {
+				kept = append(kept, node)
+			}
+		}
+		return kept
+	}
+	graph.Orphans = keep(graph.Orphans)
+	graph.HighlyDepended = keep(graph.HighlyDepended)
+	graph.ComplexNodes = keep(graph.ComplexNodes)
+
+	graph.TotalNodes = len(graph.Nodes)
+	graph.TotalEdges = 0
+	for _, node := range graph.Nodes {
+		for _, ref := range node.Dependencies {
+			graph.TotalEdges += ref.Count
+		}
+	}
+	report.NodesAfter = len(graph.Nodes)
+	graph.Pruned = report
+	return report
+}
+
+// pruneOverloads merges functions and methods that share a type, namespace, class, and
+// name into the first of them by file and line
+func pruneOverloads(graph *models.DependencyGraph, report *models.PruneReport) {
+	var keys []string
+	groups := make(map[string][]*models.DependencyNode)
+	for _, node := range sortedNodes(graph) {
+		if node.Type != "function" && node.Type != "method" {
+			continue
+		}
+		key := strings.Join([]string{node.Type, node.Namespace, node.ClassName, node.Name}, "\x00")
+		if groups[key] == nil {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], node)
+	}
+	for _, key := range keys {
+		group := groups[key]
+		for _, overload := range group[1:] {
+			mergeNode(graph, overload, group[0])
+			report.Removed = append(report.Removed, &models.PrunedNode{ID: overload.ID, Reason: "overload", Into: group[0].ID})
+		}
+	}
+}
+
+// pruneAccessors collapses getter and setter methods into their class, when the class
+// is in the graph
+func pruneAccessors(graph *models.DependencyGraph, report *models.PruneReport) {
+	classes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		if isClassLike(node.Type) {
+			classes[node.File+"\x00"+node.Namespace+"\x00"+node.Name] = node
+		}
+	}
+	for _, node := range sortedNodes(graph) {
+		if node.Type != "method" || !accessorPattern.MatchString(node.Name) {
+			continue
+		}
+		class := classes[node.File+"\x00"+node.Namespace+"\x00"+node.ClassName]
+		if class == nil {
+			continue
+		}
+		mergeNode(graph, node, class)
+		report.Removed = append(report.Removed, &models.PrunedNode{ID: node.ID, Reason: "accessor", Into: class.ID})
+	}
+}
+
+// pruneLeaves drops nodes that depend on nothing and are used by exactly one node.
+// Candidates are chosen before any is removed, so pruning doesn't cascade.
+func pruneLeaves(graph *models.DependencyGraph, report *models.PruneReport) {
+	var leaves []*models.DependencyNode
+	for _, node := range sortedNodes(graph) {
+		if len(node.Dependencies) == 0 && len(node.Dependents) == 1 && !node.IsEntrypoint {
+			leaves = append(leaves, node)
+		}
+	}
+	for _, leaf := range leaves {
+		var into string
+		for id := range leaf.Dependents {
+			into = id
+		}
+		removeNode(graph, leaf)
+		report.Removed = append(report.Removed, &models.PrunedNode{ID: leaf.ID, Reason: "leaf", Into: into})
+	}
+}
+
+// mergeNode moves every edge of from onto into and removes from. Edges between the two
+// disappear rather than becoming self-dependencies.
+func mergeNode(graph *models.DependencyGraph, from, into *models.DependencyNode) {
+	for id, ref := range from.Dependencies {
+		target := graph.Nodes[id]
+		if target == nil {
+			continue
+		}
+		back := target.Dependents[from.ID]
+		delete(target.Dependents, from.ID)
+		if target == into {
+			continue
+		}
+		mergeRef(into.Dependencies, ref, target)
+		if back != nil {
+			mergeRef(target.Dependents, back, into)
+		}
+	}
+	for id, ref := range from.Dependents {
+		source := graph.Nodes[id]
+		if source == nil {
+			continue
+		}
+		forward := source.Dependencies[from.ID]
+		delete(source.Dependencies, from.ID)
+		if source == into {
+			continue
+		}
+		mergeRef(into.Dependents, ref, source)
+		if forward != nil {
+			mergeRef(source.Dependencies, forward, into)
+		}
+	}
+	delete(graph.Nodes, from.ID)
+}
+
+// mergeRef adds ref to refs as an edge to node, combining it with an existing edge
+func mergeRef(refs map[string]*models.DependencyRef, ref *models.DependencyRef, node *models.DependencyNode) {
+	existing := refs[node.ID]
+	if existing == nil {
+		merged := *ref
+		merged.TargetID, merged.TargetName = node.ID, node.Name
+		merged.Lines = append([]int(nil), ref.Lines...)
+		refs[node.ID] = &merged
+		return
+	}
+	existing.Count += ref.Count
+	existing.Lines = append(existing.Lines, ref.Lines...)
+	sort.Ints(existing.Lines)
+	existing.Sampled = existing.Sampled || ref.Sampled
+}
+
+// removeNode deletes node and every edge to or from it
+func removeNode(graph *models.DependencyGraph, node *models.DependencyNode) {
+	for id := range node.Dependencies {
+		if target := graph.Nodes[id]; target != nil {
+			delete(target.Dependents, node.ID)
+		}
+	}
+	for id := range node.Dependents {
+		if source := graph.Nodes[id]; source != nil {
+			delete(source.Dependencies, node.ID)
+		}
+	}
+	delete(graph.Nodes, node.ID)
+}
+
+// sortedNodes returns the graph's nodes ordered by file, line, and ID
+func sortedNodes(graph *models.DependencyGraph) []*models.DependencyNode {
+	nodes := make([]*models.DependencyNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].File != nodes[j].File {
+			return nodes[i].File < nodes[j].File
+		}
+		if nodes[i].Line != nodes[j].Line {
+			return nodes[i].Line < nodes[j].Line
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// makePruneGraph builds a controller that uses a class, its two accessors, both
+// definitions of an overloaded function, and a logger only it calls
+func makePruneGraph() *models.DependencyGraph {
+	graph := &models.DependencyGraph{Nodes: map[string]*models.DependencyNode{}}
+	add := func(id, typ, name, class, file string, line int) *models.DependencyNode {
+		node := &models.DependencyNode{
+			ID: id, Type: typ, Name: name, ClassName: class, Namespace: "App", File: file, Line: line,
+			Dependencies: map[string]*models.DependencyRef{}, Dependents: map[string]*models.DependencyRef{},
+		}
+		graph.Nodes[id] = node
+		return node
+	}
+	link := func(from, to *models.DependencyNode, lines ...int) {
+		from.Dependencies[to.ID] = &models.DependencyRef{TargetID: to.ID, TargetName: to.Name, Type: "calls", Count: len(lines), Lines: lines}
+		to.Dependents[from.ID] = &models.DependencyRef{TargetID: from.ID, TargetName: from.Name, Type: "calls", Count: len(lines), Lines: lines}
+	}
+
+	index := add("method:App\\Controller::index:5", "method", "index", "Controller", "Controller.php", 5)
+	user := add("class:App\\User:3", "class", "User", "", "User.php", 3)
+	getName := add("method:App\\User::getName:6", "method", "getName", "User", "User.php", 6)
+	setName := add("method:App\\User::setName:9", "method", "setName", "User", "User.php", 9)
+	format := add("function:App\\format:1", "function", "format", "", "a.php", 1)
+	format2 := add("function:App\\format:1#b", "function", "format", "", "b.php", 1)
+	logger := add("function:App\\log:4", "function", "log", "", "a.php", 4)
+
+	link(index, user, 6)
+	link(index, getName, 7, 9)
+	link(index, setName, 8)
+	link(index, format, 10)
+	link(index, format2, 11)
+	link(index, logger, 12)
+	link(getName, format2, 7)
+	link(setName, user, 10) // Becomes a self-dependency once collapsed
+
+	graph.Orphans = []*models.DependencyNode{}
+	graph.ComplexNodes = []*models.DependencyNode{index, getName, logger}
+	return graph
+}
+
+func TestPrune_Overloads(t *testing.T) {
+	graph := makePruneGraph()
+	report := Prune(graph, []string{PruneOverloads})
+
+	if len(report.Removed) != 1 || report.Removed[0].ID != "function:App\\format:1#b" || report.Removed[0].Into != "function:App\\format:1" {
+		t.Fatalf("unexpected removals: %+v", report.Removed)
+	}
+	format := graph.Nodes["function:App\\format:1"]
+	fromIndex := format.Dependents["method:App\\Controller::index:5"]
+	if fromIndex == nil || fromIndex.Count != 2 || !reflect.DeepEqual(fromIndex.Lines, []int{10, 11}) {
+		t.Errorf("expected merged usages from index, got %+v", fromIndex)
+	}
+	if ref := graph.Nodes["method:App\\User::getName:6"].Dependencies["function:App\\format:1"]; ref == nil || ref.TargetName != "format" {
+		t.Errorf("expected getName to depend on the kept overload, got %+v", ref)
+	}
+}
+
+func TestPrune_Accessors(t *testing.T) {
+	graph := makePruneGraph()
+	report := Prune(graph, []string{PruneAccessors})
+
+	if len(report.Removed) != 2 || report.Removed[0].Reason != "accessor" || report.Removed[1].Into != "class:App\\User:3" {
+		t.Fatalf("unexpected removals: %+v", report.Removed)
+	}
+	user := graph.Nodes["class:App\\User:3"]
+	if ref := user.Dependents["method:App\\Controller::index:5"]; ref == nil || ref.Count != 4 || !reflect.DeepEqual(ref.Lines, []int{6, 7, 8, 9}) {
+		t.Errorf("expected index's accessor calls folded into User, got %+v", ref)
+	}
+	if _, ok := user.Dependencies["function:App\\format:1#b"]; !ok {
+		t.Errorf("expected User to inherit getName's dependencies")
+	}
+	if _, ok := user.Dependents[user.ID]; ok {
+		t.Errorf("expected no self-dependency")
+	}
+	if graph.TotalNodes != 5 || graph.TotalEdges != 8 {
+		t.Errorf("expected recomputed totals 5 nodes and 8 usages, got %d and %d", graph.TotalNodes, graph.TotalEdges)
+	}
+	if len(graph.ComplexNodes) != 2 {
+		t.Errorf("expected pruned nodes dropped from the complex list, got %d", len(graph.ComplexNodes))
+	}
+}
+
+func TestPrune_Leaves(t *testing.T) {
+	graph := makePruneGraph()
+	report := Prune(graph, []string{PruneLeaves})
+
+	var removed []string
+	for _, node := range report.Removed {
+		removed = append(removed, node.ID)
+	}
+	// format#b has two dependents, and User depends on nothing but has two users
+	want := []string{"function:App\\format:1", "function:App\\log:4"}
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("expected %v pruned, got %v", want, removed)
+	}
+	if _, ok := graph.Nodes["method:App\\Controller::index:5"].Dependencies["function:App\\log:4"]; ok {
+		t.Errorf("expected the edge to the dropped leaf removed")
+	}
+}
+
+func TestPrune_All(t *testing.T) {
+	graph := makePruneGraph()
+	heuristics, err := ParsePrune("all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := Prune(graph, heuristics)
+
+	if !reflect.DeepEqual(report.Heuristics, PruneHeuristics) || report.NodesBefore != 7 || report.NodesAfter != 3 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if graph.Pruned != report {
+		t.Errorf("expected the report recorded on the graph")
+	}
+}
+
+func TestParsePrune(t *testing.T) {
+	heuristics, err := ParsePrune("leaves, Accessors")
+	if err != nil || !reflect.DeepEqual(heuristics, []string{"leaves", "accessors"}) {
+		t.Errorf("unexpected heuristics %v (%v)", heuristics, err)
+	}
+	if _, err := ParsePrune("leaves,everything"); err == nil {
+		t.Error("expected an error for an unknown heuristic")
+	}
+}
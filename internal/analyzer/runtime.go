@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/trace"
+)
+
+// SetTrace reconciles runtime traces with the graph and enables the runtime report
+func (dt *DependencyTracker) SetTrace(t *trace.Trace) {
+	dt.trace = t
+}
+
+// analyzeRuntime matches the functions in the traces to nodes, then marks the edges the
+// traces confirm with their call counts and reports the calls the graph has no edge for:
+// dynamic calls such as callbacks, magic methods, and container lookups. It returns nil
+// without a trace.
+func (dt *DependencyTracker) analyzeRuntime() *models.RuntimeReport {
+	if dt.trace == nil {
+		return nil
+	}
+
+	dt.graph.Lock()
+	defer dt.graph.Unlock()
+
+	report := &models.RuntimeReport{
+		Sources: dt.trace.Sources,
+		Calls:   len(dt.trace.Calls),
+		Missed:  []*models.RuntimeEdge{},
+	}
+
+	// Traces name functions the way their runtime does (App\User->save, App\User::save,
+	// com.acme.User.save, pkg.(*User).Save), so both sides are compared in a canonical
+	// form. A name that matches no node exactly may still match the one node ending with
+	// its last two segments, for traces recorded with a different root.
+	exact := make(map[string]string)
+	suffix := make(map[string]string) // "" when more than one node ends that way
+	for id, node := range dt.graph.Nodes {
+		if node.Type != "function" && node.Type != "method" {
+			continue
+		}
+		name := node.Name
+		if node.ClassName != "" {
+			name = node.ClassName + `\` + name
+		}
+		key := canonicalFunction(dt.getFullName(node.Namespace, name))
+		exact[key] = id
+		if tail := lastSegments(key, 2); tail != key {
+			if _, taken := suffix[tail]; taken {
+				suffix[tail] = ""
+			} else {
+				suffix[tail] = id
+			}
+		}
+	}
+	resolve := func(name string) string {
+		key := canonicalFunction(name)
+		if id, ok := exact[key]; ok {
+			return id
+		}
+		return suffix[lastSegments(key, 2)]
+	}
+
+	for call, count := range dt.trace.Calls {
+		callerID, calleeID := resolve(call.Caller), resolve(call.Callee)
+		if callerID == "" || calleeID == "" || callerID == calleeID {
+			continue
+		}
+		report.Resolved++
+		caller, callee := dt.graph.Nodes[callerID], dt.graph.Nodes[calleeID]
+
+		// Static calls and instantiations link to the class rather than the method
+		targetID := calleeID
+		if caller.Dependencies[targetID] == nil && callee.ClassName != "" {
+			if classID, ok := dt.nodeIndex[dt.getFullName(callee.Namespace, callee.ClassName)]; ok && caller.Dependencies[classID] != nil {
+				targetID = classID
+			}
+		}
+		if ref := caller.Dependencies[targetID]; ref != nil {
+			ref.Runtime += count
+			if dependent := dt.graph.Nodes[targetID].Dependents[callerID]; dependent != nil {
+				dependent.Runtime += count
+			}
+			report.Confirmed++
+			continue
+		}
+		report.Missed = append(report.Missed, &models.RuntimeEdge{
+			From:     callerID,
+			FromName: caller.Name,
+			To:       calleeID,
+			ToName:   callee.Name,
+			File:     callee.File,
+			Line:     callee.Line,
+			Calls:    count,
+			Orphan:   len(callee.Dependents) == 0 && !callee.IsEntrypoint,
+		})
+	}
+	report.Unresolved = report.Calls - report.Resolved
+
+	sort.Slice(report.Missed, func(i, j int) bool {
+		a, b := report.Missed[i], report.Missed[j]
+		if a.Calls != b.Calls {
+			return a.Calls > b.Calls
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	return report
+}
+
+// canonicalFunction writes a function name with backslashes between its segments,
+// lowercased since PHP's names are case-insensitive, and without a Go receiver's
+// parentheses or a call's: `App\User->save()` and "app.User::save" are both `app\user\save`
+func canonicalFunction(name string) string {
+	if idx := strings.Index(name, "("); idx > 0 && strings.HasSuffix(name, ")") && !strings.Contains(name[idx:], ".") {
+		name = name[:idx]
+	}
+	name = strings.NewReplacer("->", `\`, "::", `\`, ".", `\`, "/", `\`, "#", `\`, "(", "", ")", "", "*", "").Replace(name)
+	return strings.ToLower(strings.Trim(name, `\`))
+}
+
+// lastSegments returns the last n backslash-separated segments of a name
+func lastSegments(name string, n int) string {
+	end := len(name)
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '\\' {
+			if n--; n == 0 {
+				return name[i+1 : end]
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/trace"
+)
+
+func TestRuntimeReport(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path:      "app/Kernel.php",
+			Namespace: "App",
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "Kernel", Namespace: "App", Line: 1},
+				{Type: "method", Name: "handle", ClassName: "Kernel", Namespace: "App", Line: 3},
+				{Type: "method", Name: "terminate", ClassName: "Kernel", Namespace: "App", Line: 9},
+			},
+			Usage: []models.UsageElement{
+				{Type: "method_call", Name: "terminate", Receiver: "$this", Context: "handle", Line: 4},
+				{Type: "static_call", Name: `App\Models\User::find`, Context: "handle", Line: 5},
+			},
+		},
+		{
+			Path:      "app/Models/User.php",
+			Namespace: `App\Models`,
+			Elements: []models.CodeElement{
+				{Type: "class", Name: "User", Namespace: `App\Models`, Line: 1},
+				{Type: "method", Name: "find", ClassName: "User", Namespace: `App\Models`, Line: 3},
+				{Type: "method", Name: "getNameAttribute", ClassName: "User", Namespace: `App\Models`, Line: 8},
+			},
+		},
+	}
+	runtimeTrace := trace.New()
+	if err := runtimeTrace.Parse([]byte(`{
+  "App\\Kernel::handle==>App\\Kernel::terminate": {"ct": 1},
+  "App\\Kernel::handle==>App\\Models\\User::find": {"ct": 4},
+  "App\\Kernel::terminate==>App\\Models\\User::getNameAttribute": {"ct": 2},
+  "App\\Kernel::handle==>PDO::prepare": {"ct": 4}
+}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewDependencyTracker()
+	tracker.SetTrace(runtimeTrace)
+	graph := tracker.BuildDependencyGraph(files)
+
+	report := graph.Runtime
+	if report == nil || report.Calls != 4 || report.Resolved != 3 || report.Unresolved != 1 || report.Confirmed != 2 {
+		t.Fatalf("unexpected runtime report: %+v", report)
+	}
+	if len(report.Missed) != 1 || report.Missed[0].ToName != "getNameAttribute" || report.Missed[0].Calls != 2 || !report.Missed[0].Orphan {
+		t.Errorf("expected the magic accessor to be the missed, orphaned call, got %+v", report.Missed)
+	}
+
+	var handle *models.DependencyNode
+	for _, node := range graph.Nodes {
+		if node.Name == "handle" {
+			handle = node
+		}
+	}
+	runtime := make(map[string]int)
+	for _, ref := range handle.Dependencies {
+		runtime[ref.TargetName] = ref.Runtime
+	}
+	if runtime["terminate"] != 1 || runtime["User"] != 4 {
+		t.Errorf("expected the method call and the static call's class edge to be confirmed, got %v", runtime)
+	}
+}
+
+func TestCanonicalFunction(t *testing.T) {
+	for name, want := range map[string]string{
+		`App\Models\User->save`:               `app\models\user\save`,
+		`\App\Models\User::save()`:            `app\models\user\save`,
+		"com.acme.User.save":                  `com\acme\user\save`,
+		"github.com/acme/app/store.(*DB).Get": `github\com\acme\app\store\db\get`,
+	} {
+		if got := canonicalFunction(name); got != want {
+			t.Errorf("canonicalFunction(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
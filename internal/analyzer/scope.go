@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// ScopeToChanges limits graph's findings to the nodes defined in files, the files changed
+// since ref: the orphans, complex nodes, and long parameter lists elsewhere are dropped,
+// and FindCycles only reports cycles through a changed node. The nodes and edges stay,
+// so references from the changed files still resolve against the whole tree.
+func ScopeToChanges(graph *models.DependencyGraph, ref string, files []string) {
+	scope := &models.ChangeScope{Ref: ref, Files: append([]string{}, files...)}
+	sort.Strings(scope.Files)
+	graph.Scope = scope
+	for _, node := range graph.Nodes {
+		if InScope(graph, node) {
+			scope.Nodes++
+		}
+	}
+
+	keep := func(nodes []*models.DependencyNode) []*models.DependencyNode {
+		kept := make([]*models.DependencyNode, 0, len(nodes))
+		for _, node := range nodes {
+			if InScope(graph, node) {
+				kept = append(kept, node)
+			}
+		}
+		return kept
+	}
+	graph.Orphans = keep(graph.Orphans)
+	graph.ComplexNodes = keep(graph.ComplexNodes)
+	if graph.LongParameters != nil {
+		kept := graph.LongParameters.Functions[:0]
+		for _, function := range graph.LongParameters.Functions {
+			if node := graph.Nodes[function.ID]; node != nil && InScope(graph, node) {
+				kept = append(kept, function)
+			}
+		}
+		graph.LongParameters.Functions = kept
+	}
+}
+
+// InScope reports whether node's findings count: always, unless the graph is scoped to
+// changed files and node is defined in none of them
+func InScope(graph *models.DependencyGraph, node *models.DependencyNode) bool {
+	if graph.Scope == nil {
+		return true
+	}
+	files := graph.Scope.Files
+	i := sort.SearchStrings(files, node.File)
+	return i < len(files) && files[i] == node.File
+}
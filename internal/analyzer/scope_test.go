@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestScopeToChanges(t *testing.T) {
+	files := []*models.ParsedFile{
+		{
+			Path: "app/old.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "a", Line: 1},
+				{Type: "function", Name: "b", Line: 5},
+				{Type: "function", Name: "unused", Line: 9},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "b", Context: "a", Line: 2},
+				{Type: "function_call", Name: "a", Context: "b", Line: 6},
+			},
+		},
+		{
+			Path: "app/new.php",
+			Elements: []models.CodeElement{
+				{Type: "function", Name: "caller", Line: 1},
+				{Type: "function", Name: "added", Line: 5},
+			},
+			Usage: []models.UsageElement{
+				{Type: "function_call", Name: "a", Context: "caller", Line: 2},
+			},
+		},
+	}
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+	ScopeToChanges(graph, "origin/main", []string{"app/new.php"})
+
+	if cycles := FindCycles(graph); len(cycles) != 0 {
+		t.Errorf("expected the cycle in an unchanged file to be out of scope, got %v", cycles)
+	}
+	for _, orphan := range graph.Orphans {
+		if orphan.File != "app/new.php" {
+			t.Errorf("expected only orphans in the changed file, got %s in %s", orphan.Name, orphan.File)
+		}
+	}
+	if graph.Scope.Nodes != 2 || graph.Nodes["function:a:1"].Dependents["function:caller:1"] == nil {
+		t.Errorf("expected 2 scoped nodes with references into the rest of the graph, got %+v", graph.Scope)
+	}
+
+	ScopeToChanges(graph, "origin/main", []string{"app/old.php"})
+	if cycles := FindCycles(graph); len(cycles) != 1 {
+		t.Errorf("expected the cycle in a changed file, got %v", cycles)
+	}
+}
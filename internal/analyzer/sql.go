@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// indexSQLObject records a table, view, procedure, function, or trigger by its
+// lowercased name, with and without its schema; of two with the same name, the later
+// wins. Triggers are entrypoints, since the database fires them.
+func (dt *DependencyTracker) indexSQLObject(element models.CodeElement, node *models.DependencyNode) {
+	name := strings.ToLower(element.Name)
+	if element.Namespace != "" {
+		dt.sqlObjects[strings.ToLower(element.Namespace)+"."+name] = node.ID
+	}
+	dt.sqlObjects[name] = node.ID
+	if element.Type == "trigger" {
+		node.IsEntrypoint = true
+	}
+}
+
+// findSQLObject returns the SQL object a name refers to, schema-qualified or not
+func (dt *DependencyTracker) findSQLObject(name string) *models.DependencyNode {
+	name = strings.ToLower(name)
+	if nodeID, exists := dt.sqlObjects[name]; exists {
+		return dt.graph.Nodes[nodeID]
+	}
+	if i := strings.LastIndex(name, "."); i != -1 {
+		return dt.graph.Nodes[dt.sqlObjects[name[i+1:]]] // Declared without its schema
+	}
+	return nil
+}
+
+// createSQLDependency links a SQL object to the tables its foreign keys reference and the
+// tables and routines its body uses. A foreign key an ALTER TABLE adds belongs to the
+// table, even one another migration created.
+func (dt *DependencyTracker) createSQLDependency(usage models.UsageElement, file *models.ParsedFile) {
+	source := dt.findSourceNode(usage, file)
+	if source == nil && usage.Type == "foreign_key" {
+		source = dt.findSQLObject(usage.Context)
+	}
+	target := dt.findSQLObject(usage.Name)
+	if source != nil && target != nil {
+		dt.addDependencyRef(source, target, usage.Type, usage.Line)
+	}
+}
+
+// processTables links application code to the SQL tables it names in queries, models,
+// and migrations, when the tables' definitions were parsed
+func (dt *DependencyTracker) processTables(file *models.ParsedFile) {
+	if len(dt.sqlObjects) == 0 || file.Language == "sql" {
+		return
+	}
+	for _, ref := range file.Tables {
+		context := ref.Function
+		if context == "" {
+			context = ref.ClassName
+		}
+		if context == "" {
+			continue // Outside any element
+		}
+		source := dt.findSourceNode(models.UsageElement{Context: context}, file)
+		target := dt.findSQLObject(ref.Table)
+		if source != nil && target != nil {
+			dt.addDependencyRef(source, target, "table_reference", ref.Line)
+		}
+	}
+}
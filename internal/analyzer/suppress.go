@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// SuppressibleFindings are the findings that can be suppressed on a node
+var SuppressibleFindings = []string{"cycles", "longParameterLists", "maxComplexity", "orphans"}
+
+// findingAliases maps the names annotations may use to the findings they suppress
+var findingAliases = map[string]string{
+	"cycle":              "cycles",
+	"cycles":             "cycles",
+	"orphan":             "orphans",
+	"orphans":            "orphans",
+	"dead-code":          "orphans",
+	"complexity":         "maxComplexity",
+	"maxcomplexity":      "maxComplexity",
+	"long-parameters":    "longParameterLists",
+	"parameters":         "longParameterLists",
+	"longparameterlists": "longParameterLists",
+}
+
+// annotation matches an inline suppression in a comment: "// tukey:ignore cycles",
+// "# tukey:ignore", or "@tukey-ignore dead-code -- kept for the v1 API" in a docblock
+var annotation = regexp.MustCompile(`(?://|#|/\*|^\s*\*).*?(?:tukey:ignore|@tukey-ignore)\b(.*)$`)
+
+// LoadSuppressions reads a suppression file. Each line names findings (comma-separated,
+// or "*" for all) and a regular expression matched against a node's qualified name or
+// its file path relative to the root, optionally followed by "-- reason":
+//
+//	orphans  App\\Legacy\\.*   -- kept for the v1 API
+//	*        src/generated/.*
+func LoadSuppressions(path string) ([]*models.Suppression, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var suppressions []*models.Suppression
+	lines := bufio.NewScanner(file)
+	for n := 1; lines.Scan(); n++ {
+		line, reason := cutReason(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected findings and a pattern, got %q", path, n, line)
+		}
+		if _, err := regexp.Compile(fields[1]); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern %q: %w", path, n, fields[1], err)
+		}
+		suppressions = append(suppressions, &models.Suppression{
+			Findings: parseFindings(strings.Split(fields[0], ",")),
+			File:     path,
+			Line:     n,
+			Pattern:  fields[1],
+			Reason:   reason,
+		})
+	}
+	return suppressions, lines.Err()
+}
+
+// Suppress applies the inline annotations in files and the suppression file entries to
+// graph: suppressed nodes are tagged, and left out of the orphans, complex nodes, long
+// parameter lists, and (through FindCycles) cycles. Every suppression is reported, so
+// ones that no longer match anything can be cleaned up. It returns nil when there are
+// no suppressions.
+func Suppress(root string, graph *models.DependencyGraph, files []*models.ParsedFile, fromFile []*models.Suppression) *models.SuppressionReport {
+	byFile := make(map[string][]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		byFile[node.File] = append(byFile[node.File], node)
+	}
+	for _, nodes := range byFile {
+		sort.Slice(nodes, func(i, j int) bool {
+			if nodes[i].Line != nodes[j].Line {
+				return nodes[i].Line < nodes[j].Line
+			}
+			return nodes[i].ID < nodes[j].ID
+		})
+	}
+
+	report := &models.SuppressionReport{Suppressions: []*models.Suppression{}}
+	for _, file := range files {
+		src, err := os.ReadFile(file.Path)
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(string(src), "\n")
+		for i, line := range lines {
+			match := annotation.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			rest, reason := cutReason(strings.TrimSuffix(strings.TrimSpace(match[1]), "*/"))
+			suppression := &models.Suppression{
+				Findings: parseFindings(strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })),
+				File:     relativeTo(root, file.Path),
+				Line:     i + 1,
+				Reason:   reason,
+				Nodes:    []string{},
+			}
+			for _, node := range annotatedNodes(byFile[file.Path], lines, i) {
+				suppress(node, suppression)
+			}
+			report.Suppressions = append(report.Suppressions, suppression)
+		}
+	}
+
+	ids := make([]string, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, suppression := range fromFile {
+		suppression.Nodes = []string{}
+		re := regexp.MustCompile("^(?:" + suppression.Pattern + ")$")
+		for _, id := range ids {
+			node := graph.Nodes[id]
+			if re.MatchString(QualifiedName(node)) || re.MatchString(relativeTo(root, node.File)) {
+				suppress(node, suppression)
+			}
+		}
+		report.Suppressions = append(report.Suppressions, suppression)
+	}
+	if len(report.Suppressions) == 0 {
+		return nil
+	}
+
+	graph.Orphans = unsuppressed(graph.Orphans, "orphans")
+	graph.ComplexNodes = unsuppressed(graph.ComplexNodes, "maxComplexity")
+	if graph.LongParameters != nil {
+		kept := graph.LongParameters.Functions[:0]
+		for _, function := range graph.LongParameters.Functions {
+			if node := graph.Nodes[function.ID]; node == nil || !IsSuppressed(node, "longParameterLists") {
+				kept = append(kept, function)
+			}
+		}
+		graph.LongParameters.Functions = kept
+	}
+
+	for _, suppression := range report.Suppressions {
+		report.Suppressed += len(suppression.Nodes)
+		if len(suppression.Nodes) == 0 {
+			report.Unused++
+		}
+	}
+	return report
+}
+
+// IsSuppressed reports whether finding is suppressed on node
+func IsSuppressed(node *models.DependencyNode, finding string) bool {
+	for _, suppressed := range node.Suppressed {
+		if suppressed == finding {
+			return true
+		}
+	}
+	return false
+}
+
+// suppress tags node with the findings suppression covers that apply to it
+func suppress(node *models.DependencyNode, suppression *models.Suppression) {
+	findings := suppression.Findings
+	if len(findings) == 0 {
+		findings = SuppressibleFindings
+	}
+	applied := false
+	for _, finding := range findings {
+		if !suppressible(finding) {
+			continue
+		}
+		applied = true
+		if !IsSuppressed(node, finding) {
+			node.Suppressed = append(node.Suppressed, finding)
+			sort.Strings(node.Suppressed)
+		}
+	}
+	if applied {
+		suppression.Nodes = append(suppression.Nodes, node.ID)
+	}
+}
+
+func suppressible(finding string) bool {
+	for _, name := range SuppressibleFindings {
+		if name == finding {
+			return true
+		}
+	}
+	return false
+}
+
+// annotatedNodes returns the nodes an annotation on lines[index] applies to: those
+// defined on its line, or else those on the first definition line after it, provided
+// only comments, attributes, and blank lines come between
+func annotatedNodes(nodes []*models.DependencyNode, lines []string, index int) []*models.DependencyNode {
+	line := index + 1
+	for i, node := range nodes {
+		if node.Line < line {
+			continue
+		}
+		for between := line; between < node.Line-1 && between < len(lines); between++ {
+			trimmed := strings.TrimSpace(lines[between])
+			if trimmed != "" && !strings.HasPrefix(trimmed, "*") && !strings.HasPrefix(trimmed, "/") &&
+				!strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "@") {
+				return nil // Code comes first; the annotation isn't on a definition
+			}
+		}
+		var annotated []*models.DependencyNode
+		for _, same := range nodes[i:] {
+			if same.Line != node.Line {
+				break
+			}
+			annotated = append(annotated, same)
+		}
+		return annotated
+	}
+	return nil
+}
+
+// unsuppressed returns the nodes that don't have finding suppressed
+func unsuppressed(nodes []*models.DependencyNode, finding string) []*models.DependencyNode {
+	kept := make([]*models.DependencyNode, 0, len(nodes))
+	for _, node := range nodes {
+		if !IsSuppressed(node, finding) {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
+
+// parseFindings canonicalizes finding names; "*" or none means every finding. Unknown
+// names are kept as written, and suppress nothing.
+func parseFindings(names []string) []string {
+	findings := []string{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "*" {
+			return []string{}
+		}
+		if canonical, ok := findingAliases[strings.ToLower(name)]; ok {
+			name = canonical
+		}
+		findings = append(findings, name)
+	}
+	return findings
+}
+
+// cutReason splits "text -- reason" into its trimmed parts
+func cutReason(line string) (string, string) {
+	text, reason, _ := strings.Cut(line, "--")
+	return strings.TrimSpace(text), strings.TrimSpace(reason)
+}
+
+// QualifiedName is a node's namespace- and class-qualified name, as virtual groups and
+// suppression patterns match it
+func QualifiedName(node *models.DependencyNode) string {
+	name := node.Name
+	if node.ClassName != "" {
+		name = node.ClassName + "::" + name
+	}
+	if node.Namespace != "" {
+		name = node.Namespace + `\` + name
+	}
+	return name
+}
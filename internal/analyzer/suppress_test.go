@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestSuppress(t *testing.T) {
+	root := t.TempDir()
+	src := `<?php
+namespace App;
+
+// tukey:ignore cycle -- ping and pong are a protocol
+function ping() { pong(); }
+
+function pong() { ping(); }
+
+/**
+ * Kept for plugins.
+ * @tukey-ignore dead-code
+ */
+#[Deprecated]
+function legacy() {}
+
+function unused() {}
+
+$x = 1; // tukey:ignore orphans
+`
+	path := filepath.Join(root, "app.php")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files := []*models.ParsedFile{{
+		Path:      path,
+		Namespace: "App",
+		Elements: []models.CodeElement{
+			{Type: "function", Name: "ping", Namespace: "App", Line: 5},
+			{Type: "function", Name: "pong", Namespace: "App", Line: 7},
+			{Type: "function", Name: "legacy", Namespace: "App", Line: 14},
+			{Type: "function", Name: "unused", Namespace: "App", Line: 16},
+		},
+		Usage: []models.UsageElement{
+			{Type: "function_call", Name: "pong", Context: "ping", Line: 5},
+			{Type: "function_call", Name: "ping", Context: "pong", Line: 7},
+		},
+	}}
+
+	entries := filepath.Join(root, ".tukey-suppressions")
+	lines := "# Reviewed suppressions\norphans App\\\\unused -- called from templates\ncycles Legacy\\\\.*\n"
+	if err := os.WriteFile(entries, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := LoadSuppressions(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fromFile) != 2 || fromFile[0].Line != 2 || fromFile[0].Reason != "called from templates" {
+		t.Fatalf("unexpected suppression file entries: %+v", fromFile)
+	}
+
+	graph := NewDependencyTracker().BuildDependencyGraph(files)
+	if len(FindCycles(graph)) != 1 {
+		t.Fatalf("expected the ping/pong cycle before suppressing, got %v", FindCycles(graph))
+	}
+	report := Suppress(root, graph, files, fromFile)
+
+	if cycles := FindCycles(graph); len(cycles) != 0 {
+		t.Errorf("expected the cycle to be suppressed, got %v", cycles)
+	}
+	for _, orphan := range graph.Orphans {
+		if orphan.Name == "legacy" || orphan.Name == "unused" {
+			t.Errorf("expected %s's orphan finding to be suppressed", orphan.Name)
+		}
+	}
+	if report == nil || len(report.Suppressions) != 5 {
+		t.Fatalf("expected 5 suppressions, got %+v", report)
+	}
+
+	byLine := make(map[string]*models.Suppression)
+	for _, s := range report.Suppressions {
+		byLine[fmt.Sprintf("%s:%d", filepath.Base(s.File), s.Line)] = s
+	}
+	if s := byLine["app.php:4"]; s == nil || strings.Join(s.Findings, ",") != "cycles" || len(s.Nodes) != 1 || s.Reason == "" {
+		t.Errorf("unexpected annotation above ping: %+v", s)
+	}
+	if s := byLine["app.php:11"]; s == nil || strings.Join(s.Findings, ",") != "orphans" || len(s.Nodes) != 1 {
+		t.Errorf("expected the docblock annotation to reach legacy past its attribute: %+v", s)
+	}
+	if s := byLine["app.php:18"]; s == nil || len(s.Nodes) != 0 {
+		t.Errorf("expected the annotation on a statement to match nothing: %+v", s)
+	}
+	if s := byLine[".tukey-suppressions:3"]; s == nil || len(s.Nodes) != 0 {
+		t.Errorf("expected the Legacy entry to be unused: %+v", s)
+	}
+	if report.Unused != 2 || report.Suppressed != 3 {
+		t.Errorf("expected 3 suppressed and 2 unused, got %d and %d", report.Suppressed, report.Unused)
+	}
+}
+
+func TestLoadSuppressions_InvalidPattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "suppressions")
+	if err := os.WriteFile(path, []byte("orphans App\\Billing(\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSuppressions(path); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Errorf("expected an error naming line 1, got %v", err)
+	}
+}
+
+func TestSuppress_None(t *testing.T) {
+	graph := NewDependencyTracker().BuildDependencyGraph(nil)
+	if report := Suppress(t.TempDir(), graph, nil, nil); report != nil {
+		t.Errorf("expected no report without suppressions, got %+v", report)
+	}
+}
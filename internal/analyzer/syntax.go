@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// NewerSyntax gathers the constructs the parsers found newer than the targeted version
+// of the language into a report by feature, with each use's file relative to root, and
+// the version the newest of them requires. It returns nil when no version is targeted.
+func NewerSyntax(root string, files []*models.ParsedFile, language, target string) *models.SyntaxReport {
+	if target == "" {
+		return nil
+	}
+	report := &models.SyntaxReport{Language: language, Target: target, Features: []*models.SyntaxFeature{}}
+	features := make(map[string]*models.SyntaxFeature)
+	for _, file := range files {
+		rel := relativeTo(root, file.Path)
+		for _, use := range file.Syntax {
+			feature := features[use.Feature]
+			if feature == nil {
+				feature = &models.SyntaxFeature{Name: use.Feature, Version: use.Version}
+				features[use.Feature] = feature
+				report.Features = append(report.Features, feature)
+			}
+			feature.Uses = append(feature.Uses, &models.SyntaxLocation{File: rel, Line: use.Line})
+		}
+	}
+
+	for _, feature := range report.Features {
+		sort.SliceStable(feature.Uses, func(i, j int) bool {
+			a, b := feature.Uses[i], feature.Uses[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	sort.Slice(report.Features, func(i, j int) bool {
+		a, b := report.Features[i], report.Features[j]
+		if a.Version != b.Version {
+			return olderVersion(a.Version, b.Version)
+		}
+		return a.Name < b.Name
+	})
+	if len(report.Features) > 0 {
+		report.Required = report.Features[len(report.Features)-1].Version
+	}
+	return report
+}
+
+// olderVersion reports whether dotted numeric version a comes before b
+func olderVersion(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x < y
+		}
+	}
+	return len(as) < len(bs)
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestNewerSyntax(t *testing.T) {
+	root := t.TempDir()
+	files := []*models.ParsedFile{
+		{
+			Path: filepath.Join(root, "src", "Order.php"),
+			Syntax: []models.SyntaxUse{
+				{Feature: "nullsafe operator", Version: "8.0", Line: 9},
+				{Feature: "enum", Version: "8.1", Line: 3},
+			},
+		},
+		{
+			Path: filepath.Join(root, "src", "Cart.php"),
+			Syntax: []models.SyntaxUse{
+				{Feature: "nullsafe operator", Version: "8.0", Line: 12},
+				{Feature: "typed property", Version: "7.4", Line: 5},
+			},
+		},
+	}
+
+	if NewerSyntax(root, files, "php", "") != nil {
+		t.Error("expected no report without a target")
+	}
+	report := NewerSyntax(root, files, "php", "7.3")
+	if report == nil || report.Language != "php" || report.Target != "7.3" || len(report.Features) != 3 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	var names []string
+	for _, feature := range report.Features {
+		names = append(names, feature.Name)
+	}
+	if names[0] != "typed property" || names[1] != "nullsafe operator" || names[2] != "enum" {
+		t.Errorf("expected features by version, got %v", names)
+	}
+	nullsafe := report.Features[1]
+	if report.Required != "8.1" {
+		t.Errorf("expected the enum to require 8.1, got %q", report.Required)
+	}
+	if len(nullsafe.Uses) != 2 || nullsafe.Uses[0].File != "src/Cart.php" || nullsafe.Uses[0].Line != 12 {
+		t.Errorf("expected uses by file and line, got %+v", nullsafe.Uses)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// DatabaseTables gathers the tables the parsers found named in SQL strings, models,
+// query builder calls, and migrations into a table-to-code map and its reverse. Tables
+// are matched by name, case-insensitively, and reported as first written. Files are
+// recorded relative to root. It returns nil when no table is named.
+func DatabaseTables(root string, files []*models.ParsedFile) *models.TableReport {
+	report := &models.TableReport{Tables: []*models.DatabaseTable{}, Classes: make(map[string][]string)}
+	tables := make(map[string]*models.DatabaseTable)
+	classes := make(map[string]map[string]bool) // Owner -> table names
+
+	for _, file := range files {
+		rel := relativeTo(root, file.Path)
+		for _, ref := range file.Tables {
+			key := strings.ToLower(ref.Table)
+			table := tables[key]
+			if table == nil {
+				table = &models.DatabaseTable{Name: ref.Table}
+				tables[key] = table
+				report.Tables = append(report.Tables, table)
+			}
+
+			owner := rel
+			if ref.ClassName != "" {
+				owner = ref.ClassName
+				// JS modules are namespaced by their path, which the file already records
+				if file.Namespace != "" && !strings.Contains(file.Namespace, "/") {
+					owner = file.Namespace + `\` + ref.ClassName
+				}
+			}
+			table.References = append(table.References, &models.TableUse{
+				Kind:     ref.Kind,
+				Owner:    owner,
+				Function: ref.Function,
+				File:     rel,
+				Line:     ref.Line,
+			})
+			switch ref.Kind {
+			case "model":
+				table.Models = appendUnique(table.Models, owner)
+			case "migration":
+				table.Migrations = appendUnique(table.Migrations, rel)
+			}
+			if classes[owner] == nil {
+				classes[owner] = make(map[string]bool)
+			}
+			classes[owner][table.Name] = true
+		}
+	}
+	if len(report.Tables) == 0 {
+		return nil
+	}
+
+	for _, table := range report.Tables {
+		owners := make(map[string]bool)
+		for _, use := range table.References {
+			owners[use.Owner] = true
+		}
+		table.Classes = sortedKeys(owners)
+		sort.Strings(table.Models)
+		sort.Strings(table.Migrations)
+		sort.SliceStable(table.References, func(i, j int) bool {
+			a, b := table.References[i], table.References[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+	}
+	sort.Slice(report.Tables, func(i, j int) bool { return report.Tables[i].Name < report.Tables[j].Name })
+	for owner, names := range classes {
+		report.Classes[owner] = sortedKeys(names)
+	}
+	return report
+}
+
+// appendUnique appends value unless the list already holds it
+func appendUnique(list []string, value string) []string {
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// sortedKeys returns a set's members in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestDatabaseTables(t *testing.T) {
+	root := t.TempDir()
+	files := []*models.ParsedFile{
+		{
+			Path:      filepath.Join(root, "app", "Models", "User.php"),
+			Namespace: `App\Models`,
+			Tables: []models.TableReference{
+				{Table: "members", Kind: "model", ClassName: "User", Line: 4},
+				{Table: "members", Kind: "query", ClassName: "User", Function: "recent", Line: 7},
+			},
+		},
+		{
+			Path: filepath.Join(root, "database", "migrations", "2024_01_01_rename.php"),
+			Tables: []models.TableReference{
+				{Table: "users", Kind: "migration", Function: "up", Line: 4},
+				{Table: "Members", Kind: "migration", Function: "up", Line: 4},
+			},
+		},
+		{
+			Path:      filepath.Join(root, "src", "repo.js"),
+			Namespace: filepath.ToSlash(filepath.Join(root, "src", "repo")),
+			Tables:    []models.TableReference{{Table: "members", Kind: "sql", ClassName: "Repo", Function: "all", Line: 3}},
+		},
+	}
+
+	report := DatabaseTables(root, files)
+	if report == nil || len(report.Tables) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	members := report.Tables[0]
+	if members.Name != "members" || len(members.References) != 4 {
+		t.Fatalf("expected names to match case-insensitively, got %+v", members)
+	}
+	if !reflect.DeepEqual(members.Models, []string{`App\Models\User`}) ||
+		!reflect.DeepEqual(members.Migrations, []string{"database/migrations/2024_01_01_rename.php"}) {
+		t.Errorf("unexpected models %v and migrations %v", members.Models, members.Migrations)
+	}
+	wantClasses := []string{`App\Models\User`, "Repo", "database/migrations/2024_01_01_rename.php"}
+	if !reflect.DeepEqual(members.Classes, wantClasses) {
+		t.Errorf("expected JS classes unqualified and files outside classes, got %v", members.Classes)
+	}
+	if first := members.References[0]; first.File != "app/Models/User.php" || first.Line != 4 || first.Kind != "model" {
+		t.Errorf("unexpected first reference %+v", first)
+	}
+	if got := report.Classes[`App\Models\User`]; !reflect.DeepEqual(got, []string{"members"}) {
+		t.Errorf("unexpected tables of User: %v", got)
+	}
+	if got := report.Classes["database/migrations/2024_01_01_rename.php"]; !reflect.DeepEqual(got, []string{"members", "users"}) {
+		t.Errorf("unexpected tables of the migration: %v", got)
+	}
+
+	if DatabaseTables(root, []*models.ParsedFile{{Path: "a.php"}}) != nil {
+		t.Error("expected no report without tables")
+	}
+}
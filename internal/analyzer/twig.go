@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package analyzer
+
+import "github.com/boone-studios/tukey/internal/models"
+
+// indexTwigExtensions records the callables a file's Twig extensions register as
+// filters, functions, and tests, so templates using them depend on the PHP behind them
+func (dt *DependencyTracker) indexTwigExtensions(file *models.ParsedFile) {
+	for _, usage := range file.Usage {
+		if usage.Type != "twig_register" {
+			continue
+		}
+		if callbackID := dt.findCallbackNode(usage, dt.findSourceNode(usage, file), file); callbackID != "" {
+			dt.twigCalls[usage.Name] = callbackID
+		}
+	}
+}
+
+// createTwigDependency links a template to the callable behind a filter or function
+// it uses; Twig's own, and those registered outside the analyzed code, don't resolve
+func (dt *DependencyTracker) createTwigDependency(usage models.UsageElement, file *models.ParsedFile) {
+	source := dt.findSourceNode(usage, file)
+	target := dt.graph.Nodes[dt.twigCalls[usage.Name]]
+	if source != nil && target != nil {
+		dt.addDependencyRef(source, target, usage.Type, usage.Line)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package checkpoint saves parsed files to disk as a run goes, so an interrupted
+// analysis can pick up where it stopped instead of parsing everything again
+package checkpoint
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// BatchSize is how many files are parsed between checkpoints
+const BatchSize = 500
+
+// Header identifies the run a checkpoint belongs to. A checkpoint written by another
+// version, for another root, with other parsers, or for another target language version
+// (which changes how files parse) is started over. Commit doesn't start it over: it's
+// the git commit a --changed-only cache was saved at, which Commit reports back so the
+// caller can tell which files changed since.
+type Header struct {
+	Version       string   `json:"version"`
+	Root          string   `json:"root"`
+	Languages     []string `json:"languages"`
+	TargetVersion string   `json:"targetVersion,omitempty"`
+	Commit        string   `json:"commit,omitempty"`
+}
+
+// entry is one parsed file, and the size and modification time it was parsed at
+type entry struct {
+	Path    string             `json:"path"`
+	Size    int64              `json:"size"`
+	ModTime time.Time          `json:"modTime"`
+	Parsed  *models.ParsedFile `json:"parsed"`
+}
+
+// Checkpoint is an append-only log of parsed files: a header line, then one JSON line
+// per file. Appending keeps each save proportional to the files just parsed, and a
+// line cut short by a crash is ignored when the log is read back.
+type Checkpoint struct {
+	path     string
+	file     *os.File
+	restored map[string]entry
+	commit   string
+}
+
+// Open reads the checkpoint at path, keeping its parsed files when it was written for
+// the same header, and readies it for appending. A missing, unreadable, or mismatched
+// checkpoint is started over.
+func Open(path string, header Header) (*Checkpoint, error) {
+	c := &Checkpoint{path: path, restored: make(map[string]entry), commit: header.Commit}
+	if end, matched := c.read(header); matched {
+		// Drop a line cut short by a crash, so appends start on a line of their own
+		if err := os.Truncate(path, end); err != nil {
+			return nil, err
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		c.file = file
+		return c, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	c.file = file
+	if err := c.writeLines(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// read loads the entries of the checkpoint at c.path, reporting whether its header
+// matches and where its last complete line ends
+func (c *Checkpoint) read(header Header) (int64, bool) {
+	file, err := os.Open(c.path)
+	if err != nil {
+		return 0, false
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	line, err := reader.ReadBytes('\n')
+	var saved Header
+	if err != nil || json.Unmarshal(line, &saved) != nil {
+		return 0, false
+	}
+	commit := saved.Commit
+	saved.Commit, header.Commit = "", ""
+	if !reflect.DeepEqual(saved, header) {
+		return 0, false
+	}
+	c.commit = commit
+	end := int64(len(line))
+	for {
+		line, err := reader.ReadBytes('\n')
+		var e entry
+		if err != nil || json.Unmarshal(line, &e) != nil || e.Parsed == nil {
+			return end, true // The end, or a line cut short by a crash
+		}
+		c.restored[e.Path] = e
+		end += int64(len(line))
+	}
+}
+
+// Restored returns how many parsed files the checkpoint held when opened
+func (c *Checkpoint) Restored() int {
+	return len(c.restored)
+}
+
+// Commit returns the git commit the checkpoint's files were saved at, or "" when it
+// wasn't saved at one
+func (c *Checkpoint) Commit() string {
+	return c.commit
+}
+
+// Lookup returns the saved result for file, if the file hasn't changed since
+func (c *Checkpoint) Lookup(file models.FileInfo) (*models.ParsedFile, bool) {
+	e, ok := c.restored[file.Path]
+	if !ok {
+		return nil, false
+	}
+	info, err := os.Stat(file.Path)
+	if err != nil || info.Size() != e.Size || !info.ModTime().Equal(e.ModTime) {
+		return nil, false
+	}
+	return e.Parsed, true
+}
+
+// Cached returns the saved result for the file at path whether or not its size and
+// modification time still match, for callers that know it's unchanged another way,
+// such as git after a fresh checkout
+func (c *Checkpoint) Cached(path string) (*models.ParsedFile, bool) {
+	e, ok := c.restored[path]
+	if !ok {
+		return nil, false
+	}
+	return e.Parsed, true
+}
+
+// Append saves parsed files to the checkpoint and syncs it to disk
+func (c *Checkpoint) Append(files []*models.ParsedFile) error {
+	lines := make([]interface{}, 0, len(files))
+	for _, parsed := range files {
+		info, err := os.Stat(parsed.Path)
+		if err != nil {
+			continue // Gone since it was parsed; nothing to resume
+		}
+		lines = append(lines, entry{Path: parsed.Path, Size: info.Size(), ModTime: info.ModTime(), Parsed: parsed})
+	}
+	if err := c.writeLines(lines...); err != nil {
+		return fmt.Errorf("writing checkpoint %s: %w", c.path, err)
+	}
+	return nil
+}
+
+func (c *Checkpoint) writeLines(values ...interface{}) error {
+	w := bufio.NewWriter(c.file)
+	encoder := json.NewEncoder(w)
+	for _, value := range values {
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return c.file.Sync()
+}
+
+// Restart empties the checkpoint under a new header, such as one for the commit a
+// --changed-only cache moved on to. The files it held stay available to Lookup and
+// Cached, for the caller to save again those that still hold.
+func (c *Checkpoint) Restart(header Header) error {
+	if err := c.file.Truncate(0); err != nil {
+		return fmt.Errorf("restarting checkpoint %s: %w", c.path, err)
+	}
+	c.commit = header.Commit
+	if err := c.writeLines(header); err != nil {
+		return fmt.Errorf("restarting checkpoint %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// Close closes the checkpoint, keeping it on disk to resume from
+func (c *Checkpoint) Close() error {
+	return c.file.Close()
+}
+
+// Remove closes and deletes the checkpoint, once the run it saved has finished
+func (c *Checkpoint) Remove() error {
+	c.file.Close()
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
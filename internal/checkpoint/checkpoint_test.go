@@ -0,0 +1,117 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.php"), filepath.Join(dir, "b.php")
+	os.WriteFile(a, []byte("<?php class A {}"), 0644)
+	os.WriteFile(b, []byte("<?php class B {}"), 0644)
+	path := filepath.Join(dir, "run.checkpoint")
+	header := Header{Version: "1.0.0", Root: dir, Languages: []string{"php"}}
+
+	c, err := Open(path, header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsedA := &models.ParsedFile{Path: a, Language: "php", Elements: []models.CodeElement{{Type: "class", Name: "A", Line: 1}}}
+	if err := c.Append([]*models.ParsedFile{parsedA, {Path: b}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Close()
+
+	// A crash part way through the next line
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	f.WriteString(`{"path":"c.php","size":`)
+	f.Close()
+	// b.php changes after it was saved
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(b, later, later)
+
+	c, err = Open(path, header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Restored() != 2 {
+		t.Fatalf("expected 2 restored files, got %d", c.Restored())
+	}
+	restored, ok := c.Lookup(models.FileInfo{Path: a})
+	if !ok || restored.Language != "php" || len(restored.Elements) != 1 || restored.Elements[0].Name != "A" {
+		t.Errorf("unexpected restored file %+v", restored)
+	}
+	if _, ok := c.Lookup(models.FileInfo{Path: b}); ok {
+		t.Error("expected a changed file to be parsed again")
+	}
+	if _, ok := c.Cached(b); !ok {
+		t.Error("expected Cached to return a file whatever its modification time")
+	}
+	if err := c.Append([]*models.ParsedFile{{Path: b}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Close()
+
+	c, _ = Open(path, header)
+	if _, ok := c.Lookup(models.FileInfo{Path: b}); !ok || c.Restored() != 2 {
+		t.Errorf("expected appends after the cut line to be readable, restored %d", c.Restored())
+	}
+	c.Close()
+
+	// Parsing depends on the target language version
+	header.TargetVersion = "7.4"
+	c, _ = Open(path, header)
+	if c.Restored() != 0 {
+		t.Errorf("expected a checkpoint for another target version to start over, restored %d", c.Restored())
+	}
+	c.Close()
+
+	header.Version = "1.1.0"
+	c, _ = Open(path, header)
+	if c.Restored() != 0 {
+		t.Errorf("expected a checkpoint from another version to start over, restored %d", c.Restored())
+	}
+	if err := c.Remove(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected the checkpoint to be removed")
+	}
+}
+
+func TestCheckpointCommit(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.php")
+	os.WriteFile(a, []byte("<?php class A {}"), 0644)
+	path := filepath.Join(dir, "run.checkpoint")
+	header := Header{Version: "1.0.0", Root: dir, Languages: []string{"php"}, Commit: "abc"}
+
+	c, _ := Open(path, header)
+	c.Append([]*models.ParsedFile{{Path: a}})
+	c.Close()
+
+	// Another commit keeps the files, reporting the commit they were saved at
+	header.Commit = "def"
+	c, _ = Open(path, header)
+	if c.Restored() != 1 || c.Commit() != "abc" {
+		t.Fatalf("expected 1 file saved at abc, got %d at %q", c.Restored(), c.Commit())
+	}
+	if err := c.Restart(header); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := c.Cached(a); !ok || c.Commit() != "def" {
+		t.Errorf("expected a restart to keep the restored files in memory at def, got %q", c.Commit())
+	}
+	c.Close()
+
+	c, _ = Open(path, header)
+	if c.Restored() != 0 || c.Commit() != "def" {
+		t.Errorf("expected a restarted checkpoint to hold only its header, got %d at %q", c.Restored(), c.Commit())
+	}
+	c.Remove()
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package churn
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Blame returns when each of the given lines of file was last changed, by its commit's
+// author date. Lines that aren't committed yet are dated now. It fails when the file
+// isn't tracked in a git repository.
+func Blame(file string, lines []int) (map[int]time.Time, error) {
+	if len(lines) == 0 {
+		return map[int]time.Time{}, nil
+	}
+	args := []string{"-C", filepath.Dir(file), "blame", "--line-porcelain"}
+	for _, line := range lines {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", line, line))
+	}
+	cmd := exec.Command("git", append(args, "--", filepath.Base(file))...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git blame: %s", msg)
+		}
+		return nil, fmt.Errorf("git blame: %w", err)
+	}
+	return parseBlame(out), nil
+}
+
+// parseBlame reads the author time of each line from "git blame --line-porcelain",
+// where every line starts with "<sha> <original line> <final line>" and repeats its
+// commit's headers
+func parseBlame(out []byte) map[int]time.Time {
+	dates := make(map[int]time.Time)
+	line := 0
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		text := scanner.Text()
+		fields := strings.Fields(text)
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			// The line's content
+		case len(fields) >= 3 && len(fields[0]) == 40:
+			line, _ = strconv.Atoi(fields[2])
+		case len(fields) == 2 && fields[0] == "author-time":
+			if unix, err := strconv.ParseInt(fields[1], 10, 64); err == nil && line > 0 {
+				dates[line] = time.Unix(unix, 0).UTC()
+			}
+		}
+	}
+	return dates
+}
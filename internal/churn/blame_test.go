@@ -0,0 +1,59 @@
+package churn
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseBlame(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	out := []byte(sha + " 1 3 1\nauthor Ana\nauthor-time 1700000000\nsummary x\n\t// TODO one\n" +
+		sha + " 5 9 1\nauthor Ana\nauthor-time 1600000000\nsummary x\n\t// author-time 1\n")
+	dates := parseBlame(out)
+	if len(dates) != 2 || dates[3].Unix() != 1700000000 || dates[9].Unix() != 1600000000 {
+		t.Errorf("unexpected dates %v", dates)
+	}
+}
+
+func TestBlame(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(env string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+env, "GIT_COMMITTER_DATE="+env)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	file := filepath.Join(repo, "a.php")
+	git("2024-01-02T00:00:00Z", "init", "-q")
+	os.WriteFile(file, []byte("1\n2\n"), 0644)
+	git("2024-01-02T00:00:00Z", "add", "-A")
+	git("2024-01-02T00:00:00Z", "commit", "-qm", "first")
+	os.WriteFile(file, []byte("1\n3\n4\n"), 0644)
+	git("2025-03-04T00:00:00Z", "commit", "-qam", "second")
+	os.WriteFile(file, []byte("1\n3\n4\n5\n"), 0644)
+
+	dates, err := Blame(file, []int{1, 2, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dates[1].Format("2006-01-02"); got != "2024-01-02" {
+		t.Errorf("expected line 1 from the first commit, got %s", got)
+	}
+	if got := dates[2].Format("2006-01-02"); got != "2025-03-04" {
+		t.Errorf("expected line 2 from the second commit, got %s", got)
+	}
+	if time.Since(dates[4]) > time.Hour {
+		t.Errorf("expected an uncommitted line to be dated now, got %s", dates[4])
+	}
+
+	if _, err := Blame(filepath.Join(t.TempDir(), "a.php"), []int{1}); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
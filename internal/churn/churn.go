@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package churn measures how much each file has changed in git history, when lines last
+// changed, and which files changed since a ref
+package churn
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DefaultSince is the history window used when none is given
+const DefaultSince = "90 days ago"
+
+// Collect returns the lines added plus deleted per file under root in the commits since
+// the given git date (e.g. "90 days ago" or "2025-01-01"). Paths are relative to root
+// with forward slashes. It fails when root isn't in a git repository.
+func Collect(root, since string) (map[string]int, error) {
+	if since == "" {
+		since = DefaultSince
+	}
+	out, err := git(root, "log", "--since="+since, "--numstat", "--format=", "--no-renames", "--relative", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	return parseNumstat(out), nil
+}
+
+// parseNumstat sums "added<TAB>deleted<TAB>path" lines per path. Binary files, which
+// git reports as "-", are skipped.
+func parseNumstat(out []byte) map[string]int {
+	churn := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		added, err1 := strconv.Atoi(fields[0])
+		deleted, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		churn[fields[2]] += added + deleted
+	}
+	return churn
+}
+
+// Changed returns the files under root that differ from the given git ref (a branch,
+// tag, or commit) in the working tree, staged or not, plus untracked files that aren't
+// ignored. Paths are relative to root with forward slashes. Deleted files are included.
+func Changed(root, ref string) ([]string, error) {
+	diff, err := git(root, "diff", "--name-only", "--no-renames", "--relative", ref, "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(root, "ls-files", "--others", "--exclude-standard", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(diff)+string(untracked), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// Head returns the commit checked out in root
+func Head(root string) (string, error) {
+	out, err := git(root, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// git runs a git command in root, turning its stderr into the error
+func git(root string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package churn
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseNumstat(t *testing.T) {
+	out := []byte("3\t1\tsrc/User.php\n-\t-\tlogo.png\n\n10\t0\tsrc/User.php\n2\t2\tREADME.md\n")
+	want := map[string]int{"src/User.php": 14, "README.md": 4}
+	if got := parseNumstat(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(rel, content string) {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("app/a.php", "1\n2\n")
+	write("other.php", "1\n")
+	git("add", "-A")
+	git("commit", "-qm", "first")
+	write("app/a.php", "1\n3\n4\n")
+	git("commit", "-qam", "second")
+
+	churn, err := Collect(filepath.Join(repo, "app"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"a.php": 5}; !reflect.DeepEqual(churn, want) {
+		t.Errorf("expected churn relative to the root, got %v", churn)
+	}
+
+	if _, err := Collect(t.TempDir(), ""); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
+
+func TestChanged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(rel, content string) {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("app/a.php", "1\n")
+	write("app/b.php", "1\n")
+	write("other.php", "1\n")
+	write(".gitignore", "*.log\n")
+	git("add", "-A")
+	git("commit", "-qm", "first")
+	git("tag", "base")
+	write("app/a.php", "2\n")
+	git("commit", "-qam", "second")
+	write("app/b.php", "2\n") // Uncommitted
+	write("app/new.php", "1\n")
+	write("app/debug.log", "ignored\n")
+	write("other.php", "2\n") // Outside the root
+
+	changed, err := Changed(filepath.Join(repo, "app"), "base")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(changed)
+	if want := []string{"a.php", "b.php", "new.php"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected %v, got %v", want, changed)
+	}
+
+	if _, err := Changed(repo, "no-such-ref"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+
+	head, err := Head(repo)
+	if err != nil || len(head) != 40 {
+		t.Fatalf("expected the HEAD commit, got %q (%v)", head, err)
+	}
+	if changed, _ := Changed(filepath.Join(repo, "app"), head); len(changed) != 2 {
+		t.Errorf("expected the uncommitted files to differ from HEAD, got %v", changed)
+	}
+}
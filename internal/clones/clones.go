@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package clones finds duplicated blocks of code by shingling each file's token stream:
+// every window of MinTokens normalized tokens is hashed, and windows that collide across
+// (or within) files are extended into the longest matching blocks
+package clones
+
+import (
+	"hash/fnv"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// DefaultMinTokens is the smallest block, in tokens, reported as a clone
+const DefaultMinTokens = 50
+
+// maxBucket caps how many places a single window may appear before it's treated as
+// boilerplate and skipped, which keeps the pairwise comparison from going quadratic
+const maxBucket = 32
+
+// position is the start of a window: a file and a token index
+type position struct {
+	file, index int
+}
+
+// Detect reads files, lexes them as language, and reports the duplicated blocks of at
+// least minTokens tokens. Files that can't be read are skipped.
+func Detect(language string, files []string, minTokens int) *models.CloneReport {
+	if minTokens <= 0 {
+		minTokens = DefaultMinTokens
+	}
+
+	streams := make([][]Token, len(files))
+	for i, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		streams[i] = Tokenize(language, string(src))
+	}
+	return detect(files, streams, minTokens)
+}
+
+// detect finds the clones in already tokenized files
+func detect(files []string, streams [][]Token, minTokens int) *models.CloneReport {
+	report := &models.CloneReport{MinTokens: minTokens, Clones: []*models.Clone{}}
+
+	buckets := make(map[uint64][]position)
+	var order []uint64
+	for f, tokens := range streams {
+		for i, hash := range windowHashes(tokens, minTokens) {
+			if buckets[hash] == nil {
+				order = append(order, hash)
+			}
+			buckets[hash] = append(buckets[hash], position{f, i})
+		}
+	}
+
+	for _, hash := range order {
+		bucket := buckets[hash]
+		if len(bucket) < 2 || len(bucket) > maxBucket {
+			continue
+		}
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				if clone := extend(files, streams, bucket[x], bucket[y], minTokens); clone != nil {
+					report.Clones = append(report.Clones, clone)
+				}
+			}
+		}
+	}
+
+	sort.Slice(report.Clones, func(i, j int) bool {
+		a, b := report.Clones[i], report.Clones[j]
+		if a.Tokens != b.Tokens {
+			return a.Tokens > b.Tokens
+		}
+		if a.Blocks[0].File != b.Blocks[0].File {
+			return a.Blocks[0].File < b.Blocks[0].File
+		}
+		return a.Blocks[0].StartLine < b.Blocks[0].StartLine
+	})
+	report.DuplicatedLines = duplicatedLines(report.Clones)
+	return report
+}
+
+// windowHashes hashes every window of size tokens, by normalized text
+func windowHashes(tokens []Token, size int) []uint64 {
+	if len(tokens) < size {
+		return nil
+	}
+
+	// Polynomial rolling hash over per-token hashes
+	const base = 1099511628211
+	tokenHash := make([]uint64, len(tokens))
+	for i, token := range tokens {
+		h := fnv.New64a()
+		h.Write([]byte(token.Norm))
+		tokenHash[i] = h.Sum64()
+	}
+	power := uint64(1)
+	for i := 1; i < size; i++ {
+		power *= base
+	}
+
+	hashes := make([]uint64, 0, len(tokens)-size+1)
+	var hash uint64
+	for i, th := range tokenHash {
+		if i >= size {
+			hash -= tokenHash[i-size] * power
+		}
+		hash = hash*base + th
+		if i >= size-1 {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// extend turns two colliding windows into a clone: the longest run of equal normalized
+// tokens starting at both. It returns nil when the pair isn't the start of that run (the
+// tokens before match too), when the hashes collided by chance, or when the run is too
+// short once blocks within one file are kept from overlapping.
+func extend(files []string, streams [][]Token, a, b position, minTokens int) *models.Clone {
+	ta, tb := streams[a.file], streams[b.file]
+	if a.index > 0 && b.index > 0 && ta[a.index-1].Norm == tb[b.index-1].Norm {
+		return nil
+	}
+
+	length := 0
+	for a.index+length < len(ta) && b.index+length < len(tb) && ta[a.index+length].Norm == tb[b.index+length].Norm {
+		length++
+	}
+	if a.file == b.file {
+		if a.index > b.index {
+			a, b = b, a
+		}
+		length = min(length, b.index-a.index)
+	}
+	if length < minTokens {
+		return nil
+	}
+
+	same := 0
+	for i := 0; i < length; i++ {
+		if ta[a.index+i].Text == tb[b.index+i].Text {
+			same++
+		}
+	}
+	block := func(p position) *models.CloneBlock {
+		tokens := streams[p.file]
+		return &models.CloneBlock{File: files[p.file], StartLine: tokens[p.index].Line, EndLine: tokens[p.index+length-1].Line}
+	}
+	return &models.Clone{
+		Tokens:     length,
+		Similarity: math.Round(float64(same)/float64(length)*100) / 100,
+		Blocks:     []*models.CloneBlock{block(a), block(b)},
+	}
+}
+
+// duplicatedLines counts the distinct lines covered by some clone block
+func duplicatedLines(clones []*models.Clone) int {
+	lines := make(map[string]map[int]bool)
+	for _, clone := range clones {
+		for _, block := range clone.Blocks {
+			if lines[block.File] == nil {
+				lines[block.File] = make(map[int]bool)
+			}
+			for line := block.StartLine; line <= block.EndLine; line++ {
+				lines[block.File][line] = true
+			}
+		}
+	}
+	total := 0
+	for _, set := range lines {
+		total += len(set)
+	}
+	return total
+}
@@ -0,0 +1,106 @@
+package clones
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const invoiceSrc = `<?php
+// Totals an invoice
+function invoice_total($items, $tax) {
+    $total = 0;
+    foreach ($items as $item) {
+        if ($item->quantity > 0) {
+            $total += $item->price * $item->quantity;
+        }
+    }
+    return round($total * (1 + $tax), 2);
+}
+`
+
+// quoteSrc is invoiceSrc with renamed variables and a different literal, after an
+// unrelated function
+const quoteSrc = `<?php
+function greet($name) {
+    echo "Hello " . $name;
+}
+
+# Totals a quote
+function quote_total($lines, $rate) {
+    $sum = 0;
+    foreach ($lines as $line) {
+        if ($line->quantity > 0) {
+            $sum += $line->price * $line->quantity;
+        }
+    }
+    return round($sum * (1 + $rate), 4);
+}
+`
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("php", "<?php\n#[Route('/')]\n$a = \"x\\\"y\"; // note\n/* multi\nline */ return 42;")
+	var norms []string
+	for _, token := range tokens {
+		norms = append(norms, token.Norm)
+	}
+	want := "# [ $id ( $str ) ] $id = $str ; return $num ;"
+	if got := strings.Join(norms, " "); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if last := tokens[len(tokens)-1]; last.Line != 5 {
+		t.Errorf("expected line numbers to count comment lines, got %d", last.Line)
+	}
+
+	js := Tokenize("javascript", "const s = `a\nb`; # x")
+	if js[3].Norm != StringToken || js[4].Line != 2 || js[5].Norm != "#" {
+		t.Errorf("unexpected JS tokens: %+v", js)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	invoice := filepath.Join(dir, "invoice.php")
+	quote := filepath.Join(dir, "quote.php")
+	os.WriteFile(invoice, []byte(invoiceSrc), 0644)
+	os.WriteFile(quote, []byte(quoteSrc), 0644)
+
+	report := Detect("php", []string{invoice, quote, filepath.Join(dir, "missing.php")}, 30)
+	if report.MinTokens != 30 || len(report.Clones) != 1 {
+		t.Fatalf("expected one clone, got %+v", report)
+	}
+	clone := report.Clones[0]
+	a, b := clone.Blocks[0], clone.Blocks[1]
+	if a.File != invoice || a.StartLine != 3 || a.EndLine != 11 {
+		t.Errorf("unexpected first block %+v", a)
+	}
+	if b.File != quote || b.StartLine != 7 || b.EndLine != 15 {
+		t.Errorf("unexpected second block %+v", b)
+	}
+	if clone.Similarity <= 0.5 || clone.Similarity >= 1 {
+		t.Errorf("expected a renamed copy to be similar but not identical, got %.2f", clone.Similarity)
+	}
+	if report.DuplicatedLines != 18 {
+		t.Errorf("expected 18 duplicated lines, got %d", report.DuplicatedLines)
+	}
+
+	if report := Detect("php", []string{invoice, quote}, 500); len(report.Clones) != 0 {
+		t.Errorf("expected no clones above the block size, got %+v", report.Clones)
+	}
+}
+
+func TestDetect_WithinFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "both.php")
+	os.WriteFile(file, []byte(invoiceSrc+strings.TrimPrefix(invoiceSrc, "<?php")), 0644)
+
+	report := Detect("php", []string{file}, 30)
+	if len(report.Clones) != 1 {
+		t.Fatalf("expected one clone, got %+v", report.Clones)
+	}
+	a, b := report.Clones[0].Blocks[0], report.Clones[0].Blocks[1]
+	if a.EndLine >= b.StartLine || report.Clones[0].Similarity != 1 {
+		t.Errorf("expected two separate verbatim blocks, got %+v and %+v", a, b)
+	}
+}
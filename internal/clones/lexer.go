@@ -0,0 +1,294 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package clones
+
+import (
+	"strings"
+)
+
+// Token is one lexical token of a source file
+type Token struct {
+	Text string // As written
+	Norm string // Identifiers, strings, and numbers replaced by a placeholder
+	Line int
+}
+
+// Placeholders that normalized tokens use, so renamed copies still match
+const (
+	IdentToken  = "$id"
+	StringToken = "$str"
+	NumberToken = "$num"
+)
+
+// syntax is what the lexer needs to know about a language
+type syntax struct {
+	lineComments []string
+	quotes       string // Characters that open a string literal
+	keywords     map[string]bool
+	foldCase     bool     // Keywords are case-insensitive
+	variables    bool     // "$name" is an identifier
+	tags         []string // Open/close tags to skip, e.g. "<?php"
+}
+
+// languages holds the lexer syntax per parser language; anything else lexes as C-like
+var languages = map[string]*syntax{
+	"php": {
+		lineComments: []string{"//", "#"},
+		quotes:       `'"`,
+		foldCase:     true,
+		variables:    true,
+		tags:         []string{"<?php", "<?=", "?>"},
+		keywords: keywordSet(`abstract and array as break callable case catch class clone const continue
+			declare default do echo else elseif empty enddeclare endfor endforeach endif endswitch endwhile
+			enum extends final finally fn for foreach function global goto if implements include
+			include_once instanceof insteadof interface isset list match namespace new or print private
+			protected public readonly require require_once return static switch throw trait try unset use
+			var while xor yield null true false self parent`),
+	},
+	"javascript": {
+		lineComments: []string{"//"},
+		quotes:       "'\"`",
+		keywords: keywordSet(`async await break case catch class const continue debugger default delete
+			do else export extends finally for from function if import in instanceof let new of return
+			static super switch this throw try typeof var void while with yield null undefined true false
+			interface type enum implements private protected public readonly`),
+	},
+	"python": {
+		lineComments: []string{"#"},
+		quotes:       `'"`,
+		keywords: keywordSet(`and as assert async await break class continue def del elif else except
+			finally for from global if import in is lambda nonlocal not or pass raise return try while
+			with yield None True False self cls`),
+	},
+	"go": {
+		lineComments: []string{"//"},
+		quotes:       "'\"`",
+		keywords: keywordSet(`break case chan const continue default defer else fallthrough for func go
+			goto if import interface map package range return select struct switch type var nil true
+			false iota`),
+	},
+	"java": {
+		lineComments: []string{"//"},
+		quotes:       `'"`,
+		keywords: keywordSet(`abstract assert boolean break byte case catch char class const continue
+			default do double else enum extends final finally float for goto if implements import
+			instanceof int interface long native new package private protected public return short
+			static strictfp super switch synchronized this throw throws transient try void volatile
+			while record var yield null true false`),
+	},
+	"csharp": {
+		lineComments: []string{"//"},
+		quotes:       `'"`,
+		keywords: keywordSet(`abstract as base bool break byte case catch char checked class const
+			continue decimal default delegate do double else enum event explicit extern false finally
+			fixed float for foreach goto if implicit in int interface internal is lock long namespace
+			new null object operator out override params private protected public readonly ref return
+			sbyte sealed short sizeof stackalloc static string struct switch this throw true try typeof
+			uint ulong unchecked unsafe ushort using virtual void volatile while async await var record
+			get set init`),
+	},
+	"kotlin": {
+		lineComments: []string{"//"},
+		quotes:       `'"`,
+		keywords: keywordSet(`as break class continue do else false for fun if in interface is null
+			object package return super this throw true try typealias typeof val var when while by catch
+			constructor companion data enum sealed abstract open override private protected public
+			internal lateinit const suspend inline import init finally get set`),
+	},
+	"rust": {
+		lineComments: []string{"//"},
+		quotes:       `"`, // ' also starts lifetimes
+		keywords: keywordSet(`as async await break const continue crate dyn else enum extern false fn
+			for if impl in let loop match mod move mut pub ref return self Self static struct super trait
+			true type unsafe use where while union macro_rules`),
+	},
+	"swift": {
+		lineComments: []string{"//"},
+		quotes:       `"`,
+		keywords: keywordSet(`actor any as associatedtype async await break case catch class continue
+			default defer deinit do else enum extension fallthrough false fileprivate final for func
+			guard if import in init inout internal is let mutating nil open operator override private
+			protocol public repeat rethrows return self Self some static struct subscript super switch
+			throw throws true try typealias var where while`),
+	},
+	"scala": {
+		lineComments: []string{"//"},
+		quotes:       `"`, // ' also starts symbols
+		keywords: keywordSet(`abstract case catch class def do else enum extends false final finally for
+			given if implicit import lazy match new null object override package private protected
+			return sealed super then this throw trait true try type using val var while with yield`),
+	},
+	"dart": {
+		lineComments: []string{"//"},
+		quotes:       `'"`,
+		keywords: keywordSet(`abstract as assert async await base break case catch class const continue
+			covariant default deferred do dynamic else enum export extends extension external factory
+			false final finally for get hide if implements import in interface is late library mixin new
+			null on operator part required rethrow return sealed set show static super switch sync this
+			throw true try typedef var void when while with yield`),
+	},
+	"cpp": {
+		lineComments: []string{"//"},
+		quotes:       `'"`,
+		keywords: keywordSet(`alignas alignof auto bool break case catch char class const constexpr
+			const_cast continue decltype default delete do double dynamic_cast else enum explicit extern
+			false final float for friend goto if inline int long mutable namespace new noexcept nullptr
+			operator override private protected public register reinterpret_cast return short signed
+			sizeof static static_assert static_cast struct switch template this throw true try typedef
+			typename union unsigned using virtual void volatile while`),
+	},
+	"ruby": {
+		lineComments: []string{"#"},
+		quotes:       "'\"`",
+		keywords: keywordSet(`alias and begin break case class def defined? do else elsif end ensure
+			false for if in module next nil not or redo rescue retry return self super then true undef
+			unless until when while yield`),
+	},
+	"elixir": {
+		lineComments: []string{"#"},
+		quotes:       `'"`,
+		keywords: keywordSet(`after alias and case catch cond def defdelegate defguard defimpl defmacro
+			defmodule defp defprotocol defstruct do else end false fn for if import in nil not or quote
+			raise receive require rescue true try unless unquote use when with`),
+	},
+	"lua": {
+		lineComments: []string{"--"},
+		quotes:       `'"`,
+		keywords: keywordSet(`and break do else elseif end false for function goto if in local nil not or
+			repeat return then true until while`),
+	},
+	"perl": {
+		lineComments: []string{"#"},
+		quotes:       "'\"`",
+		keywords: keywordSet(`and cmp do else elsif eq for foreach ge gt if last le local lt my ne next no
+			not or our package redo require return state sub undef unless until use while xor`),
+	},
+	"sql": {
+		lineComments: []string{"--"},
+		quotes:       `'`,
+		foldCase:     true,
+		keywords: keywordSet(`all alter and as asc begin between by call case create declare delete desc
+			distinct drop else end exists from function group having if in inner insert into is join key
+			left like limit not null on or order outer primary procedure references return returns right
+			select set table then trigger union update values view when where with`),
+	},
+}
+
+// TypeScript lexes like JavaScript, whose keywords include TypeScript's
+func init() {
+	languages["typescript"] = languages["javascript"]
+}
+
+// defaultSyntax lexes languages without their own entry
+var defaultSyntax = &syntax{lineComments: []string{"//"}, quotes: `'"`, keywords: map[string]bool{}}
+
+func keywordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// Tokenize splits source into tokens, dropping whitespace and comments. Keywords and
+// punctuation normalize to themselves; identifiers, strings, and numbers to placeholders.
+func Tokenize(language string, src string) []Token {
+	lang := languages[language]
+	if lang == nil {
+		lang = defaultSyntax
+	}
+
+	var tokens []Token
+	line := 1
+	emit := func(text, norm string) {
+		tokens = append(tokens, Token{Text: text, Norm: norm, Line: line})
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i - 2
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case lineComment(lang, src[i:]):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case tag(lang, src[i:]) > 0:
+			i += tag(lang, src[i:])
+		case strings.IndexByte(lang.quotes, c) >= 0:
+			start, startLine := i, line
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' {
+					i++
+				} else if src[i] == '\n' {
+					line++
+				}
+			}
+			i = min(i+1, len(src))
+			tokens = append(tokens, Token{Text: src[start:i], Norm: StringToken, Line: startLine})
+		case isDigit(c):
+			start := i
+			for i < len(src) && (isIdentByte(src[i]) || src[i] == '.') {
+				i++
+			}
+			emit(src[start:i], NumberToken)
+		case isIdentByte(c) || (lang.variables && c == '$' && i+1 < len(src) && isIdentByte(src[i+1])):
+			start := i
+			for i++; i < len(src) && isIdentByte(src[i]); i++ {
+			}
+			word, key := src[start:i], src[start:i]
+			if lang.foldCase {
+				key = strings.ToLower(word)
+			}
+			if lang.keywords[key] {
+				emit(word, key)
+			} else {
+				emit(word, IdentToken)
+			}
+		default:
+			emit(string(c), string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+// lineComment reports whether rest starts a line comment. PHP attributes ("#[") aren't
+// comments.
+func lineComment(lang *syntax, rest string) bool {
+	for _, prefix := range lang.lineComments {
+		if strings.HasPrefix(rest, prefix) && !(prefix == "#" && strings.HasPrefix(rest, "#[")) {
+			return true
+		}
+	}
+	return false
+}
+
+// tag returns the length of the open or close tag rest starts with, or 0
+func tag(lang *syntax, rest string) int {
+	for _, t := range lang.tags {
+		if strings.HasPrefix(rest, t) {
+			return len(t)
+		}
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || isDigit(c)
+}
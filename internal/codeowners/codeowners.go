@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package codeowners reads GitHub/GitLab-style CODEOWNERS files and finds the owners of
+// a path
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations are where Detect looks for a CODEOWNERS file, in order
+var Locations = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+	filepath.Join(".gitlab", "CODEOWNERS"),
+}
+
+// Rule is one "pattern owner..." line
+type Rule struct {
+	Pattern string
+	Owners  []string // Empty when the pattern explicitly has no owner
+	Line    int
+	re      *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Path  string
+	Rules []*Rule
+}
+
+// Detect loads the first CODEOWNERS file found in root's standard locations. It returns
+// nil when there is none.
+func Detect(root string) (*File, error) {
+	for _, location := range Locations {
+		path := filepath.Join(root, location)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+	}
+	return nil, nil
+}
+
+// Load reads and parses the CODEOWNERS file at path
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	file, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	file.Path = path
+	return file, nil
+}
+
+// Parse reads CODEOWNERS rules. Comments, blank lines, and GitLab section headers
+// ("[Section]") are skipped.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(text, " #"); i >= 0 {
+			text = strings.TrimSpace(text[:i])
+		}
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "[") || strings.HasPrefix(text, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		pattern := strings.ReplaceAll(fields[0], `\ `, " ")
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", line, pattern, err)
+		}
+		file.Rules = append(file.Rules, &Rule{Pattern: pattern, Owners: fields[1:], Line: line, re: re})
+	}
+	return file, scanner.Err()
+}
+
+// OwnersOf returns the owners of path, relative to the repository root with forward
+// slashes. As in GitHub, the last matching rule wins.
+func (f *File) OwnersOf(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// compile turns a gitignore-style CODEOWNERS pattern into a regular expression over
+// repository-relative paths:
+//   - a leading "/", or a "/" inside the pattern, anchors it to the root; otherwise it
+//     matches at any depth
+//   - "*" and "?" don't cross directories and "**" does
+//   - a pattern naming a directory also matches everything beneath it, except that
+//     "dir/*" covers only the files directly in dir
+func compile(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; {
+		case c == '*' && strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case strings.HasSuffix(trimmed, "/*"):
+		b.WriteString("$")
+	case dirOnly:
+		b.WriteString("/.*$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,77 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `# Default owners
+*                       @acme/platform
+
+*.js                    @acme/frontend
+/app/Billing/           @acme/billing @alice
+docs/*                  @acme/docs
+**/legacy/**            @acme/legacy
+apps/                   @acme/apps
+/vendor/                # Nobody owns vendored code
+
+[Optional section]
+/app/Http/ @acme/web    # Trailing comment
+`
+
+func TestOwnersOf(t *testing.T) {
+	file, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Rules) != 8 {
+		t.Fatalf("expected 8 rules, got %d", len(file.Rules))
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@acme/platform"}},
+		{"resources/js/app.js", []string{"@acme/frontend"}},
+		{"app/Billing/Invoice.php", []string{"@acme/billing", "@alice"}},
+		{"app/Billing/Models/Charge.php", []string{"@acme/billing", "@alice"}},
+		{"src/app/Billing/Invoice.php", []string{"@acme/platform"}}, // Anchored to the root
+		{"docs/index.md", []string{"@acme/docs"}},
+		{"docs/guides/setup.md", []string{"@acme/platform"}}, // docs/* is one level only
+		{"src/legacy/old/billing.php", []string{"@acme/legacy"}},
+		{"packages/apps/web/main.go", []string{"@acme/apps"}}, // Unanchored directory
+		{"vendor/lib/x.php", nil},
+		{"app/Http/Controller.php", []string{"@acme/web"}},
+	}
+	for _, tt := range tests {
+		if got := file.OwnersOf(tt.path); !reflect.DeepEqual(got, tt.want) && !(len(got) == 0 && tt.want == nil) {
+			t.Errorf("OwnersOf(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+	if file, err := Detect(root); file != nil || err != nil {
+		t.Fatalf("expected nothing without a CODEOWNERS file, got %v, %v", file, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, ".github", "CODEOWNERS")
+	if err := os.WriteFile(path, []byte("/src/ @acme/core\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := Detect(root)
+	if err != nil || file == nil || file.Path != path {
+		t.Fatalf("expected %s to be found, got %v, %v", path, file, err)
+	}
+	if got := file.OwnersOf("src/main.go"); !reflect.DeepEqual(got, []string{"@acme/core"}) {
+		t.Errorf("unexpected owners %v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// BatchConfig lists the projects analyzed by `tukey batch <file>`
+type BatchConfig struct {
+	Output   string         `json:"output" yaml:"output"` // Directory for reports; defaults to tukey-batch next to the file
+	Projects []BatchProject `json:"projects" yaml:"projects"`
+}
+
+// BatchProject is one project analyzed by `tukey batch`. Its own config file in Root
+// applies as in a single run.
+type BatchProject struct {
+	Name  string   `json:"name" yaml:"name"`   // Report directory name; defaults to the root's base name
+	Root  string   `json:"root" yaml:"root"`   // Relative roots are resolved against the config file
+	Flags []string `json:"flags" yaml:"flags"` // Analysis flags, e.g. ["--language", "go"]
+}
+
+// LoadBatchConfig reads a YAML or JSON project list, filling in defaulted names and the
+// output directory and checking that names are unique and safe as directory names
+func LoadBatchConfig(path string) (*BatchConfig, error) {
+	cfg := &BatchConfig{}
+	if err := decodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Projects) == 0 {
+		return nil, fmt.Errorf("%s lists no projects", path)
+	}
+
+	base := filepath.Dir(path)
+	if cfg.Output == "" {
+		cfg.Output = filepath.Join(base, "tukey-batch")
+	} else if !filepath.IsAbs(cfg.Output) {
+		cfg.Output = filepath.Join(base, cfg.Output)
+	}
+
+	seen := make(map[string]bool)
+	for i := range cfg.Projects {
+		project := &cfg.Projects[i]
+		if project.Root == "" {
+			return nil, fmt.Errorf("project %d in %s has no root", i+1, path)
+		}
+		if !filepath.IsAbs(project.Root) {
+			project.Root = filepath.Join(base, project.Root)
+		}
+		if project.Name == "" {
+			abs, _ := filepath.Abs(project.Root)
+			project.Name = filepath.Base(abs)
+		}
+		if !projectName.MatchString(project.Name) {
+			return nil, fmt.Errorf("project name %q must be letters, digits, '.', '_', or '-'", project.Name)
+		}
+		if seen[project.Name] {
+			return nil, fmt.Errorf("project name %q is used twice; set distinct names", project.Name)
+		}
+		seen[project.Name] = true
+	}
+	return cfg, nil
+}
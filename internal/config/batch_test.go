@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadBatchConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "projects.yml")
+	content := `
+projects:
+  - root: services/billing
+    flags: ["--language", "go"]
+  - name: storefront
+    root: /srv/web
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadBatchConfig(path)
+	if err != nil {
+		t.Fatalf("LoadBatchConfig failed: %v", err)
+	}
+	if cfg.Output != filepath.Join(dir, "tukey-batch") || len(cfg.Projects) != 2 {
+		t.Fatalf("expected reports to default to tukey-batch next to the file, got %+v", cfg)
+	}
+	billing := cfg.Projects[0]
+	if billing.Name != "billing" || billing.Root != filepath.Join(dir, "services", "billing") || len(billing.Flags) != 2 {
+		t.Errorf("expected name and root to default from the relative root, got %+v", billing)
+	}
+	if web := cfg.Projects[1]; web.Name != "storefront" || web.Root != "/srv/web" {
+		t.Errorf("unexpected project: %+v", web)
+	}
+
+	os.WriteFile(path, []byte(`{"output": "reports", "projects": [{"root": "api"}]}`), 0644)
+	if cfg, err := LoadBatchConfig(path); err != nil || cfg.Output != filepath.Join(dir, "reports") {
+		t.Errorf("expected the output directory relative to the file, got %+v (%v)", cfg, err)
+	}
+}
+
+func TestLoadBatchConfig_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":     `{"projects": []}`,
+		"no root":   `{"projects": [{"name": "api"}]}`,
+		"bad name":  `{"projects": [{"name": "../api", "root": "x"}]}`,
+		"duplicate": `{"projects": [{"root": "a/api"}, {"root": "b/api"}]}`,
+	}
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), "projects.json")
+		os.WriteFile(path, []byte(content), 0644)
+		if _, err := LoadBatchConfig(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
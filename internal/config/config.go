@@ -1,19 +1,118 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type FileConfig struct {
-	Language    string   `json:"language" yaml:"language"`
-	ExcludeDirs []string `json:"excludeDirs" yaml:"excludeDirs"`
-	OutputFile  string   `json:"outputFile" yaml:"outputFile"`
-	Verbose     bool     `json:"verbose" yaml:"verbose"`
+	Extends         Extends             `json:"extends" yaml:"extends"` // Shared configs this one builds on
+	Language        string              `json:"language" yaml:"language"`
+	ExcludeDirs     []string            `json:"excludeDirs" yaml:"excludeDirs"`
+	IncludeDirs     []string            `json:"includeDirs" yaml:"includeDirs"` // Defaults to scan anyway
+	OutputFile      string              `json:"outputFile" yaml:"outputFile"`
+	Format          string              `json:"format" yaml:"format"`
+	Template        string              `json:"template" yaml:"template"`
+	PathStyle       string              `json:"pathStyle" yaml:"pathStyle"`
+	Verbose         bool                `json:"verbose" yaml:"verbose"`
+	WordPress       bool                `json:"wordpress" yaml:"wordpress"`
+	Framework       string              `json:"framework" yaml:"framework"`
+	CollapseBarrels bool                `json:"collapseBarrels" yaml:"collapseBarrels"`
+	Bridges         bool                `json:"bridges" yaml:"bridges"`
+	Sign            bool                `json:"sign" yaml:"sign"`
+	Redact          bool                `json:"redact" yaml:"redact"`
+	Accessible      bool                `json:"accessible" yaml:"accessible"`
+	StatusFile      string              `json:"statusFile" yaml:"statusFile"`
+	Checkpoint      string              `json:"checkpoint" yaml:"checkpoint"`
+	ChangedOnly     string              `json:"changedOnly" yaml:"changedOnly"`
+	Gitignore       bool                `json:"gitignore" yaml:"gitignore"`
+	Extensionless   bool                `json:"extensionless" yaml:"extensionless"`
+	MaxFileSize     string              `json:"maxFileSize" yaml:"maxFileSize"` // e.g. "2MB"
+	SummaryOnly     bool                `json:"summaryOnly" yaml:"summaryOnly"`
+	MaxLinesPerEdge int                 `json:"maxLinesPerEdge" yaml:"maxLinesPerEdge"`
+	MaxParameters   int                 `json:"maxParameters" yaml:"maxParameters"`
+	Clones          bool                `json:"clones" yaml:"clones"`
+	MinCloneTokens  int                 `json:"minCloneTokens" yaml:"minCloneTokens"`
+	Literals        bool                `json:"literals" yaml:"literals"`
+	MinLiteralCount int                 `json:"minLiteralCount" yaml:"minLiteralCount"`
+	ChurnSince      string              `json:"churnSince" yaml:"churnSince"`
+	TicketPattern   string              `json:"ticketPattern" yaml:"ticketPattern"`
+	DebtAge         bool                `json:"debtAge" yaml:"debtAge"`
+	MinDocCoverage  int                 `json:"minDocCoverage" yaml:"minDocCoverage"`
+	FlowDepth       int                 `json:"flowDepth" yaml:"flowDepth"`
+	Prune           []string            `json:"prune" yaml:"prune"`
+	Groups          map[string][]string `json:"groups" yaml:"groups"` // Virtual group name -> name or path patterns
+	Codeowners      string              `json:"codeowners" yaml:"codeowners"`
+	Coverage        []string            `json:"coverage" yaml:"coverage"` // Clover, LCOV, or Go coverage files
+	Traces          []string            `json:"traces" yaml:"traces"`     // Xdebug, Tideways, or OpenTelemetry traces
+	Suppressions    string              `json:"suppressions" yaml:"suppressions"`
+	Notes           string              `json:"notes" yaml:"notes"`
+	OpenAPI         string              `json:"openapi" yaml:"openapi"`
+	FeatureFlags    []string            `json:"featureFlags" yaml:"featureFlags"` // Accessors such as "Feature::active"
+	APINamespaces   []string            `json:"apiNamespaces" yaml:"apiNamespaces"`
+	Thresholds      map[string]int      `json:"thresholds" yaml:"thresholds"`
+	Severities      map[string]string   `json:"severities" yaml:"severities"` // e.g. orphans: warning
+	Plugins         []PluginConfig      `json:"plugins" yaml:"plugins"`
+	WasmRules       []string            `json:"wasmRules" yaml:"wasmRules"` // .wasm files, relative to the project root
+	StrictConfig    bool                `json:"strictConfig" yaml:"strictConfig"`
+	PHP             PHPConfig           `json:"php" yaml:"php"`
+	EcmaVersion     int                 `json:"ecmaVersion" yaml:"ecmaVersion"` // JavaScript edition, e.g. 2020 or 11
+
+	Origins  map[string]string `json:"-" yaml:"-"` // Setting key -> the config file that set it
+	Warnings []error           `json:"-" yaml:"-"` // Problems that didn't stop loading, such as unknown keys
+}
+
+// Error is a problem with a config file, and the setting it's in when it's about one
+type Error struct {
+	File string
+	Key  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.File, e.Key, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// PluginConfig declares a plugin: the program to run, relative to the project root
+// unless it's a bare name found on the PATH, and its arguments
+type PluginConfig struct {
+	Command string   `json:"command" yaml:"command"`
+	Args    []string `json:"args" yaml:"args"`
+}
+
+// PHPConfig holds the PHP-specific settings
+type PHPConfig struct {
+	Version string `json:"version" yaml:"version"` // The PHP version the code targets, e.g. "7.4"
+}
+
+// TargetVersion is the version of the language the config targets: php.version for PHP
+// and ecmaVersion for JavaScript, or "" when it sets none
+func (c *FileConfig) TargetVersion(language string) string {
+	switch language {
+	case "php":
+		return c.PHP.Version
+	case "javascript":
+		if c.EcmaVersion != 0 {
+			return strconv.Itoa(c.EcmaVersion)
+		}
+	}
+	return ""
 }
 
 func LoadConfig(projectRoot string) (*FileConfig, error) {
@@ -35,19 +134,112 @@ func LoadConfig(projectRoot string) (*FileConfig, error) {
 }
 
 func parseFile(path string) (*FileConfig, error) {
+	cfg := &FileConfig{}
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return cfg, &Error{File: path, Err: err}
+	}
+	if err := decodeConfig(data, filepath.Ext(path), path, cfg); err != nil {
+		return cfg, err
 	}
+	return cfg, inherit(cfg, path, map[string]bool{path: true})
+}
 
-	cfg := &FileConfig{}
-	switch filepath.Ext(path) {
+// decodeConfig decodes a config file's settings into cfg one key at a time, so that a
+// problem names the key it's in and the other settings still apply, like a partial
+// unmarshal. It records the settings the file sets in cfg.Origins, and keys that aren't
+// settings in cfg.Warnings. The error is the first setting that failed to decode.
+func decodeConfig(data []byte, ext, file string, cfg *FileConfig) error {
+	fields := reflect.ValueOf(cfg).Elem()
+	keys := make(map[string]int) // Setting key -> field index
+	for i := 0; i < fields.NumField(); i++ {
+		if key := fields.Type().Field(i).Tag.Get("yaml"); key != "" && key != "-" {
+			keys[key] = i
+		}
+	}
+	cfg.Origins = make(map[string]string)
+
+	var first error
+	decode := func(key string, into func(v interface{}) error) {
+		index, ok := keys[key]
+		if !ok && ext == ".json" {
+			for name, i := range keys {
+				if strings.EqualFold(name, key) {
+					key, index, ok = name, i, true // Like json.Unmarshal, ignore case
+				}
+			}
+		}
+		if !ok {
+			cfg.Warnings = append(cfg.Warnings, &Error{File: file, Key: key, Err: errors.New("unknown setting")})
+			return
+		}
+		if err := into(fields.Field(index).Addr().Interface()); err != nil {
+			if typeErr, isType := err.(*yaml.TypeError); isType {
+				err = errors.New(strings.Join(typeErr.Errors, "; "))
+			}
+			if first == nil {
+				first = &Error{File: file, Key: key, Err: err}
+			}
+			return
+		}
+		cfg.Origins[key] = file
+	}
+
+	switch ext {
+	case ".yaml", ".yml":
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return &Error{File: file, Err: err}
+		}
+		if len(doc.Content) == 0 {
+			return nil // An empty file
+		}
+		root := doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return &Error{File: file, Err: fmt.Errorf("line %d: expected a mapping of settings", root.Line)}
+		}
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			decode(root.Content[i].Value, root.Content[i+1].Decode)
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+			return &Error{File: file, Err: errors.New("expected an object of settings")}
+		}
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return &Error{File: file, Err: err}
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return &Error{File: file, Err: err}
+			}
+			decode(token.(string), func(v interface{}) error { return json.Unmarshal(value, v) })
+		}
+	default:
+		return &Error{File: file, Err: errors.New("unsupported config format")}
+	}
+	return first
+}
+
+// decodeFile unmarshals a YAML or JSON file into v, by extension
+func decodeFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return decodeData(data, filepath.Ext(path), v)
+}
+
+// decodeData unmarshals YAML or JSON into v, by file extension
+func decodeData(data []byte, ext string, v interface{}) error {
+	switch ext {
 	case ".yaml", ".yml":
-		err = yaml.Unmarshal(data, cfg)
+		return yaml.Unmarshal(data, v)
 	case ".json":
-		err = json.Unmarshal(data, cfg)
+		return json.Unmarshal(data, v)
 	default:
-		err = errors.New("unsupported config format")
+		return errors.New("unsupported config format")
 	}
-	return cfg, err
 }
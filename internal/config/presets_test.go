@@ -0,0 +1,66 @@
+package config
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestGetPreset(t *testing.T) {
+	preset, ok := GetPreset("drupal")
+	if !ok {
+		t.Fatalf("expected drupal preset")
+	}
+	found := false
+	for _, ext := range preset.Extensions {
+		if ext == ".module" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected drupal preset to scan .module files, got %v", preset.Extensions)
+	}
+
+	if _, ok := GetPreset("rails"); ok {
+		t.Errorf("expected unknown framework to be rejected")
+	}
+
+	wp, _ := GetPreset("wordpress")
+	if !wp.HasResolver("hooks") {
+		t.Errorf("expected wordpress preset to enable hook resolution")
+	}
+}
+
+func TestSupportedFrameworks(t *testing.T) {
+	got := SupportedFrameworks()
+	want := []string{"codeigniter", "drupal", "wordpress"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestPresetPatternsCompile(t *testing.T) {
+	for _, name := range SupportedFrameworks() {
+		preset, _ := GetPreset(name)
+		for _, pattern := range append(preset.Entrypoints, preset.EntrypointFiles...) {
+			if _, err := regexp.Compile(pattern); err != nil {
+				t.Errorf("%s: invalid pattern %q: %v", name, pattern, err)
+			}
+		}
+	}
+
+	drupal, _ := GetPreset("drupal")
+	matched := false
+	for _, pattern := range drupal.Entrypoints {
+		if regexp.MustCompile(pattern).MatchString("mymodule_form_alter") {
+			matched = true
+		}
+	}
+	if !matched {
+		t.Errorf("expected hook_form_alter implementation to be an entrypoint")
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+)
+
+// ServeConfig lists the projects hosted by `tukey serve --projects <file>`
+type ServeConfig struct {
+	Addr     string         `json:"addr" yaml:"addr"`
+	Auth     *AuthConfig    `json:"auth" yaml:"auth"`
+	TLS      *TLSConfig     `json:"tls" yaml:"tls"`
+	Projects []ServeProject `json:"projects" yaml:"projects"`
+}
+
+// ServeProject is one project hosted by `tukey serve`
+type ServeProject struct {
+	Name     string   `json:"name" yaml:"name"`         // URL name; defaults to the root's base name
+	Root     string   `json:"root" yaml:"root"`         // Relative roots are resolved against the config file
+	Schedule string   `json:"schedule" yaml:"schedule"` // Defaults to --schedule
+	History  string   `json:"history" yaml:"history"`   // Defaults to <root>/.tukey/history
+	Keep     int      `json:"keep" yaml:"keep"`         // Defaults to --keep
+	Flags    []string `json:"flags" yaml:"flags"`       // Analysis flags, e.g. ["--framework", "drupal"]
+}
+
+// AuthConfig lists who may use the `tukey serve` API, read from the project list's auth
+// key or from `--auth <file>`
+type AuthConfig struct {
+	Tokens []AuthToken `json:"tokens" yaml:"tokens"`
+	Users  []AuthUser  `json:"users" yaml:"users"`
+}
+
+// AuthToken is a bearer token. Set TokenEnv to read it from the environment instead of
+// keeping it in the file.
+type AuthToken struct {
+	Name     string   `json:"name" yaml:"name"`
+	Token    string   `json:"token" yaml:"token"`
+	TokenEnv string   `json:"tokenEnv" yaml:"tokenEnv"`
+	Role     string   `json:"role" yaml:"role"`         // "read" (default) or "write"
+	Projects []string `json:"projects" yaml:"projects"` // Projects it may access; all when empty
+}
+
+// AuthUser is an HTTP basic-auth user. Password may be plain, "sha256:<hex>", or left
+// empty in favor of PasswordEnv.
+type AuthUser struct {
+	Name        string   `json:"name" yaml:"name"`
+	Password    string   `json:"password" yaml:"password"`
+	PasswordEnv string   `json:"passwordEnv" yaml:"passwordEnv"`
+	Role        string   `json:"role" yaml:"role"`
+	Projects    []string `json:"projects" yaml:"projects"`
+}
+
+// TLSConfig serves HTTPS; with ClientCA set, clients must present a certificate it signed
+type TLSConfig struct {
+	Cert     string `json:"cert" yaml:"cert"`
+	Key      string `json:"key" yaml:"key"`
+	ClientCA string `json:"clientCA" yaml:"clientCA"`
+}
+
+// LoadAuthConfig reads a YAML or JSON credentials file
+func LoadAuthConfig(path string) (*AuthConfig, error) {
+	cfg := &AuthConfig{}
+	if err := decodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// projectName matches names that are safe as a URL path segment
+var projectName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// LoadServeConfig reads a YAML or JSON project list, filling in defaulted names and
+// history directories and checking that names are unique and URL-safe
+func LoadServeConfig(path string) (*ServeConfig, error) {
+	cfg := &ServeConfig{}
+	if err := decodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Projects) == 0 {
+		return nil, fmt.Errorf("%s lists no projects", path)
+	}
+
+	base := filepath.Dir(path)
+	seen := make(map[string]bool)
+	for i := range cfg.Projects {
+		project := &cfg.Projects[i]
+		if project.Root == "" {
+			return nil, fmt.Errorf("project %d in %s has no root", i+1, path)
+		}
+		if !filepath.IsAbs(project.Root) {
+			project.Root = filepath.Join(base, project.Root)
+		}
+		if project.Name == "" {
+			abs, _ := filepath.Abs(project.Root)
+			project.Name = filepath.Base(abs)
+		}
+		if !projectName.MatchString(project.Name) {
+			return nil, fmt.Errorf("project name %q must be letters, digits, '.', '_', or '-'", project.Name)
+		}
+		if seen[project.Name] {
+			return nil, fmt.Errorf("project name %q is used twice; set distinct names", project.Name)
+		}
+		seen[project.Name] = true
+		if project.History == "" {
+			project.History = filepath.Join(project.Root, ".tukey", "history")
+		} else if !filepath.IsAbs(project.History) {
+			project.History = filepath.Join(base, project.History)
+		}
+		if project.Keep < 0 {
+			return nil, fmt.Errorf("project %q: keep must not be negative", project.Name)
+		}
+	}
+
+	if tls := cfg.TLS; tls != nil {
+		for _, path := range []*string{&tls.Cert, &tls.Key, &tls.ClientCA} {
+			if *path != "" && !filepath.IsAbs(*path) {
+				*path = filepath.Join(base, *path)
+			}
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadServeConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "projects.yml")
+	content := `
+addr: ":9000"
+projects:
+  - root: repos/api
+    schedule: "@daily"
+    flags: ["--framework", "drupal"]
+  - name: web-app
+    root: /srv/web
+    history: snapshots/web
+    keep: 30
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadServeConfig(path)
+	if err != nil {
+		t.Fatalf("LoadServeConfig failed: %v", err)
+	}
+	if cfg.Addr != ":9000" || len(cfg.Projects) != 2 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	api := cfg.Projects[0]
+	if api.Name != "api" || api.Root != filepath.Join(dir, "repos", "api") {
+		t.Errorf("expected name and root to default from the relative root, got %+v", api)
+	}
+	if api.History != filepath.Join(dir, "repos", "api", ".tukey", "history") {
+		t.Errorf("expected history under the root, got %q", api.History)
+	}
+	if len(api.Flags) != 2 || api.Schedule != "@daily" {
+		t.Errorf("unexpected project: %+v", api)
+	}
+
+	web := cfg.Projects[1]
+	if web.Name != "web-app" || web.Root != "/srv/web" || web.History != filepath.Join(dir, "snapshots", "web") || web.Keep != 30 {
+		t.Errorf("unexpected project: %+v", web)
+	}
+}
+
+func TestLoadServeConfig_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":         `{"projects": []}`,
+		"no root":       `{"projects": [{"name": "api"}]}`,
+		"bad name":      `{"projects": [{"name": "a/b", "root": "x"}]}`,
+		"duplicate":     `{"projects": [{"root": "a/api"}, {"root": "b/api"}]}`,
+		"negative keep": `{"projects": [{"root": "api", "keep": -1}]}`,
+	}
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), "projects.json")
+		os.WriteFile(path, []byte(content), 0644)
+		if _, err := LoadServeConfig(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadServeConfig_AuthAndTLS(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "projects.yml")
+	content := `
+auth:
+  tokens:
+    - name: ci
+      tokenEnv: TUKEY_CI_TOKEN
+      role: write
+  users:
+    - name: alice
+      password: "sha256:abc"
+      projects: [api]
+tls:
+  cert: certs/server.pem
+  key: /etc/tukey/server.key
+projects:
+  - root: api
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadServeConfig(path)
+	if err != nil {
+		t.Fatalf("LoadServeConfig failed: %v", err)
+	}
+	if cfg.Auth == nil || len(cfg.Auth.Tokens) != 1 || cfg.Auth.Tokens[0].TokenEnv != "TUKEY_CI_TOKEN" || cfg.Auth.Users[0].Projects[0] != "api" {
+		t.Errorf("unexpected auth: %+v", cfg.Auth)
+	}
+	if cfg.TLS.Cert != filepath.Join(dir, "certs", "server.pem") || cfg.TLS.Key != "/etc/tukey/server.key" {
+		t.Errorf("expected TLS paths resolved against the file, got %+v", cfg.TLS)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package coverage reads test coverage files (PHPUnit's Clover XML, LCOV, and Go cover
+// profiles) into the number of times each line ran
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formats Detect tells apart
+const (
+	FormatClover = "clover"
+	FormatLCOV   = "lcov"
+	FormatGo     = "go"
+)
+
+// Profile is the line coverage of one or more coverage files
+type Profile struct {
+	Sources []string               // The coverage files read
+	Files   map[string]map[int]int // Executions per executable line, by file path as written
+	byBase  map[string][]string    // File paths by base name, for matching
+	matched map[string]bool        // File paths Lines has matched
+}
+
+// NewProfile creates an empty profile
+func NewProfile() *Profile {
+	return &Profile{Files: make(map[string]map[int]int), byBase: make(map[string][]string), matched: make(map[string]bool)}
+}
+
+// Load reads coverage files into one profile, adding up the executions of lines more
+// than one of them covers, such as from separate unit and integration runs
+func Load(paths ...string) (*Profile, error) {
+	profile := NewProfile()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := profile.Parse(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		profile.Sources = append(profile.Sources, p)
+	}
+	return profile, nil
+}
+
+// Detect returns the format of coverage data, or "" when it isn't one Parse reads
+func Detect(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return FormatGo
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return FormatClover
+	case bytes.HasPrefix(trimmed, []byte("TN:")), bytes.HasPrefix(trimmed, []byte("SF:")):
+		return FormatLCOV
+	}
+	return ""
+}
+
+// Parse adds coverage data in any of the formats Detect recognizes to the profile
+func (p *Profile) Parse(data []byte) error {
+	switch Detect(data) {
+	case FormatGo:
+		return p.parseGo(data)
+	case FormatClover:
+		return p.parseClover(data)
+	case FormatLCOV:
+		return p.parseLCOV(data)
+	}
+	return fmt.Errorf("not a Clover, LCOV, or Go coverage file")
+}
+
+// parseLCOV reads LCOV tracefiles: an SF:<file> line starts each file's record, and
+// DA:<line>,<executions>[,<checksum>] lines give its lines
+func (p *Profile) parseLCOV(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	file := ""
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(text, "SF:"):
+			file = strings.TrimPrefix(text, "SF:")
+		case strings.HasPrefix(text, "DA:") && file != "":
+			fields := strings.Split(strings.TrimPrefix(text, "DA:"), ",")
+			if len(fields) < 2 {
+				continue
+			}
+			line, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return fmt.Errorf("invalid line in %q", text)
+			}
+			count, err := strconv.ParseFloat(fields[1], 64) // Some tools write "1.0e3"
+			if err != nil {
+				return fmt.Errorf("invalid execution count in %q", text)
+			}
+			p.add(file, line, int(count))
+		case text == "end_of_record":
+			file = ""
+		}
+	}
+	return scanner.Err()
+}
+
+// parseGo reads `go test -coverprofile` output: after the mode line, each line is a block
+// "file.go:startLine.startCol,endLine.endCol statements count". Every line of a block gets
+// its count; where blocks share a line, the line ran if either did.
+func (p *Profile) parseGo(data []byte) error {
+	blocks := make(map[string]map[int]int)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "mode:") {
+			continue
+		}
+		colon := strings.LastIndex(text, ":")
+		fields := strings.Fields(text[colon+1:])
+		if colon == -1 || len(fields) != 3 {
+			return fmt.Errorf("invalid block %q", text)
+		}
+		start, end, ok := strings.Cut(fields[0], ",")
+		if !ok {
+			return fmt.Errorf("invalid block %q", text)
+		}
+		startLine, err1 := strconv.Atoi(strings.Split(start, ".")[0])
+		endLine, err2 := strconv.Atoi(strings.Split(end, ".")[0])
+		count, err3 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return fmt.Errorf("invalid block %q", text)
+		}
+
+		file := text[:colon]
+		if blocks[file] == nil {
+			blocks[file] = make(map[int]int)
+		}
+		for line := startLine; line <= endLine; line++ {
+			if seen, ok := blocks[file][line]; !ok || count > seen {
+				blocks[file][line] = count
+			}
+		}
+	}
+	for file, lines := range blocks {
+		for line, count := range lines {
+			p.add(file, line, count)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseClover reads Clover XML, which PHPUnit writes with --coverage-clover:
+// <file name="..."> elements, in or out of <package>s, holding
+// <line num="..." type="stmt|method|cond" count="..."/> elements
+func (p *Profile) parseClover(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	file := ""
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "file":
+				file = attr(element, "name")
+				if file == "" {
+					file = attr(element, "path")
+				}
+			case "line":
+				if file == "" {
+					continue
+				}
+				line, err := strconv.Atoi(attr(element, "num"))
+				if err != nil {
+					return fmt.Errorf("invalid line number in %s", file)
+				}
+				count, _ := strconv.Atoi(attr(element, "count"))
+				p.add(file, line, count)
+			}
+		case xml.EndElement:
+			if element.Name.Local == "file" {
+				file = ""
+			}
+		}
+	}
+}
+
+// attr returns the value of an element's attribute, or ""
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// add records count executions of a line
+func (p *Profile) add(file string, line, count int) {
+	file = filepath.ToSlash(file)
+	if p.Files[file] == nil {
+		p.Files[file] = make(map[int]int)
+		base := path.Base(file)
+		p.byBase[base] = append(p.byBase[base], file)
+	}
+	p.Files[file][line] += count
+}
+
+// Lines returns the executions per executable line of the file at path, relative to the
+// project root with forward slashes, or nil when the profile doesn't cover it. Coverage
+// tools write paths from their own working directory, as absolute paths, or as Go import
+// paths, so a file matches the covered path that shares the most trailing segments with
+// it, as long as one path ends with all of the other's segments.
+func (p *Profile) Lines(file string) map[int]int {
+	file = strings.TrimPrefix(filepath.ToSlash(file), "/")
+	segments := strings.Split(file, "/")
+	best, bestShared := "", 0
+	for _, covered := range p.byBase[path.Base(file)] {
+		coveredSegments := strings.Split(strings.TrimPrefix(covered, "/"), "/")
+		shared := 0
+		for shared < len(segments) && shared < len(coveredSegments) &&
+			segments[len(segments)-1-shared] == coveredSegments[len(coveredSegments)-1-shared] {
+			shared++
+		}
+		if shared < len(segments) && shared < len(coveredSegments) {
+			continue // They only share a tail, such as the file name
+		}
+		if shared > bestShared {
+			best, bestShared = covered, shared
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	p.matched[best] = true
+	return p.Files[best]
+}
+
+// Unmatched returns the covered files Lines hasn't matched to a project file, sorted.
+// Many of them usually means the coverage came from a different tree.
+func (p *Profile) Unmatched() []string {
+	var unmatched []string
+	for file := range p.Files {
+		if !p.matched[file] {
+			unmatched = append(unmatched, file)
+		}
+	}
+	sort.Strings(unmatched)
+	return unmatched
+}
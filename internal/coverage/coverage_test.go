@@ -0,0 +1,130 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+		want   map[string]map[int]int
+	}{
+		{
+			name:   "clover",
+			format: FormatClover,
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<coverage generated="1700000000">
+  <project timestamp="1700000000">
+    <package name="App">
+      <file name="/app/src/Invoice.php">
+        <class name="App\Invoice" namespace="App"/>
+        <line num="12" type="method" name="total" count="3"/>
+        <line num="14" type="stmt" count="3"/>
+        <line num="16" type="stmt" count="0"/>
+      </file>
+    </package>
+    <file name="/app/src/helpers.php">
+      <line num="3" type="stmt" count="1"/>
+    </file>
+    <metrics files="2"/>
+  </project>
+</coverage>`,
+			want: map[string]map[int]int{
+				"/app/src/Invoice.php": {12: 3, 14: 3, 16: 0},
+				"/app/src/helpers.php": {3: 1},
+			},
+		},
+		{
+			name:   "lcov",
+			format: FormatLCOV,
+			data: `TN:
+SF:src/format.js
+FN:1,format
+DA:2,5
+DA:3,0,abc123
+end_of_record
+`,
+			want: map[string]map[int]int{"src/format.js": {2: 5, 3: 0}},
+		},
+		{
+			name:   "go",
+			format: FormatGo,
+			data: `mode: count
+github.com/acme/app/internal/money/money.go:10.30,12.2 1 4
+github.com/acme/app/internal/money/money.go:12.2,14.3 1 0
+`,
+			want: map[string]map[int]int{"github.com/acme/app/internal/money/money.go": {10: 4, 11: 4, 12: 4, 13: 0, 14: 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if format := Detect([]byte(tt.data)); format != tt.format {
+				t.Errorf("expected %s, detected %q", tt.format, format)
+			}
+			profile := NewProfile()
+			if err := profile.Parse([]byte(tt.data)); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(profile.Files, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, profile.Files)
+			}
+		})
+	}
+
+	if err := NewProfile().Parse([]byte("not coverage")); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestLoad_AddsUpFiles(t *testing.T) {
+	dir := t.TempDir()
+	unit, integration := filepath.Join(dir, "unit.info"), filepath.Join(dir, "integration.info")
+	os.WriteFile(unit, []byte("SF:src/a.js\nDA:1,1\nDA:2,0\nend_of_record\n"), 0644)
+	os.WriteFile(integration, []byte("SF:src/a.js\nDA:2,2\nend_of_record\n"), 0644)
+
+	profile, err := Load(unit, integration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]int{1: 1, 2: 2}; !reflect.DeepEqual(profile.Files["src/a.js"], want) {
+		t.Errorf("expected %v, got %v", want, profile.Files["src/a.js"])
+	}
+	if len(profile.Sources) != 2 {
+		t.Errorf("expected both sources, got %v", profile.Sources)
+	}
+	if _, err := Load(filepath.Join(dir, "missing.info")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestLines(t *testing.T) {
+	profile := NewProfile()
+	for _, file := range []string{"/ci/app/src/Models/User.php", "/ci/app/tests/Models/User.php", "github.com/acme/app/cmd/main.go"} {
+		profile.add(file, 1, 1)
+	}
+
+	tests := map[string]string{
+		"src/Models/User.php":     "/ci/app/src/Models/User.php",
+		"tests/Models/User.php":   "/ci/app/tests/Models/User.php",
+		"app/src/Models/User.php": "/ci/app/src/Models/User.php",
+		"cmd/main.go":             "github.com/acme/app/cmd/main.go",
+		"lib/Models/User.php":     "",
+	}
+	for file, want := range tests {
+		got := profile.Lines(file)
+		switch {
+		case want == "" && got != nil:
+			t.Errorf("%s: expected no match, got %v", file, got)
+		case want != "" && got == nil:
+			t.Errorf("%s: expected %s, got no match", file, want)
+		}
+	}
+	if unmatched := profile.Unmatched(); len(unmatched) != 0 {
+		t.Errorf("expected every covered file matched, got %v", unmatched)
+	}
+}
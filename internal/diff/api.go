@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// APIChanges lists the differences between two public API surfaces
+type APIChanges struct {
+	Added   []*models.APISymbol `json:"added"`
+	Removed []*models.APISymbol `json:"removed"`
+	Changed []*SignatureChange  `json:"changed"`
+}
+
+// SignatureChange is a public symbol whose declaration changed
+type SignatureChange struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Breaking reports whether any change can break callers: a removal or a changed signature
+func (c *APIChanges) Breaking() bool {
+	return len(c.Removed) > 0 || len(c.Changed) > 0
+}
+
+// LoadAPISurface reads the public API surface from a JSON report written by tukey. It
+// returns nil when the report has none (no API namespaces were configured).
+func LoadAPISurface(path string) (*models.APISurface, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var report struct {
+		APISurface *models.APISurface `json:"apiSurface"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("%s isn't a JSON report: %w", path, err)
+	}
+	return report.APISurface, nil
+}
+
+// CompareAPI diffs two API surfaces by qualified name, so moving a declaration to another
+// file or line isn't a change
+func CompareAPI(old, new *models.APISurface) *APIChanges {
+	changes := &APIChanges{
+		Added:   []*models.APISymbol{},
+		Removed: []*models.APISymbol{},
+		Changed: []*SignatureChange{},
+	}
+
+	before := make(map[string]*models.APISymbol, len(old.Symbols))
+	for _, symbol := range old.Symbols {
+		before[symbol.Name] = symbol
+	}
+	for _, symbol := range new.Symbols {
+		previous, ok := before[symbol.Name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, symbol)
+		case previous.Signature != symbol.Signature:
+			changes.Changed = append(changes.Changed, &SignatureChange{
+				Name: symbol.Name,
+				Kind: symbol.Kind,
+				Old:  previous.Signature,
+				New:  symbol.Signature,
+			})
+		}
+		delete(before, symbol.Name)
+	}
+	for _, symbol := range before {
+		changes.Removed = append(changes.Removed, symbol)
+	}
+
+	sort.Slice(changes.Added, func(i, j int) bool { return changes.Added[i].Name < changes.Added[j].Name })
+	sort.Slice(changes.Removed, func(i, j int) bool { return changes.Removed[i].Name < changes.Removed[j].Name })
+	sort.Slice(changes.Changed, func(i, j int) bool { return changes.Changed[i].Name < changes.Changed[j].Name })
+	return changes
+}
+
+// Changelog renders API changes as a Markdown changelog section, breaking changes first
+func (c *APIChanges) Changelog(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n", title)
+	if len(c.Added)+len(c.Removed)+len(c.Changed) == 0 {
+		b.WriteString("\nNo changes to the public API.\n")
+		return b.String()
+	}
+
+	if len(c.Removed) > 0 {
+		b.WriteString("\n### Removed\n\n")
+		for _, symbol := range c.Removed {
+			fmt.Fprintf(&b, "- `%s` (%s)\n", symbol.Name, symbol.Kind)
+		}
+	}
+	if len(c.Changed) > 0 {
+		b.WriteString("\n### Changed\n\n")
+		for _, change := range c.Changed {
+			fmt.Fprintf(&b, "- `%s`: `%s` → `%s`\n", change.Name, change.Old, change.New)
+		}
+	}
+	if len(c.Added) > 0 {
+		b.WriteString("\n### Added\n\n")
+		for _, symbol := range c.Added {
+			fmt.Fprintf(&b, "- `%s` (%s)\n", symbol.Name, symbol.Kind)
+		}
+	}
+	return b.String()
+}
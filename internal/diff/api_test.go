@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestCompareAPI(t *testing.T) {
+	old := &models.APISurface{Symbols: []*models.APISymbol{
+		{Name: `Acme\Invoice`, Kind: "class", Signature: "class Invoice", File: "src/Invoice.php", Line: 3},
+		{Name: `Acme\Invoice::total`, Kind: "method", Signature: "public function total(): int", File: "src/Invoice.php", Line: 5},
+		{Name: `Acme\Invoice::legacy`, Kind: "method", Signature: "public function legacy()", File: "src/Invoice.php", Line: 9},
+	}}
+	new := &models.APISurface{Symbols: []*models.APISymbol{
+		{Name: `Acme\Invoice`, Kind: "class", Signature: "class Invoice", File: "src/Billing/Invoice.php", Line: 1},
+		{Name: `Acme\Invoice::total`, Kind: "method", Signature: "public function total(float $tax): int", File: "src/Billing/Invoice.php", Line: 8},
+		{Name: `Acme\Invoice::pdf`, Kind: "method", Signature: "public function pdf(): string", File: "src/Billing/Invoice.php", Line: 12},
+	}}
+
+	changes := CompareAPI(old, new)
+	if len(changes.Added) != 1 || changes.Added[0].Name != `Acme\Invoice::pdf` {
+		t.Errorf("expected pdf added, got %+v", changes.Added)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].Name != `Acme\Invoice::legacy` {
+		t.Errorf("expected legacy removed, got %+v", changes.Removed)
+	}
+	if len(changes.Changed) != 1 || changes.Changed[0].New != "public function total(float $tax): int" {
+		t.Errorf("expected total's signature change only (moving files isn't a change), got %+v", changes.Changed)
+	}
+	if !changes.Breaking() || CompareAPI(old, old).Breaking() {
+		t.Error("expected removals and signature changes to be breaking")
+	}
+
+	changelog := changes.Changelog("API changes")
+	for _, want := range []string{
+		"## API changes",
+		"### Removed\n\n- `Acme\\Invoice::legacy` (method)",
+		"### Changed\n\n- `Acme\\Invoice::total`: `public function total(): int` → `public function total(float $tax): int`",
+		"### Added\n\n- `Acme\\Invoice::pdf` (method)",
+	} {
+		if !strings.Contains(changelog, want) {
+			t.Errorf("expected %q in changelog:\n%s", want, changelog)
+		}
+	}
+	if !strings.Contains(CompareAPI(old, old).Changelog("x"), "No changes") {
+		t.Error("expected an empty changelog to say so")
+	}
+}
+
+func TestLoadAPISurface(t *testing.T) {
+	dir := t.TempDir()
+	with := filepath.Join(dir, "with.json")
+	without := filepath.Join(dir, "without.json")
+	os.WriteFile(with, []byte(`{"graph":{"nodes":{}},"apiSurface":{"namespaces":["Acme"],"symbols":[{"name":"Acme\\Invoice","kind":"class"}]}}`), 0644)
+	os.WriteFile(without, []byte(`{"graph":{"nodes":{}}}`), 0644)
+
+	surface, err := LoadAPISurface(with)
+	if err != nil || surface == nil || len(surface.Symbols) != 1 || surface.Symbols[0].Name != `Acme\Invoice` {
+		t.Errorf("unexpected surface %+v (%v)", surface, err)
+	}
+	if surface, err := LoadAPISurface(without); surface != nil || err != nil {
+		t.Errorf("expected no surface, got %+v (%v)", surface, err)
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// DefaultRenameThreshold is the edge similarity a removed/added pair needs to count as a
+// rename when the name changed. Pure moves (same name, new file) need only half of it.
+const DefaultRenameThreshold = 0.6
+
+// Report lists the differences between two dependency graphs
+type Report struct {
+	Added      []*NodeRef    `json:"added"`
+	Removed    []*NodeRef    `json:"removed"`
+	Renamed    []*Rename     `json:"renamed"`
+	Changed    []*EdgeChange `json:"changed"`
+	EdgesAdded int           `json:"edgesAdded"`
+	EdgesGone  int           `json:"edgesRemoved"`
+	API        *APIChanges   `json:"api,omitempty"` // Set when both reports record a public API
+}
+
+// NodeRef identifies a node in one of the graphs
+type NodeRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// Rename pairs a removed node with the added node it most likely became
+type Rename struct {
+	From       *NodeRef `json:"from"`
+	To         *NodeRef `json:"to"`
+	Kind       string   `json:"kind"`       // "rename", "move", or "rename+move"
+	Similarity float64  `json:"similarity"` // Jaccard similarity of the two nodes' edges
+}
+
+// EdgeChange lists the dependencies a surviving node gained or lost
+type EdgeChange struct {
+	Node    *NodeRef `json:"node"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// Load reads a dependency graph from a JSON report written by tukey, or from a bare
+// graph exported with ExportGraph
+func Load(path string) (*models.DependencyGraph, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var report struct {
+		Graph *models.DependencyGraph `json:"graph"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("%s isn't a JSON report: %w", path, err)
+	}
+	if report.Graph != nil {
+		return report.Graph, nil
+	}
+
+	var graph models.DependencyGraph
+	if err := json.Unmarshal(data, &graph); err != nil || graph.Nodes == nil {
+		return nil, fmt.Errorf("%s doesn't contain a dependency graph", path)
+	}
+	return &graph, nil
+}
+
+// Compare diffs two graphs. Nodes are matched by ID, ignoring the line number embedded
+// in it so edits elsewhere in a file don't show up as changes. Of the rest, removed/added
+// pairs of the same type with similar edges (see DefaultRenameThreshold) are reported as
+// renames or moves instead of a removal plus an addition. Files are compared relative to
+// each graph's common directory, so reports of two checkouts line up.
+func Compare(old, new *models.DependencyGraph, threshold float64) *Report {
+	old, new = relativeFiles(old), relativeFiles(new)
+	report := &Report{
+		Added:   []*NodeRef{},
+		Removed: []*NodeRef{},
+		Renamed: []*Rename{},
+		Changed: []*EdgeChange{},
+	}
+
+	// matched maps each old node ID to its counterpart in the new graph
+	matched := make(map[string]string, len(old.Nodes))
+	byKey := make(map[string][]*models.DependencyNode)
+	for _, node := range sortedNodes(new.Nodes) {
+		byKey[stableKey(node)] = append(byKey[stableKey(node)], node)
+	}
+	claimed := make(map[string]bool, len(new.Nodes))
+	var removed []*models.DependencyNode
+	for _, node := range sortedNodes(old.Nodes) {
+		if _, ok := new.Nodes[node.ID]; ok {
+			matched[node.ID] = node.ID
+			claimed[node.ID] = true
+		}
+	}
+	for _, node := range sortedNodes(old.Nodes) {
+		if _, ok := matched[node.ID]; ok {
+			continue
+		}
+		for _, candidate := range byKey[stableKey(node)] {
+			if !claimed[candidate.ID] {
+				matched[node.ID] = candidate.ID
+				claimed[candidate.ID] = true
+				break
+			}
+		}
+		if _, ok := matched[node.ID]; !ok {
+			removed = append(removed, node)
+		}
+	}
+	var added []*models.DependencyNode
+	for _, node := range sortedNodes(new.Nodes) {
+		if !claimed[node.ID] {
+			added = append(added, node)
+		}
+	}
+
+	// Each rename can make its neighbors' edges line up, so match until nothing changes
+	oldKey := func(id, name string) string {
+		if counterpart, ok := matched[id]; ok {
+			return counterpart
+		}
+		return "name:" + name
+	}
+	newKey := func(id, name string) string {
+		if claimed[id] {
+			return id
+		}
+		return "name:" + name
+	}
+	for {
+		renames := matchRenames(unmatched(removed, matched), unclaimed(added, claimed), threshold, oldKey, newKey)
+		if len(renames) == 0 {
+			break
+		}
+		for _, rename := range renames {
+			matched[rename.From.ID] = rename.To.ID
+			claimed[rename.To.ID] = true
+		}
+		report.Renamed = append(report.Renamed, renames...)
+	}
+
+	// IDs don't include the file, so a declaration moved as-is keeps its ID
+	for oldID, newID := range matched {
+		from, to := old.Nodes[oldID], new.Nodes[newID]
+		if from.File != to.File && from.Name == to.Name {
+			report.Renamed = append(report.Renamed, &Rename{
+				From:       refOf(from),
+				To:         refOf(to),
+				Kind:       "move",
+				Similarity: jaccard(edgeKeys(from, oldKey), edgeKeys(to, newKey)),
+			})
+		}
+	}
+	sort.Slice(report.Renamed, func(i, j int) bool { return report.Renamed[i].From.ID < report.Renamed[j].From.ID })
+	for _, node := range removed {
+		if _, ok := matched[node.ID]; !ok {
+			report.Removed = append(report.Removed, refOf(node))
+		}
+	}
+	for _, node := range added {
+		if !claimed[node.ID] {
+			report.Added = append(report.Added, refOf(node))
+		}
+	}
+
+	// Compare the edges of nodes present in both graphs, following the matches so an edge
+	// to a renamed or shifted node isn't reported as removed and re-added
+	oldIDs := make([]string, 0, len(matched))
+	for id := range matched {
+		oldIDs = append(oldIDs, id)
+	}
+	sort.Strings(oldIDs)
+
+	for _, oldID := range oldIDs {
+		oldNode, newNode := old.Nodes[oldID], new.Nodes[matched[oldID]]
+
+		before := make(map[string]bool, len(oldNode.Dependencies))
+		for target := range oldNode.Dependencies {
+			if counterpart, ok := matched[target]; ok {
+				target = counterpart
+			}
+			before[target] = true
+		}
+
+		change := &EdgeChange{Node: refOf(newNode), Added: []string{}, Removed: []string{}}
+		for target := range newNode.Dependencies {
+			if !before[target] {
+				change.Added = append(change.Added, target)
+			}
+			delete(before, target)
+		}
+		for target := range before {
+			change.Removed = append(change.Removed, target)
+		}
+		if len(change.Added) == 0 && len(change.Removed) == 0 {
+			continue
+		}
+		sort.Strings(change.Added)
+		sort.Strings(change.Removed)
+		report.EdgesAdded += len(change.Added)
+		report.EdgesGone += len(change.Removed)
+		report.Changed = append(report.Changed, change)
+	}
+
+	return report
+}
+
+// matchRenames greedily pairs removed and added nodes of the same type, most similar
+// first. A changed name needs edge similarity of at least threshold; a node that kept its
+// name but moved to another file needs half that, or none if it has no edges at all.
+// oldKey and newKey name the node on the other end of an edge in a comparable way.
+func matchRenames(removed, added []*models.DependencyNode, threshold float64, oldKey, newKey edgeKeyFunc) []*Rename {
+	type candidate struct {
+		from, to   *models.DependencyNode
+		similarity float64
+	}
+
+	var candidates []candidate
+	for _, from := range removed {
+		for _, to := range added {
+			if from.Type != to.Type {
+				continue
+			}
+			similarity := jaccard(edgeKeys(from, oldKey), edgeKeys(to, newKey))
+			if from.Name == to.Name {
+				if similarity < threshold/2 && !edgeless(from, to) {
+					continue
+				}
+			} else if similarity == 0 || similarity < threshold {
+				continue
+			}
+			candidates = append(candidates, candidate{from, to, similarity})
+		}
+	}
+
+	// Most similar first; on ties prefer pairs that kept their name
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].similarity != candidates[j].similarity {
+			return candidates[i].similarity > candidates[j].similarity
+		}
+		keptI := candidates[i].from.Name == candidates[i].to.Name
+		keptJ := candidates[j].from.Name == candidates[j].to.Name
+		return keptI && !keptJ
+	})
+
+	used := make(map[string]bool)
+	renames := []*Rename{}
+	for _, c := range candidates {
+		if used[c.from.ID] || used[c.to.ID] {
+			continue
+		}
+		used[c.from.ID] = true
+		used[c.to.ID] = true
+		renames = append(renames, &Rename{
+			From:       refOf(c.from),
+			To:         refOf(c.to),
+			Kind:       renameKind(c.from, c.to),
+			Similarity: c.similarity,
+		})
+	}
+	return renames
+}
+
+// unmatched lists the removed nodes that have no counterpart yet
+func unmatched(nodes []*models.DependencyNode, matched map[string]string) []*models.DependencyNode {
+	var left []*models.DependencyNode
+	for _, node := range nodes {
+		if _, ok := matched[node.ID]; !ok {
+			left = append(left, node)
+		}
+	}
+	return left
+}
+
+// unclaimed lists the added nodes that aren't a counterpart yet
+func unclaimed(nodes []*models.DependencyNode, claimed map[string]bool) []*models.DependencyNode {
+	var left []*models.DependencyNode
+	for _, node := range nodes {
+		if !claimed[node.ID] {
+			left = append(left, node)
+		}
+	}
+	return left
+}
+
+// stableKey identifies a node across runs: its ID without the trailing line number, plus
+// its file
+func stableKey(node *models.DependencyNode) string {
+	key := node.ID
+	if i := strings.LastIndex(key, ":"); i > 0 {
+		if _, err := strconv.Atoi(key[i+1:]); err == nil {
+			key = key[:i]
+		}
+	}
+	return key + "@" + node.File
+}
+
+// edgeless reports whether neither node has any edge
+func edgeless(a, b *models.DependencyNode) bool {
+	return len(a.Dependencies)+len(a.Dependents)+len(b.Dependencies)+len(b.Dependents) == 0
+}
+
+// edgeKeyFunc names the node on the other end of an edge, given its ID and name
+type edgeKeyFunc func(id, name string) string
+
+// edgeKeys lists a node's edges as "->key" for dependencies and "<-key" for dependents,
+// leaving out self-references, which carry the node's own (changed) identity
+func edgeKeys(node *models.DependencyNode, key edgeKeyFunc) map[string]bool {
+	keys := make(map[string]bool, len(node.Dependencies)+len(node.Dependents))
+	for id, dep := range node.Dependencies {
+		if id != node.ID {
+			keys["->"+key(id, dep.TargetName)] = true
+		}
+	}
+	for id, dep := range node.Dependents {
+		if id != node.ID {
+			keys["<-"+key(id, dep.TargetName)] = true
+		}
+	}
+	return keys
+}
+
+// jaccard is the similarity of two edge sets, 0 when both are empty
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for key := range a {
+		if b[key] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// renameKind describes what changed between a removed node and its replacement
+func renameKind(from, to *models.DependencyNode) string {
+	switch {
+	case from.Name != to.Name && from.File != to.File:
+		return "rename+move"
+	case from.File != to.File:
+		return "move"
+	default:
+		return "rename"
+	}
+}
+
+// refOf summarizes a node
+func refOf(node *models.DependencyNode) *NodeRef {
+	return &NodeRef{ID: node.ID, Name: node.Name, Type: node.Type, File: node.File, Line: node.Line}
+}
+
+// relativeFiles returns a copy of graph's nodes with file paths relative to the deepest
+// directory containing all of them
+func relativeFiles(graph *models.DependencyGraph) *models.DependencyGraph {
+	root := ""
+	first := true
+	for _, node := range graph.Nodes {
+		dir := filepath.Dir(node.File)
+		if first {
+			root, first = dir, false
+			continue
+		}
+		for root != "." && root != string(filepath.Separator) && !strings.HasPrefix(dir+string(filepath.Separator), root+string(filepath.Separator)) {
+			root = filepath.Dir(root)
+		}
+	}
+
+	relative := &models.DependencyGraph{Nodes: make(map[string]*models.DependencyNode, len(graph.Nodes))}
+	for id, node := range graph.Nodes {
+		copied := *node
+		if rel, err := filepath.Rel(root, node.File); err == nil && root != "" {
+			copied.File = filepath.ToSlash(rel)
+		}
+		relative.Nodes[id] = &copied
+	}
+	return relative
+}
+
+// sortedNodes lists nodes ordered by ID so matching is deterministic
+func sortedNodes(nodes map[string]*models.DependencyNode) []*models.DependencyNode {
+	sorted := make([]*models.DependencyNode, 0, len(nodes))
+	for _, node := range nodes {
+		sorted = append(sorted, node)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// graphOf builds a graph from nodes and "fromID->toID" edges
+func graphOf(nodes []*models.DependencyNode, edges ...[2]string) *models.DependencyGraph {
+	graph := &models.DependencyGraph{Nodes: make(map[string]*models.DependencyNode)}
+	for _, node := range nodes {
+		node.Dependencies = make(map[string]*models.DependencyRef)
+		node.Dependents = make(map[string]*models.DependencyRef)
+		graph.Nodes[node.ID] = node
+	}
+	for _, edge := range edges {
+		from, to := graph.Nodes[edge[0]], graph.Nodes[edge[1]]
+		from.Dependencies[to.ID] = &models.DependencyRef{TargetID: to.ID, TargetName: to.Name, Type: "calls", Count: 1}
+		to.Dependents[from.ID] = &models.DependencyRef{TargetID: from.ID, TargetName: from.Name, Type: "calls", Count: 1}
+	}
+	return graph
+}
+
+func fn(name, file string, line int) *models.DependencyNode {
+	return &models.DependencyNode{ID: "function:" + name + ":" + itoa(line), Name: name, Type: "function", File: file, Line: line}
+}
+
+func itoa(n int) string {
+	return string(rune('0'+n/10)) + string(rune('0'+n%10))
+}
+
+func TestCompareDetectsRenameAndMove(t *testing.T) {
+	old := graphOf([]*models.DependencyNode{
+		fn("main", "app.php", 1),
+		fn("loadUser", "app.php", 10),
+		fn("query", "db.php", 1),
+		fn("logLine", "log.php", 1),
+		fn("legacy", "old.php", 1),
+	}, [2]string{"function:main:01", "function:loadUser:10"},
+		[2]string{"function:loadUser:10", "function:query:01"},
+		[2]string{"function:loadUser:10", "function:logLine:01"})
+
+	// loadUser became fetchUser, logLine moved files, legacy is gone, audit is new,
+	// and main shifted down a few lines
+	new := graphOf([]*models.DependencyNode{
+		fn("main", "app.php", 4),
+		fn("fetchUser", "app.php", 12),
+		fn("query", "db.php", 1),
+		fn("logLine", "support/log.php", 1),
+		fn("audit", "audit.php", 1),
+	}, [2]string{"function:main:04", "function:fetchUser:12"},
+		[2]string{"function:fetchUser:12", "function:query:01"},
+		[2]string{"function:fetchUser:12", "function:logLine:01"},
+		[2]string{"function:main:04", "function:audit:01"})
+
+	report := Compare(old, new, DefaultRenameThreshold)
+
+	if len(report.Renamed) != 2 {
+		for _, r := range report.Renamed {
+			t.Logf("%+v -> %+v", r.From, r.To)
+		}
+		t.Fatalf("expected 2 renames, got %d", len(report.Renamed))
+	}
+	kinds := map[string]string{}
+	for _, rename := range report.Renamed {
+		kinds[rename.From.Name+"->"+rename.To.Name] = rename.Kind
+	}
+	if kinds["loadUser->fetchUser"] != "rename" || kinds["logLine->logLine"] != "move" {
+		t.Errorf("unexpected renames: %v", kinds)
+	}
+
+	if len(report.Removed) != 1 || report.Removed[0].Name != "legacy" {
+		t.Errorf("expected only legacy removed, got %+v", report.Removed)
+	}
+	if len(report.Added) != 1 || report.Added[0].Name != "audit" {
+		t.Errorf("expected only audit added, got %+v", report.Added)
+	}
+
+	// Only main's new call to audit is an edge change: edges to the renamed
+	// fetchUser and the moved logLine follow the match
+	if len(report.Changed) != 1 || report.Changed[0].Node.Name != "main" {
+		t.Fatalf("expected only main's edges to change, got %+v", report.Changed)
+	}
+	if report.EdgesAdded != 1 || report.EdgesGone != 0 {
+		t.Errorf("expected +1/-0 edges, got +%d/-%d", report.EdgesAdded, report.EdgesGone)
+	}
+}
+
+func TestCompareKeepsDissimilarNodesSeparate(t *testing.T) {
+	old := graphOf([]*models.DependencyNode{
+		fn("a", "a.php", 1), fn("b", "a.php", 5), fn("x", "x.php", 1),
+	}, [2]string{"function:a:01", "function:x:01"})
+	new := graphOf([]*models.DependencyNode{
+		fn("a", "a.php", 1), fn("c", "c.php", 5), fn("y", "y.php", 1),
+	}, [2]string{"function:a:01", "function:y:01"})
+
+	report := Compare(old, new, DefaultRenameThreshold)
+
+	// x and y share their only edge (called by a), so they pair up; b and c have no
+	// edges and different names, so they don't
+	if len(report.Renamed) != 1 || report.Renamed[0].From.Name != "x" || report.Renamed[0].Kind != "rename+move" {
+		t.Errorf("expected x -> y rename+move, got %+v", report.Renamed)
+	}
+	if len(report.Added) != 1 || len(report.Removed) != 1 {
+		t.Errorf("expected b removed and c added, got +%v -%v", report.Added, report.Removed)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "report.json")
+	bare := filepath.Join(dir, "graph.json")
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(report, []byte(`{"graph":{"nodes":{"function:a:1":{"id":"function:a:1","name":"a"}}},"totalFiles":1}`), 0644)
+	os.WriteFile(bare, []byte(`{"nodes":{"function:a:1":{"id":"function:a:1","name":"a"}}}`), 0644)
+	os.WriteFile(bad, []byte(`{"totalFiles":1}`), 0644)
+
+	for _, path := range []string{report, bare} {
+		graph, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", path, err)
+		}
+		if graph.Nodes["function:a:1"] == nil {
+			t.Errorf("Load(%s): missing node", path)
+		}
+	}
+	if _, err := Load(bad); err == nil {
+		t.Errorf("expected an error for a file without a graph")
+	}
+}
+
+func TestCompareAcrossCheckouts(t *testing.T) {
+	old := graphOf([]*models.DependencyNode{fn("a", "/ci/base/src/a.php", 1), fn("b", "/ci/base/lib/b.php", 1)},
+		[2]string{"function:a:01", "function:b:01"})
+	new := graphOf([]*models.DependencyNode{fn("a", "/ci/head/src/a.php", 1), fn("b", "/ci/head/lib/b.php", 1)},
+		[2]string{"function:a:01", "function:b:01"})
+
+	report := Compare(old, new, DefaultRenameThreshold)
+	if len(report.Renamed)+len(report.Added)+len(report.Removed)+len(report.Changed) != 0 {
+		t.Errorf("expected identical graphs from different roots to match, got %+v", report)
+	}
+}
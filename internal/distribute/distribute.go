@@ -0,0 +1,345 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package distribute shards parsing across `tukey work` processes on other machines or
+// containers. The coordinator hands each connected worker a shard of files at a time and
+// merges the parsed files they stream back; workers read the files from their own
+// checkout of the same tree.
+//
+// The protocol is newline-delimited JSON over TCP: the worker sends a Hello, then the
+// coordinator sends an Assignment and the worker answers each shard with a Result, until
+// an Assignment says Done.
+package distribute
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// ShardSize is how many files a worker parses per shard
+const ShardSize = 200
+
+// ShardTimeout is how long a worker may take over a shard before it is given to another
+const ShardTimeout = 10 * time.Minute
+
+// TokenEnv names the environment variable holding the secret workers and the coordinator
+// must share. It may only be left unset when the coordinator listens on loopback, where
+// any worker that can connect is accepted.
+const TokenEnv = "TUKEY_WORK_TOKEN"
+
+// Shard is a batch of files, relative to the analyzed root, for one parser
+type Shard struct {
+	ID       int      `json:"id"`
+	Language string   `json:"language"`
+	Files    []string `json:"files"` // Slash-separated
+}
+
+// Hello is the first message a worker sends
+type Hello struct {
+	Version string `json:"version"`
+	Root    string `json:"root"` // The worker's checkout, to rebase the paths it reports
+	Token   string `json:"token,omitempty"`
+}
+
+// Assignment is the coordinator's answer to a Hello or a Result
+type Assignment struct {
+	Shard *Shard `json:"shard,omitempty"`
+	Done  bool   `json:"done,omitempty"`
+	Error string `json:"error,omitempty"` // The worker was refused
+}
+
+// Result is a worker's parsed files for a shard. Files that failed to parse are left
+// out, and count as parse errors on the coordinator.
+type Result struct {
+	ShardID int                  `json:"shardId"`
+	Files   []*models.ParsedFile `json:"files"`
+}
+
+// ParseFunc parses files with the parser for language
+type ParseFunc func(language string, files []models.FileInfo) ([]*models.ParsedFile, error)
+
+// Shards splits files, relative to root, into shards for the given language,
+// numbering them from first
+func Shards(root, language string, files []models.FileInfo, first int) []Shard {
+	var shards []Shard
+	for start := 0; start < len(files); start += ShardSize {
+		shard := Shard{ID: first + len(shards), Language: language}
+		for _, file := range files[start:min(start+ShardSize, len(files))] {
+			rel, err := filepath.Rel(root, file.Path)
+			if err != nil {
+				rel = file.RelativePath
+			}
+			shard.Files = append(shard.Files, filepath.ToSlash(rel))
+		}
+		shards = append(shards, shard)
+	}
+	return shards
+}
+
+// Coordinator hands out shards to the workers that connect to it
+type Coordinator struct {
+	listener net.Listener
+	root     string
+	version  string
+	token    string
+}
+
+// shardResult is a shard's parsed files, rebased onto the coordinator's root
+type shardResult struct {
+	files  int
+	parsed []*models.ParsedFile
+}
+
+// Listen starts a coordinator on addr for the tree at root; parsed files come back with
+// paths under root, as if parsed locally. Workers must run the same version, so every
+// shard is parsed the same way. Workers' results go straight into the graph, so outside
+// loopback it refuses to start without a TokenEnv secret.
+func Listen(addr, root, version string) (*Coordinator, error) {
+	token := os.Getenv(TokenEnv)
+	if token == "" && !loopback(addr) {
+		return nil, fmt.Errorf("%s would accept workers from anyone who can reach it; set %s to a secret shared with the workers", addr, TokenEnv)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Coordinator{listener: listener, root: root, version: version, token: token}, nil
+}
+
+// loopback reports whether addr listens only on this machine
+func loopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Addr returns the address workers connect to
+func (c *Coordinator) Addr() string {
+	return c.listener.Addr().String()
+}
+
+// Close stops accepting workers
+func (c *Coordinator) Close() error {
+	return c.listener.Close()
+}
+
+// Run hands out shards until every one has been parsed, and returns the parsed files.
+// A shard whose worker disconnects, fails, or passes ShardTimeout goes back in the
+// queue for the next worker. Run waits for workers as long as shards remain.
+func (c *Coordinator) Run(shards []Shard, progressBar *progress.ProgressBar) []*models.ParsedFile {
+	queue := make(chan Shard, len(shards))
+	for _, shard := range shards {
+		queue <- shard
+	}
+	results := make(chan shardResult)
+	done := make(chan struct{})
+
+	// Wait for every worker to be told it's done before returning
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		for {
+			conn, err := c.listener.Accept()
+			if err != nil {
+				return
+			}
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				c.serve(conn, queue, results, done)
+			}()
+		}
+	}()
+
+	var parsed []*models.ParsedFile
+	for remaining := len(shards); remaining > 0; remaining-- {
+		result := <-results
+		parsed = append(parsed, result.parsed...)
+		progressBar.Update(result.files)
+	}
+	close(done)
+	c.listener.Close()
+	workers.Wait()
+	progressBar.Finish()
+	return parsed
+}
+
+// serve hands shards to one worker until there are none left or the worker goes away
+func (c *Coordinator) serve(conn net.Conn, queue chan Shard, results chan<- shardResult, done <-chan struct{}) {
+	defer conn.Close()
+	decoder, encoder := json.NewDecoder(conn), json.NewEncoder(conn)
+
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	var hello Hello
+	if err := decoder.Decode(&hello); err != nil {
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(hello.Token), []byte(c.token)) != 1 {
+		encoder.Encode(Assignment{Error: "invalid token; set " + TokenEnv + " to the coordinator's"})
+		return
+	}
+	if hello.Version != c.version {
+		encoder.Encode(Assignment{Error: fmt.Sprintf("version %s doesn't match the coordinator's %s", hello.Version, c.version)})
+		return
+	}
+
+	for {
+		var shard Shard
+		select {
+		case shard = <-queue:
+		case <-done:
+			encoder.Encode(Assignment{Done: true})
+			return
+		}
+
+		conn.SetReadDeadline(time.Now().Add(ShardTimeout))
+		var result Result
+		if err := encoder.Encode(Assignment{Shard: &shard}); err != nil {
+			queue <- shard
+			return
+		}
+		if err := decoder.Decode(&result); err != nil || result.ShardID != shard.ID {
+			queue <- shard
+			return
+		}
+		for _, parsed := range result.Files {
+			rebase(parsed, hello.Root, c.root)
+		}
+		if err := c.check(shard, result.Files); err != nil {
+			queue <- shard
+			encoder.Encode(Assignment{Error: err.Error()})
+			return
+		}
+		select {
+		case results <- shardResult{files: len(shard.Files), parsed: result.Files}:
+		case <-done:
+			return
+		}
+	}
+}
+
+// check makes sure a result only holds files of its shard, each at most once, so a
+// worker can't add files to the graph that weren't asked for
+func (c *Coordinator) check(shard Shard, files []*models.ParsedFile) error {
+	if len(files) > len(shard.Files) {
+		return fmt.Errorf("shard %d has %d files, the result %d", shard.ID, len(shard.Files), len(files))
+	}
+	pending := make(map[string]bool, len(shard.Files))
+	for _, rel := range shard.Files {
+		pending[filepath.Join(c.root, filepath.FromSlash(rel))] = true
+	}
+	for _, parsed := range files {
+		if parsed == nil {
+			return fmt.Errorf("shard %d's result has an empty file", shard.ID)
+		}
+		if !pending[parsed.Path] {
+			return fmt.Errorf("shard %d doesn't include %s, or the result has it twice", shard.ID, parsed.Path)
+		}
+		delete(pending, parsed.Path) // A second copy is refused too
+	}
+	return nil
+}
+
+// rebase moves the paths in a parsed file from one root to another
+func rebase(parsed *models.ParsedFile, from, to string) {
+	move := func(path string) string {
+		if path == "" {
+			return path
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return path // Outside the tree, e.g. an absolute include
+		}
+		return filepath.Join(to, rel)
+	}
+	parsed.Path = move(parsed.Path)
+	for i := range parsed.Elements {
+		parsed.Elements[i].File = move(parsed.Elements[i].File)
+	}
+	for i := range parsed.Imports {
+		parsed.Imports[i].Resolved = move(parsed.Imports[i].Resolved)
+	}
+	for i := range parsed.Exports {
+		parsed.Exports[i].Resolved = move(parsed.Exports[i].Resolved)
+	}
+}
+
+// Work connects to the coordinator at addr and parses the shards it hands out, reading
+// the files under root, until the coordinator has none left. It retries the connection
+// for up to wait, so workers can start before the coordinator, and returns how many
+// shards it parsed.
+func Work(addr, root, version string, wait time.Duration, parse ParseFunc) (int, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return 0, err
+	}
+
+	deadline := time.Now().Add(wait)
+	var conn net.Conn
+	for {
+		if conn, err = net.DialTimeout("tcp", addr, 10*time.Second); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("can't connect to the coordinator at %s: %w", addr, err)
+		}
+		time.Sleep(time.Second)
+	}
+	defer conn.Close()
+
+	decoder, encoder := json.NewDecoder(conn), json.NewEncoder(conn)
+	if err := encoder.Encode(Hello{Version: version, Root: abs, Token: os.Getenv(TokenEnv)}); err != nil {
+		return 0, err
+	}
+
+	parsedShards := 0
+	for {
+		var assignment Assignment
+		if err := decoder.Decode(&assignment); err != nil {
+			return parsedShards, fmt.Errorf("lost the coordinator: %w", err)
+		}
+		switch {
+		case assignment.Error != "":
+			return parsedShards, errors.New("the coordinator refused this worker: " + assignment.Error)
+		case assignment.Done || assignment.Shard == nil:
+			return parsedShards, nil
+		}
+
+		shard := assignment.Shard
+		files := make([]models.FileInfo, 0, len(shard.Files))
+		for _, rel := range shard.Files {
+			path := filepath.Join(abs, filepath.FromSlash(rel))
+			info := models.FileInfo{Path: path, RelativePath: filepath.FromSlash(rel)}
+			if stat, err := os.Stat(path); err == nil {
+				info.Size = stat.Size()
+			}
+			files = append(files, info)
+		}
+		parsed, err := parse(shard.Language, files)
+		if err != nil {
+			return parsedShards, err
+		}
+		if err := encoder.Encode(Result{ShardID: shard.ID, Files: parsed}); err != nil {
+			return parsedShards, fmt.Errorf("lost the coordinator: %w", err)
+		}
+		parsedShards++
+	}
+}
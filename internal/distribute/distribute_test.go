@@ -0,0 +1,166 @@
+package distribute
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+func fakeFiles(root string, n int) []models.FileInfo {
+	files := make([]models.FileInfo, n)
+	for i := range files {
+		rel := filepath.Join("src", fmt.Sprintf("f%03d.php", i))
+		files[i] = models.FileInfo{Path: filepath.Join(root, rel), RelativePath: rel}
+	}
+	return files
+}
+
+func TestShards(t *testing.T) {
+	shards := Shards("/repo", "php", fakeFiles("/repo", ShardSize+1), 3)
+	if len(shards) != 2 || shards[0].ID != 3 || shards[1].ID != 4 {
+		t.Fatalf("unexpected shards %+v", shards)
+	}
+	if len(shards[1].Files) != 1 || shards[1].Files[0] != "src/f200.php" {
+		t.Errorf("unexpected last shard %+v", shards[1])
+	}
+}
+
+func TestCoordinatorAndWorkers(t *testing.T) {
+	coordinator, err := Listen("127.0.0.1:0", "repo", "1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer coordinator.Close()
+	files := fakeFiles("repo", 2*ShardSize+10)
+	shards := Shards("repo", "php", files, 0)
+
+	parse := func(language string, files []models.FileInfo) ([]*models.ParsedFile, error) {
+		var parsed []*models.ParsedFile
+		for _, file := range files {
+			parsed = append(parsed, &models.ParsedFile{
+				Path:     file.Path,
+				Language: language,
+				Elements: []models.CodeElement{{Type: "class", Name: "C", File: file.Path}},
+			})
+		}
+		return parsed, nil
+	}
+	// The first worker fails on its first shard, which goes to the others
+	failing := func(string, []models.FileInfo) ([]*models.ParsedFile, error) {
+		return nil, errors.New("out of memory")
+	}
+
+	errs := make(chan error, 3)
+	worker := func(root, version string, parse ParseFunc) {
+		_, err := Work(coordinator.Addr(), root, version, time.Second, parse)
+		errs <- err
+	}
+	go worker(t.TempDir(), "1.0.0", failing)
+	time.Sleep(50 * time.Millisecond)
+	go worker(t.TempDir(), "0.9.0", parse)
+	go worker(filepath.Join(t.TempDir(), "checkout"), "1.0.0", parse)
+
+	parsed := coordinator.Run(shards, progress.NewProgressBar(len(files), "Parsing"))
+	if len(parsed) != len(files) {
+		t.Fatalf("expected %d parsed files, got %d", len(files), len(parsed))
+	}
+	paths := make([]string, len(parsed))
+	for i, file := range parsed {
+		paths[i] = file.Path
+		if file.Elements[0].File != file.Path {
+			t.Errorf("expected element paths rebased too, got %s", file.Elements[0].File)
+		}
+	}
+	sort.Strings(paths)
+	if paths[0] != filepath.Join("repo", "src", "f000.php") {
+		t.Errorf("expected paths under the coordinator's root, got %s", paths[0])
+	}
+
+	var messages []string
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	sort.Strings(messages)
+	if len(messages) != 2 || messages[0] != "out of memory" || !strings.Contains(messages[1], "version 0.9.0") {
+		t.Errorf("expected the failing and mismatched workers to stop with errors, got %v", messages)
+	}
+}
+
+func TestListenNeedsTokenOffLoopback(t *testing.T) {
+	t.Setenv(TokenEnv, "")
+	if _, err := Listen(":0", "repo", "1.0.0"); err == nil || !strings.Contains(err.Error(), TokenEnv) {
+		t.Fatalf("expected a network address without a token to be refused, got %v", err)
+	}
+
+	t.Setenv(TokenEnv, "secret")
+	coordinator, err := Listen(":0", "repo", "1.0.0")
+	if err != nil {
+		t.Fatalf("expected a token to allow any address, got %v", err)
+	}
+	coordinator.Close()
+}
+
+func TestCoordinatorRejectsFilesOutsideShard(t *testing.T) {
+	coordinator, err := Listen("127.0.0.1:0", "repo", "1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer coordinator.Close()
+	files := fakeFiles("repo", 3)
+	shards := Shards("repo", "php", files, 0)
+
+	parse := func(language string, files []models.FileInfo) ([]*models.ParsedFile, error) {
+		var parsed []*models.ParsedFile
+		for _, file := range files {
+			parsed = append(parsed, &models.ParsedFile{Path: file.Path, Language: language})
+		}
+		return parsed, nil
+	}
+	extra := func(language string, files []models.FileInfo) ([]*models.ParsedFile, error) {
+		parsed, _ := parse(language, files)
+		return append(parsed, &models.ParsedFile{Path: "/etc/injected.php", Language: language}), nil
+	}
+	twice := func(language string, files []models.FileInfo) ([]*models.ParsedFile, error) {
+		parsed, _ := parse(language, files[:1])
+		return append(parsed, parsed[0], parsed[0]), nil
+	}
+
+	errs := make(chan error, 3)
+	worker := func(parse ParseFunc) {
+		_, err := Work(coordinator.Addr(), t.TempDir(), "1.0.0", time.Second, parse)
+		errs <- err
+	}
+	go worker(extra)
+	go worker(twice)
+	time.Sleep(100 * time.Millisecond)
+	go worker(parse)
+
+	parsed := coordinator.Run(shards, progress.NewProgressBar(len(files), "Parsing"))
+	if len(parsed) != len(files) {
+		t.Fatalf("expected only the %d files of the shard, got %d", len(files), len(parsed))
+	}
+	for _, file := range parsed {
+		if file.Path == "/etc/injected.php" {
+			t.Errorf("expected a file outside the shard to be dropped")
+		}
+	}
+
+	refused := 0
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil && strings.Contains(err.Error(), "refused") {
+			refused++
+		}
+	}
+	if refused != 2 {
+		t.Errorf("expected both misbehaving workers to be refused, got %d", refused)
+	}
+}
@@ -0,0 +1,635 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package graphql executes read-only GraphQL queries against a schema of resolver
+// functions. It implements the query language (fields, aliases, arguments, variables,
+// fragments, and @include/@skip) but no mutations, subscriptions, or introspection.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Default limits on the queries a schema runs; see Schema
+const (
+	DefaultMaxDepth  = 12
+	DefaultMaxFields = 100000
+)
+
+// Schema is the set of object types a query can select from, starting at Query.
+// MaxDepth caps how deeply a query nests fields and MaxFields how many fields it
+// resolves in all, since object types that refer to each other let a small query ask
+// for exponentially many; 0 means no limit.
+type Schema struct {
+	Query     string
+	Types     map[string]*Object
+	SDL       string // The schema in GraphQL's schema language, served for documentation
+	MaxDepth  int
+	MaxFields int
+}
+
+// Object is an object type
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDef
+}
+
+// FieldDef defines a field of an object type. Type is a type reference such as
+// "[Node!]!" naming a scalar (Int, Float, String, Boolean, ID, or any other name not in
+// the schema, returned as the resolver's JSON) or an object type.
+type FieldDef struct {
+	Type    string
+	Args    map[string]string // Argument name → type reference
+	Resolve func(p ResolveParams) (interface{}, error)
+}
+
+// ResolveParams is what a resolver gets: the parent object's value and the field's
+// coerced arguments (absent when not given)
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is absent when the request failed before
+// execution started.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is a request or field error
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// NewSchema builds a schema from its object types; query names the root type
+func NewSchema(query string, sdl string, types ...*Object) (*Schema, error) {
+	schema := &Schema{Query: query, Types: make(map[string]*Object), SDL: sdl, MaxDepth: DefaultMaxDepth, MaxFields: DefaultMaxFields}
+	for _, t := range types {
+		schema.Types[t.Name] = t
+	}
+	if _, ok := schema.Types[query]; !ok {
+		return nil, fmt.Errorf("query type %q isn't defined", query)
+	}
+	for _, t := range types {
+		for name, field := range t.Fields {
+			if field.Resolve == nil {
+				return nil, fmt.Errorf("%s.%s has no resolver", t.Name, name)
+			}
+		}
+	}
+	return schema, nil
+}
+
+// Execute runs a query request against the schema
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return failed(err)
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return failed(err)
+	}
+	if op.Type != "query" {
+		return failed(fmt.Errorf("%ss aren't supported; the API is read-only", op.Type))
+	}
+	depth, err := doc.depth(op.SelectionSet, make(map[string]int))
+	if err != nil {
+		return failed(err)
+	}
+	if s.MaxDepth > 0 && depth > s.MaxDepth {
+		return failed(fmt.Errorf("query nests fields %d deep; the limit is %d", depth, s.MaxDepth))
+	}
+	variables, err := s.coerceVariables(op, req.Variables)
+	if err != nil {
+		return failed(err)
+	}
+
+	e := &executor{ctx: ctx, schema: s, doc: doc, variables: variables}
+	data, _ := e.executeFields(s.Types[s.Query], nil, op.SelectionSet, nil)
+	if e.stopped != nil {
+		return failed(e.stopped)
+	}
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func failed(err error) *Response {
+	return &Response{Errors: []*Error{{Message: err.Error()}}}
+}
+
+// depth returns how deeply a selection set nests fields, with fragments expanded. memo
+// holds the depth of each fragment seen so far, or -1 while it's being expanded, so a
+// fragment that spreads itself is caught rather than followed forever.
+func (d *Document) depth(selections []Selection, memo map[string]int) (int, error) {
+	deepest := 0
+	for _, selection := range selections {
+		var depth int
+		var err error
+		switch sel := selection.(type) {
+		case *Field:
+			depth, err = d.depth(sel.SelectionSet, memo)
+			depth++
+		case *InlineFragment:
+			depth, err = d.depth(sel.SelectionSet, memo)
+		case *FragmentSpread:
+			fragment, ok := d.Fragments[sel.Name]
+			if !ok {
+				continue // Reported when the fields are collected
+			}
+			known, seen := memo[sel.Name]
+			switch {
+			case seen && known == -1:
+				return 0, fmt.Errorf("fragment %q spreads itself", sel.Name)
+			case seen:
+				depth = known
+			default:
+				memo[sel.Name] = -1
+				depth, err = d.depth(fragment.SelectionSet, memo)
+				memo[sel.Name] = depth
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		if depth > deepest {
+			deepest = depth
+		}
+	}
+	return deepest, nil
+}
+
+// operation picks the operation to run: the named one, or the only one
+func (d *Document) operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) > 1 {
+			return nil, fmt.Errorf("the document has several operations; set operationName")
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func (s *Schema) coerceVariables(op *Operation, values map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+	for _, def := range op.Variables {
+		value, given := values[def.Name]
+		if !given && def.Default != nil {
+			literal, err := literalValue(def.Default, nil)
+			if err != nil {
+				return nil, err
+			}
+			value, given = literal, true
+		}
+		if !given {
+			if strings.HasSuffix(def.Type, "!") {
+				return nil, fmt.Errorf("variable $%s of type %s is required", def.Name, def.Type)
+			}
+			continue
+		}
+		coerced, err := coerce(value, def.Type)
+		if err != nil {
+			return nil, fmt.Errorf("variable $%s: %w", def.Name, err)
+		}
+		variables[def.Name] = coerced
+	}
+	return variables, nil
+}
+
+// executor runs one operation, collecting field errors
+type executor struct {
+	ctx       context.Context
+	schema    *Schema
+	doc       *Document
+	variables map[string]interface{}
+	errors    []*Error
+	resolved  int   // Fields resolved so far, against Schema.MaxFields
+	stopped   error // Why execution ended early: the context ended or a limit was hit
+}
+
+// proceed counts a field about to be resolved, and reports whether execution may go on
+func (e *executor) proceed() bool {
+	if e.stopped != nil {
+		return false
+	}
+	if err := e.ctx.Err(); err != nil {
+		e.stopped = fmt.Errorf("query stopped: %w", err)
+		return false
+	}
+	e.resolved++
+	if e.schema.MaxFields > 0 && e.resolved > e.schema.MaxFields {
+		e.stopped = fmt.Errorf("query resolves more than %d fields", e.schema.MaxFields)
+		return false
+	}
+	return true
+}
+
+func (e *executor) addError(path []interface{}, format string, args ...interface{}) {
+	e.errors = append(e.errors, &Error{Message: fmt.Sprintf(format, args...), Path: append([]interface{}(nil), path...)})
+}
+
+// fieldGroup is the fields selected under one response key, merged
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+// collectFields flattens fragments and applies @include/@skip, grouping fields by
+// response key in selection order
+func (e *executor) collectFields(typeName string, selections []Selection, groups []*fieldGroup, visited map[string]bool) ([]*fieldGroup, error) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *Field:
+			include, err := e.included(sel.Directives)
+			if err != nil || !include {
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			key := sel.ResponseKey()
+			found := false
+			for _, group := range groups {
+				if group.key == key {
+					if group.fields[0].Name != sel.Name {
+						return nil, fmt.Errorf("%q selects both %s and %s", key, group.fields[0].Name, sel.Name)
+					}
+					group.fields = append(group.fields, sel)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*Field{sel}})
+			}
+
+		case *FragmentSpread:
+			include, err := e.included(sel.Directives)
+			if err != nil {
+				return nil, err
+			}
+			if !include || visited[sel.Name] {
+				continue
+			}
+			fragment, ok := e.doc.Fragments[sel.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			if fragment.On != typeName {
+				continue
+			}
+			visited[sel.Name] = true
+			if groups, err = e.collectFields(typeName, fragment.SelectionSet, groups, visited); err != nil {
+				return nil, err
+			}
+
+		case *InlineFragment:
+			include, err := e.included(sel.Directives)
+			if err != nil {
+				return nil, err
+			}
+			if !include || (sel.On != "" && sel.On != typeName) {
+				continue
+			}
+			if groups, err = e.collectFields(typeName, sel.SelectionSet, groups, visited); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return groups, nil
+}
+
+// included evaluates @skip(if:) and @include(if:)
+func (e *executor) included(directives []*Directive) (bool, error) {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			return false, fmt.Errorf("unknown directive @%s", directive.Name)
+		}
+		value, err := literalValue(directive.Arguments["if"], e.variables)
+		if err != nil {
+			return false, err
+		}
+		condition, ok := value.(bool)
+		if !ok {
+			return false, fmt.Errorf("@%s needs a Boolean if argument", directive.Name)
+		}
+		if condition == (directive.Name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// executeFields resolves a selection set on an object. It returns false when a non-null
+// field came back null, so the object itself must be null.
+func (e *executor) executeFields(object *Object, source interface{}, selections []Selection, path []interface{}) (*orderedMap, bool) {
+	groups, err := e.collectFields(object.Name, selections, nil, make(map[string]bool))
+	if err != nil {
+		e.addError(path, "%v", err)
+		return nil, false
+	}
+
+	result := &orderedMap{}
+	for _, group := range groups {
+		field := group.fields[0]
+		fieldPath := append(append([]interface{}(nil), path...), group.key)
+		if field.Name == "__typename" {
+			result.set(group.key, object.Name)
+			continue
+		}
+		def, ok := object.Fields[field.Name]
+		if !ok {
+			e.addError(fieldPath, "cannot query field %q on type %s", field.Name, object.Name)
+			return nil, false
+		}
+		if !e.proceed() {
+			return nil, false
+		}
+
+		args, err := e.coerceArguments(def, field)
+		var value interface{}
+		if err == nil {
+			value, err = def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+		}
+		if err != nil {
+			e.addError(fieldPath, "%v", err)
+			if strings.HasSuffix(def.Type, "!") {
+				return nil, false
+			}
+			result.set(group.key, nil)
+			continue
+		}
+
+		completed, ok := e.complete(def.Type, group.fields, value, fieldPath)
+		if !ok {
+			return nil, false
+		}
+		result.set(group.key, completed)
+	}
+	return result, true
+}
+
+// complete shapes a resolved value to its type. It returns false when a null has to
+// propagate to the parent because the type is non-null.
+func (e *executor) complete(typeRef string, fields []*Field, value interface{}, path []interface{}) (interface{}, bool) {
+	if inner, nonNull := strings.CutSuffix(typeRef, "!"); nonNull {
+		result, ok := e.completeNullable(inner, fields, value, path)
+		if !ok {
+			return nil, false
+		}
+		if result == nil {
+			e.addError(path, "cannot return null for non-null field %q", fields[0].Name)
+			return nil, false
+		}
+		return result, true
+	}
+	result, ok := e.completeNullable(typeRef, fields, value, path)
+	if !ok {
+		return nil, true
+	}
+	return result, true
+}
+
+func (e *executor) completeNullable(typeRef string, fields []*Field, value interface{}, path []interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	// Typed nil slices are empty lists, since Go resolvers build lists with append
+	if strings.HasPrefix(typeRef, "[") {
+		itemType := typeRef[1 : len(typeRef)-1]
+		list := reflect.ValueOf(value)
+		if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+			e.addError(path, "expected a list for field %q", fields[0].Name)
+			return nil, false
+		}
+		items := make([]interface{}, list.Len())
+		for i := range items {
+			item, ok := e.complete(itemType, fields, list.Index(i).Interface(), append(path, i))
+			if !ok {
+				return nil, false
+			}
+			items[i] = item
+		}
+		return items, true
+	}
+	if isNil(value) {
+		return nil, true
+	}
+
+	var selections []Selection
+	for _, field := range fields {
+		selections = append(selections, field.SelectionSet...)
+	}
+	object, isObject := e.schema.Types[typeRef]
+	if !isObject {
+		if len(selections) > 0 {
+			e.addError(path, "field %q is a %s and can't have a selection", fields[0].Name, typeRef)
+			return nil, false
+		}
+		return value, true
+	}
+	if len(selections) == 0 {
+		e.addError(path, "field %q of type %s needs a selection of subfields", fields[0].Name, typeRef)
+		return nil, false
+	}
+	result, ok := e.executeFields(object, value, selections, path)
+	if !ok {
+		return nil, false
+	}
+	return result, true
+}
+
+func (e *executor) coerceArguments(def *FieldDef, field *Field) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	for name, literal := range field.Arguments {
+		typeRef, ok := def.Args[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q on field %q", name, field.Name)
+		}
+		if variable, isVariable := literal.(Variable); isVariable {
+			if _, set := e.variables[string(variable)]; !set {
+				continue // Omitted optional variables leave the argument unset
+			}
+		}
+		value, err := literalValue(literal, e.variables)
+		if err != nil {
+			return nil, err
+		}
+		if args[name], err = coerce(value, typeRef); err != nil {
+			return nil, fmt.Errorf("argument %q: %w", name, err)
+		}
+	}
+	for name, typeRef := range def.Args {
+		if _, given := args[name]; !given && strings.HasSuffix(typeRef, "!") {
+			return nil, fmt.Errorf("argument %q of type %s is required on field %q", name, typeRef, field.Name)
+		}
+	}
+	return args, nil
+}
+
+// literalValue replaces variables in a literal with their values
+func literalValue(literal Value, variables map[string]interface{}) (interface{}, error) {
+	switch v := literal.(type) {
+	case Variable:
+		value, ok := variables[string(v)]
+		if !ok && variables == nil {
+			return nil, fmt.Errorf("variables aren't allowed here")
+		}
+		return value, nil
+	case Enum:
+		return string(v), nil
+	case []Value:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			value, err := literalValue(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case map[string]Value:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			value, err := literalValue(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		return object, nil
+	}
+	return literal, nil
+}
+
+// coerce converts an input value (a literal or decoded JSON) to a type: ints for Int,
+// float64s for Float, and []interface{} for lists
+func coerce(value interface{}, typeRef string) (interface{}, error) {
+	inner, nonNull := strings.CutSuffix(typeRef, "!")
+	if value == nil {
+		if nonNull {
+			return nil, fmt.Errorf("expected a non-null %s", inner)
+		}
+		return nil, nil
+	}
+
+	if strings.HasPrefix(inner, "[") {
+		itemType := inner[1 : len(inner)-1]
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value} // A single value is a list of one
+		}
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			coerced, err := coerce(item, itemType)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = coerced
+		}
+		return list, nil
+	}
+
+	switch inner {
+	case "Int":
+		switch n := value.(type) {
+		case int:
+			return n, nil
+		case float64:
+			if n == math.Trunc(n) && math.Abs(n) <= math.MaxInt32 {
+				return int(n), nil
+			}
+		}
+		return nil, fmt.Errorf("expected an Int, got %v", value)
+	case "Float":
+		switch n := value.(type) {
+		case int:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+		return nil, fmt.Errorf("expected a Float, got %v", value)
+	case "String", "ID":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		if n, ok := value.(int); ok && inner == "ID" {
+			return fmt.Sprint(n), nil
+		}
+		return nil, fmt.Errorf("expected a %s, got %v", inner, value)
+	case "Boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected a Boolean, got %v", value)
+	}
+	return value, nil
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
+
+// orderedMap is a JSON object that keeps its keys in selection order, as GraphQL
+// responses must
+type orderedMap struct {
+	keys   []string
+	values []interface{}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	m.keys = append(m.keys, key)
+	m.values = append(m.values, value)
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,259 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testBook struct {
+	Title  string
+	Pages  int
+	Author *testAuthor
+}
+
+type testAuthor struct {
+	Name string
+}
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+	books := []*testBook{
+		{Title: "Dune", Pages: 412, Author: &testAuthor{Name: "Herbert"}},
+		{Title: "Emma", Pages: 474},
+		{Title: "Ulysses", Pages: 730, Author: &testAuthor{Name: "Joyce"}},
+	}
+	query := &Object{Name: "Query", Fields: map[string]*FieldDef{
+		"books": {
+			Type: "[Book!]!",
+			Args: map[string]string{"minPages": "Int", "titles": "[String!]"},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				var result []*testBook
+				for _, book := range books {
+					if min, ok := p.Args["minPages"].(int); ok && book.Pages < min {
+						continue
+					}
+					if titles, ok := p.Args["titles"].([]interface{}); ok {
+						found := false
+						for _, title := range titles {
+							found = found || title == book.Title
+						}
+						if !found {
+							continue
+						}
+					}
+					result = append(result, book)
+				}
+				return result, nil
+			},
+		},
+		"book": {
+			Type: "Book",
+			Args: map[string]string{"title": "String!"},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				for _, book := range books {
+					if book.Title == p.Args["title"] {
+						return book, nil
+					}
+				}
+				return (*testBook)(nil), nil
+			},
+		},
+		"broken": {
+			Type:    "String",
+			Resolve: func(p ResolveParams) (interface{}, error) { return nil, errors.New("boom") },
+		},
+	}}
+	book := &Object{Name: "Book", Fields: map[string]*FieldDef{
+		"title":  {Type: "String!", Resolve: func(p ResolveParams) (interface{}, error) { return p.Source.(*testBook).Title, nil }},
+		"pages":  {Type: "Int!", Resolve: func(p ResolveParams) (interface{}, error) { return p.Source.(*testBook).Pages, nil }},
+		"author": {Type: "Author", Resolve: func(p ResolveParams) (interface{}, error) { return p.Source.(*testBook).Author, nil }},
+		"strictAuthor": {Type: "Author!", Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*testBook).Author, nil
+		}},
+	}}
+	author := &Object{Name: "Author", Fields: map[string]*FieldDef{
+		"name": {Type: "String!", Resolve: func(p ResolveParams) (interface{}, error) { return p.Source.(*testAuthor).Name, nil }},
+	}}
+
+	schema, err := NewSchema("Query", "", query, book, author)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func run(t *testing.T, schema *Schema, req Request) string {
+	t.Helper()
+	data, err := json.Marshal(schema.Execute(context.Background(), req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	schema := testSchema(t)
+
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{
+			name: "fields keep selection order",
+			req:  Request{Query: `{ books(minPages: 450) { pages title } }`},
+			want: `{"data":{"books":[{"pages":474,"title":"Emma"},{"pages":730,"title":"Ulysses"}]}}`,
+		},
+		{
+			name: "aliases and nested objects",
+			req:  Request{Query: `{ first: book(title: "Dune") { author { name } } missing: book(title: "Nope") { title } }`},
+			want: `{"data":{"first":{"author":{"name":"Herbert"}},"missing":null}}`,
+		},
+		{
+			name: "variables with defaults and list coercion",
+			req: Request{
+				Query:     `query Long($min: Int = 700, $titles: [String!]) { books(minPages: $min, titles: $titles) { title } }`,
+				Variables: map[string]interface{}{"titles": "Ulysses"},
+			},
+			want: `{"data":{"books":[{"title":"Ulysses"}]}}`,
+		},
+		{
+			name: "fragments, inline fragments, directives, and __typename",
+			req: Request{
+				Query: `query($withPages: Boolean!) {
+					book(title: "Emma") { ...Basics ... on Book { pages @include(if: $withPages) } __typename }
+				}
+				fragment Basics on Book { title author { name } }`,
+				Variables: map[string]interface{}{"withPages": false},
+			},
+			want: `{"data":{"book":{"title":"Emma","author":null,"__typename":"Book"}}}`,
+		},
+		{
+			name: "resolver errors null the field",
+			req:  Request{Query: `{ broken books(minPages: 700) { title } }`},
+			want: `{"data":{"broken":null,"books":[{"title":"Ulysses"}]},"errors":[{"message":"boom","path":["broken"]}]}`,
+		},
+		{
+			name: "non-null nulls propagate to the nearest nullable parent",
+			req:  Request{Query: `{ book(title: "Emma") { title strictAuthor { name } } }`},
+			want: `{"data":{"book":null},"errors":[{"message":"cannot return null for non-null field \"strictAuthor\"","path":["book","strictAuthor"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		if got := run(t, schema, tt.req); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExecuteRejects(t *testing.T) {
+	schema := testSchema(t)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ books { title `, "syntax error"},
+		{`mutation { books { title } }`, "read-only"},
+		{`{ books { nope } }`, `cannot query field "nope"`},
+		{`{ books { title(x: 1) } }`, `unknown argument "x"`},
+		{`{ book { title } }`, `argument "title" of type String! is required`},
+		{`{ books(minPages: "many") { title } }`, "expected an Int"},
+		{`{ books }`, "needs a selection"},
+		{`{ books { title { x } } }`, "can't have a selection"},
+		{`query A { books { title } } query B { books { pages } }`, "set operationName"},
+		{`query($min: Int!) { books(minPages: $min) { title } }`, "$min of type Int! is required"},
+		{`{ books { ...Missing } }`, `unknown fragment "Missing"`},
+	}
+	for _, tt := range tests {
+		resp := schema.Execute(context.Background(), Request{Query: tt.query})
+		if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %+v", tt.query, tt.want, resp.Errors)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# Comments and commas are ignored
+		query Q($a: [Int!]! = [1, 2], $b: String) {
+			x: field(s: "tab\t\u00e9", block: """
+				indented
+				  more
+			""", n: -1.5e2, e: ASC, o: {k: null}) @skip(if: false)
+		}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	op := doc.Operations[0]
+	if op.Name != "Q" || len(op.Variables) != 2 || op.Variables[0].Type != "[Int!]!" {
+		t.Fatalf("unexpected operation: %+v", op)
+	}
+	field := op.SelectionSet[0].(*Field)
+	if field.Alias != "x" || field.Name != "field" || len(field.Directives) != 1 {
+		t.Errorf("unexpected field: %+v", field)
+	}
+	if s := field.Arguments["s"]; s != "tab\té" {
+		t.Errorf("unexpected string %q", s)
+	}
+	if block := field.Arguments["block"]; block != "indented\n  more" {
+		t.Errorf("unexpected block string %q", block)
+	}
+	if n := field.Arguments["n"]; n != -150.0 {
+		t.Errorf("unexpected number %v", n)
+	}
+	if e := field.Arguments["e"]; e != Enum("ASC") {
+		t.Errorf("unexpected enum %v", e)
+	}
+
+	if _, err := Parse(`{ a(x: "unterminated) }`); err == nil || !strings.Contains(err.Error(), "1:8") {
+		t.Errorf("expected a positioned syntax error, got %v", err)
+	}
+}
+
+func TestExecuteNilSliceIsEmptyList(t *testing.T) {
+	schema := testSchema(t)
+	got := run(t, schema, Request{Query: `{ books(minPages: 1000) { title } }`})
+	if want := `{"data":{"books":[]}}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	schema := testSchema(t)
+	schema.MaxDepth, schema.MaxFields = 2, 5
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ books { author { name } } }`, "nests fields 3 deep; the limit is 2"},
+		{`{ books { ...Author } } fragment Author on Book { author { name } }`, "nests fields 3 deep"},
+		{`{ books { ...Loop } } fragment Loop on Book { title ...Loop }`, `fragment "Loop" spreads itself`},
+		{`{ books { title pages } }`, "resolves more than 5 fields"},
+	}
+	for _, tt := range tests {
+		resp := schema.Execute(context.Background(), Request{Query: tt.query})
+		if resp.Data != nil || len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, tt.want) {
+			t.Errorf("%s: expected only an error containing %q, got %+v", tt.query, tt.want, resp)
+		}
+	}
+
+	if got, want := run(t, schema, Request{Query: `{ books { title } }`}), `{"data":{"books":[{"title":"Dune"},{"title":"Emma"},{"title":"Ulysses"}]}}`; got != want {
+		t.Errorf("expected a query within the limits to run, got %s", got)
+	}
+}
+
+func TestExecuteStopsWhenContextEnds(t *testing.T) {
+	schema := testSchema(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp := schema.Execute(ctx, Request{Query: `{ books { title } }`})
+	if resp.Data != nil || len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "context canceled") {
+		t.Errorf("expected the query to stop, got %+v", resp)
+	}
+}
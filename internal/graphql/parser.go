@@ -0,0 +1,560 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Document is a parsed GraphQL request document
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation, or subscription definition
+type Operation struct {
+	Type         string // "query", "mutation", or "subscription"
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+// VariableDefinition declares an operation variable, e.g. "$limit: Int = 10"
+type VariableDefinition struct {
+	Name    string
+	Type    string
+	Default Value // nil when there is none
+}
+
+// Fragment is a named fragment definition
+type Fragment struct {
+	Name         string
+	On           string
+	SelectionSet []Selection
+}
+
+// Selection is a *Field, *FragmentSpread, or *InlineFragment
+type Selection interface{}
+
+// Field selects a field, optionally aliased, with arguments and a sub-selection
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]Value
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// ResponseKey is the key the field's value is returned under
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread includes a named fragment ("...Name")
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+// InlineFragment includes a selection set in place ("... on Type { }")
+type InlineFragment struct {
+	On           string // "" applies to any type
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// Directive is an annotation such as @include(if: $x)
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is a literal argument value: nil for null, bool, int, float64, string, Enum,
+// Variable, []Value, or map[string]Value
+type Value interface{}
+
+// Enum is an enum value literal
+type Enum string
+
+// Variable refers to an operation variable
+type Variable string
+
+// Parse parses a GraphQL request document
+func Parse(source string) (doc *Document, err error) {
+	p := &parser{lexer: lexer{src: source}}
+	defer func() {
+		if r := recover(); r != nil {
+			perr, ok := r.(*SyntaxError)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, perr
+		}
+	}()
+	p.next()
+	return p.document(), nil
+}
+
+// SyntaxError reports where a document failed to parse
+type SyntaxError struct {
+	Line, Column int
+	Message      string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lexer splits a document into tokens, skipping whitespace, commas, and comments
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) errorAt(pos int, format string, args ...interface{}) {
+	line, column := 1, 1
+	for _, r := range l.src[:pos] {
+		if r == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	panic(&SyntaxError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *lexer) next() token {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		} else if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			l.pos++
+		} else {
+			break
+		}
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokPunct, value: "...", pos: start}
+	case strings.IndexByte("!$()[]{}:=@|&", c) >= 0:
+		l.pos++
+		return token{kind: tokPunct, value: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokName, value: l.src[start:l.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return l.number()
+	case strings.HasPrefix(l.src[l.pos:], `"""`):
+		return l.blockString()
+	case c == '"':
+		return l.string()
+	}
+	l.errorAt(start, "unexpected character %q", c)
+	return token{}
+}
+
+func (l *lexer) number() token {
+	start := l.pos
+	kind := tokInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	digits := func() {
+		begin := l.pos
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+		if l.pos == begin {
+			l.errorAt(l.pos, "expected a digit")
+		}
+	}
+	digits()
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokFloat
+		l.pos++
+		digits()
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		digits()
+	}
+	return token{kind: kind, value: l.src[start:l.pos], pos: start}
+}
+
+func (l *lexer) string() token {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			l.errorAt(start, "unterminated string")
+		}
+		c := l.src[l.pos]
+		if c == '"' {
+			l.pos++
+			return token{kind: tokString, value: b.String(), pos: start}
+		}
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.pos += size
+			continue
+		}
+		if l.pos+1 >= len(l.src) {
+			l.errorAt(start, "unterminated string")
+		}
+		escape := l.src[l.pos+1]
+		l.pos += 2
+		switch escape {
+		case '"', '\\', '/':
+			b.WriteByte(escape)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if l.pos+4 > len(l.src) {
+				l.errorAt(l.pos, "invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+			if err != nil {
+				l.errorAt(l.pos, "invalid unicode escape")
+			}
+			b.WriteRune(rune(code))
+			l.pos += 4
+		default:
+			l.errorAt(l.pos-2, "invalid escape \\%c", escape)
+		}
+	}
+}
+
+// blockString reads a """block string""", removing its common indentation
+func (l *lexer) blockString() token {
+	start := l.pos
+	end := strings.Index(l.src[l.pos+3:], `"""`)
+	if end < 0 {
+		l.errorAt(start, "unterminated block string")
+	}
+	raw := l.src[l.pos+3 : l.pos+3+end]
+	l.pos += end + 6
+
+	lines := strings.Split(raw, "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && (indent < 0 || len(line)-len(trimmed) < indent) {
+			indent = len(line) - len(trimmed)
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return token{kind: tokString, value: strings.Join(lines, "\n"), pos: start}
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// parser is a recursive-descent parser over the lexer's tokens
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+func (p *parser) next() { p.tok = p.lexer.next() }
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	p.lexer.errorAt(p.tok.pos, format, args...)
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokPunct && p.tok.value == punct
+}
+
+func (p *parser) skip(punct string) bool {
+	if p.peek(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) {
+	if !p.skip(punct) {
+		p.errorf("expected %q, found %s", punct, p.describe())
+	}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokName {
+		p.errorf("expected a name, found %s", p.describe())
+	}
+	name := p.tok.value
+	p.next()
+	return name
+}
+
+func (p *parser) keyword(word string) {
+	if p.tok.kind != tokName || p.tok.value != word {
+		p.errorf("expected %q, found %s", word, p.describe())
+	}
+	p.next()
+}
+
+func (p *parser) describe() string {
+	if p.tok.kind == tokEOF {
+		return "end of document"
+	}
+	return strconv.Quote(p.tok.value)
+}
+
+func (p *parser) document() *Document {
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek("{"):
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: p.selectionSet()})
+		case p.tok.kind == tokName && p.tok.value == "fragment":
+			fragment := p.fragment()
+			if _, exists := doc.Fragments[fragment.Name]; exists {
+				p.errorf("fragment %q is defined twice", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+		case p.tok.kind == tokName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			doc.Operations = append(doc.Operations, p.operation())
+		default:
+			p.errorf("expected an operation or fragment, found %s", p.describe())
+		}
+	}
+	if len(doc.Operations) == 0 {
+		p.errorf("document has no operations")
+	}
+	return doc
+}
+
+func (p *parser) operation() *Operation {
+	op := &Operation{Type: p.name()}
+	if p.tok.kind == tokName {
+		op.Name = p.name()
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			p.expect("$")
+			def := &VariableDefinition{Name: p.name()}
+			p.expect(":")
+			def.Type = p.typeRef()
+			if p.skip("=") {
+				def.Default = p.value(true)
+			}
+			op.Variables = append(op.Variables, def)
+		}
+	}
+	p.directives()
+	op.SelectionSet = p.selectionSet()
+	return op
+}
+
+func (p *parser) fragment() *Fragment {
+	p.keyword("fragment")
+	fragment := &Fragment{Name: p.name()}
+	if fragment.Name == "on" {
+		p.errorf("a fragment can't be named \"on\"")
+	}
+	p.keyword("on")
+	fragment.On = p.name()
+	p.directives()
+	fragment.SelectionSet = p.selectionSet()
+	return fragment
+}
+
+// typeRef reads a type reference such as "[String!]!" back into its source form
+func (p *parser) typeRef() string {
+	var ref string
+	if p.skip("[") {
+		ref = "[" + p.typeRef() + "]"
+		p.expect("]")
+	} else {
+		ref = p.name()
+	}
+	if p.skip("!") {
+		ref += "!"
+	}
+	return ref
+}
+
+func (p *parser) selectionSet() []Selection {
+	p.expect("{")
+	var selections []Selection
+	for !p.skip("}") {
+		selections = append(selections, p.selection())
+	}
+	if len(selections) == 0 {
+		p.errorf("selection set is empty")
+	}
+	return selections
+}
+
+func (p *parser) selection() Selection {
+	if p.skip("...") {
+		if p.tok.kind == tokName && p.tok.value != "on" {
+			return &FragmentSpread{Name: p.name(), Directives: p.directives()}
+		}
+		inline := &InlineFragment{}
+		if p.tok.kind == tokName {
+			p.keyword("on")
+			inline.On = p.name()
+		}
+		inline.Directives = p.directives()
+		inline.SelectionSet = p.selectionSet()
+		return inline
+	}
+
+	field := &Field{Name: p.name()}
+	if p.skip(":") {
+		field.Alias, field.Name = field.Name, p.name()
+	}
+	field.Arguments = p.arguments(false)
+	field.Directives = p.directives()
+	if p.peek("{") {
+		field.SelectionSet = p.selectionSet()
+	}
+	return field
+}
+
+func (p *parser) arguments(constant bool) map[string]Value {
+	if !p.skip("(") {
+		return nil
+	}
+	args := make(map[string]Value)
+	for !p.skip(")") {
+		name := p.name()
+		if _, exists := args[name]; exists {
+			p.errorf("argument %q is given twice", name)
+		}
+		p.expect(":")
+		args[name] = p.value(constant)
+	}
+	return args
+}
+
+func (p *parser) directives() []*Directive {
+	var directives []*Directive
+	for p.skip("@") {
+		directives = append(directives, &Directive{Name: p.name(), Arguments: p.arguments(false)})
+	}
+	return directives
+}
+
+// value reads a literal; variables aren't allowed in constant contexts such as defaults
+func (p *parser) value(constant bool) Value {
+	tok := p.tok
+	switch tok.kind {
+	case tokInt:
+		p.next()
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			p.lexer.errorAt(tok.pos, "integer %s is out of range", tok.value)
+		}
+		return n
+	case tokFloat:
+		p.next()
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			p.lexer.errorAt(tok.pos, "invalid number %s", tok.value)
+		}
+		return f
+	case tokString:
+		p.next()
+		return tok.value
+	case tokName:
+		p.next()
+		switch tok.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return Enum(tok.value)
+	}
+
+	switch {
+	case p.skip("$"):
+		if constant {
+			p.lexer.errorAt(tok.pos, "variables aren't allowed here")
+		}
+		return Variable(p.name())
+	case p.skip("["):
+		list := []Value{}
+		for !p.skip("]") {
+			list = append(list, p.value(constant))
+		}
+		return list
+	case p.skip("{"):
+		object := make(map[string]Value)
+		for !p.skip("}") {
+			name := p.name()
+			p.expect(":")
+			object[name] = p.value(constant)
+		}
+		return object
+	}
+	p.errorf("expected a value, found %s", p.describe())
+	return nil
+}
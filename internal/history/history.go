@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// idLayout names snapshots by their UTC start time, so IDs sort chronologically
+const idLayout = "20060102T150405Z"
+
+// Store keeps timestamped analysis snapshots of one project in a directory. Each snapshot
+// is a JSON report (<id>.json) and the run status of the analysis (<id>.status.json).
+type Store struct {
+	Dir string
+}
+
+// Snapshot summarizes one stored analysis run
+type Snapshot struct {
+	ID       string              `json:"id"`
+	Time     time.Time           `json:"time"`
+	Status   string              `json:"status"`
+	ExitCode int                 `json:"exitCode"`
+	Counts   runstatus.Counts    `json:"counts"`
+	Metrics  map[string]int      `json:"metrics,omitempty"`
+	Findings []runstatus.Finding `json:"findings,omitempty"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// Point is a metric's value in one snapshot
+type Point struct {
+	Snapshot string    `json:"snapshot"`
+	Time     time.Time `json:"time"`
+	Value    int       `json:"value"`
+}
+
+// Open returns the store in dir, creating the directory if needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	return &Store{Dir: dir}, nil
+}
+
+// NewID returns the snapshot ID for an analysis started at t
+func NewID(t time.Time) string {
+	return t.UTC().Format(idLayout)
+}
+
+// ReportPath returns where the report of snapshot id is stored
+func (s *Store) ReportPath(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+// StatusPath returns where the run status of snapshot id is stored
+func (s *Store) StatusPath(id string) string {
+	return filepath.Join(s.Dir, id+".status.json")
+}
+
+// List returns the stored snapshots, oldest first
+func (s *Store) List() ([]*Snapshot, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []*Snapshot{}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".status.json")
+		if !ok || !ValidID(id) {
+			continue
+		}
+		snapshot, err := s.Get(id)
+		if err != nil {
+			continue // Being written, or not ours
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID < snapshots[j].ID })
+	return snapshots, nil
+}
+
+// Get reads the summary of snapshot id
+func (s *Store) Get(id string) (*Snapshot, error) {
+	if !ValidID(id) {
+		return nil, fmt.Errorf("invalid snapshot id %q", id)
+	}
+	data, err := os.ReadFile(s.StatusPath(id))
+	if err != nil {
+		return nil, err
+	}
+	var status runstatus.Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+
+	t, _ := time.Parse(idLayout, id)
+	return &Snapshot{
+		ID:       id,
+		Time:     t,
+		Status:   status.Status,
+		ExitCode: status.ExitCode,
+		Counts:   status.Counts,
+		Metrics:  status.Metrics,
+		Findings: status.Findings,
+		Error:    status.Error,
+	}, nil
+}
+
+// Latest returns the newest snapshot with a report, or nil when there is none
+func (s *Store) Latest() (*Snapshot, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if _, err := os.Stat(s.ReportPath(snapshots[i].ID)); err == nil {
+			return snapshots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Trends returns each metric's value over time, oldest first. With no metrics named it
+// returns every metric the snapshots recorded; snapshots whose analysis failed are skipped.
+func (s *Store) Trends(metrics ...string) (map[string][]Point, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(metrics))
+	for _, metric := range metrics {
+		wanted[metric] = true
+	}
+
+	trends := make(map[string][]Point)
+	for _, metric := range metrics {
+		trends[metric] = []Point{}
+	}
+	for _, snapshot := range snapshots {
+		for metric, value := range snapshot.Metrics {
+			if len(wanted) > 0 && !wanted[metric] {
+				continue
+			}
+			trends[metric] = append(trends[metric], Point{Snapshot: snapshot.ID, Time: snapshot.Time, Value: value})
+		}
+	}
+	return trends, nil
+}
+
+// Prune deletes all but the newest keep snapshots
+func (s *Store) Prune(keep int) error {
+	snapshots, err := s.List()
+	if err != nil || len(snapshots) <= keep {
+		return err
+	}
+	for _, snapshot := range snapshots[:len(snapshots)-keep] {
+		os.Remove(s.ReportPath(snapshot.ID))
+		if err := os.Remove(s.StatusPath(snapshot.ID)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidID reports whether id is a snapshot ID (and so safe to use in a path)
+func ValidID(id string) bool {
+	_, err := time.Parse(idLayout, id)
+	return err == nil
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// addSnapshot stores a fake analysis run with the given metrics
+func addSnapshot(t *testing.T, store *Store, at time.Time, metrics map[string]int, withReport bool) string {
+	t.Helper()
+	id := NewID(at)
+	status := runstatus.New("test")
+	status.Metrics = metrics
+	status.Finish(runstatus.ExitOK)
+	if err := status.Write(store.StatusPath(id)); err != nil {
+		t.Fatal(err)
+	}
+	if withReport {
+		if err := os.WriteFile(store.ReportPath(id), []byte(`{"graph":{"nodes":{}}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return id
+}
+
+func TestStoreListLatestAndTrends(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+	first := addSnapshot(t, store, start, map[string]int{"cycles": 0, "orphans": 4}, true)
+	second := addSnapshot(t, store, start.Add(time.Hour), map[string]int{"cycles": 1, "orphans": 6}, true)
+	addSnapshot(t, store, start.Add(2*time.Hour), nil, false) // A failed run without a report
+	os.WriteFile(store.StatusPath("notes"), []byte("{}"), 0644)
+
+	snapshots, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 3 || snapshots[0].ID != first || !snapshots[0].Time.Equal(start) {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+
+	latest, err := store.Latest()
+	if err != nil || latest == nil || latest.ID != second {
+		t.Errorf("expected latest report to be %s, got %+v (%v)", second, latest, err)
+	}
+
+	trends, err := store.Trends("cycles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []int
+	for _, point := range trends["cycles"] {
+		values = append(values, point.Value)
+	}
+	if len(trends) != 1 || !reflect.DeepEqual(values, []int{0, 1}) {
+		t.Errorf("unexpected cycles trend: %+v", trends)
+	}
+
+	all, _ := store.Trends()
+	if len(all) != 2 || len(all["orphans"]) != 2 {
+		t.Errorf("expected every recorded metric, got %+v", all)
+	}
+}
+
+func TestStorePrune(t *testing.T) {
+	store, _ := Open(t.TempDir())
+	start := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		addSnapshot(t, store, start.Add(time.Duration(i)*time.Minute), map[string]int{"nodes": i}, true)
+	}
+	if err := store.Prune(2); err != nil {
+		t.Fatal(err)
+	}
+	snapshots, _ := store.List()
+	if len(snapshots) != 2 || snapshots[0].Metrics["nodes"] != 3 {
+		t.Errorf("expected the newest 2 snapshots to remain, got %+v", snapshots)
+	}
+	if _, err := os.Stat(store.ReportPath(NewID(start))); !os.IsNotExist(err) {
+		t.Errorf("expected pruned report to be deleted")
+	}
+}
+
+func TestValidID(t *testing.T) {
+	if !ValidID("20250314T100000Z") {
+		t.Errorf("expected a timestamp ID to be valid")
+	}
+	for _, id := range []string{"", "latest", "../etc/passwd", "20250314T100000Z/.."} {
+		if ValidID(id) {
+			t.Errorf("expected %q to be invalid", id)
+		}
+	}
+}
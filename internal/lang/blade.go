@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Patterns for Laravel Blade templates
+var (
+	// Directives, not escaped ones (@@if) or e-mail addresses: @include, @if (
+	bladeDirectivePattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z]\w*)`)
+
+	// Echoes: {{ $user->name }}, {!! markdown($post) !!}, not escaped ones (@{{ }})
+	bladeEchoPattern = regexp.MustCompile(`\{\{(.*?)\}\}|\{!!(.*?)!!\}`)
+
+	// Comments on one line: {{-- TODO --}}
+	bladeCommentPattern = regexp.MustCompile(`\{\{--.*?--\}\}`)
+
+	// Components: <x-alert, <x-forms.input, <x-mail::button
+	bladeComponentPattern = regexp.MustCompile(`<x-([\w.-]+(?:::[\w.-]+)?)`)
+
+	// A directive's view: @include('partials.nav', [...]); the view of @includeWhen and
+	// @includeUnless follows their condition; @includeFirst takes a list of them
+	bladeViewPattern      = regexp.MustCompile(`^\(\s*['"]([\w.:-]+)['"]`)
+	bladeWhenViewPattern  = regexp.MustCompile(`^\(.*?,\s*['"]([\w.:-]+)['"]`)
+	bladeFirstViewPattern = regexp.MustCompile(`^\(\s*\[([^\]]*)\]`)
+)
+
+// bladeViewDirectives are the directives that render another view
+var bladeViewDirectives = map[string]*regexp.Regexp{
+	"extends": bladeViewPattern, "include": bladeViewPattern, "includeIf": bladeViewPattern,
+	"each": bladeViewPattern, "component": bladeViewPattern,
+	"includeWhen": bladeWhenViewPattern, "includeUnless": bladeWhenViewPattern,
+	"includeFirst": bladeFirstViewPattern,
+}
+
+// bladeViewName names a template the way Laravel does: by its path under the views
+// directory, dotted, so resources/views/users/show.blade.php is users.show
+func bladeViewName(path string) string {
+	name := strings.TrimSuffix(filepath.ToSlash(path), ".blade.php")
+	if i := strings.LastIndex(name, "/views/"); i != -1 {
+		name = name[i+len("/views/"):]
+	} else {
+		name = name[strings.LastIndex(name, "/")+1:]
+	}
+	return strings.ReplaceAll(name, "/", ".")
+}
+
+// bladeComponentClass is the class behind a component tag: forms.input is
+// App\View\Components\Forms\Input
+func bladeComponentClass(component string) string {
+	parts := strings.Split(component, ".")
+	for i, part := range parts {
+		parts[i] = pascalCase(part)
+	}
+	return `App\View\Components\` + strings.Join(parts, `\`)
+}
+
+// parseBlade records a Blade template as a view, with the views it extends, includes,
+// and renders as components as its dependencies, along with the functions and classes
+// its echoes, directives, and @php blocks use
+func (p *PHPParser) parseBlade(filePath string, r io.Reader) (*models.ParsedFile, error) {
+	name := bladeViewName(filePath)
+	parsed := &models.ParsedFile{
+		Path:     filePath,
+		Language: p.Language(),
+		Elements: []models.CodeElement{{
+			Type:       "view",
+			Name:       name,
+			Visibility: "public",
+			Line:       1,
+			File:       filePath,
+		}},
+		Usage: []models.UsageElement{},
+		Uses:  []string{},
+	}
+	addView := func(view string, lineNum int) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "view", Name: view, Context: name, Line: lineNum})
+	}
+	addCode := func(code string, lineNum int) {
+		p.target.check(blankStrings(code), lineNum, parsed)
+		p.parseUsage(code, lineNum, name, "", parsed)
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	inComment := false  // Inside a {{-- --}} comment
+	inPHP := false      // Inside @php ... @endphp or <?php ... ?>
+	inVerbatim := false // Inside @verbatim ... @endverbatim, which Blade doesn't compile
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		if inComment {
+			parsed.CommentLines++
+			end := strings.Index(line, "--}}")
+			if end == -1 {
+				continue
+			}
+			line, inComment = line[end+len("--}}"):], false
+		}
+		if bladeCommentPattern.MatchString(line) && strings.TrimSpace(bladeCommentPattern.ReplaceAllString(line, "")) == "" {
+			parsed.CommentLines++
+		}
+		line = bladeCommentPattern.ReplaceAllString(line, "")
+		if start := strings.Index(line, "{{--"); start != -1 {
+			parsed.CommentLines++
+			line, inComment = line[:start], true
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inVerbatim:
+			inVerbatim = !strings.Contains(line, "@endverbatim")
+			continue
+		case inPHP:
+			if end := strings.Index(line, "@endphp"); end != -1 {
+				line, inPHP = line[:end], false
+			} else if end := strings.Index(line, "?>"); end != -1 {
+				line, inPHP = line[:end], false
+			}
+			addCode(line, lineNum)
+			continue
+		case trimmed == "@php" || trimmed == "<?php":
+			inPHP = true
+			continue
+		case strings.HasPrefix(trimmed, "@verbatim"):
+			inVerbatim = !strings.Contains(line, "@endverbatim")
+			continue
+		}
+
+		for _, match := range bladeEchoPattern.FindAllStringSubmatchIndex(line, -1) {
+			if match[0] > 0 && line[match[0]-1] == '@' {
+				continue
+			}
+			if match[2] != -1 {
+				addCode(line[match[2]:match[3]], lineNum)
+			} else {
+				addCode(line[match[4]:match[5]], lineNum)
+			}
+		}
+		for _, match := range bladeDirectivePattern.FindAllStringSubmatchIndex(line, -1) {
+			directive := line[match[2]:match[3]]
+			args := strings.TrimLeft(line[match[1]:], " \t")
+			if !strings.HasPrefix(args, "(") {
+				continue
+			}
+			args = args[:balancedEnd(args)]
+			switch pattern := bladeViewDirectives[directive]; {
+			case directive == "vite":
+				p.parseAssets("@vite"+args, lineNum, name, parsed)
+			case directive == "inject":
+				if services := p.quotedPattern.FindAllStringSubmatch(args, 2); len(services) == 2 {
+					p.addTypeReferences(services[1][1], name, lineNum, parsed)
+				}
+			case pattern == bladeFirstViewPattern:
+				if views := pattern.FindStringSubmatch(args); views != nil {
+					for _, view := range p.quotedPattern.FindAllStringSubmatch(views[1], -1) {
+						addView(view[1], lineNum)
+					}
+				}
+			case pattern != nil:
+				if view := pattern.FindStringSubmatch(args); view != nil {
+					addView(view[1], lineNum)
+				}
+				addCode(args, lineNum)
+			default:
+				addCode(args, lineNum)
+			}
+		}
+		for _, match := range bladeComponentPattern.FindAllStringSubmatch(line, -1) {
+			component := match[1]
+			if component == "slot" || component == "dynamic-component" || strings.Contains(component, "::") {
+				continue // Slots, and components from packages
+			}
+			addView("components."+component, lineNum)
+			p.addTypeReferences(bladeComponentClass(component), name, lineNum, parsed)
+		}
+	}
+
+	parsed.Lines = lineNum
+	return parsed, scanner.Err()
+}
+
+// balancedEnd returns the length of the parenthesized text s starts with, or all of s
+// when it doesn't close on the line
+func balancedEnd(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,154 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestPHPParser_Blade(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "resources", "views", "orders"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := writeFixture(t, tmp, "resources/views/orders/show.blade.php", `@extends('layouts.app')
+@inject('metrics', 'App\Services\MetricsService')
+
+{{-- TODO: paginate
+     the line items --}}
+@section('content')
+  @include('orders.header', ['title' => 'Order'])
+  @includeWhen($order->isPaid(), 'orders.receipt')
+  @includeFirst(['orders.custom', 'orders.default'])
+  <x-forms.input name="email" />
+  <x-slot name="footer">{{ format_money($total) }}</x-slot>
+  <p>{!! markdown($notes) !!} @{{ raw }} support@example.com</p>
+  @if(can_refund($order))
+    <a href="{{ route('refund') }}">Refund</a>
+  @endif
+  @php
+    $tax = Tax::for($order);
+  @endphp
+  @verbatim
+    {{ notParsed() }}
+  @endverbatim
+@endsection
+`)
+
+	parsed, err := NewPHPParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Elements) != 1 || parsed.Elements[0].Type != "view" || parsed.Elements[0].Name != "orders.show" {
+		t.Fatalf("expected the template as the orders.show view, got %+v", parsed.Elements)
+	}
+
+	usage := make(map[string]int)
+	for _, u := range parsed.Usage {
+		if u.Context != "orders.show" {
+			t.Errorf("expected usage in the view, got %+v", u)
+		}
+		usage[u.Type+":"+u.Name] = u.Line
+	}
+	want := map[string]int{
+		"view:layouts.app":                               1,
+		`type_reference:App\Services\MetricsService`:     2,
+		"view:orders.header":                             7,
+		"view:orders.receipt":                            8,
+		"method_call:isPaid":                             8,
+		"view:orders.custom":                             9,
+		"view:orders.default":                            9,
+		"view:components.forms.input":                    10,
+		`type_reference:App\View\Components\Forms\Input`: 10,
+		"function_call:format_money":                     11,
+		"function_call:markdown":                         12,
+		"function_call:can_refund":                       13,
+		"static_call:Tax::for":                           17,
+	}
+	for key, line := range want {
+		if usage[key] != line {
+			t.Errorf("expected %s on line %d, got %v", key, line, usage)
+		}
+	}
+	for key := range usage {
+		switch key {
+		case "view:orders.show", "function_call:raw", "function_call:notParsed", "view:components.slot", "function_call:route":
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 4 {
+		t.Errorf("expected the TODO on line 4, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 2 || parsed.Lines != 22 {
+		t.Errorf("expected 2 comment lines of 22, got %d of %d", parsed.CommentLines, parsed.Lines)
+	}
+}
+
+func TestPHPParser_BladeGraph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"app/Http/Controllers", "resources/views/layouts", "resources/views/partials"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paths := []string{
+		writeFixture(t, tmp, "app/helpers.php", `<?php
+function money($amount) { return $amount; }
+`),
+		writeFixture(t, tmp, "app/Http/Controllers/HomeController.php", `<?php
+namespace App\Http\Controllers;
+
+class HomeController {
+    public function index() {
+        return view('home');
+    }
+}
+`),
+		writeFixture(t, tmp, "resources/views/home.blade.php", `@extends('layouts.app')
+@section('content') {{ money(5) }} @endsection
+`),
+		writeFixture(t, tmp, "resources/views/layouts/app.blade.php", `<html>@include('partials.nav') @yield('content')</html>
+`),
+		writeFixture(t, tmp, "resources/views/partials/nav.blade.php", `<nav></nav>
+`),
+		writeFixture(t, tmp, "resources/views/partials/unused.blade.php", `<footer></footer>
+`),
+	}
+
+	p := NewPHPParser()
+	var files []*models.ParsedFile
+	for _, path := range paths {
+		parsed, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Type+":"+node.Name] = node
+	}
+	for _, edge := range [][2]string{
+		{"method:index", "view:home"},
+		{"view:home", "view:layouts.app"},
+		{"view:home", "function:money"},
+		{"view:layouts.app", "view:partials.nav"},
+	} {
+		from, to := nodes[edge[0]], nodes[edge[1]]
+		if from == nil || to == nil || from.Dependencies[to.ID] == nil {
+			t.Errorf("expected %s to depend on %s, got %+v", edge[0], edge[1], from)
+		}
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Type+":"+node.Name] = true
+	}
+	if !orphans["view:partials.unused"] || orphans["view:partials.nav"] {
+		t.Errorf("expected only the unused partial among the views' orphans, got %v", orphans)
+	}
+}
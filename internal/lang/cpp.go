@@ -0,0 +1,1030 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// CppParser handles parsing of C and C++ files
+type CppParser struct {
+	includePattern   *regexp.Regexp
+	directivePattern *regexp.Regexp
+	namespacePattern *regexp.Regexp
+	externPattern    *regexp.Regexp
+	accessPattern    *regexp.Regexp
+	typePattern      *regexp.Regexp
+	fieldPattern     *regexp.Regexp
+	closingPattern   *regexp.Regexp
+	newPattern       *regexp.Regexp
+	callPattern      *regexp.Regexp
+	memberPattern    *regexp.Regexp
+	localPattern     *regexp.Regexp
+	catchPattern     *regexp.Regexp
+}
+
+// cppScope is a namespace, type, function, or other block the parser is inside
+type cppScope struct {
+	kind      string // "namespace", "type", "function", or "block"
+	name      string // "" for anonymous namespaces and extern "C" blocks
+	class     string // The class an out-of-line method definition belongs to
+	depth     int    // Brace depth inside the body
+	access    string // A type's current access level, set by public:, private:, protected:
+	constants bool   // An enum body
+	internal  bool   // An anonymous namespace, whose declarations are private to the file
+	typedef   int    // Index of the element an anonymous typedef struct names when it closes, or -1
+}
+
+// cppFile is the state of one file's parse
+type cppFile struct {
+	parsed *models.ParsedFile
+	types  map[string]bool // Types declared, forward-declared, or defined out of line in the file
+	scopes []cppScope
+}
+
+// cppConditional is an open #if, #ifdef, or #ifndef. Only its first branch that can be
+// taken is parsed, since the others repeat its declarations and braces.
+type cppConditional struct {
+	taken    bool // A branch was parsed
+	skipping bool // The current branch is skipped
+}
+
+// NewCppParser creates a new C/C++ parser with compiled regex patterns
+func NewCppParser() *CppParser {
+	return &CppParser{
+		// Includes: #include "models/user.h", #include <vector>, #import "legacy.h"
+		includePattern: regexp.MustCompile(`^\s*#\s*(?:include|import)\s*([<"])([^>"]+)[>"]`),
+
+		// Other preprocessor directives: #if, #ifdef, #else, #endif, #define, ...
+		directivePattern: regexp.MustCompile(`^\s*#\s*(\w+)\s*(.*)`),
+
+		// Namespaces: namespace app {, namespace app::models {, inline namespace v1 {, namespace {
+		namespacePattern: regexp.MustCompile(`^\s*(?:inline\s+)?namespace\s*([\w:]*)\s*(?:\[\[[^\]]*\]\]\s*)?\{`),
+
+		// Linkage blocks, whose declarations belong to the enclosing namespace: extern "C" {
+		externPattern: regexp.MustCompile(`^\s*extern\s+""\s*\{`),
+
+		// Access labels: public:, private:, protected:, and Qt's public slots: and signals:
+		accessPattern: regexp.MustCompile(`^\s*(?:(public|private|protected)\s*(?:Q_SLOTS|slots)?|Q_SIGNALS|signals)\s*:(?:[^:]|$)`),
+
+		// Types: class UserService : public Service {, struct Point {, enum class Color : uint8_t {,
+		// typedef struct node {, class APP_EXPORT Widget final
+		typePattern: regexp.MustCompile(`^\s*(typedef\s+)?(class|struct|union|enum\s+class|enum\s+struct|enum)\s+(?:\[\[[^\]]*\]\]\s*|alignas\s*\([^)]*\)\s*|[A-Z][A-Z0-9]*_[A-Z0-9_]*\s+)*([A-Za-z_]\w*)?\s*(?:final\b\s*)?(:[^;{]*)?(\{|;|$)`),
+
+		// Fields and constants: std::string name_;, static constexpr int kMax = 10;, unsigned flags : 4;
+		fieldPattern: regexp.MustCompile(`^\s*((?:(?:static|const|constexpr|constinit|mutable|volatile|inline|thread_local|extern)\s+)*)((?:struct\s+|enum\s+|unsigned\s+|signed\s+|long\s+|short\s+)*[A-Za-z_][\w:]*(?:\s*<[^;()]*>)?(?:\s+const)?)[\s*&]+([A-Za-z_]\w*)\s*(?:\[[^\]]*\]\s*)*(?::\s*\w+\s*)?(=|;|\{|,)`),
+
+		// The end of an anonymous typedef struct: } Point;
+		closingPattern: regexp.MustCompile(`^\s*\}\s*([A-Za-z_]\w*)`),
+
+		// Instantiations: new User(name), new (buffer) Node, new app::Cache<int>
+		newPattern: regexp.MustCompile(`\bnew\s+(?:\([^)]*\)\s*)?((?:::)?[A-Za-z_][\w:]*)`),
+
+		// Calls, qualified or with template arguments: save(user), User::create(), app::trim(s),
+		// std::make_unique<UserService>(repo)
+		callPattern: regexp.MustCompile(`((?:::)?(?:[A-Za-z_]\w*\s*(?:<[^<>()]*>)?\s*::\s*)*)(~?[A-Za-z_]\w*)\s*(?:<([^<>()]*(?:<[^<>()]*>)?[^<>()]*)>)?\s*\(`),
+
+		// Static members and enumerators: Color::Red, Config::kMaxUsers
+		memberPattern: regexp.MustCompile(`((?:[A-Za-z_]\w*::)*[A-Za-z_]\w*)::([A-Za-z_]\w*)\b`),
+
+		// Local variables of a class type: User user;, const Config& config = load();,
+		// for (const Order& order : orders)
+		localPattern: regexp.MustCompile(`(?:^|[;{(]\s*)(?:const\s+)?((?:[A-Za-z_]\w*::)*[A-Z]\w*)(?:<[^;()]*>)?\s*[*&]*\s+[*&]*[a-z_]\w*\s*[;=,{:)]`),
+
+		// Exception handlers: catch (const std::runtime_error& e), catch (NotFound&)
+		catchPattern: regexp.MustCompile(`\bcatch\s*\(\s*(?:const\s+)?((?:::)?[A-Za-z_][\w:]*)`),
+	}
+}
+
+// ParseFile analyzes a single C or C++ file and extracts all elements
+func (p *CppParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	c := &cppFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+			Includes: []models.Include{},
+		},
+		types: make(map[string]bool),
+	}
+	parsed := c.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	inComment := false
+	inMacro := false  // A #define continued with a trailing backslash
+	docblock := false // A ///, //!, /**, or /*! Doxygen comment precedes the next declaration
+	var conditionals []cppConditional
+
+	join := func(code, bare string) (string, string) {
+		joinedLines++
+		nextCode, nextBare, nextInComment := stripJSLine(scanner.Text(), inComment)
+		inComment = nextInComment
+		return code + " " + strings.TrimSpace(nextCode), bare + " " + strings.TrimSpace(nextBare)
+	}
+	skipping := func() bool {
+		for _, conditional := range conditionals {
+			if conditional.skipping {
+				return true
+			}
+		}
+		return false
+	}
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		if inMacro {
+			inMacro = strings.HasSuffix(strings.TrimSpace(line), `\`)
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if !inComment && (strings.HasPrefix(trimmed, "///") || strings.HasPrefix(trimmed, "//!") || strings.HasPrefix(trimmed, "/**") || strings.HasPrefix(trimmed, "/*!")) {
+			docblock = true
+		}
+		code, bare, stillInComment := stripJSLine(line, inComment)
+		inComment = stillInComment
+		if strings.TrimSpace(bare) == "" {
+			if trimmed != "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		if matches := p.directivePattern.FindStringSubmatch(bare); matches != nil {
+			switch matches[1] {
+			case "if", "ifdef", "ifndef":
+				zero := matches[1] == "if" && strings.TrimSpace(matches[2]) == "0"
+				conditionals = append(conditionals, cppConditional{taken: !zero, skipping: zero})
+			case "elif", "elifdef", "elifndef", "else":
+				if n := len(conditionals); n > 0 {
+					conditionals[n-1].skipping = conditionals[n-1].taken
+					conditionals[n-1].taken = true
+				}
+			case "endif":
+				if n := len(conditionals); n > 0 {
+					conditionals = conditionals[:n-1]
+				}
+			case "include", "import":
+				if m := p.includePattern.FindStringSubmatch(code); m != nil && !skipping() {
+					c.addInclude(m[2], m[1] == "<", lineNum)
+				}
+			}
+			inMacro = strings.HasSuffix(trimmed, `\`)
+			continue
+		}
+		if skipping() {
+			continue
+		}
+
+		// Join multi-line parameter lists and calls, so they're parsed whole
+		for parenBalance(bare) > 0 && !inComment && scanner.Scan() {
+			code, bare = join(code, bare)
+		}
+		// Declarations wrap before their body opens, in Allman style always: the brace, or a
+		// base list or member initializer list, is on a line of its own
+		if c.declaring(braceDepth) && !c.inEnum(braceDepth) && !p.accessPattern.MatchString(bare) && !isCppMacroLine(bare) {
+			for rest := stripCppAttributes(bare); strings.TrimSpace(rest) != "" && !strings.ContainsAny(rest, "{;}") && !inComment && scanner.Scan(); rest = stripCppAttributes(bare) {
+				code, bare = join(code, bare)
+				for parenBalance(bare) > 0 && !inComment && scanner.Scan() {
+					code, bare = join(code, bare)
+				}
+			}
+		}
+
+		documented := docblock
+		docblock = false
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		scopesBefore := len(c.scopes)
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := c.top()
+		declaring := c.declaring(depthBefore)
+		inTypeBody := top != nil && top.kind == "type" && top.depth == depthBefore
+		declaration := stripCppTemplate(stripCppAttributes(bare))
+
+		if c.inEnum(depthBefore) {
+			body = c.parseEnumConstants(top.name, bare, lineNum, documented)
+		} else if inTypeBody && p.accessPattern.MatchString(bare) {
+			if m := p.accessPattern.FindStringSubmatch(bare); m[1] != "" {
+				top.access = m[1]
+			}
+			body = ""
+		} else if matches := p.namespacePattern.FindStringSubmatchIndex(bare); declaring && !inTypeBody && matches != nil {
+			name := strings.ReplaceAll(bare[matches[2]:matches[3]], "::", `\`)
+			c.scopes = append(c.scopes, cppScope{kind: "namespace", name: name, depth: depthBefore + 1, internal: name == "", typedef: -1})
+			if parsed.Namespace == "" {
+				parsed.Namespace = c.namespace()
+			}
+			body = ""
+		} else if declaring && !inTypeBody && p.externPattern.MatchString(bare) {
+			c.scopes = append(c.scopes, cppScope{kind: "namespace", depth: depthBefore + 1, typedef: -1})
+			body = bare[strings.Index(bare, "{")+1:]
+		} else if matches := p.typePattern.FindStringSubmatchIndex(declaration); declaring && matches != nil && c.isType(declaration, matches) {
+			body = c.parseType(declaration, matches, lineNum, depthBefore, braceDepth, documented)
+		} else if declaring && declaration != "" {
+			if fn, function, ok := p.parseFunction(c, declaration, lineNum, depthBefore, braceDepth, documented); ok {
+				body, context = fn, function
+			} else if matches := p.fieldPattern.FindStringSubmatchIndex(declaration); matches != nil {
+				body = c.parseField(declaration, matches, lineNum, documented)
+				context = c.className()
+			}
+		} else if top != nil && top.typedef != -1 && braceDepth < top.depth {
+			// } Point; names the anonymous struct that just closed
+			if m := p.closingPattern.FindStringSubmatch(bare); m != nil {
+				c.nameTypedef(top, m[1])
+			}
+		}
+
+		// A brace opening no body the parser recognizes, such as an aggregate initializer or
+		// a test macro's, still has to be matched with its closing one
+		if declaring && len(c.scopes) == scopesBefore && braceDepth > depthBefore {
+			c.scopes = append(c.scopes, cppScope{kind: "block", depth: depthBefore + 1, typedef: -1})
+		}
+
+		if context == "" {
+			context = c.context()
+		}
+		p.parseUsage(c, body, lineNum, context)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, c.className(), context)...)
+
+		// Leave the bodies closed on this line
+		for len(c.scopes) > 0 && braceDepth < c.scopes[len(c.scopes)-1].depth {
+			c.scopes = c.scopes[:len(c.scopes)-1]
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// isType tells a type declaration or definition matched by typePattern from a variable of
+// a struct type (struct stat info;) and from an elaborated return type
+func (c *cppFile) isType(line string, matches []int) bool {
+	if matches[6] == -1 { // Anonymous: struct { ... } or typedef struct { ... } Point;
+		return line[matches[10]:matches[11]] == "{"
+	}
+	return true
+}
+
+// parseType records a class, struct, union, or enum, and opens its body. A forward
+// declaration (class Repository;) only notes that the name is a type. It returns the code
+// after the opening brace.
+func (c *cppFile) parseType(line string, matches []int, lineNum, depthBefore, braceDepth int, documented bool) string {
+	keyword := strings.Join(strings.Fields(line[matches[4]:matches[5]]), " ")
+	name := ""
+	if matches[6] != -1 {
+		name = line[matches[6]:matches[7]]
+		c.types[name] = true
+	}
+	if line[matches[10]:matches[11]] != "{" {
+		return "" // A forward declaration
+	}
+
+	element := models.CodeElement{
+		Type:       "class",
+		Name:       name,
+		Namespace:  c.namespace(),
+		Visibility: c.visibility(false),
+		Line:       lineNum,
+		File:       c.parsed.Path,
+		Documented: documented,
+	}
+	if strings.HasPrefix(keyword, "enum") {
+		element.Type = "enum"
+	}
+	typedef := -1
+	if name != "" {
+		c.parsed.Elements = append(c.parsed.Elements, element)
+	} else if matches[2] != -1 {
+		typedef = len(c.parsed.Elements) // Named by the typedef when its body closes
+		c.parsed.Elements = append(c.parsed.Elements, element)
+	}
+	if matches[8] != -1 && name != "" && element.Type == "class" {
+		c.parseBases(line[matches[8]+1:matches[9]], name, lineNum)
+	}
+
+	access := "public" // A struct's members, and a union's
+	if keyword == "class" {
+		access = "private"
+	}
+	body := line[matches[11]:]
+	if braceDepth > depthBefore {
+		c.scopes = append(c.scopes, cppScope{kind: "type", name: name, depth: depthBefore + 1, access: access, constants: element.Type == "enum", typedef: typedef})
+	} else if element.Type == "enum" {
+		body = c.parseEnumConstants(name, body, lineNum, false)
+	}
+	return body
+}
+
+// parseBases records the classes a class derives from. A base named like an interface
+// (IRenderer) is implemented; the rest are extended, since any of them can hold state.
+func (c *cppFile) parseBases(list, name string, lineNum int) {
+	for _, base := range splitTopLevel(list) {
+		fields := strings.Fields(base)
+		for len(fields) > 0 && (fields[0] == "public" || fields[0] == "private" || fields[0] == "protected" || fields[0] == "virtual") {
+			fields = fields[1:]
+		}
+		base = strings.Join(fields, " ")
+		if idx := strings.Index(base, "<"); idx != -1 {
+			base = strings.TrimSpace(base[:idx])
+		}
+		if base == "" || strings.HasPrefix(base, "std::") {
+			continue
+		}
+		usageType := "extends"
+		if isInterfaceName(base[strings.LastIndex(base, ":")+1:]) {
+			usageType = "implements"
+		}
+		c.parsed.Usage = append(c.parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    qualifyCpp(base),
+			Context: name,
+			Line:    lineNum,
+		})
+	}
+}
+
+// nameTypedef names the anonymous struct a typedef declares once its body closes, along
+// with the fields recorded in it
+func (c *cppFile) nameTypedef(scope *cppScope, name string) {
+	c.types[name] = true
+	c.parsed.Elements[scope.typedef].Name = name
+	for i := scope.typedef + 1; i < len(c.parsed.Elements); i++ {
+		if c.parsed.Elements[i].ClassName == "" && c.parsed.Elements[i].Type == "property" {
+			c.parsed.Elements[i].ClassName = name
+		}
+	}
+	scope.typedef = -1
+}
+
+// parseEnumConstants records the enumerators an enum declares on a line, and returns the
+// code after them, such as their values, for usage parsing. An anonymous enum's are
+// constants of the namespace, as C uses them.
+func (c *cppFile) parseEnumConstants(enumName, line string, lineNum int, documented bool) string {
+	for _, constant := range splitTopLevel(strings.TrimRight(strings.TrimSpace(line), "};")) {
+		constant = strings.TrimSpace(stripCppAttributes(constant))
+		end := 0
+		for end < len(constant) && isCSharpWordChar(rune(constant[end])) {
+			end++
+		}
+		if end == 0 || unicode.IsDigit(rune(constant[0])) {
+			continue
+		}
+		c.parsed.Elements = append(c.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       constant[:end],
+			Namespace:  c.namespace(),
+			ClassName:  enumName,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       c.parsed.Path,
+			Documented: documented,
+		})
+	}
+	return line
+}
+
+// parseFunction records a function or method definition, or a pure virtual method, and
+// returns the code after its parameter list, a member initializer list and the start of
+// its body, and who that code's usage belongs to. Constructors add no element, since they'd share their class's name; their
+// bodies' usage belongs to the class. Declarations without a body are prototypes, whose
+// definitions are recorded wherever they are.
+func (p *CppParser) parseFunction(c *cppFile, line string, lineNum, depthBefore, braceDepth int, documented bool) (string, string, bool) {
+	prefix, qualifier, name, params, rest, ok := cppDeclarator(line)
+	if !ok {
+		return "", "", false
+	}
+	specifiers := strings.Fields(prefix)
+	returnType := strings.Join(cppTypeWords(specifiers), " ")
+	if len(specifiers) > 0 && isCppKeyword(specifiers[0]) && !isCppSpecifier(specifiers[0]) && !isCppPrimitive(specifiers[0]) {
+		return "", "", false // return f(x);, using F = void(int);, typedef int (*F)(int);
+	}
+
+	className := ""
+	namespace := c.namespace()
+	if qualifier != "" {
+		segments := strings.Split(qualifier, `\`)
+		className = segments[len(segments)-1]
+		if len(segments) > 1 {
+			namespace = joinCppNamespace(namespace, strings.Join(segments[:len(segments)-1], `\`))
+		}
+		c.types[className] = true
+	} else if top := c.top(); top != nil && top.kind == "type" && top.depth == depthBefore {
+		className = top.name
+	}
+	constructor := className != "" && name == className
+	if returnType == "" && !constructor && !strings.HasPrefix(name, "~") && !strings.HasPrefix(name, "operator") {
+		return "", "", false // A macro call, not a declaration
+	}
+
+	brace := strings.Index(rest, "{")
+	abstract := false
+	switch {
+	case brace != -1:
+	case strings.Contains(strings.ReplaceAll(rest, " ", ""), "=0"):
+		abstract = true
+	default:
+		return "", "", true // A prototype, or = default and = delete
+	}
+
+	context := name
+	if constructor {
+		context = className
+	} else {
+		element := models.CodeElement{
+			Type:       "function",
+			Name:       name,
+			Namespace:  namespace,
+			ClassName:  className,
+			Visibility: c.visibility(hasCppWord(specifiers, "static")),
+			IsStatic:   className != "" && hasCppWord(specifiers, "static"),
+			IsAbstract: abstract,
+			Line:       lineNum,
+			File:       c.parsed.Path,
+			Documented: documented,
+			Parameters: []string{},
+			ReturnType: c.typeNames(returnType),
+		}
+		if className != "" {
+			element.Type = "method"
+		}
+		if qualifier != "" {
+			element.Visibility = "public" // Declared in its class, which may be in another file
+		}
+		for _, param := range splitTopLevel(params) {
+			paramName, paramType := cppParameter(param)
+			if paramName == "" {
+				continue
+			}
+			element.Parameters = append(element.Parameters, paramName)
+			element.ParamTypes = append(element.ParamTypes, c.typeNames(paramType))
+		}
+		c.parsed.Elements = append(c.parsed.Elements, element)
+	}
+
+	if brace != -1 && braceDepth > depthBefore {
+		scope := cppScope{kind: "function", name: context, depth: depthBefore + 1, typedef: -1}
+		if qualifier != "" {
+			scope.class = className
+		}
+		c.scopes = append(c.scopes, scope)
+	}
+	if brace == -1 {
+		return "", "", true
+	}
+	return rest, context, true
+}
+
+// parseField records a data member, or a constant at namespace scope. Other globals are
+// left out, like local variables, since reads and writes of variables aren't tracked.
+func (c *cppFile) parseField(line string, matches []int, lineNum int, documented bool) string {
+	modifiers := strings.Fields(line[matches[2]:matches[3]])
+	fieldType := line[matches[4]:matches[5]]
+	name := line[matches[6]:matches[7]]
+	rest := line[matches[8]:]
+	if first := strings.Fields(fieldType)[0]; isCppKeyword(first) && !isCppSpecifier(first) && !isCppPrimitive(first) {
+		return rest // using, typedef, friend, return, ...
+	}
+
+	constant := hasCppWord(modifiers, "const") || hasCppWord(modifiers, "constexpr") || strings.HasSuffix(fieldType, " const")
+	element := models.CodeElement{
+		Type:       "property",
+		Name:       name,
+		Namespace:  c.namespace(),
+		Visibility: c.visibility(hasCppWord(modifiers, "static")),
+		IsStatic:   hasCppWord(modifiers, "static"),
+		IsReadonly: constant,
+		Line:       lineNum,
+		File:       c.parsed.Path,
+		Documented: documented,
+	}
+	if top := c.top(); top != nil && top.kind == "type" {
+		if top.name == "" && top.typedef == -1 {
+			return rest // A member of an anonymous struct or union, which has no name to belong to
+		}
+		element.ClassName = top.name
+	} else if constant {
+		element.Type = "constant"
+		element.IsStatic, element.IsReadonly = false, false
+	} else {
+		return rest
+	}
+	c.parsed.Elements = append(c.parsed.Elements, element)
+
+	// A member's type is a dependency of its class, such as an owned collaborator
+	if element.ClassName != "" {
+		for _, typeName := range strings.Split(c.typeNames(fieldType), "|") {
+			if typeName != "" {
+				c.parsed.Usage = append(c.parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: element.ClassName, Line: lineNum})
+			}
+		}
+	}
+	return rest
+}
+
+// parseUsage finds instantiations, calls, and type references in code
+func (p *CppParser) parseUsage(c *cppFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	inClass := c.className() != ""
+	add := func(usageType, name, receiver string) {
+		c.parsed.Usage = append(c.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+	addTypes := func(typeDecl string) {
+		for _, typeName := range strings.Split(c.typeNames(typeDecl), "|") {
+			if typeName != "" {
+				add("type_reference", typeName, "")
+			}
+		}
+	}
+
+	for _, match := range p.newPattern.FindAllStringSubmatch(code, -1) {
+		if names := c.typeNames(match[1]); names != "" && !strings.Contains(names, "|") {
+			add("instantiation", names, "")
+		}
+	}
+	for _, match := range p.catchPattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+	for _, match := range p.localPattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+	for _, match := range p.memberPattern.FindAllStringSubmatchIndex(code, -1) {
+		if rest := strings.TrimLeft(code[match[1]:], " \t"); strings.HasPrefix(rest, "(") || strings.HasPrefix(rest, "<") {
+			continue // A call, below
+		}
+		qualifier := code[match[2]:match[3]]
+		if c.isTypeName(qualifier[strings.LastIndex(qualifier, ":")+1:]) {
+			addTypes(qualifier)
+		}
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		qualifier := qualifyCpp(strings.TrimSuffix(strings.Join(strings.Fields(code[match[2]:match[3]]), ""), "::"))
+		name := code[match[4]:match[5]]
+		prefix := strings.TrimRight(code[:match[0]], " \t")
+		if match[6] != -1 { // Template arguments: std::make_unique<UserService>(repo), static_cast<Base*>(p)
+			arguments := code[match[6]:match[7]]
+			if strings.HasPrefix(name, "make_") && qualifier == "std" {
+				if first := strings.Split(c.typeNames(splitTopLevel(arguments)[0]), "|")[0]; first != "" {
+					add("instantiation", first, "")
+				}
+			} else {
+				addTypes(arguments)
+			}
+		}
+		if isCppKeyword(name) || strings.HasSuffix(prefix, "new") || qualifier == "std" || strings.HasPrefix(qualifier, `std\`) {
+			continue
+		}
+
+		if strings.HasSuffix(prefix, ".") || strings.HasSuffix(prefix, "->") {
+			receiver := javaReceiver(strings.TrimRight(strings.TrimSuffix(strings.TrimSuffix(prefix, "."), "->"), " \t"))
+			if receiver == "" {
+				continue // A call on a call's result
+			}
+			add("method_call", name, receiver)
+			continue
+		}
+		if qualifier != "" {
+			if c.isTypeName(qualifier[strings.LastIndex(qualifier, `\`)+1:]) {
+				add("static_call", qualifier+"::"+name, qualifier)
+			} else {
+				add("function_call", qualifier+`\`+name, "") // A namespace's function
+			}
+			continue
+		}
+		// A variable initialized with constructor arguments: User user(name);
+		if word := trailingCppType(prefix); word != "" {
+			addTypes(word)
+			continue
+		}
+		switch {
+		case c.types[name]:
+			add("instantiation", name, "")
+		case inClass:
+			add("method_call", name, "this") // Unqualified calls are on this class, or global
+		default:
+			add("function_call", name, "")
+		}
+	}
+}
+
+// addInclude records an #include directive, resolving the header to a file
+func (c *cppFile) addInclude(header string, system bool, lineNum int) {
+	c.parsed.Includes = append(c.parsed.Includes, models.Include{
+		Path:     header,
+		System:   system,
+		Resolved: resolveInclude(c.parsed.Path, header),
+		Line:     lineNum,
+	})
+}
+
+// resolveInclude finds the file an #include names without the compiler's include paths:
+// next to the including file, or in a directory above it, or in its include or src
+// directory, up to the repository root. Headers it can't find, such as the standard
+// library's, resolve to "".
+func resolveInclude(filePath, header string) string {
+	dir := filepath.Dir(filePath)
+	for {
+		for _, sub := range []string{"", "include", "src"} {
+			candidate := filepath.Join(dir, sub, header)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+		parent := filepath.Dir(dir)
+		if info, err := os.Stat(filepath.Join(dir, ".git")); parent == dir || (err == nil && info.IsDir()) {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// isTypeName checks if a name is a type: declared in the file, or capitalized
+func (c *cppFile) isTypeName(name string) bool {
+	return c.types[name] || isTypeName(name)
+}
+
+// visibility returns the access level of a declaration: a member's from the last access
+// label, and otherwise public, or private for static functions and anonymous namespace
+// members, which can't be used outside their file
+func (c *cppFile) visibility(static bool) string {
+	if top := c.top(); top != nil && top.kind == "type" {
+		return top.access
+	}
+	if static {
+		return "private"
+	}
+	for _, scope := range c.scopes {
+		if scope.internal {
+			return "private"
+		}
+	}
+	return "public"
+}
+
+// top returns the innermost scope, or nil at the top level
+func (c *cppFile) top() *cppScope {
+	if len(c.scopes) == 0 {
+		return nil
+	}
+	return &c.scopes[len(c.scopes)-1]
+}
+
+// declaring reports whether a line at depth is where declarations go: the top level, or
+// directly in a namespace or type body
+func (c *cppFile) declaring(depth int) bool {
+	top := c.top()
+	return top == nil || ((top.kind == "namespace" || top.kind == "type") && top.depth == depth)
+}
+
+// inEnum reports whether a line at depth lists an enum's constants
+func (c *cppFile) inEnum(depth int) bool {
+	top := c.top()
+	return top != nil && top.constants && top.depth == depth
+}
+
+// namespace returns the namespace being parsed, nested namespaces joined
+func (c *cppFile) namespace() string {
+	namespace := ""
+	for _, scope := range c.scopes {
+		if scope.kind == "namespace" {
+			namespace = joinCppNamespace(namespace, scope.name)
+		}
+	}
+	return namespace
+}
+
+// className returns the innermost type being parsed, or the class of the out-of-line
+// method being parsed
+func (c *cppFile) className() string {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		switch {
+		case c.scopes[i].kind == "type":
+			return c.scopes[i].name
+		case c.scopes[i].class != "":
+			return c.scopes[i].class
+		}
+	}
+	return ""
+}
+
+// context returns the innermost function or type being parsed, or "" in a block the
+// parser doesn't recognize
+func (c *cppFile) context() string {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		switch c.scopes[i].kind {
+		case "function", "type":
+			return c.scopes[i].name
+		case "block":
+			return ""
+		}
+	}
+	return ""
+}
+
+// typeNames lists the class names in a type, qualified and separated by "|", leaving out
+// built-in types, the standard library's, and type parameters:
+// "const std::vector<app::User>&" → `app\User`
+func (c *cppFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return !isCSharpWordChar(r) && r != ':'
+	}) {
+		word = strings.Trim(word, ":")
+		last := word[strings.LastIndex(word, ":")+1:]
+		if word == "" || word == "std" || strings.HasPrefix(word, "std:") || !unicode.IsLetter(rune(last[0])) && last[0] != '_' ||
+			len(last) == 1 || isCppPrimitive(last) || isCppKeyword(last) || isCppMacro(last) {
+			continue
+		}
+		names = append(names, qualifyCpp(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// cppOperatorPattern matches an operator's name in a declaration: operator==(,
+// operator()(, operator new[](, operator bool(
+var cppOperatorPattern = regexp.MustCompile(`\boperator\s*(\(\)|\[\]|new\s*(?:\[\])?|delete\s*(?:\[\])?|[^\s\w(]+|[A-Za-z_][\w:<>*&\s]*?)\s*\(`)
+
+// cppNamePattern matches the qualified name ending a declarator's head: app::UserService::save
+var cppNamePattern = regexp.MustCompile(`((?:[A-Za-z_]\w*\s*(?:<[^()]*?>)?\s*::\s*)*)(~?[A-Za-z_]\w*)\s*$`)
+
+// cppQualifierPattern matches the qualifier ending an operator's head: Vector::
+var cppQualifierPattern = regexp.MustCompile(`((?:[A-Za-z_]\w*\s*(?:<[^()]*?>)?\s*::\s*)*)$`)
+
+// cppDeclarator splits a function declaration into the specifiers and return type before
+// its name, the class and namespaces qualifying the name (backslash-separated), the name,
+// its parameter list, and the rest: "const User& app::UserService::find(int id) const {"
+// → ("const User& ", `app\UserService`, "find", "int id", " const {")
+func cppDeclarator(line string) (prefix, qualifier, name, params, rest string, ok bool) {
+	head, open := "", -1
+	if m := cppOperatorPattern.FindStringSubmatchIndex(line); m != nil {
+		head, open = line[:m[0]], m[1]-1
+		name = "operator" + strings.Join(strings.Fields(line[m[2]:m[3]]), " ")
+		q := cppQualifierPattern.FindStringSubmatchIndex(head)
+		prefix, qualifier = head[:q[0]], head[q[2]:q[3]]
+	} else {
+		if open = topLevelIndex(line, '('); open == -1 {
+			return "", "", "", "", "", false
+		}
+		head = line[:open]
+		m := cppNamePattern.FindStringSubmatchIndex(head)
+		if m == nil {
+			return "", "", "", "", "", false
+		}
+		prefix, qualifier, name = head[:m[0]], head[m[2]:m[3]], head[m[4]:m[5]]
+	}
+	if strings.ContainsAny(prefix, "=.;{}()!+-/%|^?\"") {
+		return "", "", "", "", "", false // An expression, not a declaration
+	}
+	close := closingParen(line[open:])
+	if close == -1 {
+		return "", "", "", "", "", false
+	}
+	qualifier = qualifyCpp(strings.TrimSuffix(strings.Join(strings.Fields(qualifier), ""), "::"))
+	return prefix, qualifier, name, line[open+1 : open+close], line[open+close+1:], true
+}
+
+// cppTypeWords drops the specifiers that aren't part of a return type from the words
+// before a function's name, leaving "" for constructors and destructors
+func cppTypeWords(words []string) []string {
+	var kept []string
+	for _, word := range words {
+		switch word {
+		case "static", "inline", "virtual", "explicit", "constexpr", "consteval", "constinit", "extern", "friend", "template":
+			continue
+		}
+		if isCppMacro(word) {
+			continue // Export macros: APP_API
+		}
+		kept = append(kept, word)
+	}
+	return kept
+}
+
+// cppParameter splits a parameter declaration into its name and type, or returns "" for
+// an unnamed one: "const std::string& name = \"\"" → ("name", "const std::string&")
+func cppParameter(param string) (string, string) {
+	if idx := topLevelIndex(param, '='); idx != -1 {
+		param = param[:idx]
+	}
+	if idx := strings.Index(param, "["); idx != -1 {
+		param = param[:idx] // int values[]
+	}
+	param = strings.TrimSpace(param)
+	start := len(param)
+	for start > 0 && isCSharpWordChar(rune(param[start-1])) {
+		start--
+	}
+	name, paramType := param[start:], strings.TrimSpace(param[:start])
+	if name == "" || paramType == "" || strings.HasSuffix(paramType, ":") || isCppKeyword(name) || isCppPrimitive(name) {
+		return "", ""
+	}
+	return name, paramType
+}
+
+// trailingCppType returns the type ending the code before a call when the call is a
+// variable declared with constructor arguments (User user(name)), or ""
+func trailingCppType(prefix string) string {
+	start := len(prefix)
+	for start > 0 && (isCSharpWordChar(rune(prefix[start-1])) || prefix[start-1] == ':') {
+		start--
+	}
+	word := strings.TrimLeft(prefix[start:], ":")
+	last := word[strings.LastIndex(word, ":")+1:]
+	if last == "" || isCppKeyword(last) || isCppMacro(last) || unicode.IsDigit(rune(last[0])) {
+		return ""
+	}
+	return word
+}
+
+// stripCppAttributes removes leading [[attributes]] from a declaration
+func stripCppAttributes(code string) string {
+	for {
+		trimmed := strings.TrimSpace(code)
+		if !strings.HasPrefix(trimmed, "[[") {
+			return code
+		}
+		end := strings.Index(trimmed, "]]")
+		if end == -1 {
+			return code
+		}
+		code = trimmed[end+2:]
+	}
+}
+
+// stripCppTemplate removes a leading template parameter list from a declaration
+func stripCppTemplate(code string) string {
+	trimmed := strings.TrimSpace(code)
+	if !strings.HasPrefix(trimmed, "template") {
+		return code
+	}
+	rest := strings.TrimSpace(trimmed[len("template"):])
+	if !strings.HasPrefix(rest, "<") {
+		return code
+	}
+	return skipTypeParameters(rest)
+}
+
+// qualifyCpp turns a qualified C++ name into the analyzer's form, with backslashes
+// between namespaces and without template arguments: app::Cache<int> → app\Cache
+func qualifyCpp(name string) string {
+	if idx := strings.Index(name, "<"); idx != -1 {
+		name = name[:idx]
+	}
+	return strings.ReplaceAll(strings.TrimPrefix(strings.TrimSpace(name), "::"), "::", `\`)
+}
+
+// joinCppNamespace nests a namespace in another, either of which may be ""
+func joinCppNamespace(outer, inner string) string {
+	switch {
+	case outer == "":
+		return inner
+	case inner == "":
+		return outer
+	}
+	return outer + `\` + inner
+}
+
+// hasCppWord checks if a list of words contains word
+func hasCppWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// isCppMacroLine checks if a line is only a macro invocation, such as Q_OBJECT or
+// DECLARE_LOGGER(UserService), which ends without a semicolon
+func isCppMacroLine(line string) bool {
+	line = strings.TrimSpace(line)
+	if idx := strings.Index(line, "("); idx != -1 && strings.HasSuffix(strings.TrimRight(line, ";"), ")") {
+		line = line[:idx]
+	}
+	return isCppMacro(strings.TrimSpace(strings.TrimRight(line, ";")))
+}
+
+// isCppMacro checks if a name follows the convention for macros: upper case, with an
+// underscore, like APP_EXPORT and Q_OBJECT
+func isCppMacro(name string) bool {
+	return strings.Contains(name, "_") && name == strings.ToUpper(name) && strings.ToLower(name) != name
+}
+
+// isCppSpecifier checks if a keyword can begin a declaration's type
+func isCppSpecifier(word string) bool {
+	switch word {
+	case "static", "inline", "virtual", "explicit", "constexpr", "consteval", "constinit",
+		"extern", "friend", "const", "volatile", "mutable", "typename", "struct", "enum",
+		"union", "unsigned", "signed", "long", "short", "auto", "thread_local", "template":
+		return true
+	}
+	return false
+}
+
+// isCppKeyword checks if a word is a C or C++ keyword that can precede a parenthesis or a
+// name in a statement
+func isCppKeyword(word string) bool {
+	switch word {
+	case "if", "else", "for", "while", "do", "switch", "case", "default", "return", "break",
+		"continue", "goto", "try", "catch", "throw", "new", "delete", "sizeof", "alignof",
+		"alignas", "decltype", "typeid", "static_cast", "dynamic_cast", "const_cast",
+		"reinterpret_cast", "noexcept", "static_assert", "co_await", "co_return", "co_yield",
+		"operator", "template", "typename", "using", "namespace", "class", "struct", "union",
+		"enum", "typedef", "this", "nullptr", "true", "false", "public", "private", "protected",
+		"static", "inline", "virtual", "explicit", "constexpr", "consteval", "constinit",
+		"extern", "friend", "const", "volatile", "mutable", "register", "thread_local",
+		"requires", "concept", "export", "defined", "unsigned", "signed", "long", "short",
+		"auto", "and", "or", "not":
+		return true
+	}
+	return false
+}
+
+// isCppPrimitive checks if a type name is built in, or one of the standard fixed-width
+// and size types C code uses without a namespace
+func isCppPrimitive(name string) bool {
+	switch name {
+	case "void", "bool", "char", "wchar_t", "char8_t", "char16_t", "char32_t", "short", "int",
+		"long", "float", "double", "unsigned", "signed", "auto", "size_t", "ssize_t",
+		"ptrdiff_t", "intptr_t", "uintptr_t", "int8_t", "int16_t", "int32_t", "int64_t",
+		"uint8_t", "uint16_t", "uint32_t", "uint64_t", "nullptr_t", "FILE", "va_list":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple C and C++ files concurrently
+func (p *CppParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *CppParser) Language() string {
+	return "cpp"
+}
+
+// FileExtensions returns the file extensions supported by this parser: C's, and C++'s
+// sources, headers, and inline template implementations
+func (p *CppParser) FileExtensions() []string {
+	return []string{".c", ".h", ".cc", ".cpp", ".cxx", ".c++", ".hh", ".hpp", ".hxx", ".h++", ".inl", ".ipp", ".tpp"}
+}
+
+// DefaultExcludes returns the directories skipped in C and C++ projects: build output
+// and bundled third-party code
+func (p *CppParser) DefaultExcludes() []string {
+	return []string{"build", "out", "CMakeFiles", "third_party", "3rdparty", "external"}
+}
+
+// Entrypoints returns the functions called without a call in the code: main and its
+// Windows variants, destructors, operators, test fixture hooks, and Qt event handlers
+func (p *CppParser) Entrypoints() []string {
+	return []string{
+		`^(main|wmain|_tmain|WinMain|wWinMain|DllMain)$`,
+		`^(~|operator)`,
+		`^(SetUp|TearDown|SetUpTestSuite|TearDownTestSuite|TestBody)$`,
+		`^(paint|resize|show|hide|close|mousePress|mouseRelease|mouseMove|mouseDoubleClick|wheel|keyPress|keyRelease|focusIn|focusOut|timer|change|dragEnter|dragMove|dragLeave|drop|context)Event$`,
+	}
+}
+
+func init() {
+	parser.Register(NewCppParser())
+}
@@ -0,0 +1,255 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestCppParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"include/app", "src"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "include/app/repository.h", "#pragma once\n")
+	header := writeFixture(t, tmp, "include/app/user_service.h", `#ifndef APP_USER_SERVICE_H
+#define APP_USER_SERVICE_H
+
+#include <memory>
+#include <string>
+#include "app/repository.h"
+
+namespace app {
+
+/// Manages users.
+class APP_EXPORT UserService : public Service, public IObserver {
+    Q_OBJECT
+public:
+    explicit UserService(std::shared_ptr<Repository> repo);
+    ~UserService() override;
+
+    User find(int id) const;
+    virtual void notify(const Event& event) = 0;
+    static int count() { return instances_; }
+
+private:
+    std::shared_ptr<Repository> repo_;
+    static int instances_;
+    Cache cache_; // TODO: bound the cache
+};
+
+enum class Status { Active, Suspended };
+
+}  // namespace app
+
+#endif
+`)
+	source := writeFixture(t, tmp, "src/user_service.cpp", `#include "app/user_service.h"
+#include "missing.h"
+
+#if 0
+void disabled() {}
+#endif
+
+namespace app {
+namespace {
+constexpr int kLimit = 10;
+
+bool valid(int id) { return id > 0; }
+}  // namespace
+
+UserService::UserService(std::shared_ptr<Repository> repo)
+    : repo_(std::move(repo)),
+      cache_(kLimit)
+{
+    Logger::instance().info("created");
+}
+
+UserService::~UserService() = default;
+
+User UserService::find(int id) const
+{
+    if (!valid(id)) {
+        throw NotFound(id);
+    }
+    auto user = repo_->load(id);
+    User copy(user);
+    auto audit = std::make_unique<AuditLog>(id);
+    return *static_cast<User*>(&copy);
+}
+
+}  // namespace app
+
+/* a block
+   comment */
+static void helper(const char* name, int count);
+
+int main(int argc, char** argv) {
+    app::UserService service(nullptr);
+    return app::util::run(argc);
+}
+`)
+
+	p := NewCppParser()
+	parsedHeader, err := p.ParseFile(header)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	parsedSource, err := p.ParseFile(source)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsedHeader.Language != "cpp" || parsedHeader.Namespace != "app" {
+		t.Errorf("expected the first namespace, got %q (%q)", parsedHeader.Namespace, parsedHeader.Language)
+	}
+
+	includes := parsedHeader.Includes
+	if len(includes) != 3 || !includes[0].System || includes[0].Resolved != "" || includes[2].Path != "app/repository.h" ||
+		includes[2].Resolved != filepath.Join(tmp, "include/app/repository.h") || includes[2].Line != 6 {
+		t.Errorf("expected two system includes and the repository header resolved next to the file, got %+v", includes)
+	}
+	includes = parsedSource.Includes
+	if len(includes) != 2 || includes[0].Resolved != header || includes[1].Resolved != "" || includes[1].System {
+		t.Errorf("expected the service header resolved from the include directory and missing.h unresolved, got %+v", includes)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range append(parsedHeader.Elements, parsedSource.Elements...) {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "method:UserService.notify", "method:UserService.count",
+		"property:UserService.repo_", "property:UserService.instances_", "property:UserService.cache_",
+		"enum:.Status", "constant:Status.Active", "constant:Status.Suspended", "constant:.kLimit",
+		"function:.valid", "method:UserService.find", "function:.main"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, append(parsedHeader.Elements, parsedSource.Elements...))
+		}
+	}
+	if len(parsedHeader.Elements) != 9 || len(parsedSource.Elements) != 4 {
+		t.Errorf("expected 9 elements in the header and 4 in the source, without prototypes, constructors, or #if 0 code, got %+v and %+v",
+			parsedHeader.Elements, parsedSource.Elements)
+	}
+
+	service := elements["class:.UserService"]
+	if !service.Documented || service.Namespace != "app" || service.Line != 11 || elements["method:UserService.find"].Documented {
+		t.Errorf("expected only the class with a doc comment to be documented, got %+v", service)
+	}
+	find := elements["method:UserService.find"]
+	if find.Namespace != "app" || find.Line != 24 || find.ReturnType != "User" || len(find.Parameters) != 1 || find.Parameters[0] != "id" {
+		t.Errorf("expected the out-of-line definition with its signature, got %+v", find)
+	}
+	if notify := elements["method:UserService.notify"]; !notify.IsAbstract || notify.Visibility != "public" || notify.ParamTypes[0] != "Event" {
+		t.Errorf("expected the pure virtual method to be abstract and public, got %+v", notify)
+	}
+	if count := elements["method:UserService.count"]; !count.IsStatic || count.IsAbstract {
+		t.Errorf("expected the inline static method, got %+v", count)
+	}
+	if elements["property:UserService.repo_"].Visibility != "private" || !elements["property:UserService.instances_"].IsStatic {
+		t.Error("expected the members after private: to be private")
+	}
+	if elements["function:.valid"].Visibility != "private" || elements["constant:.kLimit"].Visibility != "private" || elements["function:.main"].Visibility != "public" {
+		t.Error("expected the anonymous namespace's declarations to be private")
+	}
+	if main := elements["function:.main"]; main.Namespace != "" || len(main.Parameters) != 2 || main.Parameters[1] != "argv" {
+		t.Errorf("expected main in the global namespace, got %+v", main)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range append(parsedHeader.Usage, parsedSource.Usage...) {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"extends:.Service in UserService",
+		"implements:.IObserver in UserService",
+		"type_reference:.Repository in UserService",
+		"type_reference:.Cache in UserService",
+		"static_call:Logger.Logger::instance in UserService",
+		"method_call:this.valid in find",
+		"method_call:repo_.load in find",
+		"type_reference:.User in find",
+		"instantiation:.AuditLog in find",
+		`type_reference:.app\UserService in main`,
+		`function_call:.app\util\run in main`,
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, append(parsedHeader.Usage, parsedSource.Usage...))
+		}
+	}
+	for _, key := range []string{"method_call:this.copy in find", "method_call:this.move in UserService", "function_call:.static_cast in find"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsedHeader.Debt) != 1 || parsedHeader.Debt[0].Line != 24 {
+		t.Errorf("expected the TODO on line 24, got %+v", parsedHeader.Debt)
+	}
+	if parsedSource.CommentLines != 2 {
+		t.Errorf("expected 2 comment lines, got %d", parsedSource.CommentLines)
+	}
+}
+
+func TestCppParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "shapes.h", `#pragma once
+
+struct Circle {
+    explicit Circle(double r) : radius(r) {}
+    double area() const { return 3.14159 * radius * radius; }
+    double radius;
+};
+`)
+	writeFixture(t, tmp, "main.cpp", `#include "shapes.h"
+
+static double total(const Circle& c)
+{
+    return c.area();
+}
+
+int main()
+{
+    auto* other = new Circle(3.0);
+    return total(*other) > 0;
+}
+`)
+
+	p := NewCppParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"shapes.h", "main.cpp"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.ClassName+"."+node.Name] = node
+	}
+	main, circle, total := nodes[".main"], nodes[".Circle"], nodes[".total"]
+	if main == nil || circle == nil || main.Dependencies[circle.ID] == nil {
+		t.Fatalf("expected main to construct the Circle, got %+v", main)
+	}
+	if total == nil || main.Dependencies[total.ID] == nil || !main.IsEntrypoint || total.IsEntrypoint {
+		t.Errorf("expected main, an entrypoint, to call total, got %+v", main.Dependencies)
+	}
+	if area := nodes["Circle.area"]; area == nil || total.Dependencies[area.ID] == nil {
+		t.Errorf("expected total to call the inline method, got %+v", total.Dependencies)
+	}
+	if graph.Includes == nil || len(graph.Includes.Edges) != 1 || graph.Includes.Edges[0].To != filepath.Join(tmp, "shapes.h") {
+		t.Errorf("expected main.cpp to include shapes.h, got %+v", graph.Includes)
+	}
+}
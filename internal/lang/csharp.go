@@ -0,0 +1,820 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// CSharpParser handles parsing of C# files
+type CSharpParser struct {
+	namespacePattern *regexp.Regexp
+	usingPattern     *regexp.Regexp
+	typePattern      *regexp.Regexp
+	methodPattern    *regexp.Regexp
+	memberPattern    *regexp.Regexp
+	newPattern       *regexp.Regexp
+	callPattern      *regexp.Regexp
+	catchPattern     *regexp.Regexp
+	typeCheckPattern *regexp.Regexp
+	tablePattern     *regexp.Regexp
+}
+
+// csharpScope is a namespace, type, or member body the parser is inside
+type csharpScope struct {
+	kind      string // "namespace", "type", or "member"
+	name      string
+	depth     int  // Brace depth inside the body
+	constants bool // An enum body
+}
+
+// csharpFile is the state of one file's parse
+type csharpFile struct {
+	parsed        *models.ParsedFile
+	aliases       map[string]string // using Alias = Target; → "Alias" → "Target", dotted
+	fileNamespace string            // A file-scoped namespace: namespace Acme.Users;
+	scopes        []csharpScope
+}
+
+// NewCSharpParser creates a new C# parser with compiled regex patterns
+func NewCSharpParser() *CSharpParser {
+	return &CSharpParser{
+		// Namespaces: namespace Acme.Users { ... }, or file-scoped: namespace Acme.Users;
+		namespacePattern: regexp.MustCompile(`^\s*namespace\s+([\w.]+)\s*([;{])?`),
+
+		// Using directives: using System.Linq; using static System.Math; using Json = Newtonsoft.Json;
+		usingPattern: regexp.MustCompile(`^\s*(?:global\s+)?using\s+(static\s+)?(?:([A-Za-z_]\w*)\s*=\s*)?([\w.]+(?:<[^;]*>)?)\s*;`),
+
+		// Types: public sealed partial class UserService : BaseService, IUserService
+		typePattern: regexp.MustCompile(`^\s*((?:(?:public|protected|private|internal|abstract|sealed|static|partial|unsafe|new|readonly|ref|file)\s+)*)(class|interface|struct|enum|record\s+struct|record\s+class|record)\s+([A-Za-z_]\w*)`),
+
+		// Methods and constructors: public async Task<User> FindAsync(int id), void IDisposable.Dispose()
+		methodPattern: regexp.MustCompile(`^\s*((?:(?:public|protected|private|internal|static|virtual|override|abstract|sealed|async|extern|unsafe|new|partial|readonly)\s+)*)(?:([\w.<>\[\]?,\s]+?)\s+)?(?:[A-Za-z_][\w.]*(?:<[^()]*>)?\.)?([A-Za-z_]\w*)\s*(?:<[^()]*>)?\s*\(`),
+
+		// Fields, properties, and events: private readonly IUserRepository _users;
+		// public string Name { get; set; }, public int Count => _items.Count;
+		memberPattern: regexp.MustCompile(`^\s*((?:(?:public|protected|private|internal|static|readonly|const|volatile|virtual|override|abstract|sealed|new|unsafe|required|event|extern)\s+)*)([\w.<>\[\]?,\s]+?)\s+(?:[A-Za-z_][\w.]*\.)?([A-Za-z_]\w*)\s*(=>|=|;|,|\{)`),
+
+		// Instantiations: new User(), new List<User>(), new Dictionary<string, int> { ... }
+		newPattern: regexp.MustCompile(`\bnew\s+([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\s*(?:<[^()]*>)?\s*[({\[]`),
+
+		// Calls, with any type arguments: Save(user), _users.Find(id), GetComponent<Rigidbody>()
+		callPattern: regexp.MustCompile(`([A-Za-z_]\w*)\s*(?:<([\w.,\s\[\]?]*(?:<[\w.,\s\[\]?]*>)?[\w.,\s\[\]?]*)>)?\s*\(`),
+
+		// Exception handlers: catch (InvalidOperationException e)
+		catchPattern: regexp.MustCompile(`\bcatch\s*\(\s*([\w.]+)`),
+
+		// Type checks and casts: value is User user, value as User, typeof(User)
+		typeCheckPattern: regexp.MustCompile(`(?:\b(?:is|as)\s+(?:not\s+)?|\btypeof\s*\(\s*)([A-Z]\w*(?:\.[A-Za-z_]\w*)*)`),
+
+		// Entity Framework entities: [Table("users")]
+		tablePattern: regexp.MustCompile(`\[\s*(?:[\w.]*\.)?Table(?:Attribute)?\s*\(\s*"([^"]+)"`),
+	}
+}
+
+// ParseFile analyzes a single C# file and extracts all elements
+func (p *CSharpParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	c := &csharpFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		aliases: make(map[string]string),
+	}
+	parsed := c.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	inComment := false
+	docblock := false // A /// XML documentation comment precedes the next declaration
+
+	var attributes []string // Attributes awaiting the declaration they apply to
+	var tables []string     // [Table] names awaiting their entity class
+
+	join := func(code, bare string) (string, string) {
+		joinedLines++
+		nextCode, nextBare, nextInComment := stripJSLine(scanner.Text(), inComment)
+		inComment = nextInComment
+		return code + " " + strings.TrimSpace(nextCode), bare + " " + strings.TrimSpace(nextBare)
+	}
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if !inComment && (strings.HasPrefix(trimmed, "///") || strings.HasPrefix(trimmed, "/**")) {
+			docblock = true
+		}
+		code, bare, stillInComment := stripJSLine(line, inComment)
+		inComment = stillInComment
+		if strings.TrimSpace(bare) == "" {
+			if trimmed != "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(bare), "#") {
+			continue // Preprocessor directives and regions
+		}
+
+		// Join multi-line parameter lists and calls, so they're parsed whole
+		for parenBalance(bare) > 0 && !inComment && scanner.Scan() {
+			code, bare = join(code, bare)
+		}
+		// Declarations wrap before their body opens, in Allman style always: the brace, or a
+		// base list or constraint, is on a line of its own
+		if c.declaring(braceDepth) && !c.inEnum(braceDepth) {
+			for rest := stripCSharpAttributes(bare); strings.TrimSpace(rest) != "" && !strings.ContainsAny(rest, "{;}") && !inComment && scanner.Scan(); rest = stripCSharpAttributes(bare) {
+				code, bare = join(code, bare)
+			}
+		}
+
+		// Attributes on their own lines wait for the declaration that follows them
+		if c.declaring(braceDepth) {
+			if m := p.tablePattern.FindStringSubmatch(code); m != nil {
+				tables = append(tables, m[1])
+			}
+			var found []string
+			bare, found = csharpAttributes(bare)
+			attributes = append(attributes, found...)
+			if strings.TrimSpace(bare) == "" {
+				continue
+			}
+		}
+
+		documented := docblock
+		docblock = false
+		attributing := attributes
+		attributes = nil
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := c.top()
+		declaring := c.declaring(depthBefore)
+		inTypeBody := top != nil && top.kind == "type" && top.depth == depthBefore
+
+		if inTypeBody && top.constants {
+			body = c.parseEnumConstants(bare, lineNum, documented)
+			c.addAttributes(attributing, top.name, lineNum)
+		} else if matches := p.namespacePattern.FindStringSubmatchIndex(bare); declaring && !inTypeBody && matches != nil {
+			name := bare[matches[2]:matches[3]]
+			if matches[4] != -1 && bare[matches[4]:matches[5]] == ";" {
+				c.fileNamespace = name
+			} else {
+				c.scopes = append(c.scopes, csharpScope{kind: "namespace", name: name, depth: depthBefore + 1})
+			}
+			if parsed.Namespace == "" {
+				parsed.Namespace = c.namespace()
+			}
+			body = ""
+		} else if matches := p.usingPattern.FindStringSubmatch(bare); declaring && !inTypeBody && matches != nil {
+			c.addUsing(matches[3], matches[2], matches[1] != "")
+			body = ""
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := strings.Join(strings.Fields(bare[matches[4]:matches[5]]), " ")
+			name := bare[matches[6]:matches[7]]
+			visibility := "internal"
+			if c.className() != "" {
+				visibility = "private" // Nested
+			}
+			element := models.CodeElement{
+				Type:       "class",
+				Name:       name,
+				Namespace:  c.namespace(),
+				Visibility: csharpVisibility(modifiers, visibility),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				IsAbstract: strings.Contains(modifiers, "abstract"),
+				IsReadonly: strings.HasPrefix(keyword, "record") || strings.Contains(modifiers, "readonly"),
+			}
+			switch keyword {
+			case "interface":
+				element.Type = "interface"
+			case "enum":
+				element.Type = "enum"
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			c.addAttributes(attributing, name, lineNum)
+			for _, table := range tables {
+				parsed.Tables = append(parsed.Tables, models.TableReference{Table: table, Kind: "model", ClassName: name, Line: lineNum})
+			}
+
+			header := bare[matches[1]:]
+			body = ""
+			if idx := strings.Index(header, "{"); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+				c.scopes = append(c.scopes, csharpScope{kind: "type", name: name, depth: depthBefore + 1, constants: keyword == "enum"})
+				if keyword == "enum" && strings.TrimSpace(body) != "" {
+					body = c.parseEnumConstants(body, lineNum, false)
+				}
+			}
+			c.parseTypeHeader(header, keyword, name, lineNum)
+		} else if inTypeBody {
+			className := top.name
+			inInterface := c.isInterface(className)
+			defaultVisibility := "private"
+			if inInterface {
+				defaultVisibility = "public"
+			}
+
+			if method := p.methodPattern.FindStringSubmatchIndex(bare); method != nil && c.isMethod(bare, method) {
+				modifiers := bare[method[2]:method[3]]
+				returnType := ""
+				if method[4] != -1 {
+					returnType = bare[method[4]:method[5]]
+				}
+				name := bare[method[6]:method[7]]
+				rest := bare[method[1]-1:]
+				params := ""
+				if end := closingParen(rest); end != -1 {
+					params, rest = rest[1:end], rest[end+1:]
+				}
+
+				element := models.CodeElement{
+					Type:       "method",
+					Name:       name,
+					Namespace:  c.namespace(),
+					ClassName:  className,
+					Visibility: csharpVisibility(modifiers, defaultVisibility),
+					IsStatic:   strings.Contains(modifiers, "static"),
+					IsAbstract: strings.Contains(modifiers, "abstract") || (inInterface && !strings.ContainsAny(rest, "{=")),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+					Parameters: []string{},
+					ReturnType: c.typeNames(returnType),
+				}
+				for _, param := range splitTopLevel(params) {
+					paramName, paramType := csharpParameter(param)
+					if paramName == "" {
+						continue
+					}
+					element.Parameters = append(element.Parameters, paramName)
+					element.ParamTypes = append(element.ParamTypes, c.typeNames(paramType))
+				}
+				parsed.Elements = append(parsed.Elements, element)
+				c.addAttributes(attributing, name, lineNum)
+
+				body, context = c.memberBody(rest, name, depthBefore)
+			} else if index := p.memberPattern.FindStringSubmatchIndex(bare); index != nil && !isCSharpKeyword(strings.Fields(bare[index[4]:index[5]])[0]) {
+				modifiers, memberType := bare[index[2]:index[3]], strings.TrimSpace(bare[index[4]:index[5]])
+				name, terminator := bare[index[6]:index[7]], bare[index[8]:index[9]]
+				rest := bare[index[8]:]
+				property := terminator == "{" || terminator == "=>" || strings.Contains(modifiers, "event")
+				element := models.CodeElement{
+					Type:       "property",
+					Name:       name,
+					Namespace:  c.namespace(),
+					ClassName:  className,
+					Visibility: csharpVisibility(modifiers, defaultVisibility),
+					IsStatic:   strings.Contains(modifiers, "static"),
+					IsAbstract: strings.Contains(modifiers, "abstract") || (inInterface && property && terminator != "=>" && !strings.Contains(rest, "=>")),
+					IsReadonly: strings.Contains(modifiers, "readonly") || terminator == "=>" || (terminator == "{" && readonlyAccessors(rest)),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				}
+				if strings.Contains(modifiers, "const") {
+					element.Type = "constant"
+					element.IsStatic, element.IsReadonly = false, false
+				}
+				parsed.Elements = append(parsed.Elements, element)
+				c.addAttributes(attributing, className, lineNum)
+
+				// A member's type is a dependency of its class, such as an injected service
+				for _, typeName := range strings.Split(c.typeNames(memberType), "|") {
+					if typeName != "" {
+						parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: className, Line: lineNum})
+					}
+				}
+				body = rest
+				if property {
+					body, context = c.memberBody(rest, name, depthBefore)
+				}
+			} else {
+				c.addAttributes(attributing, className, lineNum)
+			}
+		} else {
+			c.addAttributes(attributing, c.context(), lineNum)
+		}
+
+		if context == "" {
+			context = c.context()
+		}
+		p.parseUsage(c, body, lineNum, context)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, c.className(), context)...)
+
+		// Leave the bodies closed on this line
+		for len(c.scopes) > 0 && braceDepth < c.scopes[len(c.scopes)-1].depth {
+			c.scopes = c.scopes[:len(c.scopes)-1]
+		}
+		tables = nil
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// memberBody returns the code following a method or property declaration, and the member
+// it belongs to. A block body opens a scope for the lines that follow; an expression body
+// (=> ...) ends on its line.
+func (c *csharpFile) memberBody(rest, name string, depthBefore int) (string, string) {
+	arrow := strings.Index(rest, "=>")
+	brace := strings.Index(rest, "{")
+	switch {
+	case brace != -1 && (arrow == -1 || brace < arrow):
+		c.scopes = append(c.scopes, csharpScope{kind: "member", name: name, depth: depthBefore + 1})
+		return rest[brace+1:], name
+	case arrow != -1:
+		if brace != -1 {
+			c.scopes = append(c.scopes, csharpScope{kind: "member", name: name, depth: depthBefore + 1})
+		}
+		return rest[arrow+2:], name
+	}
+	return "", name
+}
+
+// addUsing records a using directive. A plain one imports a namespace, which can't be
+// resolved to a class; an alias or a using static names one.
+func (c *csharpFile) addUsing(target, alias string, static bool) {
+	target = strings.TrimPrefix(target, "global::")
+	switch {
+	case alias != "":
+		c.aliases[alias] = target
+		c.parsed.Uses = append(c.parsed.Uses, qualifyJava(target))
+	case static:
+		c.parsed.Uses = append(c.parsed.Uses, qualifyJava(target))
+	default:
+		c.parsed.Uses = append(c.parsed.Uses, target)
+	}
+}
+
+// parseEnumConstants records the constants an enum declares on a line, and returns the
+// code after them, such as their values, for usage parsing
+func (c *csharpFile) parseEnumConstants(line string, lineNum int, documented bool) string {
+	scope := c.scopes[len(c.scopes)-1]
+	line, _ = csharpAttributes(line)
+	for _, constant := range splitTopLevel(strings.TrimRight(strings.TrimSpace(line), "};")) {
+		constant = stripCSharpAttributes(constant)
+		constant = strings.TrimSpace(constant)
+		end := 0
+		for end < len(constant) && isCSharpWordChar(rune(constant[end])) {
+			end++
+		}
+		if end == 0 {
+			continue
+		}
+		c.parsed.Elements = append(c.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       constant[:end],
+			Namespace:  c.namespace(),
+			ClassName:  scope.name,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       c.parsed.Path,
+			Documented: documented,
+		})
+	}
+	return line
+}
+
+// parseTypeHeader records a type declaration's base list. A class or record extends the
+// first type listed unless it's named like an interface (IComparable); the rest, like all
+// of a struct's, are implemented. An interface extends the interfaces it lists.
+func (c *csharpFile) parseTypeHeader(header, keyword, name string, lineNum int) {
+	header = skipTypeParameters(strings.TrimSpace(header))
+	if strings.HasPrefix(header, "(") { // A record's primary constructor
+		if end := closingParen(header); end != -1 {
+			header = header[end+1:]
+		}
+	}
+	header = strings.TrimSpace(header)
+	if keyword == "enum" || !strings.HasPrefix(header, ":") {
+		return
+	}
+	header = header[1:]
+	if idx := strings.Index(header, " where "); idx != -1 {
+		header = header[:idx]
+	}
+
+	for i, base := range splitTopLevel(header) {
+		base = strings.TrimSpace(base)
+		if idx := strings.IndexAny(base, "<("); idx != -1 {
+			base = strings.TrimSpace(base[:idx])
+		}
+		if base == "" {
+			continue
+		}
+		usageType := "implements"
+		switch {
+		case keyword == "interface":
+			usageType = "extends"
+		case i == 0 && !strings.Contains(keyword, "struct") && !isInterfaceName(base):
+			usageType = "extends"
+		}
+		c.parsed.Usage = append(c.parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    c.qualify(base),
+			Context: name,
+			Line:    lineNum,
+		})
+	}
+}
+
+// parseUsage finds instantiations, calls, and type references in code
+func (p *CSharpParser) parseUsage(c *csharpFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	add := func(usageType, name, receiver string) {
+		c.parsed.Usage = append(c.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+
+	for _, match := range p.newPattern.FindAllStringSubmatch(code, -1) {
+		if !isCSharpPrimitive(match[1]) {
+			add("instantiation", c.qualify(match[1]), "")
+		}
+	}
+	for _, match := range p.catchPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", c.qualify(match[1]), "")
+	}
+	for _, match := range p.typeCheckPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", c.qualify(match[1]), "")
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		if isCSharpKeyword(name) || strings.HasSuffix(prefix, "new") {
+			continue
+		}
+		if match[4] != -1 { // Type arguments: GetComponent<Rigidbody>()
+			for _, typeName := range strings.Split(c.typeNames(code[match[4]:match[5]]), "|") {
+				if typeName != "" {
+					add("type_reference", typeName, "")
+				}
+			}
+		}
+		if !strings.HasSuffix(prefix, ".") {
+			add("method_call", name, "this") // Unqualified calls are on this class
+			continue
+		}
+
+		receiver := javaReceiver(strings.TrimRight(strings.TrimSuffix(prefix, "."), " \t?!"))
+		switch {
+		case receiver == "this":
+			add("method_call", name, "this")
+		case receiver != "" && isTypeName(receiver):
+			add("static_call", c.qualify(receiver)+"::"+name, c.qualify(receiver))
+		default:
+			add("method_call", name, receiver)
+		}
+	}
+}
+
+// addAttributes records attributes as usage of the attribute classes by context. [Audit]
+// names AuditAttribute, by the convention the compiler follows.
+func (c *csharpFile) addAttributes(attributes []string, context string, lineNum int) {
+	for _, name := range attributes {
+		if !strings.HasSuffix(name, "Attribute") {
+			name += "Attribute"
+		}
+		c.parsed.Usage = append(c.parsed.Usage, models.UsageElement{
+			Type:    "attribute",
+			Name:    c.qualify(name),
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// isMethod tells a method or constructor declaration matched by methodPattern from a
+// statement that looks like one, such as a field initialized by a call
+func (c *csharpFile) isMethod(line string, match []int) bool {
+	name := line[match[6]:match[7]]
+	if isCSharpKeyword(name) {
+		return false
+	}
+	if match[4] == -1 {
+		return name == c.scopes[len(c.scopes)-1].name // Constructors have no return type
+	}
+	returnType := strings.Fields(line[match[4]:match[5]])
+	return len(returnType) > 0 && !isCSharpKeyword(returnType[0]) && !strings.Contains(line[:match[1]], "=")
+}
+
+// isInterface checks if name is an interface declared in this file
+func (c *csharpFile) isInterface(name string) bool {
+	for _, element := range c.parsed.Elements {
+		if element.Name == name && element.Type == "interface" {
+			return true
+		}
+	}
+	return false
+}
+
+// top returns the innermost scope, or nil at the top level
+func (c *csharpFile) top() *csharpScope {
+	if len(c.scopes) == 0 {
+		return nil
+	}
+	return &c.scopes[len(c.scopes)-1]
+}
+
+// declaring reports whether a line at depth is where declarations go: the top level, or
+// directly in a namespace or type body
+func (c *csharpFile) declaring(depth int) bool {
+	top := c.top()
+	return top == nil || (top.kind != "member" && top.depth == depth)
+}
+
+// inEnum reports whether a line at depth lists an enum's constants
+func (c *csharpFile) inEnum(depth int) bool {
+	top := c.top()
+	return top != nil && top.constants && top.depth == depth
+}
+
+// namespace returns the namespace being parsed, nested namespaces joined
+func (c *csharpFile) namespace() string {
+	var parts []string
+	if c.fileNamespace != "" {
+		parts = append(parts, c.fileNamespace)
+	}
+	for _, scope := range c.scopes {
+		if scope.kind == "namespace" {
+			parts = append(parts, scope.name)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// className returns the innermost type being parsed
+func (c *csharpFile) className() string {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if c.scopes[i].kind == "type" {
+			return c.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the innermost member or type being parsed
+func (c *csharpFile) context() string {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if c.scopes[i].kind != "namespace" {
+			return c.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// qualify names a type the way the analyzer indexes it. Aliased and dotted names get
+// their namespace; plain ones are left for the analyzer to find, since a using directive
+// imports a whole namespace.
+func (c *csharpFile) qualify(name string) string {
+	name = strings.TrimPrefix(name, "global::")
+	first, rest, dotted := strings.Cut(name, ".")
+	if target, ok := c.aliases[first]; ok {
+		if dotted {
+			return qualifyJava(target + "." + rest)
+		}
+		return qualifyJava(target)
+	}
+	if dotted {
+		return qualifyJava(name)
+	}
+	return name
+}
+
+// typeNames lists the class names in a type, qualified and separated by "|", leaving
+// out built-in types and type parameters: "Task<List<User>>" → "Task|List|User"
+func (c *csharpFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(strings.ReplaceAll(typeDecl, "global::", ""), func(r rune) bool {
+		return !isCSharpWordChar(r) && r != '.'
+	}) {
+		word = strings.Trim(word, ".")
+		if word == "" || isCSharpPrimitive(word) || isCSharpKeyword(word) || isTypeParameter(word) {
+			continue
+		}
+		names = append(names, c.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// csharpAttributes takes the leading attribute lists off a declaration, returning the
+// rest and the attribute names: "[HttpGet, Authorize(Roles = "x")] public" → "public",
+// [HttpGet Authorize]. Assembly and module attributes apply to no declaration.
+func csharpAttributes(code string) (string, []string) {
+	var names []string
+	for {
+		code = strings.TrimSpace(code)
+		if !strings.HasPrefix(code, "[") {
+			return code, names
+		}
+		end := topLevelIndex(code[1:], ']')
+		if end == -1 {
+			return code, names
+		}
+		list := code[1 : end+1]
+		code = code[end+2:]
+
+		if target, rest, ok := strings.Cut(list, ":"); ok && isCSharpIdentifier(strings.TrimSpace(target)) {
+			if target = strings.TrimSpace(target); target == "assembly" || target == "module" {
+				continue
+			}
+			list = rest
+		}
+		for _, attribute := range splitTopLevel(list) {
+			attribute = strings.TrimSpace(attribute)
+			if idx := strings.IndexAny(attribute, "(<"); idx != -1 {
+				attribute = strings.TrimSpace(attribute[:idx])
+			}
+			if attribute != "" {
+				names = append(names, strings.TrimPrefix(attribute, "global::"))
+			}
+		}
+	}
+}
+
+// stripCSharpAttributes removes the leading attribute lists from a declaration
+func stripCSharpAttributes(code string) string {
+	code, _ = csharpAttributes(code)
+	return code
+}
+
+// csharpParameter splits a parameter declaration into its name and type:
+// "[FromBody] this IEnumerable<User> users = null" → ("users", "IEnumerable<User>")
+func csharpParameter(param string) (string, string) {
+	param = stripCSharpAttributes(param)
+	if idx := topLevelIndex(param, '='); idx != -1 {
+		param = param[:idx]
+	}
+	fields := strings.Fields(param)
+	for len(fields) > 0 {
+		switch fields[0] {
+		case "this", "ref", "out", "in", "params", "scoped", "readonly":
+			fields = fields[1:]
+			continue
+		}
+		break
+	}
+	if len(fields) < 2 {
+		return "", "" // No type: an empty list, or a lambda's parameters
+	}
+	return fields[len(fields)-1], strings.Join(fields[:len(fields)-1], " ")
+}
+
+// csharpVisibility picks the access level out of a modifier list, or returns fallback:
+// members are private without one, and top-level types internal
+func csharpVisibility(modifiers, fallback string) string {
+	for _, modifier := range strings.Fields(modifiers) {
+		switch modifier {
+		case "public", "private", "protected", "internal":
+			return modifier
+		}
+	}
+	return fallback
+}
+
+// readonlyAccessors reports whether a property's accessor list, when it closes on the
+// line, has no setter: { get; } or { get; init; }
+func readonlyAccessors(accessors string) bool {
+	end := strings.Index(accessors, "}")
+	if end == -1 || !strings.Contains(accessors[:end], "get") {
+		return false
+	}
+	return !strings.Contains(accessors[:end], "set")
+}
+
+// isInterfaceName checks for the .NET convention of naming interfaces with a leading I:
+// IUserService, but not Item
+func isInterfaceName(name string) bool {
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		name = name[idx+1:]
+	}
+	return len(name) > 1 && name[0] == 'I' && unicode.IsUpper(rune(name[1]))
+}
+
+// isTypeParameter checks for the .NET convention of naming type parameters T, or T and a
+// description: TKey, TResult
+func isTypeParameter(name string) bool {
+	if len(name) == 1 {
+		return true
+	}
+	return len(name) > 2 && name[0] == 'T' && unicode.IsUpper(rune(name[1])) && unicode.IsLower(rune(name[2])) && !strings.Contains(name, ".")
+}
+
+func isCSharpWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isCSharpIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isCSharpWordChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCSharpKeyword checks if a word is a C# keyword that can precede a parenthesis or a
+// name in a statement
+func isCSharpKeyword(word string) bool {
+	switch word {
+	case "if", "for", "foreach", "while", "switch", "catch", "using", "lock", "return", "throw",
+		"new", "this", "base", "typeof", "nameof", "sizeof", "default", "checked", "unchecked",
+		"fixed", "when", "await", "is", "as", "in", "out", "ref", "else", "do", "case", "try",
+		"finally", "yield", "get", "set", "init", "add", "remove", "operator", "implicit",
+		"explicit", "delegate", "event", "namespace", "stackalloc", "params", "where", "goto",
+		"var", "not", "and", "or":
+		return true
+	}
+	return false
+}
+
+// isCSharpPrimitive checks if a type name is one of C#'s built-in types or void
+func isCSharpPrimitive(name string) bool {
+	switch name {
+	case "bool", "byte", "sbyte", "char", "decimal", "double", "float", "int", "uint", "nint",
+		"nuint", "long", "ulong", "short", "ushort", "object", "string", "void", "var", "dynamic":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple C# files concurrently
+func (p *CSharpParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *CSharpParser) Language() string {
+	return "csharp"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *CSharpParser) FileExtensions() []string {
+	return []string{".cs"}
+}
+
+// DefaultExcludes returns the directories skipped in .NET and Unity projects: build
+// output, NuGet packages, and the editor's generated folders
+func (p *CSharpParser) DefaultExcludes() []string {
+	return []string{"bin", "obj", "packages", ".vs", "Library", "Temp", "Logs"}
+}
+
+// Entrypoints returns the methods the runtime calls: Main, ASP.NET Core's startup
+// methods, and the Unity messages MonoBehaviours receive
+func (p *CSharpParser) Entrypoints() []string {
+	return []string{
+		`^Main$`,
+		`^(Configure|ConfigureServices)$`,
+		`^(Awake|Start|Update|FixedUpdate|LateUpdate|OnEnable|OnDisable|OnDestroy|OnGUI|OnValidate|Reset|OnApplicationQuit|OnApplicationPause|OnApplicationFocus|OnBecameVisible|OnBecameInvisible|OnDrawGizmos|OnDrawGizmosSelected)$`,
+		`^On(Trigger|Collision)(Enter|Stay|Exit)(2D)?$`,
+		`^OnMouse(Down|Up|UpAsButton|Enter|Exit|Over|Drag)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewCSharpParser())
+}
@@ -0,0 +1,254 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestCSharpParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `using System;
+using System.Collections.Generic;
+using static System.Math;
+using Json = Newtonsoft.Json.JsonConvert;
+
+namespace Acme.Users
+{
+    /// <summary>
+    /// Manages users.
+    /// </summary>
+    [Serializable]
+    [Table("users")]
+    public sealed class UserService : ServiceBase<User>,
+        IUserService, IDisposable
+    {
+        public const int MaxResults = 50;
+
+        private readonly IUserRepository _repository;
+        public string Label { get; set; } = "users"; // TODO: localize
+        public int Count => _repository.Count();
+
+        public UserService(IUserRepository repository)
+        {
+            _repository = repository;
+        }
+
+        [HttpGet]
+        public async Task<List<User>> FindAll(Filter filter,
+                                              int limit = 10)
+        {
+            try
+            {
+                var user = new User(filter.Name());
+                return await _repository.FindAll(filter).Select(ToDto).ToListAsync();
+            }
+            catch (InvalidOperationException e)
+            {
+                return Json.DeserializeObject<List<User>>("[]");
+            }
+        }
+
+        protected abstract void Audit(string message);
+
+        private UserDto ToDto(User user) => UserDto.From(Validate(user));
+
+        void IDisposable.Dispose() { }
+    }
+
+    internal interface IUserService
+    {
+        List<User> FindAll(Filter filter, int limit);
+
+        int Total { get; }
+    }
+
+    public enum Status
+    {
+        Active = 1,
+        Inactive,
+    }
+}
+`
+	path := writeFixture(t, tmp, "UserService.cs", code)
+
+	parsed, err := NewCSharpParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "csharp" || parsed.Namespace != "Acme.Users" {
+		t.Errorf("expected the namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	wantUses := []string{"System", "System.Collections.Generic", `System\Math`, `Newtonsoft.Json\JsonConvert`}
+	if len(parsed.Uses) != len(wantUses) {
+		t.Fatalf("expected uses %v, got %v", wantUses, parsed.Uses)
+	}
+	for i, use := range wantUses {
+		if parsed.Uses[i] != use {
+			t.Errorf("expected uses %v, got %v", wantUses, parsed.Uses)
+		}
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "constant:UserService.MaxResults",
+		"property:UserService._repository", "property:UserService.Label", "property:UserService.Count",
+		"method:UserService.UserService", "method:UserService.FindAll", "method:UserService.Audit",
+		"method:UserService.ToDto", "method:UserService.Dispose", "interface:.IUserService",
+		"method:IUserService.FindAll", "property:IUserService.Total", "enum:.Status",
+		"constant:Status.Active", "constant:Status.Inactive"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 16 {
+		t.Errorf("expected 16 elements, got %+v", parsed.Elements)
+	}
+
+	service := elements["class:.UserService"]
+	if !service.Documented || service.Namespace != "Acme.Users" || service.Line != 13 || elements["method:UserService.FindAll"].Documented {
+		t.Errorf("expected only the class with XML docs to be documented, got %+v", service)
+	}
+	findAll := elements["method:UserService.FindAll"]
+	if len(findAll.Parameters) != 2 || findAll.Parameters[1] != "limit" || findAll.ParamTypes[0] != "Filter" || findAll.ParamTypes[1] != "" {
+		t.Errorf("expected the wrapped parameter list, got %v %v", findAll.Parameters, findAll.ParamTypes)
+	}
+	if findAll.ReturnType != "Task|List|User" || findAll.Line != 28 {
+		t.Errorf("expected the return type's classes on line 28, got %q on %d", findAll.ReturnType, findAll.Line)
+	}
+	if elements["property:UserService._repository"].Visibility != "private" || !elements["property:UserService._repository"].IsReadonly {
+		t.Error("expected a private readonly field")
+	}
+	if !elements["property:UserService.Count"].IsReadonly || elements["property:UserService.Label"].IsReadonly {
+		t.Error("expected only the expression-bodied property to be readonly")
+	}
+	if elements["interface:.IUserService"].Visibility != "internal" || elements["method:IUserService.FindAll"].Visibility != "public" {
+		t.Error("expected interface members to be public")
+	}
+	if !elements["method:IUserService.FindAll"].IsAbstract || !elements["method:UserService.Audit"].IsAbstract || elements["method:UserService.ToDto"].IsAbstract {
+		t.Error("expected only the methods without a body to be abstract")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"attribute:.SerializableAttribute in UserService",
+		"extends:.ServiceBase in UserService",
+		"implements:.IUserService in UserService",
+		"implements:.IDisposable in UserService",
+		"type_reference:.IUserRepository in UserService",
+		"method_call:_repository.Count in Count",
+		"attribute:.HttpGetAttribute in FindAll",
+		"instantiation:.User in FindAll",
+		"method_call:filter.Name in FindAll",
+		"method_call:_repository.FindAll in FindAll",
+		"type_reference:.InvalidOperationException in FindAll",
+		`static_call:Newtonsoft.Json\JsonConvert.Newtonsoft.Json\JsonConvert::DeserializeObject in FindAll`,
+		"type_reference:.User in FindAll",
+		"static_call:UserDto.UserDto::From in ToDto",
+		"method_call:this.Validate in ToDto",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"method_call:this.User in FindAll", "method_call:this.catch in FindAll", "method_call:this.FindAll in UserService"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Table != "users" || parsed.Tables[0].ClassName != "UserService" {
+		t.Errorf("expected the entity's table, got %+v", parsed.Tables)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 19 {
+		t.Errorf("expected the TODO on line 19, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 3 {
+		t.Errorf("expected 3 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestCSharpParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"Models", "Scripts"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "Models/Player.cs", `namespace Game.Models;
+
+public class Player
+{
+    public int Health { get; private set; }
+
+    public void Damage(int amount) => Health -= amount;
+}
+`)
+	writeFixture(t, tmp, "Scripts/PlayerController.cs", `using Game.Models;
+using UnityEngine;
+
+namespace Game.Scripts
+{
+    public class PlayerController : MonoBehaviour
+    {
+        private Player _player;
+
+        void Start()
+        {
+            _player = new Player();
+        }
+
+        void OnTriggerEnter(Collider other)
+        {
+            Hit(10);
+        }
+
+        private void Hit(int amount) => _player.Damage(amount);
+    }
+}
+`)
+
+	p := NewCSharpParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"Models/Player.cs", "Scripts/PlayerController.cs"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Namespace+`\`+node.ClassName+"."+node.Name] = node
+	}
+	start, player := nodes[`Game.Scripts\PlayerController.Start`], nodes[`Game.Models\.Player`]
+	if start == nil || player == nil || start.Dependencies[player.ID] == nil {
+		t.Fatalf("expected Start to instantiate the Player, got %+v", start)
+	}
+	if !start.IsEntrypoint || !nodes[`Game.Scripts\PlayerController.OnTriggerEnter`].IsEntrypoint {
+		t.Error("expected the Unity messages to be entrypoints")
+	}
+	trigger := nodes[`Game.Scripts\PlayerController.OnTriggerEnter`]
+	if trigger.Dependencies[nodes[`Game.Scripts\PlayerController.Hit`].ID] == nil {
+		t.Errorf("expected the unqualified call to resolve to the class's method, got %+v", trigger.Dependencies)
+	}
+	if nodes[`Game.Scripts\PlayerController.Hit`].IsEntrypoint {
+		t.Error("expected Hit not to be an entrypoint")
+	}
+}
@@ -0,0 +1,1097 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// DartParser handles parsing of Dart files, including Flutter apps
+type DartParser struct {
+	directivePattern  *regexp.Regexp
+	partOfPattern     *regexp.Regexp
+	annotationPattern *regexp.Regexp
+	typePattern       *regexp.Regexp
+	extensionPattern  *regexp.Regexp
+	getterPattern     *regexp.Regexp
+	funPattern        *regexp.Regexp
+	fieldPattern      *regexp.Regexp
+	callPattern       *regexp.Regexp
+	literalPattern    *regexp.Regexp
+	catchPattern      *regexp.Regexp
+	typeCheckPattern  *regexp.Regexp
+	memberPattern     *regexp.Regexp
+	localPattern      *regexp.Regexp
+	tearOffPattern    *regexp.Regexp
+}
+
+// dartScope is a type or function body the parser is inside
+type dartScope struct {
+	kind      string // "type" or "function"
+	name      string // An extension's is the type it extends
+	depth     int    // Brace depth inside the body
+	constants bool   // In an enum body, before the semicolon ending its values
+}
+
+// dartFile is the state of one file's parse
+type dartFile struct {
+	parsed   *models.ParsedFile
+	imports  map[string]string // Names from an import's show list → qualified, e.g. "User" → `shop/models/user\User`
+	prefixes map[string]string // Import prefixes → library, e.g. "http" → "http/http"
+	scopes   []dartScope
+}
+
+// dartLexState is what stripDartLine carries from one line to the next: block comments
+// nest, and ”'multi-line strings”' span lines
+type dartLexState struct {
+	comment int    // Depth of nested block comments
+	quote   string // The delimiter of an open multi-line string, ''' or """
+	raw     bool   // The open string is raw: r'''...''', without escapes or interpolation
+}
+
+// NewDartParser creates a new Dart parser with compiled regex patterns
+func NewDartParser() *DartParser {
+	return &DartParser{
+		// Directives: import 'package:shop/models/user.dart' show User;, export 'src/cart.dart';,
+		// part 'user.g.dart';, import 'package:http/http.dart' as http;
+		directivePattern: regexp.MustCompile(`^\s*(import|export|part)\s+['"]([^'"]+)['"]([^;]*)`),
+
+		// The library a part belongs to: part of 'user.dart';, part of shop.models;
+		partOfPattern: regexp.MustCompile(`^\s*part\s+of\s+(?:['"]([^'"]+)['"]|[\w.]+)`),
+
+		// A leading annotation: @override, @JsonSerializable(explicitToJson: true)
+		annotationPattern: regexp.MustCompile(`^\s*@([A-Za-z_$][\w$.]*)`),
+
+		// Types: abstract class Repository<T>, sealed class Result, mixin Loggable on Service,
+		// enum Status, mixin class Walker, extension type UserId(int id)
+		typePattern: regexp.MustCompile(`^\s*((?:(?:abstract|base|final|sealed|interface|mixin)\s+)*)(class|mixin|enum|extension\s+type)\s+([A-Za-z_$][\w$]*)`),
+
+		// Extensions: extension StringCasing on String {, extension on List<User> {
+		extensionPattern: regexp.MustCompile(`^\s*extension\s+(?:[A-Za-z_$][\w$]*\s*(?:<[^{]*?>)?\s+)?on\s+([A-Za-z_$][\w$.]*)`),
+
+		// Getters: int get count => _items.length;, static Widget get empty {
+		getterPattern: regexp.MustCompile(`^\s*((?:(?:static|external|abstract)\s+)*)(?:([A-Za-z_$][\w$.<>?,\s\[\]]*?[\w$>?\]])\s+)?get\s+([A-Za-z_$][\w$]*)\s*(=>|\{|;|async\b|$)`),
+
+		// Functions, methods, and constructors: Future<List<User>> load(int id) async {,
+		// const User({required this.name});, factory User.fromJson(Map<String, dynamic> json) =>,
+		// set name(String value) {, bool operator ==(Object other)
+		funPattern: regexp.MustCompile(`^\s*((?:(?:static|external|factory|const|abstract|covariant)\s+)*)(?:([A-Za-z_$][\w$.<>?,\s\[\]]*?[\w$>?\]])\s+)?([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)?|[-+*/%<>=~\[\]&|^]+)\s*(?:<[^()]*>)?\s*\(`),
+
+		// Fields and top-level variables: final String name;, static const max = 10;,
+		// late final UserRepository repository;, Map<String, int> counts = {};
+		fieldPattern: regexp.MustCompile(`^\s*((?:(?:static|final|const|late|external|covariant|abstract|var)\s+)*)(?:([A-Za-z_$][\w$.<>?,\s\[\]]*?[\w$>?\]])\s+)?([A-Za-z_$][\w$]*)\s*(=|;|,|$)`),
+
+		// Calls, with any type arguments: save(user), users.where(...), context.read<Cart>()
+		callPattern: regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*(?:<([\w$.,\s?]*(?:<[\w$.,\s?]*>)?[\w$.,\s?]*)>)?\s*\(`),
+
+		// Typed collection literals: <User>[], <String, Order>{}
+		literalPattern: regexp.MustCompile(`<([\w$.,\s?]*(?:<[\w$.,\s?]*>)?[\w$.,\s?]*)>\s*[\[{]`),
+
+		// Exception handlers: on FormatException catch (e), on HttpException {
+		catchPattern: regexp.MustCompile(`\bon\s+(_?[A-Z][\w$]*(?:\.[A-Z][\w$]*)*)\s*(?:catch\b|\{)`),
+
+		// Type checks and casts: value is User, value is! Admin, value as Order
+		typeCheckPattern: regexp.MustCompile(`(?:\bis!?|\bas)\s+(_?[A-Z][\w$]*(?:\.[A-Z][\w$]*)*)`),
+
+		// Static members and enum values: Status.active, Config.maxUsers
+		memberPattern: regexp.MustCompile(`(^|[^\w$.])(_?[A-Z][\w$]*)\.([a-z_$][\w$]*)\b\s*(\(|<)?`),
+
+		// Local variables of a class type: final User user = ..., List<Order> orders = [];,
+		// for (final Item item in items)
+		localPattern: regexp.MustCompile(`(?:^\s*|[;{(]\s*)(?:(?:final|const|late)\s+)*(_?[A-Z][\w$]*(?:\.[A-Z][\w$]*)?)(?:<[^;()=]*>)?\??\s+[a-z_$][\w$]*\s*(?:[=;,)]|\bin\b)`),
+
+		// Tear-offs of private members passed as values: onPressed: _submit, builder: _buildRow
+		tearOffPattern: regexp.MustCompile(`(?:[(,:\[]|=>)\s*(_[A-Za-z][\w$]*)\s*(?:[,)\]};]|$)`),
+	}
+}
+
+// ParseFile analyzes a single Dart file and extracts all elements
+func (p *DartParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	d := &dartFile{
+		parsed: &models.ParsedFile{
+			Path:      filePath,
+			Language:  p.Language(),
+			Namespace: dartLibrary(filePath),
+			Elements:  []models.CodeElement{},
+			Usage:     []models.UsageElement{},
+			Uses:      []string{},
+			Includes:  []models.Include{},
+		},
+		imports:  make(map[string]string),
+		prefixes: make(map[string]string),
+	}
+	parsed := d.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	state := &dartLexState{}
+	docblock := false // A /// or /** */ doc comment precedes the next declaration
+
+	var annotations []string // Annotations awaiting the declaration they annotate
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		if lineNum == 1 && strings.HasPrefix(line, "#!") {
+			continue // A script's shebang
+		}
+
+		if trimmed := strings.TrimSpace(line); state.comment == 0 && state.quote == "" &&
+			(strings.HasPrefix(trimmed, "///") || strings.HasPrefix(trimmed, "/**")) {
+			docblock = true
+		}
+		code, bare := stripDartLine(line, state)
+		if strings.TrimSpace(bare) == "" {
+			if strings.TrimSpace(line) != "" && strings.TrimSpace(code) == "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Join multi-line parameter lists, calls, and directives, so each is parsed whole
+		directive := p.directivePattern.MatchString(code) || p.partOfPattern.MatchString(code)
+		for (parenBalance(bare) > 0 || (directive && !strings.Contains(bare, ";"))) &&
+			state.comment == 0 && state.quote == "" && scanner.Scan() {
+			joinedLines++
+			nextCode, nextBare := stripDartLine(scanner.Text(), state)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+		}
+
+		if len(d.scopes) == 0 {
+			if matches := p.partOfPattern.FindStringSubmatch(code); matches != nil {
+				// A part's declarations belong to the library that owns it
+				if matches[1] != "" {
+					if _, library, _ := d.resolve(matches[1]); library != "" {
+						parsed.Namespace = library
+					}
+				}
+				continue
+			}
+			if matches := p.directivePattern.FindStringSubmatch(code); matches != nil {
+				d.addDirective(matches[1], matches[2], matches[3], lineNum)
+				continue
+			}
+		}
+		if keyword := firstWord(bare); keyword == "library" || keyword == "typedef" {
+			continue
+		}
+
+		// Annotations on their own lines wait for the declaration that follows them
+		for {
+			match := p.annotationPattern.FindStringSubmatchIndex(bare)
+			if match == nil {
+				break
+			}
+			annotations = append(annotations, bare[match[2]:match[3]])
+			bare = strings.TrimSpace(bare[match[1]:])
+			if strings.HasPrefix(bare, "(") {
+				if end := closingParen(bare); end != -1 {
+					bare = bare[end+1:]
+				}
+			}
+		}
+		if strings.TrimSpace(bare) == "" {
+			continue
+		}
+
+		documented := docblock
+		docblock = false
+		annotating := annotations
+		annotations = nil
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := d.top()
+		declaring := top == nil || (top.kind == "type" && top.depth == depthBefore)
+		className := d.enclosingType(depthBefore)
+
+		if top != nil && top.kind == "type" && top.depth == depthBefore && top.constants {
+			body = d.parseEnumValues(bare, lineNum, documented)
+			d.addAnnotations(annotating, top.name, lineNum)
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := strings.Join(strings.Fields(bare[matches[4]:matches[5]]), " ")
+			name := bare[matches[6]:matches[7]]
+			element := models.CodeElement{
+				Type:       "class",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				Visibility: dartVisibility(name),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				IsAbstract: strings.Contains(modifiers, "abstract") || strings.Contains(modifiers, "sealed"),
+			}
+			switch keyword {
+			case "mixin":
+				element.Type = "trait"
+			case "enum":
+				element.Type = "enum"
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			d.addAnnotations(annotating, name, lineNum)
+
+			header := bare[matches[1]:]
+			body = ""
+			if idx := topLevelBrace(header); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+				d.scopes = append(d.scopes, dartScope{kind: "type", name: name, depth: depthBefore + 1, constants: keyword == "enum"})
+				if keyword == "enum" && strings.TrimSpace(body) != "" {
+					body = d.parseEnumValues(body, lineNum, false)
+				}
+			}
+			d.parseTypeHeader(header, keyword, name, lineNum)
+		} else if matches := p.extensionPattern.FindStringSubmatchIndex(bare); declaring && top == nil && matches != nil {
+			// An extension's members are called on the type it extends
+			name := bare[matches[2]:matches[3]]
+			body = ""
+			if idx := strings.Index(bare, "{"); idx != -1 {
+				body = bare[idx+1:]
+				d.scopes = append(d.scopes, dartScope{kind: "type", name: d.qualify(name), depth: depthBefore + 1})
+			}
+		} else if matches := p.getterPattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			name := bare[matches[6]:matches[7]]
+			element := models.CodeElement{
+				Type:       "property",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				ClassName:  className,
+				Visibility: dartVisibility(name),
+				IsStatic:   strings.Contains(modifiers, "static"),
+				IsReadonly: true,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+			}
+			if className == "" {
+				element.Type, element.IsReadonly = "function", false
+				element.Parameters = []string{}
+				if matches[4] != -1 {
+					element.ReturnType = d.typeNames(bare[matches[4]:matches[5]])
+				}
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			d.addAnnotations(annotating, name, lineNum)
+			body, context = d.functionBody(bare[matches[8]:], name, depthBefore)
+		} else if fun := p.funPattern.FindStringSubmatchIndex(bare); declaring && fun != nil && !isDartKeyword(bare[fun[6]:fun[7]]) {
+			modifiers := bare[fun[2]:fun[3]]
+			returns := ""
+			if fun[4] != -1 {
+				returns = bare[fun[4]:fun[5]]
+			}
+			name := bare[fun[6]:fun[7]]
+			// Setters and operators follow their return type: void set name(, bool operator ==(
+			setter := false
+			if fields := strings.Fields(returns); len(fields) > 0 {
+				switch fields[len(fields)-1] {
+				case "set":
+					setter, returns = true, strings.Join(fields[:len(fields)-1], " ")
+				case "operator":
+					name, returns = "operator"+name, strings.Join(fields[:len(fields)-1], " ")
+				}
+			}
+			rest := bare[fun[1]-1:]
+			params := ""
+			if end := closingParen(rest); end != -1 {
+				params, rest = rest[1:end], rest[end+1:]
+			}
+
+			constructor := className != "" && (name == className || strings.HasPrefix(name, className+"."))
+			element := models.CodeElement{
+				Type:       "function",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				ClassName:  className,
+				Visibility: dartVisibility(name),
+				IsStatic:   strings.Contains(modifiers, "static"),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				Parameters: []string{},
+				ReturnType: d.typeNames(returns),
+			}
+			if className != "" {
+				element.Type = "method"
+				// Without a body (or external), a method is abstract: void save(User user);
+				element.IsAbstract = !strings.Contains(modifiers, "external") && !strings.Contains(rest, "{") &&
+					!strings.Contains(rest, "=>") && !strings.Contains(rest, "=") && !constructor
+			}
+			if constructor {
+				// A named constructor is called on the class, like a static method: User.fromJson(json)
+				if named := strings.TrimPrefix(name, className+"."); named != name {
+					element.Name, element.IsStatic = named, true
+				}
+				element.Visibility, element.ReturnType = dartVisibility(element.Name), ""
+				if strings.Contains(modifiers, "factory") {
+					element.ReturnType = d.qualify(className)
+				}
+			}
+			for _, param := range dartParams(params) {
+				paramName, paramType := dartParameter(param)
+				if paramName == "" {
+					continue
+				}
+				element.Parameters = append(element.Parameters, paramName)
+				element.ParamTypes = append(element.ParamTypes, d.typeNames(paramType))
+			}
+
+			if !setter || !d.hasProperty(className, name) {
+				if setter {
+					element.Type, element.Parameters, element.ParamTypes = "property", nil, nil
+					if className == "" {
+						element.Type = "function"
+					}
+				}
+				parsed.Elements = append(parsed.Elements, element)
+			}
+			d.addAnnotations(annotating, element.Name, lineNum)
+			body, context = d.functionBody(rest, element.Name, depthBefore)
+		} else if matches := p.fieldPattern.FindStringSubmatchIndex(bare); declaring && matches != nil && !isDartKeyword(bare[matches[6]:matches[7]]) {
+			modifiers := bare[matches[2]:matches[3]]
+			fieldType := ""
+			if matches[4] != -1 {
+				fieldType = bare[matches[4]:matches[5]]
+			}
+			name := bare[matches[6]:matches[7]]
+			body = bare[matches[8]:]
+
+			fields := strings.Fields(modifiers)
+			has := func(modifier string) bool {
+				for _, field := range fields {
+					if field == modifier {
+						return true
+					}
+				}
+				return false
+			}
+			constant := has("const") || (className == "" && has("final"))
+			if className != "" || constant {
+				element := models.CodeElement{
+					Type:       "property",
+					Name:       name,
+					Namespace:  parsed.Namespace,
+					ClassName:  className,
+					Visibility: dartVisibility(name),
+					IsStatic:   has("static"),
+					IsReadonly: has("final") || has("const"),
+					IsAbstract: has("abstract"),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				}
+				if constant {
+					element.Type = "constant"
+					element.IsStatic, element.IsReadonly = false, false
+				}
+				parsed.Elements = append(parsed.Elements, element)
+				context = className
+				if className == "" {
+					context = name
+				}
+			}
+			d.addAnnotations(annotating, className, lineNum)
+
+			// A field's type is a dependency of its class, such as an injected service
+			if className != "" {
+				for _, typeName := range strings.Split(d.typeNames(fieldType), "|") {
+					if typeName != "" {
+						parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: className, Line: lineNum})
+					}
+				}
+			}
+		} else {
+			d.addAnnotations(annotating, d.context(), lineNum)
+		}
+
+		if context == "" {
+			context = d.context()
+		}
+		p.parseUsage(d, body, lineNum, context)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, d.className(), context)...)
+
+		// Leave the bodies closed on this line
+		for len(d.scopes) > 0 && braceDepth < d.scopes[len(d.scopes)-1].depth {
+			d.scopes = d.scopes[:len(d.scopes)-1]
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// functionBody returns the code following a function declaration, and the function it
+// belongs to. A block body opens a scope for the lines that follow; an expression body
+// (=> ...) is parsed on its line, and opens a scope only if it leaves a brace open.
+func (d *dartFile) functionBody(rest, name string, depthBefore int) (string, string) {
+	brace := topLevelBrace(rest)
+	arrow := strings.Index(rest, "=>")
+	switch {
+	case brace != -1 && (arrow == -1 || brace < arrow):
+		d.scopes = append(d.scopes, dartScope{kind: "function", name: name, depth: depthBefore + 1})
+		return rest, name // The initializer list before the brace is the constructor's usage too
+	case arrow != -1:
+		if brace != -1 {
+			d.scopes = append(d.scopes, dartScope{kind: "function", name: name, depth: depthBefore + 1})
+		}
+		return rest[arrow+2:], name
+	}
+	return rest, name // An initializer list or redirect: : super(key: key);, = _User;
+}
+
+// addDirective records an import, export, or part as a use of its library and an include
+// of its file, so the file-level graph shows which libraries depend on which. An import's
+// show list and prefix let later references name its declarations.
+func (d *dartFile) addDirective(keyword, uri, rest string, lineNum int) {
+	resolved, library, external := d.resolve(uri)
+	if library == "" {
+		return
+	}
+	d.parsed.Uses = append(d.parsed.Uses, library)
+	d.parsed.Includes = append(d.parsed.Includes, models.Include{Path: uri, System: external, Resolved: resolved, Line: lineNum})
+	if keyword != "import" {
+		return
+	}
+
+	fields := strings.FieldsFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "as":
+			if i+1 < len(fields) {
+				d.prefixes[fields[i+1]] = library
+				i++
+			}
+		case "show":
+			for i+1 < len(fields) && fields[i+1] != "hide" && fields[i+1] != "as" {
+				i++
+				d.imports[fields[i]] = library + `\` + fields[i]
+			}
+		}
+	}
+}
+
+// resolve locates the file a directive's URI names and the library it is: a file of this
+// package for relative URIs and package:<this package>/..., or an external library for
+// dart: URIs and other packages, which have no file among the analyzed ones
+func (d *dartFile) resolve(uri string) (string, string, bool) {
+	if strings.HasPrefix(uri, "dart:") {
+		return "", uri, true
+	}
+	if path, ok := strings.CutPrefix(uri, "package:"); ok {
+		root, name := dartPackage(d.parsed.Path)
+		pkg, file, _ := strings.Cut(path, "/")
+		if root == "" || pkg != name {
+			return "", strings.TrimSuffix(path, ".dart"), true
+		}
+		return existingFile(filepath.Join(root, "lib", filepath.FromSlash(file))), strings.TrimSuffix(path, ".dart"), false
+	}
+	if strings.Contains(uri, ":") {
+		return "", "", false // Another scheme, such as a URL
+	}
+	path := filepath.Join(filepath.Dir(d.parsed.Path), filepath.FromSlash(uri))
+	return existingFile(path), dartLibrary(path), false
+}
+
+// existingFile returns path if it is a file, or ""
+func existingFile(path string) string {
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return path
+	}
+	return ""
+}
+
+// parseEnumValues records the values an enum declares on a line, and returns their
+// arguments for usage parsing
+func (d *dartFile) parseEnumValues(line string, lineNum int, documented bool) string {
+	scope := &d.scopes[len(d.scopes)-1]
+	if idx := topLevelIndex(line, ';'); idx != -1 {
+		line = line[:idx]
+		scope.constants = false
+	}
+	var args []string
+	for _, value := range splitTopLevel(line) {
+		value = strings.TrimSpace(stripAnnotations(value))
+		end := 0
+		for end < len(value) && (value[end] == '_' || value[end] == '$' || unicode.IsLetter(rune(value[end])) || unicode.IsDigit(rune(value[end]))) {
+			end++
+		}
+		if end == 0 {
+			continue
+		}
+		args = append(args, value[end:])
+		d.parsed.Elements = append(d.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       value[:end],
+			Namespace:  d.parsed.Namespace,
+			ClassName:  scope.name,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       d.parsed.Path,
+			Documented: documented,
+		})
+	}
+	return strings.Join(args, " ")
+}
+
+// parseTypeHeader records a type's supertypes: extends for its superclass and a mixin's
+// on types, implements for interfaces, and with for mixins, whose members the class
+// gets like a PHP trait's. The type arguments of supertypes are dependencies too, such
+// as the widget of a Flutter State<CounterPage>. An extension type's representation is a
+// property.
+func (d *dartFile) parseTypeHeader(header, keyword, name string, lineNum int) {
+	header = skipTypeParameters(strings.TrimSpace(header))
+	if keyword == "extension type" && strings.HasPrefix(header, "(") {
+		if end := closingParen(header); end != -1 {
+			if field, fieldType := dartParameter(header[1:end]); field != "" {
+				d.parsed.Elements = append(d.parsed.Elements, models.CodeElement{
+					Type:       "property",
+					Name:       field,
+					Namespace:  d.parsed.Namespace,
+					ClassName:  name,
+					Visibility: dartVisibility(field),
+					IsReadonly: true,
+					Line:       lineNum,
+					File:       d.parsed.Path,
+				})
+				d.addTypeReferences(fieldType, name, lineNum)
+			}
+			header = header[end+1:]
+		}
+	}
+
+	// Drop the spaces inside type arguments, so each supertype is one word: Map<String,int>
+	var compact strings.Builder
+	depth := 0
+	for _, r := range header {
+		switch {
+		case r == '<':
+			depth++
+		case r == '>':
+			depth--
+		case unicode.IsSpace(r) && depth > 0:
+			continue
+		}
+		compact.WriteRune(r)
+	}
+
+	usageType := ""
+	for _, word := range splitTopLevel(compact.String()) {
+		for _, supertype := range strings.Fields(word) {
+			switch supertype {
+			case "extends", "on":
+				usageType = "extends"
+				continue
+			case "implements":
+				usageType = "implements"
+				continue
+			case "with":
+				usageType = "uses_trait"
+				continue
+			}
+			if usageType == "" {
+				continue
+			}
+			args := ""
+			if idx := strings.Index(supertype, "<"); idx != -1 {
+				supertype, args = supertype[:idx], supertype[idx:]
+			}
+			supertype = strings.TrimSuffix(supertype, "?")
+			if supertype == "" || isDartBuiltin(supertype) {
+				continue
+			}
+			d.parsed.Usage = append(d.parsed.Usage, models.UsageElement{
+				Type:    usageType,
+				Name:    d.qualify(supertype),
+				Context: name,
+				Line:    lineNum,
+			})
+			d.addTypeReferences(args, name, lineNum)
+		}
+	}
+}
+
+// addTypeReferences records the classes in a type as dependencies of context
+func (d *dartFile) addTypeReferences(typeDecl, context string, lineNum int) {
+	for _, typeName := range strings.Split(d.typeNames(typeDecl), "|") {
+		if typeName != "" {
+			d.parsed.Usage = append(d.parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: context, Line: lineNum})
+		}
+	}
+}
+
+// parseUsage finds calls, instantiations, references, and type checks in code
+func (p *DartParser) parseUsage(d *dartFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	inClass := d.className() != ""
+	add := func(usageType, name, receiver string) {
+		d.parsed.Usage = append(d.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+
+	for _, pattern := range []*regexp.Regexp{p.catchPattern, p.typeCheckPattern, p.localPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(code, -1) {
+			if !isDartBuiltin(match[1]) {
+				add("type_reference", d.qualify(match[1]), "")
+			}
+		}
+	}
+	for _, match := range p.literalPattern.FindAllStringSubmatch(code, -1) {
+		for _, typeName := range strings.Split(d.typeNames(match[1]), "|") {
+			if typeName != "" {
+				add("type_reference", typeName, "")
+			}
+		}
+	}
+	for _, match := range p.memberPattern.FindAllStringSubmatch(code, -1) {
+		if match[4] != "" || isDartBuiltin(match[2]) {
+			continue // Calls are found below
+		}
+		receiver := d.qualify(match[2])
+		add("static_call", receiver+"::"+match[3], receiver)
+	}
+	if inClass {
+		for _, match := range p.tearOffPattern.FindAllStringSubmatch(code, -1) {
+			add("method_call", match[1], "this")
+		}
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		if isDartKeyword(name) {
+			continue
+		}
+		if match[4] != -1 { // Type arguments: context.read<Cart>()
+			for _, typeName := range strings.Split(d.typeNames(code[match[4]:match[5]]), "|") {
+				if typeName != "" {
+					add("type_reference", typeName, "")
+				}
+			}
+		}
+		if !strings.HasSuffix(prefix, ".") {
+			switch {
+			case isDartBuiltin(name):
+			case isDartTypeName(name):
+				add("instantiation", d.qualify(name), "") // Constructors are called like functions
+			case inClass:
+				if qualified, ok := d.imports[name]; ok {
+					add("function_call", qualified, "")
+				} else {
+					add("method_call", name, "this") // Unqualified calls are on this class, or top-level
+				}
+			default:
+				add("function_call", d.qualify(name), "")
+			}
+			continue
+		}
+
+		receiverText := strings.TrimSuffix(prefix, ".")
+		if strings.HasSuffix(receiverText, ".") {
+			add("method_call", name, "") // A cascade: ..add(item)
+			continue
+		}
+		receiver := javaReceiver(strings.TrimRight(receiverText, " \t?!"))
+		library, prefixed := d.prefixes[receiver]
+		switch {
+		case prefixed && isDartTypeName(name):
+			add("instantiation", library+`\`+name, "")
+		case prefixed:
+			add("function_call", library+`\`+name, "")
+		case receiver == "this":
+			add("method_call", name, "this")
+		case isDartBuiltin(receiver):
+		case receiver != "" && isDartTypeName(receiver):
+			// Static methods and named constructors: User.fromJson(json)
+			add("static_call", d.qualify(receiver)+"::"+name, d.qualify(receiver))
+		default:
+			add("method_call", name, receiver)
+		}
+	}
+}
+
+// addAnnotations records annotations as usage of the annotation classes by context.
+// Dart's own, such as @override, are left out.
+func (d *dartFile) addAnnotations(annotations []string, context string, lineNum int) {
+	for _, name := range annotations {
+		if context == "" || !isDartTypeName(name) && !strings.Contains(name, ".") {
+			continue // @override, @immutable, and other constants rather than classes
+		}
+		d.parsed.Usage = append(d.parsed.Usage, models.UsageElement{
+			Type:    "attribute",
+			Name:    d.qualify(name),
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// hasProperty checks if a class in this file already declares a property, as a getter
+// before its setter
+func (d *dartFile) hasProperty(className, name string) bool {
+	for _, element := range d.parsed.Elements {
+		if element.ClassName == className && element.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// top returns the innermost scope, or nil at the top level
+func (d *dartFile) top() *dartScope {
+	if len(d.scopes) == 0 {
+		return nil
+	}
+	return &d.scopes[len(d.scopes)-1]
+}
+
+// enclosingType returns the type whose body a declaration at depth is directly in, or ""
+// at the top level
+func (d *dartFile) enclosingType(depth int) string {
+	if top := d.top(); top != nil && top.kind == "type" && top.depth == depth {
+		return top.name
+	}
+	return ""
+}
+
+// className returns the innermost type being parsed
+func (d *dartFile) className() string {
+	for i := len(d.scopes) - 1; i >= 0; i-- {
+		if d.scopes[i].kind == "type" {
+			return d.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the innermost function or type being parsed
+func (d *dartFile) context() string {
+	if len(d.scopes) == 0 {
+		return ""
+	}
+	return d.scopes[len(d.scopes)-1].name
+}
+
+// qualify names a declaration the way the analyzer indexes it: those from an import's
+// show list or prefix with their library. Others are left to resolve by name, since an
+// import brings in everything its library declares.
+func (d *dartFile) qualify(name string) string {
+	if qualified, ok := d.imports[name]; ok {
+		return qualified
+	}
+	if prefix, rest, ok := strings.Cut(name, "."); ok {
+		if library, ok := d.prefixes[prefix]; ok {
+			return library + `\` + rest
+		}
+	}
+	return name
+}
+
+// typeNames lists the class names in a type, qualified and separated by "|", leaving
+// out Dart's core types: "Future<List<User>>?" → "User"
+func (d *dartFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' && r != '.'
+	}) {
+		word = strings.Trim(word, ".")
+		if word == "" || isDartBuiltin(word) || isDartKeyword(word) || len(word) == 1 || !isDartTypeName(word) {
+			continue // len 1: type variables, T and E; lowercase: parameter names of function types
+		}
+		names = append(names, d.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// dartLibrary names the library a file is, the way it's imported: a file under a package's
+// lib directory by its package: path without the extension ("shop/models/user"), other
+// files of the package by their path in it ("shop/bin/main"), and files outside any
+// package by their path
+func dartLibrary(filePath string) string {
+	root, name := dartPackage(filePath)
+	if root == "" {
+		return moduleName(filePath)
+	}
+	rel, err := filepath.Rel(root, filePath)
+	if err != nil {
+		return moduleName(filePath)
+	}
+	rel = strings.TrimSuffix(filepath.ToSlash(rel), ".dart")
+	return name + "/" + strings.TrimPrefix(rel, "lib/")
+}
+
+// dartNamePattern finds the package name in a pubspec.yaml
+var dartNamePattern = regexp.MustCompile(`(?m)^name:\s*['"]?([\w]+)`)
+
+// dartPackage finds the package a file belongs to from the pubspec.yaml above it,
+// returning the package's directory and name, or "" outside a package
+func dartPackage(filePath string) (string, string) {
+	for dir := filepath.Dir(filePath); ; {
+		if data, err := os.ReadFile(filepath.Join(dir, "pubspec.yaml")); err == nil {
+			if match := dartNamePattern.FindSubmatch(data); match != nil {
+				return dir, string(match[1])
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// dartParams splits a parameter list into its parameters, including those in its {named}
+// and [optional positional] groups
+func dartParams(params string) []string {
+	for _, group := range []string{"{}", "[]"} {
+		start, end := topLevelIndex(params, group[0]), strings.LastIndex(params, group[1:])
+		if start != -1 && end > start {
+			params = params[:start] + params[start+1:end] + params[end+1:]
+			break
+		}
+	}
+	var result []string
+	for _, param := range splitTopLevel(params) {
+		if strings.TrimSpace(param) != "" {
+			result = append(result, param)
+		}
+	}
+	return result
+}
+
+// dartParameter splits a parameter declaration into its name and type:
+// "required List<User> users" → ("users", "List<User>"), "this.name" → ("name", ""),
+// "void Function(int) onTap" → ("onTap", "void Function(int)")
+func dartParameter(param string) (string, string) {
+	param = strings.TrimSpace(stripAnnotations(param))
+	if idx := topLevelIndex(param, '='); idx != -1 {
+		param = strings.TrimSpace(param[:idx])
+	}
+	if idx := strings.LastIndex(param, ":"); idx != -1 && !strings.Contains(param[idx:], ")") {
+		param = strings.TrimSpace(param[:idx]) // An old-style default: {int count: 0}
+	}
+	if strings.HasSuffix(param, ")") {
+		// An old-style function-typed parameter: void onTap(int index)
+		if idx := strings.Index(param, "("); idx != -1 {
+			fields := strings.Fields(param[:idx])
+			if len(fields) == 0 {
+				return "", ""
+			}
+			return fields[len(fields)-1], ""
+		}
+	}
+	end := len(param)
+	start := end
+	for start > 0 && (param[start-1] == '_' || param[start-1] == '$' || unicode.IsLetter(rune(param[start-1])) || unicode.IsDigit(rune(param[start-1]))) {
+		start--
+	}
+	name := param[start:end]
+	if name == "" {
+		return "", ""
+	}
+	paramType := strings.TrimSpace(param[:start])
+	paramType = strings.TrimSuffix(strings.TrimSuffix(paramType, "this."), "super.")
+	for _, modifier := range []string{"required", "covariant", "final", "var", "const"} {
+		if rest, ok := strings.CutPrefix(paramType, modifier+" "); ok {
+			paramType = strings.TrimSpace(rest)
+		} else if paramType == modifier {
+			paramType = ""
+		}
+	}
+	return name, strings.TrimSpace(paramType)
+}
+
+// dartVisibility returns a declaration's visibility: names starting with an underscore
+// are private to their library, and the rest are public
+func dartVisibility(name string) string {
+	if strings.HasPrefix(name, "_") {
+		return "private"
+	}
+	return "public"
+}
+
+// firstWord returns the first word of a line
+func firstWord(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimRight(fields[0], ";")
+}
+
+// stripDartLine removes comments from a line, returning the code and the code with
+// string contents blanked out. The expressions of ${...} interpolations are kept, since
+// they hold calls.
+func stripDartLine(line string, state *dartLexState) (string, string) {
+	var code, bare strings.Builder
+	quote, raw := state.quote, state.raw
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case state.comment > 0:
+			if strings.HasPrefix(line[i:], "*/") {
+				state.comment--
+				i++
+			} else if strings.HasPrefix(line[i:], "/*") {
+				state.comment++
+				i++
+			}
+		case quote != "":
+			code.WriteByte(c)
+			switch {
+			case !raw && c == '\\' && i+1 < len(line):
+				code.WriteByte(line[i+1])
+				i++
+			case !raw && strings.HasPrefix(line[i:], "${"):
+				end := i + 2
+				for depth := 1; end < len(line); end++ {
+					if line[end] == '{' {
+						depth++
+					} else if line[end] == '}' {
+						if depth--; depth == 0 {
+							break
+						}
+					}
+				}
+				code.WriteString(line[i+1 : min(end+1, len(line))])
+				bare.WriteString(" " + line[i+2:min(end, len(line))] + " ")
+				i = end
+			case strings.HasPrefix(line[i:], quote):
+				code.WriteString(quote[1:])
+				bare.WriteString(quote)
+				i += len(quote) - 1
+				quote = ""
+			}
+		case strings.HasPrefix(line[i:], "//"):
+			state.quote = ""
+			return code.String(), bare.String()
+		case strings.HasPrefix(line[i:], "/*"):
+			state.comment++
+			i++
+		case c == '\'' || c == '"':
+			raw = i > 0 && line[i-1] == 'r' && (i < 2 || !isDartIdentByte(line[i-2]))
+			quote = string(c)
+			if strings.HasPrefix(line[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			code.WriteString(quote)
+			bare.WriteString(quote)
+			i += len(quote) - 1
+		default:
+			code.WriteByte(c)
+			bare.WriteByte(c)
+		}
+	}
+	// Only triple-quoted strings continue on the next line
+	state.quote, state.raw = "", false
+	if len(quote) == 3 {
+		state.quote, state.raw = quote, raw
+	}
+	return code.String(), bare.String()
+}
+
+// isDartTypeName checks if a name is a type by the convention that types are capitalized,
+// after the underscore of a private one: "User", "_CounterPageState"
+func isDartTypeName(name string) bool {
+	return isTypeName(strings.TrimLeft(name, "_$"))
+}
+
+// isDartIdentByte checks if a byte can be part of an identifier
+func isDartIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// isDartKeyword checks if a word is a Dart keyword that can precede a parenthesis or a
+// name in a statement
+func isDartKeyword(word string) bool {
+	switch word {
+	case "if", "else", "for", "while", "do", "switch", "case", "default", "try", "catch",
+		"finally", "on", "return", "throw", "rethrow", "assert", "new", "const", "final", "var",
+		"late", "this", "super", "is", "as", "in", "await", "yield", "async", "sync", "required", "covariant", "static", "factory", "external", "operator", "typedef",
+		"library", "import", "export", "part", "show", "hide", "deferred", "extension", "mixin",
+		"with", "implements", "extends", "null", "true", "false", "void", "dynamic", "when":
+		return true
+	}
+	return false
+}
+
+// isDartBuiltin checks if a name is one of dart:core's types or functions
+func isDartBuiltin(name string) bool {
+	switch name {
+	case "int", "double", "num", "String", "bool", "void", "dynamic", "Object", "Null",
+		"Never", "Function", "Type", "Symbol", "Record", "Enum", "List", "Map", "Set",
+		"Iterable", "Iterator", "MapEntry", "Future", "FutureOr", "Stream", "Duration",
+		"DateTime", "Uri", "BigInt", "Comparable", "Pattern", "RegExp", "StackTrace",
+		"Exception", "Error", "print", "identical":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Dart files concurrently
+func (p *DartParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *DartParser) Language() string {
+	return "dart"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *DartParser) FileExtensions() []string {
+	return []string{".dart"}
+}
+
+// DefaultExcludes returns the directories skipped in Dart projects: pub's and the build
+// tools' caches, and build output
+func (p *DartParser) DefaultExcludes() []string {
+	return []string{".dart_tool", "build", ".pub-cache", ".pub", ".fvm", ".idea"}
+}
+
+// Entrypoints returns the functions the runtime calls: main, and the lifecycle methods
+// Flutter calls on widgets, their state, and painters
+func (p *DartParser) Entrypoints() []string {
+	return []string{
+		`^main$`,
+		`^(build|createState|initState|dispose|didChangeDependencies|didUpdateWidget|deactivate|activate|reassemble|didChangeAppLifecycleState|paint|shouldRepaint|createRenderObject|updateRenderObject)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewDartParser())
+}
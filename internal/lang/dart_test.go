@@ -0,0 +1,289 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestDartParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"services", "util", "models"} {
+		if err := os.MkdirAll(filepath.Join(tmp, "lib", dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "pubspec.yaml", "name: shop\ndependencies:\n  flutter:\n    sdk: flutter\n")
+	writeFixture(t, tmp, "lib/services/api.dart", "class ApiClient {}\n")
+	writeFixture(t, tmp, "lib/util/format.dart", "String formatName(String name) => name;\n")
+	code := `import 'package:flutter/material.dart';
+import 'package:http/http.dart' as http;
+import '../services/api.dart' show ApiClient;
+import 'package:shop/util/format.dart';
+
+part 'user.g.dart';
+
+/// A registered user.
+@JsonSerializable()
+class User extends Entity with Auditable, Loggable implements Comparable<User> {
+  static const maxNameLength = 40;
+  final String name;
+  final Address? address;
+  int _visits = 0; // TODO: persist
+
+  User(this.name, {this.address});
+
+  factory User.fromJson(Map<String, dynamic> json) => User(json['name'] as String);
+
+  String get displayName => formatName(name);
+
+  set visits(int value) {
+    _visits = value;
+  }
+
+  Future<List<Order>> orders(ApiClient client) async {
+    final response = await http.get(Uri.parse('/users/${name}'));
+    final List<Order> result = <Order>[];
+    for (final Order order in decode(response.body)) {
+      result.add(order);
+    }
+    return result..sort();
+  }
+
+  @override
+  int compareTo(User other) => name.compareTo(other.name);
+}
+
+mixin Auditable on Entity {
+  void audit();
+}
+
+enum Role {
+  admin('A'),
+  member('M');
+
+  const Role(this.code);
+  final String code;
+}
+
+extension UserLabels on User {
+  String label() => '${name} (${Role.admin.name})';
+}
+
+final defaultUser = User('guest');
+
+/* block /* nested */ comment */
+void main() {
+  runApp(const ShopApp());
+}
+`
+	path := writeFixture(t, tmp, "lib/models/user.dart", code)
+
+	parsed, err := NewDartParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "dart" || parsed.Namespace != "shop/models/user" {
+		t.Errorf("expected the library's package path, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	wantUses := []string{"flutter/material", "http/http", "shop/services/api", "shop/util/format", "shop/models/user.g"}
+	if len(parsed.Uses) != len(wantUses) {
+		t.Fatalf("expected uses %v, got %v", wantUses, parsed.Uses)
+	}
+	for i, use := range wantUses {
+		if parsed.Uses[i] != use {
+			t.Errorf("expected uses %v, got %v", wantUses, parsed.Uses)
+		}
+	}
+	if len(parsed.Includes) != 5 || !parsed.Includes[0].System || !parsed.Includes[1].System {
+		t.Fatalf("expected other packages to be system includes, got %+v", parsed.Includes)
+	}
+	if api := parsed.Includes[2]; api.System || api.Resolved != filepath.Join(tmp, "lib", "services", "api.dart") {
+		t.Errorf("expected the relative import to resolve, got %+v", api)
+	}
+	if format := parsed.Includes[3]; format.System || format.Resolved != filepath.Join(tmp, "lib", "util", "format.dart") {
+		t.Errorf("expected the package's own import to resolve, got %+v", format)
+	}
+	if part := parsed.Includes[4]; part.System || part.Resolved != "" || part.Line != 6 {
+		t.Errorf("expected the generated part to stay unresolved, got %+v", part)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.User", "constant:User.maxNameLength", "property:User.name",
+		"property:User.address", "property:User._visits", "method:User.User", "method:User.fromJson",
+		"property:User.displayName", "property:User.visits", "method:User.orders", "method:User.compareTo",
+		"trait:.Auditable", "method:Auditable.audit", "enum:.Role", "constant:Role.admin",
+		"constant:Role.member", "method:Role.Role", "property:Role.code", "method:User.label",
+		"constant:.defaultUser", "function:.main"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 21 {
+		t.Errorf("expected 21 elements, got %+v", parsed.Elements)
+	}
+
+	user := elements["class:.User"]
+	if !user.Documented || user.Namespace != "shop/models/user" || user.Line != 10 || elements["method:User.orders"].Documented {
+		t.Errorf("expected only the class with a doc comment to be documented, got %+v", user)
+	}
+	if visits := elements["property:User._visits"]; visits.Visibility != "private" || visits.IsReadonly {
+		t.Error("expected the underscored field to be a private, writable property")
+	}
+	if !elements["property:User.name"].IsReadonly || elements["property:User.visits"].IsReadonly || !elements["property:User.displayName"].IsReadonly {
+		t.Error("expected final fields and getters to be readonly and setters to be writable")
+	}
+	constructor := elements["method:User.User"]
+	if len(constructor.Parameters) != 2 || constructor.Parameters[0] != "name" || constructor.Parameters[1] != "address" {
+		t.Errorf("expected the initializing and named parameters, got %v", constructor.Parameters)
+	}
+	if fromJSON := elements["method:User.fromJson"]; !fromJSON.IsStatic || fromJSON.ReturnType != "User" {
+		t.Errorf("expected the named factory constructor to be static and return the class, got %+v", fromJSON)
+	}
+	orders := elements["method:User.orders"]
+	if len(orders.ParamTypes) != 1 || orders.ParamTypes[0] != `shop/services/api\ApiClient` || orders.ReturnType != "Order" {
+		t.Errorf("expected the shown import as the parameter's type, got %v returning %q", orders.ParamTypes, orders.ReturnType)
+	}
+	if !elements["method:Auditable.audit"].IsAbstract || elements["method:User.orders"].IsAbstract {
+		t.Error("expected only the bodiless mixin method to be abstract")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"attribute:.JsonSerializable in User",
+		"extends:.Entity in User",
+		"uses_trait:.Auditable in User",
+		"uses_trait:.Loggable in User",
+		"type_reference:.Address in User",
+		"instantiation:.User in fromJson",
+		"method_call:this.formatName in displayName",
+		`function_call:.http/http\get in orders`,
+		"type_reference:.Order in orders",
+		"method_call:result.add in orders",
+		"method_call:.sort in orders",
+		"extends:.Entity in Auditable",
+		"static_call:Role.Role::admin in label",
+		"instantiation:.User in defaultUser",
+		"function_call:.runApp in main",
+		"instantiation:.ShopApp in main",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"instantiation:.Uri in orders", "implements:.Comparable in User", "instantiation:.admin in Role"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 14 {
+		t.Errorf("expected the TODO on line 14, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 2 {
+		t.Errorf("expected 2 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestDartLibrary(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, "app/pubspec.yaml", "name: shop\n")
+	for path, want := range map[string]string{
+		"app/lib/main.dart":                 "shop/main",
+		"app/lib/src/models/user.dart":      "shop/src/models/user",
+		"app/test/widget_test.dart":         "shop/test/widget_test",
+		"scripts/tool/generate_assets.dart": filepath.ToSlash(tmp) + "/scripts/tool/generate_assets",
+	} {
+		if got := dartLibrary(filepath.Join(tmp, path)); got != want {
+			t.Errorf("dartLibrary(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestDartParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, "pubspec.yaml", "name: counter\n")
+	writeFixture(t, tmp, "lib/counter_page.dart", `import 'package:flutter/material.dart';
+
+class CounterPage extends StatefulWidget {
+  const CounterPage({super.key});
+
+  @override
+  State<CounterPage> createState() => _CounterPageState();
+}
+
+class _CounterPageState extends State<CounterPage> {
+  int _counter = 0;
+
+  void _increment() {
+    setState(() {
+      _counter++;
+    });
+  }
+
+  @override
+  Widget build(BuildContext context) {
+    return ElevatedButton(onPressed: _increment, child: Text('$_counter'));
+  }
+}
+`)
+	writeFixture(t, tmp, "lib/main.dart", `import 'package:flutter/material.dart';
+import 'counter_page.dart';
+
+void main() {
+  runApp(const MaterialApp(home: CounterPage()));
+}
+`)
+
+	p := NewDartParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"lib/counter_page.dart", "lib/main.dart"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.ClassName+"."+node.Name] = node
+	}
+	main, page := nodes[".main"], nodes["CounterPage.CounterPage"]
+	if main == nil || page == nil || main.Dependencies[page.ID] == nil || !main.IsEntrypoint {
+		t.Fatalf("expected main, an entrypoint, to call the CounterPage's constructor, got %+v", main)
+	}
+	createState, state := nodes["CounterPage.createState"], nodes["._CounterPageState"]
+	if createState == nil || state == nil || createState.Dependencies[state.ID] == nil || !createState.IsEntrypoint {
+		t.Errorf("expected createState to construct the private state class, got %+v", createState)
+	}
+	build, increment := nodes["_CounterPageState.build"], nodes["_CounterPageState._increment"]
+	if build == nil || increment == nil || build.Dependencies[increment.ID] == nil || !build.IsEntrypoint || increment.IsEntrypoint {
+		t.Errorf("expected build to reference the tear-off handler, got %+v", build)
+	}
+	if graph.Includes == nil || len(graph.Includes.Edges) != 1 || graph.Includes.Edges[0].To != filepath.Join(tmp, "lib", "counter_page.dart") {
+		t.Errorf("expected main.dart to import counter_page.dart, got %+v", graph.Includes)
+	}
+}
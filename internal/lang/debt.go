@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// debtPattern finds a TODO, FIXME, or HACK at the start of a comment: "// TODO: x",
+// "# FIXME(ana) x", "/* HACK x */", "-- TODO x", or a docblock line "* @todo x"
+var debtPattern = regexp.MustCompile(`(?:^|[\s;{}()])(?://+|#|/\*+|\*|--+(?:\[=*\[)?)\s*@?(?i:(TODO|FIXME|HACK))\b(?:\(([^)]*)\))?:?\s*(.*)`)
+
+// debtMarker returns the debt marker in a line of source, if it has one
+func debtMarker(line string, lineNum int) (models.DebtMarker, bool) {
+	match := debtPattern.FindStringSubmatch(line)
+	if match == nil {
+		return models.DebtMarker{}, false
+	}
+	text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(match[3]), "*/"))
+	return models.DebtMarker{
+		Tag:    strings.ToUpper(match[1]),
+		Text:   text,
+		Author: strings.TrimSpace(match[2]),
+		Line:   lineNum,
+	}, true
+}
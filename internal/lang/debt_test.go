@@ -0,0 +1,60 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestDebtMarker(t *testing.T) {
+	tests := []struct {
+		line string
+		want *models.DebtMarker
+	}{
+		{"// TODO: cache this", &models.DebtMarker{Tag: "TODO", Text: "cache this"}},
+		{"    $x = 1; # FIXME(ana) rounding is off", &models.DebtMarker{Tag: "FIXME", Text: "rounding is off", Author: "ana"}},
+		{"/* HACK until JIRA-12 ships */", &models.DebtMarker{Tag: "HACK", Text: "until JIRA-12 ships"}},
+		{"     * @todo Split the class", &models.DebtMarker{Tag: "TODO", Text: "Split the class"}},
+		{"// todo", &models.DebtMarker{Tag: "TODO"}},
+		{"  local n = 0 -- HACK: off by one", &models.DebtMarker{Tag: "HACK", Text: "off by one"}},
+		{"// TODOs live in the tracker", nil},
+		{"$todo = 'TODO: not a comment';", nil},
+		{"#[Todo]", nil},
+		{`$url = "http://example.com/TODO";`, nil},
+	}
+	for _, tt := range tests {
+		got, ok := debtMarker(tt.line, 7)
+		if tt.want == nil {
+			if ok {
+				t.Errorf("debtMarker(%q) = %+v, want none", tt.line, got)
+			}
+			continue
+		}
+		tt.want.Line = 7
+		if !ok || got != *tt.want {
+			t.Errorf("debtMarker(%q) = %+v, %v; want %+v", tt.line, got, ok, *tt.want)
+		}
+	}
+}
+
+func TestParsers_CollectDebt(t *testing.T) {
+	tmp := t.TempDir()
+	php := writeFixture(t, tmp, "a.php", "<?php\n// TODO: one\nfunction a() {\n    return 1; // HACK: two\n}\n")
+	js := writeFixture(t, tmp, "a.js", "/*\n * FIXME three\n */\nexport const a = 1 // TODO(bo) four\n")
+
+	parsed, err := NewPHPParser().ParseFile(php)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Debt) != 2 || parsed.Debt[0].Line != 2 || parsed.Debt[1].Tag != "HACK" || parsed.Debt[1].Line != 4 {
+		t.Errorf("unexpected PHP debt: %+v", parsed.Debt)
+	}
+
+	parsed, err = NewJSParser().ParseFile(js)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Debt) != 2 || parsed.Debt[0].Line != 2 || parsed.Debt[1].Author != "bo" || parsed.Debt[1].Line != 4 {
+		t.Errorf("unexpected JS debt: %+v", parsed.Debt)
+	}
+}
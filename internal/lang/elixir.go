@@ -0,0 +1,881 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// ElixirParser handles parsing of Elixir files
+type ElixirParser struct {
+	modulePattern     *regexp.Regexp
+	implPattern       *regexp.Regexp
+	defPattern        *regexp.Regexp
+	directivePattern  *regexp.Regexp
+	attributePattern  *regexp.Regexp
+	schemaPattern     *regexp.Regexp
+	inlineDoPattern   *regexp.Regexp
+	remoteCallPattern *regexp.Regexp
+	localCallPattern  *regexp.Regexp
+	capturePattern    *regexp.Regexp
+	pipePattern       *regexp.Regexp
+	structPattern     *regexp.Regexp
+	moduleRefPattern  *regexp.Regexp
+	remoteTypePattern *regexp.Regexp
+	identPattern      *regexp.Regexp
+	wordPattern       *regexp.Regexp
+}
+
+// elixirScope is a body closed by "end"
+type elixirScope struct {
+	kind string // "module", "def", or "block" (do ... end, fn ... end)
+	name string
+	path string // Qualified name of a module, e.g. `MyApp\Accounts`
+}
+
+// elixirCall is a local call, qualified with its module once the file's functions are known
+type elixirCall struct {
+	index  int // Into the parsed file's usage
+	module string
+}
+
+// elixirLexer carries a heredoc, or a string or sigil left open, from one line to the next
+type elixirLexer struct {
+	closing string // Delimiter ending the open string: `"""`, `"`, `)`, ...
+	doc     bool   // The open heredoc is a @doc, @moduledoc, or @typedoc
+}
+
+// elixirFile is the state of one file's parse
+type elixirFile struct {
+	parsed     *models.ParsedFile
+	scopes     []elixirScope
+	aliases    map[string]string // Alias to the qualified module it names
+	defined    map[string]bool   // Qualified names of the functions defined in the file
+	calls      []elixirCall
+	pendingDoc bool // A @doc precedes the next function
+}
+
+// NewElixirParser creates a new Elixir parser with compiled regex patterns
+func NewElixirParser() *ElixirParser {
+	module := `(?:__MODULE__|[A-Z]\w*)(?:\.[A-Z]\w*)*`
+	return &ElixirParser{
+		// Modules and protocols: defmodule MyApp.Accounts do, defprotocol Shape do
+		modulePattern: regexp.MustCompile(`^(defmodule|defprotocol)\s+(` + module + `)`),
+
+		// Protocol implementations: defimpl Shape, for: Circle do
+		implPattern: regexp.MustCompile(`^defimpl\s+(` + module + `)(?:\s*,\s*for:\s*(` + module + `))?`),
+
+		// Functions: def get_user(id), defp valid?(user), defmacro __using__(opts),
+		// defguard is_admin(user) when ..., defdelegate fetch(id), to: Repo
+		defPattern: regexp.MustCompile(`^(def|defp|defmacro|defmacrop|defguard|defguardp|defdelegate|defn|defnp)\s+([a-z_]\w*[?!]?)`),
+
+		// Directives: alias MyApp.Accounts.{User, Team}, import Ecto.Query, only: [from: 2], use GenServer
+		directivePattern: regexp.MustCompile(`^(alias|import|require|use)\s+(.+)`),
+
+		// Module attributes: @moduledoc false, @behaviour Plug, @spec get_user(integer) :: User.t()
+		attributePattern: regexp.MustCompile(`^@([a-z_]\w*)\s*(.*)$`),
+
+		// Ecto schemas: schema "users" do
+		schemaPattern: regexp.MustCompile(`^\s*schema\s*\(?\s*"(\w+)"`),
+
+		// One-line bodies: def total(order), do: ...
+		inlineDoPattern: regexp.MustCompile(`(?:^|[\s,(])do:`),
+
+		// Remote calls and captures: Accounts.get_user(id), &Repo.insert/1, |> MyApp.Mailer.deliver
+		remoteCallPattern: regexp.MustCompile(`(` + module + `)\.([a-z_]\w*[?!]?)`),
+
+		// Local calls: validate(changeset), valid?(user)
+		localCallPattern: regexp.MustCompile(`([a-z_]\w*[?!]?)\s*\(`),
+
+		// Local captures: &format/1
+		capturePattern: regexp.MustCompile(`&([a-z_]\w*[?!]?)/\d+`),
+
+		// Pipes into a local function without parentheses: |> normalize
+		pipePattern: regexp.MustCompile(`\|>\s*([a-z_]\w*[?!]?)`),
+
+		// Structs: %User{name: name}, %__MODULE__{}
+		structPattern: regexp.MustCompile(`%(` + module + `)\s*\{`),
+
+		// Module references: Supervisor.start_link([MyApp.Worker]), rescue Ecto.NoResultsError
+		moduleRefPattern: regexp.MustCompile(module),
+
+		// Remote types in specs: User.t(), Ecto.Changeset.t()
+		remoteTypePattern: regexp.MustCompile(`(` + module + `)\.[a-z_]\w*\(`),
+
+		// Parameters bound to a name: user, _opts
+		identPattern: regexp.MustCompile(`^[a-z_]\w*$`),
+
+		// Words that open and close bodies: do ... end, fn ... end
+		wordPattern: regexp.MustCompile(`[A-Za-z_]\w*[?!]?`),
+	}
+}
+
+// ParseFile analyzes a single Elixir file and extracts all elements
+func (p *ElixirParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	e := &elixirFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		aliases: make(map[string]string),
+		defined: make(map[string]bool),
+	}
+	parsed := e.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	commentEnd := -1 // Last line of the comment block above the current line
+	var lex elixirLexer
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		raw := scanner.Text()
+		if marker, ok := debtMarker(raw, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		trimmed := strings.TrimSpace(raw)
+		inDoc := lex.closing != "" && lex.doc
+
+		// Join a statement continued by open brackets or a trailing comma
+		code, bare := stripElixirLine(raw, &lex)
+		for (bracketBalance(bare) > 0 || strings.HasSuffix(strings.TrimSpace(bare), ",")) && scanner.Scan() {
+			joinedLines++
+			if marker, ok := debtMarker(scanner.Text(), lineNum+joinedLines); ok {
+				parsed.Debt = append(parsed.Debt, marker)
+			}
+			nextCode, nextBare := stripElixirLine(scanner.Text(), &lex)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+		}
+		if strings.TrimSpace(bare) == "" {
+			if inDoc {
+				parsed.CommentLines++
+			} else if trimmed != "" && lex.closing == "" && strings.HasPrefix(trimmed, "#") {
+				parsed.CommentLines++
+				commentEnd = lineNum
+			}
+			continue
+		}
+		documented := commentEnd == lineNum-1
+
+		// Attributes between a comment and a definition, such as @spec and @impl, keep the
+		// comment attached; a doc heredoc opened here counts as comment lines
+		match := p.attributePattern.FindStringSubmatch(strings.TrimSpace(bare))
+		if match != nil && documented {
+			commentEnd = lineNum
+		}
+		if lex.doc = lex.closing != "" && match != nil && strings.HasSuffix(match[1], "doc"); lex.doc {
+			parsed.CommentLines++
+		}
+		if match := p.schemaPattern.FindStringSubmatch(code); match != nil && e.moduleName() != "" {
+			parsed.Tables = append(parsed.Tables, models.TableReference{Table: match[1], Kind: "model", ClassName: e.moduleName(), Line: lineNum})
+		}
+
+		for _, part := range strings.Split(bare, ";") {
+			if part = strings.TrimSpace(part); part != "" {
+				p.parseStatement(e, part, lineNum, documented)
+			}
+		}
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, e.moduleName(), e.function())...)
+	}
+
+	// Local calls name the function of their own module when the file defines one;
+	// others are imported, and are left to resolve by name
+	for _, call := range e.calls {
+		if usage := &parsed.Usage[call.index]; e.defined[call.module+`\`+usage.Name] {
+			usage.Name = call.module + `\` + usage.Name
+		}
+	}
+	for _, element := range parsed.Elements {
+		if element.Type == "module" || element.Type == "interface" {
+			parsed.Namespace = getElixirPath(element)
+			break
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// parseStatement handles one statement: a declaration, a directive, a body's end, or code
+func (p *ElixirParser) parseStatement(e *elixirFile, stmt string, lineNum int, documented bool) {
+	events := p.blockEvents(stmt)
+
+	switch {
+	case p.modulePattern.MatchString(stmt):
+		match := p.modulePattern.FindStringSubmatch(stmt)
+		kind := "module"
+		if match[1] == "defprotocol" {
+			kind = "interface"
+		}
+		e.declareModule(kind, e.nestedPath(match[2]), lineNum, documented || e.takeDoc())
+		p.openBody(e, events, stmt, lineNum)
+		return
+
+	case p.implPattern.MatchString(stmt):
+		// An implementation is the module Protocol.Type, whatever module it's nested in
+		match := p.implPattern.FindStringSubmatch(stmt)
+		protocol, target := e.qualify(match[1]), e.modulePath()
+		if match[2] != "" {
+			target = e.qualify(match[2])
+		}
+		name := e.declareModule("module", protocol+`\`+target[strings.LastIndex(target, `\`)+1:], lineNum, documented || e.takeDoc())
+		e.addUsage("implements", protocol, name, lineNum)
+		p.openBody(e, events, stmt, lineNum)
+		return
+
+	case p.defPattern.MatchString(stmt):
+		loc := p.defPattern.FindStringSubmatchIndex(stmt)
+		kind, name := stmt[loc[2]:loc[3]], stmt[loc[4]:loc[5]]
+		params, rest := elixirSignature(stmt[loc[1]:])
+		inline := p.inlineDoPattern.MatchString(rest)
+		body := inline || (len(events) > 0 && events[0] > 0)
+		e.addFunction(p, kind, name, params, lineNum, documented || e.takeDoc(), !body && kind != "defdelegate")
+
+		e.scopes = append(e.scopes, elixirScope{kind: "def", name: name})
+		if kind == "defdelegate" {
+			// defdelegate fetch(id), to: Repo, as: :get calls Repo.get
+			module, target := "", name
+			for _, option := range splitTopLevel(strings.TrimPrefix(rest, ",")) {
+				key, value, _ := strings.Cut(strings.TrimSpace(option), ":")
+				switch value = strings.TrimSpace(value); strings.TrimSpace(key) {
+				case "to":
+					module = p.moduleRefPattern.FindString(value)
+				case "as":
+					target = strings.TrimPrefix(value, ":")
+				}
+			}
+			if module != "" {
+				e.addUsage("function_call", e.qualify(module)+`\`+target, name, lineNum)
+			}
+			e.scopes = e.scopes[:len(e.scopes)-1]
+			return
+		}
+		p.parseUsage(e, rest, lineNum)
+		if body && !inline {
+			p.applyEvents(e, events[1:])
+			return
+		}
+		e.scopes = e.scopes[:len(e.scopes)-1]
+		p.applyEvents(e, events)
+		return
+	}
+
+	if e.inModule() {
+		if match := p.directivePattern.FindStringSubmatch(stmt); match != nil {
+			p.parseDirective(e, match[1], match[2], lineNum)
+			return
+		}
+		if match := p.attributePattern.FindStringSubmatch(stmt); match != nil {
+			p.parseAttribute(e, match[1], strings.TrimSpace(match[2]), lineNum)
+			p.applyEvents(e, events)
+			return
+		}
+	}
+
+	p.parseUsage(e, stmt, lineNum)
+	p.applyEvents(e, events)
+}
+
+// openBody opens a module's body with its "do", or closes a one-line module right away
+func (p *ElixirParser) openBody(e *elixirFile, events []int, stmt string, lineNum int) {
+	if len(events) == 0 || events[0] < 0 || p.inlineDoPattern.MatchString(stmt) {
+		if _, rest, ok := strings.Cut(stmt, "do:"); ok {
+			p.parseUsage(e, rest, lineNum)
+		}
+		e.scopes = e.scopes[:len(e.scopes)-1]
+		p.applyEvents(e, events)
+		return
+	}
+	p.applyEvents(e, events[1:])
+}
+
+// parseDirective records an alias, import, require, or use in a module body. Each names
+// a module the body depends on; use also mixes in what the module's __using__ injects.
+func (p *ElixirParser) parseDirective(e *elixirFile, directive, rest string, lineNum int) {
+	parts := splitTopLevel(rest)
+	if len(parts) == 0 {
+		return
+	}
+	target := strings.TrimSpace(parts[0])
+	as := ""
+	for _, option := range parts[1:] {
+		if key, value, ok := strings.Cut(strings.TrimSpace(option), ":"); ok && strings.TrimSpace(key) == "as" {
+			as = strings.TrimSpace(value)
+		}
+	}
+
+	// alias MyApp.Accounts.{User, Team} names several modules
+	var modules []string
+	if prefix, list, ok := strings.Cut(target, ".{"); ok {
+		for _, name := range splitTopLevel(strings.TrimSuffix(strings.TrimSpace(list), "}")) {
+			modules = append(modules, prefix+"."+strings.TrimSpace(name))
+		}
+	} else {
+		modules = append(modules, target)
+	}
+
+	usageType := "type_reference"
+	if directive == "use" {
+		usageType = "uses_trait"
+	}
+	for _, module := range modules {
+		if p.moduleRefPattern.FindString(module) != module {
+			continue // An Erlang module, such as :crypto, or an expression
+		}
+		qualified := e.qualify(module)
+		if directive == "alias" {
+			name := as
+			if name == "" {
+				name = qualified[strings.LastIndex(qualified, `\`)+1:]
+			}
+			e.aliases[name] = qualified
+		}
+		e.parsed.Uses = append(e.parsed.Uses, qualified)
+		e.addUsage(usageType, qualified, e.context(), lineNum)
+	}
+}
+
+// parseAttribute handles the module attributes Tukey reads: docs, behaviours, callbacks,
+// and the types named in specs
+func (p *ElixirParser) parseAttribute(e *elixirFile, name, value string, lineNum int) {
+	switch name {
+	case "moduledoc":
+		if value != "false" {
+			e.documentModule()
+		}
+	case "doc":
+		e.pendingDoc = value != "false"
+	case "behaviour", "behavior":
+		if ref := p.moduleRefPattern.FindString(value); ref != "" && ref == value {
+			e.addUsage("implements", e.qualify(ref), e.context(), lineNum)
+		}
+	case "spec", "callback", "macrocallback":
+		function := p.wordPattern.FindString(value)
+		if function == "" || !strings.HasPrefix(value, function) {
+			return
+		}
+		if name != "spec" {
+			// A behaviour's callbacks are the functions its implementations define
+			e.addFunction(p, "def", function, nil, lineNum, e.takeDoc(), true)
+			if head := strings.TrimSpace(value[len(function):]); strings.HasPrefix(head, "(") {
+				if end := closingParen(head); end != -1 {
+					element := &e.parsed.Elements[len(e.parsed.Elements)-1]
+					for range splitTopLevel(head[1:end]) {
+						element.Parameters = append(element.Parameters, "_")
+						element.ParamTypes = append(element.ParamTypes, "")
+					}
+				}
+			}
+		}
+		for _, match := range p.remoteTypePattern.FindAllStringSubmatch(value, -1) {
+			e.addUsage("type_reference", e.qualify(match[1]), function, lineNum)
+		}
+	}
+}
+
+// applyEvents opens a block for every +1 and closes the innermost body for every -1
+func (p *ElixirParser) applyEvents(e *elixirFile, events []int) {
+	for _, event := range events {
+		if event > 0 {
+			e.scopes = append(e.scopes, elixirScope{kind: "block"})
+		} else if len(e.scopes) > 0 {
+			e.scopes = e.scopes[:len(e.scopes)-1]
+		}
+	}
+}
+
+// blockEvents lists, in order, the bodies a statement opens (+1) with "do" or "fn" and
+// the ends that close them (-1). "do:" is a one-line body and opens nothing.
+func (p *ElixirParser) blockEvents(stmt string) []int {
+	var events []int
+	for _, loc := range p.wordPattern.FindAllStringIndex(stmt, -1) {
+		before, after := stmt[:loc[0]], stmt[loc[1]:]
+		if strings.HasSuffix(before, ".") || strings.HasSuffix(before, ":") || strings.HasSuffix(before, "@") ||
+			(strings.HasPrefix(after, ":") && !strings.HasPrefix(after, "::")) {
+			continue // A remote call, atom, attribute, or keyword with a keyword's name
+		}
+		switch stmt[loc[0]:loc[1]] {
+		case "do", "fn":
+			events = append(events, 1)
+		case "end":
+			events = append(events, -1)
+		}
+	}
+	return events
+}
+
+// parseUsage finds remote and local calls, structs, and module references in a statement
+// with string contents removed
+func (p *ElixirParser) parseUsage(e *elixirFile, stmt string, lineNum int) {
+	context := e.context()
+	if context == "" {
+		return
+	}
+	consumed := make(map[int]bool) // Offsets of the names consumed by calls and structs
+
+	for _, match := range p.remoteCallPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		if match[0] > 0 && (isElixirWordChar(stmt[match[0]-1]) || strings.ContainsRune(".:", rune(stmt[match[0]-1]))) {
+			continue
+		}
+		consumed[match[0]], consumed[match[4]] = true, true
+		e.addUsage("function_call", e.qualify(stmt[match[2]:match[3]])+`\`+stmt[match[4]:match[5]], context, lineNum)
+	}
+	for _, match := range p.structPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		consumed[match[2]] = true
+		e.addUsage("instantiation", e.qualify(stmt[match[2]:match[3]]), context, lineNum)
+	}
+	for _, loc := range p.moduleRefPattern.FindAllStringIndex(stmt, -1) {
+		ref := stmt[loc[0]:loc[1]]
+		if consumed[loc[0]] || ref == "__MODULE__" ||
+			(loc[0] > 0 && (isElixirWordChar(stmt[loc[0]-1]) || strings.ContainsRune(".:@%", rune(stmt[loc[0]-1])))) {
+			continue
+		}
+		e.addUsage("type_reference", e.qualify(ref), context, lineNum)
+	}
+
+	for _, match := range p.localCallPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		name := stmt[match[2]:match[3]]
+		if consumed[match[2]] || isElixirBuiltin(name) ||
+			(match[2] > 0 && (isElixirWordChar(stmt[match[2]-1]) || strings.ContainsRune(".:@", rune(stmt[match[2]-1])))) {
+			continue
+		}
+		e.addLocalCall(name, context, lineNum)
+	}
+	for _, match := range p.capturePattern.FindAllStringSubmatch(stmt, -1) {
+		if !isElixirBuiltin(match[1]) {
+			e.addLocalCall(match[1], context, lineNum)
+		}
+	}
+	for _, match := range p.pipePattern.FindAllStringSubmatchIndex(stmt, -1) {
+		name, after := stmt[match[2]:match[3]], strings.TrimLeft(stmt[match[1]:], " \t")
+		if !isElixirBuiltin(name) && !strings.HasPrefix(after, "(") && !strings.HasPrefix(after, ".") {
+			e.addLocalCall(name, context, lineNum)
+		}
+	}
+}
+
+// declareModule records a module or protocol by its qualified name and opens its body,
+// returning its name
+func (e *elixirFile) declareModule(kind, path string, lineNum int, documented bool) string {
+	namespace, short := "", path
+	if idx := strings.LastIndex(path, `\`); idx != -1 {
+		namespace, short = path[:idx], path[idx+1:]
+	}
+	e.parsed.Elements = append(e.parsed.Elements, models.CodeElement{
+		Type:       kind,
+		Name:       short,
+		Namespace:  namespace,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       e.parsed.Path,
+		Documented: documented,
+	})
+	e.scopes = append(e.scopes, elixirScope{kind: "module", name: short, path: path})
+	return short
+}
+
+// nestedPath returns the qualified name of a module declared in the current one, which
+// Elixir nests under it and aliases by its first segment
+func (e *elixirFile) nestedPath(name string) string {
+	if strings.HasPrefix(name, "__MODULE__") {
+		return e.qualify(name)
+	}
+	path := elixirName(name)
+	if parent := e.modulePath(); parent != "" {
+		first, _, _ := strings.Cut(path, `\`)
+		e.aliases[first] = parent + `\` + first
+		path = parent + `\` + path
+	}
+	return path
+}
+
+// addFunction records a function of the current module; the clauses of a function after
+// its first are the same element
+func (e *elixirFile) addFunction(p *ElixirParser, kind, name string, params []string, lineNum int, documented, abstract bool) {
+	module := e.modulePath()
+	if module == "" || e.defined[module+`\`+name] || name == "unquote" {
+		return
+	}
+	e.defined[module+`\`+name] = true
+	element := models.CodeElement{
+		Type:       "function",
+		Name:       name,
+		Namespace:  module,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       e.parsed.Path,
+		Documented: documented,
+		IsAbstract: abstract && e.scopes[len(e.scopes)-1].kind == "module" && e.moduleKind() == "interface",
+		Parameters: []string{},
+	}
+	if strings.HasSuffix(kind, "p") {
+		element.Visibility = "private"
+	}
+	for _, param := range params {
+		param, _, _ = strings.Cut(param, `\\`) // Defaults: opts \\ []
+		param = strings.TrimSpace(param)
+		paramType := ""
+		if match := p.structPattern.FindStringSubmatch(param); match != nil {
+			paramType = e.qualify(match[1]) // %User{} = user
+		}
+		name := "_"
+		if p.identPattern.MatchString(param) {
+			name = param
+		} else if left, right, ok := strings.Cut(param, "="); ok {
+			if right = strings.TrimSpace(right); p.identPattern.MatchString(right) {
+				name = right
+			} else if left = strings.TrimSpace(left); p.identPattern.MatchString(left) {
+				name = left
+			}
+		}
+		element.Parameters = append(element.Parameters, name)
+		element.ParamTypes = append(element.ParamTypes, paramType)
+	}
+	e.parsed.Elements = append(e.parsed.Elements, element)
+}
+
+// documentModule marks the current module documented, for a @moduledoc in its body
+func (e *elixirFile) documentModule() {
+	path := e.modulePath()
+	for i := len(e.parsed.Elements) - 1; i >= 0; i-- {
+		if element := &e.parsed.Elements[i]; element.Type != "function" && getElixirPath(*element) == path {
+			element.Documented = true
+			return
+		}
+	}
+}
+
+// takeDoc reports whether a @doc precedes the declaration being parsed, consuming it
+func (e *elixirFile) takeDoc() bool {
+	documented := e.pendingDoc
+	e.pendingDoc = false
+	return documented
+}
+
+// addUsage records a reference from context
+func (e *elixirFile) addUsage(usageType, name, context string, lineNum int) {
+	e.parsed.Usage = append(e.parsed.Usage, models.UsageElement{
+		Type:    usageType,
+		Name:    name,
+		Context: context,
+		Line:    lineNum,
+	})
+}
+
+// addLocalCall records a call without a module, to qualify once the file is parsed
+func (e *elixirFile) addLocalCall(name, context string, lineNum int) {
+	e.calls = append(e.calls, elixirCall{index: len(e.parsed.Usage), module: e.modulePath()})
+	e.addUsage("function_call", name, context, lineNum)
+}
+
+// qualify resolves a module reference against the file's aliases and __MODULE__,
+// returning it with the analyzer's separator: Accounts.User is `MyApp\Accounts\User`
+// after "alias MyApp.Accounts"
+func (e *elixirFile) qualify(ref string) string {
+	head, rest, _ := strings.Cut(elixirName(ref), `\`)
+	if head == "__MODULE__" {
+		head = e.modulePath()
+	} else if alias, ok := e.aliases[head]; ok {
+		head = alias
+	}
+	if head == "" {
+		return rest
+	}
+	if rest == "" {
+		return head
+	}
+	return head + `\` + rest
+}
+
+// inModule reports whether the parser is directly inside a module body
+func (e *elixirFile) inModule() bool {
+	return len(e.scopes) > 0 && e.scopes[len(e.scopes)-1].kind == "module"
+}
+
+// module returns the innermost module scope, or nil
+func (e *elixirFile) module() *elixirScope {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if e.scopes[i].kind == "module" {
+			return &e.scopes[i]
+		}
+	}
+	return nil
+}
+
+// modulePath returns the qualified name of the innermost module
+func (e *elixirFile) modulePath() string {
+	if module := e.module(); module != nil {
+		return module.path
+	}
+	return ""
+}
+
+// moduleName returns the name of the innermost module, for table references
+func (e *elixirFile) moduleName() string {
+	if module := e.module(); module != nil {
+		return module.name
+	}
+	return ""
+}
+
+// moduleKind returns the element type of the innermost module: "module", or "interface"
+// for a protocol
+func (e *elixirFile) moduleKind() string {
+	path := e.modulePath()
+	for i := len(e.parsed.Elements) - 1; i >= 0; i-- {
+		if element := e.parsed.Elements[i]; element.Type != "function" && getElixirPath(element) == path {
+			return element.Type
+		}
+	}
+	return ""
+}
+
+// function returns the name of the function being parsed, or ""
+func (e *elixirFile) function() string {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		switch e.scopes[i].kind {
+		case "def":
+			return e.scopes[i].name
+		case "module":
+			return ""
+		}
+	}
+	return ""
+}
+
+// context returns the name usage is attributed to: the enclosing function, or the
+// module for statements in its body
+func (e *elixirFile) context() string {
+	if function := e.function(); function != "" {
+		return function
+	}
+	return e.moduleName()
+}
+
+// getElixirPath returns the qualified name of a module element
+func getElixirPath(element models.CodeElement) string {
+	if element.Namespace == "" {
+		return element.Name
+	}
+	return element.Namespace + `\` + element.Name
+}
+
+// elixirSignature splits the text after a function's name into its parameters and the
+// rest of the statement: a guard, "do", or a one-line body
+func elixirSignature(rest string) ([]string, string) {
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "(") {
+		if end := closingParen(rest); end != -1 {
+			return splitTopLevel(rest[1:end]), strings.TrimSpace(rest[end+1:])
+		}
+		return splitTopLevel(rest[1:]), ""
+	}
+	return nil, rest
+}
+
+// elixirName rewrites a module name with the analyzer's separator: MyApp.Accounts is
+// `MyApp\Accounts`
+func elixirName(name string) string {
+	return strings.ReplaceAll(strings.TrimSpace(name), ".", `\`)
+}
+
+// elixirDelimiters maps the characters that open a sigil to those that close it
+var elixirDelimiters = map[byte]byte{'(': ')', '[': ']', '{': '}', '<': '>', '/': '/', '|': '|', '"': '"', '\'': '\''}
+
+// stripElixirLine removes a comment from a line, returning the code and the code with
+// string, heredoc, sigil, and character literal contents blanked out. A string still
+// open at the end of the line is left in lex for the next one.
+func stripElixirLine(line string, lex *elixirLexer) (string, string) {
+	var code, bare strings.Builder
+	i := 0
+	if lex.closing != "" {
+		end := closeElixirString(line, 0, lex.closing)
+		if end == -1 {
+			return line, ""
+		}
+		code.WriteString(line[:end])
+		lex.closing = ""
+		i = skipSigilModifiers(line, end)
+	}
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case c == '#':
+			return code.String(), bare.String()
+		case c == '?' && i+1 < len(line) && (i == 0 || !isElixirWordChar(line[i-1])):
+			// Character literals: ?a, ?#, ?\n
+			n := 2
+			if line[i+1] == '\\' && i+2 < len(line) {
+				n = 3
+			}
+			code.WriteString(line[i : i+n])
+			bare.WriteString("0")
+			i += n
+		case c == '~' && i+2 < len(line) && isElixirLetter(line[i+1]):
+			// Sigils: ~r/\d+/i, ~w(draft sent)a, ~H"""
+			j := i + 1
+			for j < len(line) && isElixirLetter(line[j]) {
+				j++
+			}
+			closing, ok := elixirDelimiters[line[min(j, len(line)-1)]]
+			if j == len(line) || !ok {
+				code.WriteByte(c)
+				bare.WriteByte(c)
+				i++
+				continue
+			}
+			i = openElixirString(line, i, j, string(closing), lex, &code, &bare)
+		case c == '"' || c == '\'':
+			i = openElixirString(line, i, i, string(c), lex, &code, &bare)
+		default:
+			code.WriteByte(c)
+			bare.WriteByte(c)
+			i++
+		}
+	}
+	return code.String(), bare.String()
+}
+
+// openElixirString consumes a string, or a sigil from start, whose delimiter is at open,
+// returning the offset after it; a heredoc or unclosed string is left open in lex
+func openElixirString(line string, start, open int, closing string, lex *elixirLexer, code, bare *strings.Builder) int {
+	from := open + 1
+	if triple := strings.Repeat(closing, 3); (closing == `"` || closing == "'") && strings.HasPrefix(line[open:], triple) {
+		closing, from = triple, open+3
+	}
+	bare.WriteString(`""`)
+	end := closeElixirString(line, from, closing)
+	if end == -1 {
+		code.WriteString(line[start:])
+		lex.closing = closing
+		return len(line)
+	}
+	code.WriteString(line[start:end])
+	return skipSigilModifiers(line, end)
+}
+
+// closeElixirString returns the offset after the delimiter closing a string, skipping
+// escapes, or -1 when the string continues on the next line
+func closeElixirString(line string, from int, closing string) int {
+	for i := from; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(line[i:], closing) {
+			return i + len(closing)
+		}
+	}
+	return -1
+}
+
+// skipSigilModifiers skips the letters after a sigil's closing delimiter: ~r/admin/i
+func skipSigilModifiers(line string, i int) int {
+	for i < len(line) && isElixirLetter(line[i]) {
+		i++
+	}
+	return i
+}
+
+func isElixirLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isElixirWordChar(c byte) bool {
+	return c == '_' || isElixirLetter(c) || (c >= '0' && c <= '9')
+}
+
+// isElixirBuiltin checks if a name is a special form, a Kernel function or macro, or a
+// definition that code calls without a module
+func isElixirBuiltin(name string) bool {
+	switch name {
+	case "if", "unless", "case", "cond", "with", "for", "fn", "receive", "try", "raise", "reraise",
+		"throw", "quote", "unquote", "unquote_splicing", "import", "require", "alias", "use",
+		"def", "defp", "defmacro", "defmacrop", "defmodule", "defstruct", "defexception",
+		"defimpl", "defprotocol", "defdelegate", "defguard", "defguardp", "defoverridable",
+		"is_nil", "is_atom", "is_binary", "is_bitstring", "is_boolean", "is_float", "is_function",
+		"is_integer", "is_list", "is_map", "is_map_key", "is_number", "is_pid", "is_struct",
+		"is_tuple", "is_exception", "elem", "put_elem", "length", "map_size", "tuple_size",
+		"byte_size", "bit_size", "hd", "tl", "abs", "div", "rem", "round", "trunc", "max", "min",
+		"not", "and", "or", "in", "when", "to_string", "to_charlist", "inspect", "send", "spawn",
+		"spawn_link", "self", "apply", "exit", "struct", "struct!", "get_in", "put_in",
+		"update_in", "pop_in", "get_and_update_in", "then", "tap", "dbg", "super", "match?",
+		"binding", "make_ref", "function_exported?", "macro_exported?", "node", "do", "end",
+		"else", "after", "catch", "rescue", "sigil_r", "sigil_s", "sigil_w":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Elixir files concurrently
+func (p *ElixirParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *ElixirParser) Language() string {
+	return "elixir"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *ElixirParser) FileExtensions() []string {
+	return []string{".ex", ".exs"}
+}
+
+// Sniff recognizes extensionless Elixir scripts by an elixir shebang
+// ("#!/usr/bin/env elixir")
+func (p *ElixirParser) Sniff(header []byte) bool {
+	if !bytes.HasPrefix(header, []byte("#!")) {
+		return false
+	}
+	shebang, _, _ := bytes.Cut(header, []byte("\n"))
+	for _, field := range bytes.Fields(shebang[2:]) {
+		if name := filepath.Base(string(field)); name == "elixir" {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultExcludes returns the directories skipped in Elixir projects: Mix's build output
+// and dependencies, the language server's files, and coverage reports
+func (p *ElixirParser) DefaultExcludes() []string {
+	return []string{"_build", "deps", ".elixir_ls", ".fetch", "cover"}
+}
+
+// Entrypoints returns the functions OTP, Mix, Plug, and Phoenix call: process starts and
+// callbacks, __using__, and the standard controller actions
+func (p *ElixirParser) Entrypoints() []string {
+	return []string{
+		`^(start|start_link|init|child_spec|main|run|__using__|__before_compile__)$`,
+		`^(handle_call|handle_cast|handle_info|handle_continue|handle_event|handle_params|terminate|code_change|mount|render|call)$`,
+		`^(index|show|new|create|edit|update|delete)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewElixirParser())
+}
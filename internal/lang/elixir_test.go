@@ -0,0 +1,226 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestElixirParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `defmodule MyApp.Accounts do
+  @moduledoc """
+  Users and their sessions.
+  """
+
+  import Ecto.Query, only: [from: 2]
+  alias MyApp.{Repo, Mailer}
+  alias MyApp.Accounts.User, as: Account
+
+  @doc "Fetches a user by id."
+  @spec get_user(integer()) :: Account.t() | nil
+  def get_user(id), do: Repo.get(Account, id)
+
+  def register(%Account{} = user, opts \\ []) do
+    # TODO: rate limit
+    user
+    |> Account.changeset(opts)
+    |> Repo.insert()
+    |> notify()
+    |> case do
+      {:ok, user} -> {:ok, %Account{user | name: ~s(the "name" #{user.name})}}
+      error -> error
+    end
+  end
+
+  defp notify({:ok, user} = result) do
+    Enum.each([user], &Mailer.deliver/1)
+    Task.start(fn -> log(?#, "sent") end)
+    result
+  end
+
+  defp notify(result), do: result
+
+  defp log(_char, message), do: IO.puts(message)
+
+  defdelegate fetch(id), to: Repo, as: :get
+
+  defmodule Session do
+    use Ecto.Schema
+
+    schema "sessions" do
+      field :token, :string
+    end
+
+    def expired?(session), do: __MODULE__.age(session) > 30
+
+    def age(_session), do: 0
+  end
+end
+
+defprotocol MyApp.Shape do
+  def area(shape)
+end
+
+defimpl MyApp.Shape, for: MyApp.Accounts.Session do
+  def area(_), do: 0
+end
+`
+	path := writeFixture(t, tmp, "accounts.ex", code)
+
+	parsed, err := NewElixirParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "elixir" || parsed.Namespace != `MyApp\Accounts` {
+		t.Errorf("expected the first module as the namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	if len(parsed.Uses) != 5 || parsed.Uses[0] != `Ecto\Query` || parsed.Uses[1] != `MyApp\Repo` ||
+		parsed.Uses[3] != `MyApp\Accounts\User` || parsed.Uses[4] != `Ecto\Schema` {
+		t.Errorf("expected the imported, aliased, and used modules, got %v", parsed.Uses)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Namespace+"."+el.Name] = el
+	}
+	for _, key := range []string{`module:MyApp.Accounts`, `function:MyApp\Accounts.get_user`, `function:MyApp\Accounts.register`,
+		`function:MyApp\Accounts.notify`, `function:MyApp\Accounts.log`, `function:MyApp\Accounts.fetch`,
+		`module:MyApp\Accounts.Session`, `function:MyApp\Accounts\Session.expired?`, `function:MyApp\Accounts\Session.age`,
+		`interface:MyApp.Shape`, `function:MyApp\Shape.area`, `module:MyApp\Shape.Session`, `function:MyApp\Shape\Session.area`} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 13 {
+		t.Errorf("expected 13 elements, with one per function of several clauses, got %+v", parsed.Elements)
+	}
+
+	if !elements[`module:MyApp.Accounts`].Documented || !elements[`function:MyApp\Accounts.get_user`].Documented ||
+		elements[`function:MyApp\Accounts.register`].Documented {
+		t.Error("expected only the module and the function with docs to be documented")
+	}
+	register := elements[`function:MyApp\Accounts.register`]
+	if len(register.Parameters) != 2 || register.Parameters[0] != "user" || register.Parameters[1] != "opts" ||
+		register.ParamTypes[0] != `MyApp\Accounts\User` {
+		t.Errorf("expected [user opts] with the struct's type, got %v %v", register.Parameters, register.ParamTypes)
+	}
+	if notify := elements[`function:MyApp\Accounts.notify`]; notify.Visibility != "private" || notify.Parameters[0] != "result" {
+		t.Errorf("expected a private function binding its parameter, got %+v", notify)
+	}
+	if !elements[`function:MyApp\Shape.area`].IsAbstract || elements[`function:MyApp\Shape\Session.area`].IsAbstract {
+		t.Error("expected only the protocol's function to be abstract")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		`type_reference:Ecto\Query in Accounts`,
+		`type_reference:MyApp\Mailer in Accounts`,
+		`type_reference:MyApp\Accounts\User in get_user`,
+		`function_call:MyApp\Repo\get in get_user`,
+		`function_call:MyApp\Accounts\User\changeset in register`,
+		`function_call:MyApp\Repo\insert in register`,
+		`function_call:MyApp\Accounts\notify in register`,
+		`instantiation:MyApp\Accounts\User in register`,
+		`function_call:MyApp\Mailer\deliver in notify`,
+		`function_call:MyApp\Accounts\log in notify`,
+		`function_call:IO\puts in log`,
+		`function_call:MyApp\Repo\get in fetch`,
+		`uses_trait:Ecto\Schema in Session`,
+		`function_call:MyApp\Accounts\Session\age in expired?`,
+		`implements:MyApp\Shape in Session`,
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{`function_call:case in register`, `function_call:name in register`,
+		`function_call:fn in notify`, `function_call:field in Session`} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Table != "sessions" || parsed.Tables[0].ClassName != "Session" {
+		t.Errorf("expected the schema's table, got %+v", parsed.Tables)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 15 {
+		t.Errorf("expected the TODO on line 15, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 4 {
+		t.Errorf("expected 4 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestElixirParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"lib/shop", "lib/shop_web/controllers"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "lib/shop/orders.ex", `defmodule Shop.Orders do
+  alias Shop.Repo
+
+  def list_orders, do: Repo.all(Shop.Order) |> Enum.map(&total/1)
+
+  def total(order), do: order.price * order.quantity
+
+  def unused, do: nil
+end
+`)
+	writeFixture(t, tmp, "lib/shop_web/controllers/order_controller.ex", `defmodule ShopWeb.OrderController do
+  use ShopWeb, :controller
+
+  alias Shop.Orders
+
+  def index(conn, _params) do
+    render(conn, :index, orders: Orders.list_orders())
+  end
+end
+`)
+
+	p := NewElixirParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"lib/shop/orders.ex", "lib/shop_web/controllers/order_controller.ex"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Name] = node
+	}
+	if nodes["index"].Dependencies[nodes["list_orders"].ID] == nil {
+		t.Errorf("expected the action to call the context through its alias, got %+v", nodes["index"].Dependencies)
+	}
+	if nodes["list_orders"].Dependencies[nodes["total"].ID] == nil {
+		t.Errorf("expected the capture to call the module's function, got %+v", nodes["list_orders"].Dependencies)
+	}
+	if nodes["OrderController"].Dependencies[nodes["Orders"].ID] == nil {
+		t.Errorf("expected the alias to reference the module, got %+v", nodes["OrderController"].Dependencies)
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Name] = true
+	}
+	if !orphans["unused"] || orphans["total"] || orphans["index"] || orphans["Orders"] {
+		t.Errorf("expected only the unused function among the orphans, got %v", orphans)
+	}
+}
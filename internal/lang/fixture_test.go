@@ -0,0 +1,17 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixture writes a source file for a parser test and returns its path
+func writeFixture(t *testing.T, dir, name, code string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
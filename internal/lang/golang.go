@@ -0,0 +1,500 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// GoParser handles parsing of Go files. Unlike the other parsers it works on the syntax
+// tree from the standard library's go/parser, so it isn't fooled by formatting.
+type GoParser struct{}
+
+// goFile is the state of one file's walk
+type goFile struct {
+	fset    *token.FileSet
+	parsed  *models.ParsedFile
+	imports map[string]string // Name a package is imported as → import path
+}
+
+// NewGoParser creates a new Go parser
+func NewGoParser() *GoParser {
+	return &GoParser{}
+}
+
+// ParseFile analyzes a single Go file and extracts all elements
+func (p *GoParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	return p.parsePackageFile(filePath, filepath.Dir(filePath))
+}
+
+// parsePackageFile parses a file of the package whose import path it derives from the
+// enclosing go.mod, or else from the file's directory relative to root
+func (p *GoParser) parsePackageFile(filePath, root string) (*models.ParsedFile, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, filePath, src, goparser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &goFile{
+		fset:    fset,
+		imports: make(map[string]string),
+		parsed: &models.ParsedFile{
+			Path:      filePath,
+			Language:  "go",
+			Namespace: importPath(filePath, root, file.Name.Name),
+			Elements:  []models.CodeElement{},
+			Usage:     []models.UsageElement{},
+			Uses:      []string{},
+		},
+	}
+	for _, spec := range file.Imports {
+		importPath := strings.Trim(spec.Path.Value, "`\"")
+		g.parsed.Uses = append(g.parsed.Uses, importPath)
+		name := packageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name != "_" && name != "." {
+			g.imports[name] = importPath
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			g.genDecl(decl)
+		case *ast.FuncDecl:
+			g.funcDecl(decl)
+		}
+	}
+
+	scanLines(src, g.parsed)
+	return g.parsed, nil
+}
+
+// genDecl records the types and constants a declaration declares
+func (g *goFile) genDecl(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		documented := decl.Doc != nil && len(decl.Specs) == 1
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			kind := "type"
+			switch spec.Type.(type) {
+			case *ast.StructType:
+				kind = "class"
+			case *ast.InterfaceType:
+				kind = "interface"
+			}
+			g.addElement(kind, spec.Name, "", documented || spec.Doc != nil)
+
+			skip := typeParamNames(spec.TypeParams)
+			switch t := spec.Type.(type) {
+			case *ast.StructType:
+				for _, field := range t.Fields.List {
+					if len(field.Names) == 0 {
+						g.addTypeUsage("extends", field.Type, spec.Name.Name, skip) // Embedded
+					} else {
+						g.addTypeUsage("type_reference", field.Type, spec.Name.Name, skip)
+					}
+				}
+			case *ast.InterfaceType:
+				for _, method := range t.Methods.List {
+					if len(method.Names) == 0 {
+						g.addTypeUsage("extends", method.Type, spec.Name.Name, skip) // Embedded, or a constraint
+					} else {
+						g.addTypeUsage("type_reference", method.Type, spec.Name.Name, skip)
+					}
+				}
+			default:
+				g.addTypeUsage("type_reference", spec.Type, spec.Name.Name, skip)
+			}
+		case *ast.ValueSpec:
+			if decl.Tok != token.CONST {
+				continue
+			}
+			for _, name := range spec.Names {
+				if name.Name != "_" {
+					g.addElement("constant", name, "", documented || spec.Doc != nil)
+				}
+			}
+		}
+	}
+}
+
+// funcDecl records a function, or a method of its receiver's type, and its usage
+func (g *goFile) funcDecl(decl *ast.FuncDecl) {
+	className, receiver := "", ""
+	skip := typeParamNames(decl.Type.TypeParams)
+	if decl.Recv != nil && len(decl.Recv.List) > 0 {
+		recv := decl.Recv.List[0]
+		typ := recv.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		// Type parameters of a generic receiver: func (l *List[T]) Push(v T)
+		switch index := typ.(type) {
+		case *ast.IndexExpr:
+			typ = index.X
+			for name := range exprNames(index.Index) {
+				skip[name] = true
+			}
+		case *ast.IndexListExpr:
+			typ = index.X
+			for _, param := range index.Indices {
+				for name := range exprNames(param) {
+					skip[name] = true
+				}
+			}
+		}
+		if ident, ok := typ.(*ast.Ident); ok {
+			className = ident.Name
+		}
+		if len(recv.Names) > 0 {
+			receiver = recv.Names[0].Name
+		}
+	}
+
+	kind := "function"
+	if className != "" {
+		kind = "method"
+	}
+	element := g.addElement(kind, decl.Name, className, decl.Doc != nil)
+	element.Parameters = []string{}
+	for _, field := range decl.Type.Params.List {
+		names := []string{"_"} // Unnamed parameters still count
+		if len(field.Names) > 0 {
+			names = names[:0]
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+		}
+		for _, name := range names {
+			element.Parameters = append(element.Parameters, name)
+			element.ParamTypes = append(element.ParamTypes, g.typeNames(field.Type, skip))
+		}
+	}
+	if decl.Type.Results != nil {
+		var returns []string
+		for _, field := range decl.Type.Results.List {
+			if names := g.typeNames(field.Type, skip); names != "" {
+				returns = append(returns, names)
+			}
+		}
+		element.ReturnType = strings.Join(returns, "|")
+	}
+
+	if decl.Body != nil {
+		g.addBodyUsage(decl.Body, decl.Name.Name, className, receiver, skip)
+	}
+}
+
+// addElement appends an element declared by name and returns it for the caller to fill in
+func (g *goFile) addElement(kind string, name *ast.Ident, className string, documented bool) *models.CodeElement {
+	visibility := "private"
+	if name.IsExported() {
+		visibility = "public"
+	}
+	g.parsed.Elements = append(g.parsed.Elements, models.CodeElement{
+		Type:       kind,
+		Name:       name.Name,
+		Namespace:  g.parsed.Namespace,
+		ClassName:  className,
+		Visibility: visibility,
+		Line:       g.fset.Position(name.Pos()).Line,
+		File:       g.parsed.Path,
+		Documented: documented,
+	})
+	return &g.parsed.Elements[len(g.parsed.Elements)-1]
+}
+
+// addBodyUsage records the calls, composite literals, and types named in a function body.
+// Calls on the method's receiver are recorded as calls on "this", which the analyzer
+// resolves against the receiver type's methods.
+func (g *goFile) addBodyUsage(body *ast.BlockStmt, context, className, receiver string, skip map[string]bool) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			g.addCall(n, context, className, receiver)
+		case *ast.CompositeLit:
+			if n.Type != nil {
+				g.addTypeUsage("instantiation", n.Type, context, skip)
+			}
+		case *ast.ValueSpec:
+			if n.Type != nil {
+				g.addTypeUsage("type_reference", n.Type, context, skip)
+			}
+		case *ast.TypeAssertExpr:
+			if n.Type != nil {
+				g.addTypeUsage("type_reference", n.Type, context, skip)
+			}
+		case *ast.BasicLit:
+			if n.Kind == token.STRING {
+				line := g.fset.Position(n.Pos()).Line
+				g.parsed.Tables = append(g.parsed.Tables, sqlTables(n.Value, line, className, context)...)
+			}
+		}
+		return true
+	})
+}
+
+// addCall records a function or method call
+func (g *goFile) addCall(call *ast.CallExpr, context, className, receiver string) {
+	fun := call.Fun
+	switch index := fun.(type) {
+	case *ast.IndexExpr: // Explicit instantiation: Map[string](values)
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+
+	usage := models.UsageElement{Context: context, Line: g.fset.Position(call.Pos()).Line}
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		if isGoBuiltin(fun.Name) {
+			return
+		}
+		usage.Type, usage.Name = "function_call", fun.Name
+	case *ast.SelectorExpr:
+		x, isIdent := fun.X.(*ast.Ident)
+		switch {
+		case isIdent && g.imports[x.Name] != "" && x.Obj == nil:
+			usage.Type, usage.Name = "function_call", qualify(g.imports[x.Name], fun.Sel.Name)
+		case isIdent && x.Name == receiver && receiver != "":
+			usage.Type, usage.Name, usage.Receiver = "method_call", fun.Sel.Name, "this"
+		default:
+			usage.Type, usage.Name, usage.Receiver = "method_call", fun.Sel.Name, types.ExprString(fun.X)
+		}
+	default:
+		return // Function values, such as closures called in place
+	}
+	g.parsed.Usage = append(g.parsed.Usage, usage)
+}
+
+// addTypeUsage records the project types named in a type expression as usage of
+// usageType, such as "type_reference" for a field's type
+func (g *goFile) addTypeUsage(usageType string, expr ast.Expr, context string, skip map[string]bool) {
+	for _, name := range strings.Split(g.typeNames(expr, skip), "|") {
+		if name == "" {
+			continue
+		}
+		g.parsed.Usage = append(g.parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    name,
+			Context: context,
+			Line:    g.fset.Position(expr.Pos()).Line,
+		})
+	}
+}
+
+// typeNames lists the named types in a type expression, separated by "|", leaving out
+// predeclared types and type parameters. Types from imported packages are qualified
+// with their import path, the way their nodes are named.
+func (g *goFile) typeNames(expr ast.Expr, skip map[string]bool) string {
+	var names []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && g.imports[x.Name] != "" {
+				names = append(names, qualify(g.imports[x.Name], n.Sel.Name))
+			}
+			return false
+		case *ast.Ident:
+			if !skip[n.Name] && !isGoBuiltin(n.Name) {
+				names = append(names, n.Name)
+			}
+		case *ast.FuncType:
+			// Parameter names aren't types
+			for _, list := range []*ast.FieldList{n.Params, n.Results} {
+				if list == nil {
+					continue
+				}
+				for _, field := range list.List {
+					if name := g.typeNames(field.Type, skip); name != "" {
+						names = append(names, name)
+					}
+				}
+			}
+			return false
+		case *ast.Field:
+			// Interface methods and struct fields inside anonymous types
+			if name := g.typeNames(n.Type, skip); name != "" {
+				names = append(names, name)
+			}
+			return false
+		}
+		return true
+	})
+	return strings.Join(names, "|")
+}
+
+// typeParamNames returns the names of a declaration's type parameters
+func typeParamNames(params *ast.FieldList) map[string]bool {
+	names := make(map[string]bool)
+	if params == nil {
+		return names
+	}
+	for _, field := range params.List {
+		for _, name := range field.Names {
+			names[name.Name] = true
+		}
+	}
+	return names
+}
+
+// exprNames returns the identifiers in an expression
+func exprNames(expr ast.Expr) map[string]bool {
+	names := make(map[string]bool)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			names[ident.Name] = true
+		}
+		return true
+	})
+	return names
+}
+
+// qualify names a declaration of another package the way the analyzer indexes it
+func qualify(importPath, name string) string {
+	return importPath + `\` + name
+}
+
+// scanLines counts a file's lines and comment-only lines, and collects its debt markers
+func scanLines(src []byte, parsed *models.ParsedFile) {
+	lines := bufio.NewScanner(bytes.NewReader(src))
+	lines.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	inComment := false
+	for lines.Scan() {
+		parsed.Lines++
+		if marker, ok := debtMarker(lines.Text(), parsed.Lines); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		var code string
+		code, _, inComment = stripJSLine(lines.Text(), inComment)
+		if strings.TrimSpace(code) == "" && strings.TrimSpace(lines.Text()) != "" {
+			parsed.CommentLines++
+		}
+	}
+}
+
+// importPath derives a package's import path from the go.mod above it: the module path
+// joined with the package directory. Outside a module it's the directory relative to
+// root, or the package name for a file in root itself.
+func importPath(filePath, root, packageName string) string {
+	dir := filepath.Dir(filePath)
+	for modDir := dir; ; {
+		if module := modulePath(filepath.Join(modDir, "go.mod")); module != "" {
+			rel, err := filepath.Rel(modDir, dir)
+			if err != nil || rel == "." {
+				return module
+			}
+			return path.Join(module, filepath.ToSlash(rel))
+		}
+		parent := filepath.Dir(modDir)
+		if parent == modDir {
+			break
+		}
+		modDir = parent
+	}
+
+	if rel, err := filepath.Rel(root, dir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return packageName
+}
+
+// goModulePattern finds the module directive in a go.mod file
+var goModulePattern = regexp.MustCompile(`(?m)^\s*module\s+"?([^\s"]+)"?`)
+
+// modulePath reads the module path from a go.mod file, or returns "" when there is none
+func modulePath(goMod string) string {
+	data, err := os.ReadFile(goMod)
+	if err != nil {
+		return ""
+	}
+	if match := goModulePattern.FindSubmatch(data); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+// packageName guesses the name a package is imported under from its path, the way
+// goimports does: the last element, without a major version or gopkg.in suffix
+func packageName(importPath string) string {
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && strings.HasPrefix(name, "v") && strings.Trim(name[1:], "0123456789") == "" && len(name) > 1 {
+		name = elements[len(elements)-2] // github.com/org/pkg/v2
+	}
+	if idx := strings.Index(name, ".v"); idx > 0 {
+		name = name[:idx] // gopkg.in/yaml.v3
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// isGoBuiltin checks if a name is one of Go's predeclared types or functions
+func isGoBuiltin(name string) bool {
+	switch name {
+	case "bool", "byte", "complex64", "complex128", "error", "float32", "float64", "int", "int8",
+		"int16", "int32", "int64", "rune", "string", "uint", "uint8", "uint16", "uint32", "uint64",
+		"uintptr", "any", "comparable", "append", "cap", "clear", "close", "complex", "copy",
+		"delete", "imag", "len", "make", "max", "min", "new", "panic", "print", "println", "real",
+		"recover", "nil", "true", "false", "iota":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Go files concurrently
+func (p *GoParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) {
+		root := filepath.Clean(strings.TrimSuffix(file.Path, file.RelativePath))
+		return p.parsePackageFile(file.Path, root)
+	})
+}
+
+// Language returns the language name for this parser
+func (p *GoParser) Language() string {
+	return "go"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *GoParser) FileExtensions() []string {
+	return []string{".go"}
+}
+
+// DefaultExcludes returns the directories skipped in Go projects: vendored modules and
+// test fixtures, which the go tool ignores too
+func (p *GoParser) DefaultExcludes() []string {
+	return []string{"vendor", "testdata"}
+}
+
+// Entrypoints returns the functions the Go runtime and test runner call: main, init,
+// and tests, benchmarks, fuzz tests, and examples
+func (p *GoParser) Entrypoints() []string {
+	return []string{`^(main|init)$`, `^(Test|Benchmark|Fuzz|Example)([A-Z_].*)?$`}
+}
+
+func init() {
+	parser.Register(NewGoParser())
+}
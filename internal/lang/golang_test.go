@@ -0,0 +1,223 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestGoParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "go.mod", "module example.com/shop\n\ngo 1.22\n")
+	if err := os.MkdirAll(filepath.Join(tmp, "orders"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	code := `// Package orders places orders.
+package orders
+
+import (
+	"fmt"
+	yaml "gopkg.in/yaml.v3"
+
+	"example.com/shop/billing"
+)
+
+// MaxItems caps an order's size
+const MaxItems = 50
+
+const (
+	statusOpen   = "open"
+	statusClosed = "closed"
+)
+
+// Store persists orders
+type Store interface {
+	Save(o *Order) error
+}
+
+// Order is a customer's order
+type Order struct {
+	billing.Account
+	Items []Item
+	store Store
+}
+
+type Item struct{ SKU string }
+
+type List[T any] []T
+
+// Place charges and saves the order
+func (o *Order) Place(ctx context, n int, _ string) (*billing.Receipt, error) {
+	if err := o.validate(); err != nil { // TODO: wrap the error
+		return nil, fmt.Errorf("invalid order: %w", err)
+	}
+	receipt := billing.Charge(o.Account, len(o.Items))
+	var item Item
+	_ = item
+	return &billing.Receipt{ID: receipt.ID}, o.store.Save(o)
+}
+
+func (o *Order) validate() error {
+	data, _ := yaml.Marshal(o)
+	return check(data)
+}
+
+func (l List[T]) First() T { return l[0] }
+
+func check(data []byte) error { return nil }
+`
+	path := writeFixture(t, tmp, "orders/order.go", code)
+
+	parsed, err := NewGoParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "go" || parsed.Namespace != "example.com/shop/orders" {
+		t.Errorf("expected the package's import path as its namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	if len(parsed.Uses) != 3 || parsed.Uses[1] != "gopkg.in/yaml.v3" {
+		t.Errorf("expected the import paths, got %v", parsed.Uses)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Name] = el
+	}
+	for _, key := range []string{"constant:MaxItems", "constant:statusOpen", "constant:statusClosed",
+		"interface:Store", "class:Order", "class:Item", "type:List", "method:Place", "method:validate",
+		"method:First", "function:check"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 11 {
+		t.Errorf("expected 11 elements, got %+v", parsed.Elements)
+	}
+
+	place := elements["method:Place"]
+	if place.ClassName != "Order" || place.Visibility != "public" || !place.Documented {
+		t.Errorf("expected a documented public method of Order, got %+v", place)
+	}
+	if len(place.Parameters) != 3 || place.Parameters[2] != "_" || place.ParamTypes[0] != "context" {
+		t.Errorf("expected parameters [ctx n _], got %v %v", place.Parameters, place.ParamTypes)
+	}
+	if place.ReturnType != `example.com/shop/billing\Receipt` {
+		t.Errorf("expected the imported return type qualified with its package, got %q", place.ReturnType)
+	}
+	if elements["method:First"].ClassName != "List" || elements["method:First"].ReturnType != "" {
+		t.Errorf("expected the generic receiver's type and no type parameter return, got %+v", elements["method:First"])
+	}
+	if elements["method:validate"].Visibility != "private" || elements["class:Item"].Documented {
+		t.Error("expected unexported and undocumented declarations to be reported as such")
+	}
+	if !elements["constant:MaxItems"].Documented || elements["constant:statusOpen"].Documented {
+		t.Error("expected only the documented constant to be documented")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		`extends:.example.com/shop/billing\Account in Order`,
+		"type_reference:.Item in Order",
+		"type_reference:.Store in Order",
+		"type_reference:.Order in Store",
+		"method_call:this.validate in Place",
+		`function_call:.fmt\Errorf in Place`,
+		`function_call:.example.com/shop/billing\Charge in Place`,
+		`instantiation:.example.com/shop/billing\Receipt in Place`,
+		"method_call:o.store.Save in Place",
+		"type_reference:.Item in Place",
+		`function_call:.gopkg.in/yaml.v3\Marshal in validate`,
+		"function_call:.check in validate",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"function_call:.len in Place", "type_reference:.T in List"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 37 {
+		t.Errorf("expected the TODO on line 37, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 5 {
+		t.Errorf("expected 5 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestGoParser_SyntaxError(t *testing.T) {
+	path := writeFixture(t, t.TempDir(), "broken.go", "package broken\n\nfunc {\n")
+	if _, err := NewGoParser().ParseFile(path); err == nil {
+		t.Error("expected a syntax error")
+	}
+}
+
+func TestGoParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "go.mod", "module example.com/shop\n")
+	for _, dir := range []string{"billing", "cmd/shop"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "billing/billing.go", `package billing
+
+type Invoice struct{ Total int }
+
+func (i *Invoice) Send() error { return i.render() }
+
+func (i *Invoice) render() error { return nil }
+
+func New(total int) *Invoice { return &Invoice{Total: total} }
+`)
+	writeFixture(t, tmp, "cmd/shop/main.go", `package main
+
+import "example.com/shop/billing"
+
+func main() {
+	invoice := billing.New(10)
+	_ = invoice.Send()
+}
+`)
+
+	p := NewGoParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"billing/billing.go", "cmd/shop/main.go"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Name] = node
+	}
+	if nodes["main"] == nil || nodes["main"].Dependencies[nodes["New"].ID] == nil {
+		t.Errorf("expected main to depend on the imported package's function, got %+v", nodes["main"])
+	}
+	if !nodes["main"].IsEntrypoint {
+		t.Error("expected main to be an entrypoint")
+	}
+	if nodes["Send"].Dependencies[nodes["render"].ID] == nil {
+		t.Errorf("expected i.render() to resolve to the receiver's method, got %+v", nodes["Send"].Dependencies)
+	}
+	if nodes["New"].Dependencies[nodes["Invoice"].ID] == nil {
+		t.Errorf("expected the composite literal to instantiate the struct, got %+v", nodes["New"].Dependencies)
+	}
+}
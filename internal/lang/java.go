@@ -0,0 +1,745 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// JavaParser handles parsing of Java files
+type JavaParser struct {
+	packagePattern    *regexp.Regexp
+	importPattern     *regexp.Regexp
+	annotationPattern *regexp.Regexp
+	typePattern       *regexp.Regexp
+	methodPattern     *regexp.Regexp
+	fieldPattern      *regexp.Regexp
+	newPattern        *regexp.Regexp
+	callPattern       *regexp.Regexp
+	methodRefPattern  *regexp.Regexp
+	catchPattern      *regexp.Regexp
+	instanceofPattern *regexp.Regexp
+	tablePattern      *regexp.Regexp
+}
+
+// javaScope is a type or method body the parser is inside
+type javaScope struct {
+	kind      string // "type" or "method"
+	name      string
+	depth     int  // Brace depth inside the body
+	constants bool // In an enum body, before the semicolon ending its constants
+}
+
+// javaFile is the state of one file's parse
+type javaFile struct {
+	parsed      *models.ParsedFile
+	imports     map[string]string // Simple name → qualified name, e.g. "User" → `com.acme.model\User`
+	staticNames map[string]string // Statically imported member → qualified name of its class
+	scopes      []javaScope
+}
+
+// NewJavaParser creates a new Java parser with compiled regex patterns
+func NewJavaParser() *JavaParser {
+	return &JavaParser{
+		// Package: package com.acme.service;
+		packagePattern: regexp.MustCompile(`^\s*package\s+([\w.]+)\s*;`),
+
+		// Imports: import com.acme.model.User; import static org.junit.Assert.assertEquals;
+		importPattern: regexp.MustCompile(`^\s*import\s+(static\s+)?([\w$.]+?)(\.\*)?\s*;`),
+
+		// A leading annotation: @Service, @RequestMapping("/users"), @org.junit.Test
+		// ("@interface" declares an annotation type instead)
+		annotationPattern: regexp.MustCompile(`^\s*@([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)`),
+
+		// Types: public final class UserService extends Base implements Service, record Point(int x, int y)
+		typePattern: regexp.MustCompile(`^\s*((?:(?:public|protected|private|abstract|final|static|sealed|non-sealed|strictfp)\s+)*)(class|interface|enum|record|@interface)\s+([A-Za-z_$][\w$]*)`),
+
+		// Methods and constructors: public <T> List<T> findAll(Class<T> type) throws IOException {
+		methodPattern: regexp.MustCompile(`^\s*((?:(?:public|protected|private|static|final|abstract|synchronized|native|default|strictfp)\s+)*)(?:<[^()]*>\s*)?(?:([\w$.<>\[\]?,\s]+?)\s+)?([A-Za-z_$][\w$]*)\s*\(`),
+
+		// Fields: private final UserRepository users; static final int MAX = 10;
+		fieldPattern: regexp.MustCompile(`^\s*((?:(?:public|protected|private|static|final|transient|volatile)\s+)*)([\w$.<>\[\]?,\s]+?)\s+([A-Za-z_$][\w$]*)\s*(?:=|;|,)`),
+
+		// Instantiations: new User(), new HashMap<>(), new com.acme.User()
+		newPattern: regexp.MustCompile(`\bnew\s+([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)\s*[(<{]`),
+
+		// Calls: save(user), users.save(user), this.users.save(user)
+		callPattern: regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*\(`),
+
+		// Method references: UserService::create, this::handle
+		methodRefPattern: regexp.MustCompile(`([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)::([A-Za-z_$][\w$]*)`),
+
+		// Exception handlers: catch (NotFoundException | IllegalStateException e)
+		catchPattern: regexp.MustCompile(`\bcatch\s*\(\s*(?:final\s+)?([\w$.]+(?:\s*\|\s*[\w$.]+)*)`),
+
+		// Type checks: value instanceof User, value instanceof User user
+		instanceofPattern: regexp.MustCompile(`\binstanceof\s+(?:final\s+)?([A-Za-z_$][\w$.]*)`),
+
+		// JPA entities: @Table(name = "users")
+		tablePattern: regexp.MustCompile(`@(?:javax\.persistence\.|jakarta\.persistence\.)?Table\s*\([^)]*\bname\s*=\s*"([^"]+)"`),
+	}
+}
+
+// ParseFile analyzes a single Java file and extracts all elements
+func (p *JavaParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	j := &javaFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		imports:     make(map[string]string),
+		staticNames: make(map[string]string),
+	}
+	parsed := j.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	inComment := false
+	docblock := false // A /** */ Javadoc comment precedes the next declaration
+
+	var annotations []string // Annotations awaiting the declaration they annotate
+	var tables []string      // @Table names awaiting their entity class
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		if !inComment && strings.HasPrefix(strings.TrimSpace(line), "/**") {
+			docblock = true
+		}
+		code, bare, stillInComment := stripJSLine(line, inComment)
+		inComment = stillInComment
+		if strings.TrimSpace(bare) == "" {
+			if strings.TrimSpace(line) != "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Join multi-line declarations and calls, so a parameter list is parsed whole
+		for parenBalance(bare) > 0 && !inComment && scanner.Scan() {
+			joinedLines++
+			nextCode, nextBare, nextInComment := stripJSLine(scanner.Text(), false)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+			inComment = nextInComment
+		}
+		// A type declaration's clauses may wrap before its body opens
+		if p.typePattern.MatchString(stripAnnotations(bare)) {
+			for !strings.ContainsAny(bare, "{;") && !inComment && scanner.Scan() {
+				joinedLines++
+				nextCode, nextBare, nextInComment := stripJSLine(scanner.Text(), false)
+				code += " " + strings.TrimSpace(nextCode)
+				bare += " " + strings.TrimSpace(nextBare)
+				inComment = nextInComment
+			}
+		}
+
+		if matches := p.packagePattern.FindStringSubmatch(bare); matches != nil {
+			parsed.Namespace = matches[1]
+			continue
+		}
+		if matches := p.importPattern.FindStringSubmatch(bare); matches != nil {
+			j.addImport(matches[2], matches[1] != "", matches[3] != "")
+			continue
+		}
+
+		// Annotations on their own lines wait for the declaration that follows them
+		if m := p.tablePattern.FindStringSubmatch(code); m != nil {
+			tables = append(tables, m[1])
+		}
+		for {
+			match := p.annotationPattern.FindStringSubmatchIndex(bare)
+			if match == nil || strings.HasPrefix(strings.TrimSpace(bare), "@interface") {
+				break
+			}
+			annotations = append(annotations, bare[match[2]:match[3]])
+			bare = strings.TrimSpace(bare[match[1]:])
+			if strings.HasPrefix(bare, "(") {
+				if end := closingParen(bare); end != -1 {
+					bare = bare[end+1:]
+				}
+			}
+		}
+		if strings.TrimSpace(bare) == "" {
+			continue
+		}
+
+		documented := docblock
+		docblock = false
+		annotating := annotations
+		annotations = nil
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare // The part of the line after a declaration, parsed for usage
+
+		inTypeBody := len(j.scopes) > 0 && j.scopes[len(j.scopes)-1].kind == "type" && j.scopes[len(j.scopes)-1].depth == depthBefore
+		if inTypeBody && j.scopes[len(j.scopes)-1].constants {
+			body = j.parseEnumConstants(bare, lineNum, documented)
+			j.addAnnotations(annotating, j.context(), lineNum)
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			element := models.CodeElement{
+				Type:       "class",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				Visibility: j.visibility(modifiers),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				IsAbstract: strings.Contains(modifiers, "abstract"),
+			}
+			switch keyword {
+			case "interface", "@interface":
+				element.Type = "interface"
+			case "enum":
+				element.Type = "enum"
+			case "record":
+				element.IsReadonly = true
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			j.addAnnotations(annotating, name, lineNum)
+			for _, table := range tables {
+				parsed.Tables = append(parsed.Tables, models.TableReference{Table: table, Kind: "model", ClassName: name, Line: lineNum})
+			}
+
+			header := bare[matches[1]:]
+			if idx := strings.Index(header, "{"); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+				j.scopes = append(j.scopes, javaScope{kind: "type", name: name, depth: depthBefore + 1, constants: keyword == "enum"})
+				if keyword == "enum" && strings.TrimSpace(body) != "" {
+					body = j.parseEnumConstants(body, lineNum, false)
+				}
+			} else {
+				body = ""
+			}
+			j.parseTypeHeader(header, keyword, name, lineNum)
+		} else if inTypeBody {
+			if method := p.methodPattern.FindStringSubmatchIndex(bare); method != nil && j.isMethod(bare, method) {
+				modifiers := bare[method[2]:method[3]]
+				returnType := ""
+				if method[4] != -1 {
+					returnType = bare[method[4]:method[5]]
+				}
+				name := bare[method[6]:method[7]]
+				rest := bare[method[1]-1:]
+				params := ""
+				if end := closingParen(rest); end != -1 {
+					params, rest = rest[1:end], rest[end+1:]
+				}
+
+				className := j.scopes[len(j.scopes)-1].name
+				inInterface := j.isInterface(className)
+				element := models.CodeElement{
+					Type:       "method",
+					Name:       name,
+					Namespace:  parsed.Namespace,
+					ClassName:  className,
+					Visibility: j.visibility(modifiers),
+					IsStatic:   strings.Contains(modifiers, "static"),
+					IsAbstract: strings.Contains(modifiers, "abstract") || (inInterface && !strings.Contains(rest, "{")),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+					Parameters: []string{},
+					ReturnType: j.typeNames(returnType),
+				}
+				for _, param := range splitTopLevel(params) {
+					paramName, paramType := javaParameter(param)
+					if paramName == "" {
+						continue
+					}
+					element.Parameters = append(element.Parameters, paramName)
+					element.ParamTypes = append(element.ParamTypes, j.typeNames(paramType))
+				}
+				if inInterface && element.Visibility == "package" {
+					element.Visibility = "public"
+				}
+				parsed.Elements = append(parsed.Elements, element)
+				j.addAnnotations(annotating, name, lineNum)
+
+				body = ""
+				if idx := strings.Index(rest, "{"); idx != -1 {
+					body = rest[idx+1:]
+					j.scopes = append(j.scopes, javaScope{kind: "method", name: name, depth: depthBefore + 1})
+				}
+			} else if index := p.fieldPattern.FindStringSubmatchIndex(bare); index != nil && !isJavaKeyword(strings.Fields(bare[index[4]:index[5]])[0]) {
+				field := []string{bare[index[0]:index[1]], bare[index[2]:index[3]], bare[index[4]:index[5]], bare[index[6]:index[7]]}
+				className := j.scopes[len(j.scopes)-1].name
+				modifiers, fieldType := field[1], strings.TrimSpace(field[2])
+				element := models.CodeElement{
+					Type:       "property",
+					Name:       field[3],
+					Namespace:  parsed.Namespace,
+					ClassName:  className,
+					Visibility: j.visibility(modifiers),
+					IsStatic:   strings.Contains(modifiers, "static"),
+					IsReadonly: strings.Contains(modifiers, "final"),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				}
+				if j.isInterface(className) || (element.IsStatic && element.IsReadonly && field[3] == strings.ToUpper(field[3])) {
+					element.Type = "constant"
+					element.IsStatic, element.IsReadonly = false, false
+					if element.Visibility == "package" && j.isInterface(className) {
+						element.Visibility = "public"
+					}
+				}
+				parsed.Elements = append(parsed.Elements, element)
+				j.addAnnotations(annotating, className, lineNum)
+
+				// A field's type is a dependency of its class, such as an injected service
+				for _, name := range strings.Split(j.typeNames(fieldType), "|") {
+					if name != "" {
+						parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: name, Context: className, Line: lineNum})
+					}
+				}
+				body = bare[index[7]:]
+			} else {
+				j.addAnnotations(annotating, j.context(), lineNum)
+			}
+		} else {
+			j.addAnnotations(annotating, j.context(), lineNum)
+		}
+
+		p.parseUsage(j, body, lineNum)
+		className, context := j.className(), j.context()
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, className, context)...)
+
+		// Leave the bodies closed on this line
+		for len(j.scopes) > 0 && braceDepth < j.scopes[len(j.scopes)-1].depth {
+			j.scopes = j.scopes[:len(j.scopes)-1]
+		}
+		tables = nil
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// addImport records an import: the class under its simple name, or a statically imported
+// member under its own. Wildcard imports can't be resolved to a class.
+func (j *javaFile) addImport(path string, static, wildcard bool) {
+	if wildcard && !static {
+		return
+	}
+	if static {
+		class := path
+		member := ""
+		if !wildcard {
+			idx := strings.LastIndex(path, ".")
+			class, member = path[:idx], path[idx+1:]
+		}
+		qualified := qualifyJava(class)
+		j.parsed.Uses = append(j.parsed.Uses, qualified)
+		if member != "" {
+			j.staticNames[member] = qualified
+		}
+		return
+	}
+	qualified := qualifyJava(path)
+	j.parsed.Uses = append(j.parsed.Uses, qualified)
+	j.imports[path[strings.LastIndex(path, ".")+1:]] = qualified
+}
+
+// parseEnumConstants records the constants an enum declares on a line, and returns the
+// code after them, such as the constants' arguments, for usage parsing
+func (j *javaFile) parseEnumConstants(line string, lineNum int, documented bool) string {
+	scope := &j.scopes[len(j.scopes)-1]
+	if idx := topLevelIndex(line, ';'); idx != -1 {
+		line = line[:idx]
+		scope.constants = false
+	}
+	for _, constant := range splitTopLevel(line) {
+		constant = stripAnnotations(constant)
+		end := 0
+		for end < len(constant) && (constant[end] == '_' || constant[end] == '$' || unicode.IsLetter(rune(constant[end])) || unicode.IsDigit(rune(constant[end]))) {
+			end++
+		}
+		if end == 0 {
+			continue
+		}
+		j.parsed.Elements = append(j.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       constant[:end],
+			Namespace:  j.parsed.Namespace,
+			ClassName:  scope.name,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       j.parsed.Path,
+			Documented: documented,
+		})
+	}
+	return line
+}
+
+// parseTypeHeader records a type declaration's extends and implements clauses
+func (j *javaFile) parseTypeHeader(header, keyword, name string, lineNum int) {
+	header = skipTypeParameters(strings.TrimSpace(header))
+	if keyword == "record" && strings.HasPrefix(header, "(") {
+		if end := closingParen(header); end != -1 {
+			header = header[end+1:]
+		}
+	}
+	if idx := strings.Index(header, " permits "); idx != -1 {
+		header = header[:idx]
+	}
+
+	clause := ""
+	for _, word := range strings.Fields(" " + strings.ReplaceAll(header, ",", " , ") + " ") {
+		switch word {
+		case "extends":
+			clause = "extends"
+		case "implements":
+			clause = "implements"
+		case ",":
+		default:
+			if clause == "" {
+				continue
+			}
+			typeName := word
+			if idx := strings.Index(typeName, "<"); idx != -1 {
+				typeName = typeName[:idx]
+			}
+			if typeName == "" || strings.HasPrefix(word, "<") || !strings.ContainsAny(typeName[:1], "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_$") {
+				continue // Inside a type argument list
+			}
+			j.parsed.Usage = append(j.parsed.Usage, models.UsageElement{
+				Type:    clause,
+				Name:    j.qualify(typeName),
+				Context: name,
+				Line:    lineNum,
+			})
+		}
+	}
+}
+
+// parseUsage finds instantiations, calls, method references, and type checks in code
+func (p *JavaParser) parseUsage(j *javaFile, code string, lineNum int) {
+	context := j.context()
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	add := func(usageType, name, receiver string) {
+		j.parsed.Usage = append(j.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+
+	for _, match := range p.newPattern.FindAllStringSubmatch(code, -1) {
+		if !isJavaPrimitive(match[1]) {
+			add("instantiation", j.qualify(match[1]), "")
+		}
+	}
+	for _, match := range p.catchPattern.FindAllStringSubmatch(code, -1) {
+		for _, name := range strings.Split(match[1], "|") {
+			add("type_reference", j.qualify(strings.TrimSpace(name)), "")
+		}
+	}
+	for _, match := range p.instanceofPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", j.qualify(match[1]), "")
+	}
+	for _, match := range p.methodRefPattern.FindAllStringSubmatch(code, -1) {
+		switch receiver := match[1]; {
+		case receiver == "this":
+			add("method_call", match[2], "this")
+		case isTypeName(receiver):
+			add("static_call", j.qualify(receiver)+"::"+match[2], j.qualify(receiver))
+		}
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		if isJavaKeyword(name) || strings.HasSuffix(prefix, "new") || strings.HasSuffix(prefix, "::") {
+			continue
+		}
+		if !strings.HasSuffix(prefix, ".") {
+			// Unqualified calls are on this class, or statically imported
+			if class, ok := j.staticNames[name]; ok {
+				add("static_call", class+"::"+name, class)
+			} else {
+				add("method_call", name, "this")
+			}
+			continue
+		}
+
+		receiver := javaReceiver(strings.TrimRight(strings.TrimSuffix(prefix, "."), " \t"))
+		switch {
+		case receiver == "this":
+			add("method_call", name, "this")
+		case receiver != "" && isTypeName(receiver):
+			add("static_call", j.qualify(receiver)+"::"+name, j.qualify(receiver))
+		default:
+			add("method_call", name, receiver)
+		}
+	}
+}
+
+// addAnnotations records annotations as usage of the annotation types by context
+func (j *javaFile) addAnnotations(annotations []string, context string, lineNum int) {
+	for _, name := range annotations {
+		j.parsed.Usage = append(j.parsed.Usage, models.UsageElement{
+			Type:    "attribute",
+			Name:    j.qualify(name),
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// isMethod tells a method or constructor declaration matched by methodPattern from a
+// statement that looks like one, such as a field initialized by a call
+func (j *javaFile) isMethod(line string, match []int) bool {
+	name := line[match[6]:match[7]]
+	if isJavaKeyword(name) {
+		return false
+	}
+	if match[4] == -1 {
+		return name == j.scopes[len(j.scopes)-1].name // Constructors have no return type
+	}
+	returnType := strings.Fields(line[match[4]:match[5]])
+	return len(returnType) > 0 && !isJavaKeyword(returnType[0]) && !strings.Contains(line[:match[1]], "=")
+}
+
+// isInterface checks if name is an interface declared in this file
+func (j *javaFile) isInterface(name string) bool {
+	for _, element := range j.parsed.Elements {
+		if element.Name == name && element.Type == "interface" {
+			return true
+		}
+	}
+	return false
+}
+
+// className returns the innermost type being parsed
+func (j *javaFile) className() string {
+	for i := len(j.scopes) - 1; i >= 0; i-- {
+		if j.scopes[i].kind == "type" {
+			return j.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the innermost method or type being parsed
+func (j *javaFile) context() string {
+	if len(j.scopes) == 0 {
+		return ""
+	}
+	return j.scopes[len(j.scopes)-1].name
+}
+
+// visibility picks the access modifier out of a modifier list. Without one, a member is
+// visible within its package.
+func (j *javaFile) visibility(modifiers string) string {
+	for _, modifier := range strings.Fields(modifiers) {
+		switch modifier {
+		case "public", "private", "protected":
+			return modifier
+		}
+	}
+	return "package"
+}
+
+// qualify names a type the way the analyzer indexes it: imported types with their
+// package, so they resolve to the imported class rather than one of the same name
+func (j *javaFile) qualify(name string) string {
+	if qualified, ok := j.imports[name]; ok {
+		return qualified
+	}
+	if idx := strings.Index(name, "."); idx != -1 {
+		if first := name[:idx]; unicode.IsLower(rune(first[0])) {
+			return qualifyJava(name) // Fully qualified: com.acme.model.User
+		}
+		if outer, ok := j.imports[name[:idx]]; ok {
+			return outer // A nested class of an imported one: Map.Entry
+		}
+	}
+	return name
+}
+
+// typeNames lists the class names in a type, qualified and separated by "|", leaving
+// out primitives: "Map<String, List<User>>" → "Map|String|List|User"
+func (j *javaFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' && r != '.'
+	}) {
+		word = strings.Trim(word, ".")
+		if word == "" || isJavaPrimitive(word) || word == "extends" || word == "super" || len(word) == 1 {
+			continue // len 1: type variables, T and E
+		}
+		names = append(names, j.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// qualifyJava turns a dotted class name into the analyzer's form: its package, then the
+// class after a backslash, e.g. com.acme.model.User → com.acme.model\User
+func qualifyJava(path string) string {
+	if idx := strings.LastIndex(path, "."); idx != -1 {
+		return path[:idx] + `\` + path[idx+1:]
+	}
+	return path
+}
+
+// javaParameter splits a parameter declaration into its name and type:
+// "@Valid final List<User> users" → ("users", "List<User>")
+func javaParameter(param string) (string, string) {
+	param = stripAnnotations(strings.TrimSpace(param))
+	param = strings.TrimSpace(strings.TrimPrefix(param, "final "))
+	idx := strings.LastIndexAny(param, " \t")
+	if idx == -1 {
+		return "", "" // No type: an empty list, or a lambda's parameters
+	}
+	name := strings.TrimRight(param[idx+1:], "[]")
+	return name, strings.TrimSpace(param[:idx])
+}
+
+// javaReceiver takes the expression a call is made on from the code before its dot:
+// "this.users" from "return this.users", and "" for a call result like "find()"
+func javaReceiver(prefix string) string {
+	start := len(prefix)
+	for start > 0 {
+		r := rune(prefix[start-1])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' && r != '.' {
+			break
+		}
+		start--
+	}
+	if start < len(prefix) && start > 0 && (prefix[start-1] == ')' || prefix[start-1] == ']') {
+		return ""
+	}
+	return strings.Trim(prefix[start:], ".")
+}
+
+// stripAnnotations removes leading annotations, and their arguments, from a declaration
+func stripAnnotations(code string) string {
+	for {
+		code = strings.TrimSpace(code)
+		if !strings.HasPrefix(code, "@") || strings.HasPrefix(code, "@interface") {
+			return code
+		}
+		end := 1
+		for end < len(code) && (code[end] == '.' || code[end] == '_' || code[end] == '$' || unicode.IsLetter(rune(code[end])) || unicode.IsDigit(rune(code[end]))) {
+			end++
+		}
+		code = strings.TrimSpace(code[end:])
+		if strings.HasPrefix(code, "(") {
+			if close := closingParen(code); close != -1 {
+				code = code[close+1:]
+			}
+		}
+	}
+}
+
+// closingParen returns the index of the parenthesis closing the one s starts with, or -1
+func closingParen(s string) int {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isTypeName checks if a receiver names a class rather than a variable, by the Java
+// convention that classes are capitalized: "UserService", "Map.Entry"
+func isTypeName(receiver string) bool {
+	if idx := strings.LastIndex(receiver, "."); idx != -1 {
+		receiver = receiver[idx+1:]
+	}
+	return receiver != "" && unicode.IsUpper(rune(receiver[0])) && receiver != strings.ToUpper(receiver)
+}
+
+// isJavaKeyword checks if a word is a Java keyword that can precede a parenthesis or a
+// name in a statement
+func isJavaKeyword(word string) bool {
+	switch word {
+	case "if", "for", "while", "switch", "catch", "synchronized", "return", "throw", "new",
+		"this", "super", "try", "else", "do", "case", "assert", "yield", "throws", "instanceof",
+		"package", "import", "var":
+		return true
+	}
+	return false
+}
+
+// isJavaPrimitive checks if a type name is one of Java's primitive types or void
+func isJavaPrimitive(name string) bool {
+	switch name {
+	case "boolean", "byte", "char", "short", "int", "long", "float", "double", "void", "var":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Java files concurrently
+func (p *JavaParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *JavaParser) Language() string {
+	return "java"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *JavaParser) FileExtensions() []string {
+	return []string{".java"}
+}
+
+// DefaultExcludes returns the directories skipped in Java projects: Maven and Gradle
+// build output and caches
+func (p *JavaParser) DefaultExcludes() []string {
+	return []string{"target", "build", ".gradle", "out"}
+}
+
+// Entrypoints returns the methods the JVM calls: main
+func (p *JavaParser) Entrypoints() []string {
+	return []string{`^main$`}
+}
+
+func init() {
+	parser.Register(NewJavaParser())
+}
@@ -0,0 +1,229 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestJavaParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `package com.acme.users;
+
+import com.acme.model.User;
+import java.util.*;
+import static com.acme.util.Strings.slugify;
+
+/**
+ * Manages users.
+ */
+@Service
+@Table(name = "users")
+public class UserService extends BaseService<User>
+        implements UserFinder, Auditable {
+    public static final int MAX_RESULTS = 50;
+
+    @Autowired
+    private final UserRepository repository;
+    String label = "users"; // TODO: localize
+
+    public UserService(UserRepository repository) {
+        this.repository = repository;
+    }
+
+    @Override
+    public List<User> findAll(@Valid Filter filter,
+                              int limit) throws NotFoundException {
+        try {
+            User user = new User(slugify(filter.name()));
+            return repository.findAll(filter).stream().map(this::toDto).toList();
+        } catch (IllegalStateException | NotFoundException e) {
+            return Collections.emptyList();
+        }
+    }
+
+    protected abstract void audit(String event);
+
+    private UserDto toDto(User user) {
+        validate(user);
+        return UserDto.from(user);
+    }
+}
+
+interface UserFinder {
+    List<User> findAll(Filter filter, int limit);
+
+    default int count() { return findAll(null, 0).size(); }
+}
+
+enum Status {
+    ACTIVE("a"), INACTIVE("i");
+
+    private final String code;
+}
+`
+	path := writeFixture(t, tmp, "UserService.java", code)
+
+	parsed, err := NewJavaParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "java" || parsed.Namespace != "com.acme.users" {
+		t.Errorf("expected the package as the namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	if len(parsed.Uses) != 2 || parsed.Uses[0] != `com.acme.model\User` || parsed.Uses[1] != `com.acme.util\Strings` {
+		t.Errorf("expected the imported classes, got %v", parsed.Uses)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "constant:UserService.MAX_RESULTS",
+		"property:UserService.repository", "property:UserService.label", "method:UserService.UserService",
+		"method:UserService.findAll", "method:UserService.audit", "method:UserService.toDto",
+		"interface:.UserFinder", "method:UserFinder.findAll", "method:UserFinder.count",
+		"enum:.Status", "constant:Status.ACTIVE", "constant:Status.INACTIVE", "property:Status.code"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 15 {
+		t.Errorf("expected 15 elements, got %+v", parsed.Elements)
+	}
+
+	if !elements["class:.UserService"].Documented || elements["method:UserService.findAll"].Documented {
+		t.Error("expected only the class with Javadoc to be documented")
+	}
+	findAll := elements["method:UserService.findAll"]
+	if len(findAll.Parameters) != 2 || findAll.Parameters[0] != "filter" || findAll.ParamTypes[0] != "Filter" || findAll.ParamTypes[1] != "" {
+		t.Errorf("expected the wrapped parameter list, got %v %v", findAll.Parameters, findAll.ParamTypes)
+	}
+	if findAll.ReturnType != `List|com.acme.model\User` {
+		t.Errorf("expected the return type's classes, imports qualified, got %q", findAll.ReturnType)
+	}
+	if elements["property:UserService.label"].Visibility != "package" || elements["method:UserFinder.findAll"].Visibility != "public" {
+		t.Error("expected package-private members, except in interfaces")
+	}
+	if !elements["method:UserFinder.findAll"].IsAbstract || elements["method:UserFinder.count"].IsAbstract {
+		t.Error("expected only the interface method without a body to be abstract")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"attribute:.Service in UserService",
+		"extends:.BaseService in UserService",
+		"implements:.UserFinder in UserService",
+		"implements:.Auditable in UserService",
+		"attribute:.Autowired in UserService",
+		"type_reference:.UserRepository in UserService",
+		"attribute:.Override in findAll",
+		`instantiation:.com.acme.model\User in findAll`,
+		`static_call:com.acme.util\Strings.com.acme.util\Strings::slugify in findAll`,
+		"method_call:filter.name in findAll",
+		"method_call:repository.findAll in findAll",
+		"method_call:this.toDto in findAll",
+		"type_reference:.IllegalStateException in findAll",
+		"static_call:Collections.Collections::emptyList in findAll",
+		"method_call:this.validate in toDto",
+		"static_call:UserDto.UserDto::from in toDto",
+		"method_call:this.findAll in count",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"method_call:this.User in findAll", "method_call:this.findAll in UserService", "method_call:this.catch in findAll"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Table != "users" || parsed.Tables[0].ClassName != "UserService" {
+		t.Errorf("expected the entity's table, got %+v", parsed.Tables)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 18 {
+		t.Errorf("expected the TODO on line 18, got %+v", parsed.Debt)
+	}
+}
+
+func TestJavaParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"model", "service"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "model/User.java", `package com.acme.model;
+
+public class User {
+    public String name() { return "x"; }
+}
+`)
+	// Same-named class in another package, so only the import can pick the right one
+	writeFixture(t, tmp, "service/User.java", `package com.acme.legacy;
+
+public class User {}
+`)
+	writeFixture(t, tmp, "service/Users.java", `package com.acme.service;
+
+import com.acme.model.User;
+
+public class Users {
+    public static void main(String[] args) {
+        new Users().greet(new User());
+    }
+
+    void greet(User user) {
+        print(user.name());
+    }
+
+    private void print(String text) {}
+}
+`)
+
+	p := NewJavaParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"model/User.java", "service/User.java", "service/Users.java"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Namespace+`\`+node.ClassName+"."+node.Name] = node
+	}
+	main, user := nodes[`com.acme.service\Users.main`], nodes[`com.acme.model\.User`]
+	if main == nil || user == nil || main.Dependencies[user.ID] == nil {
+		t.Fatalf("expected main to instantiate the imported User, got %+v", main)
+	}
+	if legacy := nodes[`com.acme.legacy\.User`]; main.Dependencies[legacy.ID] != nil {
+		t.Error("expected the other package's User to be left alone")
+	}
+	if !main.IsEntrypoint {
+		t.Error("expected main to be an entrypoint")
+	}
+	greet := nodes[`com.acme.service\Users.greet`]
+	if greet.Dependencies[nodes[`com.acme.service\Users.print`].ID] == nil {
+		t.Errorf("expected the unqualified call to resolve to the class's method, got %+v", greet.Dependencies)
+	}
+	if greet.Dependencies[user.ID] == nil {
+		t.Errorf("expected the parameter type to link to the imported class, got %+v", greet.Dependencies)
+	}
+}
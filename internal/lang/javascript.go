@@ -0,0 +1,935 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/nodejs"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// JSParser handles parsing of JavaScript files
+type JSParser struct {
+	language string
+	resolver *nodejs.Resolver
+	ts       *tsPatterns   // TypeScript's additions; nil when parsing JavaScript
+	target   *syntaxTarget // The ECMAScript edition the code targets; nil for the latest
+
+	// Regex patterns for different JavaScript constructs
+	importFromPattern     *regexp.Regexp
+	importBarePattern     *regexp.Regexp
+	importBlockPattern    *regexp.Regexp
+	dynamicImportPattern  *regexp.Regexp
+	requirePattern        *regexp.Regexp
+	requireAssignPattern  *regexp.Regexp
+	exportListPattern     *regexp.Regexp
+	reExportPattern       *regexp.Regexp
+	exportDefaultPattern  *regexp.Regexp
+	moduleExportsPattern  *regexp.Regexp
+	exportsMemberPattern  *regexp.Regexp
+	classPattern          *regexp.Regexp
+	functionPattern       *regexp.Regexp
+	functionExprPattern   *regexp.Regexp
+	constantPattern       *regexp.Regexp
+	methodPattern         *regexp.Regexp
+	classFieldPattern     *regexp.Regexp
+	newInstancePattern    *regexp.Regexp
+	memberCallPattern     *regexp.Regexp
+	globalFunctionPattern *regexp.Regexp
+	commonJSGlobalPattern *regexp.Regexp
+	importMetaPattern     *regexp.Regexp
+	requestPattern        *regexp.Regexp
+	requestMethodPattern  *regexp.Regexp
+}
+
+// jsScope is an open class or function body
+type jsScope struct {
+	kind  string // "class", "function", or TypeScript's "type" (interfaces and aliases) and "enum"
+	name  string
+	depth int // Brace depth the body closes back to
+}
+
+// NewJSParser creates a new JavaScript parser with compiled regex patterns
+func NewJSParser() *JSParser {
+	return &JSParser{
+		language: "javascript",
+		resolver: nodejs.NewResolver(),
+
+		// Imports: import React, { useState as useLocal } from 'react'; import * as api from './api'
+		importFromPattern: regexp.MustCompile(`^\s*import\s+(?:type\s+)?(.+?)\s+from\s+['"]([^'"]+)['"]`),
+
+		// Side-effect imports: import './polyfills'
+		importBarePattern: regexp.MustCompile(`^\s*import\s+['"]([^'"]+)['"]`),
+
+		// Start of an import/export list (or module.exports object) that continues on the next lines
+		importBlockPattern: regexp.MustCompile(`^\s*(?:(?:import|export)\s+(?:type\s+)?(?:[A-Za-z_$][\w$]*\s*,\s*)?|module\.exports\s*=\s*)\{[^}]*$`),
+
+		// Dynamic imports: await import('./lazy')
+		dynamicImportPattern: regexp.MustCompile(`\bimport\s*\(\s*['"]([^'"]+)['"]\s*\)`),
+
+		// CommonJS: require('./utils')
+		requirePattern: regexp.MustCompile(`\brequire\s*\(\s*['"]([^'"]+)['"]\s*\)`),
+
+		// CommonJS bindings: const utils = require('./utils'); const { a, b: c } = require('./x'); const f = require('./x').f
+		requireAssignPattern: regexp.MustCompile(`^\s*(?:const|let|var)\s+([A-Za-z_$][\w$]*|\{[^}]*\})\s*=\s*require\s*\(\s*['"]([^'"]+)['"]\s*\)(?:\.([A-Za-z_$][\w$]*))?`),
+
+		// Export lists: export { format, parse as parseDate }
+		exportListPattern: regexp.MustCompile(`^\s*export\s+(?:type\s+)?\{([^}]*)\}\s*;?\s*$`),
+
+		// Re-exports: export * from './format'; export * as utils from './utils'; export { default as Button } from './Button'
+		reExportPattern: regexp.MustCompile(`^\s*export\s+(?:type\s+)?(?:\*(?:\s+as\s+([A-Za-z_$][\w$]*))?|\{([^}]*)\})\s*from\s+['"]([^'"]+)['"]`),
+
+		// Default exports: export default function render() {}, export default App;
+		exportDefaultPattern: regexp.MustCompile(`^\s*export\s+default\s+(?:(?:async\s+)?function\s*\*?\s*|class\s+)?([A-Za-z_$][\w$]*)`),
+
+		// CommonJS default export: module.exports = App; module.exports = { a, b: c }
+		moduleExportsPattern: regexp.MustCompile(`^\s*module\.exports\s*=\s*(\{[^}]*\}|[A-Za-z_$][\w$]*)`),
+
+		// CommonJS named export: exports.format = format; module.exports.parse = function () {}
+		exportsMemberPattern: regexp.MustCompile(`^\s*(?:module\.)?exports\.([A-Za-z_$][\w$]*)\s*=\s*(.*)$`),
+
+		// Class: export default class UserService extends BaseService
+		classPattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?class\s+([A-Za-z_$][\w$]*)(?:\s+extends\s+([A-Za-z_$][\w$.]*))?`),
+
+		// Function: export async function fetchUser(id) {
+		functionPattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*\(`),
+
+		// Function expressions: const fetchUser = async (id) => {, export const handler = function () {
+		functionExprPattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s+)?(?:function\b[^(]*\(|\([^)]*\)\s*=>|\([^)]*$|[A-Za-z_$][\w$]*\s*=>)`),
+
+		// Exported constants: export const API_URL = '/api'
+		constantPattern: regexp.MustCompile(`^\s*export\s+(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=`),
+
+		// Methods inside a class body: static async load(id) {, get name() {, #validate() {
+		methodPattern: regexp.MustCompile(`^\s*((?:(?:static|async|get|set)\s+)*)\*?\s*(#?[A-Za-z_$][\w$]*)\s*\(`),
+
+		// Arrow-function class fields: handleClick = (event) => {
+		classFieldPattern: regexp.MustCompile(`^\s*((?:static\s+)?)(#?[A-Za-z_$][\w$]*)\s*=\s*(?:async\s+)?(?:\(|[A-Za-z_$][\w$]*\s*=>)`),
+
+		// New instances: new UserService(), new api.Client()
+		newInstancePattern: regexp.MustCompile(`\bnew\s+(?:([A-Za-z_$][\w$]*)\.)?([A-Za-z_$][\w$]*)`),
+
+		// Member calls: this.save(), api.fetchUser(), user?.greet()
+		memberCallPattern: regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*\??\.\s*(#?[A-Za-z_$][\w$]*)\s*\(`),
+
+		// Function calls: formatDate(value)
+		globalFunctionPattern: regexp.MustCompile(`(?:^|[^\w$.#])([A-Za-z_$][\w$]*)\s*\(`),
+
+		// CommonJS-only globals: __dirname, __filename, require.resolve(), require.main
+		commonJSGlobalPattern: regexp.MustCompile(`\b(__dirname|__filename|require\.(?:resolve|main|cache))\b`),
+
+		// ES module-only syntax: import.meta.url
+		importMetaPattern: regexp.MustCompile(`\bimport\.meta\b`),
+
+		// HTTP requests: fetch('/api/users'), axios.post(`/api/users/${id}`)
+		requestPattern: regexp.MustCompile("\\b(?:fetch|axios(?:\\.(get|post|put|patch|delete|head|options))?)\\s*\\(\\s*['\"`]([^'\"`]*)['\"`]"),
+
+		// Request options: fetch(url, { method: 'POST' })
+		requestMethodPattern: regexp.MustCompile(`\bmethod\s*:\s*['"]([A-Za-z]+)['"]`),
+	}
+}
+
+// ParseFile analyzes a single JavaScript file and extracts all elements
+func (p *JSParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	return p.parseModule(filePath, moduleName(filePath))
+}
+
+// moduleName derives a module identifier from a file path ("src/utils/format.js" → "src/utils/format").
+// It namespaces the module's elements so same-named functions in different files stay distinct.
+func moduleName(path string) string {
+	return strings.TrimSuffix(filepath.ToSlash(path), filepath.Ext(path))
+}
+
+// parseModule parses a file using module as the namespace of its elements
+func (p *JSParser) parseModule(filePath, module string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// A Vue single-file component is parsed as its script, then its template
+	var src io.Reader = file
+	var sfc *vueComponent
+	if strings.EqualFold(filepath.Ext(filePath), ".vue") {
+		if sfc, err = readVue(filePath, file); err != nil {
+			return nil, err
+		}
+		src = strings.NewReader(sfc.script)
+	}
+
+	parsed := &models.ParsedFile{
+		Path:      filePath,
+		Language:  p.language,
+		Namespace: module,
+		Elements:  []models.CodeElement{},
+		Usage:     []models.UsageElement{},
+		Uses:      []string{},
+		Imports:   []models.ImportBinding{},
+		Exports:   []models.ExportBinding{},
+	}
+
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // Bundled/minified files have long lines
+	lineNum := 0
+	joinedLines := 0
+	inComment := false
+	docblock := false // A /** */ JSDoc comment precedes the next declaration
+	braceDepth := 0
+	var scopes []jsScope
+	features := make(map[string]bool)
+	usesImportMeta := false
+	var decorators []tsDecorator // TypeScript decorators awaiting what they decorate
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+
+		if marker, ok := debtMarker(scanner.Text(), lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		raw := strings.TrimSpace(scanner.Text())
+		if !inComment && strings.HasPrefix(raw, "/**") {
+			docblock = true
+		}
+		var code, bare string
+		code, bare, inComment = stripJSLine(scanner.Text(), inComment)
+		if strings.TrimSpace(code) == "" {
+			if raw != "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Decorators sit between a docblock and its declaration; other code ends it
+		documented := docblock
+		if !strings.HasPrefix(raw, "@") {
+			docblock = false
+		}
+
+		// TypeScript decorators are taken off the declaration they precede
+		if p.ts != nil && strings.HasPrefix(strings.TrimSpace(bare), "@") {
+			for parenBalance(bare) > 0 && scanner.Scan() {
+				joinedLines++
+				var next, nextBare string
+				next, nextBare, inComment = stripJSLine(scanner.Text(), inComment)
+				code += " " + strings.TrimSpace(next)
+				bare += " " + strings.TrimSpace(nextBare)
+			}
+			var found []tsDecorator
+			found, bare = p.takeDecorators(bare, lineNum)
+			_, code = p.takeDecorators(code, lineNum)
+			decorators = append(decorators, found...)
+			if strings.TrimSpace(bare) == "" {
+				continue
+			}
+		}
+
+		// Join import/export lists split across lines
+		if p.importBlockPattern.MatchString(code) {
+			for !strings.Contains(code, "}") && scanner.Scan() {
+				joinedLines++
+				var next, nextBare string
+				next, nextBare, inComment = stripJSLine(scanner.Text(), inComment)
+				code += " " + strings.TrimSpace(next)
+				bare += " " + strings.TrimSpace(nextBare)
+			}
+		}
+
+		inClass, inFunction := currentScopes(scopes)
+		context := inFunction
+		if context == "" {
+			context = inClass
+		}
+
+		p.parseImports(code, lineNum, filePath, parsed)
+		p.parseExports(code, lineNum, filePath, parsed)
+		for _, match := range p.commonJSGlobalPattern.FindAllStringSubmatch(bare, -1) {
+			features[match[1]] = true
+		}
+		usesImportMeta = usesImportMeta || p.importMetaPattern.MatchString(bare)
+		p.target.check(bare, lineNum, parsed)
+
+		if p.ts != nil && p.parseTypes(bare, lineNum, filePath, module, documented, braceDepth, &scopes, parsed) {
+			braceDepth, scopes = closeScopes(bare, braceDepth, scopes)
+			continue
+		}
+
+		// Declarations open a scope at the depth before this line's braces
+		declared := false
+		if matches := p.classPattern.FindStringSubmatch(bare); matches != nil {
+			parsed.Elements = append(parsed.Elements, models.CodeElement{
+				Type:       "class",
+				Name:       matches[1],
+				Namespace:  module,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				IsAbstract: p.ts != nil && strings.Contains(bare[:strings.Index(bare, "class")], "abstract"),
+			})
+			if matches[2] != "" {
+				receiver, name := splitMember(matches[2])
+				parsed.Usage = append(parsed.Usage, models.UsageElement{
+					Type:     "extends",
+					Name:     name,
+					Context:  matches[1],
+					Receiver: receiver,
+					Line:     lineNum,
+				})
+			}
+			if p.ts != nil && matches[3] != "" {
+				p.addTypeUsage("implements", matches[3], lineNum, matches[1], parsed)
+			}
+			scopes = append(scopes, jsScope{kind: "class", name: matches[1], depth: braceDepth})
+			declared = true
+		} else if inClass != "" && inFunction == "" {
+			if name, modifiers, rest, ok := p.matchMethod(bare); ok {
+				parsed.Elements = append(parsed.Elements, models.CodeElement{
+					Type:       "method",
+					Name:       name,
+					Namespace:  module,
+					ClassName:  inClass,
+					Visibility: jsVisibility(name, modifiers),
+					IsStatic:   strings.Contains(modifiers, "static"),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+					Parameters: parseJSParameters(rest),
+				})
+				if p.ts != nil {
+					p.addSignatureTypes(rest, lineNum, name, parsed)
+				}
+				scopes = append(scopes, jsScope{kind: "function", name: name, depth: braceDepth})
+				declared = true
+			} else if p.ts != nil && braceDepth == scopes[len(scopes)-1].depth+1 {
+				p.addFieldType(bare, lineNum, inClass, parsed)
+			}
+		} else if name, rest, ok := p.matchFunction(bare, inFunction == ""); ok {
+			parsed.Elements = append(parsed.Elements, models.CodeElement{
+				Type:       "function",
+				Name:       name,
+				Namespace:  module,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				Parameters: parseJSParameters(rest),
+			})
+			if p.ts != nil {
+				p.addSignatureTypes(rest, lineNum, name, parsed)
+			}
+			scopes = append(scopes, jsScope{kind: "function", name: name, depth: braceDepth})
+			declared = true
+		} else if matches := p.constantPattern.FindStringSubmatch(bare); matches != nil && len(scopes) == 0 {
+			parsed.Elements = append(parsed.Elements, models.CodeElement{
+				Type:       "constant",
+				Name:       matches[1],
+				Namespace:  module,
+				Visibility: "public",
+				Line:       lineNum,
+				File:       filePath,
+			})
+		}
+
+		// Usage inside a new declaration belongs to it (e.g. one-line arrow functions)
+		if declared {
+			inClass, inFunction = currentScopes(scopes)
+			if context = inFunction; context == "" {
+				context = inClass
+			}
+		}
+		for _, decorator := range decorators {
+			p.addDecorator(decorator, context, parsed)
+		}
+		decorators = nil
+		p.parseUsage(bare, lineNum, context, parsed)
+		p.parseRequests(code, lineNum, context, parsed)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, inClass, inFunction)...)
+
+		braceDepth, scopes = closeScopes(bare, braceDepth, scopes)
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	classifyModule(parsed, features, usesImportMeta)
+	if sfc != nil {
+		p.addVueComponent(sfc, filePath, module, parsed)
+	}
+	return parsed, scanner.Err()
+}
+
+// closeScopes tracks the brace depth through a line and closes the scopes whose bodies ended
+func closeScopes(line string, depth int, scopes []jsScope) (int, []jsScope) {
+	depth += strings.Count(line, "{") - strings.Count(line, "}")
+	for len(scopes) > 0 && depth <= scopes[len(scopes)-1].depth {
+		scopes = scopes[:len(scopes)-1]
+	}
+	return depth, scopes
+}
+
+// classifyModule sets the file's module system from its import/export syntax
+func classifyModule(parsed *models.ParsedFile, features map[string]bool, usesImportMeta bool) {
+	esm := usesImportMeta
+	for _, binding := range parsed.Imports {
+		switch binding.Kind {
+		case "import":
+			esm = true
+		case "require":
+			features["require"] = true
+		}
+	}
+	for _, export := range parsed.Exports {
+		if export.Kind == "commonjs" {
+			features["module.exports"] = true
+		} else {
+			esm = true
+		}
+	}
+
+	for feature := range features {
+		parsed.CommonJSFeatures = append(parsed.CommonJSFeatures, feature)
+	}
+	sort.Strings(parsed.CommonJSFeatures)
+
+	switch {
+	case esm && len(features) > 0:
+		parsed.ModuleSystem = "mixed"
+	case esm:
+		parsed.ModuleSystem = "esm"
+	case len(features) > 0:
+		parsed.ModuleSystem = "commonjs"
+	}
+}
+
+// matchFunction matches a function declaration, or a function expression bound to a
+// variable when topLevel is set. It returns the name and the text after "(".
+func (p *JSParser) matchFunction(line string, topLevel bool) (string, string, bool) {
+	if matches := p.functionPattern.FindStringSubmatchIndex(line); matches != nil {
+		if p.ts != nil && strings.HasSuffix(strings.TrimSpace(line), ";") {
+			return "", "", false // An overload signature, or a declaration without a body
+		}
+		return line[matches[2]:matches[3]], line[matches[1]:], true
+	}
+	if !topLevel {
+		return "", "", false
+	}
+
+	matches := p.functionExprPattern.FindStringSubmatchIndex(line)
+	if matches == nil {
+		return "", "", false
+	}
+	name := line[matches[2]:matches[3]]
+
+	// The parameters follow "function name(" or "(", or are a single bare name before "=>"
+	rhs := strings.TrimSpace(line[matches[3]:])
+	if eq := strings.Index(rhs, "="); eq != -1 {
+		rhs = rhs[eq:] // Past a TypeScript annotation: const handler: Handler = ...
+	}
+	rhs = strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(rhs, "=")), "async ")
+	if p.ts != nil {
+		rhs = skipTypeParameters(rhs)
+	}
+	if idx := strings.Index(rhs, "("); idx != -1 && (strings.HasPrefix(rhs, "function") || idx == 0) {
+		return name, rhs[idx+1:], true
+	}
+	if idx := strings.Index(rhs, "=>"); idx != -1 {
+		return name, strings.TrimSpace(rhs[:idx]) + ")", true
+	}
+	return name, ")", true
+}
+
+// matchMethod matches a method or arrow-function field declared directly in a class body.
+// It returns the name, its modifiers, and the text after "(".
+func (p *JSParser) matchMethod(line string) (string, string, string, bool) {
+	if matches := p.classFieldPattern.FindStringSubmatchIndex(line); matches != nil && strings.Contains(line, "=>") {
+		rest := ")"
+		if strings.HasSuffix(line[:matches[1]], "(") {
+			rest = line[matches[1]:]
+		}
+		return line[matches[4]:matches[5]], line[matches[2]:matches[3]], rest, true
+	}
+
+	matches := p.methodPattern.FindStringSubmatchIndex(line)
+	if matches == nil {
+		return "", "", "", false
+	}
+	name := line[matches[4]:matches[5]]
+
+	// Calls inside multi-line field initializers are not declarations
+	if (isJSKeyword(name) && name != "constructor") || strings.HasSuffix(strings.TrimSpace(line), ";") {
+		return "", "", "", false
+	}
+	return name, line[matches[2]:matches[3]], line[matches[1]:], true
+}
+
+// parseImports records import and require() bindings, resolving their specifiers to files
+func (p *JSParser) parseImports(line string, lineNum int, filePath string, parsed *models.ParsedFile) {
+	addImport := func(local, imported, source, kind string) {
+		parsed.Imports = append(parsed.Imports, models.ImportBinding{
+			Local:    local,
+			Imported: imported,
+			Source:   source,
+			Resolved: p.resolver.Resolve(filePath, source),
+			Kind:     kind,
+			Line:     lineNum,
+		})
+	}
+
+	if matches := p.importFromPattern.FindStringSubmatch(line); matches != nil {
+		parsed.Uses = append(parsed.Uses, matches[2])
+		for _, binding := range parseImportClause(matches[1]) {
+			addImport(binding[0], binding[1], matches[2], "import")
+		}
+	} else if matches := p.importBarePattern.FindStringSubmatch(line); matches != nil {
+		parsed.Uses = append(parsed.Uses, matches[1])
+		addImport("", "", matches[1], "import")
+	}
+
+	for _, match := range p.dynamicImportPattern.FindAllStringSubmatch(line, -1) {
+		parsed.Uses = append(parsed.Uses, match[1])
+		addImport("", "*", match[1], "dynamic")
+	}
+
+	if p.ts != nil {
+		if matches := p.ts.importRequirePattern.FindStringSubmatch(line); matches != nil {
+			parsed.Uses = append(parsed.Uses, matches[2])
+			addImport(matches[1], "*", matches[2], "require")
+			return
+		}
+	}
+	if matches := p.requireAssignPattern.FindStringSubmatch(line); matches != nil {
+		parsed.Uses = append(parsed.Uses, matches[2])
+		switch {
+		case strings.HasPrefix(matches[1], "{"):
+			for _, binding := range parseBindingList(strings.Trim(matches[1], "{}"), ":") {
+				addImport(binding[0], binding[1], matches[2], "require")
+			}
+		case matches[3] != "":
+			addImport(matches[1], matches[3], matches[2], "require")
+		default:
+			addImport(matches[1], "*", matches[2], "require")
+		}
+		return
+	}
+	for _, match := range p.requirePattern.FindAllStringSubmatch(line, -1) {
+		parsed.Uses = append(parsed.Uses, match[1])
+		addImport("", "*", match[1], "require")
+	}
+}
+
+// parseExports records the names a module exports, resolving re-exported modules to files
+func (p *JSParser) parseExports(line string, lineNum int, filePath string, parsed *models.ParsedFile) {
+	addExport := func(name, local string) {
+		parsed.Exports = append(parsed.Exports, models.ExportBinding{Name: name, Local: local, Kind: "export", Line: lineNum})
+	}
+	addCommonJSExport := func(name, local string) {
+		parsed.Exports = append(parsed.Exports, models.ExportBinding{Name: name, Local: local, Kind: "commonjs", Line: lineNum})
+	}
+
+	if matches := p.reExportPattern.FindStringSubmatch(line); matches != nil {
+		parsed.Uses = append(parsed.Uses, matches[3])
+		addReExport := func(name, local string) {
+			parsed.Exports = append(parsed.Exports, models.ExportBinding{
+				Name:     name,
+				Local:    local,
+				Kind:     "export",
+				Source:   matches[3],
+				Resolved: p.resolver.Resolve(filePath, matches[3]),
+				Line:     lineNum,
+			})
+		}
+
+		switch {
+		case matches[2] != "" || strings.Contains(line, "{"):
+			for _, binding := range parseBindingList(matches[2], " as ") {
+				addReExport(binding[1], binding[0])
+			}
+		case matches[1] != "":
+			addReExport(matches[1], "*")
+		default:
+			addReExport("*", "*")
+		}
+		return
+	}
+
+	switch {
+	case p.exportDefaultPattern.MatchString(line):
+		if name := p.exportDefaultPattern.FindStringSubmatch(line)[1]; isJSIdentifier(name) {
+			addExport("default", name)
+		}
+	case p.exportListPattern.MatchString(line):
+		for _, binding := range parseBindingList(p.exportListPattern.FindStringSubmatch(line)[1], " as ") {
+			addExport(binding[1], binding[0])
+		}
+	case p.classPattern.MatchString(line) && strings.HasPrefix(strings.TrimSpace(line), "export "):
+		name := p.classPattern.FindStringSubmatch(line)[1]
+		addExport(name, name)
+	case p.functionPattern.MatchString(line) && strings.HasPrefix(strings.TrimSpace(line), "export "):
+		name := p.functionPattern.FindStringSubmatch(line)[1]
+		addExport(name, name)
+	case p.constantPattern.MatchString(line):
+		name := p.constantPattern.FindStringSubmatch(line)[1]
+		addExport(name, name)
+	case p.moduleExportsPattern.MatchString(line):
+		value := p.moduleExportsPattern.FindStringSubmatch(line)[1]
+		if !strings.HasPrefix(value, "{") {
+			addCommonJSExport("default", value)
+			break
+		}
+		for _, binding := range parseBindingList(strings.Trim(value, "{}"), ":") {
+			if !isJSIdentifier(binding[1]) {
+				binding[1] = binding[0] // Inline value: module.exports = { run: function () { ... } }
+			}
+			if isJSIdentifier(binding[0]) {
+				addCommonJSExport(binding[0], binding[1])
+			}
+		}
+	case p.exportsMemberPattern.MatchString(line):
+		matches := p.exportsMemberPattern.FindStringSubmatch(line)
+		local := strings.TrimSuffix(strings.TrimSpace(matches[2]), ";")
+		if !isJSIdentifier(local) {
+			local = matches[1] // exports.format = function (...) declares format itself
+		}
+		addCommonJSExport(matches[1], local)
+	}
+}
+
+// parseUsage finds references to other code elements in a line with string contents removed
+func (p *JSParser) parseUsage(line string, lineNum int, context string, parsed *models.ParsedFile) {
+	// Find new instances
+	for _, match := range p.newInstancePattern.FindAllStringSubmatch(line, -1) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     "instantiation",
+			Name:     match[2],
+			Context:  context,
+			Receiver: match[1],
+			Line:     lineNum,
+		})
+	}
+
+	// Find member calls
+	for _, match := range p.memberCallPattern.FindAllStringSubmatch(line, -1) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     "method_call",
+			Name:     match[2],
+			Context:  context,
+			Receiver: match[1],
+			Line:     lineNum,
+		})
+	}
+
+	// Find function calls
+	for _, match := range p.globalFunctionPattern.FindAllStringSubmatchIndex(line, -1) {
+		funcName := line[match[2]:match[3]]
+		prefix := strings.TrimRight(line[:match[2]], " \t")
+
+		// Skip declarations, instantiations, and JavaScript keywords/built-ins
+		if strings.HasSuffix(prefix, "function") || strings.HasSuffix(prefix, "new") ||
+			strings.HasSuffix(prefix, "*") || isJSBuiltin(funcName) {
+			continue
+		}
+		// async and await became keywords in ES2017; before, they name functions
+		if isJSKeyword(funcName) && ((funcName != "async" && funcName != "await") || p.target.supports("2017")) {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), funcName+"(") && strings.HasSuffix(strings.TrimSpace(line), "{") {
+			continue // Method declaration
+		}
+
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:    "function_call",
+			Name:    funcName,
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// parseRequests records the HTTP requests made with fetch or axios to literal URLs, as
+// "METHOD url". A URL concatenated with a value ends in "${}", like an interpolation.
+func (p *JSParser) parseRequests(line string, lineNum int, context string, parsed *models.ParsedFile) {
+	for _, match := range p.requestPattern.FindAllStringSubmatchIndex(line, -1) {
+		method := "GET"
+		if match[2] != -1 {
+			method = strings.ToUpper(line[match[2]:match[3]])
+		} else if options := p.requestMethodPattern.FindStringSubmatch(line[match[1]:]); options != nil {
+			method = strings.ToUpper(options[1])
+		}
+		url := line[match[4]:match[5]]
+		if strings.HasPrefix(strings.TrimSpace(line[match[1]:]), "+") {
+			url += "${}"
+		}
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:    "http_request",
+			Name:    method + " " + url,
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// currentScopes returns the innermost enclosing class and function names
+func currentScopes(scopes []jsScope) (string, string) {
+	inClass, inFunction := "", ""
+	for i := len(scopes) - 1; i >= 0; i-- {
+		switch scopes[i].kind {
+		case "class":
+			if inClass == "" {
+				inClass = scopes[i].name
+			}
+		case "function":
+			if inFunction == "" && inClass == "" {
+				inFunction = scopes[i].name
+			}
+		}
+	}
+	return inClass, inFunction
+}
+
+// parseImportClause splits an import clause into [local, imported] pairs:
+// "React, { useState as useLocal }" → [React default] [useLocal useState]
+func parseImportClause(clause string) [][2]string {
+	var bindings [][2]string
+	clause = strings.TrimSpace(clause)
+
+	if open := strings.Index(clause, "{"); open != -1 {
+		closing := strings.LastIndex(clause, "}")
+		if closing < open {
+			closing = len(clause)
+		}
+		for _, binding := range parseBindingList(clause[open+1:closing], " as ") {
+			bindings = append(bindings, [2]string{binding[1], binding[0]})
+		}
+		clause = clause[:open]
+	}
+
+	for _, part := range strings.Split(clause, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case strings.HasPrefix(part, "*"):
+			if idx := strings.Index(part, " as "); idx != -1 {
+				bindings = append(bindings, [2]string{strings.TrimSpace(part[idx+4:]), "*"})
+			}
+		default:
+			bindings = append(bindings, [2]string{part, "default"})
+		}
+	}
+	return bindings
+}
+
+// parseBindingList splits "a, b as c" (or "a, b: c") into [original, alias] pairs
+func parseBindingList(list, separator string) [][2]string {
+	var bindings [][2]string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(item), "type "))
+		if item == "" || strings.HasPrefix(item, "...") {
+			continue
+		}
+		original, alias := item, item
+		if idx := strings.Index(item, separator); idx != -1 {
+			original = strings.TrimSpace(item[:idx])
+			alias = strings.TrimSpace(item[idx+len(separator):])
+		}
+		if eq := strings.Index(alias, "="); eq != -1 {
+			alias = strings.TrimSpace(alias[:eq]) // Destructuring default
+		}
+		bindings = append(bindings, [2]string{original, alias})
+	}
+	return bindings
+}
+
+// parseJSParameters extracts parameter names from the text after a declaration's "("
+func parseJSParameters(rest string) []string {
+	depth := 1
+	end := len(rest)
+	for i, r := range rest {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		}
+		if depth == 0 {
+			end = i
+			break
+		}
+	}
+
+	result := []string{}
+	if strings.TrimSpace(rest[:end]) == "" {
+		return result
+	}
+	for _, param := range splitParams(rest[:end]) {
+		param = strings.TrimPrefix(strings.TrimSpace(stripTSModifiers(param)), "...")
+		if eq := strings.Index(param, "="); eq != -1 {
+			param = param[:eq]
+		}
+		if colon := topLevelIndex(param, ':'); colon != -1 {
+			param = param[:colon] // TypeScript annotation
+		}
+		if param = strings.TrimRight(strings.TrimSpace(param), "?"); param != "" && param != "this" {
+			result = append(result, param)
+		}
+	}
+	return result
+}
+
+// stripJSLine removes comments from a line, returning the code and the code with string
+// contents blanked out, plus whether a block comment is still open at the end of the line
+func stripJSLine(line string, inComment bool) (string, string, bool) {
+	var code, bare strings.Builder
+	var quote rune
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case inComment:
+			if r == '*' && next == '/' {
+				inComment = false
+				i++
+			}
+		case quote != 0:
+			code.WriteRune(r)
+			if r == '\\' && next != 0 {
+				code.WriteRune(next)
+				i++
+			} else if r == quote {
+				quote = 0
+				bare.WriteRune(r)
+			}
+		case r == '/' && next == '/':
+			return code.String(), bare.String(), false
+		case r == '/' && next == '*':
+			inComment = true
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			code.WriteRune(r)
+			bare.WriteRune(r)
+		default:
+			code.WriteRune(r)
+			bare.WriteRune(r)
+		}
+	}
+	return code.String(), bare.String(), inComment
+}
+
+// splitMember splits "api.Client" into ("api", "Client")
+func splitMember(name string) (string, string) {
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		return name[:idx], name[idx+1:]
+	}
+	return "", name
+}
+
+// jsVisibility treats #private class members as private, and otherwise follows
+// TypeScript's modifiers
+func jsVisibility(name, modifiers string) string {
+	if strings.HasPrefix(name, "#") {
+		return "private"
+	}
+	return visibilityOf(modifiers)
+}
+
+// isJSIdentifier reports whether s is a plain identifier
+func isJSIdentifier(s string) bool {
+	if s == "" || isJSKeyword(s) {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// isJSKeyword checks if a name is a JavaScript keyword that can precede "("
+func isJSKeyword(name string) bool {
+	switch name {
+	case "if", "else", "for", "while", "do", "switch", "case", "catch", "try", "finally",
+		"function", "return", "typeof", "instanceof", "await", "async", "yield", "delete",
+		"void", "in", "of", "new", "throw", "with", "import", "export", "super", "this",
+		"class", "extends", "const", "let", "var", "constructor", "default", "static":
+		return true
+	}
+	return false
+}
+
+// isJSBuiltin checks if a function name is a JavaScript or Node.js global
+func isJSBuiltin(name string) bool {
+	switch name {
+	case "require", "setTimeout", "setInterval", "setImmediate", "clearTimeout", "clearInterval",
+		"clearImmediate", "queueMicrotask", "structuredClone", "parseInt", "parseFloat", "isNaN",
+		"isFinite", "encodeURIComponent", "decodeURIComponent", "encodeURI", "decodeURI",
+		"String", "Number", "Boolean", "Array", "Object", "Symbol", "BigInt", "Promise", "Date",
+		"Error", "TypeError", "RangeError", "RegExp", "Map", "Set", "WeakMap", "WeakSet",
+		"fetch", "alert", "confirm", "prompt", "atob", "btoa":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple JavaScript files concurrently
+func (p *JSParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) {
+		return p.parseModule(file.Path, moduleName(file.RelativePath))
+	})
+}
+
+// Language returns the language name for this parser
+func (p *JSParser) Language() string {
+	return p.language
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *JSParser) FileExtensions() []string {
+	return []string{".js", ".mjs", ".cjs", ".jsx", ".vue"}
+}
+
+// DefaultExcludes returns the directories skipped in JavaScript projects: installed
+// packages, bundler output, and coverage reports
+func (p *JSParser) DefaultExcludes() []string {
+	return []string{"node_modules", "dist", "coverage"}
+}
+
+// SetVersion targets an ECMAScript edition, as an ecmaVersion: an edition number like 11
+// or its year like 2020. async and await name functions before ES2017, and newer
+// constructs are recorded. TypeScript compiles newer syntax down, so it has no target.
+func (p *JSParser) SetVersion(version string) error {
+	if p.ts != nil {
+		return fmt.Errorf("TypeScript compiles to the target in tsconfig.json; no version applies")
+	}
+	year, err := ecmaYear(version)
+	if err != nil {
+		return err
+	}
+	target, err := newSyntaxTarget(year, jsFeatures)
+	if err != nil {
+		return err
+	}
+	p.target = target
+	return nil
+}
+
+func init() {
+	parser.Register(NewJSParser())
+}
@@ -0,0 +1,302 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestJSParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `import Base from './base';
+
+/* Helpers like formatDate() in comments are ignored */
+export default class UserService extends Base {
+  #cache = new Map();
+
+  static async load(id) {
+    const user = await this.fetch(id);
+    return formatDate(user.createdAt);
+  }
+
+  handleClick = (event) => {
+    this.#track(event, "log('not a call')");
+  };
+}
+
+export function formatDate(date, pattern = 'YYYY') {
+  return date;
+}
+
+export const toUpper = s => s.toUpperCase();
+export const API_URL = '/api';
+`
+	path := writeFixture(t, tmp, "user.js", code)
+
+	parsed, err := NewJSParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "javascript" {
+		t.Errorf("expected language javascript, got %q", parsed.Language)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Name] = el
+	}
+	for _, key := range []string{"class:UserService", "method:load", "method:handleClick", "function:formatDate", "function:toUpper", "constant:API_URL"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if load := elements["method:load"]; load.ClassName != "UserService" || !load.IsStatic {
+		t.Errorf("expected static method load on UserService, got %+v", load)
+	}
+	if got := elements["function:formatDate"].Parameters; len(got) != 2 || got[1] != "pattern" {
+		t.Errorf("expected parameters [date pattern], got %v", got)
+	}
+
+	var foundExtends, foundCall, foundThis, foundString bool
+	for _, u := range parsed.Usage {
+		switch {
+		case u.Type == "extends" && u.Name == "Base":
+			foundExtends = true
+		case u.Type == "function_call" && u.Name == "formatDate":
+			foundCall = u.Context == "load"
+		case u.Type == "method_call" && u.Name == "fetch" && u.Receiver == "this":
+			foundThis = true
+		case u.Name == "log":
+			foundString = true
+		}
+	}
+	if !foundExtends || !foundCall || !foundThis {
+		t.Errorf("missing usage (extends=%v call=%v this=%v): %+v", foundExtends, foundCall, foundThis, parsed.Usage)
+	}
+	if foundString {
+		t.Errorf("calls inside string literals should be ignored")
+	}
+}
+
+func TestJSParser_ImportsAndExports(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "utils.js", "export function format() {}\n")
+	if err := os.Mkdir(filepath.Join(tmp, "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, filepath.Join("api", "index.js"), "module.exports = { get, post: send };\n")
+
+	code := `import React, { useState as useLocal } from 'react';
+import * as utils from './utils';
+import {
+  format,
+} from './utils.js';
+import './polyfills';
+const api = require('./api');
+const { get } = require('./api');
+
+export { format as fmt };
+export default App;
+exports.helper = function () {};
+`
+	path := writeFixture(t, tmp, "app.js", code)
+
+	parsed, err := NewJSParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	imports := make(map[string]models.ImportBinding)
+	for _, binding := range parsed.Imports {
+		imports[binding.Local] = binding
+	}
+
+	utilsPath := filepath.Join(tmp, "utils.js")
+	apiPath := filepath.Join(tmp, "api", "index.js")
+	checks := []struct {
+		local, imported, resolved, kind string
+	}{
+		{"React", "default", "", "import"},
+		{"useLocal", "useState", "", "import"},
+		{"utils", "*", utilsPath, "import"},
+		{"format", "format", utilsPath, "import"},
+		{"api", "*", apiPath, "require"},
+		{"get", "get", apiPath, "require"},
+	}
+	for _, c := range checks {
+		binding, ok := imports[c.local]
+		if !ok {
+			t.Errorf("missing import binding %s in %+v", c.local, parsed.Imports)
+			continue
+		}
+		if binding.Imported != c.imported || binding.Resolved != c.resolved || binding.Kind != c.kind {
+			t.Errorf("binding %s = %+v, want imported=%s resolved=%s kind=%s", c.local, binding, c.imported, c.resolved, c.kind)
+		}
+	}
+	if _, ok := imports[""]; !ok {
+		t.Errorf("expected side-effect import of ./polyfills")
+	}
+	if imports["format"].Line != 3 {
+		t.Errorf("expected multi-line import on line 3, got %d", imports["format"].Line)
+	}
+
+	exports := make(map[string]string)
+	for _, export := range parsed.Exports {
+		exports[export.Name] = export.Local
+	}
+	if exports["fmt"] != "format" || exports["default"] != "App" || exports["helper"] != "helper" {
+		t.Errorf("unexpected exports: %+v", parsed.Exports)
+	}
+
+	cjs, err := NewJSParser().ParseFile(apiPath)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(cjs.Exports) != 2 || cjs.Exports[1].Name != "post" || cjs.Exports[1].Local != "send" {
+		t.Errorf("unexpected module.exports bindings: %+v", cjs.Exports)
+	}
+}
+
+func TestJSParser_ModuleSystem(t *testing.T) {
+	tmp := t.TempDir()
+	tests := []struct {
+		name, code, system string
+		features           []string
+	}{
+		{"esm.mjs", "import { a } from './a';\nexport const b = a;\n", "esm", nil},
+		{"meta.mjs", "const here = new URL(import.meta.url);\n", "esm", nil},
+		{"cjs.js", "const fs = require('fs');\nmodule.exports = {\n  read,\n};\n", "commonjs", []string{"module.exports", "require"}},
+		{"mixed.js", "import path from 'path';\nexport const root = path.join(__dirname, '..');\n", "mixed", []string{"__dirname"}},
+		{"script.js", "function main() {}\n", "", nil},
+	}
+
+	for _, tt := range tests {
+		parsed, err := NewJSParser().ParseFile(writeFixture(t, tmp, tt.name, tt.code))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		if parsed.ModuleSystem != tt.system {
+			t.Errorf("%s: expected module system %q, got %q", tt.name, tt.system, parsed.ModuleSystem)
+		}
+		if len(parsed.CommonJSFeatures) != len(tt.features) {
+			t.Errorf("%s: expected features %v, got %v", tt.name, tt.features, parsed.CommonJSFeatures)
+			continue
+		}
+		for i := range tt.features {
+			if parsed.CommonJSFeatures[i] != tt.features[i] {
+				t.Errorf("%s: expected features %v, got %v", tt.name, tt.features, parsed.CommonJSFeatures)
+			}
+		}
+	}
+}
+
+func TestJSParser_ReExports(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "format.js", "export function format() {}\n")
+	writeFixture(t, tmp, "Button.js", "export default function Button() {}\n")
+
+	code := `export * from './format';
+export * as fmt from './format';
+export { default as Button, size } from './Button';
+`
+	parsed, err := NewJSParser().ParseFile(writeFixture(t, tmp, "index.js", code))
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	formatPath := filepath.Join(tmp, "format.js")
+	want := []models.ExportBinding{
+		{Name: "*", Local: "*", Source: "./format", Resolved: formatPath, Line: 1},
+		{Name: "fmt", Local: "*", Source: "./format", Resolved: formatPath, Line: 2},
+		{Name: "Button", Local: "default", Source: "./Button", Resolved: filepath.Join(tmp, "Button.js"), Line: 3},
+		{Name: "size", Local: "size", Source: "./Button", Resolved: filepath.Join(tmp, "Button.js"), Line: 3},
+	}
+	if len(parsed.Exports) != len(want) {
+		t.Fatalf("expected %d exports, got %+v", len(want), parsed.Exports)
+	}
+	for i, w := range want {
+		got := parsed.Exports[i]
+		if got.Name != w.Name || got.Local != w.Local || got.Source != w.Source || got.Resolved != w.Resolved || got.Line != w.Line {
+			t.Errorf("export %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if parsed.ModuleSystem != "esm" {
+		t.Errorf("expected re-exports to mark the file as esm, got %q", parsed.ModuleSystem)
+	}
+}
+
+func TestJSParser_Docblocks(t *testing.T) {
+	code := `/**
+ * Formats money
+ */
+export function format(cents) {}
+
+// Not a docblock
+export function parse(text) {}
+
+/** A cart */
+@observable
+export class Cart {
+  /** Adds an item */
+  add(item) {}
+  remove(item) {}
+}
+`
+	path := filepath.Join(t.TempDir(), "money.js")
+	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := NewJSParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	documented := map[string]bool{}
+	for _, el := range parsed.Elements {
+		documented[el.Name] = el.Documented
+	}
+	want := map[string]bool{"format": true, "parse": false, "Cart": true, "add": true, "remove": false}
+	for name, doc := range want {
+		if documented[name] != doc {
+			t.Errorf("expected %s documented=%v, got %v", name, doc, documented[name])
+		}
+	}
+	if parsed.Lines != 15 || parsed.CommentLines != 6 {
+		t.Errorf("expected 6 comment lines of 15, got %d of %d", parsed.CommentLines, parsed.Lines)
+	}
+}
+
+func TestJSParser_Requests(t *testing.T) {
+	code := "export async function loadUser(id) {\n" +
+		"  const res = await fetch(`/api/users/${id}`);\n" +
+		"  await fetch('/api/users', { method: 'post', body });\n" +
+		"  return axios.delete(\"/api/users/\" + id);\n" +
+		"}\n" +
+		"// fetch('/api/ignored')\n"
+	path := writeFixture(t, t.TempDir(), "users.js", code)
+
+	parsed, err := NewJSParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	var requests []string
+	for _, u := range parsed.Usage {
+		if u.Type == "http_request" {
+			if u.Context != "loadUser" {
+				t.Errorf("expected request in loadUser, got %+v", u)
+			}
+			requests = append(requests, u.Name)
+		}
+	}
+	want := []string{"GET /api/users/${id}", "POST /api/users", "DELETE /api/users/${}"}
+	if len(requests) != len(want) {
+		t.Fatalf("expected requests %v, got %v", want, requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("expected requests %v, got %v", want, requests)
+			break
+		}
+	}
+}
@@ -0,0 +1,866 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// KotlinParser handles parsing of Kotlin files and scripts
+type KotlinParser struct {
+	packagePattern    *regexp.Regexp
+	importPattern     *regexp.Regexp
+	annotationPattern *regexp.Regexp
+	typePattern       *regexp.Regexp
+	companionPattern  *regexp.Regexp
+	funPattern        *regexp.Regexp
+	propertyPattern   *regexp.Regexp
+	callPattern       *regexp.Regexp
+	catchPattern      *regexp.Regexp
+	typeCheckPattern  *regexp.Regexp
+	classRefPattern   *regexp.Regexp
+	methodRefPattern  *regexp.Regexp
+}
+
+// kotlinScope is a type or function body the parser is inside
+type kotlinScope struct {
+	kind      string // "type" or "function"
+	name      string // A companion object's is its class's
+	depth     int    // Brace depth inside the body
+	static    bool   // An object or companion object, whose members need no instance
+	constants bool   // In an enum class body, before the semicolon ending its constants
+}
+
+// kotlinFile is the state of one file's parse
+type kotlinFile struct {
+	parsed  *models.ParsedFile
+	imports map[string]string // Simple name → qualified name, e.g. "User" → `com.acme.model\User`
+	scopes  []kotlinScope
+}
+
+// NewKotlinParser creates a new Kotlin parser with compiled regex patterns
+func NewKotlinParser() *KotlinParser {
+	return &KotlinParser{
+		// Package: package com.acme.users
+		packagePattern: regexp.MustCompile(`^\s*package\s+([\w.]+)`),
+
+		// Imports: import com.acme.model.User, import com.acme.util.slugify as slug, import kotlinx.coroutines.*
+		importPattern: regexp.MustCompile(`^\s*import\s+([\w.]+?)(\.\*)?(?:\s+as\s+(\w+))?\s*;?\s*$`),
+
+		// A leading annotation: @Inject, @GetMapping("/users"), @field:Json(name = "id")
+		annotationPattern: regexp.MustCompile(`^\s*@(?:\w+:)?([A-Za-z_][\w.]*)`),
+
+		// Types: data class User(val name: String) : Entity(), enum class Status, object Registry
+		typePattern: regexp.MustCompile(`^\s*((?:(?:public|private|protected|internal|abstract|open|final|sealed|data|enum|annotation|inner|value|inline|expect|actual|fun)\s+)*)(class|interface|object)\s+([A-Za-z_]\w*)`),
+
+		// Companion objects: companion object, companion object Factory
+		companionPattern: regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal)\s+)*companion\s+object\b(?:\s+([A-Za-z_]\w*))?`),
+
+		// Functions: suspend fun findAll(filter: Filter): List<User>, fun <T> List<T>.second(): T
+		funPattern: regexp.MustCompile(`^\s*((?:(?:public|private|protected|internal|abstract|open|final|override|suspend|inline|operator|infix|tailrec|external|expect|actual)\s+)*)fun\s+(?:<[^()]*?>\s*)?(?:([\w.<>?,*\s]+?)\.)?([A-Za-z_]\w*|` + "`[^`]+`" + `)\s*\(`),
+
+		// Properties: private val repository: UserRepository, const val MAX = 10, val users by lazy
+		propertyPattern: regexp.MustCompile(`^\s*((?:(?:public|private|protected|internal|abstract|open|final|override|const|lateinit|expect|actual)\s+)*)(val|var)\s+(?:<[^>]*>\s*)?(?:[\w.<>?]+\.)?([A-Za-z_]\w*)\s*(?::\s*([^=]+?))?\s*(=|\bby\b|$)`),
+
+		// Calls, with any type arguments and trailing lambdas: save(user), users.find(id), transaction {
+		callPattern: regexp.MustCompile(`([A-Za-z_]\w*)\s*(?:<([\w.,\s?*]*(?:<[\w.,\s?*]*>)?[\w.,\s?*]*)>)?\s*([({])`),
+
+		// Exception handlers: catch (e: IOException)
+		catchPattern: regexp.MustCompile(`\bcatch\s*\(\s*\w+\s*:\s*([\w.]+)`),
+
+		// Type checks and casts: value is User, value !is User, value as? User
+		typeCheckPattern: regexp.MustCompile(`(?:!?\bis|\bas\??)\s+([A-Z]\w*(?:\.[A-Z]\w*)*)`),
+
+		// Class literals: User::class
+		classRefPattern: regexp.MustCompile(`\b([A-Z]\w*(?:\.[A-Z]\w*)*)::class\b`),
+
+		// Function references: ::handle, this::handle, UserMapper::toDto
+		methodRefPattern: regexp.MustCompile(`(?:\b([A-Za-z_][\w.]*))?::([a-z_]\w*)`),
+	}
+}
+
+// ParseFile analyzes a single Kotlin file and extracts all elements
+func (p *KotlinParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	k := &kotlinFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		imports: make(map[string]string),
+	}
+	parsed := k.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	inComment := false
+	inRaw := false    // Inside a """raw string"""
+	docblock := false // A /** */ KDoc comment precedes the next declaration
+
+	var annotations []string // Annotations awaiting the declaration they annotate
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		if lineNum == 1 && strings.HasPrefix(line, "#!") {
+			continue // A script's shebang
+		}
+
+		if !inComment && !inRaw && strings.HasPrefix(strings.TrimSpace(line), "/**") {
+			docblock = true
+		}
+		code, bare, stillInComment, stillInRaw := stripKotlinLine(line, inComment, inRaw)
+		inComment, inRaw = stillInComment, stillInRaw
+		if strings.TrimSpace(bare) == "" {
+			if strings.TrimSpace(line) != "" && strings.TrimSpace(code) == "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Join multi-line parameter lists and calls, so a parameter list is parsed whole
+		for parenBalance(bare) > 0 && !inComment && !inRaw && scanner.Scan() {
+			joinedLines++
+			nextCode, nextBare, nextInComment, nextInRaw := stripKotlinLine(scanner.Text(), false, false)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+			inComment, inRaw = nextInComment, nextInRaw
+		}
+
+		if matches := p.packagePattern.FindStringSubmatch(bare); matches != nil && len(k.scopes) == 0 {
+			parsed.Namespace = matches[1]
+			continue
+		}
+		if matches := p.importPattern.FindStringSubmatch(bare); matches != nil && len(k.scopes) == 0 {
+			k.addImport(matches[1], matches[3], matches[2] != "")
+			continue
+		}
+
+		// Annotations on their own lines wait for the declaration that follows them
+		for {
+			match := p.annotationPattern.FindStringSubmatchIndex(bare)
+			if match == nil {
+				break
+			}
+			annotations = append(annotations, bare[match[2]:match[3]])
+			bare = strings.TrimSpace(bare[match[1]:])
+			if strings.HasPrefix(bare, "(") {
+				if end := closingParen(bare); end != -1 {
+					bare = bare[end+1:]
+				}
+			}
+		}
+		if strings.TrimSpace(bare) == "" {
+			continue
+		}
+
+		documented := docblock
+		docblock = false
+		annotating := annotations
+		annotations = nil
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := k.top()
+		declaring := top == nil || (top.kind == "type" && top.depth == depthBefore)
+		className, static := k.enclosingType(depthBefore)
+
+		if top != nil && top.kind == "type" && top.depth == depthBefore && top.constants {
+			body = k.parseEnumConstants(bare, lineNum, documented)
+			k.addAnnotations(annotating, top.name, lineNum)
+		} else if matches := p.companionPattern.FindStringSubmatchIndex(bare); declaring && className != "" && matches != nil {
+			// A companion's members are its class's, without an instance
+			body = ""
+			if idx := strings.Index(bare[matches[1]:], "{"); idx != -1 {
+				body = bare[matches[1]+idx+1:]
+				k.scopes = append(k.scopes, kotlinScope{kind: "type", name: className, depth: depthBefore + 1, static: true})
+			}
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			element := models.CodeElement{
+				Type:       "class",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				Visibility: kotlinVisibility(modifiers),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				IsAbstract: strings.Contains(modifiers, "abstract") || strings.Contains(modifiers, "sealed"),
+				IsReadonly: strings.Contains(modifiers, "data") || strings.Contains(modifiers, "value"),
+			}
+			switch {
+			case keyword == "interface":
+				element.Type = "interface"
+			case strings.Contains(modifiers, "enum"):
+				element.Type = "enum"
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			k.addAnnotations(annotating, name, lineNum)
+
+			header := bare[matches[1]:]
+			body = ""
+			if idx := topLevelBrace(header); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+				k.scopes = append(k.scopes, kotlinScope{kind: "type", name: name, depth: depthBefore + 1, static: keyword == "object", constants: element.Type == "enum"})
+				if element.Type == "enum" && strings.TrimSpace(body) != "" {
+					body = k.parseEnumConstants(body, lineNum, false)
+				}
+			}
+			k.parseTypeHeader(header, keyword, name, lineNum)
+		} else if fun := p.funPattern.FindStringSubmatchIndex(bare); declaring && fun != nil {
+			modifiers := bare[fun[2]:fun[3]]
+			name := strings.Trim(bare[fun[6]:fun[7]], "`")
+			rest := bare[fun[1]-1:]
+			params := ""
+			if end := closingParen(rest); end != -1 {
+				params, rest = rest[1:end], rest[end+1:]
+			}
+
+			element := models.CodeElement{
+				Type:       "function",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				ClassName:  className,
+				Visibility: kotlinVisibility(modifiers),
+				IsStatic:   static,
+				IsAbstract: strings.Contains(modifiers, "abstract") || (k.isInterface(className) && !strings.ContainsAny(rest, "{=")),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				Parameters: []string{},
+			}
+			if className != "" {
+				element.Type = "method"
+			}
+			for _, param := range splitTopLevel(params) {
+				paramName, paramType := kotlinParameter(param)
+				if paramName == "" {
+					continue
+				}
+				element.Parameters = append(element.Parameters, paramName)
+				element.ParamTypes = append(element.ParamTypes, k.typeNames(paramType))
+			}
+			// The return type follows the parameters: ): List<User> {
+			if returns := strings.TrimSpace(rest); strings.HasPrefix(returns, ":") {
+				returns = returns[1:]
+				if idx := strings.IndexAny(returns, "{="); idx != -1 {
+					returns = returns[:idx]
+				}
+				if idx := strings.Index(returns, " where "); idx != -1 {
+					returns = returns[:idx]
+				}
+				element.ReturnType = k.typeNames(returns)
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			k.addAnnotations(annotating, name, lineNum)
+
+			body, context = k.functionBody(rest, name, depthBefore)
+		} else if matches := p.propertyPattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			propertyType := ""
+			if matches[8] != -1 {
+				propertyType = bare[matches[8]:matches[9]]
+			}
+			body = bare[matches[10]:]
+
+			const_ := strings.Contains(modifiers, "const")
+			if className != "" || const_ {
+				element := models.CodeElement{
+					Type:       "property",
+					Name:       name,
+					Namespace:  parsed.Namespace,
+					ClassName:  className,
+					Visibility: kotlinVisibility(modifiers),
+					IsStatic:   static,
+					IsReadonly: keyword == "val",
+					IsAbstract: strings.Contains(modifiers, "abstract"),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				}
+				if const_ {
+					element.Type = "constant"
+					element.IsStatic, element.IsReadonly = false, false
+				}
+				parsed.Elements = append(parsed.Elements, element)
+			}
+			context = className
+			k.addAnnotations(annotating, className, lineNum)
+
+			// A property's type is a dependency of its class, such as an injected service
+			if className != "" {
+				for _, typeName := range strings.Split(k.typeNames(propertyType), "|") {
+					if typeName != "" {
+						parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: className, Line: lineNum})
+					}
+				}
+			}
+		} else if declaring && className != "" && strings.HasPrefix(strings.TrimSpace(bare), "constructor") {
+			// A secondary constructor, named for its class like Java's
+			rest := strings.TrimSpace(bare)[len("constructor"):]
+			element := models.CodeElement{
+				Type:       "method",
+				Name:       className,
+				Namespace:  parsed.Namespace,
+				ClassName:  className,
+				Visibility: "public",
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				Parameters: []string{},
+			}
+			if end := closingParen(rest); end != -1 {
+				for _, param := range splitTopLevel(rest[1:end]) {
+					if paramName, paramType := kotlinParameter(param); paramName != "" {
+						element.Parameters = append(element.Parameters, paramName)
+						element.ParamTypes = append(element.ParamTypes, k.typeNames(paramType))
+					}
+				}
+				rest = rest[end+1:]
+			}
+			parsed.Elements = append(parsed.Elements, element)
+			k.addAnnotations(annotating, className, lineNum)
+			body, context = k.functionBody(rest, className, depthBefore)
+		} else if declaring && className != "" && strings.HasPrefix(strings.TrimSpace(bare), "init") {
+			// Initializer blocks run as part of construction
+			if idx := strings.Index(bare, "{"); idx != -1 {
+				body = bare[idx+1:]
+				k.scopes = append(k.scopes, kotlinScope{kind: "function", name: className, depth: depthBefore + 1})
+			}
+		} else {
+			k.addAnnotations(annotating, k.context(), lineNum)
+		}
+
+		if context == "" {
+			context = k.context()
+		}
+		p.parseUsage(k, body, lineNum, context)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, k.className(), context)...)
+
+		// Leave the bodies closed on this line
+		for len(k.scopes) > 0 && braceDepth < k.scopes[len(k.scopes)-1].depth {
+			k.scopes = k.scopes[:len(k.scopes)-1]
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// functionBody returns the code following a function declaration, and the function it
+// belongs to. A block body opens a scope for the lines that follow; an expression body
+// (= ...) is parsed on its line.
+func (k *kotlinFile) functionBody(rest, name string, depthBefore int) (string, string) {
+	brace := strings.Index(rest, "{")
+	equals := topLevelIndex(rest, '=')
+	switch {
+	case brace != -1 && (equals == -1 || brace < equals):
+		k.scopes = append(k.scopes, kotlinScope{kind: "function", name: name, depth: depthBefore + 1})
+		return rest[brace+1:], name
+	case equals != -1:
+		if brace != -1 {
+			k.scopes = append(k.scopes, kotlinScope{kind: "function", name: name, depth: depthBefore + 1})
+		}
+		return rest[equals+1:], name
+	}
+	return "", name
+}
+
+// addImport records an import under its simple name or alias. Star imports can't be
+// resolved to a declaration.
+func (k *kotlinFile) addImport(path, alias string, star bool) {
+	if star {
+		k.parsed.Uses = append(k.parsed.Uses, path)
+		return
+	}
+	qualified := qualifyJava(path)
+	k.parsed.Uses = append(k.parsed.Uses, qualified)
+	if alias == "" {
+		alias = path[strings.LastIndex(path, ".")+1:]
+	}
+	k.imports[alias] = qualified
+}
+
+// parseEnumConstants records the constants an enum class declares on a line, and returns
+// the code after them, such as their arguments, for usage parsing
+func (k *kotlinFile) parseEnumConstants(line string, lineNum int, documented bool) string {
+	scope := &k.scopes[len(k.scopes)-1]
+	if idx := topLevelIndex(line, ';'); idx != -1 {
+		line = line[:idx]
+		scope.constants = false
+	}
+	for _, constant := range splitTopLevel(line) {
+		constant = strings.TrimSpace(constant)
+		end := 0
+		for end < len(constant) && (constant[end] == '_' || unicode.IsLetter(rune(constant[end])) || unicode.IsDigit(rune(constant[end]))) {
+			end++
+		}
+		if end == 0 {
+			continue
+		}
+		k.parsed.Elements = append(k.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       constant[:end],
+			Namespace:  k.parsed.Namespace,
+			ClassName:  scope.name,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       k.parsed.Path,
+			Documented: documented,
+		})
+	}
+	return line
+}
+
+// parseTypeHeader records a type's primary constructor and supertypes. The primary
+// constructor's val and var parameters are properties, and every parameter's type is a
+// dependency of the class. A supertype called with arguments is the superclass; the rest
+// are interfaces, which have no constructor to call.
+func (k *kotlinFile) parseTypeHeader(header, keyword, name string, lineNum int) {
+	header = skipTypeParameters(strings.TrimSpace(header))
+	if idx := strings.Index(header, "("); idx != -1 && !strings.Contains(header[:idx], ":") {
+		if end := closingParen(header[idx:]); end != -1 {
+			k.parseConstructor(header[idx+1:idx+end], name, lineNum)
+			header = header[idx+end+1:]
+		}
+	}
+	header = strings.TrimSpace(header)
+	if !strings.HasPrefix(header, ":") {
+		return
+	}
+	header = header[1:]
+	if idx := strings.Index(header, " where "); idx != -1 {
+		header = header[:idx]
+	}
+
+	for _, supertype := range splitTopLevel(header) {
+		supertype = strings.TrimSpace(supertype)
+		if idx := strings.Index(supertype, " by "); idx != -1 {
+			supertype = supertype[:idx] // Delegation: Repository by delegate
+		}
+		usageType := "implements"
+		switch {
+		case keyword == "interface":
+			usageType = "extends"
+		case strings.Contains(supertype, "("):
+			usageType = "extends"
+		}
+		if idx := strings.IndexAny(supertype, "<("); idx != -1 {
+			supertype = supertype[:idx]
+		}
+		if supertype = strings.TrimSpace(supertype); supertype == "" {
+			continue
+		}
+		k.parsed.Usage = append(k.parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    k.qualify(supertype),
+			Context: name,
+			Line:    lineNum,
+		})
+	}
+}
+
+// parseConstructor records a primary constructor's properties and parameter types
+func (k *kotlinFile) parseConstructor(params, className string, lineNum int) {
+	for _, param := range splitTopLevel(params) {
+		param = strings.TrimSpace(stripAnnotations(param))
+		fields := strings.Fields(strings.SplitN(param, ":", 2)[0])
+		paramName, paramType := kotlinParameter(param)
+		if paramName == "" {
+			continue
+		}
+		if len(fields) > 1 && (fields[len(fields)-2] == "val" || fields[len(fields)-2] == "var") {
+			k.parsed.Elements = append(k.parsed.Elements, models.CodeElement{
+				Type:       "property",
+				Name:       paramName,
+				Namespace:  k.parsed.Namespace,
+				ClassName:  className,
+				Visibility: kotlinVisibility(strings.Join(fields, " ")),
+				IsReadonly: fields[len(fields)-2] == "val",
+				Line:       lineNum,
+				File:       k.parsed.Path,
+			})
+		}
+		for _, typeName := range strings.Split(k.typeNames(paramType), "|") {
+			if typeName != "" {
+				k.parsed.Usage = append(k.parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: className, Line: lineNum})
+			}
+		}
+	}
+}
+
+// parseUsage finds calls, instantiations, references, and type checks in code
+func (p *KotlinParser) parseUsage(k *kotlinFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	inClass := k.className() != ""
+	add := func(usageType, name, receiver string) {
+		k.parsed.Usage = append(k.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+
+	for _, match := range p.catchPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", k.qualify(match[1]), "")
+	}
+	for _, match := range p.typeCheckPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", k.qualify(match[1]), "")
+	}
+	for _, match := range p.classRefPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", k.qualify(match[1]), "")
+	}
+	for _, match := range p.methodRefPattern.FindAllStringSubmatch(code, -1) {
+		switch receiver := match[1]; {
+		case receiver == "" || receiver == "this":
+			if inClass {
+				add("method_call", match[2], "this")
+			} else {
+				add("function_call", k.qualify(match[2]), "")
+			}
+		case isTypeName(receiver):
+			add("static_call", k.qualify(receiver)+"::"+match[2], k.qualify(receiver))
+		}
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		if isKotlinKeyword(name) || strings.HasSuffix(prefix, "::") || strings.HasSuffix(prefix, "fun") {
+			continue
+		}
+		if match[4] != -1 { // Type arguments: inject<UserRepository>()
+			for _, typeName := range strings.Split(k.typeNames(code[match[4]:match[5]]), "|") {
+				if typeName != "" {
+					add("type_reference", typeName, "")
+				}
+			}
+		}
+		lambda := code[match[6]:match[7]] == "{"
+		if !strings.HasSuffix(prefix, ".") {
+			switch {
+			case lambda && !unicode.IsLower(rune(name[0])):
+				// object : Listener { ... } and the like, not a call
+				if strings.HasSuffix(prefix, ":") {
+					add("type_reference", k.qualify(name), "")
+				}
+			case isTypeName(name):
+				add("instantiation", k.qualify(name), "") // Constructors are called like functions
+			case inClass:
+				if qualified, ok := k.imports[name]; ok {
+					add("function_call", qualified, "")
+				} else {
+					add("method_call", name, "this") // Unqualified calls are on this class, or top-level
+				}
+			default:
+				add("function_call", k.qualify(name), "")
+			}
+			continue
+		}
+
+		receiver := javaReceiver(strings.TrimRight(strings.TrimSuffix(prefix, "."), " \t?!"))
+		switch {
+		case receiver == "this":
+			add("method_call", name, "this")
+		case receiver != "" && isTypeName(receiver):
+			add("static_call", k.qualify(receiver)+"::"+name, k.qualify(receiver))
+		default:
+			add("method_call", name, receiver)
+		}
+	}
+}
+
+// addAnnotations records annotations as usage of the annotation classes by context
+func (k *kotlinFile) addAnnotations(annotations []string, context string, lineNum int) {
+	for _, name := range annotations {
+		k.parsed.Usage = append(k.parsed.Usage, models.UsageElement{
+			Type:    "attribute",
+			Name:    k.qualify(name),
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// isInterface checks if name is an interface declared in this file
+func (k *kotlinFile) isInterface(name string) bool {
+	for _, element := range k.parsed.Elements {
+		if element.Name == name && element.Type == "interface" {
+			return true
+		}
+	}
+	return false
+}
+
+// top returns the innermost scope, or nil at the top level
+func (k *kotlinFile) top() *kotlinScope {
+	if len(k.scopes) == 0 {
+		return nil
+	}
+	return &k.scopes[len(k.scopes)-1]
+}
+
+// enclosingType returns the type whose body a declaration at depth is directly in, and
+// whether its members are static, or "" at the top level
+func (k *kotlinFile) enclosingType(depth int) (string, bool) {
+	if top := k.top(); top != nil && top.kind == "type" && top.depth == depth {
+		return top.name, top.static
+	}
+	return "", false
+}
+
+// className returns the innermost type being parsed
+func (k *kotlinFile) className() string {
+	for i := len(k.scopes) - 1; i >= 0; i-- {
+		if k.scopes[i].kind == "type" {
+			return k.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the innermost function or type being parsed
+func (k *kotlinFile) context() string {
+	if len(k.scopes) == 0 {
+		return ""
+	}
+	return k.scopes[len(k.scopes)-1].name
+}
+
+// qualify names a declaration the way the analyzer indexes it: imported ones with their
+// package, so they resolve to the imported declaration rather than one of the same name
+func (k *kotlinFile) qualify(name string) string {
+	if qualified, ok := k.imports[name]; ok {
+		return qualified
+	}
+	if idx := strings.Index(name, "."); idx != -1 {
+		if first := name[:idx]; unicode.IsLower(rune(first[0])) {
+			return qualifyJava(name) // Fully qualified: com.acme.model.User
+		}
+		if outer, ok := k.imports[name[:idx]]; ok {
+			return outer // A nested class of an imported one: Map.Entry
+		}
+	}
+	return name
+}
+
+// typeNames lists the class names in a type, qualified and separated by "|", leaving
+// out Kotlin's built-in types: "Map<String, List<User>>?" → "Map|List|User"
+func (k *kotlinFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+	}) {
+		word = strings.Trim(word, ".")
+		if word == "" || isKotlinBuiltin(word) || isKotlinKeyword(word) || len(word) == 1 {
+			continue // len 1: type variables, T and E
+		}
+		names = append(names, k.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// kotlinParameter splits a parameter declaration into its name and type:
+// "@Valid private val users: List<User> = emptyList()" → ("users", "List<User>")
+func kotlinParameter(param string) (string, string) {
+	param = strings.TrimSpace(stripAnnotations(param))
+	if idx := topLevelIndex(param, '='); idx != -1 {
+		param = param[:idx]
+	}
+	name, paramType, ok := strings.Cut(param, ":")
+	if !ok {
+		return "", "" // No type: a lambda's parameters
+	}
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	return fields[len(fields)-1], strings.TrimSpace(paramType)
+}
+
+// kotlinVisibility picks the visibility modifier out of a modifier list. Without one, a
+// declaration is public.
+func kotlinVisibility(modifiers string) string {
+	for _, modifier := range strings.Fields(modifiers) {
+		switch modifier {
+		case "public", "private", "protected", "internal":
+			return modifier
+		}
+	}
+	return "public"
+}
+
+// topLevelBrace returns the index of the brace opening a type's body in its header, past
+// the parentheses of its constructor and supertype calls, or -1
+func topLevelBrace(header string) int {
+	depth := 0
+	for i, r := range header {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// stripKotlinLine removes comments from a line, returning the code and the code with
+// string contents blanked out, plus whether a block comment or """raw string""" is still
+// open at the end of the line
+func stripKotlinLine(line string, inComment, inRaw bool) (string, string, bool, bool) {
+	var code, bare strings.Builder
+	var quote rune
+	runes := []rune(line)
+	rawQuote := func(i int) bool {
+		return i+2 < len(runes) && runes[i] == '"' && runes[i+1] == '"' && runes[i+2] == '"'
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case inComment:
+			if r == '*' && next == '/' {
+				inComment = false
+				i++
+			}
+		case inRaw:
+			code.WriteRune(r)
+			if rawQuote(i) {
+				code.WriteString(`""`)
+				bare.WriteString(`"""`)
+				inRaw = false
+				i += 2
+			}
+		case quote != 0:
+			code.WriteRune(r)
+			if r == '\\' && next != 0 {
+				code.WriteRune(next)
+				i++
+			} else if r == quote {
+				quote = 0
+				bare.WriteRune(r)
+			}
+		case r == '/' && next == '/':
+			return code.String(), bare.String(), false, false
+		case r == '/' && next == '*':
+			inComment = true
+			i++
+		case rawQuote(i):
+			code.WriteString(`"""`)
+			bare.WriteString(`"""`)
+			inRaw = true
+			i += 2
+		case r == '\'' || r == '"':
+			quote = r
+			code.WriteRune(r)
+			bare.WriteRune(r)
+		default:
+			code.WriteRune(r)
+			bare.WriteRune(r)
+		}
+	}
+	return code.String(), bare.String(), inComment, inRaw
+}
+
+// isKotlinKeyword checks if a word is a Kotlin keyword that can precede a parenthesis, a
+// brace, or a name in a statement
+func isKotlinKeyword(word string) bool {
+	switch word {
+	case "if", "else", "for", "while", "do", "when", "try", "catch", "finally", "return",
+		"throw", "this", "super", "init", "constructor", "fun", "val", "var", "object", "class",
+		"interface", "is", "as", "in", "out", "get", "set", "by", "where", "typealias",
+		"companion", "null", "true", "false", "import", "package", "vararg", "crossinline",
+		"noinline", "reified", "suspend":
+		return true
+	}
+	return false
+}
+
+// isKotlinBuiltin checks if a type name is one of Kotlin's built-in types
+func isKotlinBuiltin(name string) bool {
+	switch name {
+	case "Int", "Long", "Short", "Byte", "Float", "Double", "Boolean", "Char", "String",
+		"Unit", "Any", "Nothing", "UInt", "ULong", "UShort", "UByte", "Number", "Array":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Kotlin files concurrently
+func (p *KotlinParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *KotlinParser) Language() string {
+	return "kotlin"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *KotlinParser) FileExtensions() []string {
+	return []string{".kt", ".kts"}
+}
+
+// DefaultExcludes returns the directories skipped in Kotlin projects: Gradle and Maven
+// build output and caches
+func (p *KotlinParser) DefaultExcludes() []string {
+	return []string{"build", ".gradle", "target", "out", ".idea"}
+}
+
+// Entrypoints returns the functions the runtime calls: main, and the lifecycle callbacks
+// Android calls on activities, fragments, services, and receivers
+func (p *KotlinParser) Entrypoints() []string {
+	return []string{
+		`^main$`,
+		`^on(Create|Start|Resume|Pause|Stop|Restart|Destroy|CreateView|ViewCreated|DestroyView|Attach|Detach|Bind|Unbind|Receive|StartCommand|NewIntent|SaveInstanceState|RestoreInstanceState|ActivityResult|CreateOptionsMenu|OptionsItemSelected|ConfigurationChanged|LowMemory)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewKotlinParser())
+}
@@ -0,0 +1,232 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestKotlinParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `package com.acme.users
+
+import com.acme.model.User
+import com.acme.util.slugify as slug
+import kotlinx.coroutines.*
+
+/**
+ * Manages users.
+ */
+@Service
+class UserService(
+    private val repository: UserRepository,
+    clock: Clock,
+) : ServiceBase<User>(), Closeable {
+    val label = "users" // TODO: localize
+    lateinit var cache: UserCache
+
+    suspend fun findAll(filter: Filter, limit: Int = 10): List<User> {
+        try {
+            val user = User(filter.name())
+            return repository.findAll(filter).map(::toDto)
+        } catch (e: IOException) {
+            return emptyList()
+        }
+    }
+
+    private fun toDto(user: User): UserDto = UserDto.from(validate(user))
+
+    override fun close() {}
+
+    companion object {
+        const val MAX_RESULTS = 50
+
+        fun create(): UserService = UserService(InMemoryRepository(), Clock.systemUTC())
+    }
+}
+
+interface UserRepository {
+    fun findAll(filter: Filter): List<User>
+}
+
+enum class Status { ACTIVE, INACTIVE }
+
+fun String.slugged(): String = slug(this)
+`
+	path := writeFixture(t, tmp, "UserService.kt", code)
+
+	parsed, err := NewKotlinParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "kotlin" || parsed.Namespace != "com.acme.users" {
+		t.Errorf("expected the package, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	wantUses := []string{`com.acme.model\User`, `com.acme.util\slugify`, "kotlinx.coroutines"}
+	if len(parsed.Uses) != len(wantUses) {
+		t.Fatalf("expected uses %v, got %v", wantUses, parsed.Uses)
+	}
+	for i, use := range wantUses {
+		if parsed.Uses[i] != use {
+			t.Errorf("expected uses %v, got %v", wantUses, parsed.Uses)
+		}
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "property:UserService.repository",
+		"property:UserService.label", "property:UserService.cache", "method:UserService.findAll",
+		"method:UserService.toDto", "method:UserService.close", "constant:UserService.MAX_RESULTS",
+		"method:UserService.create", "interface:.UserRepository", "method:UserRepository.findAll",
+		"enum:.Status", "constant:Status.ACTIVE", "constant:Status.INACTIVE", "function:.slugged"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 15 {
+		t.Errorf("expected 15 elements, got %+v", parsed.Elements)
+	}
+
+	service := elements["class:.UserService"]
+	if !service.Documented || service.Namespace != "com.acme.users" || service.Line != 11 || elements["method:UserService.findAll"].Documented {
+		t.Errorf("expected only the class with KDoc to be documented, got %+v", service)
+	}
+	findAll := elements["method:UserService.findAll"]
+	if len(findAll.Parameters) != 2 || findAll.Parameters[1] != "limit" || findAll.ParamTypes[0] != "Filter" || findAll.ParamTypes[1] != "" {
+		t.Errorf("expected the parameters, got %v %v", findAll.Parameters, findAll.ParamTypes)
+	}
+	if findAll.ReturnType != `List|com.acme.model\User` || findAll.Line != 18 {
+		t.Errorf("expected the return type's classes on line 18, got %q on %d", findAll.ReturnType, findAll.Line)
+	}
+	if repository := elements["property:UserService.repository"]; repository.Visibility != "private" || !repository.IsReadonly {
+		t.Error("expected the constructor's private val to be a readonly property")
+	}
+	if !elements["property:UserService.label"].IsReadonly || elements["property:UserService.cache"].IsReadonly {
+		t.Error("expected only the val to be readonly")
+	}
+	if !elements["method:UserService.create"].IsStatic || elements["method:UserService.toDto"].IsStatic {
+		t.Error("expected only the companion's function to be static")
+	}
+	if elements["method:UserService.toDto"].Visibility != "private" || elements["method:UserService.close"].Visibility != "public" {
+		t.Error("expected declarations without a modifier to be public")
+	}
+	if !elements["method:UserRepository.findAll"].IsAbstract || elements["method:UserService.findAll"].IsAbstract {
+		t.Error("expected only the interface's function without a body to be abstract")
+	}
+	if slugged := elements["function:.slugged"]; slugged.Namespace != "com.acme.users" || slugged.ReturnType != "" {
+		t.Errorf("expected the top-level extension function in the package, got %+v", slugged)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"attribute:.Service in UserService",
+		`extends:.ServiceBase in UserService`,
+		"implements:.Closeable in UserService",
+		"type_reference:.UserRepository in UserService",
+		"type_reference:.Clock in UserService",
+		"type_reference:.UserCache in UserService",
+		`instantiation:.com.acme.model\User in findAll`,
+		"method_call:filter.name in findAll",
+		"method_call:repository.findAll in findAll",
+		"method_call:this.toDto in findAll",
+		"type_reference:.IOException in findAll",
+		"method_call:this.emptyList in findAll",
+		"static_call:UserDto.UserDto::from in toDto",
+		"method_call:this.validate in toDto",
+		"instantiation:.UserService in create",
+		"instantiation:.InMemoryRepository in create",
+		"static_call:Clock.Clock::systemUTC in create",
+		`function_call:.com.acme.util\slugify in slugged`,
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"method_call:this.map in findAll", "method_call:this.catch in findAll", "method_call:this.findAll in UserService"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 15 {
+		t.Errorf("expected the TODO on line 15, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 3 {
+		t.Errorf("expected 3 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestKotlinParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"model", "ui"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "model/Player.kt", `package com.acme.game.model
+
+data class Player(var health: Int) {
+    fun damage(amount: Int) {
+        health -= amount
+    }
+}
+`)
+	writeFixture(t, tmp, "ui/GameActivity.kt", `package com.acme.game.ui
+
+import android.os.Bundle
+import com.acme.game.model.Player
+
+class GameActivity : AppCompatActivity() {
+    private lateinit var player: Player
+
+    override fun onCreate(savedInstanceState: Bundle?) {
+        super.onCreate(savedInstanceState)
+        player = Player(100)
+        hit(10)
+    }
+
+    private fun hit(amount: Int) = player.damage(amount)
+}
+`)
+
+	p := NewKotlinParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"model/Player.kt", "ui/GameActivity.kt"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Namespace+`\`+node.ClassName+"."+node.Name] = node
+	}
+	onCreate, player := nodes[`com.acme.game.ui\GameActivity.onCreate`], nodes[`com.acme.game.model\.Player`]
+	if onCreate == nil || player == nil || onCreate.Dependencies[player.ID] == nil {
+		t.Fatalf("expected onCreate to construct the Player, got %+v", onCreate)
+	}
+	if !onCreate.IsEntrypoint {
+		t.Error("expected the Android lifecycle callback to be an entrypoint")
+	}
+	hit := nodes[`com.acme.game.ui\GameActivity.hit`]
+	if hit == nil || onCreate.Dependencies[hit.ID] == nil || hit.IsEntrypoint {
+		t.Errorf("expected the unqualified call to resolve to the class's function, got %+v", onCreate.Dependencies)
+	}
+}
@@ -0,0 +1,701 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// LuaParser handles parsing of Lua files
+type LuaParser struct {
+	functionPattern *regexp.Regexp
+	assignPattern   *regexp.Regexp
+	localPattern    *regexp.Regexp
+	tablePattern    *regexp.Regexp
+	requirePattern  *regexp.Regexp
+	bindingPattern  *regexp.Regexp
+	returnPattern   *regexp.Regexp
+	callPattern     *regexp.Regexp
+	wordPattern     *regexp.Regexp
+}
+
+// luaScope is a body closed by "end" or "until"
+type luaScope struct {
+	kind string // "function" for a named function, or "block"
+	name string
+}
+
+// luaLexer carries a long string or comment ([[ ... ]], --[==[ ... ]==]) from one line
+// to the next
+type luaLexer struct {
+	closing string // "]]", "]=]", ...
+	comment bool
+}
+
+// luaFile is the state of one file's parse
+type luaFile struct {
+	parsed   *models.ParsedFile
+	id       string // Qualified name of the file's module, from its path: `src\shop\orders`
+	name     string // Last segment of id, the context of top-level code
+	returned string // Variable the file returns as its module, e.g. M in "return M"
+	scopes   []luaScope
+	locals   map[string]bool   // Top-level local variables
+	tables   map[string]int    // Local tables of the file, to the line declaring them
+	declared map[string]bool   // Qualified names of the tables declared as modules
+	aliases  map[string]string // Variables bound to required modules, to what they name
+}
+
+// NewLuaParser creates a new Lua parser with compiled regex patterns
+func NewLuaParser() *LuaParser {
+	name := `[A-Za-z_]\w*`
+	return &LuaParser{
+		// Function statements: function M.total(order), function Player:update(dt),
+		// local function helper(x), function love.load()
+		functionPattern: regexp.MustCompile(`^(local\s+)?function\s+(` + name + `(?:\.` + name + `)*)(?::(` + name + `))?\s*\(`),
+
+		// Functions assigned to a name: M.total = function(order), local fmt = function(s)
+		assignPattern: regexp.MustCompile(`^(local\s+)?(` + name + `(?:\.` + name + `)*)\s*=\s*function\s*\(`),
+
+		// Local declarations: local a, b = 1, 2
+		localPattern: regexp.MustCompile(`^local\s+(` + name + `(?:\s*,\s*` + name + `)*)`),
+
+		// Local tables, which group functions like a module or class:
+		// local M = {}, local Player = setmetatable({}, Entity), local Enemy = class("Enemy")
+		tablePattern: regexp.MustCompile(`^local\s+(` + name + `)\s*=\s*(?:\{|setmetatable\s*\(|class\s*\()`),
+
+		// Requires: require("shop.orders"), require "dkjson", require('lib/log').info
+		requirePattern: regexp.MustCompile(`\brequire\s*\(?\s*["']([^"']+)["']\s*\)?((?:\.` + name + `)*)`),
+
+		// Variables bound to a require: local Orders = require("shop.orders")
+		bindingPattern: regexp.MustCompile(`^(?:local\s+)?(` + name + `)\s*=\s*require\b`),
+
+		// The module a file returns: return M
+		returnPattern: regexp.MustCompile(`^return\s+(` + name + `)\s*;?\s*$`),
+
+		// Calls, including those with a string or table argument and no parentheses:
+		// Orders.list(), player:update(dt), helper(x), require "json", Enemy{hp = 3}
+		callPattern: regexp.MustCompile(`(` + name + `(?:\.` + name + `)*)(?::(` + name + `))?\s*[({"]`),
+
+		// Words that open and close bodies: function, if, do, repeat ... end, until
+		wordPattern: regexp.MustCompile(name),
+	}
+}
+
+// ParseFile analyzes a single Lua file and extracts all elements
+func (p *LuaParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &luaFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		id:       luaModuleID(filePath),
+		returned: p.returnedName(data),
+		locals:   make(map[string]bool),
+		tables:   make(map[string]int),
+		declared: make(map[string]bool),
+		aliases:  make(map[string]string),
+	}
+	parsed := l.parsed
+	parsed.Namespace = l.id
+
+	// Every file is a module that require can load; its documentation is the comment
+	// it starts with
+	l.name = l.declareModule(l.id, 1)
+	l.declared[l.id] = true
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	commentEnd := -1 // Last line of the comment block above the current line
+	seenCode := false
+	var lex luaLexer
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		raw := scanner.Text()
+		if lineNum == 1 && strings.HasPrefix(raw, "#") {
+			continue // A shebang, which Lua skips
+		}
+		if marker, ok := debtMarker(raw, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		inComment, inString := lex.closing != "" && lex.comment, lex.closing != "" && !lex.comment
+
+		// Join a statement continued by open brackets, a trailing comma, or an operator
+		code, bare := stripLuaLine(raw, &lex)
+		for continuesLuaStatement(bare) && scanner.Scan() {
+			joinedLines++
+			if marker, ok := debtMarker(scanner.Text(), lineNum+joinedLines); ok {
+				parsed.Debt = append(parsed.Debt, marker)
+			}
+			nextCode, nextBare := stripLuaLine(scanner.Text(), &lex)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+		}
+		if strings.TrimSpace(bare) == "" {
+			if trimmed := strings.TrimSpace(raw); inComment || (!inString && strings.HasPrefix(trimmed, "--")) {
+				parsed.CommentLines++
+				commentEnd = lineNum
+				if !seenCode {
+					parsed.Elements[0].Documented = true
+				}
+			}
+			if strings.TrimSpace(raw) != "" {
+				seenCode = true
+			}
+			continue
+		}
+		seenCode = true
+		documented := commentEnd == lineNum-1
+
+		p.parseRequires(l, strings.TrimSpace(code), lineNum)
+		for _, part := range strings.Split(bare, ";") {
+			if part = strings.TrimSpace(part); part != "" {
+				p.parseStatement(l, part, lineNum, documented)
+			}
+		}
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, "", l.function())...)
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// returnedName finds the variable a file returns at its top level, the table that is
+// its module
+func (p *LuaParser) returnedName(data []byte) string {
+	returned := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		if match := p.returnPattern.FindStringSubmatch(strings.TrimRight(line, "\r")); match != nil {
+			returned = match[1]
+		}
+	}
+	return returned
+}
+
+// parseRequires records the modules a line requires and the variables bound to them.
+// A module found under the file's directory or one above it is named by its path, like
+// the file that defines it; others keep their dotted name.
+func (p *LuaParser) parseRequires(l *luaFile, code string, lineNum int) {
+	matches := p.requirePattern.FindAllStringSubmatch(code, -1)
+	for _, match := range matches {
+		module := resolveLuaModule(l.parsed.Path, match[1])
+		l.parsed.Uses = append(l.parsed.Uses, module)
+		l.addUsage("type_reference", module, "", l.context(), lineNum)
+	}
+	if binding := p.bindingPattern.FindStringSubmatch(code); binding != nil && len(matches) > 0 {
+		// local list = require("shop.orders").list binds the module's function
+		module := resolveLuaModule(l.parsed.Path, matches[0][1])
+		l.aliases[binding[1]] = module + strings.ReplaceAll(matches[0][2], ".", `\`)
+		l.locals[binding[1]] = true
+	}
+}
+
+// parseStatement handles one statement: a function definition, a local declaration, or code
+func (p *LuaParser) parseStatement(l *luaFile, stmt string, lineNum int, documented bool) {
+	events := p.blockEvents(stmt)
+
+	loc := p.functionPattern.FindStringSubmatchIndex(stmt)
+	method := ""
+	if loc != nil && loc[6] != -1 {
+		method = stmt[loc[6]:loc[7]]
+	} else if loc == nil {
+		loc = p.assignPattern.FindStringSubmatchIndex(stmt)
+	}
+	if loc != nil && len(events) > 0 && events[0] > 0 {
+		local, path := loc[2] != -1, stmt[loc[4]:loc[5]]
+		params, rest := luaSignature(stmt[loc[1]-1:])
+		name := p.addFunction(l, local, path, method, params, lineNum, documented)
+
+		l.scopes = append(l.scopes, luaScope{kind: "function", name: name})
+		p.parseUsage(l, rest, lineNum)
+		p.applyEvents(l, events[1:])
+		return
+	}
+
+	if len(l.scopes) == 0 {
+		if match := p.tablePattern.FindStringSubmatch(stmt); match != nil {
+			l.tables[match[1]] = lineNum
+		}
+		if match := p.localPattern.FindStringSubmatch(stmt); match != nil {
+			for _, name := range strings.Split(match[1], ",") {
+				l.locals[strings.TrimSpace(name)] = true
+			}
+		}
+	}
+
+	p.parseUsage(l, stmt, lineNum)
+	p.applyEvents(l, events)
+}
+
+// addFunction records a function definition, returning its name. Functions of a table are
+// part of the module the table is; those defined with ":" are methods, taking self.
+func (p *LuaParser) addFunction(l *luaFile, local bool, path, method string, params []string, lineNum int, documented bool) string {
+	element := models.CodeElement{
+		Type:       "function",
+		Name:       path,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       l.parsed.Path,
+		Documented: documented,
+		Parameters: []string{},
+	}
+	switch {
+	case method != "":
+		element.Type, element.Name = "method", method
+		element.Namespace = l.table(path, lineNum)
+		element.ClassName = element.Namespace[strings.LastIndex(element.Namespace, `\`)+1:]
+	case strings.Contains(path, "."):
+		idx := strings.LastIndex(path, ".")
+		element.Name = path[idx+1:]
+		element.Namespace = l.table(path[:idx], lineNum)
+	case local || l.locals[path]:
+		// A local function belongs to its file; a global one is visible everywhere
+		element.Namespace = l.id
+		element.Visibility = "private"
+		if len(l.scopes) == 0 {
+			l.locals[path] = true
+		}
+	}
+	for _, param := range params {
+		if param = strings.TrimSpace(param); param != "" {
+			element.Parameters = append(element.Parameters, param)
+			element.ParamTypes = append(element.ParamTypes, "")
+		}
+	}
+	l.parsed.Elements = append(l.parsed.Elements, element)
+	return element.Name
+}
+
+// applyEvents opens a block for every +1 and closes the innermost body for every -1
+func (p *LuaParser) applyEvents(l *luaFile, events []int) {
+	for _, event := range events {
+		if event > 0 {
+			l.scopes = append(l.scopes, luaScope{kind: "block"})
+		} else if len(l.scopes) > 0 {
+			l.scopes = l.scopes[:len(l.scopes)-1]
+		}
+	}
+}
+
+// blockEvents lists, in order, the bodies a statement opens (+1) with function, if, do, or
+// repeat and the ends that close them (-1). Loops open their body with "do", and
+// "elseif ... then" continues the body "if" opened.
+func (p *LuaParser) blockEvents(stmt string) []int {
+	var events []int
+	for _, loc := range p.wordPattern.FindAllStringIndex(stmt, -1) {
+		if loc[0] > 0 && (stmt[loc[0]-1] == '.' || stmt[loc[0]-1] == ':') {
+			continue
+		}
+		switch stmt[loc[0]:loc[1]] {
+		case "function", "if", "do", "repeat":
+			events = append(events, 1)
+		case "end", "until":
+			events = append(events, -1)
+		}
+	}
+	return events
+}
+
+// parseUsage finds calls and references to required modules in a statement with string
+// contents removed
+func (p *LuaParser) parseUsage(l *luaFile, stmt string, lineNum int) {
+	context := l.context()
+	consumed := make(map[int]bool) // Offsets of the names consumed by calls
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		if match[0] > 0 && (isLuaWordChar(stmt[match[0]-1]) || strings.ContainsRune(".:", rune(stmt[match[0]-1]))) {
+			continue
+		}
+		consumed[match[2]] = true
+		path := stmt[match[2]:match[3]]
+		head, _, dotted := strings.Cut(path, ".")
+		if isLuaKeyword(head) || isLuaLibrary(head) {
+			continue
+		}
+
+		switch {
+		case match[4] != -1:
+			// Method calls: self:save(), player:update(dt), Orders:fetch(id)
+			method := stmt[match[4]:match[5]]
+			switch {
+			case path == "self":
+				l.addUsage("method_call", method, "self", context, lineNum)
+			case l.isModule(head):
+				l.addUsage("method_call", l.qualify(path)+`\`+method, path, context, lineNum)
+			default:
+				l.addUsage("method_call", method, path, context, lineNum)
+			}
+		case dotted:
+			// Module functions: Orders.list(), self.on_hit(), love.graphics.print()
+			idx := strings.LastIndex(path, ".")
+			if head == "self" && idx == len("self") {
+				l.addUsage("method_call", path[idx+1:], "self", context, lineNum)
+			} else if head != "self" {
+				l.addUsage("function_call", l.qualify(path[:idx])+`\`+path[idx+1:], "", context, lineNum)
+			}
+		case l.aliases[path] != "":
+			l.addUsage("function_call", l.aliases[path], "", context, lineNum)
+		case !isLuaBuiltin(path) && !strings.HasSuffix(strings.TrimSpace(stmt[:match[2]]), "function"):
+			l.addUsage("function_call", path, "", context, lineNum)
+		}
+	}
+
+	// Required modules passed around as values: setmetatable(Enemy, {__index = Entity})
+	for _, loc := range p.wordPattern.FindAllStringIndex(stmt, -1) {
+		name, after := stmt[loc[0]:loc[1]], strings.TrimLeft(stmt[loc[1]:], " \t")
+		if consumed[loc[0]] || l.aliases[name] == "" ||
+			(loc[0] > 0 && (isLuaWordChar(stmt[loc[0]-1]) || strings.ContainsRune(".:", rune(stmt[loc[0]-1])))) ||
+			(strings.HasPrefix(after, "=") && !strings.HasPrefix(after, "==")) ||
+			strings.HasPrefix(after, ".") || strings.HasPrefix(after, ":") {
+			continue
+		}
+		l.addUsage("type_reference", l.aliases[name], "", context, lineNum)
+	}
+}
+
+// declareModule records a module element named by its qualified name, returning its
+// short name
+func (l *luaFile) declareModule(path string, lineNum int) string {
+	namespace, short := "", path
+	if idx := strings.LastIndex(path, `\`); idx != -1 {
+		namespace, short = path[:idx], path[idx+1:]
+	}
+	l.parsed.Elements = append(l.parsed.Elements, models.CodeElement{
+		Type:       "module",
+		Name:       short,
+		Namespace:  namespace,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       l.parsed.Path,
+	})
+	return short
+}
+
+// table returns the qualified name of the table a function is defined on, declaring a
+// local table of the file as a module of its own the first time
+func (l *luaFile) table(path string, lineNum int) string {
+	qualified := l.qualify(path)
+	head, _, _ := strings.Cut(path, ".")
+	if l.locals[head] && l.aliases[head] == "" && head != l.returned && !l.declared[qualified] {
+		if line, ok := l.tables[head]; ok && head == path {
+			lineNum = line
+		}
+		l.declareModule(qualified, lineNum)
+		l.declared[qualified] = true
+	}
+	return qualified
+}
+
+// qualify resolves a table reference against the file's requires and locals, returning
+// it with the analyzer's separator: Orders.Item is `src\shop\orders\Item` after
+// "local Orders = require('shop.orders')". Global tables keep their name.
+func (l *luaFile) qualify(path string) string {
+	head, rest, _ := strings.Cut(path, ".")
+	switch {
+	case head == l.returned:
+		head = l.id
+	case l.aliases[head] != "":
+		head = l.aliases[head]
+	case l.locals[head]:
+		head = l.id + `\` + head
+	}
+	if rest == "" {
+		return head
+	}
+	return head + `\` + strings.ReplaceAll(rest, ".", `\`)
+}
+
+// isModule reports whether a variable names a module: one the file requires or returns,
+// or one of its local tables
+func (l *luaFile) isModule(name string) bool {
+	_, table := l.tables[name]
+	return name == l.returned || l.aliases[name] != "" || table || l.declared[l.id+`\`+name]
+}
+
+// addUsage records a reference from context
+func (l *luaFile) addUsage(usageType, name, receiver, context string, lineNum int) {
+	l.parsed.Usage = append(l.parsed.Usage, models.UsageElement{
+		Type:     usageType,
+		Name:     name,
+		Context:  context,
+		Receiver: receiver,
+		Line:     lineNum,
+	})
+}
+
+// function returns the name of the innermost named function, or ""
+func (l *luaFile) function() string {
+	for i := len(l.scopes) - 1; i >= 0; i-- {
+		if l.scopes[i].kind == "function" {
+			return l.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the name usage is attributed to: the enclosing named function, or the
+// file's module for top-level code. Anonymous functions belong to where they're written.
+func (l *luaFile) context() string {
+	if function := l.function(); function != "" {
+		return function
+	}
+	return l.name
+}
+
+// luaModuleID returns the qualified name of the module a file is, from its path without
+// the extension: /src/shop/orders.lua is `src\shop\orders`, and a directory's init.lua
+// is the directory's module
+func luaModuleID(path string) string {
+	path = strings.TrimSuffix(filepath.ToSlash(path), filepath.Ext(path))
+	if strings.HasSuffix(path, "/init") {
+		path = strings.TrimSuffix(path, "/init")
+	}
+	return strings.ReplaceAll(strings.Trim(path, "/"), "/", `\`)
+}
+
+// resolveLuaModule finds the file a require names, as package.path's "?.lua" and
+// "?/init.lua" would from the requiring file's directory or one above it, returning its
+// module's qualified name. A module outside the project, such as a rock, keeps its name.
+func resolveLuaModule(from, module string) string {
+	segments := strings.FieldsFunc(module, func(r rune) bool { return r == '.' || r == '/' })
+	if len(segments) == 0 {
+		return module
+	}
+	dir := filepath.Dir(from)
+	for {
+		path := filepath.Join(append([]string{dir}, segments...)...)
+		for _, candidate := range []string{path + ".lua", filepath.Join(path, "init.lua")} {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return luaModuleID(candidate)
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return strings.Join(segments, `\`)
+		}
+		dir = parent
+	}
+}
+
+// luaSignature splits a function's parameters, from its opening parenthesis, from the
+// rest of the statement
+func luaSignature(rest string) ([]string, string) {
+	if end := closingParen(rest); end != -1 {
+		return strings.Split(rest[1:end], ","), rest[end+1:]
+	}
+	return strings.Split(rest[1:], ","), ""
+}
+
+// continuesLuaStatement reports whether a line with string contents removed continues on
+// the next: a bracket is left open, or it ends with a comma or a binary operator
+func continuesLuaStatement(bare string) bool {
+	bare = strings.TrimSpace(bare)
+	if bracketBalance(bare) > 0 {
+		return true
+	}
+	for _, suffix := range []string{",", "..", "=", " and", " or"} {
+		if strings.HasSuffix(bare, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripLuaLine removes comments from a line, returning the code and the code with string
+// contents blanked out. A long string or comment still open at the end of the line is
+// left in lex for the next one.
+func stripLuaLine(line string, lex *luaLexer) (string, string) {
+	var code, bare strings.Builder
+	i := 0
+	if lex.closing != "" {
+		end := strings.Index(line, lex.closing)
+		if end == -1 {
+			if lex.comment {
+				return "", ""
+			}
+			return line, ""
+		}
+		end += len(lex.closing)
+		if !lex.comment {
+			code.WriteString(line[:end])
+		}
+		lex.closing, lex.comment = "", false
+		i = end
+	}
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case strings.HasPrefix(line[i:], "--"):
+			// Comments: -- a line, --[[ a block ]], --[==[ a block with ]] in it ]==]
+			level, ok := luaLongBracket(line, i+2)
+			if !ok {
+				return code.String(), bare.String()
+			}
+			closing := "]" + strings.Repeat("=", level) + "]"
+			from := i + 2 + level + 2
+			end := strings.Index(line[from:], closing)
+			if end == -1 {
+				lex.closing, lex.comment = closing, true
+				return code.String(), bare.String()
+			}
+			i = from + end + len(closing)
+		case c == '[':
+			level, ok := luaLongBracket(line, i)
+			if !ok {
+				code.WriteByte(c)
+				bare.WriteByte(c)
+				i++
+				continue
+			}
+			closing := "]" + strings.Repeat("=", level) + "]"
+			from := i + level + 2
+			bare.WriteString(`""`)
+			end := strings.Index(line[from:], closing)
+			if end == -1 {
+				code.WriteString(line[i:])
+				lex.closing = closing
+				return code.String(), bare.String()
+			}
+			code.WriteString(line[i : from+end+len(closing)])
+			i = from + end + len(closing)
+		case c == '"' || c == '\'':
+			end := len(line)
+			for j := i + 1; j < len(line); j++ {
+				if line[j] == '\\' {
+					j++
+				} else if line[j] == c {
+					end = j + 1
+					break
+				}
+			}
+			code.WriteString(line[i:end])
+			bare.WriteString(`""`)
+			i = end
+		default:
+			code.WriteByte(c)
+			bare.WriteByte(c)
+			i++
+		}
+	}
+	return code.String(), bare.String()
+}
+
+// luaLongBracket reports whether a long bracket ([[, [=[, ...) opens at i, and its level:
+// the number of "=" signs
+func luaLongBracket(line string, i int) (int, bool) {
+	if i >= len(line) || line[i] != '[' {
+		return 0, false
+	}
+	level := 0
+	for i+1+level < len(line) && line[i+1+level] == '=' {
+		level++
+	}
+	return level, i+1+level < len(line) && line[i+1+level] == '['
+}
+
+func isLuaWordChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isLuaKeyword checks if a name is a Lua keyword, which can precede a bracket or string
+func isLuaKeyword(name string) bool {
+	switch name {
+	case "and", "break", "do", "else", "elseif", "end", "false", "for", "function", "goto", "if",
+		"in", "local", "nil", "not", "or", "repeat", "return", "then", "true", "until", "while":
+		return true
+	}
+	return false
+}
+
+// isLuaBuiltin checks if a name is one of Lua's global functions
+func isLuaBuiltin(name string) bool {
+	switch name {
+	case "assert", "collectgarbage", "dofile", "error", "getmetatable", "ipairs", "load",
+		"loadfile", "loadstring", "next", "pairs", "pcall", "print", "rawequal", "rawget", "rawlen",
+		"rawset", "require", "select", "setmetatable", "tonumber", "tostring", "type", "unpack",
+		"xpcall", "module", "setfenv", "getfenv":
+		return true
+	}
+	return false
+}
+
+// isLuaLibrary checks if a name is one of Lua's standard library tables, or LuaJIT's
+func isLuaLibrary(name string) bool {
+	switch name {
+	case "string", "table", "math", "os", "io", "coroutine", "debug", "utf8", "package", "bit32",
+		"bit", "jit", "ffi":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Lua files concurrently
+func (p *LuaParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *LuaParser) Language() string {
+	return "lua"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *LuaParser) FileExtensions() []string {
+	return []string{".lua"}
+}
+
+// Sniff recognizes extensionless Lua scripts by a lua or luajit shebang
+// ("#!/usr/bin/env lua5.4")
+func (p *LuaParser) Sniff(header []byte) bool {
+	if !bytes.HasPrefix(header, []byte("#!")) {
+		return false
+	}
+	shebang, _, _ := bytes.Cut(header, []byte("\n"))
+	for _, field := range bytes.Fields(shebang[2:]) {
+		if name := filepath.Base(string(field)); strings.HasPrefix(name, "lua") {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultExcludes returns the directories skipped in Lua projects: LuaRocks' local trees
+func (p *LuaParser) DefaultExcludes() []string {
+	return []string{"lua_modules", ".luarocks", ".rocks"}
+}
+
+// Entrypoints returns the functions game engines and Lua itself call: LÖVE's and Defold's
+// callbacks, metamethods, and the modules of main.lua and conf.lua
+func (p *LuaParser) Entrypoints() []string {
+	return []string{
+		`^(main|conf)$`,
+		`^(load|update|draw|keypressed|keyreleased|mousepressed|mousereleased|mousemoved|wheelmoved|textinput|resize|focus|quit|errorhandler)$`,
+		`^(init|final|on_message|on_input|on_reload|fixed_update|late_update)$`,
+		`^__(index|newindex|call|tostring|eq|lt|le|add|sub|mul|div|mod|pow|unm|concat|len|gc|close|pairs|name)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewLuaParser())
+}
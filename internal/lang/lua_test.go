@@ -0,0 +1,223 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestLuaParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `--- Inventory of a player.
+-- @module inventory
+local json = require("dkjson")
+local log = require('log').info
+
+local M = {}
+local Slot = setmetatable({}, {__index = json})
+
+local function weight(item)
+  return item.mass * item.count -- TODO: stackable items
+end
+
+--[[ Adds an item,
+  merging stacks ]]
+function M.add(inventory, item, ...)
+  local s = [[
+    end function (
+  ]]
+  log "adding"
+  table.insert(inventory.items, item)
+  M.sort(inventory)
+  return weight(item) + json.encode(item):len()
+end
+
+M.sort = function(inventory)
+  table.sort(inventory.items, function(a, b)
+    return weight(a) < weight(b)
+  end)
+end
+
+function Slot:fill(item)
+  if self:empty() then self.item = item end
+  self.refresh()
+  return Slot:new(item)
+end
+
+function Slot:empty() return self.item == nil end
+
+function Slot.new(item)
+  return setmetatable({item = item}, Slot)
+end
+
+function render(player)
+  for _, item in ipairs(player.items) do
+    player:draw(item)
+  end
+  repeat
+    Hud.flash("full")
+  until true
+end
+
+return M
+`
+	path := writeFixture(t, tmp, "inventory.lua", code)
+	id := luaModuleID(path)
+
+	parsed, err := NewLuaParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "lua" || parsed.Namespace != id || !strings.HasSuffix(id, `\inventory`) {
+		t.Errorf("expected the file's module as the namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	if len(parsed.Uses) != 2 || parsed.Uses[0] != "dkjson" || parsed.Uses[1] != "log" {
+		t.Errorf("expected the required modules, got %v", parsed.Uses)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Namespace+"."+el.Name] = el
+	}
+	for _, key := range []string{`module:` + id[:strings.LastIndex(id, `\`)] + `.inventory`, `function:` + id + `.weight`,
+		`function:` + id + `.add`, `function:` + id + `.sort`, `module:` + id + `.Slot`,
+		`method:` + id + `\Slot.fill`, `method:` + id + `\Slot.empty`, `function:` + id + `\Slot.new`, `function:.render`} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 9 {
+		t.Errorf("expected 9 elements, got %+v", parsed.Elements)
+	}
+
+	if module := parsed.Elements[0]; !module.Documented || module.Line != 1 {
+		t.Errorf("expected the file's module documented by its first comment, got %+v", module)
+	}
+	add := elements[`function:`+id+`.add`]
+	if !add.Documented || len(add.Parameters) != 3 || add.Parameters[2] != "..." {
+		t.Errorf("expected a documented function taking [inventory item ...], got %+v", add)
+	}
+	if weight := elements[`function:`+id+`.weight`]; weight.Visibility != "private" || weight.Documented {
+		t.Errorf("expected a private, undocumented local function, got %+v", weight)
+	}
+	if slot := elements[`module:`+id+`.Slot`]; slot.Line != 7 {
+		t.Errorf("expected the local table declared where it's assigned, got %+v", slot)
+	}
+	if fill := elements[`method:`+id+`\Slot.fill`]; fill.ClassName != "Slot" || len(fill.Parameters) != 1 {
+		t.Errorf("expected a method of Slot taking [item], got %+v", fill)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		`type_reference:dkjson in inventory`,
+		`type_reference:log in inventory`,
+		`function_call:log\info in add`,
+		`function_call:` + id + `\sort in add`,
+		`function_call:weight in add`,
+		`function_call:dkjson\encode in add`,
+		`function_call:weight in sort`,
+		`method_call:empty in fill`,
+		`method_call:refresh in fill`,
+		`method_call:` + id + `\Slot\new in fill`,
+		`method_call:draw in render`,
+		`function_call:Hud\flash in render`,
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, u := range parsed.Usage {
+		switch u.Name {
+		case `table\insert`, `table\sort`, "ipairs", "setmetatable", "if", "function", "len", "require":
+			t.Errorf("unexpected usage %+v", u)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 10 {
+		t.Errorf("expected the TODO on line 10, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 4 {
+		t.Errorf("expected 4 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestLuaParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"shop", "entities/player"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "main.lua", `local Orders = require("shop.orders")
+local Player = require "entities.player"
+
+function love.load()
+  Orders.list(Player.new())
+end
+`)
+	writeFixture(t, tmp, "shop/orders.lua", `local M = {}
+
+local function total(order) return order.price end
+
+function M.list(player)
+  return total(player)
+end
+
+function M.unused() end
+
+return M
+`)
+	writeFixture(t, tmp, "entities/player/init.lua", `local Player = {}
+
+function Player.new() return setmetatable({}, Player) end
+
+return Player
+`)
+
+	p := NewLuaParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"main.lua", "shop/orders.lua", "entities/player/init.lua"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Type+":"+node.Name] = node
+	}
+	if nodes["module:main"].Dependencies[nodes["module:orders"].ID] == nil ||
+		nodes["module:main"].Dependencies[nodes["module:player"].ID] == nil {
+		t.Errorf("expected the main module to require both modules, got %+v", nodes["module:main"].Dependencies)
+	}
+	if nodes["function:load"].Dependencies[nodes["function:list"].ID] == nil ||
+		nodes["function:load"].Dependencies[nodes["function:new"].ID] == nil {
+		t.Errorf("expected the callback to call through the required modules, got %+v", nodes["function:load"].Dependencies)
+	}
+	if nodes["function:list"].Dependencies[nodes["function:total"].ID] == nil {
+		t.Errorf("expected the local function call to resolve, got %+v", nodes["function:list"].Dependencies)
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Name] = true
+	}
+	if !orphans["unused"] || len(orphans) != 1 {
+		t.Errorf("expected only the unused function among the orphans, got %v", orphans)
+	}
+}
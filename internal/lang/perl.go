@@ -0,0 +1,930 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// PerlParser handles parsing of Perl files
+type PerlParser struct {
+	packagePattern *regexp.Regexp
+	subPattern     *regexp.Regexp
+	usePattern     *regexp.Regexp
+	parentPattern  *regexp.Regexp
+	isaPattern     *regexp.Regexp
+	moosePattern   *regexp.Regexp
+	argsPattern    *regexp.Regexp
+	shiftPattern   *regexp.Regexp
+	arrowPattern   *regexp.Regexp
+	namePattern    *regexp.Regexp
+	heredocPattern *regexp.Regexp
+}
+
+// perlScope is a body closed by "}"
+type perlScope struct {
+	kind string // "sub" for a named sub, "package" for package NAME { ... }, or "block"
+	name string
+	pkg  string // Package set inside the body by a package statement, as `Shop\Orders`
+}
+
+// perlLexer carries a string, quote-like operator, heredoc, or POD block from one line
+// to the next
+type perlLexer struct {
+	closing  byte // Delimiter closing the string open at the end of the line
+	opening  byte // Its opening bracket, when the delimiters nest: q{ {} }
+	depth    int
+	parts    int    // Delimited parts left after this one: s{...}{...} has one
+	heredoc  string // Terminator of the heredoc starting on the next line
+	indented bool   // The heredoc was opened with <<~ and its terminator may be indented
+}
+
+// perlFile is the state of one file's parse
+type perlFile struct {
+	parsed   *models.ParsedFile
+	script   string // Name of the module a script's package main code belongs to, or ""
+	scriptID string // Its qualified name, the namespace of the script's subs
+	pkg      string // Package set at the top level of the file
+	scopes   []perlScope
+	pending  string            // Sub declared on a line whose body opens on a later one
+	args     int               // Index of the sub whose "my (...) = @_" is being read, or -1
+	depth    int               // Scope depth of that sub's body
+	declared map[string]bool   // Packages declared in the file
+	imports  map[string]string // Functions imported by name, to their qualified names
+}
+
+// NewPerlParser creates a new Perl parser with compiled regex patterns
+func NewPerlParser() *PerlParser {
+	name := `[A-Za-z_]\w*(?:::\w+)*`
+	return &PerlParser{
+		// Packages: package Shop::Orders;, package Shop::Orders 1.02 { ... }
+		packagePattern: regexp.MustCompile(`^package\s+((?:::)?` + name + `)(?:\s+v?[\d._]+)?\s*(\{)?`),
+
+		// Named subs, with an optional signature or prototype: sub total { ... },
+		// sub add ($self, $item) { ... }, sub max($$) { ... }
+		subPattern: regexp.MustCompile(`^(?:(?:my|our|state)\s+)?sub\s+([A-Za-z_]\w*)\s*(\([^)]*\))?`),
+
+		// Modules loaded at compile or run time: use Shop::Orders qw(total);, require Carp;
+		usePattern: regexp.MustCompile(`^(use|require)\s+(` + name + `)(.*)`),
+
+		// Inheritance through pragmas: use parent -norequire, 'Shop::Base';, use base qw(Exporter)
+		parentPattern: regexp.MustCompile(`^use\s+(?:parent|base)\b(.*)`),
+
+		// Inheritance through @ISA: our @ISA = ('Shop::Base');, push @ISA, 'Exporter'
+		isaPattern: regexp.MustCompile(`(?:@ISA\s*=|\bpush\s*\(?\s*@ISA\s*,)(.*)`),
+
+		// Moose and Moo classes and roles: extends 'Shop::Base';, with 'Shop::Role::Priced';
+		moosePattern: regexp.MustCompile(`^(extends|with)\s*\(?\s*(?:['"]|qw\b)(.*)`),
+
+		// Arguments unpacked from @_: my ($self, %args) = @_;
+		argsPattern: regexp.MustCompile(`^my\s*\(([^)]*)\)\s*=\s*@_\s*$`),
+
+		// Arguments shifted off @_: my $self = shift;, my $order = shift @_;
+		shiftPattern: regexp.MustCompile(`^my\s+([$@%]\w+)\s*=\s*shift\b(?:\s*\(?\s*@_\s*\)?)?\s*$`),
+
+		// Method calls: $self->total, Shop::Order->new(...), __PACKAGE__->config, $x->{a}->save()
+		arrowPattern: regexp.MustCompile(`(\$[A-Za-z_]\w*|__PACKAGE__|` + name + `)?\s*->\s*(` + name + `)`),
+
+		// Names, which are calls when followed by a parenthesis or preceded by &:
+		// total(...), Shop::Orders::total(...), \&handler
+		namePattern: regexp.MustCompile(`(?:::)?` + name),
+
+		// Heredocs: <<"SQL", <<'EOT', <<~EOT, <<EOT
+		heredocPattern: regexp.MustCompile(`^<<(~?)(?:"(\w+)"|'(\w+)'|([A-Za-z_]\w*))`),
+	}
+}
+
+// ParseFile analyzes a single Perl file and extracts all elements
+func (p *PerlParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	f := &perlFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		args:     -1,
+		declared: make(map[string]bool),
+		imports:  make(map[string]string),
+	}
+	parsed := f.parsed
+
+	// A script's package main code is a module named after the file; .pm files are only
+	// the packages they declare
+	if filepath.Ext(filePath) != ".pm" {
+		namespace, name := perlScriptName(filePath)
+		f.script, f.scriptID = name, f.declarePackage(namespace, name, 1, false)
+		parsed.Namespace = f.scriptID
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	commentEnd := -1 // Last line of the comment block above the current line
+	seenCode := false
+	inPod := false // Between =head1 (or any POD command) and =cut
+	podEnd := -1   // Line of the last =cut
+	ended := false // After __END__ or __DATA__
+	var heredoc []string
+	heredocLine := 0
+	var lex perlLexer
+
+	for scanner.Scan() {
+		lineNum++
+		raw := scanner.Text()
+		trimmed := strings.TrimSpace(raw)
+
+		// POD is documentation wherever it appears, even after __END__
+		if inPod || (lex.closing == 0 && lex.heredoc == "" && heredoc == nil && isPodCommand(raw)) {
+			inPod = !strings.HasPrefix(raw, "=cut")
+			parsed.CommentLines++
+			commentEnd = lineNum
+			if !inPod {
+				podEnd = lineNum
+			}
+			if !seenCode && f.script != "" {
+				parsed.Elements[0].Documented = true
+			}
+			continue
+		}
+		if ended {
+			continue
+		}
+		if heredoc != nil {
+			terminator := raw
+			if lex.indented {
+				terminator = trimmed
+			}
+			if terminator != lex.heredoc {
+				heredoc = append(heredoc, raw)
+				continue
+			}
+			text := `"` + strings.ReplaceAll(strings.Join(heredoc, "\n"), `"`, "'") + `"`
+			parsed.Tables = append(parsed.Tables, sqlTables(text, heredocLine, f.packageName(), f.sub())...)
+			heredoc, lex.heredoc = nil, ""
+			continue
+		}
+		if lineNum == 1 && strings.HasPrefix(raw, "#!") {
+			continue // The shebang
+		}
+		if trimmed == "__END__" || trimmed == "__DATA__" {
+			ended = true
+			continue
+		}
+		if marker, ok := debtMarker(raw, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		inString := lex.closing != 0
+		code, bare := stripPerlLine(raw, &lex, p.heredocPattern)
+		if lex.heredoc != "" {
+			heredoc, heredocLine = []string{}, lineNum
+		}
+		if strings.TrimSpace(bare) == "" {
+			if !inString && strings.HasPrefix(trimmed, "#") {
+				parsed.CommentLines++
+				commentEnd = lineNum
+				if !seenCode && f.script != "" {
+					parsed.Elements[0].Documented = true
+				}
+			} else if trimmed == "" && commentEnd == lineNum-1 && podEnd == commentEnd {
+				// POD, which is rendered apart from the code, sits a blank line above what
+				// it documents
+				commentEnd, podEnd = lineNum, lineNum
+			}
+			if trimmed != "" {
+				seenCode = true
+			}
+			continue
+		}
+		seenCode = true
+		documented := commentEnd == lineNum-1
+
+		// Statements end with ";"; bare keeps the offsets of code, so each statement's code
+		// (with its strings, which name parents and imports) can be sliced alongside it
+		start := 0
+		for i := 0; i <= len(bare); i++ {
+			if i < len(bare) && bare[i] != ';' {
+				continue
+			}
+			if stmt := strings.TrimSpace(bare[start:i]); stmt != "" {
+				p.parseStatement(f, stmt, strings.TrimSpace(code[start:i]), i < len(bare), lineNum, documented)
+			}
+			start = i + 1
+		}
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, f.packageName(), f.sub())...)
+	}
+
+	parsed.Lines = lineNum
+	return parsed, scanner.Err()
+}
+
+// parseStatement handles one statement: a package or sub declaration, a use, or code.
+// terminated is set when the statement ended with ";".
+func (p *PerlParser) parseStatement(f *perlFile, stmt, code string, terminated bool, lineNum int, documented bool) {
+	events := braceEvents(stmt)
+	opens := len(events) > 0 && events[0] > 0
+
+	if match := p.packagePattern.FindStringSubmatch(stmt); match != nil {
+		path := perlName(match[1])
+		if path == "main" {
+			path = "" // A script's own package
+		}
+		if path != "" && !f.declared[path] {
+			namespace, short := "", path
+			if idx := strings.LastIndex(path, `\`); idx != -1 {
+				namespace, short = path[:idx], path[idx+1:]
+			}
+			f.declarePackage(namespace, short, lineNum, documented)
+			f.declared[path] = true
+			if f.parsed.Namespace == "" {
+				f.parsed.Namespace = path
+			}
+		}
+		switch {
+		case match[2] != "" && opens:
+			f.scopes = append(f.scopes, perlScope{kind: "package", pkg: path})
+			events = events[1:]
+		case len(f.scopes) > 0:
+			f.scopes[len(f.scopes)-1].pkg = path // Until the end of the enclosing block
+		default:
+			f.pkg = path
+		}
+		p.applyEvents(f, events)
+		return
+	}
+
+	if match := p.subPattern.FindStringSubmatchIndex(stmt); match != nil {
+		// Braces in a signature's defaults ($opts = {}) don't open the body
+		events = braceEvents(stmt[match[1]:])
+		opens = len(events) > 0 && events[0] > 0
+		if terminated && !opens {
+			return // A forward declaration: sub total;
+		}
+		name := stmt[match[2]:match[3]]
+		signature := ""
+		if match[4] != -1 {
+			signature = stmt[match[4]+1 : match[5]-1]
+		}
+		index := p.addSub(f, name, signature, lineNum, documented)
+		if !opens {
+			f.pending = name
+			return
+		}
+		p.openSub(f, name, index, stmt[match[1]:])
+		p.parseUsage(f, stmt[match[1]:], lineNum)
+		p.applyEvents(f, events[1:])
+		return
+	}
+
+	if f.pending != "" {
+		name := f.pending
+		f.pending = ""
+		if opens && strings.HasPrefix(stmt, "{") {
+			p.openSub(f, name, len(f.parsed.Elements)-1, stmt)
+			p.parseUsage(f, stmt[1:], lineNum)
+			p.applyEvents(f, events[1:])
+			return
+		}
+	}
+
+	if f.args >= 0 {
+		p.parseArgs(f, stmt)
+	}
+	p.parseUse(f, code, lineNum)
+	p.parseUsage(f, stmt, lineNum)
+	p.applyEvents(f, events)
+}
+
+// addSub records a named sub, returning its index among the file's elements. Subs of a
+// package are its methods, whether they're called as methods or functions; those of a
+// script's package main are its functions.
+func (p *PerlParser) addSub(f *perlFile, name, signature string, lineNum int, documented bool) int {
+	element := models.CodeElement{
+		Type:       "function",
+		Name:       name,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       f.parsed.Path,
+		Documented: documented,
+		Parameters: []string{},
+	}
+	if strings.HasPrefix(name, "_") {
+		element.Visibility = "private" // By convention
+	}
+	switch pkg := f.packagePath(); {
+	case pkg != "":
+		element.Type = "method"
+		element.Namespace = pkg
+		element.ClassName = pkg[strings.LastIndex(pkg, `\`)+1:]
+	default:
+		element.Namespace = f.scriptID
+	}
+	f.parsed.Elements = append(f.parsed.Elements, element)
+	index := len(f.parsed.Elements) - 1
+
+	// Signatures name their parameters; prototypes like ($$;@) don't
+	if strings.ContainsAny(signature, "$@%") && strings.IndexFunc(signature, isPerlWordRune) != -1 {
+		f.setParams(index, strings.Split(signature, ","))
+	}
+	return index
+}
+
+// openSub opens the body of a named sub at the first brace of stmt. The statements that
+// follow it may unpack the sub's arguments.
+func (p *PerlParser) openSub(f *perlFile, name string, index int, stmt string) {
+	f.scopes = append(f.scopes, perlScope{kind: "sub", name: name})
+	f.args, f.depth = -1, len(f.scopes)
+	if len(f.parsed.Elements[index].Parameters) == 0 {
+		f.args = index
+		if body := strings.TrimSpace(stmt[strings.Index(stmt, "{")+1:]); body != "" {
+			p.parseArgs(f, body)
+		}
+	}
+}
+
+// parseArgs reads the parameters of a sub without a signature from the statements that
+// open its body: my ($self, %args) = @_;, or one my $x = shift; per parameter
+func (p *PerlParser) parseArgs(f *perlFile, stmt string) {
+	if len(f.scopes) != f.depth {
+		f.args = -1
+		return
+	}
+	if match := p.argsPattern.FindStringSubmatch(stmt); match != nil {
+		f.setParams(f.args, strings.Split(match[1], ","))
+		f.args = -1
+		return
+	}
+	if match := p.shiftPattern.FindStringSubmatch(stmt); match != nil {
+		element := &f.parsed.Elements[f.args]
+		if len(element.Parameters) > 0 || !perlInvocant(match[1]) {
+			element.Parameters = append(element.Parameters, strings.TrimLeft(match[1], "$@%"))
+			element.ParamTypes = append(element.ParamTypes, "")
+		}
+		return
+	}
+	f.args = -1
+}
+
+// setParams records a sub's parameters without their sigils or defaults, leaving out a
+// method's invocant ($self or $class)
+func (f *perlFile) setParams(index int, params []string) {
+	element := &f.parsed.Elements[index]
+	element.Parameters, element.ParamTypes = []string{}, nil
+	for i, param := range params {
+		param = strings.TrimSpace(param)
+		if eq := strings.IndexAny(param, "=/"); eq != -1 {
+			param = strings.TrimSpace(param[:eq]) // Defaults: $limit = 10, $name //= ''
+		}
+		if i == 0 && perlInvocant(param) {
+			continue
+		}
+		if param = strings.TrimLeft(param, `$@%\`); param != "" {
+			element.Parameters = append(element.Parameters, param)
+			element.ParamTypes = append(element.ParamTypes, "")
+		}
+	}
+}
+
+// parseUse records the modules a statement loads, the functions it imports by name, and
+// the parents a package inherits from
+func (p *PerlParser) parseUse(f *perlFile, code string, lineNum int) {
+	context := f.context()
+
+	if match := p.parentPattern.FindStringSubmatch(code); match != nil {
+		norequire := strings.Contains(match[1], "-norequire")
+		for _, parent := range perlNames(match[1]) {
+			if !norequire {
+				f.parsed.Uses = append(f.parsed.Uses, parent)
+			}
+			f.addUsage("extends", parent, "", context, lineNum)
+		}
+		return
+	}
+	if match := p.isaPattern.FindStringSubmatch(code); match != nil {
+		for _, parent := range perlNames(match[1]) {
+			f.addUsage("extends", parent, "", context, lineNum)
+		}
+		return
+	}
+	if match := p.moosePattern.FindStringSubmatch(code); match != nil {
+		usageType := "extends"
+		if match[1] == "with" {
+			usageType = "uses_trait" // Roles are composed into the class
+		}
+		for _, name := range perlNames(match[2]) {
+			f.addUsage(usageType, name, "", context, lineNum)
+		}
+		return
+	}
+
+	match := p.usePattern.FindStringSubmatch(code)
+	if match == nil || isPerlPragma(match[2]) {
+		return
+	}
+	module := perlName(match[2])
+	f.parsed.Uses = append(f.parsed.Uses, module)
+	f.addUsage("type_reference", module, "", context, lineNum)
+
+	// Functions imported by name: use List::Util qw(sum max);, use Shop::Orders 'total';
+	if match[1] == "use" {
+		for _, name := range perlImports(match[3]) {
+			f.imports[name] = module + `\` + name
+		}
+	}
+}
+
+// parseUsage finds the calls in a statement with string contents removed
+func (p *PerlParser) parseUsage(f *perlFile, stmt string, lineNum int) {
+	context := f.context()
+	consumed := make(map[int]bool) // Offsets of the names consumed by method calls
+
+	for _, match := range p.arrowPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		consumed[match[4]] = true
+		method := stmt[match[4]:match[5]]
+		if strings.Contains(method, "::") {
+			continue // $self->SUPER::new(...), $obj->Other::method()
+		}
+		receiver := ""
+		if match[2] != -1 {
+			if match[2] > 0 && isPerlWordByte(stmt[match[2]-1]) {
+				continue
+			}
+			receiver = stmt[match[2]:match[3]]
+			consumed[match[2]] = true
+		}
+
+		switch {
+		case receiver == "$self" || receiver == "$class" || receiver == "$this" || receiver == "shift":
+			f.addUsage("method_call", method, "self", context, lineNum)
+		case receiver == "__PACKAGE__":
+			f.addUsage("static_call", perlQualify(f.packagePath(), method), f.packagePath(), context, lineNum)
+		case receiver == "" || strings.HasPrefix(receiver, "$"):
+			f.addUsage("method_call", method, strings.TrimPrefix(receiver, "$"), context, lineNum)
+		case isPerlBuiltin(receiver):
+			f.addUsage("method_call", method, "", context, lineNum)
+		default:
+			// Class methods: Shop::Order->new(...), Shop::Orders->find($id)
+			class := perlName(receiver)
+			f.addUsage("static_call", class+`\`+method, class, context, lineNum)
+			if method == "new" {
+				f.addUsage("instantiation", class, "", context, lineNum)
+			}
+		}
+	}
+
+	for _, loc := range p.namePattern.FindAllStringIndex(stmt, -1) {
+		if consumed[loc[0]] || (loc[0] > 0 && strings.ContainsRune("$@%*:-'\"", rune(stmt[loc[0]-1]))) {
+			continue
+		}
+		name := stmt[loc[0]:loc[1]]
+		before := strings.TrimRight(stmt[:loc[0]], " \t")
+		ampersand := strings.HasSuffix(before, "&") && !strings.HasSuffix(before, "&&")
+		if !ampersand && !strings.HasPrefix(strings.TrimLeft(stmt[loc[1]:], " \t"), "(") {
+			continue
+		}
+		if strings.HasSuffix(before, "sub") && (len(before) == 3 || !isPerlWordByte(before[len(before)-4])) {
+			continue
+		}
+
+		switch {
+		case strings.Contains(name, "::"):
+			// Qualified calls: Shop::Orders::total($order), main::usage()
+			qualified := perlName(name)
+			if head, _, _ := strings.Cut(qualified, `\`); head == "CORE" || head == "SUPER" || head == "POSIX" {
+				continue
+			}
+			f.addUsage("function_call", qualified, "", context, lineNum)
+		case isPerlBuiltin(name):
+		case f.imports[name] != "":
+			f.addUsage("function_call", f.imports[name], "", context, lineNum)
+		default:
+			f.addUsage("function_call", name, "", context, lineNum)
+		}
+	}
+}
+
+// applyEvents opens a block for every +1 and closes the innermost body for every -1
+func (p *PerlParser) applyEvents(f *perlFile, events []int) {
+	for _, event := range events {
+		if event > 0 {
+			f.scopes = append(f.scopes, perlScope{kind: "block"})
+		} else if len(f.scopes) > 0 {
+			f.scopes = f.scopes[:len(f.scopes)-1]
+		}
+	}
+}
+
+// braceEvents lists, in order, the braces a statement with string contents removed opens
+// (+1) and closes (-1)
+func braceEvents(stmt string) []int {
+	var events []int
+	for i := 0; i < len(stmt); i++ {
+		switch stmt[i] {
+		case '{':
+			events = append(events, 1)
+		case '}':
+			events = append(events, -1)
+		}
+	}
+	return events
+}
+
+// declarePackage records a module element for a package or a script, returning its
+// qualified name
+func (f *perlFile) declarePackage(namespace, name string, lineNum int, documented bool) string {
+	f.parsed.Elements = append(f.parsed.Elements, models.CodeElement{
+		Type:       "module",
+		Name:       name,
+		Namespace:  namespace,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       f.parsed.Path,
+		Documented: documented,
+	})
+	return perlQualify(namespace, name)
+}
+
+// addUsage records a reference from context
+func (f *perlFile) addUsage(usageType, name, receiver, context string, lineNum int) {
+	if context == "" {
+		return // Package main code of a .pm file, which nothing can depend on
+	}
+	f.parsed.Usage = append(f.parsed.Usage, models.UsageElement{
+		Type:     usageType,
+		Name:     name,
+		Context:  context,
+		Receiver: receiver,
+		Line:     lineNum,
+		IsStatic: usageType == "static_call",
+	})
+}
+
+// packagePath returns the qualified name of the current package, or "" in package main
+func (f *perlFile) packagePath() string {
+	for i := len(f.scopes) - 1; i >= 0; i-- {
+		if f.scopes[i].pkg != "" {
+			return f.scopes[i].pkg
+		}
+	}
+	return f.pkg
+}
+
+// packageName returns the short name of the current package, or ""
+func (f *perlFile) packageName() string {
+	pkg := f.packagePath()
+	return pkg[strings.LastIndex(pkg, `\`)+1:]
+}
+
+// sub returns the name of the innermost named sub, or ""
+func (f *perlFile) sub() string {
+	for i := len(f.scopes) - 1; i >= 0; i-- {
+		if f.scopes[i].kind == "sub" {
+			return f.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the name usage is attributed to: the enclosing named sub, or the
+// package (or script) for code outside subs. Anonymous subs belong to where they're
+// written.
+func (f *perlFile) context() string {
+	if sub := f.sub(); sub != "" {
+		return sub
+	}
+	if pkg := f.packageName(); pkg != "" {
+		return pkg
+	}
+	return f.script
+}
+
+// perlScriptName returns the namespace and name of the module a script is, from its
+// path: /src/bin/deploy.pl is deploy in `src\bin`
+func perlScriptName(path string) (string, string) {
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if dir == "." {
+		dir = ""
+	}
+	return strings.ReplaceAll(strings.Trim(dir, "/"), "/", `\`), strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// perlName returns a package or sub name with the analyzer's separator: Shop::Orders is
+// `Shop\Orders`. Names in package main (::usage, main::usage) lose the package.
+func perlName(name string) string {
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "::"), "main::")
+	return strings.ReplaceAll(name, "::", `\`)
+}
+
+// perlQualify joins a package and a name in it
+func perlQualify(pkg, name string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + `\` + name
+}
+
+// perlNames returns the package names listed by a use parent, @ISA, or Moose statement,
+// quoted or in qw(): -norequire, 'Shop::Base', qw(Exporter)
+var perlNamePattern = regexp.MustCompile(`(?:^|[\s'"(,&])((?:::)?[A-Za-z_]\w*(?:::\w+)*)`)
+
+func perlNames(list string) []string {
+	var names []string
+	for _, match := range perlNamePattern.FindAllStringSubmatch(list, -1) {
+		if match[1] != "qw" && match[1] != "norequire" {
+			names = append(names, perlName(match[1]))
+		}
+	}
+	return names
+}
+
+// perlImports returns the functions a use statement imports by name, leaving out tags
+// (:all), variables ($VERSION), and version numbers
+func perlImports(list string) []string {
+	var names []string
+	for _, match := range perlNamePattern.FindAllStringSubmatch(list, -1) {
+		if match[1] != "qw" && !strings.Contains(match[1], "::") {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// perlInvocant checks if a parameter is the object or class a method is called on
+func perlInvocant(param string) bool {
+	return param == "$self" || param == "$class" || param == "$this"
+}
+
+// isPodCommand reports whether a line starts a POD block: =head1, =pod, =item, ...
+func isPodCommand(line string) bool {
+	return len(line) > 1 && line[0] == '=' && ((line[1] >= 'a' && line[1] <= 'z') || (line[1] >= 'A' && line[1] <= 'Z'))
+}
+
+// stripPerlLine removes the comment from a line, returning the code and the code with the
+// contents of strings, quote-like operators (q{}, qw//, s{}{}), and regexes blanked out.
+// The two have the same length, so offsets in one hold in the other. A string still open
+// at the end of the line is left in lex for the next one, as is a heredoc it starts.
+func stripPerlLine(line string, lex *perlLexer, heredocPattern *regexp.Regexp) (string, string) {
+	code := []byte(line)
+	bare := []byte(line)
+	i := 0
+	if lex.closing != 0 {
+		end, ok := lex.scan(line, 0)
+		blank(bare, 0, end)
+		if !ok {
+			return line, string(bare)
+		}
+		i = end
+	}
+	// Strings keep their quotes in bare; quote-like operators lose their delimiters, so
+	// q{...} doesn't read as a block
+	quote := func(from int, keep bool) {
+		end, ok := lex.scan(line, from+1)
+		switch {
+		case !keep:
+			blank(bare, from, end)
+		case ok:
+			blank(bare, from+1, end-1)
+		default:
+			blank(bare, from+1, end)
+		}
+		i = end
+	}
+
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case c == '#' && (i == 0 || (line[i-1] != '$' && line[i-1] != '\\')):
+			return string(code[:i]), string(bare[:i]) // $#items is an index, not a comment
+		case c == '"' || c == '\'' || c == '`':
+			lex.open(c, 0)
+			quote(i, true)
+		case c == '<' && strings.HasPrefix(line[i:], "<<") && heredocPattern.MatchString(line[i:]):
+			match := heredocPattern.FindStringSubmatch(line[i:])
+			lex.heredoc, lex.indented = match[2]+match[3]+match[4], match[1] != ""
+			i += len(match[0])
+		case isPerlWordByte(c):
+			j := i
+			for j < len(line) && isPerlWordByte(line[j]) {
+				j++
+			}
+			if parts, ok := perlQuoteOperator(line, i, j); ok {
+				k := j
+				for k < len(line) && (line[k] == ' ' || line[k] == '\t') {
+					k++
+				}
+				lex.open(line[k], parts)
+				quote(k, false)
+				continue
+			}
+			i = j
+		case c == '/' && perlRegexAllowed(string(bare[:i])):
+			lex.open(c, 0)
+			quote(i, true)
+		default:
+			i++
+		}
+	}
+	return string(code), string(bare)
+}
+
+// open starts a quote-like operator's string at its opening delimiter
+func (lex *perlLexer) open(delim byte, parts int) {
+	lex.opening, lex.closing, lex.depth, lex.parts = 0, delim, 0, parts
+	if closing, ok := perlBrackets[delim]; ok {
+		lex.opening, lex.closing = delim, closing
+	}
+}
+
+// scan reads a string from offset i to its closing delimiter, and through the parts
+// after it (the replacement of s///), returning the offset past its end and whether it
+// closed on this line
+func (lex *perlLexer) scan(line string, i int) (int, bool) {
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case c == '\\':
+			i += 2
+			continue
+		case lex.opening != 0 && c == lex.opening:
+			lex.depth++
+		case c == lex.closing && lex.depth > 0:
+			lex.depth--
+		case c == lex.closing && lex.parts > 0:
+			lex.parts--
+			if lex.opening != 0 {
+				// s{...}{...}: the replacement has delimiters of its own
+				i++
+				for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+					i++
+				}
+				if i < len(line) {
+					lex.open(line[i], lex.parts)
+				}
+			}
+		case c == lex.closing:
+			lex.closing, lex.opening = 0, 0
+			return i + 1, true
+		}
+		i++
+	}
+	if i > len(line) {
+		i = len(line)
+	}
+	return i, false
+}
+
+var perlBrackets = map[byte]byte{'{': '}', '(': ')', '[': ']', '<': '>'}
+
+// perlQuoteOperator reports whether the word at line[i:j] is a quote-like operator (q, qq,
+// qw, qr, m, s, tr, y) followed by its delimiter, and the number of parts after the first
+func perlQuoteOperator(line string, i, j int) (int, bool) {
+	parts := 0
+	switch line[i:j] {
+	case "q", "qq", "qw", "qr", "m":
+	case "s", "tr", "y":
+		parts = 1
+	default:
+		return 0, false
+	}
+	if i > 0 && (strings.ContainsRune("$@%&*{-", rune(line[i-1])) || strings.HasSuffix(line[:i], "->")) {
+		return 0, false // $s, @{m}, $x->y, -s $file
+	}
+	k := j
+	for k < len(line) && (line[k] == ' ' || line[k] == '\t') {
+		k++
+	}
+	if k == len(line) || isPerlWordByte(line[k]) || strings.ContainsRune(",;)}=", rune(line[k])) ||
+		(line[k] == '#' && k > j) {
+		return 0, false // s => 1, {y}, q, and a comment after a word
+	}
+	return parts, true
+}
+
+// perlRegexAllowed reports whether a "/" after some code starts a regex rather than
+// dividing: it follows an operator, an opening bracket, or a keyword taking one
+func perlRegexAllowed(before string) bool {
+	before = strings.TrimRight(before, " \t")
+	if before == "" || strings.ContainsRune("(,=~!{;|&?:", rune(before[len(before)-1])) {
+		return true
+	}
+	for _, word := range []string{"split", "grep", "if", "unless", "and", "or", "not", "return", "when"} {
+		if strings.HasSuffix(before, word) && (len(before) == len(word) || !isPerlWordByte(before[len(before)-len(word)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// blank replaces the bytes of b[from:to] with spaces
+func blank(b []byte, from, to int) {
+	for i := from; i < to && i < len(b); i++ {
+		b[i] = ' '
+	}
+}
+
+func isPerlWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isPerlWordRune(r rune) bool {
+	return r < 0x80 && isPerlWordByte(byte(r))
+}
+
+// isPerlPragma checks if a module loaded by use is a pragma (strict, warnings, lib, a
+// version like v5.36) rather than a module: pragmas are lowercase by convention
+func isPerlPragma(name string) bool {
+	return !strings.Contains(name, "::") && strings.ToLower(name) == name
+}
+
+// isPerlBuiltin checks if a name is a Perl keyword or built-in function, or one of Carp's,
+// which can precede a parenthesis
+func isPerlBuiltin(name string) bool {
+	switch name {
+	case "my", "our", "local", "state", "sub", "return", "if", "elsif", "else", "unless", "while",
+		"until", "for", "foreach", "do", "eval", "and", "or", "not", "xor", "last", "next", "redo",
+		"goto", "use", "no", "require", "package", "q", "qq", "qw", "qr", "m", "s", "tr", "y",
+		"print", "printf", "say", "die", "warn", "exit", "push", "pop", "shift", "unshift", "splice",
+		"map", "grep", "sort", "reverse", "keys", "values", "each", "exists", "delete", "defined",
+		"undef", "ref", "bless", "scalar", "wantarray", "join", "split", "sprintf", "lc", "uc",
+		"lcfirst", "ucfirst", "length", "substr", "index", "rindex", "chomp", "chop", "chr", "ord",
+		"abs", "int", "sqrt", "hex", "oct", "rand", "srand", "open", "close", "binmode", "eof",
+		"read", "seek", "tell", "unlink", "mkdir", "rmdir", "opendir", "readdir", "closedir",
+		"time", "localtime", "gmtime", "sleep", "system", "exec", "fork", "wait", "waitpid", "kill",
+		"caller", "pos", "quotemeta", "pack", "unpack", "chdir", "chmod", "chown", "rename", "stat",
+		"lstat", "glob", "select", "lock", "exp", "log", "sin", "cos", "atan2",
+		"croak", "confess", "carp", "cluck", "__PACKAGE__", "__FILE__", "__LINE__", "__SUB__":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Perl files concurrently
+func (p *PerlParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *PerlParser) Language() string {
+	return "perl"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *PerlParser) FileExtensions() []string {
+	return []string{".pl", ".pm"}
+}
+
+// Sniff recognizes extensionless Perl scripts by a perl shebang ("#!/usr/bin/env perl",
+// "#!/usr/bin/perl5.36 -w")
+func (p *PerlParser) Sniff(header []byte) bool {
+	if !bytes.HasPrefix(header, []byte("#!")) {
+		return false
+	}
+	shebang, _, _ := bytes.Cut(header, []byte("\n"))
+	for _, field := range bytes.Fields(shebang[2:]) {
+		if name := filepath.Base(string(field)); name == "perl" || strings.HasPrefix(name, "perl5") {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultExcludes returns the directories skipped in Perl projects: Carton's local
+// library, build output of ExtUtils::MakeMaker, Module::Build, and Dist::Zilla, and
+// vendored dependencies
+func (p *PerlParser) DefaultExcludes() []string {
+	return []string{"local", "blib", "_build", ".build", "extlib"}
+}
+
+// Entrypoints returns the subs Perl and its frameworks call: a script's main, import and
+// the other subs perl calls itself, Moose's object lifecycle, and mod_perl and
+// Mojolicious handlers
+func (p *PerlParser) Entrypoints() []string {
+	return []string{
+		`^main$`,
+		`^(import|unimport|AUTOLOAD|DESTROY|CLONE)$`,
+		`^(BUILD|BUILDARGS|DEMOLISH)$`,
+		`^(handler|startup|register)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewPerlParser())
+}
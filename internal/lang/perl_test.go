@@ -0,0 +1,251 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestPerlParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `package Shop::Orders;
+
+# Orders and their totals.
+use strict;
+use warnings;
+use parent -norequire, 'Shop::Base';
+use List::Util qw(sum max);
+use Shop::Order;
+
+=head2 new
+
+Creates the repository.
+
+=cut
+
+sub new {
+    my ($class, %args) = @_;
+    my $self = bless { db => $args{db}, cache => {} }, $class;
+    $self->_warm("orders");
+    return $self;
+}
+
+sub total {
+    my $self = shift;
+    my $order = shift;
+    # TODO: currency conversion
+    return sum(map { $_->{price} } @{ $order->{items} }) / 100;
+}
+
+sub find ($self, $id, $opts = {}) {
+    my $sql = <<~SQL;
+        SELECT * FROM orders
+        WHERE id = ?
+        SQL
+    my $row = $self->{db}->selectrow_hashref($sql, undef, $id);
+    my $s = q{ sub { } };
+    $s =~ s{\}}{ sub x { } }g;
+    return Shop::Order->new(%$row) if $row =~ /}/;
+    return Shop::Orders::fallback($id);
+}
+
+sub _warm {
+    my ($self, $key) = @_;
+    $self->{cache}{$key} = __PACKAGE__->fallback($#{ $self->{queue} });
+}
+
+sub fallback { return undef }
+
+package Shop::Orders::Report {
+    sub render {
+        my $self = shift;
+        print STDERR render_row(Shop::Orders->new(db => undef)->total({}));
+    }
+
+    sub render_row { sprintf("%s", shift) }
+}
+
+1;
+
+__END__
+
+=head1 NAME
+
+Shop::Orders
+`
+	path := writeFixture(t, tmp, "Orders.pm", code)
+
+	parsed, err := NewPerlParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "perl" || parsed.Namespace != `Shop\Orders` {
+		t.Errorf("expected the first package as the namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	if len(parsed.Uses) != 2 || parsed.Uses[0] != `List\Util` || parsed.Uses[1] != `Shop\Order` {
+		t.Errorf("expected the used modules without pragmas, got %v", parsed.Uses)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Namespace+"."+el.Name] = el
+	}
+	for _, key := range []string{`module:Shop.Orders`, `method:Shop\Orders.new`, `method:Shop\Orders.total`,
+		`method:Shop\Orders.find`, `method:Shop\Orders._warm`, `method:Shop\Orders.fallback`,
+		`module:Shop\Orders.Report`, `method:Shop\Orders\Report.render`, `method:Shop\Orders\Report.render_row`} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 9 {
+		t.Errorf("expected 9 elements, got %+v", parsed.Elements)
+	}
+
+	if ctor := elements[`method:Shop\Orders.new`]; !ctor.Documented || ctor.ClassName != "Orders" ||
+		len(ctor.Parameters) != 1 || ctor.Parameters[0] != "args" {
+		t.Errorf("expected a documented method of Orders taking [args], got %+v", ctor)
+	}
+	if total := elements[`method:Shop\Orders.total`]; total.Documented || len(total.Parameters) != 1 || total.Parameters[0] != "order" {
+		t.Errorf("expected an undocumented method shifting [order], got %+v", total)
+	}
+	if find := elements[`method:Shop\Orders.find`]; len(find.Parameters) != 2 || find.Parameters[1] != "opts" {
+		t.Errorf("expected a signature of [id opts], got %+v", find)
+	}
+	if warm := elements[`method:Shop\Orders._warm`]; warm.Visibility != "private" || warm.Parameters[0] != "key" {
+		t.Errorf("expected a private method taking [key], got %+v", warm)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		`extends:Shop\Base in Orders`,
+		`type_reference:Shop\Order in Orders`,
+		`method_call:_warm in new`,
+		`function_call:List\Util\sum in total`,
+		`method_call:selectrow_hashref in find`,
+		`static_call:Shop\Order\new in find`,
+		`instantiation:Shop\Order in find`,
+		`function_call:Shop\Orders\fallback in find`,
+		`static_call:Shop\Orders\fallback in _warm`,
+		`static_call:Shop\Orders\new in render`,
+		`method_call:total in render`,
+		`function_call:render_row in render`,
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, u := range parsed.Usage {
+		switch u.Name {
+		case "map", "bless", "sprintf", "print", "x", "sub", "shift", `Shop\Base`:
+			if u.Type != "extends" {
+				t.Errorf("unexpected usage %+v", u)
+			}
+		}
+	}
+
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Table != "orders" || parsed.Tables[0].Function != "find" {
+		t.Errorf("expected the heredoc's table, got %+v", parsed.Tables)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 26 {
+		t.Errorf("expected the TODO on line 26, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 10 {
+		t.Errorf("expected 10 comment lines, counting POD, got %d", parsed.CommentLines)
+	}
+}
+
+func TestPerlParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"bin", "lib/Shop"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "bin/report.pl", `#!/usr/bin/env perl
+# Prints the day's orders.
+use strict;
+use Shop::Orders qw(list_orders);
+
+sub main {
+    print_orders(list_orders());
+}
+
+sub print_orders { print "@_\n" }
+
+main();
+`)
+	writeFixture(t, tmp, "lib/Shop/Orders.pm", `package Shop::Orders;
+use strict;
+use Exporter 'import';
+use Shop::Order;
+our @EXPORT_OK = qw(list_orders);
+
+sub list_orders {
+    return map { total($_) } Shop::Order->all;
+}
+
+sub total { $_[0]->{price} }
+
+sub unused { 1 }
+
+1;
+`)
+	writeFixture(t, tmp, "lib/Shop/Order.pm", `package Shop::Order;
+use Moo;
+extends 'Shop::Model';
+
+sub all { () }
+
+1;
+`)
+
+	p := NewPerlParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"bin/report.pl", "lib/Shop/Orders.pm", "lib/Shop/Order.pm"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+	if !files[0].Elements[0].Documented || files[0].Elements[0].Name != "report" {
+		t.Errorf("expected the script as a documented module, got %+v", files[0].Elements[0])
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Type+":"+node.Name] = node
+	}
+	if nodes["module:report"].Dependencies[nodes["module:Orders"].ID] == nil ||
+		nodes["module:report"].Dependencies[nodes["function:main"].ID] == nil {
+		t.Errorf("expected the script to use the module and call main, got %+v", nodes["module:report"].Dependencies)
+	}
+	if nodes["function:main"].Dependencies[nodes["method:list_orders"].ID] == nil ||
+		nodes["function:main"].Dependencies[nodes["function:print_orders"].ID] == nil {
+		t.Errorf("expected main to call the imported and the script's functions, got %+v", nodes["function:main"].Dependencies)
+	}
+	if nodes["method:list_orders"].Dependencies[nodes["method:total"].ID] == nil ||
+		nodes["method:list_orders"].Dependencies[nodes["method:all"].ID] == nil {
+		t.Errorf("expected the package's and the class method calls to resolve, got %+v", nodes["method:list_orders"].Dependencies)
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Name] = true
+	}
+	if !orphans["unused"] || len(orphans) != 1 {
+		t.Errorf("expected only the unused sub among the orphans, got %v", orphans)
+	}
+}
@@ -5,11 +5,11 @@ package lang
 
 import (
 	"bufio"
-	"fmt"
+	"bytes"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
-	"sync"
 
 	"github.com/boone-studios/tukey/internal/models"
 	"github.com/boone-studios/tukey/internal/parser"
@@ -26,6 +26,9 @@ type PHPParser struct {
 	traitPattern          *regexp.Regexp
 	enumPattern           *regexp.Regexp
 	traitUsePattern       *regexp.Regexp
+	traitBlockPattern     *regexp.Regexp
+	insteadofPattern      *regexp.Regexp
+	traitAliasPattern     *regexp.Regexp
 	functionPattern       *regexp.Regexp
 	methodPattern         *regexp.Regexp
 	propertyPattern       *regexp.Regexp
@@ -34,6 +37,34 @@ type PHPParser struct {
 	methodCallPattern     *regexp.Regexp
 	newInstancePattern    *regexp.Regexp
 	globalFunctionPattern *regexp.Regexp
+	instanceofPattern     *regexp.Regexp
+	catchPattern          *regexp.Regexp
+	attributePattern      *regexp.Regexp
+	signatureStartPattern *regexp.Regexp
+	typeStartPattern      *regexp.Regexp
+	returnTypePattern     *regexp.Regexp
+	hookPattern           *regexp.Regexp
+	callbackPatterns      []*regexp.Regexp
+	routeActionPatterns   []*regexp.Regexp
+	routePattern          *regexp.Regexp
+	routeAttrPattern      *regexp.Regexp
+	routeMethodsPattern   *regexp.Regexp
+	assetPattern          *regexp.Regexp
+	viewPattern           *regexp.Regexp
+	twigRenderPattern     *regexp.Regexp
+	twigExtensionPattern  *regexp.Regexp
+	quotedPattern         *regexp.Regexp
+	tablePropertyPattern  *regexp.Regexp
+	tableQueryPattern     *regexp.Regexp
+	schemaPattern         *regexp.Regexp
+
+	target *syntaxTarget // The PHP version the code targets; nil for the latest
+}
+
+// phpRoute is a Route attribute waiting for the class or method it annotates
+type phpRoute struct {
+	path    string
+	methods []string
 }
 
 // NewPHPParser creates a new PHP parser with compiled regex patterns
@@ -46,8 +77,8 @@ func NewPHPParser() *PHPParser {
 		usePattern: regexp.MustCompile(`^\s*use\s+([A-Za-z_\\][A-Za-z0-9_\\]*)\s*(?:as\s+([A-Za-z_][A-Za-z0-9_]*))?\s*;`),
 
 		// Class: class User extends Model implements UserInterface
-		// Supports optional leading "abstract" or "final" without treating them as class names
-		classPattern: regexp.MustCompile(`^\s*(?:(abstract|final)\s+)?class\s+([A-Za-z_][A-Za-z0-9_]*)\s*(?:extends\s+([A-Za-z_\\][A-Za-z0-9_\\]*))?\s*(?:implements\s+([A-Za-z0-9_\\,\s]+))?\s*\{?`),
+		// Supports leading "abstract", "final", and "readonly" without treating them as class names
+		classPattern: regexp.MustCompile(`^\s*((?:(?:abstract|final|readonly)\s+)*)class\s+([A-Za-z_][A-Za-z0-9_]*)\s*(?:extends\s+([A-Za-z_\\][A-Za-z0-9_\\]*))?\s*(?:implements\s+([A-Za-z0-9_\\,\s]+))?\s*\{?`),
 
 		// Interface: interface UserRepository extends BaseRepository
 		interfacePattern: regexp.MustCompile(`^\s*interface\s+([A-Za-z_][A-Za-z0-9_]*)\s*(?:extends\s+([A-Za-z0-9_,\s]+))?\s*\{?`),
@@ -59,22 +90,24 @@ func NewPHPParser() *PHPParser {
 		enumPattern: regexp.MustCompile(`^\s*enum\s+([A-Za-z_][A-Za-z0-9_]*)\s*(?::\s*([A-Za-z_\\][A-Za-z0-9_\\]*))?\s*(?:implements\s+([A-Za-z0-9_\\,\s]+))?\s*\{?`),
 
 		// Function: function getUserById($id): User
-		functionPattern: regexp.MustCompile(`^\s*function\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(([^)]*)\)\s*(?::\s*([A-Za-z_\\][A-Za-z0-9_\\]*))?\s*\{?`),
+		// Parameters and return type are split out by splitSignature so defaults like
+		// "new NullLogger()" don't end the parameter list early
+		functionPattern: regexp.MustCompile(`^\s*function\s+&?([A-Za-z_][A-Za-z0-9_]*)\s*\(`),
 
-		// Method: public static function create($data): self
-		methodPattern: regexp.MustCompile(`^\s*(public|private|protected)?\s*(static\s+)?(abstract\s+)?function\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(([^)]*)\)\s*(?::\s*([A-Za-z_\\][A-Za-z0-9_\\]*))?\s*\{?`),
+		// Method: public static function create($data): self, final public function, abstract protected function
+		methodPattern: regexp.MustCompile(`^\s*((?:(?:public|private|protected|static|abstract|final)\s+)*)function\s+&?([A-Za-z_][A-Za-z0-9_]*)\s*\(`),
 
-		// Property: private $name; protected static $instances = [];
-		propertyPattern: regexp.MustCompile(`^\s*(public|private|protected)\s+(static\s+)?\$([A-Za-z_][A-Za-z0-9_]*)`),
+		// Property: private $name; protected static $instances = []; public readonly ?User $owner;
+		propertyPattern: regexp.MustCompile(`^\s*((?:(?:public|private|protected|static|readonly|var)\s+)+)(?:\??[A-Za-z_\\][A-Za-z0-9_\\|&]*\s+)?\$([A-Za-z_][A-Za-z0-9_]*)`),
 
-		// Constant: const STATUS_ACTIVE = 'active';
-		constantPattern: regexp.MustCompile(`^\s*(public|private|protected\s+)?const\s+([A-Z_][A-Z0-9_]*)\s*=`),
+		// Constant: const STATUS_ACTIVE = 'active'; final public const string PREFIX = 'app';
+		constantPattern: regexp.MustCompile(`^\s*(?:final\s+)?(?:(public|private|protected)\s+)?(?:final\s+)?const\s+(?:\??[A-Za-z_\\][A-Za-z0-9_\\|]*\s+)?([A-Z_][A-Z0-9_]*)\s*=`),
 
 		// Static calls: User::find($id), self::$instance
 		staticCallPattern: regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)::(\$?[A-Za-z_][A-Za-z0-9_]*)`),
 
 		// Method calls: $user->getName(), $this->property
-		methodCallPattern: regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*->(\$?[A-Za-z_][A-Za-z0-9_]*)`),
+		methodCallPattern: regexp.MustCompile(`(\$[A-Za-z_][A-Za-z0-9_]*)->(\$?[A-Za-z_][A-Za-z0-9_]*)`),
 
 		// New instances: new User(), new \App\Models\User()
 		newInstancePattern: regexp.MustCompile(`new\s+([A-Za-z_\\][A-Za-z0-9_\\]*)`),
@@ -82,8 +115,82 @@ func NewPHPParser() *PHPParser {
 		// Trait use inside class: use Loggable, Auditable;
 		traitUsePattern: regexp.MustCompile(`^\s*use\s+([A-Za-z_\\][A-Za-z0-9_\\]*(?:\s*,\s*[A-Za-z_\\][A-Za-z0-9_\\]*)*)\s*;`),
 
+		// Trait use with adaptations: use A, B { A::hello insteadof B; }
+		traitBlockPattern: regexp.MustCompile(`^\s*use\s+([A-Za-z_\\][A-Za-z0-9_\\]*(?:\s*,\s*[A-Za-z_\\][A-Za-z0-9_\\]*)*)\s*\{(.*)$`),
+
+		// Trait conflict resolution: A::hello insteadof B, C
+		insteadofPattern: regexp.MustCompile(`^\s*(?:([A-Za-z_\\][A-Za-z0-9_\\]*)::)?([A-Za-z_][A-Za-z0-9_]*)\s+insteadof\s+([A-Za-z0-9_\\,\s]+)$`),
+
+		// Trait method aliasing: B::hello as protected greet
+		traitAliasPattern: regexp.MustCompile(`^\s*(?:([A-Za-z_\\][A-Za-z0-9_\\]*)::)?([A-Za-z_][A-Za-z0-9_]*)\s+as\s+(?:(public|protected|private)\b\s*)?([A-Za-z_][A-Za-z0-9_]*)?\s*$`),
+
 		// Global function calls: format_phone($phone), validate_email($email)
 		globalFunctionPattern: regexp.MustCompile(`\b([a-zA-Z_][a-zA-Z0-9_]*)\s*\(`),
+
+		// Type checks: $user instanceof User
+		instanceofPattern: regexp.MustCompile(`instanceof\s+([A-Za-z_\\][A-Za-z0-9_\\]*)`),
+
+		// Exception handlers: catch (NotFoundException | AuthException $e)
+		catchPattern: regexp.MustCompile(`catch\s*\(\s*([A-Za-z_\\][A-Za-z0-9_\\]*(?:\s*\|\s*[A-Za-z_\\][A-Za-z0-9_\\]*)*)`),
+
+		// Attributes: #[Route('/users')], #[ORM\Entity]
+		attributePattern: regexp.MustCompile(`(?:#\[|,)\s*([A-Za-z_\\][A-Za-z0-9_\\]*)\s*(?:\(|,|\])`),
+
+		// Start of a function or method declaration, used to join multi-line signatures
+		signatureStartPattern: regexp.MustCompile(`\bfunction\s+&?[A-Za-z_][A-Za-z0-9_]*\s*\(`),
+
+		// Start of a class, interface, trait, or enum declaration, whose brace may follow later
+		typeStartPattern: regexp.MustCompile(`^\s*(?:(?:abstract|final|readonly)\s+)*(?:class|interface|trait|enum)\s+[A-Za-z_]`),
+
+		// Return type following a parameter list: ": ?User", ": static", ": A|B"
+		returnTypePattern: regexp.MustCompile(`^\s*:\s*(\??[A-Za-z_\\][A-Za-z0-9_\\|&]*)`),
+
+		// WordPress hooks: add_action('init', 'my_plugin_init'), do_action('my_plugin_loaded')
+		hookPattern: regexp.MustCompile(`\b(add_action|add_filter|do_action|do_action_ref_array|apply_filters|apply_filters_ref_array)\s*\(\s*['"]([^'"]+)['"]\s*(?:,\s*(.*))?`),
+
+		// Hook callbacks: 'my_func', 'Plugin::boot', [$this, 'init'], array(Plugin::class, 'boot')
+		callbackPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`^['"]([A-Za-z_\\][A-Za-z0-9_\\]*(?:::[A-Za-z_][A-Za-z0-9_]*)?)['"]`),
+			regexp.MustCompile(`^(?:\[|array\s*\()\s*(\$this|[A-Za-z_\\][A-Za-z0-9_\\]*::class|['"][A-Za-z_\\][A-Za-z0-9_\\]*['"])\s*,\s*['"]([A-Za-z_][A-Za-z0-9_]*)['"]`),
+		},
+
+		// Route actions that aren't callables: 'UserController@index', an invokable UserController::class
+		routeActionPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`^['"]([A-Za-z_\\][A-Za-z0-9_\\]*)@([A-Za-z_][A-Za-z0-9_]*)['"]`),
+			regexp.MustCompile(`^([A-Za-z_\\][A-Za-z0-9_\\]*)::class\s*[,)]`),
+		},
+
+		// Laravel routes: Route::get('/users/{id}', [UserController::class, 'show']),
+		// Route::middleware('auth')->post('/users', 'UserController@store')
+		routePattern: regexp.MustCompile(`\bRoute::(?:[^;]*->)?(get|post|put|patch|delete|options|any)\s*\(\s*['"]([^'"]*)['"]\s*,\s*(.*)`),
+
+		// Symfony route attributes: #[Route('/users/{id}', name: 'user', methods: ['GET'])]
+		routeAttrPattern:    regexp.MustCompile(`#\[\s*(?:[A-Za-z_\\]*\\)?Route\s*\(\s*(?:path:\s*)?['"]([^'"]*)['"](.*)`),
+		routeMethodsPattern: regexp.MustCompile(`methods:\s*(\[[^\]]*\]|['"][A-Za-z]+['"])`),
+
+		// Script assets: mix('js/app.js'), @vite(['resources/js/app.js']), Vite::asset('...')
+		assetPattern: regexp.MustCompile(`(?:@vite|\bvite|\bmix|Vite::asset)\s*\(\s*(\[[^\]]*\]|['"][^'"]+['"])`),
+
+		// Rendered views: view('users.show'), View::make('home'), $this->view('emails.welcome')
+		viewPattern: regexp.MustCompile(`(?:\bview|View::(?:make|first)|->view)\s*\(\s*\[?\s*['"]([\w.:-]+)['"]`),
+
+		// Rendered Twig templates: $this->render('blog/post.html.twig'), #[Template('...')]
+		twigRenderPattern: regexp.MustCompile(`(?:->(?:render|renderView|renderBlock|display|load|stream)|\bTemplate)\s*\(\s*['"]([\w./@-]+\.twig)['"]`),
+
+		// Twig extensions: new TwigFilter('money', [$this, 'formatMoney']), new \Twig\TwigFunction('area', ...)
+		twigExtensionPattern: regexp.MustCompile(`\bnew\s+\\?(?:Twig\\)?Twig(Filter|Function|Test)\s*\(\s*['"]([^'"]+)['"]\s*(?:,\s*(.*))?`),
+
+		// Each string in a list: ['GET', 'POST']
+		quotedPattern: regexp.MustCompile(`['"]([^'"]+)['"]`),
+
+		// Eloquent models: protected $table = 'users';
+		tablePropertyPattern: regexp.MustCompile(`\b(?:public|protected|private)\s+(?:static\s+)?(?:\??string\s+)?\$table\s*=\s*['"]([^'"]+)['"]`),
+
+		// Query builder: DB::table('users'), DB::connection('legacy')->table('users')
+		tableQueryPattern: regexp.MustCompile(`\bDB::(?:connection\([^)]*\)->)?table\s*\(\s*['"]([^'"]+)['"]`),
+
+		// Migrations: Schema::create('users', ...), Schema::rename('users', 'members')
+		schemaPattern: regexp.MustCompile(`\bSchema::(?:connection\([^)]*\)->)?(?:create|table|drop|dropIfExists|rename)\s*\(\s*['"]([^'"]+)['"](?:\s*,\s*['"]([^'"]+)['"])?`),
 	}
 }
 
@@ -94,9 +201,16 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		return nil, err
 	}
 	defer file.Close()
+	if strings.HasSuffix(filePath, ".blade.php") {
+		return p.parseBlade(filePath, file)
+	}
+	if strings.HasSuffix(filePath, ".twig") {
+		return p.parseTwig(filePath, file)
+	}
 
 	parsed := &models.ParsedFile{
 		Path:     filePath,
+		Language: p.Language(),
 		Elements: []models.CodeElement{},
 		Usage:    []models.UsageElement{},
 		Uses:     []string{},
@@ -104,21 +218,97 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
+	joinedLines := 0
 	inClass := ""
 	inFunction := ""
+	inTraitBlock := false
 	braceDepth := 0
+	inComment := false // Inside a /* */ block comment
+	docblock := false  // A /** */ docblock precedes the next declaration
+
+	var routes []phpRoute // Route attributes awaiting the declaration they annotate
+	routePrefix := ""     // Path of the current class's Route attribute
 
 	for scanner.Scan() {
-		lineNum++
+		lineNum += 1 + joinedLines
+		joinedLines = 0
 		line := scanner.Text()
 		trimmedLine := strings.TrimSpace(line)
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
 
-		// Skip comments and empty lines
-		if strings.HasPrefix(trimmedLine, "//") || strings.HasPrefix(trimmedLine, "#") ||
-			strings.HasPrefix(trimmedLine, "/*") || trimmedLine == "" {
+		// Block comments may span lines; a docblock documents the declaration after it
+		if inComment || strings.HasPrefix(trimmedLine, "/*") {
+			if !inComment {
+				docblock = strings.HasPrefix(trimmedLine, "/**")
+				trimmedLine = trimmedLine[2:]
+			}
+			inComment = !strings.Contains(trimmedLine, "*/")
+			parsed.CommentLines++
 			continue
 		}
 
+		// Skip comments and empty lines ("#[" starts an attribute, not a comment)
+		if strings.HasPrefix(trimmedLine, "//") || trimmedLine == "" ||
+			(strings.HasPrefix(trimmedLine, "#") && !strings.HasPrefix(trimmedLine, "#[")) {
+			if trimmedLine != "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Join multi-line signatures so the whole parameter list is parsed as one declaration
+		if p.signatureStartPattern.MatchString(line) {
+			for balance := parenBalance(phpBare(line)); balance > 0 && scanner.Scan(); {
+				joinedLines++
+				balance += parenBalance(phpBare(scanner.Text()))
+				line += " " + strings.TrimSpace(scanner.Text())
+			}
+		}
+		// PSR-12 opens class and function bodies on a line of their own; join up to the
+		// brace, so the declaration's context holds once the line is done
+		if bare := phpBare(line); p.signatureStartPattern.MatchString(bare) || p.typeStartPattern.MatchString(bare) {
+			for open := strings.ContainsAny(bare, "{;"); !open && scanner.Scan(); {
+				joinedLines++
+				open = strings.ContainsAny(phpBare(scanner.Text()), "{;")
+				line += " " + strings.TrimSpace(scanner.Text())
+			}
+		}
+
+		p.target.check(blankStrings(line), lineNum, parsed)
+
+		// Attributes reference classes but are not calls
+		if strings.HasPrefix(trimmedLine, "#[") {
+			for _, match := range p.attributePattern.FindAllStringSubmatch(line, -1) {
+				parsed.Usage = append(parsed.Usage, models.UsageElement{
+					Type:    "attribute",
+					Name:    match[1],
+					Context: inClass,
+					Line:    lineNum,
+				})
+			}
+			for _, match := range p.newInstancePattern.FindAllStringSubmatch(line, -1) {
+				parsed.Usage = append(parsed.Usage, models.UsageElement{
+					Type:    "instantiation",
+					Name:    match[1],
+					Context: inClass,
+					Line:    lineNum,
+				})
+			}
+			if matches := p.routeAttrPattern.FindStringSubmatch(line); matches != nil {
+				routes = append(routes, phpRoute{path: matches[1], methods: p.routeMethods(matches[2])})
+			}
+			p.parseViews(line, lineNum, inClass, parsed)
+			continue
+		}
+
+		// Any other code ends what a docblock or attribute could annotate
+		documented := docblock
+		docblock = false
+		annotating := routes
+		routes = nil
+
 		// Track brace depth to know when we exit classes/functions
 		braceDepth += strings.Count(line, "{") - strings.Count(line, "}")
 
@@ -137,13 +327,19 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		// Parse class declaration
 		if matches := p.classPattern.FindStringSubmatch(line); matches != nil {
 			inClass = matches[2]
+			routePrefix = ""
+			if len(annotating) > 0 {
+				routePrefix = annotating[0].path
+			}
 			element := models.CodeElement{
 				Type:       "class",
 				Name:       matches[2],
 				Namespace:  parsed.Namespace,
 				Line:       lineNum,
 				File:       filePath,
+				Documented: documented,
 				IsAbstract: strings.Contains(matches[1], "abstract"),
+				IsReadonly: strings.Contains(matches[1], "readonly"),
 			}
 			parsed.Elements = append(parsed.Elements, element)
 
@@ -179,11 +375,12 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		if matches := p.interfacePattern.FindStringSubmatch(line); matches != nil {
 			inClass = matches[1]
 			element := models.CodeElement{
-				Type:      "interface",
-				Name:      matches[1],
-				Namespace: parsed.Namespace,
-				Line:      lineNum,
-				File:      filePath,
+				Type:       "interface",
+				Name:       matches[1],
+				Namespace:  parsed.Namespace,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
 			}
 			parsed.Elements = append(parsed.Elements, element)
 
@@ -208,11 +405,12 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		if matches := p.traitPattern.FindStringSubmatch(line); matches != nil {
 			inClass = matches[1]
 			element := models.CodeElement{
-				Type:      "trait",
-				Name:      matches[1],
-				Namespace: parsed.Namespace,
-				Line:      lineNum,
-				File:      filePath,
+				Type:       "trait",
+				Name:       matches[1],
+				Namespace:  parsed.Namespace,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
 			}
 			parsed.Elements = append(parsed.Elements, element)
 		}
@@ -221,11 +419,12 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		if matches := p.enumPattern.FindStringSubmatch(line); matches != nil {
 			inClass = matches[1]
 			element := models.CodeElement{
-				Type:      "enum",
-				Name:      matches[1],
-				Namespace: parsed.Namespace,
-				Line:      lineNum,
-				File:      filePath,
+				Type:       "enum",
+				Name:       matches[1],
+				Namespace:  parsed.Namespace,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
 			}
 			parsed.Elements = append(parsed.Elements, element)
 
@@ -246,10 +445,22 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 			}
 		}
 
+		// Adaptation rules inside a trait use block are not calls; consume them here
+		if inTraitBlock {
+			inTraitBlock = p.parseTraitAdaptations(line, inClass, lineNum, parsed)
+			continue
+		}
+
 		// Parse trait uses inside class/enum/interface/trait body
 		if inClass != "" {
-			if matches := p.traitUsePattern.FindStringSubmatch(line); matches != nil {
-				traits := strings.Split(matches[1], ",")
+			traitMatches := p.traitUsePattern.FindStringSubmatch(line)
+			blockMatches := p.traitBlockPattern.FindStringSubmatch(line)
+			if blockMatches != nil {
+				traitMatches = blockMatches
+			}
+
+			if traitMatches != nil {
+				traits := strings.Split(traitMatches[1], ",")
 				for _, tName := range traits {
 					tName = strings.TrimSpace(tName)
 					if tName == "" {
@@ -263,61 +474,94 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 					})
 				}
 			}
+
+			if blockMatches != nil {
+				inTraitBlock = p.parseTraitAdaptations(blockMatches[2], inClass, lineNum, parsed)
+				continue
+			}
 		}
 
 		// Parse method declaration (inside class/enum/interface/trait)
 		if inClass != "" {
-			if matches := p.methodPattern.FindStringSubmatch(line); matches != nil {
-				visibility := "public" // Default visibility
-				if matches[1] != "" {
-					visibility = matches[1]
-				}
+			if matches := p.methodPattern.FindStringSubmatchIndex(line); matches != nil {
+				modifiers := line[matches[2]:matches[3]]
+				name := line[matches[4]:matches[5]]
+				params, returnType := p.splitSignature(line[matches[1]:])
 
 				element := models.CodeElement{
 					Type:       "method",
-					Name:       matches[4],
+					Name:       name,
 					Namespace:  parsed.Namespace,
 					ClassName:  inClass,
-					Visibility: visibility,
-					IsStatic:   strings.Contains(matches[2], "static"),
-					IsAbstract: strings.Contains(matches[3], "abstract"),
+					Visibility: visibilityOf(modifiers),
+					IsStatic:   strings.Contains(modifiers, "static"),
+					IsAbstract: strings.Contains(modifiers, "abstract"),
 					Line:       lineNum,
 					File:       filePath,
-					Parameters: parseParameters(matches[5]),
-					ReturnType: matches[6],
+					Documented: documented,
+					Parameters: parseParameters(params),
+					ParamTypes: parseParameterTypes(params),
+					ReturnType: returnType,
 				}
 				parsed.Elements = append(parsed.Elements, element)
-				inFunction = matches[4]
+				inFunction = name
+				p.parseSignatureTypes(element, parsed)
+
+				// Route attributes map the method to HTTP requests
+				for _, route := range annotating {
+					for _, method := range route.methods {
+						parsed.Usage = append(parsed.Usage, models.UsageElement{
+							Type:     "route",
+							Name:     method + " " + joinRoutePath(routePrefix, route.path),
+							Context:  inClass,
+							Callback: inClass + "::" + name,
+							Line:     lineNum,
+						})
+					}
+				}
+
+				// Constructor promotion declares properties in the parameter list
+				if name == "__construct" {
+					parsed.Elements = append(parsed.Elements, promotedProperties(params, parsed.Namespace, inClass, lineNum, filePath)...)
+				}
 			}
 		}
 
 		// Parse standalone function declaration
 		if inClass == "" {
-			if matches := p.functionPattern.FindStringSubmatch(line); matches != nil {
+			if matches := p.functionPattern.FindStringSubmatchIndex(line); matches != nil {
+				name := line[matches[2]:matches[3]]
+				params, returnType := p.splitSignature(line[matches[1]:])
+
 				element := models.CodeElement{
 					Type:       "function",
-					Name:       matches[1],
+					Name:       name,
 					Namespace:  parsed.Namespace,
 					Line:       lineNum,
 					File:       filePath,
-					Parameters: parseParameters(matches[2]),
-					ReturnType: matches[3],
+					Documented: documented,
+					Parameters: parseParameters(params),
+					ParamTypes: parseParameterTypes(params),
+					ReturnType: returnType,
 				}
 				parsed.Elements = append(parsed.Elements, element)
-				inFunction = matches[1]
+				inFunction = name
+				p.parseSignatureTypes(element, parsed)
 			}
 		}
 
 		// Parse property declaration
 		if inClass != "" {
-			if matches := p.propertyPattern.FindStringSubmatch(line); matches != nil {
+			// A bare "static $x" is a function-local static variable, not a property
+			if matches := p.propertyPattern.FindStringSubmatch(line); matches != nil && strings.TrimSpace(matches[1]) != "static" {
 				element := models.CodeElement{
 					Type:       "property",
-					Name:       matches[3],
+					Name:       matches[2],
 					Namespace:  parsed.Namespace,
 					ClassName:  inClass,
-					Visibility: matches[1],
-					IsStatic:   strings.Contains(matches[2], "static"),
+					Visibility: visibilityOf(matches[1]),
+					IsStatic:   strings.Contains(matches[1], "static"),
+					IsReadonly: strings.Contains(matches[1], "readonly"),
 					Line:       lineNum,
 					File:       filePath,
 				}
@@ -329,7 +573,7 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		if matches := p.constantPattern.FindStringSubmatch(line); matches != nil {
 			visibility := "public" // Default for constants
 			if matches[1] != "" {
-				visibility = strings.TrimSpace(matches[1])
+				visibility = matches[1]
 			}
 
 			element := models.CodeElement{
@@ -346,6 +590,7 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 
 		// Parse usage patterns
 		p.parseUsage(line, lineNum, inFunction, inClass, parsed)
+		p.parseTables(line, lineNum, inFunction, inClass, parsed)
 
 		// Reset context when exiting classes/functions
 		if braceDepth == 0 {
@@ -354,9 +599,113 @@ func (p *PHPParser) ParseFile(filePath string) (*models.ParsedFile, error) {
 		}
 	}
 
+	parsed.Lines = lineNum + joinedLines
 	return parsed, scanner.Err()
 }
 
+// parseCallback normalizes a PHP callable argument into "func", "$this->method", or "Class::method".
+// Closures and variables can't be resolved statically and return "".
+func (p *PHPParser) parseCallback(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if matches := p.callbackPatterns[0].FindStringSubmatch(arg); matches != nil {
+		return matches[1]
+	}
+	if matches := p.callbackPatterns[1].FindStringSubmatch(arg); matches != nil {
+		target := strings.Trim(matches[1], `'"`)
+		if target == "$this" {
+			return "$this->" + matches[2]
+		}
+		return strings.TrimSuffix(target, "::class") + "::" + matches[2]
+	}
+	return ""
+}
+
+// parseRouteAction normalizes a route's action to a callback: "UserController@index" and
+// an invokable UserController::class become "UserController::index" and
+// "UserController::__invoke"; other callables are read as hook callbacks are
+func (p *PHPParser) parseRouteAction(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if matches := p.routeActionPatterns[0].FindStringSubmatch(arg); matches != nil {
+		return matches[1] + "::" + matches[2]
+	}
+	if matches := p.routeActionPatterns[1].FindStringSubmatch(arg); matches != nil {
+		return matches[1] + "::__invoke"
+	}
+	return p.parseCallback(arg)
+}
+
+// routeMethods returns the HTTP methods in the rest of a Route attribute, or ANY when it
+// doesn't restrict them
+func (p *PHPParser) routeMethods(rest string) []string {
+	matches := p.routeMethodsPattern.FindStringSubmatch(rest)
+	if matches == nil {
+		return []string{"ANY"}
+	}
+	var methods []string
+	for _, method := range p.quotedPattern.FindAllStringSubmatch(matches[1], -1) {
+		methods = append(methods, strings.ToUpper(method[1]))
+	}
+	return methods
+}
+
+// laravelRoutePath makes a route path absolute; Laravel serves routes/api.php under /api
+func laravelRoutePath(file, path string) string {
+	path = "/" + strings.Trim(path, "/")
+	if filepath.Base(file) == "api.php" && filepath.Base(filepath.Dir(file)) == "routes" {
+		path = strings.TrimSuffix("/api"+path, "/")
+	}
+	return path
+}
+
+// joinRoutePath appends a method's route path to its class's prefix
+func joinRoutePath(prefix, path string) string {
+	return "/" + strings.Trim(strings.Trim(prefix, "/")+"/"+strings.Trim(path, "/"), "/")
+}
+
+// parseTraitAdaptations records insteadof/as rules from the body of a trait use block.
+// It returns whether the block is still open after this line.
+func (p *PHPParser) parseTraitAdaptations(body, inClass string, lineNum int, parsed *models.ParsedFile) bool {
+	open := true
+	if idx := strings.Index(body, "}"); idx != -1 {
+		body = body[:idx]
+		open = false
+	}
+
+	for _, statement := range strings.Split(body, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+
+		if matches := p.insteadofPattern.FindStringSubmatch(statement); matches != nil {
+			var excluded []string
+			for _, name := range strings.Split(matches[3], ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					excluded = append(excluded, name)
+				}
+			}
+			parsed.TraitAdaptations = append(parsed.TraitAdaptations, models.TraitAdaptation{
+				ClassName: inClass,
+				Trait:     matches[1],
+				Method:    matches[2],
+				InsteadOf: excluded,
+				Line:      lineNum,
+			})
+		} else if matches := p.traitAliasPattern.FindStringSubmatch(statement); matches != nil {
+			parsed.TraitAdaptations = append(parsed.TraitAdaptations, models.TraitAdaptation{
+				ClassName:  inClass,
+				Trait:      matches[1],
+				Method:     matches[2],
+				Visibility: matches[3],
+				Alias:      matches[4],
+				Line:       lineNum,
+			})
+		}
+	}
+
+	return open
+}
+
 // parseUsage finds references to external code elements
 func (p *PHPParser) parseUsage(line string, lineNum int, inFunction, inClass string, parsed *models.ParsedFile) {
 	context := inFunction
@@ -372,6 +721,7 @@ func (p *PHPParser) parseUsage(line string, lineNum int, inFunction, inClass str
 			Type:     "static_call",
 			Name:     match[1] + "::" + match[2],
 			Context:  context,
+			Receiver: match[1],
 			Line:     lineNum,
 			IsStatic: true,
 		}
@@ -383,10 +733,11 @@ func (p *PHPParser) parseUsage(line string, lineNum int, inFunction, inClass str
 	for i := 0; i < len(methodMatches); i++ {
 		match := methodMatches[i]
 		usage := models.UsageElement{
-			Type:    "method_call",
-			Name:    match[1],
-			Context: context,
-			Line:    lineNum,
+			Type:     "method_call",
+			Name:     match[2],
+			Context:  context,
+			Receiver: match[1],
+			Line:     lineNum,
 		}
 		parsed.Usage = append(parsed.Usage, usage)
 	}
@@ -404,11 +755,66 @@ func (p *PHPParser) parseUsage(line string, lineNum int, inFunction, inClass str
 		parsed.Usage = append(parsed.Usage, usage)
 	}
 
+	// Find type checks and caught exceptions
+	for _, match := range p.instanceofPattern.FindAllStringSubmatch(line, -1) {
+		p.addTypeReferences(match[1], context, lineNum, parsed)
+	}
+	for _, match := range p.catchPattern.FindAllStringSubmatch(line, -1) {
+		p.addTypeReferences(match[1], context, lineNum, parsed)
+	}
+
+	// Find hook registrations and dispatches
+	for _, match := range p.hookPattern.FindAllStringSubmatch(line, -1) {
+		usage := models.UsageElement{
+			Type:    "hook_fire",
+			Name:    match[2],
+			Context: context,
+			Line:    lineNum,
+		}
+		if strings.HasPrefix(match[1], "add_") {
+			usage.Type = "hook_register"
+			usage.Callback = p.parseCallback(match[3])
+		}
+		parsed.Usage = append(parsed.Usage, usage)
+	}
+
+	// Find route definitions and the script assets a page loads
+	for _, match := range p.routePattern.FindAllStringSubmatch(line, -1) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     "route",
+			Name:     strings.ToUpper(match[1]) + " " + laravelRoutePath(parsed.Path, match[2]),
+			Context:  context,
+			Callback: p.parseRouteAction(match[3]),
+			Line:     lineNum,
+		})
+	}
+	p.parseAssets(line, lineNum, context, parsed)
+
+	// Find the views a controller renders: view('users.show'), View::make(...), $this->view(...),
+	// and Twig's $this->render('blog/post.html.twig')
+	p.parseViews(line, lineNum, context, parsed)
+
+	// Find the filters, functions, and tests Twig extensions register
+	for _, match := range p.twigExtensionPattern.FindAllStringSubmatch(line, -1) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     "twig_register",
+			Name:     strings.ToLower(match[1]) + ":" + match[2],
+			Context:  context,
+			Callback: p.parseCallback(strings.TrimSpace(match[3])),
+			Line:     lineNum,
+		})
+	}
+
 	// Find global function calls
-	globalMatches := p.globalFunctionPattern.FindAllStringSubmatch(line, -1)
+	globalMatches := p.globalFunctionPattern.FindAllStringSubmatchIndex(line, -1)
 	for i := 0; i < len(globalMatches); i++ {
 		match := globalMatches[i]
-		funcName := match[1]
+		funcName := line[match[2]:match[3]]
+
+		// Skip class names being instantiated, including "new" in initializers
+		if isInstantiation(line[:match[2]]) {
+			continue
+		}
 
 		// Skip if this looks like a method call or static call
 		if strings.Contains(line, "->") || strings.Contains(line, "::") {
@@ -436,6 +842,109 @@ func (p *PHPParser) parseUsage(line string, lineNum int, inFunction, inClass str
 	}
 }
 
+// parseAssets records the script and style assets a line loads
+func (p *PHPParser) parseAssets(line string, lineNum int, context string, parsed *models.ParsedFile) {
+	for _, match := range p.assetPattern.FindAllStringSubmatch(line, -1) {
+		for _, asset := range p.quotedPattern.FindAllStringSubmatch(match[1], -1) {
+			parsed.Usage = append(parsed.Usage, models.UsageElement{
+				Type:    "asset",
+				Name:    asset[1],
+				Context: context,
+				Line:    lineNum,
+			})
+		}
+	}
+}
+
+// parseViews records the Blade views and Twig templates a line renders
+func (p *PHPParser) parseViews(line string, lineNum int, context string, parsed *models.ParsedFile) {
+	for _, pattern := range []*regexp.Regexp{p.viewPattern, p.twigRenderPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(line, -1) {
+			parsed.Usage = append(parsed.Usage, models.UsageElement{
+				Type:    "view",
+				Name:    match[1],
+				Context: context,
+				Line:    lineNum,
+			})
+		}
+	}
+}
+
+// parseTables records the database tables a line names: a model's $table, a query
+// builder or migration call, or SQL in a string
+func (p *PHPParser) parseTables(line string, lineNum int, inFunction, inClass string, parsed *models.ParsedFile) {
+	add := func(table, kind string) {
+		parsed.Tables = append(parsed.Tables, models.TableReference{
+			Table:     table,
+			Kind:      kind,
+			ClassName: inClass,
+			Function:  inFunction,
+			Line:      lineNum,
+		})
+	}
+	if inClass != "" {
+		if matches := p.tablePropertyPattern.FindStringSubmatch(line); matches != nil {
+			add(matches[1], "model")
+		}
+	}
+	for _, match := range p.tableQueryPattern.FindAllStringSubmatch(line, -1) {
+		add(match[1], "query")
+	}
+	for _, match := range p.schemaPattern.FindAllStringSubmatch(line, -1) {
+		add(match[1], "migration")
+		if match[2] != "" {
+			add(match[2], "migration")
+		}
+	}
+	parsed.Tables = append(parsed.Tables, sqlTables(line, lineNum, inClass, inFunction)...)
+}
+
+// parseSignatureTypes records a function's parameter and return type hints as type references
+func (p *PHPParser) parseSignatureTypes(element models.CodeElement, parsed *models.ParsedFile) {
+	for _, paramType := range element.ParamTypes {
+		p.addTypeReferences(paramType, element.Name, element.Line, parsed)
+	}
+	if element.ReturnType != "" {
+		p.addTypeReferences(element.ReturnType, element.Name, element.Line, parsed)
+	}
+}
+
+// addTypeReferences splits a (possibly nullable or union) type and records each class name
+func (p *PHPParser) addTypeReferences(typeStr, context string, lineNum int, parsed *models.ParsedFile) {
+	for _, typeName := range splitTypeNames(typeStr) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:    "type_reference",
+			Name:    typeName,
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// splitTypeNames returns the class names in a type declaration, skipping scalar and pseudo types
+func splitTypeNames(typeStr string) []string {
+	var names []string
+	for _, typeName := range strings.FieldsFunc(typeStr, func(r rune) bool {
+		return r == '|' || r == '&' || r == '(' || r == ')' || r == '?' || r == ' '
+	}) {
+		if typeName == "" || isScalarType(typeName) {
+			continue
+		}
+		names = append(names, typeName)
+	}
+	return names
+}
+
+// isScalarType checks if a type name is a PHP built-in or pseudo type
+func isScalarType(typeName string) bool {
+	switch strings.ToLower(typeName) {
+	case "int", "float", "string", "bool", "array", "callable", "iterable", "object", "mixed",
+		"void", "null", "never", "false", "true", "self", "static", "parent":
+		return true
+	}
+	return false
+}
+
 // isBuiltinFunction checks if a function name is a PHP built-in
 func (p *PHPParser) isBuiltinFunction(funcName string) bool {
 	builtins := map[string]bool{
@@ -464,18 +973,142 @@ func (p *PHPParser) isBuiltinFunction(funcName string) bool {
 		"if": true, "else": true, "elseif": true, "endif": true, "for": true, "foreach": true,
 		"while": true, "do": true, "switch": true, "case": true, "default": true,
 		"try": true, "catch": true, "finally": true, "throw": true, "return": true,
+		"match": true, "fn": true, "function": true, "use": true, "list": true, "unset": true,
+		"clone": true, "yield": true, "and": true, "or": true, "not": true,
 	}
 
+	// match and fn became keywords in PHP 8.0 and 7.4; before, they name functions
+	switch name := strings.ToLower(funcName); {
+	case name == "match" && !p.target.supports("8.0"), name == "fn" && !p.target.supports("7.4"):
+		return false
+	}
 	return builtins[strings.ToLower(funcName)]
 }
 
+// splitSignature splits the text after a declaration's opening parenthesis into the
+// parameter list and the return type, balancing nested parentheses in default values
+func (p *PHPParser) splitSignature(rest string) (string, string) {
+	depth := 1
+	for i, r := range rest {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				returnType := ""
+				if matches := p.returnTypePattern.FindStringSubmatch(rest[i+1:]); matches != nil {
+					returnType = matches[1]
+				}
+				return rest[:i], returnType
+			}
+		}
+	}
+	return rest, ""
+}
+
+// splitParams splits a parameter list on top-level commas only
+func splitParams(paramStr string) []string {
+	var params []string
+	depth, start := 0, 0
+	for i, r := range paramStr {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, paramStr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(params, paramStr[start:])
+}
+
+// parenBalance counts unclosed parentheses on a line
+func parenBalance(line string) int {
+	return strings.Count(line, "(") - strings.Count(line, ")")
+}
+
+// phpBare blanks the string literals in a line and drops its comments, so brackets inside
+// either aren't counted ("#[" starts an attribute, not a comment)
+func phpBare(line string) string {
+	bare := blankStrings(line)
+	for i := 0; i < len(bare); i++ {
+		switch {
+		case strings.HasPrefix(bare[i:], "//"), bare[i] == '#' && !strings.HasPrefix(bare[i:], "#["):
+			return bare[:i]
+		case strings.HasPrefix(bare[i:], "/*"):
+			end := strings.Index(bare[i+2:], "*/")
+			if end == -1 {
+				return bare[:i]
+			}
+			bare = bare[:i] + bare[i+2+end+2:]
+			i--
+		}
+	}
+	return bare
+}
+
+// isInstantiation reports whether the text before a name ends in "new" (optionally namespace-qualified)
+func isInstantiation(prefix string) bool {
+	prefix = strings.TrimRight(prefix, "\\ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_")
+	return strings.HasSuffix(strings.TrimRight(prefix, " \t"), "new")
+}
+
+// visibilityOf picks the visibility keyword out of a modifier list, defaulting to public
+func visibilityOf(modifiers string) string {
+	for _, modifier := range strings.Fields(modifiers) {
+		switch modifier {
+		case "public", "private", "protected":
+			return modifier
+		}
+	}
+	return "public"
+}
+
+// promotedProperties returns the properties declared by constructor property promotion
+func promotedProperties(paramStr, namespace, className string, lineNum int, filePath string) []models.CodeElement {
+	var properties []models.CodeElement
+	for _, param := range splitParams(paramStr) {
+		idx := strings.Index(param, "$")
+		if idx == -1 {
+			continue
+		}
+
+		modifiers := param[:idx]
+		if !strings.Contains(modifiers, "public") && !strings.Contains(modifiers, "private") &&
+			!strings.Contains(modifiers, "protected") && !strings.Contains(modifiers, "readonly") {
+			continue
+		}
+
+		name := strings.TrimSpace(param[idx+1:])
+		if end := strings.IndexAny(name, " =,"); end != -1 {
+			name = name[:end]
+		}
+		properties = append(properties, models.CodeElement{
+			Type:       "property",
+			Name:       name,
+			Namespace:  namespace,
+			ClassName:  className,
+			Visibility: visibilityOf(modifiers),
+			IsReadonly: strings.Contains(modifiers, "readonly"),
+			Line:       lineNum,
+			File:       filePath,
+		})
+	}
+	return properties
+}
+
 // parseParameters extracts parameter names from function signature
 func parseParameters(paramStr string) []string {
-	if paramStr == "" {
+	if strings.TrimSpace(paramStr) == "" {
 		return []string{}
 	}
 
-	params := strings.Split(paramStr, ",")
+	params := splitParams(paramStr)
 	var result []string
 
 	for _, param := range params {
@@ -494,39 +1127,39 @@ func parseParameters(paramStr string) []string {
 	return result
 }
 
-// ProcessFiles parses multiple PHP files concurrently
-func (p *PHPParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
-	var parsedFiles []*models.ParsedFile
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	// Limit concurrency
-	semaphore := make(chan struct{}, 10)
-
-	for _, file := range files {
-		wg.Add(1)
-		go func(f models.FileInfo) {
-			defer wg.Done()
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			parsed, err := p.ParseFile(f.Path)
-			mu.Lock()
-			defer mu.Unlock()
-
-			if err != nil {
-				fmt.Printf("⚠️  Error parsing %s: %v\n", f.RelativePath, err)
-			} else {
-				parsedFiles = append(parsedFiles, parsed)
+// parseParameterTypes extracts the type hint of each parameter in a function signature
+func parseParameterTypes(paramStr string) []string {
+	if strings.TrimSpace(paramStr) == "" {
+		return []string{}
+	}
+
+	var result []string
+	for _, param := range splitParams(paramStr) {
+		param = strings.TrimSpace(param)
+		idx := strings.Index(param, "$")
+		if idx == -1 {
+			continue
+		}
+
+		// The type is the last token before the variable, after any promoted-property modifiers
+		typeHint := ""
+		if fields := strings.Fields(strings.TrimRight(param[:idx], "&. ")); len(fields) > 0 {
+			switch last := fields[len(fields)-1]; last {
+			case "public", "private", "protected", "readonly":
+				// Untyped promoted property
+			default:
+				typeHint = last
 			}
-			progressBar.Update(1) // always tick, even if parse fails
-		}(file)
+		}
+		result = append(result, typeHint)
 	}
 
-	wg.Wait()
-	progressBar.Finish()
+	return result
+}
 
-	return parsedFiles, nil
+// ProcessFiles parses multiple PHP files concurrently
+func (p *PHPParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
 }
 
 // Language returns the language name for this parser
@@ -536,7 +1169,44 @@ func (p *PHPParser) Language() string {
 
 // FileExtensions returns the file extensions supported by this parser
 func (p *PHPParser) FileExtensions() []string {
-	return []string{".php", ".phtml", ".php3", ".php4", ".php5"}
+	return []string{".php", ".phtml", ".php3", ".php4", ".php5", ".twig"}
+}
+
+// Sniff recognizes extensionless PHP scripts, such as bin/console, by a php shebang
+// or an opening <?php tag
+func (p *PHPParser) Sniff(header []byte) bool {
+	if bytes.HasPrefix(header, []byte("<?php")) {
+		return true
+	}
+	if !bytes.HasPrefix(header, []byte("#!")) {
+		return false
+	}
+	shebang, _, _ := bytes.Cut(header, []byte("\n"))
+	// "#!/usr/bin/env php", "#!/usr/bin/php8.3", or "#!/usr/bin/env -S php -d ..."
+	for _, field := range bytes.Fields(shebang[2:]) {
+		name := filepath.Base(string(field))
+		if strings.HasPrefix(name, "php") && strings.Trim(name[3:], "0123456789.") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultExcludes returns the directories skipped in PHP projects: Composer packages,
+// framework storage, and the front-end dependencies many PHP projects carry
+func (p *PHPParser) DefaultExcludes() []string {
+	return []string{"vendor", "storage", "node_modules"}
+}
+
+// SetVersion targets a PHP version, e.g. "7.4": match and fn are function names before
+// the versions that made them keywords, and newer constructs are recorded
+func (p *PHPParser) SetVersion(version string) error {
+	target, err := newSyntaxTarget(version, phpFeatures)
+	if err != nil {
+		return err
+	}
+	p.target = target
+	return nil
 }
 
 func init() {
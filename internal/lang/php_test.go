@@ -3,21 +3,13 @@ package lang
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/boone-studios/tukey/internal/models"
 	"github.com/boone-studios/tukey/internal/progress"
 )
 
-func writePHP(t *testing.T, dir, name, code string) string {
-	t.Helper()
-	path := filepath.Join(dir, name)
-	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
-		t.Fatalf("failed to write fixture: %v", err)
-	}
-	return path
-}
-
 func TestPHPParser_ClassAndMethod(t *testing.T) {
 	tmp := t.TempDir()
 	code := `<?php
@@ -31,7 +23,7 @@ abstract class User {
     const STATUS_ACTIVE = 'active';
 }
 `
-	path := writePHP(t, tmp, "User.php", code)
+	path := writeFixture(t, tmp, "User.php", code)
 
 	p := NewPHPParser()
 	parsed, err := p.ParseFile(path)
@@ -42,6 +34,9 @@ abstract class User {
 	if parsed.Namespace != "App\\Models" {
 		t.Errorf("expected namespace App\\Models, got %q", parsed.Namespace)
 	}
+	if parsed.Language != "php" {
+		t.Errorf("expected language php, got %q", parsed.Language)
+	}
 	if len(parsed.Uses) == 0 || parsed.Uses[0] != "App\\Services\\Mailer" {
 		t.Errorf("expected use statement App\\Services\\Mailer, got %+v", parsed.Uses)
 	}
@@ -82,7 +77,7 @@ $user = new User();
 $user->getName();
 format_phone("123");
 `
-	path := writePHP(t, tmp, "helpers.php", code)
+	path := writeFixture(t, tmp, "helpers.php", code)
 
 	p := NewPHPParser()
 	parsed, err := p.ParseFile(path)
@@ -117,10 +112,71 @@ format_phone("123");
 	}
 }
 
+func TestPHPParser_BracesOnTheirOwnLine(t *testing.T) {
+	tmp := t.TempDir()
+	code := `<?php
+namespace App;
+
+final class Report extends Base
+    implements Exportable
+{
+    public function render(array $rows)
+    {
+        return new Table($rows);
+    }
+
+    public function title(): string
+    {
+        return $this->render([]);
+    }
+}
+
+function helper()
+{
+    return format_title();
+}
+`
+	path := writeFixture(t, tmp, "Report.php", code)
+
+	parsed, err := NewPHPParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	methods := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		if el.Type == "method" || el.Type == "function" {
+			methods[el.Name] = el
+		}
+	}
+	if m := methods["render"]; m.Type != "method" || m.ClassName != "Report" || m.Line != 7 {
+		t.Errorf("expected method render in Report on line 7, got %+v", m)
+	}
+	if m := methods["title"]; m.Type != "method" || m.ReturnType != "string" || m.Line != 12 {
+		t.Errorf("expected method title returning string on line 12, got %+v", m)
+	}
+	if f := methods["helper"]; f.Type != "function" || f.ClassName != "" || f.Line != 18 {
+		t.Errorf("expected function helper on line 18, got %+v", f)
+	}
+
+	contexts := make(map[string]string)
+	for _, u := range parsed.Usage {
+		contexts[u.Type+" "+u.Name] = u.Context
+	}
+	if contexts["implements Exportable"] != "Report" || contexts["instantiation Table"] != "render" {
+		t.Errorf("expected the class body's usage in its methods, got %v", contexts)
+	}
+	if contexts["function_call format_title"] != "helper" {
+		t.Errorf("expected the function body's call in helper, got %v", contexts)
+	}
+	if parsed.Lines != 21 {
+		t.Errorf("expected 21 lines, got %d", parsed.Lines)
+	}
+}
+
 func TestPHPParser_ProcessFilesConcurrently(t *testing.T) {
 	tmp := t.TempDir()
-	writePHP(t, tmp, "One.php", "<?php class One {}")
-	writePHP(t, tmp, "Two.php", "<?php class Two {}")
+	writeFixture(t, tmp, "One.php", "<?php class One {}")
+	writeFixture(t, tmp, "Two.php", "<?php class Two {}")
 
 	files := []models.FileInfo{
 		{Path: filepath.Join(tmp, "One.php"), RelativePath: "One.php"},
@@ -138,6 +194,33 @@ func TestPHPParser_ProcessFilesConcurrently(t *testing.T) {
 	}
 }
 
+func TestPHPParser_Sniff(t *testing.T) {
+	p := NewPHPParser()
+	for header, want := range map[string]bool{
+		"#!/usr/bin/env php\n<?php\n":           true,
+		"#!/usr/bin/php8.3\n<?php":              true,
+		"#!/usr/bin/env -S php -d memory=1G\n":  true,
+		"<?php\nrequire 'vendor/autoload.php';": true,
+		"#!/usr/bin/env phpunit\n":              false,
+		"#!/bin/sh\nexec php artisan \"$@\"\n":  false,
+		"Just a README":                         false,
+	} {
+		if got := p.Sniff([]byte(header)); got != want {
+			t.Errorf("Sniff(%q) = %v, want %v", header, got, want)
+		}
+	}
+
+	tmp := t.TempDir()
+	path := writeFixture(t, tmp, "console", "#!/usr/bin/env php\n<?php\n\nclass Console\n{\n    public function run() {}\n}\n")
+	parsed, err := p.ProcessFiles([]models.FileInfo{{Path: path, RelativePath: "console", Language: "php"}}, progress.NewProgressBar(1, "Testing parser"))
+	if err != nil || len(parsed) != 1 {
+		t.Fatalf("ProcessFiles error: %v", err)
+	}
+	if len(parsed[0].Elements) == 0 || parsed[0].Elements[0].Name != "Console" {
+		t.Errorf("expected the script's class, got %+v", parsed[0].Elements)
+	}
+}
+
 func TestPHPParser_EnumsAndFinalClasses(t *testing.T) {
 	tmp := t.TempDir()
 	code := `<?php
@@ -153,7 +236,7 @@ enum Status: string implements BackedEnum {
     case Draft = 'draft';
 }
 `
-	path := writePHP(t, tmp, "EnumAndFinal.php", code)
+	path := writeFixture(t, tmp, "EnumAndFinal.php", code)
 
 	p := NewPHPParser()
 	parsed, err := p.ParseFile(path)
@@ -207,3 +290,423 @@ enum Status: string implements BackedEnum {
 			foundFinalClass, foundEnum, foundTrait, foundUsesTrait, extendsUsage, implementsUsage, enumImplements, traitUseEdge)
 	}
 }
+
+func TestPHPParser_TypeReferences(t *testing.T) {
+	tmp := t.TempDir()
+	code := `<?php
+class OrderService {
+    public function place(?Cart $cart, int $qty, Logger|Tracer $log): Order {
+        if ($cart instanceof \App\Models\Cart) {
+            try {
+                return $cart;
+            } catch (NotFoundException | AuthException $e) {
+                return null;
+            }
+        }
+    }
+}
+`
+	path := writeFixture(t, tmp, "OrderService.php", code)
+
+	p := NewPHPParser()
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, u := range parsed.Usage {
+		if u.Type == "type_reference" {
+			if u.Context != "place" {
+				t.Errorf("expected type reference context place, got %q", u.Context)
+			}
+			got[u.Name] = true
+		}
+	}
+
+	for _, want := range []string{"Cart", "Logger", "Tracer", "Order", "\\App\\Models\\Cart", "NotFoundException", "AuthException"} {
+		if !got[want] {
+			t.Errorf("expected type_reference to %s, got %v", want, got)
+		}
+	}
+	if got["int"] {
+		t.Errorf("scalar types should not be recorded as type references")
+	}
+
+	foundPlace := false
+	for _, el := range parsed.Elements {
+		if el.Type != "method" || el.Name != "place" {
+			continue
+		}
+		foundPlace = true
+		wantTypes := []string{"?Cart", "int", "Logger|Tracer"}
+		if len(el.ParamTypes) != len(wantTypes) {
+			t.Fatalf("expected param types %v, got %v", wantTypes, el.ParamTypes)
+		}
+		for i, want := range wantTypes {
+			if el.ParamTypes[i] != want {
+				t.Errorf("param %d: expected type %q, got %q", i, want, el.ParamTypes[i])
+			}
+		}
+		if el.ReturnType != "Order" {
+			t.Errorf("expected return type Order, got %q", el.ReturnType)
+		}
+	}
+	if !foundPlace {
+		t.Errorf("expected method place to be parsed")
+	}
+}
+
+func TestPHPParser_TraitAdaptations(t *testing.T) {
+	tmp := t.TempDir()
+	code := `<?php
+class Talker {
+    use A, B {
+        B::smallTalk insteadof A;
+        A::bigTalk insteadof B;
+        B::bigTalk as protected talk;
+    }
+
+    public function speak() {
+        $this->talk();
+        self::smallTalk();
+    }
+}
+`
+	path := writeFixture(t, tmp, "Talker.php", code)
+
+	p := NewPHPParser()
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	if len(parsed.TraitAdaptations) != 3 {
+		t.Fatalf("expected 3 trait adaptations, got %+v", parsed.TraitAdaptations)
+	}
+	first := parsed.TraitAdaptations[0]
+	if first.Trait != "B" || first.Method != "smallTalk" || len(first.InsteadOf) != 1 || first.InsteadOf[0] != "A" {
+		t.Errorf("unexpected insteadof rule: %+v", first)
+	}
+	alias := parsed.TraitAdaptations[2]
+	if alias.Trait != "B" || alias.Method != "bigTalk" || alias.Alias != "talk" || alias.Visibility != "protected" {
+		t.Errorf("unexpected alias rule: %+v", alias)
+	}
+
+	var traitUses int
+	for _, u := range parsed.Usage {
+		if u.Type == "uses_trait" {
+			traitUses++
+		}
+		if u.Type == "static_call" && u.Line >= 4 && u.Line <= 6 {
+			t.Errorf("adaptation rule recorded as static call: %+v", u)
+		}
+		if u.Type == "method_call" && u.Name == "talk" && u.Receiver != "$this" {
+			t.Errorf("expected $this receiver, got %q", u.Receiver)
+		}
+	}
+	if traitUses != 2 {
+		t.Errorf("expected 2 uses_trait usages, got %d", traitUses)
+	}
+}
+
+func TestPHPParser_ModernSyntax(t *testing.T) {
+	tmp := t.TempDir()
+	code := `<?php
+namespace App;
+
+#[Entity(new Table('users'))]
+final readonly class Account {
+    final public const string PREFIX = 'acct';
+    private const LIMIT = 10;
+    public readonly ?Owner $owner;
+    protected static int $count = 0;
+
+    public function __construct(
+        private readonly Clock $clock,
+        public string $name,
+        private Logger $logger = new NullLogger(),
+    ) {}
+
+    final public function label(): string {
+        static $cache = [];
+        $formatter = format_label(...);
+        return match ($this->name) {
+            'admin' => Role::Admin,
+            default => $formatter($this->name),
+        };
+    }
+}
+`
+	path := writeFixture(t, tmp, "Account.php", code)
+
+	p := NewPHPParser()
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	elements := map[string]models.CodeElement{}
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Name] = el
+	}
+
+	if class, ok := elements["class:Account"]; !ok || !class.IsReadonly {
+		t.Errorf("expected readonly class Account, got %+v", class)
+	}
+	for _, name := range []string{"PREFIX", "LIMIT"} {
+		if _, ok := elements["constant:"+name]; !ok {
+			t.Errorf("expected typed/visible constant %s", name)
+		}
+	}
+	if c := elements["constant:LIMIT"]; c.Visibility != "private" {
+		t.Errorf("expected private LIMIT, got %q", c.Visibility)
+	}
+	if owner := elements["property:owner"]; !owner.IsReadonly || owner.Visibility != "public" {
+		t.Errorf("expected public readonly owner property, got %+v", owner)
+	}
+	if count := elements["property:count"]; !count.IsStatic {
+		t.Errorf("expected static typed property count, got %+v", count)
+	}
+	if _, ok := elements["property:cache"]; ok {
+		t.Errorf("function-local static variable recorded as property")
+	}
+	if clock := elements["property:clock"]; !clock.IsReadonly || clock.Visibility != "private" {
+		t.Errorf("expected promoted readonly clock property, got %+v", clock)
+	}
+	if _, ok := elements["property:logger"]; !ok {
+		t.Errorf("expected promoted logger property")
+	}
+
+	ctor := elements["method:__construct"]
+	if len(ctor.Parameters) != 3 || ctor.Parameters[2] != "logger" {
+		t.Errorf("expected 3 constructor parameters, got %v", ctor.Parameters)
+	}
+	if ctor.Line != 11 {
+		t.Errorf("expected constructor on line 11, got %d", ctor.Line)
+	}
+	if label := elements["method:label"]; label.ReturnType != "string" || label.Line != 17 {
+		t.Errorf("expected final method label on line 17 returning string, got %+v", label)
+	}
+
+	usages := map[string]string{}
+	for _, u := range parsed.Usage {
+		usages[u.Type+":"+u.Name] = u.Context
+	}
+	for _, want := range []string{"attribute:Entity", "instantiation:Table", "instantiation:NullLogger", "function_call:format_label", "static_call:Role::Admin"} {
+		if _, ok := usages[want]; !ok {
+			t.Errorf("expected usage %s, got %v", want, usages)
+		}
+	}
+	for _, unwanted := range []string{"function_call:match", "function_call:NullLogger", "function_call:Table"} {
+		if _, ok := usages[unwanted]; ok {
+			t.Errorf("unexpected usage %s", unwanted)
+		}
+	}
+}
+
+func TestPHPParser_SignatureParensInStrings(t *testing.T) {
+	tmp := t.TempDir()
+	code := `<?php
+class Joiner {
+    public function join(array $parts, $sep = "(", $open = '/* (') { // split on "("
+        return implode($sep, $parts);
+    }
+
+    public function wrap(
+        string $text, // wraps in (
+        string $close = ")(",
+    ): string {
+        return $text;
+    }
+}
+
+class Splitter {
+    public function split($text) {
+        return explode(',', $text);
+    }
+}
+`
+	path := writeFixture(t, tmp, "Joiner.php", code)
+
+	p := NewPHPParser()
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	lines := map[string]int{}
+	for _, el := range parsed.Elements {
+		lines[el.Type+":"+el.Name] = el.Line
+	}
+	for want, line := range map[string]int{"method:join": 3, "method:wrap": 7, "class:Splitter": 15, "method:split": 16} {
+		if lines[want] != line {
+			t.Errorf("expected %s on line %d, got elements %v", want, line, lines)
+		}
+	}
+}
+
+func TestPHPParser_WordPressHooks(t *testing.T) {
+	tmp := t.TempDir()
+	code := `<?php
+add_action('init', 'my_plugin_init');
+add_filter( "the_title", [ $this, 'filter_title' ], 10, 2 );
+add_action('admin_menu', array(Admin::class, 'register'));
+add_action('wp_footer', function () { echo 'hi'; });
+do_action('my_plugin_loaded', $plugin);
+`
+	path := writeFixture(t, tmp, "plugin.php", code)
+
+	p := NewPHPParser()
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	callbacks := map[string]string{}
+	var fired []string
+	for _, u := range parsed.Usage {
+		switch u.Type {
+		case "hook_register":
+			callbacks[u.Name] = u.Callback
+		case "hook_fire":
+			fired = append(fired, u.Name)
+		}
+	}
+
+	want := map[string]string{
+		"init":       "my_plugin_init",
+		"the_title":  "$this->filter_title",
+		"admin_menu": "Admin::register",
+		"wp_footer":  "",
+	}
+	for hook, callback := range want {
+		got, ok := callbacks[hook]
+		if !ok {
+			t.Errorf("expected registration for hook %s", hook)
+		} else if got != callback {
+			t.Errorf("hook %s: expected callback %q, got %q", hook, callback, got)
+		}
+	}
+	if len(fired) != 1 || fired[0] != "my_plugin_loaded" {
+		t.Errorf("expected my_plugin_loaded to be fired, got %v", fired)
+	}
+}
+
+func TestPHPParser_RoutesAndAssets(t *testing.T) {
+	tmp := t.TempDir()
+	os.Mkdir(filepath.Join(tmp, "routes"), 0755)
+	routes := writeFixture(t, tmp, "routes/api.php", `<?php
+Route::get('/users/{id}', [UserController::class, 'show']);
+Route::middleware('auth')->post('users', 'UserController@store');
+Route::delete('/users/{id}', DeleteUser::class);
+Route::get('/', fn () => view('home'));
+`)
+	controller := writeFixture(t, tmp, "ReportController.php", `<?php
+#[Route('/reports')]
+class ReportController {
+    #[Route('/{id}', name: 'report', methods: ['GET', 'HEAD'])]
+    public function show($id) {}
+
+    #[Route(path: '/export')]
+    public function export() {
+        return view('report', ['script' => mix('js/report.js')]);
+    }
+}
+`)
+	view := writeFixture(t, tmp, "app.blade.php", `@vite(['resources/css/app.css', 'resources/js/app.js'])
+<script src="{{ Vite::asset('resources/js/chart.js') }}"></script>
+`)
+
+	p := NewPHPParser()
+	found := func(path, usageType string) []models.UsageElement {
+		t.Helper()
+		parsed, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		var usage []models.UsageElement
+		for _, u := range parsed.Usage {
+			if u.Type == usageType {
+				usage = append(usage, u)
+			}
+		}
+		return usage
+	}
+
+	want := map[string]string{
+		"GET /api/users/{id}":    "UserController::show",
+		"POST /api/users":        "UserController::store",
+		"DELETE /api/users/{id}": "DeleteUser::__invoke",
+		"GET /api":               "",
+	}
+	got := found(routes, "route")
+	if len(got) != len(want) {
+		t.Fatalf("expected %d routes, got %+v", len(want), got)
+	}
+	for _, route := range got {
+		if callback, ok := want[route.Name]; !ok || route.Callback != callback {
+			t.Errorf("unexpected route %s -> %q", route.Name, route.Callback)
+		}
+	}
+
+	got = found(controller, "route")
+	var names []string
+	for _, route := range got {
+		names = append(names, route.Name+" -> "+route.Callback)
+	}
+	wantNames := []string{
+		"GET /reports/{id} -> ReportController::show",
+		"HEAD /reports/{id} -> ReportController::show",
+		"ANY /reports/export -> ReportController::export",
+	}
+	if strings.Join(names, ", ") != strings.Join(wantNames, ", ") {
+		t.Errorf("expected attribute routes %v, got %v", wantNames, names)
+	}
+	if assets := found(controller, "asset"); len(assets) != 1 || assets[0].Name != "js/report.js" || assets[0].Context != "export" {
+		t.Errorf("expected mix() asset in export, got %+v", assets)
+	}
+
+	var assets []string
+	for _, asset := range found(view, "asset") {
+		assets = append(assets, asset.Name)
+	}
+	if strings.Join(assets, ", ") != "resources/css/app.css, resources/js/app.js, resources/js/chart.js" {
+		t.Errorf("unexpected Vite assets %v", assets)
+	}
+}
+
+func TestPHPParser_Docblocks(t *testing.T) {
+	code := `<?php
+/**
+ * An invoice
+ */
+#[Entity]
+class Invoice {
+    /** Total with tax */
+    public function total() {}
+
+    /* Not a docblock */
+    public function send() {}
+    // Neither is this
+    public function round() {}
+}
+`
+	parsed, err := NewPHPParser().ParseFile(writeFixture(t, t.TempDir(), "Invoice.php", code))
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	documented := map[string]bool{}
+	for _, el := range parsed.Elements {
+		documented[el.Name] = el.Documented
+	}
+	want := map[string]bool{"Invoice": true, "total": true, "send": false, "round": false}
+	for name, doc := range want {
+		if documented[name] != doc {
+			t.Errorf("expected %s documented=%v, got %v", name, doc, documented[name])
+		}
+	}
+	if parsed.Lines != 14 || parsed.CommentLines != 6 {
+		t.Errorf("expected 6 comment lines of 14, got %d of %d", parsed.CommentLines, parsed.Lines)
+	}
+}
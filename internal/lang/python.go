@@ -0,0 +1,652 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// PythonParser handles parsing of Python files
+type PythonParser struct {
+	// Regex patterns for different Python constructs
+	importPattern     *regexp.Regexp
+	fromImportPattern *regexp.Regexp
+	classPattern      *regexp.Regexp
+	functionPattern   *regexp.Regexp
+	decoratorPattern  *regexp.Regexp
+	constantPattern   *regexp.Regexp
+	memberCallPattern *regexp.Regexp
+	callPattern       *regexp.Regexp
+}
+
+// pyScope is an open class or function body
+type pyScope struct {
+	kind   string // "class", "function", or "nested" for classes and functions declared inside them
+	name   string
+	indent int // Indentation of the declaration; the body ends at a line indented no deeper
+}
+
+// pyDecorator is a decorator waiting for the class or function it decorates
+type pyDecorator struct {
+	name     string
+	receiver string // Module or object the decorator is accessed on ("app" in @app.route)
+	args     string // Arguments, for the usage they hold
+	line     int
+}
+
+// NewPythonParser creates a new Python parser with compiled regex patterns
+func NewPythonParser() *PythonParser {
+	return &PythonParser{
+		// Imports: import os, app.utils as utils
+		importPattern: regexp.MustCompile(`^import\s+(.+)$`),
+
+		// From imports: from .models import User, Post as Article; from . import views
+		fromImportPattern: regexp.MustCompile(`^from\s+(\.*)([\w.]*)\s+import\s+(.+)$`),
+
+		// Class: class UserAdmin(admin.ModelAdmin):
+		classPattern: regexp.MustCompile(`^class\s+([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\s*(?:\((.*)\))?\s*:`),
+
+		// Function: async def fetch_user(user_id: int) -> User:
+		functionPattern: regexp.MustCompile(`^(?:async\s+)?def\s+([A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\s*\(`),
+
+		// Decorators: @login_required, @app.route("/users", methods=["POST"])
+		decoratorPattern: regexp.MustCompile(`^@\s*([A-Za-z_][\w.]*)\s*(?:\((.*)\))?`),
+
+		// Module-level constants: MAX_RETRIES = 3, DEFAULT_TIMEOUT: float = 2.5
+		constantPattern: regexp.MustCompile(`^([A-Z][A-Z0-9_]*)\s*(?::[^=]+)?=[^=]`),
+
+		// Member calls: self.save(), utils.slugify(title), models.User.objects.get()
+		memberCallPattern: regexp.MustCompile(`\b([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\.([A-Za-z_]\w*)\s*\(`),
+
+		// Calls: slugify(title), UserService()
+		callPattern: regexp.MustCompile(`\b([A-Za-z_]\w*)\s*\(`),
+	}
+}
+
+// ParseFile analyzes a single Python file and extracts all elements
+func (p *PythonParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	return p.parseModule(filePath, moduleName(filePath), packageRoot(filePath))
+}
+
+// parseModule parses a file using module as the namespace of its elements, resolving
+// absolute imports against root
+func (p *PythonParser) parseModule(filePath, module, root string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	parsed := &models.ParsedFile{
+		Path:      filePath,
+		Language:  "python",
+		Namespace: module,
+		Elements:  []models.CodeElement{},
+		Usage:     []models.UsageElement{},
+		Uses:      []string{},
+		Imports:   []models.ImportBinding{},
+	}
+	roots := importRoots(filePath, root)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	var scopes []pyScope
+	var decorators []pyDecorator
+	docstring := -1 // Element whose body has just opened, so may start with a docstring
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+
+		raw := scanner.Text()
+		if marker, ok := debtMarker(raw, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		// Join a logical line continued by open brackets, a backslash, or a triple-quoted string
+		code, bare, quote := stripPythonLine(raw, "")
+		for (quote != "" || bracketBalance(bare) > 0 || strings.HasSuffix(code, `\`)) && scanner.Scan() {
+			joinedLines++
+			if marker, ok := debtMarker(scanner.Text(), lineNum+joinedLines); ok {
+				parsed.Debt = append(parsed.Debt, marker)
+			}
+			var next, nextBare string
+			next, nextBare, quote = stripPythonLine(scanner.Text(), quote)
+			code = strings.TrimSuffix(code, `\`) + " " + strings.TrimSpace(next)
+			bare = strings.TrimSuffix(bare, `\`) + " " + strings.TrimSpace(nextBare)
+		}
+		if strings.TrimSpace(code) == "" {
+			if strings.TrimSpace(raw) != "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Dedenting closes the bodies the line is no longer inside
+		indent := len(raw) - len(strings.TrimLeft(raw, " \t"))
+		for len(scopes) > 0 && indent <= scopes[len(scopes)-1].indent {
+			scopes = scopes[:len(scopes)-1]
+		}
+		stmt := strings.TrimSpace(bare)
+
+		// A string as the first statement of a body is its docstring
+		if docstring != -1 {
+			documented := isStringStatement(stmt)
+			parsed.Elements[docstring].Documented = documented
+			docstring = -1
+			if documented {
+				continue
+			}
+		}
+
+		if matches := p.decoratorPattern.FindStringSubmatch(stmt); matches != nil {
+			receiver, name := splitMember(matches[1])
+			decorators = append(decorators, pyDecorator{name: name, receiver: receiver, args: matches[2], line: lineNum})
+			continue
+		}
+
+		inClass, context := pyContext(scopes)
+		p.parseImports(stmt, lineNum, filePath, roots, parsed)
+
+		if matches := p.classPattern.FindStringSubmatch(stmt); matches != nil {
+			name := matches[1]
+			if len(scopes) > 0 {
+				scopes = append(scopes, pyScope{kind: "nested", name: name, indent: indent})
+				decorators = nil
+				continue
+			}
+			parsed.Elements = append(parsed.Elements, models.CodeElement{
+				Type:       "class",
+				Name:       name,
+				Namespace:  module,
+				Visibility: pyVisibility(name),
+				Line:       lineNum,
+				File:       filePath,
+			})
+			docstring = len(parsed.Elements) - 1
+			for _, base := range splitParams(matches[2]) {
+				base = strings.TrimSpace(base)
+				if idx := strings.Index(base, "["); idx != -1 {
+					base = base[:idx] // Generic[T]
+				}
+				if base == "" || base == "object" || strings.Contains(base, "=") {
+					continue // metaclass= and other keywords
+				}
+				receiver, baseName := splitMember(base)
+				parsed.Usage = append(parsed.Usage, models.UsageElement{
+					Type:     "extends",
+					Name:     baseName,
+					Context:  name,
+					Receiver: receiver,
+					Line:     lineNum,
+				})
+			}
+			p.addDecorators(decorators, name, parsed)
+			decorators = nil
+			scopes = append(scopes, pyScope{kind: "class", name: name, indent: indent})
+			continue
+		}
+
+		if loc := p.functionPattern.FindStringSubmatchIndex(stmt); loc != nil {
+			name := stmt[loc[2]:loc[3]]
+			rest := stmt[loc[1]:]
+			var inner pyScope
+			switch {
+			case len(scopes) == 0:
+				inner = pyScope{kind: "function", name: name, indent: indent}
+				parsed.Elements = append(parsed.Elements, p.function(name, "", rest, nil, module, filePath, lineNum))
+			case scopes[len(scopes)-1].kind == "class":
+				inner = pyScope{kind: "function", name: name, indent: indent}
+				parsed.Elements = append(parsed.Elements, p.function(name, inClass, rest, decorators, module, filePath, lineNum))
+			default:
+				inner = pyScope{kind: "nested", name: name, indent: indent}
+			}
+			if inner.kind == "function" {
+				docstring = len(parsed.Elements) - 1
+				context = name
+			}
+			p.addDecorators(decorators, context, parsed)
+			decorators = nil
+			scopes = append(scopes, inner)
+			p.parseUsage(rest, lineNum, context, parsed) // Default values and one-line bodies
+			continue
+		}
+		decorators = nil
+
+		if matches := p.constantPattern.FindStringSubmatch(stmt); matches != nil && len(scopes) == 0 {
+			parsed.Elements = append(parsed.Elements, models.CodeElement{
+				Type:       "constant",
+				Name:       matches[1],
+				Namespace:  module,
+				Visibility: "public",
+				Line:       lineNum,
+				File:       filePath,
+			})
+		}
+
+		p.parseUsage(stmt, lineNum, context, parsed)
+		function := context
+		if function == inClass {
+			function = ""
+		}
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, inClass, function)...)
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// function builds the element for a function, or a method of className. rest is the
+// text after the opening parenthesis of its parameters.
+func (p *PythonParser) function(name, className, rest string, decorators []pyDecorator, module, filePath string, lineNum int) models.CodeElement {
+	params, returns := pySignature(rest)
+	element := models.CodeElement{
+		Type:       "function",
+		Name:       name,
+		Namespace:  module,
+		ClassName:  className,
+		Visibility: pyVisibility(name),
+		Line:       lineNum,
+		File:       filePath,
+		ReturnType: pyTypeNames(returns),
+		Parameters: []string{},
+	}
+	isStatic := false
+	for _, decorator := range decorators {
+		isStatic = isStatic || decorator.name == "staticmethod"
+		element.IsStatic = element.IsStatic || decorator.name == "staticmethod" || decorator.name == "classmethod"
+		element.IsAbstract = element.IsAbstract || decorator.name == "abstractmethod"
+	}
+	if className != "" {
+		element.Type = "method"
+		if !isStatic && len(params) > 0 {
+			params = params[1:] // self, or cls for class methods
+		}
+	}
+
+	for _, param := range params {
+		param = strings.TrimLeft(strings.TrimSpace(param), "*")
+		if eq := strings.Index(param, "="); eq != -1 {
+			param = param[:eq]
+		}
+		annotation := ""
+		if colon := strings.Index(param, ":"); colon != -1 {
+			param, annotation = param[:colon], param[colon+1:]
+		}
+		if param = strings.TrimSpace(param); param == "" || param == "/" {
+			continue // Markers for keyword-only and positional-only parameters
+		}
+		element.Parameters = append(element.Parameters, param)
+		element.ParamTypes = append(element.ParamTypes, pyTypeNames(annotation))
+	}
+	return element
+}
+
+// addDecorators records the decorators applied to a class or function as its usage
+func (p *PythonParser) addDecorators(decorators []pyDecorator, context string, parsed *models.ParsedFile) {
+	for _, decorator := range decorators {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     "decorator",
+			Name:     decorator.name,
+			Context:  context,
+			Receiver: decorator.receiver,
+			Line:     decorator.line,
+		})
+		p.parseUsage(decorator.args, decorator.line, context, parsed)
+	}
+}
+
+// parseImports records import and from-import bindings, resolving modules to files
+func (p *PythonParser) parseImports(stmt string, lineNum int, filePath string, roots []string, parsed *models.ParsedFile) {
+	addImport := func(local, imported, source, resolved string) {
+		parsed.Imports = append(parsed.Imports, models.ImportBinding{
+			Local:    local,
+			Imported: imported,
+			Source:   source,
+			Resolved: resolved,
+			Kind:     "import",
+			Line:     lineNum,
+		})
+	}
+
+	if matches := p.importPattern.FindStringSubmatch(stmt); matches != nil {
+		for _, binding := range parseBindingList(matches[1], " as ") {
+			parsed.Uses = append(parsed.Uses, binding[0])
+			addImport(binding[1], "*", binding[0], resolvePythonModule(roots, binding[0]))
+		}
+		return
+	}
+
+	matches := p.fromImportPattern.FindStringSubmatch(stmt)
+	if matches == nil {
+		return
+	}
+	source := matches[1] + matches[2]
+	parsed.Uses = append(parsed.Uses, source)
+
+	// Relative imports start from the importing file's package, one level up per extra dot
+	bases := roots
+	if dots := len(matches[1]); dots > 0 {
+		base := filepath.Dir(filePath)
+		for i := 1; i < dots; i++ {
+			base = filepath.Dir(base)
+		}
+		bases = []string{base}
+	}
+	module := resolvePythonModule(bases, matches[2])
+
+	for _, binding := range parseBindingList(strings.Trim(matches[3], "() "), " as ") {
+		if binding[0] == "*" {
+			addImport("", "*", source, module)
+			continue
+		}
+		// "from . import views" binds a submodule rather than a name the package defines
+		if submodule := resolvePythonModule(bases, strings.TrimPrefix(matches[2]+"."+binding[0], ".")); submodule != "" {
+			addImport(binding[1], "*", source, submodule)
+			continue
+		}
+		addImport(binding[1], binding[0], source, module)
+	}
+}
+
+// parseUsage finds references to other code elements in a statement with string
+// contents removed
+func (p *PythonParser) parseUsage(stmt string, lineNum int, context string, parsed *models.ParsedFile) {
+	for _, match := range p.memberCallPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		if match[0] > 0 && stmt[match[0]-1] == '.' {
+			continue // Called on a call's result: get_user().save()
+		}
+		receiver, name := stmt[match[2]:match[3]], stmt[match[4]:match[5]]
+		usageType := "method_call"
+		if isCapitalized(name) && receiver != "self" && receiver != "cls" {
+			usageType = "instantiation" // models.User(...)
+		}
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+		})
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		if match[0] > 0 && stmt[match[0]-1] == '.' {
+			continue // A member call
+		}
+		name := stmt[match[2]:match[3]]
+		if isPythonKeyword(name) || isPythonBuiltin(name) {
+			continue
+		}
+		usageType := "function_call"
+		if isCapitalized(name) {
+			usageType = "instantiation"
+		}
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    name,
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// pyContext returns the innermost enclosing class and the name usage is attributed to:
+// the enclosing function or method, or the class for statements in its body
+func pyContext(scopes []pyScope) (string, string) {
+	inClass, context := "", ""
+	for i := len(scopes) - 1; i >= 0; i-- {
+		switch scopes[i].kind {
+		case "class":
+			inClass = scopes[i].name
+			if context == "" {
+				context = inClass
+			}
+		case "function":
+			if context == "" {
+				context = scopes[i].name
+			}
+		}
+	}
+	return inClass, context
+}
+
+// pySignature splits the text after a def's "(" into its parameters and return annotation
+func pySignature(rest string) ([]string, string) {
+	depth := 1
+	for i, r := range rest {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		}
+		if depth > 0 {
+			continue
+		}
+		params := splitParams(rest[:i])
+		returns := ""
+		if arrow := strings.Index(rest[i:], "->"); arrow != -1 {
+			returns = rest[i+arrow+2:]
+			if colon := strings.LastIndex(returns, ":"); colon != -1 {
+				returns = returns[:colon]
+			}
+		}
+		return params, returns
+	}
+	return splitParams(rest), ""
+}
+
+// pyTypeNames rewrites an annotation such as "Optional[User]" as the type names it holds,
+// separated by "|" ("Optional|User")
+func pyTypeNames(annotation string) string {
+	fields := strings.FieldsFunc(annotation, func(r rune) bool {
+		return strings.ContainsRune(`[], |"'`, r)
+	})
+	return strings.Join(fields, "|")
+}
+
+// pyVisibility follows Python's naming conventions: __name is private, _name protected
+func pyVisibility(name string) string {
+	switch {
+	case strings.HasPrefix(name, "__") && !strings.HasSuffix(name, "__"):
+		return "private"
+	case strings.HasPrefix(name, "_") && !strings.HasPrefix(name, "__"):
+		return "protected"
+	}
+	return "public"
+}
+
+// stripPythonLine removes a comment from a line, returning the code and the code with
+// string contents blanked out. quote is the delimiter of a string left open by the
+// previous line, and the one left open by this line is returned.
+func stripPythonLine(line, quote string) (string, string, string) {
+	var code, bare strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != "":
+			code.WriteByte(c)
+			if c == '\\' && i+1 < len(line) {
+				code.WriteByte(line[i+1])
+				i++
+			} else if strings.HasPrefix(line[i:], quote) {
+				code.WriteString(quote[1:])
+				bare.WriteString(quote)
+				i += len(quote) - 1
+				quote = ""
+			}
+		case c == '#':
+			return code.String(), bare.String(), ""
+		case c == '"' || c == '\'':
+			quote = string(c)
+			if triple := strings.Repeat(quote, 3); strings.HasPrefix(line[i:], triple) {
+				quote = triple
+			}
+			code.WriteString(quote)
+			bare.WriteString(quote)
+			i += len(quote) - 1
+		default:
+			code.WriteByte(c)
+			bare.WriteByte(c)
+		}
+	}
+	if len(quote) == 1 {
+		quote = "" // Only triple-quoted strings span lines
+	}
+	return code.String(), bare.String(), quote
+}
+
+// bracketBalance counts the brackets a line leaves open
+func bracketBalance(line string) int {
+	balance := 0
+	for _, r := range line {
+		switch r {
+		case '(', '[', '{':
+			balance++
+		case ')', ']', '}':
+			balance--
+		}
+	}
+	return balance
+}
+
+// isStringStatement reports whether a statement with string contents blanked out is
+// only a string literal
+func isStringStatement(stmt string) bool {
+	return stmt != "" && strings.Trim(stmt, `"'rRbBuUfF `) == ""
+}
+
+// isCapitalized reports whether a name starts with an upper-case letter, which by
+// convention names a class
+func isCapitalized(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z'
+}
+
+// importRoots returns the directories absolute imports are resolved from: the project
+// root, its src directory, and the directory holding the file's top-level package
+func importRoots(filePath, root string) []string {
+	roots := []string{root, filepath.Join(root, "src")}
+	if top := packageRoot(filePath); top != root {
+		roots = append(roots, top)
+	}
+	return roots
+}
+
+// packageRoot returns the directory above the outermost package (a directory with an
+// __init__.py) holding the file
+func packageRoot(filePath string) string {
+	dir := filepath.Dir(filePath)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "__init__.py")); err != nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// resolvePythonModule finds the file a dotted module path names under one of the
+// roots: a module file or a package's __init__.py. It returns "" for modules outside
+// the project, such as the standard library and installed packages.
+func resolvePythonModule(roots []string, module string) string {
+	for _, root := range roots {
+		path := filepath.Join(append([]string{root}, strings.Split(module, ".")...)...)
+		for _, candidate := range []string{path + ".py", filepath.Join(path, "__init__.py")} {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// isPythonKeyword checks if a name is a Python keyword that can precede "("
+func isPythonKeyword(name string) bool {
+	switch name {
+	case "if", "elif", "while", "for", "in", "not", "and", "or", "is", "return", "yield",
+		"await", "assert", "del", "with", "as", "except", "raise", "lambda", "print", "exec",
+		"def", "class", "import", "from", "global", "nonlocal", "match", "case":
+		return true
+	}
+	return false
+}
+
+// isPythonBuiltin checks if a function name is a Python built-in
+func isPythonBuiltin(name string) bool {
+	switch name {
+	case "abs", "all", "any", "ascii", "bin", "bool", "breakpoint", "bytearray", "bytes",
+		"callable", "chr", "classmethod", "compile", "complex", "delattr", "dict", "dir",
+		"divmod", "enumerate", "eval", "filter", "float", "format", "frozenset", "getattr",
+		"globals", "hasattr", "hash", "help", "hex", "id", "input", "int", "isinstance",
+		"issubclass", "iter", "len", "list", "locals", "map", "max", "memoryview", "min",
+		"next", "object", "oct", "open", "ord", "pow", "property", "range", "repr",
+		"reversed", "round", "set", "setattr", "slice", "sorted", "staticmethod", "str",
+		"sum", "super", "tuple", "type", "vars", "zip", "__import__",
+		"Exception", "BaseException", "ValueError", "TypeError", "KeyError", "IndexError",
+		"AttributeError", "RuntimeError", "NotImplementedError", "StopIteration",
+		"OSError", "IOError", "FileNotFoundError", "PermissionError", "LookupError",
+		"ImportError", "AssertionError", "ZeroDivisionError", "TimeoutError":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Python files concurrently
+func (p *PythonParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) {
+		root := filepath.Clean(strings.TrimSuffix(file.Path, file.RelativePath))
+		return p.parseModule(file.Path, moduleName(file.RelativePath), root)
+	})
+}
+
+// Language returns the language name for this parser
+func (p *PythonParser) Language() string {
+	return "python"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *PythonParser) FileExtensions() []string {
+	return []string{".py"}
+}
+
+// Sniff recognizes extensionless Python scripts, such as manage.py wrappers, by a
+// python shebang ("#!/usr/bin/env python3")
+func (p *PythonParser) Sniff(header []byte) bool {
+	if !bytes.HasPrefix(header, []byte("#!")) {
+		return false
+	}
+	shebang, _, _ := bytes.Cut(header, []byte("\n"))
+	for _, field := range bytes.Fields(shebang[2:]) {
+		name := filepath.Base(string(field))
+		if strings.HasPrefix(name, "python") && strings.Trim(name[6:], "0123456789.") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultExcludes returns the directories skipped in Python projects: virtual
+// environments, installed packages, and bytecode caches
+func (p *PythonParser) DefaultExcludes() []string {
+	return []string{"venv", ".venv", "site-packages", "__pycache__", ".tox"}
+}
+
+func init() {
+	parser.Register(NewPythonParser())
+}
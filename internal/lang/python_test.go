@@ -0,0 +1,199 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestPythonParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `"""Views for the users app."""
+import os
+from django.http import JsonResponse
+from .services import (
+    UserService,
+    slugify as make_slug,
+)
+
+MAX_RESULTS = 50
+
+
+@app.route("/users", methods=["GET"])
+@login_required
+def list_users(request: HttpRequest, *args, limit: Optional[int] = MAX_RESULTS, **kwargs) -> JsonResponse:
+    """Lists users.
+
+    Returns at most limit of them.
+    """
+    service = UserService(os.environ["DB"])  # TODO: inject the service
+    return JsonResponse(service.all(limit))
+
+
+class UserAdmin(admin.ModelAdmin, AuditMixin, metaclass=Registry):
+    class Meta:
+        ordering = ["name"]
+
+    def __init__(self, site):
+        self._site = site
+
+    @staticmethod
+    def title(user):
+        def helper(value):
+            return make_slug(value)
+        return helper(user.name)
+
+    def _render(self, user) -> str:
+        return self.title(user) + "\
+"
+
+
+def _private(): return list_users(None)
+`
+	path := writeFixture(t, tmp, "views.py", code)
+
+	parsed, err := NewPythonParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "python" {
+		t.Errorf("expected language python, got %q", parsed.Language)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Name] = el
+	}
+	for _, key := range []string{"constant:MAX_RESULTS", "function:list_users", "class:UserAdmin",
+		"method:__init__", "method:title", "method:_render", "function:_private"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 7 {
+		t.Errorf("expected no elements from nested classes and functions, got %+v", parsed.Elements)
+	}
+
+	listUsers := elements["function:list_users"]
+	if !listUsers.Documented || elements["class:UserAdmin"].Documented {
+		t.Error("expected only the function with a docstring to be documented")
+	}
+	if got := listUsers.Parameters; len(got) != 4 || got[0] != "request" || got[1] != "args" || got[2] != "limit" {
+		t.Errorf("expected parameters [request args limit kwargs], got %v", got)
+	}
+	if listUsers.ParamTypes[2] != "Optional|int" || listUsers.ReturnType != "JsonResponse" {
+		t.Errorf("expected the annotations' type names, got %v -> %q", listUsers.ParamTypes, listUsers.ReturnType)
+	}
+	if got := elements["method:__init__"].Parameters; len(got) != 1 || got[0] != "site" {
+		t.Errorf("expected self to be dropped, got %v", got)
+	}
+	if title := elements["method:title"]; !title.IsStatic || len(title.Parameters) != 1 {
+		t.Errorf("expected a static method keeping its first parameter, got %+v", title)
+	}
+	if elements["method:_render"].Visibility != "protected" || elements["method:_render"].ClassName != "UserAdmin" {
+		t.Errorf("expected a protected method of UserAdmin, got %+v", elements["method:_render"])
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"decorator:app.route in list_users",
+		"decorator:.login_required in list_users",
+		"instantiation:.UserService in list_users",
+		"instantiation:.JsonResponse in list_users",
+		"method_call:service.all in list_users",
+		"extends:admin.ModelAdmin in UserAdmin",
+		"extends:.AuditMixin in UserAdmin",
+		"decorator:.staticmethod in title",
+		"function_call:.make_slug in title",
+		"function_call:.helper in title",
+		"method_call:self.title in _render",
+		"function_call:.list_users in _private",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"extends:.Registry in UserAdmin", "instantiation:.Meta in UserAdmin"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	imports := make(map[string]models.ImportBinding)
+	for _, binding := range parsed.Imports {
+		imports[binding.Local] = binding
+	}
+	if binding := imports["make_slug"]; binding.Imported != "slugify" || binding.Source != ".services" {
+		t.Errorf("expected the aliased import from a parenthesized list, got %+v", parsed.Imports)
+	}
+	if binding := imports["os"]; binding.Imported != "*" || binding.Resolved != "" {
+		t.Errorf("expected the standard library to stay unresolved, got %+v", binding)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 19 {
+		t.Errorf("expected the TODO on line 19, got %+v", parsed.Debt)
+	}
+}
+
+func TestPythonParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	pkg := filepath.Join(tmp, "shop")
+	if err := os.MkdirAll(filepath.Join(pkg, "orders"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, pkg, "__init__.py", "")
+	writeFixture(t, pkg, "orders/__init__.py", "")
+	writeFixture(t, pkg, "orders/services.py", `class OrderService:
+    def place(self, order):
+        return self._charge(order)
+
+    def _charge(self, order):
+        return order
+`)
+	writeFixture(t, pkg, "orders/views.py", `from .services import OrderService
+from shop.orders import services
+
+
+def checkout(request):
+    return OrderService().place(request)
+
+
+def retry(request):
+    return services.OrderService().place(request)
+`)
+
+	p := NewPythonParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"orders/services.py", "orders/views.py"} {
+		parsed, err := p.ParseFile(filepath.Join(pkg, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+	want := filepath.Join(pkg, "orders", "services.py")
+	for _, binding := range files[1].Imports {
+		if binding.Resolved != want {
+			t.Errorf("expected %s to resolve to %s, got %+v", binding.Source, want, binding)
+		}
+	}
+
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Name] = node
+	}
+	for _, name := range []string{"checkout", "retry"} {
+		if nodes[name] == nil || nodes[name].Dependencies[nodes["OrderService"].ID] == nil {
+			t.Errorf("expected %s to depend on the imported class, got %+v", name, nodes[name])
+		}
+	}
+	if nodes["place"].Dependencies[nodes["_charge"].ID] == nil {
+		t.Errorf("expected self._charge() to resolve to the method, got %+v", nodes["place"].Dependencies)
+	}
+}
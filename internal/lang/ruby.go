@@ -0,0 +1,793 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// RubyParser handles parsing of Ruby files
+type RubyParser struct {
+	requirePattern     *regexp.Regexp
+	modulePattern      *regexp.Regexp
+	classPattern       *regexp.Regexp
+	defPattern         *regexp.Regexp
+	constantPattern    *regexp.Regexp
+	attrPattern        *regexp.Regexp
+	mixinPattern       *regexp.Regexp
+	visibilityPattern  *regexp.Regexp
+	callbackPattern    *regexp.Regexp
+	tableNamePattern   *regexp.Regexp
+	heredocPattern     *regexp.Regexp
+	memberCallPattern  *regexp.Regexp
+	constantRefPattern *regexp.Regexp
+	identifierPattern  *regexp.Regexp
+	assignmentPattern  *regexp.Regexp
+	blockParamPattern  *regexp.Regexp
+	wordPattern        *regexp.Regexp
+}
+
+// rubyScope is a body closed by "end"
+type rubyScope struct {
+	kind       string // "module", "class", "singleton" (class << self), "def", or "block"
+	name       string
+	path       string // Qualified name of a module or class, e.g. `Billing\Invoice`
+	visibility string // Visibility of the methods defined next in a module or class body
+}
+
+// rubyFile is the state of one file's parse
+type rubyFile struct {
+	parsed *models.ParsedFile
+	scopes []rubyScope
+	locals map[string]bool // Parameters and variables of the method being parsed
+}
+
+// NewRubyParser creates a new Ruby parser with compiled regex patterns
+func NewRubyParser() *RubyParser {
+	return &RubyParser{
+		// Requires: require "json", require_relative "../models/user"
+		requirePattern: regexp.MustCompile(`^\s*(require|require_relative)\s*\(?\s*["']([^"']+)["']`),
+
+		// Modules: module Billing, module Admin::Reports
+		modulePattern: regexp.MustCompile(`^module\s+((?:::)?[A-Z]\w*(?:::[A-Z]\w*)*)`),
+
+		// Classes: class Invoice < ApplicationRecord, class Admin::UsersController < BaseController,
+		// class << self
+		classPattern: regexp.MustCompile(`^class\s*(?:(<<\s*self)|((?:::)?[A-Z]\w*(?:::[A-Z]\w*)*)(?:\s*<\s*((?:::)?[A-Z]\w*(?:::[A-Z]\w*)*))?)`),
+
+		// Methods: def total, def self.find_by_slug(slug), def valid?, def name=(value), def ==(other)
+		defPattern: regexp.MustCompile(`^def\s+(?:(self)\.)?([A-Za-z_]\w*[?!=]?|\[\]=?|[+\-*/%<>=!~^&|]+@?)\s*`),
+
+		// Constants: MAX_ITEMS = 50, Point = Struct.new(:x, :y)
+		constantPattern: regexp.MustCompile(`^([A-Z]\w*)\s*=[^=~>]`),
+
+		// Attributes: attr_accessor :name, :email
+		attrPattern: regexp.MustCompile(`^attr_(reader|writer|accessor)\s*\(?\s*(.+)`),
+
+		// Mixins: include Comparable, extend ActiveSupport::Concern, prepend Auditing
+		mixinPattern: regexp.MustCompile(`^(include|extend|prepend)\s+(.+)`),
+
+		// Visibility: private, protected :helper, private def helper, private_class_method :build
+		visibilityPattern: regexp.MustCompile(`^(private|protected|public|private_class_method|public_class_method)\b\s*(.*)$`),
+
+		// Rails callbacks naming methods: before_action :authenticate_user!, validate :check_total
+		callbackPattern: regexp.MustCompile(`^(?:(?:before|after|around)_\w+|validate|helper_method)\s*\(?\s*(:.*)`),
+
+		// ActiveRecord table names: self.table_name = "legacy_users"
+		tableNamePattern: regexp.MustCompile(`^\s*self\.table_name\s*=\s*["'](\w+)["']`),
+
+		// Heredocs: <<~SQL, <<-EOS, <<"TEXT"
+		heredocPattern: regexp.MustCompile(`<<[~-]?(["'` + "`" + `]?)([A-Za-z_]\w*)(["'` + "`" + `]?)`),
+
+		// Member calls: user.save, @repo&.find(id), Billing::Invoice.new, self.total
+		memberCallPattern: regexp.MustCompile(`((?:::)?[A-Z]\w*(?:::[A-Z]\w*)*|(?:@@?|\$)?[a-z_]\w*)\s*&?\.\s*([A-Za-z_]\w*[?!]?)`),
+
+		// Constant references: Billing::Invoice, ::User, raise NotFound
+		constantRefPattern: regexp.MustCompile(`(?:::)?[A-Z]\w*(?:::[A-Z]\w*)*`),
+
+		// Identifiers, which are local variables or calls on self: total, valid?, save!
+		identifierPattern: regexp.MustCompile(`[a-z_]\w*[?!]?`),
+
+		// Local variable assignments: total = 0, count += 1, cache ||= {}
+		assignmentPattern: regexp.MustCompile(`([a-z_]\w*)\s*(?:\|\||&&|[+\-*/%])?=(?:[^=~>]|$)`),
+
+		// Block parameters: do |order, index|, { |(key, value)| ... }
+		blockParamPattern: regexp.MustCompile(`(?:\bdo|\{)\s*\|([^|]*)\|`),
+
+		// Words that open and close bodies: class ... end, do ... end
+		wordPattern: regexp.MustCompile(`[A-Za-z_]\w*[?!]?`),
+	}
+}
+
+// ParseFile analyzes a single Ruby file and extracts all elements
+func (p *RubyParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &rubyFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		locals: make(map[string]bool),
+	}
+	parsed := r.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	commentEnd := -1        // Last line of the comment block above the current line
+	inBlockComment := false // Between =begin and =end
+	heredoc := ""           // Terminator of the heredoc being read
+	var heredocText []string
+	heredocLine := 0
+	namespaced := false // parsed.Namespace has been set from the first declaration
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		raw := scanner.Text()
+		if marker, ok := debtMarker(raw, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		trimmed := strings.TrimSpace(raw)
+
+		if heredoc != "" {
+			if trimmed == heredoc {
+				// A heredoc's SQL is attributed like a string on the line that opened it
+				parsed.Tables = append(parsed.Tables, sqlTables(`"`+strings.Join(heredocText, " ")+`"`, heredocLine, r.className(), r.method())...)
+				heredoc, heredocText = "", nil
+			} else {
+				heredocText = append(heredocText, trimmed)
+			}
+			continue
+		}
+		if inBlockComment || strings.HasPrefix(raw, "=begin") {
+			inBlockComment = !strings.HasPrefix(raw, "=end")
+			parsed.CommentLines++
+			commentEnd = lineNum
+			continue
+		}
+
+		// Join a statement continued by open brackets, a trailing comma, or a backslash
+		code, bare := stripRubyLine(raw)
+		for (bracketBalance(bare) > 0 || strings.HasSuffix(strings.TrimSpace(bare), ",") || strings.HasSuffix(bare, `\`)) && scanner.Scan() {
+			joinedLines++
+			if marker, ok := debtMarker(scanner.Text(), lineNum+joinedLines); ok {
+				parsed.Debt = append(parsed.Debt, marker)
+			}
+			nextCode, nextBare := stripRubyLine(scanner.Text())
+			code = strings.TrimSuffix(code, `\`) + " " + strings.TrimSpace(nextCode)
+			bare = strings.TrimSuffix(bare, `\`) + " " + strings.TrimSpace(nextBare)
+		}
+		if strings.TrimSpace(bare) == "" {
+			if trimmed != "" {
+				parsed.CommentLines++
+				commentEnd = lineNum
+			}
+			continue
+		}
+		documented := commentEnd == lineNum-1
+
+		if match := p.heredocPattern.FindStringSubmatch(code); match != nil && match[1] == match[3] {
+			heredoc, heredocLine = match[2], lineNum
+		}
+		if match := p.requirePattern.FindStringSubmatch(code); match != nil {
+			parsed.Uses = append(parsed.Uses, match[2])
+			continue
+		}
+		if match := p.tableNamePattern.FindStringSubmatch(code); match != nil && r.className() != "" {
+			parsed.Tables = append(parsed.Tables, models.TableReference{Table: match[1], Kind: "model", ClassName: r.className(), Line: lineNum})
+		}
+
+		for _, stmt := range strings.Split(bare, ";") {
+			if stmt = strings.TrimSpace(stmt); stmt != "" {
+				p.parseStatement(r, stmt, lineNum, documented)
+			}
+		}
+		if !namespaced {
+			for _, element := range parsed.Elements {
+				if element.Type != "module" {
+					parsed.Namespace, namespaced = element.Namespace, true
+					break
+				}
+			}
+		}
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, r.className(), r.method())...)
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// parseStatement handles one statement: a declaration, a body's end, or code
+func (p *RubyParser) parseStatement(r *rubyFile, stmt string, lineNum int, documented bool) {
+	events := p.blockEvents(stmt)
+
+	// "private def helper" declares the method with that visibility
+	visibility := ""
+	if match := p.visibilityPattern.FindStringSubmatch(stmt); match != nil && r.inBody() {
+		switch rest := match[2]; {
+		case strings.HasPrefix(rest, "def "):
+			visibility, stmt = match[1], rest
+		case rest == "" && !strings.HasSuffix(match[1], "_method"):
+			r.scopes[len(r.scopes)-1].visibility = match[1]
+			return
+		default:
+			r.setVisibility(strings.TrimSuffix(strings.TrimSuffix(match[1], "_class_method"), "_method"), symbols(rest))
+			return
+		}
+	}
+
+	switch {
+	case p.modulePattern.MatchString(stmt):
+		name := p.modulePattern.FindStringSubmatch(stmt)[1]
+		r.declareType("module", name, lineNum, documented)
+		p.applyEvents(r, events[1:], stmt, lineNum)
+		return
+
+	case p.classPattern.MatchString(stmt):
+		match := p.classPattern.FindStringSubmatch(stmt)
+		if match[1] != "" {
+			r.scopes = append(r.scopes, rubyScope{kind: "singleton", name: r.typeName(), path: r.typePath(), visibility: "public"})
+			return
+		}
+		name := r.declareType("class", match[2], lineNum, documented)
+		if match[3] != "" {
+			r.addUsage("extends", rubyName(match[3]), "", name, lineNum)
+		}
+		p.applyEvents(r, events[1:], stmt, lineNum)
+		return
+
+	case p.defPattern.MatchString(stmt):
+		loc := p.defPattern.FindStringSubmatchIndex(stmt)
+		static := loc[2] != -1
+		name := stmt[loc[4]:loc[5]]
+		params, rest := rubySignature(stmt[loc[1]:])
+		element := r.addMethod(name, static, params, lineNum, documented, visibility)
+
+		// An endless method ("def total = price * quantity") has no body to close
+		r.locals = make(map[string]bool)
+		for _, param := range element.Parameters {
+			r.locals[param] = true
+		}
+		if strings.HasPrefix(rest, "=") {
+			r.scopes = append(r.scopes, rubyScope{kind: "def", name: name})
+			p.parseUsage(r, rest[1:], lineNum)
+			r.scopes = r.scopes[:len(r.scopes)-1]
+			return
+		}
+		r.scopes = append(r.scopes, rubyScope{kind: "def", name: name})
+		p.applyEvents(r, events[1:], stmt, lineNum)
+		return
+	}
+
+	if r.inBody() {
+		className := r.typeName()
+		if match := p.constantPattern.FindStringSubmatch(stmt); match != nil {
+			r.addElement(models.CodeElement{Type: "constant", Name: match[1], Visibility: "public", Line: lineNum, Documented: documented})
+		} else if match := p.attrPattern.FindStringSubmatch(stmt); match != nil {
+			for _, name := range symbols(match[2]) {
+				r.addElement(models.CodeElement{
+					Type:       "property",
+					Name:       name,
+					ClassName:  className,
+					Visibility: r.scopes[len(r.scopes)-1].visibility,
+					IsReadonly: match[1] == "reader",
+					Line:       lineNum,
+					Documented: documented,
+				})
+			}
+			return
+		} else if match := p.mixinPattern.FindStringSubmatch(stmt); match != nil {
+			for _, mixin := range splitParams(match[2]) {
+				if name := strings.TrimSpace(mixin); p.constantRefPattern.FindString(name) == name {
+					r.addUsage("uses_trait", rubyName(name), "", className, lineNum)
+				}
+			}
+			return
+		} else if match := p.callbackPattern.FindStringSubmatch(stmt); match != nil {
+			for _, name := range symbols(match[1]) {
+				r.addUsage("method_call", name, "self", className, lineNum)
+			}
+		}
+	} else if match := p.constantPattern.FindStringSubmatch(stmt); match != nil && len(r.scopes) == 0 {
+		r.addElement(models.CodeElement{Type: "constant", Name: match[1], Visibility: "public", Line: lineNum, Documented: documented})
+	}
+
+	p.parseUsage(r, stmt, lineNum)
+	p.applyEvents(r, events, stmt, lineNum)
+}
+
+// applyEvents opens a block for every +1 and closes the innermost body for every -1
+func (p *RubyParser) applyEvents(r *rubyFile, events []int, stmt string, lineNum int) {
+	for _, event := range events {
+		if event > 0 {
+			r.scopes = append(r.scopes, rubyScope{kind: "block"})
+			continue
+		}
+		if len(r.scopes) == 0 {
+			continue
+		}
+		if r.scopes[len(r.scopes)-1].kind == "def" {
+			r.locals = make(map[string]bool)
+		}
+		r.scopes = r.scopes[:len(r.scopes)-1]
+	}
+}
+
+// blockEvents lists, in order, the bodies a statement opens (+1) and the ends that close
+// them (-1). Conditionals and loops only open a body at the start of an expression;
+// "x if y" is a modifier.
+func (p *RubyParser) blockEvents(stmt string) []int {
+	var events []int
+	loop := false // A while, until, or for whose "do" is part of the loop
+	for _, loc := range p.wordPattern.FindAllStringIndex(stmt, -1) {
+		word := stmt[loc[0]:loc[1]]
+		before := strings.TrimRight(stmt[:loc[0]], " \t")
+		if strings.HasSuffix(before, ".") || strings.HasSuffix(before, "@") || strings.HasSuffix(before, "$") ||
+			(strings.HasSuffix(before, ":") && !strings.HasSuffix(before, "::")) ||
+			(strings.HasPrefix(stmt[loc[1]:], ":") && !strings.HasPrefix(stmt[loc[1]:], "::")) {
+			continue // A method, variable, symbol, or hash key with a keyword's name
+		}
+		switch word {
+		case "end":
+			events = append(events, -1)
+		case "module", "class", "def", "begin", "case":
+			if word == "def" && isEndlessDef(stmt[loc[1]:]) {
+				continue
+			}
+			events = append(events, 1)
+		case "do":
+			if loop {
+				loop = false
+				continue
+			}
+			events = append(events, 1)
+		case "if", "unless", "while", "until", "for":
+			if startsExpression(before) {
+				events = append(events, 1)
+				loop = word == "while" || word == "until" || word == "for"
+			}
+		}
+	}
+	return events
+}
+
+// parseUsage finds instantiations, calls, and constant references in a statement with
+// string contents removed
+func (p *RubyParser) parseUsage(r *rubyFile, stmt string, lineNum int) {
+	context := r.context()
+	if context == "" {
+		return
+	}
+	for _, match := range p.blockParamPattern.FindAllStringSubmatch(stmt, -1) {
+		for _, param := range strings.FieldsFunc(match[1], func(c rune) bool { return strings.ContainsRune(",() ", c) }) {
+			r.locals[strings.TrimLeft(param, "*&")] = true
+		}
+	}
+	if strings.HasPrefix(stmt, "rescue") {
+		if _, variable, ok := strings.Cut(stmt, "=>"); ok {
+			r.locals[strings.TrimSpace(variable)] = true // rescue NotFound => e
+		}
+	}
+	for _, match := range p.assignmentPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		if match[0] == 0 || !strings.ContainsRune(".@$:", rune(stmt[match[0]-1])) {
+			r.locals[stmt[match[2]:match[3]]] = true
+		}
+	}
+
+	calls := make(map[int]bool) // Offsets of the names consumed by member calls
+	for _, match := range p.memberCallPattern.FindAllStringSubmatchIndex(stmt, -1) {
+		receiver, name := stmt[match[2]:match[3]], stmt[match[4]:match[5]]
+		if match[2] > 0 && strings.ContainsRune(".:", rune(stmt[match[2]-1])) && !strings.HasPrefix(receiver, "::") {
+			continue // Called on a call's result: find(id).save
+		}
+		calls[match[2]] = true
+		switch {
+		case isCapitalized(strings.TrimPrefix(receiver, "::")):
+			class := rubyName(receiver)
+			if name == "new" {
+				r.addUsage("instantiation", class, "", context, lineNum)
+			} else {
+				r.addUsage("static_call", class+"::"+name, class, context, lineNum)
+			}
+		case receiver == "self":
+			r.addUsage("method_call", name, "self", context, lineNum)
+		default:
+			if !r.locals[receiver] && !strings.ContainsAny(receiver[:1], "@$") && !isRubyKeyword(receiver) && !isRubyBuiltin(receiver) {
+				r.addUsage("method_call", receiver, "self", context, lineNum) // current_user.admin?
+			}
+			r.addUsage("method_call", name, receiver, context, lineNum)
+		}
+	}
+
+	// Constants outside member calls are references to classes and modules:
+	// raise NotFound, rescue Stripe::CardError, Billing::Invoice::STATUSES
+	for _, loc := range p.constantRefPattern.FindAllStringIndex(stmt, -1) {
+		if calls[loc[0]] || (loc[0] > 0 && isRubyWordChar(stmt[loc[0]-1])) {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(stmt[loc[0]:loc[1]], "::"), "::")
+		if last := parts[len(parts)-1]; len(parts) > 1 && last == strings.ToUpper(last) {
+			parts = parts[:len(parts)-1] // A constant of the class
+		}
+		if name := strings.Join(parts, "::"); name != strings.ToUpper(name) {
+			r.addUsage("type_reference", rubyName(name), "", context, lineNum)
+		}
+	}
+
+	// Any other identifier that isn't a local variable is a call on self
+	for _, loc := range p.identifierPattern.FindAllStringIndex(stmt, -1) {
+		name, before, after := stmt[loc[0]:loc[1]], stmt[:loc[0]], stmt[loc[1]:]
+		if calls[loc[0]] || r.locals[name] || isRubyKeyword(name) || isRubyBuiltin(name) ||
+			(before != "" && (isRubyWordChar(before[len(before)-1]) || strings.ContainsRune(".:@$&%", rune(before[len(before)-1])))) ||
+			(strings.HasPrefix(after, ":") && !strings.HasPrefix(after, "::")) {
+			continue
+		}
+		r.addUsage("method_call", name, "self", context, lineNum)
+	}
+}
+
+// declareType records a module or class and opens its body, returning its name
+func (r *rubyFile) declareType(kind, name string, lineNum int, documented bool) string {
+	path := rubyName(name)
+	if !strings.HasPrefix(name, "::") && r.typePath() != "" {
+		path = r.typePath() + `\` + path
+	}
+	namespace, short := "", path
+	if idx := strings.LastIndex(path, `\`); idx != -1 {
+		namespace, short = path[:idx], path[idx+1:]
+	}
+	r.parsed.Elements = append(r.parsed.Elements, models.CodeElement{
+		Type:       kind,
+		Name:       short,
+		Namespace:  namespace,
+		Visibility: "public",
+		Line:       lineNum,
+		File:       r.parsed.Path,
+		Documented: documented,
+	})
+	r.scopes = append(r.scopes, rubyScope{kind: kind, name: short, path: path, visibility: "public"})
+	return short
+}
+
+// addMethod records a method, or a function outside modules and classes
+func (r *rubyFile) addMethod(name string, static bool, params []string, lineNum int, documented bool, visibility string) models.CodeElement {
+	element := models.CodeElement{
+		Type:       "function",
+		Name:       name,
+		Visibility: "public",
+		Line:       lineNum,
+		Documented: documented,
+		Parameters: []string{},
+	}
+	if r.inBody() {
+		scope := r.scopes[len(r.scopes)-1]
+		element.Type = "method"
+		element.ClassName = scope.name
+		element.IsStatic = static || scope.kind == "singleton"
+		element.Visibility = scope.visibility
+	}
+	if visibility != "" {
+		element.Visibility = visibility
+	}
+	for _, param := range params {
+		param = strings.TrimSpace(param)
+		if eq := strings.IndexAny(param, "=:"); eq != -1 {
+			param = param[:eq] // Defaults and keyword parameters: limit = 10, limit: 10
+		}
+		if param = strings.TrimLeft(strings.TrimSpace(param), "*&"); param != "" && param != "..." {
+			element.Parameters = append(element.Parameters, param)
+			element.ParamTypes = append(element.ParamTypes, "")
+		}
+	}
+	r.addElement(element)
+	return element
+}
+
+// addElement records an element declared in the current module or class
+func (r *rubyFile) addElement(element models.CodeElement) {
+	element.File = r.parsed.Path
+	if element.Type != "function" {
+		element.ClassName = r.typeName()
+		if idx := strings.LastIndex(r.typePath(), `\`); idx != -1 {
+			element.Namespace = r.typePath()[:idx]
+		}
+	}
+	r.parsed.Elements = append(r.parsed.Elements, element)
+}
+
+// addUsage records a reference from context
+func (r *rubyFile) addUsage(usageType, name, receiver, context string, lineNum int) {
+	r.parsed.Usage = append(r.parsed.Usage, models.UsageElement{
+		Type:     usageType,
+		Name:     name,
+		Context:  context,
+		Receiver: receiver,
+		Line:     lineNum,
+		IsStatic: usageType == "static_call",
+	})
+}
+
+// setVisibility changes the visibility of methods already defined in the current body,
+// as "private :helper" does
+func (r *rubyFile) setVisibility(visibility string, names []string) {
+	className := r.typeName()
+	for _, name := range names {
+		for i := len(r.parsed.Elements) - 1; i >= 0; i-- {
+			if element := &r.parsed.Elements[i]; element.Type == "method" && element.Name == name && element.ClassName == className {
+				element.Visibility = visibility
+				break
+			}
+		}
+	}
+}
+
+// inBody reports whether the parser is directly inside a module or class body, where
+// methods and constants are declared
+func (r *rubyFile) inBody() bool {
+	if len(r.scopes) == 0 {
+		return false
+	}
+	kind := r.scopes[len(r.scopes)-1].kind
+	return kind == "module" || kind == "class" || kind == "singleton"
+}
+
+// typeName returns the name of the innermost module or class
+func (r *rubyFile) typeName() string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if r.scopes[i].path != "" {
+			return r.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// typePath returns the qualified name of the innermost module or class
+func (r *rubyFile) typePath() string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if r.scopes[i].path != "" {
+			return r.scopes[i].path
+		}
+	}
+	return ""
+}
+
+// className returns the innermost class, for table references
+func (r *rubyFile) className() string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if r.scopes[i].kind == "class" {
+			return r.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// method returns the name of the method being parsed, or ""
+func (r *rubyFile) method() string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		switch r.scopes[i].kind {
+		case "def":
+			return r.scopes[i].name
+		case "module", "class", "singleton":
+			return ""
+		}
+	}
+	return ""
+}
+
+// context returns the name usage is attributed to: the enclosing method, or the module
+// or class for statements in its body
+func (r *rubyFile) context() string {
+	if method := r.method(); method != "" {
+		return method
+	}
+	return r.typeName()
+}
+
+// rubySignature splits the text after a method's name into its parameters and the rest
+// of the line, which starts with "=" for an endless method
+func rubySignature(rest string) ([]string, string) {
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "(") {
+		if end := closingParen(rest); end != -1 {
+			return splitParams(rest[1:end]), strings.TrimSpace(rest[end+1:])
+		}
+		return splitParams(rest[1:]), ""
+	}
+	if rest == "" || strings.HasPrefix(rest, "=") {
+		return nil, rest
+	}
+	return splitParams(rest), "" // def greet name, greeting = "Hi"
+}
+
+// isEndlessDef reports whether the text after "def" declares an endless method
+func isEndlessDef(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	end := strings.IndexAny(rest, " (=")
+	if end == -1 {
+		return false
+	}
+	rest = strings.TrimSpace(rest[end:])
+	if strings.HasPrefix(rest, "(") {
+		if closing := closingParen(rest); closing != -1 {
+			rest = strings.TrimSpace(rest[closing+1:])
+		}
+	}
+	return strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, "==")
+}
+
+// startsExpression reports whether a keyword following text begins an expression, as in
+// "total = if paid", rather than modifying a statement, as in "retry if failed"
+func startsExpression(before string) bool {
+	if before == "" || strings.ContainsRune("=([{,|&!?:", rune(before[len(before)-1])) {
+		return true
+	}
+	fields := strings.Fields(before)
+	switch fields[len(fields)-1] {
+	case "then", "else", "do":
+		return true
+	}
+	return false
+}
+
+// symbols returns the names in a list of symbols, stopping at the first option:
+// ":name, :email, if: :admin?" is [name email]
+func symbols(list string) []string {
+	var names []string
+	for _, item := range splitParams(strings.TrimSuffix(strings.TrimSpace(list), ")")) {
+		item = strings.TrimSpace(item)
+		if !strings.HasPrefix(item, ":") || strings.HasPrefix(item, "::") {
+			break
+		}
+		names = append(names, strings.Trim(item[1:], `"'`))
+	}
+	return names
+}
+
+// rubyName rewrites a constant path with the analyzer's separator: Billing::Invoice is
+// `Billing\Invoice`
+func rubyName(name string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(strings.TrimSpace(name), "::"), "::", `\`)
+}
+
+// stripRubyLine removes a comment from a line, returning the code and the code with
+// string contents blanked out
+func stripRubyLine(line string) (string, string) {
+	var code, bare strings.Builder
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			code.WriteByte(c)
+			if c == '\\' && i+1 < len(line) {
+				code.WriteByte(line[i+1])
+				i++
+			} else if c == quote {
+				bare.WriteByte(c)
+				quote = 0
+			}
+		case c == '#':
+			return code.String(), bare.String()
+		case c == '%' && i+2 < len(line) && strings.IndexByte("wWiIqQ", line[i+1]) != -1 && strings.IndexByte("[({<", line[i+2]) != -1:
+			// Word, symbol, and string lists: %w[draft sent], %i(admin editor)
+			closing := map[byte]byte{'[': ']', '(': ')', '{': '}', '<': '>'}[line[i+2]]
+			end := strings.IndexByte(line[i+3:], closing)
+			bare.WriteString(`""`)
+			if end == -1 {
+				code.WriteString(line[i:])
+				return code.String(), bare.String()
+			}
+			code.WriteString(line[i : i+4+end])
+			i += 3 + end
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+			code.WriteByte(c)
+			bare.WriteByte(c)
+		default:
+			code.WriteByte(c)
+			bare.WriteByte(c)
+		}
+	}
+	return code.String(), bare.String()
+}
+
+func isRubyWordChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isRubyKeyword checks if a name is a Ruby keyword
+func isRubyKeyword(name string) bool {
+	switch name {
+	case "alias", "and", "begin", "break", "case", "class", "def", "defined?", "do", "else",
+		"elsif", "end", "ensure", "false", "for", "if", "in", "module", "next", "nil", "not",
+		"or", "redo", "rescue", "retry", "return", "self", "super", "then", "true", "undef",
+		"unless", "until", "when", "while", "yield", "__method__", "__dir__", "__FILE__",
+		"__LINE__", "private", "protected", "public":
+		return true
+	}
+	return false
+}
+
+// isRubyBuiltin checks if a name is a method of Kernel or Module that code calls
+// without a receiver
+func isRubyBuiltin(name string) bool {
+	switch name {
+	case "puts", "print", "p", "pp", "require", "require_relative", "raise", "fail", "loop",
+		"lambda", "proc", "block_given?", "format", "sprintf", "printf", "rand", "sleep",
+		"catch", "throw", "freeze", "frozen?", "binding", "caller", "exit", "abort", "at_exit",
+		"include", "extend", "prepend", "attr_reader", "attr_writer", "attr_accessor",
+		"module_function", "private_constant", "private_class_method", "public_class_method",
+		"define_method", "instance_variable_get", "instance_variable_set", "send",
+		"public_send", "respond_to?", "is_a?", "kind_of?", "instance_of?", "nil?", "tap",
+		"then", "dup", "clone", "hash", "object_id", "inspect", "to_s", "gets",
+		"open", "system", "warn", "autoload", "refine", "using":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Ruby files concurrently
+func (p *RubyParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *RubyParser) Language() string {
+	return "ruby"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *RubyParser) FileExtensions() []string {
+	return []string{".rb"}
+}
+
+// Sniff recognizes extensionless Ruby scripts, such as bin/rails, by a ruby shebang
+// ("#!/usr/bin/env ruby")
+func (p *RubyParser) Sniff(header []byte) bool {
+	if !bytes.HasPrefix(header, []byte("#!")) {
+		return false
+	}
+	shebang, _, _ := bytes.Cut(header, []byte("\n"))
+	for _, field := range bytes.Fields(shebang[2:]) {
+		if name := filepath.Base(string(field)); name == "ruby" || strings.HasPrefix(name, "ruby3") || strings.HasPrefix(name, "ruby2") {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultExcludes returns the directories skipped in Ruby projects: vendored gems,
+// Bundler's settings, and Rails' temporary files, logs, and coverage reports
+func (p *RubyParser) DefaultExcludes() []string {
+	return []string{"vendor", ".bundle", "tmp", "log", "coverage"}
+}
+
+// Entrypoints returns the methods Ruby and Rails call: initializers, method_missing,
+// job perform methods, and the standard controller actions
+func (p *RubyParser) Entrypoints() []string {
+	return []string{`^(initialize|method_missing|respond_to_missing\?|perform)$`, `^(index|show|new|create|edit|update|destroy)$`}
+}
+
+func init() {
+	parser.Register(NewRubyParser())
+}
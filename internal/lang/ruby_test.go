@@ -0,0 +1,239 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestRubyParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `# frozen_string_literal: true
+
+require "json"
+require_relative "../concerns/auditable"
+
+module Billing
+  # An invoice sent to a customer.
+  class Invoice < ApplicationRecord
+    include Auditable
+    self.table_name = "legacy_invoices"
+
+    STATUSES = %w[draft sent paid].freeze
+
+    attr_accessor :notes
+    attr_reader :total
+    before_save :recalculate, if: :draft?
+
+    def initialize(customer, lines = [], currency: "USD", **options)
+      @customer = customer
+      @lines = lines
+    end
+
+    def recalculate
+      subtotal = @lines.sum(&:amount)
+      @total = subtotal + tax_for(subtotal) if draft? # TODO: round
+      Billing::Notifier.new(@customer).deliver(self)
+    rescue Stripe::CardError => e
+      log_failure(e)
+    end
+
+    def self.overdue
+      where(status: "sent").select { |invoice| invoice.due_on < Date.today }
+    end
+
+    def draft? = status == "draft"
+
+    private
+
+    def tax_for(amount)
+      TaxRate.current.apply(amount)
+    end
+
+    def log_failure(error)
+      Rails.logger.warn(error.message)
+    end
+  end
+end
+
+def helper; end
+`
+	path := writeFixture(t, tmp, "invoice.rb", code)
+
+	parsed, err := NewRubyParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "ruby" || parsed.Namespace != "Billing" {
+		t.Errorf("expected the enclosing module as the namespace, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	if len(parsed.Uses) != 2 || parsed.Uses[0] != "json" || parsed.Uses[1] != "../concerns/auditable" {
+		t.Errorf("expected the required paths, got %v", parsed.Uses)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Namespace+`\`+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{`module:\.Billing`, `class:Billing\.Invoice`, `constant:Billing\Invoice.STATUSES`,
+		`property:Billing\Invoice.notes`, `property:Billing\Invoice.total`, `method:Billing\Invoice.initialize`,
+		`method:Billing\Invoice.recalculate`, `method:Billing\Invoice.overdue`, `method:Billing\Invoice.draft?`,
+		`method:Billing\Invoice.tax_for`, `method:Billing\Invoice.log_failure`, `function:\.helper`} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 12 {
+		t.Errorf("expected 12 elements, got %+v", parsed.Elements)
+	}
+
+	invoice := elements[`class:Billing\.Invoice`]
+	if !invoice.Documented || elements[`method:Billing\Invoice.recalculate`].Documented {
+		t.Error("expected only the class with a comment above it to be documented")
+	}
+	initialize := elements[`method:Billing\Invoice.initialize`]
+	if len(initialize.Parameters) != 4 || initialize.Parameters[2] != "currency" || initialize.Parameters[3] != "options" {
+		t.Errorf("expected [customer lines currency options], got %v", initialize.Parameters)
+	}
+	if !elements[`method:Billing\Invoice.overdue`].IsStatic || elements[`method:Billing\Invoice.recalculate`].IsStatic {
+		t.Error("expected only the self. method to be static")
+	}
+	if elements[`method:Billing\Invoice.tax_for`].Visibility != "private" || elements[`method:Billing\Invoice.draft?`].Visibility != "public" {
+		t.Error("expected the methods after private to be private")
+	}
+	if !elements[`property:Billing\Invoice.total`].IsReadonly || elements[`property:Billing\Invoice.notes`].IsReadonly {
+		t.Error("expected only the attr_reader to be read-only")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"extends:.ApplicationRecord in Invoice",
+		"uses_trait:.Auditable in Invoice",
+		"method_call:self.recalculate in Invoice",
+		"method_call:self.draft? in recalculate",
+		"method_call:self.tax_for in recalculate",
+		`instantiation:.Billing\Notifier in recalculate`,
+		"method_call:@lines.sum in recalculate",
+		`type_reference:.Stripe\CardError in recalculate`,
+		"method_call:self.log_failure in recalculate",
+		"method_call:self.where in overdue",
+		"method_call:invoice.due_on in overdue",
+		"static_call:Date.Date::today in overdue",
+		"method_call:self.status in draft?",
+		"static_call:TaxRate.TaxRate::current in tax_for",
+		"method_call:error.message in log_failure",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"method_call:self.subtotal in recalculate", "method_call:self.e in recalculate",
+		"method_call:self.invoice in overdue", "method_call:self.amount in tax_for", "method_call:self.draft in Invoice",
+		"method_call:self.sent in Invoice", "method_call:self.status in overdue"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Table != "legacy_invoices" || parsed.Tables[0].ClassName != "Invoice" {
+		t.Errorf("expected the model's table, got %+v", parsed.Tables)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 25 {
+		t.Errorf("expected the TODO on line 25, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 2 {
+		t.Errorf("expected 2 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestRubyParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"app/models", "app/controllers"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFixture(t, tmp, "app/models/order.rb", `class Order < ApplicationRecord
+  def self.recent
+    where(placed: true).limit(10)
+  end
+
+  def total
+    lines.sum { |line| line.price * line.quantity }
+  end
+
+  def lines
+    []
+  end
+end
+`)
+	writeFixture(t, tmp, "app/controllers/orders_controller.rb", `module Shop
+  class OrdersController < ApplicationController
+    before_action :load_order, only: :show
+
+    def index
+      @orders = Order.recent
+    end
+
+    def show
+      render json: { total: @order.total }
+    end
+
+    private
+
+    def load_order
+      @order = Order.find(params[:id])
+    end
+
+    def unused; end
+  end
+end
+`)
+
+	p := NewRubyParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"app/models/order.rb", "app/controllers/orders_controller.rb"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Name] = node
+	}
+	if nodes["index"].Dependencies[nodes["Order"].ID] == nil {
+		t.Errorf("expected the action to depend on the model, got %+v", nodes["index"].Dependencies)
+	}
+	if !nodes["index"].IsEntrypoint || !nodes["show"].IsEntrypoint {
+		t.Error("expected the controller actions to be entrypoints")
+	}
+	if nodes["OrdersController"].Dependencies[nodes["load_order"].ID] == nil {
+		t.Errorf("expected the callback to call its method, got %+v", nodes["OrdersController"].Dependencies)
+	}
+	if nodes["total"].Dependencies[nodes["lines"].ID] == nil {
+		t.Errorf("expected the bare call to resolve to the class's method, got %+v", nodes["total"].Dependencies)
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Name] = true
+	}
+	if !orphans["unused"] || orphans["load_order"] || orphans["index"] {
+		t.Errorf("expected only the unused method among the orphans, got %v", orphans)
+	}
+}
@@ -0,0 +1,1092 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// RustParser handles parsing of Rust files
+type RustParser struct {
+	usePattern     *regexp.Regexp
+	modPattern     *regexp.Regexp
+	typePattern    *regexp.Regexp
+	implPattern    *regexp.Regexp
+	fnPattern      *regexp.Regexp
+	constPattern   *regexp.Regexp
+	macroPattern   *regexp.Regexp
+	pathPattern    *regexp.Regexp
+	callPattern    *regexp.Regexp
+	literalPattern *regexp.Regexp
+	letTypePattern *regexp.Regexp
+	castPattern    *regexp.Regexp
+}
+
+// rustScope is a module, type, impl, trait, or function body the parser is inside
+type rustScope struct {
+	kind     string // "module", "type", "impl", "trait", "function", or "macro"
+	name     string // The implementing type, for impls
+	depth    int    // Brace depth inside the body
+	variants bool   // An enum's body, whose entries are variants rather than fields
+	trait    bool   // A trait impl, whose methods are public however they're declared
+}
+
+// rustFile is the state of one file's parse
+type rustFile struct {
+	parsed  *models.ParsedFile
+	crate   string            // Namespace `crate::` refers to, e.g. "shop"
+	module  string            // The file's module, e.g. `shop\models`
+	imports map[string]string // Names brought in by use → qualified name, e.g. "User" → `shop\models\User`
+	modules map[string]bool   // Child modules declared with mod
+	scopes  []rustScope
+}
+
+// rustLexState is what stripRustLine carries from one line to the next: comments nest,
+// and strings may span lines
+type rustLexState struct {
+	comment int  // Depth of nested block comments
+	str     bool // Inside a "string"
+	raw     int  // Inside an r#"raw string"#, with raw-1 hashes
+}
+
+// NewRustParser creates a new Rust parser with compiled regex patterns
+func NewRustParser() *RustParser {
+	const visibility = `(pub(?:\s*\([^)]*\))?\s+)?`
+	return &RustParser{
+		// use crate::models::{User, Order as PurchaseOrder}, pub use self::error::*
+		usePattern: regexp.MustCompile(`^\s*` + visibility + `use\s+(.+?)\s*;`),
+
+		// mod models;, pub mod api {, mod tests {
+		modPattern: regexp.MustCompile(`^\s*` + visibility + `mod\s+(\w+)\s*([;{])`),
+
+		// struct User<T>, pub enum Status, pub(crate) trait Repository, union Bits
+		typePattern: regexp.MustCompile(`^\s*` + visibility + `(?:unsafe\s+)?(struct|enum|union|trait)\s+(\w+)`),
+
+		// impl User, impl<T: Display> fmt::Display for Wrapper<T>, unsafe impl Send for Pool
+		implPattern: regexp.MustCompile(`^\s*(?:unsafe\s+)?impl\b`),
+
+		// fn main(), pub async fn find(&self, id: u64) -> Option<User>, pub const unsafe fn raw()
+		fnPattern: regexp.MustCompile(`^\s*` + visibility + `(?:(?:default|const|async|unsafe|extern(?:\s+"[^"]*")?)\s+)*fn\s+(\w+)`),
+
+		// const MAX: usize = 10;, pub static GREETING: &str = "hi";, static mut COUNTER: u32 = 0;
+		constPattern: regexp.MustCompile(`^\s*` + visibility + `(const|static)\s+(?:mut\s+)?(\w+)\s*:\s*([^=;]+)`),
+
+		// macro_rules! hashmap {
+		macroPattern: regexp.MustCompile(`^\s*(?:#\[macro_export\]\s*)?macro_rules!\s*\w+`),
+
+		// Paths: User::new(, models::User, Status::Active, helpers::slugify(, Vec::<u8>::new(
+		pathPattern: regexp.MustCompile(`\b((?:[A-Za-z_]\w*\s*::\s*(?:<[^()]*?>\s*::\s*)?)+)([A-Za-z_]\w*)(\s*(?:::\s*<[^()]*?>\s*)?\()?`),
+
+		// Calls, with any turbofish: save(user), self.repo.find(id), iter.collect::<Vec<_>>()
+		callPattern: regexp.MustCompile(`([A-Za-z_]\w*)\s*(?:::\s*<([^()]*?)>)?\s*\(`),
+
+		// Struct literals: User { name, email }
+		literalPattern: regexp.MustCompile(`\b([A-Z]\w*)\s*\{`),
+
+		// Annotated bindings: let user: User = ..., let mut users: Vec<User> = ...
+		letTypePattern: regexp.MustCompile(`\blet\s+(?:mut\s+)?\w+\s*:\s*([^=;]+)`),
+
+		// Casts: value as Meters
+		castPattern: regexp.MustCompile(`\bas\s+([A-Z][\w:]*)`),
+	}
+}
+
+// ParseFile analyzes a single Rust file and extracts all elements
+func (p *RustParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	crate, module := rustModule(filePath)
+	r := &rustFile{
+		parsed: &models.ParsedFile{
+			Path:      filePath,
+			Language:  p.Language(),
+			Namespace: module,
+			Elements:  []models.CodeElement{},
+			Usage:     []models.UsageElement{},
+			Uses:      []string{},
+		},
+		crate:   crate,
+		module:  module,
+		imports: make(map[string]string),
+		modules: make(map[string]bool),
+	}
+	parsed := r.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	state := rustLexState{}
+	docblock := false // A /// or /** */ doc comment precedes the next declaration
+
+	join := func(code, bare string) (string, string) {
+		joinedLines++
+		nextCode, nextBare := stripRustLine(scanner.Text(), &state)
+		return code + " " + strings.TrimSpace(nextCode), bare + " " + strings.TrimSpace(nextBare)
+	}
+	inLiteral := func() bool { return state.comment > 0 || state.str || state.raw > 0 }
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if state.comment == 0 && !state.str && state.raw == 0 &&
+			(strings.HasPrefix(trimmed, "///") || strings.HasPrefix(trimmed, "/**")) && !strings.HasPrefix(trimmed, "////") {
+			docblock = true
+		}
+		code, bare := stripRustLine(line, &state)
+		if strings.TrimSpace(bare) == "" {
+			if trimmed != "" && strings.TrimSpace(code) == "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Attributes such as #[derive(Debug)] and #[cfg(test)] carry no dependencies the
+		// parser follows; skip them, with any that wrap onto the following lines
+		bare = strings.TrimSpace(bare)
+		for strings.HasPrefix(bare, "#") {
+			end := rustAttributeEnd(bare)
+			for end == -1 && !inLiteral() && scanner.Scan() {
+				code, bare = join(code, bare)
+				end = rustAttributeEnd(bare)
+			}
+			if end == -1 {
+				break
+			}
+			bare = strings.TrimSpace(bare[end:])
+		}
+		if bare == "" {
+			continue
+		}
+
+		// Join multi-line parameter lists and calls, so they're parsed whole
+		for parenBalance(bare) > 0 && !inLiteral() && scanner.Scan() {
+			code, bare = join(code, bare)
+		}
+		declaring := r.declaring(braceDepth)
+		// Declarations wrap before their body opens: where clauses, long signatures, and
+		// use trees are often on lines of their own
+		if declaring && (p.fnPattern.MatchString(bare) || p.typePattern.MatchString(bare) || p.implPattern.MatchString(bare) || strings.Contains(bare, "use ")) {
+			for !strings.ContainsAny(bare, "{;") && !inLiteral() && scanner.Scan() {
+				code, bare = join(code, bare)
+			}
+			for p.usePattern.FindStringIndex(bare) == nil && strings.Contains(bare, "use ") && strings.Contains(bare, "{") &&
+				!strings.Contains(bare, ";") && !inLiteral() && scanner.Scan() {
+				code, bare = join(code, bare)
+			}
+		}
+
+		documented := docblock
+		docblock = false
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := r.top()
+		inTypeBody := top != nil && top.kind == "type" && top.depth == depthBefore
+		className := r.className(depthBefore)
+
+		if inTypeBody {
+			// Fields of a struct, or variants of an enum
+			if top.variants {
+				r.parseVariants(bare, top.name, lineNum, documented)
+			} else {
+				r.parseFields(bare, top.name, lineNum, documented)
+			}
+			body = ""
+		} else if matches := p.usePattern.FindStringSubmatch(bare); declaring && matches != nil {
+			for _, use := range expandUseTree(nil, matches[2]) {
+				r.addUse(use.path, use.alias)
+			}
+			body = ""
+		} else if matches := p.modPattern.FindStringSubmatch(bare); declaring && matches != nil {
+			r.modules[matches[2]] = true
+			if matches[3] == "{" {
+				r.scopes = append(r.scopes, rustScope{kind: "module", name: matches[2], depth: depthBefore + 1})
+			}
+			body = ""
+		} else if p.macroPattern.MatchString(bare) && declaring {
+			// A macro's body is patterns rather than code
+			if strings.Contains(bare, "{") {
+				r.scopes = append(r.scopes, rustScope{kind: "macro", depth: depthBefore + 1})
+			}
+			body = ""
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); declaring && className == "" && matches != nil {
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			element := models.CodeElement{
+				Type:       "class",
+				Name:       name,
+				Namespace:  r.namespace(),
+				Visibility: rustVisibility(bare[:matches[4]]),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+			}
+			switch keyword {
+			case "enum":
+				element.Type = "enum"
+			case "trait":
+				element.Type = "trait"
+			}
+			parsed.Elements = append(parsed.Elements, element)
+
+			header := skipTypeParameters(strings.TrimSpace(bare[matches[1]:]))
+			body = ""
+			if idx := topLevelIndex(header, '{'); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+				kind := "type"
+				if keyword == "trait" {
+					kind = "trait"
+				}
+				r.scopes = append(r.scopes, rustScope{kind: kind, name: name, depth: depthBefore + 1, variants: keyword == "enum"})
+				if kind == "type" && strings.TrimSpace(body) != "" {
+					if keyword == "enum" {
+						r.parseVariants(body, name, lineNum, false)
+					} else {
+						r.parseFields(body, name, lineNum, false)
+					}
+					body = ""
+				}
+			}
+			context = name
+			r.parseTypeHeader(header, keyword, name, lineNum)
+		} else if p.implPattern.MatchString(bare) && declaring && className == "" {
+			header := strings.TrimSpace(bare)
+			header = strings.TrimSpace(strings.TrimPrefix(header, "unsafe"))
+			header = skipTypeParameters(strings.TrimSpace(strings.TrimPrefix(header, "impl")))
+			body = ""
+			if idx := strings.Index(header, "{"); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+			}
+			if idx := strings.Index(header, " where "); idx != -1 {
+				header = header[:idx]
+			}
+			typeName, trait := header, ""
+			if idx := strings.Index(header, " for "); idx != -1 {
+				trait, typeName = header[:idx], header[idx+len(" for "):]
+			}
+			name := rustTypeName(typeName)
+			trait = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(trait), "!"))
+			if strings.Contains(bare, "{") {
+				r.scopes = append(r.scopes, rustScope{kind: "impl", name: name, depth: depthBefore + 1, trait: trait != ""})
+			}
+			if trait != "" && name != "" {
+				if idx := strings.Index(trait, "<"); idx != -1 {
+					trait = trait[:idx]
+				}
+				parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "implements", Name: r.qualify(trait), Context: name, Line: lineNum})
+			}
+			context = name
+		} else if fn := p.fnPattern.FindStringSubmatchIndex(bare); declaring && fn != nil {
+			name := bare[fn[4]:fn[5]]
+			rest := skipTypeParameters(strings.TrimSpace(bare[fn[1]:]))
+			params := ""
+			if strings.HasPrefix(rest, "(") {
+				if end := closingParen(rest); end != -1 {
+					params, rest = rest[1:end], rest[end+1:]
+				}
+			}
+
+			element := models.CodeElement{
+				Type:       "function",
+				Name:       name,
+				Namespace:  r.namespace(),
+				ClassName:  className,
+				Visibility: rustVisibility(bare[:fn[1]]),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				Parameters: []string{},
+			}
+			if className != "" {
+				element.Type = "method"
+				element.IsStatic = true // Until a self parameter says otherwise
+				if top.kind == "trait" {
+					element.Visibility = "public"
+					element.IsAbstract = !strings.Contains(rest, "{")
+				} else if top.trait {
+					element.Visibility = "public"
+				}
+			}
+			for _, param := range splitTopLevel(params) {
+				param = strings.TrimSpace(param)
+				paramName, paramType, ok := strings.Cut(param, ":")
+				if !ok {
+					if strings.HasSuffix(strings.TrimSpace(param), "self") {
+						element.IsStatic = false // self, &self, &mut self
+					}
+					continue
+				}
+				if fields := strings.Fields(paramName); len(fields) > 0 {
+					paramName = fields[len(fields)-1]
+				}
+				if paramName == "self" {
+					element.IsStatic = false // self: Box<Self>
+					continue
+				}
+				element.Parameters = append(element.Parameters, paramName)
+				element.ParamTypes = append(element.ParamTypes, r.typeNames(paramType))
+			}
+			// The return type follows the parameters: -> Result<User, Error> {
+			if returns := strings.TrimSpace(rest); strings.HasPrefix(returns, "->") {
+				returns = returns[2:]
+				if idx := strings.IndexAny(returns, "{;"); idx != -1 {
+					returns = returns[:idx]
+				}
+				if idx := strings.Index(returns, " where "); idx != -1 {
+					returns = returns[:idx]
+				}
+				element.ReturnType = r.typeNames(returns)
+			}
+			parsed.Elements = append(parsed.Elements, element)
+
+			body = ""
+			if idx := strings.Index(rest, "{"); idx != -1 {
+				body = rest[idx+1:]
+				r.scopes = append(r.scopes, rustScope{kind: "function", name: name, depth: depthBefore + 1})
+			}
+			context = name
+		} else if matches := p.constPattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			name := bare[matches[6]:matches[7]]
+			parsed.Elements = append(parsed.Elements, models.CodeElement{
+				Type:       "constant",
+				Name:       name,
+				Namespace:  r.namespace(),
+				ClassName:  className,
+				Visibility: rustVisibility(bare[:matches[4]]),
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+			})
+			for _, typeName := range strings.Split(r.typeNames(bare[matches[8]:matches[9]]), "|") {
+				if typeName != "" {
+					parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: name, Line: lineNum})
+				}
+			}
+			body = bare[matches[1]:]
+			context = name
+		}
+
+		if context == "" {
+			context = r.context()
+		}
+		if top := r.top(); top == nil || top.kind != "macro" {
+			p.parseUsage(r, body, lineNum, context)
+			parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, r.className(braceDepth), context)...)
+		}
+
+		// Leave the bodies closed on this line
+		for len(r.scopes) > 0 && braceDepth < r.scopes[len(r.scopes)-1].depth {
+			r.scopes = r.scopes[:len(r.scopes)-1]
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// parseTypeHeader records what a type's header depends on: a tuple struct's field types,
+// and the supertraits a trait extends
+func (r *rustFile) parseTypeHeader(header, keyword, name string, lineNum int) {
+	header = strings.TrimSpace(header)
+	if idx := strings.Index(header, " where "); idx != -1 {
+		header = header[:idx]
+	}
+	switch {
+	case keyword == "trait" && strings.HasPrefix(header, ":"):
+		for _, supertrait := range strings.Split(header[1:], "+") {
+			if supertrait = strings.TrimSpace(supertrait); supertrait == "" || strings.HasPrefix(supertrait, "'") || supertrait == "?Sized" {
+				continue
+			}
+			if idx := strings.Index(supertrait, "<"); idx != -1 {
+				supertrait = supertrait[:idx]
+			}
+			if !isRustBuiltin(rustLastSegment(supertrait)) {
+				r.parsed.Usage = append(r.parsed.Usage, models.UsageElement{Type: "extends", Name: r.qualify(supertrait), Context: name, Line: lineNum})
+			}
+		}
+	case strings.HasPrefix(header, "("):
+		if end := closingParen(header); end != -1 {
+			r.parseFields(header[1:end], name, lineNum, false)
+		}
+	}
+}
+
+// parseFields records a struct's fields declared in code, with their types as
+// dependencies of the struct. Tuple struct fields have types but no names.
+func (r *rustFile) parseFields(code, typeName string, lineNum int, documented bool) {
+	for _, field := range splitTopLevel(code) {
+		field = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(field), "}"))
+		if field == "" {
+			continue
+		}
+		fieldType := field
+		if name, declared, ok := strings.Cut(field, ":"); ok && !strings.HasPrefix(declared, ":") {
+			fieldType = declared
+			fields := strings.Fields(name)
+			if len(fields) == 0 {
+				continue
+			}
+			r.parsed.Elements = append(r.parsed.Elements, models.CodeElement{
+				Type:       "property",
+				Name:       fields[len(fields)-1],
+				Namespace:  r.namespace(),
+				ClassName:  typeName,
+				Visibility: rustVisibility(strings.Join(fields[:len(fields)-1], " ") + " "),
+				Line:       lineNum,
+				File:       r.parsed.Path,
+				Documented: documented,
+			})
+		}
+		for _, name := range strings.Split(r.typeNames(fieldType), "|") {
+			if name != "" {
+				r.parsed.Usage = append(r.parsed.Usage, models.UsageElement{Type: "type_reference", Name: name, Context: typeName, Line: lineNum})
+			}
+		}
+	}
+}
+
+// parseVariants records an enum's variants declared in code as constants, with the types
+// they carry as dependencies of the enum
+func (r *rustFile) parseVariants(code, enumName string, lineNum int, documented bool) {
+	for _, variant := range splitTopLevel(code) {
+		variant = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(variant), "}"))
+		end := 0
+		for end < len(variant) && (variant[end] == '_' || unicode.IsLetter(rune(variant[end])) || unicode.IsDigit(rune(variant[end]))) {
+			end++
+		}
+		if end == 0 || !unicode.IsUpper(rune(variant[0])) {
+			continue // A field of a struct-like variant, on a line of its own
+		}
+		r.parsed.Elements = append(r.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       variant[:end],
+			Namespace:  r.namespace(),
+			ClassName:  enumName,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       r.parsed.Path,
+			Documented: documented,
+		})
+		payload := strings.TrimSpace(variant[end:])
+		if strings.HasPrefix(payload, "=") {
+			continue // A discriminant
+		}
+		for _, name := range strings.Split(r.typeNames(strings.Trim(payload, "({})")), "|") {
+			if name != "" {
+				r.parsed.Usage = append(r.parsed.Usage, models.UsageElement{Type: "type_reference", Name: name, Context: enumName, Line: lineNum})
+			}
+		}
+	}
+}
+
+// parseUsage finds calls, paths, struct literals, and annotated types in code
+func (p *RustParser) parseUsage(r *rustFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	add := func(usageType, name, receiver string) {
+		r.parsed.Usage = append(r.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+	addTypes := func(typeDecl string) {
+		for _, name := range strings.Split(r.typeNames(typeDecl), "|") {
+			if name != "" {
+				add("type_reference", name, "")
+			}
+		}
+	}
+
+	for _, match := range p.letTypePattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+	for _, match := range p.castPattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+
+	// Paths first, blanking them out so the call pattern doesn't see their last segment
+	masked := []byte(code)
+	for _, match := range p.pathPattern.FindAllStringSubmatchIndex(code, -1) {
+		if match[0] > 0 && (code[match[0]-1] == '.' || code[match[0]-1] == '!') {
+			continue
+		}
+		var segments []string
+		for _, segment := range strings.Split(code[match[2]:match[3]], "::") {
+			if segment = strings.TrimSpace(segment); segment != "" && !strings.HasPrefix(segment, "<") {
+				segments = append(segments, segment)
+			}
+		}
+		last := code[match[4]:match[5]]
+		called := match[6] != -1
+		for i := match[0]; i < match[5]; i++ {
+			masked[i] = ' '
+		}
+		parent := segments[len(segments)-1]
+		path := strings.Join(append(append([]string{}, segments...), last), "::")
+		typePath := strings.Join(segments, "::")
+
+		switch {
+		case parent == "Self":
+			if called && !unicode.IsUpper(rune(last[0])) {
+				add("static_call", "self::"+last, "self")
+			}
+		case isRustBuiltin(parent) || (len(segments) == 1 && isRustBuiltin(last)):
+			// Standard library: String::from, Vec::new, Ordering::Less
+		case unicode.IsUpper(rune(parent[0])) && unicode.IsUpper(rune(last[0])):
+			add("type_reference", r.qualify(typePath), "") // An enum variant: Status::Active
+		case unicode.IsUpper(rune(parent[0])) && called:
+			add("static_call", r.qualify(typePath)+"::"+last, r.qualify(typePath)) // An associated function: User::new(
+		case unicode.IsUpper(rune(parent[0])):
+			add("type_reference", r.qualify(typePath), "") // An associated constant: Config::DEFAULT
+		case unicode.IsUpper(rune(last[0])) && called && last != strings.ToUpper(last):
+			add("instantiation", r.qualify(path), "") // A tuple struct: units::Meters(
+		case unicode.IsUpper(rune(last[0])) && last != strings.ToUpper(last):
+			add("type_reference", r.qualify(path), "")
+		case called:
+			add("function_call", r.qualify(path), "")
+		}
+	}
+	code = string(masked)
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		if isRustKeyword(name) || strings.HasSuffix(prefix, "fn") || strings.HasSuffix(prefix, "!") {
+			continue
+		}
+		if match[4] != -1 {
+			addTypes(code[match[4]:match[5]]) // Turbofish: collect::<Vec<User>>()
+		}
+		if strings.HasSuffix(prefix, ".") {
+			receiver := javaReceiver(strings.TrimSuffix(prefix, "."))
+			if receiver == "self" {
+				add("method_call", name, "self")
+			} else {
+				add("method_call", name, receiver)
+			}
+			continue
+		}
+		switch {
+		case unicode.IsUpper(rune(name[0])):
+			if !isRustBuiltin(name) {
+				add("instantiation", r.qualify(name), "") // A tuple struct: Meters(3.0)
+			}
+		default:
+			add("function_call", r.qualify(name), "")
+		}
+	}
+
+	for _, match := range p.literalPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimSpace(code[:match[2]])
+		if name == "Self" || isRustBuiltin(name) || strings.HasSuffix(prefix, "impl") || strings.HasSuffix(prefix, "for") ||
+			strings.HasSuffix(prefix, "struct") || strings.HasSuffix(prefix, "enum") || strings.HasSuffix(prefix, "->") {
+			continue
+		}
+		add("instantiation", r.qualify(name), "")
+	}
+}
+
+// addUse records a use declaration of path, bound to alias
+func (r *rustFile) addUse(path []string, alias string) {
+	if len(path) == 0 {
+		return
+	}
+	qualified := r.qualify(strings.Join(path, "::"))
+	r.parsed.Uses = append(r.parsed.Uses, qualified)
+	if alias != "" && alias != "_" {
+		r.imports[alias] = qualified
+	}
+}
+
+// rustUse is one path a use tree brings into scope
+type rustUse struct {
+	path  []string
+	alias string // "" for a glob
+}
+
+// expandUseTree flattens a use tree into the paths it imports:
+// "models::{User, order::{self, Order as Purchase}}" → models::User as User,
+// models::order as order, models::order::Order as Purchase
+func expandUseTree(prefix []string, tree string) []rustUse {
+	tree = strings.TrimPrefix(strings.TrimSpace(tree), "::")
+	if idx := strings.Index(tree, "{"); idx != -1 && strings.HasSuffix(tree, "}") {
+		base := append(append([]string{}, prefix...), rustPathSegments(tree[:idx])...)
+		var uses []rustUse
+		for _, branch := range splitTopLevel(tree[idx+1 : len(tree)-1]) {
+			if strings.TrimSpace(branch) != "" {
+				uses = append(uses, expandUseTree(base, branch)...)
+			}
+		}
+		return uses
+	}
+
+	alias := ""
+	if path, as, ok := strings.Cut(tree, " as "); ok {
+		tree, alias = path, strings.TrimSpace(as)
+	}
+	segments := append(append([]string{}, prefix...), rustPathSegments(tree)...)
+	if len(segments) == 0 {
+		return nil
+	}
+	last := segments[len(segments)-1]
+	switch {
+	case last == "*":
+		return []rustUse{{path: segments[:len(segments)-1]}}
+	case last == "self":
+		segments = segments[:len(segments)-1]
+		if len(segments) == 0 {
+			return nil
+		}
+		last = segments[len(segments)-1]
+	}
+	if alias == "" {
+		alias = last
+	}
+	return []rustUse{{path: segments, alias: alias}}
+}
+
+// rustPathSegments splits a path at its "::" separators, dropping empty segments
+func rustPathSegments(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "::") {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// qualify resolves a path the way rustc does, naming it as the analyzer indexes it, with
+// its module's segments separated by backslashes: "crate::models::User" →
+// `shop\models\User`. Paths start at the crate root, the current module (self), its
+// parent (super), a name brought in by use, or a child module; anything else is left to
+// the analyzer, or is another crate.
+func (r *rustFile) qualify(path string) string {
+	segments := rustPathSegments(path)
+	if len(segments) == 0 {
+		return path
+	}
+	var base []string
+	switch first := segments[0]; {
+	case first == "crate":
+		base, segments = []string{r.crate}, segments[1:]
+	case first == "self":
+		base, segments = []string{r.namespace()}, segments[1:]
+	case first == "super":
+		namespace := r.namespace()
+		for len(segments) > 0 && segments[0] == "super" {
+			if idx := strings.LastIndex(namespace, `\`); idx != -1 {
+				namespace = namespace[:idx]
+			}
+			segments = segments[1:]
+		}
+		base = []string{namespace}
+	case first == "Self":
+		base, segments = []string{r.namespace(), r.className(r.innermostDepth())}, segments[1:]
+	case r.imports[first] != "":
+		base, segments = []string{r.imports[first]}, segments[1:]
+	case len(segments) > 1 && r.modules[first]:
+		base = []string{r.namespace()}
+	}
+	return strings.Join(append(base, segments...), `\`)
+}
+
+// typeNames lists the project types in a type, qualified and separated by "|", leaving
+// out primitives and the standard library: "Result<Vec<models::User>, Error>" →
+// `shop\models\User|Error`
+func (r *rustFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != ':'
+	}) {
+		word = strings.Trim(word, ":")
+		last := rustLastSegment(word)
+		if last == "" || !unicode.IsUpper(rune(last[0])) || len(last) == 1 || isRustBuiltin(last) || last == "Self" {
+			continue // Primitives, lifetimes, type parameters, and the standard library
+		}
+		names = append(names, r.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// declaring checks if a line at depth is directly in a module, impl, or trait body, where
+// items are declared
+func (r *rustFile) declaring(depth int) bool {
+	top := r.top()
+	return top == nil || (top.depth == depth && top.kind != "function" && top.kind != "macro")
+}
+
+// top returns the innermost scope, or nil at the top level of the file
+func (r *rustFile) top() *rustScope {
+	if len(r.scopes) == 0 {
+		return nil
+	}
+	return &r.scopes[len(r.scopes)-1]
+}
+
+// innermostDepth returns the brace depth of the innermost scope
+func (r *rustFile) innermostDepth() int {
+	if top := r.top(); top != nil {
+		return top.depth
+	}
+	return 0
+}
+
+// className returns the type whose impl or trait body the line at depth is directly in,
+// or whose impl it's nested in below that
+func (r *rustFile) className(depth int) string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		switch r.scopes[i].kind {
+		case "impl", "trait", "type":
+			if r.scopes[i].depth <= depth {
+				return r.scopes[i].name
+			}
+		case "module":
+			return ""
+		}
+	}
+	return ""
+}
+
+// context returns the innermost function or type being parsed
+func (r *rustFile) context() string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if r.scopes[i].kind != "module" {
+			return r.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// namespace returns the module being parsed: the file's, plus any inline modules
+func (r *rustFile) namespace() string {
+	namespace := r.module
+	for _, scope := range r.scopes {
+		if scope.kind == "module" {
+			namespace += `\` + scope.name
+		}
+	}
+	return namespace
+}
+
+// rustModule derives a file's crate and module from its path under the nearest
+// Cargo.toml: src/lib.rs and src/main.rs are the crate root, src/models.rs and
+// src/models/mod.rs are its models module, and binaries, tests, examples, and benches are
+// crates of their own. The crate is named after the package, with hyphens as
+// underscores the way other crates refer to it; outside a package it's "crate".
+func rustModule(filePath string) (string, string) {
+	dir := filepath.Dir(filePath)
+	crate, crateDir := "", ""
+	for cargoDir := dir; ; {
+		if name := cargoPackageName(filepath.Join(cargoDir, "Cargo.toml")); name != "" {
+			crate, crateDir = strings.ReplaceAll(name, "-", "_"), cargoDir
+			break
+		}
+		parent := filepath.Dir(cargoDir)
+		if parent == cargoDir {
+			break
+		}
+		cargoDir = parent
+	}
+
+	var segments []string
+	if crateDir != "" {
+		if rel, err := filepath.Rel(crateDir, filePath); err == nil {
+			segments = strings.Split(filepath.ToSlash(rel), "/")
+		}
+	} else {
+		crate = "crate"
+		segments = strings.Split(filepath.ToSlash(filePath), "/")
+		for i := len(segments) - 1; i >= 0; i-- {
+			if segments[i] == "src" {
+				segments = segments[i:]
+				break
+			}
+		}
+		if segments[0] != "src" {
+			segments = segments[len(segments)-1:]
+		}
+	}
+
+	stem := strings.TrimSuffix(segments[len(segments)-1], ".rs")
+	segments = segments[:len(segments)-1]
+	switch {
+	case len(segments) > 0 && segments[0] == "src" && (len(segments) == 1 || segments[1] != "bin"):
+		segments = segments[1:]
+		if stem != "mod" && !(len(segments) == 0 && (stem == "lib" || stem == "main")) {
+			segments = append(segments, stem)
+		}
+	default:
+		// A crate of its own: src/bin/seed.rs, tests/api.rs, examples/demo/main.rs, build.rs
+		if len(segments) > 0 && segments[0] == "src" {
+			segments = segments[1:]
+		}
+		if stem != "main" && stem != "mod" {
+			segments = append(segments, stem)
+		}
+		crate = strings.Join(append([]string{crate}, segments...), `\`)
+		return crate, crate
+	}
+	return crate, strings.Join(append([]string{crate}, segments...), `\`)
+}
+
+// cargoPackagePattern finds the package name in a Cargo.toml's [package] table
+var cargoPackagePattern = regexp.MustCompile(`(?s)\[package\][^\[]*?\bname\s*=\s*"([^"]+)"`)
+
+// cargoPackageName reads the package name from a Cargo.toml, or returns "" when there is
+// none, as for a virtual workspace manifest
+func cargoPackageName(cargoToml string) string {
+	data, err := os.ReadFile(cargoToml)
+	if err != nil {
+		return ""
+	}
+	if match := cargoPackagePattern.FindSubmatch(data); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+// rustTypeName takes the name of the type an impl is for: "&'a mut Wrapper<T>" → "Wrapper"
+func rustTypeName(typeDecl string) string {
+	typeDecl = strings.TrimSpace(typeDecl)
+	for _, prefix := range []string{"&", "'", "mut ", "dyn "} {
+		for strings.HasPrefix(typeDecl, prefix) {
+			typeDecl = strings.TrimPrefix(typeDecl, prefix)
+			if prefix == "'" {
+				typeDecl = strings.TrimLeftFunc(typeDecl, func(c rune) bool { return unicode.IsLetter(c) || c == '_' })
+			}
+			typeDecl = strings.TrimSpace(typeDecl)
+		}
+	}
+	if idx := strings.IndexAny(typeDecl, "<( "); idx != -1 {
+		typeDecl = typeDecl[:idx]
+	}
+	return rustLastSegment(typeDecl)
+}
+
+// rustLastSegment returns the last segment of a path: "std::fmt::Display" → "Display"
+func rustLastSegment(path string) string {
+	if idx := strings.LastIndex(path, "::"); idx != -1 {
+		return path[idx+2:]
+	}
+	return path
+}
+
+// rustVisibility maps a visibility qualifier to a visibility: pub is public, pub(crate)
+// and the other restricted forms are internal, and items without one are private
+func rustVisibility(qualifier string) string {
+	qualifier = strings.TrimSpace(qualifier)
+	switch {
+	case strings.HasPrefix(qualifier, "pub(") || strings.HasPrefix(qualifier, "pub ("):
+		return "internal"
+	case strings.HasPrefix(qualifier, "pub"):
+		return "public"
+	}
+	return "private"
+}
+
+// rustAttributeEnd returns the index just past the attribute s starts with, #[...] or
+// #![...], or -1 when it continues on the next line
+func rustAttributeEnd(s string) int {
+	depth := 0
+	for i, c := range s {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// stripRustLine removes comments from a line, returning the code and the code with
+// string and character contents blanked out. Block comments nest, and strings, raw or
+// not, may continue onto the next lines; state carries both across lines.
+func stripRustLine(line string, state *rustLexState) (string, string) {
+	var code, bare strings.Builder
+	runes := []rune(line)
+	isIdent := func(c rune) bool { return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) }
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case state.comment > 0:
+			if c == '/' && next == '*' {
+				state.comment++
+				i++
+			} else if c == '*' && next == '/' {
+				state.comment--
+				i++
+			}
+		case state.raw > 0:
+			code.WriteRune(c)
+			if c == '"' {
+				hashes := 0
+				for i+1+hashes < len(runes) && runes[i+1+hashes] == '#' && hashes < state.raw-1 {
+					hashes++
+				}
+				if hashes == state.raw-1 {
+					code.WriteString(strings.Repeat("#", hashes))
+					bare.WriteString(`"` + strings.Repeat("#", hashes))
+					i += hashes
+					state.raw = 0
+				}
+			}
+		case state.str:
+			code.WriteRune(c)
+			if c == '\\' && next != 0 {
+				code.WriteRune(next)
+				i++
+			} else if c == '"' {
+				state.str = false
+				bare.WriteRune(c)
+			}
+		case c == '/' && next == '/':
+			return code.String(), bare.String()
+		case c == '/' && next == '*':
+			state.comment = 1
+			i++
+		case c == 'r' && (next == '"' || next == '#') && (i == 0 || !isIdent(runes[i-1]) || (runes[i-1] == 'b' && (i == 1 || !isIdent(runes[i-2])))):
+			hashes := 0
+			for i+1+hashes < len(runes) && runes[i+1+hashes] == '#' {
+				hashes++
+			}
+			if i+1+hashes >= len(runes) || runes[i+1+hashes] != '"' {
+				code.WriteRune(c)
+				bare.WriteRune(c)
+				continue
+			}
+			opening := "r" + strings.Repeat("#", hashes) + `"`
+			code.WriteString(opening)
+			bare.WriteString(opening)
+			state.raw = hashes + 1
+			i += hashes + 1
+		case c == '"':
+			state.str = true
+			code.WriteRune(c)
+			bare.WriteRune(c)
+		case c == '\'' && (next == '\\' || (i+2 < len(runes) && runes[i+2] == '\'')):
+			// A character literal, as opposed to a lifetime: 'a', '\n', '\u{1F600}'
+			end := i + 2
+			if next == '\\' {
+				end = i + 3
+				for end < len(runes) && runes[end] != '\'' {
+					end++
+				}
+			}
+			if end >= len(runes) {
+				end = len(runes) - 1
+			}
+			code.WriteString(string(runes[i : end+1]))
+			bare.WriteString("''")
+			i = end
+		default:
+			code.WriteRune(c)
+			bare.WriteRune(c)
+		}
+	}
+	return code.String(), bare.String()
+}
+
+// isRustKeyword checks if a word is a Rust keyword that can precede a parenthesis
+func isRustKeyword(word string) bool {
+	switch word {
+	case "if", "else", "for", "while", "loop", "match", "return", "break", "continue", "in",
+		"let", "mut", "ref", "move", "fn", "impl", "where", "as", "use", "mod", "pub", "crate",
+		"super", "self", "Self", "async", "await", "unsafe", "dyn", "type", "struct", "enum",
+		"trait", "const", "static", "extern", "box", "yield":
+		return true
+	}
+	return false
+}
+
+// isRustBuiltin checks if a type name is a primitive or one of the standard library's
+// common types and traits, or a prelude variant
+func isRustBuiltin(name string) bool {
+	switch name {
+	case "i8", "i16", "i32", "i64", "i128", "isize", "u8", "u16", "u32", "u64", "u128", "usize",
+		"f32", "f64", "bool", "char", "str", "String", "Vec", "Option", "Some", "None", "Result",
+		"Ok", "Err", "Box", "Rc", "Arc", "Weak", "Cell", "RefCell", "Mutex", "RwLock", "Cow",
+		"HashMap", "HashSet", "BTreeMap", "BTreeSet", "VecDeque", "BinaryHeap", "PhantomData",
+		"Fn", "FnMut", "FnOnce", "Send", "Sync", "Sized", "Copy", "Clone", "Debug", "Default",
+		"Display", "Eq", "PartialEq", "Ord", "PartialOrd", "Hash", "Iterator", "IntoIterator",
+		"From", "Into", "TryFrom", "TryInto", "AsRef", "AsMut", "Deref", "DerefMut", "Drop",
+		"ToString", "FromStr", "Error", "Future", "Pin", "Duration", "Instant", "Path", "PathBuf",
+		"Ordering", "Formatter":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Rust files concurrently
+func (p *RustParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *RustParser) Language() string {
+	return "rust"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *RustParser) FileExtensions() []string {
+	return []string{".rs"}
+}
+
+// DefaultExcludes returns the directories skipped in Rust projects: Cargo's build output
+func (p *RustParser) DefaultExcludes() []string {
+	return []string{"target"}
+}
+
+// Entrypoints returns the functions called by the runtime rather than by other code:
+// main, and the methods of standard traits, which are called through operators,
+// formatting, conversions, and drops
+func (p *RustParser) Entrypoints() []string {
+	return []string{
+		`^main$`,
+		`^(fmt|drop|clone|default|eq|ne|partial_cmp|cmp|hash|deref|deref_mut|from|try_from|from_str|from_iter|into_iter|next|as_ref|as_mut|index|index_mut|add|sub|mul|div|rem|neg|not|poll|source|serialize|deserialize)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewRustParser())
+}
@@ -0,0 +1,239 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestRustParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "src", "services"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, "Cargo.toml", "[package]\nname = \"shop-core\"\nversion = \"0.1.0\"\n")
+	code := `//! User services.
+
+use crate::models::{self, User, Email as Address};
+use super::helpers::*;
+
+/// Registers users.
+#[derive(Debug)]
+pub struct UserService {
+    users: Vec<User>, // TODO: persist
+}
+
+pub enum Event {
+    Created(User),
+    Deleted { id: u64 },
+}
+
+pub trait Repository: Send + Audit {
+    fn save(&mut self, user: &User) -> Result<(), String>;
+    fn count(&self) -> usize { 0 }
+}
+
+impl UserService {
+    pub const LIMIT: usize = 10;
+
+    pub fn new() -> Self {
+        Self { users: Vec::new() }
+    }
+
+    pub(crate) fn register<'a>(&mut self, name: &'a str) -> Option<&User>
+    where
+        Self: Sized,
+    {
+        let user: User = User { name: name.to_string(), email: Address(String::new()) };
+        let brace = '{';
+        let raw = r#"fn fake() { "#;
+        self.notify(Event::Created(user));
+        Self::validate(name);
+        models::normalize(name);
+        /* block /* nested */ comment */
+        self.users.last()
+    }
+
+    fn notify(&self, event: Event) {}
+}
+
+macro_rules! noisy {
+    ($x:expr) => { fake($x) };
+}
+`
+	path := writeFixture(t, tmp, "src/services/mod.rs", code)
+
+	parsed, err := NewRustParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "rust" || parsed.Namespace != `shop_core\services` {
+		t.Errorf("expected the module under the package's crate name, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	wantUses := []string{`shop_core\models`, `shop_core\models\User`, `shop_core\models\Email`, `shop_core\helpers`}
+	if len(parsed.Uses) != len(wantUses) {
+		t.Fatalf("expected uses %v, got %v", wantUses, parsed.Uses)
+	}
+	for i, use := range wantUses {
+		if parsed.Uses[i] != use {
+			t.Errorf("expected uses %v, got %v", wantUses, parsed.Uses)
+		}
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "property:UserService.users", "enum:.Event",
+		"constant:Event.Created", "constant:Event.Deleted", "trait:.Repository", "method:Repository.save",
+		"method:Repository.count", "constant:UserService.LIMIT", "method:UserService.new",
+		"method:UserService.register", "method:UserService.notify"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 12 {
+		t.Errorf("expected 12 elements, got %+v", parsed.Elements)
+	}
+
+	service := elements["class:.UserService"]
+	if !service.Documented || service.Line != 8 || elements["enum:.Event"].Documented {
+		t.Errorf("expected only the struct with a doc comment to be documented, got %+v", service)
+	}
+	register := elements["method:UserService.register"]
+	if register.Visibility != "internal" || register.IsStatic || register.Line != 29 {
+		t.Errorf("expected the pub(crate) method on line 29, got %+v", register)
+	}
+	if len(register.Parameters) != 1 || register.Parameters[0] != "name" || register.ReturnType != `shop_core\models\User` {
+		t.Errorf("expected the parameters without self, got %v returning %q", register.Parameters, register.ReturnType)
+	}
+	if !elements["method:UserService.new"].IsStatic || elements["method:UserService.notify"].Visibility != "private" {
+		t.Error("expected functions without self to be static, and private by default")
+	}
+	if !elements["method:Repository.save"].IsAbstract || elements["method:Repository.count"].IsAbstract {
+		t.Error("expected only the trait's function without a body to be abstract")
+	}
+	if save := elements["method:Repository.save"]; save.Visibility != "public" || save.ParamTypes[0] != `shop_core\models\User` {
+		t.Errorf("expected trait methods to be public, got %+v", save)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		`type_reference:.shop_core\models\User in UserService`,
+		`type_reference:.shop_core\models\User in Event`,
+		"extends:.Audit in Repository",
+		`instantiation:.shop_core\models\User in register`,
+		`instantiation:.shop_core\models\Email in register`,
+		"type_reference:.Event in register",
+		"method_call:self.notify in register",
+		"static_call:self.self::validate in register",
+		`function_call:.shop_core\models\normalize in register`,
+		"method_call:self.users.last in register",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"extends:.Send in Repository", "function_call:.fake in register", "instantiation:.Self in new"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 9 {
+		t.Errorf("expected the TODO on line 9, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 3 {
+		t.Errorf("expected 3 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestRustModule(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "Cargo.toml", "[package]\nname = \"shop-core\"\n")
+	for file, want := range map[string][2]string{
+		"src/lib.rs":              {"shop_core", "shop_core"},
+		"src/models.rs":           {"shop_core", `shop_core\models`},
+		"src/models/mod.rs":       {"shop_core", `shop_core\models`},
+		"src/models/user.rs":      {"shop_core", `shop_core\models\user`},
+		"src/bin/seed.rs":         {`shop_core\bin\seed`, `shop_core\bin\seed`},
+		"tests/api.rs":            {`shop_core\tests\api`, `shop_core\tests\api`},
+		"../elsewhere/src/lib.rs": {"crate", "crate"},
+	} {
+		crate, module := rustModule(filepath.Join(tmp, file))
+		if crate != want[0] || module != want[1] {
+			t.Errorf("%s: expected crate %q and module %q, got %q and %q", file, want[0], want[1], crate, module)
+		}
+	}
+}
+
+func TestRustParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, "Cargo.toml", "[package]\nname = \"game\"\n")
+	writeFixture(t, tmp, "src/main.rs", `mod player;
+
+use player::Player;
+
+fn main() {
+    let mut player = Player::new(100);
+    player.damage(10);
+}
+`)
+	writeFixture(t, tmp, "src/player.rs", `pub struct Player {
+    health: i32,
+}
+
+impl Player {
+    pub fn new(health: i32) -> Self {
+        Player { health }
+    }
+
+    pub fn damage(&mut self, amount: i32) {
+        self.health -= amount;
+    }
+
+    fn heal(&mut self) {}
+}
+`)
+
+	p := NewRustParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"src/main.rs", "src/player.rs"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Namespace+`\`+node.ClassName+"."+node.Name] = node
+	}
+	main, player := nodes[`game\.main`], nodes[`game\player\.Player`]
+	if main == nil || player == nil || !main.IsEntrypoint {
+		t.Fatalf("expected main to be an entrypoint, got %+v", main)
+	}
+	if main.Dependencies[player.ID] == nil {
+		t.Errorf("expected main to depend on the imported Player, got %+v", main.Dependencies)
+	}
+	if heal := nodes[`game\player\Player.heal`]; heal == nil || len(heal.Dependents) != 0 {
+		t.Errorf("expected the unused method to have no dependents, got %+v", heal)
+	}
+}
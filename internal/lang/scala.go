@@ -0,0 +1,866 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// ScalaParser handles parsing of Scala files and worksheets
+type ScalaParser struct {
+	packagePattern       *regexp.Regexp
+	packageObjectPattern *regexp.Regexp
+	importPattern        *regexp.Regexp
+	annotationPattern    *regexp.Regexp
+	typePattern          *regexp.Regexp
+	defPattern           *regexp.Regexp
+	valPattern           *regexp.Regexp
+	casePattern          *regexp.Regexp
+	newPattern           *regexp.Regexp
+	typeArgPattern       *regexp.Regexp
+	typeTestPattern      *regexp.Regexp
+	callPattern          *regexp.Regexp
+	memberPattern        *regexp.Regexp
+}
+
+// scalaScope is a type or function body the parser is inside
+type scalaScope struct {
+	kind   string // "type" or "function"
+	name   string // A companion object's is its class's
+	depth  int    // Brace depth inside the body
+	indent int    // For a body without braces, the indentation of its header; -1 with braces
+	static bool   // An object or companion object, whose members need no instance
+	enum   bool   // An enum's body, where case declares cases
+}
+
+// scalaHeader is a type declared without a body on its line, whose extends and with
+// clauses may follow on the next lines
+type scalaHeader struct {
+	keyword string
+	name    string
+	static  bool
+	enum    bool
+	indent  int
+}
+
+// scalaFile is the state of one file's parse
+type scalaFile struct {
+	parsed  *models.ParsedFile
+	imports map[string]string // Simple name → qualified name, e.g. "User" → `com.acme.model\User`
+	scopes  []scalaScope
+	header  *scalaHeader
+}
+
+// scalaModifiers matches a declaration's modifiers, including qualified access: private[users]
+const scalaModifiers = `((?:(?:(?:private|protected)(?:\[\w*\])?|abstract|final|sealed|implicit|lazy|override|case|open|inline|transparent|opaque|infix|erased)\s+)*)`
+
+// NewScalaParser creates a new Scala parser with compiled regex patterns
+func NewScalaParser() *ScalaParser {
+	return &ScalaParser{
+		// Packages: package com.acme.users, package com.acme.users {
+		packagePattern: regexp.MustCompile(`^\s*package\s+([\w.]+)\s*(\{)?`),
+
+		// Package objects: package object users {
+		packageObjectPattern: regexp.MustCompile(`^\s*package\s+object\s+\w+`),
+
+		// Imports: import com.acme.model.User, import com.acme.model.{User, Account => Acct}, import scala.concurrent._
+		importPattern: regexp.MustCompile(`^\s*import\s+(.+?)\s*;?\s*$`),
+
+		// A leading annotation: @Inject(), @tailrec, @deprecated("use find", "2.0")
+		annotationPattern: regexp.MustCompile(`^\s*@([A-Za-z_][\w.]*)`),
+
+		// Types: case class User(name: String), sealed trait Status, object Registry, enum Color
+		typePattern: regexp.MustCompile(`^\s*` + scalaModifiers + `(class|trait|object|enum)\s+([A-Za-z_]\w*)`),
+
+		// Methods: def find(id: Long): Option[User], def name: String, def +(other: Money), def this(name: String)
+		defPattern: regexp.MustCompile(`^\s*` + scalaModifiers + `def\s+([A-Za-z_]\w*|` + "`[^`]+`" + `|[^\s\w\[\](){}:=,;]+)`),
+
+		// Fields: private val repository: UserRepository, lazy val cache = Cache(), var count = 0
+		valPattern: regexp.MustCompile(`^\s*` + scalaModifiers + `(val|var)\s+([A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s*(?::\s*([^=]+?))?\s*(=|$)`),
+
+		// Enum cases: case Red, Green, case Custom(rgb: Int) extends Color(rgb)
+		casePattern: regexp.MustCompile(`^\s*case\s+([A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)`),
+
+		// Instantiations: new UserService(repo), new Thread with Runnable {
+		newPattern: regexp.MustCompile(`\bnew\s+([A-Za-z_][\w.]*)`),
+
+		// Type arguments of the standard type functions: classOf[User], x.asInstanceOf[Admin]
+		typeArgPattern: regexp.MustCompile(`\b(?:classOf|isInstanceOf|asInstanceOf)\s*\[([^\]]+)\]`),
+
+		// Type patterns: case e: IOException =>, case _: Admin =>
+		typeTestPattern: regexp.MustCompile(`\bcase\s+\w+\s*:\s*([A-Z][\w.]*)`),
+
+		// Calls, with any type arguments and block arguments: save(user), inject[Repo](), Future {
+		callPattern: regexp.MustCompile(`([A-Za-z_]\w*)\s*(?:\[([\w.,\s\[\]_?]*)\])?\s*([({])`),
+
+		// Members accessed without parentheses, as parameterless methods are: user.displayName
+		memberPattern: regexp.MustCompile(`(^|[^\w.])([A-Za-z_]\w*)\.([a-z_]\w*)\b`),
+	}
+}
+
+// ParseFile analyzes a single Scala file and extracts all elements
+func (p *ScalaParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	s := &scalaFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+		imports: make(map[string]string),
+	}
+	parsed := s.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	inComment := false
+	inRaw := false    // Inside a """raw string"""
+	docblock := false // A /** */ Scaladoc comment precedes the next declaration
+
+	var annotations []string // Annotations awaiting the declaration they annotate
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		if lineNum == 1 && strings.HasPrefix(line, "#!") {
+			continue // A script's shebang
+		}
+
+		if !inComment && !inRaw && strings.HasPrefix(strings.TrimSpace(line), "/**") {
+			docblock = true
+		}
+		code, bare, stillInComment, stillInRaw := stripKotlinLine(line, inComment, inRaw)
+		inComment, inRaw = stillInComment, stillInRaw
+		if strings.TrimSpace(bare) == "" {
+			if strings.TrimSpace(line) != "" && strings.TrimSpace(code) == "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Join multi-line parameter lists and calls, so a parameter list is parsed whole
+		for parenBalance(bare) > 0 && !inComment && !inRaw && scanner.Scan() {
+			joinedLines++
+			nextCode, nextBare, nextInComment, nextInRaw := stripKotlinLine(scanner.Text(), false, false)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+			inComment, inRaw = nextInComment, nextInRaw
+		}
+
+		// Leave the bodies without braces that this line is outdented from
+		indent := len(bare) - len(strings.TrimLeft(bare, " \t"))
+		for top := s.top(); top != nil && top.indent >= 0 && top.depth == braceDepth && indent <= top.indent; top = s.top() {
+			s.scopes = s.scopes[:len(s.scopes)-1]
+		}
+		trimmed := strings.TrimSpace(bare)
+		if trimmed == "end" || strings.HasPrefix(trimmed, "end ") {
+			continue // Scala 3's end marker
+		}
+
+		// A type's extends and with clauses, continued from the lines before
+		if header := s.header; header != nil {
+			s.header = nil
+			if strings.HasPrefix(trimmed, "extends ") || strings.HasPrefix(trimmed, "with ") || strings.HasPrefix(trimmed, "derives ") {
+				depthBefore := braceDepth
+				braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+				scopes := len(s.scopes)
+				body := s.openType(header, trimmed, depthBefore, lineNum)
+				if len(s.scopes) == scopes {
+					s.header = header
+				}
+				p.parseUsage(s, body, lineNum, s.context())
+				for len(s.scopes) > 0 && braceDepth < s.scopes[len(s.scopes)-1].depth {
+					s.scopes = s.scopes[:len(s.scopes)-1]
+				}
+				continue
+			}
+		}
+
+		if p.packageObjectPattern.MatchString(bare) {
+			// A package object's members are the package's
+			braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+			continue
+		}
+		if matches := p.packagePattern.FindStringSubmatch(bare); matches != nil && len(s.scopes) == 0 {
+			if parsed.Namespace != "" {
+				parsed.Namespace += "." + matches[1] // Chained clauses: package com.acme, then package users
+			} else {
+				parsed.Namespace = matches[1]
+			}
+			braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+			continue
+		}
+		if matches := p.importPattern.FindStringSubmatch(bare); matches != nil {
+			for _, clause := range splitTopLevel(matches[1]) {
+				s.addImport(strings.TrimSpace(clause))
+			}
+			continue
+		}
+
+		// Annotations on their own lines wait for the declaration that follows them
+		for {
+			match := p.annotationPattern.FindStringSubmatchIndex(bare)
+			if match == nil {
+				break
+			}
+			annotations = append(annotations, bare[match[2]:match[3]])
+			bare = strings.TrimSpace(bare[match[1]:])
+			if strings.HasPrefix(bare, "(") {
+				if end := closingParen(bare); end != -1 {
+					bare = bare[end+1:]
+				}
+			}
+		}
+		if strings.TrimSpace(bare) == "" {
+			continue
+		}
+
+		documented := docblock
+		docblock = false
+		annotating := annotations
+		annotations = nil
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := s.top()
+		declaring := top == nil || (top.kind == "type" && top.depth == depthBefore)
+		className, static := s.enclosingType(depthBefore)
+
+		if matches := p.casePattern.FindStringSubmatchIndex(bare); declaring && top != nil && top.enum && matches != nil {
+			for _, name := range strings.Split(bare[matches[2]:matches[3]], ",") {
+				parsed.Elements = append(parsed.Elements, models.CodeElement{
+					Type:       "constant",
+					Name:       strings.TrimSpace(name),
+					Namespace:  parsed.Namespace,
+					ClassName:  className,
+					Visibility: "public",
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				})
+			}
+			body = bare[matches[1]:]
+			context = className
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			header := &scalaHeader{keyword: keyword, name: name, static: keyword == "object", enum: keyword == "enum", indent: indent}
+
+			if keyword == "object" && s.declares(name) {
+				// A companion object's members are its class's, without an instance
+			} else {
+				element := models.CodeElement{
+					Type:       "class",
+					Name:       name,
+					Namespace:  parsed.Namespace,
+					Visibility: scalaVisibility(modifiers),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+					IsAbstract: strings.Contains(modifiers, "abstract") || strings.Contains(modifiers, "sealed") || keyword == "trait",
+					IsReadonly: strings.Contains(modifiers, "case"),
+				}
+				switch keyword {
+				case "trait":
+					element.Type = "interface"
+				case "enum":
+					element.Type = "enum"
+				}
+				parsed.Elements = append(parsed.Elements, element)
+			}
+			s.addAnnotations(annotating, name, lineNum)
+
+			rest := s.parseConstructor(bare[matches[1]:], name, strings.Contains(modifiers, "case"), lineNum)
+			scopes := len(s.scopes)
+			body = s.openType(header, rest, depthBefore, lineNum)
+			if len(s.scopes) == scopes {
+				s.header = header // No body yet: extends may follow on the next line
+			}
+		} else if def := p.defPattern.FindStringSubmatchIndex(bare); declaring && def != nil {
+			modifiers := bare[def[2]:def[3]]
+			name := strings.Trim(bare[def[4]:def[5]], "`")
+			rest := bare[def[1]:]
+
+			element := models.CodeElement{
+				Type:       "function",
+				Name:       name,
+				Namespace:  parsed.Namespace,
+				ClassName:  className,
+				Visibility: scalaVisibility(modifiers),
+				IsStatic:   static,
+				Line:       lineNum,
+				File:       filePath,
+				Documented: documented,
+				Parameters: []string{},
+			}
+			if className != "" {
+				element.Type = "method"
+			}
+			if name == "this" {
+				element.Name, element.IsStatic = className, false // An auxiliary constructor, named for its class like Java's
+			}
+
+			// Type parameters, then any number of parameter lists: [T](id: Long)(implicit ec: ExecutionContext)
+			rest = strings.TrimSpace(rest)
+			if strings.HasPrefix(rest, "[") {
+				if end := topLevelIndex(rest[1:], ']'); end != -1 {
+					rest = strings.TrimSpace(rest[end+2:])
+				}
+			}
+			for strings.HasPrefix(rest, "(") {
+				end := closingParen(rest)
+				if end == -1 {
+					break
+				}
+				for _, param := range splitTopLevel(rest[1:end]) {
+					param = strings.TrimSpace(param)
+					param = strings.TrimPrefix(strings.TrimPrefix(param, "implicit "), "using ")
+					paramName, paramType := kotlinParameter(param)
+					if paramName == "" {
+						continue
+					}
+					element.Parameters = append(element.Parameters, paramName)
+					element.ParamTypes = append(element.ParamTypes, s.typeNames(paramType))
+				}
+				rest = strings.TrimSpace(rest[end+1:])
+			}
+
+			// The return type follows the parameters: ): Option[User] =
+			equals := topLevelIndex(rest, '=')
+			brace := strings.Index(rest, "{")
+			if returns := rest; strings.HasPrefix(returns, ":") {
+				returns = returns[1:]
+				if equals != -1 {
+					returns = returns[:equals-1]
+				} else if brace != -1 {
+					returns = returns[:brace-1]
+				}
+				element.ReturnType = s.typeNames(returns)
+			}
+			element.IsAbstract = equals == -1 && brace == -1 && name != "this"
+			parsed.Elements = append(parsed.Elements, element)
+			s.addAnnotations(annotating, element.Name, lineNum)
+
+			body, context = s.functionBody(rest, element.Name, depthBefore, braceDepth, indent)
+		} else if matches := p.valPattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			fieldType := ""
+			if matches[8] != -1 {
+				fieldType = bare[matches[8]:matches[9]]
+			}
+			body = bare[matches[10]:]
+
+			if className != "" {
+				for _, name := range strings.Split(bare[matches[6]:matches[7]], ",") {
+					parsed.Elements = append(parsed.Elements, models.CodeElement{
+						Type:       "property",
+						Name:       strings.TrimSpace(name),
+						Namespace:  parsed.Namespace,
+						ClassName:  className,
+						Visibility: scalaVisibility(modifiers),
+						IsStatic:   static,
+						IsReadonly: keyword == "val",
+						IsAbstract: matches[10] == matches[11],
+						Line:       lineNum,
+						File:       filePath,
+						Documented: documented,
+					})
+				}
+
+				// A field's type is a dependency of its class, such as an injected service
+				for _, typeName := range strings.Split(s.typeNames(fieldType), "|") {
+					if typeName != "" {
+						parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: className, Line: lineNum})
+					}
+				}
+			}
+			context = className
+			s.addAnnotations(annotating, className, lineNum)
+		} else {
+			s.addAnnotations(annotating, s.context(), lineNum)
+		}
+
+		if context == "" {
+			context = s.context()
+		}
+		p.parseUsage(s, body, lineNum, context)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, s.className(), context)...)
+
+		// Leave the bodies closed on this line
+		for len(s.scopes) > 0 && braceDepth < s.scopes[len(s.scopes)-1].depth {
+			s.scopes = s.scopes[:len(s.scopes)-1]
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// openType records a type's supertypes from the rest of its header and opens its body,
+// if the header opens one: a brace, or Scala 3's colon. It returns the code in the body.
+func (s *scalaFile) openType(header *scalaHeader, rest string, depthBefore, lineNum int) string {
+	body := ""
+	scope := scalaScope{kind: "type", name: header.name, static: header.static, enum: header.enum, indent: -1}
+	if idx := topLevelBrace(rest); idx != -1 {
+		rest, body = rest[:idx], rest[idx+1:]
+		scope.depth = depthBefore + 1
+		s.scopes = append(s.scopes, scope)
+	} else if trimmed := strings.TrimSpace(rest); strings.HasSuffix(trimmed, ":") {
+		rest = strings.TrimSuffix(trimmed, ":")
+		scope.depth, scope.indent = depthBefore, header.indent
+		s.scopes = append(s.scopes, scope)
+	}
+	s.parseSupertypes(rest, header.keyword, header.name, lineNum)
+	return body
+}
+
+// parseSupertypes records the types a type extends and mixes in. A class's first type
+// extends unless it's a trait declared in the file; the traits mixed in with `with` are
+// implemented. A trait extends all of its supertypes.
+func (s *scalaFile) parseSupertypes(header, keyword, name string, lineNum int) {
+	if idx := strings.Index(header, " derives "); idx != -1 {
+		header = header[:idx]
+	}
+	header = " " + strings.TrimSpace(header)
+	idx := strings.Index(header, " extends ")
+	first := idx != -1
+	if first {
+		header = header[idx+len(" extends "):]
+	} else if idx = strings.Index(header, " with "); idx != -1 {
+		header = header[idx+len(" with "):] // A continued header: with Logging
+	} else {
+		return
+	}
+
+	var supertypes []string
+	for _, part := range strings.Split(header, " with ") {
+		supertypes = append(supertypes, splitTopLevel(part)...) // Scala 3 lists them with commas
+	}
+	for i, supertype := range supertypes {
+		if idx := strings.IndexAny(supertype, "[({"); idx != -1 {
+			supertype = supertype[:idx]
+		}
+		if supertype = strings.TrimSpace(supertype); supertype == "" {
+			continue
+		}
+		usageType := "implements"
+		if keyword == "trait" || (i == 0 && first && !s.isTrait(supertype)) {
+			usageType = "extends"
+		}
+		s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    s.qualify(supertype),
+			Context: name,
+			Line:    lineNum,
+		})
+	}
+}
+
+// parseConstructor records a type's constructor parameters from the header after its
+// name and returns the rest of the header. A case class's parameters and those declared
+// val or var are properties, and every parameter's type is a dependency of the class.
+func (s *scalaFile) parseConstructor(header, className string, caseClass bool, lineNum int) string {
+	header = strings.TrimSpace(header)
+	if strings.HasPrefix(header, "[") {
+		if end := topLevelIndex(header[1:], ']'); end != -1 {
+			header = strings.TrimSpace(header[end+2:])
+		}
+	}
+	header = strings.TrimSpace(stripAnnotations(header)) // class UserService @Inject() (repo: Repo)
+	header = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(header, "private "), "protected "))
+
+	for first := true; strings.HasPrefix(header, "("); first = false {
+		end := closingParen(header)
+		if end == -1 {
+			break
+		}
+		for _, param := range splitTopLevel(header[1:end]) {
+			param = strings.TrimSpace(stripAnnotations(param))
+			fields := strings.Fields(strings.SplitN(param, ":", 2)[0])
+			paramName, paramType := kotlinParameter(strings.TrimPrefix(strings.TrimPrefix(param, "implicit "), "using "))
+			if paramName == "" {
+				continue
+			}
+			keyword := ""
+			if len(fields) > 1 {
+				keyword = fields[len(fields)-2]
+			}
+			if keyword == "val" || keyword == "var" || (caseClass && first && !strings.HasPrefix(param, "private ")) {
+				s.parsed.Elements = append(s.parsed.Elements, models.CodeElement{
+					Type:       "property",
+					Name:       paramName,
+					Namespace:  s.parsed.Namespace,
+					ClassName:  className,
+					Visibility: scalaVisibility(strings.Join(fields, " ")),
+					IsReadonly: keyword != "var",
+					Line:       lineNum,
+					File:       s.parsed.Path,
+				})
+			}
+			for _, typeName := range strings.Split(s.typeNames(paramType), "|") {
+				if typeName != "" {
+					s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: className, Line: lineNum})
+				}
+			}
+		}
+		header = strings.TrimSpace(header[end+1:])
+	}
+	return header
+}
+
+// functionBody returns the code following a method's signature, and the method it
+// belongs to. A brace opens a scope for the lines that follow, and so does `=`, for the
+// lines indented under it.
+func (s *scalaFile) functionBody(rest, name string, depthBefore, depthAfter, indent int) (string, string) {
+	equals := topLevelIndex(rest, '=')
+	brace := strings.Index(rest, "{")
+	switch {
+	case depthAfter > depthBefore:
+		s.scopes = append(s.scopes, scalaScope{kind: "function", name: name, depth: depthBefore + 1, indent: -1})
+	case equals != -1:
+		s.scopes = append(s.scopes, scalaScope{kind: "function", name: name, depth: depthAfter, indent: indent})
+	}
+	switch {
+	case equals != -1:
+		return rest[equals+1:], name
+	case brace != -1:
+		return rest[brace+1:], name
+	}
+	return "", name
+}
+
+// addImport records an import clause: a path, with any {selectors} renaming or hiding
+// names. Wildcards can't be resolved to a declaration.
+func (s *scalaFile) addImport(clause string) {
+	path, selectors, grouped := strings.Cut(clause, "{")
+	path = strings.TrimSuffix(strings.TrimSpace(path), ".")
+	if !grouped {
+		idx := strings.LastIndex(path, ".")
+		if idx == -1 {
+			return
+		}
+		path, selectors = path[:idx], path[idx+1:]
+	}
+	for _, selector := range splitTopLevel(strings.TrimSuffix(strings.TrimSpace(selectors), "}")) {
+		name, alias := strings.TrimSpace(selector), ""
+		if before, after, ok := strings.Cut(name, "=>"); ok {
+			name, alias = strings.TrimSpace(before), strings.TrimSpace(after)
+		} else if before, after, ok := strings.Cut(name, " as "); ok {
+			name, alias = strings.TrimSpace(before), strings.TrimSpace(after)
+		}
+		switch {
+		case name == "_" || name == "*" || name == "given":
+			s.parsed.Uses = append(s.parsed.Uses, path)
+		case alias == "_" || name == "":
+			// Hidden from a wildcard import
+		default:
+			qualified := path + `\` + name
+			s.parsed.Uses = append(s.parsed.Uses, qualified)
+			if alias == "" {
+				alias = name
+			}
+			s.imports[alias] = qualified
+		}
+	}
+}
+
+// parseUsage finds instantiations, calls, member accesses, and type tests in code
+func (p *ScalaParser) parseUsage(s *scalaFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	inClass := s.className() != ""
+	add := func(usageType, name, receiver string) {
+		s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+	addTypes := func(typeDecl string) {
+		for _, typeName := range strings.Split(s.typeNames(typeDecl), "|") {
+			if typeName != "" {
+				add("type_reference", typeName, "")
+			}
+		}
+	}
+
+	for _, match := range p.newPattern.FindAllStringSubmatch(code, -1) {
+		add("instantiation", s.qualify(match[1]), "")
+	}
+	for _, match := range p.typeArgPattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+	for _, match := range p.typeTestPattern.FindAllStringSubmatch(code, -1) {
+		add("type_reference", s.qualify(match[1]), "")
+	}
+
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		if isScalaKeyword(name) || strings.HasSuffix(prefix, "new") || strings.HasSuffix(prefix, "def") ||
+			name == "classOf" || name == "isInstanceOf" || name == "asInstanceOf" {
+			continue
+		}
+		if match[4] != -1 { // Type arguments: inject[UserRepository]()
+			addTypes(code[match[4]:match[5]])
+		}
+		block := code[match[6]:match[7]] == "{"
+		if !strings.HasSuffix(prefix, ".") {
+			switch {
+			case isScalaBuiltin(name):
+			case isTypeName(name) && strings.HasSuffix(prefix, "case"):
+				add("type_reference", s.qualify(name), "") // An extractor pattern: case User(name, _) =>
+			case isTypeName(name):
+				if !block || strings.HasSuffix(prefix, "=") || strings.HasSuffix(prefix, "(") {
+					add("instantiation", s.qualify(name), "") // A case class or companion's apply
+				}
+			case inClass:
+				if qualified, ok := s.imports[name]; ok {
+					add("function_call", qualified, "")
+				} else {
+					add("method_call", name, "this") // Unqualified calls are on this class, or top-level
+				}
+			default:
+				add("function_call", s.qualify(name), "")
+			}
+			continue
+		}
+
+		receiver := javaReceiver(strings.TrimRight(strings.TrimSuffix(prefix, "."), " \t"))
+		switch {
+		case receiver == "this":
+			add("method_call", name, "this")
+		case receiver != "" && isTypeName(receiver) && !isScalaBuiltin(receiver):
+			add("static_call", s.qualify(receiver)+"::"+name, s.qualify(receiver))
+		case !isScalaBuiltin(receiver):
+			add("method_call", name, receiver)
+		}
+	}
+
+	// Parameterless methods are called without parentheses, like fields are read
+	for _, match := range p.memberPattern.FindAllStringSubmatchIndex(code, -1) {
+		receiver, name := code[match[4]:match[5]], code[match[6]:match[7]]
+		if next := strings.TrimLeft(code[match[1]:], " \t"); next != "" && strings.ContainsRune("([{", rune(next[0])) {
+			continue // A call, above
+		}
+		switch {
+		case isScalaBuiltin(receiver) || isScalaKeyword(name):
+		case receiver == "this":
+			add("method_call", name, "this")
+		case isTypeName(receiver):
+			add("static_call", s.qualify(receiver)+"::"+name, s.qualify(receiver))
+		case !isScalaKeyword(receiver):
+			add("method_call", name, receiver)
+		}
+	}
+}
+
+// addAnnotations records annotations as usage of the annotation classes by context
+func (s *scalaFile) addAnnotations(annotations []string, context string, lineNum int) {
+	for _, name := range annotations {
+		s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{
+			Type:    "attribute",
+			Name:    s.qualify(name),
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// declares checks if a class, trait, or enum named name is declared in this file, so an
+// object of the same name is its companion
+func (s *scalaFile) declares(name string) bool {
+	for _, element := range s.parsed.Elements {
+		if element.Name == name && (element.Type == "class" || element.Type == "interface" || element.Type == "enum") {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrait checks if name is a trait declared in this file
+func (s *scalaFile) isTrait(name string) bool {
+	for _, element := range s.parsed.Elements {
+		if element.Name == name && element.Type == "interface" {
+			return true
+		}
+	}
+	return false
+}
+
+// top returns the innermost scope, or nil at the top level
+func (s *scalaFile) top() *scalaScope {
+	if len(s.scopes) == 0 {
+		return nil
+	}
+	return &s.scopes[len(s.scopes)-1]
+}
+
+// enclosingType returns the type whose body a declaration at depth is directly in, and
+// whether its members are static, or "" at the top level
+func (s *scalaFile) enclosingType(depth int) (string, bool) {
+	if top := s.top(); top != nil && top.kind == "type" && top.depth == depth {
+		return top.name, top.static
+	}
+	return "", false
+}
+
+// className returns the innermost type being parsed
+func (s *scalaFile) className() string {
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if s.scopes[i].kind == "type" {
+			return s.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the innermost function or type being parsed
+func (s *scalaFile) context() string {
+	if len(s.scopes) == 0 {
+		return ""
+	}
+	return s.scopes[len(s.scopes)-1].name
+}
+
+// qualify names a declaration the way the analyzer indexes it: imported ones with their
+// package, so they resolve to the imported declaration rather than one of the same name
+func (s *scalaFile) qualify(name string) string {
+	if qualified, ok := s.imports[name]; ok {
+		return qualified
+	}
+	if idx := strings.Index(name, "."); idx != -1 {
+		if first := name[:idx]; unicode.IsLower(rune(first[0])) {
+			return qualifyJava(name) // Fully qualified: com.acme.model.User
+		}
+		if outer, ok := s.imports[name[:idx]]; ok {
+			return outer // A member of an imported object: Codecs.UserCodec
+		}
+	}
+	return name
+}
+
+// typeNames lists the class names in a type, qualified and separated by "|", leaving
+// out Scala's standard types: "Map[String, List[User]]" → "User"
+func (s *scalaFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+	}) {
+		word = strings.Trim(word, ".")
+		if word == "" || isScalaBuiltin(word) || isScalaKeyword(word) || len(word) == 1 {
+			continue // len 1: type variables, A and T
+		}
+		names = append(names, s.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// scalaVisibility picks the visibility out of a modifier list. Access qualified with a
+// package, private[users], is package-private; without a modifier, a declaration is public.
+func scalaVisibility(modifiers string) string {
+	for _, modifier := range strings.Fields(modifiers) {
+		switch {
+		case modifier == "private[this]" || modifier == "private":
+			return "private"
+		case strings.HasPrefix(modifier, "private["):
+			return "internal"
+		case strings.HasPrefix(modifier, "protected"):
+			return "protected"
+		}
+	}
+	return "public"
+}
+
+// isScalaKeyword checks if a word is a Scala keyword that can precede a parenthesis, a
+// brace, or a name in a statement
+func isScalaKeyword(word string) bool {
+	switch word {
+	case "if", "else", "for", "while", "do", "match", "case", "try", "catch", "finally",
+		"return", "throw", "yield", "this", "super", "new", "def", "val", "var", "lazy", "class",
+		"object", "trait", "enum", "extends", "with", "type", "import", "package", "null", "true",
+		"false", "then", "given", "using", "extension", "implicit", "override", "end", "derives":
+		return true
+	}
+	return false
+}
+
+// isScalaBuiltin checks if a name is one of Scala's standard types or their companions
+func isScalaBuiltin(name string) bool {
+	switch name {
+	case "Int", "Long", "Short", "Byte", "Float", "Double", "Boolean", "Char", "String", "Unit",
+		"Any", "AnyRef", "AnyVal", "Nothing", "Null", "Option", "Some", "None", "List", "Nil",
+		"Seq", "IndexedSeq", "Vector", "Map", "Set", "Array", "Iterable", "Iterator", "Either",
+		"Left", "Right", "Try", "Success", "Failure", "Future", "Promise", "BigInt", "BigDecimal",
+		"Tuple2", "Tuple3", "StringBuilder", "Ordering", "Numeric", "Function1", "PartialFunction",
+		"Product", "Serializable", "Throwable", "Exception", "Predef", "Console", "Math", "System":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Scala files concurrently
+func (p *ScalaParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *ScalaParser) Language() string {
+	return "scala"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *ScalaParser) FileExtensions() []string {
+	return []string{".scala", ".sc"}
+}
+
+// DefaultExcludes returns the directories skipped in Scala projects: sbt, Mill, and
+// Bloop build output, and editor metadata
+func (p *ScalaParser) DefaultExcludes() []string {
+	return []string{"target", "out", ".bloop", ".bsp", ".metals", ".idea"}
+}
+
+// Entrypoints returns the methods the runtime calls: main, the apply and unapply methods
+// that constructor and pattern syntax call, and Akka actor lifecycle hooks
+func (p *ScalaParser) Entrypoints() []string {
+	return []string{
+		`^main$`,
+		`^(apply|unapply|unapplySeq)$`,
+		`^(receive|preStart|postStop|preRestart|postRestart)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewScalaParser())
+}
@@ -0,0 +1,231 @@
+package lang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestScalaParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `package com.acme
+package users
+
+import com.acme.model.User
+import com.acme.util.{Slugs, Clock => SystemClock}
+import scala.concurrent._
+
+/** Manages users. */
+@Singleton
+class UserService @Inject() (repository: UserRepository, val clock: SystemClock)
+    extends ServiceBase[User]
+    with Closeable {
+  private val cache: UserCache = new UserCache(100) // TODO: size from config
+  var label = "users"
+
+  def findAll(filter: Filter, limit: Int = 10)(implicit ec: ExecutionContext): Future[List[User]] =
+    repository
+      .findAll(filter)
+      .map(toDto)
+
+  private def toDto(user: User): UserDto = UserDto.from(validate(user))
+
+  def displayName: String = clock.zone + cache.size
+
+  override def close(): Unit = {
+    try {
+      repository.shutdown()
+    } catch {
+      case e: IOException => log(e)
+    }
+  }
+
+  def describe(value: Any): String = value match {
+    case Admin(name) => name
+    case _ => Slugs.slugify(label)
+  }
+}
+
+object UserService {
+  val MaxResults = 50
+
+  def apply(): UserService = new UserService(InMemoryRepository(), SystemClock.utc)
+}
+
+trait UserRepository {
+  def findAll(filter: Filter): List[User]
+  def shutdown(): Unit
+}
+
+sealed trait Status
+case object Active extends Status
+case class Suspended(reason: String, private val since: Long) extends Status
+
+enum Color:
+  case Red, Green
+  case Custom(rgb: Int)
+
+def slugged(text: String): String =
+  Slugs.slugify(text)
+`
+	path := writeFixture(t, tmp, "UserService.scala", code)
+
+	parsed, err := NewScalaParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "scala" || parsed.Namespace != "com.acme.users" {
+		t.Errorf("expected the chained package clauses, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	wantUses := []string{`com.acme.model\User`, `com.acme.util\Slugs`, `com.acme.util\Clock`, "scala.concurrent"}
+	if len(parsed.Uses) != len(wantUses) {
+		t.Fatalf("expected uses %v, got %v", wantUses, parsed.Uses)
+	}
+	for i, use := range wantUses {
+		if parsed.Uses[i] != use {
+			t.Errorf("expected uses %v, got %v", wantUses, parsed.Uses)
+		}
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "property:UserService.clock", "property:UserService.cache",
+		"property:UserService.label", "method:UserService.findAll", "method:UserService.toDto",
+		"method:UserService.displayName", "method:UserService.close", "method:UserService.describe",
+		"property:UserService.MaxResults", "method:UserService.apply", "interface:.UserRepository",
+		"method:UserRepository.findAll", "method:UserRepository.shutdown", "interface:.Status", "class:.Active",
+		"class:.Suspended", "property:Suspended.reason", "property:Suspended.since", "enum:.Color",
+		"constant:Color.Red", "constant:Color.Green", "constant:Color.Custom", "function:.slugged"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 24 {
+		t.Errorf("expected 24 elements, without one for the companion object, got %+v", parsed.Elements)
+	}
+
+	service := elements["class:.UserService"]
+	if !service.Documented || service.Namespace != "com.acme.users" || service.Line != 10 || elements["method:UserService.findAll"].Documented {
+		t.Errorf("expected only the class with Scaladoc to be documented, got %+v", service)
+	}
+	findAll := elements["method:UserService.findAll"]
+	if len(findAll.Parameters) != 3 || findAll.Parameters[2] != "ec" || findAll.ParamTypes[0] != "Filter" || findAll.ParamTypes[1] != "" {
+		t.Errorf("expected the parameters of both lists, got %v %v", findAll.Parameters, findAll.ParamTypes)
+	}
+	if findAll.ReturnType != `com.acme.model\User` || findAll.Line != 16 {
+		t.Errorf("expected the return type's classes on line 16, got %q on %d", findAll.ReturnType, findAll.Line)
+	}
+	if clock := elements["property:UserService.clock"]; !clock.IsReadonly || clock.Visibility != "public" {
+		t.Error("expected the constructor's val to be a readonly property")
+	}
+	if _, ok := elements["property:UserService.repository"]; ok {
+		t.Error("expected a plain constructor parameter not to be a property")
+	}
+	if elements["property:UserService.cache"].Visibility != "private" || elements["property:UserService.label"].IsReadonly {
+		t.Error("expected the private val and the var")
+	}
+	if !elements["method:UserService.apply"].IsStatic || !elements["property:UserService.MaxResults"].IsStatic || elements["method:UserService.toDto"].IsStatic {
+		t.Error("expected only the companion object's members to be static")
+	}
+	if !elements["method:UserRepository.findAll"].IsAbstract || elements["method:UserService.findAll"].IsAbstract {
+		t.Error("expected only the trait's method without a body to be abstract")
+	}
+	if !elements["class:.Suspended"].IsReadonly || elements["property:Suspended.since"].Visibility != "private" {
+		t.Error("expected the case class's parameters to be properties")
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"attribute:.Singleton in UserService",
+		"extends:.ServiceBase in UserService",
+		"implements:.Closeable in UserService",
+		"type_reference:.UserRepository in UserService",
+		`type_reference:.com.acme.util\Clock in UserService`,
+		"instantiation:.UserCache in UserService",
+		"method_call:.findAll in findAll",
+		"method_call:this.validate in toDto",
+		"static_call:UserDto.UserDto::from in toDto",
+		"method_call:clock.zone in displayName",
+		"method_call:repository.shutdown in close",
+		"type_reference:.IOException in close",
+		"type_reference:.Admin in describe",
+		`static_call:com.acme.util\Slugs.com.acme.util\Slugs::slugify in describe`,
+		"instantiation:.UserService in apply",
+		"instantiation:.InMemoryRepository in apply",
+		"implements:.Status in Active",
+		"implements:.Status in Suspended",
+		`static_call:com.acme.util\Slugs.com.acme.util\Slugs::slugify in slugged`,
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 13 {
+		t.Errorf("expected the TODO on line 13, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 1 {
+		t.Errorf("expected 1 comment line, got %d", parsed.CommentLines)
+	}
+}
+
+func TestScalaParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "Shapes.scala", `package shapes
+
+case class Circle(radius: Double) {
+  def area: Double = math.Pi * radius * radius
+}
+`)
+	writeFixture(t, tmp, "Main.scala", `package shapes
+
+object Main {
+  def total(circles: Seq[Circle]): Double =
+    circles.map(c => c.area).sum
+
+  def main(args: Array[String]): Unit = {
+    val circle = Circle(2.0)
+    println(total(Seq(circle)))
+  }
+}
+`)
+
+	p := NewScalaParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"Shapes.scala", "Main.scala"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.ClassName+"."+node.Name] = node
+	}
+	main, circle, total := nodes["Main.main"], nodes[".Circle"], nodes["Main.total"]
+	if main == nil || circle == nil || main.Dependencies[circle.ID] == nil {
+		t.Fatalf("expected main to construct the Circle, got %+v", main)
+	}
+	if total == nil || main.Dependencies[total.ID] == nil || !main.IsEntrypoint || total.IsEntrypoint {
+		t.Errorf("expected main, an entrypoint, to call total, got %+v", main.Dependencies)
+	}
+	if area := nodes["Circle.area"]; area == nil || total.Dependencies[area.ID] == nil || total.Dependencies[circle.ID] == nil {
+		t.Errorf("expected total to accept Circles and read their area, got %+v", total.Dependencies)
+	}
+}
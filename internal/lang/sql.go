@@ -0,0 +1,422 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// SQLParser handles parsing of SQL schema, migration, and stored procedure files
+type SQLParser struct {
+	createPattern     *regexp.Regexp
+	alterPattern      *regexp.Regexp
+	dropPattern       *regexp.Regexp
+	delimiterPattern  *regexp.Regexp
+	referencesPattern *regexp.Regexp
+	tablePattern      *regexp.Regexp
+	triggerPattern    *regexp.Regexp
+	callPattern       *regexp.Regexp
+	functionPattern   *regexp.Regexp
+	wordPattern       *regexp.Regexp
+}
+
+// sqlScope is the statement a line belongs to: the object it creates, or the table an
+// ALTER TABLE changes
+type sqlScope struct {
+	kind string // Element type ("table", "view", "procedure", "function", "trigger"), or "alter"
+	name string // The element's name, or the altered table's qualified name
+	on   bool   // A trigger's table has been found
+}
+
+// sqlLexer carries a file's comments, dollar-quoted bodies, and blocks from one line to
+// the next, to tell where statements end
+type sqlLexer struct {
+	comment   bool   // Inside /* */
+	dollar    string // Tag of the dollar-quoted body it's inside: "$$", "$body$"
+	depth     int    // BEGIN ... END and CASE ... END blocks open
+	delimiter string // Ends statements: ";", or what a DELIMITER command set
+}
+
+// sqlFile is the state of one file's parse
+type sqlFile struct {
+	parsed *models.ParsedFile
+	scope  sqlScope
+}
+
+// sqlBatchPattern finds the GO lines separating SQL Server batches
+var sqlBatchPattern = regexp.MustCompile(`(?im)^\s*GO\s*$`)
+
+// sqlKeywords can follow FROM, INTO, UPDATE, or ON without being a table
+var sqlKeywords = map[string]bool{
+	"on": true, "of": true, "set": true, "select": true, "only": true, "lateral": true,
+	"values": true, "dual": true, "each": true, "row": true, "statement": true, "new": true,
+	"old": true, "conflict": true, "delete": true, "insert": true, "update": true, "table": true,
+}
+
+// sqlCallKeywords are the words before a name that make "name(" something other than a
+// function call: a table's columns, a definition, or a procedure call matched on its own
+var sqlCallKeywords = map[string]bool{
+	"into": true, "table": true, "references": true, "join": true, "from": true, "update": true,
+	"on": true, "exists": true, "in": true, "values": true, "as": true, "key": true, "index": true,
+	"view": true, "procedure": true, "proc": true, "function": true, "trigger": true, "call": true,
+	"exec": true, "execute": true, "perform": true, "returns": true, "using": true, "over": true,
+	"filter": true, "within": true, "unique": true, "check": true, "constraint": true,
+}
+
+// NewSQLParser creates a new SQL parser with compiled regex patterns
+func NewSQLParser() *SQLParser {
+	return &SQLParser{
+		// Definitions: CREATE TABLE IF NOT EXISTS app.users, CREATE OR REPLACE VIEW
+		// active_users, CREATE MATERIALIZED VIEW, CREATE DEFINER=`root`@`%` PROCEDURE,
+		// CREATE OR ALTER PROC dbo.GetOrders, CREATE FUNCTION, CREATE TRIGGER
+		createPattern: regexp.MustCompile(`(?i)^\s*CREATE\s+(?:OR\s+(?:REPLACE|ALTER)\s+)?` +
+			`(?:DEFINER\s*=\s*\S+\s+|(?:GLOBAL|LOCAL)\s+|TEMP(?:ORARY)?\s+|UNLOGGED\s+|MATERIALIZED\s+|` +
+			`RECURSIVE\s+|ALGORITHM\s*=\s*\w+\s+|SQL\s+SECURITY\s+\w+\s+|CONSTRAINT\s+)*` +
+			`(TABLE|VIEW|PROCEDURE|PROC|FUNCTION|TRIGGER)\s+(?:IF\s+NOT\s+EXISTS\s+)?` + sqlName),
+
+		// Changes to a table: ALTER TABLE ONLY public.orders
+		alterPattern: regexp.MustCompile(`(?i)^\s*ALTER\s+TABLE\s+(?:ONLY\s+)?(?:IF\s+EXISTS\s+)?` + sqlName),
+		dropPattern:  regexp.MustCompile(`(?i)^\s*DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?` + sqlName),
+
+		// MySQL's client command changing the statement delimiter: DELIMITER //
+		delimiterPattern: regexp.MustCompile(`(?i)^\s*DELIMITER\s+(\S+)`),
+
+		// Foreign keys: REFERENCES customers(id), FOREIGN KEY (user_id) REFERENCES users
+		referencesPattern: regexp.MustCompile(`(?i)\bREFERENCES\s+` + sqlName),
+
+		// Tables read and written: FROM orders, JOIN app.users, INSERT INTO audit_log,
+		// UPDATE stock SET, TRUNCATE TABLE sessions
+		tablePattern: regexp.MustCompile(`(?i)\b(?:FROM|JOIN|INTO|UPDATE|TRUNCATE(?:\s+TABLE)?)\s+` + sqlName),
+
+		// The table a trigger fires on: AFTER INSERT ON orders
+		triggerPattern: regexp.MustCompile(`(?i)\bON\s+` + sqlName),
+
+		// Procedure calls: CALL restock(1), EXEC dbo.Archive, PERFORM notify(id),
+		// EXECUTE FUNCTION audit()
+		callPattern: regexp.MustCompile(`(?i)\b(?:CALL|EXEC(?:UTE)?(?:\s+(?:FUNCTION|PROCEDURE))?|PERFORM)\s+` + sqlName),
+
+		// Function calls in expressions: order_total(o.id), billing.tax_rate(region)
+		functionPattern: regexp.MustCompile(sqlName + `\s*\(`),
+
+		// The word before a function call
+		wordPattern: regexp.MustCompile(`([A-Za-z_]\w*)\s*$`),
+	}
+}
+
+// ParseFile analyzes a single SQL file and extracts its tables, views, procedures,
+// functions, and triggers, with the foreign keys, tables, and routines they reference
+func (p *SQLParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	// SQL Server scripts end a routine's body with GO, not a semicolon
+	batched := sqlBatchPattern.Match(data)
+
+	f := &sqlFile{
+		parsed: &models.ParsedFile{
+			Path:     filePath,
+			Language: p.Language(),
+			Elements: []models.CodeElement{},
+			Usage:    []models.UsageElement{},
+			Uses:     []string{},
+		},
+	}
+	parsed := f.parsed
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	commentEnd := -1 // Last line of the comment block above the current line
+	lex := sqlLexer{delimiter: ";"}
+
+	for scanner.Scan() {
+		lineNum++
+		raw := scanner.Text()
+		if marker, ok := debtMarker(raw, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		inComment := lex.comment
+		code, ends := lex.scan(raw)
+		trimmed := strings.TrimSpace(code)
+		if trimmed == "" {
+			if raw := strings.TrimSpace(raw); inComment || strings.HasPrefix(raw, "--") || strings.HasPrefix(raw, "/*") {
+				parsed.CommentLines++
+				commentEnd = lineNum
+			}
+			continue
+		}
+
+		// SQL Server's GO and Oracle's / end a batch; DELIMITER ends the statement before it
+		if strings.EqualFold(trimmed, "GO") || trimmed == "/" {
+			f.scope, lex.depth = sqlScope{}, 0
+			continue
+		}
+		if match := p.delimiterPattern.FindStringSubmatch(code); match != nil {
+			lex.delimiter = match[1]
+			f.scope, lex.depth = sqlScope{}, 0
+			continue
+		}
+
+		p.parseStatement(f, code, lineNum, commentEnd == lineNum-1)
+		if ends && (!batched || f.scope.kind == "table" || f.scope.kind == "view" || f.scope.kind == "alter") {
+			f.scope = sqlScope{}
+		}
+	}
+
+	parsed.Lines = lineNum
+	return parsed, scanner.Err()
+}
+
+// parseStatement records the object a line creates, and what the statement it's in
+// references
+func (p *SQLParser) parseStatement(f *sqlFile, code string, lineNum int, documented bool) {
+	parsed := f.parsed
+	rest := code
+	if match := p.createPattern.FindStringSubmatchIndex(code); match != nil {
+		kind := strings.ToLower(code[match[2]:match[3]])
+		if kind == "proc" {
+			kind = "procedure"
+		}
+		schema, name := sqlObjectName(code[match[4]:match[5]])
+		parsed.Elements = append(parsed.Elements, models.CodeElement{
+			Type:       kind,
+			Name:       name,
+			Namespace:  schema,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       parsed.Path,
+			Documented: documented,
+		})
+		if kind == "table" {
+			parsed.Tables = append(parsed.Tables, models.TableReference{Table: name, Kind: "migration", Line: lineNum})
+		}
+		f.scope = sqlScope{kind: kind, name: name}
+		rest = code[match[1]:]
+	} else if match := p.alterPattern.FindStringSubmatchIndex(code); match != nil {
+		schema, name := sqlObjectName(code[match[2]:match[3]])
+		parsed.Tables = append(parsed.Tables, models.TableReference{Table: name, Kind: "migration", Line: lineNum})
+		f.scope = sqlScope{kind: "alter", name: qualifiedSQLName(schema, name)}
+		rest = code[match[1]:]
+	} else if match := p.dropPattern.FindStringSubmatch(code); match != nil {
+		_, name := sqlObjectName(match[1])
+		parsed.Tables = append(parsed.Tables, models.TableReference{Table: name, Kind: "migration", Line: lineNum})
+		return
+	}
+	p.parseReferences(f, rest, lineNum)
+}
+
+// parseReferences records the foreign keys, tables, and routines code names. Outside a
+// definition, such as a seed file's INSERTs, only the tables are recorded, for the file.
+func (p *SQLParser) parseReferences(f *sqlFile, code string, lineNum int) {
+	parsed := f.parsed
+	scope := &f.scope
+	add := func(usageType, raw string) {
+		if scope.kind != "" {
+			parsed.Usage = append(parsed.Usage, models.UsageElement{
+				Type:    usageType,
+				Name:    qualifiedSQLName(sqlObjectName(raw)),
+				Context: scope.name,
+				Line:    lineNum,
+			})
+		}
+	}
+
+	for _, match := range p.referencesPattern.FindAllStringSubmatch(code, -1) {
+		add("foreign_key", match[1])
+	}
+	if scope.kind == "trigger" && !scope.on {
+		if match := p.triggerPattern.FindStringSubmatch(code); match != nil {
+			add("table_reference", match[1])
+			scope.on = true
+		}
+	}
+	for _, match := range p.tablePattern.FindAllStringSubmatch(code, -1) {
+		_, name := sqlObjectName(match[1])
+		if sqlKeywords[strings.ToLower(name)] {
+			continue
+		}
+		add("table_reference", match[1])
+		function := ""
+		if scope.kind != "alter" {
+			function = scope.name
+		}
+		parsed.Tables = append(parsed.Tables, models.TableReference{Table: name, Kind: "sql", Function: function, Line: lineNum})
+	}
+	if scope.kind == "" || scope.kind == "table" || scope.kind == "alter" {
+		return // Column types and constraints, not calls
+	}
+	for _, match := range p.callPattern.FindAllStringSubmatch(code, -1) {
+		add("sql_call", match[1])
+	}
+	for _, match := range p.functionPattern.FindAllStringSubmatchIndex(code, -1) {
+		if word := p.wordPattern.FindStringSubmatch(code[:match[0]]); word != nil && sqlCallKeywords[strings.ToLower(word[1])] {
+			continue
+		}
+		add("sql_call", code[match[2]:match[3]])
+	}
+}
+
+// sqlObjectName splits a possibly quoted, schema-qualified name into its schema and name:
+// "app"."users" is app and users
+func sqlObjectName(raw string) (string, string) {
+	name := strings.NewReplacer("`", "", `"`, "", "[", "", "]", "").Replace(raw)
+	if i := strings.LastIndex(name, "."); i != -1 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// qualifiedSQLName joins a schema and name the way SQL writes them: app.users
+func qualifiedSQLName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+// scan blanks a line's comments and the contents of its strings, keeping offsets, and
+// reports whether a statement ends on it. Semicolons end statements outside dollar-quoted
+// bodies and BEGIN ... END blocks, unless a DELIMITER command set another delimiter.
+func (lex *sqlLexer) scan(line string) (string, bool) {
+	code := []byte(line)
+	ends := false
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case lex.comment:
+			if strings.HasPrefix(line[i:], "*/") {
+				lex.comment = false
+				code[i], code[i+1] = ' ', ' '
+				i++
+			} else {
+				code[i] = ' '
+			}
+		case strings.HasPrefix(line[i:], "--"):
+			for j := i; j < len(code); j++ {
+				code[j] = ' '
+			}
+			i = len(code)
+		case strings.HasPrefix(line[i:], "/*"):
+			lex.comment = true
+			code[i], code[i+1] = ' ', ' '
+			i++
+		case c == '\'':
+			for i++; i < len(code); i++ {
+				if code[i] == '\'' {
+					if i+1 < len(code) && code[i+1] == '\'' {
+						code[i], code[i+1] = ' ', ' '
+						i++
+						continue
+					}
+					break
+				}
+				code[i] = ' '
+			}
+		case c == '$' && lex.delimiter == ";":
+			end := strings.IndexByte(line[i+1:], '$')
+			if end == -1 || !isSQLDollarTag(line[i+1:i+1+end]) {
+				continue
+			}
+			tag := line[i : i+end+2]
+			if lex.dollar == "" {
+				lex.dollar = tag
+			} else if lex.dollar == tag {
+				lex.dollar = ""
+			}
+			i += end + 1
+		case c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z':
+			j := i
+			for j < len(code) && isWordByte(code[j]) {
+				j++
+			}
+			lex.block(strings.ToUpper(line[i:j]), strings.TrimLeft(line[j:], " \t"))
+			i = j - 1
+		case c == ';' && lex.delimiter == ";":
+			if lex.dollar == "" && lex.depth == 0 {
+				ends = true
+			}
+		}
+	}
+	if lex.delimiter != ";" && strings.HasSuffix(strings.TrimSpace(string(code)), lex.delimiter) {
+		ends, lex.depth = true, 0
+	}
+	return string(code), ends
+}
+
+// block counts the blocks a word opens and closes: BEGIN (not a transaction's) and CASE
+// open one, and END closes one, except END IF, END LOOP, and the like
+func (lex *sqlLexer) block(word, rest string) {
+	next := strings.ToUpper(rest)
+	switch word {
+	case "BEGIN":
+		for _, transaction := range []string{";", "TRAN", "WORK", "DISTRIBUTED"} {
+			if strings.HasPrefix(next, transaction) {
+				return
+			}
+		}
+		lex.depth++
+	case "CASE":
+		lex.depth++
+	case "END":
+		for _, loop := range []string{"IF", "LOOP", "WHILE", "REPEAT", "FOR"} {
+			if strings.HasPrefix(next, loop) && (len(next) == len(loop) || !isWordByte(next[len(loop)])) {
+				return
+			}
+		}
+		if lex.depth > 0 {
+			lex.depth--
+		}
+	}
+}
+
+// isSQLDollarTag reports whether text between dollar signs is a dollar-quote tag, which
+// is empty or an identifier ($$, $body$), rather than a parameter ($1)
+func isSQLDollarTag(tag string) bool {
+	for i := 0; i < len(tag); i++ {
+		if !isWordByte(tag[i]) || (i == 0 && tag[i] >= '0' && tag[i] <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordByte reports whether c can be part of an identifier
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// ProcessFiles parses multiple SQL files concurrently
+func (p *SQLParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *SQLParser) Language() string {
+	return "sql"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *SQLParser) FileExtensions() []string {
+	return []string{".sql"}
+}
+
+// DefaultExcludes returns the directories skipped in SQL projects: dependencies' own
+// migrations, and database dumps, which repeat the schema
+func (p *SQLParser) DefaultExcludes() []string {
+	return []string{"vendor", "node_modules", "dumps"}
+}
+
+func init() {
+	parser.Register(NewSQLParser())
+}
@@ -0,0 +1,221 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestSQLParser_Definitions(t *testing.T) {
+	tmp := t.TempDir()
+	path := writeFixture(t, tmp, "schema.sql", `-- Customers who can place orders
+CREATE TABLE IF NOT EXISTS app.customers (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL -- TODO: split into first and last
+);
+
+/* Orders, one row
+   per checkout */
+CREATE TABLE "orders" (
+  id SERIAL PRIMARY KEY,
+  customer_id INT REFERENCES app.customers(id),
+  total NUMERIC(10, 2) DEFAULT 0
+);
+
+CREATE OR REPLACE VIEW big_orders AS
+  SELECT o.id, c.name, order_rank(o.total) FROM orders o
+  JOIN app.customers c ON c.id = o.customer_id
+  WHERE o.total > 100 AND o.note <> 'from the shop; update later';
+
+CREATE FUNCTION order_rank(amount NUMERIC) RETURNS INT AS $$
+BEGIN
+  IF amount > 1000 THEN
+    RETURN 1;
+  END IF;
+  INSERT INTO audit_log (message) VALUES ('ranked');
+  RETURN CASE WHEN amount > 100 THEN 2 ELSE 3 END;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_audit AFTER INSERT OR UPDATE OF total ON orders
+  FOR EACH ROW EXECUTE FUNCTION order_rank();
+
+INSERT INTO audit_log (message) VALUES ('seeded');
+
+DELIMITER //
+CREATE PROCEDURE restock(IN sku INT)
+BEGIN
+  UPDATE stock SET qty = qty + 10 WHERE id = sku;
+  CALL notify_warehouse(sku);
+END //
+DELIMITER ;
+
+ALTER TABLE ONLY orders ADD CONSTRAINT fk_stock FOREIGN KEY (stock_id) REFERENCES stock (id);
+DROP TABLE IF EXISTS legacy_orders;
+`)
+
+	parsed, err := NewSQLParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, e := range parsed.Elements {
+		elements[e.Type+":"+e.Name] = e
+	}
+	for key, line := range map[string]int{
+		"table:customers":      2,
+		"table:orders":         9,
+		"view:big_orders":      15,
+		"function:order_rank":  20,
+		"trigger:orders_audit": 30,
+		"procedure:restock":    36,
+	} {
+		if elements[key].Line != line {
+			t.Errorf("expected %s on line %d, got %+v", key, line, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 6 {
+		t.Errorf("expected 6 elements, got %+v", parsed.Elements)
+	}
+	if e := elements["table:customers"]; e.Namespace != "app" || !e.Documented {
+		t.Errorf("expected customers in the app schema, documented, got %+v", e)
+	}
+	if !elements["table:orders"].Documented || elements["view:big_orders"].Documented {
+		t.Errorf("expected only the commented tables documented, got %+v", parsed.Elements)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Context+" "+u.Type+" "+u.Name] = true
+	}
+	for _, want := range []string{
+		"orders foreign_key app.customers",
+		"big_orders table_reference orders",
+		"big_orders table_reference app.customers",
+		"big_orders sql_call order_rank",
+		"order_rank table_reference audit_log",
+		"orders_audit table_reference orders",
+		"orders_audit sql_call order_rank",
+		"restock table_reference stock",
+		"restock sql_call notify_warehouse",
+		"orders foreign_key stock",
+	} {
+		if !usage[want] {
+			t.Errorf("expected usage %q, got %v", want, usage)
+		}
+	}
+	for key := range usage {
+		switch key {
+		case "big_orders table_reference update", "orders sql_call NUMERIC", "orders sql_call VARCHAR",
+			"orders_audit table_reference total", "restock sql_call restock", "order_rank sql_call order_rank":
+			t.Errorf("unexpected usage %q", key)
+		}
+	}
+	for _, u := range parsed.Usage {
+		if u.Line > 42 && u.Context != "orders" {
+			t.Errorf("expected statements after the procedure outside it, got %+v", u)
+		}
+	}
+
+	migrations := make(map[string]int)
+	for _, ref := range parsed.Tables {
+		if ref.Kind == "migration" {
+			migrations[ref.Table]++
+		}
+	}
+	if migrations["customers"] != 1 || migrations["orders"] != 2 || migrations["legacy_orders"] != 1 {
+		t.Errorf("expected the created, altered, and dropped tables as migrations, got %v", migrations)
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 4 {
+		t.Errorf("expected the TODO on line 4, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 3 {
+		t.Errorf("expected 3 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestSQLParser_TSQLBatches(t *testing.T) {
+	tmp := t.TempDir()
+	path := writeFixture(t, tmp, "procs.sql", `CREATE PROCEDURE [dbo].[ArchiveOrders]
+AS
+  SELECT * INTO dbo.OrdersArchive FROM dbo.Orders;
+  EXEC dbo.PurgeOrders;
+GO
+SELECT * FROM Customers;
+`)
+	parsed, err := NewSQLParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Elements) != 1 || parsed.Elements[0].Name != "ArchiveOrders" || parsed.Elements[0].Namespace != "dbo" {
+		t.Fatalf("expected dbo.ArchiveOrders, got %+v", parsed.Elements)
+	}
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+" "+u.Name] = true
+	}
+	if !usage["table_reference dbo.OrdersArchive"] || !usage["sql_call dbo.PurgeOrders"] || usage["table_reference Customers"] {
+		t.Errorf("expected the batch's references only, got %v", usage)
+	}
+}
+
+func TestSQLParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	paths := []string{
+		writeFixture(t, tmp, "001_customers.sql", `CREATE TABLE customers (id INT PRIMARY KEY);
+CREATE TABLE unused (id INT);
+`),
+		writeFixture(t, tmp, "002_orders.sql", `CREATE TABLE orders (id INT, customer_id INT);
+CREATE VIEW recent_orders AS SELECT * FROM Orders;
+`),
+		writeFixture(t, tmp, "003_keys.sql", `ALTER TABLE orders ADD FOREIGN KEY (customer_id) REFERENCES customers (id);
+CREATE TRIGGER touch_orders BEFORE UPDATE ON orders FOR EACH ROW EXECUTE PROCEDURE touch();
+`),
+	}
+	var files []*models.ParsedFile
+	for _, path := range paths {
+		parsed, err := NewSQLParser().ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+	repo := writeFixture(t, tmp, "OrderRepository.php", `<?php
+class OrderRepository {
+    public function recent() {
+        return $this->db->query("SELECT * FROM recent_orders");
+    }
+}
+`)
+	parsed, err := NewPHPParser().ParseFile(repo)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	files = append(files, parsed)
+
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Type+":"+node.Name] = node
+	}
+	for _, edge := range [][2]string{
+		{"table:orders", "table:customers"},
+		{"view:recent_orders", "table:orders"},
+		{"trigger:touch_orders", "table:orders"},
+		{"method:recent", "view:recent_orders"},
+	} {
+		from, to := nodes[edge[0]], nodes[edge[1]]
+		if from == nil || to == nil || from.Dependencies[to.ID] == nil {
+			t.Errorf("expected %s to depend on %s, got %+v", edge[0], edge[1], from)
+		}
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Type+":"+node.Name] = true
+	}
+	if !orphans["table:unused"] || orphans["table:customers"] || orphans["trigger:touch_orders"] {
+		t.Errorf("expected the unused table, not the trigger, among the orphans, got %v", orphans)
+	}
+}
@@ -0,0 +1,882 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// SwiftParser handles parsing of Swift files
+type SwiftParser struct {
+	importPattern    *regexp.Regexp
+	attributePattern *regexp.Regexp
+	typePattern      *regexp.Regexp
+	funcPattern      *regexp.Regexp
+	initPattern      *regexp.Regexp
+	propertyPattern  *regexp.Regexp
+	casePattern      *regexp.Regexp
+	controlPattern   *regexp.Regexp
+	callPattern      *regexp.Regexp
+	typeCheckPattern *regexp.Regexp
+	metatypePattern  *regexp.Regexp
+	memberPattern    *regexp.Regexp
+}
+
+// swiftScope is a type or function body the parser is inside
+type swiftScope struct {
+	kind     string // "type" or "function"
+	name     string // An extension's is the type it extends
+	depth    int    // Brace depth inside the body
+	enum     bool   // An enum's body, where case declares cases
+	protocol bool   // A protocol's body, whose requirements have no bodies
+}
+
+// swiftFile is the state of one file's parse
+type swiftFile struct {
+	parsed  *models.ParsedFile
+	imports map[string]string // Declarations imported by kind, e.g. "Client" → `Networking\Client`
+	scopes  []swiftScope
+}
+
+// swiftLexState is what stripSwiftLine carries from one line to the next: block comments
+// nest, and """multi-line strings""" span lines
+type swiftLexState struct {
+	comment int  // Depth of nested block comments
+	multi   bool // Inside a multi-line string
+	hashes  int  // The # delimiting the multi-line string: #"""raw"""#
+}
+
+// NewSwiftParser creates a new Swift parser with compiled regex patterns
+func NewSwiftParser() *SwiftParser {
+	const modifiers = `((?:(?:public|private|fileprivate|internal|package|open|final|static|class|override|mutating|nonmutating|convenience|required|dynamic|lazy|weak|unowned|indirect|nonisolated|distributed|optional|prefix|postfix|infix)(?:\([^)]*\))?\s+)*)`
+	return &SwiftParser{
+		// Imports: import UIKit, @testable import App, import struct Networking.Client
+		importPattern: regexp.MustCompile(`^\s*(?:@\w+\s+)*import\s+(?:(typealias|struct|class|enum|protocol|let|var|func|actor)\s+)?([\w.]+)`),
+
+		// A leading attribute: @MainActor, @Published, @available(iOS 15, *), @objc(handleTap:)
+		attributePattern: regexp.MustCompile(`^\s*@([A-Za-z_][\w.]*)`),
+
+		// Types: final class UserService, public struct User: Codable, extension User: Equatable
+		typePattern: regexp.MustCompile(`^\s*` + modifiers + `(class|struct|enum|protocol|actor|extension)\s+([A-Za-z_][\w.]*)`),
+
+		// Functions: func load(id: Int) async throws -> User, static func == (lhs: Self, rhs: Self) -> Bool
+		funcPattern: regexp.MustCompile(`^\s*` + modifiers + `func\s+([A-Za-z_]\w*|` + "`[^`]+`" + `|[^\s\w(<]+)\s*(?:<[^()]*?>)?\s*\(`),
+
+		// Initializers, deinitializers, and subscripts: init(name: String), convenience init?(json: Data), deinit {
+		initPattern: regexp.MustCompile(`^\s*` + modifiers + `(init|deinit|subscript)[?!]?\s*(?:<[^()]*?>)?\s*([({])`),
+
+		// Properties: let id: UUID, @Published private(set) var users: [User] = [], var body: some View {
+		propertyPattern: regexp.MustCompile(`^\s*` + modifiers + `(let|var)\s+([A-Za-z_]\w*)\s*(?::\s*([^={]+?))?\s*(=|\{|$)`),
+
+		// Enum cases: case active, case loaded(User), indirect case node(Tree, Tree)
+		casePattern: regexp.MustCompile(`^\s*(?:indirect\s+)?case\s+(.+)`),
+
+		// Statements whose braces open a block rather than a trailing closure: if ready {
+		controlPattern: regexp.MustCompile(`^\s*(?:\}\s*)?(?:else\s+)?(?:if|guard|while|for|switch|catch|repeat|do|defer)\b`),
+
+		// Calls, with any generic arguments and trailing closures: save(user), users.map {, Box<Int>(1)
+		callPattern: regexp.MustCompile(`([A-Za-z_]\w*)\s*(?:<([\w.,\s?\[\]:]*)>)?\s*([({])`),
+
+		// Type checks and casts: value is User, value as? User, value as! User
+		typeCheckPattern: regexp.MustCompile(`\b(?:is|as[?!]?)\s+([A-Z]\w*(?:\.[A-Z]\w*)*)`),
+
+		// Metatypes: User.self, decoder.decode([User].self, from: data)
+		metatypePattern: regexp.MustCompile(`\b([A-Z]\w*(?:\.[A-Z]\w*)*)\]?\.self\b`),
+
+		// Static members: UserService.shared, Theme.primaryColor
+		memberPattern: regexp.MustCompile(`(^|[^\w.])([A-Z]\w*)\.([a-z_]\w*)\b`),
+	}
+}
+
+// ParseFile analyzes a single Swift file and extracts all elements
+func (p *SwiftParser) ParseFile(filePath string) (*models.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	s := &swiftFile{
+		parsed: &models.ParsedFile{
+			Path:      filePath,
+			Language:  p.Language(),
+			Namespace: swiftModule(filePath),
+			Elements:  []models.CodeElement{},
+			Usage:     []models.UsageElement{},
+			Uses:      []string{},
+		},
+		imports: make(map[string]string),
+	}
+	parsed := s.parsed
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	joinedLines := 0
+	braceDepth := 0
+	state := swiftLexState{}
+	docblock := false // A /// or /** */ doc comment precedes the next declaration
+
+	var attributes []string // Attributes awaiting the declaration they apply to
+
+	for scanner.Scan() {
+		lineNum += 1 + joinedLines
+		joinedLines = 0
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+		if lineNum == 1 && strings.HasPrefix(line, "#!") {
+			continue // A script's shebang
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if state.comment == 0 && !state.multi && (strings.HasPrefix(trimmed, "///") || strings.HasPrefix(trimmed, "/**")) {
+			docblock = true
+		}
+		code, bare := stripSwiftLine(line, &state)
+		if strings.TrimSpace(bare) == "" {
+			if trimmed != "" && strings.TrimSpace(code) == "" {
+				parsed.CommentLines++
+			}
+			continue
+		}
+
+		// Join multi-line parameter lists and calls, so a parameter list is parsed whole
+		for parenBalance(bare) > 0 && state.comment == 0 && !state.multi && scanner.Scan() {
+			joinedLines++
+			nextCode, nextBare := stripSwiftLine(scanner.Text(), &state)
+			code += " " + strings.TrimSpace(nextCode)
+			bare += " " + strings.TrimSpace(nextBare)
+		}
+
+		if matches := p.importPattern.FindStringSubmatch(bare); matches != nil && len(s.scopes) == 0 {
+			s.addImport(matches[2], matches[1] != "")
+			continue
+		}
+
+		// Attributes on their own lines wait for the declaration that follows them
+		for {
+			match := p.attributePattern.FindStringSubmatchIndex(bare)
+			if match == nil {
+				break
+			}
+			attributes = append(attributes, bare[match[2]:match[3]])
+			bare = strings.TrimSpace(bare[match[1]:])
+			if strings.HasPrefix(bare, "(") {
+				if end := closingParen(bare); end != -1 {
+					bare = bare[end+1:]
+				}
+			}
+		}
+		if strings.TrimSpace(bare) == "" {
+			continue
+		}
+
+		documented := docblock
+		docblock = false
+		attributing := attributes
+		attributes = nil
+		depthBefore := braceDepth
+		braceDepth += strings.Count(bare, "{") - strings.Count(bare, "}")
+		body := bare  // The part of the line after a declaration, parsed for usage
+		context := "" // Who the body's usage belongs to, when not the innermost scope
+
+		top := s.top()
+		declaring := top == nil || (top.kind == "type" && top.depth == depthBefore)
+		className := s.enclosingType(depthBefore)
+
+		if matches := p.casePattern.FindStringSubmatch(bare); declaring && top != nil && top.enum && matches != nil {
+			s.parseCases(matches[1], top.name, lineNum, documented)
+			body = ""
+		} else if fn := p.funcPattern.FindStringSubmatchIndex(bare); declaring && fn != nil {
+			modifiers := bare[fn[2]:fn[3]]
+			name := strings.Trim(bare[fn[4]:fn[5]], "`")
+			rest := bare[fn[1]-1:]
+			element := s.function(name, modifiers, className, rest, lineNum, documented)
+			s.addAttributes(attributing, name, lineNum)
+			body, context = s.functionBody(element, rest, depthBefore)
+		} else if matches := p.initPattern.FindStringSubmatchIndex(bare); declaring && className != "" && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			name := bare[matches[4]:matches[5]]
+			rest := bare[matches[6]:]
+			element := s.function(name, modifiers, className, rest, lineNum, documented)
+			s.addAttributes(attributing, name, lineNum)
+			body, context = s.functionBody(element, rest, depthBefore)
+		} else if matches := p.propertyPattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			propertyType := ""
+			if matches[8] != -1 {
+				propertyType = bare[matches[8]:matches[9]]
+			}
+			body = bare[matches[10]:]
+			context = className
+
+			if className != "" || keyword == "let" {
+				element := models.CodeElement{
+					Type:       "property",
+					Name:       name,
+					Namespace:  parsed.Namespace,
+					ClassName:  className,
+					Visibility: swiftVisibility(modifiers),
+					IsStatic:   swiftStatic(modifiers),
+					IsReadonly: keyword == "let",
+					IsAbstract: top != nil && top.protocol,
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				}
+				if className == "" {
+					element.Type = "constant" // A global let
+					element.IsReadonly = false
+					context = name
+				}
+				parsed.Elements = append(parsed.Elements, element)
+			}
+			s.addAttributes(attributing, context, lineNum)
+
+			// A property's type is a dependency of its type, such as an injected service
+			for _, typeName := range strings.Split(s.typeNames(propertyType), "|") {
+				if typeName != "" && context != "" {
+					parsed.Usage = append(parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: context, Line: lineNum})
+				}
+			}
+			// A computed property's body, or its observers, run like a function's
+			if bare[matches[10]:matches[11]] == "{" && braceDepth > depthBefore && !(top != nil && top.protocol) {
+				s.scopes = append(s.scopes, swiftScope{kind: "function", name: name, depth: depthBefore + 1})
+				if className == "" {
+					context = ""
+				} else {
+					context = name
+				}
+			}
+		} else if matches := p.typePattern.FindStringSubmatchIndex(bare); declaring && matches != nil {
+			modifiers := bare[matches[2]:matches[3]]
+			keyword := bare[matches[4]:matches[5]]
+			name := bare[matches[6]:matches[7]]
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				name = name[idx+1:] // extension Outer.Inner
+			}
+
+			if keyword != "extension" {
+				element := models.CodeElement{
+					Type:       "class",
+					Name:       name,
+					Namespace:  parsed.Namespace,
+					Visibility: swiftVisibility(modifiers),
+					Line:       lineNum,
+					File:       filePath,
+					Documented: documented,
+				}
+				switch keyword {
+				case "protocol":
+					element.Type = "interface"
+				case "enum":
+					element.Type = "enum"
+				}
+				parsed.Elements = append(parsed.Elements, element)
+			}
+			s.addAttributes(attributing, name, lineNum)
+
+			header := bare[matches[1]:]
+			body = ""
+			if idx := topLevelBrace(header); idx != -1 {
+				header, body = header[:idx], header[idx+1:]
+				s.scopes = append(s.scopes, swiftScope{kind: "type", name: name, depth: depthBefore + 1, enum: keyword == "enum", protocol: keyword == "protocol"})
+				if keyword == "enum" {
+					if matches := p.casePattern.FindStringSubmatch(body); matches != nil {
+						s.parseCases(strings.TrimSuffix(strings.TrimSpace(matches[1]), "}"), name, lineNum, false)
+						body = ""
+					}
+				}
+			}
+			s.parseInheritance(header, keyword, name, lineNum)
+		} else {
+			s.addAttributes(attributing, s.context(), lineNum)
+		}
+
+		if context == "" {
+			context = s.context()
+		}
+		p.parseUsage(s, body, lineNum, context)
+		parsed.Tables = append(parsed.Tables, sqlTables(code, lineNum, s.className(), context)...)
+
+		// Leave the bodies closed on this line
+		for len(s.scopes) > 0 && braceDepth < s.scopes[len(s.scopes)-1].depth {
+			s.scopes = s.scopes[:len(s.scopes)-1]
+		}
+	}
+
+	parsed.Lines = lineNum + joinedLines
+	return parsed, scanner.Err()
+}
+
+// function records a function, method, initializer, or subscript whose parameter list
+// starts rest
+func (s *swiftFile) function(name, modifiers, className, rest string, lineNum int, documented bool) models.CodeElement {
+	element := models.CodeElement{
+		Type:       "function",
+		Name:       name,
+		Namespace:  s.parsed.Namespace,
+		ClassName:  className,
+		Visibility: swiftVisibility(modifiers),
+		IsStatic:   swiftStatic(modifiers),
+		Line:       lineNum,
+		File:       s.parsed.Path,
+		Documented: documented,
+		Parameters: []string{},
+	}
+	if className != "" {
+		element.Type = "method"
+	}
+	if top := s.top(); top != nil && top.protocol {
+		element.IsAbstract = !strings.Contains(rest, "{")
+	}
+	if strings.HasPrefix(rest, "(") {
+		if end := closingParen(rest); end != -1 {
+			for _, param := range splitTopLevel(rest[1:end]) {
+				paramName, paramType := swiftParameter(param)
+				if paramName == "" {
+					continue
+				}
+				element.Parameters = append(element.Parameters, paramName)
+				element.ParamTypes = append(element.ParamTypes, s.typeNames(paramType))
+			}
+			rest = rest[end+1:]
+		}
+	}
+	// The return type follows the parameters and effects: ) async throws -> User {
+	if idx := strings.Index(rest, "->"); idx != -1 {
+		returns := rest[idx+2:]
+		if idx := strings.Index(returns, "{"); idx != -1 {
+			returns = returns[:idx]
+		}
+		if idx := strings.Index(returns, " where "); idx != -1 {
+			returns = returns[:idx]
+		}
+		element.ReturnType = s.typeNames(returns)
+	}
+	s.parsed.Elements = append(s.parsed.Elements, element)
+	return element
+}
+
+// functionBody returns the code in a function's body on its declaration's line, and the
+// function it belongs to, opening a scope for the lines that follow
+func (s *swiftFile) functionBody(element models.CodeElement, rest string, depthBefore int) (string, string) {
+	brace := strings.Index(rest, "{")
+	if brace == -1 {
+		return "", element.Name
+	}
+	s.scopes = append(s.scopes, swiftScope{kind: "function", name: element.Name, depth: depthBefore + 1})
+	return rest[brace+1:], element.Name
+}
+
+// addImport records an import. A module import makes its declarations visible without
+// naming them; an import of one declaration (import struct Networking.Client) names it.
+func (s *swiftFile) addImport(path string, declaration bool) {
+	idx := strings.LastIndex(path, ".")
+	if !declaration || idx == -1 {
+		s.parsed.Uses = append(s.parsed.Uses, path)
+		return
+	}
+	qualified := qualifyJava(path)
+	s.parsed.Uses = append(s.parsed.Uses, qualified)
+	s.imports[path[idx+1:]] = qualified
+}
+
+// parseCases records the cases an enum declares in a case declaration's list, with the
+// types their associated values carry as dependencies of the enum
+func (s *swiftFile) parseCases(list, enumName string, lineNum int, documented bool) {
+	for _, enumCase := range splitTopLevel(list) {
+		enumCase = strings.TrimSpace(enumCase)
+		end := 0
+		for end < len(enumCase) && (enumCase[end] == '_' || unicode.IsLetter(rune(enumCase[end])) || unicode.IsDigit(rune(enumCase[end]))) {
+			end++
+		}
+		if end == 0 {
+			continue
+		}
+		s.parsed.Elements = append(s.parsed.Elements, models.CodeElement{
+			Type:       "constant",
+			Name:       enumCase[:end],
+			Namespace:  s.parsed.Namespace,
+			ClassName:  enumName,
+			Visibility: "public",
+			Line:       lineNum,
+			File:       s.parsed.Path,
+			Documented: documented,
+		})
+		payload := strings.TrimSpace(enumCase[end:])
+		if !strings.HasPrefix(payload, "(") {
+			continue // No associated values, or a raw value
+		}
+		if close := closingParen(payload); close != -1 {
+			for _, value := range splitTopLevel(payload[1:close]) {
+				if _, valueType, ok := strings.Cut(value, ":"); ok {
+					value = valueType // A labeled value: loaded(user: User)
+				}
+				for _, typeName := range strings.Split(s.typeNames(value), "|") {
+					if typeName != "" {
+						s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{Type: "type_reference", Name: typeName, Context: enumName, Line: lineNum})
+					}
+				}
+			}
+		}
+	}
+}
+
+// parseInheritance records the types a type's inheritance clause names. Only a class can
+// have a superclass, listed first; protocols are told from it by being declared as one in
+// this file, or named like one (Codable, UITableViewDelegate). Protocols a protocol
+// inherits from are extended; everything else is implemented.
+func (s *swiftFile) parseInheritance(header, keyword, name string, lineNum int) {
+	header = skipTypeParameters(strings.TrimSpace(header))
+	if !strings.HasPrefix(header, ":") {
+		return
+	}
+	header = header[1:]
+	if idx := strings.Index(header, " where "); idx != -1 {
+		header = header[:idx]
+	}
+
+	for i, inherited := range splitTopLevel(header) {
+		if idx := strings.Index(inherited, "<"); idx != -1 {
+			inherited = inherited[:idx]
+		}
+		inherited = strings.TrimSpace(inherited)
+		if inherited == "" || isSwiftBuiltin(inherited) {
+			continue
+		}
+		usageType := "implements"
+		switch {
+		case keyword == "protocol":
+			usageType = "extends"
+		case keyword == "class" && i == 0 && !s.isInterface(inherited) && !swiftProtocolName(inherited):
+			usageType = "extends"
+		}
+		s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{
+			Type:    usageType,
+			Name:    s.qualify(inherited),
+			Context: name,
+			Line:    lineNum,
+		})
+	}
+}
+
+// parseUsage finds calls, instantiations, and type references in code
+func (p *SwiftParser) parseUsage(s *swiftFile, code string, lineNum int, context string) {
+	if context == "" || strings.TrimSpace(code) == "" {
+		return
+	}
+	inClass := s.className() != ""
+	add := func(usageType, name, receiver string) {
+		s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+			IsStatic: usageType == "static_call",
+		})
+	}
+	addTypes := func(typeDecl string) {
+		for _, typeName := range strings.Split(s.typeNames(typeDecl), "|") {
+			if typeName != "" {
+				add("type_reference", typeName, "")
+			}
+		}
+	}
+
+	for _, match := range p.typeCheckPattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+	for _, match := range p.metatypePattern.FindAllStringSubmatch(code, -1) {
+		addTypes(match[1])
+	}
+	for _, match := range p.memberPattern.FindAllStringSubmatchIndex(code, -1) {
+		if rest := strings.TrimLeft(code[match[1]:], " \t"); strings.HasPrefix(rest, "(") || code[match[6]:match[7]] == "self" {
+			continue // A static call, below, or a metatype, above
+		}
+		addTypes(code[match[4]:match[5]])
+	}
+
+	control := p.controlPattern.MatchString(code)
+	for _, match := range p.callPattern.FindAllStringSubmatchIndex(code, -1) {
+		name := code[match[2]:match[3]]
+		prefix := strings.TrimRight(code[:match[2]], " \t")
+		closure := code[match[6]:match[7]] == "{"
+		if isSwiftKeyword(name) || strings.HasSuffix(prefix, "func") || strings.HasSuffix(prefix, "#") || (closure && control) {
+			continue
+		}
+		if match[4] != -1 { // Generic arguments: Box<User>(value)
+			addTypes(code[match[4]:match[5]])
+		}
+		if !strings.HasSuffix(prefix, ".") {
+			switch {
+			case isTypeName(name):
+				if !isSwiftBuiltin(name) {
+					add("instantiation", s.qualify(name), "") // Initializers are called like functions
+				}
+			case inClass:
+				add("method_call", name, "self") // Unqualified calls are on self, or global
+			default:
+				add("function_call", s.qualify(name), "")
+			}
+			continue
+		}
+
+		receiver := javaReceiver(strings.TrimRight(strings.TrimSuffix(prefix, "."), " \t?!"))
+		switch {
+		case receiver == "" && strings.HasSuffix(prefix, "."):
+			// An implicit member, .init( or .success(, of a type the compiler infers
+		case receiver == "self" || receiver == "Self":
+			add("method_call", name, "self")
+		case receiver == "super":
+			add("method_call", name, "super")
+		case isTypeName(receiver):
+			if !isSwiftBuiltin(receiver) {
+				add("static_call", s.qualify(receiver)+"::"+name, s.qualify(receiver))
+			}
+		default:
+			add("method_call", name, receiver)
+		}
+	}
+}
+
+// addAttributes records attributes as usage of the types they name, such as property
+// wrappers and result builders, by context
+func (s *swiftFile) addAttributes(attributes []string, context string, lineNum int) {
+	for _, name := range attributes {
+		s.parsed.Usage = append(s.parsed.Usage, models.UsageElement{
+			Type:    "attribute",
+			Name:    s.qualify(name),
+			Context: context,
+			Line:    lineNum,
+		})
+	}
+}
+
+// isInterface checks if name is a protocol declared in this file
+func (s *swiftFile) isInterface(name string) bool {
+	for _, element := range s.parsed.Elements {
+		if element.Name == name && element.Type == "interface" {
+			return true
+		}
+	}
+	return false
+}
+
+// top returns the innermost scope, or nil at the top level
+func (s *swiftFile) top() *swiftScope {
+	if len(s.scopes) == 0 {
+		return nil
+	}
+	return &s.scopes[len(s.scopes)-1]
+}
+
+// enclosingType returns the type whose body a declaration at depth is directly in, or ""
+// at the top level
+func (s *swiftFile) enclosingType(depth int) string {
+	if top := s.top(); top != nil && top.kind == "type" && top.depth == depth {
+		return top.name
+	}
+	return ""
+}
+
+// className returns the innermost type being parsed
+func (s *swiftFile) className() string {
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if s.scopes[i].kind == "type" {
+			return s.scopes[i].name
+		}
+	}
+	return ""
+}
+
+// context returns the innermost function or type being parsed
+func (s *swiftFile) context() string {
+	if len(s.scopes) == 0 {
+		return ""
+	}
+	return s.scopes[len(s.scopes)-1].name
+}
+
+// qualify names a declaration the way the analyzer indexes it: one imported by kind with
+// its module. Everything else a module declares is visible without an import, so names
+// are left for the analyzer to resolve in the file's module.
+func (s *swiftFile) qualify(name string) string {
+	if qualified, ok := s.imports[name]; ok {
+		return qualified
+	}
+	return name
+}
+
+// typeNames lists the type names in a type, qualified and separated by "|", leaving out
+// the standard library's: "[String: [User]]?" → "User", "(Result<User, Error>) -> Void" → "User"
+func (s *swiftFile) typeNames(typeDecl string) string {
+	var names []string
+	for _, word := range strings.FieldsFunc(typeDecl, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+	}) {
+		word = strings.Trim(word, ".")
+		if idx := strings.LastIndex(word, "."); idx != -1 {
+			word = word[idx+1:] // Module-qualified, Foundation.Data, or nested, Outer.Inner
+		}
+		if word == "" || !unicode.IsUpper(rune(word[0])) || isSwiftBuiltin(word) || len(word) == 1 {
+			continue // len 1: type parameters, T and U
+		}
+		names = append(names, s.qualify(word))
+	}
+	return strings.Join(names, "|")
+}
+
+// swiftModule names a file's module after its SwiftPM target, the directory under
+// Sources or Tests it's in, or "" for files outside a package, such as an Xcode
+// project's, whose target the file layout doesn't say
+func swiftModule(filePath string) string {
+	segments := strings.Split(filepath.ToSlash(filePath), "/")
+	for i := len(segments) - 3; i >= 0; i-- {
+		if segments[i] == "Sources" || segments[i] == "Tests" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
+
+// swiftParameter splits a parameter declaration into its name and type, preferring the
+// name used inside the function to the argument label:
+// "for id: User.ID = 0" → ("id", "User.ID"), "_ handler: @escaping (User) -> Void" → ("handler", ...)
+func swiftParameter(param string) (string, string) {
+	if idx := topLevelIndex(param, '='); idx != -1 {
+		param = param[:idx]
+	}
+	name, paramType, ok := strings.Cut(param, ":")
+	if !ok {
+		return "", "" // No type: a closure's parameters
+	}
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	paramType = stripAnnotations(strings.TrimSpace(paramType))
+	for _, specifier := range []string{"inout ", "borrowing ", "consuming ", "sending "} {
+		paramType = strings.TrimPrefix(paramType, specifier)
+	}
+	return fields[len(fields)-1], strings.TrimSpace(paramType)
+}
+
+// swiftVisibility picks the access level out of a modifier list. open is public,
+// fileprivate is private, and without one a declaration is internal to its module.
+func swiftVisibility(modifiers string) string {
+	for _, modifier := range strings.Fields(modifiers) {
+		switch {
+		case strings.HasPrefix(modifier, "public"), strings.HasPrefix(modifier, "open"):
+			return "public"
+		case strings.HasPrefix(modifier, "private"), strings.HasPrefix(modifier, "fileprivate"):
+			return "private"
+		}
+	}
+	return "internal"
+}
+
+// swiftStatic checks if a modifier list makes a member belong to its type: static, or
+// class for overridable ones
+func swiftStatic(modifiers string) bool {
+	for _, modifier := range strings.Fields(modifiers) {
+		if modifier == "static" || modifier == "class" {
+			return true
+		}
+	}
+	return false
+}
+
+// swiftProtocolName checks if a type name reads like a protocol's, by the suffixes Apple's
+// frameworks and Swift style give them
+func swiftProtocolName(name string) bool {
+	for _, suffix := range []string{"Protocol", "Delegate", "DataSource", "able", "ible", "Convertible", "Representable", "Providing"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripSwiftLine removes comments from a line, returning the code and the code with
+// string contents blanked out. Block comments nest, and multi-line strings may continue
+// onto the next lines; state carries both across lines.
+func stripSwiftLine(line string, state *swiftLexState) (string, string) {
+	var code, bare strings.Builder
+	runes := []rune(line)
+	inString, stringHashes := false, 0
+	closes := func(i, hashes int, triple bool) int { // Runes the closing delimiter at i spans, or 0
+		quotes := 1
+		if triple {
+			quotes = 3
+		}
+		if i+quotes+hashes > len(runes) {
+			return 0
+		}
+		for j := 0; j < quotes; j++ {
+			if runes[i+j] != '"' {
+				return 0
+			}
+		}
+		for j := 0; j < hashes; j++ {
+			if runes[i+quotes+j] != '#' {
+				return 0
+			}
+		}
+		return quotes + hashes
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case state.comment > 0:
+			if r == '/' && next == '*' {
+				state.comment++
+				i++
+			} else if r == '*' && next == '/' {
+				state.comment--
+				i++
+			}
+		case state.multi:
+			if n := closes(i, state.hashes, true); n > 0 {
+				code.WriteString(string(runes[i : i+n]))
+				bare.WriteString(string(runes[i : i+n]))
+				state.multi = false
+				i += n - 1
+			} else {
+				code.WriteRune(r)
+			}
+		case inString:
+			if r == '\\' && stringHashes == 0 && next != 0 {
+				code.WriteRune(r)
+				code.WriteRune(next)
+				i++
+			} else if n := closes(i, stringHashes, false); n > 0 {
+				code.WriteString(string(runes[i : i+n]))
+				bare.WriteString(string(runes[i : i+n]))
+				inString = false
+				i += n - 1
+			} else {
+				code.WriteRune(r)
+			}
+		case r == '/' && next == '/':
+			return code.String(), bare.String()
+		case r == '/' && next == '*':
+			state.comment = 1
+			i++
+		case r == '#' || r == '"':
+			hashes := 0
+			for i+hashes < len(runes) && runes[i+hashes] == '#' {
+				hashes++
+			}
+			if i+hashes >= len(runes) || runes[i+hashes] != '"' {
+				code.WriteRune(r) // #available, #selector, #if
+				bare.WriteRune(r)
+				continue
+			}
+			opening := i + hashes
+			n := 1
+			if closes(opening, 0, true) == 3 {
+				n = 3
+				state.multi, state.hashes = true, hashes
+			} else {
+				inString, stringHashes = true, hashes
+			}
+			code.WriteString(string(runes[i : opening+n]))
+			bare.WriteString(string(runes[i : opening+n]))
+			i = opening + n - 1
+		default:
+			code.WriteRune(r)
+			bare.WriteRune(r)
+		}
+	}
+	return code.String(), bare.String()
+}
+
+// isSwiftKeyword checks if a word is a Swift keyword that can precede a parenthesis, a
+// brace, or a name in a statement
+func isSwiftKeyword(word string) bool {
+	switch word {
+	case "if", "else", "guard", "for", "in", "while", "repeat", "switch", "case", "default",
+		"do", "catch", "try", "throw", "throws", "rethrows", "return", "defer", "break",
+		"continue", "fallthrough", "where", "func", "init", "deinit", "subscript", "let", "var",
+		"class", "struct", "enum", "protocol", "extension", "actor", "import", "typealias",
+		"self", "Self", "super", "nil", "true", "false", "is", "as", "some", "any", "get", "set",
+		"willSet", "didSet", "async", "await", "inout", "static", "private", "public",
+		"internal", "fileprivate", "open", "override", "mutating", "lazy", "weak", "unowned",
+		"convenience", "required", "final", "operator", "precedencegroup", "associatedtype":
+		return true
+	}
+	return false
+}
+
+// isSwiftBuiltin checks if a type name is one of the standard library's or Foundation's
+// common types, or a protocol every type conforms to from them
+func isSwiftBuiltin(name string) bool {
+	switch name {
+	case "Int", "Int8", "Int16", "Int32", "Int64", "UInt", "UInt8", "UInt16", "UInt32", "UInt64",
+		"Float", "Double", "CGFloat", "Bool", "String", "Substring", "Character", "Void", "Never",
+		"Any", "AnyObject", "Array", "Dictionary", "Set", "Optional", "Result", "Error", "Data",
+		"Date", "URL", "UUID", "Decimal", "Range", "ClosedRange", "Task", "Codable", "Encodable",
+		"Decodable", "Equatable", "Hashable", "Comparable", "Identifiable", "Sendable",
+		"CustomStringConvertible", "CaseIterable", "RawRepresentable", "Sequence", "Collection",
+		"IteratorProtocol", "Encoder", "Decoder", "CodingKey", "NSObject", "Self":
+		return true
+	}
+	return false
+}
+
+// ProcessFiles parses multiple Swift files concurrently
+func (p *SwiftParser) ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error) {
+	return parser.ProcessFiles(files, progressBar, func(file models.FileInfo) (*models.ParsedFile, error) { return p.ParseFile(file.Path) })
+}
+
+// Language returns the language name for this parser
+func (p *SwiftParser) Language() string {
+	return "swift"
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *SwiftParser) FileExtensions() []string {
+	return []string{".swift"}
+}
+
+// DefaultExcludes returns the directories skipped in Swift projects: SwiftPM and Xcode
+// build output, and CocoaPods and Carthage dependencies
+func (p *SwiftParser) DefaultExcludes() []string {
+	return []string{".build", ".swiftpm", "DerivedData", "Pods", "Carthage"}
+}
+
+// Entrypoints returns the functions the runtime calls: main, initializers, and the
+// lifecycle and delegate callbacks UIKit and SwiftUI call on views, controllers, and
+// app delegates
+func (p *SwiftParser) Entrypoints() []string {
+	return []string{
+		`^(main|init|deinit|body)$`,
+		`^(viewDidLoad|viewWillAppear|viewDidAppear|viewWillDisappear|viewDidDisappear|viewWillLayoutSubviews|viewDidLayoutSubviews|loadView|awakeFromNib|layoutSubviews|draw|prepare|application|scene|sceneDidBecomeActive|sceneWillResignActive|sceneWillEnterForeground|sceneDidEnterBackground|applicationDidFinishLaunching|tableView|collectionView|numberOfSections|makeUIView|updateUIView|makeUIViewController|updateUIViewController|makeCoordinator|encode|hash)$`,
+	}
+}
+
+func init() {
+	parser.Register(NewSwiftParser())
+}
@@ -0,0 +1,254 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestSwiftParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "Sources", "UserKit"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	code := `import Foundation
+@testable import Networking
+import struct Models.User
+
+/// Manages users.
+@MainActor
+final class UserService: BaseService, UserServiceProtocol {
+    static let shared = UserService(client: APIClient())
+    private(set) var users: [User] = [] // TODO: cache
+    let client: APIClient
+
+    init(client: APIClient) {
+        self.client = client
+    }
+
+    func load(for id: UUID, force: Bool = false) async throws -> [User] {
+        let data = try await client.fetch("/users/\(id)")
+        let decoded = try JSONDecoder().decode([User].self, from: data)
+        if decoded.isEmpty {
+            return []
+        }
+        log(Logger.format(decoded))
+        return decoded.map { $0 as? Admin ?? $0 }
+    }
+
+    var count: Int {
+        users.count
+    }
+
+    private func log(_ message: String) {}
+}
+
+protocol UserServiceProtocol: AnyObject, Loading {
+    func load(for id: UUID, force: Bool) async throws -> [User]
+}
+
+enum Status {
+    case active, suspended(reason: Reason)
+}
+
+extension User: Identifiable, Auditable {
+    /* block /* nested */ comment */
+    func display() -> String { Formatter.shared.format(self) }
+}
+
+let defaultService = UserService(client: MockClient())
+
+func makeView() -> some View {
+    VStack {
+        Text("Hi")
+    }
+}
+`
+	path := writeFixture(t, tmp, "Sources/UserKit/UserService.swift", code)
+
+	parsed, err := NewSwiftParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "swift" || parsed.Namespace != "UserKit" {
+		t.Errorf("expected the SwiftPM target, got %q (%q)", parsed.Namespace, parsed.Language)
+	}
+	wantUses := []string{"Foundation", "Networking", `Models\User`}
+	if len(parsed.Uses) != len(wantUses) {
+		t.Fatalf("expected uses %v, got %v", wantUses, parsed.Uses)
+	}
+	for i, use := range wantUses {
+		if parsed.Uses[i] != use {
+			t.Errorf("expected uses %v, got %v", wantUses, parsed.Uses)
+		}
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.ClassName+"."+el.Name] = el
+	}
+	for _, key := range []string{"class:.UserService", "property:UserService.shared",
+		"property:UserService.users", "property:UserService.client", "method:UserService.init",
+		"method:UserService.load", "property:UserService.count", "method:UserService.log",
+		"interface:.UserServiceProtocol", "method:UserServiceProtocol.load", "enum:.Status",
+		"constant:Status.active", "constant:Status.suspended", "method:User.display",
+		"constant:.defaultService", "function:.makeView"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 16 {
+		t.Errorf("expected 16 elements, got %+v", parsed.Elements)
+	}
+
+	service := elements["class:.UserService"]
+	if !service.Documented || service.Namespace != "UserKit" || service.Line != 7 || elements["method:UserService.load"].Documented {
+		t.Errorf("expected only the class with a doc comment to be documented, got %+v", service)
+	}
+	load := elements["method:UserService.load"]
+	if len(load.Parameters) != 2 || load.Parameters[0] != "id" || load.Parameters[1] != "force" {
+		t.Errorf("expected the parameters' names rather than their labels, got %v", load.Parameters)
+	}
+	if load.ReturnType != `Models\User` || load.Line != 16 {
+		t.Errorf("expected the imported return type on line 16, got %q on %d", load.ReturnType, load.Line)
+	}
+	if users := elements["property:UserService.users"]; users.Visibility != "private" || users.IsReadonly {
+		t.Error("expected the private(set) var to be a private, writable property")
+	}
+	if !elements["property:UserService.client"].IsReadonly || !elements["property:UserService.shared"].IsStatic {
+		t.Error("expected let to be readonly and static let to be static")
+	}
+	if elements["method:UserService.load"].Visibility != "internal" || elements["method:UserService.log"].Visibility != "private" {
+		t.Error("expected declarations without a modifier to be internal")
+	}
+	if !elements["method:UserServiceProtocol.load"].IsAbstract || elements["method:UserService.load"].IsAbstract {
+		t.Error("expected only the protocol's requirement to be abstract")
+	}
+	if makeView := elements["function:.makeView"]; makeView.Namespace != "UserKit" || makeView.ReturnType != "View" {
+		t.Errorf("expected the top-level function in the module, got %+v", makeView)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Receiver+"."+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"attribute:.MainActor in UserService",
+		"extends:.BaseService in UserService",
+		"implements:.UserServiceProtocol in UserService",
+		"instantiation:.UserService in UserService",
+		"instantiation:.APIClient in UserService",
+		`type_reference:.Models\User in UserService`,
+		"type_reference:.APIClient in UserService",
+		"method_call:client.fetch in load",
+		"instantiation:.JSONDecoder in load",
+		`type_reference:.Models\User in load`,
+		"method_call:self.log in load",
+		"static_call:Logger.Logger::format in load",
+		"method_call:decoded.map in load",
+		"type_reference:.Admin in load",
+		"extends:.Loading in UserServiceProtocol",
+		"type_reference:.Reason in Status",
+		"implements:.Auditable in User",
+		"type_reference:.Formatter in display",
+		"method_call:Formatter.shared.format in display",
+		"instantiation:.MockClient in defaultService",
+		"instantiation:.VStack in makeView",
+		"instantiation:.Text in makeView",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"method_call:self.isEmpty in load", "method_call:.decode in load", "extends:.AnyObject in UserServiceProtocol", "implements:.Identifiable in User"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 9 {
+		t.Errorf("expected the TODO on line 9, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 2 {
+		t.Errorf("expected 2 comment lines, got %d", parsed.CommentLines)
+	}
+}
+
+func TestSwiftModule(t *testing.T) {
+	for path, want := range map[string]string{
+		"/app/Sources/GameKit/Models/Player.swift":  "GameKit",
+		"/app/Tests/GameKitTests/PlayerTests.swift": "GameKitTests",
+		"/app/Game/ViewController.swift":            "",
+	} {
+		if got := swiftModule(path); got != want {
+			t.Errorf("swiftModule(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestSwiftParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "Sources", "Game"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, "Sources/Game/Player.swift", `struct Player {
+    var health: Int
+
+    mutating func damage(_ amount: Int) {
+        health -= amount
+    }
+}
+`)
+	writeFixture(t, tmp, "Sources/Game/GameViewController.swift", `import UIKit
+
+class GameViewController: UIViewController {
+    private var player = Player(health: 100)
+
+    override func viewDidLoad() {
+        super.viewDidLoad()
+        player = Player(health: 100)
+        hit(10)
+    }
+
+    private func hit(_ amount: Int) {
+        player.damage(amount)
+    }
+}
+`)
+
+	p := NewSwiftParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"Sources/Game/Player.swift", "Sources/Game/GameViewController.swift"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	tracker := analyzer.NewDependencyTracker()
+	for _, pattern := range p.Entrypoints() {
+		if err := tracker.AddEntrypoint(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	graph := tracker.BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Namespace+`\`+node.ClassName+"."+node.Name] = node
+	}
+	viewDidLoad, player := nodes[`Game\GameViewController.viewDidLoad`], nodes[`Game\.Player`]
+	if viewDidLoad == nil || player == nil || viewDidLoad.Dependencies[player.ID] == nil {
+		t.Fatalf("expected viewDidLoad to construct the Player, got %+v", viewDidLoad)
+	}
+	if !viewDidLoad.IsEntrypoint {
+		t.Error("expected the UIKit lifecycle callback to be an entrypoint")
+	}
+	hit := nodes[`Game\GameViewController.hit`]
+	if hit == nil || viewDidLoad.Dependencies[hit.ID] == nil || hit.IsEntrypoint {
+		t.Errorf("expected the unqualified call to resolve to the class's method, got %+v", viewDidLoad.Dependencies)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// versionPattern matches the versions a target can be set to: "8", "7.4", "2020"
+var versionPattern = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+
+// syntaxFeature is a construct introduced in a version of a language, found by pattern in
+// a line with comments removed and string contents blanked out
+type syntaxFeature struct {
+	name    string
+	version string
+	pattern *regexp.Regexp
+}
+
+// syntaxTarget is the language version a codebase targets. A nil target supports every
+// construct and records none.
+type syntaxTarget struct {
+	version string
+	newer   []syntaxFeature // Features introduced after version
+}
+
+// newSyntaxTarget validates version and picks out the features newer than it
+func newSyntaxTarget(version string, features []syntaxFeature) (*syntaxTarget, error) {
+	if !versionPattern.MatchString(version) {
+		return nil, fmt.Errorf("invalid version %q (expected e.g. 7.4)", version)
+	}
+	target := &syntaxTarget{version: version}
+	for _, feature := range features {
+		if compareVersions(feature.version, version) > 0 {
+			target.newer = append(target.newer, feature)
+		}
+	}
+	return target, nil
+}
+
+// supports reports whether the target has the syntax introduced in version
+func (t *syntaxTarget) supports(version string) bool {
+	return t == nil || compareVersions(t.version, version) >= 0
+}
+
+// check records each feature newer than the target that the line uses
+func (t *syntaxTarget) check(bare string, lineNum int, parsed *models.ParsedFile) {
+	if t == nil {
+		return
+	}
+	for _, feature := range t.newer {
+		if feature.pattern.MatchString(bare) {
+			parsed.Syntax = append(parsed.Syntax, models.SyntaxUse{Feature: feature.name, Version: feature.version, Line: lineNum})
+		}
+	}
+}
+
+// compareVersions compares dotted numeric versions, treating missing parts as 0: it
+// returns -1 when a is older than b, 1 when it's newer, and 0 when they're the same
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// blankStrings empties the string literals in a line, keeping their quotes
+func blankStrings(line string) string {
+	return stringLiteralPattern.ReplaceAllStringFunc(line, func(literal string) string {
+		if len(literal) > 1 && literal[len(literal)-1] == literal[0] {
+			return literal[:1] + literal[:1]
+		}
+		return literal[:1]
+	})
+}
+
+// phpFeatures are the PHP constructs introduced since 7.0
+var phpFeatures = []syntaxFeature{
+	{"nullable type", "7.1", regexp.MustCompile(`\bfunction\b.*[(,]\s*\?[A-Za-z_\\]|\)\s*:\s*\?[A-Za-z_\\]`)},
+	{"void return type", "7.1", regexp.MustCompile(`\)\s*:\s*void\b`)},
+	{"class constant visibility", "7.1", regexp.MustCompile(`^\s*(?:final\s+)?(?:public|protected|private)\s+(?:final\s+)?const\b`)},
+	{"arrow function", "7.4", regexp.MustCompile(`\bfn\s*\([^)]*\)\s*(?::\s*\??[\w\\|]+\s*)?=>`)},
+	{"typed property", "7.4", regexp.MustCompile(`^\s*(?:(?:public|protected|private|static|readonly|var)\s+)+(?:\?|\\|[A-Z]|(?:int|float|string|bool|array|iterable|object|mixed|self)\b)[\w\\|?]*\s+\$`)},
+	{"null coalescing assignment", "7.4", regexp.MustCompile(`\?\?=`)},
+	{"nullsafe operator", "8.0", regexp.MustCompile(`\?->`)},
+	{"match expression", "8.0", regexp.MustCompile(`\bmatch\s*\(.*\)\s*\{`)},
+	{"attribute", "8.0", regexp.MustCompile(`^\s*#\[`)},
+	{"constructor promotion", "8.0", regexp.MustCompile(`\bfunction\s+__construct\s*\(.*\b(?:public|protected|private)\s`)},
+	{"union type", "8.0", regexp.MustCompile(`\b(?:function|fn)\b.*(?:[(,]\s*|\)\s*:\s*)\??[A-Za-z_\\][\w\\]*\s*\|\s*[A-Za-z_\\]`)},
+	{"enum", "8.1", regexp.MustCompile(`^\s*enum\s+[A-Za-z_]`)},
+	{"readonly property", "8.1", regexp.MustCompile(`\breadonly\s+(?:(?:public|protected|private)\s+)?\??[A-Za-z_\\][\w\\|]*\s+\$`)},
+	{"first-class callable", "8.1", regexp.MustCompile(`\(\s*\.\.\.\s*\)`)},
+	{"never return type", "8.1", regexp.MustCompile(`\)\s*:\s*never\b`)},
+	{"readonly class", "8.2", regexp.MustCompile(`^\s*(?:(?:abstract|final)\s+)*readonly\s+(?:(?:abstract|final)\s+)*class\b`)},
+	{"typed class constant", "8.3", regexp.MustCompile(`\bconst\s+\??[A-Za-z_\\][\w\\|]*\s+[A-Za-z_]\w*\s*=`)},
+}
+
+// jsFeatures are the JavaScript constructs introduced since ES5, by the year of the
+// ECMAScript edition
+var jsFeatures = []syntaxFeature{
+	{"arrow function", "2015", regexp.MustCompile(`=>`)},
+	{"class", "2015", regexp.MustCompile(`(?:^|[^.\w$])class(?:\s+[A-Za-z_$]|\s*\{)`)},
+	{"let/const", "2015", regexp.MustCompile(`(?:^|[^.\w$])(?:let|const)\s+[A-Za-z_$\[{]`)},
+	{"template literal", "2015", regexp.MustCompile("`")},
+	{"module syntax", "2015", regexp.MustCompile(`^\s*(?:import\s*[\w${*'"]|export\b)`)},
+	{"generator", "2015", regexp.MustCompile(`\bfunction\s*\*`)},
+	{"exponent operator", "2016", regexp.MustCompile(`\*\*`)},
+	{"async/await", "2017", regexp.MustCompile(`\basync\s+(?:function\b|\(|[A-Za-z_$][\w$]*\s*(?:=>|\())|\bawait\s`)},
+	{"async iteration", "2018", regexp.MustCompile(`\bfor\s+await\b`)},
+	{"optional catch binding", "2019", regexp.MustCompile(`\bcatch\s*\{`)},
+	{"optional chaining", "2020", regexp.MustCompile(`\?\.(?:[^\d]|$)`)},
+	{"nullish coalescing", "2020", regexp.MustCompile(`\?\?(?:[^=]|$)`)},
+	{"BigInt literal", "2020", regexp.MustCompile(`\b\d[\d_]*n\b`)},
+	{"dynamic import", "2020", regexp.MustCompile(`(?:^|[^.\w$])import\s*\(`)},
+	{"import.meta", "2020", regexp.MustCompile(`\bimport\.meta\b`)},
+	{"logical assignment", "2021", regexp.MustCompile(`(?:\?\?|\|\||&&)=`)},
+	{"numeric separator", "2021", regexp.MustCompile(`\b\d+_\d`)},
+	{"private class member", "2022", regexp.MustCompile(`(?:^|[^\w$])#[A-Za-z_$]`)},
+	{"class static block", "2022", regexp.MustCompile(`\bstatic\s*\{`)},
+}
+
+// ecmaYear turns an ecmaVersion, an edition (6 through 99) or a year (2015 on), into
+// the edition's year; ES3 and ES5 are 1999 and 2009
+func ecmaYear(version string) (string, error) {
+	n, err := strconv.Atoi(version)
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("invalid ecmaVersion %q (expected e.g. 2020 or 11)", version)
+	case n == 3:
+		return "1999", nil
+	case n == 5:
+		return "2009", nil
+	case n >= 6 && n < 100:
+		return strconv.Itoa(n + 2009), nil
+	case n >= 2015:
+		return version, nil
+	}
+	return "", fmt.Errorf("unknown ecmaVersion %q (expected 3, 5, 6 and on, or 2015 and on)", version)
+}
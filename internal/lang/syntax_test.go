@@ -0,0 +1,148 @@
+package lang
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// syntaxFeatures lists the features a file was found using, as "feature@line"
+func syntaxFeatures(parsed *models.ParsedFile) []string {
+	var found []string
+	for _, use := range parsed.Syntax {
+		found = append(found, fmt.Sprintf("%s@%d", use.Feature, use.Line))
+	}
+	return found
+}
+
+func TestPHPParser_TargetVersion(t *testing.T) {
+	tmp := t.TempDir()
+	path := writeFixture(t, tmp, "Order.php", `<?php
+namespace App;
+
+class Order
+{
+    public static $count;
+    private ?Customer $customer = null;
+
+    public function __construct(private readonly int $id) {}
+
+    public function label(): string
+    {
+        $msg = "?->";
+        return $this->customer?->name ?? match($this->id) { 1 => 'first', default => 'other' };
+    }
+
+    public function legacy()
+    {
+        return fn($x) . match($x);
+    }
+}
+`)
+
+	p := NewPHPParser()
+	if err := p.SetVersion("7.3"); err != nil {
+		t.Fatalf("SetVersion error: %v", err)
+	}
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	want := []string{"typed property@7", "constructor promotion@9", "readonly property@9", "nullsafe operator@14", "match expression@14"}
+	if got := syntaxFeatures(parsed); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the constructs newer than 7.3, got %v", got)
+	}
+	calls := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		if u.Type == "function_call" {
+			calls[u.Name] = true
+		}
+	}
+	if !calls["fn"] || !calls["match"] {
+		t.Errorf("expected match and fn to be function names before 7.4, got %v", calls)
+	}
+
+	if err := p.SetVersion("8.1"); err != nil {
+		t.Fatalf("SetVersion error: %v", err)
+	}
+	if parsed, _ = p.ParseFile(path); len(parsed.Syntax) != 0 {
+		t.Errorf("expected nothing newer than 8.1, got %v", syntaxFeatures(parsed))
+	}
+	for _, u := range parsed.Usage {
+		if u.Name == "fn" || u.Name == "match" {
+			t.Errorf("expected keywords in 8.1, got %+v", u)
+		}
+	}
+	if NewPHPParser().SetVersion("eight") == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestJSParser_TargetVersion(t *testing.T) {
+	tmp := t.TempDir()
+	path := writeFixture(t, tmp, "cart.js", `var total = require('./total');
+
+// const x = () => 1;
+function load(cart) {
+  var label = "a ?. b => c";
+  var item = cart?.items ?? [];
+  return await(item) ** 2;
+}
+
+class Cart {
+  #items = [];
+}
+`)
+
+	p := NewJSParser()
+	if err := p.SetVersion("5"); err != nil {
+		t.Fatalf("SetVersion error: %v", err)
+	}
+	parsed, err := p.ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	want := []string{"optional chaining@6", "nullish coalescing@6", "exponent operator@7", "class@10", "private class member@11"}
+	if got := syntaxFeatures(parsed); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the constructs newer than ES5, got %v", got)
+	}
+	awaited := false
+	for _, u := range parsed.Usage {
+		awaited = awaited || (u.Type == "function_call" && u.Name == "await")
+	}
+	if !awaited {
+		t.Errorf("expected await to be a function name before ES2017, got %+v", parsed.Usage)
+	}
+
+	if err := p.SetVersion("13"); err != nil {
+		t.Fatalf("SetVersion error: %v", err)
+	}
+	if parsed, _ = p.ParseFile(path); len(parsed.Syntax) != 0 {
+		t.Errorf("expected nothing newer than ES2022, got %v", syntaxFeatures(parsed))
+	}
+	if NewTSParser().SetVersion("2020") == nil {
+		t.Error("expected TypeScript to have no target")
+	}
+	if NewJSParser().SetVersion("4") == nil {
+		t.Error("expected an error for an unknown ecmaVersion")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"7.4", "8.0", -1},
+		{"8", "8.0", 0},
+		{"7.10", "7.4", 1},
+		{"2020", "2017", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// stringLiteralPattern finds quoted strings, including one left open at the end of the
+// line (a query continued on the next)
+var stringLiteralPattern = regexp.MustCompile("'(?:[^'\\\\]|\\\\.)*(?:'|$)|\"(?:[^\"\\\\]|\\\\.)*(?:\"|$)|`(?:[^`\\\\]|\\\\.)*(?:`|$)")
+
+// sqlTablePatterns find the tables a SQL statement names. Each needs the statement's
+// own shape (INSERT INTO, UPDATE ... SET), so prose like "Update your profile" doesn't
+// read as SQL; FROM and JOIN only count in statements that read or delete rows, written
+// in the same case as the statement's verb ("Select a file from the list" isn't SQL).
+var sqlTablePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)^\s*(SELECT|DELETE|WITH)\b.*?\b(FROM|JOIN)\s+` + sqlName),
+	regexp.MustCompile(`(?i)^\s*(?:INSERT|REPLACE)\s+(?:IGNORE\s+)?INTO\s+` + sqlName),
+	regexp.MustCompile(`(?i)^\s*UPDATE\s+` + sqlName + `\s+(?:[A-Za-z_]\w*\s+)?SET\b`),
+	regexp.MustCompile(`(?i)^\s*(?:CREATE|ALTER|DROP)\s+TABLE\s+(?:IF\s+(?:NOT\s+)?EXISTS\s+)?` + sqlName),
+	regexp.MustCompile(`(?i)^\s*TRUNCATE\s+(?:TABLE\s+)?` + sqlName),
+}
+
+// sqlJoinPattern finds the tables joined after the first one
+var sqlJoinPattern = regexp.MustCompile(`(?i)\b(?:FROM|JOIN)\s+` + sqlName)
+
+// sqlName is a table name, optionally schema-qualified and quoted: users, `app`.`users`
+const sqlName = "([`\"\\[]?[A-Za-z_][\\w$]*[`\"\\]]?(?:\\.[`\"\\[]?[A-Za-z_][\\w$]*[`\"\\]]?)?)"
+
+// sqlTables returns the tables named by SQL in a line's string literals, attributed to
+// the enclosing class and function
+func sqlTables(line string, lineNum int, className, function string) []models.TableReference {
+	var refs []models.TableReference
+	for _, literal := range stringLiteralPattern.FindAllString(line, -1) {
+		text := strings.Trim(literal, "'\"`")
+		seen := make(map[string]bool)
+		add := func(name string) {
+			name = strings.NewReplacer("`", "", `"`, "", "[", "", "]", "").Replace(name)
+			if seen[name] {
+				return
+			}
+			seen[name] = true
+			refs = append(refs, models.TableReference{
+				Table:     name,
+				Kind:      "sql",
+				ClassName: className,
+				Function:  function,
+				Line:      lineNum,
+			})
+		}
+		for i, pattern := range sqlTablePatterns {
+			match := pattern.FindStringSubmatch(text)
+			if match == nil {
+				continue
+			}
+			if i == 0 && !sameCase(match[1], match[2]) {
+				break
+			}
+			add(match[len(match)-1])
+			if i == 0 {
+				for _, join := range sqlJoinPattern.FindAllStringSubmatch(text, -1) {
+					add(join[1])
+				}
+			}
+			break
+		}
+	}
+	return refs
+}
+
+// sameCase reports whether two keywords are both upper case or both lower case
+func sameCase(a, b string) bool {
+	return (a == strings.ToUpper(a) && b == strings.ToUpper(b)) || (a == strings.ToLower(a) && b == strings.ToLower(b))
+}
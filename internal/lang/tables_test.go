@@ -0,0 +1,93 @@
+package lang
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSQLTables(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{`$db->query("SELECT * FROM users u JOIN orders o ON o.user_id = u.id");`, []string{"users", "orders"}},
+		{`$sql = 'select id from ` + "`app`.`users`" + ` where id in (select user_id from bans)';`, []string{"app.users", "bans"}},
+		{`db.run("INSERT INTO audit_log (msg) VALUES (?)")`, []string{"audit_log"}},
+		{"await db.query(`UPDATE accounts SET balance = ${b}`)", []string{"accounts"}},
+		{`"CREATE TABLE IF NOT EXISTS [sessions] (id int)"`, []string{"sessions"}},
+		{`$sql = "DELETE FROM carts`, []string{"carts"}},
+		{`'TRUNCATE jobs'`, []string{"jobs"}},
+		{`$msg = 'Update your profile';`, nil},
+		{`$msg = "Select an item from the list";`, nil},
+		{`"SELECT * FROM {$table}"`, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, ref := range sqlTables(tt.line, 3, "Repo", "find") {
+			if ref.Kind != "sql" || ref.Line != 3 || ref.ClassName != "Repo" || ref.Function != "find" {
+				t.Errorf("%s: unexpected reference %+v", tt.line, ref)
+			}
+			got = append(got, ref.Table)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sqlTables(%s) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParsers_CollectTables(t *testing.T) {
+	tmp := t.TempDir()
+	model := writeFixture(t, tmp, "User.php", `<?php
+namespace App\Models;
+class User extends Model {
+    protected $table = 'members';
+
+    public function recent() {
+        return DB::table('members')->latest()->get();
+    }
+}
+`)
+	migration := writeFixture(t, tmp, "2024_01_01_rename.php", `<?php
+return new class extends Migration {
+    public function up(): void {
+        Schema::rename('users', 'members');
+        Schema::create('teams', function (Blueprint $table) {
+            $table->string('name');
+        });
+    }
+};
+`)
+	js := writeFixture(t, tmp, "repo.js", "export class Repo {\n  all() {\n    return db.query('SELECT * FROM members')\n  }\n}\n")
+
+	parsed, err := NewPHPParser().ParseFile(model)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Tables) != 2 || parsed.Tables[0].Kind != "model" || parsed.Tables[0].ClassName != "User" ||
+		parsed.Tables[1].Kind != "query" || parsed.Tables[1].Function != "recent" || parsed.Tables[1].Line != 7 {
+		t.Errorf("unexpected model tables: %+v", parsed.Tables)
+	}
+
+	parsed, err = NewPHPParser().ParseFile(migration)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	var names []string
+	for _, ref := range parsed.Tables {
+		if ref.Kind != "migration" {
+			t.Errorf("unexpected migration reference %+v", ref)
+		}
+		names = append(names, ref.Table)
+	}
+	if !reflect.DeepEqual(names, []string{"users", "members", "teams"}) {
+		t.Errorf("unexpected migration tables: %v", names)
+	}
+
+	parsed, err = NewJSParser().ParseFile(js)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Tables) != 1 || parsed.Tables[0].Table != "members" || parsed.Tables[0].ClassName != "Repo" || parsed.Tables[0].Line != 3 {
+		t.Errorf("unexpected JS tables: %+v", parsed.Tables)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Patterns for Twig templates
+var (
+	// Tags and their expression: {% extends 'base.html.twig' %}, {%- if is_granted('ROLE_ADMIN') -%}
+	twigTagPattern = regexp.MustCompile(`\{%-?\s*(\w+)(.*?)-?%\}`)
+
+	// Output: {{ product.price|money }}
+	twigOutputPattern = regexp.MustCompile(`\{\{-?(.*?)-?\}\}`)
+
+	// Comments on one line: {# TODO #}
+	twigCommentPattern = regexp.MustCompile(`\{#.*?#\}`)
+
+	// Templates named in a tag or by include() and source(): 'blog/post.html.twig'
+	twigTemplatePattern = regexp.MustCompile(`['"]([\w./@-]+\.twig)['"]`)
+	twigIncludePattern  = regexp.MustCompile(`\b(?:include|source)\s*\(\s*\[?\s*['"]([\w./@-]+\.twig)['"]`)
+
+	// Filters and functions: |money, |format_date('short'), area(shape); not methods (post.title())
+	twigFilterPattern   = regexp.MustCompile(`\|\s*([A-Za-z_]\w*)`)
+	twigFunctionPattern = regexp.MustCompile(`(?:^|[^\w.|])([A-Za-z_]\w*)\s*\(`)
+
+	// Strings in an expression, so their contents aren't read as code
+	twigStringPattern = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+)
+
+// twigTemplateTags are the tags that extend, include, or import another template
+var twigTemplateTags = map[string]bool{
+	"extends": true, "include": true, "embed": true, "import": true, "from": true, "use": true,
+}
+
+// twigBuiltins are the filters, functions, and operators that Twig, and Symfony's Twig
+// bridge, provide
+var twigBuiltins = map[string]bool{
+	// Filters
+	"abs": true, "batch": true, "capitalize": true, "column": true, "convert_encoding": true,
+	"country_name": true, "currency_name": true, "data_uri": true, "date": true,
+	"date_modify": true, "default": true, "e": true, "escape": true, "filter": true,
+	"first": true, "format": true, "format_currency": true, "format_date": true,
+	"format_datetime": true, "format_number": true, "format_time": true, "join": true,
+	"json_encode": true, "keys": true, "last": true, "length": true, "lower": true, "map": true,
+	"merge": true, "nl2br": true, "number_format": true, "raw": true, "reduce": true,
+	"replace": true, "reverse": true, "round": true, "slice": true, "slug": true, "sort": true,
+	"spaceless": true, "split": true, "striptags": true, "title": true, "trim": true,
+	"upper": true, "url_encode": true, "trans": true, "humanize": true, "yaml_encode": true,
+	// Functions
+	"attribute": true, "block": true, "constant": true, "cycle": true, "dump": true,
+	"include": true, "max": true, "min": true, "parent": true, "random": true, "range": true,
+	"source": true, "template_from_string": true, "path": true, "url": true, "asset": true,
+	"asset_version": true, "absolute_url": true, "relative_path": true, "is_granted": true,
+	"csrf_token": true, "logout_path": true, "logout_url": true, "render": true,
+	"controller": true, "form": true, "form_start": true, "form_end": true, "form_row": true,
+	"form_widget": true, "form_label": true, "form_errors": true, "form_rest": true,
+	"encore_entry_link_tags": true, "encore_entry_script_tags": true, "importmap": true,
+	// Operators and tests written like calls
+	"not": true, "and": true, "or": true, "in": true, "is": true, "defined": true,
+	"divisible": true, "same": true, "matches": true, "starts": true, "ends": true,
+}
+
+// twigTemplateName names a template the way Symfony does: by its path under the
+// templates directory, so templates/blog/post.html.twig is blog/post.html.twig
+func twigTemplateName(path string) string {
+	name := filepath.ToSlash(path)
+	if i := strings.LastIndex(name, "/templates/"); i != -1 {
+		return name[i+len("/templates/"):]
+	}
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// parseTwig records a Twig template as a view, with the templates it extends, includes,
+// embeds, and imports, and the filters and functions it uses, as its dependencies.
+// Filters and functions resolve to the callables Twig extensions register for them.
+func (p *PHPParser) parseTwig(filePath string, r io.Reader) (*models.ParsedFile, error) {
+	name := twigTemplateName(filePath)
+	parsed := &models.ParsedFile{
+		Path:     filePath,
+		Language: p.Language(),
+		Elements: []models.CodeElement{{
+			Type:       "view",
+			Name:       name,
+			Visibility: "public",
+			Line:       1,
+			File:       filePath,
+		}},
+		Usage: []models.UsageElement{},
+		Uses:  []string{},
+	}
+	add := func(usageType, target string, lineNum int) {
+		parsed.Usage = append(parsed.Usage, models.UsageElement{Type: usageType, Name: target, Context: name, Line: lineNum})
+	}
+	addExpression := func(expression string, lineNum int) {
+		for _, match := range twigIncludePattern.FindAllStringSubmatch(expression, -1) {
+			add("view", match[1], lineNum)
+		}
+		code := twigStringPattern.ReplaceAllString(expression, "''")
+		for _, match := range twigFilterPattern.FindAllStringSubmatch(code, -1) {
+			if !twigBuiltins[match[1]] {
+				add("twig_call", "filter:"+match[1], lineNum)
+			}
+		}
+		for _, match := range twigFunctionPattern.FindAllStringSubmatch(twigFilterPattern.ReplaceAllString(code, ""), -1) {
+			if !twigBuiltins[match[1]] {
+				add("twig_call", "function:"+match[1], lineNum)
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	inComment := false  // Inside a {# #} comment
+	inVerbatim := false // Inside {% verbatim %}, which Twig doesn't compile
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if marker, ok := debtMarker(line, lineNum); ok {
+			parsed.Debt = append(parsed.Debt, marker)
+		}
+
+		if inComment {
+			parsed.CommentLines++
+			end := strings.Index(line, "#}")
+			if end == -1 {
+				continue
+			}
+			line, inComment = line[end+len("#}"):], false
+		}
+		if twigCommentPattern.MatchString(line) && strings.TrimSpace(twigCommentPattern.ReplaceAllString(line, "")) == "" {
+			parsed.CommentLines++
+		}
+		line = twigCommentPattern.ReplaceAllString(line, "")
+		if start := strings.Index(line, "{#"); start != -1 {
+			parsed.CommentLines++
+			line, inComment = line[:start], true
+		}
+
+		for _, match := range twigTagPattern.FindAllStringSubmatch(line, -1) {
+			tag, expression := match[1], match[2]
+			switch {
+			case inVerbatim:
+				inVerbatim = tag != "endverbatim"
+				continue
+			case tag == "verbatim":
+				inVerbatim = true
+				continue
+			case twigTemplateTags[tag]:
+				for _, template := range twigTemplatePattern.FindAllStringSubmatch(expression, -1) {
+					add("view", template[1], lineNum)
+				}
+			}
+			addExpression(expression, lineNum)
+		}
+		if inVerbatim {
+			continue
+		}
+		for _, match := range twigOutputPattern.FindAllStringSubmatch(line, -1) {
+			addExpression(match[1], lineNum)
+		}
+	}
+
+	parsed.Lines = lineNum
+	return parsed, scanner.Err()
+}
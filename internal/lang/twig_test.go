@@ -0,0 +1,159 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestPHPParser_Twig(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "templates", "product"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := writeFixture(t, tmp, "templates/product/show.html.twig", `{% extends 'base.html.twig' %}
+{% import 'macros/forms.html.twig' as forms %}
+
+{# TODO: reviews,
+   once they're in #}
+{% block body %}
+  {%- include 'product/_gallery.html.twig' with {images: product.images} only -%}
+  <h1>{{ product.name|upper }}</h1>
+  <p>{{ product.price | money('EUR') }} {{ "a|fake(1)"|raw }}</p>
+  {% if in_stock(product) and product.visible() %}
+    {{ include('product/_buy.html.twig') }}
+    {% embed "components/card.html.twig" %}{% endembed %}
+  {% endif %}
+  {{ forms.input('qty') }} {{ path('cart') }}
+  {% verbatim %}
+    {{ notParsed() }}
+  {% endverbatim %}
+{% endblock %}
+`)
+
+	parsed, err := NewPHPParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if len(parsed.Elements) != 1 || parsed.Elements[0].Type != "view" || parsed.Elements[0].Name != "product/show.html.twig" {
+		t.Fatalf("expected the template as a view named by its path under templates, got %+v", parsed.Elements)
+	}
+
+	usage := make(map[string]int)
+	for _, u := range parsed.Usage {
+		if u.Context != "product/show.html.twig" {
+			t.Errorf("expected usage in the template, got %+v", u)
+		}
+		usage[u.Type+":"+u.Name] = u.Line
+	}
+	want := map[string]int{
+		"view:base.html.twig":             1,
+		"view:macros/forms.html.twig":     2,
+		"view:product/_gallery.html.twig": 7,
+		"twig_call:filter:money":          9,
+		"twig_call:function:in_stock":     10,
+		"view:product/_buy.html.twig":     11,
+		"view:components/card.html.twig":  12,
+	}
+	for key, line := range want {
+		if usage[key] != line {
+			t.Errorf("expected %s on line %d, got %v", key, line, usage)
+		}
+	}
+	if len(usage) != len(want) {
+		t.Errorf("expected only %v, got %v", want, usage)
+	}
+
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 4 {
+		t.Errorf("expected the TODO on line 4, got %+v", parsed.Debt)
+	}
+	if parsed.CommentLines != 2 || parsed.Lines != 18 {
+		t.Errorf("expected 2 comment lines of 18, got %d of %d", parsed.CommentLines, parsed.Lines)
+	}
+}
+
+func TestPHPParser_TwigGraph(t *testing.T) {
+	tmp := t.TempDir()
+	for _, dir := range []string{"src/Controller", "src/Twig", "templates/blog"} {
+		if err := os.MkdirAll(filepath.Join(tmp, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paths := []string{
+		writeFixture(t, tmp, "src/Controller/BlogController.php", `<?php
+namespace App\Controller;
+
+class BlogController extends AbstractController {
+    public function index() {
+        return $this->render('blog/index.html.twig', ['posts' => []]);
+    }
+
+    #[Template('blog/archive.html.twig')]
+    public function archive() {}
+}
+`),
+		writeFixture(t, tmp, "src/Twig/AppExtension.php", `<?php
+namespace App\Twig;
+
+use Twig\Extension\AbstractExtension;
+use Twig\TwigFilter;
+
+class AppExtension extends AbstractExtension {
+    public function getFilters() {
+        return [
+            new TwigFilter('excerpt', [$this, 'excerpt']),
+        ];
+    }
+
+    public function excerpt($text) {
+        return $text;
+    }
+}
+`),
+		writeFixture(t, tmp, "templates/base.html.twig", `<body>{% block body %}{% endblock %}</body>
+`),
+		writeFixture(t, tmp, "templates/blog/index.html.twig", `{% extends 'base.html.twig' %}
+{% block body %}{{ post.body|excerpt }}{% endblock %}
+`),
+		writeFixture(t, tmp, "templates/blog/archive.html.twig", `{% extends 'base.html.twig' %}
+`),
+		writeFixture(t, tmp, "templates/blog/unused.html.twig", `<p></p>
+`),
+	}
+
+	p := NewPHPParser()
+	var files []*models.ParsedFile
+	for _, path := range paths {
+		parsed, err := p.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Type+":"+node.Name] = node
+	}
+	for _, edge := range [][2]string{
+		{"method:index", "view:blog/index.html.twig"},
+		{"class:BlogController", "view:blog/archive.html.twig"},
+		{"view:blog/index.html.twig", "view:base.html.twig"},
+		{"view:blog/index.html.twig", "method:excerpt"},
+	} {
+		from, to := nodes[edge[0]], nodes[edge[1]]
+		if from == nil || to == nil || from.Dependencies[to.ID] == nil {
+			t.Errorf("expected %s to depend on %s, got %+v", edge[0], edge[1], from)
+		}
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Type+":"+node.Name] = true
+	}
+	if !orphans["view:blog/unused.html.twig"] || orphans["method:excerpt"] {
+		t.Errorf("expected the unused template among the orphans, and the filter's method not, got %v", orphans)
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/parser"
+)
+
+// TSParser handles parsing of TypeScript files. TypeScript is JavaScript with types, so
+// it runs the JavaScript parser with patterns that allow for annotations, generics, and
+// modifiers, and adds interfaces, enums, type aliases, decorators, and the types named
+// in signatures and fields.
+type TSParser struct {
+	*JSParser
+}
+
+// tsPatterns are the patterns only TypeScript needs
+type tsPatterns struct {
+	interfacePattern     *regexp.Regexp
+	enumPattern          *regexp.Regexp
+	typeAliasPattern     *regexp.Regexp
+	decoratorPattern     *regexp.Regexp
+	fieldPattern         *regexp.Regexp
+	typeNamePattern      *regexp.Regexp
+	importRequirePattern *regexp.Regexp
+}
+
+// typeParams matches a type parameter list, with one level of nesting: <T extends Base<U>>
+const typeParams = `<(?:[^<>]|<[^<>]*>)*>`
+
+// NewTSParser creates a new TypeScript parser
+func NewTSParser() *TSParser {
+	p := NewJSParser()
+	p.language = "typescript"
+
+	// Class: export abstract class UserService<T> extends BaseService<T> implements OnInit, OnDestroy
+	p.classPattern = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)(?:\s*` + typeParams + `)?(?:\s+extends\s+([A-Za-z_$][\w$.]*)(?:\s*` + typeParams + `)?)?(?:\s+implements\s+([^{]+))?`)
+
+	// Function: export async function fetchUser<T>(id: string): Promise<T> {
+	p.functionPattern = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*(?:` + typeParams + `)?\s*\(`)
+
+	// Function expressions: const fetchUser = async (id: string): Promise<User> => {, export const handler: Handler = (req) => {
+	p.functionExprPattern = regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:` + typeParams + `\s*)?(?:function\b[^(]*\(|\([^)]*\)\s*(?::[^=]+)?=>|\([^)]*$|[A-Za-z_$][\w$]*\s*=>)`)
+
+	// Exported constants: export const API_URL: string = '/api'
+	p.constantPattern = regexp.MustCompile(`^\s*export\s+(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=`)
+
+	// Default exports: export default abstract class Repository
+	p.exportDefaultPattern = regexp.MustCompile(`^\s*export\s+default\s+(?:(?:async\s+)?function\s*\*?\s*|(?:abstract\s+)?class\s+)?([A-Za-z_$][\w$]*)`)
+
+	// Methods inside a class body: private async load<T>(id: string): Promise<T> {, ngOnInit(): void {
+	p.methodPattern = regexp.MustCompile(`^\s*((?:(?:public|private|protected|static|async|get|set|readonly|override|abstract|declare|accessor)\s+)*)\*?\s*(#?[A-Za-z_$][\w$]*)\s*\??\s*(?:` + typeParams + `)?\s*\(`)
+
+	// Arrow-function class fields: private handleClick = (event: MouseEvent): void => {
+	p.classFieldPattern = regexp.MustCompile(`^\s*((?:(?:public|private|protected|static|readonly|override)\s+)*)(#?[A-Za-z_$][\w$]*)\s*[?!]?\s*(?::[^=]+)?=\s*(?:async\s+)?(?:\(|[A-Za-z_$][\w$]*\s*=>)`)
+
+	p.ts = &tsPatterns{
+		// Interfaces: export interface Repository<T> extends Reader<T>, Writer<T> {
+		interfacePattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:declare\s+)?interface\s+([A-Za-z_$][\w$]*)(?:\s*` + typeParams + `)?(?:\s+extends\s+([^{]+))?`),
+
+		// Enums: export const enum Status {
+		enumPattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:declare\s+)?(?:const\s+)?enum\s+([A-Za-z_$][\w$]*)`),
+
+		// Type aliases: export type Handler<T> = (event: T) => void
+		typeAliasPattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:declare\s+)?type\s+([A-Za-z_$][\w$]*)\s*(?:` + typeParams + `)?\s*=(.*)$`),
+
+		// Decorators: @Component({ ... }), @Input(), @ng.Injectable()
+		decoratorPattern: regexp.MustCompile(`^\s*@([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)\s*`),
+
+		// Typed class fields: private readonly users: Map<string, User> = new Map();
+		fieldPattern: regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|readonly|override|declare|abstract|accessor)\s+)*#?[A-Za-z_$][\w$]*\s*[?!]?\s*:(.*)$`),
+
+		// Names in a type: User, models.User
+		typeNamePattern: regexp.MustCompile(`[A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*`),
+
+		// CommonJS-style imports: import fs = require('fs')
+		importRequirePattern: regexp.MustCompile(`^\s*(?:export\s+)?import\s+([A-Za-z_$][\w$]*)\s*=\s*require\s*\(\s*['"]([^'"]+)['"]\s*\)`),
+	}
+	return &TSParser{JSParser: p}
+}
+
+// tsDecorator is a decorator awaiting the declaration it decorates
+type tsDecorator struct {
+	name string // e.g. "Component" or "ng.Injectable"
+	args string // The text between its parentheses
+	line int
+}
+
+// takeDecorators removes the decorators at the start of a line, returning them and the
+// rest of the line
+func (p *JSParser) takeDecorators(line string, lineNum int) ([]tsDecorator, string) {
+	var found []tsDecorator
+	for {
+		matches := p.ts.decoratorPattern.FindStringSubmatchIndex(line)
+		if matches == nil {
+			return found, line
+		}
+		decorator := tsDecorator{name: line[matches[2]:matches[3]], line: lineNum}
+		line = line[matches[1]:]
+		if strings.HasPrefix(line, "(") {
+			end := topLevelIndex(line[1:], ')')
+			if end == -1 {
+				end = len(line) - 1
+			}
+			decorator.args = line[1 : end+1]
+			line = line[min(end+2, len(line)):]
+		}
+		found = append(found, decorator)
+	}
+}
+
+// addDecorator records a decorator, and the classes and calls in its arguments (such as
+// an Angular module's declarations and providers), as usage by the declaration it decorates
+func (p *JSParser) addDecorator(decorator tsDecorator, context string, parsed *models.ParsedFile) {
+	receiver, name := splitMember(decorator.name)
+	parsed.Usage = append(parsed.Usage, models.UsageElement{
+		Type:     "decorator",
+		Name:     name,
+		Context:  context,
+		Receiver: receiver,
+		Line:     decorator.line,
+	})
+	p.addTypeReferences(decorator.args, decorator.line, context, parsed)
+	p.parseUsage(decorator.args, decorator.line, context, parsed)
+}
+
+// parseTypes handles the declarations JavaScript doesn't have, interfaces, enums, and type
+// aliases, and the lines of their bodies, which name types but run nothing. It reports
+// whether the line was one of them.
+func (p *JSParser) parseTypes(line string, lineNum int, filePath, module string, documented bool, depth int, scopes *[]jsScope, parsed *models.ParsedFile) bool {
+	if n := len(*scopes); n > 0 {
+		switch scope := (*scopes)[n-1]; scope.kind {
+		case "type":
+			p.addTypeReferences(line, lineNum, scope.name, parsed)
+			return true
+		case "enum":
+			return true // Members are values
+		}
+	}
+
+	declare := func(elementType, name, scope string) {
+		parsed.Elements = append(parsed.Elements, models.CodeElement{
+			Type:       elementType,
+			Name:       name,
+			Namespace:  module,
+			Line:       lineNum,
+			File:       filePath,
+			Documented: documented,
+		})
+		if strings.HasPrefix(strings.TrimSpace(line), "export ") {
+			parsed.Exports = append(parsed.Exports, models.ExportBinding{Name: name, Local: name, Kind: "export", Line: lineNum})
+		}
+		*scopes = append(*scopes, jsScope{kind: scope, name: name, depth: depth})
+	}
+
+	switch {
+	case p.ts.interfacePattern.MatchString(line):
+		matches := p.ts.interfacePattern.FindStringSubmatch(line)
+		declare("interface", matches[1], "type")
+		if matches[2] != "" {
+			p.addTypeUsage("extends", matches[2], lineNum, matches[1], parsed)
+		}
+		if open := strings.Index(line, "{"); open != -1 {
+			p.addTypeReferences(line[open:], lineNum, matches[1], parsed) // One-line body
+		}
+	case p.ts.enumPattern.MatchString(line):
+		declare("enum", p.ts.enumPattern.FindStringSubmatch(line)[1], "enum")
+	case p.ts.typeAliasPattern.MatchString(line):
+		matches := p.ts.typeAliasPattern.FindStringSubmatch(line)
+		declare("type", matches[1], "type")
+		p.addTypeReferences(matches[2], lineNum, matches[1], parsed)
+	default:
+		return false
+	}
+	return true
+}
+
+// addTypeUsage records each type in a list, e.g. "implements OnInit, Repository<User>"
+func (p *JSParser) addTypeUsage(usageType, list string, lineNum int, context string, parsed *models.ParsedFile) {
+	for _, item := range splitTopLevel(list) {
+		item = strings.TrimSpace(item)
+		if lt := strings.Index(item, "<"); lt != -1 {
+			item = strings.TrimSpace(item[:lt])
+		}
+		receiver, name := splitMember(item)
+		if !isJSIdentifier(name) {
+			continue
+		}
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     usageType,
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+		})
+	}
+}
+
+// addSignatureTypes records the types annotating the parameters and return type in the
+// text after a declaration's "(". Constructor parameters are how Angular and NestJS
+// inject dependencies.
+func (p *JSParser) addSignatureTypes(rest string, lineNum int, context string, parsed *models.ParsedFile) {
+	end := topLevelIndex(rest, ')')
+	params := rest
+	if end != -1 {
+		params = rest[:end]
+	}
+	for _, param := range splitTopLevel(params) {
+		if colon := topLevelIndex(param, ':'); colon != -1 {
+			p.addTypeReferences(untilTopLevel(param[colon+1:], "="), lineNum, context, parsed)
+		}
+	}
+	if end == -1 {
+		return
+	}
+	if returns := strings.TrimSpace(rest[end+1:]); strings.HasPrefix(returns, ":") {
+		if arrow := strings.Index(returns, "=>"); arrow != -1 {
+			returns = returns[:arrow]
+		}
+		p.addTypeReferences(untilTopLevel(returns[1:], "{;"), lineNum, context, parsed)
+	}
+}
+
+// addFieldType records the types annotating a class field: private users: Map<string, User>
+func (p *JSParser) addFieldType(line string, lineNum int, className string, parsed *models.ParsedFile) {
+	if matches := p.ts.fieldPattern.FindStringSubmatch(line); matches != nil {
+		p.addTypeReferences(untilTopLevel(matches[1], "=;"), lineNum, className, parsed)
+	}
+}
+
+// addTypeReferences records the names of classes, interfaces, and other declared types in
+// a type. By convention they are capitalized, which tells them from object keys and
+// primitives; built-in and single-letter generic types are skipped.
+func (p *JSParser) addTypeReferences(typeText string, lineNum int, context string, parsed *models.ParsedFile) {
+	for _, match := range p.ts.typeNamePattern.FindAllStringIndex(typeText, -1) {
+		receiver, name := splitMember(typeText[match[0]:match[1]])
+		if len(name) < 2 || name[0] < 'A' || name[0] > 'Z' || isTSBuiltinType(name) {
+			continue
+		}
+		if after := strings.TrimLeft(typeText[match[1]:], " \t?"); strings.HasPrefix(after, ":") || strings.HasPrefix(after, "(") {
+			continue // A key, or a call in a decorator's arguments
+		}
+		parsed.Usage = append(parsed.Usage, models.UsageElement{
+			Type:     "type_reference",
+			Name:     name,
+			Context:  context,
+			Receiver: receiver,
+			Line:     lineNum,
+		})
+	}
+}
+
+// topLevelIndex returns the index of the first target outside brackets, parentheses,
+// braces, and type arguments in s, or -1. The ">" of "=>" and "->" closes nothing, and
+// the "=" of "=>" is not a target.
+func topLevelIndex(s string, target byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		arrow := ((c == '=' || c == '-') && i+1 < len(s) && s[i+1] == '>') || (c == '>' && i > 0 && (s[i-1] == '=' || s[i-1] == '-'))
+		if c == target && depth == 0 && !arrow {
+			return i
+		}
+		switch {
+		case c == '(' || c == '[' || c == '{' || c == '<':
+			depth++
+		case (c == ')' || c == ']' || c == '}' || c == '>') && !arrow:
+			depth--
+		}
+	}
+	return -1
+}
+
+// untilTopLevel cuts s at the first of the stop characters found at the top level
+func untilTopLevel(s, stops string) string {
+	for i := 0; i < len(stops); i++ {
+		if idx := topLevelIndex(s, stops[i]); idx != -1 {
+			s = s[:idx]
+		}
+	}
+	return s
+}
+
+// splitTopLevel splits s at its top-level commas: "a: Map<K, V>, b" → ["a: Map<K, V>", " b"]
+func splitTopLevel(s string) []string {
+	var parts []string
+	for {
+		idx := topLevelIndex(s, ',')
+		if idx == -1 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:idx])
+		s = s[idx+1:]
+	}
+}
+
+// skipTypeParameters removes a leading type parameter list: "<T,>(value: T) =>" → "(value: T) =>"
+func skipTypeParameters(s string) string {
+	if !strings.HasPrefix(s, "<") {
+		return s
+	}
+	if end := topLevelIndex(s[1:], '>'); end != -1 {
+		return strings.TrimSpace(s[end+2:])
+	}
+	return s
+}
+
+// parameterDecorator matches the name of a decorator on a parameter: @Inject(TOKEN)
+var parameterDecorator = regexp.MustCompile(`^@[A-Za-z_$][\w$.]*\s*`)
+
+// stripTSModifiers removes the decorators and property modifiers of a TypeScript
+// parameter: "@Inject(TOKEN) private readonly http"
+func stripTSModifiers(param string) string {
+	for {
+		trimmed := strings.TrimSpace(param)
+		stripped := false
+		if name := parameterDecorator.FindString(trimmed); name != "" {
+			param, stripped = trimmed[len(name):], true
+			if strings.HasPrefix(param, "(") {
+				if end := topLevelIndex(param[1:], ')'); end != -1 {
+					param = param[end+2:]
+				}
+			}
+		}
+		for _, modifier := range []string{"public ", "private ", "protected ", "readonly ", "override "} {
+			if strings.HasPrefix(trimmed, modifier) {
+				param, stripped = trimmed[len(modifier):], true
+			}
+		}
+		if !stripped {
+			return param
+		}
+	}
+}
+
+// isTSBuiltinType checks if a type name is a JavaScript global or a TypeScript utility type
+func isTSBuiltinType(name string) bool {
+	if isJSBuiltin(name) {
+		return true
+	}
+	switch name {
+	case "Record", "Partial", "Required", "Readonly", "ReadonlyArray", "Pick", "Omit", "Exclude",
+		"Extract", "NonNullable", "ReturnType", "Parameters", "ConstructorParameters", "InstanceType",
+		"Awaited", "ThisType", "Uppercase", "Lowercase", "Capitalize", "Uncapitalize", "Function",
+		"PromiseLike", "Iterable", "Iterator", "IterableIterator", "AsyncIterable", "AsyncIterator",
+		"Generator", "AsyncGenerator", "ArrayLike", "JSON", "Math":
+		return true
+	}
+	return false
+}
+
+// FileExtensions returns the file extensions supported by this parser
+func (p *TSParser) FileExtensions() []string {
+	return []string{".ts", ".tsx", ".mts", ".cts", ".vue"}
+}
+
+func init() {
+	parser.Register(NewTSParser())
+}
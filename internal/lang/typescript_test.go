@@ -0,0 +1,180 @@
+package lang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestTSParser_DeclarationsAndUsage(t *testing.T) {
+	tmp := t.TempDir()
+	code := `import { Component, OnInit } from '@angular/core';
+import { UserService } from './user.service';
+import type { User } from './user';
+
+/** Lists users */
+@Component({
+  selector: 'app-users',
+  providers: [UserService],
+})
+export class UsersComponent extends BaseComponent<User> implements OnInit {
+  @Input() title: string;
+  private users: Map<string, User> = new Map();
+
+  constructor(private readonly service: UserService, @Inject(CONFIG) config?: AppConfig) {
+    super();
+  }
+
+  async ngOnInit(): Promise<void> {
+    this.service.load();
+  }
+
+  protected select = (user: User): Selection => {
+    return toSelection(user);
+  };
+}
+
+export interface Repository<T> extends Reader<T>, Writer {
+  find(id: string): Promise<T | NotFound>;
+}
+
+export enum Status {
+  Active = 'ACTIVE',
+  Banned = 'BANNED',
+}
+
+export type Handler<T> = (event: T) => Outcome;
+
+export function toSelection<T extends User>(user: T, options: Options = {}): Selection {
+  return new Selection(user);
+}
+
+export function overload(a: string): void;
+export const parse = async <T,>(text: string): Promise<T> => JSON.parse(text);
+`
+	path := writeFixture(t, tmp, "users.component.ts", code)
+
+	parsed, err := NewTSParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if parsed.Language != "typescript" {
+		t.Errorf("expected language typescript, got %q", parsed.Language)
+	}
+
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Name] = el
+	}
+	for _, key := range []string{"class:UsersComponent", "method:constructor", "method:ngOnInit", "method:select",
+		"interface:Repository", "enum:Status", "type:Handler", "function:toSelection", "function:parse"} {
+		if _, ok := elements[key]; !ok {
+			t.Errorf("expected element %s, got %+v", key, parsed.Elements)
+		}
+	}
+	if len(parsed.Elements) != 9 {
+		t.Errorf("expected no elements from members, overloads, or enum values, got %+v", parsed.Elements)
+	}
+	if !elements["class:UsersComponent"].Documented {
+		t.Error("expected the docblock above the decorator to document the class")
+	}
+	if got := elements["method:constructor"].Parameters; len(got) != 2 || got[0] != "service" || got[1] != "config" {
+		t.Errorf("expected parameters [service config], got %v", got)
+	}
+	if got := elements["function:toSelection"].Parameters; len(got) != 2 || got[1] != "options" {
+		t.Errorf("expected parameters [user options], got %v", got)
+	}
+	if elements["method:select"].Visibility != "protected" {
+		t.Errorf("expected a protected method, got %+v", elements["method:select"])
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Name] = true
+		usage[u.Type+":"+u.Name+" in "+u.Context] = true
+	}
+	want := map[string]string{
+		"decorator:Component":        "UsersComponent",
+		"type_reference:UserService": "UsersComponent", // From the decorator, before the constructor
+		"extends:BaseComponent":      "UsersComponent",
+		"implements:OnInit":          "UsersComponent",
+		"decorator:Input":            "UsersComponent",
+		"type_reference:AppConfig":   "constructor",
+		"type_reference:Selection":   "select",
+		"extends:Reader":             "Repository",
+		"extends:Writer":             "Repository",
+		"type_reference:NotFound":    "Repository",
+		"type_reference:Outcome":     "Handler",
+		"type_reference:Options":     "toSelection",
+		"function_call:toSelection":  "select",
+	}
+	for key, context := range want {
+		if !usage[key+" in "+context] {
+			t.Errorf("expected usage %s in %s, got %+v", key, context, parsed.Usage)
+		}
+	}
+	for _, key := range []string{"type_reference:Promise", "type_reference:T", "type_reference:Map", "type_reference:Active", "function_call:find"} {
+		if usage[key] {
+			t.Errorf("unexpected usage %s", key)
+		}
+	}
+
+	exports := make(map[string]bool)
+	for _, export := range parsed.Exports {
+		exports[export.Name] = true
+	}
+	for _, name := range []string{"UsersComponent", "Repository", "Status", "Handler", "toSelection", "parse"} {
+		if !exports[name] {
+			t.Errorf("expected export %s, got %+v", name, parsed.Exports)
+		}
+	}
+}
+
+func TestTSParser_Graph(t *testing.T) {
+	tmp := t.TempDir()
+	writeFixture(t, tmp, "user.service.ts", `import fs = require('fs');
+
+@Injectable()
+export class UserService {
+  load(): void {}
+}
+`)
+	writeFixture(t, tmp, "users.ts", `import { UserService } from './user.service';
+
+export class Users {
+  constructor(private service: UserService) {}
+}
+`)
+
+	p := NewTSParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"user.service.ts", "users.ts"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+	if imports := files[0].Imports; len(imports) != 1 || imports[0].Local != "fs" || imports[0].Kind != "require" {
+		t.Errorf("expected import = require() to bind fs, got %+v", imports)
+	}
+
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+	var constructor, service *models.DependencyNode
+	for _, node := range graph.Nodes {
+		switch {
+		case node.Name == "constructor":
+			constructor = node
+		case node.Name == "UserService":
+			service = node
+		}
+	}
+	if constructor == nil || service == nil {
+		t.Fatalf("expected the constructor and service nodes, got %v", graph.Nodes)
+	}
+	if ref := constructor.Dependencies[service.ID]; ref == nil || ref.Type != "type_reference" {
+		t.Errorf("expected the injected service as a dependency, got %+v", constructor.Dependencies)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Patterns for the blocks of a Vue single-file component and its template
+var (
+	// Block tags start a line: <script setup lang="ts">, </template>
+	vueOpenPattern = regexp.MustCompile(`^<(script|template|style)\b[^>]*>`)
+
+	// Tags in a template: <UserCard, <user-card, <router-link
+	vueTagPattern = regexp.MustCompile(`<([A-Za-z][\w-]*)`)
+
+	// Template expressions: {{ total(cart) }}, and directive values: @click="save",
+	// :items="sorted(list)", v-if="ready", #header="{ title }"
+	vueMustachePattern  = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	vueDirectivePattern = regexp.MustCompile(`(?:^|\s)(?:v-[\w-]+|[@:#][\w-]*)(?::[\w-]+)?(?:\.[\w-]+)*\s*=\s*"([^"]*)"`)
+	vueHandlerPattern   = regexp.MustCompile(`(?:^|\s)(?:v-on:|@)[\w.-]+\s*=\s*"\s*([A-Za-z_$][\w$]*)\s*"`)
+)
+
+// vueBuiltins are the components Vue and Vue Router provide, lowercased without hyphens
+var vueBuiltins = map[string]bool{
+	"component": true, "transition": true, "transitiongroup": true, "keepalive": true,
+	"teleport": true, "suspense": true, "slot": true, "template": true,
+	"routerview": true, "routerlink": true,
+}
+
+// vueComponent is a single-file component split into its blocks
+type vueComponent struct {
+	name       string   // From the file name: UserCard for user-card.vue
+	script     string   // The file with everything but its script blocks blanked out
+	scriptLine int      // Line of the first <script> tag; 0 without one
+	template   []string // The file's lines, empty outside the template
+}
+
+// readVue splits a single-file component into its script, which keeps its line numbers,
+// and its template
+func readVue(path string, r io.Reader) (*vueComponent, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(src), "\n")
+	sfc := &vueComponent{name: vueComponentName(path), template: make([]string, len(lines))}
+	script := make([]string, len(lines))
+
+	block := "" // The block the line is in
+	for i, line := range lines {
+		content := line
+		opened := false
+		if block == "" {
+			match := vueOpenPattern.FindStringSubmatchIndex(line)
+			if match == nil {
+				continue
+			}
+			block, opened = line[match[2]:match[3]], true
+			if block == "script" && sfc.scriptLine == 0 {
+				sfc.scriptLine = i + 1
+			}
+			content = line[match[1]:]
+		}
+
+		// Templates nest <template> tags, so only one starting a line ends the block,
+		// unless the block opened and closed on the same line
+		current := block
+		if block == "template" {
+			if strings.HasPrefix(line, "</template>") && !opened {
+				block = ""
+				continue
+			}
+			if opened && strings.HasSuffix(strings.TrimSpace(content), "</template>") {
+				block = ""
+			}
+		} else if end := strings.Index(content, "</"+block+">"); end != -1 {
+			content = content[:end]
+			block = ""
+		}
+		switch current {
+		case "script":
+			script[i] = content
+		case "template":
+			sfc.template[i] = content
+		}
+	}
+	sfc.script = strings.Join(script, "\n")
+	return sfc, nil
+}
+
+// vueComponentName names a component after its file, in PascalCase, or after its
+// directory for an index.vue
+func vueComponentName(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if base == "index" {
+		base = filepath.Base(filepath.Dir(path))
+	}
+	return pascalCase(base)
+}
+
+// pascalCase turns a kebab- or snake-case name into PascalCase: user-card → UserCard
+func pascalCase(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r == '-' || r == '_' || r == '.':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// addVueComponent records a single-file component as a class exported by default, and
+// the components, functions, and handlers its template uses as its own usage. Script
+// code outside functions belongs to the component too.
+func (p *JSParser) addVueComponent(sfc *vueComponent, filePath, module string, parsed *models.ParsedFile) {
+	line := sfc.scriptLine
+	if line == 0 {
+		line = 1
+	}
+	parsed.Elements = append(parsed.Elements, models.CodeElement{
+		Type:       "class",
+		Name:       sfc.name,
+		Namespace:  module,
+		Visibility: "public",
+		Line:       line,
+		File:       filePath,
+	})
+
+	// The component is the default export, whatever the script's says (defineComponent)
+	exports := parsed.Exports[:0]
+	for _, export := range parsed.Exports {
+		if export.Name != "default" {
+			exports = append(exports, export)
+		}
+	}
+	parsed.Exports = append(exports, models.ExportBinding{Name: "default", Local: sfc.name, Kind: "export", Line: line})
+
+	for i := range parsed.Usage {
+		if parsed.Usage[i].Context == "" {
+			parsed.Usage[i].Context = sfc.name
+		}
+	}
+
+	for i, text := range sfc.template {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lineNum := i + 1
+		for _, match := range vueTagPattern.FindAllStringSubmatch(text, -1) {
+			tag, name := match[1], pascalCase(match[1])
+			if vueBuiltins[strings.ToLower(name)] || (!strings.Contains(tag, "-") && !unicode.IsUpper(rune(tag[0]))) {
+				continue // HTML elements and Vue's own components
+			}
+			parsed.Usage = append(parsed.Usage, models.UsageElement{
+				Type:    "component",
+				Name:    name,
+				Context: sfc.name,
+				Line:    lineNum,
+			})
+		}
+		for _, match := range vueHandlerPattern.FindAllStringSubmatch(text, -1) {
+			parsed.Usage = append(parsed.Usage, models.UsageElement{
+				Type:    "function_call",
+				Name:    match[1],
+				Context: sfc.name,
+				Line:    lineNum,
+			})
+		}
+		var expressions []string
+		for _, match := range vueMustachePattern.FindAllStringSubmatch(text, -1) {
+			expressions = append(expressions, match[1])
+		}
+		for _, match := range vueDirectivePattern.FindAllStringSubmatch(text, -1) {
+			expressions = append(expressions, match[1])
+		}
+		for _, expression := range expressions {
+			_, bare, _ := stripJSLine(expression, false)
+			p.parseUsage(bare, lineNum, sfc.name, parsed)
+		}
+	}
+}
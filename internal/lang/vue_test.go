@@ -0,0 +1,150 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestJSParser_VueComponent(t *testing.T) {
+	tmp := t.TempDir()
+	path := writeFixture(t, tmp, "order-list.vue", `<template>
+  <div class="orders">
+    <OrderRow v-for="order in sorted(orders)" :key="order.id" :order="order" @select="pick" />
+    <template #footer>
+      <pager-bar :total="orders.length" @next="api.next()" />
+    </template>
+    <router-link to="/">{{ label(orders) }}</router-link>
+  </div>
+</template>
+
+<script setup>
+import OrderRow from './OrderRow.vue'
+import api from './api'
+
+const props = defineProps(['orders'])
+api.track('orders')
+
+// TODO: paginate on the server
+function sorted(orders) {
+  return [...orders].sort(byDate)
+}
+
+function pick(order) {}
+</script>
+
+<style scoped>
+.orders { display: flex; }
+</style>
+`)
+
+	parsed, err := NewJSParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	elements := make(map[string]models.CodeElement)
+	for _, el := range parsed.Elements {
+		elements[el.Type+":"+el.Name] = el
+	}
+	if component, ok := elements["class:OrderList"]; !ok || component.Line != 11 || component.Namespace != moduleName(path) {
+		t.Errorf("expected the component as a class at its script, got %+v", parsed.Elements)
+	}
+	if _, ok := elements["function:sorted"]; !ok || len(parsed.Elements) != 3 {
+		t.Errorf("expected the script's functions, got %+v", parsed.Elements)
+	}
+	if len(parsed.Exports) != 1 || parsed.Exports[0].Name != "default" || parsed.Exports[0].Local != "OrderList" {
+		t.Errorf("expected the component as the default export, got %+v", parsed.Exports)
+	}
+	if len(parsed.Imports) != 2 || parsed.Imports[0].Resolved != "" || parsed.Imports[0].Local != "OrderRow" {
+		t.Errorf("expected the script's imports, got %+v", parsed.Imports)
+	}
+
+	usage := make(map[string]bool)
+	for _, u := range parsed.Usage {
+		usage[u.Type+":"+u.Name+" in "+u.Context] = true
+	}
+	for _, key := range []string{
+		"component:OrderRow in OrderList",
+		"component:PagerBar in OrderList",
+		"function_call:sorted in OrderList",
+		"function_call:pick in OrderList",
+		"function_call:label in OrderList",
+		"method_call:next in OrderList",
+		"method_call:track in OrderList",
+		"function_call:defineProps in OrderList",
+	} {
+		if !usage[key] {
+			t.Errorf("expected usage %s, got %+v", key, parsed.Usage)
+		}
+	}
+	for _, u := range parsed.Usage {
+		switch u.Name {
+		case "RouterLink", "Template", "Div", "order", "orders":
+			t.Errorf("unexpected usage %+v", u)
+		}
+	}
+	if len(parsed.Debt) != 1 || parsed.Debt[0].Line != 18 || parsed.Lines != 28 {
+		t.Errorf("expected the TODO on line 18 of 28, got %+v (%d lines)", parsed.Debt, parsed.Lines)
+	}
+}
+
+func TestJSParser_VueGraph(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmp, "components"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, tmp, "App.vue", `<template>
+  <user-card :user="current" @save="store" />
+</template>
+
+<script>
+import UserCard from './components/UserCard.vue'
+
+export default defineComponent({
+  components: { UserCard },
+})
+</script>
+`)
+	writeFixture(t, tmp, "components/UserCard.vue", `<template><span>{{ initials(user) }}</span></template>
+<script setup lang="ts">
+import { initials } from '../format.js'
+defineProps(['user'])
+</script>
+`)
+	writeFixture(t, tmp, "format.js", `export function initials(user) { return user.name[0] }
+
+export function unused() {}
+`)
+
+	p := NewJSParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"App.vue", "components/UserCard.vue", "format.js"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		files = append(files, parsed)
+	}
+
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+	nodes := make(map[string]*models.DependencyNode)
+	for _, node := range graph.Nodes {
+		nodes[node.Type+":"+node.Name] = node
+	}
+	if ref := nodes["class:App"].Dependencies[nodes["class:UserCard"].ID]; ref == nil || ref.Type != "component" {
+		t.Errorf("expected App to use UserCard in its template, got %+v", nodes["class:App"].Dependencies)
+	}
+	if nodes["class:UserCard"].Dependencies[nodes["function:initials"].ID] == nil {
+		t.Errorf("expected the template to call the imported function, got %+v", nodes["class:UserCard"].Dependencies)
+	}
+	orphans := make(map[string]bool)
+	for _, node := range graph.Orphans {
+		orphans[node.Name] = true
+	}
+	if !orphans["unused"] || len(orphans) != 1 {
+		t.Errorf("expected only the unused function among the orphans, got %v", orphans)
+	}
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package layout checks that PHP namespaces match their directories the way PSR-4
+// autoloading expects, and plans the fixes for those that don't: moving the file where its
+// namespace says, or changing the namespace and the use statements that name it. The plan
+// is for codemod tools to apply; nothing here changes files.
+package layout
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Mapping is a PSR-4 namespace prefix and the directory it's loaded from
+type Mapping struct {
+	Prefix string `json:"prefix"` // Without the trailing backslash; "" for the global namespace
+	Dir    string `json:"dir"`    // Relative to the root, slash-separated; "" for the root
+	Source string `json:"source"` // "composer.json" or "inferred"
+}
+
+// Plan is the fix plan for a tree
+type Plan struct {
+	Mappings []*Mapping `json:"mappings"`
+	Fixes    []*Fix     `json:"fixes"`
+}
+
+// Fix is one file whose namespace or name doesn't match its path, and how to fix it
+type Fix struct {
+	File         string       `json:"file"` // Relative to the root
+	Class        string       `json:"class"`
+	Namespace    string       `json:"namespace"` // As declared
+	Expected     string       `json:"expected"`  // What the path says it should be
+	Action       string       `json:"action"`    // "move" or "namespace"
+	MoveTo       string       `json:"moveTo,omitempty"`
+	NewNamespace string       `json:"newNamespace,omitempty"`
+	Uses         []*UseChange `json:"uses"` // Use statements to add or remove with a namespace change
+}
+
+// UseChange is a use statement to add to or remove from a file
+type UseChange struct {
+	File   string `json:"file"`           // Relative to the root
+	Line   int    `json:"line,omitempty"` // The statement to remove
+	Action string `json:"action"`         // "add" or "remove"
+	Name   string `json:"name"`           // Fully qualified
+}
+
+// Fix actions
+const (
+	ActionMove      = "move"      // The namespace falls under a mapping, so the file moves to match it
+	ActionNamespace = "namespace" // It doesn't, so the namespace changes to match the file's directory
+)
+
+// LoadComposer reads the PSR-4 mappings from root's composer.json, autoload-dev included.
+// It returns nil when there's no composer.json or it has none.
+func LoadComposer(root string) ([]*Mapping, error) {
+	data, err := os.ReadFile(filepath.Join(root, "composer.json"))
+	if err != nil {
+		return nil, nil
+	}
+	var manifest struct {
+		Autoload struct {
+			PSR4 map[string]json.RawMessage `json:"psr-4"`
+		} `json:"autoload"`
+		AutoloadDev struct {
+			PSR4 map[string]json.RawMessage `json:"psr-4"`
+		} `json:"autoload-dev"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var mappings []*Mapping
+	for _, psr4 := range []map[string]json.RawMessage{manifest.Autoload.PSR4, manifest.AutoloadDev.PSR4} {
+		for prefix, raw := range psr4 {
+			// A prefix maps to one directory or a list of them
+			var dirs []string
+			if err := json.Unmarshal(raw, &dirs); err != nil {
+				var dir string
+				if err := json.Unmarshal(raw, &dir); err != nil {
+					return nil, err
+				}
+				dirs = []string{dir}
+			}
+			for _, dir := range dirs {
+				mappings = append(mappings, &Mapping{Prefix: strings.Trim(prefix, `\`), Dir: cleanDir(dir), Source: "composer.json"})
+			}
+		}
+	}
+	sortMappings(mappings)
+	return mappings, nil
+}
+
+// Infer works out the mappings a tree follows without a composer.json, from the files
+// whose namespaces end with the directories they're in: App\Billing in src/Billing votes
+// for App → src. The most voted mappings win, one per prefix and directory.
+func Infer(root string, files []*models.ParsedFile) []*Mapping {
+	votes := make(map[Mapping]int)
+	for _, file := range files {
+		if file.Language != "php" || file.Namespace == "" {
+			continue
+		}
+		nsParts := strings.Split(file.Namespace, `\`)
+		dirParts := dirSegments(root, file.Path)
+		shared := 0
+		for shared < len(nsParts) && shared < len(dirParts) && nsParts[len(nsParts)-1-shared] == dirParts[len(dirParts)-1-shared] {
+			shared++
+		}
+		if shared == 0 {
+			continue
+		}
+		votes[Mapping{
+			Prefix: strings.Join(nsParts[:len(nsParts)-shared], `\`),
+			Dir:    strings.Join(dirParts[:len(dirParts)-shared], "/"),
+		}]++
+	}
+
+	candidates := make([]Mapping, 0, len(votes))
+	for candidate := range votes {
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if votes[a] != votes[b] {
+			return votes[a] > votes[b]
+		}
+		if a.Dir != b.Dir {
+			return a.Dir < b.Dir
+		}
+		return a.Prefix < b.Prefix
+	})
+
+	var mappings []*Mapping
+	prefixes, dirs := make(map[string]bool), make(map[string]bool)
+	for _, candidate := range candidates {
+		if prefixes[candidate.Prefix] || dirs[candidate.Dir] {
+			continue // A mismatched file's vote, outvoted
+		}
+		prefixes[candidate.Prefix], dirs[candidate.Dir] = true, true
+		mappings = append(mappings, &Mapping{Prefix: candidate.Prefix, Dir: candidate.Dir, Source: "inferred"})
+	}
+	sortMappings(mappings)
+	return mappings
+}
+
+// Check compares each PHP file declaring one class, interface, trait, or enum against the
+// mapping its directory falls under, and plans fixes for those that don't match. Files
+// outside every mapping aren't autoloaded, and are left alone.
+func Check(root string, mappings []*Mapping, files []*models.ParsedFile, graph *models.DependencyGraph) *Plan {
+	plan := &Plan{Mappings: mappings, Fixes: []*Fix{}}
+	if plan.Mappings == nil {
+		plan.Mappings = []*Mapping{}
+	}
+
+	for _, file := range files {
+		class := declaredClass(file)
+		if class == "" {
+			continue
+		}
+		rel := relative(root, file.Path)
+		mapping := mappingForDir(mappings, path.Dir(rel))
+		if mapping == nil {
+			continue
+		}
+		expected := expectedNamespace(mapping, path.Dir(rel))
+		if expected == file.Namespace && class+".php" == path.Base(rel) {
+			continue
+		}
+
+		fix := &Fix{File: rel, Class: class, Namespace: file.Namespace, Expected: expected, Uses: []*UseChange{}}
+		if target := mappingForNamespace(mappings, file.Namespace); target != nil {
+			fix.Action = ActionMove
+			fix.MoveTo = path.Join(target.Dir, strings.ReplaceAll(strings.TrimPrefix(strings.TrimPrefix(file.Namespace, target.Prefix), `\`), `\`, "/"), class+".php")
+		} else {
+			fix.Action = ActionNamespace
+			fix.NewNamespace = expected
+			if class+".php" != path.Base(rel) {
+				fix.MoveTo = path.Join(path.Dir(rel), class+".php")
+			}
+			fix.Uses = useChanges(root, file, class, expected, files, graph)
+		}
+		plan.Fixes = append(plan.Fixes, fix)
+	}
+
+	sort.Slice(plan.Fixes, func(i, j int) bool { return plan.Fixes[i].File < plan.Fixes[j].File })
+	return plan
+}
+
+// useChanges lists the use statements that change when file's namespace becomes
+// namespace: those naming its class elsewhere, those its old neighbors need now that it
+// isn't one, and in file itself, those for its old neighbors and its new ones
+func useChanges(root string, file *models.ParsedFile, class, namespace string, files []*models.ParsedFile, graph *models.DependencyGraph) []*UseChange {
+	oldName, newName := qualify(file.Namespace, class), qualify(namespace, class)
+	var changes []*UseChange
+	seen := make(map[string]bool)
+	add := func(path string, line int, action, name string) {
+		key := path + " " + action + " " + name
+		if !seen[key] {
+			seen[key] = true
+			changes = append(changes, &UseChange{File: relative(root, path), Line: line, Action: action, Name: name})
+		}
+	}
+
+	// Files that name the class
+	referencing := make(map[string]bool)
+	for _, node := range graph.Nodes {
+		if node.File != file.Path {
+			continue
+		}
+		for callerID := range node.Dependents {
+			if caller := graph.Nodes[callerID]; caller != nil && caller.File != file.Path {
+				referencing[caller.File] = true
+			}
+		}
+	}
+	for _, other := range files {
+		if other.Path == file.Path {
+			continue
+		}
+		switch {
+		case hasUse(other, oldName):
+			add(other.Path, useLine(other.Path, oldName), "remove", oldName)
+			if other.Namespace != namespace {
+				add(other.Path, 0, "add", newName)
+			}
+		case referencing[other.Path] && other.Namespace == file.Namespace:
+			add(other.Path, 0, "add", newName) // Resolved as a neighbor until now
+		}
+	}
+
+	// The file's own references to its old neighbors, and uses of its new ones
+	for _, node := range graph.Nodes {
+		if node.File != file.Path {
+			continue
+		}
+		for targetID := range node.Dependencies {
+			target := graph.Nodes[targetID]
+			if target == nil || target.File == file.Path || target.Namespace != file.Namespace || file.Namespace == namespace {
+				continue
+			}
+			name := target.Name
+			if target.ClassName != "" {
+				name = target.ClassName
+			}
+			if neighbor := qualify(target.Namespace, name); !hasUse(file, neighbor) {
+				add(file.Path, 0, "add", neighbor)
+			}
+		}
+	}
+	for _, use := range file.Uses {
+		name := strings.TrimPrefix(use, `\`)
+		if idx := strings.LastIndex(name, `\`); idx != -1 && name[:idx] == namespace {
+			add(file.Path, useLine(file.Path, name), "remove", name)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].File != changes[j].File {
+			return changes[i].File < changes[j].File
+		}
+		return changes[i].Action > changes[j].Action // Removals first
+	})
+	return changes
+}
+
+// declaredClass returns the one class, interface, trait, or enum file declares, or "" when
+// it declares none or several, which PSR-4 can't load anyway
+func declaredClass(file *models.ParsedFile) string {
+	if file.Language != "php" {
+		return ""
+	}
+	class := ""
+	for _, element := range file.Elements {
+		switch element.Type {
+		case "class", "interface", "trait", "enum":
+			if class != "" {
+				return ""
+			}
+			class = element.Name
+		}
+	}
+	return class
+}
+
+// mappingForDir returns the mapping with the longest directory containing dir
+func mappingForDir(mappings []*Mapping, dir string) *Mapping {
+	var best *Mapping
+	for _, mapping := range mappings {
+		if mapping.Dir == "" || dir == mapping.Dir || strings.HasPrefix(dir, mapping.Dir+"/") {
+			if best == nil || len(mapping.Dir) > len(best.Dir) {
+				best = mapping
+			}
+		}
+	}
+	return best
+}
+
+// mappingForNamespace returns the mapping with the longest prefix containing namespace
+func mappingForNamespace(mappings []*Mapping, namespace string) *Mapping {
+	var best *Mapping
+	for _, mapping := range mappings {
+		if mapping.Prefix == "" || namespace == mapping.Prefix || strings.HasPrefix(namespace, mapping.Prefix+`\`) {
+			if best == nil || len(mapping.Prefix) > len(best.Prefix) {
+				best = mapping
+			}
+		}
+	}
+	return best
+}
+
+// expectedNamespace is the namespace of the files in dir, under mapping
+func expectedNamespace(mapping *Mapping, dir string) string {
+	rest := strings.Trim(strings.TrimPrefix(dir, mapping.Dir), "/")
+	if dir == "." {
+		rest = ""
+	}
+	if rest == "" {
+		return mapping.Prefix
+	}
+	return qualify(mapping.Prefix, strings.ReplaceAll(rest, "/", `\`))
+}
+
+func hasUse(file *models.ParsedFile, name string) bool {
+	for _, use := range file.Uses {
+		if strings.TrimPrefix(use, `\`) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// useLine returns the line of path's use statement for name, or 0
+func useLine(path, name string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "use ") && strings.Contains(text, name) {
+			return line
+		}
+	}
+	return 0
+}
+
+func qualify(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + `\` + name
+}
+
+// dirSegments returns the directories of path below root
+func dirSegments(root, path string) []string {
+	dir := filepath.Dir(relative(root, path))
+	if dir == "." {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
+
+func relative(root, path string) string {
+	abs, _ := filepath.Abs(path)
+	rootAbs, _ := filepath.Abs(root)
+	if rel, err := filepath.Rel(rootAbs, abs); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+func cleanDir(dir string) string {
+	dir = strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/")
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+func sortMappings(mappings []*Mapping) {
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].Prefix != mappings[j].Prefix {
+			return mappings[i].Prefix < mappings[j].Prefix
+		}
+		return mappings[i].Dir < mappings[j].Dir
+	})
+}
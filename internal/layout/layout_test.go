@@ -0,0 +1,104 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/lang"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func write(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func parse(t *testing.T, root string, names ...string) ([]*models.ParsedFile, *models.DependencyGraph) {
+	t.Helper()
+	var files []*models.ParsedFile
+	for _, name := range names {
+		parsed, err := lang.NewPHPParser().ParseFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, parsed)
+	}
+	return files, analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+}
+
+func TestLoadComposer(t *testing.T) {
+	tmp := t.TempDir()
+	write(t, tmp, "composer.json", `{
+  "autoload": {"psr-4": {"App\\": "src/", "Lib\\": ["lib/", "legacy"]}},
+  "autoload-dev": {"psr-4": {"Tests\\": "tests/"}}
+}`)
+	mappings, err := LoadComposer(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Mapping{{"App", "src", "composer.json"}, {"Lib", "legacy", "composer.json"}, {"Lib", "lib", "composer.json"}, {"Tests", "tests", "composer.json"}}
+	if len(mappings) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, mappings)
+	}
+	for i, mapping := range mappings {
+		if *mapping != want[i] {
+			t.Errorf("expected %v, got %v", want[i], *mapping)
+		}
+	}
+
+	if mappings, err := LoadComposer(t.TempDir()); mappings != nil || err != nil {
+		t.Errorf("expected nothing without a composer.json, got %v (%v)", mappings, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tmp := t.TempDir()
+	write(t, tmp, "src/Billing/Invoice.php", "<?php\nnamespace App\\Billing;\n\nclass Invoice {\n    public function tax() { return new Rate(); }\n}\n")
+	write(t, tmp, "src/Billing/Rate.php", "<?php\nnamespace App\\Billing;\n\nclass Rate {}\n")
+	write(t, tmp, "src/Billing/Payment.php", "<?php\nnamespace App\\Billing;\n\nuse App\\Orders\\Refund;\n\nclass Payment {\n    public function refund() { return new Refund(); }\n}\n")
+	write(t, tmp, "src/Models/Order.php", "<?php\nnamespace App\\Orders;\n\nclass Order {}\n")
+	write(t, tmp, "src/Orders/Refund.php", "<?php\nnamespace Legacy;\n\nuse App\\Orders\\Order;\n\nclass Refund {\n    public function pay() { return new Ledger(); }\n}\n")
+	write(t, tmp, "lib/Ledger.php", "<?php\nnamespace Legacy;\n\nclass Ledger {}\n") // Outside the mapping
+	write(t, tmp, "src/Http/Controller.php", "<?php\nnamespace App\\Http;\n\nuse App\\Orders\\Refund;\nuse Legacy\\Refund as OldRefund;\n\nclass Controller {}\n")
+	files, graph := parse(t, tmp, "src/Billing/Invoice.php", "src/Billing/Rate.php", "src/Billing/Payment.php", "src/Models/Order.php", "src/Orders/Refund.php", "src/Http/Controller.php", "lib/Ledger.php")
+
+	mappings := Infer(tmp, files)
+	if len(mappings) != 1 || mappings[0].Prefix != "App" || mappings[0].Dir != "src" {
+		t.Fatalf("expected App → src to be inferred, got %+v", mappings)
+	}
+
+	plan := Check(tmp, mappings, files, graph)
+	if len(plan.Fixes) != 2 {
+		t.Fatalf("expected 2 fixes, got %+v", plan.Fixes)
+	}
+	order, refund := plan.Fixes[0], plan.Fixes[1]
+	if order.File != "src/Models/Order.php" || order.Action != ActionMove || order.MoveTo != "src/Orders/Order.php" || order.Expected != `App\Models` {
+		t.Errorf("expected Order to move where its namespace says, got %+v", order)
+	}
+	if refund.Action != ActionNamespace || refund.NewNamespace != `App\Orders` || refund.MoveTo != "" {
+		t.Errorf("expected Refund's unmapped namespace to change, got %+v", refund)
+	}
+
+	changes := make(map[string]*UseChange)
+	for _, change := range refund.Uses {
+		changes[change.File+" "+change.Action+" "+change.Name] = change
+	}
+	for key, line := range map[string]int{
+		`src/Http/Controller.php remove Legacy\Refund`:  5,
+		`src/Http/Controller.php add App\Orders\Refund`: 0,
+		`src/Orders/Refund.php add Legacy\Ledger`:       0,
+		`src/Orders/Refund.php remove App\Orders\Order`: 4,
+	} {
+		if change := changes[key]; change == nil || change.Line != line {
+			t.Errorf("expected %s (line %d), got %+v", key, line, change)
+		}
+	}
+}
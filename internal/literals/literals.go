@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package literals inventories the string literals and numbers written more than once
+// across a codebase ("magic" values), to guide their extraction into constants or
+// configuration
+package literals
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/clones"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// DefaultMinCount is the fewest occurrences for a value to be reported
+const DefaultMinCount = 3
+
+// trivialNumbers are too common to be worth naming
+var trivialNumbers = map[string]bool{"0": true, "1": true, "0.0": true, "1.0": true}
+
+// Detect reads files, lexes them as language, and reports the strings and numbers that
+// appear at least minCount times. Files that can't be read are skipped.
+func Detect(language string, files []string, minCount int) *models.LiteralReport {
+	if minCount <= 0 {
+		minCount = DefaultMinCount
+	}
+
+	strs := make(map[string]*models.Literal)
+	nums := make(map[string]*models.Literal)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		tokens := clones.Tokenize(language, string(src))
+		for i, token := range tokens {
+			if named(tokens, i) {
+				continue
+			}
+			switch token.Norm {
+			case clones.StringToken:
+				if value, ok := stringValue(language, token.Text); ok {
+					record(strs, value, file, token.Line)
+				}
+			case clones.NumberToken:
+				if !trivialNumbers[token.Text] {
+					record(nums, token.Text, file, token.Line)
+				}
+			}
+		}
+	}
+
+	return &models.LiteralReport{
+		MinCount: minCount,
+		Strings:  repeated(strs, minCount),
+		Numbers:  repeated(nums, minCount),
+	}
+}
+
+func record(values map[string]*models.Literal, value, file string, line int) {
+	literal := values[value]
+	if literal == nil {
+		literal = &models.Literal{Value: value}
+		values[value] = literal
+	}
+	literal.Count++
+	literal.Locations = append(literal.Locations, &models.LiteralLocation{File: file, Line: line})
+}
+
+// repeated returns the values seen at least minCount times, most repeated first
+func repeated(values map[string]*models.Literal, minCount int) []*models.Literal {
+	literals := []*models.Literal{}
+	for _, literal := range values {
+		if literal.Count < minCount {
+			continue
+		}
+		files := make(map[string]bool)
+		for _, loc := range literal.Locations {
+			files[loc.File] = true
+		}
+		literal.Files = len(files)
+		literals = append(literals, literal)
+	}
+	sort.Slice(literals, func(i, j int) bool {
+		a, b := literals[i], literals[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.Value < b.Value
+	})
+	return literals
+}
+
+// named reports whether the literal at i already has a name: the value of a constant
+// ("const NAME = 5", "const int NAME = 5") or either argument of PHP's define()
+func named(tokens []clones.Token, i int) bool {
+	at := func(j int) string {
+		if j < 0 {
+			return ""
+		}
+		return tokens[j].Text
+	}
+	keyword := func(j int) bool {
+		return j >= 0 && tokens[j].Norm == "const"
+	}
+	if at(i-1) == "=" && (keyword(i-3) || keyword(i-4)) {
+		return true
+	}
+	if strings.EqualFold(at(i-2), "define") && at(i-1) == "(" {
+		return true
+	}
+	return strings.EqualFold(at(i-4), "define") && at(i-3) == "(" && at(i-1) == ","
+}
+
+// stringValue strips the quotes from a string literal. It returns false for strings
+// too short to be worth naming and for strings with interpolated variables, whose value
+// changes at runtime.
+func stringValue(language, text string) (string, bool) {
+	if len(text) < 2 || text[len(text)-1] != text[0] {
+		return "", false // Unterminated
+	}
+	quote, value := text[0], text[1:len(text)-1]
+	if len(value) < 2 {
+		return "", false
+	}
+	switch {
+	case language == "php" && quote == '"' && strings.Contains(value, "$"):
+		return "", false
+	case quote == '`' && strings.Contains(value, "${"):
+		return "", false
+	}
+	return value, true
+}
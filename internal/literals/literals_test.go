@@ -0,0 +1,86 @@
+package literals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	orders := write("orders.php", `<?php
+const STATUS_PAID = 'paid';
+define('MAX_ITEMS', 250);
+function ship($order) {
+    if ($order->status === 'paid' && $order->weight > 250) {
+        return "Hello $order->name"; // Interpolated, never the same value
+    }
+    return $order->total * 1.2 + 0;
+}
+`)
+	invoices := write("invoices.php", `<?php
+function invoice($invoice) {
+    $invoice->status = 'paid';
+    $invoice->tax = $invoice->net * 1.2;
+    log("paid", 250, 'x', "Hello $invoice->name", "Hello $invoice->name", "Hello $invoice->name");
+}
+`)
+
+	report := Detect("php", []string{orders, invoices, filepath.Join(dir, "missing.php")}, 2)
+	if report.MinCount != 2 {
+		t.Errorf("expected the minimum count to be recorded, got %d", report.MinCount)
+	}
+	if len(report.Strings) != 1 {
+		t.Fatalf("expected only \"paid\" to repeat, got %+v", report.Strings)
+	}
+	paid := report.Strings[0]
+	if paid.Value != "paid" || paid.Count != 3 || paid.Files != 2 || len(paid.Locations) != 3 {
+		t.Errorf("unexpected string literal %+v", paid)
+	}
+	if loc := paid.Locations[0]; loc.File != orders || loc.Line != 5 {
+		t.Errorf("expected the constant's own value to be skipped, got first location %+v", loc)
+	}
+
+	if len(report.Numbers) != 2 {
+		t.Fatalf("expected 250 and 1.2, got %+v", report.Numbers)
+	}
+	if n := report.Numbers[0]; n.Value != "1.2" || n.Count != 2 {
+		t.Errorf("unexpected first number %+v", n)
+	}
+	if n := report.Numbers[1]; n.Value != "250" || n.Count != 2 {
+		t.Errorf("expected define()'s value to be skipped, got %+v", n)
+	}
+
+	if report := Detect("php", []string{orders, invoices}, 0); report.MinCount != DefaultMinCount || len(report.Numbers) != 0 {
+		t.Errorf("expected the default minimum of %d, got %+v", DefaultMinCount, report)
+	}
+}
+
+func TestStringValue(t *testing.T) {
+	tests := []struct {
+		language, text, want string
+		ok                   bool
+	}{
+		{"php", `'paid'`, "paid", true},
+		{"php", `"paid"`, "paid", true},
+		{"php", `'$5'`, "$5", true},
+		{"php", `"Hi $name"`, "", false},
+		{"php", `'x'`, "", false},
+		{"php", `'unterminated`, "", false},
+		{"javascript", "`a ${b}`", "", false},
+		{"javascript", `"$ref"`, "$ref", true},
+	}
+	for _, tt := range tests {
+		got, ok := stringValue(tt.language, tt.text)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("stringValue(%q, %q) = %q, %v; want %q, %v", tt.language, tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -12,6 +12,7 @@ type FileInfo struct {
 	Path         string
 	RelativePath string
 	Size         int64
+	Language     string // Parser that recognized an extensionless file by its content; "" otherwise
 }
 
 // CodeElement represents any parseable element in PHP code
@@ -23,19 +24,58 @@ type CodeElement struct {
 	Visibility string   // "public", "private", "protected"
 	IsStatic   bool     // For methods and properties
 	IsAbstract bool     // For classes and methods
+	IsReadonly bool     // For readonly classes and properties
 	Line       int      // Line number where defined
 	File       string   // File path
 	Parameters []string // For functions/methods
+	ParamTypes []string // Type hints parallel to Parameters ("" when untyped)
 	ReturnType string   // Return type hint (if any)
+	Documented bool     // Preceded by a docblock (/** ... */)
 }
 
 // ParsedFile contains all elements found in a PHP file
 type ParsedFile struct {
-	Path      string
-	Namespace string
-	Uses      []string       // Import statements
-	Elements  []CodeElement  // All defined elements
-	Usage     []UsageElement // References to other elements
+	Path             string
+	Language         string // Parser language, e.g. "php" or "javascript"
+	Namespace        string
+	Uses             []string          // Import statements
+	Elements         []CodeElement     // All defined elements
+	Usage            []UsageElement    // References to other elements
+	TraitAdaptations []TraitAdaptation // insteadof/as rules from trait use blocks
+	Imports          []ImportBinding   // JS/TS import and require() bindings
+	Exports          []ExportBinding   // JS/TS exported names
+	ModuleSystem     string            // JS/TS: "esm", "commonjs", or "mixed" ("" when neither is used)
+	CommonJSFeatures []string          // JS/TS: CommonJS-only constructs used ("require", "module.exports", "__dirname")
+	Includes         []Include         // C/C++ #include directives, and Dart's import, export, and part
+	Debt             []DebtMarker      // TODO, FIXME, and HACK comments
+	Tables           []TableReference  // Database tables named in SQL, models, and migrations
+	Syntax           []SyntaxUse       // Constructs newer than the targeted language version
+	Lines            int               // Lines in the file
+	CommentLines     int               // Lines holding only a comment
+}
+
+// DebtMarker is a TODO, FIXME, or HACK comment
+type DebtMarker struct {
+	Tag    string // "TODO", "FIXME", or "HACK"
+	Text   string // The rest of the comment
+	Author string // From "TODO(name):", if given
+	Line   int
+}
+
+// SyntaxUse is a construct a file uses that's newer than the targeted language version
+type SyntaxUse struct {
+	Feature string // e.g. "nullsafe operator"
+	Version string // The version that introduced it, e.g. "8.0"
+	Line    int
+}
+
+// TableReference is a database table named in code
+type TableReference struct {
+	Table     string
+	Kind      string // "sql", "model" (protected $table), "query" (DB::table), or "migration" (Schema::)
+	ClassName string // Enclosing class; "" outside classes
+	Function  string // Enclosing function or method
+	Line      int
 }
 
 // UsageElement represents usage of external code elements
@@ -43,10 +83,55 @@ type UsageElement struct {
 	Type     string // "class", "function", "method", "property"
 	Name     string
 	Context  string // Where it's used (function name, class name, etc.)
+	Receiver string // Object or class a member is accessed on ("$this", "self", "User")
+	Callback string // Callback attached to a hook ("my_func", "$this->init", "Plugin::boot")
 	Line     int
 	IsStatic bool
 }
 
+// TraitAdaptation is a conflict-resolution or aliasing rule inside a trait use block,
+// e.g. "A::hello insteadof B;" or "B::hello as protected greet;"
+type TraitAdaptation struct {
+	ClassName  string   // Class composing the traits
+	Trait      string   // Trait the method is taken from ("" when unqualified)
+	Method     string   // Method being adapted
+	InsteadOf  []string // Traits whose method is excluded in favour of Trait
+	Alias      string   // New method name (for "as")
+	Visibility string   // Visibility override (for "as")
+	Line       int
+}
+
+// ImportBinding is a name bound by a JS/TS import or require() call,
+// e.g. "import { format as fmt } from './utils'"
+type ImportBinding struct {
+	Local    string // Name bound in the importing file ("" for side-effect imports)
+	Imported string // Exported name it refers to ("default", or "*" for the whole module)
+	Source   string // Module specifier as written ("./utils", "lodash")
+	Resolved string // File the specifier resolves to ("" for external or unresolvable modules)
+	Kind     string // "import", "require", or "dynamic"
+	Line     int
+}
+
+// ExportBinding is a name exported by a JS/TS module, e.g. "export { format as fmt }"
+// or a re-export such as "export * from './format'"
+type ExportBinding struct {
+	Name     string // Exported name ("default" for default exports and module.exports, "*" for export *)
+	Local    string // Element the export refers to (its name in Source for re-exports, "*" for namespaces)
+	Kind     string // "export" (ES module syntax) or "commonjs" (module.exports/exports.x)
+	Source   string // Module specifier re-exported from ("" for local exports)
+	Resolved string // File Source resolves to ("" for local exports and external modules)
+	Line     int
+}
+
+// Include is a C/C++ #include directive, e.g. `#include "models/user.h"`, or a Dart
+// directive naming another file, e.g. `import 'models/user.dart';`
+type Include struct {
+	Path     string // Header as written ("models/user.h", "vector")
+	System   bool   // Written <angle-bracketed>, or a dart: library or another package's
+	Resolved string // File the header resolves to ("" for the standard library's and unresolvable headers)
+	Line     int
+}
+
 // DependencyNode represents a node in the dependency tree
 type DependencyNode struct {
 	ID           string                    `json:"id"`
@@ -59,6 +144,14 @@ type DependencyNode struct {
 	Dependencies map[string]*DependencyRef `json:"dependencies"`
 	Dependents   map[string]*DependencyRef `json:"dependents"`
 	Score        int                       `json:"score"`
+	IsEntrypoint bool                      `json:"entrypoint,omitempty"` // Invoked by a framework, so never orphaned
+	Package      string                    `json:"package,omitempty"`    // Owning monorepo package
+	Group        string                    `json:"group,omitempty"`      // Config-defined virtual group
+	Owners       []string                  `json:"owners,omitempty"`     // CODEOWNERS owners of the node's file
+	Coverage     *float64                  `json:"coverage,omitempty"`   // Percent of its executable lines the tests ran
+	Language     string                    `json:"language,omitempty"`   // Language of the file that defines it
+	Suppressed   []string                  `json:"suppressed,omitempty"` // Findings suppressed on the node, e.g. "orphans"
+	Notes        []Note                    `json:"notes,omitempty"`      // Notes from the notes file
 }
 
 // DependencyRef represents a reference between nodes
@@ -68,6 +161,8 @@ type DependencyRef struct {
 	Type       string `json:"type"` // "uses", "extends", "implements", "calls", "instantiates"
 	Count      int    `json:"count"`
 	Lines      []int  `json:"lines"`
+	Sampled    bool   `json:"sampled,omitempty"` // Lines is a random sample of Count call sites
+	Runtime    int    `json:"runtime,omitempty"` // Calls observed in runtime traces
 	Context    string `json:"context"`
 }
 
@@ -79,9 +174,661 @@ type DependencyGraph struct {
 	Orphans        []*DependencyNode          `json:"orphans"`
 	HighlyDepended []*DependencyNode          `json:"highlyDepended"`
 	ComplexNodes   []*DependencyNode          `json:"complexNodes"`
+	AmbiguousNames []*AmbiguousName           `json:"ambiguousNames"`
+	ModuleInterop  *ModuleInterop             `json:"moduleInterop,omitempty"`
+	Includes       *IncludeGraph              `json:"includes,omitempty"`
+	Packages       *PackageReport             `json:"packages,omitempty"`
+	Groups         *GroupReport               `json:"groups,omitempty"`
+	Ownership      *OwnershipReport           `json:"ownership,omitempty"`
+	Coverage       *CoverageReport            `json:"coverage,omitempty"`
+	Runtime        *RuntimeReport             `json:"runtime,omitempty"`
+	LongParameters *ParameterReport           `json:"longParameters,omitempty"`
+	Bridges        *BridgeReport              `json:"bridges,omitempty"`
+	Pruned         *PruneReport               `json:"pruned,omitempty"`
+	Scope          *ChangeScope               `json:"scope,omitempty"`
 	mu             sync.RWMutex
 }
 
+// AmbiguousName records a short name declared in more than one namespace.
+// Usages of such a name can't be resolved without a fully-qualified reference.
+type AmbiguousName struct {
+	Name             string   `json:"name"`
+	Candidates       []string `json:"candidates"` // Fully-qualified names sharing the short name
+	UnresolvedUsages int      `json:"unresolvedUsages"`
+}
+
+// ModuleInterop summarizes how JS/TS files mix ES modules and CommonJS
+type ModuleInterop struct {
+	ESMFiles          int                 `json:"esmFiles"`
+	CommonJSFiles     int                 `json:"commonjsFiles"`
+	MixedFiles        int                 `json:"mixedFiles"`
+	Boundaries        []*ModuleBoundary   `json:"boundaries"`        // Imports that cross module systems
+	MigrationBlockers []*MigrationBlocker `json:"migrationBlockers"` // Files still relying on CommonJS
+}
+
+// IncludeGraph is the file-level graph of C/C++ #include directives and Dart imports
+type IncludeGraph struct {
+	Files      int               `json:"files"`      // Files with an include, or included
+	Edges      []*IncludeEdge    `json:"edges"`      // Includes of analyzed files
+	System     int               `json:"system"`     // <angle-bracketed> includes of headers outside the project
+	Unresolved []*IncludeEdge    `json:"unresolved"` // "Quoted" includes of headers that weren't found
+	Headers    []*IncludedHeader `json:"headers"`    // Included headers, those that reach the most files first
+	Unused     []string          `json:"unused"`     // Headers no analyzed file includes
+	Cycles     [][]string        `json:"cycles"`     // Files that include each other, directly or not
+}
+
+// IncludeEdge is an #include of one file in another
+type IncludeEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to,omitempty"` // "" when unresolved
+	Header string `json:"header"`       // As written
+	Line   int    `json:"line"`
+}
+
+// IncludedHeader is a header and the files that include it. Reach counts the files that
+// include it through other headers too, which are recompiled when it changes.
+type IncludedHeader struct {
+	File      string `json:"file"`
+	Includers int    `json:"includers"`
+	Reach     int    `json:"reach"`
+}
+
+// ModuleBoundary is an import from a file in one module system into a file in another,
+// e.g. an ES module importing a CommonJS file, or require() of an ES module
+type ModuleBoundary struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	FromSystem string `json:"fromSystem"`
+	ToSystem   string `json:"toSystem"`
+	Kind       string `json:"kind"` // "import", "require", or "dynamic"
+	Line       int    `json:"line"`
+}
+
+// MigrationBlocker is a file that has to change before a project can move to pure ESM
+type MigrationBlocker struct {
+	File      string   `json:"file"`
+	System    string   `json:"system"`    // "commonjs" or "mixed"
+	Features  []string `json:"features"`  // CommonJS constructs to replace
+	Importers int      `json:"importers"` // Files importing it, i.e. affected by its migration
+}
+
+// PackageReport summarizes dependencies between the packages of a monorepo
+type PackageReport struct {
+	Manager      string               `json:"manager"` // "npm", "pnpm", or "composer"
+	Packages     []*PackageSummary    `json:"packages"`
+	Dependencies []*PackageDependency `json:"dependencies"` // Cross-package dependency matrix
+	Violations   []*PackageDependency `json:"violations"`   // Dependencies missing from the package manifest
+}
+
+// PackageSummary is one workspace package and the number of nodes it contains
+type PackageSummary struct {
+	Name  string `json:"name"`
+	Dir   string `json:"dir"`
+	Kind  string `json:"kind"` // "npm" or "composer"
+	Nodes int    `json:"nodes"`
+}
+
+// PackageDependency counts the edges from nodes in one package to nodes in another
+type PackageDependency struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Edges    int    `json:"edges"`
+	Declared bool   `json:"declared"`          // To is listed in From's manifest
+	Example  string `json:"example,omitempty"` // First offending edge, e.g. "render -> Button"
+}
+
+// GroupReport summarizes the virtual groups defined in config: sets of nodes matched by
+// name or path patterns, cutting across namespaces and directories
+type GroupReport struct {
+	Groups       []*GroupSummary    `json:"groups"`
+	Dependencies []*GroupDependency `json:"dependencies"` // Cross-group dependency matrix
+	Ungrouped    int                `json:"ungrouped"`    // Nodes matching no group
+}
+
+// GroupSummary is one virtual group and its aggregate metrics
+type GroupSummary struct {
+	Name        string  `json:"name"`
+	Nodes       int     `json:"nodes"`
+	Files       int     `json:"files"`
+	Complexity  int     `json:"complexity"`  // Sum of the nodes' complexity scores
+	Internal    int     `json:"internal"`    // Usages between nodes of the group
+	Outgoing    int     `json:"outgoing"`    // Usages of other groups' nodes
+	Incoming    int     `json:"incoming"`    // Usages by other groups' nodes
+	Instability float64 `json:"instability"` // Outgoing / (Outgoing + Incoming); 0 when isolated
+}
+
+// GroupDependency counts the edges from nodes in one group to nodes in another
+type GroupDependency struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Edges   int    `json:"edges"`             // Distinct node-to-node dependencies
+	Usages  int    `json:"usages"`            // Sum of the edges' counts
+	Example string `json:"example,omitempty"` // One of the edges, e.g. "charge -> Invoice"
+}
+
+// OwnershipReport attributes nodes to their CODEOWNERS owners (usually teams) and
+// measures how much each owner's code depends on the others'
+type OwnershipReport struct {
+	Source       string             `json:"source"` // The CODEOWNERS file
+	Owners       []*OwnerSummary    `json:"owners"`
+	Dependencies []*OwnerDependency `json:"dependencies"` // Cross-owner dependency matrix
+	Unowned      int                `json:"unowned"`      // Nodes in files no rule assigns an owner
+}
+
+// OwnerSummary is one owner's code and its dependencies. A node with several owners
+// counts for each of them.
+type OwnerSummary struct {
+	Name      string        `json:"name"`
+	Nodes     int           `json:"nodes"`
+	Files     int           `json:"files"`
+	Internal  int           `json:"internal"`  // Usages within the owner's code
+	Outgoing  int           `json:"outgoing"`  // Usages of other owners' code
+	Incoming  int           `json:"incoming"`  // Usages by other owners' code
+	DependsOn []*OwnerUsage `json:"dependsOn"` // Owners whose code this one uses, most used first
+}
+
+// OwnerUsage is how much one owner uses another's code
+type OwnerUsage struct {
+	Owner  string `json:"owner"`
+	Usages int    `json:"usages"`
+	Edges  int    `json:"edges"`
+}
+
+// OwnerDependency counts the edges from one owner's code to another's
+type OwnerDependency struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Edges   int    `json:"edges"`
+	Usages  int    `json:"usages"`
+	Example string `json:"example,omitempty"` // One of the edges, e.g. "charge -> Invoice"
+}
+
+// CoverageReport overlays test coverage on the graph: the heavily depended-on code no test
+// runs, and the code that static analysis sees used but that never ran
+type CoverageReport struct {
+	Sources        []string        `json:"sources"`        // The coverage files
+	Files          int             `json:"files"`          // Analyzed files the coverage covers
+	Unmatched      int             `json:"unmatched"`      // Covered files matching no analyzed file
+	Lines          int             `json:"lines"`          // Executable lines in the covered files
+	CoveredLines   int             `json:"coveredLines"`   // Of those, the lines that ran
+	Percent        float64         `json:"percent"`        // CoveredLines / Lines
+	Nodes          int             `json:"nodes"`          // Nodes with executable lines
+	CoveredNodes   int             `json:"coveredNodes"`   // Of those, the nodes with a line that ran
+	Uncovered      []*CoverageNode `json:"uncovered"`      // Never-run nodes with several dependents, most depended first
+	DeadInPractice []*CoverageNode `json:"deadInPractice"` // Never-run nodes whose dependents never ran either
+}
+
+// CoverageNode is a node in the coverage report
+type CoverageNode struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Dependents int    `json:"dependents"`
+}
+
+// RuntimeReport reconciles runtime traces with the graph: how many of the calls observed
+// the static analysis found, and the ones it missed
+type RuntimeReport struct {
+	Sources    []string       `json:"sources"`    // The trace files
+	Calls      int            `json:"calls"`      // Distinct caller → callee pairs in the traces
+	Resolved   int            `json:"resolved"`   // Of those, the pairs whose functions both matched nodes
+	Unresolved int            `json:"unresolved"` // The pairs calling or called by code outside the graph
+	Confirmed  int            `json:"confirmed"`  // Resolved pairs the graph has an edge for
+	Missed     []*RuntimeEdge `json:"missed"`     // Resolved pairs it doesn't, most called first
+}
+
+// RuntimeEdge is a call observed at runtime that the graph has no edge for
+type RuntimeEdge struct {
+	From     string `json:"from"`
+	FromName string `json:"fromName"`
+	To       string `json:"to"`
+	ToName   string `json:"toName"`
+	File     string `json:"file"` // Where the callee is declared
+	Line     int    `json:"line"`
+	Calls    int    `json:"calls"`
+	Orphan   bool   `json:"orphan,omitempty"` // The callee has no static dependents, so it was reported as orphaned
+}
+
+// ParameterReport lists the functions and methods with more parameters than the limit,
+// and the parameters that keep appearing together in them: candidates for a parameter
+// object
+type ParameterReport struct {
+	Max       int                  `json:"max"`
+	Functions []*LongParameterList `json:"functions"` // Most parameters first
+	Clumps    []*ParameterClump    `json:"clumps"`    // Shared by the most functions first
+}
+
+// LongParameterList is a function or method over the parameter limit, with its callers
+type LongParameterList struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"` // Function name, or Class::method
+	File       string      `json:"file"`
+	Line       int         `json:"line"`
+	Parameters []string    `json:"parameters"`
+	Calls      int         `json:"calls"` // Usages over all call sites
+	CallSites  []*CallSite `json:"callSites"`
+}
+
+// CallSite is where one caller uses a function
+type CallSite struct {
+	Caller string `json:"caller"` // Node ID of the caller
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Count  int    `json:"count"`
+	Lines  []int  `json:"lines"` // Empty with --summary-only
+}
+
+// ParameterClump is a set of parameter names that several long parameter lists share
+type ParameterClump struct {
+	Parameters []string `json:"parameters"`
+	Functions  []string `json:"functions"`
+}
+
+// BridgeReport lists the references from code in one language into another: scripts
+// loaded from server-side code, and HTTP requests made by scripts to server routes
+type BridgeReport struct {
+	Routes    int       `json:"routes"`    // HTTP routes defined
+	Bridges   []*Bridge `json:"bridges"`   // References that resolved
+	Unmatched []*Bridge `json:"unmatched"` // Requests no route serves and scripts not found
+}
+
+// Bridge is one cross-language reference
+type Bridge struct {
+	Kind       string `json:"kind"`           // "asset" or "request"
+	Target     string `json:"target"`         // Asset path, or "METHOD /path", as written
+	From       string `json:"from,omitempty"` // Node making the reference; empty at file level
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Language   string `json:"language"`
+	To         string `json:"to,omitempty"` // Asset or route node
+	ToLanguage string `json:"toLanguage,omitempty"`
+	Handler    string `json:"handler,omitempty"` // Node that serves the route
+}
+
+// TableReport maps database tables to the code that touches them, and code to its
+// tables, so the reach of a schema change is known before making it
+type TableReport struct {
+	Tables  []*DatabaseTable    `json:"tables"`  // By name
+	Classes map[string][]string `json:"classes"` // Class (or file, outside classes) to the tables it names
+}
+
+// DatabaseTable is one table and every reference to it
+type DatabaseTable struct {
+	Name       string      `json:"name"`
+	Models     []string    `json:"models,omitempty"`     // Classes mapped to it with protected $table
+	Migrations []string    `json:"migrations,omitempty"` // Files creating, altering, or dropping it
+	Classes    []string    `json:"classes"`              // Classes (or files) naming it, sorted
+	References []*TableUse `json:"references"`           // By file and line
+}
+
+// TableUse is one place a table is named
+type TableUse struct {
+	Kind     string `json:"kind"`  // "sql", "model", "query", or "migration"
+	Owner    string `json:"owner"` // Qualified class; the file outside classes
+	Function string `json:"function,omitempty"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// SyntaxReport lists the constructs the code uses that are newer than the language
+// version it targets, by feature
+type SyntaxReport struct {
+	Language string           `json:"language"`
+	Target   string           `json:"target"`
+	Source   string           `json:"source"`             // Where the target came from: "configured", "composer.json", "package.json"
+	Required string           `json:"required,omitempty"` // The version the newest construct needs; "" when none is newer
+	Features []*SyntaxFeature `json:"features"`           // By version, then name
+}
+
+// SyntaxFeature is one construct and the places it's used
+type SyntaxFeature struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"` // The version that introduced it
+	Uses    []*SyntaxLocation `json:"uses"`    // By file and line
+}
+
+// SyntaxLocation is one use of a construct
+type SyntaxLocation struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// PlatformReport cross-references the PHP extensions the code calls into with the ext-*
+// platform requirements in composer.json
+type PlatformReport struct {
+	Declared   []string             `json:"declared"`   // Required extensions, and those a required polyfill provides
+	Used       []string             `json:"used"`       // Extensions the code calls into, sorted
+	Undeclared []*PlatformExtension `json:"undeclared"` // Used without a requirement, by name
+}
+
+// PlatformExtension is an extension the code uses and where
+type PlatformExtension struct {
+	Name    string         `json:"name"`    // As required, without "ext-": "curl"
+	Symbols []string       `json:"symbols"` // Its functions and classes the code uses, sorted
+	Uses    []*PlatformUse `json:"uses"`    // By file and line
+}
+
+// PlatformUse is one call into an extension
+type PlatformUse struct {
+	Symbol string `json:"symbol"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+}
+
+// SuppressionReport lists the suppressions in effect, and what each one hides, so they
+// can be reviewed instead of accumulating unnoticed
+type SuppressionReport struct {
+	Suppressions []*Suppression `json:"suppressions"` // By source, file, and line
+	Suppressed   int            `json:"suppressed"`   // Node findings hidden
+	Unused       int            `json:"unused"`       // Suppressions that matched nothing
+}
+
+// Suppression is an inline "tukey:ignore" annotation or a suppression file entry
+type Suppression struct {
+	Findings []string `json:"findings"` // Metric names, e.g. "orphans"; empty for every finding
+	File     string   `json:"file"`     // The annotated source file, or the suppression file
+	Line     int      `json:"line"`
+	Pattern  string   `json:"pattern,omitempty"` // Suppression file entries: qualified names or paths
+	Reason   string   `json:"reason,omitempty"`
+	Nodes    []string `json:"nodes"` // Nodes whose findings it suppressed
+}
+
+// FlagReport lists the feature flags checked through the configured accessors, where
+// they're checked, and the code that only runs behind them
+type FlagReport struct {
+	Accessors []string       `json:"accessors"`
+	Flags     []*FeatureFlag `json:"flags"` // By name
+}
+
+// FeatureFlag is one flag and its checks
+type FeatureFlag struct {
+	Name      string       `json:"name"`
+	Checks    []*FlagCheck `json:"checks"`    // By file and line
+	Gated     []string     `json:"gated"`     // Nodes called in a checked branch, sorted
+	Footprint int          `json:"footprint"` // Gated nodes and every node they reach
+}
+
+// FlagCheck is one call to a flag accessor
+type FlagCheck struct {
+	File  string   `json:"file"`
+	Line  int      `json:"line"`
+	From  string   `json:"from,omitempty"`  // Function or method making the check
+	Gated []string `json:"gated,omitempty"` // Nodes it calls in the checked branch
+}
+
+// PassFinding is something an analyzer pass reports about the code, e.g. one cycle
+type PassFinding struct {
+	Pass    string   `json:"pass"`
+	Message string   `json:"message"`
+	Nodes   []string `json:"nodes,omitempty"` // Node IDs involved
+	File    string   `json:"file,omitempty"`
+	Line    int      `json:"line,omitempty"`
+	Notes   []Note   `json:"notes,omitempty"` // Notes on the finding's nodes that explain it
+}
+
+// Note is a human note on a node, or on one of its findings, from the notes file,
+// e.g. "intentional cycle, scheduled refactor Q3"
+type Note struct {
+	Text     string `json:"text"`
+	Finding  string `json:"finding,omitempty"` // The finding it explains, e.g. "cycles"; empty for the node itself
+	Author   string `json:"author,omitempty"`
+	Expected string `json:"expected,omitempty"` // Why an orphan is expected: "entrypoint", "reflection", or "keep"
+}
+
+// NoteEntry is an entry of the notes file: a note and the nodes it's attached to
+type NoteEntry struct {
+	Note
+	Pattern string   // Qualified names or root-relative paths, as in suppression files
+	Nodes   []string // Nodes it matched
+}
+
+// ScanReport describes what the scanner matched and what it skipped, and why
+type ScanReport struct {
+	Matched     int            `json:"matched"`
+	Extensions  map[string]int `json:"extensions"`          // Matched files per extension
+	Unmatched   map[string]int `json:"unmatchedExtensions"` // Files no parser reads, per extension ("" for none)
+	SkippedDirs []SkippedPath  `json:"skippedDirectories"`  // Reason: "default", "excluded", or "gitignore"
+	Gitignored  int            `json:"gitignored"`          // Matching files a .gitignore ignores
+	Oversized   []SkippedPath  `json:"oversized"`           // Matching files over MaxFileSize
+	MaxFileSize int64          `json:"maxFileSize,omitempty"`
+}
+
+// SkippedPath is a directory or file the scanner left out
+type SkippedPath struct {
+	Path   string `json:"path"` // Relative to the analyzed root
+	Reason string `json:"reason,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// SampleReport extrapolates per-file counts from a --sample run to the whole tree
+type SampleReport struct {
+	Percent   float64           `json:"percent"`
+	Files     int               `json:"files"`   // Files in the tree
+	Sampled   int               `json:"sampled"` // Files parsed in the sample
+	Estimates []*SampleEstimate `json:"estimates"`
+}
+
+// SampleEstimate is one count extrapolated from the sample, with a 95% confidence
+// interval
+type SampleEstimate struct {
+	Metric   string  `json:"metric"`
+	Sampled  int     `json:"sampled"` // The count in the sampled files
+	Estimate float64 `json:"estimate"`
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+}
+
+// OpenAPIReport correlates the operations of an OpenAPI specification with the HTTP
+// routes the code defines
+type OpenAPIReport struct {
+	Spec          string      `json:"spec"` // Path of the specification
+	Title         string      `json:"title,omitempty"`
+	Version       string      `json:"version,omitempty"`
+	Operations    int         `json:"operations"`
+	Routes        int         `json:"routes"`
+	Endpoints     []*Endpoint `json:"endpoints"`     // Operations a route implements
+	Unimplemented []*Endpoint `json:"unimplemented"` // Operations no route serves
+	Undocumented  []*Endpoint `json:"undocumented"`  // Routes no operation describes
+}
+
+// Endpoint is a specified operation, the route implementing it, or both
+type Endpoint struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"` // The operation's path; the route's when undocumented
+	OperationID string   `json:"operationId,omitempty"`
+	Route       string   `json:"route,omitempty"`    // Route node
+	Handlers    []string `json:"handlers,omitempty"` // Nodes the route dispatches to
+	File        string   `json:"file,omitempty"`     // Where the route is defined
+	Line        int      `json:"line,omitempty"`
+	Footprint   int      `json:"footprint"` // Nodes the route depends on, directly or not
+	Files       int      `json:"files"`     // Files those nodes are in
+}
+
+// ChangeScope limits the findings to the nodes defined in the files changed since a git
+// ref, for --changed-only; the rest of the graph is there to resolve their references
+type ChangeScope struct {
+	Ref   string   `json:"ref"`
+	Files []string `json:"files"` // Sorted, as node files name them
+	Nodes int      `json:"nodes"` // Nodes defined in Files
+}
+
+// PruneReport records the nodes --prune removed from the graph before export
+type PruneReport struct {
+	Heuristics  []string      `json:"heuristics"`
+	NodesBefore int           `json:"nodesBefore"`
+	NodesAfter  int           `json:"nodesAfter"`
+	Removed     []*PrunedNode `json:"removed"`
+}
+
+// PrunedNode is one removed node and what its edges became part of
+type PrunedNode struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`         // "leaf", "accessor", or "overload"
+	Into   string `json:"into,omitempty"` // The node that absorbed its edges, or its only dependent for leaves
+}
+
+// Provenance records who produced an exported report, from what, and a digest of the
+// report so tampering can be detected
+type Provenance struct {
+	Tool        string `json:"tool"`
+	ToolVersion string `json:"toolVersion"`
+	ToolCommit  string `json:"toolCommit,omitempty"`
+	Timestamp   string `json:"timestamp"`
+	Root        string `json:"root"`
+	GitCommit   string `json:"gitCommit,omitempty"` // Commit of the analyzed tree
+	GitDirty    bool   `json:"gitDirty,omitempty"`  // Analyzed tree had uncommitted changes
+	Algorithm   string `json:"algorithm,omitempty"` // "sha256" or "hmac-sha256"
+	Checksum    string `json:"checksum,omitempty"`  // SHA-256 of the canonical report
+	Signature   string `json:"signature,omitempty"` // HMAC-SHA256 of the checksum, when a key is set
+}
+
+// RunMetadata describes the run that produced a report, so archived reports can be
+// reproduced and audited
+type RunMetadata struct {
+	ToolVersion string                 `json:"toolVersion"`
+	ToolCommit  string                 `json:"toolCommit,omitempty"`
+	Root        string                 `json:"root"` // Absolute path of the analyzed tree
+	GitCommit   string                 `json:"gitCommit,omitempty"`
+	GitBranch   string                 `json:"gitBranch,omitempty"` // "" on a detached HEAD
+	GitDirty    bool                   `json:"gitDirty,omitempty"`
+	Host        HostInfo               `json:"host"`
+	Args        []string               `json:"args"`   // Command-line arguments, as given
+	Config      map[string]interface{} `json:"config"` // Effective settings after the config file, preset, and flags
+}
+
+// HostInfo is the machine a report was generated on
+type HostInfo struct {
+	Hostname  string `json:"hostname,omitempty"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	CPUs      int    `json:"cpus"`
+	GoVersion string `json:"goVersion"`
+}
+
+// CloneReport lists blocks of code duplicated across or within files
+type CloneReport struct {
+	MinTokens       int      `json:"minTokens"`
+	Clones          []*Clone `json:"clones"`          // Longest first
+	DuplicatedLines int      `json:"duplicatedLines"` // Distinct lines inside some clone block
+}
+
+// Clone is a pair of blocks whose tokens match once identifiers, strings, and numbers
+// are normalized
+type Clone struct {
+	Tokens     int           `json:"tokens"`
+	Similarity float64       `json:"similarity"` // Share of tokens identical as written; 1 for a verbatim copy
+	Blocks     []*CloneBlock `json:"blocks"`
+}
+
+// CloneBlock is where one copy of a clone sits
+type CloneBlock struct {
+	File      string `json:"file"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+}
+
+// LiteralReport inventories string literals and numbers repeated across the code, which
+// are candidates for constants or configuration
+type LiteralReport struct {
+	MinCount int        `json:"minCount"` // Fewest occurrences reported
+	Strings  []*Literal `json:"strings"`  // Most repeated first
+	Numbers  []*Literal `json:"numbers"`  // Most repeated first
+}
+
+// Literal is one repeated value and everywhere it's written
+type Literal struct {
+	Value     string             `json:"value"` // As written, without the quotes of a string
+	Count     int                `json:"count"`
+	Files     int                `json:"files"` // Distinct files it appears in
+	Locations []*LiteralLocation `json:"locations"`
+}
+
+// LiteralLocation is one occurrence of a literal
+type LiteralLocation struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// DebtReport is the technical debt inventory: every TODO, FIXME, and HACK comment,
+// grouped by directory and, when dated with git blame, by age
+type DebtReport struct {
+	Total       int              `json:"total"`
+	Tags        map[string]int   `json:"tags"`              // Markers per tag
+	Tickets     int              `json:"tickets,omitempty"` // Markers referencing a ticket, when a ticket pattern is set
+	Directories []*DebtDirectory `json:"directories"`       // Most markers first
+	Ages        []*DebtAge       `json:"ages,omitempty"`    // Youngest first; only when dated
+	Undated     int              `json:"undated,omitempty"` // Markers git blame had no date for
+}
+
+// DebtDirectory is the debt in one directory (not including its subdirectories)
+type DebtDirectory struct {
+	Path       string      `json:"path"` // Relative to the analyzed root; "." for the root
+	Count      int         `json:"count"`
+	OldestDays int         `json:"oldestDays,omitempty"`
+	Items      []*DebtItem `json:"items"` // By file and line
+}
+
+// DebtItem is one debt marker and where it is
+type DebtItem struct {
+	Tag     string `json:"tag"`
+	Text    string `json:"text"`
+	Author  string `json:"author,omitempty"`
+	Ticket  string `json:"ticket,omitempty"` // First match of the ticket pattern in the text
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Date    string `json:"date,omitempty"` // When the line was last changed, YYYY-MM-DD
+	AgeDays int    `json:"ageDays,omitempty"`
+}
+
+// DebtAge counts the markers in one age range
+type DebtAge struct {
+	Label string `json:"label"` // e.g. "30-90 days"
+	Count int    `json:"count"`
+}
+
+// DocReport measures how well the public API is documented: the share of public
+// classes, functions, and methods with a docblock, and the share of lines that are
+// comments, overall and per namespace
+type DocReport struct {
+	DocStats
+	Namespaces   []*DocNamespace `json:"namespaces"`   // Lowest coverage first
+	Undocumented []*APISymbol    `json:"undocumented"` // By file and line
+}
+
+// DocNamespace is the documentation of one namespace (or JS module)
+type DocNamespace struct {
+	Name string `json:"name"` // "(global)" for code outside a namespace
+	DocStats
+}
+
+// DocStats are documentation counts and the percentages derived from them
+type DocStats struct {
+	Public         int     `json:"public"`     // Public classes, functions, and methods
+	Documented     int     `json:"documented"` // Those with a docblock
+	Coverage       float64 `json:"coverage"`   // Percent documented; 100 without public API
+	Lines          int     `json:"lines"`
+	CommentLines   int     `json:"commentLines"`
+	CommentDensity float64 `json:"commentDensity"` // Percent of lines that are comments
+}
+
+// APISurface is the public API of a library: the public declarations in its configured
+// namespaces, which other code may depend on
+type APISurface struct {
+	Namespaces []string     `json:"namespaces"`
+	Symbols    []*APISymbol `json:"symbols"` // Sorted by name
+}
+
+// APISymbol is one public declaration
+type APISymbol struct {
+	Name      string `json:"name"`      // Qualified name, e.g. App\Billing\Invoice::total
+	Kind      string `json:"kind"`      // Element type: "class", "interface", "method", ...
+	Signature string `json:"signature"` // Declaration as it affects callers, e.g. "public function total(int $tax): int"
+	File      string `json:"file"`      // Relative to the analyzed root
+	Line      int    `json:"line"`
+}
+
 // AnalysisResult holds the complete analysis results
 type AnalysisResult struct {
 	Graph          *DependencyGraph
@@ -89,6 +836,24 @@ type AnalysisResult struct {
 	TotalFiles     int
 	TotalElements  int
 	ProcessingTime string
+	Root           string             // The analyzed directory
+	Provenance     *Provenance        // Set to sign exported reports
+	Metadata       *RunMetadata       // The run that produced the result, for exports
+	Churn          map[string]int     // Lines changed per file relative to Root; nil unless collected
+	APISurface     *APISurface        // Public API of the configured namespaces; nil unless configured
+	Clones         *CloneReport       // Duplicated code; nil unless clone detection ran
+	Literals       *LiteralReport     // Repeated strings and numbers; nil unless the inventory ran
+	Debt           *DebtReport        // TODO, FIXME, and HACK comments; nil when there are none
+	Docs           *DocReport         // Documentation coverage; nil when nothing was parsed
+	OpenAPI        *OpenAPIReport     // Spec operations matched to routes; nil without a spec
+	Tables         *TableReport       // Database table usage; nil when no table is named
+	Flags          *FlagReport        // Feature flag checks; nil without configured accessors
+	Syntax         *SyntaxReport      // Constructs newer than the target version; nil without a target
+	Platform       *PlatformReport    // PHP extensions used against composer.json; nil without either
+	Suppressions   *SuppressionReport // Active suppressions; nil when there are none
+	Findings       []*PassFinding     // Reported by the analyzer passes, by pass
+	Sample         *SampleReport      // Whole-tree estimates; nil unless a sample was analyzed
+	Scan           *ScanReport        // Files matched and skipped by the scanner
 }
 
 // Lock Concurrency helpers (exported so other packages can coordinate safely)
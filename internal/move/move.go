@@ -0,0 +1,438 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package move previews moving a file: the imports, use statements, and references
+// across the analyzed tree that would have to change with it
+package move
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Preview is what moving a file would take
+type Preview struct {
+	From         string  `json:"from"`
+	To           string  `json:"to"`
+	Namespace    string  `json:"namespace,omitempty"`    // The file's namespace, package, or module
+	NewNamespace string  `json:"newNamespace,omitempty"` // What it becomes; "" when it doesn't follow the path
+	Renames      []*Name `json:"renames"`                // Declarations whose qualified names change
+	Edits        []*Edit `json:"edits"`
+}
+
+// Name is a declaration's qualified name before and after the move
+type Name struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// Edit is one place that has to change
+type Edit struct {
+	File string `json:"file"`
+	Line int    `json:"line"` // 0 when the line couldn't be found
+	Kind string `json:"kind"` // "import", "use", "reference", or in the moved file, "namespace" and "declaration"
+	Old  string `json:"old"`
+	New  string `json:"new"` // "" when it can't be worked out
+}
+
+// pathNamespaced are the languages whose namespaces follow the directory layout and are
+// written out in code: PSR-4 PHP, Java and Kotlin packages, C# namespaces, Go import
+// paths, and Python modules
+var pathNamespaced = map[string]bool{"php": true, "java": true, "kotlin": true, "csharp": true, "go": true, "python": true}
+
+// Plan works out what moving from to to would take, given the parsed files and graph of
+// the tree. Paths may be relative to the working directory.
+func Plan(files []*models.ParsedFile, graph *models.DependencyGraph, from, to string) *Preview {
+	from, to = absolute(from), absolute(to)
+	preview := &Preview{From: from, To: to, Renames: []*Name{}, Edits: []*Edit{}}
+
+	var moved *models.ParsedFile
+	for _, file := range files {
+		if absolute(file.Path) == from {
+			moved = file
+		}
+	}
+	if moved == nil {
+		return preview
+	}
+
+	// Qualified names that change with the namespace, or with the class named after the file
+	names := make(map[string]string)
+	if pathNamespaced[moved.Language] && moved.Namespace != "" {
+		preview.Namespace = moved.Namespace
+		preview.NewNamespace = movedNamespace(moved.Namespace, moved.Language, from, to)
+	}
+	oldStem, newStem := stem(from), stem(to)
+	for _, element := range moved.Elements {
+		if element.ClassName != "" || !pathNamespaced[moved.Language] {
+			continue
+		}
+		name := element.Name
+		if name == oldStem && moved.Language != "python" && moved.Language != "go" {
+			name = newStem // PSR-4 and Java name the class after its file
+		}
+		namespace := element.Namespace
+		if preview.NewNamespace != "" {
+			namespace = preview.NewNamespace
+		}
+		if old, renamed := qualify(element.Namespace, element.Name), qualify(namespace, name); old != renamed {
+			names[old] = renamed
+			preview.Renames = append(preview.Renames, &Name{Old: old, New: renamed})
+		}
+	}
+
+	nodes := make(map[string]bool) // The moved file's nodes
+	for id, node := range graph.Nodes {
+		if absolute(node.File) == from {
+			nodes[id] = true
+		}
+	}
+
+	for _, file := range files {
+		path := absolute(file.Path)
+		if path == from {
+			preview.Edits = append(preview.Edits, ownEdits(file, preview, to)...)
+			continue
+		}
+		preview.Edits = append(preview.Edits, importEdits(file, from, to, preview)...)
+		preview.Edits = append(preview.Edits, useEdits(file, names, preview)...)
+		preview.Edits = append(preview.Edits, referenceEdits(file, graph, nodes, names, preview)...)
+	}
+
+	sort.SliceStable(preview.Edits, func(i, j int) bool {
+		a, b := preview.Edits[i], preview.Edits[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return preview
+}
+
+// ownEdits are the changes inside the moved file: its namespace or package declaration,
+// the class named after the file and references to it, and the relative imports that now
+// start from another directory
+func ownEdits(file *models.ParsedFile, preview *Preview, to string) []*Edit {
+	var edits []*Edit
+	for _, element := range file.Elements {
+		old := element.Name
+		if element.ClassName != "" || old != stem(file.Path) || old == stem(to) {
+			continue
+		}
+		if !pathNamespaced[file.Language] || file.Language == "python" || file.Language == "go" {
+			continue
+		}
+		edits = append(edits, &Edit{File: file.Path, Line: element.Line, Kind: "declaration", Old: old, New: stem(to)})
+		for _, usage := range file.Usage {
+			if usage.Name == old || strings.HasPrefix(usage.Name, old+"::") {
+				edits = append(edits, &Edit{File: file.Path, Line: usage.Line, Kind: "reference", Old: old, New: stem(to)})
+			}
+		}
+	}
+
+	if preview.NewNamespace != "" && preview.NewNamespace != preview.Namespace {
+		old, updated := "namespace "+preview.Namespace, "namespace "+preview.NewNamespace
+		switch file.Language {
+		case "java":
+			old, updated = "package "+preview.Namespace, "package "+preview.NewNamespace
+		case "go":
+			old, updated = "package "+lastSegment(preview.Namespace, "/"), "package "+lastSegment(preview.NewNamespace, "/")
+		case "python":
+			old = "" // A module's name isn't declared in it
+		}
+		if old != "" && old != updated {
+			edits = append(edits, &Edit{File: file.Path, Line: lineOf(file.Path, old), Kind: "namespace", Old: old, New: updated})
+		}
+	}
+
+	for _, binding := range file.Imports {
+		if binding.Resolved == "" || !strings.HasPrefix(binding.Source, ".") || file.Language == "python" {
+			continue
+		}
+		if spec := specifier(to, absolute(binding.Resolved), binding.Source); spec != binding.Source {
+			edits = append(edits, &Edit{File: file.Path, Line: binding.Line, Kind: "import", Old: binding.Source, New: spec})
+		}
+	}
+	if file.Language == "ruby" {
+		for _, use := range file.Uses {
+			if target := requiredFile(file.Path, use); target != "" {
+				if spec := specifier(to, target, use); spec != use {
+					edits = append(edits, &Edit{File: file.Path, Line: lineOf(file.Path, use), Kind: "import", Old: use, New: spec})
+				}
+			}
+		}
+	}
+	return edits
+}
+
+// importEdits are the imports in file that resolve to the moved file
+func importEdits(file *models.ParsedFile, from, to string, preview *Preview) []*Edit {
+	var edits []*Edit
+	seen := make(map[int]bool)
+	for _, binding := range file.Imports {
+		if binding.Resolved == "" || absolute(binding.Resolved) != from || seen[binding.Line] {
+			continue
+		}
+		seen[binding.Line] = true
+		edit := &Edit{File: file.Path, Line: binding.Line, Kind: "import", Old: binding.Source}
+		switch {
+		case file.Language == "python":
+			edit.New = preview.NewNamespace
+		case strings.HasPrefix(binding.Source, "."):
+			edit.New = specifier(absolute(file.Path), to, binding.Source)
+		}
+		edits = append(edits, edit)
+	}
+
+	if file.Language == "ruby" {
+		for _, use := range file.Uses {
+			if requiredFile(file.Path, use) == from {
+				edits = append(edits, &Edit{File: file.Path, Line: lineOf(file.Path, use), Kind: "import", Old: use, New: specifier(absolute(file.Path), to, use)})
+			}
+		}
+	}
+	return edits
+}
+
+// useEdits are the use statements and imports in file naming a renamed declaration. Go
+// imports name packages rather than declarations, so those are reported when file
+// references something that moves to another package.
+func useEdits(file *models.ParsedFile, names map[string]string, preview *Preview) []*Edit {
+	if file.Imports != nil {
+		return nil // Covered by importEdits
+	}
+	var edits []*Edit
+	for _, use := range file.Uses {
+		if file.Language == "go" && use == preview.Namespace && preview.NewNamespace != "" && preview.NewNamespace != use && referencesAny(file, names) {
+			edits = append(edits, &Edit{File: file.Path, Line: lineOf(file.Path, useText("go", use)), Kind: "import", Old: use, New: preview.NewNamespace})
+			continue
+		}
+		if renamed, ok := names[strings.TrimPrefix(use, `\`)]; ok {
+			edits = append(edits, &Edit{File: file.Path, Line: lineOf(file.Path, useText(file.Language, use)), Kind: "use", Old: use, New: renamed})
+		}
+	}
+	return edits
+}
+
+// referenceEdits are the references in file to the moved file's renamed declarations that
+// the use statements don't cover: fully-qualified names, and names that resolved only
+// because file shared the old namespace
+func referenceEdits(file *models.ParsedFile, graph *models.DependencyGraph, moved map[string]bool, names map[string]string, preview *Preview) []*Edit {
+	if len(names) == 0 {
+		return nil
+	}
+	imported := make(map[string]bool)
+	for _, use := range file.Uses {
+		imported[strings.TrimPrefix(use, `\`)] = true
+	}
+
+	var edits []*Edit
+	seen := make(map[string]bool)
+	for id := range moved {
+		node := graph.Nodes[id]
+		old := qualify(node.Namespace, node.Name)
+		if node.ClassName != "" {
+			old = qualify(node.Namespace, node.ClassName)
+		}
+		renamed, ok := names[old]
+		if !ok {
+			continue
+		}
+		for callerID, ref := range node.Dependents {
+			caller := graph.Nodes[callerID]
+			if caller == nil || absolute(caller.File) != absolute(file.Path) {
+				continue
+			}
+			for _, line := range ref.Lines {
+				key := old + "@" + strconv.Itoa(line)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				switch {
+				case qualifiedAt(file, line, old):
+					edits = append(edits, &Edit{File: file.Path, Line: line, Kind: "reference", Old: old, New: renamed})
+				case !imported[old] && file.Namespace == preview.Namespace && preview.NewNamespace != preview.Namespace:
+					edits = append(edits, &Edit{File: file.Path, Line: line, Kind: "reference", Old: lastSegment(old, `\`), New: renamed})
+				}
+			}
+		}
+	}
+	return edits
+}
+
+// referencesAny reports whether file spells out any of the renamed qualified names
+func referencesAny(file *models.ParsedFile, names map[string]string) bool {
+	for _, usage := range file.Usage {
+		if _, ok := names[strings.TrimPrefix(usage.Name, `\`)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedAt reports whether line spells out the qualified name. Parsers that resolve
+// names as they go keep it in the usage; the others drop it, so the line is checked too.
+func qualifiedAt(file *models.ParsedFile, line int, qualified string) bool {
+	for _, usage := range file.Usage {
+		name := strings.TrimPrefix(usage.Name, `\`)
+		if usage.Line == line && (name == qualified || strings.HasPrefix(name, qualified+"::") || strings.HasPrefix(name, qualified+`\`)) {
+			return true
+		}
+	}
+	return file.Language != "go" && strings.Contains(lineAt(file.Path, line), useText(file.Language, qualified))
+}
+
+// movedNamespace works out the namespace a file gets in its new directory, from how its
+// namespace follows its current path: App\Billing in src/Billing maps src to App, so
+// src/Payments becomes App\Payments. It returns "" when the namespace doesn't end with
+// the directories it's in, or the new path leaves the mapped root.
+func movedNamespace(namespace, language, from, to string) string {
+	sep := "."
+	switch {
+	case strings.Contains(namespace, `\`):
+		sep = `\`
+	case language == "go":
+		sep = "/"
+	}
+	nsParts := strings.Split(namespace, sep)
+	oldParts, newParts := pathSegments(filepath.Dir(from)), pathSegments(filepath.Dir(to))
+	if language == "python" {
+		oldParts, newParts = append(oldParts, stem(from)), append(newParts, stem(to))
+		if filepath.Base(from) == "__init__.py" {
+			oldParts = oldParts[:len(oldParts)-1]
+		}
+		if filepath.Base(to) == "__init__.py" {
+			newParts = newParts[:len(newParts)-1]
+		}
+	}
+
+	shared := 0
+	for shared < len(nsParts) && shared < len(oldParts) && nsParts[len(nsParts)-1-shared] == oldParts[len(oldParts)-1-shared] {
+		shared++
+	}
+	if shared == 0 {
+		return ""
+	}
+	root := oldParts[:len(oldParts)-shared]
+	if len(newParts) < len(root) || strings.Join(newParts[:len(root)], "/") != strings.Join(root, "/") {
+		return ""
+	}
+	parts := append(append([]string{}, nsParts[:len(nsParts)-shared]...), newParts[len(root):]...)
+	return strings.Join(parts, sep)
+}
+
+// specifier writes a relative import of target from the file at importer, in the style of
+// the old specifier: with or without the extension, and through a directory's index file
+func specifier(importer, target, old string) string {
+	rel, err := filepath.Rel(filepath.Dir(importer), target)
+	if err != nil {
+		return ""
+	}
+	rel = filepath.ToSlash(rel)
+	if filepath.Ext(old) == "" {
+		rel = strings.TrimSuffix(rel, filepath.Ext(rel))
+		if filepath.Base(rel) == "index" && filepath.Base(old) != "index" {
+			rel = filepath.ToSlash(filepath.Dir(rel))
+		}
+	}
+	if !strings.HasPrefix(rel, ".") && (strings.HasPrefix(old, ".") || rel == "") {
+		rel = "./" + rel
+	}
+	return strings.TrimSuffix(rel, "/")
+}
+
+// requiredFile resolves a Ruby require_relative path, returning "" when it names no file
+func requiredFile(importer, required string) string {
+	path := filepath.Join(filepath.Dir(absolute(importer)), required)
+	if filepath.Ext(path) != ".rb" {
+		path += ".rb"
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// useText is how a use of name is written, for finding its line
+func useText(language, name string) string {
+	switch language {
+	case "java", "kotlin", "csharp":
+		return strings.ReplaceAll(name, `\`, ".")
+	case "go":
+		return `"` + name + `"`
+	}
+	return name
+}
+
+// lineOf returns the first line of path containing text, or 0
+func lineOf(path, text string) int {
+	found := 0
+	scanLines(path, func(line int, content string) bool {
+		if strings.Contains(content, text) {
+			found = line
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// lineAt returns the content of a line of path, or ""
+func lineAt(path string, line int) string {
+	found := ""
+	scanLines(path, func(n int, content string) bool {
+		if n == line {
+			found = content
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// scanLines calls fn with each line of path until it returns false
+func scanLines(path string, fn func(line int, content string) bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan() && fn(line, scanner.Text()); line++ {
+	}
+}
+
+func qualify(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + `\` + name
+}
+
+func lastSegment(name, sep string) string {
+	return name[strings.LastIndex(name, sep)+1:]
+}
+
+func stem(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func pathSegments(dir string) []string {
+	return strings.FieldsFunc(filepath.ToSlash(dir), func(r rune) bool { return r == '/' })
+}
+
+func absolute(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
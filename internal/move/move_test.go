@@ -0,0 +1,141 @@
+package move
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/lang"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func write(t *testing.T, root, name, content string) string {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPlan(t *testing.T) {
+	tmp := t.TempDir()
+	invoice := write(t, tmp, "src/Billing/Invoice.php", `<?php
+namespace App\Billing;
+
+class Invoice {
+    public static function make() { return new Invoice(); }
+}
+`)
+	write(t, tmp, "src/Billing/Ledger.php", `<?php
+namespace App\Billing;
+
+class Ledger {
+    public function add() { return new Invoice(); }
+}
+`)
+	write(t, tmp, "src/Http/Controller.php", `<?php
+namespace App\Http;
+
+use App\Billing\Invoice;
+
+class Controller {
+    public function show() { return Invoice::make(); }
+    public function export() { return \App\Billing\Invoice::make(); }
+}
+`)
+
+	p := lang.NewPHPParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"src/Billing/Invoice.php", "src/Billing/Ledger.php", "src/Http/Controller.php"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, parsed)
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+
+	preview := Plan(files, graph, invoice, filepath.Join(tmp, "src/Payments/Bill.php"))
+	if preview.Namespace != `App\Billing` || preview.NewNamespace != `App\Payments` {
+		t.Errorf("expected the namespace to follow the directory, got %q → %q", preview.Namespace, preview.NewNamespace)
+	}
+	if len(preview.Renames) != 1 || preview.Renames[0].Old != `App\Billing\Invoice` || preview.Renames[0].New != `App\Payments\Bill` {
+		t.Errorf("expected the class to take the file's new name, got %+v", preview.Renames)
+	}
+
+	edits := make(map[string]*Edit)
+	for _, edit := range preview.Edits {
+		edits[filepath.Base(edit.File)+":"+edit.Kind+":"+edit.Old] = edit
+	}
+	for key, line := range map[string]int{
+		`Invoice.php:namespace:namespace App\Billing`:  2,
+		`Invoice.php:declaration:Invoice`:              4,
+		`Invoice.php:reference:Invoice`:                5,
+		`Controller.php:use:App\Billing\Invoice`:       4,
+		`Controller.php:reference:App\Billing\Invoice`: 8,
+		`Ledger.php:reference:Invoice`:                 5,
+	} {
+		if edit := edits[key]; edit == nil || edit.Line != line {
+			t.Errorf("expected edit %s on line %d, got %+v", key, line, edit)
+		}
+	}
+	if len(preview.Edits) != 6 {
+		for _, edit := range preview.Edits {
+			t.Logf("%+v", edit)
+		}
+		t.Errorf("expected 6 edits, got %d", len(preview.Edits))
+	}
+}
+
+func TestPlan_RelativeImports(t *testing.T) {
+	tmp := t.TempDir()
+	util := write(t, tmp, "src/lib/util.js", "import { pad } from './pad.js';\nexport function fmt(s) { return pad(s); }\n")
+	write(t, tmp, "src/lib/pad.js", "export function pad(s) { return s; }\n")
+	write(t, tmp, "src/app.js", "import { fmt } from './lib/util';\nfmt('x');\n")
+
+	p := lang.NewJSParser()
+	var files []*models.ParsedFile
+	for _, name := range []string{"src/lib/util.js", "src/lib/pad.js", "src/app.js"} {
+		parsed, err := p.ParseFile(filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, parsed)
+	}
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph(files)
+
+	preview := Plan(files, graph, util, filepath.Join(tmp, "src/shared/format.js"))
+	want := map[string]string{"app.js": "./shared/format", "util.js": "../lib/pad.js"}
+	if len(preview.Edits) != len(want) {
+		t.Fatalf("expected %d edits, got %+v", len(want), preview.Edits)
+	}
+	for _, edit := range preview.Edits {
+		if edit.Kind != "import" || edit.New != want[filepath.Base(edit.File)] || edit.Line != 1 {
+			t.Errorf("unexpected edit %+v", edit)
+		}
+	}
+	if preview.NewNamespace != "" || len(preview.Renames) != 0 {
+		t.Errorf("expected no namespace for JavaScript, got %+v", preview)
+	}
+}
+
+func TestMovedNamespace(t *testing.T) {
+	for _, tc := range []struct{ namespace, language, from, to, want string }{
+		{`App\Billing`, "php", "/r/src/Billing/Invoice.php", "/r/src/Payments/Invoice.php", `App\Payments`},
+		{`App\Billing`, "php", "/r/src/Billing/Invoice.php", "/r/src/Billing/Tax/Invoice.php", `App\Billing\Tax`},
+		{`App\Billing`, "php", "/r/src/Billing/Invoice.php", "/r/tests/Invoice.php", ""},
+		{"com.acme.users", "java", "/r/src/main/java/com/acme/users/A.java", "/r/src/main/java/com/acme/admin/A.java", "com.acme.admin"},
+		{"example.com/app/internal/users", "go", "/r/internal/users/a.go", "/r/internal/admin/a.go", "example.com/app/internal/admin"},
+		{"app.billing.invoice", "python", "/r/app/billing/invoice.py", "/r/app/payments/bill.py", "app.payments.bill"},
+		{`Legacy`, "php", "/r/src/Billing/Invoice.php", "/r/src/Payments/Invoice.php", ""},
+	} {
+		if got := movedNamespace(tc.namespace, tc.language, tc.from, tc.to); got != tc.want {
+			t.Errorf("movedNamespace(%q, %s → %s) = %q, want %q", tc.namespace, tc.from, tc.to, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package nodejs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/boone-studios/tukey/internal/workspace"
+)
+
+// Extensions are tried, in order, when a specifier omits the file extension
+var Extensions = []string{".js", ".mjs", ".cjs", ".jsx", ".ts", ".tsx", ".mts", ".cts", ".json"}
+
+// conditions are the package.json "exports" conditions a static analyzer should follow
+var conditions = []string{"import", "require", "node", "default", "types"}
+
+// trailingCommas matches the trailing commas tsconfig.json (JSONC) allows
+var trailingCommas = regexp.MustCompile(`,(\s*[}\]])`)
+
+// packageJSON is the subset of package.json used for resolution
+type packageJSON struct {
+	Name    string          `json:"name"`
+	Main    string          `json:"main"`
+	Module  string          `json:"module"`
+	Exports json.RawMessage `json:"exports"`
+}
+
+// tsconfig is the subset of tsconfig.json used for path aliases
+type tsconfig struct {
+	dir     string
+	baseURL string
+	paths   map[string][]string
+}
+
+// Resolver maps import specifiers to files using Node's resolution algorithm
+// plus tsconfig "paths" aliases. It is safe for concurrent use.
+type Resolver struct {
+	mu         sync.Mutex
+	packages   map[string]*packageJSON         // package.json by directory (nil when absent)
+	tsconfigs  map[string]*tsconfig            // nearest tsconfig by directory (nil when absent)
+	workspaces map[string]*workspace.Workspace // workspace root by directory (nil when absent)
+}
+
+// NewResolver creates a resolver with empty caches
+func NewResolver() *Resolver {
+	return &Resolver{
+		packages:   make(map[string]*packageJSON),
+		tsconfigs:  make(map[string]*tsconfig),
+		workspaces: make(map[string]*workspace.Workspace),
+	}
+}
+
+// IsRelative reports whether a specifier is a relative or absolute path rather than a package name
+func IsRelative(specifier string) bool {
+	return strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../") ||
+		specifier == "." || specifier == ".." || strings.HasPrefix(specifier, "/")
+}
+
+// Resolve returns the file an import specifier in fromFile refers to, or "" when it
+// can't be resolved (builtins, missing packages, dynamic specifiers)
+func (r *Resolver) Resolve(fromFile, specifier string) string {
+	specifier = strings.TrimPrefix(specifier, "node:")
+	if specifier == "" {
+		return ""
+	}
+
+	dir := filepath.Dir(fromFile)
+	if IsRelative(specifier) {
+		target := specifier
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, specifier)
+		}
+		return r.resolvePath(target)
+	}
+
+	// tsconfig path aliases take precedence over node_modules
+	if resolved := r.resolveAlias(dir, specifier); resolved != "" {
+		return resolved
+	}
+	return r.resolvePackage(dir, specifier)
+}
+
+// resolvePath resolves a path as a file, then as a directory
+func (r *Resolver) resolvePath(target string) string {
+	if resolved := resolveFile(target); resolved != "" {
+		return resolved
+	}
+	return r.resolveDirectory(target)
+}
+
+// resolveFile tries a path as-is and with each known extension
+func resolveFile(target string) string {
+	if isFile(target) {
+		return filepath.Clean(target)
+	}
+	for _, ext := range Extensions {
+		if isFile(target + ext) {
+			return filepath.Clean(target + ext)
+		}
+	}
+
+	// TypeScript sources are imported with the extension they compile to ("./user.js" → user.ts)
+	if ext := filepath.Ext(target); ext == ".js" || ext == ".mjs" || ext == ".cjs" {
+		base := strings.TrimSuffix(target, ext)
+		for _, tsExt := range []string{".ts", ".tsx", ".mts", ".cts"} {
+			if isFile(base + tsExt) {
+				return filepath.Clean(base + tsExt)
+			}
+		}
+	}
+	return ""
+}
+
+// resolveDirectory resolves a directory through its package.json entry point or index file
+func (r *Resolver) resolveDirectory(dir string) string {
+	if !isDir(dir) {
+		return ""
+	}
+
+	if pkg := r.packageAt(dir); pkg != nil {
+		if entry := exportTarget(pkg.Exports, "."); entry != "" {
+			if resolved := resolveFile(filepath.Join(dir, entry)); resolved != "" {
+				return resolved
+			}
+		}
+		for _, entry := range []string{pkg.Module, pkg.Main} {
+			if entry == "" {
+				continue
+			}
+			if resolved := resolveFile(filepath.Join(dir, entry)); resolved != "" {
+				return resolved
+			}
+			if resolved := resolveIndex(filepath.Join(dir, entry)); resolved != "" {
+				return resolved
+			}
+		}
+	}
+	return resolveIndex(dir)
+}
+
+// resolveIndex looks for an index file inside a directory
+func resolveIndex(dir string) string {
+	for _, ext := range Extensions {
+		if candidate := filepath.Join(dir, "index"+ext); isFile(candidate) {
+			return filepath.Clean(candidate)
+		}
+	}
+	return ""
+}
+
+// resolvePackage walks up node_modules directories looking for a bare specifier, then
+// falls back to the packages of an enclosing npm/yarn/pnpm workspace
+func (r *Resolver) resolvePackage(dir, specifier string) string {
+	name, subpath := splitPackageSpecifier(specifier)
+	for current := dir; ; current = filepath.Dir(current) {
+		pkgDir := filepath.Join(current, "node_modules", name)
+		if isDir(pkgDir) {
+			// Workspace packages are symlinked into node_modules; resolve to their sources
+			if target, err := os.Readlink(pkgDir); err == nil {
+				if !filepath.IsAbs(target) {
+					target = filepath.Join(filepath.Dir(pkgDir), target)
+				}
+				pkgDir = target
+			}
+			return r.resolvePackageEntry(pkgDir, subpath)
+		}
+
+		if parent := filepath.Dir(current); parent == current {
+			break
+		}
+	}
+
+	if ws := r.workspaceFor(dir); ws != nil {
+		if pkg := ws.Find(name); pkg != nil && pkg.Kind == "npm" {
+			return r.resolvePackageEntry(pkg.Dir, subpath)
+		}
+	}
+	return ""
+}
+
+// resolvePackageEntry resolves a subpath ("." or "./sub") of a package directory
+func (r *Resolver) resolvePackageEntry(pkgDir, subpath string) string {
+	if subpath == "." {
+		return r.resolveDirectory(pkgDir)
+	}
+	if pkg := r.packageAt(pkgDir); pkg != nil {
+		if entry := exportTarget(pkg.Exports, subpath); entry != "" {
+			return resolveFile(filepath.Join(pkgDir, entry))
+		}
+	}
+	return r.resolvePath(filepath.Join(pkgDir, subpath))
+}
+
+// workspaceFor finds and caches the nearest workspace root above dir
+func (r *Resolver) workspaceFor(dir string) *workspace.Workspace {
+	r.mu.Lock()
+	if ws, cached := r.workspaces[dir]; cached {
+		r.mu.Unlock()
+		return ws
+	}
+	r.mu.Unlock()
+
+	var ws *workspace.Workspace
+	if workspace.IsRoot(dir) {
+		ws, _ = workspace.Detect(dir)
+	} else if parent := filepath.Dir(dir); parent != dir {
+		ws = r.workspaceFor(parent)
+	}
+
+	r.mu.Lock()
+	r.workspaces[dir] = ws
+	r.mu.Unlock()
+	return ws
+}
+
+// resolveAlias applies the nearest tsconfig's "paths" and "baseUrl" to a specifier
+func (r *Resolver) resolveAlias(dir, specifier string) string {
+	cfg := r.tsconfigFor(dir)
+	if cfg == nil {
+		return ""
+	}
+	base := filepath.Join(cfg.dir, cfg.baseURL)
+
+	// Longest prefix wins, as in TypeScript
+	patterns := make([]string, 0, len(cfg.paths))
+	for pattern := range cfg.paths {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return len(strings.SplitN(patterns[i], "*", 2)[0]) > len(strings.SplitN(patterns[j], "*", 2)[0])
+	})
+
+	for _, pattern := range patterns {
+		wildcard, ok := matchPattern(pattern, specifier)
+		if !ok {
+			continue
+		}
+		for _, target := range cfg.paths[pattern] {
+			if resolved := r.resolvePath(filepath.Join(base, strings.Replace(target, "*", wildcard, 1))); resolved != "" {
+				return resolved
+			}
+		}
+	}
+
+	// Non-relative imports are also looked up from baseUrl
+	if cfg.baseURL != "" {
+		return r.resolvePath(filepath.Join(base, specifier))
+	}
+	return ""
+}
+
+// matchPattern matches a specifier against a "paths" key with at most one "*"
+func matchPattern(pattern, specifier string) (string, bool) {
+	star := strings.Index(pattern, "*")
+	if star == -1 {
+		return "", pattern == specifier
+	}
+	prefix, suffix := pattern[:star], pattern[star+1:]
+	if len(specifier) < len(prefix)+len(suffix) ||
+		!strings.HasPrefix(specifier, prefix) || !strings.HasSuffix(specifier, suffix) {
+		return "", false
+	}
+	return specifier[len(prefix) : len(specifier)-len(suffix)], true
+}
+
+// splitPackageSpecifier splits "@scope/pkg/sub/path" into ("@scope/pkg", "./sub/path")
+func splitPackageSpecifier(specifier string) (string, string) {
+	parts := strings.Split(specifier, "/")
+	n := 1
+	if strings.HasPrefix(specifier, "@") && len(parts) > 1 {
+		n = 2
+	}
+	name := strings.Join(parts[:n], "/")
+	if len(parts) == n {
+		return name, "."
+	}
+	return name, "./" + strings.Join(parts[n:], "/")
+}
+
+// exportTarget resolves a subpath ("." or "./sub") through a package.json "exports" field
+func exportTarget(raw json.RawMessage, subpath string) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var exports interface{}
+	if err := json.Unmarshal(raw, &exports); err != nil {
+		return ""
+	}
+
+	// "exports": "./index.js" or a condition map is shorthand for {".": ...}
+	if m, ok := exports.(map[string]interface{}); ok && !hasSubpathKeys(m) {
+		exports = map[string]interface{}{".": m}
+	} else if !ok {
+		exports = map[string]interface{}{".": exports}
+	}
+
+	subpaths := exports.(map[string]interface{})
+	if target, exists := subpaths[subpath]; exists {
+		return conditionTarget(target)
+	}
+
+	// Subpath patterns: "./features/*": "./src/features/*.js"
+	for key, target := range subpaths {
+		if wildcard, ok := matchPattern(key, subpath); ok && strings.Contains(key, "*") {
+			return strings.Replace(conditionTarget(target), "*", wildcard, 1)
+		}
+	}
+	return ""
+}
+
+// hasSubpathKeys reports whether an exports map is keyed by subpath rather than condition
+func hasSubpathKeys(m map[string]interface{}) bool {
+	for key := range m {
+		if strings.HasPrefix(key, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionTarget picks a file out of a (possibly nested) conditional export
+func conditionTarget(target interface{}) string {
+	switch t := target.(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, fallback := range t {
+			if resolved := conditionTarget(fallback); resolved != "" {
+				return resolved
+			}
+		}
+	case map[string]interface{}:
+		for _, condition := range conditions {
+			if nested, exists := t[condition]; exists {
+				if resolved := conditionTarget(nested); resolved != "" {
+					return resolved
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// PackageName returns the "name" field of the package.json in dir, if any
+func (r *Resolver) PackageName(dir string) string {
+	if pkg := r.packageAt(dir); pkg != nil {
+		return pkg.Name
+	}
+	return ""
+}
+
+// packageAt loads and caches the package.json in a directory
+func (r *Resolver) packageAt(dir string) *packageJSON {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pkg, cached := r.packages[dir]; cached {
+		return pkg
+	}
+
+	var pkg *packageJSON
+	if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		pkg = &packageJSON{}
+		if err := json.Unmarshal(data, pkg); err != nil {
+			pkg = nil
+		}
+	}
+	r.packages[dir] = pkg
+	return pkg
+}
+
+// tsconfigFor finds and caches the nearest tsconfig.json (or jsconfig.json) above dir
+func (r *Resolver) tsconfigFor(dir string) *tsconfig {
+	r.mu.Lock()
+	if cfg, cached := r.tsconfigs[dir]; cached {
+		r.mu.Unlock()
+		return cfg
+	}
+	r.mu.Unlock()
+
+	var cfg *tsconfig
+	for _, name := range []string{"tsconfig.json", "jsconfig.json"} {
+		if loaded := loadTSConfig(filepath.Join(dir, name)); loaded != nil {
+			cfg = loaded
+			break
+		}
+	}
+	if cfg == nil {
+		if parent := filepath.Dir(dir); parent != dir {
+			cfg = r.tsconfigFor(parent)
+		}
+	}
+
+	r.mu.Lock()
+	r.tsconfigs[dir] = cfg
+	r.mu.Unlock()
+	return cfg
+}
+
+// loadTSConfig reads compilerOptions.baseUrl and compilerOptions.paths from a tsconfig file
+func loadTSConfig(path string) *tsconfig {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	data = stripComments(data)
+	data = trailingCommas.ReplaceAll(data, []byte("$1"))
+
+	var raw struct {
+		CompilerOptions struct {
+			BaseURL string              `json:"baseUrl"`
+			Paths   map[string][]string `json:"paths"`
+		} `json:"compilerOptions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	return &tsconfig{
+		dir:     filepath.Dir(path),
+		baseURL: raw.CompilerOptions.BaseURL,
+		paths:   raw.CompilerOptions.Paths,
+	}
+}
+
+// stripComments removes // and /* */ comments outside of JSON strings
+func stripComments(data []byte) []byte {
+	var out []byte
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(string(data[i+2:]), "*/")
+			if end == -1 {
+				return out
+			}
+			i += end + 3
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
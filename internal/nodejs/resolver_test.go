@@ -0,0 +1,123 @@
+package nodejs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write fixture: %v", err)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"src/app.js":                               "",
+		"src/utils/index.js":                       "",
+		"src/utils/format.ts":                      "",
+		"src/lib/package.json":                     `{"main": "./main.js"}`,
+		"src/lib/main.js":                          "",
+		"node_modules/left-pad/package.json":       `{"main": "lib/index"}`,
+		"node_modules/left-pad/lib/index.js":       "",
+		"node_modules/@acme/ui/package.json":       `{"exports": {".": {"import": "./esm/index.mjs", "require": "./cjs/index.cjs"}, "./button": "./esm/button.mjs", "./icons/*": "./esm/icons/*.mjs"}}`,
+		"node_modules/@acme/ui/esm/index.mjs":      "",
+		"node_modules/@acme/ui/esm/button.mjs":     "",
+		"node_modules/@acme/ui/esm/icons/star.mjs": "",
+		"tsconfig.json": `{
+  // Path aliases
+  "compilerOptions": {
+    "baseUrl": ".",
+    "paths": {"@/*": ["src/*"], "@utils": ["src/utils/index.js"],},
+  },
+}`,
+	})
+
+	from := filepath.Join(root, "src", "app.js")
+	r := NewResolver()
+	tests := []struct {
+		specifier string
+		want      string
+	}{
+		{"./utils", "src/utils/index.js"},
+		{"./utils/format", "src/utils/format.ts"},
+		{"./utils/format.js", "src/utils/format.ts"},
+		{"./lib", "src/lib/main.js"},
+		{"left-pad", "node_modules/left-pad/lib/index.js"},
+		{"@acme/ui", "node_modules/@acme/ui/esm/index.mjs"},
+		{"@acme/ui/button", "node_modules/@acme/ui/esm/button.mjs"},
+		{"@acme/ui/icons/star", "node_modules/@acme/ui/esm/icons/star.mjs"},
+		{"@/utils/format", "src/utils/format.ts"},
+		{"@utils", "src/utils/index.js"},
+		{"src/lib", "src/lib/main.js"},
+		{"fs", ""},
+		{"node:path", ""},
+		{"./missing", ""},
+	}
+
+	for _, tt := range tests {
+		got := r.Resolve(from, tt.specifier)
+		want := ""
+		if tt.want != "" {
+			want = filepath.Join(root, tt.want)
+		}
+		if got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.specifier, got, want)
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	if wildcard, ok := matchPattern("@/*", "@/utils/format"); !ok || wildcard != "utils/format" {
+		t.Errorf("expected wildcard utils/format, got %q (%v)", wildcard, ok)
+	}
+	if _, ok := matchPattern("@/*", "lodash"); ok {
+		t.Errorf("expected no match for lodash")
+	}
+	if _, ok := matchPattern("~config", "~config"); !ok {
+		t.Errorf("expected exact match")
+	}
+}
+
+func TestSplitPackageSpecifier(t *testing.T) {
+	if name, sub := splitPackageSpecifier("@scope/pkg/a/b"); name != "@scope/pkg" || sub != "./a/b" {
+		t.Errorf("got %q %q", name, sub)
+	}
+	if name, sub := splitPackageSpecifier("lodash"); name != "lodash" || sub != "." {
+		t.Errorf("got %q %q", name, sub)
+	}
+}
+
+func TestResolveWorkspacePackage(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":                 `{"name": "acme", "private": true, "workspaces": ["packages/*"]}`,
+		"packages/ui/package.json":     `{"name": "@acme/ui", "main": "src/index.js"}`,
+		"packages/ui/src/index.js":     "",
+		"packages/ui/src/button.js":    "",
+		"packages/web/package.json":    `{"name": "@acme/web", "dependencies": {"@acme/ui": "*"}}`,
+		"packages/web/src/app.js":      "",
+		"packages/web/src/lib/deep.js": "",
+	})
+
+	r := NewResolver()
+	from := filepath.Join(root, "packages", "web", "src", "lib", "deep.js")
+	if got, want := r.Resolve(from, "@acme/ui"), filepath.Join(root, "packages/ui/src/index.js"); got != want {
+		t.Errorf("Resolve(@acme/ui) = %q, want %q", got, want)
+	}
+	if got, want := r.Resolve(from, "@acme/ui/src/button"), filepath.Join(root, "packages/ui/src/button.js"); got != want {
+		t.Errorf("Resolve(@acme/ui/src/button) = %q, want %q", got, want)
+	}
+	if got := r.Resolve(from, "@acme/missing"); got != "" {
+		t.Errorf("expected unknown workspace package to stay unresolved, got %q", got)
+	}
+}
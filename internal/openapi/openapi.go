@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package openapi reads the operations of an OpenAPI (or Swagger 2) specification
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// methods are the path item keys that are operations
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Operation is one method on one path of the spec
+type Operation struct {
+	Method      string // Upper case, e.g. "GET"
+	Path        string // As written in the spec, e.g. "/users/{userId}"
+	OperationID string
+	Summary     string
+}
+
+// Spec is a parsed specification
+type Spec struct {
+	Path       string
+	Title      string
+	Version    string
+	Prefixes   []string // Path prefixes of the servers (or Swagger basePath), e.g. "/v1"
+	Operations []Operation
+}
+
+// document is the part of a specification Load reads. Path items are decoded by key,
+// since besides operations they hold parameter lists and references.
+type document struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	BasePath string `yaml:"basePath"`
+	Servers  []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+// Load reads the YAML or JSON specification at path
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	spec.Path = path
+	return spec, nil
+}
+
+// Parse reads a YAML or JSON specification. Operations are sorted by path, then method.
+func Parse(data []byte) (*Spec, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.OpenAPI == "" && doc.Swagger == "" {
+		return nil, fmt.Errorf("not an OpenAPI specification (no openapi or swagger version)")
+	}
+
+	spec := &Spec{Title: doc.Info.Title, Version: doc.Info.Version}
+	if prefix := strings.TrimRight(doc.BasePath, "/"); prefix != "" {
+		spec.Prefixes = append(spec.Prefixes, prefix)
+	}
+	for _, server := range doc.Servers {
+		if prefix := serverPrefix(server.URL); prefix != "" {
+			spec.Prefixes = append(spec.Prefixes, prefix)
+		}
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, method := range methods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op struct {
+				OperationID string `yaml:"operationId"`
+				Summary     string `yaml:"summary"`
+			}
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			spec.Operations = append(spec.Operations, Operation{
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+			})
+		}
+	}
+	return spec, nil
+}
+
+// serverPrefix returns the path of a server URL ("https://api.example.com/v1" is "/v1"),
+// without a trailing slash. Server variables ({version}) are kept as written.
+func serverPrefix(server string) string {
+	path := server
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		path = u.Path
+	} else if i := strings.Index(server, "://"); i != -1 {
+		path = ""
+		if j := strings.Index(server[i+3:], "/"); j != -1 {
+			path = server[i+3+j:]
+		}
+	}
+	return strings.TrimRight(path, "/")
+}
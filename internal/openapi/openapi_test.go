@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sample = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.2.0
+servers:
+  - url: https://api.example.com/api/
+  - url: /
+paths:
+  /users/{userId}:
+    parameters:
+      - name: userId
+        in: path
+    get:
+      operationId: getUser
+      summary: Fetch a user
+    delete:
+      operationId: deleteUser
+  /users:
+    post:
+      operationId: createUser
+`
+
+func TestParse(t *testing.T) {
+	spec, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Title != "Users" || spec.Version != "1.2.0" {
+		t.Errorf("unexpected info %q %q", spec.Title, spec.Version)
+	}
+	if !reflect.DeepEqual(spec.Prefixes, []string{"/api"}) {
+		t.Errorf("unexpected prefixes %v", spec.Prefixes)
+	}
+	want := []Operation{
+		{Method: "POST", Path: "/users", OperationID: "createUser"},
+		{Method: "GET", Path: "/users/{userId}", OperationID: "getUser", Summary: "Fetch a user"},
+		{Method: "DELETE", Path: "/users/{userId}", OperationID: "deleteUser"},
+	}
+	if !reflect.DeepEqual(spec.Operations, want) {
+		t.Errorf("unexpected operations %+v", spec.Operations)
+	}
+}
+
+func TestParse_SwaggerJSON(t *testing.T) {
+	spec, err := Parse([]byte(`{"swagger": "2.0", "basePath": "/v1/", "paths": {"/ping": {"get": {}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spec.Prefixes, []string{"/v1"}) || len(spec.Operations) != 1 || spec.Operations[0].Method != "GET" {
+		t.Errorf("unexpected spec %+v", spec)
+	}
+}
+
+func TestParse_NotASpec(t *testing.T) {
+	if _, err := Parse([]byte("name: tukey\n")); err == nil {
+		t.Error("expected an error for a document without a version")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	os.WriteFile(path, []byte(sample), 0644)
+	spec, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Path != path || len(spec.Operations) != 3 {
+		t.Errorf("unexpected spec %+v", spec)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
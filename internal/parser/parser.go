@@ -15,4 +15,26 @@ type LanguageParser interface {
 	ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar) ([]*models.ParsedFile, error)
 	Language() string // e.g., "php", "go", etc.
 	FileExtensions() []string
+	DefaultExcludes() []string // Directory names skipped unless configured otherwise, e.g. "vendor"
+}
+
+// Sniffer is implemented by parsers that can recognize their files without an
+// extension, such as executable scripts, from the first bytes of the file
+type Sniffer interface {
+	Sniff(header []byte) bool
+}
+
+// Entrypointer is implemented by parsers whose language has functions called by its
+// runtime rather than by other code, such as Go's main and init. Entrypoints returns
+// patterns matched against element names, like a preset's entrypoints.
+type Entrypointer interface {
+	Entrypoints() []string
+}
+
+// Versioned is implemented by parsers that can target a version of their language, such
+// as PHP 7.4. SetVersion returns an error for a version it doesn't recognize; after it,
+// the parser reads syntax the way that version does and records the constructs newer
+// than it in ParsedFile.Syntax.
+type Versioned interface {
+	SetVersion(version string) error
 }
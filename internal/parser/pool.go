@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package parser
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+// workers is how many files ProcessFiles parses at once
+const workers = 10
+
+// FileError is a file that couldn't be parsed
+type FileError struct {
+	File models.FileInfo
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.File.RelativePath, e.Err)
+}
+
+// reportError receives the files ProcessFiles couldn't parse; nil drops them, leaving
+// callers to count what's missing from the results
+var (
+	reportMu    sync.Mutex
+	reportError func(*FileError)
+)
+
+// SetErrorReporter passes the files ProcessFiles couldn't parse to report, one call at a
+// time, rather than printing them over the progress bars. nil stops reporting.
+func SetErrorReporter(report func(*FileError)) {
+	reportMu.Lock()
+	defer reportMu.Unlock()
+	reportError = report
+}
+
+// ProcessFiles parses files concurrently with parse, through Guard, ticking progressBar
+// once per file whether or not it parsed. The results keep the order of files; files
+// that fail are left out and passed to the error reporter. LanguageParser
+// implementations should use it for ProcessFiles.
+func ProcessFiles(files []models.FileInfo, progressBar *progress.ProgressBar, parse func(file models.FileInfo) (*models.ParsedFile, error)) ([]*models.ParsedFile, error) {
+	results := make([]*models.ParsedFile, len(files))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, workers)
+
+	for i, file := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			parsed, err := Guard(file.Path, func() (*models.ParsedFile, error) { return parse(file) })
+			if err != nil {
+				report(&FileError{File: file, Err: err})
+			} else {
+				results[i] = parsed
+			}
+			mu.Lock()
+			progressBar.Update(1)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	progressBar.Finish()
+
+	parsedFiles := make([]*models.ParsedFile, 0, len(files))
+	for _, parsed := range results {
+		if parsed != nil {
+			parsedFiles = append(parsedFiles, parsed)
+		}
+	}
+	return parsedFiles, nil
+}
+
+// report passes a file that couldn't be parsed to the error reporter, if one is set
+func report(err *FileError) {
+	reportMu.Lock()
+	defer reportMu.Unlock()
+	if reportError != nil {
+		reportError(err)
+	}
+}
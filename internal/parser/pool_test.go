@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/progress"
+)
+
+func TestProcessFiles(t *testing.T) {
+	var files []models.FileInfo
+	for i := 0; i < 25; i++ {
+		path := fmt.Sprintf("file%d.php", i)
+		files = append(files, models.FileInfo{Path: "/src/" + path, RelativePath: path})
+	}
+	var reported []*FileError
+	SetErrorReporter(func(err *FileError) { reported = append(reported, err) })
+	defer SetErrorReporter(nil)
+
+	bar := progress.NewProgressBar(len(files), "Testing pool")
+	parsed, err := ProcessFiles(files, bar, func(file models.FileInfo) (*models.ParsedFile, error) {
+		switch file.RelativePath {
+		case "file3.php":
+			return nil, errors.New("unexpected token")
+		case "file7.php":
+			panic("boom")
+		}
+		return &models.ParsedFile{Path: file.Path}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(parsed) != 23 || parsed[0].Path != "/src/file0.php" || parsed[3].Path != "/src/file4.php" || parsed[22].Path != "/src/file24.php" {
+		t.Errorf("expected the parsed files in order without the failures, got %d", len(parsed))
+	}
+	failed := make(map[string]error)
+	for _, fileErr := range reported {
+		failed[fileErr.File.RelativePath] = fileErr.Err
+	}
+	var panicErr *PanicError
+	if len(reported) != 2 || failed["file3.php"] == nil || !errors.As(failed["file7.php"], &panicErr) {
+		t.Errorf("expected the failed and panicking files to be reported, got %v", reported)
+	}
+	for _, fileErr := range reported {
+		if !strings.HasPrefix(fileErr.Error(), fileErr.File.RelativePath+": ") {
+			t.Errorf("expected the error to name the file, got %q", fileErr.Error())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package parser
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// debugMode attaches a stack trace to the errors Guard makes from panics. It is set by
+// --debug or the TUKEY_DEBUG environment variable.
+var debugMode = os.Getenv("TUKEY_DEBUG") != ""
+
+// SetDebug switches stack traces on panic errors on or off
+func SetDebug(enabled bool) {
+	debugMode = enabled
+}
+
+// PanicError is a panic raised while parsing a file, recovered so the rest of the run
+// can continue
+type PanicError struct {
+	Path  string
+	Value interface{}
+	Stack []byte // Only in debug mode
+}
+
+func (e *PanicError) Error() string {
+	if len(e.Stack) > 0 {
+		return fmt.Sprintf("parser panicked: %v\n%s", e.Value, e.Stack)
+	}
+	return fmt.Sprintf("parser panicked: %v (set TUKEY_DEBUG=1 or --debug for the stack trace)", e.Value)
+}
+
+// Guard runs parse for the file at path, turning a panic into a *PanicError, so one
+// pathological file is reported as a parse error instead of ending the process.
+// ProcessFiles implementations should parse each file through it.
+func Guard(path string, parse func() (*models.ParsedFile, error)) (parsed *models.ParsedFile, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &PanicError{Path: path, Value: r}
+			if debugMode {
+				panicErr.Stack = debug.Stack()
+			}
+			parsed, err = nil, panicErr
+		}
+	}()
+	return parse()
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestGuard(t *testing.T) {
+	parsed, err := Guard("ok.php", func() (*models.ParsedFile, error) {
+		return &models.ParsedFile{Path: "ok.php"}, nil
+	})
+	if err != nil || parsed.Path != "ok.php" {
+		t.Fatalf("expected the parse result to pass through, got %v, %v", parsed, err)
+	}
+
+	SetDebug(false)
+	parsed, err = Guard("bad.php", func() (*models.ParsedFile, error) {
+		var elements []models.CodeElement
+		_ = elements[3]
+		return nil, nil
+	})
+	var panicErr *PanicError
+	if parsed != nil || !errors.As(err, &panicErr) {
+		t.Fatalf("expected a panic error, got %v, %v", parsed, err)
+	}
+	if panicErr.Path != "bad.php" || panicErr.Stack != nil || !strings.Contains(err.Error(), "index out of range") {
+		t.Errorf("unexpected error %+v", panicErr)
+	}
+
+	SetDebug(true)
+	defer SetDebug(false)
+	_, err = Guard("bad.php", func() (*models.ParsedFile, error) { panic("boom") })
+	if !strings.Contains(err.Error(), "boom") || !strings.Contains(err.Error(), "recover_test.go") {
+		t.Errorf("expected the stack in debug mode, got %v", err)
+	}
+}
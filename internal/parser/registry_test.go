@@ -28,6 +28,10 @@ func (d *DummyParser) FileExtensions() []string {
 	return []string{".dummy"}
 }
 
+func (d *DummyParser) DefaultExcludes() []string {
+	return nil
+}
+
 func TestRegistry_RegisterAndGet(t *testing.T) {
 	registry = map[string]LanguageParser{}
 
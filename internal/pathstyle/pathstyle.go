@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package pathstyle rewrites the file paths in an analysis result to forward slashes,
+// so a report generated on Windows matches one generated on Linux or macOS
+package pathstyle
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+const (
+	Native = "native" // The platform's separator, as the scanner found the paths
+	Posix  = "posix"  // Forward slashes everywhere
+)
+
+// Styles are the accepted --path-style values
+var Styles = []string{Native, Posix}
+
+// separator is the platform's path separator; a variable so tests can play Windows
+var separator = filepath.Separator
+
+// Validate checks that style is one of Styles; "" means Native
+func Validate(style string) error {
+	if style == "" || style == Native || style == Posix {
+		return nil
+	}
+	return fmt.Errorf("unknown path style %q (supported: %s)", style, strings.Join(Styles, ", "))
+}
+
+// Apply rewrites, in place, every string in result that is the path of a scanned file or
+// of a directory above one. Only whole paths are rewritten, so names, patterns, and URL
+// paths that merely contain a separator are left alone. Go's file functions accept
+// forward slashes on Windows too, so exporters can still read the files.
+func Apply(result *models.AnalysisResult, files []models.FileInfo, style string) {
+	if style != Posix || separator == '/' {
+		return
+	}
+	known := make(map[string]bool)
+	for _, file := range files {
+		for _, path := range []string{file.Path, file.RelativePath} {
+			for path = filepath.Clean(path); path != "." && !known[path]; path = filepath.Dir(path) {
+				known[path] = true
+			}
+		}
+	}
+	r := &rewriter{known: known, seen: make(map[uintptr]bool)}
+	r.walk(reflect.ValueOf(result))
+}
+
+// rewriter walks a value, converting the known paths it holds
+type rewriter struct {
+	known map[string]bool // Cleaned native paths
+	seen  map[uintptr]bool
+}
+
+func (r *rewriter) convert(s string) (string, bool) {
+	if s == "" || !r.known[filepath.Clean(s)] {
+		return s, false
+	}
+	return strings.ReplaceAll(s, string(separator), "/"), true
+}
+
+func (r *rewriter) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || r.seen[v.Pointer()] {
+			return
+		}
+		r.seen[v.Pointer()] = true
+		r.walk(v.Elem())
+	case reflect.Interface:
+		if !v.IsNil() {
+			r.walk(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				r.walk(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.walk(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			// Map values aren't addressable; rewrite a copy and store it back
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			r.walk(value)
+			if key.Kind() == reflect.String {
+				if converted, ok := r.convert(key.String()); ok {
+					v.SetMapIndex(key, reflect.Value{})
+					key = reflect.ValueOf(converted).Convert(key.Type())
+				}
+			}
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String:
+		if converted, ok := r.convert(v.String()); ok && v.CanSet() {
+			v.SetString(converted)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package pathstyle
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestApply(t *testing.T) {
+	separator = '\\'
+	defer func() { separator = '/' }()
+
+	files := []models.FileInfo{{Path: `proj\src\App.php`, RelativePath: `src\App.php`}}
+	node := &models.DependencyNode{ID: "class:App:3", File: `proj\src\App.php`}
+	result := &models.AnalysisResult{
+		Graph: &models.DependencyGraph{
+			Nodes:   map[string]*models.DependencyNode{node.ID: node},
+			Orphans: []*models.DependencyNode{node},
+		},
+		ParsedFiles: []*models.ParsedFile{{Path: `proj\src\App.php`, Elements: []models.CodeElement{{Name: `App`, File: `proj\src\App.php`}}}},
+		Churn:       map[string]int{`src\App.php`: 4},
+		Debt: &models.DebtReport{Directories: []*models.DebtDirectory{{
+			Path:  "src",
+			Items: []*models.DebtItem{{File: `src\App.php`, Text: `see src\App.php`}},
+		}}},
+	}
+
+	Apply(result, files, Native)
+	if node.File != `proj\src\App.php` {
+		t.Fatalf("expected native paths to be kept, got %s", node.File)
+	}
+
+	Apply(result, files, Posix)
+	if node.File != "proj/src/App.php" || result.ParsedFiles[0].Elements[0].File != "proj/src/App.php" {
+		t.Errorf("expected forward slashes, got %s and %s", node.File, result.ParsedFiles[0].Elements[0].File)
+	}
+	if result.Churn["src/App.php"] != 4 || len(result.Churn) != 1 {
+		t.Errorf("expected the churn key to be rewritten, got %v", result.Churn)
+	}
+	marker := result.Debt.Directories[0].Items[0]
+	if marker.File != "src/App.php" || marker.Text != `see src\App.php` {
+		t.Errorf("expected only the whole path to be rewritten, got %+v", marker)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, style := range []string{"", "native", "posix"} {
+		if err := Validate(style); err != nil {
+			t.Errorf("expected %q to be valid: %v", style, err)
+		}
+	}
+	if Validate("windows") == nil {
+		t.Error("expected an error for an unknown style")
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package plugin runs analyzer passes and exporters shipped as separate programs, so
+// they can be added without rebuilding Tukey. A plugin is an executable declared in
+// config that speaks newline-delimited JSON on stdin and stdout: Tukey sends a Hello,
+// the plugin answers with its Manifest, and then each Request gets one Response until
+// Tukey closes stdin. Requests point the plugin at the analysis written as a --format
+// json report, so plugins read the same documented layout as any other consumer.
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/pkg/output"
+)
+
+// Protocol is the version of the plugin protocol; a plugin must answer with the same
+const Protocol = 1
+
+// HandshakeTimeout is how long a plugin may take to send its manifest
+const HandshakeTimeout = 10 * time.Second
+
+// Hello is the first message Tukey sends
+type Hello struct {
+	Protocol int    `json:"protocol"`
+	Version  string `json:"version"` // Tukey's
+}
+
+// Manifest is the plugin's answer to the Hello: what it provides
+type Manifest struct {
+	Name      string     `json:"name"`
+	Protocol  int        `json:"protocol"`
+	Passes    []PassInfo `json:"passes,omitempty"`
+	Exporters []string   `json:"exporters,omitempty"` // Formats, e.g. "sonarqube"
+}
+
+// PassInfo describes an analyzer pass and the metrics it returns
+type PassInfo struct {
+	Name    string   `json:"name"`
+	Metrics []string `json:"metrics"`
+}
+
+// Request asks the plugin to run a pass or export a report
+type Request struct {
+	Method   string `json:"method"` // "run" or "export"
+	Pass     string `json:"pass,omitempty"`
+	Format   string `json:"format,omitempty"`
+	Filename string `json:"filename,omitempty"` // Where to export
+	Report   string `json:"report"`             // The analysis, as a JSON report file
+}
+
+// Response answers a Request
+type Response struct {
+	Metrics  map[string]int        `json:"metrics,omitempty"`
+	Findings []*models.PassFinding `json:"findings,omitempty"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// Plugin is a running plugin program
+type Plugin struct {
+	Manifest Manifest
+
+	mu      sync.Mutex // One request at a time
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	encoder *json.Encoder
+	decoder *json.Decoder
+	err     error // The first failed request
+
+	result *models.AnalysisResult // The result last written to report
+	report string
+}
+
+// Start runs each configured plugin and registers the passes and exporters it provides.
+// Relative commands are resolved against root, where the plugins also run. On error,
+// the plugins already started are closed.
+func Start(root, version string, configs []config.PluginConfig) ([]*Plugin, error) {
+	var plugins []*Plugin
+	for _, declared := range configs {
+		p, err := start(root, version, declared)
+		if err != nil {
+			Close(plugins)
+			return nil, fmt.Errorf("%s: %w", declared.Command, err)
+		}
+		plugins = append(plugins, p)
+	}
+	for _, p := range plugins {
+		if err := p.register(); err != nil {
+			Close(plugins)
+			return nil, fmt.Errorf("%s: %w", p.Manifest.Name, err)
+		}
+	}
+	return plugins, nil
+}
+
+func start(root, version string, declared config.PluginConfig) (*Plugin, error) {
+	command := declared.Command
+	if strings.ContainsRune(command, '/') || strings.ContainsRune(command, filepath.Separator) {
+		if !filepath.IsAbs(command) {
+			command = filepath.Join(root, command)
+		}
+	}
+	cmd := exec.Command(command, declared.Args...)
+	cmd.Dir = root
+	cmd.Stderr = os.Stderr // Let plugins log
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &Plugin{
+		cmd:     cmd,
+		stdin:   stdin,
+		encoder: json.NewEncoder(stdin),
+		decoder: json.NewDecoder(bufio.NewReader(stdout)),
+	}
+	handshake := make(chan error, 1)
+	go func() {
+		if err := p.encoder.Encode(Hello{Protocol: Protocol, Version: version}); err != nil {
+			handshake <- err
+			return
+		}
+		handshake <- p.decoder.Decode(&p.Manifest)
+	}()
+	select {
+	case err = <-handshake:
+	case <-time.After(HandshakeTimeout):
+		err = fmt.Errorf("no manifest after %s", HandshakeTimeout)
+	}
+	switch {
+	case err != nil:
+	case p.Manifest.Protocol != Protocol:
+		err = fmt.Errorf("speaks protocol %d, expected %d", p.Manifest.Protocol, Protocol)
+	case p.Manifest.Name == "":
+		err = errors.New("the manifest has no name")
+	}
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// register adds the plugin's passes and exporters to the registries, refusing names
+// already taken rather than letting the registries panic
+func (p *Plugin) register() error {
+	for _, info := range p.Manifest.Passes {
+		for _, existing := range analyzer.Passes() {
+			if existing.Name() == info.Name {
+				return fmt.Errorf("analyzer pass %q is already registered", info.Name)
+			}
+		}
+		for _, metric := range info.Metrics {
+			for _, existing := range analyzer.PassMetrics() {
+				if existing == metric {
+					return fmt.Errorf("metric %q is already declared", metric)
+				}
+			}
+		}
+	}
+	for _, format := range p.Manifest.Exporters {
+		if _, exists := output.Get(format); exists {
+			return fmt.Errorf("format %q is already registered", format)
+		}
+	}
+
+	for _, info := range p.Manifest.Passes {
+		analyzer.RegisterPass(&pass{plugin: p, info: info})
+	}
+	for _, format := range p.Manifest.Exporters {
+		output.Register(&exporter{plugin: p, format: format})
+	}
+	return nil
+}
+
+// Err returns the error of the first request the plugin failed, if any. Passes can't
+// return errors, so callers check this after running them.
+func (p *Plugin) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// call sends a request about result and reads the response
+func (p *Plugin) call(request Request, result *models.AnalysisResult) (*Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Passes over the same result share one report; exports see the finished result
+	if p.result != result || p.report == "" || request.Method == "export" {
+		if err := p.writeReport(result); err != nil {
+			return nil, p.fail(err)
+		}
+	}
+	request.Report = p.report
+	if err := p.encoder.Encode(request); err != nil {
+		return nil, p.fail(fmt.Errorf("plugin %s exited: %w", p.Manifest.Name, err))
+	}
+	var response Response
+	if err := p.decoder.Decode(&response); err != nil {
+		return nil, p.fail(fmt.Errorf("plugin %s exited: %w", p.Manifest.Name, err))
+	}
+	if response.Error != "" {
+		return nil, p.fail(fmt.Errorf("plugin %s: %s", p.Manifest.Name, response.Error))
+	}
+	return &response, nil
+}
+
+// fail records err as the plugin's first error, and returns it
+func (p *Plugin) fail(err error) error {
+	if p.err == nil {
+		p.err = err
+	}
+	return err
+}
+
+// writeReport writes result as a JSON report for the plugin to read
+func (p *Plugin) writeReport(result *models.AnalysisResult) error {
+	if p.report == "" {
+		file, err := os.CreateTemp("", "tukey-plugin-*.json")
+		if err != nil {
+			return err
+		}
+		file.Close()
+		p.report = file.Name()
+	}
+	if err := output.NewJSONExporter().Export(result, p.report); err != nil {
+		return err
+	}
+	p.result = result
+	return nil
+}
+
+// Close ends the plugin: it closes the plugin's stdin, waits briefly for it to exit,
+// and kills it otherwise
+func (p *Plugin) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.report != "" {
+		os.Remove(p.report)
+		p.report = ""
+	}
+	p.stdin.Close()
+	exited := make(chan error, 1)
+	go func() { exited <- p.cmd.Wait() }()
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(5 * time.Second):
+		p.cmd.Process.Kill()
+		return <-exited
+	}
+}
+
+// Close ends every plugin
+func Close(plugins []*Plugin) {
+	for _, p := range plugins {
+		p.Close()
+	}
+}
+
+// pass runs one of a plugin's analyzer passes
+type pass struct {
+	plugin *Plugin
+	info   PassInfo
+}
+
+func (ps *pass) Name() string      { return ps.info.Name }
+func (ps *pass) Metrics() []string { return ps.info.Metrics }
+
+// Run asks the plugin to run the pass. A failed request returns no metrics; the
+// error is kept for Plugin.Err.
+func (ps *pass) Run(result *models.AnalysisResult) *analyzer.PassResult {
+	response, err := ps.plugin.call(Request{Method: "run", Pass: ps.info.Name}, result)
+	if err != nil {
+		return nil
+	}
+	return &analyzer.PassResult{Metrics: response.Metrics, Findings: response.Findings}
+}
+
+// exporter exports through a plugin
+type exporter struct {
+	plugin *Plugin
+	format string
+}
+
+func (e *exporter) Format() string { return e.format }
+
+func (e *exporter) Export(result *models.AnalysisResult, filename string) error {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	_, err = e.plugin.call(Request{Method: "export", Format: e.format, Filename: abs}, result)
+	return err
+}
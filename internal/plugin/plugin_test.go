@@ -0,0 +1,137 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/config"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/pkg/output"
+)
+
+// TestMain lets the test binary act as a plugin, when started by a test
+func TestMain(m *testing.M) {
+	if mode := os.Getenv("TUKEY_TEST_PLUGIN"); mode != "" {
+		servePlugin(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// servePlugin is a plugin counting the report's nodes, as a pass and as an exporter
+func servePlugin(mode string) {
+	decoder, encoder := json.NewDecoder(os.Stdin), json.NewEncoder(os.Stdout)
+	var hello Hello
+	if decoder.Decode(&hello) != nil {
+		return
+	}
+	encoder.Encode(Manifest{
+		Name:      "acme-" + mode,
+		Protocol:  Protocol,
+		Passes:    []PassInfo{{Name: "acmeNodes-" + mode, Metrics: []string{"acmeNodes-" + mode}}},
+		Exporters: []string{"acme-" + mode},
+	})
+	for {
+		var request Request
+		if decoder.Decode(&request) != nil {
+			return
+		}
+		if mode == "broken" {
+			encoder.Encode(Response{Error: "no licence for acme checks"})
+			continue
+		}
+		var report struct {
+			Graph struct {
+				Nodes map[string]json.RawMessage `json:"nodes"`
+			} `json:"graph"`
+		}
+		data, _ := os.ReadFile(request.Report)
+		json.Unmarshal(data, &report)
+		nodes := len(report.Graph.Nodes)
+
+		switch request.Method {
+		case "run":
+			encoder.Encode(Response{
+				Metrics:  map[string]int{request.Pass: nodes},
+				Findings: []*models.PassFinding{{Message: fmt.Sprintf("%d nodes", nodes)}},
+			})
+		case "export":
+			os.WriteFile(request.Filename, []byte(fmt.Sprintf("nodes: %d\n", nodes)), 0644)
+			encoder.Encode(Response{})
+		}
+	}
+}
+
+func startTestPlugin(t *testing.T, mode string) []*Plugin {
+	t.Setenv("TUKEY_TEST_PLUGIN", mode)
+	plugins, err := Start(t.TempDir(), "1.0.0", []config.PluginConfig{{Command: os.Args[0], Args: []string{"-test.run=^$"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { Close(plugins) })
+	return plugins
+}
+
+func TestPlugin(t *testing.T) {
+	plugins := startTestPlugin(t, "ok")
+	if len(plugins) != 1 || plugins[0].Manifest.Name != "acme-ok" {
+		t.Fatalf("unexpected plugins: %+v", plugins)
+	}
+
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph([]*models.ParsedFile{{
+		Path:     "app/a.php",
+		Elements: []models.CodeElement{{Type: "function", Name: "a", Line: 1}, {Type: "function", Name: "b", Line: 2}},
+	}})
+	result := &models.AnalysisResult{Graph: graph}
+	metrics, findings := analyzer.RunPasses(result)
+	if metrics["acmeNodes-ok"] != 2 {
+		t.Errorf("expected the plugin's metric, got %v", metrics)
+	}
+	found := false
+	for _, finding := range findings {
+		found = found || (finding.Pass == "acmeNodes-ok" && finding.Message == "2 nodes")
+	}
+	if !found {
+		t.Errorf("expected the plugin's finding, got %+v", findings)
+	}
+
+	exporter, ok := output.Get("acme-ok")
+	if !ok {
+		t.Fatal("expected the plugin's exporter to be registered")
+	}
+	path := filepath.Join(t.TempDir(), "report.acme")
+	if err := exporter.Export(result, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "nodes: 2\n" {
+		t.Errorf("unexpected export %q", data)
+	}
+	if err := plugins[0].Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPlugin_Errors(t *testing.T) {
+	plugins := startTestPlugin(t, "broken")
+	metrics, _ := analyzer.RunPasses(&models.AnalysisResult{Graph: analyzer.NewDependencyTracker().BuildDependencyGraph(nil)})
+	if metrics["acmeNodes-broken"] != 0 {
+		t.Errorf("expected no metric from a failed pass, got %v", metrics)
+	}
+	if err := plugins[0].Err(); err == nil || !strings.Contains(err.Error(), "no licence") {
+		t.Errorf("expected the plugin's error, got %v", err)
+	}
+
+	t.Setenv("TUKEY_TEST_PLUGIN", "broken")
+	if _, err := Start(t.TempDir(), "1.0.0", []config.PluginConfig{{Command: os.Args[0], Args: []string{"-test.run=^$"}}}); err == nil ||
+		!strings.Contains(err.Error(), "already registered") {
+		t.Errorf("expected a second plugin with the same pass to be refused, got %v", err)
+	}
+	if _, err := Start(t.TempDir(), "1.0.0", []config.PluginConfig{{Command: "./missing-plugin"}}); err == nil {
+		t.Error("expected an error for a missing plugin")
+	}
+}
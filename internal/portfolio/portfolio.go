@@ -0,0 +1,274 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package portfolio summarizes the analyses of many projects, as written by `tukey batch`,
+// and compares them side by side and against an earlier summary
+package portfolio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// FileName is the summary's name in a batch output directory
+const FileName = "portfolio.json"
+
+// Summary is the combined outcome of analyzing several projects
+type Summary struct {
+	Version     string           `json:"version"`
+	GeneratedAt string           `json:"generatedAt"`
+	ExitCode    int              `json:"exitCode"` // The highest of the projects' exit codes
+	Totals      runstatus.Counts `json:"totals"`
+	Projects    []Project        `json:"projects"`
+}
+
+// Project is one project's outcome
+type Project struct {
+	Name       string              `json:"name"`
+	Root       string              `json:"root"`
+	Report     string              `json:"report,omitempty"` // Relative to the summary's directory
+	Status     string              `json:"status"`
+	ExitCode   int                 `json:"exitCode"`
+	DurationMs int64               `json:"durationMs"`
+	Counts     runstatus.Counts    `json:"counts"`
+	Metrics    map[string]int      `json:"metrics,omitempty"`
+	Findings   []runstatus.Finding `json:"findings"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// Add appends a project, adding its counts to the totals and its exit code to the summary's
+func (s *Summary) Add(project Project) {
+	s.Projects = append(s.Projects, project)
+	s.ExitCode = max(s.ExitCode, project.ExitCode)
+	s.Totals.Files += project.Counts.Files
+	s.Totals.ParsedFiles += project.Counts.ParsedFiles
+	s.Totals.ParseErrors += project.Counts.ParseErrors
+	s.Totals.Elements += project.Counts.Elements
+	s.Totals.Nodes += project.Counts.Nodes
+	s.Totals.Edges += project.Counts.Edges
+	s.Totals.Orphans += project.Counts.Orphans
+}
+
+// Write saves the summary as JSON
+func (s *Summary) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// FromStatus makes a project of a single run's status, named after its root
+func FromStatus(status *runstatus.Status) Project {
+	project := Project{
+		Name:       filepath.Base(status.Root),
+		Root:       status.Root,
+		Status:     status.Status,
+		ExitCode:   status.ExitCode,
+		DurationMs: status.DurationMs,
+		Counts:     status.Counts,
+		Metrics:    status.Metrics,
+		Findings:   status.Findings,
+		Error:      status.Error,
+	}
+	if project.Findings == nil {
+		project.Findings = []runstatus.Finding{}
+	}
+	return project
+}
+
+// Load reads a summary from a portfolio.json, a batch output directory holding one, or
+// a single run's run-status.json
+func Load(path string) (*Summary, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, FileName)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		Projects json.RawMessage `json:"projects"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("%s isn't a portfolio summary or run status: %w", path, err)
+	}
+	if probe.Projects != nil {
+		summary := &Summary{}
+		if err := json.Unmarshal(data, summary); err != nil {
+			return nil, err
+		}
+		return summary, nil
+	}
+
+	var status runstatus.Status
+	if err := json.Unmarshal(data, &status); err != nil || status.Status == "" {
+		return nil, fmt.Errorf("%s isn't a portfolio summary or run status", path)
+	}
+	if status.Root == "" {
+		return nil, fmt.Errorf("%s doesn't record the analyzed directory", path)
+	}
+	summary := &Summary{Version: status.Version, GeneratedAt: status.FinishedAt}
+	summary.Add(FromStatus(&status))
+	return summary, nil
+}
+
+// Merge combines summaries of separate runs into one. Projects are matched by name, so
+// each may appear only once.
+func Merge(summaries ...*Summary) (*Summary, error) {
+	merged := &Summary{Projects: []Project{}}
+	seen := make(map[string]bool)
+	for _, summary := range summaries {
+		if summary.GeneratedAt > merged.GeneratedAt {
+			merged.Version, merged.GeneratedAt = summary.Version, summary.GeneratedAt
+		}
+		for _, project := range summary.Projects {
+			if seen[project.Name] {
+				return nil, fmt.Errorf("project %q appears more than once", project.Name)
+			}
+			seen[project.Name] = true
+			merged.Add(project)
+		}
+	}
+	if len(merged.Projects) == 0 {
+		return nil, errors.New("no projects to compare")
+	}
+	return merged, nil
+}
+
+// Row is one project's line in a comparison, with the change since the previous summary
+type Row struct {
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Files           int     `json:"files"`
+	Nodes           int     `json:"nodes"`
+	Edges           int     `json:"edges"`
+	Coupling        float64 `json:"coupling"` // Edges per node
+	Cycles          int     `json:"cycles"`
+	MaxComplexity   int     `json:"maxComplexity"`
+	Orphans         int     `json:"orphans"`
+	Findings        int     `json:"findings"`
+	Maintainability int     `json:"maintainability"`    // 0 to 100, higher is better
+	Previous        *Row    `json:"previous,omitempty"` // nil when the project is new
+}
+
+// Comparison lines projects up side by side, least maintainable first
+type Comparison struct {
+	GeneratedAt string   `json:"generatedAt"`
+	Since       string   `json:"since,omitempty"` // When the previous summary was generated
+	Rows        []*Row   `json:"rows"`
+	Total       *Row     `json:"total"`
+	Removed     []string `json:"removed,omitempty"` // Projects only in the previous summary
+}
+
+// Compare builds the comparison of current's projects, against previous when it isn't nil
+func Compare(current, previous *Summary) *Comparison {
+	comparison := &Comparison{GeneratedAt: current.GeneratedAt, Rows: []*Row{}}
+	before := make(map[string]*Row)
+	if previous != nil {
+		comparison.Since = previous.GeneratedAt
+		for _, project := range previous.Projects {
+			before[project.Name] = rowOf(project)
+		}
+	}
+
+	total := &Row{Name: "Total"}
+	var previousTotal *Row
+	for _, project := range current.Projects {
+		row := rowOf(project)
+		if row.Previous = before[project.Name]; row.Previous != nil {
+			delete(before, project.Name)
+			if previousTotal == nil {
+				previousTotal = &Row{Name: "Total"}
+			}
+			accumulate(previousTotal, row.Previous)
+		}
+		comparison.Rows = append(comparison.Rows, row)
+		accumulate(total, row)
+	}
+	finishTotal(total, len(comparison.Rows))
+	if previousTotal != nil {
+		finishTotal(previousTotal, 0)
+		total.Previous = previousTotal
+	}
+	comparison.Total = total
+
+	for name := range before {
+		comparison.Removed = append(comparison.Removed, name)
+	}
+	sort.Strings(comparison.Removed)
+	sort.SliceStable(comparison.Rows, func(i, j int) bool {
+		if comparison.Rows[i].Maintainability != comparison.Rows[j].Maintainability {
+			return comparison.Rows[i].Maintainability < comparison.Rows[j].Maintainability
+		}
+		return comparison.Rows[i].Name < comparison.Rows[j].Name
+	})
+	return comparison
+}
+
+// rowOf measures a project
+func rowOf(project Project) *Row {
+	row := &Row{
+		Name:          project.Name,
+		Status:        project.Status,
+		Files:         project.Counts.Files,
+		Nodes:         project.Counts.Nodes,
+		Edges:         project.Counts.Edges,
+		Cycles:        project.Metrics["cycles"],
+		MaxComplexity: project.Metrics["maxComplexity"],
+		Orphans:       project.Counts.Orphans,
+		Findings:      len(project.Findings),
+	}
+	if row.Nodes > 0 {
+		row.Coupling = float64(row.Edges) / float64(row.Nodes)
+	}
+	row.Maintainability = Maintainability(row)
+	return row
+}
+
+// accumulate adds a project's row to the total. Maintainability is summed weighted by
+// nodes, and averaged by finishTotal.
+func accumulate(total, row *Row) {
+	total.Files += row.Files
+	total.Nodes += row.Nodes
+	total.Edges += row.Edges
+	total.Cycles += row.Cycles
+	total.MaxComplexity = max(total.MaxComplexity, row.MaxComplexity)
+	total.Orphans += row.Orphans
+	total.Findings += row.Findings
+	total.Maintainability += row.Maintainability * max(row.Nodes, 1)
+}
+
+// finishTotal averages the total's maintainability over its projects' nodes
+func finishTotal(total *Row, projects int) {
+	if total.Nodes > 0 {
+		total.Coupling = float64(total.Edges) / float64(total.Nodes)
+	}
+	total.Maintainability /= max(total.Nodes, 1)
+	if projects > 0 {
+		total.Status = fmt.Sprintf("%d projects", projects)
+	}
+}
+
+// Maintainability scores a project from 0 to 100, higher being easier to change. It
+// starts at 100 and loses up to 25 points for coupling above two edges per node, up to 25
+// for dependency cycles (per 100 nodes), up to 25 for complexity above 20 in the most
+// complex node, and up to 25 for the share of nodes that are orphans.
+func Maintainability(row *Row) int {
+	if row.Nodes == 0 {
+		return 0
+	}
+	score := 100.0
+	score -= min(25, max(0, row.Coupling-2)*5)
+	score -= min(25, float64(row.Cycles)*100/float64(row.Nodes)*5)
+	score -= min(25, max(0, float64(row.MaxComplexity-20))/2)
+	score -= min(25, float64(row.Orphans)/float64(row.Nodes)*50)
+	return int(score + 0.5)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package portfolio
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// project makes a fake analyzed project
+func project(name string, nodes, edges, orphans, cycles, complexity int) Project {
+	return Project{
+		Name:     name,
+		Root:     "/src/" + name,
+		Status:   "ok",
+		Counts:   runstatus.Counts{Files: nodes / 2, Nodes: nodes, Edges: edges, Orphans: orphans},
+		Metrics:  map[string]int{"cycles": cycles, "maxComplexity": complexity},
+		Findings: []runstatus.Finding{},
+	}
+}
+
+func TestLoadAndMerge(t *testing.T) {
+	dir := t.TempDir()
+	batch := &Summary{Version: "1.0.0", GeneratedAt: "2025-03-01T00:00:00Z"}
+	batch.Add(project("api", 100, 150, 5, 0, 12))
+	batch.Add(project("web", 40, 120, 10, 2, 30))
+	if err := batch.Write(filepath.Join(dir, FileName)); err != nil {
+		t.Fatal(err)
+	}
+
+	status := runstatus.New("1.0.1")
+	status.Root = "/src/worker"
+	status.Counts = runstatus.Counts{Files: 3, Nodes: 10, Edges: 8}
+	status.Finish(runstatus.ExitOK)
+	statusPath := filepath.Join(dir, "run-status.json")
+	if err := status.Write(statusPath); err != nil {
+		t.Fatal(err)
+	}
+
+	fromDir, err := Load(dir)
+	if err != nil || len(fromDir.Projects) != 2 || fromDir.Totals.Nodes != 140 {
+		t.Fatalf("expected the batch summary from its directory, got %+v (%v)", fromDir, err)
+	}
+	single, err := Load(statusPath)
+	if err != nil || len(single.Projects) != 1 || single.Projects[0].Name != "worker" || single.Projects[0].Counts.Edges != 8 {
+		t.Fatalf("expected a run status as a single project, got %+v (%v)", single, err)
+	}
+
+	merged, err := Merge(fromDir, single)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Projects) != 3 || merged.Totals.Nodes != 150 || merged.Version != "1.0.1" {
+		t.Errorf("unexpected merged summary: %+v", merged)
+	}
+	if _, err := Merge(fromDir, fromDir); err == nil || !strings.Contains(err.Error(), `"api"`) {
+		t.Errorf("expected an error for a project merged twice, got %v", err)
+	}
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestMaintainability(t *testing.T) {
+	tests := []struct {
+		name string
+		row  Row
+		want int
+	}{
+		{"healthy", Row{Nodes: 100, Coupling: 1.5, MaxComplexity: 10}, 100},
+		{"coupled", Row{Nodes: 100, Coupling: 4}, 90},
+		{"cyclic and complex", Row{Nodes: 100, Cycles: 2, MaxComplexity: 40}, 80},
+		{"capped", Row{Nodes: 10, Coupling: 20, Cycles: 10, MaxComplexity: 200, Orphans: 10}, 0},
+		{"empty", Row{}, 0},
+	}
+	for _, tt := range tests {
+		if got := Maintainability(&tt.row); got != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCompareAndRender(t *testing.T) {
+	previous := &Summary{GeneratedAt: "2025-02-01T00:00:00Z"}
+	previous.Add(project("api", 100, 150, 5, 0, 12))
+	previous.Add(project("legacy", 50, 50, 0, 0, 5))
+	current := &Summary{GeneratedAt: "2025-03-01T00:00:00Z"}
+	current.Add(project("api", 100, 250, 5, 1, 12))
+	current.Add(project("web", 40, 120, 10, 2, 30))
+
+	comparison := Compare(current, previous)
+	if len(comparison.Rows) != 2 || comparison.Rows[0].Name != "web" {
+		t.Fatalf("expected the least maintainable project first, got %+v", comparison.Rows)
+	}
+	api := comparison.Rows[1]
+	if api.Previous == nil || api.Coupling != 2.5 || api.Previous.Coupling != 1.5 || comparison.Rows[0].Previous != nil {
+		t.Errorf("unexpected rows: %+v", comparison.Rows)
+	}
+	if len(comparison.Removed) != 1 || comparison.Removed[0] != "legacy" {
+		t.Errorf("expected legacy to be removed, got %v", comparison.Removed)
+	}
+	if comparison.Total.Nodes != 140 || comparison.Total.Previous == nil || comparison.Total.Previous.Nodes != 100 {
+		t.Errorf("unexpected total: %+v", comparison.Total)
+	}
+
+	var markdown bytes.Buffer
+	if err := comparison.Markdown(&markdown); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"| Project | Status | Files |",
+		"| web (new) | ok |",
+		"| api | ok | 50 → | 100 → | 2.50 ↑1.00 | 1 ↑1 |",
+		"| **Total** | 2 projects |",
+		"No longer analyzed: legacy.",
+	} {
+		if !strings.Contains(markdown.String(), want) {
+			t.Errorf("expected Markdown to contain %q:\n%s", want, markdown.String())
+		}
+	}
+
+	var html bytes.Buffer
+	if err := comparison.HTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<td>api</td>`, `<span class="trend worse">↑1.00</span>`, `web <span class="trend">new</span>`} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("expected HTML to contain %q:\n%s", want, html.String())
+		}
+	}
+}
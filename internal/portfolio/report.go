@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package portfolio
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+)
+
+// column is one measurement in the comparison table. Better is 1 when a higher value is
+// an improvement, -1 when a lower one is, and 0 when growth is neither.
+type column struct {
+	Title  string
+	Value  func(row *Row) float64
+	Format string
+	Better int
+}
+
+var columns = []column{
+	{"Files", func(r *Row) float64 { return float64(r.Files) }, "%.0f", 0},
+	{"Nodes", func(r *Row) float64 { return float64(r.Nodes) }, "%.0f", 0},
+	{"Coupling", func(r *Row) float64 { return r.Coupling }, "%.2f", -1},
+	{"Cycles", func(r *Row) float64 { return float64(r.Cycles) }, "%.0f", -1},
+	{"Max complexity", func(r *Row) float64 { return float64(r.MaxComplexity) }, "%.0f", -1},
+	{"Orphans", func(r *Row) float64 { return float64(r.Orphans) }, "%.0f", -1},
+	{"Findings", func(r *Row) float64 { return float64(r.Findings) }, "%.0f", -1},
+	{"Maintainability", func(r *Row) float64 { return float64(r.Maintainability) }, "%.0f", 1},
+}
+
+// Cell is one rendered measurement with its trend: an arrow and the change since the
+// previous summary, and whether the change is "better" or "worse"
+type Cell struct {
+	Value string
+	Trend string
+	Class string
+}
+
+// String formats the cell as plain text
+func (c Cell) String() string {
+	if c.Trend == "" {
+		return c.Value
+	}
+	return c.Value + " " + c.Trend
+}
+
+// cells renders a row's measurements
+func cells(row *Row) []Cell {
+	result := make([]Cell, len(columns))
+	for i, col := range columns {
+		value := col.Value(row)
+		result[i].Value = fmt.Sprintf(col.Format, value)
+		if row.Previous == nil {
+			continue
+		}
+		delta := value - col.Value(row.Previous)
+		if strings.Trim(fmt.Sprintf(col.Format, math.Abs(delta)), "0.") == "" {
+			result[i].Trend = "→"
+			continue
+		}
+		arrow := "↑"
+		if delta < 0 {
+			arrow = "↓"
+		}
+		result[i].Trend = arrow + fmt.Sprintf(col.Format, math.Abs(delta))
+		switch {
+		case col.Better == 0:
+		case (delta > 0) == (col.Better > 0):
+			result[i].Class = "better"
+		default:
+			result[i].Class = "worse"
+		}
+	}
+	return result
+}
+
+// titles returns the table's header
+func titles() []string {
+	result := []string{"Project", "Status"}
+	for _, col := range columns {
+		result = append(result, col.Title)
+	}
+	return result
+}
+
+// scoreNote explains the maintainability score under the table
+const scoreNote = "Coupling is dependency edges per node. Maintainability starts at 100 and loses up to 25 points each " +
+	"for coupling above 2, cycles per 100 nodes, complexity above 20 in the most complex node, and the share of orphaned nodes. " +
+	"The total weights each project's score by its nodes."
+
+// Markdown writes the comparison as a Markdown document
+func (c *Comparison) Markdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Architecture portfolio\n\n")
+	fmt.Fprintf(&b, "%d projects, generated %s", len(c.Rows), orUnknown(c.GeneratedAt))
+	if c.Since != "" {
+		fmt.Fprintf(&b, "; trends since %s", c.Since)
+	}
+	b.WriteString(".\n\n")
+
+	header := titles()
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	b.WriteString("|---|---|" + strings.Repeat("---:|", len(columns)) + "\n")
+	writeRow := func(name, status string, row *Row) {
+		values := []string{name, status}
+		for _, cell := range cells(row) {
+			values = append(values, cell.String())
+		}
+		b.WriteString("| " + strings.Join(values, " | ") + " |\n")
+	}
+	for _, row := range c.Rows {
+		name := escapeMarkdown(row.Name)
+		if c.Since != "" && row.Previous == nil {
+			name += " (new)"
+		}
+		writeRow(name, row.Status, row)
+	}
+	writeRow("**Total**", c.Total.Status, c.Total)
+
+	if len(c.Removed) > 0 {
+		fmt.Fprintf(&b, "\nNo longer analyzed: %s.\n", escapeMarkdown(strings.Join(c.Removed, ", ")))
+	}
+	b.WriteString("\n" + scoreNote + "\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// HTML writes the comparison as a standalone HTML page
+func (c *Comparison) HTML(w io.Writer) error {
+	return reportTemplate.Execute(w, c)
+}
+
+// escapeMarkdown keeps a project name from breaking the table
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", "\\|", "*", "\\*", "_", "\\_").Replace(text)
+}
+
+func orUnknown(text string) string {
+	if text == "" {
+		return "at an unknown time"
+	}
+	return text
+}
+
+var reportTemplate = template.Must(template.New("portfolio").Funcs(template.FuncMap{
+	"cells":  cells,
+	"titles": titles,
+	"note":   func() string { return scoreNote },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Architecture portfolio · Tukey</title>
+<style>
+body { margin: 2em; font: 14px -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; }
+table { border-collapse: collapse; }
+th, td { padding: 6px 12px; border-bottom: 1px solid #d0d7de; text-align: right; white-space: nowrap; }
+th:nth-child(-n+2), td:nth-child(-n+2) { text-align: left; }
+tfoot td { font-weight: 600; border-top: 2px solid #1f2328; }
+.trend { color: #57606a; font-size: 12px; }
+.better { color: #1a7f37; }
+.worse { color: #cf222e; }
+.note { color: #57606a; max-width: 60em; }
+</style>
+</head>
+<body>
+<h1>Architecture portfolio</h1>
+<p>{{len .Rows}} projects, generated {{or .GeneratedAt "at an unknown time"}}{{if .Since}}; trends since {{.Since}}{{end}}.</p>
+<table>
+<thead><tr>{{range titles}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr><td>{{.Name}}{{if and $.Since (not .Previous)}} <span class="trend">new</span>{{end}}</td><td>{{.Status}}</td>
+{{- range cells .}}<td>{{.Value}}{{if .Trend}} <span class="trend {{.Class}}">{{.Trend}}</span>{{end}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+<tfoot><tr><td>Total</td><td>{{.Total.Status}}</td>
+{{- range cells .Total}}<td>{{.Value}}{{if .Trend}} <span class="trend {{.Class}}">{{.Trend}}</span>{{end}}</td>{{end}}</tr></tfoot>
+</table>
+{{- if .Removed}}
+<p>No longer analyzed: {{range $i, $name := .Removed}}{{if $i}}, {{end}}{{$name}}{{end}}.</p>
+{{- end}}
+<p class="note">{{note}}</p>
+</body>
+</html>
+`))
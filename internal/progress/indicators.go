@@ -10,6 +10,23 @@ import (
 	"time"
 )
 
+// accessible replaces animated, carriage-return repainted output with plain lines
+var accessible bool
+
+// SetAccessible switches progress indicators to screen-reader friendly output: spinners
+// print their message once, and progress bars print a line at each 25% milestone
+func SetAccessible(enabled bool) {
+	accessible = enabled
+}
+
+// The rate a bar's ETA is estimated from is an exponential moving average of the rate
+// over each rateInterval, so a run of large files doesn't swing it the way the average
+// since the start would, or the rate over a single update would
+const (
+	rateInterval  = 250 * time.Millisecond
+	rateSmoothing = 0.3 // Weight of the newest interval's rate
+)
+
 // ProgressBar represents a simple progress bar
 type ProgressBar struct {
 	total       int
@@ -18,6 +35,12 @@ type ProgressBar struct {
 	description string
 	startTime   time.Time
 	lastUpdate  time.Time
+	milestone   int           // Last 25% step announced in accessible mode
+	took        time.Duration // Time to complete, kept once done so redraws don't grow it
+	group       *MultiBar     // The multi-bar drawing the bar, if any
+	rate        float64       // Smoothed items per second; 0 until the first interval ends
+	sampleTime  time.Time     // Start of the interval being measured
+	sampleCount int           // Progress at sampleTime
 }
 
 // NewProgressBar creates a new progress bar
@@ -29,12 +52,22 @@ func NewProgressBar(total int, description string) *ProgressBar {
 		description: description,
 		startTime:   time.Now(),
 		lastUpdate:  time.Now(),
+		sampleTime:  time.Now(),
 	}
 }
 
 // Update increments the progress bar
 func (pb *ProgressBar) Update(increment int) {
+	if pb.group != nil {
+		pb.group.update(pb, func() { pb.current += increment })
+		return
+	}
 	pb.current += increment
+	pb.observe(time.Now())
+	if accessible {
+		pb.announce()
+		return
+	}
 
 	// Only update display every 100ms to avoid flickering
 	if time.Since(pb.lastUpdate) > 100*time.Millisecond || pb.current >= pb.total {
@@ -45,7 +78,16 @@ func (pb *ProgressBar) Update(increment int) {
 
 // SetCurrent sets the current progress value
 func (pb *ProgressBar) SetCurrent(current int) {
+	if pb.group != nil {
+		pb.group.update(pb, func() { pb.current = current })
+		return
+	}
 	pb.current = current
+	pb.observe(time.Now())
+	if accessible {
+		pb.announce()
+		return
+	}
 	if time.Since(pb.lastUpdate) > 100*time.Millisecond || pb.current >= pb.total {
 		pb.render()
 		pb.lastUpdate = time.Now()
@@ -54,40 +96,106 @@ func (pb *ProgressBar) SetCurrent(current int) {
 
 // Finish completes the progress bar
 func (pb *ProgressBar) Finish() {
+	if pb.group != nil {
+		pb.group.finish(pb)
+		return
+	}
 	pb.current = pb.total
+	if accessible {
+		pb.announceDone()
+		return
+	}
 	pb.render()
 	fmt.Println() // New line after completion
 }
 
 // render draws the progress bar
 func (pb *ProgressBar) render() {
-	percentage := float64(pb.current) / float64(pb.total) * 100
-	if percentage > 100 {
-		percentage = 100
+	fmt.Printf("\r%s", pb.line())
+}
+
+// line formats the progress bar, without moving the cursor
+func (pb *ProgressBar) line() string {
+	percentage := 100.0 // Nothing to do is done
+	if pb.total > 0 {
+		percentage = min(float64(pb.current)/float64(pb.total)*100, 100)
 	}
 
 	filled := int(float64(pb.width) * percentage / 100)
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", pb.width-filled)
 
 	elapsed := time.Since(pb.startTime)
+	if pb.current >= pb.total {
+		if pb.took == 0 {
+			pb.took = elapsed
+		}
+		elapsed = pb.took
+	}
 
 	// Estimate time remaining
 	var eta string
 	if pb.current > 0 && pb.current < pb.total {
-		rate := float64(pb.current) / elapsed.Seconds()
+		rate := pb.rate
+		if rate == 0 {
+			rate = float64(pb.current) / elapsed.Seconds() // Before the first interval ends
+		}
 		remaining := float64(pb.total-pb.current) / rate
-		eta = fmt.Sprintf(" ETA: %s", formatDuration(time.Duration(remaining)*time.Second))
+		eta = fmt.Sprintf(" %.1f files/s ETA: %s", rate, formatDuration(time.Duration(remaining*float64(time.Second))))
 	} else if pb.current >= pb.total {
-		eta = fmt.Sprintf(" Done in %s", formatDuration(elapsed))
+		eta = fmt.Sprintf(" Done in %s%s", formatDuration(elapsed), throughput(pb.total, elapsed))
 	} else {
 		eta = ""
 	}
 
-	// Format: Description [██████████░░░░░░░░] 65% (650/1000) ETA: 2s
-	fmt.Printf("\r%s [%s] %.1f%% (%d/%d)%s",
+	// Format: Description [██████████░░░░░░░░] 65% (650/1000) 120.5 files/s ETA: 2s
+	return fmt.Sprintf("%s [%s] %.1f%% (%d/%d)%s",
 		pb.description, bar, percentage, pb.current, pb.total, eta)
 }
 
+// observe folds the rate since the last interval ended into the smoothed rate, once the
+// interval is rateInterval long
+func (pb *ProgressBar) observe(now time.Time) {
+	interval := now.Sub(pb.sampleTime)
+	if interval < rateInterval {
+		return
+	}
+	rate := float64(pb.current-pb.sampleCount) / interval.Seconds()
+	if pb.rate == 0 {
+		pb.rate = rate
+	} else {
+		pb.rate = rateSmoothing*rate + (1-rateSmoothing)*pb.rate
+	}
+	pb.sampleTime, pb.sampleCount = now, pb.current
+}
+
+// throughput formats the average rate of a completed bar: " (587.6 files/s)", or ""
+// when it finished too quickly to measure
+func throughput(count int, elapsed time.Duration) string {
+	if elapsed < time.Millisecond || count == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%.1f files/s)", float64(count)/elapsed.Seconds())
+}
+
+// announce prints a plain progress line each time another quarter of the work completes
+func (pb *ProgressBar) announce() {
+	if pb.total <= 0 || pb.current >= pb.total {
+		return // Finish reports completion
+	}
+	step := pb.current * 4 / pb.total
+	if step > pb.milestone {
+		pb.milestone = step
+		fmt.Printf("%s: %d percent, %d of %d\n", pb.description, step*25, pb.current, pb.total)
+	}
+}
+
+// announceDone prints the plain line reporting the bar complete
+func (pb *ProgressBar) announceDone() {
+	elapsed := time.Since(pb.startTime)
+	fmt.Printf("%s: done, %d of %d in %s%s\n",
+		pb.description, pb.total, pb.total, formatDuration(elapsed), throughput(pb.total, elapsed))
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
@@ -125,6 +233,11 @@ func NewSpinner(message string) *Spinner {
 
 // Start begins the spinner animation
 func (s *Spinner) Start() {
+	if accessible {
+		fmt.Println(s.message)
+		return
+	}
+
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
@@ -1,7 +1,9 @@
 package progress
 
 import (
+	"io"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -35,9 +37,78 @@ func TestProgressBarLifecycle(t *testing.T) {
 	_ = r // could read captured output if needed
 }
 
+func TestProgressBarRate(t *testing.T) {
+	pb := NewProgressBar(100, "Parsing files")
+	start := pb.startTime
+
+	pb.current = 10
+	pb.observe(start.Add(time.Second)) // 10 files/s
+	pb.current = 50
+	pb.observe(start.Add(2 * time.Second)) // 40 files/s, smoothed
+	if pb.rate < 18.99 || pb.rate > 19.01 {
+		t.Errorf("expected a smoothed rate of 19 files/s, got %f", pb.rate)
+	}
+	pb.current = 90
+	pb.observe(start.Add(2*time.Second + 100*time.Millisecond)) // Too soon to measure
+	if pb.sampleCount != 50 {
+		t.Errorf("expected the interval to keep running, got a sample at %d", pb.sampleCount)
+	}
+
+	pb.current = 50
+	if line := pb.line(); !strings.Contains(line, "(50/100) 19.0 files/s ETA: 2.6s") {
+		t.Errorf("expected the smoothed rate and its ETA, got %q", line)
+	}
+	pb.current = 100
+	pb.startTime = time.Now().Add(-4 * time.Second)
+	if line := pb.line(); !strings.Contains(line, "Done in 4.0s (25.0 files/s)") {
+		t.Errorf("expected the average throughput once done, got %q", line)
+	}
+}
+
 func TestSpinnerStartStop(t *testing.T) {
 	s := NewSpinner("Working")
 	s.Start()
 	time.Sleep(200 * time.Millisecond) // let it tick once
 	s.Stop()                           // ensure it shuts down without panic
 }
+
+func TestAccessibleProgress(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	s := NewSpinner("Working")
+	s.Start()
+	s.Stop()
+
+	pb := NewProgressBar(8, "Parsing files")
+	for i := 0; i < 8; i++ {
+		pb.Update(1)
+	}
+	pb.Finish()
+
+	w.Close()
+	os.Stdout = old
+	data, _ := io.ReadAll(r)
+	out := string(data)
+
+	if strings.ContainsAny(out, "\r⠋█") {
+		t.Errorf("expected no repainting or animation in accessible mode:\n%q", out)
+	}
+	for _, want := range []string{
+		"Working\n",
+		"Parsing files: 25 percent, 2 of 8\n",
+		"Parsing files: 75 percent, 6 of 8\n",
+		"Parsing files: done, 8 of 8",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "percent") != 3 {
+		t.Errorf("expected one line per milestone:\n%s", out)
+	}
+}
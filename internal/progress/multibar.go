@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package progress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MultiBar draws several progress bars at once, each on its own line, so work running
+// in parallel, such as parsing each language, reports progress without the bars
+// repainting over one another. Its bars share one lock and redraw the whole block.
+type MultiBar struct {
+	mu       sync.Mutex
+	bars     []*ProgressBar
+	drawn    int // Lines of the block on the terminal, the cursor just below them
+	lastDraw time.Time
+}
+
+// NewMultiBar creates an empty multi-bar
+func NewMultiBar() *MultiBar {
+	return &MultiBar{}
+}
+
+// Add creates a bar drawn on the next line of the multi-bar. Its Update, SetCurrent,
+// and Finish are safe to call from different goroutines than the other bars'.
+func (mb *MultiBar) Add(total int, description string) *ProgressBar {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	pb := NewProgressBar(total, description)
+	pb.group = mb
+	mb.bars = append(mb.bars, pb)
+	return pb
+}
+
+// update applies a change to one of the bars and redraws the block, at most every 100ms
+// unless the bar completed
+func (mb *MultiBar) update(pb *ProgressBar, change func()) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	change()
+	pb.observe(time.Now())
+	if accessible {
+		pb.announce()
+		return
+	}
+	if time.Since(mb.lastDraw) > 100*time.Millisecond || pb.current >= pb.total {
+		mb.draw()
+	}
+}
+
+// finish completes one of the bars
+func (mb *MultiBar) finish(pb *ProgressBar) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	pb.current = pb.total
+	if accessible {
+		pb.announceDone()
+		return
+	}
+	mb.draw()
+}
+
+// draw moves the cursor back up over the block and repaints every bar on its line
+func (mb *MultiBar) draw() {
+	if mb.drawn > 0 {
+		fmt.Printf("\033[%dA", mb.drawn)
+	}
+	for _, pb := range mb.bars {
+		fmt.Printf("\r\033[K%s\n", pb.line())
+	}
+	mb.drawn = len(mb.bars)
+	mb.lastDraw = time.Now()
+}
@@ -0,0 +1,85 @@
+package progress
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMultiBar(t *testing.T) {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	mb := NewMultiBar()
+	bars := []*ProgressBar{mb.Add(40, "Parsing php files"), mb.Add(25, "Parsing javascript files")}
+	var wg sync.WaitGroup
+	for _, pb := range bars {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < pb.total; i++ {
+				pb.Update(1)
+			}
+			pb.Finish()
+		}()
+	}
+	wg.Wait()
+
+	w.Close()
+	os.Stdout = old
+	out := <-output
+
+	// Every redraw after the first moves up over the two lines and repaints both
+	draws := strings.Count(out, "\r\033[K") / 2
+	if draws < 2 || strings.Count(out, "\033[2A") != draws-1 {
+		t.Errorf("expected each redraw to repaint the block in place:\n%q", out)
+	}
+	last := out[strings.LastIndex(out, "\033[2A"):]
+	for _, want := range []string{"Parsing php files [", "(40/40) Done", "Parsing javascript files [", "(25/25) Done"} {
+		if !strings.Contains(last, want) {
+			t.Errorf("expected %q in the final block:\n%q", want, last)
+		}
+	}
+	if !strings.HasSuffix(out, "\n") {
+		t.Errorf("expected the cursor below the block:\n%q", out)
+	}
+}
+
+func TestMultiBarAccessible(t *testing.T) {
+	SetAccessible(true)
+	defer SetAccessible(false)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	mb := NewMultiBar()
+	php, js := mb.Add(4, "Parsing php files"), mb.Add(0, "Parsing javascript files")
+	for i := 0; i < 4; i++ {
+		php.Update(1)
+	}
+	php.Finish()
+	js.Finish()
+
+	w.Close()
+	os.Stdout = old
+	data, _ := io.ReadAll(r)
+	out := string(data)
+
+	if strings.ContainsAny(out, "\r\033█") {
+		t.Errorf("expected plain lines in accessible mode:\n%q", out)
+	}
+	for _, want := range []string{"Parsing php files: 50 percent, 2 of 4\n", "Parsing php files: done, 4 of 4", "Parsing javascript files: done, 0 of 0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package provenance
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// KeyEnv names the environment variable holding the report signing key
+const KeyEnv = "TUKEY_SIGNING_KEY"
+
+// New describes a report about root produced by this tool version, including the git
+// commit of root when it is a git checkout
+func New(root, toolVersion, toolCommit string) *models.Provenance {
+	prov := &models.Provenance{
+		Tool:        "tukey",
+		ToolVersion: toolVersion,
+		ToolCommit:  toolCommit,
+		Root:        root,
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		prov.Root = abs
+	}
+	prov.GitCommit, prov.GitDirty = gitState(root)
+	return prov
+}
+
+// Metadata describes a run of this tool version over root: the tree's git commit and
+// branch, and the host. The caller fills in the arguments and effective configuration.
+func Metadata(root, toolVersion, toolCommit string) *models.RunMetadata {
+	meta := &models.RunMetadata{
+		ToolVersion: toolVersion,
+		ToolCommit:  toolCommit,
+		Root:        root,
+		Host: models.HostInfo{
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			CPUs:      runtime.NumCPU(),
+			GoVersion: runtime.Version(),
+		},
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		meta.Root = abs
+	}
+	meta.Host.Hostname, _ = os.Hostname()
+	meta.GitCommit, meta.GitDirty = gitState(root)
+	if meta.GitCommit != "" {
+		if out, err := exec.Command("git", "-C", root, "symbolic-ref", "--short", "-q", "HEAD").Output(); err == nil {
+			meta.GitBranch = strings.TrimSpace(string(out))
+		}
+	}
+	return meta
+}
+
+// gitState returns the HEAD commit of the repository containing root and whether its
+// working tree has uncommitted changes. It returns "" when root isn't under git.
+func gitState(root string) (string, bool) {
+	out, err := exec.Command("git", "-C", root, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", false
+	}
+	commit := strings.TrimSpace(string(out))
+
+	status, err := exec.Command("git", "-C", root, "status", "--porcelain").Output()
+	return commit, err == nil && len(bytes.TrimSpace(status)) > 0
+}
+
+// Digest returns the hex SHA-256 of a JSON report in canonical form: object keys sorted,
+// no whitespace, and the provenance checksum and signature left out
+func Digest(report []byte) (string, error) {
+	canonical, err := canonicalize(report)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Sign returns the hex HMAC-SHA256 of a digest under key
+func Sign(digest string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(digest))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a report's embedded checksum, and its signature when it has one, returning
+// the report's provenance. Signed reports need the key they were signed with, and given a
+// key, unsigned reports are refused.
+func Verify(report []byte, key []byte) (*models.Provenance, error) {
+	var envelope struct {
+		Provenance *models.Provenance `json:"provenance"`
+	}
+	if err := json.Unmarshal(report, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid report: %w", err)
+	}
+	prov := envelope.Provenance
+	if prov == nil || prov.Checksum == "" {
+		return nil, errors.New("report is not signed (no provenance checksum)")
+	}
+
+	digest, err := Digest(report)
+	if err != nil {
+		return prov, err
+	}
+	if !hmac.Equal([]byte(digest), []byte(prov.Checksum)) {
+		return prov, errors.New("checksum mismatch: report was modified after export")
+	}
+
+	if prov.Signature == "" {
+		// With a key, a missing signature means it was stripped, and the checksum redone
+		if len(key) != 0 {
+			return prov, errors.New("report is not signed, but a signing key was given")
+		}
+		return prov, nil
+	}
+	if len(key) == 0 {
+		return prov, fmt.Errorf("report is signed; set %s to verify its signature", KeyEnv)
+	}
+	if !hmac.Equal([]byte(Sign(digest, key)), []byte(prov.Signature)) {
+		return prov, errors.New("signature mismatch: wrong key or forged report")
+	}
+	return prov, nil
+}
+
+// canonicalize re-encodes a JSON report with sorted keys and without the provenance
+// checksum/signature, so the digest doesn't depend on formatting
+func canonicalize(report []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(report))
+	decoder.UseNumber()
+
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid report: %w", err)
+	}
+	if prov, ok := doc["provenance"].(map[string]interface{}); ok {
+		delete(prov, "checksum")
+		delete(prov, "signature")
+	}
+	return json.Marshal(doc)
+}
@@ -0,0 +1,125 @@
+package provenance
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// sealedReport builds an indented report whose provenance carries a valid checksum
+func sealedReport(t *testing.T, key []byte) []byte {
+	t.Helper()
+	report := struct {
+		TotalFiles int                `json:"totalFiles"`
+		Provenance *models.Provenance `json:"provenance"`
+	}{
+		TotalFiles: 3,
+		Provenance: &models.Provenance{Tool: "tukey", ToolVersion: "1.0.0", Root: "/src", Algorithm: "sha256"},
+	}
+
+	data, _ := json.MarshalIndent(report, "", "  ")
+	digest, err := Digest(data)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	report.Provenance.Checksum = digest
+	if key != nil {
+		report.Provenance.Signature = Sign(digest, key)
+	}
+	data, _ = json.MarshalIndent(report, "", "  ")
+	return data
+}
+
+func TestVerify_Checksum(t *testing.T) {
+	data := sealedReport(t, nil)
+
+	prov, err := Verify(data, nil)
+	if err != nil {
+		t.Fatalf("expected intact report, got %v", err)
+	}
+	if prov.ToolVersion != "1.0.0" {
+		t.Errorf("unexpected provenance: %+v", prov)
+	}
+
+	// Formatting doesn't matter, content does
+	var compact bytes.Buffer
+	_ = json.Compact(&compact, data)
+	if _, err := Verify(compact.Bytes(), nil); err != nil {
+		t.Errorf("expected reformatted report to verify, got %v", err)
+	}
+
+	tampered := bytes.Replace(data, []byte(`"totalFiles": 3`), []byte(`"totalFiles": 4`), 1)
+	if _, err := Verify(tampered, nil); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+
+	if _, err := Verify([]byte(`{"totalFiles": 3}`), nil); err == nil {
+		t.Errorf("expected error for a report without provenance")
+	}
+}
+
+func TestVerify_Signature(t *testing.T) {
+	data := sealedReport(t, []byte("secret"))
+
+	if _, err := Verify(data, []byte("secret")); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	if _, err := Verify(data, []byte("wrong")); err == nil || !strings.Contains(err.Error(), "signature mismatch") {
+		t.Errorf("expected signature mismatch, got %v", err)
+	}
+	if _, err := Verify(data, nil); err == nil || !strings.Contains(err.Error(), KeyEnv) {
+		t.Errorf("expected missing key error, got %v", err)
+	}
+
+	// Stripping the signature and recomputing the checksum mustn't pass as intact
+	downgraded := sealedReport(t, nil)
+	if _, err := Verify(downgraded, []byte("secret")); err == nil || !strings.Contains(err.Error(), "not signed") {
+		t.Errorf("expected unsigned report to fail with a key, got %v", err)
+	}
+}
+
+func TestNew_GitState(t *testing.T) {
+	prov := New(t.TempDir(), "1.0.0", "abc1234")
+	if prov.Tool != "tukey" || prov.ToolCommit != "abc1234" {
+		t.Errorf("unexpected provenance: %+v", prov)
+	}
+	if prov.GitCommit != "" || prov.GitDirty {
+		t.Errorf("expected no git state outside a repository, got %+v", prov)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("checkout", "-q", "-b", "release")
+	os.WriteFile(filepath.Join(repo, "app.php"), []byte("<?php\n"), 0644)
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	meta := Metadata(repo, "1.0.0", "abc1234")
+	if meta.ToolVersion != "1.0.0" || meta.ToolCommit != "abc1234" || len(meta.GitCommit) != 40 || meta.GitBranch != "release" || meta.GitDirty {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if meta.Host.OS == "" || meta.Host.CPUs < 1 || meta.Host.GoVersion == "" {
+		t.Errorf("expected host details, got %+v", meta.Host)
+	}
+
+	if meta := Metadata(t.TempDir(), "1.0.0", ""); meta.GitCommit != "" || meta.GitBranch != "" {
+		t.Errorf("expected no git state outside a repository, got %+v", meta)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package redact replaces the names in an analysis result with salted hashes and strips
+// its source text, so a structural report can be shared outside the organization without
+// revealing proprietary names. Every occurrence of a name gets the same hash, so node IDs,
+// edges, and groupings still line up, and counts and metrics are left as they are.
+package redact
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// SaltEnv names the environment variable holding the salt names are hashed with. Reports
+// redacted with the same salt can be compared; without one, each report gets a random salt.
+const SaltEnv = "TUKEY_REDACT_SALT"
+
+// Root replaces the analyzed directory in redacted paths
+const Root = "/redacted"
+
+// hashLength is how many hex digits of a name's hash are kept, unless names collide
+const hashLength = 10
+
+// keep lists the fields, as "Type.Field" or just "Field", whose values are Tukey's own
+// vocabulary (element types, metric names, debt tags, versions) rather than names from
+// the code. Their values stay readable wherever they appear, such as in node IDs.
+var keep = map[string]bool{
+	"Type": true, "Kind": true, "Language": true, "Visibility": true, "Tag": true, "Tags": true,
+	"Pass": true, "Metric": true, "Finding": true, "Findings": true, "Suppressed": true,
+	"Expected": true, "Heuristics": true, "System": true, "FromSystem": true, "ToSystem": true,
+	"ModuleSystem": true, "CommonJSFeatures": true, "Features": true, "Manager": true,
+	"Extensions": true, "Unmatched": true, "Label": true, "Date": true, "ProcessingTime": true,
+	"ToolVersion": true, "ToolCommit": true, "Timestamp": true, "Algorithm": true,
+	"GitCommit": true, "OS": true, "Arch": true, "GoVersion": true,
+	"Provenance.Tool": true, "Provenance.Checksum": true, "Provenance.Signature": true,
+	"OpenAPIReport.Version": true, "Endpoint.Method": true,
+	"PrunedNode.Reason": true, "SkippedPath.Reason": true,
+	"SyntaxReport.Target": true, "SyntaxReport.Source": true, "SyntaxReport.Required": true,
+	"SyntaxFeature.Name": true, "SyntaxFeature.Version": true, "SyntaxUse.Feature": true, "SyntaxUse.Version": true,
+	"PlatformReport.Declared": true, "PlatformReport.Used": true, "PlatformExtension.Name": true,
+	"PlatformExtension.Symbols": true, "PlatformUse.Symbol": true,
+}
+
+// strip lists the fields holding source text, comments, or details of the machine and
+// the command line, which are emptied rather than hashed
+var strip = map[string]bool{
+	"Text": true, "Message": true, "Author": true, "Hostname": true, "Args": true,
+	"GitBranch": true, "Literal.Value": true, "APISymbol.Signature": true,
+	"Suppression.Reason": true, "OpenAPIReport.Title": true,
+}
+
+// relative lists the fields holding paths relative to the analyzed directory rather than
+// scanned paths
+var relative = map[string]bool{
+	"Churn": true, "DebtDirectory.Path": true, "DebtItem.File": true,
+	"SkippedPath.Path": true, "APISymbol.File": true, "SyntaxLocation.File": true,
+	"PlatformUse.File": true,
+}
+
+// words are kept readable even though no kept field names them: receivers and HTTP methods
+var words = []string{"this", "self", "parent", "super", "static", "global",
+	"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// identPattern finds the names in a string; separators, punctuation, and numbers such as
+// the line in a node ID are kept
+var identPattern = regexp.MustCompile(`[\p{L}_][\p{L}\p{N}_]*`)
+
+// Apply redacts result in place. Paths of the scanned files and their directories are
+// moved under Root with each name in them hashed, keeping extensions. Names elsewhere are
+// hashed with salt, or with a random salt when it's empty. Comments, literals, messages,
+// signatures, and the command line are removed. Run it after pathstyle.Apply and before
+// exporting; exporters that read the analyzed files can't honor it.
+func Apply(result *models.AnalysisResult, files []models.FileInfo, salt string) {
+	if salt == "" {
+		random := make([]byte, 16)
+		rand.Read(random)
+		salt = hex.EncodeToString(random)
+	}
+	r := &redactor{
+		salt:   salt,
+		known:  make(map[string]bool),
+		words:  make(map[string]bool),
+		hashes: make(map[string]string),
+		owners: make(map[string]string),
+	}
+	for _, word := range words {
+		r.words[word] = true
+	}
+	if result.Root != "" {
+		for _, root := range []string{result.Root, absPath(result.Root)} {
+			if root = filepath.ToSlash(filepath.Clean(root)); root != "." {
+				r.roots = append(r.roots, root)
+			}
+		}
+	}
+	for _, file := range files {
+		rel := filepath.ToSlash(filepath.Clean(file.RelativePath))
+		for ; rel != "." && rel != "/" && !r.known[rel]; rel = path.Dir(rel) {
+			r.known[rel] = true
+		}
+	}
+
+	// The effective configuration is keyed by Tukey's settings, so it's filtered rather than walked
+	var config map[string]interface{}
+	if result.Metadata != nil {
+		config, result.Metadata.Config = result.Metadata.Config, nil
+	}
+
+	value := reflect.ValueOf(result)
+	r.seen = make(map[visit]bool)
+	r.walk(value, "", false)
+	r.seen = make(map[visit]bool)
+	r.walk(value, "", true)
+
+	if result.Metadata != nil {
+		result.Metadata.Args = []string{}
+		result.Metadata.Config = settings(config)
+	}
+}
+
+// visit is a pointer, map, or slice already walked, by type since a struct and its first
+// field share an address
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// redactor walks a value twice: first collecting the kept fields' words, then rewriting
+type redactor struct {
+	salt   string
+	roots  []string        // The analyzed directory as given and absolute, with forward slashes
+	known  map[string]bool // Scanned files and directories, relative to the root
+	words  map[string]bool // Names left readable
+	hashes map[string]string
+	owners map[string]string // Hash to the name it stands for, to catch collisions
+	seen   map[visit]bool
+}
+
+func (r *redactor) walk(v reflect.Value, field string, rewrite bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || r.visited(v) {
+			return
+		}
+		r.walk(v.Elem(), field, rewrite)
+	case reflect.Interface:
+		if !v.IsNil() {
+			r.walk(v.Elem(), field, rewrite)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			if qualified := v.Type().Name() + "." + f.Name; keep[qualified] || strip[qualified] || relative[qualified] {
+				name = qualified
+			}
+			if rewrite && strip[name] && v.Field(i).CanSet() {
+				v.Field(i).Set(reflect.Zero(f.Type))
+				continue
+			}
+			r.walk(v.Field(i), name, rewrite)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.Len() == 0 || r.visited(v)) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			r.walk(v.Index(i), field, rewrite)
+		}
+	case reflect.Map:
+		if v.IsNil() || r.visited(v) {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			// Map values aren't addressable; rewrite a copy and store it back
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			r.walk(value, field, rewrite)
+			if key.Kind() == reflect.String {
+				if !rewrite {
+					r.collect(key.String(), field)
+					continue
+				}
+				v.SetMapIndex(key, reflect.Value{})
+				key = reflect.ValueOf(r.redact(key.String(), field)).Convert(key.Type())
+			}
+			if rewrite {
+				v.SetMapIndex(key, value)
+			}
+		}
+	case reflect.String:
+		if !rewrite {
+			r.collect(v.String(), field)
+		} else if v.CanSet() {
+			v.SetString(r.redact(v.String(), field))
+		}
+	}
+}
+
+// visited records a pointer, map, or slice, reporting whether it was already walked, so
+// values shared between nodes aren't hashed twice
+func (r *redactor) visited(v reflect.Value) bool {
+	key := visit{v.Pointer(), v.Type()}
+	if r.seen[key] {
+		return true
+	}
+	r.seen[key] = true
+	return false
+}
+
+// collect adds a kept field's words to those left readable
+func (r *redactor) collect(s, field string) {
+	if keep[field] {
+		for _, word := range identPattern.FindAllString(s, -1) {
+			r.words[word] = true
+		}
+	}
+}
+
+// redact returns the redacted form of a field's value
+func (r *redactor) redact(s, field string) string {
+	if s == "" || keep[field] {
+		return s
+	}
+	slashed := filepath.ToSlash(s)
+	rel, isPath := "", false
+	for _, root := range r.roots {
+		if slashed == root {
+			rel, isPath = ".", true
+			break
+		}
+		if strings.HasPrefix(slashed, root+"/") {
+			rel, isPath = slashed[len(root)+1:], true
+			break
+		}
+	}
+	// A bare name that happens to match a directory at the root is left to be hashed like
+	// other names, so namespaces stay consistent with the node IDs that contain them
+	if !isPath && r.known[path.Clean(slashed)] && (relative[field] || strings.ContainsAny(slashed, "/.")) {
+		rel, isPath = path.Clean(slashed), true
+	}
+	if !isPath {
+		return r.hashNames(s)
+	}
+
+	if rel != "." {
+		ext := path.Ext(rel)
+		if strings.Contains(ext, "/") {
+			ext = ""
+		}
+		rel = r.hashNames(strings.TrimSuffix(rel, ext)) + ext
+	}
+	if relative[field] {
+		return rel
+	}
+	if rel == "." {
+		return Root
+	}
+	return Root + "/" + rel
+}
+
+// hashNames replaces the names in s with their hashes
+func (r *redactor) hashNames(s string) string {
+	return identPattern.ReplaceAllStringFunc(s, func(name string) string {
+		if r.words[name] {
+			return name
+		}
+		return r.hash(name)
+	})
+}
+
+// hash returns a name's hash: "h" and the first hex digits of its salted SHA-256, more of
+// them when the short form is taken by another name, so distinct names stay distinct
+func (r *redactor) hash(name string) string {
+	if hashed, ok := r.hashes[name]; ok {
+		return hashed
+	}
+	sum := sha256.Sum256([]byte(r.salt + "\x00" + name))
+	digest := hex.EncodeToString(sum[:])
+	hashed := "h" + digest[:hashLength]
+	for n := hashLength + 1; r.owners[hashed] != "" && n <= len(digest); n++ {
+		hashed = "h" + digest[:n]
+	}
+	r.hashes[name] = hashed
+	r.owners[hashed] = name
+	return hashed
+}
+
+// settings keeps the switches and numbers of the effective configuration and drops its
+// strings, lists, and maps, which name directories, patterns, and groups
+func settings(config map[string]interface{}) map[string]interface{} {
+	kept := make(map[string]interface{})
+	for name, value := range config {
+		switch value.(type) {
+		case bool, float64, int:
+			kept[name] = value
+		}
+	}
+	return kept
+}
+
+func absPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return p
+}
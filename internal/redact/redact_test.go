@@ -0,0 +1,136 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// sample builds a small result: a controller calling a service, with a debt marker,
+// a repeated literal, and the metadata of the run
+func sample() (*models.AnalysisResult, []models.FileInfo) {
+	files := []models.FileInfo{
+		{Path: "/src/acme/app/Http/UserController.php", RelativePath: "app/Http/UserController.php"},
+		{Path: "/src/acme/app/Billing/Invoice.php", RelativePath: "app/Billing/Invoice.php"},
+	}
+	controller := &models.DependencyNode{ID: `method:Acme\Http\UserController::show:12`, Name: "show", Type: "method",
+		File: files[0].Path, Namespace: `Acme\Http`, ClassName: "UserController", Score: 7, Owners: []string{"@acme/web"}}
+	invoice := &models.DependencyNode{ID: `class:Acme\Billing\Invoice:5`, Name: "Invoice", Type: "class",
+		File: files[1].Path, Namespace: `Acme\Billing`, Score: 3, Owners: controller.Owners}
+	ref := &models.DependencyRef{TargetID: invoice.ID, TargetName: "Invoice", Type: "instantiates", Context: "show", Count: 2}
+	controller.Dependencies = map[string]*models.DependencyRef{invoice.ID: ref}
+	invoice.Dependents = map[string]*models.DependencyRef{controller.ID: {TargetID: controller.ID, TargetName: "show", Type: "instantiates"}}
+
+	result := &models.AnalysisResult{
+		Root: "/src/acme",
+		Graph: &models.DependencyGraph{
+			Nodes:   map[string]*models.DependencyNode{controller.ID: controller, invoice.ID: invoice},
+			Orphans: []*models.DependencyNode{controller},
+		},
+		Churn: map[string]int{"app/Http/UserController.php": 40},
+		Debt: &models.DebtReport{Total: 1, Tags: map[string]int{"TODO": 1}, Directories: []*models.DebtDirectory{{
+			Path:  "app/Http",
+			Count: 1,
+			Items: []*models.DebtItem{{Tag: "TODO", Text: "ask Dana about the Acme contract", Author: "dana", File: "app/Http/UserController.php", Line: 14}},
+		}}},
+		Literals: &models.LiteralReport{Strings: []*models.Literal{{Value: "acme-secret-key", Count: 3, Files: 2}}},
+		Metadata: &models.RunMetadata{
+			ToolVersion: "1.4.0",
+			Root:        "/src/acme",
+			GitBranch:   "feature/acme-merger",
+			Host:        models.HostInfo{Hostname: "dana-laptop", OS: "linux"},
+			Args:        []string{"--redact", "/src/acme"},
+			Config:      map[string]interface{}{"redact": true, "outputFile": "acme.json", "maxParameters": float64(5)},
+		},
+	}
+	return result, files
+}
+
+func TestApply(t *testing.T) {
+	result, files := sample()
+	Apply(result, files, "pepper")
+
+	if len(result.Graph.Nodes) != 2 || result.Root != Root {
+		t.Fatalf("expected both nodes under %s, got %+v in %s", Root, result.Graph.Nodes, result.Root)
+	}
+	var controller, invoice *models.DependencyNode
+	for id, node := range result.Graph.Nodes {
+		if id != node.ID {
+			t.Errorf("expected the node's key to match its ID, got %s and %s", id, node.ID)
+		}
+		if node.Type == "class" {
+			invoice = node
+		} else {
+			controller = node
+		}
+	}
+	if controller == nil || invoice == nil || controller.Type != "method" || controller.Score != 7 || invoice.Score != 3 {
+		t.Fatalf("expected types and scores to be kept, got %+v and %+v", controller, invoice)
+	}
+	if !strings.HasPrefix(controller.ID, "method:") || !strings.HasSuffix(controller.ID, ":12") || strings.Contains(controller.ID, "User") {
+		t.Errorf("expected the ID's names to be hashed around its type and line, got %s", controller.ID)
+	}
+	if ref := controller.Dependencies[invoice.ID]; ref == nil || ref.TargetID != invoice.ID || ref.TargetName != invoice.Name || ref.Count != 2 || ref.Type != "instantiates" {
+		t.Errorf("expected the edge to point at the redacted node, got %+v", controller.Dependencies)
+	}
+	if invoice.Dependents[controller.ID] == nil || result.Graph.Orphans[0] != controller {
+		t.Errorf("expected the dependents and orphans to match the redacted IDs, got %+v", invoice.Dependents)
+	}
+	if invoice.Namespace == controller.Namespace || !strings.HasPrefix(controller.ID, "method:"+controller.Namespace+`\`) {
+		t.Errorf("expected namespaces to be hashed by segment, consistently with IDs, got %s in %s", controller.Namespace, controller.ID)
+	}
+	if len(invoice.Owners) != 1 || invoice.Owners[0] != controller.Owners[0] || !strings.HasPrefix(invoice.Owners[0], "@h") {
+		t.Errorf("expected shared owners to be hashed once, got %v", invoice.Owners)
+	}
+
+	if !strings.HasPrefix(controller.File, Root+"/h") || !strings.HasSuffix(controller.File, ".php") || strings.Contains(controller.File, "Http") {
+		t.Errorf("expected the file under %s with its extension, got %s", Root, controller.File)
+	}
+	relative := strings.TrimPrefix(controller.File, Root+"/")
+	if result.Churn[relative] != 40 || len(result.Churn) != 1 {
+		t.Errorf("expected churn keyed by the same path relative to the root, got %v", result.Churn)
+	}
+	item := result.Debt.Directories[0].Items[0]
+	if item.Text != "" || item.Author != "" || item.Tag != "TODO" || item.File != relative || result.Debt.Tags["TODO"] != 1 {
+		t.Errorf("expected the comment to be stripped and its tag kept, got %+v", item)
+	}
+	if dir := result.Debt.Directories[0].Path; !strings.HasPrefix(relative, dir+"/") {
+		t.Errorf("expected the directory %s to contain %s", dir, relative)
+	}
+	if literal := result.Literals.Strings[0]; literal.Value != "" || literal.Count != 3 {
+		t.Errorf("expected the literal's value to be stripped and its count kept, got %+v", literal)
+	}
+
+	meta := result.Metadata
+	if meta.Root != Root || meta.GitBranch != "" || meta.Host.Hostname != "" || meta.Host.OS != "linux" || len(meta.Args) != 0 || meta.ToolVersion != "1.4.0" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if len(meta.Config) != 2 || meta.Config["redact"] != true || meta.Config["outputFile"] != nil {
+		t.Errorf("expected only switches and numbers in the config, got %v", meta.Config)
+	}
+}
+
+func TestApply_Salt(t *testing.T) {
+	ids := func(salt string) string {
+		result, files := sample()
+		Apply(result, files, salt)
+		return result.Graph.Orphans[0].ID
+	}
+	if ids("pepper") != ids("pepper") {
+		t.Error("expected the same salt to give the same hashes")
+	}
+	if ids("pepper") == ids("salt") || ids("") == ids("") {
+		t.Error("expected different salts, and random ones, to give different hashes")
+	}
+}
+
+func TestHash_Collision(t *testing.T) {
+	r := &redactor{salt: "pepper", hashes: make(map[string]string), owners: make(map[string]string)}
+	first := r.hash("Invoice")
+	delete(r.hashes, "Invoice")
+	r.owners[first] = "Receipt" // Another name took the short hash
+	if second := r.hash("Invoice"); second == first || !strings.HasPrefix(second, first) {
+		t.Errorf("expected a longer hash after a collision, got %s and %s", first, second)
+	}
+}
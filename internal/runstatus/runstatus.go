@@ -0,0 +1,324 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package runstatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Exit codes returned by the tukey CLI. When several apply, the highest wins.
+const (
+	ExitOK          = 0 // Analysis completed and no threshold was exceeded
+	ExitFindings    = 1 // A configured threshold was exceeded
+	ExitParseErrors = 2 // Some files couldn't be parsed, so the results are incomplete
+	ExitUsage       = 3 // Invalid flags, config values, language, or format
+	ExitInternal    = 4 // Scanning, analysis, or export failed
+
+	// ExitVerifyFailed is returned by `tukey verify` alone, for a report whose checksum
+	// or signature doesn't match, or that isn't signed when a key is set
+	ExitVerifyFailed = 5
+)
+
+// Severity levels a finding can be configured with. Only errors affect the exit code;
+// warnings and infos are reported and recorded in the run status.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// ParseErrors names the finding of files that couldn't be parsed, for severities;
+// other findings are named by their metric
+const ParseErrors = "parseErrors"
+
+// statusNames are the run-status.json names for each exit code
+var statusNames = map[int]string{
+	ExitOK:          "ok",
+	ExitFindings:    "findings",
+	ExitParseErrors: "parse_errors",
+	ExitUsage:       "usage_error",
+	ExitInternal:    "internal_error",
+}
+
+// Status is the machine-readable outcome of a run, written as run-status.json
+type Status struct {
+	Status     string           `json:"status"`
+	ExitCode   int              `json:"exitCode"`
+	Version    string           `json:"version"`
+	Root       string           `json:"root,omitempty"`
+	StartedAt  string           `json:"startedAt"`
+	FinishedAt string           `json:"finishedAt"`
+	DurationMs int64            `json:"durationMs"`
+	TimingsMs  map[string]int64 `json:"timingsMs"` // Duration of each phase: scan, parse, analyze, export
+	Counts     Counts           `json:"counts"`
+	Metrics    map[string]int   `json:"metrics,omitempty"`
+	Findings   []Finding        `json:"findings"`
+	Error      string           `json:"error,omitempty"`
+
+	started time.Time
+}
+
+// Counts are the sizes of the analyzed project
+type Counts struct {
+	Files       int `json:"files"`
+	ParsedFiles int `json:"parsedFiles"`
+	ParseErrors int `json:"parseErrors"`
+	Elements    int `json:"elements"`
+	Nodes       int `json:"nodes"`
+	Edges       int `json:"edges"`
+	Orphans     int `json:"orphans"`
+}
+
+// Finding is a metric that exceeded its configured threshold, or fell below a minimum
+type Finding struct {
+	Metric    string `json:"metric"`
+	Value     int    `json:"value"`
+	Threshold int    `json:"threshold"`
+	Minimum   bool   `json:"minimum,omitempty"` // Threshold is the least allowed value
+	Severity  string `json:"severity"`          // SeverityInfo, SeverityWarning, or SeverityError
+}
+
+// New starts timing a run
+func New(version string) *Status {
+	now := time.Now()
+	return &Status{
+		Version:   version,
+		StartedAt: now.UTC().Format(time.RFC3339),
+		TimingsMs: make(map[string]int64),
+		Findings:  []Finding{},
+		started:   now,
+	}
+}
+
+// Phase records how long the named phase took since start
+func (s *Status) Phase(name string, start time.Time) {
+	s.TimingsMs[name] = time.Since(start).Milliseconds()
+}
+
+// Finish sets the final exit code and total duration
+func (s *Status) Finish(code int) {
+	s.ExitCode = code
+	s.Status = statusNames[code]
+	s.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	s.DurationMs = time.Since(s.started).Milliseconds()
+}
+
+// Write saves the status as JSON
+func (s *Status) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// metricFuncs compute the metrics thresholds can be set on, besides those the analyzer
+// passes (cycles, orphans, maxComplexity, longParameterLists, and any registered) declare
+var metricFuncs = map[string]func(result *models.AnalysisResult) int{
+	"nodes":          func(r *models.AnalysisResult) int { return r.Graph.TotalNodes },
+	"edges":          func(r *models.AnalysisResult) int { return r.Graph.TotalEdges },
+	"ambiguousNames": func(r *models.AnalysisResult) int { return len(r.Graph.AmbiguousNames) },
+	"moduleBoundaries": func(r *models.AnalysisResult) int {
+		if r.Graph.ModuleInterop == nil {
+			return 0
+		}
+		return len(r.Graph.ModuleInterop.Boundaries)
+	},
+	"includeCycles": func(r *models.AnalysisResult) int {
+		if r.Graph.Includes == nil {
+			return 0
+		}
+		return len(r.Graph.Includes.Cycles)
+	},
+	"packageViolations": func(r *models.AnalysisResult) int {
+		if r.Graph.Packages == nil {
+			return 0
+		}
+		return len(r.Graph.Packages.Violations)
+	},
+	"clones": func(r *models.AnalysisResult) int {
+		if r.Clones == nil {
+			return 0
+		}
+		return len(r.Clones.Clones)
+	},
+	"repeatedLiterals": func(r *models.AnalysisResult) int {
+		if r.Literals == nil {
+			return 0
+		}
+		return len(r.Literals.Strings) + len(r.Literals.Numbers)
+	},
+	"debtMarkers": func(r *models.AnalysisResult) int {
+		if r.Debt == nil {
+			return 0
+		}
+		return r.Debt.Total
+	},
+	"docCoverage": func(r *models.AnalysisResult) int {
+		if r.Docs == nil {
+			return 100
+		}
+		return int(r.Docs.Coverage) // Rounded down, so a minimum isn't met by rounding
+	},
+	"undocumentedAPI": func(r *models.AnalysisResult) int {
+		if r.Docs == nil {
+			return 0
+		}
+		return len(r.Docs.Undocumented)
+	},
+	"unimplementedEndpoints": func(r *models.AnalysisResult) int {
+		if r.OpenAPI == nil {
+			return 0
+		}
+		return len(r.OpenAPI.Unimplemented)
+	},
+	"undocumentedEndpoints": func(r *models.AnalysisResult) int {
+		if r.OpenAPI == nil {
+			return 0
+		}
+		return len(r.OpenAPI.Undocumented)
+	},
+	"featureFlags": func(r *models.AnalysisResult) int {
+		if r.Flags == nil {
+			return 0
+		}
+		return len(r.Flags.Flags)
+	},
+	"newerSyntax": func(r *models.AnalysisResult) int {
+		if r.Syntax == nil {
+			return 0
+		}
+		uses := 0
+		for _, feature := range r.Syntax.Features {
+			uses += len(feature.Uses)
+		}
+		return uses
+	},
+	"undeclaredExtensions": func(r *models.AnalysisResult) int {
+		if r.Platform == nil {
+			return 0
+		}
+		return len(r.Platform.Undeclared)
+	},
+}
+
+// SupportedMetrics returns the sorted names thresholds can be set on
+func SupportedMetrics() []string {
+	names := analyzer.PassMetrics()
+	for name := range metricFuncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// supported reports whether a threshold can be set on the named metric
+func supported(name string) bool {
+	if _, ok := metricFuncs[name]; ok {
+		return true
+	}
+	for _, metric := range analyzer.PassMetrics() {
+		if metric == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateThresholds reports the first threshold naming an unknown metric
+func ValidateThresholds(thresholds map[string]int) error {
+	for name := range thresholds {
+		if !supported(name) {
+			return fmt.Errorf("unknown threshold metric %q (supported: %v)", name, SupportedMetrics())
+		}
+	}
+	return nil
+}
+
+// ValidateSeverities reports the first severity naming an unknown finding or level
+func ValidateSeverities(severities map[string]string) error {
+	for name, level := range severities {
+		if !supported(name) && name != ParseErrors {
+			return fmt.Errorf("unknown finding %q for a severity (supported: %s and %v)", name, ParseErrors, SupportedMetrics())
+		}
+		switch level {
+		case SeverityInfo, SeverityWarning, SeverityError:
+		default:
+			return fmt.Errorf("unknown severity %q for %s (supported: %s, %s, %s)", level, name, SeverityInfo, SeverityWarning, SeverityError)
+		}
+	}
+	return nil
+}
+
+// SeverityOf returns the configured severity of a finding, SeverityError by default
+func SeverityOf(severities map[string]string, name string) string {
+	if level, ok := severities[name]; ok {
+		return level
+	}
+	return SeverityError
+}
+
+// ApplySeverities sets each finding's severity from severities
+func ApplySeverities(findings []Finding, severities map[string]string) {
+	for i := range findings {
+		findings[i].Severity = SeverityOf(severities, findings[i].Metric)
+	}
+}
+
+// HasErrors reports whether any finding has SeverityError, and so fails the run
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Metrics computes every supported metric for result. The analyzer passes run here, and
+// their findings are stored in result.Findings.
+func Metrics(result *models.AnalysisResult) map[string]int {
+	result.Graph.RLock()
+	defer result.Graph.RUnlock()
+
+	metrics, findings := analyzer.RunPasses(result)
+	result.Findings = findings
+	for name, fn := range metricFuncs {
+		metrics[name] = fn(result)
+	}
+	return metrics
+}
+
+// Check compares metrics against thresholds (maximum allowed values), returning the
+// metrics that exceed them sorted by name
+func Check(metrics, thresholds map[string]int) []Finding {
+	findings := []Finding{}
+	for name, max := range thresholds {
+		if value, ok := metrics[name]; ok && value > max {
+			findings = append(findings, Finding{Metric: name, Value: value, Threshold: max, Severity: SeverityError})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Metric < findings[j].Metric })
+	return findings
+}
+
+// CheckMinimums compares metrics against minimums (least allowed values), returning the
+// metrics below them sorted by name
+func CheckMinimums(metrics, minimums map[string]int) []Finding {
+	findings := []Finding{}
+	for name, min := range minimums {
+		if value, ok := metrics[name]; ok && value < min {
+			findings = append(findings, Finding{Metric: name, Value: value, Threshold: min, Minimum: true, Severity: SeverityError})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Metric < findings[j].Metric })
+	return findings
+}
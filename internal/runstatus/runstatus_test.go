@@ -0,0 +1,156 @@
+package runstatus
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestMetricsAndCheck(t *testing.T) {
+	simple := &models.DependencyNode{ID: "a", Score: 3}
+	complex := &models.DependencyNode{ID: "b", Score: 12}
+	result := &models.AnalysisResult{Graph: &models.DependencyGraph{
+		Nodes:      map[string]*models.DependencyNode{"a": simple, "b": complex},
+		TotalNodes: 2,
+		TotalEdges: 1,
+		Orphans:    []*models.DependencyNode{simple},
+		Packages: &models.PackageReport{
+			Violations: []*models.PackageDependency{{From: "web", To: "db"}},
+		},
+		LongParameters: &models.ParameterReport{
+			Functions: []*models.LongParameterList{{ID: "b", Parameters: []string{"a", "b", "c", "d", "e", "f"}}},
+		},
+	}}
+
+	metrics := Metrics(result)
+	if metrics["maxComplexity"] != 12 || metrics["orphans"] != 1 || metrics["packageViolations"] != 1 {
+		t.Errorf("unexpected metrics: %v", metrics)
+	}
+	if metrics["clones"] != 0 {
+		t.Errorf("expected no clones without clone detection, got %d", metrics["clones"])
+	}
+	if metrics["longParameterLists"] != 1 {
+		t.Errorf("expected one long parameter list, got %d", metrics["longParameterLists"])
+	}
+	result.Literals = &models.LiteralReport{
+		Strings: []*models.Literal{{Value: "paid", Count: 4}},
+		Numbers: []*models.Literal{{Value: "250", Count: 3}, {Value: "1.2", Count: 3}},
+	}
+	if got := Metrics(result)["repeatedLiterals"]; got != 3 {
+		t.Errorf("expected three repeated literals, got %d", got)
+	}
+	if metrics["docCoverage"] != 100 || metrics["undocumentedAPI"] != 0 {
+		t.Errorf("expected full coverage without a documentation report, got %v", metrics)
+	}
+	result.Docs = &models.DocReport{
+		DocStats:     models.DocStats{Public: 3, Documented: 2, Coverage: 66.7},
+		Undocumented: []*models.APISymbol{{Name: "send"}},
+	}
+	docs := Metrics(result)
+	if docs["docCoverage"] != 66 || docs["undocumentedAPI"] != 1 {
+		t.Errorf("expected coverage rounded down and one undocumented symbol, got %v", docs)
+	}
+	if below := CheckMinimums(docs, map[string]int{"docCoverage": 70}); len(below) != 1 || !below[0].Minimum || below[0].Value != 66 || below[0].Threshold != 70 {
+		t.Errorf("expected coverage below its minimum, got %+v", below)
+	}
+	if findings := CheckMinimums(docs, map[string]int{"docCoverage": 66}); len(findings) != 0 {
+		t.Errorf("expected a met minimum to pass, got %+v", findings)
+	}
+	result.Debt = &models.DebtReport{Total: 4}
+	if got := Metrics(result)["debtMarkers"]; got != 4 {
+		t.Errorf("expected four debt markers, got %d", got)
+	}
+	result.OpenAPI = &models.OpenAPIReport{
+		Unimplemented: []*models.Endpoint{{Method: "DELETE", Path: "/users/{id}"}},
+		Undocumented:  []*models.Endpoint{{Method: "POST", Path: "/users"}, {Method: "GET", Path: "/health"}},
+	}
+	if got := Metrics(result); got["unimplementedEndpoints"] != 1 || got["undocumentedEndpoints"] != 2 {
+		t.Errorf("expected one unimplemented and two undocumented endpoints, got %v", got)
+	}
+	result.Flags = &models.FlagReport{Flags: []*models.FeatureFlag{{Name: "new-checkout"}}}
+	if got := Metrics(result)["featureFlags"]; got != 1 {
+		t.Errorf("expected one feature flag, got %d", got)
+	}
+	result.Syntax = &models.SyntaxReport{Features: []*models.SyntaxFeature{
+		{Name: "enum", Uses: []*models.SyntaxLocation{{File: "a.php", Line: 3}, {File: "b.php", Line: 5}}},
+	}}
+	if got := Metrics(result)["newerSyntax"]; got != 2 {
+		t.Errorf("expected two uses of newer syntax, got %d", got)
+	}
+	result.Platform = &models.PlatformReport{Undeclared: []*models.PlatformExtension{{Name: "curl"}}}
+	if got := Metrics(result)["undeclaredExtensions"]; got != 1 {
+		t.Errorf("expected one undeclared extension, got %d", got)
+	}
+	if metrics["moduleBoundaries"] != 0 {
+		t.Errorf("expected no module boundaries without interop data, got %d", metrics["moduleBoundaries"])
+	}
+
+	findings := Check(metrics, map[string]int{"maxComplexity": 10, "orphans": 1, "edges": 0})
+	if len(findings) != 2 || findings[0].Metric != "edges" || findings[1].Metric != "maxComplexity" {
+		t.Errorf("expected edges and maxComplexity to exceed their thresholds, got %+v", findings)
+	}
+	if findings[1].Value != 12 || findings[1].Threshold != 10 {
+		t.Errorf("unexpected finding: %+v", findings[1])
+	}
+}
+
+func TestValidateThresholds(t *testing.T) {
+	if err := ValidateThresholds(map[string]int{"orphans": 5}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateThresholds(map[string]int{"cyclomatic": 5}); err == nil {
+		t.Errorf("expected error for unknown metric")
+	}
+}
+
+func TestSeverities(t *testing.T) {
+	if err := ValidateSeverities(map[string]string{"orphans": SeverityWarning, ParseErrors: SeverityInfo}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ValidateSeverities(map[string]string{"unusedThings": SeverityInfo}) == nil {
+		t.Error("expected error for an unknown finding")
+	}
+	if ValidateSeverities(map[string]string{"orphans": "fatal"}) == nil {
+		t.Error("expected error for an unknown level")
+	}
+
+	findings := Check(map[string]int{"orphans": 4, "cycles": 2}, map[string]int{"orphans": 1, "cycles": 0})
+	ApplySeverities(findings, map[string]string{"orphans": SeverityWarning})
+	if findings[0].Metric != "cycles" || findings[0].Severity != SeverityError || findings[1].Severity != SeverityWarning {
+		t.Errorf("unexpected severities %+v", findings)
+	}
+	if !HasErrors(findings) || HasErrors(findings[1:]) {
+		t.Error("expected only error findings to fail the run")
+	}
+	if SeverityOf(nil, ParseErrors) != SeverityError {
+		t.Error("expected findings to be errors by default")
+	}
+}
+
+func TestStatusWrite(t *testing.T) {
+	status := New("1.2.3")
+	status.Phase("scan", time.Now().Add(-25*time.Millisecond))
+	status.Counts.Files = 4
+	status.Finish(ExitParseErrors)
+
+	path := filepath.Join(t.TempDir(), "run-status.json")
+	if err := status.Write(path); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["status"] != "parse_errors" || decoded["exitCode"] != float64(2) {
+		t.Errorf("unexpected status: %s", data)
+	}
+	if timings := decoded["timingsMs"].(map[string]interface{}); timings["scan"].(float64) < 25 {
+		t.Errorf("expected scan timing of at least 25ms: %s", data)
+	}
+}
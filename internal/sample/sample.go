@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package sample picks a deterministic sample of files for a quick preview run and
+// extrapolates per-file counts from it to the whole tree
+package sample
+
+import (
+	"hash/fnv"
+	"math"
+	"path/filepath"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// z95 is the normal quantile for a two-sided 95% confidence interval
+const z95 = 1.96
+
+// buckets is the resolution of a sample percentage: 0.0001%
+const buckets = 1_000_000
+
+// Select returns the files whose relative path hashes into the first percent of the
+// buckets. The same path is always in or out of a given sample, so repeated runs parse
+// the same files and a sample grows with the tree. At least one file is kept.
+func Select(files []models.FileInfo, percent float64) []models.FileInfo {
+	limit := uint64(percent / 100 * buckets)
+	var sampled []models.FileInfo
+	first, firstBucket := -1, uint64(math.MaxUint64)
+	for i, file := range files {
+		bucket := Bucket(file.RelativePath)
+		if bucket < limit {
+			sampled = append(sampled, file)
+		}
+		if bucket < firstBucket {
+			first, firstBucket = i, bucket
+		}
+	}
+	if len(sampled) == 0 && first != -1 {
+		sampled = append(sampled, files[first])
+	}
+	return sampled
+}
+
+// Bucket places a relative path in one of the sample buckets
+func Bucket(path string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(filepath.ToSlash(path)))
+	return h.Sum64() % buckets
+}
+
+// metrics are the per-file counts Estimate extrapolates
+var metrics = []struct {
+	name  string
+	count func(*models.ParsedFile) int
+}{
+	{"lines", func(f *models.ParsedFile) int { return f.Lines }},
+	{"elements", func(f *models.ParsedFile) int { return len(f.Elements) }},
+	{"references", func(f *models.ParsedFile) int { return len(f.Usage) }},
+	{"debtMarkers", func(f *models.ParsedFile) int { return len(f.Debt) }},
+}
+
+// Estimate extrapolates the per-file counts of the sampled, parsed files to a tree of
+// total files: the sample mean times total, with a 95% interval from the sample's
+// standard error (corrected for sampling without replacement). The interval is the
+// estimate itself with fewer than two files, where there is no spread to go on.
+func Estimate(parsed []*models.ParsedFile, total int, percent float64) *models.SampleReport {
+	report := &models.SampleReport{Percent: percent, Files: total, Sampled: len(parsed), Estimates: []*models.SampleEstimate{}}
+	n, N := float64(len(parsed)), float64(total)
+	for _, metric := range metrics {
+		estimate := &models.SampleEstimate{Metric: metric.name}
+		report.Estimates = append(report.Estimates, estimate)
+		if n == 0 {
+			continue
+		}
+
+		sum, sumSquares := 0.0, 0.0
+		for _, file := range parsed {
+			value := float64(metric.count(file))
+			sum += value
+			sumSquares += value * value
+		}
+		mean := sum / n
+		estimate.Sampled = int(sum)
+		estimate.Estimate = mean * N
+		estimate.Low, estimate.High = estimate.Estimate, estimate.Estimate
+		if n < 2 {
+			continue
+		}
+		variance := (sumSquares - n*mean*mean) / (n - 1)
+		correction := math.Max(0, 1-n/N)
+		margin := z95 * N * math.Sqrt(math.Max(0, variance)/n*correction)
+		estimate.Low = math.Max(sum, estimate.Estimate-margin) // Never below what was counted
+		estimate.High = estimate.Estimate + margin
+	}
+	return report
+}
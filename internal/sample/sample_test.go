@@ -0,0 +1,72 @@
+package sample
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestSelect(t *testing.T) {
+	files := make([]models.FileInfo, 2000)
+	for i := range files {
+		files[i] = models.FileInfo{RelativePath: fmt.Sprintf("src/file%d.php", i)}
+	}
+
+	sampled := Select(files, 10)
+	if len(sampled) < 150 || len(sampled) > 250 {
+		t.Errorf("expected about 200 files in a 10%% sample, got %d", len(sampled))
+	}
+	again := Select(files[:1000], 10)
+	inFirstHalf := 0
+	for _, file := range sampled {
+		if Bucket(file.RelativePath) >= buckets/10 {
+			t.Fatalf("%s is outside the sample", file.RelativePath)
+		}
+		var n int
+		fmt.Sscanf(file.RelativePath, "src/file%d.php", &n)
+		if n < 1000 {
+			inFirstHalf++
+		}
+	}
+	if len(again) != inFirstHalf {
+		t.Errorf("expected a file's membership not to depend on the others, got %d and %d", len(again), inFirstHalf)
+	}
+
+	if got := Select(files[:3], 0.001); len(got) != 1 {
+		t.Errorf("expected at least one file, got %d", len(got))
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	parsed := []*models.ParsedFile{
+		{Lines: 100, Elements: make([]models.CodeElement, 2)},
+		{Lines: 200, Elements: make([]models.CodeElement, 2)},
+		{Lines: 300, Elements: make([]models.CodeElement, 2), Debt: make([]models.DebtMarker, 1)},
+	}
+	report := Estimate(parsed, 30, 10)
+	if report.Files != 30 || report.Sampled != 3 || len(report.Estimates) != 4 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	lines, elements := report.Estimates[0], report.Estimates[1]
+	if lines.Metric != "lines" || lines.Sampled != 600 || lines.Estimate != 6000 {
+		t.Errorf("unexpected lines estimate %+v", lines)
+	}
+	// Standard deviation 100, standard error 100/sqrt(3), 90% of the tree unsampled
+	margin := 1.96 * 30 * 100 / math.Sqrt(3) * math.Sqrt(0.9)
+	if math.Abs(lines.High-(6000+margin)) > 0.01 || math.Abs(lines.Low-(6000-margin)) > 0.01 {
+		t.Errorf("unexpected interval %.2f to %.2f", lines.Low, lines.High)
+	}
+	if elements.Estimate != 60 || elements.Low != 60 || elements.High != 60 {
+		t.Errorf("expected no spread without variance, got %+v", elements)
+	}
+	if debt := report.Estimates[3]; debt.Low < 1 {
+		t.Errorf("expected the interval never to fall below the sampled count, got %+v", debt)
+	}
+
+	if single := Estimate(parsed[:1], 30, 10).Estimates[0]; single.Low != 3000 || single.High != 3000 {
+		t.Errorf("expected a point estimate from one file, got %+v", single)
+	}
+}
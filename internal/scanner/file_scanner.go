@@ -4,86 +4,198 @@
 package scanner
 
 import (
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 
 	"github.com/boone-studios/tukey/internal/models"
 )
 
+// caseInsensitive is whether the platform's file systems usually ignore case, so
+// "Vendor" and "vendor" are the same directory
+var caseInsensitive = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
 // Scanner handles file discovery and filtering
 type Scanner struct {
 	rootPath    string
-	excludeDirs map[string]bool
+	excludeDirs map[string]bool // By dirKey; false for a default kept with IncludeDir
+	configured  map[string]bool // Exclusions added with AddExcludeDir, as opposed to the defaults
 	fileCount   int
 	extensions  map[string]bool
+	maxFileSize int64 // Bytes; 0 for no limit
+	gitignore   bool
+	sniffers    []sniffer
+	report      *models.ScanReport
 	mu          sync.Mutex
 }
 
+// sniffer recognizes a language's extensionless files by their first bytes
+type sniffer struct {
+	language string
+	sniff    func(header []byte) bool
+}
+
+// sniffSize is how much of an extensionless file sniffers see
+const sniffSize = 512
+
 // NewScanner creates a new file scanner instance
 func NewScanner(rootPath string) *Scanner {
-	// Common directories to exclude from scanning
+	// Directories to exclude whatever the language; parsers add their own with
+	// AddDefaultExcludes
 	excludeDirs := map[string]bool{
-		"vendor":       true,
-		"node_modules": true,
-		".git":         true,
-		".svn":         true,
-		"storage":      true,
-		"cache":        true,
-		"tmp":          true,
-		"temp":         true,
-		".idea":        true,
-		".vscode":      true,
+		".git":    true,
+		".svn":    true,
+		"cache":   true,
+		"tmp":     true,
+		"temp":    true,
+		".idea":   true,
+		".vscode": true,
 	}
 
 	return &Scanner{
 		rootPath:    rootPath,
 		excludeDirs: excludeDirs,
+		configured:  make(map[string]bool),
 		extensions:  make(map[string]bool),
 	}
 }
 
-// AddExcludeDir adds a directory to the exclusion list
+// AddExcludeDir adds a directory to the exclusion list: a name, skipped at any depth,
+// or a path relative to the root such as "src/legacy" (either separator)
 func (s *Scanner) AddExcludeDir(dir string) {
-	s.excludeDirs[dir] = true
+	s.excludeDirs[dirKey(dir)] = true
+	s.configured[dirKey(dir)] = true
+}
+
+// AddDefaultExcludes adds default exclusions, such as a parser's DefaultExcludes
+func (s *Scanner) AddDefaultExcludes(dirs []string) {
+	for _, dir := range dirs {
+		if _, seen := s.excludeDirs[dirKey(dir)]; !seen {
+			s.excludeDirs[dirKey(dir)] = true
+		}
+	}
+}
+
+// IncludeDir scans a directory the defaults or AddExcludeDir would otherwise skip
+func (s *Scanner) IncludeDir(dir string) {
+	s.excludeDirs[dirKey(dir)] = false
+	delete(s.configured, dirKey(dir))
+}
+
+// dirKey normalizes a directory name or relative path for matching: slash-separated,
+// without leading or trailing separators, and lowercase where case doesn't matter
+func dirKey(dir string) string {
+	key := strings.Trim(strings.ReplaceAll(dir, `\`, "/"), "/")
+	if caseInsensitive {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// SetMaxFileSize skips files larger than size bytes (0 for no limit)
+func (s *Scanner) SetMaxFileSize(size int64) {
+	s.maxFileSize = size
+}
+
+// UseGitignore skips the files and directories the .gitignore files in the tree ignore
+func (s *Scanner) UseGitignore() {
+	s.gitignore = true
+}
+
+// AddSniffer includes extensionless files whose first bytes sniff accepts, such as
+// executable scripts, recording language as the parser to read them with
+func (s *Scanner) AddSniffer(language string, sniff func(header []byte) bool) {
+	s.sniffers = append(s.sniffers, sniffer{language: language, sniff: sniff})
 }
 
 // ScanFiles discovers all PHP files in the codebase
 func (s *Scanner) ScanFiles() ([]models.FileInfo, error) {
 	var files []models.FileInfo
 	var mu sync.Mutex
+	var ignore gitignore
+	report := &models.ScanReport{
+		Extensions:  make(map[string]int),
+		Unmatched:   make(map[string]int),
+		SkippedDirs: []models.SkippedPath{},
+		Oversized:   []models.SkippedPath{},
+		MaxFileSize: s.maxFileSize,
+	}
 
 	err := filepath.Walk(s.rootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		relativePath, _ := filepath.Rel(s.rootPath, path)
 
-		// Skip if it's a directory we want to exclude
-		if info.IsDir() && s.shouldExcludeDir(info.Name()) {
-			return filepath.SkipDir
+		if info.IsDir() {
+			if path == s.rootPath {
+				if s.gitignore {
+					ignore.load(path, relativePath)
+				}
+				return nil
+			}
+			// Skip if it's a directory we want to exclude; an entry for its path takes
+			// precedence over one for its name
+			key := dirKey(relativePath)
+			if _, listed := s.excludeDirs[key]; !listed {
+				key = dirKey(info.Name())
+			}
+			reason := ""
+			switch {
+			case s.shouldExcludeDir(key) && s.configured[key]:
+				reason = "excluded"
+			case s.shouldExcludeDir(key):
+				reason = "default"
+			case s.gitignore && ignore.ignored(relativePath, true):
+				reason = "gitignore"
+			}
+			if reason != "" {
+				report.SkippedDirs = append(report.SkippedDirs, models.SkippedPath{Path: filepath.ToSlash(relativePath), Reason: reason})
+				return filepath.SkipDir
+			}
+			if s.gitignore {
+				ignore.load(path, relativePath)
+			}
+			return nil
 		}
 
-		// Only process PHP files
-		// todo: add support for other file types
-		if !info.IsDir() && s.hasAllowedExtension(path) {
-			relativePath, _ := filepath.Rel(s.rootPath, path)
-
+		ext := strings.ToLower(filepath.Ext(path))
+		language := ""
+		if ext == "" && info.Mode().IsRegular() {
+			language = s.sniff(path)
+		}
+		switch {
+		case !s.hasAllowedExtension(path) && language == "":
+			report.Unmatched[ext]++
+		case s.gitignore && ignore.ignored(relativePath, false):
+			report.Gitignored++
+		case s.maxFileSize > 0 && info.Size() > s.maxFileSize:
+			report.Oversized = append(report.Oversized, models.SkippedPath{Path: filepath.ToSlash(relativePath), Size: info.Size()})
+		default:
 			fileData := models.FileInfo{
 				Path:         path,
 				RelativePath: relativePath,
 				Size:         info.Size(),
+				Language:     language,
 			}
 
 			mu.Lock()
 			files = append(files, fileData)
 			s.fileCount++
 			mu.Unlock()
+			report.Extensions[ext]++
+			report.Matched++
 		}
 
 		return nil
 	})
 
+	s.mu.Lock()
+	s.report = report
+	s.mu.Unlock()
 	return files, err
 }
 
@@ -97,10 +209,9 @@ func (s *Scanner) SetExtensions(exts []string) {
 	}
 }
 
-// shouldExcludeDir checks if a directory should be excluded
-func (s *Scanner) shouldExcludeDir(dirName string) bool {
-	excluded, exists := s.excludeDirs[strings.ToLower(dirName)]
-	return exists && excluded
+// shouldExcludeDir checks if a directory, by its dirKey, should be excluded
+func (s *Scanner) shouldExcludeDir(key string) bool {
+	return s.excludeDirs[key]
 }
 
 // GetStats returns scanning statistics
@@ -110,6 +221,33 @@ func (s *Scanner) GetStats() (int, map[string]bool) {
 	return s.fileCount, s.excludeDirs
 }
 
+// Report returns what the last ScanFiles matched and skipped, and why; nil before a scan
+func (s *Scanner) Report() *models.ScanReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.report
+}
+
+// sniff returns the language of the first sniffer that recognizes the file at path
+func (s *Scanner) sniff(path string) string {
+	if len(s.sniffers) == 0 {
+		return ""
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	header := make([]byte, sniffSize)
+	n, _ := io.ReadFull(file, header)
+	for _, sn := range s.sniffers {
+		if sn.sniff(header[:n]) {
+			return sn.language
+		}
+	}
+	return ""
+}
+
 // hasAllowedExtension checks if the extension is expected of the set language
 func (s *Scanner) hasAllowedExtension(filename string) bool {
 	ext := strings.ToLower(filepath.Ext(filename))
@@ -48,3 +48,89 @@ func TestScanFiles_Golden(t *testing.T) {
 		t.Errorf("scanner output mismatch.\nGot:\n%s\nWant:\n%s", gotStr, wantStr)
 	}
 }
+
+func TestScanFiles_DefaultExcludes(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"src/App.js", "dist/app.js", "node_modules/lib/index.js", "storage/cache.js", ".git/hook.js"} {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("x"), 0644)
+	}
+
+	s := NewScanner(root)
+	s.SetExtensions([]string{".js"})
+	s.AddDefaultExcludes([]string{"node_modules", "dist"})
+	s.IncludeDir("dist")
+	files, err := s.ScanFiles()
+	if err != nil {
+		t.Fatalf("ScanFiles failed: %v", err)
+	}
+
+	var got []string
+	for _, f := range files {
+		got = append(got, filepath.ToSlash(f.RelativePath))
+	}
+	sort.Strings(got)
+	want := []string{"dist/app.js", "src/App.js", "storage/cache.js"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestScanFiles_ExcludeMatching(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"src/legacy/Old.php", "lib/legacy/Kept.php", "Tests/UserTest.php", "src/App.php"} {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("<?php"), 0644)
+	}
+
+	scan := func() []string {
+		s := NewScanner(root)
+		s.SetExtensions([]string{".php"})
+		s.AddExcludeDir(`src\legacy\`)
+		s.AddExcludeDir("tests")
+		files, err := s.ScanFiles()
+		if err != nil {
+			t.Fatalf("ScanFiles failed: %v", err)
+		}
+		var got []string
+		for _, f := range files {
+			got = append(got, filepath.ToSlash(f.RelativePath))
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	defer func(saved bool) { caseInsensitive = saved }(caseInsensitive)
+	caseInsensitive = false
+	if got := strings.Join(scan(), ","); got != "Tests/UserTest.php,lib/legacy/Kept.php,src/App.php" {
+		t.Errorf("expected only src/legacy to be excluded, got %s", got)
+	}
+	caseInsensitive = true
+	if got := strings.Join(scan(), ","); got != "lib/legacy/Kept.php,src/App.php" {
+		t.Errorf("expected Tests to match tests ignoring case, got %s", got)
+	}
+}
+
+func TestScanFiles_Sniffers(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "bin"), 0755)
+	os.WriteFile(filepath.Join(root, "bin", "console"), []byte("#!/usr/bin/env php\n<?php\n"), 0755)
+	os.WriteFile(filepath.Join(root, "bin", "deploy"), []byte("#!/bin/sh\n"), 0755)
+	os.WriteFile(filepath.Join(root, "App.php"), []byte("<?php"), 0644)
+
+	s := NewScanner(root)
+	s.SetExtensions([]string{".php"})
+	s.AddSniffer("php", func(header []byte) bool { return strings.HasPrefix(string(header), "#!/usr/bin/env php") })
+	files, err := s.ScanFiles()
+	if err != nil {
+		t.Fatalf("ScanFiles failed: %v", err)
+	}
+	if len(files) != 2 || files[0].Language != "" || files[1].RelativePath != filepath.Join("bin", "console") || files[1].Language != "php" {
+		t.Errorf("expected App.php and the sniffed console script, got %+v", files)
+	}
+	if report := s.Report(); report.Extensions[""] != 1 || report.Unmatched[""] != 1 {
+		t.Errorf("expected one sniffed and one unmatched extensionless file, got %v and %v", report.Extensions, report.Unmatched)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is one pattern from a .gitignore file
+type ignoreRule struct {
+	base    string // Directory of the .gitignore, relative to the root and slash-separated; "" for the root
+	pattern *regexp.Regexp
+	negate  bool // "!pattern" re-includes what an earlier rule ignored
+	dirOnly bool // "pattern/" only matches directories
+}
+
+// gitignore holds the rules of the .gitignore files met so far in a walk. It covers the
+// common syntax: comments, negation, directory-only and anchored patterns, *, ?, [...],
+// and **.
+type gitignore struct {
+	rules []ignoreRule
+}
+
+// load adds the rules of the .gitignore in dir, whose path relative to the root is rel
+func (g *gitignore) load(dir, rel string) {
+	file, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	base := filepath.ToSlash(rel)
+	if base == "." {
+		base = ""
+	}
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		line := strings.TrimRight(lines.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate, line = true, line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:] // An escaped leading ! or #
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly, line = true, strings.TrimSuffix(line, "/")
+		}
+		// A pattern with a slash is anchored to its .gitignore; others match a name at any depth
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+		expr := globToRegexp(line)
+		if !anchored {
+			expr = "(?:.*/)?" + expr
+		}
+		if caseInsensitive {
+			expr = "(?i)" + expr
+		}
+		rule.pattern = regexp.MustCompile("^" + expr + "$")
+		g.rules = append(g.rules, rule)
+	}
+}
+
+// ignored reports whether the path relative to the root is ignored; the last rule that
+// matches decides
+func (g *gitignore) ignored(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	ignored := false
+	for _, rule := range g.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		sub := rel
+		if rule.base != "" {
+			if len(rel) <= len(rule.base) || rel[len(rule.base)] != '/' || !samePath(rel[:len(rule.base)], rule.base) {
+				continue
+			}
+			sub = rel[len(rule.base)+1:]
+		}
+		if rule.pattern.MatchString(sub) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// samePath compares slash-separated paths, ignoring case where the platform does
+func samePath(a, b string) bool {
+	if caseInsensitive {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// globToRegexp translates a gitignore glob into a regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			if end := strings.IndexByte(glob[i+1:], ']'); end > 0 {
+				class := glob[i+1 : i+1+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += end + 1
+				continue
+			}
+			b.WriteString(`\[`)
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestGitignore(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, ".gitignore"), []byte("# build output\n/build/\n*.min.js\n!keep.min.js\nlogs/**/*.php\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "src", "gen"), 0755)
+	os.WriteFile(filepath.Join(root, "src", ".gitignore"), []byte("gen/\n"), 0644)
+
+	var g gitignore
+	g.load(root, ".")
+	g.load(filepath.Join(root, "src"), "src")
+
+	for rel, want := range map[string]bool{
+		"build":               true,
+		"src/build":           false, // Anchored to the root
+		"app.min.js":          true,
+		"src/vendor.min.js":   true,
+		"keep.min.js":         false,
+		"logs/a/b/error.php":  true,
+		"logs/error.php":      true,
+		"src/gen":             true,
+		"gen":                 false, // Only under src
+		"src/generated.php":   false,
+		"src/app/Handler.php": false,
+	} {
+		isDir := filepath.Ext(rel) == ""
+		if got := g.ignored(rel, isDir); got != want {
+			t.Errorf("ignored(%q) = %v, want %v", rel, got, want)
+		}
+	}
+}
+
+func TestScanFiles_Report(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel string, size int) {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, make([]byte, size), 0644)
+	}
+	os.WriteFile(filepath.Join(root, ".gitignore"), []byte("cache.php\ngenerated/\n"), 0644)
+	write("src/App.php", 10)
+	write("src/View.phtml", 10)
+	write("src/big.php", 5000)
+	write("src/cache.php", 10)
+	write("src/README.md", 10)
+	write("generated/Proxy.php", 10)
+	write("vendor/lib/Lib.php", 10)
+	write("legacy/Old.php", 10)
+
+	s := NewScanner(root)
+	s.SetExtensions([]string{".php", ".phtml"})
+	s.AddDefaultExcludes([]string{"vendor"})
+	s.AddExcludeDir("legacy")
+	s.UseGitignore()
+	s.SetMaxFileSize(1024)
+	files, err := s.ScanFiles()
+	if err != nil {
+		t.Fatalf("ScanFiles failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("expected App.php and View.phtml, got %v", files)
+	}
+
+	report := s.Report()
+	if report.Matched != 2 || !reflect.DeepEqual(report.Extensions, map[string]int{".php": 1, ".phtml": 1}) {
+		t.Errorf("unexpected matches %d %v", report.Matched, report.Extensions)
+	}
+	if !reflect.DeepEqual(report.Unmatched, map[string]int{".md": 1, ".gitignore": 1}) {
+		t.Errorf("unexpected unmatched files %v", report.Unmatched)
+	}
+	wantDirs := []models.SkippedPath{
+		{Path: "generated", Reason: "gitignore"},
+		{Path: "legacy", Reason: "excluded"},
+		{Path: "vendor", Reason: "default"},
+	}
+	if !reflect.DeepEqual(report.SkippedDirs, wantDirs) {
+		t.Errorf("unexpected skipped directories %v", report.SkippedDirs)
+	}
+	if report.Gitignored != 1 {
+		t.Errorf("expected cache.php ignored, got %d", report.Gitignored)
+	}
+	if len(report.Oversized) != 1 || report.Oversized[0].Path != "src/big.php" || report.Oversized[0].Size != 5000 {
+		t.Errorf("unexpected oversized files %v", report.Oversized)
+	}
+}
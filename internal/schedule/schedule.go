@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when the next run is due
+type Schedule interface {
+	// Next returns the first run time strictly after t
+	Next(t time.Time) time.Time
+}
+
+// Parse reads a schedule: a five-field cron expression ("minute hour day-of-month month
+// day-of-week", with *, ranges, lists, and /steps), "@every <duration>", or one of
+// @hourly, @daily, @weekly, and @monthly
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || every < time.Minute {
+			return nil, fmt.Errorf("invalid schedule %q: @every needs a duration of at least 1m", spec)
+		}
+		return Every(every), nil
+	}
+
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 cron fields (minute hour day month weekday)", spec)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	var cron Cron
+	sets := []*uint64{&cron.minutes, &cron.hours, &cron.days, &cron.months, &cron.weekdays}
+	for i, field := range fields {
+		set, err := parseField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		*sets[i] = set
+	}
+	// Like cron, a restricted day-of-month or day-of-week matches either
+	cron.anyDay = fields[2] == "*" || fields[4] == "*"
+	return &cron, nil
+}
+
+// Every runs at a fixed interval
+type Every time.Duration
+
+// Next returns t plus the interval
+func (e Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// Cron runs at the times matching a cron expression, in t's location
+type Cron struct {
+	minutes, hours, days, months, weekdays uint64 // Bit sets of allowed values
+	anyDay                                 bool
+}
+
+// Next returns the first whole minute after t matching the expression
+func (c *Cron) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	// Every matching time recurs within four years (leap days)
+	limit := next.AddDate(4, 0, 1)
+	for next.Before(limit) {
+		switch {
+		case !has(c.months, int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !c.dayMatches(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case !has(c.hours, next.Hour()):
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case !has(c.minutes, next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day-of-month/day-of-week rule to t
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := has(c.days, t.Day())
+	dow := has(c.weekdays, int(t.Weekday()))
+	if c.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// has reports whether value is in set
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// parseField parses one comma-separated cron field into a bit set of values
+func parseField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				return 0, fmt.Errorf("bad step in %q", part)
+			}
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			loText, hiText, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loText); err != nil {
+				return 0, fmt.Errorf("bad value in %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiText); err != nil {
+					return 0, fmt.Errorf("bad range in %q", part)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		// Sunday may be written as 7
+		if max == 6 && hi == 7 {
+			set |= 1
+			if lo == 7 {
+				continue
+			}
+			hi = 6
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
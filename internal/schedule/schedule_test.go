@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronNext(t *testing.T) {
+	from := time.Date(2025, 3, 14, 10, 17, 30, 0, time.UTC) // A Friday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2025, 3, 15, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)}, // Next weekday is Monday
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},   // 7 is Sunday
+		{"@monthly", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,15 * *", time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		sched, err := Parse(tt.spec)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.spec, err)
+		}
+		if got := sched.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseEvery(t *testing.T) {
+	sched, err := Parse("@every 90m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	from := time.Date(2025, 3, 14, 10, 17, 30, 0, time.UTC)
+	if got := sched.Next(from); !got.Equal(from.Add(90 * time.Minute)) {
+		t.Errorf("unexpected next run %v", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "@every 10s", "@every soon", "@yearly"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package semver classifies public API changes by the version bump they require under
+// Semantic Versioning and suggests the next version
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/diff"
+)
+
+// Bump levels, from least to most significant
+const (
+	Patch = "patch"
+	Minor = "minor"
+	Major = "major"
+)
+
+// rank orders the bump levels
+var rank = map[string]int{Patch: 0, Minor: 1, Major: 2}
+
+// relaxations are modifiers whose removal loosens a declaration without breaking callers
+// or subclasses: an abstract class becomes instantiable, a readonly property writable
+var relaxations = map[string]bool{"abstract": true, "readonly": true}
+
+// Version is a MAJOR.MINOR.PATCH version. Prefix keeps a leading "v" so suggestions
+// match the tag style.
+type Version struct {
+	Major, Minor, Patch int
+	Prefix              string
+}
+
+// versionPattern matches v2.3.0, 2.3, or 2.3.0-rc.1 (pre-release and build metadata are
+// dropped)
+var versionPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)(?:\.(\d+))?(?:[-+].*)?$`)
+
+// Parse reads a version from a tag such as v2.3.0
+func Parse(tag string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(strings.TrimSpace(tag))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q isn't a semantic version", tag)
+	}
+	v := Version{Prefix: m[1]}
+	v.Major, _ = strconv.Atoi(m[2])
+	v.Minor, _ = strconv.Atoi(m[3])
+	if m[4] != "" {
+		v.Patch, _ = strconv.Atoi(m[4])
+	}
+	return v, nil
+}
+
+// String formats the version with its prefix
+func (v Version) String() string {
+	return fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+}
+
+// Bump returns the next version at level
+func (v Version) Bump(level string) Version {
+	switch level {
+	case Major:
+		return Version{Major: v.Major + 1, Prefix: v.Prefix}
+	case Minor:
+		return Version{Major: v.Major, Minor: v.Minor + 1, Prefix: v.Prefix}
+	default:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, Prefix: v.Prefix}
+	}
+}
+
+// Change is one API change and the bump it requires
+type Change struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Change string `json:"change"` // "added", "removed", or "changed"
+	Level  string `json:"level"`
+	Reason string `json:"reason"`
+	Old    string `json:"old,omitempty"` // Signatures, for changed symbols
+	New    string `json:"new,omitempty"`
+}
+
+// Advice is the bump a set of API changes calls for
+type Advice struct {
+	Level   string    `json:"level"`
+	Current string    `json:"current,omitempty"` // The version compared against, when known
+	Next    string    `json:"next,omitempty"`
+	Changes []*Change `json:"changes"` // Most significant first
+}
+
+// Classify applies semver rules to API changes: removals and incompatible signature
+// changes are major, additions and relaxed modifiers minor, and no API change at all a
+// patch. When current is known, Next is the suggested version; before 1.0.0, where the
+// API isn't considered stable, breaking changes bump the minor version instead.
+func Classify(changes *diff.APIChanges, current *Version) *Advice {
+	advice := &Advice{Level: Patch, Changes: []*Change{}}
+	add := func(change *Change) {
+		advice.Changes = append(advice.Changes, change)
+		if rank[change.Level] > rank[advice.Level] {
+			advice.Level = change.Level
+		}
+	}
+
+	for _, symbol := range changes.Removed {
+		add(&Change{Name: symbol.Name, Kind: symbol.Kind, Change: "removed", Level: Major, Reason: "callers of a removed " + symbol.Kind + " break"})
+	}
+	for _, change := range changes.Changed {
+		level, reason := classifySignature(change.Old, change.New)
+		add(&Change{Name: change.Name, Kind: change.Kind, Change: "changed", Level: level, Reason: reason, Old: change.Old, New: change.New})
+	}
+	for _, symbol := range changes.Added {
+		add(&Change{Name: symbol.Name, Kind: symbol.Kind, Change: "added", Level: Minor, Reason: "new public " + symbol.Kind})
+	}
+
+	sort.SliceStable(advice.Changes, func(i, j int) bool {
+		return rank[advice.Changes[i].Level] > rank[advice.Changes[j].Level]
+	})
+
+	if current != nil {
+		level := advice.Level
+		if current.Major == 0 && level == Major {
+			level = Minor
+		}
+		advice.Current = current.String()
+		advice.Next = current.Bump(level).String()
+	}
+	return advice
+}
+
+// classifySignature decides whether a signature change is compatible. Dropping abstract
+// or readonly, with the rest unchanged, only loosens the declaration; anything else
+// (parameters, types, static, other modifiers) can break callers or subclasses.
+func classifySignature(old, new string) (string, string) {
+	oldWords, newWords := strings.Fields(old), strings.Fields(new)
+	var oldRest, newRest []string
+	dropped := []string{}
+	kept := make(map[string]bool)
+	for _, word := range newWords {
+		if relaxations[word] {
+			kept[word] = true
+			continue
+		}
+		newRest = append(newRest, word)
+	}
+	for _, word := range oldWords {
+		if relaxations[word] {
+			if !kept[word] {
+				dropped = append(dropped, word)
+			}
+			delete(kept, word)
+			continue
+		}
+		oldRest = append(oldRest, word)
+	}
+
+	if len(kept) == 0 && len(dropped) > 0 && strings.Join(oldRest, " ") == strings.Join(newRest, " ") {
+		return Minor, "no longer " + strings.Join(dropped, " or ")
+	}
+	return Major, "incompatible signature change"
+}
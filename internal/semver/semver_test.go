@@ -0,0 +1,81 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/diff"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"v2.3.0":      "v2.3.0",
+		"2.3":         "2.3.0",
+		"v1.0.0-rc.1": "v1.0.0",
+	}
+	for tag, want := range tests {
+		v, err := Parse(tag)
+		if err != nil || v.String() != want {
+			t.Errorf("Parse(%q) = %v (%v), want %s", tag, v, err, want)
+		}
+	}
+	if _, err := Parse("main"); err == nil {
+		t.Error("expected an error for a branch name")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	v230, _ := Parse("v2.3.0")
+
+	added := &diff.APIChanges{Added: []*models.APISymbol{{Name: `Acme\Invoice::pdf`, Kind: "method"}}}
+	if advice := Classify(added, &v230); advice.Level != Minor || advice.Next != "v2.4.0" {
+		t.Errorf("expected a minor bump to v2.4.0, got %+v", advice)
+	}
+
+	if advice := Classify(&diff.APIChanges{}, &v230); advice.Level != Patch || advice.Next != "v2.3.1" {
+		t.Errorf("expected a patch bump to v2.3.1, got %+v", advice)
+	}
+
+	breaking := &diff.APIChanges{
+		Added: []*models.APISymbol{{Name: `Acme\Invoice::pdf`, Kind: "method"}},
+		Changed: []*diff.SignatureChange{
+			{Name: `Acme\Invoice`, Kind: "class", Old: "abstract class Invoice", New: "class Invoice"},
+			{Name: `Acme\Invoice::total`, Kind: "method", Old: "public function total(): int", New: "public function total(float $tax): int"},
+		},
+	}
+	advice := Classify(breaking, &v230)
+	if advice.Level != Major || advice.Next != "v3.0.0" || advice.Current != "v2.3.0" {
+		t.Fatalf("expected a major bump to v3.0.0, got %+v", advice)
+	}
+	if first := advice.Changes[0]; first.Name != `Acme\Invoice::total` || first.Level != Major {
+		t.Errorf("expected the breaking change listed first, got %+v", first)
+	}
+	for _, change := range advice.Changes {
+		if change.Name == `Acme\Invoice` && (change.Level != Minor || change.Reason != "no longer abstract") {
+			t.Errorf("expected dropping abstract to be minor, got %+v", change)
+		}
+	}
+
+	v041, _ := Parse("0.4.1")
+	if advice := Classify(breaking, &v041); advice.Level != Major || advice.Next != "0.5.0" {
+		t.Errorf("expected breaking changes before 1.0 to bump the minor version, got %+v", advice)
+	}
+	if advice := Classify(breaking, nil); advice.Next != "" {
+		t.Errorf("expected no suggestion without a current version, got %q", advice.Next)
+	}
+}
+
+func TestClassifySignature(t *testing.T) {
+	tests := []struct {
+		old, new, want string
+	}{
+		{"public readonly $amount", "public $amount", Minor},
+		{"public $amount", "public readonly $amount", Major},
+		{"public function make()", "public static function make()", Major},
+	}
+	for _, tt := range tests {
+		if got, _ := classifySignature(tt.old, tt.new); got != tt.want {
+			t.Errorf("classifySignature(%q, %q) = %s, want %s", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role is what a credential may do
+type Role string
+
+const (
+	RoleRead  Role = "read"  // Read snapshots, reports, and trends
+	RoleWrite Role = "write" // Also trigger analyses
+)
+
+// Credential is an API token or basic-auth user and what it may access
+type Credential struct {
+	Name     string // User name, or a label for a token
+	Secret   string // The token, or the user's password ("sha256:<hex>" for a hashed one)
+	Basic    bool   // Checked against HTTP basic auth instead of a bearer token
+	Role     Role
+	Projects []string // Projects it may access; all when empty
+}
+
+// Auth authenticates API requests against a set of credentials
+type Auth struct {
+	credentials []Credential
+}
+
+// principalKey stores the authenticated credential in a request's context
+type principalKey struct{}
+
+// NewAuth checks the credentials' roles and secrets. A role left empty means read-only.
+func NewAuth(credentials []Credential) (*Auth, error) {
+	auth := &Auth{}
+	for _, cred := range credentials {
+		if cred.Role == "" {
+			cred.Role = RoleRead
+		}
+		if cred.Role != RoleRead && cred.Role != RoleWrite {
+			return nil, fmt.Errorf("credential %q: role must be %q or %q", cred.Name, RoleRead, RoleWrite)
+		}
+		if cred.Secret == "" {
+			return nil, fmt.Errorf("credential %q has no secret", cred.Name)
+		}
+		if cred.Basic && cred.Name == "" {
+			return nil, fmt.Errorf("basic-auth credentials need a user name")
+		}
+		auth.credentials = append(auth.credentials, cred)
+	}
+	return auth, nil
+}
+
+// authenticate returns the credential a request presents, or nil
+func (a *Auth) authenticate(r *http.Request) *Credential {
+	if user, password, ok := r.BasicAuth(); ok {
+		for i := range a.credentials {
+			cred := &a.credentials[i]
+			if cred.Basic && equal(cred.Name, user) && cred.checkPassword(password) {
+				return cred
+			}
+		}
+		return nil
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil
+	}
+	for i := range a.credentials {
+		cred := &a.credentials[i]
+		if !cred.Basic && equal(cred.Secret, strings.TrimSpace(token)) {
+			return cred
+		}
+	}
+	return nil
+}
+
+// middleware rejects requests without valid credentials and records the credential for
+// the per-project checks. /healthz stays open for load balancers.
+func (a *Auth) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cred := a.authenticate(r)
+		if cred == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="tukey", Basic realm="tukey"`)
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, cred)))
+	})
+}
+
+// allows reports whether the credential may access project, and trigger analyses of it
+// when write is set
+func (c *Credential) allows(project string, write bool) bool {
+	if write && c.Role != RoleWrite {
+		return false
+	}
+	if len(c.Projects) == 0 {
+		return true
+	}
+	for _, allowed := range c.Projects {
+		if allowed == project {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPassword compares password against the stored one, hashed or plain
+func (c *Credential) checkPassword(password string) bool {
+	if hash, ok := strings.CutPrefix(c.Secret, "sha256:"); ok {
+		sum := sha256.Sum256([]byte(password))
+		return equal(strings.ToLower(hash), hex.EncodeToString(sum[:]))
+	}
+	return equal(c.Secret, password)
+}
+
+// principal returns the credential that authenticated r, or nil when auth is off
+func principal(r *http.Request) *Credential {
+	cred, _ := r.Context().Value(principalKey{}).(*Credential)
+	return cred
+}
+
+// equal compares secrets in constant time
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/history"
+)
+
+func TestAuthRolesAndProjects(t *testing.T) {
+	sum := sha256.Sum256([]byte("hunter2"))
+	auth, err := NewAuth([]Credential{
+		{Name: "ci", Secret: "write-token", Role: RoleWrite},
+		{Name: "dashboard", Secret: "read-token"},
+		{Name: "billing-team", Secret: "billing-token", Projects: []string{"billing"}},
+		{Name: "alice", Secret: "sha256:" + hex.EncodeToString(sum[:]), Basic: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewRegistry()
+	for _, name := range []string{"billing", "storefront"} {
+		store, _ := history.Open(t.TempDir())
+		calls := 0
+		registry.Add(&Server{Name: name, Store: store, Analyze: fakeAnalyze(&calls)})
+	}
+	registry.SetAuth(auth)
+	handler := registry.Handler()
+
+	request := func(method, path string, setup func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if setup != nil {
+			setup(req)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	bearer := func(token string) func(*http.Request) {
+		return func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		setup  func(*http.Request)
+		want   int
+	}{
+		{"health is open", "GET", "/healthz", nil, http.StatusOK},
+		{"no credentials", "GET", "/api/projects", nil, http.StatusUnauthorized},
+		{"wrong token", "GET", "/api/projects", bearer("guess"), http.StatusUnauthorized},
+		{"read token reads", "GET", "/api/projects/storefront/snapshots", bearer("read-token"), http.StatusOK},
+		{"read token can't analyze", "POST", "/api/projects/storefront/snapshots", bearer("read-token"), http.StatusForbidden},
+		{"write token analyzes", "POST", "/api/projects/storefront/snapshots", bearer("write-token"), http.StatusCreated},
+		{"scoped token reads its project", "GET", "/api/projects/billing", bearer("billing-token"), http.StatusOK},
+		{"scoped token can't see others", "GET", "/api/projects/storefront", bearer("billing-token"), http.StatusNotFound},
+		{"basic auth with hashed password", "GET", "/api/projects", func(req *http.Request) { req.SetBasicAuth("alice", "hunter2") }, http.StatusOK},
+		{"basic auth with wrong password", "GET", "/api/projects", func(req *http.Request) { req.SetBasicAuth("alice", "hunter3") }, http.StatusUnauthorized},
+		{"token isn't a password", "GET", "/api/projects", func(req *http.Request) { req.SetBasicAuth("ci", "write-token") }, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if rec := request(tt.method, tt.path, tt.setup); rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d (%s)", tt.name, tt.want, rec.Code, rec.Body)
+		}
+	}
+
+	var projects []ProjectStatus
+	json.Unmarshal(request("GET", "/api/projects", bearer("billing-token")).Body.Bytes(), &projects)
+	if len(projects) != 1 || projects[0].Name != "billing" {
+		t.Errorf("expected the project list to be filtered to billing, got %+v", projects)
+	}
+}
+
+func TestNewAuthValidates(t *testing.T) {
+	for _, creds := range [][]Credential{
+		{{Name: "x", Secret: "t", Role: "admin"}},
+		{{Name: "x"}},
+		{{Secret: "pw", Basic: true}},
+	} {
+		if _, err := NewAuth(creds); err == nil {
+			t.Errorf("expected an error for %+v", creds)
+		}
+	}
+}
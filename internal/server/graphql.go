@@ -0,0 +1,638 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/diff"
+	"github.com/boone-studios/tukey/internal/graphql"
+	"github.com/boone-studios/tukey/internal/history"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// maxGraphQLRequest caps the size of a GraphQL request body
+const maxGraphQLRequest = 1 << 20
+
+// graphQLSchema documents the schema built by newGraphQLSchema; keep the two in sync
+const graphQLSchema = `"RFC 3339 timestamp"
+scalar Time
+
+type Query {
+  "Hosted projects the caller may access"
+  projects: [Project!]!
+  project(name: String!): Project
+}
+
+type Project {
+  name: String!
+  root: String!
+  schedule: String
+  nextRun: Time
+  "Stored snapshots, oldest first; last keeps only the newest n"
+  snapshots(last: Int): [Snapshot!]!
+  "A snapshot by id; the newest with a report when id is omitted or \"latest\""
+  snapshot(id: String): Snapshot
+  "Each metric's values over time; every recorded metric when metrics is omitted"
+  trends(metrics: [String!]): [Trend!]!
+}
+
+type Snapshot {
+  id: String!
+  time: Time!
+  status: String!
+  exitCode: Int!
+  counts: Counts!
+  metrics: [Metric!]!
+  findings: [Finding!]!
+  error: String
+  "The analyzed dependency graph; null when the analysis wrote no report"
+  graph: Graph
+}
+
+type Counts {
+  files: Int!
+  parsedFiles: Int!
+  parseErrors: Int!
+  elements: Int!
+  nodes: Int!
+  edges: Int!
+  orphans: Int!
+}
+
+type Metric {
+  name: String!
+  value: Int!
+}
+
+type Finding {
+  metric: String!
+  value: Int!
+  threshold: Int!
+  minimum: Boolean!
+}
+
+type Trend {
+  metric: String!
+  points: [Point!]!
+}
+
+type Point {
+  snapshot: String!
+  time: Time!
+  value: Int!
+}
+
+type Graph {
+  totalNodes: Int!
+  totalEdges: Int!
+  node(id: String!): Node
+  "Nodes sorted by id: exact type, case-insensitive name substring, file path prefix, exact language; limit defaults to 100"
+  nodes(type: String, name: String, file: String, language: String, offset: Int, limit: Int): NodeConnection!
+  "Edges sorted by source, then target; limit defaults to 100"
+  edges(type: String, from: String, to: String, offset: Int, limit: Int): EdgeConnection!
+  orphans(offset: Int, limit: Int): NodeConnection!
+  "Groups of node ids that depend on each other in a loop"
+  cycles: [[String!]!]!
+}
+
+type NodeConnection {
+  total: Int!
+  nodes: [Node!]!
+}
+
+type EdgeConnection {
+  total: Int!
+  edges: [Edge!]!
+}
+
+type Node {
+  id: String!
+  name: String!
+  type: String!
+  file: String!
+  line: Int!
+  namespace: String
+  package: String
+  "Source language of the defining file, e.g. php or javascript"
+  language: String
+  score: Int!
+  entrypoint: Boolean!
+  "What this node depends on"
+  dependencies(type: String): [Edge!]!
+  "What depends on this node"
+  dependents(type: String): [Edge!]!
+}
+
+type Edge {
+  fromId: String!
+  toId: String!
+  from: Node
+  to: Node
+  type: String!
+  count: Int!
+  lines: [Int!]!
+  sampled: Boolean!
+}
+`
+
+// gqlRequest is the per-request state GraphQL resolvers share: who is asking, and the
+// graphs already loaded so a query touching a snapshot twice reads its report once
+type gqlRequest struct {
+	registry *Registry
+	cred     *Credential
+	graphs   map[string]*models.DependencyGraph
+}
+
+type gqlRequestKey struct{}
+
+func requestState(ctx context.Context) *gqlRequest {
+	return ctx.Value(gqlRequestKey{}).(*gqlRequest)
+}
+
+// gqlSnapshot is a snapshot together with the project it belongs to
+type gqlSnapshot struct {
+	*history.Snapshot
+	server *Server
+}
+
+// gqlNode is a node together with the graph its edges point into
+type gqlNode struct {
+	*models.DependencyNode
+	graph *models.DependencyGraph
+}
+
+// gqlEdge is one dependency between two nodes of a graph
+type gqlEdge struct {
+	From, To string
+	Ref      *models.DependencyRef
+	graph    *models.DependencyGraph
+}
+
+type gqlConnection struct {
+	Total int
+	Items interface{}
+}
+
+type gqlTrend struct {
+	Metric string
+	Points []history.Point
+}
+
+// newGraphQLSchema builds the schema documented by graphQLSchema
+func newGraphQLSchema() *graphql.Schema {
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.FieldDef{
+		"projects": {Type: "[Project!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			req := requestState(p.Context)
+			var servers []*Server
+			for _, s := range req.registry.Projects() {
+				if req.cred == nil || req.cred.allows(s.Name, false) {
+					servers = append(servers, s)
+				}
+			}
+			return servers, nil
+		}},
+		"project": {
+			Type: "Project",
+			Args: map[string]string{"name": "String!"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				req := requestState(p.Context)
+				name := p.Args["name"].(string)
+				s, ok := req.registry.Get(name)
+				if !ok || (req.cred != nil && !req.cred.allows(name, false)) {
+					return nil, nil
+				}
+				return s, nil
+			},
+		},
+	}}
+
+	project := &graphql.Object{Name: "Project", Fields: map[string]*graphql.FieldDef{
+		"name":     {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*Server).Name, nil }},
+		"root":     {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*Server).Root, nil }},
+		"schedule": {Type: "String", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return optional(p.Source.(*Server).Spec), nil }},
+		"nextRun": {Type: "Time", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*Server).Status().NextRun, nil
+		}},
+		"snapshots": {
+			Type: "[Snapshot!]!",
+			Args: map[string]string{"last": "Int"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				s := p.Source.(*Server)
+				snapshots, err := s.Store.List()
+				if err != nil {
+					return nil, err
+				}
+				if last, ok := p.Args["last"].(int); ok && last < len(snapshots) {
+					snapshots = snapshots[len(snapshots)-last:]
+				}
+				result := make([]*gqlSnapshot, len(snapshots))
+				for i, snapshot := range snapshots {
+					result[i] = &gqlSnapshot{Snapshot: snapshot, server: s}
+				}
+				return result, nil
+			},
+		},
+		"snapshot": {
+			Type: "Snapshot",
+			Args: map[string]string{"id": "String"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				s := p.Source.(*Server)
+				id, _ := p.Args["id"].(string)
+				if id == "" {
+					id = "latest"
+				}
+				id, _, err := s.resolveSnapshot(id)
+				if err != nil {
+					return nil, nil
+				}
+				snapshot, err := s.Store.Get(id)
+				if err != nil {
+					return nil, nil
+				}
+				return &gqlSnapshot{Snapshot: snapshot, server: s}, nil
+			},
+		},
+		"trends": {
+			Type: "[Trend!]!",
+			Args: map[string]string{"metrics": "[String!]"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				var metrics []string
+				if list, ok := p.Args["metrics"].([]interface{}); ok {
+					for _, metric := range list {
+						metrics = append(metrics, metric.(string))
+					}
+				}
+				trends, err := p.Source.(*Server).Store.Trends(metrics...)
+				if err != nil {
+					return nil, err
+				}
+				var result []*gqlTrend
+				for metric, points := range trends {
+					result = append(result, &gqlTrend{Metric: metric, Points: points})
+				}
+				sort.Slice(result, func(i, j int) bool { return result[i].Metric < result[j].Metric })
+				return result, nil
+			},
+		},
+	}}
+
+	snapshot := &graphql.Object{Name: "Snapshot", Fields: map[string]*graphql.FieldDef{
+		"id":       {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlSnapshot).ID, nil }},
+		"time":     {Type: "Time!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlSnapshot).Time, nil }},
+		"status":   {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlSnapshot).Status, nil }},
+		"exitCode": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlSnapshot).ExitCode, nil }},
+		"counts":   {Type: "Counts!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return &p.Source.(*gqlSnapshot).Counts, nil }},
+		"error": {Type: "String", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return optional(p.Source.(*gqlSnapshot).Error), nil
+		}},
+		"metrics": {Type: "[Metric!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			metrics := p.Source.(*gqlSnapshot).Metrics
+			names := make([]string, 0, len(metrics))
+			for name := range metrics {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			result := make([]map[string]interface{}, len(names))
+			for i, name := range names {
+				result[i] = map[string]interface{}{"name": name, "value": metrics[name]}
+			}
+			return result, nil
+		}},
+		"findings": {Type: "[Finding!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			findings := p.Source.(*gqlSnapshot).Findings
+			if findings == nil {
+				findings = []runstatus.Finding{}
+			}
+			return findings, nil
+		}},
+		"graph": {Type: "Graph", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			snapshot := p.Source.(*gqlSnapshot)
+			req := requestState(p.Context)
+			key := snapshot.server.Name + "/" + snapshot.ID
+			if graph, ok := req.graphs[key]; ok {
+				return graph, nil
+			}
+			graph, err := diff.Load(snapshot.server.Store.ReportPath(snapshot.ID))
+			if err != nil {
+				return nil, nil // The analysis failed before writing a report
+			}
+			req.graphs[key] = graph
+			return graph, nil
+		}},
+	}}
+
+	counts := &graphql.Object{Name: "Counts", Fields: fieldsOf(map[string]func(c *runstatus.Counts) int{
+		"files":       func(c *runstatus.Counts) int { return c.Files },
+		"parsedFiles": func(c *runstatus.Counts) int { return c.ParsedFiles },
+		"parseErrors": func(c *runstatus.Counts) int { return c.ParseErrors },
+		"elements":    func(c *runstatus.Counts) int { return c.Elements },
+		"nodes":       func(c *runstatus.Counts) int { return c.Nodes },
+		"edges":       func(c *runstatus.Counts) int { return c.Edges },
+		"orphans":     func(c *runstatus.Counts) int { return c.Orphans },
+	})}
+
+	metric := &graphql.Object{Name: "Metric", Fields: map[string]*graphql.FieldDef{
+		"name":  {Type: "String!", Resolve: mapField("name")},
+		"value": {Type: "Int!", Resolve: mapField("value")},
+	}}
+
+	finding := &graphql.Object{Name: "Finding", Fields: map[string]*graphql.FieldDef{
+		"metric":    {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(runstatus.Finding).Metric, nil }},
+		"value":     {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(runstatus.Finding).Value, nil }},
+		"threshold": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(runstatus.Finding).Threshold, nil }},
+		"minimum":   {Type: "Boolean!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(runstatus.Finding).Minimum, nil }},
+	}}
+
+	trend := &graphql.Object{Name: "Trend", Fields: map[string]*graphql.FieldDef{
+		"metric": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlTrend).Metric, nil }},
+		"points": {Type: "[Point!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlTrend).Points, nil }},
+	}}
+
+	point := &graphql.Object{Name: "Point", Fields: map[string]*graphql.FieldDef{
+		"snapshot": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(history.Point).Snapshot, nil }},
+		"time":     {Type: "Time!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(history.Point).Time, nil }},
+		"value":    {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(history.Point).Value, nil }},
+	}}
+
+	page := map[string]string{"offset": "Int", "limit": "Int"}
+	graph := &graphql.Object{Name: "Graph", Fields: map[string]*graphql.FieldDef{
+		"totalNodes": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*models.DependencyGraph).TotalNodes, nil
+		}},
+		"totalEdges": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*models.DependencyGraph).TotalEdges, nil
+		}},
+		"node": {
+			Type: "Node",
+			Args: map[string]string{"id": "String!"},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				g := p.Source.(*models.DependencyGraph)
+				return wrapNode(g, g.Nodes[p.Args["id"].(string)]), nil
+			},
+		},
+		"nodes": {
+			Type: "NodeConnection!",
+			Args: withArgs(page, "type", "String", "name", "String", "file", "String", "language", "String"),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				g := p.Source.(*models.DependencyGraph)
+				filter := nodeFilter{}
+				filter.Type, _ = p.Args["type"].(string)
+				filter.Name, _ = p.Args["name"].(string)
+				filter.File, _ = p.Args["file"].(string)
+				filter.Language, _ = p.Args["language"].(string)
+				return nodePage(g, filter.apply(g), p.Args)
+			},
+		},
+		"orphans": {
+			Type: "NodeConnection!",
+			Args: page,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				g := p.Source.(*models.DependencyGraph)
+				orphans := append([]*models.DependencyNode(nil), g.Orphans...)
+				sort.Slice(orphans, func(i, j int) bool { return orphans[i].ID < orphans[j].ID })
+				return nodePage(g, orphans, p.Args)
+			},
+		},
+		"edges": {
+			Type: "EdgeConnection!",
+			Args: withArgs(page, "type", "String", "from", "String", "to", "String"),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				g := p.Source.(*models.DependencyGraph)
+				edgeType, _ := p.Args["type"].(string)
+				from, _ := p.Args["from"].(string)
+				to, _ := p.Args["to"].(string)
+
+				var edges []*gqlEdge
+				for _, node := range (nodeFilter{}).apply(g) {
+					if from != "" && node.ID != from {
+						continue
+					}
+					for _, edge := range edgesOf(g, node, node.Dependencies, edgeType, false) {
+						if to == "" || edge.To == to {
+							edges = append(edges, edge)
+						}
+					}
+				}
+				start, end, err := pageBounds(len(edges), p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return &gqlConnection{Total: len(edges), Items: edges[start:end]}, nil
+			},
+		},
+		"cycles": {Type: "[[String!]!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			cycles := analyzer.FindCycles(p.Source.(*models.DependencyGraph))
+			if cycles == nil {
+				cycles = [][]string{}
+			}
+			return cycles, nil
+		}},
+	}}
+
+	nodeConnection := &graphql.Object{Name: "NodeConnection", Fields: map[string]*graphql.FieldDef{
+		"total": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlConnection).Total, nil }},
+		"nodes": {Type: "[Node!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlConnection).Items, nil }},
+	}}
+	edgeConnection := &graphql.Object{Name: "EdgeConnection", Fields: map[string]*graphql.FieldDef{
+		"total": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlConnection).Total, nil }},
+		"edges": {Type: "[Edge!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlConnection).Items, nil }},
+	}}
+
+	edgeArgs := map[string]string{"type": "String"}
+	node := &graphql.Object{Name: "Node", Fields: map[string]*graphql.FieldDef{
+		"id":   {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).ID, nil }},
+		"name": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).Name, nil }},
+		"type": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).Type, nil }},
+		"file": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).File, nil }},
+		"line": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).Line, nil }},
+		"namespace": {Type: "String", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return optional(p.Source.(*gqlNode).Namespace), nil
+		}},
+		"package":    {Type: "String", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return optional(p.Source.(*gqlNode).Package), nil }},
+		"language":   {Type: "String", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return optional(p.Source.(*gqlNode).Language), nil }},
+		"score":      {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).Score, nil }},
+		"entrypoint": {Type: "Boolean!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlNode).IsEntrypoint, nil }},
+		"dependencies": {Type: "[Edge!]!", Args: edgeArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			n := p.Source.(*gqlNode)
+			edgeType, _ := p.Args["type"].(string)
+			return edgesOf(n.graph, n.DependencyNode, n.Dependencies, edgeType, false), nil
+		}},
+		"dependents": {Type: "[Edge!]!", Args: edgeArgs, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			n := p.Source.(*gqlNode)
+			edgeType, _ := p.Args["type"].(string)
+			return edgesOf(n.graph, n.DependencyNode, n.Dependents, edgeType, true), nil
+		}},
+	}}
+
+	edge := &graphql.Object{Name: "Edge", Fields: map[string]*graphql.FieldDef{
+		"fromId": {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlEdge).From, nil }},
+		"toId":   {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlEdge).To, nil }},
+		"from": {Type: "Node", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			e := p.Source.(*gqlEdge)
+			return wrapNode(e.graph, e.graph.Nodes[e.From]), nil
+		}},
+		"to": {Type: "Node", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			e := p.Source.(*gqlEdge)
+			return wrapNode(e.graph, e.graph.Nodes[e.To]), nil
+		}},
+		"type":  {Type: "String!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlEdge).Ref.Type, nil }},
+		"count": {Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlEdge).Ref.Count, nil }},
+		"lines": {Type: "[Int!]!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			lines := p.Source.(*gqlEdge).Ref.Lines
+			if lines == nil {
+				lines = []int{}
+			}
+			return lines, nil
+		}},
+		"sampled": {Type: "Boolean!", Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(*gqlEdge).Ref.Sampled, nil }},
+	}}
+
+	schema, err := graphql.NewSchema("Query", graphQLSchema,
+		query, project, snapshot, counts, metric, finding, trend, point,
+		graph, nodeConnection, edgeConnection, node, edge)
+	if err != nil {
+		panic(err) // The schema is static, so this is a programming error
+	}
+	return schema
+}
+
+// handleGraphQL runs a GraphQL query, sent as a JSON body by POST or as query, variables,
+// and operationName parameters by GET
+func (r *Registry) handleGraphQL(w http.ResponseWriter, req *http.Request) {
+	var gqlReq graphql.Request
+	if req.Method == http.MethodPost {
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxGraphQLRequest)).Decode(&gqlReq); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid GraphQL request: %w", err))
+			return
+		}
+	} else {
+		query := req.URL.Query()
+		gqlReq.Query, gqlReq.OperationName = query.Get("query"), query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &gqlReq.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid variables: %w", err))
+				return
+			}
+		}
+	}
+	if gqlReq.Query == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing query"))
+		return
+	}
+
+	state := &gqlRequest{registry: r, cred: principal(req), graphs: make(map[string]*models.DependencyGraph)}
+	ctx := context.WithValue(req.Context(), gqlRequestKey{}, state)
+	writeJSON(w, http.StatusOK, r.graphql.Execute(ctx, gqlReq))
+}
+
+func (r *Registry) handleGraphQLSchema(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(r.graphql.SDL))
+}
+
+// edgesOf lists a node's dependency (or, when reverse is set, dependent) edges of the
+// given type ("" for all), sorted by the node at the other end
+func edgesOf(graph *models.DependencyGraph, node *models.DependencyNode, refs map[string]*models.DependencyRef, edgeType string, reverse bool) []*gqlEdge {
+	edges := []*gqlEdge{}
+	for other, ref := range refs {
+		if edgeType != "" && ref.Type != edgeType {
+			continue
+		}
+		edge := &gqlEdge{From: node.ID, To: other, Ref: ref, graph: graph}
+		if reverse {
+			edge.From, edge.To = other, node.ID
+		}
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+func wrapNode(graph *models.DependencyGraph, node *models.DependencyNode) *gqlNode {
+	if node == nil {
+		return nil
+	}
+	return &gqlNode{DependencyNode: node, graph: graph}
+}
+
+// nodePage wraps a page of nodes, selected by the offset and limit arguments
+func nodePage(graph *models.DependencyGraph, nodes []*models.DependencyNode, args map[string]interface{}) (*gqlConnection, error) {
+	start, end, err := pageBounds(len(nodes), args)
+	if err != nil {
+		return nil, err
+	}
+	page := make([]*gqlNode, 0, end-start)
+	for _, node := range nodes[start:end] {
+		page = append(page, wrapNode(graph, node))
+	}
+	return &gqlConnection{Total: len(nodes), Items: page}, nil
+}
+
+// pageBounds applies the offset and limit arguments (limit defaulting to 100)
+func pageBounds(total int, args map[string]interface{}) (int, int, error) {
+	offset, limit := 0, defaultNodeLimit
+	if value, ok := args["offset"].(int); ok {
+		offset = value
+	}
+	if value, ok := args["limit"].(int); ok {
+		limit = value
+	}
+	if offset < 0 || limit < 0 {
+		return 0, 0, errors.New("offset and limit must not be negative")
+	}
+	start, end := paginate(total, offset, limit)
+	return start, end, nil
+}
+
+// withArgs returns args plus name/type pairs
+func withArgs(args map[string]string, pairs ...string) map[string]string {
+	result := make(map[string]string, len(args)+len(pairs)/2)
+	for name, typeRef := range args {
+		result[name] = typeRef
+	}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		result[pairs[i]] = pairs[i+1]
+	}
+	return result
+}
+
+// fieldsOf builds Int! fields over a runstatus.Counts
+func fieldsOf(getters map[string]func(c *runstatus.Counts) int) map[string]*graphql.FieldDef {
+	fields := make(map[string]*graphql.FieldDef, len(getters))
+	for name, get := range getters {
+		get := get
+		fields[name] = &graphql.FieldDef{Type: "Int!", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*runstatus.Counts)), nil
+		}}
+	}
+	return fields
+}
+
+// mapField resolves a field from a map[string]interface{} source
+func mapField(key string) func(p graphql.ResolveParams) (interface{}, error) {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source.(map[string]interface{})[key], nil
+	}
+}
+
+// optional returns nil for an empty string, so nullable fields come back null
+func optional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
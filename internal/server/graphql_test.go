@@ -0,0 +1,200 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func graphQL(t *testing.T, handler http.Handler, query string, variables map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/graphql", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if errs, ok := resp["errors"]; ok {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	return resp["data"].(map[string]interface{})
+}
+
+func TestGraphQLQueriesGraph(t *testing.T) {
+	handler := handlerFor(t, newQueryServer(t))
+
+	data := graphQL(t, handler, `query($type: String) {
+		project(name: "app") {
+			snapshot {
+				status
+				findings { metric value threshold }
+				graph {
+					totalNodes
+					classes: nodes(type: $type, limit: 1) { total nodes { name file dependents { fromId } } }
+					php: nodes(language: "php") { total nodes { language } }
+					helper: node(id: "function:helper:1") { name language dependencies { toId to { name } } }
+				}
+			}
+		}
+	}`, map[string]interface{}{"type": "class"})
+
+	snapshot := data["project"].(map[string]interface{})["snapshot"].(map[string]interface{})
+	if snapshot["status"] != "findings" {
+		t.Errorf("unexpected status %v", snapshot["status"])
+	}
+	if findings := snapshot["findings"].([]interface{}); len(findings) != 1 {
+		t.Errorf("expected one finding, got %v", findings)
+	}
+	graph := snapshot["graph"].(map[string]interface{})
+	classes := graph["classes"].(map[string]interface{})
+	if classes["total"] != 2.0 {
+		t.Errorf("expected 2 classes in total, got %v", classes["total"])
+	}
+	nodes := classes["nodes"].([]interface{})
+	first := nodes[0].(map[string]interface{})
+	if len(nodes) != 1 || first["name"] != "User" || len(first["dependents"].([]interface{})) != 2 {
+		t.Errorf("unexpected class page: %v", nodes)
+	}
+
+	php := graph["php"].(map[string]interface{})
+	if php["total"] != 2.0 || php["nodes"].([]interface{})[0].(map[string]interface{})["language"] != "php" {
+		t.Errorf("expected the 2 PHP nodes, got %v", php)
+	}
+
+	helper := graph["helper"].(map[string]interface{})
+	if helper["language"] != nil {
+		t.Errorf("expected no language for an untagged node, got %v", helper["language"])
+	}
+	deps := helper["dependencies"].([]interface{})
+	if len(deps) != 1 || deps[0].(map[string]interface{})["toId"] != "x" || deps[0].(map[string]interface{})["to"] != nil {
+		t.Errorf("expected one edge to a node outside the graph, got %v", deps)
+	}
+
+	// Keys come back in selection order
+	body, _ := json.Marshal(map[string]string{"query": `{ projects { root name } }`})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/graphql", bytes.NewReader(body)))
+	if !strings.Contains(rec.Body.String(), `"root": ".",`) {
+		t.Errorf("expected root before name, got %s", rec.Body)
+	}
+}
+
+func TestGraphQLOverGETAndErrors(t *testing.T) {
+	handler := handlerFor(t, newQueryServer(t))
+
+	rec := get(t, handler, "GET", "/api/graphql?query="+url.QueryEscape(`{ projects { name } }`))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"app"`) {
+		t.Errorf("expected a GET query to work, got %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = get(t, handler, "GET", "/api/graphql?query="+url.QueryEscape(`{ projects { nope } }`))
+	if !strings.Contains(rec.Body.String(), `"errors"`) {
+		t.Errorf("expected an error for an unknown field, got %s", rec.Body)
+	}
+	if rec := get(t, handler, "GET", "/api/graphql"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a query, got %d", rec.Code)
+	}
+	if rec := get(t, handler, "GET", "/api/graphql/schema"); !strings.Contains(rec.Body.String(), "type Query") {
+		t.Errorf("expected the schema, got %s", rec.Body)
+	}
+}
+
+func TestGraphQLLimitsCyclicQueries(t *testing.T) {
+	handler := handlerFor(t, newQueryServer(t))
+
+	// Node → Edge → Node is cyclic, so two aliases per level double the work each time
+	selection := "id"
+	for i := 0; i < 8; i++ {
+		selection = "dependents { a: from { " + selection + " } b: to { " + selection + " } }"
+	}
+	query := `{ project(name: "app") { snapshot { graph { nodes { nodes { ` + selection + ` } } } } } }`
+	body, _ := json.Marshal(map[string]string{"query": query})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/graphql", bytes.NewReader(body)))
+	if !strings.Contains(rec.Body.String(), "the limit is") || strings.Contains(rec.Body.String(), `"data"`) {
+		t.Errorf("expected a deeply nested query to be refused, got %s", rec.Body)
+	}
+
+	// A client that went away stops the query
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	body, _ = json.Marshal(map[string]string{"query": `{ projects { name } }`})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/graphql", bytes.NewReader(body)).WithContext(ctx))
+	if !strings.Contains(rec.Body.String(), "context canceled") {
+		t.Errorf("expected a canceled request to stop, got %s", rec.Body)
+	}
+}
+
+func TestGraphQLRespectsProjectScope(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []string{"api", "web"} {
+		srv := newQueryServer(t)
+		srv.Name = name
+		if err := registry.Add(srv); err != nil {
+			t.Fatal(err)
+		}
+	}
+	auth, err := NewAuth([]Credential{{Name: "web-only", Secret: "tok", Projects: []string{"web"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.SetAuth(auth)
+	handler := registry.Handler()
+
+	body := strings.NewReader(`{"query": "{ projects { name } api: project(name: \"api\") { name } }"}`)
+	req := httptest.NewRequest("POST", "/api/graphql", body)
+	req.Header.Set("Authorization", "Bearer tok")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp struct {
+		Data struct {
+			Projects []struct{ Name string }
+			API      interface{}
+		}
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Data.Projects) != 1 || resp.Data.Projects[0].Name != "web" || resp.Data.API != nil {
+		t.Errorf("expected only the web project, got %s", rec.Body)
+	}
+}
+
+// TestGraphQLSchemaDocumented checks graphQLSchema lists exactly the fields the schema resolves
+func TestGraphQLSchemaDocumented(t *testing.T) {
+	documented := make(map[string][]string)
+	typeBlock := regexp.MustCompile(`(?s)type (\w+) \{(.*?)\n\}`)
+	fieldLine := regexp.MustCompile(`(?m)^  (\w+)[(:]`)
+	for _, match := range typeBlock.FindAllStringSubmatch(graphQLSchema, -1) {
+		for _, field := range fieldLine.FindAllStringSubmatch(match[2], -1) {
+			documented[match[1]] = append(documented[match[1]], field[1])
+		}
+		sort.Strings(documented[match[1]])
+	}
+
+	schema := newGraphQLSchema()
+	if len(documented) != len(schema.Types) {
+		t.Errorf("documented %d types, schema has %d", len(documented), len(schema.Types))
+	}
+	for name, object := range schema.Types {
+		var fields []string
+		for field := range object.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		if strings.Join(fields, ",") != strings.Join(documented[name], ",") {
+			t.Errorf("%s: documented %v, resolves %v", name, documented[name], fields)
+		}
+	}
+}
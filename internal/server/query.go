@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/diff"
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// defaultNodeLimit caps node queries that don't set ?limit, since graphs of large
+// projects run to hundreds of thousands of nodes
+const defaultNodeLimit = 100
+
+// NodeSummary is a graph node without its edges, as returned by node queries
+type NodeSummary struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	Package      string `json:"package,omitempty"`
+	Language     string `json:"language,omitempty"`
+	Score        int    `json:"score"`
+	Dependencies int    `json:"dependencies"`
+	Dependents   int    `json:"dependents"`
+}
+
+// NodeQueryResult is a page of matching nodes
+type NodeQueryResult struct {
+	Snapshot string         `json:"snapshot"`
+	Total    int            `json:"total"` // Matches before the limit
+	Nodes    []*NodeSummary `json:"nodes"`
+}
+
+// FindingsResult lists the thresholds a snapshot exceeded
+type FindingsResult struct {
+	Snapshot string              `json:"snapshot"`
+	Findings []runstatus.Finding `json:"findings"`
+}
+
+// handleNodes queries the graph of ?snapshot (default latest). Nodes are sorted by ID
+// and filtered by ?type (exact), ?name (case-insensitive substring), ?file (path
+// prefix), and ?language (exact); ?offset and ?limit page through them.
+func (s *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("offset: %w", err))
+		return
+	}
+	limit, err := queryInt(query.Get("limit"), defaultNodeLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("limit: %w", err))
+		return
+	}
+
+	id, code, err := s.resolveSnapshot(snapshotParam(r))
+	if err != nil {
+		writeError(w, code, err)
+		return
+	}
+	graph, err := diff.Load(s.Store.ReportPath(id))
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("snapshot %s has no report", id))
+		return
+	}
+
+	filter := nodeFilter{Type: query.Get("type"), Name: query.Get("name"), File: query.Get("file"), Language: query.Get("language")}
+	result := &NodeQueryResult{Snapshot: id, Nodes: []*NodeSummary{}}
+	var matches []*NodeSummary
+	for _, node := range filter.apply(graph) {
+		matches = append(matches, &NodeSummary{
+			ID:           node.ID,
+			Name:         node.Name,
+			Type:         node.Type,
+			File:         node.File,
+			Line:         node.Line,
+			Package:      node.Package,
+			Language:     node.Language,
+			Score:        node.Score,
+			Dependencies: len(node.Dependencies),
+			Dependents:   len(node.Dependents),
+		})
+	}
+
+	result.Total = len(matches)
+	if start, end := paginate(len(matches), offset, limit); start < end {
+		result.Nodes = matches[start:end]
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// nodeFilter selects graph nodes by exact type, case-insensitive name substring, file
+// path prefix, and exact language; empty fields match everything
+type nodeFilter struct {
+	Type, Name, File, Language string
+}
+
+// apply returns the matching nodes sorted by ID
+func (f nodeFilter) apply(graph *models.DependencyGraph) []*models.DependencyNode {
+	name := strings.ToLower(f.Name)
+	var nodes []*models.DependencyNode
+	for _, node := range graph.Nodes {
+		if (f.Type != "" && node.Type != f.Type) ||
+			(name != "" && !strings.Contains(strings.ToLower(node.Name), name)) ||
+			(f.File != "" && !strings.HasPrefix(node.File, f.File)) ||
+			(f.Language != "" && node.Language != f.Language) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// paginate returns the bounds of the page of total items starting at offset
+func paginate(total, offset, limit int) (start, end int) {
+	start, end = min(offset, total), total
+	if limit < end-start {
+		end = start + limit
+	}
+	return start, end
+}
+
+// handleFindings returns the thresholds exceeded by ?snapshot (default latest)
+func (s *Server) handleFindings(w http.ResponseWriter, r *http.Request) {
+	id, code, err := s.resolveSnapshot(snapshotParam(r))
+	if err != nil {
+		writeError(w, code, err)
+		return
+	}
+	snapshot, err := s.Store.Get(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown snapshot"))
+		return
+	}
+
+	result := &FindingsResult{Snapshot: id, Findings: snapshot.Findings}
+	if result.Findings == nil {
+		result.Findings = []runstatus.Finding{}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// snapshotParam returns the ?snapshot a query targets, defaulting to the latest
+func snapshotParam(r *http.Request) string {
+	if id := r.URL.Query().Get("snapshot"); id != "" {
+		return id
+	}
+	return "latest"
+}
+
+// queryInt parses a non-negative integer query parameter
+func queryInt(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("expected a non-negative integer, got %q", value)
+	}
+	return n, nil
+}
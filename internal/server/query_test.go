@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/history"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+const queryReport = `{"graph":{"nodes":{
+	"class:App\\User:3":         {"id":"class:App\\User:3","name":"User","type":"class","file":"src/User.php","line":3,"language":"php","dependents":{"a":{},"b":{}}},
+	"class:App\\UserRepo:5":     {"id":"class:App\\UserRepo:5","name":"UserRepo","type":"class","file":"src/Repo/UserRepo.php","line":5,"language":"php"},
+	"function:helper:1":         {"id":"function:helper:1","name":"helper","type":"function","file":"lib/helpers.php","line":1,"dependencies":{"x":{}}}
+}}}`
+
+func newQueryServer(t *testing.T) *Server {
+	t.Helper()
+	store, err := history.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyze := func(reportPath, statusPath string) error {
+		status := runstatus.New("test")
+		status.Findings = []runstatus.Finding{{Metric: "orphans", Value: 12, Threshold: 10}}
+		status.Finish(runstatus.ExitFindings)
+		if err := os.WriteFile(reportPath, []byte(queryReport), 0644); err != nil {
+			return err
+		}
+		return status.Write(statusPath)
+	}
+	srv := &Server{Name: "app", Root: ".", Store: store, Analyze: analyze}
+	if _, err := srv.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+func TestHandleNodesFiltersAndPages(t *testing.T) {
+	handler := handlerFor(t, newQueryServer(t))
+
+	tests := []struct {
+		query string
+		total int
+		ids   []string
+	}{
+		{"", 3, []string{`class:App\User:3`, `class:App\UserRepo:5`, "function:helper:1"}},
+		{"?type=class", 2, []string{`class:App\User:3`, `class:App\UserRepo:5`}},
+		{"?name=user&file=src/Repo", 1, []string{`class:App\UserRepo:5`}},
+		{"?language=php", 2, []string{`class:App\User:3`, `class:App\UserRepo:5`}},
+		{"?language=javascript", 0, []string{}},
+		{"?limit=1&offset=1", 3, []string{`class:App\UserRepo:5`}},
+		{"?offset=10", 3, []string{}},
+	}
+	for _, tt := range tests {
+		rec := get(t, handler, "GET", "/api/projects/app/nodes"+tt.query)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", tt.query, rec.Code, rec.Body)
+		}
+		var result NodeQueryResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for _, node := range result.Nodes {
+			ids = append(ids, node.ID)
+		}
+		if result.Total != tt.total || len(ids) != len(tt.ids) {
+			t.Errorf("%s: expected %d of %d nodes %v, got %d of %d %v", tt.query, len(tt.ids), tt.total, tt.ids, len(ids), result.Total, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.ids[i] {
+				t.Errorf("%s: expected %v, got %v", tt.query, tt.ids, ids)
+				break
+			}
+		}
+	}
+
+	rec := get(t, handler, "GET", "/api/projects/app/nodes?type=class&name=User&limit=1")
+	var result NodeQueryResult
+	json.Unmarshal(rec.Body.Bytes(), &result)
+	if node := result.Nodes[0]; node.Dependents != 2 || node.File != "src/User.php" {
+		t.Errorf("unexpected summary: %+v", node)
+	}
+
+	if rec := get(t, handler, "GET", "/api/projects/app/nodes?limit=-1"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a negative limit, got %d", rec.Code)
+	}
+	if rec := get(t, handler, "GET", "/api/projects/app/nodes?snapshot=../../etc/passwd"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an invalid snapshot, got %d", rec.Code)
+	}
+}
+
+func TestHandleFindings(t *testing.T) {
+	handler := handlerFor(t, newQueryServer(t))
+
+	rec := get(t, handler, "GET", "/api/projects/app/findings")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var result FindingsResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].Metric != "orphans" || result.Snapshot == "" {
+		t.Errorf("unexpected findings: %+v", result)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/boone-studios/tukey/internal/graphql"
+)
+
+// Registry hosts the servers of several projects, namespacing each project's API under
+// /api/projects/{project}
+type Registry struct {
+	servers map[string]*Server
+	names   []string // In the order added
+	auth    *Auth    // nil serves everyone
+	graphql *graphql.Schema
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{servers: make(map[string]*Server), graphql: newGraphQLSchema()}
+}
+
+// Add hosts a project's server under its name
+func (r *Registry) Add(s *Server) error {
+	if s.Name == "" {
+		return errors.New("project has no name")
+	}
+	if _, exists := r.servers[s.Name]; exists {
+		return fmt.Errorf("project %q is already registered", s.Name)
+	}
+	r.servers[s.Name] = s
+	r.names = append(r.names, s.Name)
+	return nil
+}
+
+// SetAuth requires every API request to present one of auth's credentials, and limits
+// each credential to its projects and role
+func (r *Registry) SetAuth(auth *Auth) {
+	r.auth = auth
+}
+
+// Get returns the server of the named project
+func (r *Registry) Get(name string) (*Server, bool) {
+	s, ok := r.servers[name]
+	return s, ok
+}
+
+// Projects returns the hosted servers in the order they were added
+func (r *Registry) Projects() []*Server {
+	servers := make([]*Server, 0, len(r.names))
+	for _, name := range r.names {
+		servers = append(servers, r.servers[name])
+	}
+	return servers
+}
+
+// Run runs every project's schedule until ctx is canceled
+func (r *Registry) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, s := range r.Projects() {
+		wg.Add(1)
+		go func(s *Server) {
+			defer wg.Done()
+			s.Run(ctx)
+		}(s)
+	}
+	wg.Wait()
+}
+
+// Handler returns the HTTP API:
+//
+//	GET  /healthz                                 liveness check
+//	GET  /api/projects                            every project's status
+//	GET  /api/projects/{project}                  schedule, next and last run
+//	GET  /api/projects/{project}/snapshots        stored snapshots, oldest first
+//	POST /api/projects/{project}/snapshots        analyze now
+//	GET  /api/projects/{project}/snapshots/latest newest JSON report
+//	GET  /api/projects/{project}/snapshots/{id}   JSON report of one snapshot
+//	GET  /api/projects/{project}/trends?metric=a  metric values over time (all without ?metric)
+//	GET  /api/projects/{project}/nodes?type=class nodes of a snapshot's graph (see handleNodes)
+//	GET  /api/projects/{project}/findings         exceeded thresholds of a snapshot
+//	POST /api/graphql                             GraphQL queries across projects (also GET)
+//	GET  /api/graphql/schema                      the GraphQL schema
+func (r *Registry) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("GET /api/projects", r.handleProjects)
+	mux.HandleFunc("GET /api/projects/{project}", r.project((*Server).handleStatus))
+	mux.HandleFunc("GET /api/projects/{project}/snapshots", r.project((*Server).handleSnapshots))
+	mux.HandleFunc("POST /api/projects/{project}/snapshots", r.project((*Server).handleSnapshotNow))
+	mux.HandleFunc("GET /api/projects/{project}/snapshots/{id}", r.project((*Server).handleReport))
+	mux.HandleFunc("GET /api/projects/{project}/trends", r.project((*Server).handleTrends))
+	mux.HandleFunc("GET /api/projects/{project}/nodes", r.project((*Server).handleNodes))
+	mux.HandleFunc("GET /api/projects/{project}/findings", r.project((*Server).handleFindings))
+	mux.HandleFunc("GET /api/graphql", r.handleGraphQL)
+	mux.HandleFunc("POST /api/graphql", r.handleGraphQL)
+	mux.HandleFunc("GET /api/graphql/schema", r.handleGraphQLSchema)
+	if r.auth != nil {
+		return r.auth.middleware(mux)
+	}
+	return mux
+}
+
+func (r *Registry) handleProjects(w http.ResponseWriter, req *http.Request) {
+	projects := []ProjectStatus{}
+	cred := principal(req)
+	for _, s := range r.Projects() {
+		if cred == nil || cred.allows(s.Name, false) {
+			projects = append(projects, s.Status())
+		}
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// project adapts a per-project handler, looking the project up from the URL and checking
+// the caller may access it. Projects outside a credential's scope look like unknown ones.
+func (r *Registry) project(handler func(*Server, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := req.PathValue("project")
+		s, ok := r.Get(name)
+		cred := principal(req)
+		if !ok || (cred != nil && !cred.allows(name, false)) {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown project %q", name))
+			return
+		}
+		if cred != nil && req.Method != http.MethodGet && !cred.allows(name, true) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("%s can't trigger analyses", cred.Name))
+			return
+		}
+		handler(s, w, req)
+	}
+}
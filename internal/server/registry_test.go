@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/history"
+)
+
+func TestRegistryNamespacesProjects(t *testing.T) {
+	registry := NewRegistry()
+	calls := map[string]*int{}
+	for _, name := range []string{"api", "web"} {
+		store, err := history.Open(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		calls[name] = &n
+		if err := registry.Add(&Server{Name: name, Root: "/srv/" + name, Store: store, Analyze: fakeAnalyze(&n)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := registry.Add(&Server{Name: "api"}); err == nil {
+		t.Errorf("expected an error registering a duplicate name")
+	}
+
+	registry.Run(context.Background())
+	handler := registry.Handler()
+
+	var projects []ProjectStatus
+	json.Unmarshal(get(t, handler, "GET", "/api/projects").Body.Bytes(), &projects)
+	if len(projects) != 2 || projects[0].Name != "api" || projects[1].Root != "/srv/web" || projects[0].LastRun == nil {
+		t.Errorf("unexpected projects: %+v", projects)
+	}
+
+	// Snapshotting one project leaves the other alone
+	if rec := get(t, handler, "POST", "/api/projects/web/snapshots"); rec.Code == http.StatusNotFound {
+		t.Fatalf("expected the web project to be routed")
+	}
+	if *calls["api"] != 1 || *calls["web"] < 1 {
+		t.Errorf("unexpected analysis counts: api=%d web=%d", *calls["api"], *calls["web"])
+	}
+
+	if rec := get(t, handler, "GET", "/api/projects/mobile/snapshots"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown project, got %d", rec.Code)
+	}
+	var status ProjectStatus
+	json.Unmarshal(get(t, handler, "GET", "/api/projects/api").Body.Bytes(), &status)
+	if status.Name != "api" {
+		t.Errorf("unexpected project status: %+v", status)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/history"
+	"github.com/boone-studios/tukey/internal/schedule"
+)
+
+// ErrBusy is returned when a snapshot is requested while another analysis is running
+var ErrBusy = errors.New("an analysis is already running")
+
+// AnalyzeFunc analyzes the project, writing the JSON report to reportPath and the run
+// status to statusPath
+type AnalyzeFunc func(reportPath, statusPath string) error
+
+// Server re-analyzes a project on a schedule, keeps the snapshots in a history store, and
+// serves them and their metric trends over HTTP through a Registry
+type Server struct {
+	Name     string // The project's URL name
+	Root     string
+	Store    *history.Store
+	Schedule schedule.Schedule // nil analyzes only at startup and on request
+	Spec     string            // The schedule as configured, for status responses
+	Keep     int               // Snapshots to keep; 0 keeps all
+	Analyze  AnalyzeFunc
+	Logf     func(format string, args ...interface{})
+
+	running sync.Mutex // Held while an analysis runs
+
+	mu      sync.Mutex
+	nextRun time.Time
+	lastRun *history.Snapshot
+}
+
+// Snapshot analyzes the project now and stores the result. It returns ErrBusy instead of
+// waiting when another analysis is in progress.
+func (s *Server) Snapshot() (*history.Snapshot, error) {
+	if !s.running.TryLock() {
+		return nil, ErrBusy
+	}
+	defer s.running.Unlock()
+
+	id := history.NewID(time.Now())
+	if err := s.Analyze(s.Store.ReportPath(id), s.Store.StatusPath(id)); err != nil {
+		return nil, err
+	}
+	snapshot, err := s.Store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.lastRun = snapshot
+	s.mu.Unlock()
+
+	if s.Keep > 0 {
+		if err := s.Store.Prune(s.Keep); err != nil {
+			s.logf("⚠️ %s: failed to prune history: %v\n", s.Name, err)
+		}
+	}
+	return snapshot, nil
+}
+
+// Run takes a snapshot now and then at every scheduled time until ctx is canceled
+func (s *Server) Run(ctx context.Context) {
+	for {
+		snapshot, err := s.Snapshot()
+		switch {
+		case errors.Is(err, ErrBusy):
+			s.logf("⏭️ %s: skipping scheduled analysis: %v\n", s.Name, err)
+		case err != nil:
+			s.logf("❌ %s: analysis failed: %v\n", s.Name, err)
+		default:
+			s.logf("📸 %s: snapshot %s (%s)\n", s.Name, snapshot.ID, snapshot.Status)
+		}
+
+		if s.Schedule == nil {
+			return
+		}
+		next := s.Schedule.Next(time.Now())
+		if next.IsZero() {
+			return
+		}
+		s.mu.Lock()
+		s.nextRun = next
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// ProjectStatus describes a hosted project and its schedule
+type ProjectStatus struct {
+	Name     string            `json:"name"`
+	Root     string            `json:"root"`
+	Schedule string            `json:"schedule,omitempty"`
+	NextRun  *time.Time        `json:"nextRun,omitempty"`
+	LastRun  *history.Snapshot `json:"lastRun,omitempty"`
+}
+
+// Status returns the project's schedule and its next and last run
+func (s *Server) Status() ProjectStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ProjectStatus{Name: s.Name, Root: s.Root, Schedule: s.Spec, LastRun: s.lastRun}
+	if !s.nextRun.IsZero() {
+		next := s.nextRun
+		status.NextRun = &next
+	}
+	return status
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Status())
+}
+
+func (s *Server) handleSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := s.Store.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+func (s *Server) handleSnapshotNow(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := s.Snapshot()
+	switch {
+	case errors.Is(err, ErrBusy):
+		writeError(w, http.StatusConflict, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusCreated, snapshot)
+	}
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	id, code, err := s.resolveSnapshot(r.PathValue("id"))
+	if err != nil {
+		writeError(w, code, err)
+		return
+	}
+
+	data, err := os.ReadFile(s.Store.ReportPath(id))
+	if err != nil {
+		writeError(w, http.StatusNotFound, errors.New("unknown snapshot"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (s *Server) handleTrends(w http.ResponseWriter, r *http.Request) {
+	var metrics []string
+	if param := r.URL.Query().Get("metric"); param != "" {
+		metrics = strings.Split(param, ",")
+	}
+	trends, err := s.Store.Trends(metrics...)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trends)
+}
+
+// resolveSnapshot checks a snapshot ID from a request, resolving "latest" to the newest
+// snapshot with a report. On failure it also returns the HTTP status to respond with.
+func (s *Server) resolveSnapshot(id string) (string, int, error) {
+	if id == "latest" {
+		latest, err := s.Store.Latest()
+		if err != nil {
+			return "", http.StatusInternalServerError, err
+		}
+		if latest == nil {
+			return "", http.StatusNotFound, errors.New("no snapshots yet")
+		}
+		return latest.ID, 0, nil
+	}
+	if !history.ValidID(id) {
+		return "", http.StatusNotFound, errors.New("unknown snapshot")
+	}
+	return id, 0, nil
+}
+
+// writeJSON sends v as an indented JSON response
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// writeError sends err as a JSON error response
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
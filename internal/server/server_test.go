@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/history"
+	"github.com/boone-studios/tukey/internal/runstatus"
+)
+
+// fakeAnalyze writes a report and a status whose nodes metric counts the calls
+func fakeAnalyze(calls *int) AnalyzeFunc {
+	return func(reportPath, statusPath string) error {
+		*calls++
+		status := runstatus.New("test")
+		status.Metrics = map[string]int{"nodes": *calls * 10}
+		status.Finish(runstatus.ExitOK)
+		if err := os.WriteFile(reportPath, []byte(`{"graph":{"nodes":{}},"totalFiles":`+string(rune('0'+*calls))+`}`), 0644); err != nil {
+			return err
+		}
+		return status.Write(statusPath)
+	}
+}
+
+func newTestServer(t *testing.T) (*Server, *int) {
+	t.Helper()
+	store, err := history.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	return &Server{Name: "app", Root: ".", Store: store, Analyze: fakeAnalyze(&calls)}, &calls
+}
+
+func get(t *testing.T, handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+// handlerFor serves srv through a registry
+func handlerFor(t *testing.T, srv *Server) http.Handler {
+	t.Helper()
+	registry := NewRegistry()
+	if err := registry.Add(srv); err != nil {
+		t.Fatal(err)
+	}
+	return registry.Handler()
+}
+
+func TestSnapshotsAndTrends(t *testing.T) {
+	srv, _ := newTestServer(t)
+	handler := handlerFor(t, srv)
+
+	if rec := get(t, handler, "GET", "/api/projects/app/snapshots/latest"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 before any snapshot, got %d", rec.Code)
+	}
+
+	first, err := srv.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1100 * time.Millisecond) // Snapshot IDs have one-second resolution
+	rec := get(t, handler, "POST", "/api/projects/app/snapshots")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var snapshots []*history.Snapshot
+	json.Unmarshal(get(t, handler, "GET", "/api/projects/app/snapshots").Body.Bytes(), &snapshots)
+	if len(snapshots) != 2 || snapshots[0].ID != first.ID {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+
+	if body := get(t, handler, "GET", "/api/projects/app/snapshots/latest").Body.String(); !strings.Contains(body, `"totalFiles":2`) {
+		t.Errorf("expected the latest report, got %s", body)
+	}
+	if body := get(t, handler, "GET", "/api/projects/app/snapshots/"+first.ID).Body.String(); !strings.Contains(body, `"totalFiles":1`) {
+		t.Errorf("expected the first report, got %s", body)
+	}
+	if rec := get(t, handler, "GET", "/api/projects/app/snapshots/..%2F..%2Fetc"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an invalid id, got %d", rec.Code)
+	}
+
+	var trends map[string][]history.Point
+	json.Unmarshal(get(t, handler, "GET", "/api/projects/app/trends?metric=nodes").Body.Bytes(), &trends)
+	if len(trends["nodes"]) != 2 || trends["nodes"][1].Value != 20 {
+		t.Errorf("unexpected trends: %+v", trends)
+	}
+}
+
+func TestSnapshotRejectsOverlappingRuns(t *testing.T) {
+	srv, _ := newTestServer(t)
+	srv.running.Lock()
+	defer srv.running.Unlock()
+
+	if rec := get(t, handlerFor(t, srv), "POST", "/api/projects/app/snapshots"); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 while an analysis runs, got %d", rec.Code)
+	}
+}
+
+func TestRunWithoutScheduleSnapshotsOnce(t *testing.T) {
+	srv, calls := newTestServer(t)
+	srv.Keep = 1
+	srv.Run(context.Background())
+	if *calls != 1 {
+		t.Errorf("expected one analysis, got %d", *calls)
+	}
+}
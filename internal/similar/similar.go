@@ -0,0 +1,228 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package similar finds elements built like a given one: calling the same things, taking
+// the same parameters, named alike. Near-identical fingerprints usually mean copy-pasted
+// implementations worth consolidating.
+package similar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// DefaultLimit is how many matches are reported when no limit is given
+const DefaultLimit = 10
+
+// DefaultMinScore is the lowest score reported when no minimum is given
+const DefaultMinScore = 0.5
+
+// Weights of the signals in a score. Parameters only count for functions and methods;
+// for other elements their weight is spread over the rest.
+const (
+	dependencyWeight = 0.5
+	nameWeight       = 0.2
+	paramCountWeight = 0.15
+	paramNameWeight  = 0.15
+)
+
+// Match is an element found similar to the target
+type Match struct {
+	Node       *models.DependencyNode
+	Score      float64  // 0 to 1
+	Shared     int      // Dependencies both elements have
+	Union      int      // Dependencies either element has
+	Parameters int      // The match's parameter count
+	Reasons    []string // What the two have in common, for display
+}
+
+// Find scores every element of the same kind as target in graph, and returns those
+// scoring at least minScore, best first, up to limit. files supplies the parameters, which
+// the graph doesn't keep; elements missing from it are compared without them.
+func Find(graph *models.DependencyGraph, files []*models.ParsedFile, target *models.DependencyNode, minScore float64, limit int) []*Match {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	params := parameters(files)
+	targetParams, targetDeps, targetTokens := params[nodeKey(target)], dependencies(graph, target), nameTokens(target.Name)
+
+	var matches []*Match
+	for _, node := range graph.Nodes {
+		if node.ID == target.ID || kind(node.Type) != kind(target.Type) {
+			continue
+		}
+		match := score(target, node, targetDeps, dependencies(graph, node), targetTokens, targetParams, params[nodeKey(node)])
+		if match.Score >= minScore {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Node.File != b.Node.File {
+			return a.Node.File < b.Node.File
+		}
+		return a.Node.Line < b.Node.Line
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// score compares candidate to target
+func score(target, candidate *models.DependencyNode, targetDeps, candidateDeps, targetTokens map[string]bool, targetParams, candidateParams []string) *Match {
+	match := &Match{Node: candidate, Parameters: len(candidateParams)}
+	match.Shared, match.Union = overlap(targetDeps, candidateDeps)
+	deps := ratio(match.Shared, match.Union)
+	names := jaccard(targetTokens, nameTokens(candidate.Name))
+
+	total, weight := dependencyWeight*deps+nameWeight*names, dependencyWeight+nameWeight
+	if kind(target.Type) == "callable" {
+		count := 1.0
+		if most := max(len(targetParams), len(candidateParams)); most > 0 {
+			count = 1 - float64(abs(len(targetParams)-len(candidateParams)))/float64(most)
+		}
+		paramNames := 1.0
+		if len(targetParams) > 0 || len(candidateParams) > 0 {
+			paramNames = jaccard(paramSet(targetParams), paramSet(candidateParams))
+		}
+		total += paramCountWeight*count + paramNameWeight*paramNames
+		weight += paramCountWeight + paramNameWeight
+
+		switch {
+		case paramNames == 1:
+			match.Reasons = append(match.Reasons, "same parameters")
+		case count == 1:
+			match.Reasons = append(match.Reasons, fmt.Sprintf("%d parameters each", len(candidateParams)))
+		}
+	}
+	match.Score = total / weight
+
+	if match.Shared > 0 {
+		match.Reasons = append([]string{fmt.Sprintf("%d of %d dependencies shared", match.Shared, match.Union)}, match.Reasons...)
+	}
+	if names == 1 {
+		match.Reasons = append(match.Reasons, "same name")
+	} else if names >= 0.5 {
+		match.Reasons = append(match.Reasons, "similar name")
+	}
+	return match
+}
+
+// kind groups element types that are worth comparing with each other
+func kind(nodeType string) string {
+	switch nodeType {
+	case "function", "method":
+		return "callable"
+	case "class", "interface", "trait", "enum", "module", "type":
+		return "type"
+	}
+	return nodeType
+}
+
+// key identifies an element across the parsed files and the graph
+func key(file string, line int, nodeType, name string) string {
+	return fmt.Sprintf("%s:%d:%s:%s", file, line, nodeType, name)
+}
+
+func nodeKey(node *models.DependencyNode) string {
+	return key(node.File, node.Line, node.Type, node.Name)
+}
+
+// parameters indexes the parameter names of the functions and methods in files
+func parameters(files []*models.ParsedFile) map[string][]string {
+	params := make(map[string][]string)
+	for _, file := range files {
+		for _, element := range file.Elements {
+			if element.Type == "function" || element.Type == "method" {
+				params[key(file.Path, element.Line, element.Type, element.Name)] = element.Parameters
+			}
+		}
+	}
+	return params
+}
+
+// dependencies is a node's fingerprint: what it depends on, and how. Members of the
+// node's own class go by name, so a method copied into a sibling class still matches.
+func dependencies(graph *models.DependencyGraph, node *models.DependencyNode) map[string]bool {
+	deps := make(map[string]bool, len(node.Dependencies))
+	for id, ref := range node.Dependencies {
+		if target := graph.Nodes[id]; target != nil && target.ClassName != "" && target.ClassName == node.ClassName && target.File == node.File {
+			id = "self::" + target.Name
+		}
+		deps[ref.Type+" "+id] = true
+	}
+	return deps
+}
+
+// nameTokens splits a name into lowercase words at case changes, digits, underscores,
+// and dashes, so getUserName and get_user_name match
+func nameTokens(name string) map[string]bool {
+	tokens := make(map[string]bool)
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			tokens[strings.ToLower(string(word))] = true
+			word = word[:0]
+		}
+	}
+	runes := []rune(strings.TrimLeft(name, "$_"))
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+		case unicode.IsDigit(r) != (i > 0 && unicode.IsDigit(runes[i-1])):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return tokens
+}
+
+// paramSet normalizes parameter names for comparison
+func paramSet(params []string) map[string]bool {
+	set := make(map[string]bool, len(params))
+	for _, param := range params {
+		set[strings.ToLower(strings.TrimLeft(param, "$&*."))] = true
+	}
+	return set
+}
+
+func overlap(a, b map[string]bool) (shared, union int) {
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return shared, len(a) + len(b) - shared
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	return ratio(overlap(a, b))
+}
+
+func ratio(shared, union int) float64 {
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
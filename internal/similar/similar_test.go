@@ -0,0 +1,84 @@
+package similar
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func node(id, nodeType, name string, line int, deps ...string) *models.DependencyNode {
+	n := &models.DependencyNode{ID: id, Type: nodeType, Name: name, File: "app.php", Line: line, Dependencies: make(map[string]*models.DependencyRef)}
+	for _, dep := range deps {
+		n.Dependencies[dep] = &models.DependencyRef{TargetID: dep, Type: "calls"}
+	}
+	return n
+}
+
+func TestFind(t *testing.T) {
+	// Calls to their own class's helper count as the same dependency
+	target := node("invoice", "method", "invoiceTotal", 1, "tax", "round", "discount", "invoice.lines")
+	copied := node("quote", "method", "invoice_total", 10, "tax", "round", "discount", "quote.lines")
+	target.ClassName, copied.ClassName = "Invoice", "Quote"
+	invoiceLines, quoteLines := node("invoice.lines", "method", "lines", 2), node("quote.lines", "method", "lines", 11)
+	invoiceLines.ClassName, quoteLines.ClassName = "Invoice", "Quote"
+	drifted := node("order", "function", "orderTotal", 20, "tax", "round", "log")
+	unrelated := node("send", "method", "sendMail", 30, "smtp")
+	class := node("cart", "class", "invoiceTotal", 40, "tax", "round", "discount", "lines")
+	graph := &models.DependencyGraph{Nodes: map[string]*models.DependencyNode{}}
+	for _, n := range []*models.DependencyNode{target, copied, invoiceLines, quoteLines, drifted, unrelated, class} {
+		graph.Nodes[n.ID] = n
+	}
+	files := []*models.ParsedFile{{Path: "app.php", Elements: []models.CodeElement{
+		{Type: "method", Name: "invoiceTotal", Line: 1, Parameters: []string{"$items", "$rate"}},
+		{Type: "method", Name: "invoice_total", Line: 10, Parameters: []string{"$items", "$rate"}},
+		{Type: "function", Name: "orderTotal", Line: 20, Parameters: []string{"$order"}},
+		{Type: "method", Name: "sendMail", Line: 30, Parameters: []string{"$to", "$body"}},
+	}}}
+
+	matches := Find(graph, files, target, 0.3, 0)
+	if len(matches) != 2 || matches[0].Node != copied || matches[1].Node != drifted {
+		t.Fatalf("expected the copy, then the drifted version, got %+v", matches)
+	}
+	if matches[0].Score != 1 || matches[0].Shared != 4 || matches[0].Union != 4 {
+		t.Errorf("expected the copy with a renamed method to score 1, got %+v", matches[0])
+	}
+	want := []string{"4 of 4 dependencies shared", "same parameters", "same name"}
+	if len(matches[0].Reasons) != len(want) {
+		t.Fatalf("expected reasons %v, got %v", want, matches[0].Reasons)
+	}
+	for i, reason := range want {
+		if matches[0].Reasons[i] != reason {
+			t.Errorf("expected reasons %v, got %v", want, matches[0].Reasons)
+		}
+	}
+	if matches[1].Shared != 2 || matches[1].Union != 5 || matches[1].Parameters != 1 {
+		t.Errorf("expected 2 of 5 dependencies and 1 parameter, got %+v", matches[1])
+	}
+
+	if matches := Find(graph, files, target, 0.3, 1); len(matches) != 1 {
+		t.Errorf("expected the limit to apply, got %+v", matches)
+	}
+	if matches := Find(graph, files, class, 0, 0); len(matches) != 0 {
+		t.Errorf("expected classes to be compared only with classes, got %+v", matches)
+	}
+}
+
+func TestNameTokens(t *testing.T) {
+	for name, want := range map[string][]string{
+		"getUserName":   {"get", "user", "name"},
+		"get_user_name": {"get", "user", "name"},
+		"$HTMLParser2":  {"html", "parser", "2"},
+		"__init__":      {"init"},
+	} {
+		tokens := nameTokens(name)
+		if len(tokens) != len(want) {
+			t.Errorf("%s: expected %v, got %v", name, want, tokens)
+			continue
+		}
+		for _, token := range want {
+			if !tokens[token] {
+				t.Errorf("%s: expected %v, got %v", name, want, tokens)
+			}
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package trace reads runtime traces (Xdebug function traces, Tideways/XHProf profiles,
+// and OpenTelemetry span exports) into the calls between functions they observed
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Formats Detect tells apart
+const (
+	FormatXdebug   = "xdebug"
+	FormatTideways = "tideways"
+	FormatOTel     = "otel"
+)
+
+// Call is a caller → callee pair, named as the trace names them, e.g.
+// `App\Http\Kernel->handle` → `App\Models\User::find`
+type Call struct {
+	Caller string
+	Callee string
+}
+
+// Trace is the calls observed in one or more trace files
+type Trace struct {
+	Sources []string     // The trace files read
+	Calls   map[Call]int // Times each pair was observed
+}
+
+// New creates an empty trace
+func New() *Trace {
+	return &Trace{Calls: make(map[Call]int)}
+}
+
+// Load reads trace files into one trace, adding up calls more than one of them observed
+func Load(paths ...string) (*Trace, error) {
+	t := New()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.Parse(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		t.Sources = append(t.Sources, p)
+	}
+	return t, nil
+}
+
+// Detect returns the format of trace data, or "" when it isn't one Parse reads
+func Detect(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("Version:")), bytes.HasPrefix(trimmed, []byte("TRACE START")):
+		return FormatXdebug
+	case bytes.HasPrefix(trimmed, []byte("{")) && bytes.Contains(trimmed, []byte(`"resourceSpans"`)):
+		return FormatOTel
+	case bytes.HasPrefix(trimmed, []byte("{")) && bytes.Contains(trimmed, []byte("==>")):
+		return FormatTideways
+	}
+	return ""
+}
+
+// Parse adds trace data in any of the formats Detect recognizes to the trace
+func (t *Trace) Parse(data []byte) error {
+	switch Detect(data) {
+	case FormatXdebug:
+		return t.parseXdebug(data)
+	case FormatOTel:
+		return t.parseOTel(data)
+	case FormatTideways:
+		return t.parseTideways(data)
+	}
+	return fmt.Errorf("not an Xdebug trace, Tideways/XHProf profile, or OpenTelemetry export")
+}
+
+// add records count calls from caller to callee. Calls from the script itself ({main})
+// have no caller to link, and recursion adds nothing to the graph.
+func (t *Trace) add(caller, callee string, count int) {
+	if caller == "" || callee == "" || caller == callee {
+		return
+	}
+	t.Calls[Call{Caller: caller, Callee: callee}] += count
+}
+
+// xdebugFrame is a function on the call stack of an Xdebug trace
+type xdebugFrame struct {
+	name        string
+	userDefined bool
+}
+
+// humanCallPattern matches a call in a human-readable Xdebug trace (trace_format=0):
+// "    0.0011      57000     -> App\Models\User::find($id = 1) /app/src/Models/User.php:12"
+var humanCallPattern = regexp.MustCompile(`^\s*[\d.]+\s+\d+(\s+)-> ([^\s(]+)\(`)
+
+// parseXdebug reads Xdebug function traces. In the computerized format (trace_format=1),
+// entry lines are tab-separated "level, function number, 0, time, memory, name, user-defined,
+// ..." and say whether PHP or the project defined the function, so a callback PHP calls
+// (array_map, usort) is attributed to the project function that called PHP. The human
+// format only has the nesting, by indentation, and every function counts as the project's.
+func (t *Trace) parseXdebug(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // Parameters can be long
+	var stack []xdebugFrame
+	push := func(level int, frame xdebugFrame) {
+		if level < 1 {
+			return
+		}
+		if level-1 < len(stack) {
+			stack = stack[:level-1]
+		}
+		for len(stack) < level-1 {
+			stack = append(stack, xdebugFrame{}) // A level the trace started below
+		}
+		frame.userDefined = frame.userDefined && xdebugName(frame.name) != ""
+		if frame.userDefined {
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].userDefined {
+					t.add(xdebugName(stack[i].name), xdebugName(frame.name), 1)
+					break
+				}
+			}
+		}
+		stack = append(stack, frame)
+	}
+
+	indent := -1 // The human format's indentation at level 1
+	for scanner.Scan() {
+		line := scanner.Text()
+		if fields := strings.Split(line, "\t"); len(fields) >= 7 && fields[2] == "0" {
+			var level int
+			if _, err := fmt.Sscanf(fields[0], "%d", &level); err != nil {
+				return fmt.Errorf("invalid trace line %q", line)
+			}
+			push(level, xdebugFrame{name: fields[5], userDefined: fields[6] == "1"})
+		} else if match := humanCallPattern.FindStringSubmatch(line); match != nil {
+			if indent == -1 {
+				indent = len(match[1])
+			}
+			push((len(match[1])-indent)/2+1, xdebugFrame{name: match[2], userDefined: true})
+		}
+	}
+	return scanner.Err()
+}
+
+// xdebugName returns the name a function is declared with, or "" for the frames no
+// declaration has: the script itself ({main}), closures ("{closure:/app/routes.php:12-14}"),
+// and included files. Calls in those are attributed to the function around them.
+func xdebugName(name string) string {
+	if name == "{main}" || strings.HasPrefix(name, "{closure") || strings.HasPrefix(name, "require") || strings.HasPrefix(name, "include") {
+		return ""
+	}
+	return name
+}
+
+// parseTideways reads Tideways and XHProf profiles: a JSON object whose keys are
+// "caller==>callee" and whose values count the calls in "ct". Recursive calls are
+// suffixed "@1", "@2", ...
+func (t *Trace) parseTideways(data []byte) error {
+	var profile map[string]struct {
+		Calls int `json:"ct"`
+	}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return err
+	}
+	for key, stats := range profile {
+		caller, callee, ok := strings.Cut(key, "==>")
+		if !ok {
+			continue
+		}
+		count := stats.Calls
+		if count == 0 {
+			count = 1
+		}
+		t.add(tidewaysName(caller), tidewaysName(callee), count)
+	}
+	return nil
+}
+
+// tidewaysName drops the recursion suffix and the profile's root, main()
+func tidewaysName(name string) string {
+	if idx := strings.LastIndex(name, "@"); idx != -1 && strings.Trim(name[idx+1:], "0123456789") == "" {
+		name = name[:idx]
+	}
+	if name == "main()" || strings.HasPrefix(name, "{closure") || strings.HasPrefix(name, "run_init::") {
+		return ""
+	}
+	return name
+}
+
+// otelExport is the part of an OTLP JSON export (ExportTraceServiceRequest) the trace
+// reads. The file exporter and collectors write one per line.
+type otelExport struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []otelSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type otelSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Attributes   []struct {
+		Key   string `json:"key"`
+		Value struct {
+			String string `json:"stringValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+}
+
+// function names the code a span instrumented, from the code.* semantic conventions:
+// code.function.name, fully qualified, or code.namespace with code.function. Without them,
+// a span name that looks like a function (no spaces, as auto-instrumentation of methods
+// names them) stands in; "GET /users" does not.
+func (s otelSpan) function() string {
+	attrs := make(map[string]string)
+	for _, attr := range s.Attributes {
+		attrs[attr.Key] = attr.Value.String
+	}
+	switch {
+	case attrs["code.function.name"] != "":
+		return attrs["code.function.name"]
+	case attrs["code.function"] != "" && attrs["code.namespace"] != "":
+		return attrs["code.namespace"] + "::" + attrs["code.function"]
+	case attrs["code.function"] != "":
+		return attrs["code.function"]
+	case s.Name != "" && !strings.ContainsAny(s.Name, " /"):
+		return s.Name
+	}
+	return ""
+}
+
+// parseOTel reads OpenTelemetry span exports in OTLP JSON. Each span that names a
+// function is a call from the function of its nearest ancestor span that names one.
+func (t *Trace) parseOTel(data []byte) error {
+	var exports []otelExport
+	var whole otelExport
+	if err := json.Unmarshal(data, &whole); err == nil {
+		exports = append(exports, whole)
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var export otelExport
+			if err := json.Unmarshal(scanner.Bytes(), &export); err != nil {
+				return err
+			}
+			exports = append(exports, export)
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	spans := make(map[string]otelSpan) // By trace and span ID
+	var order []otelSpan
+	for _, export := range exports {
+		for _, resource := range export.ResourceSpans {
+			for _, scope := range resource.ScopeSpans {
+				for _, span := range scope.Spans {
+					spans[span.TraceID+"/"+span.SpanID] = span
+					order = append(order, span)
+				}
+			}
+		}
+	}
+	for _, span := range order {
+		callee := span.function()
+		if callee == "" {
+			continue
+		}
+		parent, seen := spans[span.TraceID+"/"+span.ParentSpanID], map[string]bool{}
+		for parent.SpanID != "" && parent.function() == "" && !seen[parent.SpanID] {
+			seen[parent.SpanID] = true
+			parent = spans[span.TraceID+"/"+parent.ParentSpanID]
+		}
+		if parent.SpanID != "" {
+			t.add(parent.function(), callee, 1)
+		}
+	}
+	return nil
+}
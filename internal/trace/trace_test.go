@@ -0,0 +1,92 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+		want   map[Call]int
+	}{
+		{
+			name:   "xdebug computerized",
+			format: FormatXdebug,
+			data: "Version: 3.3.0\nFile format: 4\nTRACE START [2025-01-01 10:00:00.000000]\n" +
+				"1\t0\t0\t0.0001\t393472\t{main}\t1\t\t/app/index.php\t0\t0\n" +
+				"2\t1\t0\t0.0002\t393472\tApp\\Kernel->handle\t1\t\t/app/index.php\t5\t1\t$request\n" +
+				"3\t2\t0\t0.0003\t393500\tarray_map\t0\t\t/app/src/Kernel.php\t20\t2\n" +
+				"4\t3\t0\t0.0004\t393600\t{closure:/app/src/Kernel.php:20-20}\t1\t\t/app/src/Kernel.php\t20\t1\n" +
+				"5\t4\t0\t0.0005\t393700\tApp\\Models\\User::find\t1\t\t/app/src/Kernel.php\t20\t1\n" +
+				"5\t4\t1\t0.0006\t393700\n" +
+				"4\t3\t1\t0.0007\t393600\n" +
+				"4\t5\t0\t0.0008\t393600\t{closure:/app/src/Kernel.php:20-20}\t1\t\t/app/src/Kernel.php\t20\t1\n" +
+				"5\t6\t0\t0.0009\t393700\tApp\\Models\\User::find\t1\t\t/app/src/Kernel.php\t20\t1\n" +
+				"3\t7\t0\t0.0010\t393500\tApp\\Kernel->terminate\t1\t\t/app/src/Kernel.php\t21\t0\n" +
+				"2\t8\t0\t0.0011\t393472\thelper\t1\t\t/app/index.php\t6\t0\n" +
+				"TRACE END   [2025-01-01 10:00:00.001000]\n",
+			want: map[Call]int{
+				{`App\Kernel->handle`, `App\Models\User::find`}: 2,
+				{`App\Kernel->handle`, `App\Kernel->terminate`}: 1,
+			},
+		},
+		{
+			name:   "xdebug human",
+			format: FormatXdebug,
+			data: `TRACE START [2025-01-01 10:00:00]
+    0.0010      57000   -> {main}() /app/index.php:0
+    0.0011      57000     -> App\Kernel->handle($request = class Request {  }) /app/index.php:5
+    0.0012      57100       -> App\Models\User::find($id = 1) /app/src/Kernel.php:20
+    0.0013      57200     -> helper() /app/index.php:6
+TRACE END   [2025-01-01 10:00:01]
+`,
+			want: map[Call]int{{`App\Kernel->handle`, `App\Models\User::find`}: 1},
+		},
+		{
+			name:   "tideways",
+			format: FormatTideways,
+			data: `{
+  "main()": {"ct": 1, "wt": 900},
+  "main()==>App\\Kernel::handle": {"ct": 1, "wt": 800},
+  "App\\Kernel::handle==>App\\Models\\User::find": {"ct": 3, "wt": 200},
+  "App\\Models\\User::find==>App\\Models\\User::find@1": {"ct": 1, "wt": 10}
+}`,
+			want: map[Call]int{{`App\Kernel::handle`, `App\Models\User::find`}: 3},
+		},
+		{
+			name:   "otel",
+			format: FormatOTel,
+			data: `{"resourceSpans":[{"scopeSpans":[{"spans":[
+  {"traceId":"t1","spanId":"a","name":"GET /users"},
+  {"traceId":"t1","spanId":"b","parentSpanId":"a","name":"UserController.index",
+   "attributes":[{"key":"code.namespace","value":{"stringValue":"com.acme.web.UserController"}},{"key":"code.function","value":{"stringValue":"index"}}]},
+  {"traceId":"t1","spanId":"c","parentSpanId":"b","name":"SELECT users"},
+  {"traceId":"t1","spanId":"d","parentSpanId":"c","name":"ignored",
+   "attributes":[{"key":"code.function.name","value":{"stringValue":"com.acme.store.UserStore.all"}}]}
+]}]}]}`,
+			want: map[Call]int{{"com.acme.web.UserController::index", "com.acme.store.UserStore.all"}: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect([]byte(tt.data)); got != tt.format {
+				t.Fatalf("Detect = %q, want %q", got, tt.format)
+			}
+			trace := New()
+			if err := trace.Parse([]byte(tt.data)); err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			if !reflect.DeepEqual(trace.Calls, tt.want) {
+				t.Errorf("expected calls %v, got %v", tt.want, trace.Calls)
+			}
+		})
+	}
+
+	if err := New().Parse([]byte("mode: set\n")); err == nil {
+		t.Error("expected an error for a coverage profile")
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRepo is the GitHub repository releases are published to
+const DefaultRepo = "boone-studios/tukey"
+
+// Release is a published GitHub release
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Name       string  `json:"name"`
+	HTMLURL    string  `json:"html_url"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Updater checks GitHub releases and replaces the running binary
+type Updater struct {
+	Repo   string
+	APIURL string // GitHub API base URL, overridable for tests and GitHub Enterprise
+	GOOS   string
+	GOARCH string
+	Client *http.Client
+}
+
+// NewUpdater creates an updater for repo targeting the current platform
+func NewUpdater(repo string) *Updater {
+	return &Updater{
+		Repo:   repo,
+		APIURL: "https://api.github.com",
+		GOOS:   runtime.GOOS,
+		GOARCH: runtime.GOARCH,
+		Client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Latest returns the newest non-prerelease release
+func (u *Updater) Latest() (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimSuffix(u.APIURL, "/"), u.Repo)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for updates: %s returned %s", url, resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to decode release: %w", err)
+	}
+	return &release, nil
+}
+
+// AssetName returns the archive name the release workflow publishes for this platform
+func (u *Updater) AssetName(tag string) string {
+	name := fmt.Sprintf("tukey_%s_%s_%s", tag, u.GOOS, u.GOARCH)
+	if u.GOOS == "windows" {
+		return name + ".zip"
+	}
+	return name + ".tar.gz"
+}
+
+// Apply downloads the release archive for this platform, verifies it against the release's
+// SHA256SUMS, and replaces the binary at exePath
+func (u *Updater) Apply(release *Release, exePath string) error {
+	archiveName := u.AssetName(release.TagName)
+	archive := release.asset(archiveName)
+	if archive == nil {
+		return fmt.Errorf("release %s has no build for %s/%s", release.TagName, u.GOOS, u.GOARCH)
+	}
+	sums := release.asset("SHA256SUMS")
+	if sums == nil {
+		return fmt.Errorf("release %s has no SHA256SUMS", release.TagName)
+	}
+
+	data, err := u.download(archive.URL)
+	if err != nil {
+		return err
+	}
+	sumData, err := u.download(sums.URL)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(archiveName, data, sumData); err != nil {
+		return err
+	}
+
+	binary, err := extractBinary(archiveName, data)
+	if err != nil {
+		return err
+	}
+	return replaceExecutable(exePath, binary)
+}
+
+// asset finds a release asset by name
+func (r *Release) asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// download fetches url into memory
+func (u *Updater) download(url string) ([]byte, error) {
+	resp, err := u.Client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// verifyChecksum checks data against the entry for name in a sha256sum listing
+func verifyChecksum(name string, data, sums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != strings.ToLower(fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum listed for %s", name)
+}
+
+// extractBinary pulls the tukey executable out of a .tar.gz or .zip release archive
+func extractBinary(archiveName string, data []byte) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", archiveName, err)
+		}
+		for _, f := range zr.File {
+			if isBinaryName(f.Name) {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer rc.Close()
+				return io.ReadAll(rc)
+			}
+		}
+		return nil, fmt.Errorf("%s doesn't contain a tukey binary", archiveName)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", archiveName, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archiveName, err)
+		}
+		if header.Typeflag == tar.TypeReg && isBinaryName(header.Name) {
+			return io.ReadAll(tr)
+		}
+	}
+	return nil, fmt.Errorf("%s doesn't contain a tukey binary", archiveName)
+}
+
+// isBinaryName reports whether an archive entry is the tukey executable
+// ("tukey", "tukey.exe", or the versioned "tukey_v1.2.3_linux_amd64")
+func isBinaryName(name string) bool {
+	base := strings.TrimSuffix(filepath.Base(name), ".exe")
+	return base == "tukey" || strings.HasPrefix(base, "tukey_")
+}
+
+// replaceExecutable swaps the file at exePath for binary. The new file is written next to
+// the old one and renamed over it, so a failed update never leaves a partial binary.
+func replaceExecutable(exePath string, binary []byte) error {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, ".tukey-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to write update (is %s writable?): %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0111); err != nil {
+		return err
+	}
+
+	// Windows can't replace a running executable, but it can rename it out of the way
+	if runtime.GOOS == "windows" {
+		old := exePath + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(exePath, old); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmpPath, exePath)
+}
+
+// IsNewer reports whether version latest is newer than current. Both may carry a leading
+// "v"; builds that aren't a release (e.g. "dev" or "v1.2.3-4-gabcdef") compare by their
+// numeric prefix, with a pre-release suffix sorting before the release itself.
+func IsNewer(latest, current string) bool {
+	l, lPre := parseVersion(latest)
+	c, cPre := parseVersion(current)
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return cPre && !lPre
+}
+
+// parseVersion splits "v1.2.3-rc1" into [1 2 3] and whether it has a suffix
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	core, suffix, hasSuffix := strings.Cut(version, "-")
+	for i, field := range strings.SplitN(core, ".", 3) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		parts[i] = n
+	}
+	return parts, hasSuffix && suffix != ""
+}
@@ -0,0 +1,197 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func tarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("failed to write tar header: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("failed to write tar entry: %v", err)
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+// releaseServer serves a fake GitHub release API with one linux/amd64 archive
+func releaseServer(t *testing.T, archive []byte, sums string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/repos/acme/tukey/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Release{
+			TagName: "v9.9.9",
+			Assets: []Asset{
+				{Name: "tukey_v9.9.9_linux_amd64.tar.gz", URL: server.URL + "/download/archive"},
+				{Name: "SHA256SUMS", URL: server.URL + "/download/sums"},
+			},
+		})
+	})
+	mux.HandleFunc("/download/archive", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/download/sums", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(sums)) })
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testUpdater(server *httptest.Server) *Updater {
+	u := NewUpdater("acme/tukey")
+	u.APIURL = server.URL
+	u.GOOS, u.GOARCH = "linux", "amd64"
+	return u
+}
+
+func TestUpdater_LatestAndApply(t *testing.T) {
+	archive := tarGz(t, "tukey_v9.9.9_linux_amd64", []byte("new binary"))
+	sum := sha256.Sum256(archive)
+	sums := fmt.Sprintf("%s  tukey_v9.9.9_linux_amd64.tar.gz\n%s  tukey_v9.9.9_windows_amd64.zip\n",
+		hex.EncodeToString(sum[:]), hex.EncodeToString(sum[:]))
+	u := testUpdater(releaseServer(t, archive, sums))
+
+	release, err := u.Latest()
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if release.TagName != "v9.9.9" {
+		t.Errorf("unexpected release: %+v", release)
+	}
+
+	exe := filepath.Join(t.TempDir(), "tukey")
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	if err := u.Apply(release, exe); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new binary" {
+		t.Errorf("expected binary to be replaced, got %q", data)
+	}
+	if info, _ := os.Stat(exe); info.Mode().Perm()&0100 == 0 {
+		t.Errorf("expected replaced binary to stay executable, got %v", info.Mode())
+	}
+}
+
+func TestUpdater_ApplyRejectsChecksumMismatch(t *testing.T) {
+	archive := tarGz(t, "tukey", []byte("tampered"))
+	sums := "0000000000000000000000000000000000000000000000000000000000000000  tukey_v9.9.9_linux_amd64.tar.gz\n"
+	u := testUpdater(releaseServer(t, archive, sums))
+
+	release, err := u.Latest()
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	exe := filepath.Join(t.TempDir(), "tukey")
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	if err := u.Apply(release, exe); err == nil {
+		t.Fatalf("expected checksum mismatch error")
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old binary" {
+		t.Errorf("expected binary to be untouched, got %q", data)
+	}
+
+	u.GOOS = "plan9"
+	if err := u.Apply(release, exe); err == nil {
+		t.Errorf("expected error for a platform without a build")
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	u := &Updater{GOOS: "windows", GOARCH: "arm64"}
+	if got := u.AssetName("v1.2.3"); got != "tukey_v1.2.3_windows_arm64.zip" {
+		t.Errorf("unexpected windows asset: %s", got)
+	}
+	u.GOOS = "darwin"
+	if got := u.AssetName("v1.2.3"); got != "tukey_v1.2.3_darwin_arm64.tar.gz" {
+		t.Errorf("unexpected darwin asset: %s", got)
+	}
+}
+
+func TestAssetName_MatchesReleaseWorkflow(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", ".github", "workflows", "release.yml"))
+	if err != nil {
+		t.Fatalf("failed to read release workflow: %v", err)
+	}
+	workflow := string(data)
+
+	// The packaging step must strip only ".exe", not everything after the version's last dot
+	if !strings.Contains(workflow, `base="${f%.exe}"`) {
+		t.Fatalf("release workflow no longer names archives after the whole binary name")
+	}
+	out := regexp.MustCompile(`out="([^"]+)"`).FindStringSubmatch(workflow)
+	if out == nil {
+		t.Fatalf("release workflow has no binary name template")
+	}
+
+	targets := regexp.MustCompile(`(?m)^\s+"(\w+) (\w+)"$`).FindAllStringSubmatch(workflow, -1)
+	if len(targets) == 0 {
+		t.Fatalf("release workflow has no build targets")
+	}
+	seen := map[string]bool{}
+	for _, target := range targets {
+		goos, goarch := target[1], target[2]
+		ext := ""
+		if goos == "windows" {
+			ext = ".exe"
+		}
+		binary := strings.NewReplacer("${VERSION}", "v1.2.3", "${goos}", goos, "${goarch}", goarch, "${ext}", ext).Replace(out[1])
+
+		archive := strings.TrimSuffix(binary, ".exe") + ".tar.gz"
+		if ext == ".exe" {
+			archive = strings.TrimSuffix(binary, ".exe") + ".zip"
+		}
+		if seen[archive] {
+			t.Errorf("release workflow writes %s more than once", archive)
+		}
+		seen[archive] = true
+
+		u := &Updater{GOOS: goos, GOARCH: goarch}
+		if got := u.AssetName("v1.2.3"); got != archive {
+			t.Errorf("%s/%s: self-update looks for %s, the workflow publishes %s", goos, goarch, got, archive)
+		}
+		if !isBinaryName(binary) {
+			t.Errorf("%s/%s: self-update wouldn't find %s in the archive", goos, goarch, binary)
+		}
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v0.4.0", "0.3.0", true},
+		{"v0.3.0", "v0.3.0", false},
+		{"v0.3.0", "0.3.1", false},
+		{"v1.0.0", "v0.9.12", true},
+		{"v0.3.0", "v0.3.0-rc1", true},
+		{"v0.3.0-rc2", "v0.3.0", false},
+		{"v0.3.1", "v0.3.0-4-gabcdef-dirty", true},
+	}
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+// Package wasmrule runs analyzer rules compiled to WebAssembly, so rules can be shared
+// without trusting their authors. A rule runs sandboxed: it has no file system, network,
+// clock, or environment, its memory and run time are capped, and all it sees of the
+// analysis is the graph, one node at a time, through the host functions below.
+//
+// A rule module exports its memory as "memory" and a function "run", and may import
+// from the "tukey" module:
+//
+//	node_count() -> i32                  the number of nodes, sorted by ID
+//	node(index, ptr, cap i32) -> i32     writes node index as JSON at ptr and returns
+//	                                     its length; nothing is written when the length
+//	                                     is over cap, and -1 is returned past the end
+//	report(ptr, len i32)                 reports a finding, as JSON at ptr
+//
+// Each rule is registered as an analyzer pass named after its file, e.g. "no-god-classes"
+// for no-god-classes.wasm, with a metric of the same name counting its findings.
+package wasmrule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Limits of a rule run
+const (
+	MemoryPages = 256 // 16 MiB
+	MaxFindings = 10000
+)
+
+// Timeout is how long a rule may run on one analysis
+var Timeout = 30 * time.Second
+
+// Node is a graph node as rules see it
+type Node struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	File         string   `json:"file"`
+	Line         int      `json:"line"`
+	Namespace    string   `json:"namespace"`
+	ClassName    string   `json:"className,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	Score        int      `json:"score"`
+	Dependencies []string `json:"dependencies"` // Node IDs, sorted
+	Dependents   []string `json:"dependents"`
+	Suppressed   []string `json:"suppressed,omitempty"`
+	InScope      bool     `json:"inScope"` // False for nodes outside --changed-only
+}
+
+// Finding is what a rule reports
+type Finding struct {
+	Message string   `json:"message"`
+	Nodes   []string `json:"nodes,omitempty"`
+	File    string   `json:"file,omitempty"`
+	Line    int      `json:"line,omitempty"`
+}
+
+// Rule is a compiled WASM rule
+type Rule struct {
+	Path string
+
+	name     string
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+
+	mu  sync.Mutex // One run at a time
+	err error      // The first failed run
+}
+
+// Load compiles each rule and registers it as an analyzer pass. Relative paths are
+// resolved against root. On error, the rules already loaded are closed.
+func Load(root string, paths []string) ([]*Rule, error) {
+	var rules []*Rule
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		rule, err := load(path)
+		if err == nil {
+			err = rule.register()
+			if err != nil {
+				rule.Close()
+			}
+		}
+		if err != nil {
+			Close(rules)
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func load(path string) (*Rule, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(MemoryPages).
+		WithCloseOnContextDone(true))
+	rule := &Rule{
+		Path:    path,
+		name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		runtime: runtime,
+	}
+
+	if _, err := runtime.NewHostModuleBuilder("tukey").
+		NewFunctionBuilder().WithGoModuleFunction(api.GoModuleFunc(nodeCount), nil, []api.ValueType{api.ValueTypeI32}).Export("node_count").
+		NewFunctionBuilder().WithGoModuleFunction(api.GoModuleFunc(node), []api.ValueType{api.ValueTypeI32, api.ValueTypeI32, api.ValueTypeI32}, []api.ValueType{api.ValueTypeI32}).Export("node").
+		NewFunctionBuilder().WithGoModuleFunction(api.GoModuleFunc(report), []api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, nil).Export("report").
+		Instantiate(ctx); err != nil {
+		rule.Close()
+		return nil, err
+	}
+	rule.compiled, err = runtime.CompileModule(ctx, code)
+	if err != nil {
+		rule.Close()
+		return nil, err
+	}
+
+	run, ok := rule.compiled.ExportedFunctions()["run"]
+	switch {
+	case !ok:
+		err = errors.New(`the module doesn't export "run"`)
+	case len(run.ParamTypes()) != 0 || len(run.ResultTypes()) != 0:
+		err = errors.New(`"run" must take and return nothing`)
+	case rule.compiled.ExportedMemories()["memory"] == nil:
+		err = errors.New(`the module doesn't export "memory"`)
+	}
+	for _, imported := range rule.compiled.ImportedFunctions() {
+		if module, _, _ := imported.Import(); module != "tukey" && err == nil {
+			err = fmt.Errorf("the module imports from %q; rules may only import from \"tukey\"", module)
+		}
+	}
+	if err != nil {
+		rule.Close()
+		return nil, err
+	}
+	return rule, nil
+}
+
+// register adds the rule to the analyzer passes, refusing names already taken rather
+// than letting the registry panic
+func (r *Rule) register() error {
+	for _, existing := range analyzer.Passes() {
+		if existing.Name() == r.name {
+			return fmt.Errorf("analyzer pass %q is already registered", r.name)
+		}
+	}
+	for _, existing := range analyzer.PassMetrics() {
+		if existing == r.name {
+			return fmt.Errorf("metric %q is already declared", r.name)
+		}
+	}
+	analyzer.RegisterPass(r)
+	return nil
+}
+
+// Err returns the error of the first run that failed, if any. Passes can't return
+// errors, so callers check this after running them.
+func (r *Rule) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close frees the rule's runtime
+func (r *Rule) Close() error {
+	return r.runtime.Close(context.Background())
+}
+
+// Close frees every rule
+func Close(rules []*Rule) {
+	for _, rule := range rules {
+		rule.Close()
+	}
+}
+
+func (r *Rule) Name() string      { return r.name }
+func (r *Rule) Metrics() []string { return []string{r.name} }
+
+// Run runs the rule on a fresh instance. A failed run returns no metrics; the error is
+// kept for Err.
+func (r *Rule) Run(result *models.AnalysisResult) *analyzer.PassResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state := &run{nodes: nodes(result.Graph)}
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	ctx = context.WithValue(ctx, runKey{}, state)
+
+	module, err := r.runtime.InstantiateModule(ctx, r.compiled, wazero.NewModuleConfig().WithName(""))
+	if err != nil {
+		r.fail(err)
+		return nil
+	}
+	defer module.Close(ctx)
+	_, err = module.ExportedFunction("run").Call(ctx)
+	switch {
+	case state.err != nil:
+		err = state.err
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		err = fmt.Errorf("no result after %s", Timeout)
+	}
+	if err != nil {
+		r.fail(err)
+		return nil
+	}
+
+	out := &analyzer.PassResult{Metrics: map[string]int{r.name: len(state.findings)}}
+	for _, finding := range state.findings {
+		out.Findings = append(out.Findings, &models.PassFinding{
+			Message: finding.Message,
+			Nodes:   finding.Nodes,
+			File:    finding.File,
+			Line:    finding.Line,
+		})
+	}
+	return out
+}
+
+// fail records err as the rule's first error
+func (r *Rule) fail(err error) {
+	if r.err == nil {
+		r.err = fmt.Errorf("wasm rule %s: %w", r.name, err)
+	}
+}
+
+// run is the state of one run, reached by the host functions through the context
+type run struct {
+	nodes    []Node
+	findings []Finding
+	err      error
+}
+
+type runKey struct{}
+
+// nodes lists the graph's nodes as rules see them, sorted by ID
+func nodes(graph *models.DependencyGraph) []Node {
+	list := make([]Node, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		list = append(list, Node{
+			ID:           node.ID,
+			Name:         node.Name,
+			Type:         node.Type,
+			File:         node.File,
+			Line:         node.Line,
+			Namespace:    node.Namespace,
+			ClassName:    node.ClassName,
+			Language:     node.Language,
+			Score:        node.Score,
+			Dependencies: ids(node.Dependencies),
+			Dependents:   ids(node.Dependents),
+			Suppressed:   node.Suppressed,
+			InScope:      analyzer.InScope(graph, node),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func ids(refs map[string]*models.DependencyRef) []string {
+	list := make([]string, 0, len(refs))
+	for id := range refs {
+		list = append(list, id)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func nodeCount(ctx context.Context, _ api.Module, stack []uint64) {
+	state := ctx.Value(runKey{}).(*run)
+	stack[0] = api.EncodeI32(int32(len(state.nodes)))
+}
+
+func node(ctx context.Context, module api.Module, stack []uint64) {
+	state := ctx.Value(runKey{}).(*run)
+	index, ptr, capacity := api.DecodeI32(stack[0]), api.DecodeU32(stack[1]), api.DecodeI32(stack[2])
+	if index < 0 || int(index) >= len(state.nodes) {
+		stack[0] = api.EncodeI32(-1)
+		return
+	}
+	data, _ := json.Marshal(state.nodes[index])
+	if len(data) <= int(capacity) && !module.Memory().Write(ptr, data) {
+		abort(state, fmt.Errorf("node %d: out of bounds write at %d", index, ptr))
+		return
+	}
+	stack[0] = api.EncodeI32(int32(len(data)))
+}
+
+func report(ctx context.Context, module api.Module, stack []uint64) {
+	state := ctx.Value(runKey{}).(*run)
+	ptr, length := api.DecodeU32(stack[0]), api.DecodeU32(stack[1])
+	data, ok := module.Memory().Read(ptr, length)
+	if !ok {
+		abort(state, fmt.Errorf("report: out of bounds read at %d", ptr))
+		return
+	}
+	var finding Finding
+	if err := json.Unmarshal(data, &finding); err != nil {
+		abort(state, fmt.Errorf("report: %w", err))
+		return
+	}
+	if finding.Message == "" {
+		abort(state, errors.New("report: the finding has no message"))
+		return
+	}
+	if len(state.findings) == MaxFindings {
+		abort(state, fmt.Errorf("more than %d findings", MaxFindings))
+		return
+	}
+	state.findings = append(state.findings, finding)
+}
+
+// abort ends the run with err; the panic unwinds the guest and fails its call
+func abort(state *run, err error) {
+	state.err = err
+	panic(err)
+}
@@ -0,0 +1,193 @@
+package wasmrule
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/analyzer"
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// The modules below are assembled by hand, to keep a WebAssembly toolchain out of the tests
+
+// wasmImport is a function imported by a test module
+type wasmImport struct {
+	module, name string
+	typ          byte // Index into the types of wasmModule
+}
+
+// wasmModule assembles a module exporting its memory and run, with body as run's code
+// and data at offset 0
+func wasmModule(imports []wasmImport, body []byte, data string) []byte {
+	vec := func(items ...[]byte) []byte {
+		out := uleb(len(items))
+		for _, item := range items {
+			out = append(out, item...)
+		}
+		return out
+	}
+	name := func(s string) []byte { return append(uleb(len(s)), s...) }
+	section := func(id byte, content []byte) []byte {
+		return append(append([]byte{id}, uleb(len(content))...), content...)
+	}
+
+	types := vec(
+		[]byte{0x60, 0x00, 0x01, 0x7f},                   // 0: () -> i32
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x00},             // 1: (i32, i32) -> ()
+		[]byte{0x60, 0x00, 0x00},                         // 2: () -> ()
+		[]byte{0x60, 0x03, 0x7f, 0x7f, 0x7f, 0x01, 0x7f}, // 3: (i32, i32, i32) -> i32
+	)
+	var entries [][]byte
+	for _, imp := range imports {
+		entries = append(entries, append(append(name(imp.module), name(imp.name)...), 0x00, imp.typ))
+	}
+	exports := vec(
+		append(name("memory"), 0x02, 0x00),
+		append(name("run"), 0x00, byte(len(imports))),
+	)
+	code := vec(append(uleb(len(body)), body...))
+
+	out := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	out = append(out, section(1, types)...)
+	out = append(out, section(2, vec(entries...))...)
+	out = append(out, section(3, vec([]byte{0x02}))...)
+	out = append(out, section(5, vec([]byte{0x00, 0x01}))...)
+	out = append(out, section(7, exports)...)
+	out = append(out, section(10, code)...)
+	if data != "" {
+		out = append(out, section(11, vec(append([]byte{0x00, 0x41, 0x00, 0x0b}, name(data)...)))...)
+	}
+	return out
+}
+
+func uleb(n int) []byte {
+	var out []byte
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+// flagEveryNode reports {"message":"flagged"} once per node
+var flagEveryNode = wasmModule(
+	[]wasmImport{{"tukey", "node_count", 0}, {"tukey", "report", 1}},
+	[]byte{
+		0x01, 0x01, 0x7f, // One i32 local: the node index
+		0x02, 0x40, // block
+		0x03, 0x40, // loop
+		0x20, 0x00, 0x10, 0x00, 0x4e, 0x0d, 0x01, // Break out once index >= node_count()
+		0x41, 0x00, 0x41, byte(len(`{"message":"flagged"}`)), 0x10, 0x01, // report(0, len)
+		0x20, 0x00, 0x41, 0x01, 0x6a, 0x21, 0x00, // index++
+		0x0c, 0x00, // Loop
+		0x0b, 0x0b, 0x0b,
+	},
+	`{"message":"flagged"}`,
+)
+
+func loadRule(t *testing.T, name string, code []byte) *Rule {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name+".wasm"), code, 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := Load(dir, []string{name + ".wasm"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { Close(rules) })
+	return rules[0]
+}
+
+func testResult() *models.AnalysisResult {
+	graph := analyzer.NewDependencyTracker().BuildDependencyGraph([]*models.ParsedFile{{
+		Path:     "app/a.php",
+		Elements: []models.CodeElement{{Type: "function", Name: "a", Line: 1}, {Type: "function", Name: "b", Line: 2}},
+		Usage:    []models.UsageElement{{Type: "function_call", Name: "b", Context: "a", Line: 1}},
+	}})
+	return &models.AnalysisResult{Graph: graph}
+}
+
+func TestRule(t *testing.T) {
+	rule := loadRule(t, "flag-everything", flagEveryNode)
+	if rule.Name() != "flag-everything" {
+		t.Errorf("expected the rule to be named after its file, got %q", rule.Name())
+	}
+
+	metrics, findings := analyzer.RunPasses(testResult())
+	if metrics["flag-everything"] != 2 {
+		t.Errorf("expected a finding per node, got %v", metrics)
+	}
+	count := 0
+	for _, finding := range findings {
+		if finding.Pass == "flag-everything" && finding.Message == "flagged" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected the rule's findings, got %+v", findings)
+	}
+	if err := rule.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRule_Errors(t *testing.T) {
+	// A finding without a message
+	rule := loadRule(t, "empty-findings", wasmModule(
+		[]wasmImport{{"tukey", "report", 1}},
+		[]byte{0x00, 0x41, 0x00, 0x41, 0x02, 0x10, 0x00, 0x0b},
+		`{}`,
+	))
+	if out := rule.Run(testResult()); out != nil {
+		t.Errorf("expected no result from a failed run, got %+v", out)
+	}
+	if err := rule.Err(); err == nil || !strings.Contains(err.Error(), "no message") {
+		t.Errorf("expected the rule's error, got %v", err)
+	}
+
+	// A rule that never ends
+	defer func(timeout time.Duration) { Timeout = timeout }(Timeout)
+	Timeout = 50 * time.Millisecond
+	rule = loadRule(t, "forever", wasmModule(nil, []byte{0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b}, ""))
+	if out := rule.Run(testResult()); out != nil {
+		t.Errorf("expected no result from a run out of time, got %+v", out)
+	}
+	if err := rule.Err(); err == nil || !strings.Contains(err.Error(), "no result after") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+
+	// Rules get the tukey host functions, nothing else
+	dir := t.TempDir()
+	wasi := wasmModule([]wasmImport{{"wasi_snapshot_preview1", "proc_exit", 2}}, []byte{0x00, 0x0b}, "")
+	os.WriteFile(filepath.Join(dir, "wasi.wasm"), wasi, 0644)
+	if _, err := Load(dir, []string{"wasi.wasm"}); err == nil || !strings.Contains(err.Error(), "may only import") {
+		t.Errorf("expected imports outside tukey to be refused, got %v", err)
+	}
+	if _, err := Load(dir, []string{"missing.wasm"}); err == nil {
+		t.Error("expected an error for a missing rule")
+	}
+	os.WriteFile(filepath.Join(dir, "forever.wasm"), flagEveryNode, 0644)
+	if _, err := Load(dir, []string{"forever.wasm"}); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("expected a second rule with the same name to be refused, got %v", err)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	list := nodes(testResult().Graph)
+	if len(list) != 2 || list[0].Name != "a" || list[1].Name != "b" {
+		t.Fatalf("expected the nodes sorted by ID, got %+v", list)
+	}
+	if len(list[0].Dependencies) != 1 || list[0].Dependencies[0] != list[1].ID || len(list[1].Dependents) != 1 {
+		t.Errorf("expected the edges as node IDs, got %+v", list)
+	}
+	if !list[0].InScope {
+		t.Error("expected nodes in scope without --changed-only")
+	}
+}
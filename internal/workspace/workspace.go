@@ -0,0 +1,292 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Package is one package of a monorepo
+type Package struct {
+	Name         string          // Name from package.json or composer.json
+	Dir          string          // Package directory
+	Kind         string          // "npm" or "composer"
+	Dependencies map[string]bool // Declared dependency names (including dev/peer/optional)
+}
+
+// Workspace is the set of packages declared by a monorepo root
+type Workspace struct {
+	Root     string
+	Manager  string // "npm", "pnpm", or "composer" ("npm" also covers yarn workspaces)
+	Packages []*Package
+}
+
+// packageManifest is the subset of package.json used for workspaces
+type packageManifest struct {
+	Name                 string            `json:"name"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// composerManifest is the subset of composer.json used for path repositories
+type composerManifest struct {
+	Name         string            `json:"name"`
+	Require      map[string]string `json:"require"`
+	RequireDev   map[string]string `json:"require-dev"`
+	Repositories json.RawMessage   `json:"repositories"`
+}
+
+// Detect looks for npm/yarn/pnpm workspaces and Composer path repositories declared in
+// root. It returns nil when root isn't a monorepo.
+func Detect(root string) (*Workspace, error) {
+	ws := &Workspace{Root: root}
+
+	npmPatterns, manager, err := npmWorkspacePatterns(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range expandPatterns(root, npmPatterns) {
+		if pkg := loadNPMPackage(dir); pkg != nil {
+			ws.Packages = append(ws.Packages, pkg)
+		}
+	}
+	if len(ws.Packages) > 0 {
+		ws.Manager = manager
+	}
+
+	composerPatterns, err := composerPathRepositories(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range expandPatterns(root, composerPatterns) {
+		if pkg := loadComposerPackage(dir); pkg != nil {
+			ws.Packages = append(ws.Packages, pkg)
+			if ws.Manager == "" {
+				ws.Manager = "composer"
+			}
+		}
+	}
+
+	if len(ws.Packages) == 0 {
+		return nil, nil
+	}
+
+	// The root manifest owns everything outside the member packages
+	if pkg := loadNPMPackage(root); pkg != nil && len(npmPatterns) > 0 {
+		ws.Packages = append(ws.Packages, pkg)
+	}
+	if pkg := loadComposerPackage(root); pkg != nil && len(composerPatterns) > 0 {
+		ws.Packages = append(ws.Packages, pkg)
+	}
+
+	sort.Slice(ws.Packages, func(i, j int) bool {
+		return ws.Packages[i].Name < ws.Packages[j].Name
+	})
+	return ws, nil
+}
+
+// IsRoot reports whether dir declares npm/yarn or pnpm workspaces
+func IsRoot(dir string) bool {
+	patterns, _, err := npmWorkspacePatterns(dir)
+	return err == nil && len(patterns) > 0
+}
+
+// PackageFor returns the innermost package containing path, or nil
+func (ws *Workspace) PackageFor(path string) *Package {
+	path = filepath.Clean(path)
+
+	var best *Package
+	for _, pkg := range ws.Packages {
+		dir := filepath.Clean(pkg.Dir)
+		if path != dir && !strings.HasPrefix(path, dir+string(filepath.Separator)) && dir != "." {
+			continue
+		}
+		if best == nil || len(dir) > len(filepath.Clean(best.Dir)) {
+			best = pkg
+		}
+	}
+	return best
+}
+
+// Find returns the package with the given name, or nil
+func (ws *Workspace) Find(name string) *Package {
+	for _, pkg := range ws.Packages {
+		if pkg.Name == name {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// npmWorkspacePatterns reads workspace globs from package.json or pnpm-workspace.yaml
+func npmWorkspacePatterns(root string) ([]string, string, error) {
+	if data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml")); err == nil {
+		var cfg struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, "", err
+		}
+		return cfg.Packages, "pnpm", nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "package.json"))
+	if err != nil {
+		return nil, "", nil
+	}
+	var manifest packageManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", err
+	}
+	if len(manifest.Workspaces) == 0 {
+		return nil, "", nil
+	}
+
+	// "workspaces": ["packages/*"] or, for yarn, {"packages": ["packages/*"]}
+	var patterns []string
+	if err := json.Unmarshal(manifest.Workspaces, &patterns); err != nil {
+		var yarn struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(manifest.Workspaces, &yarn); err != nil {
+			return nil, "", err
+		}
+		patterns = yarn.Packages
+	}
+	return patterns, "npm", nil
+}
+
+// composerPathRepositories reads the "path" repository URLs from composer.json
+func composerPathRepositories(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "composer.json"))
+	if err != nil {
+		return nil, nil
+	}
+	var manifest composerManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	if len(manifest.Repositories) == 0 {
+		return nil, nil
+	}
+
+	type repository struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+
+	// "repositories" is either a list or an object keyed by name
+	var repos []repository
+	if err := json.Unmarshal(manifest.Repositories, &repos); err != nil {
+		var named map[string]repository
+		if err := json.Unmarshal(manifest.Repositories, &named); err != nil {
+			return nil, err
+		}
+		for _, repo := range named {
+			repos = append(repos, repo)
+		}
+	}
+
+	var patterns []string
+	for _, repo := range repos {
+		if repo.Type == "path" && repo.URL != "" {
+			patterns = append(patterns, repo.URL)
+		}
+	}
+	return patterns, nil
+}
+
+// expandPatterns turns workspace globs into package directories. Negated patterns ("!x")
+// exclude directories, and "**" is treated as a single path segment.
+func expandPatterns(root string, patterns []string) []string {
+	excluded := make(map[string]bool)
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			for _, dir := range globDirs(root, strings.TrimPrefix(pattern, "!")) {
+				excluded[dir] = true
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		for _, dir := range globDirs(root, pattern) {
+			if !excluded[dir] && !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// globDirs expands one workspace glob relative to root into directories
+func globDirs(root, pattern string) []string {
+	pattern = strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(pattern, "./"), "/"), "**", "*")
+	matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			dirs = append(dirs, filepath.Clean(match))
+		}
+	}
+	return dirs
+}
+
+// loadNPMPackage reads a workspace member's package.json
+func loadNPMPackage(dir string) *Package {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var manifest packageManifest
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Name == "" {
+		return nil
+	}
+
+	pkg := &Package{Name: manifest.Name, Dir: dir, Kind: "npm", Dependencies: make(map[string]bool)}
+	for _, deps := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+		for name := range deps {
+			pkg.Dependencies[name] = true
+		}
+	}
+	return pkg
+}
+
+// loadComposerPackage reads a path repository's composer.json
+func loadComposerPackage(dir string) *Package {
+	data, err := os.ReadFile(filepath.Join(dir, "composer.json"))
+	if err != nil {
+		return nil
+	}
+	var manifest composerManifest
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Name == "" {
+		return nil
+	}
+
+	pkg := &Package{Name: manifest.Name, Dir: dir, Kind: "composer", Dependencies: make(map[string]bool)}
+	for _, deps := range []map[string]string{manifest.Require, manifest.RequireDev} {
+		for name := range deps {
+			pkg.Dependencies[name] = true
+		}
+	}
+	return pkg
+}
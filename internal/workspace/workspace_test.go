@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write fixture: %v", err)
+		}
+	}
+}
+
+func packageNames(ws *Workspace) []string {
+	var names []string
+	for _, pkg := range ws.Packages {
+		names = append(names, pkg.Name)
+	}
+	return names
+}
+
+func TestDetect_NPMWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":                 `{"name": "acme", "workspaces": ["packages/*", "!packages/legacy"]}`,
+		"packages/ui/package.json":     `{"name": "@acme/ui", "peerDependencies": {"react": "^18"}}`,
+		"packages/web/package.json":    `{"name": "@acme/web", "dependencies": {"@acme/ui": "workspace:*"}}`,
+		"packages/legacy/package.json": `{"name": "@acme/legacy"}`,
+		"packages/notes/README.md":     "no manifest",
+	})
+
+	ws, err := Detect(root)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if ws == nil || ws.Manager != "npm" {
+		t.Fatalf("expected an npm workspace, got %+v", ws)
+	}
+	if got := packageNames(ws); len(got) != 3 || got[0] != "@acme/ui" || got[1] != "@acme/web" || got[2] != "acme" {
+		t.Errorf("unexpected packages: %v", got)
+	}
+
+	web := ws.Find("@acme/web")
+	if web == nil || !web.Dependencies["@acme/ui"] {
+		t.Errorf("expected @acme/web to declare @acme/ui, got %+v", web)
+	}
+	if ui := ws.Find("@acme/ui"); ui == nil || !ui.Dependencies["react"] {
+		t.Errorf("expected peer dependencies to count as declared, got %+v", ui)
+	}
+
+	if pkg := ws.PackageFor(filepath.Join(root, "packages", "web", "src", "app.js")); pkg != web {
+		t.Errorf("expected file to belong to @acme/web, got %+v", pkg)
+	}
+	if pkg := ws.PackageFor(filepath.Join(root, "scripts", "build.js")); pkg == nil || pkg.Name != "acme" {
+		t.Errorf("expected file outside members to belong to the root package, got %+v", pkg)
+	}
+}
+
+func TestDetect_YarnAndPnpm(t *testing.T) {
+	yarn := t.TempDir()
+	writeFiles(t, yarn, map[string]string{
+		"package.json":           `{"name": "root", "workspaces": {"packages": ["apps/*"]}}`,
+		"apps/site/package.json": `{"name": "site"}`,
+	})
+	ws, err := Detect(yarn)
+	if err != nil || ws == nil || ws.Find("site") == nil {
+		t.Errorf("expected yarn workspace with site, got %+v (err %v)", ws, err)
+	}
+
+	pnpm := t.TempDir()
+	writeFiles(t, pnpm, map[string]string{
+		"pnpm-workspace.yaml":    "packages:\n  - 'libs/**'\n",
+		"libs/core/package.json": `{"name": "core"}`,
+	})
+	ws, err = Detect(pnpm)
+	if err != nil || ws == nil || ws.Manager != "pnpm" || ws.Find("core") == nil {
+		t.Errorf("expected pnpm workspace with core, got %+v (err %v)", ws, err)
+	}
+	if !IsRoot(pnpm) || IsRoot(filepath.Join(pnpm, "libs", "core")) {
+		t.Errorf("expected only the pnpm root to be a workspace root")
+	}
+}
+
+func TestDetect_ComposerPathRepositories(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"composer.json": `{"name": "acme/app", "require": {"acme/billing": "*"},
+			"repositories": [{"type": "path", "url": "modules/*"}, {"type": "vcs", "url": "https://example.com/x.git"}]}`,
+		"modules/billing/composer.json": `{"name": "acme/billing", "require": {"php": ">=8.1"}}`,
+		"modules/search/composer.json":  `{"name": "acme/search"}`,
+	})
+
+	ws, err := Detect(root)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if ws == nil || ws.Manager != "composer" {
+		t.Fatalf("expected a composer workspace, got %+v", ws)
+	}
+	if got := packageNames(ws); len(got) != 3 {
+		t.Errorf("expected 2 path repositories plus the root, got %v", got)
+	}
+	if app := ws.Find("acme/app"); app == nil || app.Kind != "composer" || !app.Dependencies["acme/billing"] {
+		t.Errorf("unexpected root package: %+v", app)
+	}
+}
+
+func TestDetect_NotAMonorepo(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"package.json": `{"name": "single"}`})
+
+	ws, err := Detect(root)
+	if err != nil || ws != nil {
+		t.Errorf("expected no workspace, got %+v (err %v)", ws, err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"strings"
+	"unicode"
+)
+
+// plainLabels spells out the symbols that carry meaning; other emoji are dropped
+var plainLabels = strings.NewReplacer(
+	"❌ Error", "Error",
+	"❌", "Error:",
+	"⚠️", "Warning:",
+	"ℹ️", "Info:",
+	"💡 Tip", "Tip",
+	"💡", "Tip:",
+	"📝", "Note:",
+	"•", "-",
+	"→", "->",
+	"←", "<-",
+	"↳", "-",
+	"█", "#",
+	"░", ".",
+)
+
+// Plain rewrites console text for screen readers: meaningful symbols become words,
+// decorative emoji and separator rules are removed, and the leftover indentation is
+// collapsed so each line reads as a plain labeled line
+func Plain(text string) string {
+	lines := strings.Split(plainLabels.Replace(text), "\n")
+	for i, line := range lines {
+		if isRule(line) {
+			lines[i] = ""
+			continue
+		}
+
+		var b strings.Builder
+		dropped := false
+		for _, r := range line {
+			if isDecoration(r) {
+				dropped = true
+				continue
+			}
+			// Swallow the spacing that separated a dropped emoji from its text
+			if dropped && r == ' ' {
+				continue
+			}
+			dropped = false
+			b.WriteRune(r)
+		}
+		lines[i] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDecoration reports whether r is an emoji or emoji modifier
+func isDecoration(r rune) bool {
+	switch {
+	case r == '\uFE0F' || r == '\u200D': // Variation selector, zero-width joiner
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF: // Emoji and pictographs
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats, e.g. ✅
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Symbols and arrows, e.g. ⬆
+		return true
+	}
+	return unicode.Is(unicode.So, r) && r > 0x2000
+}
+
+// isRule reports whether a line is only a separator such as "=====" or "-----"
+func isRule(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < 10 {
+		return false
+	}
+	return strings.Trim(trimmed, "=") == "" || strings.Trim(trimmed, "-") == ""
+}
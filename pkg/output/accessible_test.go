@@ -0,0 +1,44 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"\n📊 DEPENDENCY ANALYSIS SUMMARY\n", "\nDEPENDENCY ANALYSIS SUMMARY\n"},
+		{"   • Total Nodes: 5", "   - Total Nodes: 5"},
+		{"❌ Error scanning files: boom", "Error scanning files: boom"},
+		{"❌ Unsupported language: cobol", "Error: Unsupported language: cobol"},
+		{"⚠️ Failed to load config file", "Warning: Failed to load config file"},
+		{"💡 Tip: Use -v", "Tip: Use -v"},
+		{"🏗️  Packages: 2 npm packages", "Packages: 2 npm packages"},
+		{"   • a.js:3 (esm) → b.js (commonjs)", "   - a.js:3 (esm) -> b.js (commonjs)"},
+		{strings.Repeat("=", 70), ""},
+		{"a --flag value", "a --flag value"},
+	}
+	for _, tt := range tests {
+		if got := Plain(tt.in); got != tt.want {
+			t.Errorf("Plain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConsoleFormatter_Accessible(t *testing.T) {
+	res := makeDummyResult()
+	cf := NewConsoleFormatter()
+	cf.SetAccessible(true)
+	out := captureOutput(func() { cf.PrintSummary(res, true) })
+
+	if !strings.Contains(out, "DEPENDENCY ANALYSIS SUMMARY") {
+		t.Errorf("expected summary header in output:\n%s", out)
+	}
+	for _, r := range out {
+		if isDecoration(r) || r == '•' || r == '=' {
+			t.Fatalf("unexpected decoration %q in accessible output:\n%s", r, out)
+		}
+	}
+}
@@ -6,31 +6,53 @@ package output
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/boone-studios/tukey/internal/models"
 )
 
 // ConsoleFormatter handles console output formatting
-type ConsoleFormatter struct{}
+type ConsoleFormatter struct {
+	accessible bool
+}
 
 // NewConsoleFormatter creates a new console formatter
 func NewConsoleFormatter() *ConsoleFormatter {
 	return &ConsoleFormatter{}
 }
 
+// SetAccessible prints plain labeled lines without emoji or separator rules
+func (cf *ConsoleFormatter) SetAccessible(enabled bool) {
+	cf.accessible = enabled
+}
+
+// printf writes formatted output, rewritten by Plain in accessible mode
+func (cf *ConsoleFormatter) printf(format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	if cf.accessible {
+		text = Plain(text)
+	}
+	fmt.Print(text)
+}
+
+// println writes a line, rewritten by Plain in accessible mode
+func (cf *ConsoleFormatter) println(args ...interface{}) {
+	cf.printf("%s", fmt.Sprintln(args...))
+}
+
 // PrintSummary displays a human-readable summary of the analysis results
 func (cf *ConsoleFormatter) PrintSummary(result *models.AnalysisResult, verbose bool) {
 	graph := result.Graph
 
-	fmt.Println("\n" + strings.Repeat("=", 70))
-	fmt.Println("DEPENDENCY ANALYSIS SUMMARY")
-	fmt.Println(strings.Repeat("=", 70))
+	cf.println("\n" + strings.Repeat("=", 70))
+	cf.println("DEPENDENCY ANALYSIS SUMMARY")
+	cf.println(strings.Repeat("=", 70))
 
-	fmt.Printf("📊 Graph Statistics:\n")
-	fmt.Printf("   • Total Nodes: %d\n", graph.TotalNodes)
-	fmt.Printf("   • Total Dependencies: %d\n", graph.TotalEdges)
-	fmt.Printf("   • Orphaned Elements: %d\n", len(graph.Orphans))
+	cf.printf("📊 Graph Statistics:\n")
+	cf.printf("   • Total Nodes: %d\n", graph.TotalNodes)
+	cf.printf("   • Total Dependencies: %d\n", graph.TotalEdges)
+	cf.printf("   • Orphaned Elements: %d\n", len(graph.Orphans))
 
 	// Determine how many items to show
 	maxHighlyDepended := 5
@@ -43,14 +65,14 @@ func (cf *ConsoleFormatter) PrintSummary(result *models.AnalysisResult, verbose
 		maxComplexNodes = len(graph.ComplexNodes)
 		maxOrphans = len(graph.Orphans)
 		maxDependentsToShow = -1 // Show all
-		fmt.Printf("\n🔍 VERBOSE MODE: Showing complete dependency lists\n")
+		cf.printf("\n🔍 VERBOSE MODE: Showing complete dependency lists\n")
 	}
 
-	fmt.Printf("\n🔥 Most Depended Upon Elements:\n")
+	cf.printf("\n🔥 Most Depended Upon Elements:\n")
 	for i, node := range graph.HighlyDepended {
 		if i >= maxHighlyDepended {
 			if !verbose {
-				fmt.Printf("   ... and %d more (use -v for full list)\n", len(graph.HighlyDepended)-maxHighlyDepended)
+				cf.printf("   ... and %d more (use -v for full list)\n", len(graph.HighlyDepended)-maxHighlyDepended)
 			}
 			break
 		}
@@ -60,30 +82,30 @@ func (cf *ConsoleFormatter) PrintSummary(result *models.AnalysisResult, verbose
 			relativePath = relativePath[1:] // Remove leading slash if still present
 		}
 
-		fmt.Printf("   %d. %s (%s) - %d dependents\n",
+		cf.printf("   %d. %s (%s) - %d dependents\n",
 			i+1, node.Name, relativePath, len(node.Dependents))
 
 		// Show dependents
 		dependentCount := 0
 		for _, dep := range node.Dependents {
 			if maxDependentsToShow > 0 && dependentCount >= maxDependentsToShow {
-				fmt.Printf("      ... and %d more dependents\n", len(node.Dependents)-maxDependentsToShow)
+				cf.printf("      ... and %d more dependents\n", len(node.Dependents)-maxDependentsToShow)
 				break
 			}
-			fmt.Printf("      ← %s (%s)\n", dep.TargetName, dep.Type)
+			cf.printf("      ← %s (%s)\n", dep.TargetName, dep.Type)
 			dependentCount++
 		}
 
 		if verbose && i < len(graph.HighlyDepended)-1 {
-			fmt.Println() // Add spacing between entries in verbose mode
+			cf.println() // Add spacing between entries in verbose mode
 		}
 	}
 
-	fmt.Printf("\n🧠 Most Complex Elements:\n")
+	cf.printf("\n🧠 Most Complex Elements:\n")
 	for i, node := range graph.ComplexNodes {
 		if i >= maxComplexNodes {
 			if !verbose {
-				fmt.Printf("   ... and %d more (use -v for full list)\n", len(graph.ComplexNodes)-maxComplexNodes)
+				cf.printf("   ... and %d more (use -v for full list)\n", len(graph.ComplexNodes)-maxComplexNodes)
 			}
 			break
 		}
@@ -93,46 +115,47 @@ func (cf *ConsoleFormatter) PrintSummary(result *models.AnalysisResult, verbose
 			relativePath = relativePath[1:]
 		}
 
-		fmt.Printf("   %d. %s (%s) - Score: %d\n",
+		cf.printf("   %d. %s (%s) - Score: %d\n",
 			i+1, node.Name, relativePath, node.Score)
-		fmt.Printf("      Dependencies: %d, Dependents: %d\n",
+		cf.printf("      Dependencies: %d, Dependents: %d\n",
 			len(node.Dependencies), len(node.Dependents))
+		cf.printNotes(node.Notes, "maxComplexity")
 
 		if verbose {
 			// Show what this node depends on
 			if len(node.Dependencies) > 0 {
-				fmt.Printf("      Depends on:\n")
+				cf.printf("      Depends on:\n")
 				for _, dep := range node.Dependencies {
-					fmt.Printf("        → %s (%s, %d times)\n", dep.TargetName, dep.Type, dep.Count)
+					cf.printf("        → %s (%s, %d times)\n", dep.TargetName, dep.Type, dep.Count)
 				}
 			}
 
 			// Show what depends on this node
 			if len(node.Dependents) > 0 {
-				fmt.Printf("      Depended upon by:\n")
+				cf.printf("      Depended upon by:\n")
 				depCount := 0
 				for _, dep := range node.Dependents {
 					if depCount >= 10 { // Limit even in verbose mode for readability
-						fmt.Printf("        ... and %d more\n", len(node.Dependents)-10)
+						cf.printf("        ... and %d more\n", len(node.Dependents)-10)
 						break
 					}
-					fmt.Printf("        ← %s (%s, %d times)\n", dep.TargetName, dep.Type, dep.Count)
+					cf.printf("        ← %s (%s, %d times)\n", dep.TargetName, dep.Type, dep.Count)
 					depCount++
 				}
 			}
 
 			if i < len(graph.ComplexNodes)-1 {
-				fmt.Println() // Add spacing between entries
+				cf.println() // Add spacing between entries
 			}
 		}
 	}
 
 	if len(graph.Orphans) > 0 {
-		fmt.Printf("\n👻 Orphaned Elements (%d total):\n", len(graph.Orphans))
+		cf.printf("\n👻 Orphaned Elements (%d total):\n", len(graph.Orphans))
 		for i, node := range graph.Orphans {
 			if i >= maxOrphans {
 				if !verbose {
-					fmt.Printf("   ... and %d more (use -v for full list)\n", len(graph.Orphans)-maxOrphans)
+					cf.printf("   ... and %d more (use -v for full list)\n", len(graph.Orphans)-maxOrphans)
 				}
 				break
 			}
@@ -143,14 +166,120 @@ func (cf *ConsoleFormatter) PrintSummary(result *models.AnalysisResult, verbose
 			}
 
 			if verbose {
-				fmt.Printf("   • %s (%s) in %s (line %d)\n", node.Name, node.Type, relativePath, node.Line)
+				cf.printf("   • %s (%s) in %s (line %d)\n", node.Name, node.Type, relativePath, node.Line)
 			} else {
-				fmt.Printf("   • %s (%s) in %s\n", node.Name, node.Type, relativePath)
+				cf.printf("   • %s (%s) in %s\n", node.Name, node.Type, relativePath)
+			}
+			cf.printNotes(node.Notes, "orphans")
+		}
+	}
+
+	if len(graph.AmbiguousNames) > 0 {
+		maxAmbiguous := 5
+		if verbose {
+			maxAmbiguous = len(graph.AmbiguousNames)
+		}
+
+		cf.printf("\n⚠️  Ambiguous Names (%d total):\n", len(graph.AmbiguousNames))
+		for i, ambiguous := range graph.AmbiguousNames {
+			if i >= maxAmbiguous {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(graph.AmbiguousNames)-maxAmbiguous)
+				break
+			}
+
+			cf.printf("   • %s - %d definitions, %d unresolved usages\n",
+				ambiguous.Name, len(ambiguous.Candidates), ambiguous.UnresolvedUsages)
+			for _, candidate := range ambiguous.Candidates {
+				cf.printf("      ↳ %s\n", candidate)
 			}
 		}
 	}
 
-	fmt.Println(strings.Repeat("=", 70))
+	if graph.ModuleInterop != nil {
+		cf.printModuleInterop(graph.ModuleInterop, verbose)
+	}
+
+	if graph.Includes != nil {
+		cf.printIncludes(graph.Includes, verbose)
+	}
+
+	if graph.Packages != nil {
+		cf.printPackages(graph.Packages, verbose)
+	}
+
+	if graph.Groups != nil {
+		cf.printGroups(graph.Groups, verbose)
+	}
+
+	if graph.Ownership != nil {
+		cf.printOwnership(graph.Ownership, verbose)
+	}
+
+	if graph.Coverage != nil {
+		cf.printCoverage(graph.Coverage, verbose)
+	}
+
+	if graph.Runtime != nil {
+		cf.printRuntime(graph.Runtime, verbose)
+	}
+
+	if graph.LongParameters != nil && len(graph.LongParameters.Functions) > 0 {
+		cf.printLongParameters(graph.LongParameters, verbose)
+	}
+
+	if graph.Bridges != nil {
+		cf.printBridges(graph.Bridges, verbose)
+	}
+
+	if result.Clones != nil {
+		cf.printClones(result.Clones, verbose)
+	}
+
+	if result.Literals != nil {
+		cf.printLiterals(result.Literals, verbose)
+	}
+
+	if result.Debt != nil {
+		cf.printDebt(result.Debt, verbose)
+	}
+
+	if result.Docs != nil {
+		cf.printDocs(result.Docs, verbose)
+	}
+
+	if result.OpenAPI != nil {
+		cf.printOpenAPI(result.OpenAPI, verbose)
+	}
+
+	if result.Tables != nil {
+		cf.printTables(result.Tables, verbose)
+	}
+
+	if result.Flags != nil {
+		cf.printFlags(result.Flags, verbose)
+	}
+
+	if result.Syntax != nil {
+		cf.printSyntax(result.Syntax, verbose)
+	}
+
+	if result.Platform != nil {
+		cf.printPlatform(result.Platform, verbose)
+	}
+
+	if len(result.Findings) > 0 {
+		cf.printFindings(result.Findings, verbose)
+	}
+
+	if result.Suppressions != nil {
+		cf.printSuppressions(result.Suppressions, verbose)
+	}
+
+	if result.Sample != nil {
+		cf.printSample(result.Sample)
+	}
+
+	cf.println(strings.Repeat("=", 70))
 
 	// Add a function usage report in verbose mode
 	if verbose {
@@ -158,15 +287,815 @@ func (cf *ConsoleFormatter) PrintSummary(result *models.AnalysisResult, verbose
 	}
 
 	if !verbose {
-		fmt.Printf("💡 Tip: Use -v or --verbose flag to see complete dependency lists and function usage report\n")
-		fmt.Println(strings.Repeat("=", 70))
+		cf.printf("💡 Tip: Use -v or --verbose flag to see complete dependency lists and function usage report\n")
+		cf.println(strings.Repeat("=", 70))
+	}
+}
+
+// PrintMetrics displays only aggregate counts and metrics, for --summary-only runs
+func (cf *ConsoleFormatter) PrintMetrics(result *models.AnalysisResult, metrics map[string]int) {
+	cf.println("\n" + strings.Repeat("=", 70))
+	cf.println("DEPENDENCY ANALYSIS SUMMARY (summary only)")
+	cf.println(strings.Repeat("=", 70))
+
+	cf.printf("📊 Files: %d, Elements: %d, Processing Time: %s\n",
+		result.TotalFiles, result.TotalElements, result.ProcessingTime)
+
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cf.printf("   • %s: %d\n", name, metrics[name])
+	}
+	if result.Sample != nil {
+		cf.printSample(result.Sample)
+	}
+
+	cf.println(strings.Repeat("=", 70))
+}
+
+// printModuleInterop lists ESM/CommonJS boundaries and files blocking an ESM migration
+func (cf *ConsoleFormatter) printModuleInterop(interop *models.ModuleInterop, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n📦 Module Systems: %d ESM, %d CommonJS, %d mixed\n",
+		interop.ESMFiles, interop.CommonJSFiles, interop.MixedFiles)
+
+	if len(interop.Boundaries) > 0 {
+		cf.printf("   Cross-system imports (%d total):\n", len(interop.Boundaries))
+		for i, boundary := range interop.Boundaries {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(interop.Boundaries)-maxItems)
+				break
+			}
+			cf.printf("   • %s:%d (%s) → %s (%s) via %s\n",
+				strings.TrimPrefix(boundary.From, "/"), boundary.Line, boundary.FromSystem,
+				strings.TrimPrefix(boundary.To, "/"), boundary.ToSystem, boundary.Kind)
+		}
+	}
+
+	if len(interop.MigrationBlockers) > 0 {
+		cf.printf("   ESM migration blockers (%d total):\n", len(interop.MigrationBlockers))
+		for i, blocker := range interop.MigrationBlockers {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(interop.MigrationBlockers)-maxItems)
+				break
+			}
+			cf.printf("   • %s (%s) - %d importers, uses %s\n",
+				strings.TrimPrefix(blocker.File, "/"), blocker.System, blocker.Importers,
+				strings.Join(blocker.Features, ", "))
+		}
+	}
+}
+
+// printIncludes summarizes the C/C++ include graph: the headers that reach the most files,
+// include cycles, headers nothing includes, and includes of headers that weren't found
+func (cf *ConsoleFormatter) printIncludes(includes *models.IncludeGraph, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🧩 Includes: %d files, %d includes between them, %d system includes\n",
+		includes.Files, len(includes.Edges), includes.System)
+
+	if len(includes.Headers) > 0 {
+		cf.printf("   Most included headers:\n")
+		for i, header := range includes.Headers {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(includes.Headers)-maxItems)
+				break
+			}
+			cf.printf("   • %s - included by %d, reaches %d files\n",
+				strings.TrimPrefix(header.File, "/"), header.Includers, header.Reach)
+		}
+	}
+
+	if len(includes.Cycles) > 0 {
+		cf.printf("   Include cycles (%d total):\n", len(includes.Cycles))
+		for i, cycle := range includes.Cycles {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(includes.Cycles)-maxItems)
+				break
+			}
+			files := make([]string, len(cycle))
+			for j, file := range cycle {
+				files[j] = strings.TrimPrefix(file, "/")
+			}
+			cf.printf("   • %s\n", strings.Join(files, " ↔ "))
+		}
+	}
+
+	if len(includes.Unused) > 0 {
+		cf.printf("   Headers nothing includes (%d total):\n", len(includes.Unused))
+		for i, file := range includes.Unused {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(includes.Unused)-maxItems)
+				break
+			}
+			cf.printf("   • %s\n", strings.TrimPrefix(file, "/"))
+		}
+	}
+
+	if len(includes.Unresolved) > 0 {
+		cf.printf("   Headers not found (%d total):\n", len(includes.Unresolved))
+		for i, edge := range includes.Unresolved {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(includes.Unresolved)-maxItems)
+				break
+			}
+			cf.printf("   • %s:%d includes \"%s\"\n", strings.TrimPrefix(edge.From, "/"), edge.Line, edge.Header)
+		}
+	}
+}
+
+// printPackages shows the cross-package dependency matrix of a monorepo and any
+// dependencies missing from a package's manifest
+func (cf *ConsoleFormatter) printPackages(report *models.PackageReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🏗️  Packages: %d %s packages\n", len(report.Packages), report.Manager)
+
+	if len(report.Dependencies) > 0 {
+		cf.printf("   Cross-package dependencies (%d total):\n", len(report.Dependencies))
+		for i, dep := range report.Dependencies {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(report.Dependencies)-maxItems)
+				break
+			}
+			cf.printf("   • %s → %s (%d edges)\n", dep.From, dep.To, dep.Edges)
+		}
+	}
+
+	if len(report.Violations) > 0 {
+		cf.printf("   ⚠️  Undeclared package dependencies (%d total):\n", len(report.Violations))
+		for i, dep := range report.Violations {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(report.Violations)-maxItems)
+				break
+			}
+			cf.printf("   • %s uses %s without declaring it (%d edges, e.g. %s)\n",
+				dep.From, dep.To, dep.Edges, dep.Example)
+		}
+	}
+}
+
+// printGroups shows the metrics of each config-defined virtual group and the
+// dependencies between them
+func (cf *ConsoleFormatter) printGroups(report *models.GroupReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🧩 Groups: %d virtual groups, %d ungrouped nodes\n", len(report.Groups), report.Ungrouped)
+	for _, group := range report.Groups {
+		cf.printf("   • %s - %d nodes in %d files, complexity %d, %d internal / %d outgoing / %d incoming usages, instability %.2f\n",
+			group.Name, group.Nodes, group.Files, group.Complexity, group.Internal, group.Outgoing, group.Incoming, group.Instability)
+	}
+
+	if len(report.Dependencies) > 0 {
+		cf.printf("   Cross-group dependencies (%d total):\n", len(report.Dependencies))
+		for i, dep := range report.Dependencies {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(report.Dependencies)-maxItems)
+				break
+			}
+			cf.printf("   • %s → %s (%d usages over %d edges, e.g. %s)\n", dep.From, dep.To, dep.Usages, dep.Edges, dep.Example)
+		}
+	}
+}
+
+// printOwnership shows each CODEOWNERS owner's code and the owners it depends on most
+func (cf *ConsoleFormatter) printOwnership(report *models.OwnershipReport, verbose bool) {
+	maxItems := 3
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n👥 Ownership: %d owners from %s, %d unowned nodes\n", len(report.Owners), report.Source, report.Unowned)
+	for _, owner := range report.Owners {
+		cf.printf("   • %s - %d nodes in %d files, %d internal / %d outgoing / %d incoming usages\n",
+			owner.Name, owner.Nodes, owner.Files, owner.Internal, owner.Outgoing, owner.Incoming)
+		for i, usage := range owner.DependsOn {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("      ... and %d more (use -v for full list)\n", len(owner.DependsOn)-maxItems)
+				break
+			}
+			cf.printf("      ↳ depends on %s (%d usages over %d edges)\n", usage.Owner, usage.Usages, usage.Edges)
+		}
+	}
+}
+
+// printCoverage shows how much of the graph the tests ran, the never-run code that much
+// depends on, and the code only used by other never-run code
+func (cf *ConsoleFormatter) printCoverage(report *models.CoverageReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🧪 Coverage: %.1f%% of %d lines in %d files, %d of %d nodes ran\n",
+		report.Percent, report.Lines, report.Files, report.CoveredNodes, report.Nodes)
+	if report.Unmatched > 0 {
+		cf.printf("   %d covered files match no analyzed file; check the paths the coverage was recorded with\n", report.Unmatched)
+	}
+	lists := []struct {
+		title string
+		nodes []*models.CoverageNode
+	}{
+		{"Depended on but never run", report.Uncovered},
+		{"Used only by code that never ran", report.DeadInPractice},
+	}
+	for _, list := range lists {
+		if len(list.nodes) == 0 {
+			continue
+		}
+		cf.printf("   %s:\n", list.title)
+		for i, node := range list.nodes {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("      ... and %d more (use -v for full list)\n", len(list.nodes)-maxItems)
+				break
+			}
+			cf.printf("      • %s (%s) - %d dependents, %s:%d\n", node.Name, node.Type, node.Dependents, strings.TrimPrefix(node.File, "/"), node.Line)
+		}
+	}
+}
+
+// printRuntime shows how many of the calls in the runtime traces the graph has edges for,
+// and the dynamic calls it missed
+func (cf *ConsoleFormatter) printRuntime(report *models.RuntimeReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🛰️  Runtime: %d of %d traced calls matched the graph, %d confirmed static edges\n",
+		report.Resolved, report.Calls, report.Confirmed)
+	if report.Calls > 0 && report.Resolved == 0 {
+		cf.printf("   No traced function matched a node; check that the traces come from this code\n")
+	}
+	if len(report.Missed) == 0 {
+		return
+	}
+	cf.printf("   Dynamic calls the static analysis missed:\n")
+	for i, edge := range report.Missed {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("      ... and %d more (use -v for full list)\n", len(report.Missed)-maxItems)
+			break
+		}
+		orphan := ""
+		if edge.Orphan {
+			orphan = ", reported as orphaned"
+		}
+		cf.printf("      • %s → %s - %d calls, %s:%d%s\n", edge.FromName, edge.ToName, edge.Calls, strings.TrimPrefix(edge.File, "/"), edge.Line, orphan)
+	}
+}
+
+// printLongParameters lists functions over the parameter limit, where they're called
+// from, and the parameter groups worth turning into a parameter object
+func (cf *ConsoleFormatter) printLongParameters(report *models.ParameterReport, verbose bool) {
+	maxItems, maxSites := 5, 3
+	if verbose {
+		maxItems, maxSites = -1, -1
+	}
+
+	cf.printf("\n📏 Long Parameter Lists: %d functions with more than %d parameters\n", len(report.Functions), report.Max)
+	for i, fn := range report.Functions {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Functions)-maxItems)
+			break
+		}
+		cf.printf("   • %s (%s:%d) - %d parameters, %d calls from %d sites\n",
+			fn.Name, strings.TrimPrefix(fn.File, "/"), fn.Line, len(fn.Parameters), fn.Calls, len(fn.CallSites))
+		for j, site := range fn.CallSites {
+			if maxSites > 0 && j >= maxSites {
+				cf.printf("      ... and %d more call sites\n", len(fn.CallSites)-maxSites)
+				break
+			}
+			cf.printf("      ← %s (%s) x%d\n", site.Name, strings.TrimPrefix(site.File, "/"), site.Count)
+		}
+	}
+
+	if len(report.Clumps) > 0 {
+		cf.printf("   Parameter object candidates:\n")
+		for i, clump := range report.Clumps {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("   ... and %d more (use -v for full list)\n", len(report.Clumps)-maxItems)
+				break
+			}
+			cf.printf("   • (%s) shared by %s\n", strings.Join(clump.Parameters, ", "), strings.Join(clump.Functions, ", "))
+		}
+	}
+}
+
+// printClones lists the largest duplicated blocks of code
+func (cf *ConsoleFormatter) printClones(report *models.CloneReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🧬 Duplicated Code: %d clones of at least %d tokens, %d duplicated lines\n",
+		len(report.Clones), report.MinTokens, report.DuplicatedLines)
+	for i, clone := range report.Clones {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Clones)-maxItems)
+			break
+		}
+		a, b := clone.Blocks[0], clone.Blocks[1]
+		cf.printf("   • %s:%d-%d ↔ %s:%d-%d - %d tokens, %.0f%% identical\n",
+			strings.TrimPrefix(a.File, "/"), a.StartLine, a.EndLine,
+			strings.TrimPrefix(b.File, "/"), b.StartLine, b.EndLine,
+			clone.Tokens, clone.Similarity*100)
+	}
+}
+
+// printLiterals lists the most repeated strings and numbers with where they're written
+func (cf *ConsoleFormatter) printLiterals(report *models.LiteralReport, verbose bool) {
+	maxItems, maxLocations := 5, 3
+	if verbose {
+		maxItems, maxLocations = -1, -1
+	}
+
+	cf.printf("\n🔢 Repeated Literals: %d strings and %d numbers written at least %d times\n",
+		len(report.Strings), len(report.Numbers), report.MinCount)
+	list := func(title string, literals []*models.Literal, quote bool) {
+		if len(literals) == 0 {
+			return
+		}
+		cf.printf("   %s:\n", title)
+		for i, literal := range literals {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("      ... and %d more (use -v for full list)\n", len(literals)-maxItems)
+				break
+			}
+			value := literal.Value
+			if quote {
+				value = strconv.Quote(value)
+			}
+			var locations []string
+			for j, loc := range literal.Locations {
+				if maxLocations > 0 && j >= maxLocations {
+					locations = append(locations, "...")
+					break
+				}
+				locations = append(locations, fmt.Sprintf("%s:%d", strings.TrimPrefix(loc.File, "/"), loc.Line))
+			}
+			cf.printf("      • %s x%d in %d files: %s\n", value, literal.Count, literal.Files, strings.Join(locations, ", "))
+		}
+	}
+	list("Strings", report.Strings, true)
+	list("Numbers", report.Numbers, false)
+}
+
+// printDebt summarizes the TODO, FIXME, and HACK comments by tag, age, and directory
+func (cf *ConsoleFormatter) printDebt(report *models.DebtReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	tags := make([]string, 0, len(report.Tags))
+	for tag := range report.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	counts := make([]string, len(tags))
+	for i, tag := range tags {
+		counts[i] = fmt.Sprintf("%s %d", tag, report.Tags[tag])
+	}
+	cf.printf("\n🧾 Technical Debt: %d markers (%s)\n", report.Total, strings.Join(counts, ", "))
+	if report.Tickets > 0 {
+		cf.printf("   %d reference a ticket, %d don't\n", report.Tickets, report.Total-report.Tickets)
+	}
+	if report.Ages != nil {
+		ages := make([]string, 0, len(report.Ages))
+		for _, age := range report.Ages {
+			ages = append(ages, fmt.Sprintf("%s %d", age.Label, age.Count))
+		}
+		if report.Undated > 0 {
+			ages = append(ages, fmt.Sprintf("undated %d", report.Undated))
+		}
+		cf.printf("   By age: %s\n", strings.Join(ages, ", "))
+	}
+
+	for i, dir := range report.Directories {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Directories)-maxItems)
+			break
+		}
+		if dir.OldestDays > 0 {
+			cf.printf("   • %s: %d (oldest %d days)\n", dir.Path, dir.Count, dir.OldestDays)
+		} else {
+			cf.printf("   • %s: %d\n", dir.Path, dir.Count)
+		}
+		if verbose {
+			for _, item := range dir.Items {
+				cf.printf("      %s:%d %s %s\n", item.File, item.Line, item.Tag, item.Text)
+			}
+		}
+	}
+}
+
+// printDocs shows documentation coverage, the least documented namespaces, and with -v
+// the undocumented public API
+func (cf *ConsoleFormatter) printDocs(report *models.DocReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n📚 Documentation: %.1f%% of %d public declarations documented, %.1f%% of lines are comments\n",
+		report.Coverage, report.Public, report.CommentDensity)
+	var namespaces []*models.DocNamespace
+	for _, ns := range report.Namespaces {
+		if ns.Public > 0 {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	for i, ns := range namespaces {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(namespaces)-maxItems)
+			break
+		}
+		cf.printf("   • %s: %.1f%% (%d/%d), %.1f%% comments\n", ns.Name, ns.Coverage, ns.Documented, ns.Public, ns.CommentDensity)
+	}
+
+	if len(report.Undocumented) == 0 {
+		return
+	}
+	if !verbose {
+		cf.printf("   %d undocumented (use -v for full list)\n", len(report.Undocumented))
+		return
+	}
+	cf.printf("   Undocumented:\n")
+	for _, sym := range report.Undocumented {
+		cf.printf("      %s:%d %s %s\n", sym.File, sym.Line, sym.Kind, sym.Name)
+	}
+}
+
+// printBridges lists the references that cross languages and, with -v, those that
+// matched no route or script
+func (cf *ConsoleFormatter) printBridges(report *models.BridgeReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🌉 Cross-language Bridges: %d references, %d routes, %d unmatched\n",
+		len(report.Bridges), report.Routes, len(report.Unmatched))
+	for i, bridge := range report.Bridges {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Bridges)-maxItems)
+			break
+		}
+		line := fmt.Sprintf("   • %s:%d %s %s → %s", bridge.File, bridge.Line, bridge.Kind, bridge.Target, bridge.To)
+		if bridge.Handler != "" {
+			line += " (" + bridge.Handler + ")"
+		}
+		cf.println(line)
+	}
+
+	if len(report.Unmatched) == 0 {
+		return
+	}
+	if !verbose {
+		cf.printf("   %d unmatched (use -v for full list)\n", len(report.Unmatched))
+		return
+	}
+	cf.printf("   Unmatched:\n")
+	for _, bridge := range report.Unmatched {
+		cf.printf("      %s:%d %s %s\n", bridge.File, bridge.Line, bridge.Kind, bridge.Target)
+	}
+}
+
+// printOpenAPI lists the implemented operations with their footprint, then the
+// operations without a route and, with -v, the routes missing from the spec
+func (cf *ConsoleFormatter) printOpenAPI(report *models.OpenAPIReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n📜 OpenAPI: %d of %d operations implemented, %d routes undocumented (%s)\n",
+		len(report.Endpoints), report.Operations, len(report.Undocumented), report.Spec)
+	for i, endpoint := range report.Endpoints {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Endpoints)-maxItems)
+			break
+		}
+		line := fmt.Sprintf("   • %s %s → %s", endpoint.Method, endpoint.Path, endpoint.Route)
+		if len(endpoint.Handlers) > 0 {
+			line += " (" + strings.Join(endpoint.Handlers, ", ") + ")"
+		}
+		cf.printf("%s, %d nodes in %d files\n", line, endpoint.Footprint, endpoint.Files)
+	}
+
+	if len(report.Unimplemented) > 0 {
+		cf.printf("   Unimplemented:\n")
+		for i, endpoint := range report.Unimplemented {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("      ... and %d more (use -v for full list)\n", len(report.Unimplemented)-maxItems)
+				break
+			}
+			cf.printf("      %s %s %s\n", endpoint.Method, endpoint.Path, endpoint.OperationID)
+		}
+	}
+
+	if len(report.Undocumented) == 0 {
+		return
+	}
+	if !verbose {
+		cf.printf("   %d undocumented (use -v for full list)\n", len(report.Undocumented))
+		return
+	}
+	cf.printf("   Undocumented:\n")
+	for _, endpoint := range report.Undocumented {
+		cf.printf("      %s:%d %s %s\n", endpoint.File, endpoint.Line, endpoint.Method, endpoint.Path)
+	}
+}
+
+// printTables lists the most referenced database tables with their models and
+// migrations and, with -v, every class naming each table
+func (cf *ConsoleFormatter) printTables(report *models.TableReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	tables := append([]*models.DatabaseTable(nil), report.Tables...)
+	sort.SliceStable(tables, func(i, j int) bool { return len(tables[i].Classes) > len(tables[j].Classes) })
+
+	cf.printf("\n🗄️ Database Tables: %d tables named by %d classes\n", len(tables), len(report.Classes))
+	for i, table := range tables {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(tables)-maxItems)
+			break
+		}
+		line := fmt.Sprintf("   • %s: %d references from %d classes", table.Name, len(table.References), len(table.Classes))
+		if len(table.Models) > 0 {
+			line += ", model " + strings.Join(table.Models, ", ")
+		}
+		if len(table.Migrations) > 0 {
+			line += fmt.Sprintf(", %d migrations", len(table.Migrations))
+		}
+		cf.println(line)
+		if verbose {
+			cf.printf("      %s\n", strings.Join(table.Classes, ", "))
+		}
+	}
+}
+
+// printFlags lists each feature flag with its checks and gated code and, with -v,
+// where every check is
+func (cf *ConsoleFormatter) printFlags(report *models.FlagReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🚩 Feature Flags: %d flags checked through %s\n", len(report.Flags), strings.Join(report.Accessors, ", "))
+	for i, flag := range report.Flags {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Flags)-maxItems)
+			break
+		}
+		cf.printf("   • %s: %d checks, %d nodes gated (%d reached)\n", flag.Name, len(flag.Checks), len(flag.Gated), flag.Footprint)
+		if !verbose {
+			continue
+		}
+		for _, check := range flag.Checks {
+			line := fmt.Sprintf("      %s:%d", check.File, check.Line)
+			if len(check.Gated) > 0 {
+				line += " → " + strings.Join(check.Gated, ", ")
+			}
+			cf.println(line)
+		}
+	}
+}
+
+// printSyntax lists the constructs newer than the target version with how often each is
+// used and, with -v, where
+func (cf *ConsoleFormatter) printSyntax(report *models.SyntaxReport, verbose bool) {
+	declared := ""
+	if report.Source != "" && report.Source != "configured" {
+		declared = fmt.Sprintf(" (declared in %s)", report.Source)
+	}
+	if len(report.Features) == 0 {
+		cf.printf("\n🧬 Newer Syntax: nothing newer than %s %s%s\n", report.Language, report.Target, declared)
+		return
+	}
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🧬 Newer Syntax: %d constructs newer than %s %s%s; the code requires %s\n",
+		len(report.Features), report.Language, report.Target, declared, report.Required)
+	for i, feature := range report.Features {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Features)-maxItems)
+			break
+		}
+		first := feature.Uses[0]
+		cf.printf("   • %s (%s): %d uses, first at %s:%d\n", feature.Name, feature.Version, len(feature.Uses), first.File, first.Line)
+		if !verbose {
+			continue
+		}
+		for _, use := range feature.Uses[1:] {
+			cf.printf("      %s:%d\n", use.File, use.Line)
+		}
+	}
+}
+
+// printPlatform lists the PHP extensions used without a requirement in composer.json
+// with the functions and classes called and, with -v, where
+func (cf *ConsoleFormatter) printPlatform(report *models.PlatformReport, verbose bool) {
+	if len(report.Undeclared) == 0 {
+		cf.printf("\n🧩 PHP Extensions: all %d used are required in composer.json\n", len(report.Used))
+		return
+	}
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🧩 PHP Extensions: %d of %d used aren't required in composer.json\n", len(report.Undeclared), len(report.Used))
+	for i, ext := range report.Undeclared {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(report.Undeclared)-maxItems)
+			break
+		}
+		first := ext.Uses[0]
+		cf.printf("   • ext-%s: %s (%d uses, first at %s:%d)\n", ext.Name, strings.Join(ext.Symbols, ", "), len(ext.Uses), first.File, first.Line)
+		if !verbose {
+			continue
+		}
+		for _, use := range ext.Uses[1:] {
+			cf.printf("      %s:%d %s\n", use.File, use.Line, use.Symbol)
+		}
+	}
+}
+
+// printFindings lists what the analyzer passes found, such as dependency cycles
+func (cf *ConsoleFormatter) printFindings(findings []*models.PassFinding, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🔎 Analyzer Findings (%d total):\n", len(findings))
+	for i, finding := range findings {
+		if maxItems > 0 && i >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", len(findings)-maxItems)
+			break
+		}
+		if finding.File != "" {
+			cf.printf("   • [%s] %s (%s:%d)\n", finding.Pass, finding.Message, finding.File, finding.Line)
+		} else {
+			cf.printf("   • [%s] %s\n", finding.Pass, finding.Message)
+		}
+		cf.printNotes(finding.Notes, finding.Pass)
+	}
+}
+
+// printNotes shows the notes that apply to a finding under it: those about the finding,
+// and those about the node itself
+func (cf *ConsoleFormatter) printNotes(notes []models.Note, finding string) {
+	for _, note := range notes {
+		if note.Finding != "" && note.Finding != finding {
+			continue
+		}
+		if note.Author != "" {
+			cf.printf("      📝 %s (%s)\n", note.Text, note.Author)
+		} else {
+			cf.printf("      📝 %s\n", note.Text)
+		}
+	}
+}
+
+// printSuppressions counts the suppressed nodes and lists the suppressions that no
+// longer match anything, or with -v every suppression
+func (cf *ConsoleFormatter) printSuppressions(report *models.SuppressionReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🔇 Suppressions: %d, covering %d nodes (%d unused)\n", len(report.Suppressions), report.Suppressed, report.Unused)
+	shown := 0
+	for _, suppression := range report.Suppressions {
+		if !verbose && len(suppression.Nodes) > 0 {
+			continue
+		}
+		if maxItems > 0 && shown >= maxItems {
+			cf.printf("   ... and %d more (use -v for full list)\n", report.Unused-maxItems)
+			break
+		}
+		shown++
+
+		findings := "all findings"
+		if len(suppression.Findings) > 0 {
+			findings = strings.Join(suppression.Findings, ", ")
+		}
+		line := fmt.Sprintf("   • %s:%d %s", suppression.File, suppression.Line, findings)
+		if suppression.Pattern != "" {
+			line += " on " + suppression.Pattern
+		}
+		if len(suppression.Nodes) == 0 {
+			line += " (unused)"
+		} else {
+			line += fmt.Sprintf(" (%d nodes)", len(suppression.Nodes))
+		}
+		if suppression.Reason != "" {
+			line += " -- " + suppression.Reason
+		}
+		cf.println(line)
+	}
+}
+
+// PrintScanReport shows what the scanner matched and skipped, for --scan-report
+func (cf *ConsoleFormatter) PrintScanReport(report *models.ScanReport, verbose bool) {
+	maxItems := 5
+	if verbose {
+		maxItems = -1
+	}
+
+	cf.printf("\n🗂️ Scan: %d files matched (%s)\n", report.Matched, countsByKey(report.Extensions))
+	if len(report.Unmatched) > 0 {
+		cf.printf("   • Not read by any parser: %s\n", countsByKey(report.Unmatched))
+	}
+	if report.Gitignored > 0 {
+		cf.printf("   • Ignored by .gitignore: %d files\n", report.Gitignored)
+	}
+	if len(report.SkippedDirs) > 0 {
+		cf.printf("   • Skipped directories: %d\n", len(report.SkippedDirs))
+		for i, dir := range report.SkippedDirs {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("      ... and %d more (use -v for full list)\n", len(report.SkippedDirs)-maxItems)
+				break
+			}
+			cf.printf("      %s (%s)\n", dir.Path, dir.Reason)
+		}
+	}
+	if len(report.Oversized) > 0 {
+		cf.printf("   • Over the %.2f MB size limit: %d files\n", float64(report.MaxFileSize)/(1024*1024), len(report.Oversized))
+		for i, file := range report.Oversized {
+			if maxItems > 0 && i >= maxItems {
+				cf.printf("      ... and %d more (use -v for full list)\n", len(report.Oversized)-maxItems)
+				break
+			}
+			cf.printf("      %s (%.2f MB)\n", file.Path, float64(file.Size)/(1024*1024))
+		}
+	}
+}
+
+// countsByKey formats counts as "key 3, other 1", most common first; an empty key is
+// shown as "no extension"
+func countsByKey(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		name := key
+		if name == "" {
+			name = "no extension"
+		}
+		parts[i] = fmt.Sprintf("%s %d", name, counts[key])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printSample shows the whole-tree estimates of a sampled run
+func (cf *ConsoleFormatter) printSample(report *models.SampleReport) {
+	cf.printf("\n🎲 Sample: %d of %d files (%g%%); other counts above cover the sample only\n", report.Sampled, report.Files, report.Percent)
+	for _, estimate := range report.Estimates {
+		cf.printf("   • %s: ~%.0f (95%% CI %.0f to %.0f; %d in the sample)\n",
+			estimate.Metric, estimate.Estimate, estimate.Low, estimate.High, estimate.Sampled)
 	}
 }
 
 // PrintFunctionUsageReport shows detailed function usage across the codebase
 func (cf *ConsoleFormatter) PrintFunctionUsageReport(result *models.AnalysisResult) {
-	fmt.Printf("\n📋 FUNCTION USAGE REPORT\n")
-	fmt.Println(strings.Repeat("=", 70))
+	cf.printf("\n📋 FUNCTION USAGE REPORT\n")
+	cf.println(strings.Repeat("=", 70))
 
 	// Collect function definitions from the dependency graph
 	functionDefinitions := make(map[string]*models.DependencyNode)
@@ -201,9 +1130,9 @@ func (cf *ConsoleFormatter) PrintFunctionUsageReport(result *models.AnalysisResu
 	}
 
 	if len(functionCalls) == 0 {
-		fmt.Printf("   No custom function calls detected.\n")
-		fmt.Printf("   (Built-in PHP and common Laravel functions are filtered out)\n")
-		fmt.Println(strings.Repeat("=", 70))
+		cf.printf("   No custom function calls detected.\n")
+		cf.printf("   (Built-in PHP and common Laravel functions are filtered out)\n")
+		cf.println(strings.Repeat("=", 70))
 		return
 	}
 
@@ -237,15 +1166,15 @@ func (cf *ConsoleFormatter) PrintFunctionUsageReport(result *models.AnalysisResu
 				relativePath = relativePath[1:]
 			}
 
-			fmt.Printf("\n📁 %s\n", relativePath)
-			fmt.Printf("  📋 function %s() (line %d) - %d calls\n",
+			cf.printf("\n📁 %s\n", relativePath)
+			cf.printf("  📋 function %s() (line %d) - %d calls\n",
 				summary.Name, summary.Definition.Line, summary.TotalCalls)
 		} else {
-			fmt.Printf("\n🔧 function %s() - %d calls (external/helper)\n",
+			cf.printf("\n🔧 function %s() - %d calls (external/helper)\n",
 				summary.Name, summary.TotalCalls)
 		}
 
-		fmt.Printf("  🔗 Called from %d locations:\n", len(summary.Calls))
+		cf.printf("  🔗 Called from %d locations:\n", len(summary.Calls))
 
 		// Group calls by file for nicer output
 		callsByFile := make(map[string][]functionCallSite)
@@ -269,9 +1198,9 @@ func (cf *ConsoleFormatter) PrintFunctionUsageReport(result *models.AnalysisResu
 			}
 
 			if relativePath == "" {
-				fmt.Printf("    📂 Unknown context:\n")
+				cf.printf("    📂 Unknown context:\n")
 			} else {
-				fmt.Printf("    📂 %s:\n", relativePath)
+				cf.printf("    📂 %s:\n", relativePath)
 			}
 
 			// Sort calls by line number within each file
@@ -285,10 +1214,10 @@ func (cf *ConsoleFormatter) PrintFunctionUsageReport(result *models.AnalysisResu
 					contextStr = fmt.Sprintf(" in %s()", call.Context)
 				}
 
-				fmt.Printf("      → line %d%s\n", call.Line, contextStr)
+				cf.printf("      → line %d%s\n", call.Line, contextStr)
 			}
 		}
 	}
 
-	fmt.Println(strings.Repeat("=", 70))
+	cf.println(strings.Repeat("=", 70))
 }
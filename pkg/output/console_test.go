@@ -72,3 +72,526 @@ func TestConsoleFormatter_PrintSummary_Verbose(t *testing.T) {
 		t.Errorf("expected function usage report in verbose output:\n%s", out)
 	}
 }
+
+func TestConsoleFormatter_PrintSummary_ModuleInterop(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.ModuleInterop = &models.ModuleInterop{
+		ESMFiles:      1,
+		CommonJSFiles: 1,
+		Boundaries: []*models.ModuleBoundary{
+			{From: "src/app.mjs", To: "lib/legacy.js", FromSystem: "esm", ToSystem: "commonjs", Kind: "import", Line: 3},
+		},
+		MigrationBlockers: []*models.MigrationBlocker{
+			{File: "lib/legacy.js", System: "commonjs", Features: []string{"module.exports"}, Importers: 1},
+		},
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Module Systems: 1 ESM, 1 CommonJS, 0 mixed",
+		"src/app.mjs:3 (esm) → lib/legacy.js (commonjs) via import",
+		"lib/legacy.js (commonjs) - 1 importers, uses module.exports",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Packages(t *testing.T) {
+	res := makeDummyResult()
+	undeclared := &models.PackageDependency{From: "@acme/web", To: "@acme/db", Edges: 2, Example: "render -> query"}
+	res.Graph.Packages = &models.PackageReport{
+		Manager: "pnpm",
+		Packages: []*models.PackageSummary{
+			{Name: "@acme/db", Dir: "packages/db", Kind: "npm", Nodes: 3},
+			{Name: "@acme/web", Dir: "packages/web", Kind: "npm", Nodes: 4},
+		},
+		Dependencies: []*models.PackageDependency{undeclared},
+		Violations:   []*models.PackageDependency{undeclared},
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Packages: 2 pnpm packages",
+		"@acme/web → @acme/db (2 edges)",
+		"@acme/web uses @acme/db without declaring it (2 edges, e.g. render -> query)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Groups(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.Groups = &models.GroupReport{
+		Groups: []*models.GroupSummary{
+			{Name: "Billing", Nodes: 3, Files: 2, Complexity: 21, Internal: 2, Incoming: 1},
+			{Name: "Web", Nodes: 1, Files: 1, Complexity: 4, Outgoing: 1, Instability: 1},
+		},
+		Dependencies: []*models.GroupDependency{{From: "Web", To: "Billing", Edges: 1, Usages: 1, Example: "show -> Invoice"}},
+		Ungrouped:    5,
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Groups: 2 virtual groups, 5 ungrouped nodes",
+		"Billing - 3 nodes in 2 files, complexity 21, 2 internal / 0 outgoing / 1 incoming usages, instability 0.00",
+		"Web → Billing (1 usages over 1 edges, e.g. show -> Invoice)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Ownership(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.Ownership = &models.OwnershipReport{
+		Source: ".github/CODEOWNERS",
+		Owners: []*models.OwnerSummary{{
+			Name: "@acme/billing", Nodes: 2, Files: 2, Internal: 1, Outgoing: 4,
+			DependsOn: []*models.OwnerUsage{
+				{Owner: "@acme/platform", Usages: 3, Edges: 2},
+				{Owner: "@acme/sre", Usages: 1, Edges: 1},
+				{Owner: "@acme/web", Usages: 1, Edges: 1},
+				{Owner: "@acme/data", Usages: 1, Edges: 1},
+			},
+		}},
+		Unowned: 1,
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Ownership: 1 owners from .github/CODEOWNERS, 1 unowned nodes",
+		"@acme/billing - 2 nodes in 2 files, 1 internal / 4 outgoing / 0 incoming usages",
+		"depends on @acme/platform (3 usages over 2 edges)",
+		"... and 1 more",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_LongParameters(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.LongParameters = &models.ParameterReport{
+		Max: 5,
+		Functions: []*models.LongParameterList{{
+			Name: "create_invoice", File: "/app/billing.php", Line: 4,
+			Parameters: []string{"customer", "amount", "currency", "taxRate", "dueDate", "notes"},
+			Calls:      3,
+			CallSites: []*models.CallSite{
+				{Name: "checkout", File: "/app/checkout.php", Count: 2},
+				{Name: "Api::store", File: "/app/Api.php", Count: 1},
+			},
+		}},
+		Clumps: []*models.ParameterClump{{Parameters: []string{"amount", "currency", "customer"}, Functions: []string{"create_invoice", "quote"}}},
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Long Parameter Lists: 1 functions with more than 5 parameters",
+		"create_invoice (app/billing.php:4) - 6 parameters, 3 calls from 2 sites",
+		"← checkout (app/checkout.php) x2",
+		"(amount, currency, customer) shared by create_invoice, quote",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Clones(t *testing.T) {
+	res := makeDummyResult()
+	res.Clones = &models.CloneReport{
+		MinTokens:       50,
+		DuplicatedLines: 24,
+		Clones: []*models.Clone{{
+			Tokens: 96, Similarity: 0.875,
+			Blocks: []*models.CloneBlock{
+				{File: "/app/Invoice.php", StartLine: 10, EndLine: 21},
+				{File: "/app/Quote.php", StartLine: 4, EndLine: 15},
+			},
+		}},
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Duplicated Code: 1 clones of at least 50 tokens, 24 duplicated lines",
+		"app/Invoice.php:10-21 ↔ app/Quote.php:4-15 - 96 tokens, 88% identical",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Literals(t *testing.T) {
+	res := makeDummyResult()
+	res.Literals = &models.LiteralReport{
+		MinCount: 3,
+		Strings: []*models.Literal{{Value: "paid", Count: 4, Files: 2, Locations: []*models.LiteralLocation{
+			{File: "/app/Order.php", Line: 5}, {File: "/app/Order.php", Line: 9},
+			{File: "/app/Invoice.php", Line: 3}, {File: "/app/Invoice.php", Line: 8},
+		}}},
+		Numbers: []*models.Literal{},
+	}
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+
+	for _, want := range []string{
+		"Repeated Literals: 1 strings and 0 numbers written at least 3 times",
+		`"paid" x4 in 2 files: app/Order.php:5, app/Order.php:9, app/Invoice.php:3, ...`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Numbers:") {
+		t.Errorf("expected no numbers heading without repeated numbers:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Debt(t *testing.T) {
+	res := makeDummyResult()
+	res.Debt = &models.DebtReport{
+		Total:   3,
+		Tags:    map[string]int{"TODO": 2, "FIXME": 1},
+		Tickets: 1,
+		Ages:    []*models.DebtAge{{Label: "under 30 days", Count: 1}, {Label: "over a year", Count: 1}},
+		Undated: 1,
+		Directories: []*models.DebtDirectory{
+			{Path: "app", Count: 2, OldestDays: 400, Items: []*models.DebtItem{
+				{Tag: "TODO", Text: "split", File: "app/a.php", Line: 3},
+				{Tag: "FIXME", Text: "rounding", File: "app/b.php", Line: 9},
+			}},
+			{Path: ".", Count: 1, Items: []*models.DebtItem{{Tag: "TODO", Text: "remove", File: "index.php", Line: 1}}},
+		},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{
+		"Technical Debt: 3 markers (FIXME 1, TODO 2)",
+		"1 reference a ticket, 2 don't",
+		"By age: under 30 days 1, over a year 1, undated 1",
+		"• app: 2 (oldest 400 days)",
+		"• .: 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "app/b.php:9") {
+		t.Error("expected markers to be listed only with -v")
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "app/b.php:9 FIXME rounding") {
+		t.Errorf("expected markers with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Docs(t *testing.T) {
+	res := makeDummyResult()
+	res.Docs = &models.DocReport{
+		DocStats: models.DocStats{Public: 4, Documented: 3, Coverage: 75, Lines: 100, CommentLines: 20, CommentDensity: 20},
+		Namespaces: []*models.DocNamespace{
+			{Name: `App\Billing`, DocStats: models.DocStats{Public: 3, Documented: 2, Coverage: 66.7, CommentDensity: 12.5}},
+			{Name: "(global)", DocStats: models.DocStats{Coverage: 100}},
+			{Name: `App\Http`, DocStats: models.DocStats{Public: 1, Documented: 1, Coverage: 100, CommentDensity: 30}},
+		},
+		Undocumented: []*models.APISymbol{{Name: `App\Billing\Invoice::send`, Kind: "method", File: "src/Invoice.php", Line: 20}},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{
+		"Documentation: 75.0% of 4 public declarations documented, 20.0% of lines are comments",
+		`• App\Billing: 66.7% (2/3), 12.5% comments`,
+		`• App\Http: 100.0% (1/1)`,
+		"1 undocumented (use -v for full list)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "(global)") {
+		t.Errorf("expected namespaces without public API to be left out:\n%s", out)
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, `src/Invoice.php:20 method App\Billing\Invoice::send`) {
+		t.Errorf("expected the undocumented list with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Bridges(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.Bridges = &models.BridgeReport{
+		Routes: 2,
+		Bridges: []*models.Bridge{
+			{Kind: "request", Target: "GET /api/users/${id}", File: "resources/js/users.js", Line: 4, To: "route:GET /api/users/{id}", Handler: "method:show:12"},
+		},
+		Unmatched: []*models.Bridge{{Kind: "asset", Target: "js/gone.js", File: "resources/views/app.blade.php", Line: 9}},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{
+		"Cross-language Bridges: 1 references, 2 routes, 1 unmatched",
+		"• resources/js/users.js:4 request GET /api/users/${id} → route:GET /api/users/{id} (method:show:12)",
+		"1 unmatched (use -v for full list)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "resources/views/app.blade.php:9 asset js/gone.js") {
+		t.Errorf("expected the unmatched list with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_OpenAPI(t *testing.T) {
+	res := makeDummyResult()
+	res.OpenAPI = &models.OpenAPIReport{
+		Spec:       "openapi.yaml",
+		Operations: 2,
+		Routes:     2,
+		Endpoints: []*models.Endpoint{
+			{Method: "GET", Path: "/users/{userId}", Route: "route:GET /api/users/{id}", Handlers: []string{"method:show:12"}, Footprint: 4, Files: 2},
+		},
+		Unimplemented: []*models.Endpoint{{Method: "DELETE", Path: "/users/{userId}", OperationID: "deleteUser"}},
+		Undocumented:  []*models.Endpoint{{Method: "POST", Path: "/api/users", File: "routes/api.php", Line: 5}},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{
+		"OpenAPI: 1 of 2 operations implemented, 1 routes undocumented (openapi.yaml)",
+		"• GET /users/{userId} → route:GET /api/users/{id} (method:show:12), 4 nodes in 2 files",
+		"DELETE /users/{userId} deleteUser",
+		"1 undocumented (use -v for full list)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "routes/api.php:5 POST /api/users") {
+		t.Errorf("expected the undocumented list with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Tables(t *testing.T) {
+	res := makeDummyResult()
+	res.Tables = &models.TableReport{
+		Tables: []*models.DatabaseTable{
+			{Name: "jobs", Classes: []string{"Worker"}, References: []*models.TableUse{{Kind: "sql", Owner: "Worker"}}},
+			{
+				Name:       "users",
+				Models:     []string{`App\User`},
+				Migrations: []string{"database/migrations/create_users.php"},
+				Classes:    []string{`App\User`, "Report"},
+				References: []*models.TableUse{{Kind: "model"}, {Kind: "sql"}, {Kind: "migration"}},
+			},
+		},
+		Classes: map[string][]string{`App\User`: {"users"}, "Report": {"users"}, "Worker": {"jobs"}},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{
+		"Database Tables: 2 tables named by 3 classes",
+		"• users: 3 references from 2 classes, model App\\User, 1 migrations\n   • jobs",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "App\\User, Report") {
+		t.Errorf("expected class lists only with -v:\n%s", out)
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "      App\\User, Report") {
+		t.Errorf("expected the classes with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Flags(t *testing.T) {
+	res := makeDummyResult()
+	res.Flags = &models.FlagReport{
+		Accessors: []string{"Feature::active"},
+		Flags: []*models.FeatureFlag{{
+			Name:      "new-checkout",
+			Checks:    []*models.FlagCheck{{File: "app/Checkout.php", Line: 3, Gated: []string{"method:pay:10"}}},
+			Gated:     []string{"method:pay:10"},
+			Footprint: 4,
+		}},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{"Feature Flags: 1 flags checked through Feature::active", "• new-checkout: 1 checks, 1 nodes gated (4 reached)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "app/Checkout.php:3") {
+		t.Errorf("expected checks only with -v:\n%s", out)
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "app/Checkout.php:3 → method:pay:10") {
+		t.Errorf("expected the checks with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Findings(t *testing.T) {
+	res := makeDummyResult()
+	for i := 0; i < 6; i++ {
+		res.Findings = append(res.Findings, &models.PassFinding{Pass: "cycles", Message: "Cycle through 2 nodes: a, b", File: "app/a.php", Line: i + 1})
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{"Analyzer Findings (6 total)", "• [cycles] Cycle through 2 nodes: a, b (app/a.php:1)", "... and 1 more"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "(app/a.php:6)") {
+		t.Errorf("expected every finding with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Suppressions(t *testing.T) {
+	res := makeDummyResult()
+	res.Suppressions = &models.SuppressionReport{
+		Suppressions: []*models.Suppression{
+			{Findings: []string{"cycles"}, File: "app/Queue.php", Line: 4, Reason: "a protocol", Nodes: []string{"function:ping:5"}},
+			{Findings: []string{"orphans"}, File: ".tukey-suppressions", Line: 2, Pattern: `Legacy\\.*`, Nodes: []string{}},
+		},
+		Suppressed: 1,
+		Unused:     1,
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{"Suppressions: 2, covering 1 nodes (1 unused)", `• .tukey-suppressions:2 orphans on Legacy\\.* (unused)`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "app/Queue.php:4") {
+		t.Errorf("expected used suppressions only with -v:\n%s", out)
+	}
+
+	out = captureOutput(func() { cf.PrintSummary(res, true) })
+	if !strings.Contains(out, "app/Queue.php:4 cycles (1 nodes) -- a protocol") {
+		t.Errorf("expected every suppression with -v:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Notes(t *testing.T) {
+	res := makeDummyResult()
+	for _, node := range res.Graph.Nodes {
+		node.Notes = []models.Note{
+			{Text: "Called from templates", Finding: "orphans", Author: "web-team"},
+			{Text: "Split up in Q3", Finding: "maxComplexity"},
+		}
+	}
+	res.Findings = []*models.PassFinding{{
+		Pass:    "cycles",
+		Message: "Cycle through 2 nodes: a, b",
+		Notes:   []models.Note{{Text: "Intentional cycle", Finding: "cycles"}},
+	}}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{"📝 Called from templates (web-team)", "📝 Split up in Q3", "📝 Intentional cycle"} {
+		if strings.Count(out, want) != 1 {
+			t.Errorf("expected %q once, under its finding, in output:\n%s", want, out)
+		}
+	}
+
+	cf.SetAccessible(true)
+	if out := captureOutput(func() { cf.PrintSummary(res, false) }); !strings.Contains(out, "Note: Intentional cycle") {
+		t.Errorf("expected notes labeled in accessible output:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintSummary_Sample(t *testing.T) {
+	res := makeDummyResult()
+	res.Sample = &models.SampleReport{
+		Percent:   10,
+		Files:     2000,
+		Sampled:   195,
+		Estimates: []*models.SampleEstimate{{Metric: "lines", Sampled: 14147, Estimate: 145097, Low: 144155, High: 146040}},
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintSummary(res, false) })
+	for _, want := range []string{"Sample: 195 of 2000 files (10%)", "• lines: ~145097 (95% CI 144155 to 146040; 14147 in the sample)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if out := captureOutput(func() { cf.PrintMetrics(res, map[string]int{}) }); !strings.Contains(out, "~145097") {
+		t.Errorf("expected the estimates with --summary-only:\n%s", out)
+	}
+}
+
+func TestConsoleFormatter_PrintScanReport(t *testing.T) {
+	report := &models.ScanReport{
+		Matched:     12,
+		Extensions:  map[string]int{".php": 10, ".phtml": 2},
+		Unmatched:   map[string]int{".md": 3, "": 1},
+		SkippedDirs: []models.SkippedPath{{Path: "vendor", Reason: "default"}, {Path: "build", Reason: "gitignore"}},
+		Gitignored:  4,
+		Oversized:   []models.SkippedPath{{Path: "data/dump.php", Size: 3 << 20}},
+		MaxFileSize: 1 << 20,
+	}
+	cf := NewConsoleFormatter()
+
+	out := captureOutput(func() { cf.PrintScanReport(report, false) })
+	for _, want := range []string{
+		"Scan: 12 files matched (.php 10, .phtml 2)",
+		"Not read by any parser: .md 3, no extension 1",
+		"Ignored by .gitignore: 4 files",
+		"build (gitignore)",
+		"Over the 1.00 MB size limit: 1 files",
+		"data/dump.php (3.00 MB)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestConsoleFormatter_PrintMetrics(t *testing.T) {
+	res := makeDummyResult()
+	cf := NewConsoleFormatter()
+	out := captureOutput(func() { cf.PrintMetrics(res, map[string]int{"orphans": 1, "edges": 0}) })
+
+	if !strings.Contains(out, "summary only") || !strings.Contains(out, "• edges: 0\n   • orphans: 1") {
+		t.Errorf("expected sorted metrics in output:\n%s", out)
+	}
+	if strings.Contains(out, "Most Depended Upon") {
+		t.Errorf("expected no detailed sections in summary-only output:\n%s", out)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// FlowsVersion is bumped whenever the flows format changes incompatibly
+const FlowsVersion = 1
+
+// DefaultFlowDepth is how many namespace segments or directories name a group by default
+const DefaultFlowDepth = 2
+
+// FlowsExporter writes the dependencies between groups of nodes (namespaces, or
+// directories for code without them) as a chord matrix and a list of sankey links
+type FlowsExporter struct {
+	depth int
+}
+
+// Flows is the exported document. Groups are indexed by Matrix and Links.
+type Flows struct {
+	Version     int                 `json:"version"`
+	Root        string              `json:"root"`
+	GeneratedAt string              `json:"generatedAt"`
+	Metadata    *models.RunMetadata `json:"metadata,omitempty"`
+	Depth       int                 `json:"depth"`
+	Groups      []*FlowGroup        `json:"groups"`
+	Matrix      [][]int             `json:"matrix"` // Matrix[i][j]: usages from group i into group j
+	Links       []*FlowLink         `json:"links"`
+}
+
+// FlowGroup is one namespace or directory
+type FlowGroup struct {
+	Name     string `json:"name"`
+	Nodes    int    `json:"nodes"`
+	Internal int    `json:"internal"` // Usages within the group
+	Outgoing int    `json:"outgoing"`
+	Incoming int    `json:"incoming"`
+}
+
+// FlowLink is the flow from one group into another
+type FlowLink struct {
+	Source   int  `json:"source"`
+	Target   int  `json:"target"`
+	Value    int  `json:"value"`              // Usages
+	Edges    int  `json:"edges"`              // Distinct node-to-node dependencies
+	Circular bool `json:"circular,omitempty"` // The target also flows back into the source
+}
+
+func init() {
+	Register(NewFlowsExporter())
+}
+
+// NewFlowsExporter creates a new flows exporter
+func NewFlowsExporter() *FlowsExporter {
+	return &FlowsExporter{depth: DefaultFlowDepth}
+}
+
+// Format returns the registry key for flows
+func (fe *FlowsExporter) Format() string {
+	return "flows"
+}
+
+// SetDepth sets how many namespace segments or directories name a group
+func (fe *FlowsExporter) SetDepth(depth int) {
+	fe.depth = depth
+}
+
+// Export writes the flows of result to filename as JSON
+func (fe *FlowsExporter) Export(result *models.AnalysisResult, filename string) error {
+	result.Graph.RLock()
+	flows := BuildFlows(result, fe.depth)
+	result.Graph.RUnlock()
+
+	data, err := json.MarshalIndent(flows, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// BuildFlows aggregates the graph's edges by group. Groups are sorted by name, links by
+// value, largest first. Usages within a group are counted on the group and the matrix
+// diagonal but never become links, so sankey layouts don't get self-loops.
+func BuildFlows(result *models.AnalysisResult, depth int) *Flows {
+	if depth < 1 {
+		depth = DefaultFlowDepth
+	}
+	root := absPath(result.Root)
+	flows := &Flows{
+		Version:     FlowsVersion,
+		Root:        filepath.ToSlash(root),
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Metadata:    result.Metadata,
+		Depth:       depth,
+		Groups:      []*FlowGroup{},
+		Matrix:      [][]int{},
+		Links:       []*FlowLink{},
+	}
+
+	groupOf := make(map[string]string, len(result.Graph.Nodes))
+	counts := make(map[string]int)
+	for id, node := range result.Graph.Nodes {
+		group := flowGroup(node, root, depth)
+		groupOf[id] = group
+		counts[group]++
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+		flows.Groups = append(flows.Groups, &FlowGroup{Name: name, Nodes: counts[name]})
+		flows.Matrix = append(flows.Matrix, make([]int, len(names)))
+	}
+
+	links := make(map[[2]int]*FlowLink)
+	for id, node := range result.Graph.Nodes {
+		source := index[groupOf[id]]
+		for targetID, ref := range node.Dependencies {
+			group, ok := groupOf[targetID]
+			if !ok {
+				continue
+			}
+			target := index[group]
+			flows.Matrix[source][target] += ref.Count
+			if source == target {
+				flows.Groups[source].Internal += ref.Count
+				continue
+			}
+			flows.Groups[source].Outgoing += ref.Count
+			flows.Groups[target].Incoming += ref.Count
+
+			link := links[[2]int{source, target}]
+			if link == nil {
+				link = &FlowLink{Source: source, Target: target}
+				links[[2]int{source, target}] = link
+				flows.Links = append(flows.Links, link)
+			}
+			link.Value += ref.Count
+			link.Edges++
+		}
+	}
+
+	for key, link := range links {
+		_, link.Circular = links[[2]int{key[1], key[0]}]
+	}
+	sort.Slice(flows.Links, func(i, j int) bool {
+		a, b := flows.Links[i], flows.Links[j]
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+	return flows
+}
+
+// flowGroup names the group a node belongs to: the first depth segments of its namespace,
+// or of its file's directory relative to root when it has no namespace ("." for files
+// directly in the root)
+func flowGroup(node *models.DependencyNode, root string, depth int) string {
+	if node.Namespace != "" {
+		segments := strings.Split(strings.Trim(node.Namespace, `\`), `\`)
+		return strings.Join(segments[:min(depth, len(segments))], `\`)
+	}
+	dir := path.Dir(relPath(root, node.File))
+	if dir == "." {
+		return dir
+	}
+	segments := strings.Split(dir, "/")
+	return strings.Join(segments[:min(depth, len(segments))], "/")
+}
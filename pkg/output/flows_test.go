@@ -0,0 +1,114 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func makeFlowsResult(root string) *models.AnalysisResult {
+	node := func(id, namespace, file string) *models.DependencyNode {
+		return &models.DependencyNode{
+			ID: id, Name: id, Namespace: namespace, File: filepath.Join(root, file),
+			Dependencies: map[string]*models.DependencyRef{},
+		}
+	}
+	controller := node("controller", `App\Http\Controllers`, "app/Http/Controllers/UserController.php")
+	request := node("request", `App\Http\Requests`, "app/Http/Requests/UserRequest.php")
+	user := node("user", `App\Models`, "app/Models/User.php")
+	post := node("post", `App\Models`, "app/Models/Post.php")
+	render := node("render", "", "resources/js/components/render.js")
+	boot := node("boot", "", "bootstrap.js")
+
+	depend := func(from, to *models.DependencyNode, count int) {
+		from.Dependencies[to.ID] = &models.DependencyRef{TargetID: to.ID, Count: count}
+	}
+	depend(controller, user, 3)
+	depend(controller, post, 2)
+	depend(controller, request, 1) // Same group at depth 2
+	depend(user, controller, 1)
+	depend(user, post, 4)
+	depend(boot, render, 1)
+	controller.Dependencies["missing"] = &models.DependencyRef{TargetID: "missing", Count: 9}
+
+	nodes := map[string]*models.DependencyNode{}
+	for _, n := range []*models.DependencyNode{controller, request, user, post, render, boot} {
+		nodes[n.ID] = n
+	}
+	return &models.AnalysisResult{Root: root, Graph: &models.DependencyGraph{Nodes: nodes}}
+}
+
+func TestBuildFlows(t *testing.T) {
+	flows := BuildFlows(makeFlowsResult(t.TempDir()), 2)
+
+	var names []string
+	for _, group := range flows.Groups {
+		names = append(names, group.Name)
+	}
+	if want := []string{".", `App\Http`, `App\Models`, "resources/js"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected groups %v, got %v", want, names)
+	}
+
+	want := [][]int{
+		{0, 0, 0, 1},
+		{0, 1, 5, 0},
+		{0, 1, 4, 0},
+		{0, 0, 0, 0},
+	}
+	if !reflect.DeepEqual(flows.Matrix, want) {
+		t.Errorf("expected matrix %v, got %v", want, flows.Matrix)
+	}
+
+	if len(flows.Links) != 3 {
+		t.Fatalf("expected 3 links without self-loops, got %d", len(flows.Links))
+	}
+	top := flows.Links[0]
+	if top.Source != 1 || top.Target != 2 || top.Value != 5 || top.Edges != 2 || !top.Circular {
+		t.Errorf("unexpected top link: %+v", top)
+	}
+	if last := flows.Links[2]; last.Source != 2 || last.Target != 1 || !last.Circular {
+		t.Errorf("expected the back link to be circular: %+v", last)
+	}
+	if js := flows.Links[1]; js.Source != 0 || js.Target != 3 || js.Circular {
+		t.Errorf("unexpected directory link: %+v", js)
+	}
+
+	http := flows.Groups[1]
+	if http.Nodes != 2 || http.Internal != 1 || http.Outgoing != 5 || http.Incoming != 1 {
+		t.Errorf("unexpected group totals: %+v", http)
+	}
+}
+
+func TestBuildFlows_Depth(t *testing.T) {
+	flows := BuildFlows(makeFlowsResult(t.TempDir()), 1)
+	if len(flows.Groups) != 3 || flows.Groups[1].Name != "App" || flows.Groups[1].Internal != 11 {
+		t.Errorf("expected one App group at depth 1, got %+v", flows.Groups)
+	}
+}
+
+func TestFlowsExporter_Export(t *testing.T) {
+	outPath := filepath.Join(t.TempDir(), "flows.json")
+	exporter, ok := Get("flows")
+	if !ok {
+		t.Fatal("expected the flows exporter to register itself")
+	}
+	if err := exporter.Export(makeFlowsResult(t.TempDir()), outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var flows Flows
+	if err := json.Unmarshal(data, &flows); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if flows.Version != FlowsVersion || flows.Depth != DefaultFlowDepth || len(flows.Matrix) != len(flows.Groups) {
+		t.Errorf("unexpected flows: %+v", flows)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// HeatmapVersion is bumped whenever the heatmap format changes incompatibly
+const HeatmapVersion = 1
+
+// HeatmapExporter writes a directory tree sized by lines of code and scored by complexity
+// and git churn, ready for treemap and heatmap charts
+type HeatmapExporter struct{}
+
+// Heatmap is the exported document
+type Heatmap struct {
+	Version     int                 `json:"version"`
+	Root        string              `json:"root"`
+	GeneratedAt string              `json:"generatedAt"`
+	Metadata    *models.RunMetadata `json:"metadata,omitempty"`
+	HasChurn    bool                `json:"hasChurn"` // False when the root isn't in a git repository
+	Tree        *HeatmapCell        `json:"tree"`
+}
+
+// HeatmapCell is a directory or, when Children is nil, a file
+type HeatmapCell struct {
+	Name        string         `json:"name"`
+	Path        string         `json:"path"` // Relative to the root; "" for the root itself
+	Files       int            `json:"files"`
+	Lines       int            `json:"lines"`
+	Definitions int            `json:"definitions"`
+	Complexity  int            `json:"complexity"` // Sum of the definitions' complexity scores
+	Churn       int            `json:"churn"`      // Lines added plus deleted in the churn window
+	Risk        float64        `json:"risk"`       // 0 (calm) to 1 (complex and changing often)
+	Children    []*HeatmapCell `json:"children,omitempty"`
+}
+
+func init() {
+	Register(NewHeatmapExporter())
+}
+
+// NewHeatmapExporter creates a new heatmap exporter
+func NewHeatmapExporter() *HeatmapExporter {
+	return &HeatmapExporter{}
+}
+
+// Format returns the registry key for heatmaps
+func (he *HeatmapExporter) Format() string {
+	return "heatmap"
+}
+
+// UsesChurn asks the caller to collect git churn before exporting
+func (he *HeatmapExporter) UsesChurn() bool {
+	return true
+}
+
+// ReadsSource reports that the treemap sizes files by counting their lines
+func (he *HeatmapExporter) ReadsSource() bool {
+	return true
+}
+
+// Export writes the heatmap of result to filename as JSON
+func (he *HeatmapExporter) Export(result *models.AnalysisResult, filename string) error {
+	result.Graph.RLock()
+	heatmap := BuildHeatmap(result)
+	result.Graph.RUnlock()
+
+	data, err := json.MarshalIndent(heatmap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// BuildHeatmap aggregates every analyzed file into a directory tree. A file's risk is the
+// mean of its complexity and churn, each relative to the highest of any file (complexity
+// alone without churn data); a directory's risk is its files' risk weighted by lines.
+// Children are sorted largest first, as treemap layouts expect.
+func BuildHeatmap(result *models.AnalysisResult) *Heatmap {
+	heatmap := &Heatmap{
+		Version:     HeatmapVersion,
+		Root:        filepath.ToSlash(absPath(result.Root)),
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Metadata:    result.Metadata,
+		HasChurn:    result.Churn != nil,
+		Tree:        &HeatmapCell{Name: filepath.Base(absPath(result.Root)), Children: []*HeatmapCell{}},
+	}
+
+	var leaves []*HeatmapCell
+	for _, file := range sourceFiles(result) {
+		leaf := &HeatmapCell{
+			Name:        path.Base(file.Path),
+			Path:        file.Path,
+			Files:       1,
+			Lines:       countLines(file.Abs),
+			Definitions: len(file.Nodes),
+			Churn:       result.Churn[file.Path],
+		}
+		for _, node := range file.Nodes {
+			leaf.Complexity += node.Score
+		}
+		heatmap.Tree.insert(leaf)
+		leaves = append(leaves, leaf)
+	}
+
+	maxComplexity, maxChurn := 0, 0
+	for _, leaf := range leaves {
+		maxComplexity = max(maxComplexity, leaf.Complexity)
+		maxChurn = max(maxChurn, leaf.Churn)
+	}
+	for _, leaf := range leaves {
+		risk := ratio(leaf.Complexity, maxComplexity)
+		if heatmap.HasChurn {
+			risk = (risk + ratio(leaf.Churn, maxChurn)) / 2
+		}
+		leaf.Risk = risk
+	}
+	heatmap.Tree.aggregate()
+	return heatmap
+}
+
+// insert adds a file cell under cell, creating its parent directories
+func (cell *HeatmapCell) insert(leaf *HeatmapCell) {
+	segments := strings.Split(leaf.Path, "/")
+	for i, name := range segments[:len(segments)-1] {
+		var dir *HeatmapCell
+		for _, child := range cell.Children {
+			if child.Name == name && child.Children != nil {
+				dir = child
+				break
+			}
+		}
+		if dir == nil {
+			dir = &HeatmapCell{Name: name, Path: strings.Join(segments[:i+1], "/"), Children: []*HeatmapCell{}}
+			cell.Children = append(cell.Children, dir)
+		}
+		cell = dir
+	}
+	cell.Children = append(cell.Children, leaf)
+}
+
+// aggregate sums a directory's children into it, sorts them, and rounds the risk scores
+func (cell *HeatmapCell) aggregate() {
+	if cell.Children == nil {
+		cell.Risk = math.Round(cell.Risk*1000) / 1000
+		return
+	}
+	weighted := 0.0
+	for _, child := range cell.Children {
+		child.aggregate()
+		cell.Files += child.Files
+		cell.Lines += child.Lines
+		cell.Definitions += child.Definitions
+		cell.Complexity += child.Complexity
+		cell.Churn += child.Churn
+		weighted += child.Risk * float64(child.Lines)
+	}
+	if cell.Lines > 0 {
+		cell.Risk = math.Round(weighted/float64(cell.Lines)*1000) / 1000
+	}
+	sort.Slice(cell.Children, func(i, j int) bool {
+		if cell.Children[i].Lines != cell.Children[j].Lines {
+			return cell.Children[i].Lines > cell.Children[j].Lines
+		}
+		return cell.Children[i].Name < cell.Children[j].Name
+	})
+}
+
+func ratio(value, max int) float64 {
+	if max == 0 {
+		return 0
+	}
+	return float64(value) / float64(max)
+}
+
+// countLines returns the number of lines in a file, or 0 when it can't be read
+func countLines(file string) int {
+	data, err := os.ReadFile(file)
+	if err != nil || len(data) == 0 {
+		return 0
+	}
+	lines := bytes.Count(data, []byte("\n"))
+	if data[len(data)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// treemapRect is one box of the rendered treemap, positioned in percent of the chart
+type treemapRect struct {
+	Cell  *HeatmapCell
+	Style template.CSS
+	Href  string // The file's page; empty for directories
+}
+
+// treemapDepth is how many directory levels the HTML treemap opens before summarizing
+const treemapDepth = 3
+
+// treemapRects lays out the heatmap with slice-and-dice: each level splits its box among
+// its children by lines, alternating between columns and rows. Boxes too small to see
+// are left out.
+func treemapRects(heatmap *Heatmap) []treemapRect {
+	var rects []treemapRect
+	var layout func(cell *HeatmapCell, x, y, w, h float64, depth int)
+	layout = func(cell *HeatmapCell, x, y, w, h float64, depth int) {
+		if w*h < 0.05 {
+			return
+		}
+		if cell.Children == nil || depth == treemapDepth || cell.Lines == 0 {
+			rect := treemapRect{
+				Cell: cell,
+				Style: template.CSS(fmt.Sprintf("left:%.3f%%;top:%.3f%%;width:%.3f%%;height:%.3f%%;background:hsl(%.0f,70%%,%.0f%%)",
+					x, y, w, h, 120*(1-cell.Risk), 75-cell.Risk*20)),
+			}
+			if cell.Children == nil {
+				rect.Href = "files/" + urlPath(cell.Path) + ".html"
+			}
+			rects = append(rects, rect)
+			return
+		}
+		offset := 0.0
+		for _, child := range cell.Children {
+			share := float64(child.Lines) / float64(cell.Lines)
+			if depth%2 == 0 {
+				layout(child, x+offset, y, w*share, h, depth+1)
+				offset += w * share
+			} else {
+				layout(child, x, y+offset, w, h*share, depth+1)
+				offset += h * share
+			}
+		}
+	}
+	layout(heatmap.Tree, 0, 0, 100, 100, 0)
+	return rects
+}
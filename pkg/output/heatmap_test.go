@@ -0,0 +1,123 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func makeHeatmapResult(t *testing.T) *models.AnalysisResult {
+	root := t.TempDir()
+	write := func(rel string, lines int) string {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x\n", lines)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	big := write("app/Models/User.php", 30)
+	small := write("app/helpers.php", 10)
+	lib := write("lib/util.php", 60)
+
+	nodes := map[string]*models.DependencyNode{}
+	for i, spec := range []struct {
+		file  string
+		score int
+	}{{big, 20}, {big, 20}, {small, 10}, {lib, 5}} {
+		id := filepath.Base(spec.file) + string(rune('a'+i))
+		nodes[id] = &models.DependencyNode{ID: id, Name: id, File: spec.file, Line: 1, Score: spec.score}
+	}
+	return &models.AnalysisResult{
+		Root:  root,
+		Graph: &models.DependencyGraph{Nodes: nodes},
+		Churn: map[string]int{"app/Models/User.php": 50, "app/helpers.php": 100},
+	}
+}
+
+func TestBuildHeatmap(t *testing.T) {
+	heatmap := BuildHeatmap(makeHeatmapResult(t))
+	tree := heatmap.Tree
+
+	if !heatmap.HasChurn || tree.Files != 3 || tree.Lines != 100 || tree.Complexity != 55 || tree.Churn != 150 {
+		t.Fatalf("unexpected totals: %+v", tree)
+	}
+	if len(tree.Children) != 2 || tree.Children[0].Name != "lib" || tree.Children[1].Name != "app" {
+		t.Fatalf("expected directories sorted by size, got %+v", tree.Children)
+	}
+
+	app := tree.Children[1]
+	if app.Path != "app" || app.Files != 2 || app.Lines != 40 || app.Definitions != 3 {
+		t.Errorf("unexpected app directory: %+v", app)
+	}
+	models, helpers := app.Children[0], app.Children[1]
+	if models.Path != "app/Models" || helpers.Path != "app/helpers.php" || helpers.Children != nil {
+		t.Fatalf("unexpected app children: %+v %+v", models, helpers)
+	}
+
+	// User.php has the most complexity and half the top churn; helpers.php the top churn
+	user := models.Children[0]
+	if user.Risk != 0.75 || helpers.Risk != 0.625 {
+		t.Errorf("unexpected file risks: User.php %v, helpers.php %v", user.Risk, helpers.Risk)
+	}
+	if want := 0.719; app.Risk != want { // (0.75*30 + 0.625*10) / 40
+		t.Errorf("expected line-weighted directory risk %v, got %v", want, app.Risk)
+	}
+}
+
+func TestBuildHeatmap_WithoutChurn(t *testing.T) {
+	result := makeHeatmapResult(t)
+	result.Churn = nil
+	heatmap := BuildHeatmap(result)
+
+	lib := heatmap.Tree.Children[0].Children[0]
+	if heatmap.HasChurn || lib.Churn != 0 || lib.Risk != 0.125 {
+		t.Errorf("expected complexity-only risk, got %+v", lib)
+	}
+}
+
+func TestHeatmapExporter_Export(t *testing.T) {
+	outPath := filepath.Join(t.TempDir(), "heatmap.json")
+	exporter, ok := Get("heatmap")
+	if !ok {
+		t.Fatal("expected the heatmap exporter to register itself")
+	}
+	if churner, ok := exporter.(ChurnExporter); !ok || !churner.UsesChurn() {
+		t.Error("expected the heatmap exporter to ask for churn")
+	}
+	if err := exporter.Export(makeHeatmapResult(t), outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var heatmap Heatmap
+	if err := json.Unmarshal(data, &heatmap); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if heatmap.Version != HeatmapVersion || heatmap.Tree.Files != 3 {
+		t.Errorf("unexpected heatmap: %+v", heatmap)
+	}
+}
+
+func TestTreemapRects(t *testing.T) {
+	rects := treemapRects(BuildHeatmap(makeHeatmapResult(t)))
+	if len(rects) != 3 {
+		t.Fatalf("expected a box per file, got %d", len(rects))
+	}
+	// lib takes the left 60%, then app's files split the rest top to bottom
+	if rects[0].Href != "files/lib/util.php.html" || !strings.HasPrefix(string(rects[0].Style), "left:0.000%;top:0.000%;width:60.000%;height:100.000%") {
+		t.Errorf("unexpected first box: %+v", rects[0])
+	}
+	if !strings.HasPrefix(string(rects[2].Style), "left:60.000%;top:75.000%;width:40.000%;height:25.000%") {
+		t.Errorf("unexpected last box: %+v", rects[2])
+	}
+}
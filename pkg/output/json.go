@@ -6,33 +6,104 @@ package output
 import (
 	"encoding/json"
 	"os"
+	"sort"
+	"time"
 
 	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/provenance"
 )
 
 // JSONExporter handles JSON export functionality
-type JSONExporter struct{}
+type JSONExporter struct {
+	signingKey []byte
+}
+
+func init() {
+	Register(NewJSONExporter())
+}
 
 // NewJSONExporter creates a new JSON exporter
 func NewJSONExporter() *JSONExporter {
 	return &JSONExporter{}
 }
 
+// Format returns the registry key for JSON reports
+func (je *JSONExporter) Format() string {
+	return "json"
+}
+
+// SetSigningKey signs exported reports with HMAC-SHA256 under key, in addition to the
+// checksum every report with provenance carries
+func (je *JSONExporter) SetSigningKey(key []byte) {
+	je.signingKey = key
+}
+
+// reportFile is an analyzed file and its language, so reports can be filtered by language
+type reportFile struct {
+	Path     string `json:"path"` // As in the nodes' file
+	Language string `json:"language,omitempty"`
+}
+
 // Export exports the analysis results to a JSON file
 func (je *JSONExporter) Export(result *models.AnalysisResult, filename string) error {
+	generatedAt := time.Now().UTC().Format(time.RFC3339)
+
 	// Create the export data structure
 	exportData := struct {
-		Graph          *models.DependencyGraph `json:"graph"`
-		TotalFiles     int                     `json:"totalFiles"`
-		TotalElements  int                     `json:"totalElements"`
-		ProcessingTime string                  `json:"processingTime"`
-		GeneratedAt    string                  `json:"generatedAt"`
+		Graph          *models.DependencyGraph   `json:"graph"`
+		TotalFiles     int                       `json:"totalFiles"`
+		Files          []reportFile              `json:"files,omitempty"`
+		TotalElements  int                       `json:"totalElements"`
+		ProcessingTime string                    `json:"processingTime"`
+		GeneratedAt    string                    `json:"generatedAt"`
+		Metadata       *models.RunMetadata       `json:"metadata,omitempty"`
+		APISurface     *models.APISurface        `json:"apiSurface,omitempty"`
+		Clones         *models.CloneReport       `json:"clones,omitempty"`
+		Literals       *models.LiteralReport     `json:"literals,omitempty"`
+		Debt           *models.DebtReport        `json:"debt,omitempty"`
+		Docs           *models.DocReport         `json:"docs,omitempty"`
+		OpenAPI        *models.OpenAPIReport     `json:"openapi,omitempty"`
+		Tables         *models.TableReport       `json:"tables,omitempty"`
+		Flags          *models.FlagReport        `json:"flags,omitempty"`
+		Syntax         *models.SyntaxReport      `json:"syntax,omitempty"`
+		Platform       *models.PlatformReport    `json:"platform,omitempty"`
+		Sample         *models.SampleReport      `json:"sample,omitempty"`
+		Scan           *models.ScanReport        `json:"scan,omitempty"`
+		Suppressions   *models.SuppressionReport `json:"suppressions,omitempty"`
+		Findings       []*models.PassFinding     `json:"findings,omitempty"`
+		Provenance     *models.Provenance        `json:"provenance,omitempty"`
 	}{
 		Graph:          result.Graph,
 		TotalFiles:     result.TotalFiles,
+		Files:          reportFiles(result),
 		TotalElements:  result.TotalElements,
 		ProcessingTime: result.ProcessingTime,
-		GeneratedAt:    "2025-09-24T18:54:12Z", // You might want to make this dynamic
+		GeneratedAt:    generatedAt,
+		Metadata:       result.Metadata,
+		APISurface:     result.APISurface,
+		Clones:         result.Clones,
+		Literals:       result.Literals,
+		Debt:           result.Debt,
+		Docs:           result.Docs,
+		OpenAPI:        result.OpenAPI,
+		Tables:         result.Tables,
+		Flags:          result.Flags,
+		Syntax:         result.Syntax,
+		Platform:       result.Platform,
+		Sample:         result.Sample,
+		Scan:           result.Scan,
+		Suppressions:   result.Suppressions,
+		Findings:       result.Findings,
+	}
+
+	if result.Provenance != nil {
+		prov := *result.Provenance
+		prov.Timestamp = generatedAt
+		prov.Algorithm = "sha256"
+		if len(je.signingKey) > 0 {
+			prov.Algorithm = "hmac-sha256"
+		}
+		exportData.Provenance = &prov
 	}
 
 	data, err := json.MarshalIndent(exportData, "", "  ")
@@ -40,9 +111,41 @@ func (je *JSONExporter) Export(result *models.AnalysisResult, filename string) e
 		return err
 	}
 
+	// Seal the report: the digest covers everything but the checksum and signature
+	if prov := exportData.Provenance; prov != nil {
+		if prov.Checksum, err = provenance.Digest(data); err != nil {
+			return err
+		}
+		if len(je.signingKey) > 0 {
+			prov.Signature = provenance.Sign(prov.Checksum, je.signingKey)
+		}
+		if data, err = json.MarshalIndent(exportData, "", "  "); err != nil {
+			return err
+		}
+	}
+
 	return os.WriteFile(filename, data, 0644)
 }
 
+// reportFiles lists the parsed files sorted by path
+func reportFiles(result *models.AnalysisResult) []reportFile {
+	files := make([]reportFile, 0, len(result.ParsedFiles))
+	for _, parsed := range result.ParsedFiles {
+		files = append(files, reportFile{Path: parsed.Path, Language: parsed.Language})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// fileLanguages maps each parsed file's path to its language
+func fileLanguages(result *models.AnalysisResult) map[string]string {
+	languages := make(map[string]string, len(result.ParsedFiles))
+	for _, parsed := range result.ParsedFiles {
+		languages[parsed.Path] = parsed.Language
+	}
+	return languages
+}
+
 // ExportGraph exports just the dependency graph to JSON (for backwards compatibility)
 func (je *JSONExporter) ExportGraph(graph *models.DependencyGraph, filename string) error {
 	data, err := json.MarshalIndent(graph, "", "  ")
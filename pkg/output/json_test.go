@@ -1,10 +1,15 @@
 package output
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+	"github.com/boone-studios/tukey/internal/provenance"
 )
 
 func TestJSONExporter_Export(t *testing.T) {
@@ -31,6 +36,70 @@ func TestJSONExporter_Export(t *testing.T) {
 	}
 }
 
+func TestJSONExporter_ExportMetadata(t *testing.T) {
+	res := makeDummyResult()
+	res.Metadata = &models.RunMetadata{
+		ToolVersion: "1.2.0",
+		Root:        "/src/app",
+		GitBranch:   "main",
+		Host:        models.HostInfo{OS: "linux", Arch: "amd64", CPUs: 8},
+		Args:        []string{"--clones", "/src/app"},
+		Config:      map[string]interface{}{"clones": true},
+	}
+
+	outPath := filepath.Join(t.TempDir(), "result.json")
+	if err := NewJSONExporter().Export(res, outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	data, _ := os.ReadFile(outPath)
+	var report struct {
+		Metadata *models.RunMetadata `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !reflect.DeepEqual(report.Metadata, res.Metadata) {
+		t.Errorf("expected %+v, got %+v", res.Metadata, report.Metadata)
+	}
+}
+
+func TestJSONExporter_ExportLanguages(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.Nodes["1"].Language = "php"
+	res.ParsedFiles = []*models.ParsedFile{
+		{Path: "web/app.js", Language: "javascript"},
+		{Path: "app/User.php", Language: "php"},
+	}
+
+	outPath := filepath.Join(t.TempDir(), "result.json")
+	if err := NewJSONExporter().Export(res, outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	var report struct {
+		Files []reportFile `json:"files"`
+		Graph struct {
+			Nodes map[string]struct {
+				Language string `json:"language"`
+			} `json:"nodes"`
+		} `json:"graph"`
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []reportFile{{Path: "app/User.php", Language: "php"}, {Path: "web/app.js", Language: "javascript"}}
+	if !reflect.DeepEqual(report.Files, want) {
+		t.Errorf("expected files sorted by path with their language, got %+v", report.Files)
+	}
+	if report.Graph.Nodes["1"].Language != "php" {
+		t.Errorf("expected the node's language, got %+v", report.Graph.Nodes["1"])
+	}
+}
+
 func TestJSONExporter_ExportGraph(t *testing.T) {
 	res := makeDummyResult()
 	je := NewJSONExporter()
@@ -46,3 +115,24 @@ func TestJSONExporter_ExportGraph(t *testing.T) {
 		t.Errorf("expected graph JSON to contain totalNodes=1")
 	}
 }
+
+func TestJSONExporter_ExportSigned(t *testing.T) {
+	res := makeDummyResult()
+	res.Provenance = &models.Provenance{Tool: "tukey", ToolVersion: "1.0.0", Root: "/src", GitCommit: "abc1234"}
+	je := NewJSONExporter()
+	je.SetSigningKey([]byte("secret"))
+
+	outPath := filepath.Join(t.TempDir(), "signed.json")
+	if err := je.Export(res, outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	data, _ := os.ReadFile(outPath)
+
+	prov, err := provenance.Verify(data, []byte("secret"))
+	if err != nil {
+		t.Fatalf("expected exported report to verify, got %v\n%s", err, data)
+	}
+	if prov.Algorithm != "hmac-sha256" || prov.GitCommit != "abc1234" || prov.Timestamp == "" {
+		t.Errorf("unexpected provenance: %+v", prov)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// Exporter is the contract any report exporter must satisfy
+type Exporter interface {
+	Export(result *models.AnalysisResult, filename string) error
+	Format() string // e.g., "json"
+}
+
+// SigningExporter is implemented by exporters that can embed a signature in their reports
+type SigningExporter interface {
+	Exporter
+	SetSigningKey(key []byte)
+}
+
+// ChurnExporter is implemented by exporters that report git churn. The caller collects
+// churn into AnalysisResult.Churn only for these, since it means reading the git history.
+type ChurnExporter interface {
+	Exporter
+	UsesChurn() bool
+}
+
+// SourceReader is implemented by exporters that read the analyzed files themselves, for
+// line counts or source listings. A redacted result no longer names the files, and what
+// these exporters would read is what redaction hides, so they refuse redacted exports.
+type SourceReader interface {
+	Exporter
+	ReadsSource() bool
+}
+
+// registry of available exporters
+var (
+	mu       sync.RWMutex
+	registry = map[string]Exporter{}
+)
+
+// Register adds an exporter to the global registry.
+// Typically called from exporter init() functions.
+func Register(e Exporter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	format := e.Format()
+	if _, exists := registry[format]; exists {
+		panic(fmt.Sprintf("exporter for format %q already registered", format))
+	}
+	registry[format] = e
+}
+
+// Get retrieves an exporter for the given format key (e.g. "json").
+func Get(format string) (Exporter, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	e, ok := registry[format]
+	return e, ok
+}
+
+// SupportedFormats returns a sorted list of registered format keys.
+func SupportedFormats() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	formats := make([]string, 0, len(registry))
+	for k := range registry {
+		formats = append(formats, k)
+	}
+	sort.Strings(formats)
+	return formats
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// dummyExporter is a simple exporter for testing the registry.
+type dummyExporter struct{}
+
+func (d *dummyExporter) Export(result *models.AnalysisResult, filename string) error {
+	return nil
+}
+
+func (d *dummyExporter) Format() string {
+	return "dummy"
+}
+
+func TestRegistry_JSONRegistered(t *testing.T) {
+	e, ok := Get("json")
+	if !ok {
+		t.Fatalf("expected the json exporter to register itself")
+	}
+	if _, ok := e.(SigningExporter); !ok {
+		t.Errorf("expected the json exporter to support signing")
+	}
+}
+
+func TestRegistry_RegisterAndGet(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+	registry = map[string]Exporter{}
+
+	Register(&dummyExporter{})
+
+	e, ok := Get("dummy")
+	if !ok || e.Format() != "dummy" {
+		t.Fatalf("expected dummy exporter to be registered")
+	}
+	if formats := SupportedFormats(); len(formats) != 1 || formats[0] != "dummy" {
+		t.Errorf("unexpected formats: %v", formats)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic when registering a duplicate format")
+		}
+	}()
+	Register(&dummyExporter{})
+}
@@ -0,0 +1,533 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// SourceExporter writes a browsable HTML copy of the analyzed source: every definition is
+// anchored and listed with what uses it, and every usage Tukey resolved links to its
+// definition. The output "file" is a directory holding index.html, which opens with a
+// risk treemap of the project, and a page per file.
+type SourceExporter struct{}
+
+func init() {
+	Register(NewSourceExporter())
+}
+
+// NewSourceExporter creates a new source browser exporter
+func NewSourceExporter() *SourceExporter {
+	return &SourceExporter{}
+}
+
+// Format returns the registry key for the source browser
+func (se *SourceExporter) Format() string {
+	return "source"
+}
+
+// UsesChurn asks the caller to collect git churn for the index page's treemap
+func (se *SourceExporter) UsesChurn() bool {
+	return true
+}
+
+// ReadsSource reports that the browser copies the analyzed source
+func (se *SourceExporter) ReadsSource() bool {
+	return true
+}
+
+// sourceFile is one page of the browser
+type sourceFile struct {
+	Path  string // Relative to the root, with forward slashes
+	Abs   string // Where to read the source
+	Nodes []*models.DependencyNode
+}
+
+// sourceLink is a span of a line to wrap in a link or anchor
+type sourceLink struct {
+	Start, End int
+	Href       string // Empty for a definition anchor
+	Title      string
+}
+
+// sourceUse is one place a definition is used, for the "used by" lists
+type sourceUse struct {
+	Name string
+	Href string
+	Line int
+}
+
+// sourceDuplicate is a block of a file duplicated elsewhere, or one copy of a clone on
+// the index
+type sourceDuplicate struct {
+	StartLine, EndLine int
+	Path               string // The file holding this copy
+	Href               string
+	Other              string // Where the other copy is, as path:start-end
+	Tokens             int
+	Similarity         float64
+}
+
+// Export writes the browser into the directory dir
+func (se *SourceExporter) Export(result *models.AnalysisResult, dir string) error {
+	result.Graph.RLock()
+	defer result.Graph.RUnlock()
+
+	files := sourceFiles(result)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		page, err := renderSourcePage(file, files, result.Graph, result.Clones)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file.Path, err)
+		}
+		out := filepath.Join(dir, "files", filepath.FromSlash(file.Path)+".html")
+		if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, page, 0644); err != nil {
+			return err
+		}
+	}
+
+	if result.Metadata != nil {
+		data, err := json.MarshalIndent(result.Metadata, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "metadata.json"), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	heatmap := BuildHeatmap(result)
+	var index strings.Builder
+	err := sourceIndexTemplate.Execute(&index, map[string]interface{}{
+		"Files":   files,
+		"Heatmap": heatmap,
+		"Treemap": treemapRects(heatmap),
+		"Clones":  indexDuplicates(result.Clones, files),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "index.html"), []byte(index.String()), 0644)
+}
+
+// sourceFiles groups the graph's nodes by file, sorted by path, including parsed files
+// that define nothing
+func sourceFiles(result *models.AnalysisResult) map[string]*sourceFile {
+	root := absPath(result.Root)
+	files := make(map[string]*sourceFile)
+	add := func(file string) *sourceFile {
+		if f, ok := files[file]; ok {
+			return f
+		}
+		f := &sourceFile{Path: relPath(root, file), Abs: file}
+		files[file] = f
+		return f
+	}
+	for _, parsed := range result.ParsedFiles {
+		add(parsed.Path)
+	}
+	for _, node := range result.Graph.Nodes {
+		f := add(node.File)
+		f.Nodes = append(f.Nodes, node)
+	}
+	for _, f := range files {
+		sort.Slice(f.Nodes, func(i, j int) bool {
+			if f.Nodes[i].Line != f.Nodes[j].Line {
+				return f.Nodes[i].Line < f.Nodes[j].Line
+			}
+			return f.Nodes[i].ID < f.Nodes[j].ID
+		})
+	}
+	return files
+}
+
+// indexDuplicates lists each clone as its pair of copies, linked to their lines
+func indexDuplicates(clones *models.CloneReport, files map[string]*sourceFile) [][]sourceDuplicate {
+	if clones == nil {
+		return nil
+	}
+	pairs := make([][]sourceDuplicate, 0, len(clones.Clones))
+	for _, clone := range clones.Clones {
+		var pair []sourceDuplicate
+		for _, block := range clone.Blocks {
+			dup := sourceDuplicate{
+				StartLine: block.StartLine, EndLine: block.EndLine, Path: block.File,
+				Tokens: clone.Tokens, Similarity: clone.Similarity * 100,
+			}
+			if f, ok := files[block.File]; ok {
+				dup.Path = f.Path
+				dup.Href = fmt.Sprintf("files/%s.html#L%d", urlPath(f.Path), block.StartLine)
+			}
+			pair = append(pair, dup)
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+// renderSourcePage renders one file with line anchors, definition anchors, usage links,
+// and its duplicated blocks highlighted
+func renderSourcePage(file *sourceFile, files map[string]*sourceFile, graph *models.DependencyGraph, clones *models.CloneReport) ([]byte, error) {
+	lines, err := readLines(file.Abs)
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.Repeat("../", strings.Count(file.Path, "/")+1)
+	href := func(node *models.DependencyNode, line int) string {
+		target, ok := files[node.File]
+		if !ok {
+			return ""
+		}
+		if target == file {
+			return fmt.Sprintf("#L%d", line)
+		}
+		return fmt.Sprintf("%sfiles/%s.html#L%d", prefix, urlPath(target.Path), line)
+	}
+
+	links := make(map[int][]sourceLink)
+	type definition struct {
+		Node   *models.DependencyNode
+		UsedBy []sourceUse
+	}
+	var definitions []definition
+	for _, node := range file.Nodes {
+		if text, ok := lineText(lines, node.Line); ok {
+			if start := findIdentifier(text, node.Name); start >= 0 {
+				links[node.Line] = append(links[node.Line], sourceLink{
+					Start: start, End: start + len(node.Name),
+					Title: fmt.Sprintf("%s %s, %d dependents", node.Type, node.Name, len(node.Dependents)),
+				})
+			}
+		}
+
+		// Usages this node makes link to their targets
+		for _, ref := range sortedRefs(node.Dependencies, graph) {
+			target := graph.Nodes[ref.TargetID]
+			if target == nil {
+				continue
+			}
+			link := href(target, target.Line)
+			if link == "" {
+				continue
+			}
+			for _, line := range ref.Lines {
+				text, ok := lineText(lines, line)
+				if !ok {
+					continue
+				}
+				if start := findIdentifier(text, target.Name); start >= 0 {
+					links[line] = append(links[line], sourceLink{
+						Start: start, End: start + len(target.Name), Href: link,
+						Title: fmt.Sprintf("%s %s (%s)", target.Type, target.Name, ref.Type),
+					})
+				}
+			}
+		}
+
+		def := definition{Node: node}
+		for _, ref := range sortedRefs(node.Dependents, graph) {
+			user := graph.Nodes[ref.TargetID]
+			if user == nil {
+				continue
+			}
+			line := user.Line
+			if len(ref.Lines) > 0 {
+				line = sortedLines(ref.Lines)[0]
+			}
+			def.UsedBy = append(def.UsedBy, sourceUse{Name: user.Name, Href: href(user, line), Line: line})
+		}
+		definitions = append(definitions, def)
+	}
+
+	// Blocks of this file copied elsewhere link to the other copy
+	var duplicates []sourceDuplicate
+	duplicated := make([]bool, len(lines))
+	if clones != nil {
+		for _, clone := range clones.Clones {
+			for i, block := range clone.Blocks {
+				if block.File != file.Abs {
+					continue
+				}
+				other := clone.Blocks[1-i]
+				dup := sourceDuplicate{
+					StartLine: block.StartLine, EndLine: block.EndLine,
+					Other:  fmt.Sprintf("%s:%d-%d", relPath("", other.File), other.StartLine, other.EndLine),
+					Tokens: clone.Tokens, Similarity: clone.Similarity * 100,
+				}
+				if target, ok := files[other.File]; ok {
+					dup.Other = fmt.Sprintf("%s:%d-%d", target.Path, other.StartLine, other.EndLine)
+					if target == file {
+						dup.Href = fmt.Sprintf("#L%d", other.StartLine)
+					} else {
+						dup.Href = fmt.Sprintf("%sfiles/%s.html#L%d", prefix, urlPath(target.Path), other.StartLine)
+					}
+				}
+				duplicates = append(duplicates, dup)
+				for line := block.StartLine; line <= block.EndLine && line <= len(lines); line++ {
+					duplicated[line-1] = true
+				}
+			}
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i].StartLine < duplicates[j].StartLine })
+
+	rendered := make([]template.HTML, len(lines))
+	for i, text := range lines {
+		rendered[i] = renderLine(text, links[i+1])
+	}
+
+	var page strings.Builder
+	err = sourcePageTemplate.Execute(&page, map[string]interface{}{
+		"Path":        file.Path,
+		"Index":       prefix + "index.html",
+		"Lines":       rendered,
+		"Duplicated":  duplicated,
+		"Duplicates":  duplicates,
+		"Definitions": definitions,
+	})
+	return []byte(page.String()), err
+}
+
+// renderLine escapes a line of source, wrapping the linked spans. Overlapping spans
+// keep the first.
+func renderLine(text string, links []sourceLink) template.HTML {
+	sort.SliceStable(links, func(i, j int) bool { return links[i].Start < links[j].Start })
+	var b strings.Builder
+	pos := 0
+	for _, link := range links {
+		if link.Start < pos {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:link.Start]))
+		name := html.EscapeString(text[link.Start:link.End])
+		title := html.EscapeString(link.Title)
+		if link.Href == "" {
+			fmt.Fprintf(&b, `<span class="def" title="%s">%s</span>`, title, name)
+		} else {
+			fmt.Fprintf(&b, `<a href="%s" title="%s">%s</a>`, html.EscapeString(link.Href), title, name)
+		}
+		pos = link.End
+	}
+	b.WriteString(html.EscapeString(text[pos:]))
+	return template.HTML(b.String())
+}
+
+// findIdentifier returns the index of the first whole-identifier occurrence of name in
+// text, or -1
+func findIdentifier(text, name string) int {
+	if name == "" {
+		return -1
+	}
+	for from := 0; from <= len(text)-len(name); {
+		i := strings.Index(text[from:], name)
+		if i < 0 {
+			return -1
+		}
+		i += from
+		end := i + len(name)
+		if (i == 0 || !isIdentByte(text[i-1])) && (end == len(text) || !isIdentByte(text[end])) {
+			return i
+		}
+		from = i + 1
+	}
+	return -1
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func lineText(lines []string, line int) (string, bool) {
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+	return lines[line-1], true
+}
+
+func readLines(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.ReplaceAll(scanner.Text(), "\t", "    "))
+	}
+	return lines, scanner.Err()
+}
+
+// relPath returns file relative to root with forward slashes. Files outside the root
+// keep their path, cleaned of leading separators and parent references.
+func relPath(root, file string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, absPath(file)); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return strings.TrimLeft(path.Clean("/"+filepath.ToSlash(file)), "/")
+}
+
+// urlPath escapes each segment of a slash-separated path for use in a link
+func urlPath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+const sourceStyle = `
+body { margin: 0; font: 14px/1.5 -apple-system, "Segoe UI", sans-serif; color: #1f2328; }
+header { padding: 12px 20px; border-bottom: 1px solid #d0d7de; background: #f6f8fa; }
+header a { color: #0969da; text-decoration: none; }
+main { display: flex; align-items: flex-start; }
+table.code { border-collapse: collapse; font: 13px/1.45 ui-monospace, SFMono-Regular, Menlo, monospace; flex: 1; }
+table.code td { padding: 0 12px; white-space: pre; vertical-align: top; }
+table.code td.n { text-align: right; color: #8c959f; user-select: none; }
+table.code td.n a { color: inherit; text-decoration: none; }
+table.code tr:target { background: #fff8c5; }
+table.code a { color: #0969da; text-decoration: none; border-bottom: 1px dotted; }
+.def { font-weight: 600; background: #ddf4ff; }
+table.code tr.dup td.n { background: #ffebe9; box-shadow: inset -3px 0 #cf222e; }
+aside { width: 320px; padding: 12px 20px; border-left: 1px solid #d0d7de; position: sticky; top: 0; max-height: 100vh; overflow: auto; }
+aside ul { padding-left: 18px; margin: 4px 0; }
+aside .kind { color: #57606a; font-size: 12px; }
+section { padding: 12px 20px; }
+.treemap { position: relative; height: 480px; border: 1px solid #d0d7de; }
+.treemap a, .treemap span { position: absolute; box-sizing: border-box; border: 1px solid #fff; overflow: hidden;
+  padding: 2px 4px; font-size: 11px; color: #1f2328; text-decoration: none; white-space: nowrap; text-overflow: ellipsis; }
+.kind { color: #57606a; font-size: 12px; }
+`
+
+var sourcePageTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Path}} · Tukey</title>
+<style>` + sourceStyle + `</style>
+</head>
+<body>
+<header><a href="{{.Index}}">Files</a> / {{.Path}}</header>
+<main>
+<table class="code">
+{{- range $i, $line := .Lines}}
+<tr id="L{{add $i 1}}"{{if index $.Duplicated $i}} class="dup"{{end}}><td class="n"><a href="#L{{add $i 1}}">{{add $i 1}}</a></td><td>{{$line}}</td></tr>
+{{- end}}
+</table>
+<aside>
+<strong>Defined here</strong>
+{{- range .Definitions}}
+<div>
+<a href="#L{{.Node.Line}}">{{.Node.Name}}</a> <span class="kind">{{.Node.Type}}</span>
+{{- if .UsedBy}}
+<details><summary>used by {{len .UsedBy}}</summary><ul>
+{{- range .UsedBy}}
+<li>{{if .Href}}<a href="{{.Href}}">{{.Name}}</a>{{else}}{{.Name}}{{end}} <span class="kind">line {{.Line}}</span></li>
+{{- end}}
+</ul></details>
+{{- end}}
+</div>
+{{- else}}
+<p class="kind">Nothing</p>
+{{- end}}
+{{- if .Duplicates}}
+<p><strong>Duplicated blocks</strong></p>
+<ul>
+{{- range .Duplicates}}
+<li><a href="#L{{.StartLine}}">lines {{.StartLine}}-{{.EndLine}}</a> also in {{if .Href}}<a href="{{.Href}}">{{.Other}}</a>{{else}}{{.Other}}{{end}}
+<span class="kind">{{.Tokens}} tokens, {{printf "%.0f" .Similarity}}% identical</span></li>
+{{- end}}
+</ul>
+{{- end}}
+</aside>
+</main>
+</body>
+</html>
+`))
+
+var sourceIndexTemplate = template.Must(template.New("index").Funcs(template.FuncMap{
+	"urlPath": urlPath,
+	"title": func(cell *HeatmapCell) string {
+		name := cell.Path
+		if name == "" {
+			name = cell.Name
+		}
+		return fmt.Sprintf("%s: %d files, %d lines, complexity %d, churn %d, risk %.2f",
+			name, cell.Files, cell.Lines, cell.Complexity, cell.Churn, cell.Risk)
+	},
+	"sorted": func(files map[string]*sourceFile) []*sourceFile {
+		sorted := make([]*sourceFile, 0, len(files))
+		for _, f := range files {
+			sorted = append(sorted, f)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+		return sorted
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Source · Tukey</title>
+<style>` + sourceStyle + `</style>
+</head>
+<body>
+<header>Files</header>
+<section>
+<strong>Heatmap</strong>
+<p class="kind">Area is lines of code; color runs from green to red with risk, which combines complexity
+{{- if .Heatmap.HasChurn}} and git churn{{else}} (no git history was found for churn){{end}}.</p>
+<div class="treemap">
+{{- range .Treemap}}
+{{- if .Href}}
+<a href="{{.Href}}" style="{{.Style}}" title="{{title .Cell}}">{{.Cell.Name}}</a>
+{{- else}}
+<span style="{{.Style}}" title="{{title .Cell}}">{{.Cell.Name}}/</span>
+{{- end}}
+{{- end}}
+</div>
+</section>
+{{- if .Clones}}
+<section>
+<strong>Duplicated code</strong>
+<ul>
+{{- range .Clones}}
+<li>
+{{- range $i, $dup := .}}{{if $i}} ↔ {{end}}{{if .Href}}<a href="{{.Href}}">{{.Path}}:{{.StartLine}}-{{.EndLine}}</a>{{else}}{{.Path}}:{{.StartLine}}-{{.EndLine}}{{end}}{{end}}
+<span class="kind">{{(index . 0).Tokens}} tokens, {{printf "%.0f" (index . 0).Similarity}}% identical</span></li>
+{{- end}}
+</ul>
+</section>
+{{- end}}
+<main><ul>
+{{- range sorted .Files}}
+<li><a href="files/{{urlPath .Path}}.html">{{.Path}}</a> <span class="kind">{{len .Nodes}} definitions</span></li>
+{{- end}}
+</ul></main>
+</body>
+</html>
+`))
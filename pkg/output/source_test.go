@@ -0,0 +1,181 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func makeSourceResult(t *testing.T) (*models.AnalysisResult, string) {
+	root := t.TempDir()
+	write := func(rel, content string) string {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	userFile := write("src/Models/User.php", "<?php\nclass User {\n\tpublic function save() {}\n}\n")
+	controllerFile := write("src/Controller.php", "<?php\nclass Controller {\n  function index() {\n    $u = new User(); // <User>\n    $u->save();\n    $this->index();\n  }\n}\n")
+
+	user := &models.DependencyNode{ID: "class:User:2", Name: "User", Type: "class", File: userFile, Line: 2}
+	save := &models.DependencyNode{ID: "method:User::save:3", Name: "save", Type: "method", ClassName: "User", File: userFile, Line: 3}
+	index := &models.DependencyNode{ID: "method:Controller::index:3", Name: "index", Type: "method", ClassName: "Controller", File: controllerFile, Line: 3}
+	index.Dependencies = map[string]*models.DependencyRef{
+		user.ID:  {TargetID: user.ID, Type: "instantiates", Count: 1, Lines: []int{4}},
+		save.ID:  {TargetID: save.ID, Type: "calls", Count: 1, Lines: []int{5}},
+		index.ID: {TargetID: index.ID, Type: "calls", Count: 1, Lines: []int{6}},
+	}
+	user.Dependents = map[string]*models.DependencyRef{index.ID: {TargetID: index.ID, Type: "instantiates", Count: 1, Lines: []int{4}}}
+	save.Dependents = map[string]*models.DependencyRef{index.ID: {TargetID: index.ID, Type: "calls", Count: 1, Lines: []int{5}}}
+
+	return &models.AnalysisResult{
+		Root: root,
+		Graph: &models.DependencyGraph{Nodes: map[string]*models.DependencyNode{
+			user.ID: user, save.ID: save, index.ID: index,
+		}},
+		ParsedFiles: []*models.ParsedFile{{Path: write("src/empty.php", "<?php\n")}},
+	}, root
+}
+
+func TestSourceExporter_Export(t *testing.T) {
+	result, _ := makeSourceResult(t)
+	out := filepath.Join(t.TempDir(), "browser")
+
+	exporter, ok := Get("source")
+	if !ok {
+		t.Fatal("expected the source exporter to register itself")
+	}
+	if err := exporter.Export(result, out); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	read := func(rel string) string {
+		data, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	index := read("index.html")
+	for _, want := range []string{
+		`href="files/src/Controller.php.html"`,
+		`href="files/src/Models/User.php.html"`,
+		`href="files/src/empty.php.html"`,
+		`<div class="treemap">`,
+		`no git history was found for churn`,
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("expected index to contain %s", want)
+		}
+	}
+
+	controller := read("files/src/Controller.php.html")
+	for _, want := range []string{
+		`<tr id="L4">`,
+		`new <a href="../../files/src/Models/User.php.html#L2" title="class User (instantiates)">User</a>(); // &lt;User&gt;`,
+		`$u-&gt;<a href="../../files/src/Models/User.php.html#L3" title="method save (calls)">save</a>();`,
+		`$this-&gt;<a href="#L3" title="method index (calls)">index</a>();`,
+		`function <span class="def" title="method index, 0 dependents">index</span>()`,
+		`<a href="../../index.html">Files</a>`,
+	} {
+		if !strings.Contains(controller, want) {
+			t.Errorf("expected Controller page to contain %s", want)
+		}
+	}
+
+	user := read("files/src/Models/User.php.html")
+	for _, want := range []string{
+		`class <span class="def" title="class User, 1 dependents">User</span> {`,
+		"    public function", // Tabs are expanded
+		`<summary>used by 1</summary>`,
+		`<a href="../../../files/src/Controller.php.html#L4">index</a> <span class="kind">line 4</span>`,
+	} {
+		if !strings.Contains(user, want) {
+			t.Errorf("expected User page to contain %s", want)
+		}
+	}
+
+	if empty := read("files/src/empty.php.html"); !strings.Contains(empty, "Nothing") {
+		t.Errorf("expected a page for a file without definitions")
+	}
+}
+
+func TestSourceExporter_Export_Clones(t *testing.T) {
+	result, root := makeSourceResult(t)
+	userFile := filepath.Join(root, "src", "Models", "User.php")
+	controllerFile := filepath.Join(root, "src", "Controller.php")
+	result.Clones = &models.CloneReport{MinTokens: 10, Clones: []*models.Clone{{
+		Tokens: 12, Similarity: 0.5,
+		Blocks: []*models.CloneBlock{
+			{File: controllerFile, StartLine: 3, EndLine: 5},
+			{File: userFile, StartLine: 2, EndLine: 4},
+		},
+	}}}
+	out := filepath.Join(t.TempDir(), "browser")
+	if err := NewSourceExporter().Export(result, out); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "files", "src", "Controller.php.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller := string(data)
+	for _, want := range []string{
+		`<tr id="L2"><td class="n">`,
+		`<tr id="L3" class="dup">`,
+		`<tr id="L5" class="dup">`,
+		`<tr id="L6"><td class="n">`,
+		`<a href="#L3">lines 3-5</a> also in <a href="../../files/src/Models/User.php.html#L2">src/Models/User.php:2-4</a>`,
+		`12 tokens, 50% identical`,
+	} {
+		if !strings.Contains(controller, want) {
+			t.Errorf("expected Controller page to contain %s", want)
+		}
+	}
+
+	data, err = os.ReadFile(filepath.Join(out, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<a href="files/src/Controller.php.html#L3">src/Controller.php:3-5</a> ↔ <a href="files/src/Models/User.php.html#L2">src/Models/User.php:2-4</a>`
+	if index := string(data); !strings.Contains(index, "Duplicated code") || !strings.Contains(index, want) {
+		t.Errorf("expected the index to list the clone, got:\n%s", index)
+	}
+}
+
+func TestFindIdentifier(t *testing.T) {
+	tests := []struct {
+		text, name string
+		want       int
+	}{
+		{"new User();", "User", 4},
+		{"$users = new UserUser(); User::find()", "User", 25},
+		{"$save = save();", "save", 8},
+		{"nothing here", "User", -1},
+		{"User", "", -1},
+	}
+	for _, tt := range tests {
+		if got := findIdentifier(tt.text, tt.name); got != tt.want {
+			t.Errorf("findIdentifier(%q, %q) = %d, want %d", tt.text, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRelPath(t *testing.T) {
+	root := t.TempDir()
+	if got := relPath(root, filepath.Join(root, "a", "b.go")); got != "a/b.go" {
+		t.Errorf("expected a path relative to the root, got %s", got)
+	}
+	if got := relPath(root, filepath.Join(root, "..", "outside.go")); strings.Contains(got, "..") {
+		t.Errorf("expected files outside the root not to escape the output, got %s", got)
+	}
+}
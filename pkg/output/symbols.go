@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// SymbolMapVersion is bumped whenever the symbols format changes incompatibly
+const SymbolMapVersion = 1
+
+// SymbolsExporter writes a compact symbol location map for editor integrations: each
+// short name maps to its definitions, and each definition to the places that use it
+type SymbolsExporter struct{}
+
+// SymbolMap is the exported document. Files are stored once, relative to Root, and
+// referenced by index so large projects stay small.
+type SymbolMap struct {
+	Version     int                     `json:"version"`
+	Root        string                  `json:"root"`
+	GeneratedAt string                  `json:"generatedAt"`
+	Metadata    *models.RunMetadata     `json:"metadata,omitempty"`
+	Files       []string                `json:"files"`
+	Symbols     map[string][]*SymbolDef `json:"symbols"`
+}
+
+// SymbolDef is one definition of a name
+type SymbolDef struct {
+	ID        string       `json:"id"`
+	Kind      string       `json:"kind"`
+	Qualified string       `json:"qualified,omitempty"` // e.g. App\Models\User::save
+	File      int          `json:"file"`                // Index into Files
+	Line      int          `json:"line"`
+	UsedBy    []*SymbolUse `json:"usedBy,omitempty"`
+}
+
+// SymbolUse is a definition that depends on a symbol, and the lines it does so on
+type SymbolUse struct {
+	ID    string `json:"id"`
+	File  int    `json:"file"`
+	Lines []int  `json:"lines,omitempty"` // Empty for --summary-only runs; may be sampled
+	Count int    `json:"count"`
+}
+
+func init() {
+	Register(NewSymbolsExporter())
+}
+
+// NewSymbolsExporter creates a new symbol map exporter
+func NewSymbolsExporter() *SymbolsExporter {
+	return &SymbolsExporter{}
+}
+
+// Format returns the registry key for symbol maps
+func (se *SymbolsExporter) Format() string {
+	return "symbols"
+}
+
+// Export writes the symbol map of result to filename as compact JSON
+func (se *SymbolsExporter) Export(result *models.AnalysisResult, filename string) error {
+	result.Graph.RLock()
+	symbols := BuildSymbolMap(result)
+	result.Graph.RUnlock()
+
+	data, err := json.Marshal(symbols)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// BuildSymbolMap indexes the graph's nodes by name. Definitions of a name and their uses
+// are sorted by file and line so the output is stable between runs.
+func BuildSymbolMap(result *models.AnalysisResult) *SymbolMap {
+	root, err := filepath.Abs(result.Root)
+	if err != nil || result.Root == "" {
+		root = result.Root
+	}
+	symbols := &SymbolMap{
+		Version:     SymbolMapVersion,
+		Root:        filepath.ToSlash(root),
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Metadata:    result.Metadata,
+		Files:       []string{},
+		Symbols:     make(map[string][]*SymbolDef),
+	}
+
+	nodes := make([]*models.DependencyNode, 0, len(result.Graph.Nodes))
+	for _, node := range result.Graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].File != nodes[j].File {
+			return nodes[i].File < nodes[j].File
+		}
+		if nodes[i].Line != nodes[j].Line {
+			return nodes[i].Line < nodes[j].Line
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	fileIndex := make(map[string]int)
+	indexOf := func(path string) int {
+		if index, ok := fileIndex[path]; ok {
+			return index
+		}
+		rel := path
+		if root != "" {
+			if r, err := filepath.Rel(root, absPath(path)); err == nil && !strings.HasPrefix(r, "..") {
+				rel = r
+			}
+		}
+		fileIndex[path] = len(symbols.Files)
+		symbols.Files = append(symbols.Files, filepath.ToSlash(rel))
+		return fileIndex[path]
+	}
+
+	for _, node := range nodes {
+		def := &SymbolDef{
+			ID:        node.ID,
+			Kind:      node.Type,
+			Qualified: qualifiedName(node),
+			File:      indexOf(node.File),
+			Line:      node.Line,
+		}
+		for _, ref := range sortedRefs(node.Dependents, result.Graph) {
+			user := result.Graph.Nodes[ref.TargetID]
+			if user == nil {
+				continue
+			}
+			def.UsedBy = append(def.UsedBy, &SymbolUse{
+				ID:    user.ID,
+				File:  indexOf(user.File),
+				Lines: sortedLines(ref.Lines),
+				Count: ref.Count,
+			})
+		}
+		symbols.Symbols[node.Name] = append(symbols.Symbols[node.Name], def)
+	}
+	return symbols
+}
+
+// qualifiedName returns the namespace- and class-qualified name of a node, or "" when
+// it's the same as the short name
+func qualifiedName(node *models.DependencyNode) string {
+	name := node.Name
+	if node.ClassName != "" && node.ClassName != node.Name {
+		name = node.ClassName + "::" + name
+	}
+	if node.Namespace != "" {
+		name = node.Namespace + `\` + name
+	}
+	if name == node.Name {
+		return ""
+	}
+	return name
+}
+
+// sortedRefs orders a node's dependents by the user's file and line
+func sortedRefs(refs map[string]*models.DependencyRef, graph *models.DependencyGraph) []*models.DependencyRef {
+	sorted := make([]*models.DependencyRef, 0, len(refs))
+	for _, ref := range refs {
+		sorted = append(sorted, ref)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := graph.Nodes[sorted[i].TargetID], graph.Nodes[sorted[j].TargetID]
+		if a != nil && b != nil {
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			if a.Line != b.Line {
+				return a.Line < b.Line
+			}
+		}
+		return sorted[i].TargetID < sorted[j].TargetID
+	})
+	return sorted
+}
+
+func sortedLines(lines []int) []int {
+	sorted := append([]int(nil), lines...)
+	sort.Ints(sorted)
+	return sorted
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func makeSymbolsResult(root string) *models.AnalysisResult {
+	user := &models.DependencyNode{
+		ID: `class:App\User:3`, Name: "User", Type: "class", Namespace: "App",
+		File: filepath.Join(root, "src", "User.php"), Line: 3,
+		Dependents: map[string]*models.DependencyRef{},
+	}
+	save := &models.DependencyNode{
+		ID: `method:App\User::save:9`, Name: "save", Type: "method", Namespace: "App", ClassName: "User",
+		File: filepath.Join(root, "src", "User.php"), Line: 9,
+		Dependents: map[string]*models.DependencyRef{},
+	}
+	index := &models.DependencyNode{
+		ID: `method:App\Controller::index:12`, Name: "index", Type: "method", Namespace: "App", ClassName: "Controller",
+		File: filepath.Join(root, "src", "Controller.php"), Line: 12,
+		Dependents: map[string]*models.DependencyRef{},
+	}
+	helper := &models.DependencyNode{
+		ID: "function:save:1", Name: "save", Type: "function",
+		File: filepath.Join(root, "lib", "helpers.php"), Line: 1,
+		Dependents: map[string]*models.DependencyRef{},
+	}
+	user.Dependents[index.ID] = &models.DependencyRef{TargetID: index.ID, Type: "instantiates", Count: 2, Lines: []int{20, 14}}
+	save.Dependents[index.ID] = &models.DependencyRef{TargetID: index.ID, Type: "calls", Count: 1, Lines: []int{15}}
+
+	return &models.AnalysisResult{
+		Root: root,
+		Graph: &models.DependencyGraph{Nodes: map[string]*models.DependencyNode{
+			user.ID: user, save.ID: save, index.ID: index, helper.ID: helper,
+		}},
+	}
+}
+
+func TestBuildSymbolMap(t *testing.T) {
+	root := t.TempDir()
+	symbols := BuildSymbolMap(makeSymbolsResult(root))
+
+	if want := []string{"lib/helpers.php", "src/Controller.php", "src/User.php"}; !reflect.DeepEqual(symbols.Files, want) {
+		t.Errorf("expected files relative to the root, sorted by first use, got %v", symbols.Files)
+	}
+
+	saves := symbols.Symbols["save"]
+	if len(saves) != 2 || saves[0].Kind != "function" || saves[1].Qualified != `App\User::save` {
+		t.Fatalf("expected both definitions of save ordered by file, got %+v %+v", saves[0], saves[1])
+	}
+
+	user := symbols.Symbols["User"][0]
+	if symbols.Files[user.File] != "src/User.php" || user.Line != 3 || user.Qualified != `App\User` {
+		t.Errorf("unexpected User definition: %+v", user)
+	}
+	if len(user.UsedBy) != 1 {
+		t.Fatalf("expected one user of User, got %v", user.UsedBy)
+	}
+	use := user.UsedBy[0]
+	if symbols.Files[use.File] != "src/Controller.php" || !reflect.DeepEqual(use.Lines, []int{14, 20}) || use.Count != 2 {
+		t.Errorf("unexpected use: %+v", use)
+	}
+	if symbols.Symbols["index"][0].UsedBy != nil {
+		t.Errorf("expected no users of index")
+	}
+}
+
+func TestSymbolsExporter_Export(t *testing.T) {
+	root := t.TempDir()
+	outPath := filepath.Join(t.TempDir(), "symbols.json")
+
+	exporter, ok := Get("symbols")
+	if !ok {
+		t.Fatal("expected the symbols exporter to register itself")
+	}
+	if err := exporter.Export(makeSymbolsResult(root), outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var symbols SymbolMap
+	if err := json.Unmarshal(data, &symbols); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if symbols.Version != SymbolMapVersion || symbols.Root != filepath.ToSlash(root) || len(symbols.Symbols) != 3 {
+		t.Errorf("unexpected symbol map: %+v", symbols)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Boone Studios
+// SPDX-License-Identifier: MIT
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+// TemplateExporter renders the analysis result through a user-supplied text/template
+type TemplateExporter struct {
+	templatePath string
+}
+
+func init() {
+	Register(NewTemplateExporter())
+}
+
+// NewTemplateExporter creates a new template exporter
+func NewTemplateExporter() *TemplateExporter {
+	return &TemplateExporter{}
+}
+
+// Format returns the registry key for template-rendered reports
+func (te *TemplateExporter) Format() string {
+	return "template"
+}
+
+// SetTemplate sets the template file rendered by Export
+func (te *TemplateExporter) SetTemplate(path string) {
+	te.templatePath = path
+}
+
+// Export renders result through the template into filename. The template's dot is the
+// *models.AnalysisResult; TemplateFuncs lists the helpers available to it.
+func (te *TemplateExporter) Export(result *models.AnalysisResult, filename string) error {
+	if te.templatePath == "" {
+		return errors.New("the template format requires --template <file>")
+	}
+
+	tmpl, err := template.New(filepath.Base(te.templatePath)).
+		Funcs(TemplateFuncs()).
+		ParseFiles(te.templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	result.Graph.RLock()
+	err = tmpl.Execute(&buf, result)
+	result.Graph.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// TemplateFuncs returns the helper functions available to report templates. Node helpers
+// take the node list last so they chain in pipelines:
+//
+//	{{range nodes .Graph | ofType "class" | sortBy "-dependents" | limit 10}}...{{end}}
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"nodes":        graphNodes,
+		"ofType":       nodesOfType,
+		"inFile":       nodesInFile,
+		"sortBy":       sortNodes,
+		"limit":        limitNodes,
+		"dependents":   func(n *models.DependencyNode) int { return len(n.Dependents) },
+		"dependencies": func(n *models.DependencyNode) int { return len(n.Dependencies) },
+		"join":         strings.Join,
+		"upper":        strings.ToUpper,
+		"lower":        strings.ToLower,
+		"trimPrefix":   func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"repeat":       func(n int, s string) string { return strings.Repeat(s, n) },
+		"add":          func(a, b int) int { return a + b },
+		"sub":          func(a, b int) int { return a - b },
+		"json": func(v interface{}) (string, error) {
+			data, err := json.MarshalIndent(v, "", "  ")
+			return string(data), err
+		},
+	}
+}
+
+// graphNodes lists the graph's nodes ordered by ID
+func graphNodes(graph *models.DependencyGraph) []*models.DependencyNode {
+	nodes := make([]*models.DependencyNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// nodesOfType keeps nodes of any of the given comma-separated types (e.g. "class,interface")
+func nodesOfType(types string, nodes []*models.DependencyNode) []*models.DependencyNode {
+	wanted := make(map[string]bool)
+	for _, t := range strings.Split(types, ",") {
+		wanted[strings.TrimSpace(t)] = true
+	}
+
+	var kept []*models.DependencyNode
+	for _, node := range nodes {
+		if wanted[node.Type] {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
+
+// nodesInFile keeps nodes whose file path contains substr
+func nodesInFile(substr string, nodes []*models.DependencyNode) []*models.DependencyNode {
+	var kept []*models.DependencyNode
+	for _, node := range nodes {
+		if strings.Contains(node.File, substr) {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
+
+// sortNodes returns a copy of nodes sorted by key: name, type, file, line, score,
+// dependents, or dependencies. A leading "-" sorts descending.
+func sortNodes(key string, nodes []*models.DependencyNode) ([]*models.DependencyNode, error) {
+	desc := strings.HasPrefix(key, "-")
+	key = strings.TrimPrefix(key, "-")
+
+	var less func(a, b *models.DependencyNode) bool
+	switch key {
+	case "name":
+		less = func(a, b *models.DependencyNode) bool { return a.Name < b.Name }
+	case "type":
+		less = func(a, b *models.DependencyNode) bool { return a.Type < b.Type }
+	case "file":
+		less = func(a, b *models.DependencyNode) bool { return a.File < b.File }
+	case "line":
+		less = func(a, b *models.DependencyNode) bool { return a.Line < b.Line }
+	case "score":
+		less = func(a, b *models.DependencyNode) bool { return a.Score < b.Score }
+	case "dependents":
+		less = func(a, b *models.DependencyNode) bool { return len(a.Dependents) < len(b.Dependents) }
+	case "dependencies":
+		less = func(a, b *models.DependencyNode) bool { return len(a.Dependencies) < len(b.Dependencies) }
+	default:
+		return nil, fmt.Errorf("sortBy: unknown key %q", key)
+	}
+
+	sorted := append([]*models.DependencyNode(nil), nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if desc {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted, nil
+}
+
+// limitNodes keeps the first n nodes
+func limitNodes(n int, nodes []*models.DependencyNode) []*models.DependencyNode {
+	if n >= 0 && n < len(nodes) {
+		return nodes[:n]
+	}
+	return nodes
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boone-studios/tukey/internal/models"
+)
+
+func TestTemplateExporter_Export(t *testing.T) {
+	res := makeDummyResult()
+	res.Graph.Nodes["2"] = &models.DependencyNode{ID: "2", Name: "save", Type: "method", File: "app/User.php", Score: 7}
+	res.Graph.Nodes["3"] = &models.DependencyNode{ID: "3", Name: "helper", Type: "function", File: "lib/helpers.php", Score: 9}
+
+	tmp := t.TempDir()
+	tmplPath := filepath.Join(tmp, "report.tmpl")
+	tmpl := `{{.TotalFiles}} files
+{{range nodes .Graph | ofType "function,method" | sortBy "-score" | limit 1}}top={{.Name}}{{end}}
+{{range nodes .Graph | inFile "app/" | sortBy "name"}}{{.Name}} {{end}}
+{{len (nodes .Graph | ofType "class")}} class`
+	if err := os.WriteFile(tmplPath, []byte(tmpl), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	te := NewTemplateExporter()
+	te.SetTemplate(tmplPath)
+	outPath := filepath.Join(tmp, "report.txt")
+	if err := te.Export(res, outPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(outPath)
+	want := "1 files\ntop=helper\nUser save \n1 class"
+	if string(data) != want {
+		t.Errorf("unexpected render:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestTemplateExporter_Errors(t *testing.T) {
+	res := makeDummyResult()
+	tmp := t.TempDir()
+	te := NewTemplateExporter()
+
+	if err := te.Export(res, filepath.Join(tmp, "out")); err == nil {
+		t.Errorf("expected error without a template")
+	}
+
+	tmplPath := filepath.Join(tmp, "bad.tmpl")
+	_ = os.WriteFile(tmplPath, []byte(`{{range nodes .Graph | sortBy "size"}}{{end}}`), 0644)
+	te.SetTemplate(tmplPath)
+	if err := te.Export(res, filepath.Join(tmp, "out")); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("expected unknown sort key error, got %v", err)
+	}
+}
+
+func TestTemplateExporter_ExampleTemplate(t *testing.T) {
+	te := NewTemplateExporter()
+	te.SetTemplate(filepath.Join("..", "..", "docs", "templates", "hotspots.md.tmpl"))
+
+	outPath := filepath.Join(t.TempDir(), "hotspots.md")
+	if err := te.Export(makeDummyResult(), outPath); err != nil {
+		t.Fatalf("example template failed: %v", err)
+	}
+	data, _ := os.ReadFile(outPath)
+	if !strings.Contains(string(data), "| `User` | app/User.php:0 | 0 |") {
+		t.Errorf("unexpected render:\n%s", data)
+	}
+}